@@ -4,6 +4,7 @@ import (
 	"os"
 
 	cmd "github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
 	flag "github.com/spf13/pflag"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 )
@@ -15,6 +16,6 @@ func main() {
 
 	root := cmd.NewRayCommand(ioStreams)
 	if err := root.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(util.ExitCode(err))
 	}
 }
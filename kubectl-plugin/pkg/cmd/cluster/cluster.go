@@ -1,11 +1,12 @@
 package cluster
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
 )
 
 func NewClusterCommand(streams genericclioptions.IOStreams) *cobra.Command {
@@ -16,12 +17,14 @@ func NewClusterCommand(streams genericclioptions.IOStreams) *cobra.Command {
 		SilenceUsage: true,
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) > 0 {
-				fmt.Println(fmt.Errorf("unknown command(s) %q", strings.Join(args, " ")))
+				logging.New(streams.ErrOut).Warnf("unknown command(s) %q", strings.Join(args, " "))
 			}
 			cmd.HelpFunc()(cmd, args)
 		},
 	}
 
 	cmd.AddCommand(NewClusterGetCommand(streams))
+	cmd.AddCommand(NewClusterCreateCommand(streams))
+	cmd.AddCommand(NewClusterUpdateCommand(streams))
 	return cmd
 }
@@ -0,0 +1,223 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/config"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/generation"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/printer"
+)
+
+type ClusterCreateOptions struct {
+	configFlags    *genericclioptions.ConfigFlags
+	ioStreams      *genericclioptions.IOStreams
+	outputFlags    *printer.OutputFlags
+	RayCluster     *unstructured.Unstructured
+	clusterName    string
+	namespace      string
+	rayVersion     string
+	image          string
+	headCPU        string
+	headMemory     string
+	workerCPU      string
+	workerMemory   string
+	workerGPU      string
+	dryRunStrategy cmdutil.DryRunStrategy
+	workerGroups   []string
+	workerReplicas int32
+	autoscaler     bool
+}
+
+var (
+	createClusterLong = templates.LongDesc(`
+		Create a RayCluster from flags.
+
+		The RayCluster has a single worker group configured with the '--worker-*' flags, unless worker groups are given with the
+		repeatable '--worker-group' flag. A worker group is given as comma separated KEY=VALUE pairs, where the keys are
+		name, replicas, min-replicas, max-replicas, cpu, memory and gpu. Only name is required, the other keys default to
+		the values of the '--worker-*' flags.
+
+		Use '--dry-run=client' to print the generated RayCluster CR without creating it, e.g. to commit it to a GitOps repository.
+	`)
+
+	createClusterExample = templates.Examples(`
+		# Create a RayCluster with the default head and a single worker group
+		kubectl ray cluster create sample-cluster
+
+		# Create a RayCluster with a custom image and head resources
+		kubectl ray cluster create sample-cluster --image my-registry/ray:2.37.0-py311 --head-cpu 4 --head-memory 16Gi
+
+		# Create a RayCluster with a CPU and a GPU worker group
+		kubectl ray cluster create sample-cluster --worker-group name=cpu,replicas=2,cpu=4,memory=8Gi --worker-group name=gpu,replicas=4,gpu=1
+
+		# Create an autoscaling RayCluster whose GPU worker group scales between 0 and 8 replicas
+		kubectl ray cluster create sample-cluster --autoscaler --worker-group name=gpu,replicas=0,min-replicas=0,max-replicas=8,gpu=1
+
+		# Print the generated RayCluster CR without creating it
+		kubectl ray cluster create sample-cluster --worker-group name=gpu,replicas=4,gpu=1 --dry-run=client
+	`)
+)
+
+func NewClusterCreateOptions(streams genericclioptions.IOStreams) *ClusterCreateOptions {
+	return &ClusterCreateOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+		outputFlags: printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
+	}
+}
+
+func NewClusterCreateCommand(streams genericclioptions.IOStreams) *cobra.Command {
+	options := NewClusterCreateOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:          "create NAME",
+		Short:        "Create a RayCluster from flags",
+		Long:         createClusterLong,
+		Example:      createClusterExample,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	cmd.Flags().StringVar(&options.rayVersion, "ray-version", generation.DefaultRayVersion, "Ray version to use")
+	cmd.Flags().StringVar(&options.image, "image", options.image, "Container image to use. Defaults to rayproject/ray:<ray-version>")
+	config.BindFlag(cmd.Flags(), "image", config.Image)
+	cmd.Flags().StringVar(&options.headCPU, "head-cpu", "2", "Number of CPUs in the Ray head")
+	cmd.Flags().StringVar(&options.headMemory, "head-memory", "4Gi", "Amount of memory in the Ray head")
+	cmd.Flags().Int32Var(&options.workerReplicas, "worker-replicas", 1, "Number of replicas of each worker group")
+	cmd.Flags().StringVar(&options.workerCPU, "worker-cpu", "2", "Number of CPUs in each worker")
+	cmd.Flags().StringVar(&options.workerMemory, "worker-memory", "4Gi", "Amount of memory in each worker")
+	cmd.Flags().StringVar(&options.workerGPU, "worker-gpu", "0", "Number of GPUs in each worker")
+	cmd.Flags().StringArrayVar(&options.workerGroups, "worker-group", options.workerGroups, "Worker group as KEY=VALUE pairs, e.g. name=gpu,replicas=4,gpu=1. Can be repeated")
+	cmd.Flags().BoolVar(&options.autoscaler, "autoscaler", options.autoscaler, "If present, enable the Ray autoscaler to scale the worker groups between their min and max replicas")
+	cmdutil.AddDryRunFlag(cmd)
+	options.outputFlags.AddFlags(cmd)
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *ClusterCreateOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return cmdutil.UsageErrorf(cmd, "%s", cmd.Use)
+	}
+	options.clusterName = args[0]
+
+	if *options.configFlags.Namespace == "" {
+		options.namespace = "default"
+	} else {
+		options.namespace = *options.configFlags.Namespace
+	}
+
+	var err error
+	options.dryRunStrategy, err = cmdutil.GetDryRunStrategy(cmd)
+	return err
+}
+
+func (options *ClusterCreateOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	if err := options.outputFlags.Validate(); err != nil {
+		return err
+	}
+
+	options.RayCluster, err = options.generateRayCluster()
+	if err != nil {
+		return fmt.Errorf("Failed to generate RayCluster: %w", err)
+	}
+	return nil
+}
+
+// generateRayCluster synthesizes the RayCluster CR from the flags
+func (options *ClusterCreateOptions) generateRayCluster() (*unstructured.Unstructured, error) {
+	rayClusterObject := generation.RayClusterYamlObject{
+		ClusterName: options.clusterName,
+		Namespace:   options.namespace,
+		RayClusterSpecObject: generation.RayClusterSpecObject{
+			RayVersion:     options.rayVersion,
+			Image:          options.image,
+			HeadCPU:        options.headCPU,
+			HeadMemory:     options.headMemory,
+			WorkerCPU:      options.workerCPU,
+			WorkerMemory:   options.workerMemory,
+			WorkerGPU:      options.workerGPU,
+			WorkerReplicas: options.workerReplicas,
+			Autoscaler:     options.autoscaler,
+		},
+	}
+
+	defaults := generation.WorkerGroup{
+		CPU:      options.workerCPU,
+		Memory:   options.workerMemory,
+		GPU:      options.workerGPU,
+		Replicas: options.workerReplicas,
+	}
+	for _, shorthand := range options.workerGroups {
+		workerGroup, err := generation.ParseWorkerGroup(shorthand, defaults)
+		if err != nil {
+			return nil, err
+		}
+		rayClusterObject.WorkerGroups = append(rayClusterObject.WorkerGroups, workerGroup)
+	}
+
+	if err := rayClusterObject.Validate(); err != nil {
+		return nil, err
+	}
+	return generation.ConvertRayClusterApplyConfigToUnstructured(rayClusterObject.GenerateRayClusterApplyConfig())
+}
+
+func (options *ClusterCreateOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	if options.dryRunStrategy == cmdutil.DryRunClient {
+		// The generated RayCluster CR is printed as YAML unless another output format is requested
+		if !options.outputFlags.IsStructured() {
+			options.outputFlags.Format = printer.YAML
+		}
+		return options.outputFlags.PrintObj(options.RayCluster, options.ioStreams.Out)
+	}
+
+	dynamicClient, err := client.NewDynamicClient(factory)
+	if err != nil {
+		return fmt.Errorf("dynamic client failed to initialize: %w", err)
+	}
+
+	createOptions := v1.CreateOptions{}
+	if options.dryRunStrategy == cmdutil.DryRunServer {
+		createOptions.DryRun = []string{v1.DryRunAll}
+	}
+	rayCluster, err := dynamicClient.Resource(util.RayClusterGVR).Namespace(options.namespace).Create(ctx, options.RayCluster, createOptions)
+	if err != nil {
+		return fmt.Errorf("failed to create RayCluster %s: %w", options.clusterName, err)
+	}
+
+	if options.outputFlags.IsStructured() {
+		return options.outputFlags.PrintObj(rayCluster, options.ioStreams.Out)
+	}
+	if options.dryRunStrategy == cmdutil.DryRunServer {
+		fmt.Fprintf(options.ioStreams.Out, "RayCluster %s created in namespace %s (server dry run)\n", rayCluster.GetName(), options.namespace)
+		return nil
+	}
+	fmt.Fprintf(options.ioStreams.Out, "Created RayCluster %s in namespace %s\n", rayCluster.GetName(), options.namespace)
+	return nil
+}
@@ -0,0 +1,112 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/printer"
+)
+
+func TestRayClusterCreateComplete(t *testing.T) {
+	testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+	fakeClusterCreateOptions := NewClusterCreateOptions(testStreams)
+	cmd := &cobra.Command{Use: "create NAME"}
+	cmdutil.AddDryRunFlag(cmd)
+	assert.Nil(t, cmd.Flags().Set("dry-run", "client"))
+
+	err := fakeClusterCreateOptions.Complete(cmd, []string{"raycluster-sample"})
+	assert.Nil(t, err)
+	assert.Equal(t, "raycluster-sample", fakeClusterCreateOptions.clusterName)
+	assert.Equal(t, "default", fakeClusterCreateOptions.namespace)
+	assert.Equal(t, cmdutil.DryRunClient, fakeClusterCreateOptions.dryRunStrategy)
+
+	err = fakeClusterCreateOptions.Complete(cmd, []string{})
+	assert.NotNil(t, err)
+}
+
+func TestRayClusterCreateGenerateRayCluster(t *testing.T) {
+	testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+	fakeClusterCreateOptions := NewClusterCreateOptions(testStreams)
+	fakeClusterCreateOptions.clusterName = "raycluster-sample"
+	fakeClusterCreateOptions.namespace = "test-namespace"
+	fakeClusterCreateOptions.workerCPU = "2"
+	fakeClusterCreateOptions.workerReplicas = 1
+	fakeClusterCreateOptions.workerGroups = []string{"name=cpu,replicas=2", "name=gpu,replicas=4,gpu=1"}
+
+	rayCluster, err := fakeClusterCreateOptions.generateRayCluster()
+	assert.Nil(t, err)
+	assert.Equal(t, "raycluster-sample", rayCluster.GetName())
+	assert.Equal(t, "test-namespace", rayCluster.GetNamespace())
+
+	workerGroupSpecs, _, _ := unstructured.NestedSlice(rayCluster.Object, "spec", "workerGroupSpecs")
+	assert.Len(t, workerGroupSpecs, 2)
+	assert.Equal(t, "cpu", workerGroupSpecs[0].(map[string]interface{})["groupName"])
+	assert.Equal(t, int64(2), workerGroupSpecs[0].(map[string]interface{})["replicas"])
+	assert.Equal(t, "gpu", workerGroupSpecs[1].(map[string]interface{})["groupName"])
+	assert.Equal(t, int64(4), workerGroupSpecs[1].(map[string]interface{})["replicas"])
+
+	fakeClusterCreateOptions.workerGroups = []string{"name=gpu,replicas=4", "name=gpu,replicas=2"}
+	_, err = fakeClusterCreateOptions.generateRayCluster()
+	assert.EqualError(t, err, "duplicate worker group \"gpu\"")
+}
+
+func TestRayClusterCreateRunDryRun(t *testing.T) {
+	testStreams, _, resBuf, _ := genericclioptions.NewTestIOStreams()
+	fakeClusterCreateOptions := NewClusterCreateOptions(testStreams)
+	fakeClusterCreateOptions.clusterName = "raycluster-sample"
+	fakeClusterCreateOptions.namespace = "default"
+	fakeClusterCreateOptions.workerGroups = []string{"name=gpu,replicas=4,gpu=1"}
+	fakeClusterCreateOptions.autoscaler = true
+	fakeClusterCreateOptions.dryRunStrategy = cmdutil.DryRunClient
+
+	var err error
+	fakeClusterCreateOptions.RayCluster, err = fakeClusterCreateOptions.generateRayCluster()
+	assert.Nil(t, err)
+
+	// Client dry run must not touch the cluster, so no factory is needed
+	err = fakeClusterCreateOptions.Run(context.Background(), nil)
+	assert.Nil(t, err)
+
+	output := resBuf.String()
+	assert.Contains(t, output, "kind: RayCluster")
+	assert.Contains(t, output, "name: raycluster-sample")
+	assert.Contains(t, output, "enableInTreeAutoscaling: true")
+	assert.Contains(t, output, "groupName: gpu")
+	assert.Contains(t, output, "nvidia.com/gpu: \"1\"")
+}
+
+func TestRayClusterCreateRun(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+	fakeDynamicClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme())
+	tf.FakeDynamicClient = fakeDynamicClient
+
+	testStreams, _, resBuf, _ := genericclioptions.NewTestIOStreams()
+	fakeClusterCreateOptions := NewClusterCreateOptions(testStreams)
+	fakeClusterCreateOptions.clusterName = "raycluster-sample"
+	fakeClusterCreateOptions.namespace = "test"
+	fakeClusterCreateOptions.outputFlags.Format = printer.Name
+
+	var err error
+	fakeClusterCreateOptions.RayCluster, err = fakeClusterCreateOptions.generateRayCluster()
+	assert.Nil(t, err)
+
+	err = fakeClusterCreateOptions.Run(context.Background(), tf)
+	assert.Nil(t, err)
+	assert.Equal(t, "raycluster.ray.io/raycluster-sample\n", resBuf.String())
+
+	rayCluster, err := fakeDynamicClient.Resource(util.RayClusterGVR).Namespace("test").Get(context.Background(), "raycluster-sample", v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "RayCluster", rayCluster.GetKind())
+}
@@ -14,11 +14,15 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/printers"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/printer"
 )
 
 type ClusterGetOptions struct {
 	configFlags   *genericclioptions.ConfigFlags
 	ioStreams     *genericclioptions.IOStreams
+	outputFlags   *printer.OutputFlags
 	args          []string
 	AllNamespaces bool
 }
@@ -27,6 +31,7 @@ func NewClusterGetOptions(streams genericclioptions.IOStreams) *ClusterGetOption
 	return &ClusterGetOptions{
 		configFlags: genericclioptions.NewConfigFlags(true),
 		ioStreams:   &streams,
+		outputFlags: printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
 	}
 }
 
@@ -52,6 +57,7 @@ func NewClusterGetCommand(streams genericclioptions.IOStreams) *cobra.Command {
 		},
 	}
 	cmd.Flags().BoolVarP(&options.AllNamespaces, "all-namespaces", "A", options.AllNamespaces, "If present, list the requested clusters across all namespaces. Namespace in current context is ignored even if specified with --namespace.")
+	options.outputFlags.AddFlags(cmd)
 	options.configFlags.AddFlags(cmd.Flags())
 	return cmd
 }
@@ -77,12 +83,12 @@ func (options *ClusterGetOptions) Validate() error {
 	if len(options.args) > 1 {
 		return fmt.Errorf("too many arguments, either one or no arguments are allowed")
 	}
-	return nil
+	return options.outputFlags.Validate()
 }
 
 func (options *ClusterGetOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
 	// Retrieves the dynamic client with factory.
-	dynamicClient, err := factory.DynamicClient()
+	dynamicClient, err := client.NewDynamicClient(factory)
 	if err != nil {
 		return fmt.Errorf("dynamic client failed to initialize: %w", err)
 	}
@@ -114,6 +120,9 @@ func (options *ClusterGetOptions) Run(ctx context.Context, factory cmdutil.Facto
 		}
 	}
 
+	if options.outputFlags.IsStructured() {
+		return options.outputFlags.PrintObj(rayclustersList, options.ioStreams.Out)
+	}
 	return printClusters(rayclustersList, options.ioStreams.Out)
 }
 
@@ -17,6 +17,8 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/printer"
 )
 
 // This is to test Complete() and ensure that it is setting the namespace and arguments correctly
@@ -98,6 +100,7 @@ func TestRayClusterGetValidate(t *testing.T) {
 				AllNamespaces: false,
 				args:          []string{"random_arg"},
 				ioStreams:     &testStreams,
+				outputFlags:   printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
 			},
 			expectError: "no context is currently set, use \"kubectl config use-context <context>\" to select a new one",
 		},
@@ -109,6 +112,7 @@ func TestRayClusterGetValidate(t *testing.T) {
 				AllNamespaces: false,
 				args:          []string{"fake", "args"},
 				ioStreams:     &testStreams,
+				outputFlags:   printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
 			},
 			expectError: "too many arguments, either one or no arguments are allowed",
 		},
@@ -120,6 +124,7 @@ func TestRayClusterGetValidate(t *testing.T) {
 				AllNamespaces: false,
 				args:          []string{"random_arg"},
 				ioStreams:     &testStreams,
+				outputFlags:   printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
 			},
 			expectError: "",
 		},
@@ -216,3 +221,61 @@ func TestRayClusterGetRun(t *testing.T) {
 		t.Errorf("\nexpected\n%v\ngot\n%v", e, a)
 	}
 }
+
+// Tests the Run() step of the command with machine-readable output.
+func TestRayClusterGetRunStructuredOutput(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+
+	raycluster := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "ray.io/v1",
+			"kind":       "RayCluster",
+			"metadata": map[string]interface{}{
+				"name":      "raycluster-kuberay",
+				"namespace": "test",
+			},
+		},
+	}
+	tf.FakeDynamicClient = fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), raycluster)
+
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{
+			name:     "name",
+			format:   printer.Name,
+			expected: "raycluster.ray.io/raycluster-kuberay\n",
+		},
+		{
+			name:   "yaml",
+			format: printer.YAML,
+			expected: `apiVersion: ray.io/v1
+items:
+- apiVersion: ray.io/v1
+  kind: RayCluster
+  metadata:
+    name: raycluster-kuberay
+    namespace: test
+kind: RayClusterList
+metadata:
+  continue: ""
+  resourceVersion: ""
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testStreams, _, resBuf, _ := genericclioptions.NewTestIOStreams()
+			fakeClusterGetOptions := NewClusterGetOptions(testStreams)
+			fakeClusterGetOptions.outputFlags.Format = tc.format
+
+			err := fakeClusterGetOptions.Run(context.Background(), tf)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, resBuf.String())
+		})
+	}
+}
@@ -0,0 +1,449 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/podutils"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/config"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
+)
+
+const (
+	// headGroupName is the value of the ray.io/group label of the head Pod
+	headGroupName = "headgroup"
+	// fieldManager is the field manager of the fields applied by the plugin
+	fieldManager = "kubectl-ray"
+	// resourceNvidiaGPU is the resource name used by the NVIDIA device plugin
+	resourceNvidiaGPU = "nvidia.com/gpu"
+
+	defaultRestartTimeout = 10 * time.Minute
+)
+
+// restartPollInterval is how often the Pods of a worker group are checked during a rolling restart
+var restartPollInterval = 2 * time.Second
+
+type ClusterUpdateOptions struct {
+	configFlags    *genericclioptions.ConfigFlags
+	ioStreams      *genericclioptions.IOStreams
+	env            map[string]string
+	rayStartParams map[string]string
+	clusterName    string
+	namespace      string
+	image          string
+	cpu            string
+	memory         string
+	gpu            string
+	dryRunStrategy cmdutil.DryRunStrategy
+	groups         []string
+	envArgs        []string
+	rayStartArgs   []string
+	timeout        time.Duration
+	restart        bool
+}
+
+var (
+	updateClusterLong = templates.LongDesc(`
+		Update the image, environment variables, Ray start parameters or resources of the Ray container of a RayCluster.
+
+		The changes are applied with server-side apply to the head and all worker groups, or only to the groups given with '--group'.
+		Use 'headgroup' to select the head group.
+
+		The KubeRay operator does not recreate running Pods when their template changes, so the changes only take effect for new Pods.
+		Use '--restart' to restart the Pods of the changed worker groups one at a time, waiting for each replacement Pod to be ready.
+		The head Pod is never restarted by this command, as restarting it restarts the RayCluster unless GCS fault tolerance is enabled.
+	`)
+
+	updateClusterExample = templates.Examples(`
+		# Update the image of all groups of the RayCluster
+		kubectl ray cluster update sample-cluster --image rayproject/ray:2.37.0
+
+		# Set an environment variable and a Ray start parameter of the gpu worker group and restart its Pods
+		kubectl ray cluster update sample-cluster --group gpu --env NCCL_DEBUG=INFO --ray-start-param num-gpus=1 --restart
+
+		# Update the resources of the head
+		kubectl ray cluster update sample-cluster --group headgroup --cpu 4 --memory 16Gi
+
+		# Print the RayCluster that would be applied without updating it
+		kubectl ray cluster update sample-cluster --image rayproject/ray:2.37.0 --dry-run=client
+	`)
+)
+
+func NewClusterUpdateOptions(streams genericclioptions.IOStreams) *ClusterUpdateOptions {
+	return &ClusterUpdateOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+		timeout:     defaultRestartTimeout,
+	}
+}
+
+func NewClusterUpdateCommand(streams genericclioptions.IOStreams) *cobra.Command {
+	options := NewClusterUpdateOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:          "update NAME",
+		Short:        "Update the Ray container of a RayCluster in place",
+		Long:         updateClusterLong,
+		Example:      updateClusterExample,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	cmd.Flags().StringArrayVar(&options.groups, "group", options.groups, "Name of a group to update, 'headgroup' for the head group. Can be repeated. Defaults to all groups")
+	cmd.Flags().StringVar(&options.image, "image", options.image, "Container image of the Ray container")
+	cmd.Flags().StringArrayVar(&options.envArgs, "env", options.envArgs, "Environment variable of the Ray container as KEY=VALUE. Can be repeated")
+	cmd.Flags().StringArrayVar(&options.rayStartArgs, "ray-start-param", options.rayStartArgs, "Ray start parameter as KEY=VALUE, e.g. num-cpus=4. Can be repeated")
+	cmd.Flags().StringVar(&options.cpu, "cpu", options.cpu, "Number of CPUs of the Ray container")
+	cmd.Flags().StringVar(&options.memory, "memory", options.memory, "Amount of memory of the Ray container")
+	cmd.Flags().StringVar(&options.gpu, "gpu", options.gpu, "Number of GPUs of the Ray container")
+	cmd.Flags().BoolVar(&options.restart, "restart", options.restart, "If present, restart the Pods of the changed worker groups one at a time")
+	cmd.Flags().DurationVar(&options.timeout, "timeout", defaultRestartTimeout, "Maximum time to wait for each replacement Pod to be ready during the restart")
+	config.BindFlag(cmd.Flags(), "timeout", config.Timeout)
+	cmdutil.AddDryRunFlag(cmd)
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *ClusterUpdateOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return cmdutil.UsageErrorf(cmd, "%s", cmd.Use)
+	}
+	options.clusterName = args[0]
+
+	if *options.configFlags.Namespace == "" {
+		options.namespace = "default"
+	} else {
+		options.namespace = *options.configFlags.Namespace
+	}
+
+	var err error
+	options.dryRunStrategy, err = cmdutil.GetDryRunStrategy(cmd)
+	return err
+}
+
+func (options *ClusterUpdateOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+
+	if options.env, err = util.ParseKeyValues("env", options.envArgs); err != nil {
+		return err
+	}
+	if options.rayStartParams, err = util.ParseKeyValues("ray-start-param", options.rayStartArgs); err != nil {
+		return err
+	}
+	if options.image == "" && len(options.env) == 0 && len(options.rayStartParams) == 0 &&
+		options.cpu == "" && options.memory == "" && options.gpu == "" {
+		return fmt.Errorf("nothing to update, use at least one of --image, --env, --ray-start-param, --cpu, --memory or --gpu")
+	}
+
+	quantities := map[string]string{"cpu": options.cpu, "memory": options.memory, "gpu": options.gpu}
+	for name, quantity := range quantities {
+		if quantity == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(quantity); err != nil {
+			return fmt.Errorf("invalid --%s %q: %w", name, quantity, err)
+		}
+	}
+	if options.timeout <= 0 {
+		return fmt.Errorf("timeout must be a positive duration, got %s", options.timeout)
+	}
+	return nil
+}
+
+func (options *ClusterUpdateOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	dynamicClient, err := client.NewDynamicClient(factory)
+	if err != nil {
+		return fmt.Errorf("dynamic client failed to initialize: %w", err)
+	}
+	rayCluster, err := dynamicClient.Resource(util.RayClusterGVR).Namespace(options.namespace).Get(ctx, options.clusterName, v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to find RayCluster %s: %w", options.clusterName, err)
+	}
+
+	desired, changedGroups, err := options.updateRayCluster(rayCluster)
+	if err != nil {
+		return err
+	}
+	if len(changedGroups) == 0 {
+		fmt.Fprintf(options.ioStreams.Out, "RayCluster %s is unchanged\n", options.clusterName)
+		return nil
+	}
+
+	if options.dryRunStrategy == cmdutil.DryRunClient {
+		desiredYaml, err := yaml.Marshal(desired.Object)
+		if err != nil {
+			return fmt.Errorf("failed to convert RayCluster to yaml: %w", err)
+		}
+		fmt.Fprint(options.ioStreams.Out, string(desiredYaml))
+		return nil
+	}
+
+	applyOptions := v1.ApplyOptions{FieldManager: fieldManager, Force: true}
+	if options.dryRunStrategy == cmdutil.DryRunServer {
+		applyOptions.DryRun = []string{v1.DryRunAll}
+	}
+	if _, err := dynamicClient.Resource(util.RayClusterGVR).Namespace(options.namespace).Apply(ctx, options.clusterName, desired, applyOptions); err != nil {
+		return fmt.Errorf("failed to update RayCluster %s: %w", options.clusterName, err)
+	}
+	if options.dryRunStrategy == cmdutil.DryRunServer {
+		fmt.Fprintf(options.ioStreams.Out, "RayCluster %s updated (server dry run), changed groups: %s\n", options.clusterName, strings.Join(changedGroups, ", "))
+		return nil
+	}
+	fmt.Fprintf(options.ioStreams.Out, "RayCluster %s updated, changed groups: %s\n", options.clusterName, strings.Join(changedGroups, ", "))
+
+	var changedWorkerGroups []string
+	for _, group := range changedGroups {
+		if group == headGroupName {
+			logging.New(options.ioStreams.ErrOut).Warnf("the running head Pod keeps its old spec until it is recreated, which restarts the RayCluster unless GCS fault tolerance is enabled")
+			continue
+		}
+		changedWorkerGroups = append(changedWorkerGroups, group)
+	}
+	if len(changedWorkerGroups) == 0 {
+		return nil
+	}
+	if !options.restart {
+		logging.New(options.ioStreams.ErrOut).Warnf("the running Pods of worker groups %s keep their old spec until they are recreated, use --restart to restart them", strings.Join(changedWorkerGroups, ", "))
+		return nil
+	}
+
+	kubeClient, err := factory.KubernetesClientSet()
+	if err != nil {
+		return fmt.Errorf("failed to initialize clientset: %w", err)
+	}
+	for _, group := range changedWorkerGroups {
+		if err := options.restartWorkerGroup(ctx, kubeClient, group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateRayCluster returns the RayCluster to apply, with the mutations applied to the selected groups of the live
+// RayCluster, and the names of the groups that changed. The whole spec is applied, as the worker groups are an atomic list.
+func (options *ClusterUpdateOptions) updateRayCluster(rayCluster *unstructured.Unstructured) (*unstructured.Unstructured, []string, error) {
+	spec, ok, err := unstructured.NestedMap(rayCluster.Object, "spec")
+	if err != nil || !ok {
+		return nil, nil, fmt.Errorf("RayCluster %s has no valid spec", options.clusterName)
+	}
+
+	groupSpecs := map[string]map[string]interface{}{}
+	var groupNames []string
+	if headGroupSpec, ok := spec["headGroupSpec"].(map[string]interface{}); ok {
+		groupSpecs[headGroupName] = headGroupSpec
+		groupNames = append(groupNames, headGroupName)
+	}
+	workerGroupSpecs, _ := spec["workerGroupSpecs"].([]interface{})
+	for _, workerGroupSpec := range workerGroupSpecs {
+		workerGroupSpec, ok := workerGroupSpec.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		groupName, _ := workerGroupSpec["groupName"].(string)
+		groupSpecs[groupName] = workerGroupSpec
+		groupNames = append(groupNames, groupName)
+	}
+
+	selectedGroups := groupNames
+	if len(options.groups) > 0 {
+		for _, group := range options.groups {
+			if _, ok := groupSpecs[group]; !ok {
+				return nil, nil, fmt.Errorf("RayCluster %s has no group %s, must be one of: %s", options.clusterName, group, strings.Join(groupNames, ", "))
+			}
+		}
+		selectedGroups = options.groups
+	}
+
+	var changedGroups []string
+	for _, group := range groupNames {
+		if !slices.Contains(selectedGroups, group) {
+			continue
+		}
+		changed, err := options.updateGroupSpec(groupSpecs[group])
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to update group %s: %w", group, err)
+		}
+		if changed {
+			changedGroups = append(changedGroups, group)
+		}
+	}
+
+	desired := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": rayCluster.GetAPIVersion(),
+		"kind":       rayCluster.GetKind(),
+		"metadata": map[string]interface{}{
+			"name":      rayCluster.GetName(),
+			"namespace": rayCluster.GetNamespace(),
+		},
+		"spec": spec,
+	}}
+	return desired, changedGroups, nil
+}
+
+// updateGroupSpec applies the mutations to the Ray container of the group spec in place and returns whether it changed
+func (options *ClusterUpdateOptions) updateGroupSpec(groupSpec map[string]interface{}) (bool, error) {
+	original := runtime.DeepCopyJSON(groupSpec)
+
+	if len(options.rayStartParams) > 0 {
+		rayStartParams, _, err := unstructured.NestedStringMap(groupSpec, "rayStartParams")
+		if err != nil {
+			return false, err
+		}
+		if rayStartParams == nil {
+			rayStartParams = map[string]string{}
+		}
+		for key, value := range options.rayStartParams {
+			rayStartParams[key] = value
+		}
+		if err := unstructured.SetNestedStringMap(groupSpec, rayStartParams, "rayStartParams"); err != nil {
+			return false, err
+		}
+	}
+
+	containers, ok, err := unstructured.NestedSlice(groupSpec, "template", "spec", "containers")
+	if err != nil || !ok || len(containers) == 0 {
+		return false, fmt.Errorf("the Pod template has no containers")
+	}
+	// The Ray container is the first container of the Pod template
+	rayContainer, ok := containers[0].(map[string]interface{})
+	if !ok {
+		return false, fmt.Errorf("the Ray container is invalid")
+	}
+	if options.image != "" {
+		rayContainer["image"] = options.image
+	}
+	if len(options.env) > 0 {
+		rayContainer["env"] = updateEnv(rayContainer["env"], options.env)
+	}
+	resources := map[corev1.ResourceName]string{corev1.ResourceCPU: options.cpu, corev1.ResourceMemory: options.memory}
+	for name, quantity := range resources {
+		if quantity == "" {
+			continue
+		}
+		// Requests and limits are set to the same value like in the generated RayClusters
+		if err := unstructured.SetNestedField(rayContainer, quantity, "resources", "requests", string(name)); err != nil {
+			return false, err
+		}
+		if err := unstructured.SetNestedField(rayContainer, quantity, "resources", "limits", string(name)); err != nil {
+			return false, err
+		}
+	}
+	if options.gpu != "" {
+		// Extended resources cannot be overcommitted, so only the limit is set
+		if err := unstructured.SetNestedField(rayContainer, options.gpu, "resources", "limits", resourceNvidiaGPU); err != nil {
+			return false, err
+		}
+	}
+	containers[0] = rayContainer
+	if err := unstructured.SetNestedSlice(groupSpec, containers, "template", "spec", "containers"); err != nil {
+		return false, err
+	}
+
+	return !reflect.DeepEqual(original, groupSpec), nil
+}
+
+// updateEnv sets the environment variables in the env list of a container, keeping the order of existing variables
+func updateEnv(env interface{}, values map[string]string) []interface{} {
+	envList, _ := env.([]interface{})
+	remaining := make(map[string]string, len(values))
+	for key, value := range values {
+		remaining[key] = value
+	}
+	for i, envVar := range envList {
+		envVarMap, ok := envVar.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := envVarMap["name"].(string)
+		if value, ok := remaining[name]; ok {
+			envList[i] = map[string]interface{}{"name": name, "value": value}
+			delete(remaining, name)
+		}
+	}
+	names := make([]string, 0, len(remaining))
+	for name := range remaining {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		envList = append(envList, map[string]interface{}{"name": name, "value": remaining[name]})
+	}
+	return envList
+}
+
+// restartWorkerGroup deletes the Pods of the worker group one at a time. After each deletion it waits until the group
+// has as many ready Pods as before, so the capacity of the worker group is only ever reduced by a single Pod.
+func (options *ClusterUpdateOptions) restartWorkerGroup(ctx context.Context, kubeClient kubernetes.Interface, group string) error {
+	labelSelector := fmt.Sprintf("ray.io/cluster=%s,ray.io/node-type=worker,ray.io/group=%s", options.clusterName, group)
+	pods, err := kubeClient.CoreV1().Pods(options.namespace).List(ctx, v1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return fmt.Errorf("unable to list Pods of worker group %s: %w", group, err)
+	}
+	readyPods := 0
+	for i := range pods.Items {
+		if podutils.IsPodReady(&pods.Items[i]) {
+			readyPods++
+		}
+	}
+	sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].Name < pods.Items[j].Name })
+
+	for i, pod := range pods.Items {
+		fmt.Fprintf(options.ioStreams.Out, "Restarting Pod %s of worker group %s (%d/%d)...\n", pod.Name, group, i+1, len(pods.Items))
+		if err := kubeClient.CoreV1().Pods(options.namespace).Delete(ctx, pod.Name, v1.DeleteOptions{}); err != nil {
+			return fmt.Errorf("failed to delete Pod %s: %w", pod.Name, err)
+		}
+		err := wait.PollUntilContextTimeout(ctx, restartPollInterval, options.timeout, true, func(ctx context.Context) (bool, error) {
+			current, err := kubeClient.CoreV1().Pods(options.namespace).List(ctx, v1.ListOptions{LabelSelector: labelSelector})
+			if err != nil {
+				return false, err
+			}
+			ready := 0
+			for j := range current.Items {
+				if current.Items[j].Name != pod.Name && podutils.IsPodReady(&current.Items[j]) {
+					ready++
+				}
+			}
+			return ready >= readyPods, nil
+		})
+		if err != nil {
+			return fmt.Errorf("worker group %s did not recover after restarting Pod %s: %w", group, pod.Name, err)
+		}
+	}
+	fmt.Fprintf(options.ioStreams.Out, "Restarted worker group %s\n", group)
+	return nil
+}
@@ -0,0 +1,291 @@
+package cluster
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+func newUpdateTestRayCluster() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "ray.io/v1",
+			"kind":       "RayCluster",
+			"metadata": map[string]interface{}{
+				"name":            "raycluster-sample",
+				"namespace":       "test",
+				"resourceVersion": "1",
+			},
+			"spec": map[string]interface{}{
+				"headGroupSpec": map[string]interface{}{
+					"rayStartParams": map[string]interface{}{"dashboard-host": "0.0.0.0"},
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"containers": []interface{}{
+								map[string]interface{}{"name": "ray-head", "image": "rayproject/ray:2.9.0"},
+							},
+						},
+					},
+				},
+				"workerGroupSpecs": []interface{}{
+					map[string]interface{}{
+						"groupName":      "gpu",
+						"replicas":       int64(2),
+						"rayStartParams": map[string]interface{}{},
+						"template": map[string]interface{}{
+							"spec": map[string]interface{}{
+								"containers": []interface{}{
+									map[string]interface{}{
+										"name":  "ray-worker",
+										"image": "rayproject/ray:2.9.0",
+										"env":   []interface{}{map[string]interface{}{"name": "NCCL_DEBUG", "value": "WARN"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRayClusterUpdateValidate(t *testing.T) {
+	testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+
+	tests := []struct {
+		name        string
+		opts        *ClusterUpdateOptions
+		expectError string
+	}{
+		{
+			name:        "nothing to update",
+			opts:        &ClusterUpdateOptions{},
+			expectError: "nothing to update, use at least one of --image, --env, --ray-start-param, --cpu, --memory or --gpu",
+		},
+		{
+			name:        "invalid env",
+			opts:        &ClusterUpdateOptions{envArgs: []string{"NCCL_DEBUG"}},
+			expectError: "invalid --env \"NCCL_DEBUG\", expected KEY=VALUE",
+		},
+		{
+			name:        "invalid quantity",
+			opts:        &ClusterUpdateOptions{cpu: "four"},
+			expectError: "invalid --cpu \"four\": quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'",
+		},
+		{
+			name: "valid",
+			opts: &ClusterUpdateOptions{image: "rayproject/ray:2.37.0", envArgs: []string{"NCCL_DEBUG=INFO"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.opts.configFlags = newFakeConfigFlags(t)
+			tc.opts.ioStreams = &testStreams
+			tc.opts.timeout = defaultRestartTimeout
+			err := tc.opts.Validate()
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestRayClusterUpdateRayCluster(t *testing.T) {
+	testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+	fakeClusterUpdateOptions := NewClusterUpdateOptions(testStreams)
+	fakeClusterUpdateOptions.clusterName = "raycluster-sample"
+	fakeClusterUpdateOptions.groups = []string{"gpu"}
+	fakeClusterUpdateOptions.image = "rayproject/ray:2.37.0"
+	fakeClusterUpdateOptions.env = map[string]string{"NCCL_DEBUG": "INFO", "NCCL_P2P_DISABLE": "1"}
+	fakeClusterUpdateOptions.rayStartParams = map[string]string{"num-gpus": "1"}
+	fakeClusterUpdateOptions.gpu = "1"
+
+	desired, changedGroups, err := fakeClusterUpdateOptions.updateRayCluster(newUpdateTestRayCluster())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"gpu"}, changedGroups)
+	assert.Equal(t, map[string]interface{}{"name": "raycluster-sample", "namespace": "test"}, desired.Object["metadata"])
+
+	headImage, _, _ := unstructured.NestedSlice(desired.Object, "spec", "headGroupSpec", "template", "spec", "containers")
+	assert.Equal(t, "rayproject/ray:2.9.0", headImage[0].(map[string]interface{})["image"])
+
+	workerGroupSpec := desired.Object["spec"].(map[string]interface{})["workerGroupSpecs"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"num-gpus": "1"}, workerGroupSpec["rayStartParams"])
+	workerContainers, _, _ := unstructured.NestedSlice(workerGroupSpec, "template", "spec", "containers")
+	workerContainer := workerContainers[0].(map[string]interface{})
+	assert.Equal(t, "rayproject/ray:2.37.0", workerContainer["image"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "NCCL_DEBUG", "value": "INFO"},
+		map[string]interface{}{"name": "NCCL_P2P_DISABLE", "value": "1"},
+	}, workerContainer["env"])
+	gpuLimit, _, _ := unstructured.NestedString(workerContainer, "resources", "limits", resourceNvidiaGPU)
+	assert.Equal(t, "1", gpuLimit)
+
+	// Applying the same change again changes nothing
+	_, changedGroups, err = fakeClusterUpdateOptions.updateRayCluster(desired)
+	assert.Nil(t, err)
+	assert.Empty(t, changedGroups)
+
+	fakeClusterUpdateOptions.groups = []string{"cpu"}
+	_, _, err = fakeClusterUpdateOptions.updateRayCluster(newUpdateTestRayCluster())
+	assert.EqualError(t, err, "RayCluster raycluster-sample has no group cpu, must be one of: headgroup, gpu")
+}
+
+func TestRayClusterUpdateRun(t *testing.T) {
+	tests := []struct {
+		name           string
+		dryRunStrategy cmdutil.DryRunStrategy
+		expectedOut    string
+		expectApply    bool
+	}{
+		{
+			name:           "apply",
+			dryRunStrategy: cmdutil.DryRunNone,
+			expectedOut:    "RayCluster raycluster-sample updated, changed groups: headgroup, gpu\n",
+			expectApply:    true,
+		},
+		{
+			name:           "client dry run",
+			dryRunStrategy: cmdutil.DryRunClient,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace("test")
+			defer tf.Cleanup()
+			fakeDynamicClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), newUpdateTestRayCluster())
+			tf.FakeDynamicClient = fakeDynamicClient
+			// The fake dynamic client cannot apply unstructured objects, so the applied RayCluster is only recorded
+			var appliedRayCluster *unstructured.Unstructured
+			fakeDynamicClient.PrependReactor("patch", "rayclusters", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				patchAction := action.(k8stesting.PatchAction)
+				assert.Equal(t, types.ApplyPatchType, patchAction.GetPatchType())
+				appliedRayCluster = &unstructured.Unstructured{}
+				if err := appliedRayCluster.UnmarshalJSON(patchAction.GetPatch()); err != nil {
+					return true, nil, err
+				}
+				return true, appliedRayCluster, nil
+			})
+
+			testStreams, _, resBuf, errBuf := genericclioptions.NewTestIOStreams()
+			fakeClusterUpdateOptions := NewClusterUpdateOptions(testStreams)
+			fakeClusterUpdateOptions.clusterName = "raycluster-sample"
+			fakeClusterUpdateOptions.namespace = "test"
+			fakeClusterUpdateOptions.image = "rayproject/ray:2.37.0"
+			fakeClusterUpdateOptions.dryRunStrategy = tc.dryRunStrategy
+
+			err := fakeClusterUpdateOptions.Run(context.Background(), tf)
+			assert.Nil(t, err)
+			if tc.dryRunStrategy == cmdutil.DryRunClient {
+				assert.Contains(t, resBuf.String(), "image: rayproject/ray:2.37.0")
+				assert.Empty(t, errBuf.String())
+			} else {
+				assert.Equal(t, tc.expectedOut, resBuf.String())
+				assert.Contains(t, errBuf.String(), "Warning: the running head Pod keeps its old spec until it is recreated")
+				assert.Contains(t, errBuf.String(), "Warning: the running Pods of worker groups gpu keep their old spec until they are recreated, use --restart to restart them")
+			}
+
+			if !tc.expectApply {
+				assert.Nil(t, appliedRayCluster)
+				return
+			}
+			containers, _, _ := unstructured.NestedSlice(appliedRayCluster.Object, "spec", "headGroupSpec", "template", "spec", "containers")
+			assert.Equal(t, "rayproject/ray:2.37.0", containers[0].(map[string]interface{})["image"])
+		})
+	}
+}
+
+func newReadyWorkerPod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels:    map[string]string{"ray.io/cluster": "raycluster-sample", "ray.io/node-type": "worker", "ray.io/group": "gpu"},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func TestRestartWorkerGroup(t *testing.T) {
+	restartPollInterval = 10 * time.Millisecond
+	kubeClientSet := kubeFake.NewSimpleClientset(newReadyWorkerPod("gpu-worker-a"), newReadyWorkerPod("gpu-worker-b"))
+	// Recreate deleted Pods like the KubeRay operator does
+	var deletedPods []string
+	kubeClientSet.PrependReactor("delete", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		name := action.(k8stesting.DeleteAction).GetName()
+		deletedPods = append(deletedPods, name)
+		if err := kubeClientSet.Tracker().Delete(corev1.SchemeGroupVersion.WithResource("pods"), "test", name); err != nil {
+			return true, nil, err
+		}
+		return true, nil, kubeClientSet.Tracker().Add(newReadyWorkerPod(name + "-new"))
+	})
+
+	testStreams, _, resBuf, _ := genericclioptions.NewTestIOStreams()
+	fakeClusterUpdateOptions := NewClusterUpdateOptions(testStreams)
+	fakeClusterUpdateOptions.clusterName = "raycluster-sample"
+	fakeClusterUpdateOptions.namespace = "test"
+
+	err := fakeClusterUpdateOptions.restartWorkerGroup(context.Background(), kubeClientSet, "gpu")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"gpu-worker-a", "gpu-worker-b"}, deletedPods)
+	assert.Contains(t, resBuf.String(), "Restarting Pod gpu-worker-b of worker group gpu (2/2)...")
+	assert.Contains(t, resBuf.String(), "Restarted worker group gpu")
+}
+
+func TestRestartWorkerGroupTimeout(t *testing.T) {
+	restartPollInterval = 10 * time.Millisecond
+	// Deleted Pods are not recreated
+	kubeClientSet := kubeFake.NewSimpleClientset(newReadyWorkerPod("gpu-worker-a"))
+
+	testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+	fakeClusterUpdateOptions := NewClusterUpdateOptions(testStreams)
+	fakeClusterUpdateOptions.clusterName = "raycluster-sample"
+	fakeClusterUpdateOptions.namespace = "test"
+	fakeClusterUpdateOptions.timeout = 50 * time.Millisecond
+
+	err := fakeClusterUpdateOptions.restartWorkerGroup(context.Background(), kubeClientSet, "gpu")
+	assert.ErrorContains(t, err, "worker group gpu did not recover after restarting Pod gpu-worker-a")
+}
+
+// newFakeConfigFlags returns config flags using a kubeconfig with a current context
+func newFakeConfigFlags(t *testing.T) *genericclioptions.ConfigFlags {
+	config := &api.Config{
+		Clusters: map[string]*api.Cluster{
+			"my-fake-cluster": {Server: "https://fake-kubernetes-cluster.example.com"},
+		},
+		Contexts: map[string]*api.Context{
+			"my-fake-context": {Cluster: "my-fake-cluster", AuthInfo: "my-fake-user"},
+		},
+		CurrentContext: "my-fake-context",
+		AuthInfos:      map[string]*api.AuthInfo{"my-fake-user": {}},
+	}
+	fakeFile := filepath.Join(t.TempDir(), ".kubeconfig")
+	assert.Nil(t, clientcmd.WriteToFile(*config, fakeFile))
+
+	configFlags := genericclioptions.NewConfigFlags(false)
+	configFlags.KubeConfig = &fakeFile
+	return configFlags
+}
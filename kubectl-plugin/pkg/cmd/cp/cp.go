@@ -0,0 +1,211 @@
+package cp
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdcp "k8s.io/kubectl/pkg/cmd/cp"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/completion"
+)
+
+// defaultRemoteDir is the directory relative remote paths are resolved against
+const defaultRemoteDir = "/tmp/ray/session_latest"
+
+// remoteSpec is a file in a Pod of a Ray resource, given as "RAYCLUSTER:PATH" or "TYPE/NAME:PATH"
+type remoteSpec struct {
+	resourceType util.ResourceType
+	resourceName string
+	path         string
+}
+
+type CpOptions struct {
+	configFlags   *genericclioptions.ConfigFlags
+	ioStreams     *genericiooptions.IOStreams
+	remote        *remoteSpec
+	Namespace     string
+	src           string
+	dest          string
+	workerGroup   string
+	containerName string
+	retries       int
+	noPreserve    bool
+	toRemote      bool
+}
+
+var (
+	cpLong = templates.LongDesc(`
+		Copy files and directories to and from a Pod of a RayCluster, or of the RayCluster used by a RayJob or RayService.
+
+		The head Pod is used by default. Use '--worker-group' to use a running Pod of a worker group instead.
+		Relative remote paths are resolved against the Ray session directory '/tmp/ray/session_latest'.
+
+		Requires that the 'tar' binary is present in the container image.
+	`)
+
+	cpExample = templates.Examples(`
+		# Copy the logs of the Ray session in the head Pod of the RayCluster to a local directory
+		kubectl ray cp my-raycluster:logs ./logs
+
+		# Copy an artifact written by a Ray job from the head Pod of the RayCluster used by the RayJob
+		kubectl ray cp rayjob/my-rayjob:/home/ray/output/model.pt ./model.pt
+
+		# Copy a local file to the head Pod of the RayCluster
+		kubectl ray cp ./data.csv my-raycluster:/home/ray/data.csv
+
+		# Copy a file from a Pod of the worker group 'gpu-group'
+		kubectl ray cp my-raycluster:/tmp/profile.json ./profile.json --worker-group gpu-group
+	`)
+)
+
+func NewCpOptions(streams genericiooptions.IOStreams) *CpOptions {
+	return &CpOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+	}
+}
+
+func NewCpCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	options := NewCpOptions(streams)
+	factory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:               "cp (SRC DEST) [--worker-group GROUP] [-c CONTAINER]",
+		Short:             "Copy files to and from a Pod of a Ray resource",
+		Long:              cpLong,
+		Example:           cpExample,
+		SilenceUsage:      true,
+		ValidArgsFunction: completion.RayClusterResourceNameCompletionFunc(factory),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), factory, cmd)
+		},
+	}
+	cmd.Flags().StringVar(&options.workerGroup, "worker-group", options.workerGroup, "Name of the worker group whose Pod to use instead of the head Pod")
+	cmd.Flags().StringVarP(&options.containerName, "container", "c", options.containerName, "Container name. If omitted, the first container in the Pod will be chosen")
+	cmd.Flags().BoolVar(&options.noPreserve, "no-preserve", options.noPreserve, "The copied file/directory's ownership and permissions will not be preserved in the container")
+	cmd.Flags().IntVar(&options.retries, "retries", options.retries, "Set number of retries to complete a copy operation from a container. Specify 0 to disable or any negative value for infinite retrying")
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *CpOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return cmdutil.UsageErrorf(cmd, "%s", cmd.Use)
+	}
+	options.src = args[0]
+	options.dest = args[1]
+
+	if *options.configFlags.Namespace == "" {
+		options.Namespace = "default"
+	} else {
+		options.Namespace = *options.configFlags.Namespace
+	}
+
+	srcRemote, err := parseRemoteSpec(options.src)
+	if err != nil {
+		return cmdutil.UsageErrorf(cmd, "%s", err.Error())
+	}
+	destRemote, err := parseRemoteSpec(options.dest)
+	if err != nil {
+		return cmdutil.UsageErrorf(cmd, "%s", err.Error())
+	}
+	if (srcRemote == nil) == (destRemote == nil) {
+		return cmdutil.UsageErrorf(cmd, "exactly one of SRC or DEST must be a remote file specification, e.g. RAYCLUSTER:PATH")
+	}
+	if destRemote != nil {
+		options.remote = destRemote
+		options.toRemote = true
+	} else {
+		options.remote = srcRemote
+	}
+	return nil
+}
+
+func (options *CpOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	return nil
+}
+
+func (options *CpOptions) Run(ctx context.Context, factory cmdutil.Factory, cmd *cobra.Command) error {
+	k8sClient, err := client.NewClient(factory)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	clusterName, err := k8sClient.GetRayClusterName(ctx, options.Namespace, options.remote.resourceType, options.remote.resourceName)
+	if err != nil {
+		return err
+	}
+	pod, err := k8sClient.GetRayPod(ctx, options.Namespace, clusterName, options.workerGroup)
+	if err != nil {
+		return err
+	}
+
+	copyOptions := cmdcp.NewCopyOptions(*options.ioStreams)
+	copyOptions.Container = options.containerName
+	copyOptions.NoPreserve = options.noPreserve
+	copyOptions.MaxTries = options.retries
+	if err := copyOptions.Complete(factory, cmd, options.kubectlCpArgs(pod.Name)); err != nil {
+		return err
+	}
+	// The Pod is addressed as NAMESPACE/POD, so the namespace from the kubeconfig is not used
+	copyOptions.Namespace = options.Namespace
+	if err := copyOptions.Validate(); err != nil {
+		return err
+	}
+	return copyOptions.Run()
+}
+
+// kubectlCpArgs returns the SRC and DEST arguments of `kubectl cp` with the remote file in the given Pod
+func (options *CpOptions) kubectlCpArgs(podName string) []string {
+	remoteFile := fmt.Sprintf("%s/%s:%s", options.Namespace, podName, options.remote.path)
+	if options.toRemote {
+		return []string{options.src, remoteFile}
+	}
+	return []string{remoteFile, options.dest}
+}
+
+// parseRemoteSpec parses "RAYCLUSTER:PATH" or "TYPE/NAME:PATH". It returns nil for local paths.
+func parseRemoteSpec(arg string) (*remoteSpec, error) {
+	i := strings.Index(arg, ":")
+	// Paths without a colon, or starting with "/" or "." are local paths
+	if i <= 0 || strings.HasPrefix(arg, "/") || strings.HasPrefix(arg, ".") {
+		return nil, nil
+	}
+
+	resourceType, resourceName, err := util.ParseRayResource(arg[:i])
+	if err != nil {
+		return nil, err
+	}
+	remotePath := arg[i+1:]
+	if !path.IsAbs(remotePath) {
+		remotePath = path.Join(defaultRemoteDir, remotePath)
+	}
+	return &remoteSpec{
+		resourceType: resourceType,
+		resourceName: resourceName,
+		path:         remotePath,
+	}, nil
+}
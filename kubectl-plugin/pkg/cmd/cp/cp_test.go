@@ -0,0 +1,129 @@
+package cp
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+)
+
+func TestParseRemoteSpec(t *testing.T) {
+	tests := []struct {
+		expected *remoteSpec
+		name     string
+		arg      string
+		hasErr   bool
+	}{
+		{
+			name: "local relative path",
+			arg:  "logs",
+		},
+		{
+			name: "local absolute path with colon",
+			arg:  "/tmp/a:b",
+		},
+		{
+			name: "local dot path with colon",
+			arg:  "./a:b",
+		},
+		{
+			name: "RayCluster with relative path",
+			arg:  "my-raycluster:logs",
+			expected: &remoteSpec{
+				resourceType: util.RayCluster,
+				resourceName: "my-raycluster",
+				path:         "/tmp/ray/session_latest/logs",
+			},
+		},
+		{
+			name: "RayCluster without path",
+			arg:  "my-raycluster:",
+			expected: &remoteSpec{
+				resourceType: util.RayCluster,
+				resourceName: "my-raycluster",
+				path:         "/tmp/ray/session_latest",
+			},
+		},
+		{
+			name: "RayJob with absolute path",
+			arg:  "rayjob/my-rayjob:/home/ray/model.pt",
+			expected: &remoteSpec{
+				resourceType: util.RayJob,
+				resourceName: "my-rayjob",
+				path:         "/home/ray/model.pt",
+			},
+		},
+		{
+			name:   "invalid resource type",
+			arg:    "pod/my-pod:/home/ray",
+			hasErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			spec, err := parseRemoteSpec(tc.arg)
+			if tc.hasErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, spec)
+		})
+	}
+}
+
+func TestComplete(t *testing.T) {
+	cmd := &cobra.Command{Use: "cp"}
+
+	tests := []struct {
+		name         string
+		args         []string
+		expectedArgs []string
+		hasErr       bool
+	}{
+		{
+			name:         "copy from RayCluster",
+			args:         []string{"my-raycluster:logs", "./logs"},
+			expectedArgs: []string{"default/test-pod:/tmp/ray/session_latest/logs", "./logs"},
+		},
+		{
+			name:         "copy to RayService",
+			args:         []string{"data.csv", "rayservice/my-rayservice:/home/ray/data.csv"},
+			expectedArgs: []string{"data.csv", "default/test-pod:/home/ray/data.csv"},
+		},
+		{
+			name:   "both local",
+			args:   []string{"a", "b"},
+			hasErr: true,
+		},
+		{
+			name:   "both remote",
+			args:   []string{"my-raycluster:a", "my-raycluster:b"},
+			hasErr: true,
+		},
+		{
+			name:   "missing DEST",
+			args:   []string{"my-raycluster:logs"},
+			hasErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+			fakeCpOptions := NewCpOptions(testStreams)
+			err := fakeCpOptions.Complete(cmd, tc.args)
+			if tc.hasErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, "default", fakeCpOptions.Namespace)
+			assert.Equal(t, tc.expectedArgs, fakeCpOptions.kubectlCpArgs("test-pod"))
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package create
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+func NewCreateCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "create",
+		Short:        "Create Ray resources",
+		SilenceUsage: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	cmd.AddCommand(NewCreateWorkerGroupCommand(streams))
+	return cmd
+}
@@ -0,0 +1,352 @@
+package create
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/podutils"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/completion"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/config"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/generation"
+)
+
+const defaultWorkerGroupTimeout = 5 * time.Minute
+
+// workerGroupPollInterval is how often the RayCluster and the Pods of the new worker group are checked
+var workerGroupPollInterval = 2 * time.Second
+
+type CreateWorkerGroupOptions struct {
+	configFlags        *genericclioptions.ConfigFlags
+	ioStreams          *genericiooptions.IOStreams
+	workerGroup        generation.WorkerGroup
+	groupName          string
+	clusterName        string
+	namespace          string
+	image              string
+	workerCPU          string
+	workerMemory       string
+	workerGPU          string
+	dryRunStrategy     cmdutil.DryRunStrategy
+	nodeSelectorArgs   []string
+	tolerationArgs     []string
+	timeout            time.Duration
+	workerReplicas     int32
+	workerMinReplicas  int32
+	workerMaxReplicas  int32
+	minReplicasChanged bool
+	maxReplicasChanged bool
+	noWait             bool
+}
+
+var (
+	createWorkerGroupLong = templates.LongDesc(`
+		Add a worker group to an existing RayCluster, e.g. to add a GPU pool to a running RayCluster.
+
+		The worker group is appended to the worker groups of the RayCluster with a JSON patch, which fails if the RayCluster
+		was changed since it was read. The Ray container uses the image of the Ray head unless '--image' is given.
+
+		The command waits until the KubeRay operator observed the change and all Pods of the worker group are ready, unless
+		'--no-wait' is given.
+
+		A toleration is given as KEY[=VALUE][:EFFECT]. A toleration without a value tolerates any value of the taint.
+	`)
+
+	createWorkerGroupExample = templates.Examples(`
+		# Add a worker group with 2 replicas to the RayCluster
+		kubectl ray create workergroup cpu --ray-cluster sample-cluster --worker-replicas 2 --worker-cpu 4 --worker-memory 8Gi
+
+		# Add a GPU worker group scheduled on tainted GPU nodes
+		kubectl ray create workergroup gpu --ray-cluster sample-cluster --worker-gpu 1 --node-selector cloud.google.com/gke-accelerator=nvidia-l4 --toleration nvidia.com/gpu:NoSchedule
+
+		# Add an autoscaling worker group that scales between 0 and 8 replicas
+		kubectl ray create workergroup gpu --ray-cluster sample-cluster --worker-replicas 0 --worker-min-replicas 0 --worker-max-replicas 8 --worker-gpu 1
+
+		# Print the RayCluster with the new worker group without changing it
+		kubectl ray create workergroup gpu --ray-cluster sample-cluster --worker-gpu 1 --dry-run=client
+	`)
+)
+
+func NewCreateWorkerGroupOptions(streams genericiooptions.IOStreams) *CreateWorkerGroupOptions {
+	return &CreateWorkerGroupOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+		timeout:     defaultWorkerGroupTimeout,
+	}
+}
+
+func NewCreateWorkerGroupCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	options := NewCreateWorkerGroupOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:          "workergroup NAME --ray-cluster RAYCLUSTER",
+		Short:        "Add a worker group to a RayCluster",
+		Long:         createWorkerGroupLong,
+		Example:      createWorkerGroupExample,
+		Aliases:      []string{"worker-group"},
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	cmd.Flags().StringVar(&options.clusterName, "ray-cluster", options.clusterName, "Name of the RayCluster to add the worker group to")
+	cobra.CheckErr(cmd.MarkFlagRequired("ray-cluster"))
+	cobra.CheckErr(cmd.RegisterFlagCompletionFunc("ray-cluster", completion.RayClusterCompletionFunc(cmdFactory)))
+	cmd.Flags().StringVar(&options.image, "image", options.image, "Container image of the Ray container. Defaults to the image of the Ray head")
+	cmd.Flags().Int32Var(&options.workerReplicas, "worker-replicas", 1, "Number of replicas of the worker group")
+	cmd.Flags().Int32Var(&options.workerMinReplicas, "worker-min-replicas", options.workerMinReplicas, "Minimum number of replicas the autoscaler scales the worker group to")
+	cmd.Flags().Int32Var(&options.workerMaxReplicas, "worker-max-replicas", options.workerMaxReplicas, "Maximum number of replicas the autoscaler scales the worker group to")
+	cmd.Flags().StringVar(&options.workerCPU, "worker-cpu", "2", "Number of CPUs in each worker")
+	cmd.Flags().StringVar(&options.workerMemory, "worker-memory", "4Gi", "Amount of memory in each worker")
+	cmd.Flags().StringVar(&options.workerGPU, "worker-gpu", "0", "Number of GPUs in each worker")
+	cmd.Flags().StringArrayVar(&options.nodeSelectorArgs, "node-selector", options.nodeSelectorArgs, "Node selector of the worker Pods as KEY=VALUE. Can be repeated")
+	cmd.Flags().StringArrayVar(&options.tolerationArgs, "toleration", options.tolerationArgs, "Toleration of the worker Pods as KEY[=VALUE][:EFFECT], e.g. nvidia.com/gpu:NoSchedule. Can be repeated")
+	cmd.Flags().BoolVar(&options.noWait, "no-wait", options.noWait, "If present, do not wait for the Pods of the worker group to be ready")
+	cmd.Flags().DurationVar(&options.timeout, "timeout", defaultWorkerGroupTimeout, "Maximum time to wait for the Pods of the worker group to be ready")
+	config.BindFlag(cmd.Flags(), "timeout", config.Timeout)
+	cmdutil.AddDryRunFlag(cmd)
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *CreateWorkerGroupOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return cmdutil.UsageErrorf(cmd, "%s", cmd.Use)
+	}
+	options.groupName = args[0]
+
+	if *options.configFlags.Namespace == "" {
+		options.namespace = "default"
+	} else {
+		options.namespace = *options.configFlags.Namespace
+	}
+
+	// The min and max replicas are only set on the worker group if they are given
+	options.minReplicasChanged = cmd.Flags().Changed("worker-min-replicas")
+	options.maxReplicasChanged = cmd.Flags().Changed("worker-max-replicas")
+
+	var err error
+	options.dryRunStrategy, err = cmdutil.GetDryRunStrategy(cmd)
+	return err
+}
+
+func (options *CreateWorkerGroupOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	if options.clusterName == "" {
+		return fmt.Errorf("--ray-cluster is required")
+	}
+	if options.groupName == "headgroup" {
+		return fmt.Errorf("worker group name %q is reserved for the head group", options.groupName)
+	}
+
+	options.workerGroup = generation.WorkerGroup{
+		Name:     options.groupName,
+		CPU:      options.workerCPU,
+		Memory:   options.workerMemory,
+		GPU:      options.workerGPU,
+		Replicas: options.workerReplicas,
+	}
+	if options.minReplicasChanged {
+		options.workerGroup.MinReplicas = &options.workerMinReplicas
+		if options.workerReplicas < options.workerMinReplicas {
+			return fmt.Errorf("worker replicas %d must not be less than min replicas %d", options.workerReplicas, options.workerMinReplicas)
+		}
+	}
+	if options.maxReplicasChanged {
+		options.workerGroup.MaxReplicas = &options.workerMaxReplicas
+		if options.workerReplicas > options.workerMaxReplicas {
+			return fmt.Errorf("worker replicas %d must not be greater than max replicas %d", options.workerReplicas, options.workerMaxReplicas)
+		}
+	}
+	if options.workerGroup.NodeSelector, err = util.ParseKeyValues("node-selector", options.nodeSelectorArgs); err != nil {
+		return err
+	}
+	if options.workerGroup.Tolerations, err = util.ParseTolerations(options.tolerationArgs); err != nil {
+		return err
+	}
+	if err := options.workerGroup.Validate(); err != nil {
+		return err
+	}
+
+	if options.timeout <= 0 {
+		return fmt.Errorf("timeout must be a positive duration, got %s", options.timeout)
+	}
+	return nil
+}
+
+func (options *CreateWorkerGroupOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	dynamicClient, err := client.NewDynamicClient(factory)
+	if err != nil {
+		return fmt.Errorf("dynamic client failed to initialize: %w", err)
+	}
+	rayCluster, err := dynamicClient.Resource(util.RayClusterGVR).Namespace(options.namespace).Get(ctx, options.clusterName, v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to find RayCluster %s: %w", options.clusterName, err)
+	}
+
+	patch, err := options.workerGroupPatch(rayCluster)
+	if err != nil {
+		return err
+	}
+
+	if options.dryRunStrategy == cmdutil.DryRunClient {
+		desired, err := applyJSONPatch(rayCluster, patch)
+		if err != nil {
+			return err
+		}
+		desiredYaml, err := yaml.Marshal(desired.Object)
+		if err != nil {
+			return fmt.Errorf("failed to convert RayCluster to yaml: %w", err)
+		}
+		fmt.Fprint(options.ioStreams.Out, string(desiredYaml))
+		return nil
+	}
+
+	patchOptions := v1.PatchOptions{}
+	if options.dryRunStrategy == cmdutil.DryRunServer {
+		patchOptions.DryRun = []string{v1.DryRunAll}
+	}
+	patched, err := dynamicClient.Resource(util.RayClusterGVR).Namespace(options.namespace).Patch(ctx, options.clusterName, types.JSONPatchType, patch, patchOptions)
+	if err != nil {
+		return fmt.Errorf("failed to add worker group %s to RayCluster %s: %w", options.groupName, options.clusterName, err)
+	}
+	if options.dryRunStrategy == cmdutil.DryRunServer {
+		fmt.Fprintf(options.ioStreams.Out, "Worker group %s added to RayCluster %s (server dry run)\n", options.groupName, options.clusterName)
+		return nil
+	}
+	fmt.Fprintf(options.ioStreams.Out, "Worker group %s added to RayCluster %s\n", options.groupName, options.clusterName)
+	if options.noWait {
+		return nil
+	}
+
+	kubeClient, err := factory.KubernetesClientSet()
+	if err != nil {
+		return fmt.Errorf("failed to initialize clientset: %w", err)
+	}
+	return options.waitForWorkerGroup(ctx, dynamicClient, kubeClient, patched.GetGeneration())
+}
+
+// workerGroupPatch returns the JSON patch that appends the worker group to the RayCluster. The patch tests the
+// resourceVersion, as the worker groups are replaced as a whole and a concurrent change would otherwise be lost.
+func (options *CreateWorkerGroupOptions) workerGroupPatch(rayCluster *unstructured.Unstructured) ([]byte, error) {
+	workerGroupSpecs, _, err := unstructured.NestedSlice(rayCluster.Object, "spec", "workerGroupSpecs")
+	if err != nil {
+		return nil, fmt.Errorf("RayCluster %s has invalid worker groups: %w", options.clusterName, err)
+	}
+	for _, workerGroupSpec := range workerGroupSpecs {
+		workerGroupSpec, _ := workerGroupSpec.(map[string]interface{})
+		if groupName, _ := workerGroupSpec["groupName"].(string); groupName == options.groupName {
+			return nil, fmt.Errorf("RayCluster %s already has worker group %s, use 'kubectl ray cluster update' to change it", options.clusterName, options.groupName)
+		}
+	}
+
+	image := options.image
+	if image == "" {
+		headContainers, _, _ := unstructured.NestedSlice(rayCluster.Object, "spec", "headGroupSpec", "template", "spec", "containers")
+		if len(headContainers) == 0 {
+			return nil, fmt.Errorf("RayCluster %s has no head container, use --image to set the image of the worker group", options.clusterName)
+		}
+		// The Ray container is the first container of the Pod template
+		image, _, _ = unstructured.NestedString(headContainers[0].(map[string]interface{}), "image")
+	}
+	workerGroupSpec, err := generation.ConvertWorkerGroupSpecApplyConfigToUnstructured(options.workerGroup.GenerateWorkerGroupSpec(image))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate worker group %s: %w", options.groupName, err)
+	}
+
+	patch := []map[string]interface{}{
+		{"op": "test", "path": "/metadata/resourceVersion", "value": rayCluster.GetResourceVersion()},
+	}
+	if workerGroupSpecs == nil {
+		patch = append(patch, map[string]interface{}{"op": "add", "path": "/spec/workerGroupSpecs", "value": []interface{}{workerGroupSpec}})
+	} else {
+		patch = append(patch, map[string]interface{}{"op": "add", "path": "/spec/workerGroupSpecs/-", "value": workerGroupSpec})
+	}
+	return json.Marshal(patch)
+}
+
+// applyJSONPatch returns a copy of the RayCluster with the JSON patch applied, which is only used to print it
+func applyJSONPatch(rayCluster *unstructured.Unstructured, patch []byte) (*unstructured.Unstructured, error) {
+	decodedPatch, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		return nil, err
+	}
+	rayClusterJson, err := rayCluster.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	desiredJson, err := decodedPatch.Apply(rayClusterJson)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply patch to RayCluster %s: %w", rayCluster.GetName(), err)
+	}
+	desired := &unstructured.Unstructured{}
+	if err := desired.UnmarshalJSON(desiredJson); err != nil {
+		return nil, err
+	}
+	return desired, nil
+}
+
+// waitForWorkerGroup waits until the KubeRay operator observed the generation of the RayCluster with the new worker
+// group and all Pods of the worker group are ready
+func (options *CreateWorkerGroupOptions) waitForWorkerGroup(ctx context.Context, dynamicClient dynamic.Interface, kubeClient kubernetes.Interface, generation int64) error {
+	fmt.Fprintf(options.ioStreams.Out, "Waiting for %d Pods of worker group %s to be ready...\n", options.workerReplicas, options.groupName)
+	labelSelector := fmt.Sprintf("ray.io/cluster=%s,ray.io/node-type=worker,ray.io/group=%s", options.clusterName, options.groupName)
+	readyPods := 0
+	err := wait.PollUntilContextTimeout(ctx, workerGroupPollInterval, options.timeout, true, func(ctx context.Context) (bool, error) {
+		rayCluster, err := dynamicClient.Resource(util.RayClusterGVR).Namespace(options.namespace).Get(ctx, options.clusterName, v1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		if observedGeneration, _, _ := unstructured.NestedInt64(rayCluster.Object, "status", "observedGeneration"); observedGeneration < generation {
+			return false, nil
+		}
+
+		pods, err := kubeClient.CoreV1().Pods(options.namespace).List(ctx, v1.ListOptions{LabelSelector: labelSelector})
+		if err != nil {
+			return false, err
+		}
+		readyPods = 0
+		for i := range pods.Items {
+			if podutils.IsPodReady(&pods.Items[i]) {
+				readyPods++
+			}
+		}
+		return readyPods >= int(options.workerReplicas), nil
+	})
+	if err != nil {
+		return fmt.Errorf("worker group %s is not ready, %d/%d Pods are ready: %w", options.groupName, readyPods, options.workerReplicas, err)
+	}
+	fmt.Fprintf(options.ioStreams.Out, "Worker group %s is ready\n", options.groupName)
+	return nil
+}
@@ -0,0 +1,234 @@
+package create
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+)
+
+func newTestRayCluster(status map[string]interface{}) *unstructured.Unstructured {
+	rayCluster := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "ray.io/v1",
+			"kind":       "RayCluster",
+			"metadata": map[string]interface{}{
+				"name":            "raycluster-sample",
+				"namespace":       "test",
+				"resourceVersion": "1",
+			},
+			"spec": map[string]interface{}{
+				"headGroupSpec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"containers": []interface{}{
+								map[string]interface{}{"name": "ray-head", "image": "rayproject/ray:2.9.0"},
+							},
+						},
+					},
+				},
+				"workerGroupSpecs": []interface{}{
+					map[string]interface{}{"groupName": "cpu", "replicas": int64(1)},
+				},
+			},
+		},
+	}
+	if status != nil {
+		rayCluster.Object["status"] = status
+	}
+	return rayCluster
+}
+
+func TestCreateWorkerGroupValidate(t *testing.T) {
+	testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+
+	tests := []struct {
+		opts        *CreateWorkerGroupOptions
+		name        string
+		expectError string
+	}{
+		{
+			name: "valid",
+			opts: &CreateWorkerGroupOptions{
+				groupName:        "gpu",
+				clusterName:      "raycluster-sample",
+				workerGPU:        "1",
+				nodeSelectorArgs: []string{"cloud.google.com/gke-accelerator=nvidia-l4"},
+				tolerationArgs:   []string{"nvidia.com/gpu:NoSchedule"},
+			},
+		},
+		{
+			name:        "head group name",
+			opts:        &CreateWorkerGroupOptions{groupName: "headgroup", clusterName: "raycluster-sample"},
+			expectError: "worker group name \"headgroup\" is reserved for the head group",
+		},
+		{
+			name:        "replicas above max replicas",
+			opts:        &CreateWorkerGroupOptions{groupName: "gpu", clusterName: "raycluster-sample", workerReplicas: 4, workerMaxReplicas: 2, maxReplicasChanged: true},
+			expectError: "worker replicas 4 must not be greater than max replicas 2",
+		},
+		{
+			name:        "invalid node selector",
+			opts:        &CreateWorkerGroupOptions{groupName: "gpu", clusterName: "raycluster-sample", nodeSelectorArgs: []string{"gpu"}},
+			expectError: "invalid --node-selector \"gpu\", expected KEY=VALUE",
+		},
+		{
+			name:        "invalid toleration effect",
+			opts:        &CreateWorkerGroupOptions{groupName: "gpu", clusterName: "raycluster-sample", tolerationArgs: []string{"nvidia.com/gpu:Never"}},
+			expectError: "invalid --toleration \"nvidia.com/gpu:Never\", effect must be one of NoSchedule, PreferNoSchedule or NoExecute",
+		},
+		{
+			name:        "invalid quantity",
+			opts:        &CreateWorkerGroupOptions{groupName: "gpu", clusterName: "raycluster-sample", workerGPU: "one"},
+			expectError: "invalid worker group \"gpu\": invalid GPU \"one\": quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.opts.configFlags = newFakeConfigFlags(t)
+			tc.opts.ioStreams = &testStreams
+			tc.opts.timeout = defaultWorkerGroupTimeout
+			err := tc.opts.Validate()
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func newTestCreateWorkerGroupOptions(t *testing.T, groupName string) (*CreateWorkerGroupOptions, *bytes.Buffer) {
+	testStreams, _, resBuf, _ := genericclioptions.NewTestIOStreams()
+	options := NewCreateWorkerGroupOptions(testStreams)
+	options.configFlags = newFakeConfigFlags(t)
+	options.groupName = groupName
+	options.clusterName = "raycluster-sample"
+	options.namespace = "test"
+	options.workerReplicas = 1
+	options.workerGPU = "1"
+	options.tolerationArgs = []string{"nvidia.com/gpu:NoSchedule"}
+	assert.Nil(t, options.Validate())
+	return options, resBuf
+}
+
+func TestCreateWorkerGroupRun(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+	fakeDynamicClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), newTestRayCluster(nil))
+	tf.FakeDynamicClient = fakeDynamicClient
+
+	options, resBuf := newTestCreateWorkerGroupOptions(t, "gpu")
+	options.noWait = true
+	assert.Nil(t, options.Run(context.Background(), tf))
+	assert.Equal(t, "Worker group gpu added to RayCluster raycluster-sample\n", resBuf.String())
+
+	rayCluster, err := fakeDynamicClient.Resource(util.RayClusterGVR).Namespace("test").Get(context.Background(), "raycluster-sample", v1.GetOptions{})
+	assert.Nil(t, err)
+	workerGroupSpecs, _, _ := unstructured.NestedSlice(rayCluster.Object, "spec", "workerGroupSpecs")
+	assert.Len(t, workerGroupSpecs, 2)
+	workerGroupSpec := workerGroupSpecs[1].(map[string]interface{})
+	assert.Equal(t, "gpu", workerGroupSpec["groupName"])
+	podSpec, _, _ := unstructured.NestedMap(workerGroupSpec, "template", "spec")
+	// The worker group uses the image of the Ray head
+	assert.Equal(t, "rayproject/ray:2.9.0", podSpec["containers"].([]interface{})[0].(map[string]interface{})["image"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"key": "nvidia.com/gpu", "operator": "Exists", "effect": "NoSchedule"}}, podSpec["tolerations"])
+
+	// The worker group cannot be added twice
+	err = options.Run(context.Background(), tf)
+	assert.EqualError(t, err, "RayCluster raycluster-sample already has worker group gpu, use 'kubectl ray cluster update' to change it")
+}
+
+func TestCreateWorkerGroupRunClientDryRun(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+	fakeDynamicClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), newTestRayCluster(nil))
+	tf.FakeDynamicClient = fakeDynamicClient
+
+	options, resBuf := newTestCreateWorkerGroupOptions(t, "gpu")
+	options.image = "rayproject/ray:2.37.0-gpu"
+	options.dryRunStrategy = cmdutil.DryRunClient
+	assert.Nil(t, options.Run(context.Background(), tf))
+	assert.Contains(t, resBuf.String(), "groupName: gpu")
+	assert.Contains(t, resBuf.String(), "image: rayproject/ray:2.37.0-gpu")
+
+	// The RayCluster is unchanged
+	rayCluster, err := fakeDynamicClient.Resource(util.RayClusterGVR).Namespace("test").Get(context.Background(), "raycluster-sample", v1.GetOptions{})
+	assert.Nil(t, err)
+	workerGroupSpecs, _, _ := unstructured.NestedSlice(rayCluster.Object, "spec", "workerGroupSpecs")
+	assert.Len(t, workerGroupSpecs, 1)
+}
+
+func newTestWorkerPod(name string, ready bool) *corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels:    map[string]string{"ray.io/cluster": "raycluster-sample", "ray.io/node-type": "worker", "ray.io/group": "gpu"},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+		},
+	}
+}
+
+func TestWaitForWorkerGroup(t *testing.T) {
+	workerGroupPollInterval = 10 * time.Millisecond
+	fakeDynamicClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), newTestRayCluster(map[string]interface{}{"observedGeneration": int64(2)}))
+
+	options, resBuf := newTestCreateWorkerGroupOptions(t, "gpu")
+	kubeClientSet := kubeFake.NewSimpleClientset(newTestWorkerPod("gpu-worker-a", true))
+	assert.Nil(t, options.waitForWorkerGroup(context.Background(), fakeDynamicClient, kubeClientSet, 2))
+	assert.Equal(t, "Waiting for 1 Pods of worker group gpu to be ready...\nWorker group gpu is ready\n", resBuf.String())
+
+	// The KubeRay operator has not observed the generation with the new worker group yet
+	options.timeout = 50 * time.Millisecond
+	err := options.waitForWorkerGroup(context.Background(), fakeDynamicClient, kubeClientSet, 3)
+	assert.ErrorContains(t, err, "worker group gpu is not ready, 0/1 Pods are ready")
+
+	kubeClientSet = kubeFake.NewSimpleClientset(newTestWorkerPod("gpu-worker-a", false))
+	err = options.waitForWorkerGroup(context.Background(), fakeDynamicClient, kubeClientSet, 2)
+	assert.ErrorContains(t, err, "worker group gpu is not ready, 0/1 Pods are ready")
+}
+
+// newFakeConfigFlags returns config flags using a kubeconfig with a current context
+func newFakeConfigFlags(t *testing.T) *genericclioptions.ConfigFlags {
+	config := &api.Config{
+		Clusters: map[string]*api.Cluster{
+			"my-fake-cluster": {Server: "https://fake-kubernetes-cluster.example.com"},
+		},
+		Contexts: map[string]*api.Context{
+			"my-fake-context": {Cluster: "my-fake-cluster", AuthInfo: "my-fake-user"},
+		},
+		CurrentContext: "my-fake-context",
+		AuthInfos:      map[string]*api.AuthInfo{"my-fake-user": {}},
+	}
+	fakeFile := filepath.Join(t.TempDir(), ".kubeconfig")
+	assert.Nil(t, clientcmd.WriteToFile(*config, fakeFile))
+
+	configFlags := genericclioptions.NewConfigFlags(false)
+	configFlags.KubeConfig = &fakeFile
+	return configFlags
+}
@@ -0,0 +1,174 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/completion"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/config"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/dashboard"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/portforward"
+)
+
+type DashboardOptions struct {
+	configFlags  *genericclioptions.ConfigFlags
+	ioStreams    *genericiooptions.IOStreams
+	openBrowser  func(url string) error
+	ResourceType util.ResourceType
+	ResourceName string
+	Namespace    string
+	localPort    int
+	noOpen       bool
+}
+
+var (
+	dashboardLong = templates.LongDesc(`
+		Open the Ray dashboard of a RayCluster, or of the RayCluster used by a RayJob or RayService, in the default browser.
+
+		A local port is forwarded to the Ray dashboard of the head service until the command is interrupted.
+		Dropped connections, e.g. when the head Pod restarts, are re-established automatically.
+	`)
+
+	dashboardExample = templates.Examples(`
+		# Open the Ray dashboard of the RayCluster
+		kubectl ray dashboard my-raycluster
+
+		# Open the Ray dashboard of the RayCluster used by the RayService on local port 18265
+		kubectl ray dashboard rayservice/my-rayservice --port 18265
+
+		# Only print the URL of the Ray dashboard of the RayCluster used by the RayJob
+		kubectl ray dashboard rayjob/my-rayjob --no-open
+	`)
+)
+
+func NewDashboardOptions(streams genericiooptions.IOStreams) *DashboardOptions {
+	return &DashboardOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+		openBrowser: util.OpenBrowser,
+		localPort:   dashboard.Port,
+	}
+}
+
+func NewDashboardCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	options := NewDashboardOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:               "dashboard (RAYCLUSTER | TYPE/NAME) [--port LOCAL_PORT] [--no-open]",
+		Short:             "Open the Ray dashboard in a browser",
+		Long:              dashboardLong,
+		Example:           dashboardExample,
+		SilenceUsage:      true,
+		ValidArgsFunction: completion.RayClusterResourceNameCompletionFunc(cmdFactory),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	cmd.Flags().IntVar(&options.localPort, "port", options.localPort, "Local port to forward to the Ray dashboard. Use 0 to select a free local port automatically")
+	config.BindFlag(cmd.Flags(), "port", config.DashboardPort)
+	cmd.Flags().BoolVar(&options.noOpen, "no-open", options.noOpen, "Only print the URL of the Ray dashboard instead of opening it in the browser")
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *DashboardOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return cmdutil.UsageErrorf(cmd, "%s", cmd.Use)
+	}
+
+	resourceType, resourceName, err := util.ParseRayResource(args[0])
+	if err != nil {
+		return cmdutil.UsageErrorf(cmd, "%s", err.Error())
+	}
+	options.ResourceType = resourceType
+	options.ResourceName = resourceName
+
+	if *options.configFlags.Namespace == "" {
+		options.Namespace = "default"
+	} else {
+		options.Namespace = *options.configFlags.Namespace
+	}
+
+	if options.localPort == 0 {
+		freePort, err := util.GetFreeLocalPort()
+		if err != nil {
+			return fmt.Errorf("failed to find a free local port for the Ray dashboard: %w", err)
+		}
+		options.localPort = freePort
+	}
+	return nil
+}
+
+func (options *DashboardOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	if options.localPort < 0 || options.localPort > 65535 {
+		return fmt.Errorf("local port %d is out of range, must be between 0 and 65535", options.localPort)
+	}
+	return nil
+}
+
+func (options *DashboardOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	k8sClient, err := client.NewClient(factory)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	target := func(ctx context.Context) (string, error) {
+		svcName, err := k8sClient.GetRayHeadSvcName(ctx, options.Namespace, options.ResourceType, options.ResourceName)
+		if err != nil {
+			return "", err
+		}
+		return "service/" + svcName, nil
+	}
+	forwarder := portforward.NewReconnectingPortForwarder(factory, *options.ioStreams, target, []string{fmt.Sprintf("%d:%d", options.localPort, dashboard.Port)})
+	forwarder.OnReady = options.onReady()
+
+	if err := forwarder.Run(ctx); err != nil {
+		return fmt.Errorf("failed to port-forward: %w", err)
+	}
+	return nil
+}
+
+// onReady prints the URL of the Ray dashboard and opens it the first time the port is forwarded
+func (options *DashboardOptions) onReady() func(target string) {
+	ready := false
+	return func(target string) {
+		if ready {
+			fmt.Fprintf(options.ioStreams.Out, "Reconnected to %s\n", target)
+			return
+		}
+		ready = true
+
+		url := dashboard.Address(options.localPort)
+		fmt.Fprintf(options.ioStreams.Out, "Ray Dashboard: %s\n", url)
+		if !options.noOpen {
+			if err := options.openBrowser(url); err != nil {
+				logging.New(options.ioStreams.ErrOut).Warnf("unable to open the browser, open %s manually: %v", url, err)
+			}
+		}
+		fmt.Fprintln(options.ioStreams.Out, "Press Ctrl+C to stop forwarding the Ray dashboard")
+	}
+}
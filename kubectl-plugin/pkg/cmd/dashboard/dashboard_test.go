@@ -0,0 +1,87 @@
+package dashboard
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+)
+
+func TestRayDashboardComplete(t *testing.T) {
+	cmd := &cobra.Command{Use: "dashboard"}
+	testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+
+	fakeDashboardOptions := NewDashboardOptions(testStreams)
+	err := fakeDashboardOptions.Complete(cmd, []string{"rayservice/test-rayservice"})
+	assert.Nil(t, err)
+	assert.Equal(t, util.RayService, fakeDashboardOptions.ResourceType)
+	assert.Equal(t, "test-rayservice", fakeDashboardOptions.ResourceName)
+	assert.Equal(t, "default", fakeDashboardOptions.Namespace)
+	assert.Equal(t, 8265, fakeDashboardOptions.localPort)
+
+	fakeDashboardOptions = NewDashboardOptions(testStreams)
+	fakeDashboardOptions.localPort = 0
+	err = fakeDashboardOptions.Complete(cmd, []string{"test-raycluster"})
+	assert.Nil(t, err)
+	assert.Equal(t, util.RayCluster, fakeDashboardOptions.ResourceType)
+	assert.NotEqual(t, 0, fakeDashboardOptions.localPort)
+
+	err = fakeDashboardOptions.Complete(cmd, []string{})
+	assert.NotNil(t, err)
+}
+
+func TestRayDashboardOnReady(t *testing.T) {
+	tests := []struct {
+		name            string
+		openBrowserErr  error
+		expectedOut     string
+		expectedErrOut  string
+		noOpen          bool
+		expectedOpenURL []string
+	}{
+		{
+			name:            "open the browser",
+			expectedOut:     "Ray Dashboard: http://localhost:18265\nPress Ctrl+C to stop forwarding the Ray dashboard\nReconnected to service/test-raycluster-head-svc\n",
+			expectedOpenURL: []string{"http://localhost:18265"},
+		},
+		{
+			name:        "only print the URL",
+			noOpen:      true,
+			expectedOut: "Ray Dashboard: http://localhost:18265\nPress Ctrl+C to stop forwarding the Ray dashboard\nReconnected to service/test-raycluster-head-svc\n",
+		},
+		{
+			name:            "browser cannot be opened",
+			openBrowserErr:  fmt.Errorf("xdg-open not found"),
+			expectedOut:     "Ray Dashboard: http://localhost:18265\nPress Ctrl+C to stop forwarding the Ray dashboard\nReconnected to service/test-raycluster-head-svc\n",
+			expectedErrOut:  "Warning: unable to open the browser, open http://localhost:18265 manually: xdg-open not found\n",
+			expectedOpenURL: []string{"http://localhost:18265"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testStreams, _, outBuf, errBuf := genericiooptions.NewTestIOStreams()
+			options := NewDashboardOptions(testStreams)
+			options.localPort = 18265
+			options.noOpen = tc.noOpen
+			var openedURLs []string
+			options.openBrowser = func(url string) error {
+				openedURLs = append(openedURLs, url)
+				return tc.openBrowserErr
+			}
+
+			onReady := options.onReady()
+			onReady("service/test-raycluster-head-svc")
+			// The browser is only opened once when the connection is re-established
+			onReady("service/test-raycluster-head-svc")
+
+			assert.Equal(t, tc.expectedOut, outBuf.String())
+			assert.Equal(t, tc.expectedErrOut, errBuf.String())
+			assert.Equal(t, tc.expectedOpenURL, openedURLs)
+		})
+	}
+}
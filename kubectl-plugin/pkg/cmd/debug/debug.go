@@ -0,0 +1,20 @@
+package debug
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+func NewDebugCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "debug",
+		Short:        "Collect debugging information about Ray resources",
+		SilenceUsage: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	cmd.AddCommand(NewDebugBundleCommand(streams))
+	return cmd
+}
@@ -0,0 +1,457 @@
+package debug
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/completion"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/config"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/dashboard"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
+)
+
+const (
+	portforwardReadyTimeout = 30 * time.Second
+	defaultOperatorLogLines = 10000
+	// lastAppliedConfigAnnotation holds the whole applied object, including values that would otherwise be redacted
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+type DebugBundleOptions struct {
+	configFlags        *genericclioptions.ConfigFlags
+	ioStreams          *genericiooptions.IOStreams
+	ResourceType       util.ResourceType
+	ResourceName       string
+	Namespace          string
+	outputFile         string
+	localDashboardPort int
+	operatorLogLines   int64
+}
+
+// bundleCollector collects the debugging information of a Ray resource into a tar archive. Failures to collect
+// an item are recorded in errors.txt of the archive instead of aborting the collection.
+type bundleCollector struct {
+	k8sClient        client.Client
+	tarWriter        *tar.Writer
+	getClusterStatus func(ctx context.Context, svcName string) ([]byte, error)
+	now              time.Time
+	namespace        string
+	rootDir          string
+	errors           []string
+	operatorLogLines int64
+}
+
+var (
+	debugBundleLong = templates.LongDesc(`
+		Collect debugging information about a RayCluster, or a RayJob or RayService and its RayCluster, into a tar.gz archive
+		that can be attached to bug reports.
+
+		The archive contains the YAML of the Ray resources and of the Ray Pods, the events of these objects, the logs of the
+		KubeRay operator, and the Ray cluster status reported by the Ray dashboard of the head Pod.
+
+		Values that look like credentials, such as password environment variables, Redis passwords and passwords in URLs,
+		are redacted. Secrets are never collected. Review the archive before sharing it.
+	`)
+
+	debugBundleExample = templates.Examples(`
+		# Collect debugging information about the RayCluster into my-raycluster-debug-<TIMESTAMP>.tar.gz
+		kubectl ray debug bundle my-raycluster
+
+		# Collect debugging information about the RayJob and its RayCluster into a given file
+		kubectl ray debug bundle rayjob/my-rayjob -o /tmp/bundle.tar.gz
+	`)
+)
+
+func NewDebugBundleOptions(streams genericiooptions.IOStreams) *DebugBundleOptions {
+	return &DebugBundleOptions{
+		configFlags:      genericclioptions.NewConfigFlags(true),
+		ioStreams:        &streams,
+		operatorLogLines: defaultOperatorLogLines,
+	}
+}
+
+func NewDebugBundleCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	options := NewDebugBundleOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:               "bundle (RAYCLUSTER | TYPE/NAME) [-o FILE]",
+		Short:             "Collect a support archive of a Ray resource",
+		Long:              debugBundleLong,
+		Example:           debugBundleExample,
+		SilenceUsage:      true,
+		ValidArgsFunction: completion.RayClusterResourceNameCompletionFunc(cmdFactory),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	cmd.Flags().StringVarP(&options.outputFile, "output", "o", options.outputFile, "Path of the tar.gz archive. Defaults to NAME-debug-TIMESTAMP.tar.gz in the current directory")
+	cmd.Flags().Int64Var(&options.operatorLogLines, "operator-log-lines", options.operatorLogLines, "Number of most recent lines of the KubeRay operator logs to collect")
+	cmd.Flags().IntVar(&options.localDashboardPort, "dashboard-port", options.localDashboardPort, "Local port used to forward the Ray dashboard. If not set, a free local port is selected automatically")
+	config.BindFlag(cmd.Flags(), "dashboard-port", config.DashboardPort)
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *DebugBundleOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return cmdutil.UsageErrorf(cmd, "%s", cmd.Use)
+	}
+
+	resourceType, resourceName, err := util.ParseRayResource(args[0])
+	if err != nil {
+		return cmdutil.UsageErrorf(cmd, "%s", err.Error())
+	}
+	options.ResourceType = resourceType
+	options.ResourceName = resourceName
+
+	if *options.configFlags.Namespace == "" {
+		options.Namespace = "default"
+	} else {
+		options.Namespace = *options.configFlags.Namespace
+	}
+
+	if options.outputFile == "" {
+		options.outputFile = fmt.Sprintf("%s-debug-%s.tar.gz", options.ResourceName, time.Now().Format("20060102-150405"))
+	}
+
+	if options.localDashboardPort == 0 {
+		freePort, err := util.GetFreeLocalPort()
+		if err != nil {
+			return fmt.Errorf("failed to find a free local port for the Ray dashboard: %w", err)
+		}
+		options.localDashboardPort = freePort
+	}
+	return nil
+}
+
+func (options *DebugBundleOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	if options.operatorLogLines < 0 {
+		return fmt.Errorf("--operator-log-lines must not be negative, got %d", options.operatorLogLines)
+	}
+	if options.localDashboardPort < 0 || options.localDashboardPort > 65535 {
+		return fmt.Errorf("dashboard port %d is out of range, must be between 0 and 65535", options.localDashboardPort)
+	}
+	return nil
+}
+
+func (options *DebugBundleOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	k8sClient, err := client.NewClient(factory)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	file, err := os.Create(options.outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", options.outputFile, err)
+	}
+	defer file.Close()
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	collector := &bundleCollector{
+		k8sClient:        k8sClient,
+		tarWriter:        tarWriter,
+		getClusterStatus: options.getClusterStatus(factory),
+		now:              time.Now(),
+		namespace:        options.Namespace,
+		rootDir:          strings.TrimSuffix(path.Base(options.outputFile), ".tar.gz"),
+		operatorLogLines: options.operatorLogLines,
+	}
+	if err := collector.collect(ctx, options.ResourceType, options.ResourceName); err != nil {
+		return fmt.Errorf("failed to write %s: %w", options.outputFile, err)
+	}
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", options.outputFile, err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", options.outputFile, err)
+	}
+
+	fmt.Fprintf(options.ioStreams.Out, "Debug bundle written to %s\n", options.outputFile)
+	if len(collector.errors) > 0 {
+		logging.New(options.ioStreams.ErrOut).Warnf("%d items could not be collected, see errors.txt in the archive", len(collector.errors))
+	}
+	return nil
+}
+
+// getClusterStatus port-forwards the Ray dashboard of the head service for the duration of the call
+func (options *DebugBundleOptions) getClusterStatus(factory cmdutil.Factory) func(ctx context.Context, svcName string) ([]byte, error) {
+	return func(ctx context.Context, svcName string) ([]byte, error) {
+		portforwardctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		// Keep the port forwarding messages out of the output
+		streams := genericiooptions.IOStreams{In: options.ioStreams.In, Out: io.Discard, ErrOut: io.Discard}
+		if err := dashboard.PortForward(portforwardctx, factory, streams, svcName, options.localDashboardPort, portforwardReadyTimeout, nil); err != nil {
+			return nil, err
+		}
+		return dashboard.GetClusterStatus(ctx, dashboard.Address(options.localDashboardPort))
+	}
+}
+
+// collect writes all the debugging information of the Ray resource. Only errors writing the archive are returned.
+func (c *bundleCollector) collect(ctx context.Context, resourceType util.ResourceType, resourceName string) error {
+	objectNames := map[string]bool{resourceName: true}
+
+	if err := c.addResource(ctx, resourceType, resourceName); err != nil {
+		return err
+	}
+	clusterName, err := c.k8sClient.GetRayClusterName(ctx, c.namespace, resourceType, resourceName)
+	if err != nil {
+		c.recordError("RayCluster", err)
+	} else {
+		objectNames[clusterName] = true
+		if resourceType != util.RayCluster {
+			if err := c.addResource(ctx, util.RayCluster, clusterName); err != nil {
+				return err
+			}
+		}
+		podNames, err := c.addPods(ctx, clusterName)
+		if err != nil {
+			return err
+		}
+		for _, podName := range podNames {
+			objectNames[podName] = true
+		}
+	}
+
+	if err := c.addEvents(ctx, objectNames); err != nil {
+		return err
+	}
+	if err := c.addOperatorLogs(ctx); err != nil {
+		return err
+	}
+	if err := c.addClusterStatus(ctx, resourceType, resourceName); err != nil {
+		return err
+	}
+
+	if len(c.errors) > 0 {
+		return c.addFile("errors.txt", []byte(strings.Join(c.errors, "\n")+"\n"))
+	}
+	return nil
+}
+
+func (c *bundleCollector) recordError(item string, err error) {
+	c.errors = append(c.errors, fmt.Sprintf("%s: %v", item, err))
+}
+
+func (c *bundleCollector) addFile(name string, content []byte) error {
+	header := &tar.Header{
+		Name:    path.Join(c.rootDir, name),
+		Mode:    0o644,
+		Size:    int64(len(content)),
+		ModTime: c.now,
+	}
+	if err := c.tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := c.tarWriter.Write(content)
+	return err
+}
+
+// addObject writes the object as redacted YAML, without the fields that only add noise or leak redacted values
+func (c *bundleCollector) addObject(name string, obj map[string]interface{}) error {
+	unstructured.RemoveNestedField(obj, "metadata", "managedFields")
+	unstructured.RemoveNestedField(obj, "metadata", "annotations", lastAppliedConfigAnnotation)
+	redact(obj)
+	content, err := yaml.Marshal(obj)
+	if err != nil {
+		c.recordError(name, err)
+		return nil
+	}
+	return c.addFile(name, content)
+}
+
+func (c *bundleCollector) addResource(ctx context.Context, resourceType util.ResourceType, name string) error {
+	var gvr schema.GroupVersionResource
+	switch resourceType {
+	case util.RayCluster:
+		gvr = util.RayClusterGVR
+	case util.RayJob:
+		gvr = util.RayJobGVR
+	case util.RayService:
+		gvr = util.RayServiceGVR
+	default:
+		c.recordError(name, fmt.Errorf("unsupported resource type: %s", resourceType))
+		return nil
+	}
+
+	obj, err := c.k8sClient.DynamicClient().Resource(gvr).Namespace(c.namespace).Get(ctx, name, v1.GetOptions{})
+	if err != nil {
+		c.recordError(fmt.Sprintf("%s %s", resourceType, name), err)
+		return nil
+	}
+	return c.addObject(fmt.Sprintf("resources/%s-%s.yaml", resourceType, name), obj.Object)
+}
+
+// addPods writes the Pods of the RayCluster and returns their names
+func (c *bundleCollector) addPods(ctx context.Context, clusterName string) ([]string, error) {
+	pods, err := c.k8sClient.KubernetesClient().CoreV1().Pods(c.namespace).List(ctx, v1.ListOptions{
+		LabelSelector: fmt.Sprintf("ray.io/cluster=%s", clusterName),
+	})
+	if err != nil {
+		c.recordError("Pods", err)
+		return nil, nil
+	}
+
+	podNames := make([]string, 0, len(pods.Items))
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		podNames = append(podNames, pod.Name)
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+		if err != nil {
+			c.recordError(fmt.Sprintf("Pod %s", pod.Name), err)
+			continue
+		}
+		obj["apiVersion"] = "v1"
+		obj["kind"] = "Pod"
+		if err := c.addObject(fmt.Sprintf("pods/%s.yaml", pod.Name), obj); err != nil {
+			return nil, err
+		}
+	}
+	return podNames, nil
+}
+
+// addEvents writes the events of the namespace involving the given objects, oldest first
+func (c *bundleCollector) addEvents(ctx context.Context, objectNames map[string]bool) error {
+	events, err := c.k8sClient.KubernetesClient().CoreV1().Events(c.namespace).List(ctx, v1.ListOptions{})
+	if err != nil {
+		c.recordError("Events", err)
+		return nil
+	}
+
+	var relatedEvents []corev1.Event
+	for _, event := range events.Items {
+		if objectNames[event.InvolvedObject.Name] {
+			relatedEvents = append(relatedEvents, event)
+		}
+	}
+	sort.SliceStable(relatedEvents, func(i, j int) bool {
+		return eventTime(&relatedEvents[i]).Before(eventTime(&relatedEvents[j]))
+	})
+
+	var content strings.Builder
+	if err := printEvents(relatedEvents, &content); err != nil {
+		c.recordError("Events", err)
+		return nil
+	}
+	return c.addFile("events.txt", []byte(content.String()))
+}
+
+func eventTime(event *corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+func printEvents(events []corev1.Event, output io.Writer) error {
+	resultTablePrinter := printers.NewTablePrinter(printers.PrintOptions{})
+
+	resTable := &v1.Table{
+		ColumnDefinitions: []v1.TableColumnDefinition{
+			{Name: "Last Seen", Type: "string"},
+			{Name: "Type", Type: "string"},
+			{Name: "Reason", Type: "string"},
+			{Name: "Object", Type: "string"},
+			{Name: "Count", Type: "integer"},
+			{Name: "Message", Type: "string"},
+		},
+	}
+	for _, event := range events {
+		resTable.Rows = append(resTable.Rows, v1.TableRow{
+			Cells: []interface{}{
+				eventTime(&event).UTC().Format(time.RFC3339),
+				event.Type,
+				event.Reason,
+				fmt.Sprintf("%s/%s", strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name),
+				event.Count,
+				strings.TrimSpace(event.Message),
+			},
+		})
+	}
+	return resultTablePrinter.PrintObj(resTable, output)
+}
+
+// addOperatorLogs writes the most recent logs of all the KubeRay operator Pods
+func (c *bundleCollector) addOperatorLogs(ctx context.Context) error {
+	pods, err := c.k8sClient.KubernetesClient().CoreV1().Pods("").List(ctx, v1.ListOptions{
+		LabelSelector: "app.kubernetes.io/name in (kuberay-operator,kuberay)",
+	})
+	if err != nil {
+		c.recordError("KubeRay operator logs", err)
+		return nil
+	}
+	if len(pods.Items) == 0 {
+		c.recordError("KubeRay operator logs", fmt.Errorf("no KubeRay operator Pod found in any namespace"))
+		return nil
+	}
+
+	for _, pod := range pods.Items {
+		logOptions := &corev1.PodLogOptions{TailLines: &c.operatorLogLines}
+		logs, err := c.k8sClient.KubernetesClient().CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOptions).DoRaw(ctx)
+		if err != nil {
+			c.recordError(fmt.Sprintf("logs of Pod %s/%s", pod.Namespace, pod.Name), err)
+			continue
+		}
+		if err := c.addFile(fmt.Sprintf("operator/%s_%s.log", pod.Namespace, pod.Name), logs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addClusterStatus writes the Ray cluster status, as reported by `ray status`, from the Ray dashboard
+func (c *bundleCollector) addClusterStatus(ctx context.Context, resourceType util.ResourceType, resourceName string) error {
+	svcName, err := c.k8sClient.GetRayHeadSvcName(ctx, c.namespace, resourceType, resourceName)
+	if err != nil {
+		c.recordError("Ray cluster status", err)
+		return nil
+	}
+	clusterStatus, err := c.getClusterStatus(ctx, svcName)
+	if err != nil {
+		c.recordError("Ray cluster status", err)
+		return nil
+	}
+	return c.addFile("ray/cluster_status.json", clusterStatus)
+}
@@ -0,0 +1,186 @@
+package debug
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+)
+
+func TestRayDebugBundleComplete(t *testing.T) {
+	cmd := &cobra.Command{Use: "bundle"}
+	testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+
+	fakeDebugBundleOptions := NewDebugBundleOptions(testStreams)
+	err := fakeDebugBundleOptions.Complete(cmd, []string{"rayjob/test-rayjob"})
+	assert.Nil(t, err)
+	assert.Equal(t, util.RayJob, fakeDebugBundleOptions.ResourceType)
+	assert.Equal(t, "test-rayjob", fakeDebugBundleOptions.ResourceName)
+	assert.Equal(t, "default", fakeDebugBundleOptions.Namespace)
+	assert.Regexp(t, `^test-rayjob-debug-\d{8}-\d{6}\.tar\.gz$`, fakeDebugBundleOptions.outputFile)
+	assert.Equal(t, int64(defaultOperatorLogLines), fakeDebugBundleOptions.operatorLogLines)
+
+	err = fakeDebugBundleOptions.Complete(cmd, []string{})
+	assert.NotNil(t, err)
+}
+
+// readArchive returns the content of the files of the tar archive by name
+func readArchive(t *testing.T, archive *bytes.Buffer) map[string]string {
+	files := map[string]string{}
+	tarReader := tar.NewReader(archive)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		content, err := io.ReadAll(tarReader)
+		assert.Nil(t, err)
+		files[header.Name] = string(content)
+	}
+	return files
+}
+
+func TestBundleCollectorCollect(t *testing.T) {
+	rayJob := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "ray.io/v1",
+			"kind":       "RayJob",
+			"metadata": map[string]interface{}{
+				"name":      "test-rayjob",
+				"namespace": "default",
+				"annotations": map[string]interface{}{
+					lastAppliedConfigAnnotation: `{"spec": {"rayStartParams": {"redis-password": "my-password"}}}`,
+				},
+			},
+			"status": map[string]interface{}{
+				"rayClusterName": "test-raycluster",
+				"rayClusterStatus": map[string]interface{}{
+					"head": map[string]interface{}{
+						"serviceName": "test-raycluster-head-svc",
+					},
+				},
+			},
+		},
+	}
+	rayCluster := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "ray.io/v1",
+			"kind":       "RayCluster",
+			"metadata": map[string]interface{}{
+				"name":      "test-raycluster",
+				"namespace": "default",
+			},
+			"spec": map[string]interface{}{
+				"headGroupSpec": map[string]interface{}{
+					"rayStartParams": map[string]interface{}{
+						"redis-password": "my-password",
+					},
+				},
+			},
+		},
+	}
+	headPod := &corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test-raycluster-head",
+			Namespace: "default",
+			Labels:    map[string]string{"ray.io/cluster": "test-raycluster"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "ray-head",
+				Env:  []corev1.EnvVar{{Name: "REDIS_PASSWORD", Value: "my-password"}},
+			}},
+		},
+	}
+	operatorPod := &corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "kuberay-operator-xxxxx",
+			Namespace: "kuberay-system",
+			Labels:    map[string]string{"app.kubernetes.io/name": "kuberay-operator"},
+		},
+	}
+	newEvent := func(name string, object string, reason string, lastTimestamp time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     v1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: object},
+			Type:           corev1.EventTypeWarning,
+			Reason:         reason,
+			Message:        reason + " message",
+			Count:          1,
+			LastTimestamp:  v1.NewTime(lastTimestamp),
+		}
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []runtime.Object{
+		newEvent("event-2", "test-raycluster-head", "BackOff", now.Add(time.Minute)),
+		newEvent("event-1", "test-raycluster-head", "Pulling", now),
+		newEvent("unrelated", "other-pod", "Unrelated", now),
+	}
+
+	kubeClientSet := kubeFake.NewSimpleClientset(append(events, headPod, operatorPod)...)
+	dynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), rayJob, rayCluster)
+
+	var archive bytes.Buffer
+	tarWriter := tar.NewWriter(&archive)
+	collector := &bundleCollector{
+		k8sClient: client.NewClientForTesting(kubeClientSet, dynamicClient),
+		tarWriter: tarWriter,
+		getClusterStatus: func(_ context.Context, svcName string) ([]byte, error) {
+			assert.Equal(t, "test-raycluster-head-svc", svcName)
+			return nil, fmt.Errorf("dashboard is not reachable")
+		},
+		now:              now,
+		namespace:        "default",
+		rootDir:          "bundle",
+		operatorLogLines: 100,
+	}
+	err := collector.collect(context.Background(), util.RayJob, "test-rayjob")
+	assert.Nil(t, err)
+	assert.Nil(t, tarWriter.Close())
+
+	files := readArchive(t, &archive)
+	assert.ElementsMatch(t, []string{
+		"bundle/resources/rayjob-test-rayjob.yaml",
+		"bundle/resources/raycluster-test-raycluster.yaml",
+		"bundle/pods/test-raycluster-head.yaml",
+		"bundle/events.txt",
+		"bundle/operator/kuberay-system_kuberay-operator-xxxxx.log",
+		"bundle/errors.txt",
+	}, func() []string {
+		names := make([]string, 0, len(files))
+		for name := range files {
+			names = append(names, name)
+		}
+		return names
+	}())
+
+	for name, content := range files {
+		assert.NotContains(t, content, "my-password", name)
+	}
+	assert.NotContains(t, files["bundle/resources/rayjob-test-rayjob.yaml"], lastAppliedConfigAnnotation)
+	assert.Contains(t, files["bundle/pods/test-raycluster-head.yaml"], "value: <redacted>")
+
+	expectedEvents := `LAST SEEN              TYPE      REASON    OBJECT                     COUNT   MESSAGE
+2024-01-01T00:00:00Z   Warning   Pulling   pod/test-raycluster-head   1       Pulling message
+2024-01-01T00:01:00Z   Warning   BackOff   pod/test-raycluster-head   1       BackOff message
+`
+	assert.Equal(t, expectedEvents, files["bundle/events.txt"])
+	assert.Equal(t, "Ray cluster status: dashboard is not reachable\n", files["bundle/errors.txt"])
+}
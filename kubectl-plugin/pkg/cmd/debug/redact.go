@@ -0,0 +1,58 @@
+package debug
+
+import (
+	"regexp"
+	"strings"
+)
+
+const redacted = "<redacted>"
+
+var (
+	// sensitiveKeyPattern matches keys and environment variable names whose values are likely credentials
+	sensitiveKeyPattern = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|api[_-]?key|access[_-]?key|private[_-]?key)`)
+	// urlCredentialsPattern matches the password of URLs with user info, e.g. redis://:PASSWORD@HOST:PORT
+	urlCredentialsPattern = regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://[^:@/\s]*:)[^@/\s]+@`)
+	// passwordFlagPattern matches the value of password flags in commands, e.g. ray start --redis-password=PASSWORD
+	passwordFlagPattern = regexp.MustCompile(`(?i)(--[a-z-]*password[= ])[^\s'"]+`)
+)
+
+// redact replaces the values of sensitive fields of an unstructured object in place: environment variables and keys
+// whose name looks like a credential, such as REDIS_PASSWORD or the redis-password Ray start parameter, and passwords
+// embedded in URLs or passed as command flags. References to Secrets, e.g. secretKeyRef, are kept as they do not hold the values.
+func redact(obj interface{}) {
+	switch typed := obj.(type) {
+	case map[string]interface{}:
+		// Environment variables are {"name": NAME, "value": VALUE}
+		if name, ok := typed["name"].(string); ok && sensitiveKeyPattern.MatchString(name) {
+			if _, ok := typed["value"].(string); ok {
+				typed["value"] = redacted
+			}
+		}
+		for key, value := range typed {
+			str, ok := value.(string)
+			if !ok {
+				redact(value)
+				continue
+			}
+			// Names of sensitive objects, e.g. secretName, are not sensitive themselves
+			if sensitiveKeyPattern.MatchString(key) && !strings.HasSuffix(strings.ToLower(key), "name") {
+				typed[key] = redacted
+				continue
+			}
+			typed[key] = redactString(str)
+		}
+	case []interface{}:
+		for i, value := range typed {
+			if str, ok := value.(string); ok {
+				typed[i] = redactString(str)
+				continue
+			}
+			redact(value)
+		}
+	}
+}
+
+func redactString(s string) string {
+	s = urlCredentialsPattern.ReplaceAllString(s, "${1}"+redacted+"@")
+	return passwordFlagPattern.ReplaceAllString(s, "${1}"+redacted)
+}
@@ -0,0 +1,88 @@
+package debug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	obj := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"headGroupSpec": map[string]interface{}{
+				"rayStartParams": map[string]interface{}{
+					"redis-password": "my-password",
+					"num-cpus":       "1",
+				},
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{
+								"name": "ray-head",
+								"args": []interface{}{"ray start --head --redis-password=my-password --num-cpus=1"},
+								"env": []interface{}{
+									map[string]interface{}{"name": "REDIS_PASSWORD", "value": "my-password"},
+									map[string]interface{}{"name": "RAY_REDIS_ADDRESS", "value": "redis://:my-password@redis:6379"},
+									map[string]interface{}{"name": "RAY_LOG_LEVEL", "value": "debug"},
+									map[string]interface{}{
+										"name": "AWS_SECRET_ACCESS_KEY",
+										"valueFrom": map[string]interface{}{
+											"secretKeyRef": map[string]interface{}{"name": "aws", "key": "secret"},
+										},
+									},
+								},
+							},
+						},
+						"volumes": []interface{}{
+							map[string]interface{}{
+								"name":   "certs",
+								"secret": map[string]interface{}{"secretName": "ray-certs"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	redact(obj)
+
+	expected := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"headGroupSpec": map[string]interface{}{
+				"rayStartParams": map[string]interface{}{
+					"redis-password": redacted,
+					"num-cpus":       "1",
+				},
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{
+								"name": "ray-head",
+								"args": []interface{}{"ray start --head --redis-password=<redacted> --num-cpus=1"},
+								"env": []interface{}{
+									map[string]interface{}{"name": "REDIS_PASSWORD", "value": redacted},
+									map[string]interface{}{"name": "RAY_REDIS_ADDRESS", "value": "redis://:<redacted>@redis:6379"},
+									map[string]interface{}{"name": "RAY_LOG_LEVEL", "value": "debug"},
+									map[string]interface{}{
+										"name": "AWS_SECRET_ACCESS_KEY",
+										"valueFrom": map[string]interface{}{
+											"secretKeyRef": map[string]interface{}{"name": "aws", "key": "secret"},
+										},
+									},
+								},
+							},
+						},
+						"volumes": []interface{}{
+							map[string]interface{}{
+								"name":   "certs",
+								"secret": map[string]interface{}{"secretName": "ray-certs"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, obj)
+}
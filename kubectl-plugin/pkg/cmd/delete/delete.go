@@ -0,0 +1,284 @@
+package delete
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/completion"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/config"
+)
+
+const defaultDeleteTimeout = 5 * time.Minute
+
+// podDeletionPollInterval is how often the Pods of a RayCluster are checked while waiting for the teardown
+var podDeletionPollInterval = 2 * time.Second
+
+type DeleteOptions struct {
+	configFlags  *genericclioptions.ConfigFlags
+	ioStreams    *genericiooptions.IOStreams
+	ResourceType util.ResourceType
+	ResourceName string
+	namespace    string
+	timeout      time.Duration
+	yes          bool
+	wait         bool
+	keepCluster  bool
+}
+
+var (
+	deleteLong = templates.LongDesc(`
+		Delete a RayCluster, RayJob or RayService.
+
+		The RayClusters created by a RayJob or RayService are deleted together with it. Use '--keep-cluster' to keep the
+		RayCluster of a RayJob, e.g. to inspect it after the job failed.
+
+		The deletion has to be confirmed interactively unless '--yes' is given. Use '--wait' to wait until the resource,
+		its RayClusters and their Pods are gone.
+	`)
+
+	deleteExample = templates.Examples(`
+		# Delete the RayCluster after confirming the deletion
+		kubectl ray delete my-raycluster
+
+		# Delete the RayService and its RayClusters without confirmation and wait until all their Pods are gone
+		kubectl ray delete rayservice/my-rayservice --yes --wait
+
+		# Delete the RayJob but keep the RayCluster it ran on
+		kubectl ray delete rayjob/my-rayjob --keep-cluster
+	`)
+)
+
+func NewDeleteOptions(streams genericiooptions.IOStreams) *DeleteOptions {
+	return &DeleteOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+		timeout:     defaultDeleteTimeout,
+	}
+}
+
+func NewDeleteCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	options := NewDeleteOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:               "delete (RAYCLUSTER | TYPE/NAME)",
+		Short:             "Delete a Ray resource",
+		Long:              deleteLong,
+		Example:           deleteExample,
+		SilenceUsage:      true,
+		ValidArgsFunction: completion.RayClusterResourceNameCompletionFunc(cmdFactory),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			k8sClient, err := client.NewClient(cmdFactory)
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			return options.Run(cmd.Context(), k8sClient)
+		},
+	}
+	cmd.Flags().BoolVarP(&options.yes, "yes", "y", options.yes, "If present, delete without asking for confirmation")
+	cmd.Flags().BoolVar(&options.wait, "wait", options.wait, "If present, wait until the resource, its RayClusters and their Pods are deleted")
+	cmd.Flags().DurationVar(&options.timeout, "timeout", defaultDeleteTimeout, "Maximum time to wait for the deletion with --wait")
+	config.BindFlag(cmd.Flags(), "timeout", config.Timeout)
+	cmd.Flags().BoolVar(&options.keepCluster, "keep-cluster", options.keepCluster, "If present, keep the RayCluster of the RayJob. Only valid for RayJobs")
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *DeleteOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return cmdutil.UsageErrorf(cmd, "%s", cmd.Use)
+	}
+
+	resourceType, resourceName, err := util.ParseRayResource(args[0])
+	if err != nil {
+		return cmdutil.UsageErrorf(cmd, "%s", err.Error())
+	}
+	options.ResourceType = resourceType
+	options.ResourceName = resourceName
+
+	if *options.configFlags.Namespace == "" {
+		options.namespace = "default"
+	} else {
+		options.namespace = *options.configFlags.Namespace
+	}
+	return nil
+}
+
+func (options *DeleteOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	if options.keepCluster && options.ResourceType != util.RayJob {
+		return fmt.Errorf("--keep-cluster can only be used with RayJobs")
+	}
+	if options.timeout <= 0 {
+		return fmt.Errorf("timeout must be a positive duration, got %s", options.timeout)
+	}
+	return nil
+}
+
+func (options *DeleteOptions) Run(ctx context.Context, k8sClient client.Client) error {
+	gvr, kind, err := resourceGVR(options.ResourceType)
+	if err != nil {
+		return err
+	}
+	obj, err := k8sClient.DynamicClient().Resource(gvr).Namespace(options.namespace).Get(ctx, options.ResourceName, v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to find %s %s: %w", kind, options.ResourceName, err)
+	}
+
+	clusterNames := options.ownedClusterNames(obj)
+	if !options.yes {
+		confirmed, err := options.confirm(kind, clusterNames)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(options.ioStreams.Out, "Deletion cancelled")
+			return nil
+		}
+	}
+
+	deleteOptions := v1.DeleteOptions{}
+	if options.keepCluster {
+		// Orphan the RayCluster so that it is not garbage collected together with the RayJob
+		orphan := v1.DeletePropagationOrphan
+		deleteOptions.PropagationPolicy = &orphan
+	}
+	if err := k8sClient.DynamicClient().Resource(gvr).Namespace(options.namespace).Delete(ctx, options.ResourceName, deleteOptions); err != nil {
+		return fmt.Errorf("failed to delete %s %s: %w", kind, options.ResourceName, err)
+	}
+	fmt.Fprintf(options.ioStreams.Out, "Deleted %s %s\n", kind, options.ResourceName)
+	if options.keepCluster {
+		if clusterName, _, _ := unstructured.NestedString(obj.Object, "status", "rayClusterName"); clusterName != "" {
+			fmt.Fprintf(options.ioStreams.Out, "Kept RayCluster %s\n", clusterName)
+		}
+	}
+
+	if !options.wait {
+		return nil
+	}
+	return options.waitForTeardown(ctx, k8sClient, gvr, kind, clusterNames)
+}
+
+// ownedClusterNames returns the names of the RayClusters that are deleted together with the resource
+func (options *DeleteOptions) ownedClusterNames(obj *unstructured.Unstructured) []string {
+	switch options.ResourceType {
+	case util.RayCluster:
+		return []string{obj.GetName()}
+	case util.RayJob:
+		// A RayCluster selected with clusterSelector is not owned by the RayJob
+		if options.keepCluster || hasClusterSelector(obj) {
+			return nil
+		}
+		if clusterName, _, _ := unstructured.NestedString(obj.Object, "status", "rayClusterName"); clusterName != "" {
+			return []string{clusterName}
+		}
+	case util.RayService:
+		var clusterNames []string
+		for _, serviceStatus := range []string{"activeServiceStatus", "pendingServiceStatus"} {
+			if clusterName, _, _ := unstructured.NestedString(obj.Object, "status", serviceStatus, "rayClusterName"); clusterName != "" {
+				clusterNames = append(clusterNames, clusterName)
+			}
+		}
+		return clusterNames
+	}
+	return nil
+}
+
+func hasClusterSelector(rayJob *unstructured.Unstructured) bool {
+	clusterSelector, _, _ := unstructured.NestedStringMap(rayJob.Object, "spec", "clusterSelector")
+	return len(clusterSelector) > 0
+}
+
+// confirm asks for confirmation of the deletion on the input stream. Anything but "y" or "yes" declines.
+func (options *DeleteOptions) confirm(kind string, clusterNames []string) (bool, error) {
+	prompt := fmt.Sprintf("Delete %s %s in namespace %s", kind, options.ResourceName, options.namespace)
+	if options.ResourceType != util.RayCluster && len(clusterNames) > 0 {
+		prompt += fmt.Sprintf(" and RayCluster %s", strings.Join(clusterNames, ", "))
+	}
+	fmt.Fprintf(options.ioStreams.Out, "%s? [y/N]: ", prompt)
+
+	answer, err := bufio.NewReader(options.ioStreams.In).ReadString('\n')
+	if err != nil && answer == "" {
+		// The input is closed, e.g. when not running in a terminal
+		fmt.Fprintln(options.ioStreams.Out)
+		return false, nil
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// waitForTeardown waits until the resource, its RayClusters and their Pods are deleted
+func (options *DeleteOptions) waitForTeardown(ctx context.Context, k8sClient client.Client, gvr schema.GroupVersionResource, kind string, clusterNames []string) error {
+	waitCtx, cancel := context.WithTimeout(ctx, options.timeout)
+	defer cancel()
+
+	fmt.Fprintf(options.ioStreams.Out, "Waiting for %s %s to be deleted...\n", kind, options.ResourceName)
+	if err := client.WaitForResourceDeletion(waitCtx, k8sClient.DynamicClient(), gvr, options.namespace, options.ResourceName); err != nil {
+		return fmt.Errorf("%s %s was not deleted: %w", kind, options.ResourceName, err)
+	}
+	for _, clusterName := range clusterNames {
+		if options.ResourceType != util.RayCluster {
+			fmt.Fprintf(options.ioStreams.Out, "Waiting for RayCluster %s to be deleted...\n", clusterName)
+			if err := client.WaitForResourceDeletion(waitCtx, k8sClient.DynamicClient(), util.RayClusterGVR, options.namespace, clusterName); err != nil {
+				return fmt.Errorf("RayCluster %s was not deleted: %w", clusterName, err)
+			}
+		}
+
+		fmt.Fprintf(options.ioStreams.Out, "Waiting for the Pods of RayCluster %s to be deleted...\n", clusterName)
+		err := wait.PollUntilContextCancel(waitCtx, podDeletionPollInterval, true, func(ctx context.Context) (bool, error) {
+			pods, err := k8sClient.KubernetesClient().CoreV1().Pods(options.namespace).List(ctx, v1.ListOptions{
+				LabelSelector: fmt.Sprintf("ray.io/cluster=%s", clusterName),
+			})
+			if err != nil {
+				return false, err
+			}
+			return len(pods.Items) == 0, nil
+		})
+		if err != nil {
+			return fmt.Errorf("the Pods of RayCluster %s were not deleted: %w", clusterName, err)
+		}
+	}
+	fmt.Fprintf(options.ioStreams.Out, "%s %s is deleted\n", kind, options.ResourceName)
+	return nil
+}
+
+func resourceGVR(resourceType util.ResourceType) (schema.GroupVersionResource, string, error) {
+	switch resourceType {
+	case util.RayCluster:
+		return util.RayClusterGVR, "RayCluster", nil
+	case util.RayJob:
+		return util.RayJobGVR, "RayJob", nil
+	case util.RayService:
+		return util.RayServiceGVR, "RayService", nil
+	default:
+		return schema.GroupVersionResource{}, "", fmt.Errorf("unsupported resource type: %s", resourceType)
+	}
+}
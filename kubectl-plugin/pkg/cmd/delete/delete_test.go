@@ -0,0 +1,167 @@
+package delete
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+)
+
+func TestRayDeleteComplete(t *testing.T) {
+	cmd := &cobra.Command{Use: "delete"}
+	testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+
+	fakeDeleteOptions := NewDeleteOptions(testStreams)
+	err := fakeDeleteOptions.Complete(cmd, []string{"rayjob/test-rayjob"})
+	assert.Nil(t, err)
+	assert.Equal(t, util.RayJob, fakeDeleteOptions.ResourceType)
+	assert.Equal(t, "test-rayjob", fakeDeleteOptions.ResourceName)
+	assert.Equal(t, "default", fakeDeleteOptions.namespace)
+
+	err = fakeDeleteOptions.Complete(cmd, []string{})
+	assert.NotNil(t, err)
+	err = fakeDeleteOptions.Complete(cmd, []string{"pod/test-pod"})
+	assert.NotNil(t, err)
+}
+
+func newTestObjects() []runtime.Object {
+	return []runtime.Object{
+		&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "ray.io/v1",
+			"kind":       "RayJob",
+			"metadata":   map[string]interface{}{"name": "test-rayjob", "namespace": "default"},
+			"status":     map[string]interface{}{"rayClusterName": "test-rayjob-raycluster"},
+		}},
+		&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "ray.io/v1",
+			"kind":       "RayService",
+			"metadata":   map[string]interface{}{"name": "test-rayservice", "namespace": "default"},
+			"status": map[string]interface{}{
+				"activeServiceStatus":  map[string]interface{}{"rayClusterName": "test-rayservice-raycluster-a"},
+				"pendingServiceStatus": map[string]interface{}{"rayClusterName": "test-rayservice-raycluster-b"},
+			},
+		}},
+		&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "ray.io/v1",
+			"kind":       "RayCluster",
+			"metadata":   map[string]interface{}{"name": "test-raycluster", "namespace": "default"},
+		}},
+	}
+}
+
+func TestRayDeleteRun(t *testing.T) {
+	tests := []struct {
+		name           string
+		resourceType   util.ResourceType
+		resourceName   string
+		input          string
+		expectedOutput string
+		yes            bool
+		keepCluster    bool
+		expectDeleted  bool
+	}{
+		{
+			name:           "confirmed deletion of a RayCluster",
+			resourceType:   util.RayCluster,
+			resourceName:   "test-raycluster",
+			input:          "y\n",
+			expectedOutput: "Delete RayCluster test-raycluster in namespace default? [y/N]: Deleted RayCluster test-raycluster\n",
+			expectDeleted:  true,
+		},
+		{
+			name:           "declined deletion of a RayService",
+			resourceType:   util.RayService,
+			resourceName:   "test-rayservice",
+			input:          "n\n",
+			expectedOutput: "Delete RayService test-rayservice in namespace default and RayCluster test-rayservice-raycluster-a, test-rayservice-raycluster-b? [y/N]: Deletion cancelled\n",
+		},
+		{
+			name:           "deletion is cancelled without input",
+			resourceType:   util.RayJob,
+			resourceName:   "test-rayjob",
+			expectedOutput: "Delete RayJob test-rayjob in namespace default and RayCluster test-rayjob-raycluster? [y/N]: \nDeletion cancelled\n",
+		},
+		{
+			name:           "deletion without confirmation",
+			resourceType:   util.RayJob,
+			resourceName:   "test-rayjob",
+			yes:            true,
+			expectedOutput: "Deleted RayJob test-rayjob\n",
+			expectDeleted:  true,
+		},
+		{
+			name:           "deletion of a RayJob keeping its RayCluster",
+			resourceType:   util.RayJob,
+			resourceName:   "test-rayjob",
+			yes:            true,
+			keepCluster:    true,
+			expectedOutput: "Deleted RayJob test-rayjob\nKept RayCluster test-rayjob-raycluster\n",
+			expectDeleted:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testStreams, inBuf, outBuf, _ := genericiooptions.NewTestIOStreams()
+			inBuf.WriteString(tc.input)
+
+			dynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), newTestObjects()...)
+			k8sClients := client.NewClientForTesting(kubeFake.NewSimpleClientset(), dynamicClient)
+
+			fakeDeleteOptions := NewDeleteOptions(testStreams)
+			fakeDeleteOptions.ResourceType = tc.resourceType
+			fakeDeleteOptions.ResourceName = tc.resourceName
+			fakeDeleteOptions.namespace = "default"
+			fakeDeleteOptions.yes = tc.yes
+			fakeDeleteOptions.keepCluster = tc.keepCluster
+
+			err := fakeDeleteOptions.Run(context.Background(), k8sClients)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedOutput, outBuf.String())
+
+			gvr, _, err := resourceGVR(tc.resourceType)
+			assert.Nil(t, err)
+			_, err = dynamicClient.Resource(gvr).Namespace("default").Get(context.Background(), tc.resourceName, v1.GetOptions{})
+			assert.Equal(t, tc.expectDeleted, err != nil)
+		})
+	}
+}
+
+func TestRayDeleteRunWait(t *testing.T) {
+	podDeletionPollInterval = 10 * time.Millisecond
+	testStreams, _, outBuf, _ := genericiooptions.NewTestIOStreams()
+	dynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), newTestObjects()...)
+	k8sClients := client.NewClientForTesting(kubeFake.NewSimpleClientset(), dynamicClient)
+
+	fakeDeleteOptions := NewDeleteOptions(testStreams)
+	fakeDeleteOptions.ResourceType = util.RayCluster
+	fakeDeleteOptions.ResourceName = "test-raycluster"
+	fakeDeleteOptions.namespace = "default"
+	fakeDeleteOptions.yes = true
+	fakeDeleteOptions.wait = true
+
+	err := fakeDeleteOptions.Run(context.Background(), k8sClients)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasSuffix(outBuf.String(), "Waiting for the Pods of RayCluster test-raycluster to be deleted...\nRayCluster test-raycluster is deleted\n"))
+}
+
+func TestRayDeleteOwnedClusterNames(t *testing.T) {
+	rayJobWithSelector := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec":   map[string]interface{}{"clusterSelector": map[string]interface{}{"ray.io/cluster": "shared-raycluster"}},
+		"status": map[string]interface{}{"rayClusterName": "shared-raycluster"},
+	}}
+	fakeDeleteOptions := &DeleteOptions{ResourceType: util.RayJob}
+	assert.Empty(t, fakeDeleteOptions.ownedClusterNames(rayJobWithSelector))
+}
@@ -0,0 +1,455 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/dashboard"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+type checkStatus string
+
+const (
+	statusOK   checkStatus = "OK"
+	statusWarn checkStatus = "WARN"
+	statusFail checkStatus = "FAIL"
+	statusSkip checkStatus = "SKIP"
+)
+
+// redisAddressEnv is the environment variable of the Ray head container holding the Redis address used for GCS fault tolerance
+const redisAddressEnv = "RAY_REDIS_ADDRESS"
+
+// redisConnectivityCommand opens a TCP connection to the first Redis address of RAY_REDIS_ADDRESS from the Ray container
+var redisConnectivityCommand = []string{
+	"python", "-c",
+	"import os, socket; from urllib.parse import urlparse; " +
+		"address = os.getenv('RAY_REDIS_ADDRESS', '').split(',')[0]; " +
+		"address = address if '://' in address else 'redis://' + address; " +
+		"parsed = urlparse(address); " +
+		"socket.create_connection((parsed.hostname, parsed.port or 6379), timeout=5).close()",
+}
+
+// checkResult is the outcome of a single diagnostic check. hint is an actionable remediation for non-OK results.
+type checkResult struct {
+	name    string
+	status  checkStatus
+	message string
+	hint    string
+}
+
+// runInPodFunc runs a command in the Ray container of the Pod and returns its output
+type runInPodFunc func(ctx context.Context, pod *corev1.Pod, command []string) (string, error)
+
+// listRayNodesFunc lists the Ray nodes through the Ray dashboard exposed by the given Ray head service
+type listRayNodesFunc func(ctx context.Context, svcName string) ([]dashboard.Node, error)
+
+// doctor runs the diagnostic checks against the Kubernetes cluster and, optionally, a RayCluster
+type doctor struct {
+	k8sClient    client.Client
+	runInPod     runInPodFunc
+	listRayNodes listRayNodesFunc
+	namespace    string
+}
+
+// checkOperator checks that a KubeRay operator Deployment exists and all its replicas are available
+func (d *doctor) checkOperator(ctx context.Context) checkResult {
+	result := checkResult{name: "KubeRay operator"}
+	deployments, err := d.k8sClient.KubernetesClient().AppsV1().Deployments("").List(ctx, metav1.ListOptions{
+		LabelSelector: "app.kubernetes.io/name in (kuberay-operator,kuberay)",
+	})
+	if err != nil {
+		result.status = statusFail
+		result.message = fmt.Sprintf("unable to list KubeRay operator Deployments: %v", err)
+		result.hint = "Check that you are allowed to list Deployments in all namespaces."
+		return result
+	}
+	if len(deployments.Items) == 0 {
+		result.status = statusFail
+		result.message = "no KubeRay operator Deployment found in any namespace"
+		result.hint = "Install the KubeRay operator, e.g. 'helm install kuberay-operator kuberay/kuberay-operator'."
+		return result
+	}
+
+	deployment := deployments.Items[0]
+	desiredReplicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desiredReplicas = *deployment.Spec.Replicas
+	}
+	if deployment.Status.AvailableReplicas < desiredReplicas {
+		result.status = statusFail
+		result.message = fmt.Sprintf("Deployment %s/%s has %d/%d available replicas", deployment.Namespace, deployment.Name, deployment.Status.AvailableReplicas, desiredReplicas)
+		result.hint = fmt.Sprintf("Inspect the operator Pods with 'kubectl describe deployment -n %s %s' and 'kubectl logs -n %s deployment/%s'.", deployment.Namespace, deployment.Name, deployment.Namespace, deployment.Name)
+		return result
+	}
+
+	result.status = statusOK
+	result.message = fmt.Sprintf("Deployment %s/%s has %d/%d available replicas", deployment.Namespace, deployment.Name, deployment.Status.AvailableReplicas, desiredReplicas)
+	return result
+}
+
+// checkCRDs checks that the ray.io/v1 API serves the RayCluster, RayJob and RayService resources
+func (d *doctor) checkCRDs(_ context.Context) checkResult {
+	result := checkResult{name: "CRDs"}
+	hint := "Install or upgrade the KubeRay CRDs to match the operator version, e.g. 'kubectl apply --server-side -k \"github.com/ray-project/kuberay/ray-operator/config/crd?ref=<version>\"'."
+
+	groups, err := d.k8sClient.KubernetesClient().Discovery().ServerGroups()
+	if err != nil {
+		result.status = statusFail
+		result.message = fmt.Sprintf("unable to discover the API groups: %v", err)
+		return result
+	}
+	var versions []string
+	for _, group := range groups.Groups {
+		if group.Name != util.RayGroup {
+			continue
+		}
+		for _, version := range group.Versions {
+			versions = append(versions, version.Version)
+		}
+	}
+	if !slices.Contains(versions, util.RayVersion) {
+		result.status = statusFail
+		result.message = fmt.Sprintf("API %s/%s is not served, found versions: [%s]", util.RayGroup, util.RayVersion, strings.Join(versions, ", "))
+		result.hint = hint
+		return result
+	}
+
+	resources, err := d.k8sClient.KubernetesClient().Discovery().ServerResourcesForGroupVersion(util.RayGroup + "/" + util.RayVersion)
+	if err != nil {
+		result.status = statusFail
+		result.message = fmt.Sprintf("unable to discover the resources of %s/%s: %v", util.RayGroup, util.RayVersion, err)
+		return result
+	}
+	var missing []string
+	for _, gvr := range []string{util.RayClusterGVR.Resource, util.RayJobGVR.Resource, util.RayServiceGVR.Resource} {
+		if !slices.ContainsFunc(resources.APIResources, func(resource metav1.APIResource) bool { return resource.Name == gvr }) {
+			missing = append(missing, gvr)
+		}
+	}
+	if len(missing) > 0 {
+		result.status = statusFail
+		result.message = fmt.Sprintf("missing resources in %s/%s: [%s]", util.RayGroup, util.RayVersion, strings.Join(missing, ", "))
+		result.hint = hint
+		return result
+	}
+
+	result.status = statusOK
+	result.message = fmt.Sprintf("served versions of %s: [%s]", util.RayGroup, strings.Join(versions, ", "))
+	return result
+}
+
+// checkWebhooks checks that the admission webhooks of Ray resources, if any, are backed by ready endpoints
+func (d *doctor) checkWebhooks(ctx context.Context) checkResult {
+	result := checkResult{name: "Webhooks"}
+	webhookConfigurations, err := d.k8sClient.KubernetesClient().AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		result.status = statusWarn
+		result.message = fmt.Sprintf("unable to list ValidatingWebhookConfigurations: %v", err)
+		return result
+	}
+
+	var webhooks, unavailable []string
+	failClosed := false
+	for _, webhookConfiguration := range webhookConfigurations.Items {
+		for _, webhook := range webhookConfiguration.Webhooks {
+			if !isRayWebhook(webhook.Rules) || webhook.ClientConfig.Service == nil {
+				continue
+			}
+			webhooks = append(webhooks, webhook.Name)
+			service := webhook.ClientConfig.Service
+			ready, err := d.hasReadyEndpoints(ctx, service.Namespace, service.Name)
+			if err != nil || !ready {
+				unavailable = append(unavailable, fmt.Sprintf("%s (service %s/%s)", webhook.Name, service.Namespace, service.Name))
+				if webhook.FailurePolicy == nil || *webhook.FailurePolicy == admissionregistrationv1.Fail {
+					failClosed = true
+				}
+			}
+		}
+	}
+
+	switch {
+	case len(webhooks) == 0:
+		result.status = statusOK
+		result.message = "no admission webhook configured for Ray resources"
+	case len(unavailable) > 0:
+		result.status = statusWarn
+		if failClosed {
+			// Creating or updating Ray resources is rejected by the API server
+			result.status = statusFail
+		}
+		result.message = fmt.Sprintf("webhooks without ready endpoints: %s", strings.Join(unavailable, ", "))
+		result.hint = "Check that the KubeRay operator Pod serving the webhook is running and ready, or remove the webhook configuration if the webhook is disabled."
+	default:
+		result.status = statusOK
+		result.message = fmt.Sprintf("webhooks are available: %s", strings.Join(webhooks, ", "))
+	}
+	return result
+}
+
+func isRayWebhook(rules []admissionregistrationv1.RuleWithOperations) bool {
+	for _, rule := range rules {
+		if slices.Contains(rule.APIGroups, util.RayGroup) || slices.Contains(rule.APIGroups, "*") {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *doctor) hasReadyEndpoints(ctx context.Context, namespace, name string) (bool, error) {
+	endpoints, err := d.k8sClient.KubernetesClient().CoreV1().Endpoints(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkRayCluster runs the checks of the RayCluster of the given Ray resource
+func (d *doctor) checkRayCluster(ctx context.Context, resourceType util.ResourceType, resourceName string) []checkResult {
+	clusterName, err := d.k8sClient.GetRayClusterName(ctx, d.namespace, resourceType, resourceName)
+	if err != nil {
+		return []checkResult{{
+			name:    "RayCluster",
+			status:  statusFail,
+			message: err.Error(),
+			hint:    fmt.Sprintf("Check that the %s exists in namespace %s and that the operator created its RayCluster.", resourceType, d.namespace),
+		}}
+	}
+	unstructuredRayCluster, err := d.k8sClient.DynamicClient().Resource(util.RayClusterGVR).Namespace(d.namespace).Get(ctx, clusterName, metav1.GetOptions{})
+	if err != nil {
+		return []checkResult{{
+			name:    "RayCluster",
+			status:  statusFail,
+			message: fmt.Sprintf("unable to find RayCluster %s: %v", clusterName, err),
+			hint:    fmt.Sprintf("Check that the RayCluster exists in namespace %s.", d.namespace),
+		}}
+	}
+	var rayCluster rayv1.RayCluster
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredRayCluster.Object, &rayCluster); err != nil {
+		return []checkResult{{
+			name:    "RayCluster",
+			status:  statusFail,
+			message: fmt.Sprintf("unable to parse RayCluster %s: %v", clusterName, err),
+			hint:    "Check that the KubeRay CRDs match the version of the plugin.",
+		}}
+	}
+
+	results := []checkResult{checkRayClusterConditions(&rayCluster)}
+	headPod, headPodResult := d.checkHeadPod(ctx, &rayCluster)
+	results = append(results, headPodResult)
+	results = append(results, d.checkDashboard(ctx, &rayCluster, headPod))
+	results = append(results, d.checkGCSFaultTolerance(ctx, unstructuredRayCluster, headPod))
+	return results
+}
+
+// checkRayClusterConditions checks the state and the conditions of the RayCluster
+func checkRayClusterConditions(rayCluster *rayv1.RayCluster) checkResult {
+	result := checkResult{name: "RayCluster"}
+	describeHint := fmt.Sprintf("Inspect the events with 'kubectl describe raycluster -n %s %s'.", rayCluster.Namespace, rayCluster.Name)
+
+	if condition := meta.FindStatusCondition(rayCluster.Status.Conditions, string(rayv1.RayClusterReplicaFailure)); condition != nil && condition.Status == metav1.ConditionTrue {
+		result.status = statusFail
+		result.message = fmt.Sprintf("%s: %s", condition.Reason, condition.Message)
+		result.hint = "The operator fails to create or delete Pods. " + describeHint
+		return result
+	}
+	if rayCluster.Spec.Suspend != nil && *rayCluster.Spec.Suspend {
+		result.status = statusWarn
+		result.message = "RayCluster is suspended"
+		result.hint = "Set spec.suspend to false to resume the RayCluster."
+		return result
+	}
+	if condition := meta.FindStatusCondition(rayCluster.Status.Conditions, string(rayv1.HeadPodReady)); condition != nil && condition.Status != metav1.ConditionTrue {
+		result.status = statusWarn
+		result.message = fmt.Sprintf("head Pod is not ready: %s", condition.Message)
+		result.hint = describeHint
+		return result
+	}
+	if rayCluster.Status.State != rayv1.Ready {
+		result.status = statusWarn
+		result.message = fmt.Sprintf("RayCluster state is %q", rayCluster.Status.State)
+		if rayCluster.Status.Reason != "" {
+			result.message += ": " + rayCluster.Status.Reason
+		}
+		result.hint = describeHint
+		return result
+	}
+
+	result.status = statusOK
+	result.message = fmt.Sprintf("RayCluster %s is ready with %d/%d ready worker replicas", rayCluster.Name, rayCluster.Status.ReadyWorkerReplicas, rayCluster.Status.DesiredWorkerReplicas)
+	return result
+}
+
+// checkHeadPod checks that the head Pod is running and ready. It returns the head Pod if it is ready.
+func (d *doctor) checkHeadPod(ctx context.Context, rayCluster *rayv1.RayCluster) (*corev1.Pod, checkResult) {
+	result := checkResult{name: "Head Pod"}
+	pods, err := d.k8sClient.KubernetesClient().CoreV1().Pods(d.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("ray.io/cluster=%s,ray.io/node-type=head", rayCluster.Name),
+	})
+	if err != nil {
+		result.status = statusFail
+		result.message = fmt.Sprintf("unable to list the head Pod: %v", err)
+		return nil, result
+	}
+	if len(pods.Items) == 0 {
+		result.status = statusFail
+		result.message = "no head Pod found"
+		result.hint = fmt.Sprintf("Check the operator logs and the events of the RayCluster with 'kubectl describe raycluster -n %s %s'.", rayCluster.Namespace, rayCluster.Name)
+		return nil, result
+	}
+
+	pod := &pods.Items[0]
+	if isPodReady(pod) {
+		result.status = statusOK
+		result.message = fmt.Sprintf("Pod %s is ready", pod.Name)
+		return pod, result
+	}
+
+	result.status = statusFail
+	result.message = fmt.Sprintf("Pod %s is %s and not ready", pod.Name, pod.Status.Phase)
+	result.hint = fmt.Sprintf("Inspect the Pod with 'kubectl describe pod -n %s %s'.", pod.Namespace, pod.Name)
+	if condition := podCondition(pod, corev1.PodScheduled); condition != nil && condition.Status == corev1.ConditionFalse {
+		result.message = fmt.Sprintf("Pod %s cannot be scheduled: %s", pod.Name, condition.Message)
+		result.hint = "Check the resource requests, node selectors and tolerations of the head group against the capacity of the nodes."
+		return nil, result
+	}
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.State.Waiting == nil {
+			continue
+		}
+		reason := containerStatus.State.Waiting.Reason
+		result.message = fmt.Sprintf("container %s of Pod %s is waiting: %s", containerStatus.Name, pod.Name, reason)
+		switch reason {
+		case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+			result.hint = fmt.Sprintf("Check that the image %s exists and that the image pull secrets allow pulling it.", containerStatus.Image)
+		case "CrashLoopBackOff":
+			result.hint = fmt.Sprintf("Inspect the logs of the previous run with 'kubectl logs -n %s %s -c %s --previous'.", pod.Namespace, pod.Name, containerStatus.Name)
+		case "CreateContainerConfigError":
+			result.hint = "Check that the ConfigMaps and Secrets referenced by the head group exist."
+		}
+		break
+	}
+	return nil, result
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+		return false
+	}
+	condition := podCondition(pod, corev1.PodReady)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+func podCondition(pod *corev1.Pod, conditionType corev1.PodConditionType) *corev1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == conditionType {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// checkDashboard checks that the Ray dashboard can be reached through the head service
+func (d *doctor) checkDashboard(ctx context.Context, rayCluster *rayv1.RayCluster, headPod *corev1.Pod) checkResult {
+	result := checkResult{name: "Dashboard"}
+	if headPod == nil {
+		result.status = statusSkip
+		result.message = "head Pod is not ready"
+		return result
+	}
+	if rayCluster.Status.Head.ServiceName == "" {
+		result.status = statusFail
+		result.message = "the head service is not reported in the RayCluster status"
+		result.hint = fmt.Sprintf("Check the operator logs and the events of the RayCluster with 'kubectl describe raycluster -n %s %s'.", rayCluster.Namespace, rayCluster.Name)
+		return result
+	}
+
+	nodes, err := d.listRayNodes(ctx, rayCluster.Status.Head.ServiceName)
+	if err != nil {
+		result.status = statusFail
+		result.message = fmt.Sprintf("unable to reach the Ray dashboard through service %s: %v", rayCluster.Status.Head.ServiceName, err)
+		result.hint = "Check that the dashboard is started with 'dashboard-host: 0.0.0.0' in the rayStartParams of the head group, and that the head service exposes the dashboard port 8265."
+		return result
+	}
+	aliveNodes := 0
+	for _, node := range nodes {
+		if node.Raylet.State == "ALIVE" {
+			aliveNodes++
+		}
+	}
+	result.status = statusOK
+	result.message = fmt.Sprintf("Ray dashboard is reachable, %d alive Ray nodes", aliveNodes)
+	return result
+}
+
+// checkGCSFaultTolerance checks that the Redis used for GCS fault tolerance can be reached from the head Pod
+func (d *doctor) checkGCSFaultTolerance(ctx context.Context, rayCluster *unstructured.Unstructured, headPod *corev1.Pod) checkResult {
+	result := checkResult{name: "GCS fault tolerance"}
+	if !isGCSFaultToleranceEnabled(rayCluster) {
+		result.status = statusSkip
+		result.message = "GCS fault tolerance is not enabled"
+		return result
+	}
+	if headPod == nil {
+		result.status = statusSkip
+		result.message = "head Pod is not ready"
+		return result
+	}
+
+	if output, err := d.runInPod(ctx, headPod, redisConnectivityCommand); err != nil {
+		result.status = statusFail
+		result.message = fmt.Sprintf("unable to connect to Redis from Pod %s: %v", headPod.Name, err)
+		if output = strings.TrimSpace(output); output != "" {
+			result.message += ": " + output
+		}
+		result.hint = fmt.Sprintf("Check that Redis is running and reachable from the head Pod at the address set in %s or in spec.gcsFaultToleranceOptions.redisAddress.", redisAddressEnv)
+		return result
+	}
+	result.status = statusOK
+	result.message = "Redis is reachable from the head Pod"
+	return result
+}
+
+// isGCSFaultToleranceEnabled uses the unstructured RayCluster as spec.gcsFaultToleranceOptions is not known to all KubeRay versions
+func isGCSFaultToleranceEnabled(rayCluster *unstructured.Unstructured) bool {
+	if rayCluster.GetAnnotations()["ray.io/ft-enabled"] == "true" {
+		return true
+	}
+	if _, found, _ := unstructured.NestedMap(rayCluster.Object, "spec", "gcsFaultToleranceOptions"); found {
+		return true
+	}
+	containers, _, _ := unstructured.NestedSlice(rayCluster.Object, "spec", "headGroupSpec", "template", "spec", "containers")
+	for _, container := range containers {
+		containerMap, ok := container.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		env, _, _ := unstructured.NestedSlice(containerMap, "env")
+		for _, envVar := range env {
+			envVarMap, ok := envVar.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if name, _, _ := unstructured.NestedString(envVarMap, "name"); name == redisAddressEnv {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,263 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/rest"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/completion"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/config"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/dashboard"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/printer"
+)
+
+const portforwardReadyTimeout = 30 * time.Second
+
+type DoctorOptions struct {
+	configFlags        *genericclioptions.ConfigFlags
+	outputFlags        *printer.OutputFlags
+	ioStreams          *genericiooptions.IOStreams
+	ResourceType       util.ResourceType
+	ResourceName       string
+	Namespace          string
+	localDashboardPort int
+}
+
+var (
+	doctorLong = templates.LongDesc(`
+		Run diagnostic checks of the KubeRay installation and print remediation hints for the issues found.
+
+		The KubeRay operator Deployment, the Ray CRDs and the admission webhooks of Ray resources are always checked.
+		When a RayCluster, or a RayJob or RayService, is given, the conditions of the RayCluster, the readiness of its head Pod,
+		the reachability of the Ray dashboard and, when GCS fault tolerance is enabled, the connectivity to Redis are checked as well.
+
+		Returns an error if any check fails.
+	`)
+
+	doctorExample = templates.Examples(`
+		# Check the KubeRay installation
+		kubectl ray doctor
+
+		# Check the KubeRay installation and the RayCluster
+		kubectl ray doctor my-raycluster
+
+		# Check the KubeRay installation and the RayCluster used by the RayService
+		kubectl ray doctor rayservice/my-rayservice -n my-namespace
+
+		# Output the results of the checks as JSON
+		kubectl ray doctor my-raycluster -o json
+	`)
+)
+
+func NewDoctorOptions(streams genericiooptions.IOStreams) *DoctorOptions {
+	return &DoctorOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		outputFlags: printer.NewOutputFlags(printer.JSON, printer.YAML),
+		ioStreams:   &streams,
+	}
+}
+
+func NewDoctorCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	options := NewDoctorOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:               "doctor [RAYCLUSTER | TYPE/NAME]",
+		Aliases:           []string{"check"},
+		Short:             "Diagnose the KubeRay installation and Ray resources",
+		Long:              doctorLong,
+		Example:           doctorExample,
+		SilenceUsage:      true,
+		ValidArgsFunction: completion.RayClusterResourceNameCompletionFunc(cmdFactory),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	cmd.Flags().IntVar(&options.localDashboardPort, "dashboard-port", options.localDashboardPort, "Local port used to forward the Ray dashboard. If not set, a free local port is selected automatically")
+	config.BindFlag(cmd.Flags(), "dashboard-port", config.DashboardPort)
+	options.outputFlags.AddFlags(cmd)
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *DoctorOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return cmdutil.UsageErrorf(cmd, "%s", cmd.Use)
+	}
+
+	if len(args) == 1 {
+		resourceType, resourceName, err := util.ParseRayResource(args[0])
+		if err != nil {
+			return cmdutil.UsageErrorf(cmd, "%s", err.Error())
+		}
+		options.ResourceType = resourceType
+		options.ResourceName = resourceName
+	}
+
+	if *options.configFlags.Namespace == "" {
+		options.Namespace = "default"
+	} else {
+		options.Namespace = *options.configFlags.Namespace
+	}
+
+	if options.ResourceName != "" && options.localDashboardPort == 0 {
+		freePort, err := util.GetFreeLocalPort()
+		if err != nil {
+			return fmt.Errorf("failed to find a free local port for the Ray dashboard: %w", err)
+		}
+		options.localDashboardPort = freePort
+	}
+	return nil
+}
+
+func (options *DoctorOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	if options.localDashboardPort < 0 || options.localDashboardPort > 65535 {
+		return fmt.Errorf("dashboard port %d is out of range, must be between 0 and 65535", options.localDashboardPort)
+	}
+	return options.outputFlags.Validate()
+}
+
+func (options *DoctorOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	k8sClient, err := client.NewClient(factory)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	restConfig, err := factory.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get restconfig: %w", err)
+	}
+
+	d := &doctor{
+		k8sClient:    k8sClient,
+		namespace:    options.Namespace,
+		runInPod:     execInPod(k8sClient, restConfig),
+		listRayNodes: options.listRayNodes(factory),
+	}
+	results := d.run(ctx, options.ResourceType, options.ResourceName)
+	if options.outputFlags.IsStructured() {
+		err = options.outputFlags.PrintData(resultsOutput(results), options.ioStreams.Out)
+	} else {
+		err = printResults(results, options.ioStreams.Out)
+	}
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.status == statusFail {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(results))
+	}
+	return nil
+}
+
+// run runs all the checks. The checks of the RayCluster are only run if a resource name is given.
+func (d *doctor) run(ctx context.Context, resourceType util.ResourceType, resourceName string) []checkResult {
+	results := []checkResult{
+		d.checkOperator(ctx),
+		d.checkCRDs(ctx),
+		d.checkWebhooks(ctx),
+	}
+	if resourceName != "" {
+		results = append(results, d.checkRayCluster(ctx, resourceType, resourceName)...)
+	}
+	return results
+}
+
+// listRayNodes port-forwards the Ray dashboard of the head service for the duration of the call
+func (options *DoctorOptions) listRayNodes(factory cmdutil.Factory) listRayNodesFunc {
+	return func(ctx context.Context, svcName string) ([]dashboard.Node, error) {
+		portforwardctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		// Keep the port forwarding messages out of the report
+		streams := genericiooptions.IOStreams{In: options.ioStreams.In, Out: io.Discard, ErrOut: io.Discard}
+		if err := dashboard.PortForward(portforwardctx, factory, streams, svcName, options.localDashboardPort, portforwardReadyTimeout, nil); err != nil {
+			return nil, err
+		}
+		return dashboard.ListNodes(ctx, dashboard.Address(options.localDashboardPort))
+	}
+}
+
+// execInPod runs commands in the Ray container of the Pod
+func execInPod(k8sClient client.Client, restConfig *rest.Config) runInPodFunc {
+	return func(ctx context.Context, pod *corev1.Pod, command []string) (string, error) {
+		return client.ExecInPod(ctx, k8sClient.KubernetesClient(), restConfig, pod, command)
+	}
+}
+
+// checkOutput is the machine-readable output of a check
+type checkOutput struct {
+	Name    string      `json:"name"`
+	Status  checkStatus `json:"status"`
+	Message string      `json:"message"`
+	Hint    string      `json:"hint,omitempty"`
+}
+
+func resultsOutput(results []checkResult) []checkOutput {
+	output := make([]checkOutput, 0, len(results))
+	for _, result := range results {
+		output = append(output, checkOutput{Name: result.name, Status: result.status, Message: result.message, Hint: result.hint})
+	}
+	return output
+}
+
+func printResults(results []checkResult, output io.Writer) error {
+	resultTablePrinter := printers.NewTablePrinter(printers.PrintOptions{})
+
+	resTable := &v1.Table{
+		ColumnDefinitions: []v1.TableColumnDefinition{
+			{Name: "Check", Type: "string"},
+			{Name: "Status", Type: "string"},
+			{Name: "Message", Type: "string"},
+		},
+	}
+	var hints []string
+	for _, result := range results {
+		resTable.Rows = append(resTable.Rows, v1.TableRow{
+			Cells: []interface{}{result.name, result.status, result.message},
+		})
+		if result.status != statusOK && result.hint != "" {
+			hints = append(hints, fmt.Sprintf("  - %s: %s", result.name, result.hint))
+		}
+	}
+	if err := resultTablePrinter.PrintObj(resTable, output); err != nil {
+		return err
+	}
+
+	if len(hints) > 0 {
+		fmt.Fprintf(output, "\nHints:\n%s\n", strings.Join(hints, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,349 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/dashboard"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/printer"
+)
+
+func TestRayDoctorComplete(t *testing.T) {
+	cmd := &cobra.Command{Use: "doctor"}
+	testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+
+	fakeDoctorOptions := NewDoctorOptions(testStreams)
+	err := fakeDoctorOptions.Complete(cmd, []string{})
+	assert.Nil(t, err)
+	assert.Equal(t, "", fakeDoctorOptions.ResourceName)
+	assert.Equal(t, "default", fakeDoctorOptions.Namespace)
+	// No dashboard port is needed without a resource
+	assert.Equal(t, 0, fakeDoctorOptions.localDashboardPort)
+
+	fakeDoctorOptions = NewDoctorOptions(testStreams)
+	err = fakeDoctorOptions.Complete(cmd, []string{"rayjob/test-rayjob"})
+	assert.Nil(t, err)
+	assert.Equal(t, util.RayJob, fakeDoctorOptions.ResourceType)
+	assert.Equal(t, "test-rayjob", fakeDoctorOptions.ResourceName)
+	assert.NotEqual(t, 0, fakeDoctorOptions.localDashboardPort)
+
+	err = fakeDoctorOptions.Complete(cmd, []string{"a", "b"})
+	assert.NotNil(t, err)
+}
+
+func newOperatorDeployment(availableReplicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "kuberay-operator",
+			Namespace: "kuberay-system",
+			Labels:    map[string]string{"app.kubernetes.io/name": "kuberay-operator"},
+		},
+		Spec:   appsv1.DeploymentSpec{Replicas: ptr.To[int32](1)},
+		Status: appsv1.DeploymentStatus{AvailableReplicas: availableReplicas},
+	}
+}
+
+func newRayDiscoveryResources(resources ...string) []*v1.APIResourceList {
+	apiResources := make([]v1.APIResource, 0, len(resources))
+	for _, resource := range resources {
+		apiResources = append(apiResources, v1.APIResource{Name: resource, Namespaced: true})
+	}
+	return []*v1.APIResourceList{{GroupVersion: "ray.io/v1", APIResources: apiResources}}
+}
+
+func TestCheckOperator(t *testing.T) {
+	tests := []struct {
+		name           string
+		objects        []runtime.Object
+		expectedStatus checkStatus
+	}{
+		{
+			name:           "operator is not installed",
+			expectedStatus: statusFail,
+		},
+		{
+			name:           "operator is not available",
+			objects:        []runtime.Object{newOperatorDeployment(0)},
+			expectedStatus: statusFail,
+		},
+		{
+			name:           "operator is available",
+			objects:        []runtime.Object{newOperatorDeployment(1)},
+			expectedStatus: statusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &doctor{k8sClient: client.NewClientForTesting(kubeFake.NewSimpleClientset(tc.objects...), nil)}
+			result := d.checkOperator(context.Background())
+			assert.Equal(t, tc.expectedStatus, result.status)
+		})
+	}
+}
+
+func TestCheckCRDs(t *testing.T) {
+	tests := []struct {
+		name           string
+		resources      []*v1.APIResourceList
+		expectedStatus checkStatus
+	}{
+		{
+			name:           "CRDs are not installed",
+			expectedStatus: statusFail,
+		},
+		{
+			name:           "RayService CRD is missing",
+			resources:      newRayDiscoveryResources("rayclusters", "rayjobs"),
+			expectedStatus: statusFail,
+		},
+		{
+			name:           "CRDs are installed",
+			resources:      newRayDiscoveryResources("rayclusters", "rayjobs", "rayservices"),
+			expectedStatus: statusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeClientSet := kubeFake.NewSimpleClientset()
+			kubeClientSet.Discovery().(*fakediscovery.FakeDiscovery).Resources = tc.resources
+			d := &doctor{k8sClient: client.NewClientForTesting(kubeClientSet, nil)}
+			result := d.checkCRDs(context.Background())
+			assert.Equal(t, tc.expectedStatus, result.status, result.message)
+		})
+	}
+}
+
+func TestCheckWebhooks(t *testing.T) {
+	newWebhookConfiguration := func(failurePolicy admissionregistrationv1.FailurePolicyType) *admissionregistrationv1.ValidatingWebhookConfiguration {
+		return &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: v1.ObjectMeta{Name: "validating-webhook-configuration"},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+				Name: "vraycluster.kb.io",
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service: &admissionregistrationv1.ServiceReference{Namespace: "kuberay-system", Name: "webhook-service"},
+				},
+				Rules: []admissionregistrationv1.RuleWithOperations{{
+					Rule: admissionregistrationv1.Rule{APIGroups: []string{"ray.io"}},
+				}},
+				FailurePolicy: &failurePolicy,
+			}},
+		}
+	}
+	readyEndpoints := &corev1.Endpoints{
+		ObjectMeta: v1.ObjectMeta{Namespace: "kuberay-system", Name: "webhook-service"},
+		Subsets:    []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}}},
+	}
+
+	tests := []struct {
+		name           string
+		objects        []runtime.Object
+		expectedStatus checkStatus
+	}{
+		{
+			name:           "no webhook",
+			expectedStatus: statusOK,
+		},
+		{
+			name:           "webhook without endpoints fails closed",
+			objects:        []runtime.Object{newWebhookConfiguration(admissionregistrationv1.Fail)},
+			expectedStatus: statusFail,
+		},
+		{
+			name:           "webhook without endpoints is ignored",
+			objects:        []runtime.Object{newWebhookConfiguration(admissionregistrationv1.Ignore)},
+			expectedStatus: statusWarn,
+		},
+		{
+			name:           "webhook with ready endpoints",
+			objects:        []runtime.Object{newWebhookConfiguration(admissionregistrationv1.Fail), readyEndpoints},
+			expectedStatus: statusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &doctor{k8sClient: client.NewClientForTesting(kubeFake.NewSimpleClientset(tc.objects...), nil)}
+			result := d.checkWebhooks(context.Background())
+			assert.Equal(t, tc.expectedStatus, result.status, result.message)
+		})
+	}
+}
+
+func newRayCluster(annotations map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "ray.io/v1",
+			"kind":       "RayCluster",
+			"metadata": map[string]interface{}{
+				"name":        "raycluster-sample",
+				"namespace":   "default",
+				"annotations": annotations,
+			},
+			"status": map[string]interface{}{
+				"state":                 "ready",
+				"readyWorkerReplicas":   int64(1),
+				"desiredWorkerReplicas": int64(1),
+				"head": map[string]interface{}{
+					"serviceName": "raycluster-sample-head-svc",
+				},
+			},
+		},
+	}
+}
+
+func newHeadPod(ready bool) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "raycluster-sample-head",
+			Namespace: "default",
+			Labels:    map[string]string{"ray.io/cluster": "raycluster-sample", "ray.io/node-type": "head"},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head"}}},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	if !ready {
+		pod.Status.Conditions[0].Status = corev1.ConditionFalse
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:  "ray-head",
+			Image: "rayproject/ray:nonexistent",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+		}}
+	}
+	return pod
+}
+
+func TestCheckRayCluster(t *testing.T) {
+	listRayNodes := func(_ context.Context, svcName string) ([]dashboard.Node, error) {
+		assert.Equal(t, "raycluster-sample-head-svc", svcName)
+		return []dashboard.Node{{Raylet: dashboard.Raylet{State: "ALIVE"}}, {Raylet: dashboard.Raylet{State: "DEAD"}}}, nil
+	}
+
+	tests := []struct {
+		name             string
+		rayCluster       *unstructured.Unstructured
+		headPod          *corev1.Pod
+		runInPodErr      error
+		expectedStatuses []checkStatus
+	}{
+		{
+			name:             "healthy RayCluster",
+			rayCluster:       newRayCluster(nil),
+			headPod:          newHeadPod(true),
+			expectedStatuses: []checkStatus{statusOK, statusOK, statusOK, statusSkip},
+		},
+		{
+			name:             "head Pod is not ready",
+			rayCluster:       newRayCluster(nil),
+			headPod:          newHeadPod(false),
+			expectedStatuses: []checkStatus{statusOK, statusFail, statusSkip, statusSkip},
+		},
+		{
+			name:             "Redis is reachable",
+			rayCluster:       newRayCluster(map[string]interface{}{"ray.io/ft-enabled": "true"}),
+			headPod:          newHeadPod(true),
+			expectedStatuses: []checkStatus{statusOK, statusOK, statusOK, statusOK},
+		},
+		{
+			name:             "Redis is not reachable",
+			rayCluster:       newRayCluster(map[string]interface{}{"ray.io/ft-enabled": "true"}),
+			headPod:          newHeadPod(true),
+			runInPodErr:      fmt.Errorf("command terminated with exit code 1"),
+			expectedStatuses: []checkStatus{statusOK, statusOK, statusOK, statusFail},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &doctor{
+				k8sClient:    client.NewClientForTesting(kubeFake.NewSimpleClientset(tc.headPod), dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), tc.rayCluster)),
+				namespace:    "default",
+				listRayNodes: listRayNodes,
+				runInPod: func(_ context.Context, pod *corev1.Pod, command []string) (string, error) {
+					assert.Equal(t, "raycluster-sample-head", pod.Name)
+					assert.Equal(t, redisConnectivityCommand, command)
+					return "", tc.runInPodErr
+				},
+			}
+			results := d.checkRayCluster(context.Background(), util.RayCluster, "raycluster-sample")
+			statuses := make([]checkStatus, 0, len(results))
+			for _, result := range results {
+				statuses = append(statuses, result.status)
+			}
+			assert.Equal(t, tc.expectedStatuses, statuses)
+		})
+	}
+}
+
+func TestCheckRayClusterNotFound(t *testing.T) {
+	d := &doctor{
+		k8sClient: client.NewClientForTesting(kubeFake.NewSimpleClientset(), dynamicFake.NewSimpleDynamicClient(runtime.NewScheme())),
+		namespace: "default",
+	}
+	results := d.checkRayCluster(context.Background(), util.RayCluster, "nonexistent")
+	assert.Len(t, results, 1)
+	assert.Equal(t, statusFail, results[0].status)
+}
+
+func TestPrintResults(t *testing.T) {
+	results := []checkResult{
+		{name: "KubeRay operator", status: statusOK, message: "ready", hint: "not printed"},
+		{name: "Head Pod", status: statusFail, message: "not ready", hint: "Inspect the Pod."},
+		{name: "GCS fault tolerance", status: statusSkip, message: "not enabled"},
+	}
+
+	var out bytes.Buffer
+	err := printResults(results, &out)
+	assert.Nil(t, err)
+
+	expected := `CHECK                 STATUS   MESSAGE
+KubeRay operator      OK       ready
+Head Pod              FAIL     not ready
+GCS fault tolerance   SKIP     not enabled
+
+Hints:
+  - Head Pod: Inspect the Pod.
+`
+	assert.Equal(t, expected, out.String())
+}
+
+func TestResultsOutput(t *testing.T) {
+	results := []checkResult{
+		{name: "KubeRay operator", status: statusOK, message: "ready"},
+		{name: "Head Pod", status: statusFail, message: "not ready", hint: "Inspect the Pod."},
+	}
+
+	outputFlags := printer.NewOutputFlags(printer.JSON, printer.YAML)
+	outputFlags.Format = printer.JSON
+	var out bytes.Buffer
+	err := outputFlags.PrintData(resultsOutput(results), &out)
+	assert.Nil(t, err)
+
+	expected := `[
+	{"name": "KubeRay operator", "status": "OK", "message": "ready"},
+	{"name": "Head Pod", "status": "FAIL", "message": "not ready", "hint": "Inspect the Pod."}
+]`
+	assert.JSONEq(t, expected, out.String())
+}
@@ -0,0 +1,219 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdexec "k8s.io/kubectl/pkg/cmd/exec"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/completion"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/selector"
+)
+
+// defaultShell is started when no command is given
+var defaultShell = []string{"/bin/bash"}
+
+type ExecOptions struct {
+	configFlags    *genericclioptions.ConfigFlags
+	ioStreams      *genericiooptions.IOStreams
+	ResourceType   util.ResourceType
+	ResourceName   string
+	Namespace      string
+	workerGroup    string
+	podName        string
+	containerName  string
+	command        []string
+	stdin          bool
+	tty            bool
+	nonInteractive bool
+}
+
+var (
+	execLong = templates.LongDesc(`
+		Execute a command in a Pod of a RayCluster, or of the RayCluster used by a RayJob or RayService.
+
+		The head Pod is used by default. Use '--worker-group' to use a running Pod of a worker group instead, or '--pod' to pick a specific Pod of the RayCluster.
+		Without a command, an interactive shell is started.
+		Without a resource, the only RayCluster of the namespace is used. When there are several, one can be selected
+		interactively on a terminal, unless '--non-interactive' is set.
+	`)
+
+	execExample = templates.Examples(`
+		# Open an interactive shell in the head Pod of the RayCluster
+		kubectl ray exec my-raycluster
+
+		# Run 'ray status' in the head Pod of the only RayCluster of the namespace, or select one when there are several
+		kubectl ray exec -- ray status
+
+		# Run 'ray status' in the head Pod of the RayCluster used by the RayJob
+		kubectl ray exec rayjob/my-rayjob -- ray status
+
+		# Open an interactive shell in a Pod of the worker group 'gpu-group'
+		kubectl ray exec my-raycluster --worker-group gpu-group
+
+		# Run a command in a specific Pod of the RayCluster
+		kubectl ray exec my-raycluster --pod my-raycluster-gpu-group-worker-abcde -- nvidia-smi
+	`)
+)
+
+func NewExecOptions(streams genericiooptions.IOStreams) *ExecOptions {
+	return &ExecOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+	}
+}
+
+func NewExecCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	options := NewExecOptions(streams)
+	factory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:               "exec [RAYCLUSTER | TYPE/NAME] [--worker-group GROUP | --pod POD] [-c CONTAINER] [-i] [-t] [-- COMMAND [args...]]",
+		Short:             "Execute a command in a Pod of a Ray resource",
+		Long:              execLong,
+		Example:           execExample,
+		SilenceUsage:      true,
+		ValidArgsFunction: completion.RayClusterResourceNameCompletionFunc(factory),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), factory)
+		},
+	}
+	cmd.Flags().StringVar(&options.workerGroup, "worker-group", options.workerGroup, "Name of the worker group whose Pod to use instead of the head Pod")
+	cmd.Flags().StringVar(&options.podName, "pod", options.podName, "Name of the Pod of the RayCluster to use instead of the head Pod")
+	cmd.Flags().StringVarP(&options.containerName, "container", "c", options.containerName, "Container name. If omitted, the first container in the Pod will be chosen")
+	cmd.Flags().BoolVarP(&options.stdin, "stdin", "i", options.stdin, "Pass stdin to the container. Always set when no command is given")
+	cmd.Flags().BoolVarP(&options.tty, "tty", "t", options.tty, "Stdin is a TTY. Always set when no command is given")
+	cmd.Flags().BoolVar(&options.nonInteractive, "non-interactive", options.nonInteractive, "If present, fail instead of prompting for the RayCluster when none is given and the namespace has several")
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *ExecOptions) Complete(cmd *cobra.Command, args []string) error {
+	argsLenAtDash := cmd.ArgsLenAtDash()
+	resourceArgs := args
+	if argsLenAtDash > -1 {
+		resourceArgs = args[:argsLenAtDash]
+		options.command = args[argsLenAtDash:]
+	}
+	if len(resourceArgs) > 1 {
+		return cmdutil.UsageErrorf(cmd, "%s", cmd.Use)
+	}
+
+	// Without a resource, the RayCluster is selected in Run
+	options.ResourceType, options.ResourceName = util.RayCluster, ""
+	if len(resourceArgs) == 1 {
+		resourceType, resourceName, err := util.ParseRayResource(resourceArgs[0])
+		if err != nil {
+			return cmdutil.UsageErrorf(cmd, "%s", err.Error())
+		}
+		options.ResourceType = resourceType
+		options.ResourceName = resourceName
+	}
+
+	if *options.configFlags.Namespace == "" {
+		options.Namespace = "default"
+	} else {
+		options.Namespace = *options.configFlags.Namespace
+	}
+
+	if len(options.command) == 0 {
+		options.command = defaultShell
+		options.stdin = true
+		options.tty = true
+	}
+	return nil
+}
+
+func (options *ExecOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	if options.workerGroup != "" && options.podName != "" {
+		return fmt.Errorf("--worker-group and --pod cannot be used together")
+	}
+	return nil
+}
+
+func (options *ExecOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	k8sClient, err := client.NewClient(factory)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	if options.ResourceName == "" {
+		options.ResourceName, err = selector.RayCluster(ctx, k8sClient.DynamicClient(), *options.ioStreams, options.Namespace, options.nonInteractive)
+		if err != nil {
+			return err
+		}
+	}
+
+	podName, err := options.resolvePodName(ctx, k8sClient)
+	if err != nil {
+		return err
+	}
+
+	restConfig, err := factory.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get restconfig: %w", err)
+	}
+
+	execOptions := &cmdexec.ExecOptions{
+		StreamOptions: cmdexec.StreamOptions{
+			IOStreams:     *options.ioStreams,
+			Namespace:     options.Namespace,
+			PodName:       podName,
+			ContainerName: options.containerName,
+			Stdin:         options.stdin,
+			TTY:           options.tty,
+		},
+		Command:   options.command,
+		Executor:  &cmdexec.DefaultRemoteExecutor{},
+		PodClient: k8sClient.KubernetesClient().CoreV1(),
+		Config:    restConfig,
+	}
+	return execOptions.Run()
+}
+
+// resolvePodName returns the name of the Pod to execute the command in
+func (options *ExecOptions) resolvePodName(ctx context.Context, k8sClient client.Client) (string, error) {
+	clusterName, err := k8sClient.GetRayClusterName(ctx, options.Namespace, options.ResourceType, options.ResourceName)
+	if err != nil {
+		return "", err
+	}
+
+	if options.podName != "" {
+		pod, err := k8sClient.KubernetesClient().CoreV1().Pods(options.Namespace).Get(ctx, options.podName, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("unable to find Pod %s: %w", options.podName, err)
+		}
+		if pod.Labels["ray.io/cluster"] != clusterName {
+			return "", fmt.Errorf("Pod %s does not belong to RayCluster %s", options.podName, clusterName)
+		}
+		return pod.Name, nil
+	}
+
+	pod, err := k8sClient.GetRayPod(ctx, options.Namespace, clusterName, options.workerGroup)
+	if err != nil {
+		return "", err
+	}
+	return pod.Name, nil
+}
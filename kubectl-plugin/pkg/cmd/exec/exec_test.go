@@ -0,0 +1,175 @@
+package exec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+)
+
+func TestComplete(t *testing.T) {
+	tests := []struct {
+		name                 string
+		namespace            string
+		expectedResourceType util.ResourceType
+		expectedNamespace    string
+		expectedName         string
+		args                 []string
+		expectedCommand      []string
+		expectedTTY          bool
+		hasErr               bool
+	}{
+		{
+			name:                 "shell in raycluster without namespace",
+			args:                 []string{"test-raycluster"},
+			expectedResourceType: util.RayCluster,
+			expectedNamespace:    "default",
+			expectedName:         "test-raycluster",
+			expectedCommand:      []string{"/bin/bash"},
+			expectedTTY:          true,
+		},
+		{
+			name:                 "command in rayjob with namespace",
+			namespace:            "test-namespace",
+			args:                 []string{"rayjob/test-rayjob", "--", "ray", "status"},
+			expectedResourceType: util.RayJob,
+			expectedNamespace:    "test-namespace",
+			expectedName:         "test-rayjob",
+			expectedCommand:      []string{"ray", "status"},
+		},
+		{
+			name:                 "command without resource selects a raycluster later",
+			args:                 []string{"--", "ray", "status"},
+			expectedResourceType: util.RayCluster,
+			expectedNamespace:    "default",
+			expectedName:         "",
+			expectedCommand:      []string{"ray", "status"},
+		},
+		{
+			name:   "invalid args (too many resources)",
+			args:   []string{"test-raycluster", "other-raycluster", "--", "ray", "status"},
+			hasErr: true,
+		},
+		{
+			name:   "invalid args (command without dash)",
+			args:   []string{"test-raycluster", "ray", "status"},
+			hasErr: true,
+		},
+		{
+			name:   "invalid args (invalid resource type)",
+			args:   []string{"invalid-type/test-resource"},
+			hasErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "exec"}
+			assert.Nil(t, cmd.ParseFlags(tc.args))
+
+			testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+			fakeExecOptions := NewExecOptions(testStreams)
+			fakeExecOptions.configFlags.Namespace = &tc.namespace
+			err := fakeExecOptions.Complete(cmd, cmd.Flags().Args())
+			if tc.hasErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedNamespace, fakeExecOptions.Namespace)
+			assert.Equal(t, tc.expectedResourceType, fakeExecOptions.ResourceType)
+			assert.Equal(t, tc.expectedName, fakeExecOptions.ResourceName)
+			assert.Equal(t, tc.expectedCommand, fakeExecOptions.command)
+			assert.Equal(t, tc.expectedTTY, fakeExecOptions.tty)
+			assert.Equal(t, tc.expectedTTY, fakeExecOptions.stdin)
+		})
+	}
+}
+
+func TestResolvePodName(t *testing.T) {
+	kubeClientSet := kubeFake.NewSimpleClientset(
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-raycluster-head-xxxxx",
+				Namespace: "default",
+				Labels:    map[string]string{"ray.io/cluster": "test-raycluster", "ray.io/node-type": "head"},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-raycluster-gpu-group-worker-xxxxx",
+				Namespace: "default",
+				Labels:    map[string]string{"ray.io/cluster": "test-raycluster", "ray.io/node-type": "worker", "ray.io/group": "gpu-group"},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-raycluster-head-xxxxx",
+				Namespace: "default",
+				Labels:    map[string]string{"ray.io/cluster": "other-raycluster", "ray.io/node-type": "head"},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	)
+	k8sClient := client.NewClientForTesting(kubeClientSet, dynamicFake.NewSimpleDynamicClient(runtime.NewScheme()))
+
+	tests := []struct {
+		name            string
+		workerGroup     string
+		podName         string
+		expectedPodName string
+		hasErr          bool
+	}{
+		{
+			name:            "head Pod by default",
+			expectedPodName: "test-raycluster-head-xxxxx",
+		},
+		{
+			name:            "worker group Pod",
+			workerGroup:     "gpu-group",
+			expectedPodName: "test-raycluster-gpu-group-worker-xxxxx",
+		},
+		{
+			name:            "specific Pod",
+			podName:         "test-raycluster-gpu-group-worker-xxxxx",
+			expectedPodName: "test-raycluster-gpu-group-worker-xxxxx",
+		},
+		{
+			name:    "Pod of another RayCluster",
+			podName: "other-raycluster-head-xxxxx",
+			hasErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+			fakeExecOptions := NewExecOptions(testStreams)
+			fakeExecOptions.ResourceType = util.RayCluster
+			fakeExecOptions.ResourceName = "test-raycluster"
+			fakeExecOptions.Namespace = "default"
+			fakeExecOptions.workerGroup = tc.workerGroup
+			fakeExecOptions.podName = tc.podName
+
+			podName, err := fakeExecOptions.resolvePodName(context.Background(), k8sClient)
+			if tc.hasErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedPodName, podName)
+		})
+	}
+}
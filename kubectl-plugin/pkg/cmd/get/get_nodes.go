@@ -0,0 +1,258 @@
+package get
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/completion"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/config"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/dashboard"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/printer"
+)
+
+const portforwardReadyTimeout = 60 * time.Second
+
+// nodeWithPod is a Ray node reported by the Ray dashboard together with the Pod it runs in
+type nodeWithPod struct {
+	dashboard.Node
+	Pod string `json:"pod"`
+}
+
+type GetNodesOptions struct {
+	configFlags        *genericclioptions.ConfigFlags
+	outputFlags        *printer.OutputFlags
+	ioStreams          *genericiooptions.IOStreams
+	ResourceType       util.ResourceType
+	ResourceName       string
+	Namespace          string
+	localDashboardPort int
+}
+
+var (
+	getNodesLong = templates.LongDesc(`
+		Display the Ray nodes of a RayCluster, or of the RayCluster used by a RayJob or RayService, as reported by the Ray dashboard.
+
+		Each Ray node is mapped to the Pod it runs in, which helps to debug scheduling issues.
+	`)
+
+	getNodesExample = templates.Examples(`
+		# Display the Ray nodes of the RayCluster
+		kubectl ray get nodes my-raycluster
+
+		# Display the Ray nodes of the RayCluster used by the RayJob
+		kubectl ray get nodes rayjob/my-rayjob
+
+		# Output the Ray nodes of the RayCluster as JSON
+		kubectl ray get nodes my-raycluster -o json
+	`)
+)
+
+func NewGetNodesOptions(streams genericiooptions.IOStreams) *GetNodesOptions {
+	return &GetNodesOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		outputFlags: printer.NewOutputFlags(printer.JSON, printer.YAML),
+		ioStreams:   &streams,
+	}
+}
+
+func NewGetNodesCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	options := NewGetNodesOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:               "nodes (RAYCLUSTER | TYPE/NAME)",
+		Short:             "Display the Ray nodes of a Ray resource",
+		Long:              getNodesLong,
+		Example:           getNodesExample,
+		Aliases:           []string{"node"},
+		SilenceUsage:      true,
+		ValidArgsFunction: completion.RayClusterResourceNameCompletionFunc(cmdFactory),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	cmd.Flags().IntVar(&options.localDashboardPort, "dashboard-port", options.localDashboardPort, "Local port used to forward the Ray dashboard. If not set, a free local port is selected automatically")
+	config.BindFlag(cmd.Flags(), "dashboard-port", config.DashboardPort)
+	options.outputFlags.AddFlags(cmd)
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *GetNodesOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return cmdutil.UsageErrorf(cmd, "%s", cmd.Use)
+	}
+
+	resourceType, resourceName, err := util.ParseRayResource(args[0])
+	if err != nil {
+		return cmdutil.UsageErrorf(cmd, "%s", err.Error())
+	}
+	options.ResourceType = resourceType
+	options.ResourceName = resourceName
+
+	if *options.configFlags.Namespace == "" {
+		options.Namespace = "default"
+	} else {
+		options.Namespace = *options.configFlags.Namespace
+	}
+
+	if options.localDashboardPort == 0 {
+		freePort, err := util.GetFreeLocalPort()
+		if err != nil {
+			return fmt.Errorf("failed to find a free local port for the Ray dashboard: %w", err)
+		}
+		options.localDashboardPort = freePort
+	}
+	return nil
+}
+
+func (options *GetNodesOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	if options.localDashboardPort < 0 || options.localDashboardPort > 65535 {
+		return fmt.Errorf("dashboard port %d is out of range, must be between 0 and 65535", options.localDashboardPort)
+	}
+	return options.outputFlags.Validate()
+}
+
+func (options *GetNodesOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	k8sClient, err := client.NewClient(factory)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	clusterName, err := k8sClient.GetRayClusterName(ctx, options.Namespace, options.ResourceType, options.ResourceName)
+	if err != nil {
+		return err
+	}
+	svcName, err := k8sClient.GetRayHeadSvcName(ctx, options.Namespace, options.ResourceType, options.ResourceName)
+	if err != nil {
+		return err
+	}
+
+	pods, err := k8sClient.KubernetesClient().CoreV1().Pods(options.Namespace).List(ctx, v1.ListOptions{
+		LabelSelector: fmt.Sprintf("ray.io/cluster=%s", clusterName),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to list Pods of RayCluster %s: %w", clusterName, err)
+	}
+	podNamesByIP := make(map[string]string, len(pods.Items))
+	for _, pod := range pods.Items {
+		if pod.Status.PodIP != "" {
+			podNamesByIP[pod.Status.PodIP] = pod.Name
+		}
+	}
+
+	portforwardctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	portforwardStreams := *options.ioStreams
+	if options.outputFlags.IsStructured() {
+		// Keep the port forwarding messages out of the machine-readable output
+		portforwardStreams.Out = options.ioStreams.ErrOut
+	}
+	if err := dashboard.PortForward(portforwardctx, factory, portforwardStreams, svcName, options.localDashboardPort, portforwardReadyTimeout, nil); err != nil {
+		return err
+	}
+
+	nodes, err := dashboard.ListNodes(ctx, dashboard.Address(options.localDashboardPort))
+	if err != nil {
+		return err
+	}
+	if options.outputFlags.IsStructured() {
+		return options.outputFlags.PrintData(nodesWithPods(nodes, podNamesByIP), options.ioStreams.Out)
+	}
+	return printNodes(nodes, podNamesByIP, options.ioStreams.Out)
+}
+
+// nodesWithPods maps each Ray node to the Pod it runs in. The Pod is empty if it is unknown.
+func nodesWithPods(nodes []dashboard.Node, podNamesByIP map[string]string) []nodeWithPod {
+	result := make([]nodeWithPod, 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, nodeWithPod{Node: node, Pod: podNamesByIP[nodeIP(node)]})
+	}
+	return result
+}
+
+// nodeIP returns the IP of the Ray node, preferring the address of the raylet
+func nodeIP(node dashboard.Node) string {
+	if node.Raylet.NodeManagerAddress != "" {
+		return node.Raylet.NodeManagerAddress
+	}
+	return node.IP
+}
+
+func printNodes(nodes []dashboard.Node, podNamesByIP map[string]string, output io.Writer) error {
+	resultTablePrinter := printers.NewTablePrinter(printers.PrintOptions{})
+
+	resTable := &v1.Table{
+		ColumnDefinitions: []v1.TableColumnDefinition{
+			{Name: "Node ID", Type: "string"},
+			{Name: "State", Type: "string"},
+			{Name: "Head", Type: "boolean"},
+			{Name: "IP", Type: "string"},
+			{Name: "Pod", Type: "string"},
+			{Name: "CPUs", Type: "string"},
+			{Name: "GPUs", Type: "string"},
+		},
+	}
+
+	for _, node := range nodes {
+		ip := nodeIP(node)
+		podName, ok := podNamesByIP[ip]
+		if !ok {
+			podName = "<unknown>"
+		}
+		resTable.Rows = append(resTable.Rows, v1.TableRow{
+			Cells: []interface{}{
+				node.Raylet.NodeID,
+				node.Raylet.State,
+				node.Raylet.IsHeadNode,
+				ip,
+				podName,
+				formatResourceUsage(node.Raylet, "CPU"),
+				formatResourceUsage(node.Raylet, "GPU"),
+			},
+		})
+	}
+
+	return resultTablePrinter.PrintObj(resTable, output)
+}
+
+// formatResourceUsage returns the used and total amount of a Ray resource as "USED/TOTAL", or only the total
+// when the available resources are not reported. Ray omits fully used resources from the available resources.
+func formatResourceUsage(raylet dashboard.Raylet, resource string) string {
+	total := raylet.ResourcesTotal[resource]
+	if raylet.ResourcesAvailable == nil {
+		return formatFloat(total)
+	}
+	return fmt.Sprintf("%s/%s", formatFloat(total-raylet.ResourcesAvailable[resource]), formatFloat(total))
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
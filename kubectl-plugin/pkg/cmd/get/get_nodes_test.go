@@ -0,0 +1,119 @@
+package get
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/dashboard"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/printer"
+)
+
+func TestRayGetNodesComplete(t *testing.T) {
+	cmd := &cobra.Command{Use: "nodes"}
+	testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+
+	fakeGetNodesOptions := NewGetNodesOptions(testStreams)
+	fakeGetNodesOptions.localDashboardPort = 18265
+
+	err := fakeGetNodesOptions.Complete(cmd, []string{"rayjob/test-rayjob"})
+	assert.Nil(t, err)
+	assert.Equal(t, util.RayJob, fakeGetNodesOptions.ResourceType)
+	assert.Equal(t, "test-rayjob", fakeGetNodesOptions.ResourceName)
+	assert.Equal(t, "default", fakeGetNodesOptions.Namespace)
+	assert.Equal(t, 18265, fakeGetNodesOptions.localDashboardPort)
+
+	err = fakeGetNodesOptions.Complete(cmd, []string{})
+	assert.NotNil(t, err)
+}
+
+func TestPrintNodes(t *testing.T) {
+	nodes := []dashboard.Node{
+		{
+			IP: "10.0.0.1",
+			Raylet: dashboard.Raylet{
+				NodeID:             "head-node-id",
+				State:              "ALIVE",
+				NodeManagerAddress: "10.0.0.1",
+				IsHeadNode:         true,
+				ResourcesTotal:     map[string]float64{"CPU": 2},
+				ResourcesAvailable: map[string]float64{"CPU": 1.5},
+			},
+		},
+		{
+			IP: "10.0.0.2",
+			Raylet: dashboard.Raylet{
+				NodeID:             "worker-node-id",
+				State:              "ALIVE",
+				NodeManagerAddress: "10.0.0.2",
+				ResourcesTotal:     map[string]float64{"CPU": 4, "GPU": 1},
+				// Fully used resources are not reported as available
+				ResourcesAvailable: map[string]float64{"CPU": 4},
+			},
+		},
+		{
+			IP: "10.0.0.3",
+			Raylet: dashboard.Raylet{
+				NodeID:         "dead-node-id",
+				State:          "DEAD",
+				ResourcesTotal: map[string]float64{"CPU": 4},
+			},
+		},
+	}
+	podNamesByIP := map[string]string{
+		"10.0.0.1": "raycluster-head-xxxxx",
+		"10.0.0.2": "raycluster-gpu-group-worker-xxxxx",
+	}
+
+	var out bytes.Buffer
+	err := printNodes(nodes, podNamesByIP, &out)
+	assert.Nil(t, err)
+
+	expected := `NODE ID          STATE   HEAD    IP         POD                                 CPUS    GPUS
+head-node-id     ALIVE   true    10.0.0.1   raycluster-head-xxxxx               0.5/2   0/0
+worker-node-id   ALIVE   false   10.0.0.2   raycluster-gpu-group-worker-xxxxx   0/4     1/1
+dead-node-id     DEAD    false   10.0.0.3   <unknown>                           4       0
+`
+	assert.Equal(t, expected, out.String())
+}
+
+func TestNodesWithPodsStructuredOutput(t *testing.T) {
+	nodes := []dashboard.Node{
+		{IP: "10.0.0.1", Raylet: dashboard.Raylet{NodeID: "head-node-id", State: "ALIVE", NodeManagerAddress: "10.0.0.1", IsHeadNode: true}},
+		{IP: "10.0.0.3", Raylet: dashboard.Raylet{NodeID: "dead-node-id", State: "DEAD"}},
+	}
+	podNamesByIP := map[string]string{"10.0.0.1": "raycluster-head-xxxxx"}
+
+	outputFlags := printer.NewOutputFlags(printer.JSON, printer.YAML)
+	outputFlags.Format = printer.YAML
+	var out bytes.Buffer
+	err := outputFlags.PrintData(nodesWithPods(nodes, podNamesByIP), &out)
+	assert.Nil(t, err)
+
+	expected := `- hostname: ""
+  ip: 10.0.0.1
+  pod: raycluster-head-xxxxx
+  raylet:
+    isHeadNode: true
+    nodeId: head-node-id
+    nodeManagerAddress: 10.0.0.1
+    resourcesAvailable: null
+    resourcesTotal: null
+    state: ALIVE
+- hostname: ""
+  ip: 10.0.0.3
+  pod: ""
+  raylet:
+    isHeadNode: false
+    nodeId: dead-node-id
+    nodeManagerAddress: ""
+    resourcesAvailable: null
+    resourcesTotal: null
+    state: DEAD
+`
+	assert.Equal(t, expected, out.String())
+}
@@ -8,7 +8,7 @@ import (
 func NewJobCommand(streams genericclioptions.IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "job",
-		Short:        "submit ray job",
+		Short:        "Manage ray jobs",
 		SilenceUsage: true,
 		Run: func(cmd *cobra.Command, args []string) {
 			cmd.HelpFunc()(cmd, args)
@@ -16,5 +16,10 @@ func NewJobCommand(streams genericclioptions.IOStreams) *cobra.Command {
 	}
 
 	cmd.AddCommand(NewJobSubmitCommand(streams))
+	cmd.AddCommand(NewJobResubmitCommand(streams))
+	cmd.AddCommand(NewJobLogsCommand(streams))
+	cmd.AddCommand(NewJobStopCommand(streams))
+	cmd.AddCommand(NewJobDeleteCommand(streams))
+	cmd.AddCommand(NewJobListCommand(streams))
 	return cmd
 }
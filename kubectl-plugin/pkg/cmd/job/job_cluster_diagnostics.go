@@ -0,0 +1,199 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/progress"
+)
+
+const (
+	// maxSummaryEvents is the number of most recent warning events included in the error when a RayCluster is not ready
+	maxSummaryEvents = 10
+	// diagnosticsTimeout bounds the collection of the diagnostics once the wait for the RayCluster has timed out
+	diagnosticsTimeout = 10 * time.Second
+)
+
+// rayPodState is the state of a pod of a RayCluster that is not ready yet
+type rayPodState struct {
+	name  string
+	group string
+	phase corev1.PodPhase
+	// reason explains why the pod is not ready, e.g. Unschedulable, ImagePullBackOff or CrashLoopBackOff
+	reason string
+	ready  bool
+}
+
+func (s rayPodState) String() string {
+	switch {
+	case s.ready:
+		return fmt.Sprintf("%s, ready", s.phase)
+	case s.reason != "":
+		return fmt.Sprintf("%s, %s", s.phase, s.reason)
+	case s.phase == corev1.PodRunning:
+		return fmt.Sprintf("%s, not ready", s.phase)
+	default:
+		return string(s.phase)
+	}
+}
+
+// rayClusterDiagnostics is a snapshot of the pods of a RayCluster and of the warning events of the RayCluster and its pods
+type rayClusterDiagnostics struct {
+	clusterName string
+	pods        []rayPodState
+	// events are the warning events sorted from the oldest to the most recent
+	events []corev1.Event
+}
+
+// getRayClusterDiagnostics returns the state of the pods of the RayCluster and the warning events of the RayCluster and its pods
+func getRayClusterDiagnostics(ctx context.Context, k8sClients client.Client, namespace, clusterName string) (*rayClusterDiagnostics, error) {
+	pods, err := k8sClients.KubernetesClient().CoreV1().Pods(namespace).List(ctx, v1.ListOptions{LabelSelector: fmt.Sprintf("ray.io/cluster=%s", clusterName)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the pods of RayCluster %s: %w", clusterName, err)
+	}
+	events, err := k8sClients.KubernetesClient().CoreV1().Events(namespace).List(ctx, v1.ListOptions{FieldSelector: "type=" + corev1.EventTypeWarning})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the events of RayCluster %s: %w", clusterName, err)
+	}
+
+	diagnostics := &rayClusterDiagnostics{clusterName: clusterName}
+	podNames := map[string]bool{}
+	for _, pod := range pods.Items {
+		podNames[pod.Name] = true
+		diagnostics.pods = append(diagnostics.pods, newRayPodState(pod))
+	}
+	sort.Slice(diagnostics.pods, func(i, j int) bool {
+		return diagnostics.pods[i].name < diagnostics.pods[j].name
+	})
+
+	for _, event := range events.Items {
+		if event.Type != corev1.EventTypeWarning {
+			continue
+		}
+		involved := event.InvolvedObject
+		if (involved.Kind == "Pod" && podNames[involved.Name]) || (involved.Kind == "RayCluster" && involved.Name == clusterName) {
+			diagnostics.events = append(diagnostics.events, event)
+		}
+	}
+	sort.SliceStable(diagnostics.events, func(i, j int) bool {
+		return eventTime(diagnostics.events[i]).Before(eventTime(diagnostics.events[j]))
+	})
+	return diagnostics, nil
+}
+
+func newRayPodState(pod corev1.Pod) rayPodState {
+	state := rayPodState{name: pod.Name, group: pod.Labels["ray.io/group"], phase: pod.Status.Phase}
+	for _, condition := range pod.Status.Conditions {
+		switch {
+		case condition.Type == corev1.PodReady:
+			state.ready = condition.Status == corev1.ConditionTrue
+		case condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse:
+			state.reason = condition.Reason
+		}
+	}
+	for _, containerStatus := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if waiting := containerStatus.State.Waiting; waiting != nil && waiting.Reason != "" {
+			state.reason = waiting.Reason
+			break
+		}
+		if terminated := containerStatus.State.Terminated; terminated != nil && terminated.ExitCode != 0 {
+			state.reason = terminated.Reason
+			break
+		}
+	}
+	return state
+}
+
+// eventTime returns when the event was last seen
+func eventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// eventDescription returns the object, reason and message of the event, which identify it across repetitions
+func eventDescription(event corev1.Event) string {
+	return fmt.Sprintf("%s %s: %s: %s", event.InvolvedObject.Kind, event.InvolvedObject.Name, event.Reason, strings.TrimSpace(event.Message))
+}
+
+// summary describes why the RayCluster is not ready, with the state of its pods and its most recent warning events
+func (d *rayClusterDiagnostics) summary() string {
+	var lines []string
+	if len(d.pods) == 0 {
+		lines = append(lines, fmt.Sprintf("RayCluster %s has no pods", d.clusterName))
+	} else {
+		lines = append(lines, fmt.Sprintf("Pods of RayCluster %s:", d.clusterName))
+		for _, pod := range d.pods {
+			lines = append(lines, fmt.Sprintf("  %s (%s): %s", pod.name, pod.group, pod))
+		}
+	}
+
+	// The most recent occurrence of each event is kept
+	var descriptions []string
+	seen := map[string]bool{}
+	for i := len(d.events) - 1; i >= 0 && len(descriptions) < maxSummaryEvents; i-- {
+		description := eventDescription(d.events[i])
+		if !seen[description] {
+			seen[description] = true
+			descriptions = append(descriptions, description)
+		}
+	}
+	if len(descriptions) > 0 {
+		lines = append(lines, "Recent warning events:")
+		for i := len(descriptions) - 1; i >= 0; i-- {
+			lines = append(lines, "  "+descriptions[i])
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// withRayClusterDiagnostics adds the summary of the diagnostics of the RayCluster to the error of the wait for it.
+// The diagnostics are collected with their own timeout, since the wait usually failed because ctx expired.
+func withRayClusterDiagnostics(ctx context.Context, err error, k8sClients client.Client, namespace, clusterName string) error {
+	diagnosticsCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), diagnosticsTimeout)
+	defer cancel()
+	diagnostics, diagnosticsErr := getRayClusterDiagnostics(diagnosticsCtx, k8sClients, namespace, clusterName)
+	if diagnosticsErr != nil {
+		return err
+	}
+	return fmt.Errorf("%w\n%s", err, diagnostics.summary())
+}
+
+// reportRayClusterProgress reports the state changes of the pods of the RayCluster and each new warning event of the
+// RayCluster and its pods until ctx is done. The reporting is best effort, so errors are ignored.
+func reportRayClusterProgress(ctx context.Context, k8sClients client.Client, reporter *progress.Reporter, namespace, clusterName string) {
+	podStates := map[string]string{}
+	reported := map[string]bool{}
+	_ = wait.PollUntilContextCancel(ctx, pendingPodsReportInterval, true, func(ctx context.Context) (bool, error) {
+		diagnostics, err := getRayClusterDiagnostics(ctx, k8sClients, namespace, clusterName)
+		if err != nil {
+			return false, nil
+		}
+		for _, pod := range diagnostics.pods {
+			if state := pod.String(); podStates[pod.name] != state {
+				podStates[pod.name] = state
+				reporter.Info("Pod %s (%s) is %s", pod.name, pod.group, state)
+			}
+		}
+		for _, event := range diagnostics.events {
+			if description := eventDescription(event); !reported[description] {
+				reported[description] = true
+				reporter.Info("%s", description)
+			}
+		}
+		return false, nil
+	})
+}
@@ -0,0 +1,120 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/progress"
+)
+
+func newDiagnosticsTestPod(name, group string, status corev1.PodStatus) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{"ray.io/cluster": "raycluster-sample", "ray.io/group": group},
+		},
+		Status: status,
+	}
+}
+
+func newDiagnosticsTestEvent(name, kind, objectName, eventType, reason, message string, lastSeen time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{Kind: kind, Name: objectName, Namespace: "default"},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        message,
+		LastTimestamp:  metav1.NewTime(lastSeen),
+	}
+}
+
+func newDiagnosticsTestClients() client.Client {
+	now := time.Now()
+	kubeClientSet := kubeFake.NewSimpleClientset(
+		newDiagnosticsTestPod("raycluster-sample-head", "headgroup", corev1.PodStatus{
+			Phase: corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "ray-head", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}},
+			},
+		}),
+		newDiagnosticsTestPod("raycluster-sample-gpu-worker-abcde", "gpu", corev1.PodStatus{
+			Phase:      corev1.PodPending,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: "Unschedulable"}},
+		}),
+		newDiagnosticsTestEvent("pull", "Pod", "raycluster-sample-head", corev1.EventTypeWarning, "Failed", "Failed to pull image \"rayproject/ray:nope\"", now.Add(-time.Minute)),
+		// The same event is reported once
+		newDiagnosticsTestEvent("pull-again", "Pod", "raycluster-sample-head", corev1.EventTypeWarning, "Failed", "Failed to pull image \"rayproject/ray:nope\"", now),
+		newDiagnosticsTestEvent("scheduling", "Pod", "raycluster-sample-gpu-worker-abcde", corev1.EventTypeWarning, "FailedScheduling", "0/3 nodes are available: 3 Insufficient nvidia.com/gpu.", now.Add(-2*time.Minute)),
+		newDiagnosticsTestEvent("scheduled", "Pod", "raycluster-sample-head", corev1.EventTypeNormal, "Scheduled", "Successfully assigned", now),
+		// Events of other objects are ignored
+		newDiagnosticsTestEvent("other", "Pod", "other-pod", corev1.EventTypeWarning, "BackOff", "Back-off restarting failed container", now),
+	)
+	return client.NewClientForTesting(kubeClientSet, dynamicFake.NewSimpleDynamicClient(runtime.NewScheme()))
+}
+
+func TestRayClusterDiagnosticsSummary(t *testing.T) {
+	diagnostics, err := getRayClusterDiagnostics(context.Background(), newDiagnosticsTestClients(), "default", "raycluster-sample")
+	assert.Nil(t, err)
+	assert.Equal(t, "Pods of RayCluster raycluster-sample:\n"+
+		"  raycluster-sample-gpu-worker-abcde (gpu): Pending, Unschedulable\n"+
+		"  raycluster-sample-head (headgroup): Pending, ImagePullBackOff\n"+
+		"Recent warning events:\n"+
+		"  Pod raycluster-sample-gpu-worker-abcde: FailedScheduling: 0/3 nodes are available: 3 Insufficient nvidia.com/gpu.\n"+
+		"  Pod raycluster-sample-head: Failed: Failed to pull image \"rayproject/ray:nope\"", diagnostics.summary())
+
+	emptyClients := client.NewClientForTesting(kubeFake.NewSimpleClientset(), dynamicFake.NewSimpleDynamicClient(runtime.NewScheme()))
+	diagnostics, err = getRayClusterDiagnostics(context.Background(), emptyClients, "default", "raycluster-sample")
+	assert.Nil(t, err)
+	assert.Equal(t, "RayCluster raycluster-sample has no pods", diagnostics.summary())
+}
+
+func TestRayPodState(t *testing.T) {
+	assert.Equal(t, "Running, ready", newRayPodState(*newDiagnosticsTestPod("head", "headgroup", corev1.PodStatus{
+		Phase:      corev1.PodRunning,
+		Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+	})).String())
+	assert.Equal(t, "Running, not ready", newRayPodState(*newDiagnosticsTestPod("head", "headgroup", corev1.PodStatus{
+		Phase:      corev1.PodRunning,
+		Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}},
+	})).String())
+	assert.Equal(t, "Running, CrashLoopBackOff", newRayPodState(*newDiagnosticsTestPod("head", "headgroup", corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		ContainerStatuses: []corev1.ContainerStatus{
+			{Name: "ray-head", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+		},
+	})).String())
+	assert.Equal(t, "Pending", newRayPodState(*newDiagnosticsTestPod("head", "headgroup", corev1.PodStatus{Phase: corev1.PodPending})).String())
+}
+
+func TestWithRayClusterDiagnostics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	// The diagnostics are collected even though the wait for the RayCluster is over
+	cancel()
+	err := withRayClusterDiagnostics(ctx, errors.New("timed out waiting for the condition"), newDiagnosticsTestClients(), "default", "raycluster-sample")
+	assert.ErrorContains(t, err, "timed out waiting for the condition\nPods of RayCluster raycluster-sample:\n")
+	assert.ErrorContains(t, err, "raycluster-sample-head (headgroup): Pending, ImagePullBackOff")
+}
+
+func TestReportRayClusterProgress(t *testing.T) {
+	out := &bytes.Buffer{}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	reportRayClusterProgress(ctx, newDiagnosticsTestClients(), progress.NewReporter(out, progress.Plain), "default", "raycluster-sample")
+
+	assert.Equal(t, "  Pod raycluster-sample-gpu-worker-abcde (gpu) is Pending, Unschedulable\n"+
+		"  Pod raycluster-sample-head (headgroup) is Pending, ImagePullBackOff\n"+
+		"  Pod raycluster-sample-gpu-worker-abcde: FailedScheduling: 0/3 nodes are available: 3 Insufficient nvidia.com/gpu.\n"+
+		"  Pod raycluster-sample-head: Failed: Failed to pull image \"rayproject/ray:nope\"\n", out.String())
+}
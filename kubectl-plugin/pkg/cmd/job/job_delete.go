@@ -0,0 +1,139 @@
+package job
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/completion"
+)
+
+type JobDeleteOptions struct {
+	configFlags    *genericclioptions.ConfigFlags
+	ioStreams      *genericiooptions.IOStreams
+	rayJobName     string
+	namespace      string
+	cascadeCluster bool
+}
+
+var (
+	jobDeleteLong = templates.LongDesc(`
+		Delete a RayJob CR.
+
+		A RayCluster created by the RayJob is garbage collected together with it. Use '--cascade-cluster' to also delete
+		the RayCluster referenced in the RayJob status, for example a RayCluster selected with 'clusterSelector'.
+	`)
+
+	jobDeleteExample = templates.Examples(`
+		# Delete the RayJob
+		kubectl ray job delete my-rayjob
+
+		# Delete the RayJob and the RayCluster it ran on
+		kubectl ray job delete my-rayjob --cascade-cluster
+	`)
+)
+
+func NewJobDeleteOptions(streams genericiooptions.IOStreams) *JobDeleteOptions {
+	return &JobDeleteOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+	}
+}
+
+func NewJobDeleteCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	options := NewJobDeleteOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:               "delete (RAYJOB) [--cascade-cluster]",
+		Short:             "Delete a RayJob",
+		Long:              jobDeleteLong,
+		Example:           jobDeleteExample,
+		SilenceUsage:      true,
+		ValidArgsFunction: completion.RayJobCompletionFunc(cmdFactory),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			k8sClient, err := client.NewClient(cmdFactory)
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			return options.Run(cmd.Context(), k8sClient)
+		},
+	}
+	cmd.Flags().BoolVar(&options.cascadeCluster, "cascade-cluster", options.cascadeCluster, "If present, also delete the RayCluster referenced in the RayJob status")
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *JobDeleteOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return cmdutil.UsageErrorf(cmd, "%s", cmd.Use)
+	}
+	options.rayJobName = args[0]
+
+	if *options.configFlags.Namespace == "" {
+		options.namespace = "default"
+	} else {
+		options.namespace = *options.configFlags.Namespace
+	}
+	return nil
+}
+
+func (options *JobDeleteOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	return nil
+}
+
+func (options *JobDeleteOptions) Run(ctx context.Context, k8sClient client.Client) error {
+	rayJob, err := k8sClient.DynamicClient().Resource(util.RayJobGVR).Namespace(options.namespace).Get(ctx, options.rayJobName, v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to find RayJob %s: %w", options.rayJobName, err)
+	}
+	clusterName, _, _ := unstructured.NestedString(rayJob.Object, "status", "rayClusterName")
+
+	err = k8sClient.DynamicClient().Resource(util.RayJobGVR).Namespace(options.namespace).Delete(ctx, options.rayJobName, v1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete RayJob %s: %w", options.rayJobName, err)
+	}
+	fmt.Fprintf(options.ioStreams.Out, "Deleted RayJob %s\n", options.rayJobName)
+
+	if !options.cascadeCluster {
+		return nil
+	}
+	if clusterName == "" {
+		fmt.Fprintf(options.ioStreams.Out, "RayJob %s does not reference a RayCluster, nothing else to delete\n", options.rayJobName)
+		return nil
+	}
+	err = k8sClient.DynamicClient().Resource(util.RayClusterGVR).Namespace(options.namespace).Delete(ctx, clusterName, v1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		// The RayCluster may already be garbage collected together with the RayJob
+		fmt.Fprintf(options.ioStreams.Out, "RayCluster %s is already deleted\n", clusterName)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to delete RayCluster %s: %w", clusterName, err)
+	}
+	fmt.Fprintf(options.ioStreams.Out, "Deleted RayCluster %s\n", clusterName)
+	return nil
+}
@@ -0,0 +1,91 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+)
+
+func TestRayJobDeleteComplete(t *testing.T) {
+	cmd := &cobra.Command{Use: "delete"}
+	testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+
+	fakeJobDeleteOptions := NewJobDeleteOptions(testStreams)
+	err := fakeJobDeleteOptions.Complete(cmd, []string{"test-rayjob"})
+	assert.Nil(t, err)
+	assert.Equal(t, "test-rayjob", fakeJobDeleteOptions.rayJobName)
+	assert.Equal(t, "default", fakeJobDeleteOptions.namespace)
+
+	err = fakeJobDeleteOptions.Complete(cmd, []string{"test-rayjob", "extra-arg"})
+	assert.NotNil(t, err)
+}
+
+func TestRayJobDeleteRun(t *testing.T) {
+	newObjects := func() []runtime.Object {
+		return []runtime.Object{
+			&unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "ray.io/v1",
+				"kind":       "RayJob",
+				"metadata":   map[string]interface{}{"name": "test-rayjob", "namespace": "default"},
+				"status":     map[string]interface{}{"rayClusterName": "test-raycluster"},
+			}},
+			&unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "ray.io/v1",
+				"kind":       "RayCluster",
+				"metadata":   map[string]interface{}{"name": "test-raycluster", "namespace": "default"},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		expectedOutput  string
+		cascadeCluster  bool
+		clusterShouldGo bool
+	}{
+		{
+			name:           "delete RayJob only",
+			cascadeCluster: false,
+			expectedOutput: "Deleted RayJob test-rayjob\n",
+		},
+		{
+			name:            "delete RayJob and RayCluster",
+			cascadeCluster:  true,
+			clusterShouldGo: true,
+			expectedOutput:  "Deleted RayJob test-rayjob\nDeleted RayCluster test-raycluster\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testStreams, _, outBuf, _ := genericiooptions.NewTestIOStreams()
+			dynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), newObjects()...)
+			k8sClient := client.NewClientForTesting(kubeFake.NewSimpleClientset(), dynamicClient)
+
+			options := NewJobDeleteOptions(testStreams)
+			options.rayJobName = "test-rayjob"
+			options.namespace = "default"
+			options.cascadeCluster = tc.cascadeCluster
+
+			err := options.Run(context.Background(), k8sClient)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedOutput, outBuf.String())
+
+			_, err = dynamicClient.Resource(util.RayJobGVR).Namespace("default").Get(context.Background(), "test-rayjob", v1.GetOptions{})
+			assert.NotNil(t, err)
+			_, err = dynamicClient.Resource(util.RayClusterGVR).Namespace("default").Get(context.Background(), "test-raycluster", v1.GetOptions{})
+			assert.Equal(t, tc.clusterShouldGo, err != nil)
+		})
+	}
+}
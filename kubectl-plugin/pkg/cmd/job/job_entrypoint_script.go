@@ -0,0 +1,131 @@
+package job
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// validateEntrypointScript validates --entrypoint-script and makes the entrypoint run it with python, passing the
+// arguments given after '--' to the script. The script is referenced relative to the working directory: a script
+// outside the working directory is staged into it, and a temporary working directory is created if none is given.
+func (options *SubmitJobOptions) validateEntrypointScript() error {
+	info, err := os.Stat(options.entryPointScript)
+	if err != nil {
+		return fmt.Errorf("failed to read --entrypoint-script: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("--entrypoint-script %s is not a regular file", options.entryPointScript)
+	}
+	options.entryPointScript = filepath.Clean(options.entryPointScript)
+
+	scriptPath := filepath.Base(options.entryPointScript)
+	options.stageEntryPointScript = true
+	if options.workingDir != "" {
+		info, err := os.Stat(options.workingDir)
+		if err != nil || !info.IsDir() {
+			return fmt.Errorf("--entrypoint-script requires a local working directory to stage the script into, got %s", options.workingDir)
+		}
+		options.workingDir = filepath.Clean(options.workingDir)
+		if relPath, ok := pathInDir(options.entryPointScript, options.workingDir); ok {
+			scriptPath = relPath
+			options.stageEntryPointScript = false
+		} else if _, err := os.Stat(filepath.Join(options.workingDir, scriptPath)); err == nil {
+			return fmt.Errorf("cannot stage --entrypoint-script into working directory %s, which already contains a file named %s", options.workingDir, scriptPath)
+		}
+		if err := options.checkWorkingDirSize(); err != nil {
+			return err
+		}
+	}
+
+	entryPoint := []string{"python", quoteEntrypointArg(filepath.ToSlash(scriptPath))}
+	if options.entryPoint != "" {
+		entryPoint = append(entryPoint, options.entryPoint)
+	}
+	options.entryPoint = strings.Join(entryPoint, " ")
+	return nil
+}
+
+// stageEntrypointScript copies the --entrypoint-script into the working directory, or into a new temporary working
+// directory if none is given. The returned function removes what was staged.
+func (options *SubmitJobOptions) stageEntrypointScript() (func(), error) {
+	if options.entryPointScript == "" || !options.stageEntryPointScript {
+		return func() {}, nil
+	}
+
+	if options.workingDir != "" {
+		stagedPath := filepath.Join(options.workingDir, filepath.Base(options.entryPointScript))
+		if err := copyFile(options.entryPointScript, stagedPath); err != nil {
+			return nil, fmt.Errorf("failed to stage --entrypoint-script into working directory %s: %w", options.workingDir, err)
+		}
+		options.reporter().Info("Staged %s into working directory %s", options.entryPointScript, options.workingDir)
+		return func() { os.Remove(stagedPath) }, nil
+	}
+
+	workingDir, err := os.MkdirTemp("", "ray-working-dir-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a working directory for --entrypoint-script: %w", err)
+	}
+	if err := copyFile(options.entryPointScript, filepath.Join(workingDir, filepath.Base(options.entryPointScript))); err != nil {
+		os.RemoveAll(workingDir)
+		return nil, fmt.Errorf("failed to stage --entrypoint-script into working directory %s: %w", workingDir, err)
+	}
+	options.reporter().Info("Staged %s into working directory %s", options.entryPointScript, workingDir)
+	options.workingDir = workingDir
+	return func() {
+		options.workingDir = ""
+		os.RemoveAll(workingDir)
+	}, nil
+}
+
+// pathInDir returns the path of the file relative to the directory if the file is within it
+func pathInDir(path string, dir string) (string, bool) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	relPath, err := filepath.Rel(absDir, absPath)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return relPath, true
+}
+
+// quoteEntrypointArg quotes an argument of the entrypoint that contains whitespace or quotes, so that it is not split
+// when the entrypoint is parsed
+func quoteEntrypointArg(arg string) string {
+	if !strings.ContainsAny(arg, " \t\n'\"\\") {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'"'"'`) + "'"
+}
+
+// copyFile copies the regular file src to dst, keeping its permissions
+func copyFile(src string, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
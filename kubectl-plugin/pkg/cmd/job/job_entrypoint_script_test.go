@@ -0,0 +1,102 @@
+package job
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+func newTestEntrypointScriptOptions(t *testing.T, script string, workingDir string, entryPoint string) *SubmitJobOptions {
+	testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+	options := NewJobSubmitOptions(testStreams)
+	options.progressMode = "none"
+	options.entryPointScript = script
+	options.workingDir = workingDir
+	options.entryPoint = entryPoint
+	return options
+}
+
+func TestValidateEntrypointScript(t *testing.T) {
+	scriptDir := t.TempDir()
+	script := filepath.Join(scriptDir, "train.py")
+	assert.Nil(t, os.WriteFile(script, []byte("print('hello')\n"), 0o600))
+	workingDir := t.TempDir()
+
+	t.Run("without working directory", func(t *testing.T) {
+		options := newTestEntrypointScriptOptions(t, script, "", "--epochs 10")
+		assert.Nil(t, options.validateWorkingDir())
+		assert.Equal(t, "python train.py --epochs 10", options.entryPoint)
+		assert.True(t, options.stageEntryPointScript)
+	})
+
+	t.Run("script outside of the working directory", func(t *testing.T) {
+		options := newTestEntrypointScriptOptions(t, script, workingDir, "")
+		assert.Nil(t, options.validateWorkingDir())
+		assert.Equal(t, "python train.py", options.entryPoint)
+		assert.True(t, options.stageEntryPointScript)
+	})
+
+	t.Run("script within the working directory", func(t *testing.T) {
+		options := newTestEntrypointScriptOptions(t, script, filepath.Dir(scriptDir), "")
+		assert.Nil(t, options.validateWorkingDir())
+		assert.Equal(t, "python "+filepath.Base(scriptDir)+"/train.py", options.entryPoint)
+		assert.False(t, options.stageEntryPointScript)
+	})
+
+	t.Run("working directory contains a different file with the same name", func(t *testing.T) {
+		otherWorkingDir := t.TempDir()
+		assert.Nil(t, os.WriteFile(filepath.Join(otherWorkingDir, "train.py"), []byte("print('other')\n"), 0o600))
+		options := newTestEntrypointScriptOptions(t, script, otherWorkingDir, "")
+		assert.EqualError(t, options.validateWorkingDir(), "cannot stage --entrypoint-script into working directory "+otherWorkingDir+", which already contains a file named train.py")
+	})
+
+	t.Run("remote working directory", func(t *testing.T) {
+		options := newTestEntrypointScriptOptions(t, script, "s3://bucket/working-dir.zip", "")
+		assert.EqualError(t, options.validateWorkingDir(), "--entrypoint-script requires a local working directory to stage the script into, got s3://bucket/working-dir.zip")
+	})
+
+	t.Run("missing script", func(t *testing.T) {
+		options := newTestEntrypointScriptOptions(t, filepath.Join(scriptDir, "missing.py"), "", "")
+		assert.ErrorContains(t, options.validateWorkingDir(), "failed to read --entrypoint-script")
+	})
+}
+
+func TestStageEntrypointScript(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "train.py")
+	assert.Nil(t, os.WriteFile(script, []byte("print('hello')\n"), 0o600))
+
+	// A temporary working directory is created and removed
+	options := newTestEntrypointScriptOptions(t, script, "", "")
+	assert.Nil(t, options.validateWorkingDir())
+	removeStagedScript, err := options.stageEntrypointScript()
+	assert.Nil(t, err)
+	stagedWorkingDir := options.workingDir
+	content, err := os.ReadFile(filepath.Join(stagedWorkingDir, "train.py"))
+	assert.Nil(t, err)
+	assert.Equal(t, "print('hello')\n", string(content))
+	removeStagedScript()
+	assert.Equal(t, "", options.workingDir)
+	assert.NoDirExists(t, stagedWorkingDir)
+
+	// The script is staged into the working directory and removed again
+	workingDir := t.TempDir()
+	options = newTestEntrypointScriptOptions(t, script, workingDir, "")
+	assert.Nil(t, options.validateWorkingDir())
+	removeStagedScript, err = options.stageEntrypointScript()
+	assert.Nil(t, err)
+	assert.FileExists(t, filepath.Join(workingDir, "train.py"))
+	removeStagedScript()
+	assert.NoFileExists(t, filepath.Join(workingDir, "train.py"))
+}
+
+func TestQuoteEntrypointArg(t *testing.T) {
+	for _, arg := range []string{"train.py", "my scripts/train.py", "it's.py"} {
+		args, err := shlex.Split("python " + quoteEntrypointArg(arg))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"python", arg}, args)
+	}
+}
@@ -0,0 +1,115 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+)
+
+// secretEnvSource is a --env-from-secret value. All keys of the Secret are used if key is empty.
+type secretEnvSource struct {
+	secretName string
+	key        string
+}
+
+// parseSecretEnvSources parses the secretName[:key] values of --env-from-secret
+func parseSecretEnvSources(args []string) ([]secretEnvSource, error) {
+	sources := make([]secretEnvSource, 0, len(args))
+	for _, arg := range args {
+		secretName, key, found := strings.Cut(arg, ":")
+		if secretName == "" || (found && key == "") {
+			return nil, fmt.Errorf("invalid --env-from-secret %q, expected SECRET_NAME or SECRET_NAME:KEY", arg)
+		}
+		sources = append(sources, secretEnvSource{secretName: secretName, key: key})
+	}
+	return sources, nil
+}
+
+// secretEnvVars reads the environment variables of the Secrets given with --env-from-secret using the credentials of the user
+func (options *SubmitJobOptions) secretEnvVars(ctx context.Context, k8sClients client.Client) (map[string]string, error) {
+	envVars := map[string]string{}
+	for _, source := range options.secretEnvSources {
+		secret, err := k8sClients.KubernetesClient().CoreV1().Secrets(*options.configFlags.Namespace).Get(ctx, source.secretName, v1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Secret %s for --env-from-secret: %w", source.secretName, err)
+		}
+		if source.key == "" {
+			for key, value := range secret.Data {
+				envVars[key] = string(value)
+			}
+			continue
+		}
+		value, ok := secret.Data[source.key]
+		if !ok {
+			return nil, fmt.Errorf("Secret %s has no key %s", source.secretName, source.key)
+		}
+		envVars[source.key] = string(value)
+	}
+	return envVars, nil
+}
+
+// applyEnvVars merges the environment variables of --env-from-secret and --env into the `env_vars` of the runtime env,
+// in increasing order of precedence. The merged runtime env is passed to `ray job submit` with --runtime-env-json.
+func (options *SubmitJobOptions) applyEnvVars(ctx context.Context, k8sClients client.Client) error {
+	if len(options.env) == 0 && len(options.secretEnvSources) == 0 {
+		return nil
+	}
+
+	runtimeEnv, err := options.resolveRuntimeEnv("")
+	if err != nil {
+		return err
+	}
+	secretEnvVars, err := options.secretEnvVars(ctx, k8sClients)
+	if err != nil {
+		return err
+	}
+	mergeEnvVars(runtimeEnv, secretEnvVars)
+	mergeEnvVars(runtimeEnv, options.env)
+
+	runtimeEnvJson, err := json.Marshal(runtimeEnv)
+	if err != nil {
+		return fmt.Errorf("failed to convert runtime env to json: %w", err)
+	}
+	options.runtimeEnvJson = string(runtimeEnvJson)
+	// `ray job submit` does not accept both a runtime env file and JSON
+	options.runtimeEnv = ""
+	return nil
+}
+
+// mergeEnvVars adds the environment variables to the `env_vars` of the runtime env, overriding existing values
+func mergeEnvVars(runtimeEnv map[string]interface{}, envVars map[string]string) {
+	if len(envVars) == 0 {
+		return
+	}
+	runtimeEnvVars, ok := runtimeEnv["env_vars"].(map[string]interface{})
+	if !ok {
+		runtimeEnvVars = map[string]interface{}{}
+		runtimeEnv["env_vars"] = runtimeEnvVars
+	}
+	for key, value := range envVars {
+		runtimeEnvVars[key] = value
+	}
+}
+
+// redactedRaySubmitCmd returns the `ray job submit` command for printing. The runtime env is hidden if it contains
+// values read from Secrets, and the headers are hidden if they contain the bearer token of --dashboard-token.
+func (options *SubmitJobOptions) redactedRaySubmitCmd(raySubmitCmd []string) []string {
+	redactRuntimeEnv := len(options.secretEnvSources) > 0
+	redactHeaders := options.dashboardConn.AuthorizationHeader() != ""
+	if !redactRuntimeEnv && !redactHeaders {
+		return raySubmitCmd
+	}
+	redacted := make([]string, len(raySubmitCmd))
+	copy(redacted, raySubmitCmd)
+	for i := 0; i+1 < len(redacted); i++ {
+		if (redactRuntimeEnv && redacted[i] == "--runtime-env-json") || (redactHeaders && redacted[i] == "--headers") {
+			redacted[i+1] = "<redacted>"
+		}
+	}
+	return redacted
+}
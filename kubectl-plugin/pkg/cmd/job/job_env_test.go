@@ -0,0 +1,136 @@
+package job
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+)
+
+func TestParseSecretEnvSources(t *testing.T) {
+	sources, err := parseSecretEnvSources([]string{"hf-token", "aws-credentials:AWS_SECRET_ACCESS_KEY"})
+	assert.Nil(t, err)
+	assert.Equal(t, []secretEnvSource{
+		{secretName: "hf-token"},
+		{secretName: "aws-credentials", key: "AWS_SECRET_ACCESS_KEY"},
+	}, sources)
+
+	_, err = parseSecretEnvSources([]string{"aws-credentials:"})
+	assert.EqualError(t, err, "invalid --env-from-secret \"aws-credentials:\", expected SECRET_NAME or SECRET_NAME:KEY")
+	_, err = parseSecretEnvSources([]string{":KEY"})
+	assert.NotNil(t, err)
+}
+
+func newTestEnvClient() client.Client {
+	kubeClientSet := kubeFake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "hf-token", Namespace: "default"},
+			Data:       map[string][]byte{"HF_TOKEN": []byte("hf_secret")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws-credentials", Namespace: "default"},
+			Data: map[string][]byte{
+				"AWS_ACCESS_KEY_ID":     []byte("access-key"),
+				"AWS_SECRET_ACCESS_KEY": []byte("secret-key"),
+			},
+		},
+	)
+	return client.NewClientForTesting(kubeClientSet, dynamicFake.NewSimpleDynamicClient(runtime.NewScheme()))
+}
+
+func TestApplyEnvVars(t *testing.T) {
+	runtimeEnvFile := filepath.Join(t.TempDir(), "runtime-env.yaml")
+	assert.Nil(t, os.WriteFile(runtimeEnvFile, []byte("pip:\n- requests\nenv_vars:\n  LOG_LEVEL: info\n"), 0o600))
+
+	tests := []struct {
+		name             string
+		runtimeEnv       string
+		runtimeEnvJson   string
+		expectedJson     string
+		expectError      string
+		env              map[string]string
+		secretEnvSources []secretEnvSource
+	}{
+		{
+			name:           "no environment variables keep the runtime env",
+			runtimeEnvJson: `{"pip": ["requests"]}`,
+			expectedJson:   `{"pip": ["requests"]}`,
+		},
+		{
+			name:           "--env takes precedence over the runtime env JSON and Secrets",
+			runtimeEnvJson: `{"pip": ["requests"], "env_vars": {"LOG_LEVEL": "info", "HF_TOKEN": "old"}}`,
+			env:            map[string]string{"LOG_LEVEL": "debug", "AWS_ACCESS_KEY_ID": "override"},
+			secretEnvSources: []secretEnvSource{
+				{secretName: "hf-token"},
+				{secretName: "aws-credentials"},
+			},
+			expectedJson: `{"pip": ["requests"], "env_vars": {"LOG_LEVEL": "debug", "HF_TOKEN": "hf_secret", "AWS_ACCESS_KEY_ID": "override", "AWS_SECRET_ACCESS_KEY": "secret-key"}}`,
+		},
+		{
+			name:             "single key of a Secret is merged into the runtime env file",
+			runtimeEnv:       runtimeEnvFile,
+			secretEnvSources: []secretEnvSource{{secretName: "aws-credentials", key: "AWS_SECRET_ACCESS_KEY"}},
+			expectedJson:     `{"pip": ["requests"], "env_vars": {"LOG_LEVEL": "info", "AWS_SECRET_ACCESS_KEY": "secret-key"}}`,
+		},
+		{
+			name:             "missing key of a Secret",
+			secretEnvSources: []secretEnvSource{{secretName: "hf-token", key: "TOKEN"}},
+			expectError:      "Secret hf-token has no key TOKEN",
+		},
+		{
+			name:             "missing Secret",
+			secretEnvSources: []secretEnvSource{{secretName: "missing"}},
+			expectError:      "failed to get Secret missing for --env-from-secret: secrets \"missing\" not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+			fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+			*fakeSubmitJobOptions.configFlags.Namespace = "default"
+			fakeSubmitJobOptions.runtimeEnv = tc.runtimeEnv
+			fakeSubmitJobOptions.runtimeEnvJson = tc.runtimeEnvJson
+			fakeSubmitJobOptions.env = tc.env
+			fakeSubmitJobOptions.secretEnvSources = tc.secretEnvSources
+
+			err := fakeSubmitJobOptions.applyEnvVars(context.Background(), newTestEnvClient())
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				return
+			}
+			assert.Nil(t, err)
+			assert.JSONEq(t, tc.expectedJson, fakeSubmitJobOptions.runtimeEnvJson)
+			assert.Empty(t, fakeSubmitJobOptions.runtimeEnv)
+		})
+	}
+}
+
+func TestRedactedRaySubmitCmd(t *testing.T) {
+	testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+	fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+	raySubmitCmd := []string{"ray", "job", "submit", "--runtime-env-json", `{"env_vars": {"HF_TOKEN": "hf_secret"}}`, "--", "python", "main.py"}
+
+	assert.Equal(t, raySubmitCmd, fakeSubmitJobOptions.redactedRaySubmitCmd(raySubmitCmd))
+
+	fakeSubmitJobOptions.secretEnvSources = []secretEnvSource{{secretName: "hf-token"}}
+	assert.Equal(t, []string{"ray", "job", "submit", "--runtime-env-json", "<redacted>", "--", "python", "main.py"}, fakeSubmitJobOptions.redactedRaySubmitCmd(raySubmitCmd))
+	// The command that is run keeps the runtime env
+	assert.Equal(t, `{"env_vars": {"HF_TOKEN": "hf_secret"}}`, raySubmitCmd[4])
+
+	// The headers carry the bearer token of --dashboard-token
+	fakeSubmitJobOptions.secretEnvSources = nil
+	fakeSubmitJobOptions.dashboardConn.Token = "fake-token"
+	raySubmitCmd = []string{"ray", "job", "submit", "--headers", `{"Authorization":"Bearer fake-token"}`, "--", "python", "main.py"}
+	assert.Equal(t, []string{"ray", "job", "submit", "--headers", "<redacted>", "--", "python", "main.py"}, fakeSubmitJobOptions.redactedRaySubmitCmd(raySubmitCmd))
+}
@@ -0,0 +1,185 @@
+package job
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/yaml"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+)
+
+// submitterPodPollInterval is how often the submitter pod of a K8sJobMode RayJob is looked up
+const submitterPodPollInterval = time.Second
+
+// applyK8sJobModeSpec applies the `ray job submit` flags to a K8sJobMode RayJob. The KubeRay operator submits the Ray
+// job from a submitter Kubernetes Job using the RayJob spec, so nothing is uploaded from the local machine.
+func (options *SubmitJobOptions) applyK8sJobModeSpec() error {
+	var unsupported []string
+	if options.workingDir != "" && !strings.Contains(options.workingDir, "://") {
+		unsupported = append(unsupported, "a local --working-dir")
+	}
+	if options.zipWorkingDir {
+		unsupported = append(unsupported, "--zip-working-dir")
+	}
+	if options.entryPointScript != "" {
+		unsupported = append(unsupported, "--entrypoint-script")
+	}
+	if len(options.secretEnvSources) > 0 {
+		// The values would be stored in plain text in the RayJob CR
+		unsupported = append(unsupported, "--env-from-secret")
+	}
+	if options.entryPointMemory > 0 {
+		unsupported = append(unsupported, "--entrypoint-memory")
+	}
+	if options.headers != "" || options.verify != "" {
+		unsupported = append(unsupported, "--headers and --verify")
+	}
+	if options.dashboardConn.UseTLS() || options.dashboardConn.AuthorizationHeader() != "" {
+		// The submitter Job of the KubeRay operator connects to the Ray dashboard
+		unsupported = append(unsupported, "the --dashboard TLS and token flags")
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("%s cannot be used with a K8sJobMode RayJob, use an InteractiveMode RayJob or a remote working_dir URI instead", strings.Join(unsupported, ", "))
+	}
+
+	spec, _ := options.RayJob.Object["spec"].(map[string]interface{})
+	if options.entryPoint != "" {
+		spec["entrypoint"] = options.entryPoint
+	} else if entrypoint, _ := spec["entrypoint"].(string); entrypoint == "" {
+		return fmt.Errorf("entrypoint is required for a K8sJobMode RayJob, give it after '--' or set spec.entrypoint")
+	}
+
+	// The runtime env given with flags is combined with the one of the RayJob, as in InteractiveMode
+	runtimeEnvYaml, _ := spec["runtimeEnvYAML"].(string)
+	runtimeEnv, err := options.resolveRuntimeEnv(runtimeEnvYaml)
+	if err != nil {
+		return err
+	}
+	if options.workingDir != "" {
+		runtimeEnv["working_dir"] = options.workingDir
+	}
+	mergeEnvVars(runtimeEnv, options.env)
+	if len(runtimeEnv) > 0 {
+		runtimeEnvYaml, err := yaml.Marshal(runtimeEnv)
+		if err != nil {
+			return fmt.Errorf("failed to convert runtime env to yaml: %w", err)
+		}
+		spec["runtimeEnvYAML"] = string(runtimeEnvYaml)
+	}
+
+	if options.submissionID != "" {
+		spec["jobId"] = options.submissionID
+	}
+	if options.entryPointCPU > 0 {
+		spec["entrypointNumCpus"] = float64(options.entryPointCPU)
+	}
+	if options.entryPointGPU > 0 {
+		spec["entrypointNumGpus"] = float64(options.entryPointGPU)
+	}
+	if options.entryPointResource != "" {
+		spec["entrypointResources"] = options.entryPointResource
+	}
+	if options.metadataJson != "" {
+		metadata := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(options.metadataJson), &metadata); err != nil {
+			return fmt.Errorf("failed to parse --metadata-json: %w", err)
+		}
+		spec["metadata"] = metadata
+	}
+	return nil
+}
+
+// submitWithK8sJob creates a K8sJobMode RayJob and streams the logs of the submitter pod, which runs `ray job submit`
+// and follows the Ray job, unless --no-wait is set. It returns the submission ID if it is known.
+func (options *SubmitJobOptions) submitWithK8sJob(ctx context.Context, k8sClients client.Client) (string, error) {
+	if err := options.createRayJob(ctx, k8sClients); err != nil {
+		return "", err
+	}
+	rayJobName := options.RayJob.GetName()
+
+	if !options.noWait {
+		if err := options.streamSubmitterLogs(ctx, k8sClients); err != nil {
+			return "", err
+		}
+	}
+
+	rayJob, err := k8sClients.DynamicClient().Resource(util.RayJobGVR).Namespace(*options.configFlags.Namespace).Get(ctx, rayJobName, v1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get RayJob %s: %w", rayJobName, err)
+	}
+	options.RayJob = rayJob
+	// The KubeRay operator generates the submission ID unless it is set in the spec
+	submissionID, _, _ := unstructured.NestedString(rayJob.Object, "status", "jobId")
+	if submissionID == "" {
+		submissionID, _, _ = unstructured.NestedString(rayJob.Object, "spec", "jobId")
+	}
+	return submissionID, nil
+}
+
+// streamSubmitterLogs waits until the submitter pod of the RayJob starts and copies its logs until it terminates.
+// Waiting for the pod shares the --timeout deadline, as it only starts once the RayCluster is ready.
+func (options *SubmitJobOptions) streamSubmitterLogs(ctx context.Context, k8sClients client.Client) error {
+	rayJobName := options.RayJob.GetName()
+	namespace := *options.configFlags.Namespace
+	waitCtx, waitCancel := context.WithDeadline(ctx, options.deadline)
+	defer waitCancel()
+
+	options.reporter().Step("Waiting for the submitter pod of RayJob %s to start", rayJobName)
+	var podName string
+	err := wait.PollUntilContextCancel(waitCtx, submitterPodPollInterval, true, func(ctx context.Context) (bool, error) {
+		// The submitter Job has the name of the RayJob and the Job controller labels its pods with it
+		pods, err := k8sClients.KubernetesClient().CoreV1().Pods(namespace).List(ctx, v1.ListOptions{LabelSelector: "job-name=" + rayJobName})
+		if err != nil {
+			return false, err
+		}
+		for _, pod := range pods.Items {
+			if pod.Status.Phase != corev1.PodPending {
+				podName = pod.Name
+				return true, nil
+			}
+		}
+		// The RayJob can fail before the submitter starts, e.g. if its RayCluster cannot be created
+		rayJob, err := k8sClients.DynamicClient().Resource(util.RayJobGVR).Namespace(namespace).Get(ctx, rayJobName, v1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return isRayJobComplete(rayJob)
+	})
+	if err != nil {
+		options.reporter().Fail()
+		return fmt.Errorf("submitter pod of RayJob %s did not start: %w", rayJobName, err)
+	}
+	if podName == "" {
+		options.reporter().Fail()
+		options.reporter().Info("RayJob %s completed before its submitter pod started", rayJobName)
+		return nil
+	}
+
+	options.reporter().Step("Running Ray job, streaming the logs of submitter pod %s", podName)
+	podLogs, err := k8sClients.KubernetesClient().CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{Follow: true}).Stream(ctx)
+	if err != nil {
+		options.reporter().Fail()
+		return fmt.Errorf("failed to stream logs of submitter pod %s: %w", podName, err)
+	}
+	defer podLogs.Close()
+	scanner := bufio.NewScanner(podLogs)
+	for scanner.Scan() {
+		options.reporter().Output(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		options.reporter().Fail()
+		return fmt.Errorf("failed to stream logs of submitter pod %s: %w", podName, err)
+	}
+	options.reporter().Done()
+	return nil
+}
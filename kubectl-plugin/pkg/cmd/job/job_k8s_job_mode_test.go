@@ -0,0 +1,145 @@
+package job
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+)
+
+func newK8sJobModeRayJob(spec map[string]interface{}) *unstructured.Unstructured {
+	spec["submissionMode"] = "K8sJobMode"
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "ray.io/v1",
+		"kind":       "RayJob",
+		"metadata":   map[string]interface{}{"name": "rayjob-sample", "namespace": "default"},
+		"spec":       spec,
+	}}
+}
+
+func TestApplyK8sJobModeSpec(t *testing.T) {
+	tests := []struct {
+		rayJobSpec   map[string]interface{}
+		expectedSpec map[string]interface{}
+		configure    func(options *SubmitJobOptions)
+		name         string
+		expectError  string
+	}{
+		{
+			name:       "flags are written to the RayJob spec",
+			rayJobSpec: map[string]interface{}{"runtimeEnvYAML": "pip:\n- requests\n"},
+			configure: func(options *SubmitJobOptions) {
+				options.entryPoint = "python main.py"
+				options.workingDir = "s3://bucket/working-dir.zip"
+				options.env = map[string]string{"LOG_LEVEL": "debug"}
+				options.submissionID = "raysubmit-1"
+				options.entryPointCPU = 1
+				options.metadataJson = `{"team": "ml"}`
+			},
+			expectedSpec: map[string]interface{}{
+				"submissionMode":    "K8sJobMode",
+				"entrypoint":        "python main.py",
+				"runtimeEnvYAML":    "env_vars:\n  LOG_LEVEL: debug\npip:\n- requests\nworking_dir: s3://bucket/working-dir.zip\n",
+				"jobId":             "raysubmit-1",
+				"entrypointNumCpus": float64(1),
+				"metadata":          map[string]interface{}{"team": "ml"},
+			},
+		},
+		{
+			name:       "entrypoint of the RayJob is kept",
+			rayJobSpec: map[string]interface{}{"entrypoint": "python main.py"},
+			configure:  func(_ *SubmitJobOptions) {},
+			expectedSpec: map[string]interface{}{
+				"submissionMode": "K8sJobMode",
+				"entrypoint":     "python main.py",
+			},
+		},
+		{
+			name:        "missing entrypoint",
+			rayJobSpec:  map[string]interface{}{},
+			configure:   func(_ *SubmitJobOptions) {},
+			expectError: "entrypoint is required for a K8sJobMode RayJob, give it after '--' or set spec.entrypoint",
+		},
+		{
+			name:       "local working directory",
+			rayJobSpec: map[string]interface{}{"entrypoint": "python main.py"},
+			configure: func(options *SubmitJobOptions) {
+				options.workingDir = "/path/to/working-dir"
+				options.secretEnvSources = []secretEnvSource{{secretName: "hf-token"}}
+			},
+			expectError: "a local --working-dir, --env-from-secret cannot be used with a K8sJobMode RayJob, use an InteractiveMode RayJob or a remote working_dir URI instead",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+			fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+			fakeSubmitJobOptions.RayJob = newK8sJobModeRayJob(tc.rayJobSpec)
+			tc.configure(fakeSubmitJobOptions)
+
+			err := fakeSubmitJobOptions.applyRayJobSpec()
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				return
+			}
+			assert.Nil(t, err)
+			assert.True(t, fakeSubmitJobOptions.k8sJobMode)
+			assert.Equal(t, tc.expectedSpec, fakeSubmitJobOptions.RayJob.Object["spec"])
+		})
+	}
+}
+
+func TestSubmitWithK8sJob(t *testing.T) {
+	submitterPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rayjob-sample-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{"job-name": "rayjob-sample"},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	dynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme())
+	k8sClients := client.NewClientForTesting(kubeFake.NewSimpleClientset(submitterPod), dynamicClient)
+
+	testStreams, _, outBuf, _ := genericclioptions.NewTestIOStreams()
+	fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+	*fakeSubmitJobOptions.configFlags.Namespace = "default"
+	fakeSubmitJobOptions.deadline = time.Now().Add(time.Minute)
+	fakeSubmitJobOptions.RayJob = newK8sJobModeRayJob(map[string]interface{}{"entrypoint": "python main.py", "jobId": "raysubmit-1"})
+
+	submissionID, err := fakeSubmitJobOptions.submitWithK8sJob(context.Background(), k8sClients)
+	assert.Nil(t, err)
+	assert.Equal(t, "raysubmit-1", submissionID)
+	// The fake clientset returns "fake logs" as the logs of every pod
+	assert.Contains(t, outBuf.String(), "Running Ray job, streaming the logs of submitter pod rayjob-sample-abcde...\nfake logs\n")
+
+	_, err = dynamicClient.Resource(util.RayJobGVR).Namespace("default").Get(context.Background(), "rayjob-sample", metav1.GetOptions{})
+	assert.Nil(t, err)
+}
+
+func TestStreamSubmitterLogsRayJobFailed(t *testing.T) {
+	rayJob := newK8sJobModeRayJob(map[string]interface{}{"entrypoint": "python main.py"})
+	rayJob.Object["status"] = map[string]interface{}{"jobDeploymentStatus": "Failed"}
+	k8sClients := client.NewClientForTesting(kubeFake.NewSimpleClientset(), dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), rayJob))
+
+	testStreams, _, outBuf, _ := genericclioptions.NewTestIOStreams()
+	fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+	*fakeSubmitJobOptions.configFlags.Namespace = "default"
+	fakeSubmitJobOptions.deadline = time.Now().Add(time.Minute)
+	fakeSubmitJobOptions.RayJob = rayJob
+
+	assert.Nil(t, fakeSubmitJobOptions.streamSubmitterLogs(context.Background(), k8sClients))
+	assert.Contains(t, outBuf.String(), "RayJob rayjob-sample completed before its submitter pod started")
+}
@@ -0,0 +1,207 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/printer"
+)
+
+// maxEntrypointLength is the number of characters of the entrypoint shown before it is truncated
+const maxEntrypointLength = 50
+
+type JobListOptions struct {
+	configFlags   *genericclioptions.ConfigFlags
+	ioStreams     *genericiooptions.IOStreams
+	outputFlags   *printer.OutputFlags
+	labelSelector string
+	status        string
+	args          []string
+	AllNamespaces bool
+}
+
+var (
+	jobListLong = templates.LongDesc(`
+		List RayJobs with their Ray job status, deployment status, RayCluster, submission mode, and entrypoint.
+	`)
+
+	jobListExample = templates.Examples(`
+		# List RayJobs in the current namespace
+		kubectl ray job list --namespace my-namespace
+
+		# List RayJobs across all namespaces
+		kubectl ray job list --all-namespaces
+
+		# List failed RayJobs with the label team=ml
+		kubectl ray job list --status FAILED -l team=ml
+
+		# List the names of the running RayJobs, e.g. in CI pipelines
+		kubectl ray job list --status RUNNING -o name
+	`)
+)
+
+func NewJobListOptions(streams genericiooptions.IOStreams) *JobListOptions {
+	return &JobListOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+		outputFlags: printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
+	}
+}
+
+func NewJobListCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	options := NewJobListOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List RayJobs",
+		Long:         jobListLong,
+		Example:      jobListExample,
+		Aliases:      []string{"ls"},
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	cmd.Flags().BoolVarP(&options.AllNamespaces, "all-namespaces", "A", options.AllNamespaces, "If present, list the RayJobs across all namespaces. Namespace in current context is ignored even if specified with --namespace.")
+	cmd.Flags().StringVarP(&options.labelSelector, "selector", "l", options.labelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+	cmd.Flags().StringVar(&options.status, "status", options.status, "Only list RayJobs whose Ray job status or deployment status matches, e.g. RUNNING, FAILED or Complete")
+	options.outputFlags.AddFlags(cmd)
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *JobListOptions) Complete(args []string) error {
+	if *options.configFlags.Namespace == "" {
+		options.AllNamespaces = true
+	}
+
+	options.args = args
+	return nil
+}
+
+func (options *JobListOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	if len(options.args) > 0 {
+		return fmt.Errorf("no arguments are allowed, use --selector or --status to filter RayJobs")
+	}
+	return options.outputFlags.Validate()
+}
+
+func (options *JobListOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	dynamicClient, err := client.NewDynamicClient(factory)
+	if err != nil {
+		return fmt.Errorf("dynamic client failed to initialize: %w", err)
+	}
+
+	listopts := v1.ListOptions{LabelSelector: options.labelSelector}
+
+	var rayJobList *unstructured.UnstructuredList
+	if options.AllNamespaces {
+		rayJobList, err = dynamicClient.Resource(util.RayJobGVR).List(ctx, listopts)
+		if err != nil {
+			return fmt.Errorf("unable to retrieve RayJobs for all namespaces: %w", err)
+		}
+	} else {
+		rayJobList, err = dynamicClient.Resource(util.RayJobGVR).Namespace(*options.configFlags.Namespace).List(ctx, listopts)
+		if err != nil {
+			return fmt.Errorf("unable to retrieve RayJobs for namespace %s: %w", *options.configFlags.Namespace, err)
+		}
+	}
+
+	rayJobList.Items = filterJobsByStatus(rayJobList.Items, options.status)
+	if options.outputFlags.IsStructured() {
+		return options.outputFlags.PrintObj(rayJobList, options.ioStreams.Out)
+	}
+	return printJobs(rayJobList.Items, options.ioStreams.Out)
+}
+
+// filterJobsByStatus returns the RayJobs whose Ray job status or deployment status matches status, ignoring case
+func filterJobsByStatus(rayJobs []unstructured.Unstructured, status string) []unstructured.Unstructured {
+	if status == "" {
+		return rayJobs
+	}
+	var filtered []unstructured.Unstructured
+	for _, rayJob := range rayJobs {
+		jobStatus, _, _ := unstructured.NestedString(rayJob.Object, "status", "jobStatus")
+		deploymentStatus, _, _ := unstructured.NestedString(rayJob.Object, "status", "jobDeploymentStatus")
+		if strings.EqualFold(jobStatus, status) || strings.EqualFold(deploymentStatus, status) {
+			filtered = append(filtered, rayJob)
+		}
+	}
+	return filtered
+}
+
+func printJobs(rayJobs []unstructured.Unstructured, output io.Writer) error {
+	resultTablePrinter := printers.NewTablePrinter(printers.PrintOptions{})
+
+	resTable := &v1.Table{
+		ColumnDefinitions: []v1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Namespace", Type: "string"},
+			{Name: "Job Status", Type: "string"},
+			{Name: "Deployment Status", Type: "string"},
+			{Name: "Cluster", Type: "string"},
+			{Name: "Submission Mode", Type: "string"},
+			{Name: "Entrypoint", Type: "string"},
+			{Name: "Age", Type: "string"},
+		},
+	}
+
+	for _, rayJob := range rayJobs {
+		age := duration.HumanDuration(time.Since(rayJob.GetCreationTimestamp().Time))
+		if rayJob.GetCreationTimestamp().Time.IsZero() {
+			age = "<unknown>"
+		}
+		jobStatus, _, _ := unstructured.NestedString(rayJob.Object, "status", "jobStatus")
+		deploymentStatus, _, _ := unstructured.NestedString(rayJob.Object, "status", "jobDeploymentStatus")
+		clusterName, _, _ := unstructured.NestedString(rayJob.Object, "status", "rayClusterName")
+		submissionMode, _, _ := unstructured.NestedString(rayJob.Object, "spec", "submissionMode")
+		entrypoint, _, _ := unstructured.NestedString(rayJob.Object, "spec", "entrypoint")
+		if len(entrypoint) > maxEntrypointLength {
+			entrypoint = entrypoint[:maxEntrypointLength-3] + "..."
+		}
+		resTable.Rows = append(resTable.Rows, v1.TableRow{
+			Cells: []interface{}{
+				rayJob.GetName(),
+				rayJob.GetNamespace(),
+				jobStatus,
+				deploymentStatus,
+				clusterName,
+				submissionMode,
+				entrypoint,
+				age,
+			},
+		})
+	}
+
+	return resultTablePrinter.PrintObj(resTable, output)
+}
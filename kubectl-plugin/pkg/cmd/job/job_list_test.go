@@ -0,0 +1,141 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/printer"
+)
+
+func newTestRayJob(name string, labels map[string]interface{}, jobStatus string, entrypoint string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "ray.io/v1",
+			"kind":       "RayJob",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "test",
+				"labels":    labels,
+			},
+			"spec": map[string]interface{}{
+				"submissionMode": "K8sJobMode",
+				"entrypoint":     entrypoint,
+			},
+			"status": map[string]interface{}{
+				"jobStatus":           jobStatus,
+				"jobDeploymentStatus": "Running",
+				"rayClusterName":      name + "-raycluster",
+			},
+		},
+	}
+}
+
+func TestRayJobListComplete(t *testing.T) {
+	testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+	fakeJobListOptions := NewJobListOptions(testStreams)
+	*fakeJobListOptions.configFlags.Namespace = ""
+
+	err := fakeJobListOptions.Complete([]string{})
+	assert.Nil(t, err)
+	assert.True(t, fakeJobListOptions.AllNamespaces)
+}
+
+func TestFilterJobsByStatus(t *testing.T) {
+	rayJobs := []unstructured.Unstructured{
+		*newTestRayJob("running-job", nil, "RUNNING", ""),
+		*newTestRayJob("failed-job", nil, "FAILED", ""),
+	}
+
+	assert.Len(t, filterJobsByStatus(rayJobs, ""), 2)
+
+	filtered := filterJobsByStatus(rayJobs, "failed")
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "failed-job", filtered[0].GetName())
+
+	// Deployment status also matches
+	assert.Len(t, filterJobsByStatus(rayJobs, "Running"), 2)
+	assert.Empty(t, filterJobsByStatus(rayJobs, "Complete"))
+}
+
+func TestRayJobListRun(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+
+	testStreams, _, resBuf, _ := genericiooptions.NewTestIOStreams()
+	fakeJobListOptions := NewJobListOptions(testStreams)
+	*fakeJobListOptions.configFlags.Namespace = "test"
+	fakeJobListOptions.labelSelector = "team=ml"
+	fakeJobListOptions.status = "FAILED"
+
+	longEntrypoint := "python /home/ray/samples/sample_code.py --with --a --very --long --list --of --arguments"
+	tf.FakeDynamicClient = fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(),
+		newTestRayJob("ml-job", map[string]interface{}{"team": "ml"}, "RUNNING", "python run.py"),
+		newTestRayJob("ml-failed-job", map[string]interface{}{"team": "ml"}, "FAILED", longEntrypoint),
+		newTestRayJob("other-job", map[string]interface{}{"team": "other"}, "FAILED", "python other.py"),
+	)
+
+	err := fakeJobListOptions.Run(context.Background(), tf)
+	assert.Nil(t, err)
+
+	expectedTable := &v1.Table{
+		ColumnDefinitions: []v1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Namespace", Type: "string"},
+			{Name: "Job Status", Type: "string"},
+			{Name: "Deployment Status", Type: "string"},
+			{Name: "Cluster", Type: "string"},
+			{Name: "Submission Mode", Type: "string"},
+			{Name: "Entrypoint", Type: "string"},
+			{Name: "Age", Type: "string"},
+		},
+		Rows: []v1.TableRow{
+			{
+				Cells: []interface{}{
+					"ml-failed-job",
+					"test",
+					"FAILED",
+					"Running",
+					"ml-failed-job-raycluster",
+					"K8sJobMode",
+					"python /home/ray/samples/sample_code.py --with ...",
+					"<unknown>",
+				},
+			},
+		},
+	}
+	var expectedBuf bytes.Buffer
+	err = printers.NewTablePrinter(printers.PrintOptions{}).PrintObj(expectedTable, &expectedBuf)
+	assert.Nil(t, err)
+
+	assert.Equal(t, expectedBuf.String(), resBuf.String())
+}
+
+func TestRayJobListRunStructuredOutput(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+
+	testStreams, _, resBuf, _ := genericiooptions.NewTestIOStreams()
+	fakeJobListOptions := NewJobListOptions(testStreams)
+	*fakeJobListOptions.configFlags.Namespace = "test"
+	fakeJobListOptions.status = "FAILED"
+	fakeJobListOptions.outputFlags.Format = printer.Name
+
+	tf.FakeDynamicClient = fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(),
+		newTestRayJob("running-job", nil, "RUNNING", "python run.py"),
+		newTestRayJob("failed-job", nil, "FAILED", "python run.py"),
+	)
+
+	err := fakeJobListOptions.Run(context.Background(), tf)
+	assert.Nil(t, err)
+	assert.Equal(t, "rayjob.ray.io/failed-job\n", resBuf.String())
+}
@@ -0,0 +1,231 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/completion"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/config"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/dashboard"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+const followInterval = 2 * time.Second
+
+type JobLogsOptions struct {
+	configFlags        *genericclioptions.ConfigFlags
+	ioStreams          *genericiooptions.IOStreams
+	dashboardConn      *dashboard.ConnectionOptions
+	rayJobName         string
+	namespace          string
+	localDashboardPort int
+	tail               int
+	follow             bool
+	timestamps         bool
+}
+
+var (
+	jobLogsLong = templates.LongDesc(`
+		Print the driver logs of a Ray job submitted with 'kubectl ray job submit'.
+
+		The Ray job is looked up with the submission ID recorded on the RayJob CR, and the logs are retrieved from the Ray dashboard through a port-forward.
+	`)
+
+	jobLogsExample = templates.Examples(`
+		# Print the logs of the Ray job submitted for the RayJob
+		kubectl ray job logs my-rayjob
+
+		# Print the last 20 lines of the logs
+		kubectl ray job logs my-rayjob --tail 20
+
+		# Stream the logs until the Ray job finishes, prefixing each line with the time it was received
+		kubectl ray job logs my-rayjob --follow --timestamps
+
+		# Print the logs from a Ray dashboard that serves TLS and requires a bearer token
+		kubectl ray job logs my-rayjob --dashboard-ca-cert ca.crt --dashboard-token "$TOKEN"
+	`)
+)
+
+func NewJobLogsOptions(streams genericiooptions.IOStreams) *JobLogsOptions {
+	return &JobLogsOptions{
+		configFlags:   genericclioptions.NewConfigFlags(true),
+		ioStreams:     &streams,
+		dashboardConn: &dashboard.ConnectionOptions{},
+		tail:          -1,
+	}
+}
+
+func NewJobLogsCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	options := NewJobLogsOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:               "logs (RAYJOB) [--follow] [--tail N] [--timestamps]",
+		Short:             "Print the logs of a Ray job",
+		Long:              jobLogsLong,
+		Example:           jobLogsExample,
+		Aliases:           []string{"log"},
+		SilenceUsage:      true,
+		ValidArgsFunction: completion.RayJobCompletionFunc(cmdFactory),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	cmd.Flags().BoolVarP(&options.follow, "follow", "F", options.follow, "If present, stream the logs until the Ray job reaches a terminal state")
+	cmd.Flags().IntVar(&options.tail, "tail", options.tail, "Number of lines of the most recent logs to print. Defaults to -1 which prints all lines")
+	cmd.Flags().BoolVar(&options.timestamps, "timestamps", options.timestamps, "If present, prefix each line with the time it was received")
+	cmd.Flags().IntVar(&options.localDashboardPort, "dashboard-port", options.localDashboardPort, "Local port used to forward the Ray dashboard. If not set, a free local port is selected automatically")
+	config.BindFlag(cmd.Flags(), "dashboard-port", config.DashboardPort)
+	options.dashboardConn.AddFlags(cmd.Flags())
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *JobLogsOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return cmdutil.UsageErrorf(cmd, "%s", cmd.Use)
+	}
+	options.rayJobName = args[0]
+
+	if *options.configFlags.Namespace == "" {
+		options.namespace = "default"
+	} else {
+		options.namespace = *options.configFlags.Namespace
+	}
+
+	if options.localDashboardPort == 0 {
+		freePort, err := util.GetFreeLocalPort()
+		if err != nil {
+			return fmt.Errorf("failed to find a free local port for the Ray dashboard: %w", err)
+		}
+		options.localDashboardPort = freePort
+	}
+	return nil
+}
+
+func (options *JobLogsOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	if options.tail < -1 {
+		return fmt.Errorf("tail must be -1 or greater, got %d", options.tail)
+	}
+	if options.localDashboardPort < 0 || options.localDashboardPort > 65535 {
+		return fmt.Errorf("dashboard port %d is out of range, must be between 0 and 65535", options.localDashboardPort)
+	}
+	return options.dashboardConn.Validate()
+}
+
+func (options *JobLogsOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	k8sClient, err := client.NewClient(factory)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	dashboardClient, submissionID, cancel, err := connectToRayJobDashboard(ctx, factory, *options.ioStreams, k8sClient, options.namespace, options.rayJobName, options.localDashboardPort, options.dashboardConn)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	return options.printJobLogs(ctx, dashboardClient, submissionID)
+}
+
+// printJobLogs prints the current logs of the Ray job and, when following, keeps printing
+// newly appended lines until the Ray job reaches a terminal state.
+func (options *JobLogsOptions) printJobLogs(ctx context.Context, dashboardClient dashboard.JobClient, submissionID string) error {
+	logs, err := dashboardClient.GetJobLog(ctx, submissionID)
+	if err != nil {
+		return fmt.Errorf("failed to get logs of Ray job %s: %w", submissionID, err)
+	}
+	if logs == nil {
+		return fmt.Errorf("Ray job %s not found in the Ray cluster", submissionID)
+	}
+	printLogLines(options.ioStreams.Out, tailLogs(*logs, options.tail), options.timestamps)
+
+	if !options.follow {
+		return nil
+	}
+
+	printed := len(*logs)
+	ticker := time.NewTicker(followInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		// Check the job status before fetching logs so that no lines written before the job finished are missed
+		jobInfo, err := dashboardClient.GetJobInfo(ctx, submissionID)
+		if err != nil {
+			return fmt.Errorf("failed to get status of Ray job %s: %w", submissionID, err)
+		}
+		logs, err = dashboardClient.GetJobLog(ctx, submissionID)
+		if err != nil {
+			return fmt.Errorf("failed to get logs of Ray job %s: %w", submissionID, err)
+		}
+		if logs != nil && len(*logs) > printed {
+			printLogLines(options.ioStreams.Out, (*logs)[printed:], options.timestamps)
+			printed = len(*logs)
+		}
+		if rayv1api.IsJobTerminal(jobInfo.JobStatus) {
+			return nil
+		}
+	}
+}
+
+// tailLogs returns the last n lines of logs. A negative n returns all logs.
+func tailLogs(logs string, n int) string {
+	if n < 0 {
+		return logs
+	}
+	if n == 0 {
+		return ""
+	}
+	lines := strings.SplitAfter(logs, "\n")
+	// SplitAfter returns an empty trailing element when logs end with a new line
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "")
+}
+
+func printLogLines(out io.Writer, logs string, timestamps bool) {
+	if !timestamps {
+		fmt.Fprint(out, logs)
+		return
+	}
+	now := time.Now().Format(time.RFC3339)
+	for _, line := range strings.SplitAfter(logs, "\n") {
+		if line != "" {
+			fmt.Fprintf(out, "%s %s", now, line)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/dashboard"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestRayJobLogsComplete(t *testing.T) {
+	cmd := &cobra.Command{Use: "logs"}
+
+	testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+	fakeJobLogsOptions := NewJobLogsOptions(testStreams)
+	err := fakeJobLogsOptions.Complete(cmd, []string{"test-rayjob"})
+	assert.Nil(t, err)
+	assert.Equal(t, "test-rayjob", fakeJobLogsOptions.rayJobName)
+	assert.Equal(t, "default", fakeJobLogsOptions.namespace)
+	assert.Equal(t, -1, fakeJobLogsOptions.tail)
+	assert.NotZero(t, fakeJobLogsOptions.localDashboardPort)
+
+	err = fakeJobLogsOptions.Complete(cmd, []string{})
+	assert.NotNil(t, err)
+}
+
+func TestTailLogs(t *testing.T) {
+	logs := "line 1\nline 2\nline 3\n"
+	assert.Equal(t, logs, tailLogs(logs, -1))
+	assert.Equal(t, "", tailLogs(logs, 0))
+	assert.Equal(t, "line 2\nline 3\n", tailLogs(logs, 2))
+	assert.Equal(t, logs, tailLogs(logs, 10))
+	assert.Equal(t, "line 3", tailLogs("line 1\nline 2\nline 3", 1))
+}
+
+// newFakeDashboardServer serves job info and logs, appending to the logs and finishing the job on the second log request
+func newFakeDashboardServer(t *testing.T, submissionID string) *httptest.Server {
+	logRequests := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case utils.JobPath + submissionID:
+			status := rayv1api.JobStatusRunning
+			if logRequests >= 1 {
+				status = rayv1api.JobStatusSucceeded
+			}
+			assert.Nil(t, json.NewEncoder(w).Encode(utils.RayJobInfo{JobStatus: status}))
+		case utils.JobPath + submissionID + "/logs":
+			logs := "line 1\nline 2\n"
+			if logRequests >= 1 {
+				logs += "line 3\n"
+			}
+			logRequests++
+			assert.Nil(t, json.NewEncoder(w).Encode(utils.RayJobLogsResponse{Logs: logs}))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newTestDashboardClient(t *testing.T, server *httptest.Server) dashboard.JobClient {
+	port, err := strconv.Atoi(server.URL[strings.LastIndex(server.URL, ":")+1:])
+	assert.Nil(t, err)
+	dashboardClient, err := dashboard.NewJobClient(port, nil)
+	assert.Nil(t, err)
+	return dashboardClient
+}
+
+func TestPrintJobLogs(t *testing.T) {
+	server := newFakeDashboardServer(t, "raysubmit_123")
+	defer server.Close()
+	dashboardClient := newTestDashboardClient(t, server)
+
+	t.Run("tail without follow", func(t *testing.T) {
+		testStreams, _, outBuf, _ := genericiooptions.NewTestIOStreams()
+		options := NewJobLogsOptions(testStreams)
+		options.tail = 1
+
+		err := options.printJobLogs(context.Background(), dashboardClient, "raysubmit_123")
+		assert.Nil(t, err)
+		assert.Equal(t, "line 2\n", outBuf.String())
+	})
+
+	t.Run("follow until job finishes", func(t *testing.T) {
+		testStreams, _, outBuf, _ := genericiooptions.NewTestIOStreams()
+		options := NewJobLogsOptions(testStreams)
+		options.follow = true
+
+		err := options.printJobLogs(context.Background(), dashboardClient, "raysubmit_123")
+		assert.Nil(t, err)
+		assert.Equal(t, "line 1\nline 2\nline 3\n", outBuf.String())
+	})
+
+	t.Run("job not found", func(t *testing.T) {
+		testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+		options := NewJobLogsOptions(testStreams)
+
+		err := options.printJobLogs(context.Background(), dashboardClient, "raysubmit_unknown")
+		assert.EqualError(t, err, "Ray job raysubmit_unknown not found in the Ray cluster")
+	})
+}
+
+func TestPrintLogLinesWithTimestamps(t *testing.T) {
+	testStreams, _, outBuf, _ := genericiooptions.NewTestIOStreams()
+	printLogLines(testStreams.Out, "line 1\nline 2\n", true)
+
+	lines := strings.Split(strings.TrimSuffix(outBuf.String(), "\n"), "\n")
+	assert.Len(t, lines, 2)
+	assert.Regexp(t, `^\S+ line 1$`, lines[0])
+	assert.Regexp(t, `^\S+ line 2$`, lines[1])
+}
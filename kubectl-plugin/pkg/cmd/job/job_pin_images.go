@@ -0,0 +1,85 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// imagePinner resolves the tag of an image to a digest, see image.Resolver
+type imagePinner interface {
+	Pin(ctx context.Context, image string) (string, error)
+}
+
+// pinRayJobImages replaces the images of the containers of the head and worker groups of the RayJob with the digests of
+// their tags, so that the Ray job runs with the same images if it is submitted again later. The original images are
+// recorded in the pinnedImagesAnnotation of the RayJob.
+func (options *SubmitJobOptions) pinRayJobImages(ctx context.Context, pinner imagePinner) error {
+	options.reporter().Step("Pinning the images of RayJob %s to digests", options.RayJob.GetName())
+	// pinned maps each original image to its pinned image, so that each image is resolved once
+	pinned := map[string]string{}
+	found, err := updateRayClusterPodSpecs(options.RayJob, func(podSpec map[string]interface{}) (map[string]interface{}, error) {
+		for _, field := range []string{"initContainers", "containers"} {
+			containers, _, err := unstructured.NestedSlice(podSpec, field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s of the RayJob: %w", field, err)
+			}
+			for _, item := range containers {
+				container, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				image, _ := container["image"].(string)
+				if image == "" {
+					continue
+				}
+				pinnedImage, ok := pinned[image]
+				if !ok {
+					if pinnedImage, err = pinner.Pin(ctx, image); err != nil {
+						return nil, err
+					}
+					pinned[image] = pinnedImage
+					options.reporter().Info("%s is %s", image, pinnedImage)
+				}
+				container["image"] = pinnedImage
+			}
+			if len(containers) > 0 {
+				if err := unstructured.SetNestedSlice(podSpec, containers, field); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return podSpec, nil
+	})
+	if err == nil && !found {
+		err = fmt.Errorf("--pin-images requires a RayJob with spec.rayClusterSpec")
+	}
+	if err != nil {
+		options.reporter().Fail()
+		return err
+	}
+
+	originalImages := map[string]string{}
+	for image, pinnedImage := range pinned {
+		if image != pinnedImage {
+			originalImages[pinnedImage] = image
+		}
+	}
+	if len(originalImages) > 0 {
+		originalImagesJson, err := json.Marshal(originalImages)
+		if err != nil {
+			options.reporter().Fail()
+			return fmt.Errorf("failed to convert the pinned images to json: %w", err)
+		}
+		annotations := options.RayJob.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[pinnedImagesAnnotation] = string(originalImagesJson)
+		options.RayJob.SetAnnotations(annotations)
+	}
+	options.reporter().Done()
+	return nil
+}
@@ -0,0 +1,105 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/progress"
+)
+
+const testImageDigest = "sha256:2d4e0bd8f2c5a5b0e5e8b3c4d7f9a1b2c3d4e5f60718293a4b5c6d7e8f901234"
+
+// fakeImagePinner pins the images it knows and records the resolved images
+type fakeImagePinner struct {
+	digests  map[string]string
+	resolved []string
+}
+
+func (p *fakeImagePinner) Pin(_ context.Context, image string) (string, error) {
+	p.resolved = append(p.resolved, image)
+	if strings.Contains(image, "@") {
+		return image, nil
+	}
+	digest, ok := p.digests[image]
+	if !ok {
+		return "", fmt.Errorf("unable to resolve the digest of image %s", image)
+	}
+	return image + "@" + digest, nil
+}
+
+func newPinImagesTestOptions(rayJob *unstructured.Unstructured) (*SubmitJobOptions, *bytes.Buffer) {
+	testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+	options := NewJobSubmitOptions(testStreams)
+	out := &bytes.Buffer{}
+	options.progress = progress.NewReporter(out, progress.Plain)
+	options.RayJob = rayJob
+	return options, out
+}
+
+func TestPinRayJobImages(t *testing.T) {
+	pinnedImage := "rayproject/ray:2.37.0@" + testImageDigest
+	options, out := newPinImagesTestOptions(&unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "rayjob-sample", "annotations": map[string]interface{}{"team": "ml"}},
+		"spec": map[string]interface{}{
+			"rayClusterSpec": map[string]interface{}{
+				"headGroupSpec": map[string]interface{}{
+					"template": map[string]interface{}{"spec": map[string]interface{}{
+						"initContainers": []interface{}{map[string]interface{}{"name": "init", "image": "busybox@" + testImageDigest}},
+						"containers":     []interface{}{map[string]interface{}{"name": "ray-head", "image": "rayproject/ray:2.37.0"}},
+					}},
+				},
+				"workerGroupSpecs": []interface{}{
+					map[string]interface{}{
+						"groupName": "gpu",
+						"template": map[string]interface{}{"spec": map[string]interface{}{
+							"containers": []interface{}{map[string]interface{}{"name": "ray-worker", "image": "rayproject/ray:2.37.0"}},
+						}},
+					},
+				},
+			},
+		},
+	}})
+	pinner := &fakeImagePinner{digests: map[string]string{"rayproject/ray:2.37.0": testImageDigest}}
+
+	assert.Nil(t, options.pinRayJobImages(context.Background(), pinner))
+	// Each image is resolved once
+	assert.Equal(t, []string{"busybox@" + testImageDigest, "rayproject/ray:2.37.0"}, pinner.resolved)
+	headImage, _, _ := unstructured.NestedSlice(options.RayJob.Object, "spec", "rayClusterSpec", "headGroupSpec", "template", "spec", "containers")
+	assert.Equal(t, pinnedImage, headImage[0].(map[string]interface{})["image"])
+	workerGroupSpecs, _, _ := unstructured.NestedSlice(options.RayJob.Object, "spec", "rayClusterSpec", "workerGroupSpecs")
+	workerContainers, _, _ := unstructured.NestedSlice(workerGroupSpecs[0].(map[string]interface{}), "template", "spec", "containers")
+	assert.Equal(t, pinnedImage, workerContainers[0].(map[string]interface{})["image"])
+	assert.Equal(t, map[string]string{
+		"team":                 "ml",
+		pinnedImagesAnnotation: `{"rayproject/ray:2.37.0@` + testImageDigest + `":"rayproject/ray:2.37.0"}`,
+	}, options.RayJob.GetAnnotations())
+	assert.Contains(t, out.String(), "rayproject/ray:2.37.0 is "+pinnedImage)
+}
+
+func TestPinRayJobImagesErrors(t *testing.T) {
+	options, _ := newPinImagesTestOptions(&unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"clusterSelector": map[string]interface{}{"ray.io/cluster": "raycluster-sample"}},
+	}})
+	assert.EqualError(t, options.pinRayJobImages(context.Background(), &fakeImagePinner{}), "--pin-images requires a RayJob with spec.rayClusterSpec")
+
+	options, _ = newPinImagesTestOptions(&unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"rayClusterSpec": map[string]interface{}{
+				"headGroupSpec": map[string]interface{}{
+					"template": map[string]interface{}{"spec": map[string]interface{}{
+						"containers": []interface{}{map[string]interface{}{"name": "ray-head", "image": "private.example.com/ray:dev"}},
+					}},
+				},
+			},
+		},
+	}})
+	assert.EqualError(t, options.pinRayJobImages(context.Background(), &fakeImagePinner{}), "unable to resolve the digest of image private.example.com/ray:dev")
+	assert.Empty(t, options.RayJob.GetAnnotations())
+}
@@ -0,0 +1,203 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/completion"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
+)
+
+// JobResubmitOptions reuses the `ray job submit` flags and submission logic of SubmitJobOptions
+type JobResubmitOptions struct {
+	*SubmitJobOptions
+}
+
+var (
+	jobResubmitLong = templates.LongDesc(`
+		Submit a new Ray job for an existing InteractiveMode RayJob.
+
+		If the RayCluster of the RayJob is still ready, the new Ray job is submitted to it directly. Otherwise the RayJob
+		is recreated from its spec and the new Ray job is submitted once the new RayCluster is ready. The submission ID
+		of the new Ray job replaces the one recorded on the RayJob, so 'kubectl ray job logs' and 'kubectl ray job stop'
+		act on the new Ray job.
+	`)
+
+	jobResubmitExample = templates.Examples(`
+		# Submit a new Ray job for the RayJob
+		kubectl ray job resubmit my-rayjob --working-dir /path/to/working-dir/ -- python my_script.py
+
+		# Submit a new Ray job with a specific submission ID
+		kubectl ray job resubmit my-rayjob --submission-id my-job-2 --working-dir /path/to/working-dir/ -- python my_script.py
+	`)
+)
+
+func NewJobResubmitOptions(streams genericiooptions.IOStreams) *JobResubmitOptions {
+	return &JobResubmitOptions{
+		SubmitJobOptions: NewJobSubmitOptions(streams),
+	}
+}
+
+func NewJobResubmitCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	options := NewJobResubmitOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:               "resubmit (RAYJOB) [OPTIONS] -- ENTRYPOINT",
+		Short:             "Submit a new Ray job for an existing InteractiveMode RayJob",
+		Long:              jobResubmitLong,
+		Example:           jobResubmitExample,
+		SilenceUsage:      true,
+		ValidArgsFunction: completion.RayJobCompletionFunc(cmdFactory),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	addRaySubmitFlags(cmd, options.SubmitJobOptions)
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *JobResubmitOptions) Complete(cmd *cobra.Command, args []string) error {
+	entryPointStart := cmd.ArgsLenAtDash()
+	if entryPointStart == -1 && options.entryPointScript != "" {
+		// The arguments of the entrypoint script are optional
+		entryPointStart = len(args)
+	}
+	if entryPointStart != 1 || (len(args) == entryPointStart && options.entryPointScript == "") {
+		return cmdutil.UsageErrorf(cmd, "%s", cmd.Use)
+	}
+	options.rayJobName = args[0]
+	options.entryPoint = strings.Join(args[entryPointStart:], " ")
+
+	if *options.configFlags.Namespace == "" {
+		*options.configFlags.Namespace = "default"
+	}
+
+	return options.SubmitJobOptions.Complete()
+}
+
+func (options *JobResubmitOptions) Validate() error {
+	if err := options.validateRaySubmitFlags(); err != nil {
+		return err
+	}
+	return options.validateWorkingDir()
+}
+
+func (options *JobResubmitOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	k8sClients, err := client.NewClient(factory)
+	if err != nil {
+		return fmt.Errorf("failed to initialize clientset: %w", err)
+	}
+	namespace := *options.configFlags.Namespace
+
+	options.RayJob, err = k8sClients.DynamicClient().Resource(util.RayJobGVR).Namespace(namespace).Get(ctx, options.rayJobName, v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get RayJob %s: %w", options.rayJobName, err)
+	}
+	if err := options.applyRayJobSpec(); err != nil {
+		return err
+	}
+
+	options.deadline = time.Now().Add(options.timeout)
+	defer options.reporter().Close()
+	reusable, err := options.rayClusterIsReusable(ctx, k8sClients)
+	if err != nil {
+		return err
+	}
+	if reusable {
+		logging.New(options.progressOut()).Infof("Reusing RayCluster %s of RayJob %s.", options.cluster, options.rayJobName)
+	} else {
+		if err := options.recreateRayJob(ctx, k8sClients); err != nil {
+			return err
+		}
+	}
+	_, err = options.submitToRayCluster(ctx, factory, k8sClients)
+	return err
+}
+
+// rayClusterIsReusable reports whether the RayCluster referenced by the RayJob status still exists and is ready
+func (options *JobResubmitOptions) rayClusterIsReusable(ctx context.Context, k8sClients client.Client) (bool, error) {
+	clusterName, _, err := unstructured.NestedString(options.RayJob.Object, "status", "rayClusterName")
+	if err != nil {
+		return false, err
+	}
+	if clusterName == "" {
+		return false, nil
+	}
+
+	rayCluster, err := k8sClients.DynamicClient().Resource(util.RayClusterGVR).Namespace(*options.configFlags.Namespace).Get(ctx, clusterName, v1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get RayCluster %s: %w", clusterName, err)
+	}
+
+	ready, err := client.IsRayClusterReady(rayCluster)
+	if err != nil || !ready {
+		return false, err
+	}
+	options.cluster = clusterName
+	return true, nil
+}
+
+// recreateRayJob deletes the RayJob and creates it again from its spec so that the operator provisions a new RayCluster
+func (options *JobResubmitOptions) recreateRayJob(ctx context.Context, k8sClients client.Client) error {
+	namespace := *options.configFlags.Namespace
+	logging.New(options.progressOut()).Infof("RayCluster of RayJob %s is no longer available, recreating RayJob...", options.rayJobName)
+
+	err := k8sClients.DynamicClient().Resource(util.RayJobGVR).Namespace(namespace).Delete(ctx, options.rayJobName, v1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete RayJob %s: %w", options.rayJobName, err)
+	}
+
+	waitCtx, waitCancel := context.WithDeadline(ctx, options.deadline)
+	defer waitCancel()
+	if err := client.WaitForResourceDeletion(waitCtx, k8sClients.DynamicClient(), util.RayJobGVR, namespace, options.rayJobName); err != nil {
+		return fmt.Errorf("failed to wait for RayJob %s to be deleted: %w", options.rayJobName, err)
+	}
+
+	options.RayJob = newRayJobForResubmission(options.RayJob)
+	return options.createRayJobAndWaitForCluster(ctx, k8sClients)
+}
+
+// newRayJobForResubmission returns a copy of the RayJob without its status, server populated metadata and the
+// identifiers of the previous Ray job
+func newRayJobForResubmission(rayJob *unstructured.Unstructured) *unstructured.Unstructured {
+	newRayJob := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	newRayJob.SetAPIVersion(rayJob.GetAPIVersion())
+	newRayJob.SetKind(rayJob.GetKind())
+	newRayJob.SetName(rayJob.GetName())
+	newRayJob.SetNamespace(rayJob.GetNamespace())
+	newRayJob.SetLabels(rayJob.GetLabels())
+
+	annotations := rayJob.GetAnnotations()
+	delete(annotations, submissionIDAnnotation)
+	newRayJob.SetAnnotations(annotations)
+
+	if spec, ok := rayJob.Object["spec"].(map[string]interface{}); ok {
+		newSpec := runtime.DeepCopyJSON(spec)
+		delete(newSpec, "jobId")
+		newRayJob.Object["spec"] = newSpec
+	}
+	return newRayJob
+}
@@ -0,0 +1,103 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+func TestRayJobResubmitComplete(t *testing.T) {
+	testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+
+	tests := []struct {
+		name        string
+		args        []string
+		expectError bool
+	}{
+		{
+			name: "RayJob name and entrypoint",
+			args: []string{"test-rayjob", "--", "python", "my_script.py"},
+		},
+		{
+			name:        "missing entrypoint",
+			args:        []string{"test-rayjob"},
+			expectError: true,
+		},
+		{
+			name:        "missing RayJob name",
+			args:        []string{"--", "python", "my_script.py"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "resubmit"}
+			assert.Nil(t, cmd.ParseFlags(tc.args))
+
+			fakeJobResubmitOptions := NewJobResubmitOptions(testStreams)
+			fakeJobResubmitOptions.localDashboardPort = 18265
+
+			err := fakeJobResubmitOptions.Complete(cmd, cmd.Flags().Args())
+			if tc.expectError {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, "test-rayjob", fakeJobResubmitOptions.rayJobName)
+			assert.Equal(t, "python my_script.py", fakeJobResubmitOptions.entryPoint)
+			assert.Equal(t, "default", *fakeJobResubmitOptions.configFlags.Namespace)
+			assert.Equal(t, 18265, fakeJobResubmitOptions.localDashboardPort)
+		})
+	}
+}
+
+func TestNewRayJobForResubmission(t *testing.T) {
+	rayJob := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "ray.io/v1",
+			"kind":       "RayJob",
+			"metadata": map[string]interface{}{
+				"name":            "test-rayjob",
+				"namespace":       "default",
+				"uid":             "1234",
+				"resourceVersion": "42",
+				"labels":          map[string]interface{}{"app": "test"},
+				"annotations": map[string]interface{}{
+					submissionIDAnnotation: "raysubmit_1",
+					"team":                 "ml",
+				},
+			},
+			"spec": map[string]interface{}{
+				"submissionMode": string(interactiveMode),
+				"jobId":          "raysubmit_1",
+			},
+			"status": map[string]interface{}{
+				"rayClusterName": "test-rayjob-raycluster-abcde",
+			},
+		},
+	}
+
+	newRayJob := newRayJobForResubmission(rayJob)
+	assert.Equal(t, "test-rayjob", newRayJob.GetName())
+	assert.Equal(t, "default", newRayJob.GetNamespace())
+	assert.Equal(t, "RayJob", newRayJob.GetKind())
+	assert.Empty(t, newRayJob.GetUID())
+	assert.Empty(t, newRayJob.GetResourceVersion())
+	assert.Equal(t, map[string]string{"app": "test"}, newRayJob.GetLabels())
+	assert.Equal(t, map[string]string{"team": "ml"}, newRayJob.GetAnnotations())
+	assert.NotContains(t, newRayJob.Object, "status")
+
+	submissionMode, _, _ := unstructured.NestedString(newRayJob.Object, "spec", "submissionMode")
+	assert.Equal(t, string(interactiveMode), submissionMode)
+	_, found, _ := unstructured.NestedString(newRayJob.Object, "spec", "jobId")
+	assert.False(t, found)
+
+	// The original RayJob is left untouched
+	jobID, _, _ := unstructured.NestedString(rayJob.Object, "spec", "jobId")
+	assert.Equal(t, "raysubmit_1", jobID)
+	assert.Contains(t, rayJob.GetAnnotations(), submissionIDAnnotation)
+}
@@ -0,0 +1,394 @@
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// runtimeEnvStrategyMerge deep-merges the runtime envs of the RayJob, --runtime-env and --runtime-env-json
+	runtimeEnvStrategyMerge = "merge"
+	// runtimeEnvStrategyOverride uses the whole runtime env of the source with the highest precedence
+	runtimeEnvStrategyOverride = "override"
+)
+
+// pipRequirementName matches the project name at the start of a pip requirement, e.g. torch in torch==2.3.0
+var pipRequirementName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*`)
+
+// runtimeEnvFields are the fields of a Ray runtime env and the check of their values
+var runtimeEnvFields = map[string]func(value interface{}) error{
+	"working_dir":               isString,
+	"py_modules":                isStringList,
+	"py_executable":             isString,
+	"excludes":                  isStringList,
+	"pip":                       isPackages("pip_check", "pip_version", "pip_install_options"),
+	"uv":                        isPackages("uv_check", "uv_version", "uv_pip_install_options"),
+	"conda":                     isStringOrMap,
+	"container":                 isMap,
+	"image_uri":                 isString,
+	"env_vars":                  isStringMap,
+	"worker_process_setup_hook": isString,
+	"config":                    isMap,
+	"nsight":                    isStringOrMap,
+	"mpi":                       isMap,
+	"java_jars":                 isStringList,
+}
+
+// runtimeEnvConflicts are the pairs of fields that Ray does not accept in the same runtime env
+var runtimeEnvConflicts = [][2]string{
+	{"pip", "conda"},
+	{"pip", "uv"},
+	{"uv", "conda"},
+	{"image_uri", "container"},
+}
+
+// runtimeEnvSource is a runtime env and where it was given, for the error messages
+type runtimeEnvSource struct {
+	runtimeEnv map[string]interface{}
+	name       string
+}
+
+// resolveRuntimeEnv combines the runtimeEnvYAML of the RayJob, --runtime-env and --runtime-env-json, in increasing order
+// of precedence, according to --runtime-env-strategy, and validates the result.
+func (options *SubmitJobOptions) resolveRuntimeEnv(rayJobRuntimeEnvYaml string) (map[string]interface{}, error) {
+	sources, err := options.runtimeEnvSources(rayJobRuntimeEnvYaml)
+	if err != nil {
+		return nil, err
+	}
+	runtimeEnv := map[string]interface{}{}
+	for _, source := range sources {
+		if options.runtimeEnvStrategy == runtimeEnvStrategyOverride {
+			runtimeEnv = source.runtimeEnv
+		} else {
+			runtimeEnv = mergeRuntimeEnvs(runtimeEnv, source.runtimeEnv)
+		}
+	}
+	if err := validateRuntimeEnv(runtimeEnv); err != nil {
+		return nil, err
+	}
+	return runtimeEnv, nil
+}
+
+// applyRuntimeEnv resolves the runtime env of the Ray job. The runtime env file is passed as is to `ray job submit` if it
+// is the only source, otherwise the resolved runtime env is passed with --runtime-env-json.
+func (options *SubmitJobOptions) applyRuntimeEnv(rayJobRuntimeEnvYaml string) error {
+	runtimeEnv, err := options.resolveRuntimeEnv(rayJobRuntimeEnvYaml)
+	if err != nil {
+		return err
+	}
+	if rayJobRuntimeEnvYaml == "" && options.runtimeEnvJson == "" {
+		return nil
+	}
+	runtimeEnvJson, err := json.Marshal(runtimeEnv)
+	if err != nil {
+		return fmt.Errorf("failed to convert runtime env to json: %w", err)
+	}
+	options.runtimeEnvJson = string(runtimeEnvJson)
+	// `ray job submit` does not accept both a runtime env file and JSON
+	options.runtimeEnv = ""
+	return nil
+}
+
+// runtimeEnvSources reads the runtime envs that are given, in increasing order of precedence
+func (options *SubmitJobOptions) runtimeEnvSources(rayJobRuntimeEnvYaml string) ([]runtimeEnvSource, error) {
+	var sources []runtimeEnvSource
+	if rayJobRuntimeEnvYaml != "" {
+		runtimeEnv := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(rayJobRuntimeEnvYaml), &runtimeEnv); err != nil {
+			return nil, fmt.Errorf("failed to parse runtimeEnvYAML of the RayJob: %w", err)
+		}
+		sources = append(sources, runtimeEnvSource{name: "runtimeEnvYAML of the RayJob", runtimeEnv: runtimeEnv})
+	}
+	if options.runtimeEnv != "" {
+		runtimeEnvYaml, err := os.ReadFile(options.runtimeEnv)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read runtime env file: %w", err)
+		}
+		runtimeEnv := map[string]interface{}{}
+		if err := yaml.Unmarshal(runtimeEnvYaml, &runtimeEnv); err != nil {
+			return nil, fmt.Errorf("failed to parse runtime env file: %w", err)
+		}
+		sources = append(sources, runtimeEnvSource{name: "--runtime-env", runtimeEnv: runtimeEnv})
+	}
+	if options.runtimeEnvJson != "" {
+		runtimeEnv := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(options.runtimeEnvJson), &runtimeEnv); err != nil {
+			return nil, fmt.Errorf("failed to parse runtime env JSON: %w", err)
+		}
+		sources = append(sources, runtimeEnvSource{name: "--runtime-env-json", runtimeEnv: runtimeEnv})
+	}
+	return sources, nil
+}
+
+// mergeRuntimeEnvs deep-merges overlay into base and returns the result. Maps such as env_vars are merged key by key,
+// lists such as pip are concatenated without duplicates, and any other value of overlay overrides the one of base.
+// A pip or uv requirement of overlay replaces the requirement of base for the same package, e.g. torch==2.3.0 replaces torch.
+func mergeRuntimeEnvs(base, overlay map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(overlay))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range overlay {
+		baseValue, ok := merged[key]
+		if !ok {
+			merged[key] = value
+			continue
+		}
+		if key == "pip" || key == "uv" {
+			merged[key] = mergePackages(baseValue, value)
+			continue
+		}
+		merged[key] = mergeRuntimeEnvValues(baseValue, value, concatLists)
+	}
+	return merged
+}
+
+func mergeRuntimeEnvValues(base, overlay interface{}, mergeLists func(base, overlay []interface{}) []interface{}) interface{} {
+	switch overlayValue := overlay.(type) {
+	case map[string]interface{}:
+		if baseValue, ok := base.(map[string]interface{}); ok {
+			merged := make(map[string]interface{}, len(baseValue)+len(overlayValue))
+			for key, value := range baseValue {
+				merged[key] = value
+			}
+			for key, value := range overlayValue {
+				if baseItem, ok := merged[key]; ok {
+					merged[key] = mergeRuntimeEnvValues(baseItem, value, mergeLists)
+				} else {
+					merged[key] = value
+				}
+			}
+			return merged
+		}
+	case []interface{}:
+		if baseValue, ok := base.([]interface{}); ok {
+			return mergeLists(baseValue, overlayValue)
+		}
+	}
+	return overlay
+}
+
+// mergePackages merges the pip or uv field of two runtime envs. A list of packages is merged into the packages of a map.
+func mergePackages(base, overlay interface{}) interface{} {
+	if packages, ok := base.([]interface{}); ok {
+		if _, ok := overlay.(map[string]interface{}); ok {
+			base = map[string]interface{}{"packages": packages}
+		}
+	}
+	if packages, ok := overlay.([]interface{}); ok {
+		if _, ok := base.(map[string]interface{}); ok {
+			overlay = map[string]interface{}{"packages": packages}
+		}
+	}
+	return mergeRuntimeEnvValues(base, overlay, mergeRequirements)
+}
+
+// concatLists appends the items of overlay that are not in base
+func concatLists(base, overlay []interface{}) []interface{} {
+	merged := append([]interface{}{}, base...)
+	for _, item := range overlay {
+		if !containsValue(merged, item) {
+			merged = append(merged, item)
+		}
+	}
+	return merged
+}
+
+// mergeRequirements concatenates two lists of pip requirements, replacing the requirements of base for the packages of overlay
+func mergeRequirements(base, overlay []interface{}) []interface{} {
+	overridden := map[string]bool{}
+	for _, item := range overlay {
+		if name := requirementName(item); name != "" {
+			overridden[name] = true
+		}
+	}
+	var merged []interface{}
+	for _, item := range base {
+		if name := requirementName(item); name == "" || !overridden[name] {
+			merged = append(merged, item)
+		}
+	}
+	return concatLists(merged, overlay)
+}
+
+// requirementName returns the normalized package name of a pip requirement, or an empty string for options such as
+// -r requirements.txt and URLs, which are only de-duplicated
+func requirementName(item interface{}) string {
+	requirement, ok := item.(string)
+	if !ok || strings.HasPrefix(requirement, "-") || strings.Contains(requirement, "://") {
+		return ""
+	}
+	name := pipRequirementName.FindString(strings.TrimSpace(requirement))
+	return strings.ToLower(strings.NewReplacer("_", "-", ".", "-").Replace(name))
+}
+
+func containsValue(items []interface{}, value interface{}) bool {
+	for _, item := range items {
+		if reflect.DeepEqual(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateRuntimeEnv checks the fields of the runtime env against the schema of Ray, so that an invalid runtime env is
+// reported before the RayCluster is created rather than when the Ray job starts
+func validateRuntimeEnv(runtimeEnv map[string]interface{}) error {
+	var problems []string
+	for _, field := range sortedKeys(runtimeEnv) {
+		if strings.HasPrefix(field, "_") {
+			// Private fields of Ray
+			continue
+		}
+		check, ok := runtimeEnvFields[field]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown field %q, supported fields are %s", field, strings.Join(sortedKeys(runtimeEnvFields), ", ")))
+			continue
+		}
+		if err := check(runtimeEnv[field]); err != nil {
+			problems = append(problems, fmt.Sprintf("%s %s", field, err))
+		}
+	}
+	for _, conflict := range runtimeEnvConflicts {
+		_, first := runtimeEnv[conflict[0]]
+		_, second := runtimeEnv[conflict[1]]
+		if first && second {
+			problems = append(problems, fmt.Sprintf("%s and %s cannot both be specified", conflict[0], conflict[1]))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid runtime env: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func isString(value interface{}) error {
+	if _, ok := value.(string); !ok {
+		return fmt.Errorf("must be a string, got %s", jsonType(value))
+	}
+	return nil
+}
+
+func isMap(value interface{}) error {
+	if _, ok := value.(map[string]interface{}); !ok {
+		return fmt.Errorf("must be a map, got %s", jsonType(value))
+	}
+	return nil
+}
+
+func isStringOrMap(value interface{}) error {
+	switch value.(type) {
+	case string, map[string]interface{}:
+		return nil
+	default:
+		return fmt.Errorf("must be a string or a map, got %s", jsonType(value))
+	}
+}
+
+func isStringList(value interface{}) error {
+	items, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("must be a list of strings, got %s", jsonType(value))
+	}
+	for i, item := range items {
+		if _, ok := item.(string); !ok {
+			return fmt.Errorf("must be a list of strings, got %s at index %d", jsonType(item), i)
+		}
+	}
+	return nil
+}
+
+// isStringMap checks env_vars, whose values Ray requires to be strings, e.g. a port must be quoted in YAML
+func isStringMap(value interface{}) error {
+	items, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("must be a map of strings, got %s", jsonType(value))
+	}
+	for _, key := range sortedKeys(items) {
+		if _, ok := items[key].(string); !ok {
+			return fmt.Errorf("value of %s must be a string, got %s", key, jsonType(items[key]))
+		}
+	}
+	return nil
+}
+
+// isPackages returns the check of pip and uv, which are a requirements file, a list of requirements, or a map with the
+// packages and the given options
+func isPackages(checkOption, versionOption, installOptions string) func(value interface{}) error {
+	return func(value interface{}) error {
+		switch packages := value.(type) {
+		case string:
+			return nil
+		case []interface{}:
+			return isStringList(packages)
+		case map[string]interface{}:
+			requirements, ok := packages["packages"]
+			if !ok {
+				return fmt.Errorf("must set packages")
+			}
+			if _, ok := requirements.(string); !ok {
+				if err := isStringList(requirements); err != nil {
+					return fmt.Errorf("packages %w", err)
+				}
+			}
+			for _, key := range sortedKeys(packages) {
+				var err error
+				switch key {
+				case "packages":
+				case checkOption:
+					if _, ok := packages[key].(bool); !ok {
+						err = fmt.Errorf("must be a boolean, got %s", jsonType(packages[key]))
+					}
+				case versionOption:
+					err = isString(packages[key])
+				case installOptions:
+					err = isStringList(packages[key])
+				default:
+					err = fmt.Errorf("is not supported")
+				}
+				if err != nil {
+					return fmt.Errorf("%s %w", key, err)
+				}
+			}
+			return nil
+		default:
+			return fmt.Errorf("must be a string, a list of strings or a map, got %s", jsonType(value))
+		}
+	}
+}
+
+// jsonType returns the JSON type of a value decoded from YAML or JSON
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64, int64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "map"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
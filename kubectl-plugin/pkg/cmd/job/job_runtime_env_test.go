@@ -0,0 +1,204 @@
+package job
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestMergeRuntimeEnvs(t *testing.T) {
+	base := map[string]interface{}{
+		"pip":         []interface{}{"requests", "torch==2.2.0", "-r requirements.txt"},
+		"env_vars":    map[string]interface{}{"LOG_LEVEL": "info", "HF_HOME": "/data"},
+		"working_dir": "s3://bucket/old.zip",
+		"excludes":    []interface{}{"*.ckpt"},
+	}
+	overlay := map[string]interface{}{
+		"pip":         []interface{}{"Torch==2.3.0", "emoji", "-r requirements.txt"},
+		"env_vars":    map[string]interface{}{"LOG_LEVEL": "debug"},
+		"working_dir": "s3://bucket/new.zip",
+		"excludes":    []interface{}{"*.ckpt", "data/"},
+		"config":      map[string]interface{}{"setup_timeout_seconds": float64(600)},
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"pip":         []interface{}{"requests", "-r requirements.txt", "Torch==2.3.0", "emoji"},
+		"env_vars":    map[string]interface{}{"LOG_LEVEL": "debug", "HF_HOME": "/data"},
+		"working_dir": "s3://bucket/new.zip",
+		"excludes":    []interface{}{"*.ckpt", "data/"},
+		"config":      map[string]interface{}{"setup_timeout_seconds": float64(600)},
+	}, mergeRuntimeEnvs(base, overlay))
+	// The inputs are not modified
+	assert.Equal(t, map[string]interface{}{"LOG_LEVEL": "info", "HF_HOME": "/data"}, base["env_vars"])
+}
+
+func TestMergeRuntimeEnvsPackages(t *testing.T) {
+	// A list of packages is merged into the packages of a map
+	assert.Equal(t, map[string]interface{}{
+		"pip": map[string]interface{}{"packages": []interface{}{"requests", "emoji"}, "pip_check": false},
+	}, mergeRuntimeEnvs(
+		map[string]interface{}{"pip": []interface{}{"requests"}},
+		map[string]interface{}{"pip": map[string]interface{}{"packages": []interface{}{"emoji"}, "pip_check": false}},
+	))
+	// A requirements file is replaced
+	assert.Equal(t, map[string]interface{}{"uv": []interface{}{"emoji"}}, mergeRuntimeEnvs(
+		map[string]interface{}{"uv": "requirements.txt"},
+		map[string]interface{}{"uv": []interface{}{"emoji"}},
+	))
+}
+
+func TestResolveRuntimeEnv(t *testing.T) {
+	runtimeEnvFile := filepath.Join(t.TempDir(), "runtime-env.yaml")
+	assert.Nil(t, os.WriteFile(runtimeEnvFile, []byte("pip:\n- torch==2.3.0\nenv_vars:\n  LOG_LEVEL: info\n"), 0o600))
+	rayJobRuntimeEnvYaml := "pip:\n- requests\n- torch\nenv_vars:\n  LOG_LEVEL: warning\n  HF_HOME: /data\n"
+
+	tests := []struct {
+		name         string
+		strategy     string
+		runtimeEnv   string
+		json         string
+		rayJobYaml   string
+		expectedJson string
+		expectError  string
+	}{
+		{
+			name:         "no runtime env",
+			expectedJson: `{}`,
+		},
+		{
+			name:         "runtime envs are merged in increasing order of precedence",
+			runtimeEnv:   runtimeEnvFile,
+			json:         `{"env_vars": {"LOG_LEVEL": "debug"}}`,
+			rayJobYaml:   rayJobRuntimeEnvYaml,
+			expectedJson: `{"pip": ["requests", "torch==2.3.0"], "env_vars": {"LOG_LEVEL": "debug", "HF_HOME": "/data"}}`,
+		},
+		{
+			name:         "merge is the default strategy",
+			strategy:     runtimeEnvStrategyMerge,
+			json:         `{"pip": ["emoji"]}`,
+			rayJobYaml:   rayJobRuntimeEnvYaml,
+			expectedJson: `{"pip": ["requests", "torch", "emoji"], "env_vars": {"LOG_LEVEL": "warning", "HF_HOME": "/data"}}`,
+		},
+		{
+			name:         "the runtime env with the highest precedence overrides the others",
+			strategy:     runtimeEnvStrategyOverride,
+			runtimeEnv:   runtimeEnvFile,
+			rayJobYaml:   rayJobRuntimeEnvYaml,
+			expectedJson: `{"pip": ["torch==2.3.0"], "env_vars": {"LOG_LEVEL": "info"}}`,
+		},
+		{
+			name:        "invalid runtime env of the RayJob",
+			rayJobYaml:  "pip: [",
+			expectError: "failed to parse runtimeEnvYAML of the RayJob",
+		},
+		{
+			name:        "invalid merged runtime env",
+			json:        `{"conda": "environment.yml"}`,
+			rayJobYaml:  rayJobRuntimeEnvYaml,
+			expectError: "invalid runtime env: pip and conda cannot both be specified",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+			fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+			fakeSubmitJobOptions.runtimeEnvStrategy = tc.strategy
+			fakeSubmitJobOptions.runtimeEnv = tc.runtimeEnv
+			fakeSubmitJobOptions.runtimeEnvJson = tc.json
+
+			runtimeEnv, err := fakeSubmitJobOptions.resolveRuntimeEnv(tc.rayJobYaml)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			assert.Nil(t, err)
+			expected := map[string]interface{}{}
+			assert.Nil(t, json.Unmarshal([]byte(tc.expectedJson), &expected))
+			assert.Equal(t, expected, runtimeEnv)
+		})
+	}
+}
+
+func TestApplyRuntimeEnv(t *testing.T) {
+	runtimeEnvFile := filepath.Join(t.TempDir(), "runtime-env.yaml")
+	assert.Nil(t, os.WriteFile(runtimeEnvFile, []byte("pip:\n- requests\n"), 0o600))
+	testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+
+	// The runtime env file is passed as is if it is the only source
+	fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+	fakeSubmitJobOptions.runtimeEnv = runtimeEnvFile
+	assert.Nil(t, fakeSubmitJobOptions.applyRuntimeEnv(""))
+	assert.Equal(t, runtimeEnvFile, fakeSubmitJobOptions.runtimeEnv)
+	assert.Empty(t, fakeSubmitJobOptions.runtimeEnvJson)
+
+	// Otherwise the combined runtime env is passed as JSON
+	fakeSubmitJobOptions.runtimeEnvJson = `{"pip": ["emoji"]}`
+	assert.Nil(t, fakeSubmitJobOptions.applyRuntimeEnv("env_vars:\n  LOG_LEVEL: info\n"))
+	assert.Empty(t, fakeSubmitJobOptions.runtimeEnv)
+	assert.JSONEq(t, `{"pip": ["requests", "emoji"], "env_vars": {"LOG_LEVEL": "info"}}`, fakeSubmitJobOptions.runtimeEnvJson)
+}
+
+func TestValidateRuntimeEnv(t *testing.T) {
+	tests := []struct {
+		runtimeEnv  map[string]interface{}
+		name        string
+		expectError string
+	}{
+		{
+			name: "valid runtime env",
+			runtimeEnv: map[string]interface{}{
+				"working_dir":                 "s3://bucket/working-dir.zip",
+				"pip":                         map[string]interface{}{"packages": []interface{}{"requests"}, "pip_check": false, "pip_version": "==23.3.1"},
+				"env_vars":                    map[string]interface{}{"LOG_LEVEL": "info"},
+				"py_modules":                  []interface{}{"s3://bucket/module.zip"},
+				"config":                      map[string]interface{}{"setup_timeout_seconds": float64(600)},
+				"_inject_current_ray_version": true,
+			},
+		},
+		{
+			name:       "requirements file",
+			runtimeEnv: map[string]interface{}{"uv": "requirements.txt"},
+		},
+		{
+			name:        "unknown field",
+			runtimeEnv:  map[string]interface{}{"env": map[string]interface{}{}},
+			expectError: `invalid runtime env: unknown field "env", supported fields are conda, config, container, env_vars, excludes, image_uri, java_jars, mpi, nsight, pip, py_executable, py_modules, uv, worker_process_setup_hook, working_dir`,
+		},
+		{
+			name:        "environment variable that is not a string",
+			runtimeEnv:  map[string]interface{}{"env_vars": map[string]interface{}{"PORT": float64(8080)}},
+			expectError: "invalid runtime env: env_vars value of PORT must be a string, got number",
+		},
+		{
+			name:        "invalid pip options",
+			runtimeEnv:  map[string]interface{}{"pip": map[string]interface{}{"packages": []interface{}{"requests"}, "uv_check": true}},
+			expectError: "invalid runtime env: pip uv_check is not supported",
+		},
+		{
+			name:        "pip packages that are not strings",
+			runtimeEnv:  map[string]interface{}{"pip": []interface{}{"requests", float64(1)}},
+			expectError: "invalid runtime env: pip must be a list of strings, got number at index 1",
+		},
+		{
+			name:        "several problems",
+			runtimeEnv:  map[string]interface{}{"working_dir": []interface{}{"."}, "image_uri": "rayproject/ray", "container": map[string]interface{}{}},
+			expectError: "invalid runtime env: working_dir must be a string, got list; image_uri and container cannot both be specified",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateRuntimeEnv(tc.runtimeEnv)
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
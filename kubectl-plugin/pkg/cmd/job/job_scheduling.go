@@ -0,0 +1,159 @@
+package job
+
+import (
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+)
+
+// hasSchedulingFlags reports whether --priority-class, --node-selector or --toleration is given
+func (options *SubmitJobOptions) hasSchedulingFlags() bool {
+	return options.priorityClassName != "" || len(options.nodeSelectorArgs) > 0 || len(options.tolerationArgs) > 0
+}
+
+// parseSchedulingFlags parses the values of --node-selector and --toleration
+func (options *SubmitJobOptions) parseSchedulingFlags() error {
+	var err error
+	if options.nodeSelector, err = util.ParseKeyValues("node-selector", options.nodeSelectorArgs); err != nil {
+		return err
+	}
+	if options.tolerations, err = util.ParseTolerations(options.tolerationArgs); err != nil {
+		return err
+	}
+	return nil
+}
+
+// applySchedulingFlags sets the priority class, node selector and tolerations given with flags on the pod templates of
+// the head and all worker groups of the RayJob. The node selector is merged with the one of each template, the flags
+// taking precedence, and the tolerations are added to the ones of each template.
+func (options *SubmitJobOptions) applySchedulingFlags() error {
+	if !options.hasSchedulingFlags() {
+		return nil
+	}
+	found, err := updateRayClusterPodSpecs(options.RayJob, options.schedulePodSpec)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("--priority-class, --node-selector and --toleration require a RayJob with spec.rayClusterSpec")
+	}
+	return nil
+}
+
+// updateRayClusterPodSpecs replaces the pod specs of the head group template and of each worker group template of the
+// RayJob with the ones returned by update. It returns false if the RayJob has no spec.rayClusterSpec, e.g. because it
+// selects an existing RayCluster.
+func updateRayClusterPodSpecs(rayJob *unstructured.Unstructured, update func(podSpec map[string]interface{}) (map[string]interface{}, error)) (bool, error) {
+	rayClusterSpec, found, err := unstructured.NestedMap(rayJob.Object, "spec", "rayClusterSpec")
+	if err != nil {
+		return false, fmt.Errorf("invalid spec.rayClusterSpec of the RayJob: %w", err)
+	}
+	if !found {
+		return false, nil
+	}
+
+	headPodSpec, _, err := unstructured.NestedMap(rayClusterSpec, "headGroupSpec", "template", "spec")
+	if err != nil {
+		return false, fmt.Errorf("invalid head group template of the RayJob: %w", err)
+	}
+	if headPodSpec, err = update(headPodSpec); err != nil {
+		return false, err
+	}
+	if err := unstructured.SetNestedMap(rayClusterSpec, headPodSpec, "headGroupSpec", "template", "spec"); err != nil {
+		return false, err
+	}
+
+	workerGroupSpecs, _, err := unstructured.NestedSlice(rayClusterSpec, "workerGroupSpecs")
+	if err != nil {
+		return false, fmt.Errorf("invalid worker groups of the RayJob: %w", err)
+	}
+	for i, workerGroupSpec := range workerGroupSpecs {
+		workerGroup, ok := workerGroupSpec.(map[string]interface{})
+		if !ok {
+			return false, fmt.Errorf("invalid worker group %d of the RayJob", i)
+		}
+		workerPodSpec, _, err := unstructured.NestedMap(workerGroup, "template", "spec")
+		if err != nil {
+			return false, fmt.Errorf("invalid template of worker group %d of the RayJob: %w", i, err)
+		}
+		if workerPodSpec, err = update(workerPodSpec); err != nil {
+			return false, err
+		}
+		if err := unstructured.SetNestedMap(workerGroup, workerPodSpec, "template", "spec"); err != nil {
+			return false, err
+		}
+	}
+	if len(workerGroupSpecs) > 0 {
+		if err := unstructured.SetNestedSlice(rayClusterSpec, workerGroupSpecs, "workerGroupSpecs"); err != nil {
+			return false, err
+		}
+	}
+	return true, unstructured.SetNestedMap(rayJob.Object, rayClusterSpec, "spec", "rayClusterSpec")
+}
+
+// schedulePodSpec returns the pod spec with the priority class, node selector and tolerations of the flags
+func (options *SubmitJobOptions) schedulePodSpec(podSpec map[string]interface{}) (map[string]interface{}, error) {
+	if podSpec == nil {
+		podSpec = map[string]interface{}{}
+	}
+	if options.priorityClassName != "" {
+		podSpec["priorityClassName"] = options.priorityClassName
+	}
+
+	if len(options.nodeSelector) > 0 {
+		nodeSelector, _, err := unstructured.NestedStringMap(podSpec, "nodeSelector")
+		if err != nil {
+			return nil, fmt.Errorf("invalid nodeSelector of the RayJob: %w", err)
+		}
+		if nodeSelector == nil {
+			nodeSelector = map[string]string{}
+		}
+		for key, value := range options.nodeSelector {
+			nodeSelector[key] = value
+		}
+		if err := unstructured.SetNestedStringMap(podSpec, nodeSelector, "nodeSelector"); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(options.tolerations) > 0 {
+		tolerations, _, err := unstructured.NestedSlice(podSpec, "tolerations")
+		if err != nil {
+			return nil, fmt.Errorf("invalid tolerations of the RayJob: %w", err)
+		}
+		for _, toleration := range options.tolerations {
+			if containsToleration(tolerations, toleration) {
+				continue
+			}
+			tolerationObject, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&toleration)
+			if err != nil {
+				return nil, err
+			}
+			tolerations = append(tolerations, tolerationObject)
+		}
+		if err := unstructured.SetNestedSlice(podSpec, tolerations, "tolerations"); err != nil {
+			return nil, err
+		}
+	}
+	return podSpec, nil
+}
+
+// containsToleration reports whether the tolerations of a template already contain the toleration
+func containsToleration(tolerations []interface{}, toleration corev1.Toleration) bool {
+	for _, item := range tolerations {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var existing corev1.Toleration
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object, &existing); err == nil && reflect.DeepEqual(existing, toleration) {
+			return true
+		}
+	}
+	return false
+}
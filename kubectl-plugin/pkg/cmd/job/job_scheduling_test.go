@@ -0,0 +1,84 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestApplySchedulingFlags(t *testing.T) {
+	testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+	options := NewJobSubmitOptions(testStreams)
+	options.priorityClassName = "low-priority"
+	options.nodeSelectorArgs = []string{"cloud.google.com/gke-spot=true", "pool=gpu"}
+	options.tolerationArgs = []string{"nvidia.com/gpu:NoSchedule", "spot=true:NoExecute"}
+	assert.Nil(t, options.parseSchedulingFlags())
+	options.RayJob = &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"rayClusterSpec": map[string]interface{}{
+				"headGroupSpec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "ray-head"}}},
+					},
+				},
+				"workerGroupSpecs": []interface{}{
+					map[string]interface{}{
+						"groupName": "gpu",
+						"template": map[string]interface{}{
+							"spec": map[string]interface{}{
+								"priorityClassName": "high-priority",
+								"nodeSelector":      map[string]interface{}{"pool": "cpu", "zone": "us-central1-a"},
+								"tolerations": []interface{}{
+									map[string]interface{}{"key": "nvidia.com/gpu", "operator": "Exists", "effect": "NoSchedule"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+
+	assert.Nil(t, options.applySchedulingFlags())
+	headPodSpec, _, _ := unstructured.NestedMap(options.RayJob.Object, "spec", "rayClusterSpec", "headGroupSpec", "template", "spec")
+	assert.Equal(t, map[string]interface{}{
+		"containers":        []interface{}{map[string]interface{}{"name": "ray-head"}},
+		"priorityClassName": "low-priority",
+		"nodeSelector":      map[string]interface{}{"cloud.google.com/gke-spot": "true", "pool": "gpu"},
+		"tolerations": []interface{}{
+			map[string]interface{}{"key": "nvidia.com/gpu", "operator": "Exists", "effect": "NoSchedule"},
+			map[string]interface{}{"key": "spot", "operator": "Equal", "value": "true", "effect": "NoExecute"},
+		},
+	}, headPodSpec)
+
+	// The node selector of the worker group is merged and its tolerations are not duplicated
+	workerGroupSpecs, _, _ := unstructured.NestedSlice(options.RayJob.Object, "spec", "rayClusterSpec", "workerGroupSpecs")
+	workerPodSpec, _, _ := unstructured.NestedMap(workerGroupSpecs[0].(map[string]interface{}), "template", "spec")
+	assert.Equal(t, map[string]interface{}{
+		"priorityClassName": "low-priority",
+		"nodeSelector":      map[string]interface{}{"cloud.google.com/gke-spot": "true", "pool": "gpu", "zone": "us-central1-a"},
+		"tolerations": []interface{}{
+			map[string]interface{}{"key": "nvidia.com/gpu", "operator": "Exists", "effect": "NoSchedule"},
+			map[string]interface{}{"key": "spot", "operator": "Equal", "value": "true", "effect": "NoExecute"},
+		},
+	}, workerPodSpec)
+}
+
+func TestApplySchedulingFlagsWithClusterSelector(t *testing.T) {
+	testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+	options := NewJobSubmitOptions(testStreams)
+	options.RayJob = &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"clusterSelector": map[string]interface{}{"ray.io/cluster": "raycluster-sample"}},
+	}}
+	// Nothing is applied without scheduling flags
+	assert.Nil(t, options.applySchedulingFlags())
+
+	options.nodeSelectorArgs = []string{"pool=gpu"}
+	assert.Nil(t, options.parseSchedulingFlags())
+	assert.EqualError(t, options.applySchedulingFlags(), "--priority-class, --node-selector and --toleration require a RayJob with spec.rayClusterSpec")
+
+	options.tolerationArgs = []string{"spot=true:Sometimes"}
+	assert.EqualError(t, options.parseSchedulingFlags(), "invalid --toleration \"spot=true:Sometimes\", effect must be one of NoSchedule, PreferNoSchedule or NoExecute")
+}
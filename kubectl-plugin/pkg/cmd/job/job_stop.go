@@ -0,0 +1,132 @@
+package job
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/completion"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/config"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/dashboard"
+)
+
+type JobStopOptions struct {
+	configFlags        *genericclioptions.ConfigFlags
+	ioStreams          *genericiooptions.IOStreams
+	dashboardConn      *dashboard.ConnectionOptions
+	rayJobName         string
+	namespace          string
+	localDashboardPort int
+}
+
+var (
+	jobStopLong = templates.LongDesc(`
+		Stop the Ray job of a RayJob using the Ray Jobs API.
+
+		The RayJob CR and its RayCluster are left untouched. Use 'kubectl ray job delete' to remove them.
+	`)
+
+	jobStopExample = templates.Examples(`
+		# Stop the Ray job submitted for the RayJob
+		kubectl ray job stop my-rayjob
+	`)
+)
+
+func NewJobStopOptions(streams genericiooptions.IOStreams) *JobStopOptions {
+	return &JobStopOptions{
+		configFlags:   genericclioptions.NewConfigFlags(true),
+		ioStreams:     &streams,
+		dashboardConn: &dashboard.ConnectionOptions{},
+	}
+}
+
+func NewJobStopCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	options := NewJobStopOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:               "stop (RAYJOB)",
+		Short:             "Stop the Ray job of a RayJob",
+		Long:              jobStopLong,
+		Example:           jobStopExample,
+		SilenceUsage:      true,
+		ValidArgsFunction: completion.RayJobCompletionFunc(cmdFactory),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	cmd.Flags().IntVar(&options.localDashboardPort, "dashboard-port", options.localDashboardPort, "Local port used to forward the Ray dashboard. If not set, a free local port is selected automatically")
+	config.BindFlag(cmd.Flags(), "dashboard-port", config.DashboardPort)
+	options.dashboardConn.AddFlags(cmd.Flags())
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *JobStopOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return cmdutil.UsageErrorf(cmd, "%s", cmd.Use)
+	}
+	options.rayJobName = args[0]
+
+	if *options.configFlags.Namespace == "" {
+		options.namespace = "default"
+	} else {
+		options.namespace = *options.configFlags.Namespace
+	}
+
+	if options.localDashboardPort == 0 {
+		freePort, err := util.GetFreeLocalPort()
+		if err != nil {
+			return fmt.Errorf("failed to find a free local port for the Ray dashboard: %w", err)
+		}
+		options.localDashboardPort = freePort
+	}
+	return nil
+}
+
+func (options *JobStopOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	if options.localDashboardPort < 0 || options.localDashboardPort > 65535 {
+		return fmt.Errorf("dashboard port %d is out of range, must be between 0 and 65535", options.localDashboardPort)
+	}
+	return options.dashboardConn.Validate()
+}
+
+func (options *JobStopOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	k8sClient, err := client.NewClient(factory)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	dashboardClient, submissionID, cancel, err := connectToRayJobDashboard(ctx, factory, *options.ioStreams, k8sClient, options.namespace, options.rayJobName, options.localDashboardPort, options.dashboardConn)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if err := dashboardClient.StopJob(ctx, submissionID); err != nil {
+		return fmt.Errorf("failed to stop Ray job %s: %w", submissionID, err)
+	}
+	fmt.Fprintf(options.ioStreams.Out, "Stopped Ray job %s of RayJob %s\n", submissionID, options.rayJobName)
+	return nil
+}
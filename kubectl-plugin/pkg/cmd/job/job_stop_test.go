@@ -0,0 +1,28 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+func TestRayJobStopComplete(t *testing.T) {
+	cmd := &cobra.Command{Use: "stop"}
+	testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+
+	fakeJobStopOptions := NewJobStopOptions(testStreams)
+	namespace := "test-namespace"
+	fakeJobStopOptions.configFlags.Namespace = &namespace
+	fakeJobStopOptions.localDashboardPort = 18265
+
+	err := fakeJobStopOptions.Complete(cmd, []string{"test-rayjob"})
+	assert.Nil(t, err)
+	assert.Equal(t, "test-rayjob", fakeJobStopOptions.rayJobName)
+	assert.Equal(t, "test-namespace", fakeJobStopOptions.namespace)
+	assert.Equal(t, 18265, fakeJobStopOptions.localDashboardPort)
+
+	err = fakeJobStopOptions.Complete(cmd, []string{})
+	assert.NotNil(t, err)
+}
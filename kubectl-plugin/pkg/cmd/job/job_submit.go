@@ -3,24 +3,27 @@ package job
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
-	"net/http"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
-	"k8s.io/apimachinery/pkg/api/meta"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	k8syaml "k8s.io/apimachinery/pkg/runtime/serializer/yaml"
+	"k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
-	"k8s.io/kubectl/pkg/cmd/portforward"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/templates"
 	"sigs.k8s.io/yaml"
@@ -28,23 +31,61 @@ import (
 	"github.com/google/shlex"
 	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
 	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/config"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/dashboard"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/generation"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/image"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/printer"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/progress"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/validation"
 	"github.com/spf13/cobra"
 
 	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 )
 
 const (
-	dashboardAddr      = "http://localhost:8265"
-	clusterTimeout     = 120.0
-	portforwardtimeout = 60.0
+	defaultSubmitTimeout = 5 * time.Minute
+	// pendingPodsReportInterval is how often the pods and events of the RayCluster are checked while waiting for it
+	pendingPodsReportInterval = 5 * time.Second
+	// submissionIDAnnotation records the Ray job submission ID on the RayJob CR
+	submissionIDAnnotation = "ray.io/ray-job-submission-id"
+	// jobStatusAnnotation records the status of the Ray job when `ray job submit` completed
+	jobStatusAnnotation = "ray.io/ray-job-status"
+	// driverExitCodeAnnotation records the exit code of the Ray job driver, which Ray reports since 2.9
+	driverExitCodeAnnotation = "ray.io/ray-job-driver-exit-code"
+	// failureMessageAnnotation records why the Ray job did not succeed
+	failureMessageAnnotation = "ray.io/ray-job-failure-message"
+	// dashboardURLAnnotation records the address of the Ray dashboard within the Kubernetes cluster
+	dashboardURLAnnotation = "ray.io/ray-job-dashboard-url"
+	// pinnedImagesAnnotation records the original image of each image pinned to a digest with --pin-images, as JSON
+	pinnedImagesAnnotation = "ray.io/pinned-images"
+	// maxFailureMessageLength limits the failure message, which can contain a whole stack trace, in the annotation
+	maxFailureMessageLength = 4096
+	// interactiveMode is not available in the ray-operator API version the plugin depends on
+	interactiveMode rayv1api.JobSubmissionMode = "InteractiveMode"
+)
+
+// Exit codes of `job submit --wait-until-complete`. Any other error exits with 1.
+const (
+	// ExitCodeJobFailed is returned when the Ray job ends in any other status than SUCCEEDED
+	ExitCodeJobFailed = 2
+	// ExitCodeJobTimeout is returned when the Ray job does not complete before --timeout
+	ExitCodeJobTimeout = 3
 )
 
 type SubmitJobOptions struct {
 	ioStreams          *genericiooptions.IOStreams
 	configFlags        *genericclioptions.ConfigFlags
+	outputFlags        *printer.OutputFlags
 	RayJob             *unstructured.Unstructured
+	progress           *progress.Reporter
+	dashboardConn      *dashboard.ConnectionOptions
+	env                map[string]string
+	nodeSelector       map[string]string
 	submissionID       string
 	entryPoint         string
+	entryPointScript   string
 	fileName           string
 	workingDir         string
 	runtimeEnv         string
@@ -52,14 +93,56 @@ type SubmitJobOptions struct {
 	verify             string
 	cluster            string
 	runtimeEnvJson     string
+	runtimeEnvStrategy string
 	entryPointResource string
 	metadataJson       string
 	logStyle           string
 	logColor           string
+	rayJobName         string
+	rayVersion         string
+	submissionMode     string
+	image              string
+	headCPU            string
+	headMemory         string
+	workerCPU          string
+	workerMemory       string
+	workerGPU          string
+	workingDirWarnSize string
+	workingDirMaxSize  string
+	progressMode       string
+	priorityClassName  string
+	envArgs            []string
+	envFromSecretArgs  []string
+	secretEnvSources   []secretEnvSource
+	nodeSelectorArgs   []string
+	tolerationArgs     []string
+	tolerations        []corev1.Toleration
 	entryPointCPU      float32
 	entryPointGPU      float32
 	entryPointMemory   int
+	localDashboardPort int
+	timeout            time.Duration
+	deadline           time.Time
+	workerReplicas     int32
 	noWait             bool
+	dryRun             bool
+	waitUntilComplete  bool
+	zipWorkingDir      bool
+	validate           bool
+	override           bool
+	namespaceFromFlag  bool
+	pinImages          bool
+	k8sJobMode         bool
+	// stageEntryPointScript is set if the --entrypoint-script is outside of the working directory
+	stageEntryPointScript bool
+}
+
+// submitResult is the machine-readable output of a submitted Ray job
+type submitResult struct {
+	RayJob       map[string]interface{} `json:"rayJob,omitempty"`
+	SubmissionID string                 `json:"submissionId"`
+	RayCluster   string                 `json:"rayCluster"`
+	DashboardURL string                 `json:"dashboardUrl"`
 }
 
 type RayJob struct {
@@ -69,9 +152,63 @@ type RayJob struct {
 var (
 	jobSubmitLong = templates.LongDesc(`
 		Submit ray job to ray cluster as one would using ray CLI e.g. 'ray job submit ENTRYPOINT'. Command supports all options that 'ray job submit' supports, except '--address'.
-		If RayCluster is already setup, use 'kubectl ray session' instead.
 
-		Command will apply RayJob CR and also submit the ray job. RayJob CR is required.
+		Command will apply RayJob CR and also submit the ray job. If no RayJob YAML file is provided with '-f', an InteractiveMode RayJob CR
+		is generated from the cluster flags such as '--image', '--head-cpu' and '--worker-replicas'.
+
+		The RayJob CR is created in the namespace of the RayJob YAML file if it sets one, unless a different namespace is given
+		with '--namespace'.
+
+		If a RayCluster is already running, use '--ray-cluster' to submit the ray job directly to it without creating a RayJob CR.
+		'--cluster' is the kubeconfig flag that selects the Kubernetes cluster, not the RayCluster.
+
+		A K8sJobMode RayJob, from the RayJob YAML file or generated with '--submission-mode K8sJobMode', is submitted by a
+		submitter Kubernetes Job created by the KubeRay operator instead of the local 'ray' CLI. The entrypoint and the
+		'ray job submit' flags are written to the RayJob spec and the logs of the submitter pod are streamed unless '--no-wait'
+		is given. The working directory must be a remote URI in the runtime env, as nothing is uploaded from the local machine.
+
+		The RayJob YAML file may set 'metadata.generateName' instead of 'metadata.name' to create a RayJob with a unique name
+		on every submission. If a RayJob with the same name already exists, the submission fails unless its Ray job has
+		completed and '--override' is given, in which case the completed RayJob is deleted and created again.
+
+		The size of a local working directory is estimated before submitting, excluding the files matched by the
+		gitignore-style patterns in its .gitignore and .rayignore files. A warning is printed above '--working-dir-warn-size'
+		and the submission fails above '--working-dir-max-size'. Use '--zip-working-dir' to upload a zip file of the
+		working directory without the excluded files.
+
+		For one-file experiments, '--entrypoint-script' runs a local Python file with the arguments given after '--'. The
+		script is staged into '--working-dir', or into a temporary working directory if none is given, and removed
+		after the submission.
+
+		The runtime envs of the RayJob CR, '--runtime-env' and '--runtime-env-json' are combined in this increasing order
+		of precedence. With '--runtime-env-strategy merge', the default, they are deep-merged: 'env_vars' and other maps are
+		merged key by key, lists such as 'pip' are concatenated, a pip requirement replaces the one of the same package,
+		and any other field overrides the one of a lower precedence. With '--runtime-env-strategy override', the runtime
+		env with the highest precedence is used as a whole. The resulting runtime env is validated against the runtime
+		env fields of Ray before anything is created.
+
+		'--priority-class', '--node-selector' and '--toleration' are applied to the head and all worker Pod templates of
+		the RayJob CR, whether it is generated or read from a file, to run ad-hoc jobs on specific node pools without
+		editing the YAML. The node selector is merged with the one of each template and the tolerations are added to
+		the ones of each template. A toleration is given as KEY[=VALUE][:EFFECT].
+
+		With '--pin-images', the image tags of the head and worker groups of the RayJob CR are resolved to digests before
+		the RayJob CR is created, so that the experiment can be reproduced with the same images. The digest of a tag is
+		asked to its registry, or looked up in the images pulled by the nodes if the registry requires credentials. The
+		original images are recorded in the 'ray.io/pinned-images' annotation of the RayJob CR.
+
+		Environment variables given with '--env' and '--env-from-secret' are merged into the 'env_vars' of the runtime env,
+		so that credentials do not have to be stored in runtime env files. Secret values are read with your credentials.
+
+		When 'ray job submit' completes, the RayJob CR is annotated with the submission ID, the status, the driver exit
+		code, the failure message and the in-cluster dashboard URL of the Ray job, so that they remain available after
+		the Ray cluster is deleted.
+
+		With '--wait-until-complete', the command waits until the RayJob CR reports a terminal job status and exits with:
+		  0: the Ray job SUCCEEDED
+		  1: the command failed, e.g. the RayJob CR could not be created
+		  2: the Ray job FAILED or was STOPPED
+		  3: the Ray job did not complete before '--timeout'
 	`)
 
 	jobSubmitExample = templates.Examples(`
@@ -81,15 +218,68 @@ var (
 		# Submit ray job with runtime Env file and working directory
 		kubectl ray job submit -f rayjob.yaml --working-dir /path/to/working-dir/ --runtime-env /runtimeEnv.yaml -- python my_script.py
 
+		# Submit a local Python script without preparing a working directory
+		kubectl ray job submit -f rayjob.yaml --entrypoint-script train.py -- --epochs 10
+
 		# Submit ray job with runtime Env file assuming runtime-env has working_dir set
 		kubectl ray job submit -f rayjob.yaml --runtime-env path/to/runtimeEnv.yaml -- python my_script.py
+
+		# Add a pip package and an environment variable to the runtime env of the RayJob CR
+		kubectl ray job submit -f rayjob.yaml --working-dir /path/to/working-dir/ --runtime-env-json '{"pip": ["emoji"], "env_vars": {"LOG_LEVEL": "debug"}}' -- python my_script.py
+
+		# Replace the runtime env of the RayJob CR instead of merging it
+		kubectl ray job submit -f rayjob.yaml --working-dir /path/to/working-dir/ --runtime-env runtimeEnv.yaml --runtime-env-strategy override -- python my_script.py
+
+		# Generate the RayJob CR from flags and submit ray job
+		kubectl ray job submit --name rayjob-sample --ray-version 2.37.0 --worker-replicas 2 --worker-gpu 1 --working-dir /path/to/working-dir/ -- python my_script.py
+
+		# Run the Ray job on spot GPU nodes with a low priority
+		kubectl ray job submit -f rayjob.yaml --priority-class low-priority --node-selector cloud.google.com/gke-spot=true --toleration nvidia.com/gpu:NoSchedule --working-dir /path/to/working-dir/ -- python my_script.py
+
+		# Pin the images of the RayJob CR to digests to reproduce the experiment later
+		kubectl ray job submit -f rayjob.yaml --pin-images --working-dir /path/to/working-dir/ -- python my_script.py
+
+		# Print the generated RayJob CR without creating it
+		kubectl ray job submit --name rayjob-sample --worker-replicas 2 --dry-run --working-dir /path/to/working-dir/ -- python my_script.py
+
+		# Generate a K8sJobMode RayJob CR whose Ray job is submitted by the KubeRay operator and stream the submitter logs
+		kubectl ray job submit --name rayjob-sample --submission-mode K8sJobMode --working-dir s3://bucket/working-dir.zip -- python my_script.py
+
+		# Submit ray job to an existing RayCluster without creating a RayJob CR
+		kubectl ray job submit --ray-cluster raycluster-sample --working-dir /path/to/working-dir/ -- python my_script.py
+
+		# Submit ray job and forward the Ray dashboard to local port 18265 instead of a randomly selected free port
+		kubectl ray job submit -f rayjob.yaml --working-dir /path/to/working-dir/ --dashboard-port 18265 -- python my_script.py
+
+		# Submit ray job and print the created RayJob CR, submission ID and dashboard URL as JSON, e.g. in CI pipelines
+		kubectl ray job submit -f rayjob.yaml --working-dir /path/to/working-dir/ --no-wait -o json -- python my_script.py
+
+		# Submit ray job with an environment variable and all keys of a Secret as environment variables
+		kubectl ray job submit -f rayjob.yaml --working-dir /path/to/working-dir/ --env LOG_LEVEL=debug --env-from-secret hf-token -- python my_script.py
+
+		# Submit ray job with a single key of a Secret as environment variable
+		kubectl ray job submit -f rayjob.yaml --working-dir /path/to/working-dir/ --env-from-secret aws-credentials:AWS_SECRET_ACCESS_KEY -- python my_script.py
+
+		# Submit ray job with a zipped working directory, allowing up to 1Gi of files
+		kubectl ray job submit -f rayjob.yaml --working-dir /path/to/working-dir/ --zip-working-dir --working-dir-max-size 1Gi -- python my_script.py
+
+		# Submit ray job and replace the RayJob CR of a previous, completed submission with the same name
+		kubectl ray job submit -f rayjob.yaml --working-dir /path/to/working-dir/ --override -- python my_script.py
+
+		# Submit ray job and exit with a non-zero code if it does not succeed within an hour, e.g. in CI pipelines
+		kubectl ray job submit -f rayjob.yaml --working-dir /path/to/working-dir/ --no-wait --wait-until-complete --timeout 1h -- python my_script.py
 	`)
 )
 
 func NewJobSubmitOptions(streams genericiooptions.IOStreams) *SubmitJobOptions {
 	return &SubmitJobOptions{
-		ioStreams:   &streams,
-		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:      &streams,
+		configFlags:    genericclioptions.NewConfigFlags(true),
+		outputFlags:    printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
+		dashboardConn:  &dashboard.ConnectionOptions{},
+		timeout:        defaultSubmitTimeout,
+		submissionMode: string(interactiveMode),
+		validate:       true,
 	}
 }
 
@@ -98,13 +288,15 @@ func NewJobSubmitCommand(streams genericclioptions.IOStreams) *cobra.Command {
 	cmdFactory := cmdutil.NewFactory(options.configFlags)
 
 	cmd := &cobra.Command{
-		Use:     "submit [OPTIONS] -f/--filename RAYJOB_YAML -- ENTRYPOINT",
+		Use:     "submit [OPTIONS] [-f/--filename RAYJOB_YAML] -- ENTRYPOINT",
 		Short:   "Submit ray job to ray cluster",
 		Long:    jobSubmitLong,
 		Example: jobSubmitExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			entryPointStart := cmd.ArgsLenAtDash()
-			options.entryPoint = strings.Join(args[entryPointStart:], " ")
+			if entryPointStart := cmd.ArgsLenAtDash(); entryPointStart >= 0 {
+				options.entryPoint = strings.Join(args[entryPointStart:], " ")
+			}
+			options.namespaceFromFlag = cmd.Flags().Changed("namespace")
 			if err := options.Complete(); err != nil {
 				return err
 			}
@@ -115,26 +307,62 @@ func NewJobSubmitCommand(streams genericclioptions.IOStreams) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&options.fileName, "filename", "f", options.fileName, "Path and name of the Ray Job YAML file")
+	cmd.Flags().StringVar(&options.cluster, "ray-cluster", options.cluster, "Name of an existing RayCluster to submit the ray job to. No RayJob CR is created when set")
+	cmd.Flags().StringVar(&options.rayJobName, "name", options.rayJobName, "Name of the generated RayJob CR. Only used when no RayJob YAML file is provided. If not provided, one will be generated")
+	cmd.Flags().StringVar(&options.submissionMode, "submission-mode", options.submissionMode, "Submission mode of the generated RayJob CR, InteractiveMode or K8sJobMode")
+	cmd.Flags().StringVar(&options.rayVersion, "ray-version", generation.DefaultRayVersion, "Ray version to use for the generated RayJob CR")
+	cmd.Flags().StringVar(&options.image, "image", options.image, "Container image to use for the generated RayJob CR. Defaults to rayproject/ray:<ray-version>")
+	config.BindFlag(cmd.Flags(), "image", config.Image)
+	cmd.Flags().StringVar(&options.headCPU, "head-cpu", "2", "Number of CPUs in the Ray head of the generated RayJob CR")
+	cmd.Flags().StringVar(&options.headMemory, "head-memory", "4Gi", "Amount of memory in the Ray head of the generated RayJob CR")
+	cmd.Flags().Int32Var(&options.workerReplicas, "worker-replicas", 1, "Number of worker replicas in the generated RayJob CR")
+	cmd.Flags().StringVar(&options.workerCPU, "worker-cpu", "2", "Number of CPUs in each worker of the generated RayJob CR")
+	cmd.Flags().StringVar(&options.workerMemory, "worker-memory", "4Gi", "Amount of memory in each worker of the generated RayJob CR")
+	cmd.Flags().StringVar(&options.workerGPU, "worker-gpu", "0", "Number of GPUs in each worker of the generated RayJob CR")
+	cmd.Flags().BoolVar(&options.pinImages, "pin-images", options.pinImages, "If present, pin the images of the RayJob CR to the digests of their tags before creating it, and record the original images in the ray.io/pinned-images annotation")
+	cmd.Flags().StringVar(&options.priorityClassName, "priority-class", options.priorityClassName, "PriorityClass of the head and worker Pods of the RayJob CR")
+	cmd.Flags().StringArrayVar(&options.nodeSelectorArgs, "node-selector", options.nodeSelectorArgs, "Node selector of the head and worker Pods of the RayJob CR as KEY=VALUE. Can be repeated")
+	cmd.Flags().StringArrayVar(&options.tolerationArgs, "toleration", options.tolerationArgs, "Toleration of the head and worker Pods of the RayJob CR as KEY[=VALUE][:EFFECT], e.g. nvidia.com/gpu:NoSchedule. Can be repeated")
+	cmd.Flags().BoolVar(&options.dryRun, "dry-run", options.dryRun, "If present, print the generated RayJob CR and exit without creating it")
+	cmd.Flags().BoolVar(&options.validate, "validate", options.validate, "If true, validate the RayJob CR against the schema of the RayJob CRD in the cluster before creating it")
+	cmd.Flags().BoolVar(&options.override, "override", options.override, "If present, delete an existing RayJob CR with the same name whose Ray job has completed and create it again")
+	cmd.Flags().BoolVar(&options.waitUntilComplete, "wait-until-complete", options.waitUntilComplete, "If present, wait until the RayJob CR reports a terminal job status and exit with a non-zero code unless the Ray job succeeded. --timeout also bounds this wait")
+	addRaySubmitFlags(cmd, options)
+	options.outputFlags.AddFlags(cmd)
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+// addRaySubmitFlags adds the flags that are passed through to `ray job submit` or control how the Ray job is submitted
+func addRaySubmitFlags(cmd *cobra.Command, options *SubmitJobOptions) {
 	cmd.Flags().StringVar(&options.submissionID, "submission-id", options.submissionID, "ID to specify for the ray job. If not provided, one will be generated")
 	cmd.Flags().StringVar(&options.runtimeEnv, "runtime-env", options.runtimeEnv, "Path and name to the runtime env YAML file.")
 	cmd.Flags().StringVar(&options.workingDir, "working-dir", options.workingDir, "Directory containing files that your job will run in")
+	cmd.Flags().StringVar(&options.entryPointScript, "entrypoint-script", options.entryPointScript, "Local Python file to run as the entrypoint with the arguments given after '--'. It is staged into the working directory, which is created if --working-dir is not given")
+	cmd.Flags().StringVar(&options.workingDirWarnSize, "working-dir-warn-size", defaultWorkingDirWarnSize, "Print a warning if the local working directory is larger than this size, e.g. 100Mi. Set to empty to disable")
+	cmd.Flags().StringVar(&options.workingDirMaxSize, "working-dir-max-size", defaultWorkingDirMaxSize, "Fail if the local working directory is larger than this size, e.g. 500Mi. Set to empty to disable")
+	cmd.Flags().BoolVar(&options.zipWorkingDir, "zip-working-dir", options.zipWorkingDir, "If present, zip the local working directory without the files excluded by .gitignore and .rayignore before uploading it")
 	cmd.Flags().StringVar(&options.headers, "headers", options.headers, "Used to pass headers through http/s to Ray Cluster. Must be JSON formatting")
-	cmd.Flags().StringVar(&options.runtimeEnvJson, "runtime-env-json", options.runtimeEnvJson, "JSON-serialized runtime_env dictionary. Precedence over ray job CR.")
+	cmd.Flags().StringVar(&options.runtimeEnvJson, "runtime-env-json", options.runtimeEnvJson, "JSON-serialized runtime_env dictionary. Precedence over --runtime-env and the ray job CR.")
+	cmd.Flags().StringVar(&options.runtimeEnvStrategy, "runtime-env-strategy", runtimeEnvStrategyMerge, "How the runtime envs of the ray job CR, --runtime-env and --runtime-env-json are combined: merge or override")
+	cmd.Flags().StringArrayVar(&options.envArgs, "env", options.envArgs, "Environment variable KEY=VALUE to add to the env_vars of the runtime env. Can be repeated")
+	cmd.Flags().StringArrayVar(&options.envFromSecretArgs, "env-from-secret", options.envFromSecretArgs, "Secret, given as SECRET_NAME or SECRET_NAME:KEY, whose keys are added to the env_vars of the runtime env. Can be repeated")
 	cmd.Flags().StringVar(&options.verify, "verify", options.verify, "Boolean indication to verify the server’s TLS certificate or a path to a file or directory of trusted certificates.")
 	cmd.Flags().StringVar(&options.entryPointResource, "entrypoint-resources", options.entryPointResource, "JSON-serialized dictionary mapping resource name to resource quantity")
 	cmd.Flags().StringVar(&options.metadataJson, "metadata-json", options.metadataJson, "JSON-serialized dictionary of metadata to attach to the job.")
 	cmd.Flags().StringVar(&options.logStyle, "log-style", options.logStyle, "Specific to 'ray job submit'. Options are 'auto | record | pretty'")
+	config.BindFlag(cmd.Flags(), "log-style", config.LogStyle)
 	cmd.Flags().StringVar(&options.logColor, "log-clor", options.logColor, "Specifc to 'ray job submit'. Options are 'auto | false | true'")
 	cmd.Flags().Float32Var(&options.entryPointCPU, "entrypoint-num-cpus", options.entryPointCPU, "Number of CPU reserved for the for the entrypoint command")
 	cmd.Flags().Float32Var(&options.entryPointGPU, "entrypoint-num-gpus", options.entryPointGPU, "Number of GPU reserved for the for the entrypoint command")
 	cmd.Flags().IntVar(&options.entryPointMemory, "entrypoint-memory", options.entryPointMemory, "Amount of memory reserved for the entrypoint command")
 	cmd.Flags().BoolVar(&options.noWait, "no-wait", options.noWait, "If present, will not stream logs and wait for job to finish")
-	err := cmd.MarkFlagRequired("filename")
-	if err != nil {
-		log.Fatalf("Failed to mark flag as required %v", err)
-	}
-	options.configFlags.AddFlags(cmd.Flags())
-	return cmd
+	cmd.Flags().StringVar(&options.progressMode, "progress", options.progressMode, "How to report the progress of the submission: plain, fancy or none. Defaults to fancy on a terminal and plain otherwise")
+	cmd.Flags().DurationVar(&options.timeout, "timeout", defaultSubmitTimeout, "Maximum time to wait for the RayCluster to be ready and the Ray dashboard to be reachable")
+	config.BindFlag(cmd.Flags(), "timeout", config.Timeout)
+	cmd.Flags().IntVar(&options.localDashboardPort, "dashboard-port", options.localDashboardPort, "Local port used to forward the Ray dashboard. If not set, a free local port is selected automatically")
+	config.BindFlag(cmd.Flags(), "dashboard-port", config.DashboardPort)
+	options.dashboardConn.AddFlags(cmd.Flags())
 }
 
 func (options *SubmitJobOptions) Complete() error {
@@ -146,11 +374,115 @@ func (options *SubmitJobOptions) Complete() error {
 		options.runtimeEnv = filepath.Clean(options.runtimeEnv)
 	}
 
-	options.fileName = filepath.Clean(options.fileName)
+	if len(options.fileName) > 0 {
+		options.fileName = filepath.Clean(options.fileName)
+	} else if options.rayJobName == "" && options.cluster == "" {
+		options.rayJobName = fmt.Sprintf("rayjob-%s", utilrand.String(5))
+	}
+
+	if options.localDashboardPort == 0 {
+		freePort, err := util.GetFreeLocalPort()
+		if err != nil {
+			return fmt.Errorf("failed to find a free local port for the Ray dashboard: %w", err)
+		}
+		options.localDashboardPort = freePort
+	}
 	return nil
 }
 
 func (options *SubmitJobOptions) Validate() error {
+	if err := options.validateRaySubmitFlags(); err != nil {
+		return err
+	}
+	if err := options.outputFlags.Validate(); err != nil {
+		return err
+	}
+
+	if options.cluster != "" {
+		if len(options.fileName) > 0 || options.rayJobName != "" {
+			return fmt.Errorf("--ray-cluster cannot be used together with --filename or --name")
+		}
+		if options.dryRun {
+			return fmt.Errorf("--dry-run cannot be used together with --ray-cluster")
+		}
+		if options.outputFlags.Format == printer.Name {
+			return fmt.Errorf("-o name cannot be used together with --ray-cluster, no RayJob CR is created")
+		}
+		if options.waitUntilComplete {
+			return fmt.Errorf("--wait-until-complete cannot be used together with --ray-cluster, no RayJob CR is created")
+		}
+		if options.override {
+			return fmt.Errorf("--override cannot be used together with --ray-cluster, no RayJob CR is created")
+		}
+		if options.submissionMode == string(rayv1api.K8sJobMode) {
+			return fmt.Errorf("--submission-mode K8sJobMode cannot be used together with --ray-cluster, no RayJob CR is created")
+		}
+		if options.hasSchedulingFlags() {
+			return fmt.Errorf("--priority-class, --node-selector and --toleration cannot be used together with --ray-cluster, no RayJob CR is created")
+		}
+		if options.pinImages {
+			return fmt.Errorf("--pin-images cannot be used together with --ray-cluster, no RayJob CR is created")
+		}
+		if err := options.applyRuntimeEnv(""); err != nil {
+			return err
+		}
+		return options.validateWorkingDir()
+	}
+
+	if options.dryRun && options.waitUntilComplete {
+		return fmt.Errorf("--wait-until-complete cannot be used together with --dry-run")
+	}
+	if options.dryRun && options.pinImages {
+		return fmt.Errorf("--pin-images cannot be used together with --dry-run, the images are resolved with the registries and the nodes of the cluster")
+	}
+	if err := options.parseSchedulingFlags(); err != nil {
+		return err
+	}
+
+	var err error
+	if len(options.fileName) > 0 {
+		info, err := os.Stat(options.fileName)
+		if os.IsNotExist(err) {
+			return fmt.Errorf("Ray Job file does not exist. Failed with: %w", err)
+		} else if err != nil {
+			return fmt.Errorf("Error occurred when checking ray job file: %w", err)
+		} else if !info.Mode().IsRegular() {
+			return fmt.Errorf("Filename given is not a regular file. Failed with: %w", err)
+		}
+
+		options.RayJob, err = decodeRayJobYaml(options.fileName)
+		if err != nil {
+			return fmt.Errorf("Failed to decode RayJob Yaml: %w", err)
+		}
+		if options.RayJob.GetName() == "" && options.RayJob.GetGenerateName() == "" {
+			return fmt.Errorf("RayJob Yaml must set metadata.name or metadata.generateName")
+		}
+		*options.configFlags.Namespace, err = util.ManifestNamespace(options.RayJob, *options.configFlags.Namespace, options.namespaceFromFlag)
+		if err != nil {
+			return err
+		}
+	} else {
+		options.RayJob, err = options.generateRayJob()
+		if err != nil {
+			return fmt.Errorf("Failed to generate RayJob: %w", err)
+		}
+	}
+
+	if err := options.applySchedulingFlags(); err != nil {
+		return err
+	}
+	if err := options.applyRayJobSpec(); err != nil {
+		return err
+	}
+	if options.k8sJobMode {
+		// Nothing is uploaded from the local machine
+		return nil
+	}
+	return options.validateWorkingDir()
+}
+
+// validateRaySubmitFlags validates the flags shared by all commands that submit a Ray job
+func (options *SubmitJobOptions) validateRaySubmitFlags() error {
 	// Overrides and binds the kube config then retrieves the merged result
 	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
 	if err != nil {
@@ -179,197 +511,453 @@ func (options *SubmitJobOptions) Validate() error {
 		}
 	}
 
-	info, err := os.Stat(options.fileName)
-	if os.IsNotExist(err) {
-		return fmt.Errorf("Ray Job file does not exist. Failed with: %w", err)
-	} else if err != nil {
-		return fmt.Errorf("Error occurred when checking ray job file: %w", err)
-	} else if !info.Mode().IsRegular() {
-		return fmt.Errorf("Filename given is not a regular file. Failed with: %w", err)
+	switch options.runtimeEnvStrategy {
+	case "", runtimeEnvStrategyMerge, runtimeEnvStrategyOverride:
+	default:
+		return fmt.Errorf("invalid --runtime-env-strategy %q, must be one of %s or %s", options.runtimeEnvStrategy, runtimeEnvStrategyMerge, runtimeEnvStrategyOverride)
 	}
 
-	options.RayJob, err = decodeRayJobYaml(options.fileName)
-	if err != nil {
-		return fmt.Errorf("Failed to decode RayJob Yaml: %w", err)
+	if options.env, err = util.ParseKeyValues("env", options.envArgs); err != nil {
+		return err
+	}
+	if options.secretEnvSources, err = parseSecretEnvSources(options.envFromSecretArgs); err != nil {
+		return err
+	}
+
+	if options.timeout <= 0 {
+		return fmt.Errorf("timeout must be a positive duration, got %s", options.timeout)
+	}
+	if _, err := progress.ParseMode(options.progressMode); err != nil {
+		return err
 	}
 
+	if options.localDashboardPort < 0 || options.localDashboardPort > 65535 {
+		return fmt.Errorf("dashboard port %d is out of range, must be between 0 and 65535", options.localDashboardPort)
+	}
+	return options.validateDashboardConn()
+}
+
+// validateDashboardConn validates the dashboard connection flags against what `ray job submit` supports, as it only
+// takes the CA certificate through --verify and the token through --headers
+func (options *SubmitJobOptions) validateDashboardConn() error {
+	if options.dashboardConn == nil {
+		return nil
+	}
+	if err := options.dashboardConn.Validate(); err != nil {
+		return err
+	}
+	if options.dashboardConn.ClientCert != "" || options.dashboardConn.ServerName != "" {
+		return fmt.Errorf("--dashboard-client-cert and --dashboard-tls-server-name are not supported by 'ray job submit'")
+	}
+	if options.verify != "" && (options.dashboardConn.CACert != "" || options.dashboardConn.InsecureSkipTLSVerify) {
+		return fmt.Errorf("--verify cannot be used together with --dashboard-ca-cert or --dashboard-insecure-skip-tls-verify")
+	}
+	if options.dashboardConn.AuthorizationHeader() != "" && options.headers != "" {
+		headers := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(options.headers), &headers); err != nil {
+			return fmt.Errorf("failed to parse --headers: %w", err)
+		}
+		if _, ok := headers["Authorization"]; ok {
+			return fmt.Errorf("--dashboard-token cannot be used together with an Authorization header in --headers")
+		}
+	}
+	return nil
+}
+
+// applyRayJobSpec checks the submission mode of the RayJob. The runtime env of an InteractiveMode RayJob is combined with
+// the one given with flags, while the flags are applied to the spec of a K8sJobMode RayJob.
+func (options *SubmitJobOptions) applyRayJobSpec() error {
 	submissionMode, ok := options.RayJob.Object["spec"].(map[string]interface{})["submissionMode"]
 	if !ok {
 		return fmt.Errorf("RayJob does not have `submissionMode` field set")
 	}
-	if submissionMode != nil {
-		if submissionMode != "InteractiveMode" {
-			return fmt.Errorf("Submission mode of the Ray Job is not supported")
-		}
-	} else {
-		return fmt.Errorf("Submission mode must be set to 'InteractiveMode'")
+	switch submissionMode {
+	case string(interactiveMode):
+	case string(rayv1api.K8sJobMode):
+		options.k8sJobMode = true
+		return options.applyK8sJobModeSpec()
+	case nil:
+		return fmt.Errorf("Submission mode must be set to 'InteractiveMode' or 'K8sJobMode'")
+	default:
+		return fmt.Errorf("Submission mode %v of the Ray Job is not supported, use 'InteractiveMode' or 'K8sJobMode'", submissionMode)
 	}
 
-	runtimeEnvYaml, ok := options.RayJob.Object["spec"].(map[string]interface{})["runtimeEnvYAML"].(string)
-	if ok && options.runtimeEnv == "" && options.runtimeEnvJson == "" {
-		runtimeJson, err := yaml.YAMLToJSON([]byte(runtimeEnvYaml))
-		if err != nil {
-			return fmt.Errorf("Failed to convert runtime env to json: %w", err)
-		}
-		options.runtimeEnvJson = string(runtimeJson)
-	}
+	runtimeEnvYaml, _ := options.RayJob.Object["spec"].(map[string]interface{})["runtimeEnvYAML"].(string)
+	return options.applyRuntimeEnv(runtimeEnvYaml)
+}
 
+func (options *SubmitJobOptions) validateWorkingDir() error {
+	if options.entryPointScript != "" {
+		return options.validateEntrypointScript()
+	}
 	if options.workingDir == "" {
 		return fmt.Errorf("working directory is required, use --working-dir or set with runtime env")
 	}
 
 	// Changed working dir clean to here instead of complete since calling Clean on empty string return "." and it would be dificult to determine if that is actually user input or not.
 	options.workingDir = filepath.Clean(options.workingDir)
-	return nil
+	return options.checkWorkingDirSize()
 }
 
 func (options *SubmitJobOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	if options.dryRun {
+		if options.outputFlags.IsStructured() {
+			return options.outputFlags.PrintObj(options.RayJob, options.ioStreams.Out)
+		}
+		rayJobYaml, err := yaml.Marshal(options.RayJob.Object)
+		if err != nil {
+			return fmt.Errorf("Failed to convert RayJob to yaml: %w", err)
+		}
+		fmt.Fprint(options.ioStreams.Out, string(rayJobYaml))
+		return nil
+	}
+
 	k8sClients, err := client.NewClient(factory)
 	if err != nil {
 		return fmt.Errorf("failed to initialize clientset: %w", err)
 	}
 
-	// createdRayJob, err = k8sClients.CreateRayCustomResource(ctx, util.RayJob, options.configFlags.Namespace, unstructuredRayjob)
-	options.RayJob, err = k8sClients.DynamicClient().Resource(util.RayJobGVR).Namespace(*options.configFlags.Namespace).Create(ctx, options.RayJob, v1.CreateOptions{})
-	if err != nil {
-		return fmt.Errorf("Error when creating RayJob CR: %w", err)
+	// All waits share a single deadline controlled by --timeout
+	options.deadline = time.Now().Add(options.timeout)
+	defer options.reporter().Close()
+	if options.cluster != "" {
+		if err := options.waitForExistingCluster(ctx, k8sClients); err != nil {
+			return err
+		}
+	} else {
+		if options.pinImages {
+			if err := options.pinRayJobImages(ctx, image.NewResolver(k8sClients.KubernetesClient())); err != nil {
+				return err
+			}
+		}
+		if options.validate {
+			if err := options.validateRayJobSchema(ctx, k8sClients); err != nil {
+				return err
+			}
+		}
 	}
-	fmt.Printf("Submitted RayJob %s.\n", options.RayJob.GetName())
 
-	if len(options.RayJob.GetName()) > 0 {
-		// Add timeout?
-		for options.RayJob.Object["status"] == nil {
-			options.RayJob, err = k8sClients.DynamicClient().Resource(util.RayJobGVR).Namespace(*options.configFlags.Namespace).Get(ctx, options.RayJob.GetName(), v1.GetOptions{})
-			if err != nil {
-				return fmt.Errorf("Failed to get Ray Job status")
+	var submissionID string
+	if options.k8sJobMode {
+		submissionID, err = options.submitWithK8sJob(ctx, k8sClients)
+	} else {
+		if options.cluster == "" {
+			if err := options.createRayJobAndWaitForCluster(ctx, k8sClients); err != nil {
+				return err
 			}
-			time.Sleep(2 * time.Second)
 		}
-		clusterName, ok := options.RayJob.Object["status"].(map[string]interface{})["rayClusterName"].(string)
-		if !ok {
-			return fmt.Errorf("Unable to find ray cluster status")
+		submissionID, err = options.submitToRayCluster(ctx, factory, k8sClients)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The result is printed even if the Ray job did not succeed, so that CI pipelines can inspect the RayJob
+	var waitErr error
+	if options.waitUntilComplete {
+		waitErr = options.waitForJobCompletion(ctx, k8sClients)
+	}
+	if options.outputFlags.IsStructured() {
+		if err := options.printSubmitResult(submissionID); err != nil {
+			return err
 		}
-		if len(clusterName) == 0 {
-			return fmt.Errorf("No cluster name available even after status of Ray Job is set")
+	}
+	return waitErr
+}
+
+// waitForJobCompletion waits until the RayJob reports a terminal job status. It returns an ExitError if the
+// Ray job did not succeed or did not complete before the deadline.
+func (options *SubmitJobOptions) waitForJobCompletion(ctx context.Context, k8sClients client.Client) error {
+	waitCtx, waitCancel := context.WithDeadline(ctx, options.deadline)
+	defer waitCancel()
+
+	rayJobName := options.RayJob.GetName()
+	options.reporter().Step("Waiting for RayJob %s to complete", rayJobName)
+	rayJob, err := client.WaitForResource(waitCtx, k8sClients.DynamicClient(), util.RayJobGVR, *options.configFlags.Namespace, rayJobName, isRayJobComplete)
+	if err != nil {
+		options.reporter().Fail()
+		if errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+			return &util.ExitError{
+				Err:  fmt.Errorf("timed out waiting for RayJob %s to complete after %s", rayJobName, options.timeout),
+				Code: ExitCodeJobTimeout,
+			}
 		}
-		options.cluster = clusterName
-	} else {
-		return fmt.Errorf("Unknown cluster and did not provide Ray Job. One of the fields must be set")
+		return fmt.Errorf("failed to wait for RayJob %s to complete: %w", rayJobName, err)
 	}
+	options.RayJob = rayJob
 
-	// Wait til the cluster is ready
-	var clusterReady bool
-	clusterWaitStartTime := time.Now()
-	currTime := clusterWaitStartTime
-	fmt.Printf("Waiting for RayCluster\n")
-	fmt.Printf("Checking Cluster Status for cluster %s...\n", options.cluster)
-	for !clusterReady && currTime.Sub(clusterWaitStartTime).Seconds() <= clusterTimeout {
-		time.Sleep(2 * time.Second)
-		currCluster, err := k8sClients.DynamicClient().Resource(util.RayClusterGVR).Namespace(*options.configFlags.Namespace).Get(ctx, options.cluster, v1.GetOptions{})
-		if err != nil {
-			return fmt.Errorf("Failed to get cluster information with error: %w", err)
+	jobStatus, _, _ := unstructured.NestedString(rayJob.Object, "status", "jobStatus")
+	if jobStatus == "" {
+		// The RayJob failed before the Ray job reported a status, e.g. because its activeDeadlineSeconds passed
+		jobStatus, _, _ = unstructured.NestedString(rayJob.Object, "status", "jobDeploymentStatus")
+	}
+	if jobStatus != string(rayv1api.JobStatusSucceeded) {
+		options.reporter().Fail()
+		err := fmt.Errorf("RayJob %s completed with status %s", rayJobName, jobStatus)
+		if message, _, _ := unstructured.NestedString(rayJob.Object, "status", "message"); message != "" {
+			err = fmt.Errorf("%w: %s", err, message)
 		}
-		clusterReady, err = isRayClusterReady(currCluster)
-		if err != nil {
-			err = fmt.Errorf("Cluster is not ready: %w", err)
-			fmt.Println(err)
+		return &util.ExitError{Err: err, Code: ExitCodeJobFailed}
+	}
+	options.reporter().Done()
+	options.reporter().Info("RayJob %s completed with status %s", rayJobName, jobStatus)
+	return nil
+}
+
+// printSubmitResult prints the RayJob, or its name, and the Ray job submission in the requested output format
+func (options *SubmitJobOptions) printSubmitResult(submissionID string) error {
+	if options.outputFlags.Format == printer.Name {
+		return options.outputFlags.PrintObj(options.RayJob, options.ioStreams.Out)
+	}
+	result := submitResult{
+		SubmissionID: submissionID,
+		RayCluster:   options.cluster,
+		DashboardURL: options.dashboardAddr(),
+	}
+	if options.RayJob != nil {
+		result.RayJob = options.RayJob.Object
+	}
+	return options.outputFlags.PrintData(result, options.ioStreams.Out)
+}
+
+// reporter returns the reporter of the submission progress, which writes to progressOut
+func (options *SubmitJobOptions) reporter() *progress.Reporter {
+	if options.progress == nil {
+		options.progress = progress.NewReporter(options.progressOut(), progress.Mode(options.progressMode))
+	}
+	return options.progress
+}
+
+// progressOut returns the writer for progress messages, which are kept out of stdout when machine-readable output is requested
+func (options *SubmitJobOptions) progressOut() io.Writer {
+	if options.outputFlags.IsStructured() {
+		return options.ioStreams.ErrOut
+	}
+	return options.ioStreams.Out
+}
+
+// validateRayJobSchema validates the RayJob against the schema of the RayJob CRD in the cluster, so that mistakes such as
+// misspelled fields are reported before anything is created. The validation is skipped if the CRD cannot be read, e.g.
+// because the user is not allowed to read CRDs.
+func (options *SubmitJobOptions) validateRayJobSchema(ctx context.Context, k8sClients client.Client) error {
+	rayJobSchema, err := validation.FetchCRDSchema(ctx, k8sClients.DynamicClient(), util.RayJobGVR)
+	if err != nil {
+		logging.New(options.ioStreams.ErrOut).Warnf("skipping validation of the RayJob CR: %v", err)
+		return nil
+	}
+
+	errs := validation.ValidateObject(rayJobSchema, options.RayJob.Object)
+	if len(errs) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, "  "+err.Error())
+	}
+	return fmt.Errorf("RayJob %s is invalid, use --validate=false to skip the validation:\n%s", rayJobDisplayName(options.RayJob), strings.Join(messages, "\n"))
+}
+
+// waitForExistingCluster waits until the RayCluster given with --ray-cluster is ready
+func (options *SubmitJobOptions) waitForExistingCluster(ctx context.Context, k8sClients client.Client) error {
+	waitCtx, waitCancel := context.WithDeadline(ctx, options.deadline)
+	defer waitCancel()
+
+	options.reporter().Step("Waiting for the pods of RayCluster %s to be scheduled and ready", options.cluster)
+	if err := options.waitForRayClusterReady(waitCtx, k8sClients); err != nil {
+		options.reporter().Fail()
+		return fmt.Errorf("RayCluster %s did not become ready: %w", options.cluster, err)
+	}
+	options.reporter().Done()
+	return nil
+}
+
+// createRayJobAndWaitForCluster creates the RayJob CR and waits until the RayCluster created for it is ready.
+// The RayJob is deleted again if the RayCluster is not ready before the deadline.
+func (options *SubmitJobOptions) createRayJobAndWaitForCluster(ctx context.Context, k8sClients client.Client) error {
+	var err error
+	if err := options.createRayJob(ctx, k8sClients); err != nil {
+		return err
+	}
+
+	waitCtx, waitCancel := context.WithDeadline(ctx, options.deadline)
+	defer waitCancel()
+
+	options.reporter().Step("Waiting for RayJob %s to provision a RayCluster", options.RayJob.GetName())
+	options.RayJob, err = client.WaitForResource(waitCtx, k8sClients.DynamicClient(), util.RayJobGVR, *options.configFlags.Namespace, options.RayJob.GetName(), rayJobHasClusterName)
+	if err != nil {
+		options.reporter().Fail()
+		return fmt.Errorf("Failed to get RayCluster name from RayJob status: %w", err)
+	}
+	options.cluster, _, _ = unstructured.NestedString(options.RayJob.Object, "status", "rayClusterName")
+
+	// Wait til the cluster is ready
+	options.reporter().Step("Waiting for the pods of RayCluster %s to be scheduled and ready", options.cluster)
+	err = options.waitForRayClusterReady(waitCtx, k8sClients)
+	if err != nil {
+		options.reporter().Fail()
+		options.reporter().Info("Deleting RayJob %s", options.RayJob.GetName())
+		deleteErr := k8sClients.DynamicClient().Resource(util.RayJobGVR).Namespace(*options.configFlags.Namespace).Delete(ctx, options.RayJob.GetName(), v1.DeleteOptions{})
+		if deleteErr != nil {
+			return fmt.Errorf("Failed to clean up ray job after time out.: %w", deleteErr)
 		}
-		currTime = time.Now()
+		options.reporter().Info("Cleaned up RayJob %s", options.RayJob.GetName())
+
+		return fmt.Errorf("Timed out waiting for RayCluster %s to be ready: %w", options.cluster, err)
 	}
+	options.reporter().Done()
+	return nil
+}
 
-	if !clusterReady {
-		fmt.Printf("Deleting RayJob...\n")
-		err = k8sClients.DynamicClient().Resource(util.RayJobGVR).Namespace(*options.configFlags.Namespace).Delete(ctx, options.RayJob.GetName(), v1.DeleteOptions{})
-		if err != nil {
-			return fmt.Errorf("Failed to clean up ray job after time out.: %w", err)
+// waitForRayClusterReady waits until options.cluster is ready. In the meantime, the state changes of its pods and
+// their warning events are reported, e.g. FailedScheduling because no node has enough resources. If the RayCluster
+// does not become ready, the error describes the state of its pods and its recent warning events.
+func (options *SubmitJobOptions) waitForRayClusterReady(ctx context.Context, k8sClients client.Client) error {
+	reportCtx, stopReporting := context.WithCancel(ctx)
+	defer stopReporting()
+	go reportRayClusterProgress(reportCtx, k8sClients, options.reporter(), *options.configFlags.Namespace, options.cluster)
+
+	_, err := client.WaitForResource(ctx, k8sClients.DynamicClient(), util.RayClusterGVR, *options.configFlags.Namespace, options.cluster, client.IsRayClusterReady)
+	if err != nil {
+		stopReporting()
+		return withRayClusterDiagnostics(ctx, err, k8sClients, *options.configFlags.Namespace, options.cluster)
+	}
+	return nil
+}
+
+// createRayJob creates the RayJob CR. If a RayJob with the same name exists, it is replaced with --override once its
+// Ray job has completed. An active RayJob is never replaced.
+func (options *SubmitJobOptions) createRayJob(ctx context.Context, k8sClients client.Client) error {
+	rayJobClient := k8sClients.DynamicClient().Resource(util.RayJobGVR).Namespace(*options.configFlags.Namespace)
+	options.reporter().Step("Creating RayJob %s", rayJobDisplayName(options.RayJob))
+	createdRayJob, err := rayJobClient.Create(ctx, options.RayJob, v1.CreateOptions{})
+	if err == nil {
+		options.RayJob = createdRayJob
+		options.reporter().Done()
+		return nil
+	}
+	options.reporter().Fail()
+	if !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("Error when creating RayJob CR: %w", err)
+	}
+
+	rayJobName := options.RayJob.GetName()
+	existingRayJob, err := rayJobClient.Get(ctx, rayJobName, v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("RayJob %s already exists and could not be read: %w", rayJobName, err)
+	}
+	complete, err := isRayJobComplete(existingRayJob)
+	if err != nil {
+		return fmt.Errorf("failed to read the status of the existing RayJob %s: %w", rayJobName, err)
+	}
+	if !complete {
+		jobStatus, _, _ := unstructured.NestedString(existingRayJob.Object, "status", "jobStatus")
+		if jobStatus == "" {
+			jobStatus = "PENDING"
 		}
-		fmt.Printf("Cleaned Up RayJob: %s\n", options.RayJob.GetName())
+		return fmt.Errorf("RayJob %s already exists and its Ray job is %s. Wait for it to complete, delete it with 'kubectl ray delete rayjob/%s', or use a different name", rayJobName, jobStatus, rayJobName)
+	}
+	if !options.override {
+		return fmt.Errorf("RayJob %s already exists and its Ray job has completed. Use --override to replace it", rayJobName)
+	}
 
-		return fmt.Errorf("Timed out waiting for cluster")
+	options.reporter().Step("Replacing completed RayJob %s", rayJobName)
+	propagationPolicy := v1.DeletePropagationForeground
+	err = rayJobClient.Delete(ctx, rayJobName, v1.DeleteOptions{PropagationPolicy: &propagationPolicy})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the existing RayJob %s: %w", rayJobName, err)
+	}
+	waitCtx, waitCancel := context.WithDeadline(ctx, options.deadline)
+	defer waitCancel()
+	if err := client.WaitForResourceDeletion(waitCtx, k8sClients.DynamicClient(), util.RayJobGVR, *options.configFlags.Namespace, rayJobName); err != nil {
+		return fmt.Errorf("failed to wait for the existing RayJob %s to be deleted: %w", rayJobName, err)
 	}
 
+	// The object read from the file or generated from flags must not carry the resource version of the deleted RayJob
+	options.RayJob.SetResourceVersion("")
+	createdRayJob, err = rayJobClient.Create(ctx, options.RayJob, v1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("Error when creating RayJob CR: %w", err)
+	}
+	options.RayJob = createdRayJob
+	options.reporter().Done()
+	return nil
+}
+
+// rayJobDisplayName returns the name of the RayJob, or its generateName prefix before it is created
+func rayJobDisplayName(rayJob *unstructured.Unstructured) string {
+	if name := rayJob.GetName(); name != "" {
+		return name
+	}
+	return rayJob.GetGenerateName() + "<generated>"
+}
+
+// submitToRayCluster port-forwards the Ray dashboard of options.cluster, runs `ray job submit` and records the
+// submission ID on the RayJob if one was created. It returns the submission ID if it is known.
+func (options *SubmitJobOptions) submitToRayCluster(ctx context.Context, factory cmdutil.Factory, k8sClients client.Client) (string, error) {
 	svcName, err := k8sClients.GetRayHeadSvcName(ctx, *options.configFlags.Namespace, util.RayCluster, options.cluster)
 	if err != nil {
-		return fmt.Errorf("Failed to find service name: %w", err)
+		return "", fmt.Errorf("Failed to find service name: %w", err)
 	}
 
 	// start port forward section
-	portForwardCmd := portforward.NewCmdPortForward(factory, *options.ioStreams)
-	portForwardCmd.SetArgs([]string{"service/" + svcName, fmt.Sprintf("%d:%d", 8265, 8265)})
-
 	// create new context for port-forwarding so we can cancel the context to stop the port forwarding only
 	portforwardctx, cancel := context.WithCancel(ctx)
 	defer cancel()
-	go func() {
-		fmt.Printf("Port Forwarding service %s\n", svcName)
-		if err := portForwardCmd.ExecuteContext(portforwardctx); err != nil {
-			log.Fatalf("Error occurred while port-forwarding Ray dashboard: %v", err)
-		}
-	}()
-
-	// Wait for port forward to be ready
-	var portforwardReady bool
-	portforwardWaitStartTime := time.Now()
-	currTime = portforwardWaitStartTime
-
-	portforwardCheckRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, dashboardAddr, nil)
-	if err != nil {
-		return fmt.Errorf("Error occurred when trying to create request to probe cluster endpoint: %w", err)
+	options.reporter().Step("Port-forwarding the Ray dashboard of service %s", svcName)
+	portforwardStreams := *options.ioStreams
+	portforwardStreams.Out = options.reporter().Verbose()
+	if err := dashboard.PortForward(portforwardctx, factory, portforwardStreams, svcName, options.localDashboardPort, time.Until(options.deadline), options.dashboardConn); err != nil {
+		options.reporter().Fail()
+		return "", fmt.Errorf("Timed out waiting for port forwarding: %w", err)
 	}
-	httpClient := http.Client{
-		Timeout: 5 * time.Second,
+	options.reporter().Done()
+	options.reporter().Info("Ray dashboard is available at %s", options.dashboardAddr())
+
+	if err := options.applyEnvVars(ctx, k8sClients); err != nil {
+		return "", err
 	}
-	fmt.Printf("Waiting for portforwarding...")
-	for !portforwardReady && currTime.Sub(portforwardWaitStartTime).Seconds() <= portforwardtimeout {
-		time.Sleep(2 * time.Second)
-		rayDashboardResponse, err := httpClient.Do(portforwardCheckRequest)
-		if err != nil {
-			err = fmt.Errorf("Error occurred when waiting for portforwarding: %w", err)
-			fmt.Println(err)
-		}
-		if rayDashboardResponse.StatusCode >= 200 && rayDashboardResponse.StatusCode < 300 {
-			portforwardReady = true
-		}
-		rayDashboardResponse.Body.Close()
-		currTime = time.Now()
+	removeStagedScript, err := options.stageEntrypointScript()
+	if err != nil {
+		return "", err
 	}
-	if !portforwardReady {
-		return fmt.Errorf("Timed out waiting for port forwarding")
+	defer removeStagedScript()
+	removePackage, err := options.packageWorkingDir()
+	if err != nil {
+		return "", err
 	}
-	fmt.Printf("Portforwarding started on %s\n", dashboardAddr)
+	defer removePackage()
 
 	// Submitting ray job to cluster
 	raySubmitCmd, err := options.raySubmitCmd()
 	if err != nil {
-		return fmt.Errorf("failed to create Ray submit command with error: %w", err)
+		return "", fmt.Errorf("failed to create Ray submit command with error: %w", err)
 	}
-	fmt.Printf("Ray command: %v\n", raySubmitCmd)
+	options.reporter().Step("Submitting Ray job")
+	options.reporter().Info("Ray command: %v", options.redactedRaySubmitCmd(raySubmitCmd))
 	cmd := exec.Command(raySubmitCmd[0], raySubmitCmd[1:]...) //nolint:gosec // command is sanitized in raySubmitCmd() and file paths are cleaned in Complete()
 
 	// Get the outputs/pipes for `ray job submit` outputs
 	rayCmdStdOut, err := cmd.StdoutPipe()
 	if err != nil {
-		return fmt.Errorf("Error while setting up `ray job submit` stdout: %w", err)
+		return "", fmt.Errorf("Error while setting up `ray job submit` stdout: %w", err)
 	}
 	rayCmdStdErr, err := cmd.StderrPipe()
 	if err != nil {
-		return fmt.Errorf("Error while setting up `ray job submit` stderr: %w", err)
+		return "", fmt.Errorf("Error while setting up `ray job submit` stderr: %w", err)
 	}
 
-	go func() {
-		fmt.Printf("Running ray submit job command...\n")
-		err := cmd.Start()
-		if err != nil {
-			log.Fatalf("error occurred while running command %s: %v", fmt.Sprint(raySubmitCmd), err)
-		}
-	}()
+	if err := cmd.Start(); err != nil {
+		options.reporter().Fail()
+		return "", fmt.Errorf("error occurred while running command %s: %w", options.redactedRaySubmitCmd(raySubmitCmd), err)
+	}
 
 	var rayJobID string
 	if options.submissionID != "" {
 		rayJobID = options.submissionID
 	}
-	// Make channel for retrieving rayJobID from output
-	rayJobIDChan := make(chan string)
+	// Make channel for retrieving rayJobID from output. It is buffered so that printing the output never blocks on
+	// the submission ID not being read, e.g. when there is no RayJob CR to record it on.
+	rayJobIDChan := make(chan string, 1)
 
 	rayCmdStdOutScanner := bufio.NewScanner(rayCmdStdOut)
 	rayCmdStdErrScanner := bufio.NewScanner(rayCmdStdErr)
@@ -382,11 +970,17 @@ func (options *SubmitJobOptions) Run(ctx context.Context, factory cmdutil.Factor
 				// Search for rayjob name. Returns at least two string, first one has single quotes and second string does not have single quotes
 				match := regexExp.FindStringSubmatch(currStdToken)
 				if len(match) > 1 {
-					rayJobIDChan <- match[1]
+					select {
+					case rayJobIDChan <- match[1]:
+					default:
+					}
 				}
 			}
 			if currStdToken != "" {
-				fmt.Println(currStdToken)
+				options.reporter().Output(currStdToken)
+				if strings.Contains(currStdToken, "submitted successfully") && !options.noWait {
+					options.reporter().Step("Running Ray job")
+				}
 			}
 			scanNotDone := rayCmdStdOutScanner.Scan()
 			if !scanNotDone {
@@ -407,39 +1001,125 @@ func (options *SubmitJobOptions) Run(ctx context.Context, factory cmdutil.Factor
 		}
 	}()
 
+	// Without a RayJob CR there is nothing to record the submission ID on
+	if options.RayJob == nil {
+		if err := cmd.Wait(); err != nil {
+			options.reporter().Fail()
+			return "", fmt.Errorf("Error occurred with ray job submit: %w", err)
+		}
+		options.reporter().Done()
+		if rayJobID == "" {
+			select {
+			case rayJobID = <-rayJobIDChan:
+			default:
+			}
+		}
+		return rayJobID, nil
+	}
+
 	// Wait till rayJobID is populated
 	if rayJobID == "" {
 		rayJobID = <-rayJobIDChan
 	}
-	// Add annotation to RayJob with the correct ray job id and update the CR
-	options.RayJob, err = k8sClients.DynamicClient().Resource(util.RayJobGVR).Namespace(*options.configFlags.Namespace).Get(ctx, options.RayJob.GetName(), v1.GetOptions{})
+	// Add annotation to RayJob with the correct ray job id and update the CR. The KubeRay operator updates the RayJob
+	// concurrently, so the update is retried with the latest version on conflicts.
+	err = client.RetryOnConflict(ctx, func() error {
+		rayJob, err := k8sClients.DynamicClient().Resource(util.RayJobGVR).Namespace(*options.configFlags.Namespace).Get(ctx, options.RayJob.GetName(), v1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("Failed to get latest version of Ray Job: %w", err)
+		}
+
+		rayJobAnnotations := rayJob.GetAnnotations()
+		if rayJobAnnotations == nil {
+			rayJobAnnotations = make(map[string]string)
+		}
+		rayJobAnnotations[submissionIDAnnotation] = rayJobID
+		rayJob.SetAnnotations(rayJobAnnotations)
+
+		updated, err := k8sClients.DynamicClient().Resource(util.RayJobGVR).Namespace(*options.configFlags.Namespace).Update(ctx, rayJob, v1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+		options.RayJob = updated
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("Failed to get latest version of Ray Job")
+		return "", fmt.Errorf("Error occurred when trying to add job ID to rayJob: %w", err)
 	}
 
-	rayJobAnnotations := options.RayJob.GetAnnotations()
-	if rayJobAnnotations == nil {
-		rayJobAnnotations = make(map[string]string)
+	// Wait for ray job submit to finish. The result of the Ray job is recorded even if it failed.
+	err = cmd.Wait()
+	options.recordJobResult(ctx, k8sClients, rayJobID, svcName)
+	if err != nil {
+		options.reporter().Fail()
+		return "", fmt.Errorf("Error occurred with ray job submit: %w", err)
 	}
+	options.reporter().Done()
+	return rayJobID, nil
+}
 
-	rayJobAnnotations["ray.io/ray-job-submission-id"] = rayJobID
-	options.RayJob.SetAnnotations(rayJobAnnotations)
-
-	_, err = k8sClients.DynamicClient().Resource(util.RayJobGVR).Namespace(*options.configFlags.Namespace).Update(ctx, options.RayJob, v1.UpdateOptions{})
+// recordJobResult annotates the RayJob with the result of the Ray job reported by the Ray dashboard, so that post-mortem
+// tooling can read it from the CR. The annotations are used instead of the status, which the KubeRay operator owns.
+// Failures are only reported as warnings because the Ray job itself was submitted.
+func (options *SubmitJobOptions) recordJobResult(ctx context.Context, k8sClients client.Client, submissionID, svcName string) {
+	annotations := map[string]string{
+		dashboardURLAnnotation: options.dashboardConn.ServiceAddress(svcName, *options.configFlags.Namespace),
+	}
+	jobClient, err := dashboard.NewJobClient(options.localDashboardPort, options.dashboardConn)
+	if err == nil {
+		var jobInfo *dashboard.JobInfo
+		if jobInfo, err = jobClient.GetJobInfo(ctx, submissionID); err == nil {
+			for key, value := range jobResultAnnotations(jobInfo) {
+				annotations[key] = value
+			}
+		}
+	}
 	if err != nil {
-		return fmt.Errorf("Error occurred when trying to add job ID to rayJob: %w", err)
+		logging.New(options.ioStreams.ErrOut).Warnf("failed to get the result of Ray job %s: %v", submissionID, err)
 	}
 
-	// Wait for ray job submit to finish.
-	err = cmd.Wait()
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": annotations},
+	})
+	if err == nil {
+		var rayJob *unstructured.Unstructured
+		rayJob, err = k8sClients.DynamicClient().Resource(util.RayJobGVR).Namespace(*options.configFlags.Namespace).Patch(ctx, options.RayJob.GetName(), types.MergePatchType, patch, v1.PatchOptions{})
+		if err == nil {
+			options.RayJob = rayJob
+		}
+	}
 	if err != nil {
-		return fmt.Errorf("Error occurred with ray job submit: %w", err)
+		logging.New(options.ioStreams.ErrOut).Warnf("failed to record the result of Ray job %s on RayJob %s: %v", submissionID, options.RayJob.GetName(), err)
 	}
-	return nil
+}
+
+// jobResultAnnotations returns the annotations recording the status of the Ray job. The driver exit code and failure
+// message are only known once the Ray job reached a terminal status, e.g. not with --no-wait.
+func jobResultAnnotations(jobInfo *dashboard.JobInfo) map[string]string {
+	annotations := map[string]string{jobStatusAnnotation: string(jobInfo.JobStatus)}
+	if !rayv1api.IsJobTerminal(jobInfo.JobStatus) {
+		return annotations
+	}
+	if jobInfo.DriverExitCode != nil {
+		annotations[driverExitCodeAnnotation] = strconv.Itoa(*jobInfo.DriverExitCode)
+	}
+	if jobInfo.JobStatus != rayv1api.JobStatusSucceeded && jobInfo.Message != "" {
+		message := jobInfo.Message
+		if len(message) > maxFailureMessageLength {
+			message = message[:maxFailureMessageLength]
+		}
+		annotations[failureMessageAnnotation] = message
+	}
+	return annotations
+}
+
+// dashboardAddr returns the local address of the port-forwarded Ray dashboard
+func (options *SubmitJobOptions) dashboardAddr() string {
+	return options.dashboardConn.Address(options.localDashboardPort)
 }
 
 func (options *SubmitJobOptions) raySubmitCmd() ([]string, error) {
-	raySubmitCmd := []string{"ray", "job", "submit", "--address", dashboardAddr}
+	raySubmitCmd := []string{"ray", "job", "submit", "--address", options.dashboardAddr()}
 
 	if len(options.runtimeEnv) > 0 {
 		raySubmitCmd = append(raySubmitCmd, "--runtime-env", options.runtimeEnv)
@@ -468,11 +1148,15 @@ func (options *SubmitJobOptions) raySubmitCmd() ([]string, error) {
 	if options.noWait {
 		raySubmitCmd = append(raySubmitCmd, "--no-wait")
 	}
-	if len(options.headers) > 0 {
-		raySubmitCmd = append(raySubmitCmd, "--headers", options.headers)
+	headers, err := options.rayHeaders()
+	if err != nil {
+		return nil, err
+	}
+	if len(headers) > 0 {
+		raySubmitCmd = append(raySubmitCmd, "--headers", headers)
 	}
-	if len(options.verify) > 0 {
-		raySubmitCmd = append(raySubmitCmd, "--verify", options.verify)
+	if verify := options.rayVerify(); len(verify) > 0 {
+		raySubmitCmd = append(raySubmitCmd, "--verify", verify)
 	}
 	if len(options.logStyle) > 0 {
 		raySubmitCmd = append(raySubmitCmd, "--log-style", options.logStyle)
@@ -494,6 +1178,68 @@ func (options *SubmitJobOptions) raySubmitCmd() ([]string, error) {
 	return raySubmitCmd, nil
 }
 
+// rayHeaders returns the --headers of `ray job submit`, which carry the bearer token of --dashboard-token
+func (options *SubmitJobOptions) rayHeaders() (string, error) {
+	authorization := options.dashboardConn.AuthorizationHeader()
+	if authorization == "" {
+		return options.headers, nil
+	}
+	headers := map[string]interface{}{}
+	if options.headers != "" {
+		if err := json.Unmarshal([]byte(options.headers), &headers); err != nil {
+			return "", fmt.Errorf("failed to parse --headers: %w", err)
+		}
+	}
+	headers["Authorization"] = authorization
+	headersJson, err := json.Marshal(headers)
+	if err != nil {
+		return "", err
+	}
+	return string(headersJson), nil
+}
+
+// rayVerify returns the --verify of `ray job submit`, which is derived from the dashboard TLS flags unless it is set
+func (options *SubmitJobOptions) rayVerify() string {
+	switch {
+	case options.verify != "":
+		return options.verify
+	case !options.dashboardConn.UseTLS():
+		return ""
+	case options.dashboardConn.CACert != "":
+		return options.dashboardConn.CACert
+	case options.dashboardConn.InsecureSkipTLSVerify:
+		return "False"
+	}
+	return ""
+}
+
+// generateRayJob synthesizes a RayJob CR from the cluster flags
+func (options *SubmitJobOptions) generateRayJob() (*unstructured.Unstructured, error) {
+	submissionMode := interactiveMode
+	if options.submissionMode != "" {
+		submissionMode = rayv1api.JobSubmissionMode(options.submissionMode)
+	}
+	rayJobObject := generation.RayJobYamlObject{
+		RayJobName:     options.rayJobName,
+		Namespace:      *options.configFlags.Namespace,
+		SubmissionMode: submissionMode,
+		RayClusterSpecObject: generation.RayClusterSpecObject{
+			RayVersion:     options.rayVersion,
+			Image:          options.image,
+			HeadCPU:        options.headCPU,
+			HeadMemory:     options.headMemory,
+			WorkerCPU:      options.workerCPU,
+			WorkerMemory:   options.workerMemory,
+			WorkerGPU:      options.workerGPU,
+			WorkerReplicas: options.workerReplicas,
+		},
+	}
+	if err := rayJobObject.Validate(); err != nil {
+		return nil, err
+	}
+	return generation.ConvertRayJobApplyConfigToUnstructured(rayJobObject.GenerateRayJobApplyConfig())
+}
+
 // Decode rayjob yaml if we decide to submit job using kube client
 func decodeRayJobYaml(rayJobFilePath string) (*unstructured.Unstructured, error) {
 	decodedRayJob := &unstructured.Unstructured{}
@@ -524,7 +1270,7 @@ func runtimeEnvHasWorkingDir(runtimePath string) (string, error) {
 		return "", err
 	}
 
-	workingDir := runtimeEnvYaml["working_dir"].(string)
+	workingDir, _ := runtimeEnvYaml["working_dir"].(string)
 	if workingDir != "" {
 		return workingDir, nil
 	}
@@ -532,21 +1278,27 @@ func runtimeEnvHasWorkingDir(runtimePath string) (string, error) {
 	return "", nil
 }
 
-func isRayClusterReady(rayCluster *unstructured.Unstructured) (bool, error) {
-	var isReady bool
-	rayClusterConditions, ok := rayCluster.Object["status"].(map[string]interface{})["conditions"].([]v1.Condition)
-	if ok {
-		isReady = meta.IsStatusConditionTrue(rayClusterConditions, "Ready")
+// rayJobHasClusterName reports whether the RayJob status references the RayCluster created for it
+func rayJobHasClusterName(rayJob *unstructured.Unstructured) (bool, error) {
+	clusterName, _, err := unstructured.NestedString(rayJob.Object, "status", "rayClusterName")
+	if err != nil {
+		return false, err
 	}
+	return clusterName != "", nil
+}
 
-	rayClusterState, ok := rayCluster.Object["status"].(map[string]interface{})["state"].(string)
-	if ok {
-		isReady = isReady || rayClusterState == "ready"
+// isRayJobComplete reports whether the Ray job of the RayJob reached a terminal status, or the RayJob failed
+func isRayJobComplete(rayJob *unstructured.Unstructured) (bool, error) {
+	jobStatus, _, err := unstructured.NestedString(rayJob.Object, "status", "jobStatus")
+	if err != nil {
+		return false, err
 	}
-
-	if isReady {
-		return isReady, nil
+	if rayv1api.IsJobTerminal(rayv1api.JobStatus(jobStatus)) {
+		return true, nil
 	}
-
-	return false, errors.New("Cannot determine cluster state")
+	jobDeploymentStatus, _, err := unstructured.NestedString(rayJob.Object, "status", "jobDeploymentStatus")
+	if err != nil {
+		return false, err
+	}
+	return jobDeploymentStatus == string(rayv1api.JobDeploymentStatusFailed), nil
 }
@@ -1,14 +1,32 @@
 package job
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/dashboard"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/printer"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 )
 
 func TestRayJobSubmitComplete(t *testing.T) {
@@ -21,6 +39,7 @@ func TestRayJobSubmitComplete(t *testing.T) {
 	assert.Equal(t, "default", *fakeSubmitJobOptions.configFlags.Namespace)
 	assert.Nil(t, err)
 	assert.Equal(t, "fake/path/to/env/yaml", fakeSubmitJobOptions.runtimeEnv)
+	assert.NotZero(t, fakeSubmitJobOptions.localDashboardPort)
 }
 
 func TestRayJobSubmitValidate(t *testing.T) {
@@ -85,6 +104,9 @@ spec:
 	_, err = file.Write([]byte(rayYaml))
 	assert.Nil(t, err)
 
+	nameOutputFlags := printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name)
+	nameOutputFlags.Format = printer.Name
+
 	tests := []struct {
 		name        string
 		opts        *SubmitJobOptions
@@ -95,6 +117,7 @@ spec:
 			opts: &SubmitJobOptions{
 				configFlags: genericclioptions.NewConfigFlags(false),
 				ioStreams:   &testStreams,
+				outputFlags: printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
 			},
 			expectError: "no context is currently set, use \"kubectl config use-context <context>\" to select a new one",
 		},
@@ -103,9 +126,123 @@ spec:
 			opts: &SubmitJobOptions{
 				configFlags: fakeConfigFlags,
 				ioStreams:   &testStreams,
+				outputFlags: printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
+				fileName:    rayJobYamlPath,
+				workingDir:  "Fake/File/Path",
+				timeout:     defaultSubmitTimeout,
+			},
+		},
+		{
+			name: "Successful submit job validation with generated RayJob",
+			opts: &SubmitJobOptions{
+				configFlags:    fakeConfigFlags,
+				ioStreams:      &testStreams,
+				outputFlags:    printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
+				rayJobName:     "rayjob-sample",
+				workerReplicas: 2,
+				workerGPU:      "1",
+				workingDir:     "Fake/File/Path",
+				timeout:        defaultSubmitTimeout,
+			},
+		},
+		{
+			name: "Failed submit job validation with invalid generated RayJob",
+			opts: &SubmitJobOptions{
+				configFlags: fakeConfigFlags,
+				ioStreams:   &testStreams,
+				outputFlags: printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
+				rayJobName:  "rayjob-sample",
+				headCPU:     "not-a-quantity",
+				workingDir:  "Fake/File/Path",
+				timeout:     defaultSubmitTimeout,
+			},
+			expectError: "Failed to generate RayJob: invalid head CPU \"not-a-quantity\": quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'",
+		},
+		{
+			name: "Failed submit job validation with non-positive timeout",
+			opts: &SubmitJobOptions{
+				configFlags: fakeConfigFlags,
+				ioStreams:   &testStreams,
+				outputFlags: printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
+				fileName:    rayJobYamlPath,
+				workingDir:  "Fake/File/Path",
+			},
+			expectError: "timeout must be a positive duration, got 0s",
+		},
+		{
+			name: "Successful submit job validation with existing RayCluster",
+			opts: &SubmitJobOptions{
+				configFlags: fakeConfigFlags,
+				ioStreams:   &testStreams,
+				outputFlags: printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
+				cluster:     "raycluster-sample",
+				workingDir:  "Fake/File/Path",
+				timeout:     defaultSubmitTimeout,
+			},
+		},
+		{
+			name: "Failed submit job validation with existing RayCluster and RayJob file",
+			opts: &SubmitJobOptions{
+				configFlags: fakeConfigFlags,
+				ioStreams:   &testStreams,
+				outputFlags: printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
+				cluster:     "raycluster-sample",
 				fileName:    rayJobYamlPath,
 				workingDir:  "Fake/File/Path",
+				timeout:     defaultSubmitTimeout,
+			},
+			expectError: "--ray-cluster cannot be used together with --filename or --name",
+		},
+		{
+			name: "Failed submit job validation with existing RayCluster and name output",
+			opts: &SubmitJobOptions{
+				configFlags: fakeConfigFlags,
+				ioStreams:   &testStreams,
+				outputFlags: nameOutputFlags,
+				cluster:     "raycluster-sample",
+				workingDir:  "Fake/File/Path",
+				timeout:     defaultSubmitTimeout,
+			},
+			expectError: "-o name cannot be used together with --ray-cluster, no RayJob CR is created",
+		},
+		{
+			name: "Failed submit job validation with existing RayCluster and waiting until completion",
+			opts: &SubmitJobOptions{
+				configFlags:       fakeConfigFlags,
+				ioStreams:         &testStreams,
+				outputFlags:       printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
+				cluster:           "raycluster-sample",
+				workingDir:        "Fake/File/Path",
+				timeout:           defaultSubmitTimeout,
+				waitUntilComplete: true,
+			},
+			expectError: "--wait-until-complete cannot be used together with --ray-cluster, no RayJob CR is created",
+		},
+		{
+			name: "Failed submit job validation with existing RayCluster and scheduling flags",
+			opts: &SubmitJobOptions{
+				configFlags:       fakeConfigFlags,
+				ioStreams:         &testStreams,
+				outputFlags:       printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
+				cluster:           "raycluster-sample",
+				workingDir:        "Fake/File/Path",
+				timeout:           defaultSubmitTimeout,
+				priorityClassName: "low-priority",
+			},
+			expectError: "--priority-class, --node-selector and --toleration cannot be used together with --ray-cluster, no RayJob CR is created",
+		},
+		{
+			name: "Failed submit job validation with existing RayCluster and pinned images",
+			opts: &SubmitJobOptions{
+				configFlags: fakeConfigFlags,
+				ioStreams:   &testStreams,
+				outputFlags: printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
+				cluster:     "raycluster-sample",
+				workingDir:  "Fake/File/Path",
+				timeout:     defaultSubmitTimeout,
+				pinImages:   true,
 			},
+			expectError: "--pin-images cannot be used together with --ray-cluster, no RayJob CR is created",
 		},
 	}
 
@@ -119,6 +256,320 @@ spec:
 			}
 		})
 	}
+
+	// The namespace of the RayJob YAML file is used unless another namespace is given with --namespace
+	namespacedRayJobYamlPath := filepath.Join(fakeDir, "rayjob-namespaced.yaml")
+	err = os.WriteFile(namespacedRayJobYamlPath, []byte(strings.Replace(rayYaml, "name: rayjob-sample", "name: rayjob-sample\n  namespace: ml-team", 1)), 0o600)
+	assert.Nil(t, err)
+	for _, namespaceFromFlag := range []bool{false, true} {
+		namespace := "default"
+		namespacedConfigFlags := &genericclioptions.ConfigFlags{
+			Namespace:  &namespace,
+			Context:    &testContext,
+			KubeConfig: &fakeFile,
+		}
+		opts := &SubmitJobOptions{
+			configFlags:       namespacedConfigFlags,
+			ioStreams:         &testStreams,
+			outputFlags:       printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
+			fileName:          namespacedRayJobYamlPath,
+			workingDir:        "Fake/File/Path",
+			timeout:           defaultSubmitTimeout,
+			namespaceFromFlag: namespaceFromFlag,
+		}
+		err = opts.Validate()
+		if namespaceFromFlag {
+			assert.EqualError(t, err, "the namespace from the provided object \"ml-team\" does not match the namespace \"default\". You must pass '--namespace=ml-team' to perform this operation")
+		} else {
+			assert.Nil(t, err)
+			assert.Equal(t, "ml-team", namespace)
+		}
+	}
+}
+
+func TestIsRayJobComplete(t *testing.T) {
+	tests := []struct {
+		status   map[string]interface{}
+		name     string
+		expected bool
+	}{
+		{
+			name:     "no status",
+			expected: false,
+		},
+		{
+			name:     "running",
+			status:   map[string]interface{}{"jobStatus": "RUNNING", "jobDeploymentStatus": "Running"},
+			expected: false,
+		},
+		{
+			name:     "succeeded",
+			status:   map[string]interface{}{"jobStatus": "SUCCEEDED", "jobDeploymentStatus": "Complete"},
+			expected: true,
+		},
+		{
+			name:     "stopped",
+			status:   map[string]interface{}{"jobStatus": "STOPPED"},
+			expected: true,
+		},
+		{
+			name:     "RayJob failed before the Ray job started",
+			status:   map[string]interface{}{"jobDeploymentStatus": "Failed"},
+			expected: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rayJob := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			if tc.status != nil {
+				rayJob.Object["status"] = tc.status
+			}
+			isComplete, err := isRayJobComplete(rayJob)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, isComplete)
+		})
+	}
+}
+
+func TestRayJobSubmitWaitForJobCompletion(t *testing.T) {
+	tests := []struct {
+		status           map[string]interface{}
+		name             string
+		expectError      string
+		expectedExitCode int
+		deadline         time.Duration
+	}{
+		{
+			name:     "succeeded",
+			status:   map[string]interface{}{"jobStatus": "SUCCEEDED", "jobDeploymentStatus": "Complete"},
+			deadline: time.Minute,
+		},
+		{
+			name:             "failed",
+			status:           map[string]interface{}{"jobStatus": "FAILED", "jobDeploymentStatus": "Complete", "message": "Job entrypoint command failed with exit code 1"},
+			deadline:         time.Minute,
+			expectError:      "RayJob rayjob-sample completed with status FAILED: Job entrypoint command failed with exit code 1",
+			expectedExitCode: ExitCodeJobFailed,
+		},
+		{
+			name:             "RayJob failed before the Ray job started",
+			status:           map[string]interface{}{"jobDeploymentStatus": "Failed"},
+			deadline:         time.Minute,
+			expectError:      "RayJob rayjob-sample completed with status Failed",
+			expectedExitCode: ExitCodeJobFailed,
+		},
+		{
+			name:             "timed out",
+			status:           map[string]interface{}{"jobStatus": "RUNNING", "jobDeploymentStatus": "Running"},
+			deadline:         100 * time.Millisecond,
+			expectError:      "timed out waiting for RayJob rayjob-sample to complete after 5m0s",
+			expectedExitCode: ExitCodeJobTimeout,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rayJob := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "ray.io/v1",
+				"kind":       "RayJob",
+				"metadata":   map[string]interface{}{"name": "rayjob-sample", "namespace": "default"},
+				"status":     tc.status,
+			}}
+			dynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), rayJob)
+			k8sClients := client.NewClientForTesting(kubeFake.NewSimpleClientset(), dynamicClient)
+
+			testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+			fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+			*fakeSubmitJobOptions.configFlags.Namespace = "default"
+			fakeSubmitJobOptions.RayJob = rayJob
+			fakeSubmitJobOptions.deadline = time.Now().Add(tc.deadline)
+
+			err := fakeSubmitJobOptions.waitForJobCompletion(context.Background(), k8sClients)
+			assert.Equal(t, tc.expectedExitCode, util.ExitCode(err))
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestJobResultAnnotations(t *testing.T) {
+	exitCode := 1
+	tests := []struct {
+		jobInfo  *dashboard.JobInfo
+		expected map[string]string
+		name     string
+	}{
+		{
+			name:     "running",
+			jobInfo:  &dashboard.JobInfo{RayJobInfo: utils.RayJobInfo{JobStatus: rayv1api.JobStatusRunning, Message: "Job is currently running."}},
+			expected: map[string]string{jobStatusAnnotation: "RUNNING"},
+		},
+		{
+			name:     "succeeded",
+			jobInfo:  &dashboard.JobInfo{DriverExitCode: new(int), RayJobInfo: utils.RayJobInfo{JobStatus: rayv1api.JobStatusSucceeded, Message: "Job finished successfully."}},
+			expected: map[string]string{jobStatusAnnotation: "SUCCEEDED", driverExitCodeAnnotation: "0"},
+		},
+		{
+			name:    "failed",
+			jobInfo: &dashboard.JobInfo{DriverExitCode: &exitCode, RayJobInfo: utils.RayJobInfo{JobStatus: rayv1api.JobStatusFailed, Message: "Job entrypoint command failed with exit code 1"}},
+			expected: map[string]string{
+				jobStatusAnnotation:      "FAILED",
+				driverExitCodeAnnotation: "1",
+				failureMessageAnnotation: "Job entrypoint command failed with exit code 1",
+			},
+		},
+		{
+			name:     "failed without driver exit code",
+			jobInfo:  &dashboard.JobInfo{RayJobInfo: utils.RayJobInfo{JobStatus: rayv1api.JobStatusFailed, Message: strings.Repeat("x", maxFailureMessageLength+1)}},
+			expected: map[string]string{jobStatusAnnotation: "FAILED", failureMessageAnnotation: strings.Repeat("x", maxFailureMessageLength)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, jobResultAnnotations(tc.jobInfo))
+		})
+	}
+}
+
+func TestRayJobSubmitRecordJobResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, utils.JobPath+"raysubmit_123", r.URL.Path)
+		_, err := w.Write([]byte(`{"status": "FAILED", "message": "Job entrypoint command failed with exit code 3", "driver_exit_code": 3}`))
+		assert.Nil(t, err)
+	}))
+	defer server.Close()
+	port, err := strconv.Atoi(server.URL[strings.LastIndex(server.URL, ":")+1:])
+	assert.Nil(t, err)
+
+	rayJob := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "ray.io/v1",
+		"kind":       "RayJob",
+		"metadata": map[string]interface{}{
+			"name":        "rayjob-sample",
+			"namespace":   "default",
+			"annotations": map[string]interface{}{submissionIDAnnotation: "raysubmit_123"},
+		},
+	}}
+	dynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), rayJob)
+	k8sClients := client.NewClientForTesting(kubeFake.NewSimpleClientset(), dynamicClient)
+
+	testStreams, _, _, errBuf := genericclioptions.NewTestIOStreams()
+	fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+	*fakeSubmitJobOptions.configFlags.Namespace = "default"
+	fakeSubmitJobOptions.RayJob = rayJob
+	fakeSubmitJobOptions.localDashboardPort = port
+
+	fakeSubmitJobOptions.recordJobResult(context.Background(), k8sClients, "raysubmit_123", "rayjob-sample-raycluster-head-svc")
+	assert.Empty(t, errBuf.String())
+	expected := map[string]string{
+		submissionIDAnnotation:   "raysubmit_123",
+		jobStatusAnnotation:      "FAILED",
+		driverExitCodeAnnotation: "3",
+		failureMessageAnnotation: "Job entrypoint command failed with exit code 3",
+		dashboardURLAnnotation:   "http://rayjob-sample-raycluster-head-svc.default.svc:8265",
+	}
+	assert.Equal(t, expected, fakeSubmitJobOptions.RayJob.GetAnnotations())
+	updated, err := dynamicClient.Resource(util.RayJobGVR).Namespace("default").Get(context.Background(), "rayjob-sample", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, updated.GetAnnotations())
+
+	// The dashboard URL is recorded even if the Ray dashboard cannot be reached
+	server.Close()
+	fakeSubmitJobOptions.recordJobResult(context.Background(), k8sClients, "raysubmit_123", "rayjob-sample-raycluster-head-svc")
+	assert.Contains(t, errBuf.String(), "Warning: failed to get the result of Ray job raysubmit_123")
+}
+
+func TestRayJobHasClusterName(t *testing.T) {
+	rayJob := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	hasClusterName, err := rayJobHasClusterName(rayJob)
+	assert.Nil(t, err)
+	assert.False(t, hasClusterName)
+
+	rayJob.Object["status"] = map[string]interface{}{"rayClusterName": "raycluster-sample"}
+	hasClusterName, err = rayJobHasClusterName(rayJob)
+	assert.Nil(t, err)
+	assert.True(t, hasClusterName)
+}
+
+func TestRayJobSubmitCompleteGeneratesName(t *testing.T) {
+	testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+	fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+
+	err := fakeSubmitJobOptions.Complete()
+	assert.Nil(t, err)
+	assert.Empty(t, fakeSubmitJobOptions.fileName)
+	assert.Regexp(t, "^rayjob-[a-z0-9]{5}$", fakeSubmitJobOptions.rayJobName)
+}
+
+func TestRayJobSubmitCompleteWithCluster(t *testing.T) {
+	testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+	fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+	fakeSubmitJobOptions.cluster = "raycluster-sample"
+
+	err := fakeSubmitJobOptions.Complete()
+	assert.Nil(t, err)
+	assert.Empty(t, fakeSubmitJobOptions.rayJobName)
+}
+
+func TestRayJobSubmitDryRun(t *testing.T) {
+	testStreams, _, outBuf, _ := genericclioptions.NewTestIOStreams()
+	fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+	*fakeSubmitJobOptions.configFlags.Namespace = "test-namespace"
+	fakeSubmitJobOptions.rayJobName = "rayjob-sample"
+	fakeSubmitJobOptions.workerReplicas = 3
+	fakeSubmitJobOptions.dryRun = true
+
+	var err error
+	fakeSubmitJobOptions.RayJob, err = fakeSubmitJobOptions.generateRayJob()
+	assert.Nil(t, err)
+
+	// Dry run must not touch the cluster, so no factory is needed
+	err = fakeSubmitJobOptions.Run(context.Background(), nil)
+	assert.Nil(t, err)
+
+	output := outBuf.String()
+	assert.Contains(t, output, "kind: RayJob")
+	assert.Contains(t, output, "name: rayjob-sample")
+	assert.Contains(t, output, "namespace: test-namespace")
+	assert.Contains(t, output, "submissionMode: InteractiveMode")
+	assert.Contains(t, output, "replicas: 3")
+}
+
+func TestRayJobSubmitDryRunStructuredOutput(t *testing.T) {
+	testStreams, _, outBuf, _ := genericclioptions.NewTestIOStreams()
+	fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+	*fakeSubmitJobOptions.configFlags.Namespace = "test-namespace"
+	fakeSubmitJobOptions.rayJobName = "rayjob-sample"
+	fakeSubmitJobOptions.dryRun = true
+	fakeSubmitJobOptions.outputFlags.Format = printer.Name
+
+	var err error
+	fakeSubmitJobOptions.RayJob, err = fakeSubmitJobOptions.generateRayJob()
+	assert.Nil(t, err)
+
+	err = fakeSubmitJobOptions.Run(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "rayjob.ray.io/rayjob-sample\n", outBuf.String())
+}
+
+func TestRayJobSubmitPrintSubmitResult(t *testing.T) {
+	testStreams, _, outBuf, _ := genericclioptions.NewTestIOStreams()
+	fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+	fakeSubmitJobOptions.outputFlags.Format = printer.JSON
+	fakeSubmitJobOptions.cluster = "raycluster-sample"
+	fakeSubmitJobOptions.localDashboardPort = 18265
+
+	err := fakeSubmitJobOptions.printSubmitResult("raysubmit_12345")
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"submissionId": "raysubmit_12345", "rayCluster": "raycluster-sample", "dashboardUrl": "http://localhost:18265"}`, outBuf.String())
+
+	// Progress messages go to stderr when a machine-readable output is requested
+	assert.Equal(t, testStreams.ErrOut, fakeSubmitJobOptions.progressOut())
 }
 
 func TestDecodeRayJobYaml(t *testing.T) {
@@ -187,6 +638,7 @@ func TestRaySubmitCmd(t *testing.T) {
 	fakeSubmitJobOptions.verify = "True"
 	fakeSubmitJobOptions.workingDir = "/fake/working/dir"
 	fakeSubmitJobOptions.entryPoint = "python fake_python_script.py"
+	fakeSubmitJobOptions.localDashboardPort = 18265
 
 	actualCmd, err := fakeSubmitJobOptions.raySubmitCmd()
 	assert.Nil(t, err)
@@ -195,7 +647,7 @@ func TestRaySubmitCmd(t *testing.T) {
 		"job",
 		"submit",
 		"--address",
-		dashboardAddr,
+		"http://localhost:18265",
 		"--runtime-env",
 		"/fake-runtime/path",
 		"--runtime-env-json",
@@ -224,3 +676,204 @@ func TestRaySubmitCmd(t *testing.T) {
 
 	assert.Equal(t, expectedCmd, actualCmd)
 }
+
+func TestRaySubmitCmdWithDashboardConn(t *testing.T) {
+	testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+	fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+	fakeSubmitJobOptions.headers = `{"X-Team": "ml"}`
+	fakeSubmitJobOptions.workingDir = "/fake/working/dir"
+	fakeSubmitJobOptions.entryPoint = "python fake_python_script.py"
+	fakeSubmitJobOptions.localDashboardPort = 18265
+	fakeSubmitJobOptions.dashboardConn.CACert = "/fake/ca.crt"
+	fakeSubmitJobOptions.dashboardConn.Token = "fake-token"
+
+	actualCmd, err := fakeSubmitJobOptions.raySubmitCmd()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"ray", "job", "submit",
+		"--address", "https://localhost:18265",
+		"--headers", `{"Authorization":"Bearer fake-token","X-Team":"ml"}`,
+		"--verify", "/fake/ca.crt",
+		"--working-dir", "/fake/working/dir",
+		"--", "python", "fake_python_script.py",
+	}, actualCmd)
+
+	// The certificate is not verified by `ray job submit` either
+	fakeSubmitJobOptions.dashboardConn.CACert = ""
+	fakeSubmitJobOptions.dashboardConn.InsecureSkipTLSVerify = true
+	assert.Equal(t, "False", fakeSubmitJobOptions.rayVerify())
+}
+
+func TestRayJobSubmitValidateDashboardConn(t *testing.T) {
+	tests := []struct {
+		conn        dashboard.ConnectionOptions
+		name        string
+		headers     string
+		verify      string
+		expectedErr string
+	}{
+		{
+			name: "token",
+			conn: dashboard.ConnectionOptions{Token: "fake-token"},
+		},
+		{
+			name:        "client certificate",
+			conn:        dashboard.ConnectionOptions{ClientCert: "/fake/tls.crt", ClientKey: "/fake/tls.key"},
+			expectedErr: "failed to load --dashboard-client-cert and --dashboard-client-key",
+		},
+		{
+			name:        "server name",
+			conn:        dashboard.ConnectionOptions{ServerName: "ray.example.com"},
+			expectedErr: "--dashboard-client-cert and --dashboard-tls-server-name are not supported by 'ray job submit'",
+		},
+		{
+			name:        "verify with insecure",
+			conn:        dashboard.ConnectionOptions{InsecureSkipTLSVerify: true},
+			verify:      "True",
+			expectedErr: "--verify cannot be used together with --dashboard-ca-cert or --dashboard-insecure-skip-tls-verify",
+		},
+		{
+			name:        "token with Authorization header",
+			conn:        dashboard.ConnectionOptions{Token: "fake-token"},
+			headers:     `{"Authorization": "Basic fake"}`,
+			expectedErr: "--dashboard-token cannot be used together with an Authorization header in --headers",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			options := &SubmitJobOptions{dashboardConn: &tc.conn, headers: tc.headers, verify: tc.verify}
+			err := options.validateDashboardConn()
+			if tc.expectedErr == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRayJobSubmitValidateRayJobSchema(t *testing.T) {
+	rayJobCRD := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": "rayjobs.ray.io"},
+		"spec": map[string]interface{}{
+			"versions": []interface{}{
+				map[string]interface{}{
+					"name": "v1",
+					"schema": map[string]interface{}{"openAPIV3Schema": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"apiVersion": map[string]interface{}{"type": "string"},
+							"kind":       map[string]interface{}{"type": "string"},
+							"metadata":   map[string]interface{}{"type": "object"},
+							"spec": map[string]interface{}{
+								"type": "object",
+								"properties": map[string]interface{}{
+									"submissionMode": map[string]interface{}{"type": "string"},
+								},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}}
+	rayJob := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "ray.io/v1",
+		"kind":       "RayJob",
+		"metadata":   map[string]interface{}{"name": "rayjob-sample"},
+		"spec":       map[string]interface{}{"submisionMode": "InteractiveMode"},
+	}}
+
+	testStreams, _, _, errBuf := genericclioptions.NewTestIOStreams()
+	fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+	fakeSubmitJobOptions.RayJob = rayJob
+
+	// The validation is skipped if the CRD cannot be read
+	k8sClients := client.NewClientForTesting(kubeFake.NewSimpleClientset(), dynamicFake.NewSimpleDynamicClient(runtime.NewScheme()))
+	err := fakeSubmitJobOptions.validateRayJobSchema(context.Background(), k8sClients)
+	assert.Nil(t, err)
+	assert.Contains(t, errBuf.String(), "Warning: skipping validation of the RayJob CR")
+
+	k8sClients = client.NewClientForTesting(kubeFake.NewSimpleClientset(), dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), rayJobCRD))
+	err = fakeSubmitJobOptions.validateRayJobSchema(context.Background(), k8sClients)
+	assert.EqualError(t, err, "RayJob rayjob-sample is invalid, use --validate=false to skip the validation:\n  spec.submisionMode: Forbidden: unknown field")
+}
+
+func TestRayJobSubmitCreateRayJob(t *testing.T) {
+	tests := []struct {
+		existingStatus map[string]interface{}
+		name           string
+		expectError    string
+		override       bool
+	}{
+		{
+			name: "no existing RayJob",
+		},
+		{
+			name:           "active RayJob is not replaced",
+			existingStatus: map[string]interface{}{"jobStatus": "RUNNING", "jobDeploymentStatus": "Running"},
+			override:       true,
+			expectError:    "RayJob rayjob-sample already exists and its Ray job is RUNNING. Wait for it to complete, delete it with 'kubectl ray delete rayjob/rayjob-sample', or use a different name",
+		},
+		{
+			name:           "completed RayJob without --override",
+			existingStatus: map[string]interface{}{"jobStatus": "SUCCEEDED", "jobDeploymentStatus": "Complete"},
+			expectError:    "RayJob rayjob-sample already exists and its Ray job has completed. Use --override to replace it",
+		},
+		{
+			name:           "completed RayJob with --override",
+			existingStatus: map[string]interface{}{"jobStatus": "FAILED", "jobDeploymentStatus": "Complete"},
+			override:       true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var objects []runtime.Object
+			if tc.existingStatus != nil {
+				objects = append(objects, &unstructured.Unstructured{Object: map[string]interface{}{
+					"apiVersion": "ray.io/v1",
+					"kind":       "RayJob",
+					"metadata":   map[string]interface{}{"name": "rayjob-sample", "namespace": "default"},
+					"status":     tc.existingStatus,
+				}})
+			}
+			dynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+			k8sClients := client.NewClientForTesting(kubeFake.NewSimpleClientset(), dynamicClient)
+
+			testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+			fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+			*fakeSubmitJobOptions.configFlags.Namespace = "default"
+			fakeSubmitJobOptions.override = tc.override
+			fakeSubmitJobOptions.deadline = time.Now().Add(time.Minute)
+			fakeSubmitJobOptions.RayJob = &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "ray.io/v1",
+				"kind":       "RayJob",
+				"metadata":   map[string]interface{}{"name": "rayjob-sample", "namespace": "default"},
+				"spec":       map[string]interface{}{"submissionMode": "InteractiveMode"},
+			}}
+
+			err := fakeSubmitJobOptions.createRayJob(context.Background(), k8sClients)
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				return
+			}
+			assert.Nil(t, err)
+			rayJob, err := dynamicClient.Resource(util.RayJobGVR).Namespace("default").Get(context.Background(), "rayjob-sample", metav1.GetOptions{})
+			assert.Nil(t, err)
+			_, hasStatus := rayJob.Object["status"]
+			assert.False(t, hasStatus)
+		})
+	}
+}
+
+func TestRayJobDisplayName(t *testing.T) {
+	rayJob := &unstructured.Unstructured{}
+	rayJob.SetGenerateName("rayjob-")
+	assert.Equal(t, "rayjob-<generated>", rayJobDisplayName(rayJob))
+	rayJob.SetName("rayjob-sample")
+	assert.Equal(t, "rayjob-sample", rayJobDisplayName(rayJob))
+}
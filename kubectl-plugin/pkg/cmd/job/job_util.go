@@ -0,0 +1,64 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/dashboard"
+)
+
+const portforwardReadyTimeout = 60 * time.Second
+
+// connectToRayJobDashboard port-forwards the Ray dashboard of the RayCluster used by the RayJob and returns a dashboard client
+// together with the Ray job submission ID. The returned cancel function stops the port forwarding.
+func connectToRayJobDashboard(ctx context.Context, factory cmdutil.Factory, streams genericiooptions.IOStreams, k8sClient client.Client, namespace string, rayJobName string, localPort int, conn *dashboard.ConnectionOptions) (dashboard.JobClient, string, context.CancelFunc, error) {
+	rayJob, err := k8sClient.DynamicClient().Resource(util.RayJobGVR).Namespace(namespace).Get(ctx, rayJobName, v1.GetOptions{})
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("unable to find RayJob %s: %w", rayJobName, err)
+	}
+	submissionID, err := getRayJobSubmissionID(rayJob)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	svcName, err := k8sClient.GetRayHeadSvcName(ctx, namespace, util.RayJob, rayJobName)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	portforwardctx, cancel := context.WithCancel(ctx)
+	if err := dashboard.PortForward(portforwardctx, factory, streams, svcName, localPort, portforwardReadyTimeout, conn); err != nil {
+		cancel()
+		return nil, "", nil, err
+	}
+
+	dashboardClient, err := dashboard.NewJobClient(localPort, conn)
+	if err != nil {
+		cancel()
+		return nil, "", nil, fmt.Errorf("failed to create Ray dashboard client: %w", err)
+	}
+	return dashboardClient, submissionID, cancel, nil
+}
+
+// getRayJobSubmissionID returns the Ray job submission ID of the RayJob. The annotation set by `kubectl ray job submit`
+// takes precedence over the job ID in the RayJob status and spec.
+func getRayJobSubmissionID(rayJob *unstructured.Unstructured) (string, error) {
+	if submissionID := rayJob.GetAnnotations()[submissionIDAnnotation]; submissionID != "" {
+		return submissionID, nil
+	}
+	if submissionID, _, _ := unstructured.NestedString(rayJob.Object, "status", "jobId"); submissionID != "" {
+		return submissionID, nil
+	}
+	if submissionID, _, _ := unstructured.NestedString(rayJob.Object, "spec", "jobId"); submissionID != "" {
+		return submissionID, nil
+	}
+	return "", fmt.Errorf("unable to find the Ray job submission ID of RayJob %s", rayJob.GetName())
+}
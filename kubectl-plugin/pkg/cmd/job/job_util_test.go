@@ -0,0 +1,56 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGetRayJobSubmissionID(t *testing.T) {
+	tests := []struct {
+		rayJob       *unstructured.Unstructured
+		name         string
+		expectedID   string
+		expectsError bool
+	}{
+		{
+			name: "submission ID from annotation",
+			rayJob: &unstructured.Unstructured{Object: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"name":        "test-rayjob",
+					"annotations": map[string]interface{}{submissionIDAnnotation: "raysubmit_123"},
+				},
+				"status": map[string]interface{}{"jobId": "status-job-id"},
+			}},
+			expectedID: "raysubmit_123",
+		},
+		{
+			name: "submission ID from status",
+			rayJob: &unstructured.Unstructured{Object: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "test-rayjob"},
+				"status":   map[string]interface{}{"jobId": "status-job-id"},
+			}},
+			expectedID: "status-job-id",
+		},
+		{
+			name: "no submission ID",
+			rayJob: &unstructured.Unstructured{Object: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "test-rayjob"},
+			}},
+			expectsError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			submissionID, err := getRayJobSubmissionID(tc.rayJob)
+			if tc.expectsError {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedID, submissionID)
+			}
+		})
+	}
+}
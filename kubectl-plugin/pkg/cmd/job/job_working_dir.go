@@ -0,0 +1,296 @@
+package job
+
+import (
+	"archive/zip"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
+)
+
+const (
+	defaultWorkingDirWarnSize = "100Mi"
+	// defaultWorkingDirMaxSize is the largest working directory Ray accepts
+	defaultWorkingDirMaxSize = "500Mi"
+)
+
+// ignoreFiles contain the gitignore-style exclusion patterns of a working directory. Only the files at the root of
+// the working directory are read, and later files take precedence.
+var ignoreFiles = []string{".gitignore", ".rayignore"}
+
+// ignorePattern is a single line of an ignore file
+type ignorePattern struct {
+	regexp  *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// loadIgnorePatterns reads the exclusion patterns of the ignore files in the working directory
+func loadIgnorePatterns(workingDir string) ([]ignorePattern, error) {
+	var patterns []ignorePattern
+	for _, ignoreFile := range ignoreFiles {
+		file, err := os.Open(filepath.Join(workingDir, ignoreFile))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", ignoreFile, err)
+		}
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			pattern, err := parseIgnorePattern(scanner.Text())
+			if err != nil {
+				file.Close()
+				return nil, fmt.Errorf("invalid pattern in %s: %w", ignoreFile, err)
+			}
+			if pattern != nil {
+				patterns = append(patterns, *pattern)
+			}
+		}
+		file.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", ignoreFile, err)
+		}
+	}
+	return patterns, nil
+}
+
+// parseIgnorePattern converts a gitignore-style pattern to a regular expression matching slash-separated paths
+// relative to the working directory. It returns nil for blank lines and comments.
+func parseIgnorePattern(line string) (*ignorePattern, error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil, nil
+	}
+
+	pattern := &ignorePattern{}
+	if strings.HasPrefix(line, "!") {
+		pattern.negate = true
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		pattern.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	// A pattern without a slash matches at any depth, otherwise it is relative to the working directory
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	var expr strings.Builder
+	if !anchored {
+		expr.WriteString("(.*/)?")
+	}
+	for i := 0; i < len(line); i++ {
+		switch {
+		case strings.HasPrefix(line[i:], "**/"):
+			expr.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(line[i:], "/**") && i+3 == len(line):
+			expr.WriteString("(/.*)?")
+			i += 2
+		case line[i] == '*':
+			expr.WriteString("[^/]*")
+		case line[i] == '?':
+			expr.WriteString("[^/]")
+		case line[i] == '[':
+			end := strings.IndexByte(line[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated character class in %q", line)
+			}
+			expr.WriteString(line[i : i+end+1])
+			i += end
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(line[i])))
+		}
+	}
+
+	var err error
+	pattern.regexp, err = regexp.Compile("^" + expr.String() + "$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", line, err)
+	}
+	return pattern, nil
+}
+
+// isIgnored reports whether the path relative to the working directory is excluded. The last matching pattern wins.
+func isIgnored(patterns []ignorePattern, relPath string, isDir bool) bool {
+	ignored := false
+	for _, pattern := range patterns {
+		if pattern.dirOnly && !isDir {
+			continue
+		}
+		if pattern.regexp.MatchString(relPath) {
+			ignored = !pattern.negate
+		}
+	}
+	return ignored
+}
+
+// walkWorkingDir calls fn for each regular file of the working directory that is not excluded
+func walkWorkingDir(workingDir string, patterns []ignorePattern, fn func(path string, relPath string, info fs.FileInfo) error) error {
+	return filepath.WalkDir(workingDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(workingDir, path)
+		if err != nil {
+			return err
+		}
+		if relPath == "." {
+			return nil
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		if isIgnored(patterns, relPath, entry.IsDir()) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		return fn(path, relPath, info)
+	})
+}
+
+// workingDirSize returns the total size and number of the files of the working directory that are not excluded
+func workingDirSize(workingDir string, patterns []ignorePattern) (int64, int, error) {
+	var size int64
+	var files int
+	err := walkWorkingDir(workingDir, patterns, func(_ string, _ string, info fs.FileInfo) error {
+		size += info.Size()
+		files++
+		return nil
+	})
+	return size, files, err
+}
+
+// zipWorkingDir packages the files of the working directory that are not excluded into a temporary zip file.
+// The caller is responsible for removing the returned file.
+func zipWorkingDir(workingDir string, patterns []ignorePattern) (zipPath string, err error) {
+	zipFile, err := os.CreateTemp("", "ray-working-dir-*.zip")
+	if err != nil {
+		return "", fmt.Errorf("failed to create zip file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			zipFile.Close()
+			os.Remove(zipFile.Name())
+		}
+	}()
+
+	zipWriter := zip.NewWriter(zipFile)
+	err = walkWorkingDir(workingDir, patterns, func(path string, relPath string, info fs.FileInfo) error {
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = relPath
+		header.Method = zip.Deflate
+		writer, err := zipWriter.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = io.Copy(writer, file)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to zip working directory %s: %w", workingDir, err)
+	}
+	if err := zipWriter.Close(); err != nil {
+		return "", fmt.Errorf("failed to zip working directory %s: %w", workingDir, err)
+	}
+	if err := zipFile.Close(); err != nil {
+		return "", fmt.Errorf("failed to zip working directory %s: %w", workingDir, err)
+	}
+	return zipFile.Name(), nil
+}
+
+// checkWorkingDirSize estimates the size of a local working directory after exclusions. It warns above the warning
+// size and fails above the maximum size. Working directories that are not local directories, e.g. remote URIs, are
+// left to Ray.
+func (options *SubmitJobOptions) checkWorkingDirSize() error {
+	info, err := os.Stat(options.workingDir)
+	if err != nil || !info.IsDir() {
+		return nil
+	}
+
+	patterns, err := loadIgnorePatterns(options.workingDir)
+	if err != nil {
+		return err
+	}
+	size, files, err := workingDirSize(options.workingDir, patterns)
+	if err != nil {
+		return fmt.Errorf("failed to estimate the size of working directory %s: %w", options.workingDir, err)
+	}
+	sizeQuantity := resource.NewQuantity(size, resource.BinarySI)
+
+	if options.workingDirMaxSize != "" {
+		maxSize, err := resource.ParseQuantity(options.workingDirMaxSize)
+		if err != nil {
+			return fmt.Errorf("invalid --working-dir-max-size %q: %w", options.workingDirMaxSize, err)
+		}
+		if sizeQuantity.Cmp(maxSize) > 0 {
+			return fmt.Errorf("working directory %s is %s in %d files, which exceeds --working-dir-max-size %s. Exclude files with .rayignore or .gitignore",
+				options.workingDir, sizeQuantity.String(), files, maxSize.String())
+		}
+	}
+	if options.workingDirWarnSize != "" {
+		warnSize, err := resource.ParseQuantity(options.workingDirWarnSize)
+		if err != nil {
+			return fmt.Errorf("invalid --working-dir-warn-size %q: %w", options.workingDirWarnSize, err)
+		}
+		if sizeQuantity.Cmp(warnSize) > 0 {
+			logging.New(options.ioStreams.ErrOut).Warnf("working directory %s is %s in %d files. Exclude files with .rayignore or .gitignore if they are not needed by the job",
+				options.workingDir, sizeQuantity.String(), files)
+		}
+	}
+	return nil
+}
+
+// packageWorkingDir zips the local working directory if --zip-working-dir is set, and submits the zip file instead.
+// The returned function removes the zip file.
+func (options *SubmitJobOptions) packageWorkingDir() (func(), error) {
+	info, err := os.Stat(options.workingDir)
+	if !options.zipWorkingDir || err != nil || !info.IsDir() {
+		return func() {}, nil
+	}
+
+	patterns, err := loadIgnorePatterns(options.workingDir)
+	if err != nil {
+		return nil, err
+	}
+	zipPath, err := zipWorkingDir(options.workingDir, patterns)
+	if err != nil {
+		return nil, err
+	}
+	options.reporter().Info("Packaged working directory %s into %s", options.workingDir, zipPath)
+
+	workingDir := options.workingDir
+	options.workingDir = zipPath
+	return func() {
+		options.workingDir = workingDir
+		os.Remove(zipPath)
+	}, nil
+}
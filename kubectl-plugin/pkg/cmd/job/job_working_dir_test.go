@@ -0,0 +1,179 @@
+package job
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestIsIgnored(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		patterns []string
+		isDir    bool
+		expected bool
+	}{
+		{name: "pattern without slash matches at any depth", patterns: []string{"*.ckpt"}, path: "models/epoch1.ckpt", expected: true},
+		{name: "pattern without slash does not match other files", patterns: []string{"*.ckpt"}, path: "models/epoch1.py", expected: false},
+		{name: "anchored pattern matches relative to the working directory", patterns: []string{"/data"}, path: "data", isDir: true, expected: true},
+		{name: "anchored pattern does not match nested paths", patterns: []string{"/data"}, path: "src/data", isDir: true, expected: false},
+		{name: "directory pattern does not match files", patterns: []string{"cache/"}, path: "cache", expected: false},
+		{name: "directory pattern matches directories", patterns: []string{"cache/"}, path: "src/cache", isDir: true, expected: true},
+		{name: "double star matches any directories", patterns: []string{"logs/**/*.txt"}, path: "logs/a/b/out.txt", expected: true},
+		{name: "trailing double star matches everything inside", patterns: []string{"build/**"}, path: "build/lib/x.so", expected: true},
+		{name: "negated pattern re-includes a file", patterns: []string{"*.csv", "!small.csv"}, path: "small.csv", expected: false},
+		{name: "comments and blank lines are skipped", patterns: []string{"# *.py", ""}, path: "main.py", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var patterns []ignorePattern
+			for _, line := range tc.patterns {
+				pattern, err := parseIgnorePattern(line)
+				assert.Nil(t, err)
+				if pattern != nil {
+					patterns = append(patterns, *pattern)
+				}
+			}
+			assert.Equal(t, tc.expected, isIgnored(patterns, tc.path, tc.isDir))
+		})
+	}
+
+	_, err := parseIgnorePattern("[abc")
+	assert.NotNil(t, err)
+}
+
+// newTestWorkingDir creates a working directory with a 10 byte script and 100 bytes of excluded data
+func newTestWorkingDir(t *testing.T) string {
+	workingDir := t.TempDir()
+	files := map[string]string{
+		"main.py":         "print(1)\n\n",
+		"data/train.bin":  string(make([]byte, 60)),
+		"logs/output.log": string(make([]byte, 40)),
+		".gitignore":      "*.log\n",
+		".rayignore":      "/data/\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(workingDir, name)
+		assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return workingDir
+}
+
+func TestWorkingDirSize(t *testing.T) {
+	workingDir := newTestWorkingDir(t)
+
+	size, files, err := workingDirSize(workingDir, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(110+len("*.log\n")+len("/data/\n")), size)
+	assert.Equal(t, 5, files)
+
+	patterns, err := loadIgnorePatterns(workingDir)
+	assert.Nil(t, err)
+	size, files, err = workingDirSize(workingDir, patterns)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10+len("*.log\n")+len("/data/\n")), size)
+	assert.Equal(t, 3, files)
+}
+
+func TestZipWorkingDir(t *testing.T) {
+	workingDir := newTestWorkingDir(t)
+	patterns, err := loadIgnorePatterns(workingDir)
+	assert.Nil(t, err)
+
+	zipPath, err := zipWorkingDir(workingDir, patterns)
+	assert.Nil(t, err)
+	defer os.Remove(zipPath)
+
+	zipReader, err := zip.OpenReader(zipPath)
+	assert.Nil(t, err)
+	defer zipReader.Close()
+
+	var names []string
+	for _, file := range zipReader.File {
+		names = append(names, file.Name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{".gitignore", ".rayignore", "main.py"}, names)
+}
+
+func TestCheckWorkingDirSize(t *testing.T) {
+	workingDir := newTestWorkingDir(t)
+
+	tests := []struct {
+		name          string
+		warnSize      string
+		maxSize       string
+		expectError   string
+		expectWarning bool
+	}{
+		{
+			name:     "below the warning size",
+			warnSize: "1Ki",
+			maxSize:  "2Ki",
+		},
+		{
+			name:          "above the warning size",
+			warnSize:      "10",
+			maxSize:       "2Ki",
+			expectWarning: true,
+		},
+		{
+			name:        "above the maximum size",
+			warnSize:    "10",
+			maxSize:     "20",
+			expectError: "working directory " + workingDir + " is 23 in 3 files, which exceeds --working-dir-max-size 20. Exclude files with .rayignore or .gitignore",
+		},
+		{
+			name:        "invalid maximum size",
+			maxSize:     "lots",
+			expectError: "invalid --working-dir-max-size \"lots\": quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'",
+		},
+		{
+			name: "disabled checks",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testStreams, _, _, errBuf := genericclioptions.NewTestIOStreams()
+			fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+			fakeSubmitJobOptions.workingDir = workingDir
+			fakeSubmitJobOptions.workingDirWarnSize = tc.warnSize
+			fakeSubmitJobOptions.workingDirMaxSize = tc.maxSize
+
+			err := fakeSubmitJobOptions.checkWorkingDirSize()
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectWarning, errBuf.Len() > 0)
+		})
+	}
+}
+
+func TestPackageWorkingDir(t *testing.T) {
+	workingDir := newTestWorkingDir(t)
+	testStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+	fakeSubmitJobOptions := NewJobSubmitOptions(testStreams)
+	fakeSubmitJobOptions.workingDir = workingDir
+	fakeSubmitJobOptions.zipWorkingDir = true
+
+	removePackage, err := fakeSubmitJobOptions.packageWorkingDir()
+	assert.Nil(t, err)
+	zipPath := fakeSubmitJobOptions.workingDir
+	assert.Equal(t, ".zip", filepath.Ext(zipPath))
+	assert.FileExists(t, zipPath)
+
+	removePackage()
+	assert.Equal(t, workingDir, fakeSubmitJobOptions.workingDir)
+	assert.NoFileExists(t, zipPath)
+}
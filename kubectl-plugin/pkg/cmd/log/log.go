@@ -2,56 +2,83 @@ package log
 
 import (
 	"archive/tar"
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"math"
 	"net/url"
 	"os"
 	"path"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/templates"
 
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
 	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/completion"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/selector"
 )
 
 const filePathInPod = "/tmp/ray/session_latest/logs/"
 
 type ClusterLogOptions struct {
-	configFlags *genericclioptions.ConfigFlags
-	ioStreams   *genericclioptions.IOStreams
-	Executor    RemoteExecutor
-	outputDir   string
-	nodeType    string
-	args        []string
+	configFlags    *genericclioptions.ConfigFlags
+	ioStreams      *genericclioptions.IOStreams
+	Executor       RemoteExecutor
+	outputDir      string
+	nodeType       string
+	container      string
+	args           []string
+	since          time.Duration
+	follow         bool
+	nonInteractive bool
 }
 
 var (
 	logLong = templates.LongDesc(`
 		Download logs from a RayCluster and save them to a directory.
+
+		The logs of all selected Ray nodes are downloaded concurrently. The container log of each node is saved to
+		<out-dir>/<group>/<pod>.log and the Ray session logs of the node to <out-dir>/<group>/<pod>/.
+
+		Use '--follow' to stream the log of the head node instead of downloading it.
+
+		Without a RayCluster, the only RayCluster of the namespace is used. When there are several, one can be selected
+		interactively on a terminal, unless '--non-interactive' is set.
 	`)
 
 	logExample = templates.Examples(`
 		# Download logs from a RayCluster and save them to a directory with the RayCluster's name
 		kubectl ray log my-raycluster
 
+		# Download logs from the only RayCluster of the namespace, or select one when there are several
+		kubectl ray log
+
 		# Download logs from a RayCluster and save them to a directory named /path/to/dir
 		kubectl ray log my-raycluster --out-dir /path/to/dir
 
 		# Download logs from a RayCluster, but only for the head node
 		kubectl ray log my-raycluster --node-type head
+
+		# Download the logs of the last hour from all nodes of a RayCluster
+		kubectl ray log my-raycluster --node-type all --since 1h
+
+		# Download the logs of the autoscaler sidecar container of the head node
+		kubectl ray log my-raycluster --container autoscaler
+
+		# Stream the log of the head node
+		kubectl ray log my-raycluster --follow
 	`)
 )
 
@@ -69,7 +96,7 @@ func NewClusterLogCommand(streams genericclioptions.IOStreams) *cobra.Command {
 	cmdFactory := cmdutil.NewFactory(options.configFlags)
 
 	cmd := &cobra.Command{
-		Use:               "log (RAYCLUSTER) [--out-dir DIR_PATH] [--node-type all|head|worker]",
+		Use:               "log [RAYCLUSTER] [--out-dir DIR_PATH] [--node-type all|head|worker] [--container CONTAINER] [--since DURATION] [--follow]",
 		Short:             "Get ray cluster log",
 		Long:              logLong,
 		Example:           logExample,
@@ -87,7 +114,11 @@ func NewClusterLogCommand(streams genericclioptions.IOStreams) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVar(&options.outputDir, "out-dir", options.outputDir, "File Directory PATH of where to download the file logs to.")
-	cmd.Flags().StringVar(&options.nodeType, "node-type", options.nodeType, "Type of Ray node to download the files for.")
+	cmd.Flags().StringVar(&options.nodeType, "node-type", options.nodeType, "Type of Ray node to download the files for. One of: all, head, worker.")
+	cmd.Flags().StringVarP(&options.container, "container", "c", options.container, "Container to get the log of. Defaults to the Ray container.")
+	cmd.Flags().DurationVar(&options.since, "since", options.since, "Only return logs newer than a relative duration like 5s, 2m, or 3h. Defaults to all logs.")
+	cmd.Flags().BoolVarP(&options.follow, "follow", "f", options.follow, "If present, stream the log of the head node instead of downloading the logs.")
+	cmd.Flags().BoolVar(&options.nonInteractive, "non-interactive", options.nonInteractive, "If present, fail instead of prompting for the RayCluster when none is given and the namespace has several")
 	options.configFlags.AddFlags(cmd.Flags())
 	return cmd
 }
@@ -112,29 +143,49 @@ func (options *ClusterLogOptions) Validate() error {
 		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
 	}
 
-	// Command must have ray cluster name
-	if len(options.args) != 1 {
+	// Without a ray cluster name, the RayCluster is selected in Run
+	if len(options.args) > 1 {
 		return fmt.Errorf("must have at only one argument")
-	} else if options.outputDir == "" {
-		fmt.Fprintln(options.ioStreams.Out, "No output directory specified, creating dir under current directory using cluster name.")
-		options.outputDir = options.args[0]
-		err := os.MkdirAll(options.outputDir, 0o755)
-		if err != nil {
-			return fmt.Errorf("could not create directory with cluster name %s: %w", options.outputDir, err)
-		}
+	}
+
+	if options.since < 0 {
+		return fmt.Errorf("--since must be a positive duration, got %s", options.since)
 	}
 
 	switch options.nodeType {
-	case "all":
-		return fmt.Errorf("node type `all` is currently not supported")
-	case "head":
+	case "all", "head", "worker":
 		break
-	case "worker":
-		return fmt.Errorf("node type `worker` is currently not supported")
 	default:
 		return fmt.Errorf("unknown node type `%s`", options.nodeType)
 	}
 
+	if options.follow {
+		if options.nodeType != "head" {
+			return fmt.Errorf("--follow is only supported for the head node")
+		}
+		// The log is streamed to the output, so no directory is needed
+		return nil
+	}
+
+	if len(options.args) == 0 {
+		// The output directory defaults to the name of the RayCluster, which is only known once it is selected
+		return nil
+	}
+	return options.validateOutputDir()
+}
+
+// validateOutputDir checks that the output directory exists, creating a directory named after the RayCluster if
+// no output directory is specified
+func (options *ClusterLogOptions) validateOutputDir() error {
+	if options.outputDir == "" {
+		fmt.Fprintln(options.ioStreams.Out, "No output directory specified, creating dir under current directory using cluster name.")
+		options.outputDir = options.args[0]
+		err := os.MkdirAll(options.outputDir, 0o755)
+		if err != nil {
+			return fmt.Errorf("could not create directory with cluster name %s: %w", options.outputDir, err)
+		}
+	}
+
 	info, err := os.Stat(options.outputDir)
 	if os.IsNotExist(err) {
 		return fmt.Errorf("Directory does not exist. Failed with: %w", err)
@@ -153,91 +204,189 @@ func (options *ClusterLogOptions) Run(ctx context.Context, factory cmdutil.Facto
 		return fmt.Errorf("failed to retrieve kubernetes client set: %w", err)
 	}
 
+	if len(options.args) == 0 {
+		if err := options.selectRayCluster(ctx, factory); err != nil {
+			return err
+		}
+	}
+
 	var listopts v1.ListOptions
-	if options.nodeType == "head" {
+	switch options.nodeType {
+	case "head":
 		listopts = v1.ListOptions{
 			LabelSelector: fmt.Sprintf("ray.io/group=headgroup, ray.io/cluster=%s", options.args[0]),
 		}
+	case "worker":
+		listopts = v1.ListOptions{
+			LabelSelector: fmt.Sprintf("ray.io/node-type=worker, ray.io/cluster=%s", options.args[0]),
+		}
+	default:
+		listopts = v1.ListOptions{
+			LabelSelector: fmt.Sprintf("ray.io/cluster=%s", options.args[0]),
+		}
 	}
 
-	// Get list of nodes that are considered ray heads
-	rayHeads, err := kubeClientSet.CoreV1().Pods(*options.configFlags.Namespace).List(ctx, listopts)
+	// Get list of Ray nodes of the requested type
+	rayNodes, err := kubeClientSet.CoreV1().Pods(*options.configFlags.Namespace).List(ctx, listopts)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve head node for cluster %s: %w", options.args[0], err)
+		return fmt.Errorf("failed to retrieve %s nodes for cluster %s: %w", options.nodeType, options.args[0], err)
 	}
 
-	// Get a list of logs of the ray heads.
-	var logList []*bytes.Buffer
-	for _, rayHead := range rayHeads.Items {
-		request := kubeClientSet.CoreV1().Pods(rayHead.Namespace).GetLogs(rayHead.Name, &corev1.PodLogOptions{})
-
-		podLogs, err := request.Stream(ctx)
-		if err != nil {
-			return fmt.Errorf("Error retrieving log for kuberay-head %s: %w", rayHead.Name, err)
+	if options.follow {
+		if len(rayNodes.Items) == 0 {
+			return fmt.Errorf("no head node found for cluster %s", options.args[0])
 		}
-		defer podLogs.Close()
+		return options.followLogs(ctx, kubeClientSet, rayNodes.Items[0])
+	}
 
-		// Get current logs:
-		buf := new(bytes.Buffer)
-		_, err = io.Copy(buf, podLogs)
-		if err != nil {
-			return fmt.Errorf("Failed to get read current logs for kuberay-head %s: %w", rayHead.Name, err)
-		}
+	restconfig, err := factory.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get restconfig: %w", err)
+	}
 
-		logList = append(logList, buf)
+	// The Pods are written to separate files, so their logs can be fetched concurrently
+	out := &syncWriter{w: options.ioStreams.Out}
+	errs := make([]error, len(rayNodes.Items))
+	var wg sync.WaitGroup
+	for ind := range rayNodes.Items {
+		wg.Add(1)
+		go func(ind int) {
+			defer wg.Done()
+			errs[ind] = options.downloadPodLogs(ctx, kubeClientSet, restconfig, out, rayNodes.Items[ind])
+		}(ind)
 	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
 
-	// Pod file name format is name of the ray head
-	for ind, logList := range logList {
-		curFilePath := filepath.Join(options.outputDir, rayHeads.Items[ind].Name, "stdout.log")
-		dirPath := filepath.Join(options.outputDir, rayHeads.Items[ind].Name)
-		err := os.MkdirAll(dirPath, 0o755)
-		if err != nil {
-			return fmt.Errorf("failed to create directory within path %s: %w", dirPath, err)
-		}
-		file, err := os.OpenFile(curFilePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
-		if err != nil {
-			return fmt.Errorf("failed to create/open file for kuberay-head with path: %s: %w", curFilePath, err)
-		}
-		defer file.Close()
+// selectRayCluster selects the RayCluster of the namespace to get the logs of when none is given
+func (options *ClusterLogOptions) selectRayCluster(ctx context.Context, factory cmdutil.Factory) error {
+	dynamicClient, err := client.NewDynamicClient(factory)
+	if err != nil {
+		return fmt.Errorf("dynamic client failed to initialize: %w", err)
+	}
+	namespace := *options.configFlags.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	clusterName, err := selector.RayCluster(ctx, dynamicClient, *options.ioStreams, namespace, options.nonInteractive)
+	if err != nil {
+		return err
+	}
+	options.args = []string{clusterName}
+	if options.follow {
+		return nil
+	}
+	return options.validateOutputDir()
+}
 
-		_, err = logList.WriteTo(file)
-		if err != nil {
-			return fmt.Errorf("failed to write to file for kuberay-head: %s: %w", rayHeads.Items[ind].Name, err)
-		}
+// downloadPodLogs writes the container log of the Ray node to <out-dir>/<group>/<pod>.log and
+// the Ray session logs to <out-dir>/<group>/<pod>/
+func (options *ClusterLogOptions) downloadPodLogs(ctx context.Context, kubeClientSet kubernetes.Interface, restconfig *rest.Config, out io.Writer, rayNode corev1.Pod) error {
+	container := options.containerName(rayNode)
+	podLogs, err := kubeClientSet.CoreV1().Pods(rayNode.Namespace).GetLogs(rayNode.Name, options.podLogOptions(container)).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("Error retrieving log for Ray node %s: %w", rayNode.Name, err)
+	}
+	defer podLogs.Close()
 
-		req := kubeClientSet.CoreV1().RESTClient().
-			Get().
-			Namespace(rayHeads.Items[ind].Namespace).
-			Resource("pods").
-			Name(rayHeads.Items[ind].Name).
-			SubResource("exec").
-			VersionedParams(&corev1.PodExecOptions{
-				Command: []string{"tar", "--warning=no-file-changed", "-cf", "-", "-C", filePathInPod, "."},
-				Stdin:   true,
-				Stdout:  true,
-				Stderr:  true,
-				TTY:     false,
-			}, clientgoscheme.ParameterCodec)
-
-		restconfig, err := factory.ToRESTConfig()
-		if err != nil {
-			return fmt.Errorf("failed to get restconfig: %w", err)
-		}
+	groupDir := filepath.Join(options.outputDir, groupName(rayNode))
+	if err := os.MkdirAll(groupDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory within path %s: %w", groupDir, err)
+	}
+	curFilePath := filepath.Join(groupDir, rayNode.Name+".log")
+	file, err := os.OpenFile(curFilePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create/open file for Ray node with path: %s: %w", curFilePath, err)
+	}
+	defer file.Close()
 
-		exec, err := options.Executor.CreateExecutor(restconfig, req.URL())
-		if err != nil {
-			return fmt.Errorf("failed to create executor with error: %w", err)
-		}
+	if _, err := io.Copy(file, podLogs); err != nil {
+		return fmt.Errorf("failed to write to file for Ray node: %s: %w", rayNode.Name, err)
+	}
+	fmt.Fprintf(out, "Downloaded log of container %s for Ray node %s to %s\n", container, rayNode.Name, curFilePath)
+
+	req := kubeClientSet.CoreV1().RESTClient().
+		Get().
+		Namespace(rayNode.Namespace).
+		Resource("pods").
+		Name(rayNode.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   []string{"tar", "--warning=no-file-changed", "-cf", "-", "-C", filePathInPod, "."},
+			Stdin:     false,
+			Stdout:    true,
+			Stderr:    true,
+			TTY:       false,
+		}, clientgoscheme.ParameterCodec)
+
+	exec, err := options.Executor.CreateExecutor(restconfig, req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to create executor with error: %w", err)
+	}
 
-		err = options.downloadRayLogFiles(ctx, exec, rayHeads.Items[ind])
-		if err != nil {
-			return fmt.Errorf("failed to download ray head log files with error: %w", err)
-		}
+	if err := options.downloadRayLogFiles(ctx, exec, rayNode, out); err != nil {
+		return fmt.Errorf("failed to download log files of Ray node %s with error: %w", rayNode.Name, err)
+	}
+	return nil
+}
+
+// followLogs streams the container log of the Ray node to the output until the context is cancelled
+func (options *ClusterLogOptions) followLogs(ctx context.Context, kubeClientSet kubernetes.Interface, rayNode corev1.Pod) error {
+	logOptions := options.podLogOptions(options.containerName(rayNode))
+	logOptions.Follow = true
+
+	podLogs, err := kubeClientSet.CoreV1().Pods(rayNode.Namespace).GetLogs(rayNode.Name, logOptions).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("Error retrieving log for Ray node %s: %w", rayNode.Name, err)
+	}
+	defer podLogs.Close()
+
+	if _, err := io.Copy(options.ioStreams.Out, podLogs); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to follow log for Ray node %s: %w", rayNode.Name, err)
 	}
 	return nil
 }
 
+func (options *ClusterLogOptions) podLogOptions(container string) *corev1.PodLogOptions {
+	logOptions := &corev1.PodLogOptions{Container: container}
+	if options.since > 0 {
+		sinceSeconds := int64(math.Ceil(options.since.Seconds()))
+		logOptions.SinceSeconds = &sinceSeconds
+	}
+	return logOptions
+}
+
+// containerName returns the container selected with --container, defaulting to the Ray container,
+// which KubeRay always places first in the Pod
+func (options *ClusterLogOptions) containerName(rayNode corev1.Pod) string {
+	if options.container != "" || len(rayNode.Spec.Containers) == 0 {
+		return options.container
+	}
+	return rayNode.Spec.Containers[0].Name
+}
+
+// groupName returns the name of the group the Ray node belongs to, which is used as its log directory
+func groupName(rayNode corev1.Pod) string {
+	if group := rayNode.Labels["ray.io/group"]; group != "" {
+		return group
+	}
+	return "ungrouped"
+}
+
+// syncWriter serializes the writes of the concurrent log downloads
+type syncWriter struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
 // RemoteExecutor creates the executor for executing exec on the pod - provided for testing purposes
 type RemoteExecutor interface {
 	CreateExecutor(restConfig *rest.Config, url *url.URL) (remotecommand.Executor, error)
@@ -250,36 +399,36 @@ func (dre *DefaultRemoteExecutor) CreateExecutor(restConfig *rest.Config, url *u
 	return remotecommand.NewSPDYExecutor(restConfig, "POST", url)
 }
 
-// downloadRayLogFiles will use to the executor and retrieve the logs file from the inputted ray head
-func (options *ClusterLogOptions) downloadRayLogFiles(ctx context.Context, exec remotecommand.Executor, rayhead corev1.Pod) error {
+// downloadRayLogFiles will use to the executor and retrieve the logs file from the inputted ray node
+func (options *ClusterLogOptions) downloadRayLogFiles(ctx context.Context, exec remotecommand.Executor, rayNode corev1.Pod, out io.Writer) error {
 	outreader, outStream := io.Pipe()
 	go func() {
-		defer outStream.Close()
 		err := exec.StreamWithContext(ctx, remotecommand.StreamOptions{
-			Stdin:  options.ioStreams.In,
 			Stdout: outStream,
 			Stderr: options.ioStreams.ErrOut,
 			Tty:    false,
 		})
 		if err != nil {
-			log.Fatalf("Error occurred while calling remote command: %v", err)
+			err = fmt.Errorf("Error occurred while calling remote command: %w", err)
 		}
+		// A nil error closes the pipe with io.EOF
+		outStream.CloseWithError(err)
 	}()
 
 	// Goes through the tar and create/copy them one by one into the destination dir
 	tarReader := tar.NewReader(outreader)
 	header, err := tarReader.Next()
 	if err != nil && !errors.Is(err, io.EOF) {
-		return fmt.Errorf("error will extracting head tar file for ray head %s: %w", rayhead.Name, err)
+		return fmt.Errorf("error will extracting tar file for ray node %s: %w", rayNode.Name, err)
 	}
 	for !errors.Is(err, io.EOF) {
-		fmt.Printf("Downloading file %s for Ray Head %s\n", header.Name, rayhead.Name)
 		if err != nil {
 			return fmt.Errorf("Error reading tar archive: %w", err)
 		}
+		fmt.Fprintf(out, "Downloading file %s for Ray node %s\n", header.Name, rayNode.Name)
 
 		// Construct the full local path and a directory for the tmp file logs
-		localFilePath := filepath.Join(path.Clean(options.outputDir), path.Clean(rayhead.Name), path.Clean(header.Name))
+		localFilePath := filepath.Join(path.Clean(options.outputDir), path.Clean(groupName(rayNode)), path.Clean(rayNode.Name), path.Clean(header.Name))
 
 		switch header.Typeflag {
 		case tar.TypeDir:
@@ -289,7 +438,7 @@ func (options *ClusterLogOptions) downloadRayLogFiles(ctx context.Context, exec
 		case tar.TypeReg:
 			// Check for overflow: G115
 			if header.Mode < 0 || header.Mode > math.MaxUint32 {
-				fmt.Fprintf(options.ioStreams.Out, "file mode out side of accceptable value %d skipping file", header.Mode)
+				fmt.Fprintf(out, "file mode out side of accceptable value %d skipping file", header.Mode)
 			}
 			// Create file and write contents
 			outFile, err := os.OpenFile(localFilePath, os.O_CREATE|os.O_RDWR, os.FileMode(header.Mode)) //nolint:gosec // lint failing due to file mode conversion from uint64 to int32, checked above
@@ -311,7 +460,7 @@ func (options *ClusterLogOptions) downloadRayLogFiles(ctx context.Context, exec
 				}
 			}
 		default:
-			fmt.Printf("Ignoring unsupported file type: %b", header.Typeflag)
+			fmt.Fprintf(out, "Ignoring unsupported file type: %b\n", header.Typeflag)
 		}
 
 		header, err = tarReader.Next()
@@ -200,6 +200,15 @@ func TestRayClusterLogValidate(t *testing.T) {
 			},
 			expectError: "must have at only one argument",
 		},
+		{
+			name: "Test validation without arg leaves the output directory to the selected cluster",
+			opts: &ClusterLogOptions{
+				configFlags: fakeConfigFlags,
+				args:        []string{},
+				nodeType:    "head",
+				ioStreams:   &testStreams,
+			},
+		},
 		{
 			name: "Test validation when node type is `all`",
 			opts: &ClusterLogOptions{
@@ -210,7 +219,7 @@ func TestRayClusterLogValidate(t *testing.T) {
 				nodeType:    "all",
 				ioStreams:   &testStreams,
 			},
-			expectError: "node type `all` is currently not supported",
+			expectError: "",
 		},
 		{
 			name: "Test validation when node type is `worker`",
@@ -222,7 +231,45 @@ func TestRayClusterLogValidate(t *testing.T) {
 				nodeType:    "worker",
 				ioStreams:   &testStreams,
 			},
-			expectError: "node type `worker` is currently not supported",
+			expectError: "",
+		},
+		{
+			name: "Test validation when following worker nodes",
+			opts: &ClusterLogOptions{
+				// Use fake config to bypass the config flag checks
+				configFlags: fakeConfigFlags,
+				args:        []string{"fake-cluster"},
+				nodeType:    "worker",
+				follow:      true,
+				ioStreams:   &testStreams,
+			},
+			expectError: "--follow is only supported for the head node",
+		},
+		{
+			name: "Test validation when following the head node without output directory",
+			opts: &ClusterLogOptions{
+				// Use fake config to bypass the config flag checks
+				configFlags: fakeConfigFlags,
+				outputDir:   "randomPath-here",
+				args:        []string{"fake-cluster"},
+				nodeType:    "head",
+				follow:      true,
+				ioStreams:   &testStreams,
+			},
+			expectError: "",
+		},
+		{
+			name: "Test validation with negative since",
+			opts: &ClusterLogOptions{
+				// Use fake config to bypass the config flag checks
+				configFlags: fakeConfigFlags,
+				outputDir:   fakeDir,
+				args:        []string{"fake-cluster"},
+				nodeType:    "head",
+				since:       -time.Minute,
+				ioStreams:   &testStreams,
+			},
+			expectError: "--since must be a positive duration, got -1m0s",
 		},
 		{
 			name: "Test validation when node type is `random-string`",
@@ -292,7 +339,7 @@ func TestRayClusterLogValidate(t *testing.T) {
 			if tc.expectError != "" {
 				assert.Equal(t, tc.expectError, err.Error())
 			} else {
-				if tc.opts.outputDir == "" {
+				if len(tc.opts.args) > 0 && tc.opts.outputDir == "" {
 					assert.Equal(t, tc.opts.args[0], tc.opts.outputDir)
 				}
 				assert.True(t, err == nil)
@@ -316,6 +363,7 @@ func TestRayClusterLogRun(t *testing.T) {
 	fakeClusterLogOptions.Executor = &FakeRemoteExecutor{}
 	fakeClusterLogOptions.args = []string{"test-cluster"}
 	fakeClusterLogOptions.outputDir = fakeDir
+	fakeClusterLogOptions.nodeType = "all"
 
 	// Create list of fake ray heads
 	rayHeadsList := &v1.PodList{
@@ -347,10 +395,10 @@ func TestRayClusterLogRun(t *testing.T) {
 			},
 			{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-cluster-kuberay-head-2",
+					Name:      "test-cluster-kuberay-worker-1",
 					Namespace: "test",
 					Labels: map[string]string{
-						"ray.io/group":    "headgroup",
+						"ray.io/group":    "cpu-group",
 						"ray.io/clusters": "test-cluster",
 					},
 				},
@@ -369,7 +417,7 @@ func TestRayClusterLogRun(t *testing.T) {
 		},
 	}
 
-	// create logs for multiple head pods and turn them into io streams so they can be returned with the fake client
+	// create logs for the head and worker pods and turn them into io streams so they can be returned with the fake client
 	fakeLogs := []string{
 		"This is some fake log data for first pod.\nStill first pod logs\n",
 		"This is some fake log data for second pod.\nStill second pod logs\n",
@@ -387,8 +435,10 @@ func TestRayClusterLogRun(t *testing.T) {
 			case "/api/v1/pods":
 				return &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(), Body: cmdtesting.ObjBody(codec, rayHeadsList)}, nil
 			case "/api/v1/namespaces/test/pods/test-cluster-kuberay-head-1/log":
+				assert.Equal(t, "mycontainer", req.URL.Query().Get("container"))
 				return &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(), Body: logReader1}, nil
-			case "/api/v1/namespaces/test/pods/test-cluster-kuberay-head-2/log":
+			case "/api/v1/namespaces/test/pods/test-cluster-kuberay-worker-1/log":
+				assert.Equal(t, "anothercontainer", req.URL.Query().Get("container"))
 				return &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(), Body: logReader2}, nil
 			default:
 				t.Fatalf("request url: %#v,and request: %#v", req.URL, req)
@@ -404,28 +454,76 @@ func TestRayClusterLogRun(t *testing.T) {
 	err = fakeClusterLogOptions.Run(context.Background(), tf)
 	assert.Nil(t, err)
 
-	// Check that the two directories are there
-	entries, err := os.ReadDir(fakeDir)
-	assert.Nil(t, err)
-	assert.Equal(t, 2, len(entries))
-
-	assert.Equal(t, "test-cluster-kuberay-head-1", entries[0].Name())
-	assert.Equal(t, "test-cluster-kuberay-head-2", entries[1].Name())
-
-	// Check the first directory for the logs
-	for ind, entry := range entries {
-		currPath := filepath.Join(fakeDir, entry.Name())
-		currDir, err := os.ReadDir(currPath)
-		assert.Nil(t, err)
-		assert.Equal(t, 1, len(currDir))
-		openfile, err := os.Open(filepath.Join(currPath, "stdout.log"))
-		assert.Nil(t, err)
-		actualContent, err := io.ReadAll(openfile)
+	// Check that the logs are saved under the directory of their group
+	expectedLogFiles := []string{
+		filepath.Join(fakeDir, "headgroup", "test-cluster-kuberay-head-1.log"),
+		filepath.Join(fakeDir, "cpu-group", "test-cluster-kuberay-worker-1.log"),
+	}
+	for ind, logFile := range expectedLogFiles {
+		actualContent, err := os.ReadFile(logFile)
 		assert.Nil(t, err)
 		assert.Equal(t, fakeLogs[ind], string(actualContent))
 	}
 }
 
+func TestRayClusterLogRunFollow(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+
+	testStreams, _, resBuf, _ := genericiooptions.NewTestIOStreams()
+
+	fakeClusterLogOptions := NewClusterLogOptions(testStreams)
+	fakeClusterLogOptions.args = []string{"test-cluster"}
+	fakeClusterLogOptions.nodeType = "head"
+	fakeClusterLogOptions.follow = true
+	fakeClusterLogOptions.since = 90 * time.Second
+
+	rayHeadsList := &v1.PodList{
+		Items: []v1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster-kuberay-head-1",
+					Namespace: "test",
+					Labels: map[string]string{
+						"ray.io/group":   "headgroup",
+						"ray.io/cluster": "test-cluster",
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{Name: "ray-head"},
+						{Name: "autoscaler"},
+					},
+				},
+			},
+		},
+	}
+
+	codec := scheme.Codecs.LegacyCodec(scheme.Scheme.PrioritizedVersionsAllGroups()...)
+	tf.Client = &fake.RESTClient{
+		GroupVersion:         v1.SchemeGroupVersion,
+		NegotiatedSerializer: resource.UnstructuredPlusDefaultContentConfig().NegotiatedSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			switch req.URL.Path {
+			case "/api/v1/pods":
+				return &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(), Body: cmdtesting.ObjBody(codec, rayHeadsList)}, nil
+			case "/api/v1/namespaces/test/pods/test-cluster-kuberay-head-1/log":
+				assert.Equal(t, "ray-head", req.URL.Query().Get("container"))
+				assert.Equal(t, "true", req.URL.Query().Get("follow"))
+				assert.Equal(t, "90", req.URL.Query().Get("sinceSeconds"))
+				return &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(), Body: io.NopCloser(bytes.NewReader([]byte("head log\n")))}, nil
+			default:
+				t.Fatalf("request url: %#v,and request: %#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+
+	err := fakeClusterLogOptions.Run(context.Background(), tf)
+	assert.Nil(t, err)
+	assert.Equal(t, "head log\n", resBuf.String())
+}
+
 func TestDownloadRayLogFiles(t *testing.T) {
 	fakeDir, err := os.MkdirTemp("", "fake-directory")
 	assert.Nil(t, err)
@@ -467,16 +565,16 @@ func TestDownloadRayLogFiles(t *testing.T) {
 
 	executor, _ := fakeNewSPDYExecutor("GET", &url.URL{}, fakeTar)
 
-	err = fakeClusterLogOptions.downloadRayLogFiles(context.Background(), executor, rayHead)
+	err = fakeClusterLogOptions.downloadRayLogFiles(context.Background(), executor, rayHead, testStreams.Out)
 	assert.Nil(t, err)
 
-	entries, err := os.ReadDir(fakeDir)
+	nodeDir := filepath.Join(fakeDir, "headgroup", "test-cluster-kuberay-head-1")
+	info, err := os.Stat(nodeDir)
 	assert.Nil(t, err)
-	assert.Equal(t, 1, len(entries))
+	assert.True(t, info.IsDir())
 
 	// Assert the files
-	assert.True(t, entries[0].IsDir())
-	files, err := os.ReadDir(filepath.Join(fakeDir, entries[0].Name()))
+	files, err := os.ReadDir(nodeDir)
 	assert.Nil(t, err)
 	assert.Equal(t, 2, len(files))
 
@@ -495,7 +593,7 @@ func TestDownloadRayLogFiles(t *testing.T) {
 		curr := expectedfileoutput[ind]
 
 		assert.Equal(t, curr.Name, fileInfo.Name())
-		openfile, err := os.Open(filepath.Join(fakeDir, entries[0].Name(), file.Name()))
+		openfile, err := os.Open(filepath.Join(nodeDir, file.Name()))
 		assert.Nil(t, err)
 		actualContent, err := io.ReadAll(openfile)
 		assert.Nil(t, err)
@@ -0,0 +1,30 @@
+package operator
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
+)
+
+func NewOperatorCommand(streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "operator",
+		Short:        "Manage the KubeRay operator",
+		Long:         `Install, upgrade and report the health of the KubeRay operator and its CRDs.`,
+		SilenceUsage: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) > 0 {
+				logging.New(streams.ErrOut).Warnf("unknown command(s) %q", strings.Join(args, " "))
+			}
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	cmd.AddCommand(NewOperatorInstallCommand(streams))
+	cmd.AddCommand(NewOperatorUpgradeCommand(streams))
+	cmd.AddCommand(NewOperatorStatusCommand(streams))
+	return cmd
+}
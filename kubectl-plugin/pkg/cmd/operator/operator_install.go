@@ -0,0 +1,245 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/operator"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/progress"
+)
+
+const defaultOperatorTimeout = 5 * time.Minute
+
+type OperatorInstallOptions struct {
+	configFlags    *genericclioptions.ConfigFlags
+	ioStreams      *genericclioptions.IOStreams
+	reporter       *progress.Reporter
+	dryRunStrategy cmdutil.DryRunStrategy
+	timeout        time.Duration
+	// upgrade is set by `kubectl ray operator upgrade`, which requires an installed operator
+	upgrade bool
+	force   bool
+	noWait  bool
+}
+
+var (
+	operatorInstallLong = templates.LongDesc(`
+		Install the KubeRay operator ` + operator.Version + ` and its CRDs in the ray-system namespace.
+
+		The manifests of the operator are embedded in the plugin and pinned to the KubeRay release above, so the installed
+		version only changes with the version of the plugin. They are applied with server-side apply. Use
+		'kubectl ray operator upgrade' to upgrade an operator installed by this command.
+
+		CRDs that are already installed, e.g. left over from a previous installation, are checked against the existing
+		custom resources first, see 'kubectl ray operator upgrade --help'.
+
+		Use '--dry-run=client' to print the manifests without applying them, e.g. to review them or to commit them to a
+		GitOps repository.
+	`)
+
+	operatorInstallExample = templates.Examples(`
+		# Install the KubeRay operator and wait until it is available
+		kubectl ray operator install
+
+		# Print the manifests of the KubeRay operator
+		kubectl ray operator install --dry-run=client
+
+		# Validate the manifests with the API server without installing them
+		kubectl ray operator install --dry-run=server
+	`)
+)
+
+func NewOperatorInstallOptions(streams genericclioptions.IOStreams) *OperatorInstallOptions {
+	return &OperatorInstallOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+		timeout:     defaultOperatorTimeout,
+	}
+}
+
+func NewOperatorInstallCommand(streams genericclioptions.IOStreams) *cobra.Command {
+	options := NewOperatorInstallOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:          "install",
+		Short:        "Install the KubeRay operator embedded in the plugin",
+		Long:         operatorInstallLong,
+		Example:      operatorInstallExample,
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := options.Complete(cmd); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	addOperatorApplyFlags(cmd, options)
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+// addOperatorApplyFlags adds the flags shared by install and upgrade
+func addOperatorApplyFlags(cmd *cobra.Command, options *OperatorInstallOptions) {
+	cmd.Flags().BoolVar(&options.force, "force", options.force, "If present, apply the manifests even if the compatibility check with the installed CRDs and existing custom resources fails")
+	cmd.Flags().BoolVar(&options.noWait, "no-wait", options.noWait, "If present, do not wait for the CRDs to be established and the operator to be available")
+	cmd.Flags().DurationVar(&options.timeout, "timeout", options.timeout, "Maximum time to wait for the operator to be available")
+	cmdutil.AddDryRunFlag(cmd)
+}
+
+func (options *OperatorInstallOptions) Complete(cmd *cobra.Command) error {
+	if options.reporter == nil {
+		options.reporter = progress.NewReporter(options.ioStreams.Out, progress.Auto)
+	}
+	var err error
+	options.dryRunStrategy, err = cmdutil.GetDryRunStrategy(cmd)
+	return err
+}
+
+func (options *OperatorInstallOptions) Validate() error {
+	if options.dryRunStrategy == cmdutil.DryRunClient {
+		return nil
+	}
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	if options.timeout <= 0 {
+		return fmt.Errorf("--timeout must be positive")
+	}
+	return nil
+}
+
+func (options *OperatorInstallOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	if options.dryRunStrategy == cmdutil.DryRunClient {
+		_, err := options.ioStreams.Out.Write(operator.ManifestsYAML())
+		return err
+	}
+	defer options.reporter.Close()
+
+	objects, err := operator.Manifests()
+	if err != nil {
+		return err
+	}
+	k8sClient, err := client.NewClient(factory)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	deployments, err := operator.Deployments(ctx, k8sClient.KubernetesClient())
+	if err != nil {
+		return err
+	}
+
+	var installedVersion string
+	switch {
+	case !options.upgrade && len(deployments) > 0:
+		deployment := deployments[0]
+		if operator.IsManaged(&deployment) {
+			return fmt.Errorf("KubeRay operator is already installed in %s/%s, use 'kubectl ray operator upgrade' to upgrade it", deployment.Namespace, deployment.Name)
+		}
+		return fmt.Errorf("KubeRay operator is already installed in %s/%s, e.g. with Helm, uninstall it first", deployment.Namespace, deployment.Name)
+	case options.upgrade && len(deployments) == 0:
+		return fmt.Errorf("KubeRay operator is not installed, use 'kubectl ray operator install' to install it")
+	case options.upgrade:
+		for _, deployment := range deployments {
+			if !operator.IsManaged(&deployment) {
+				return fmt.Errorf("KubeRay operator %s/%s was not installed by 'kubectl ray operator install', e.g. with Helm, upgrade it the way it was installed", deployment.Namespace, deployment.Name)
+			}
+		}
+		// The version is empty if it cannot be determined, e.g. because the image is not tagged with a version
+		installedVersion, _ = k8sClient.GetKubeRayOperatorVersion(ctx)
+	}
+
+	options.reporter.Step("Checking the compatibility of KubeRay %s with the installed CRDs and custom resources", operator.Version)
+	check, err := operator.CheckUpgrade(ctx, k8sClient.DynamicClient(), installedVersion, objects)
+	if err != nil {
+		options.reporter.Fail()
+		return err
+	}
+	if len(check.Problems) > 0 && !options.force {
+		options.reporter.Fail()
+		options.printWarnings(check.Warnings)
+		return fmt.Errorf("KubeRay %s is not compatible with the installed CRDs or custom resources, use --force to apply it anyway:\n%s", operator.Version, formatProblems(check.Problems))
+	}
+	options.reporter.Done()
+	options.printWarnings(check.Warnings)
+	for _, problem := range check.Problems {
+		options.reporter.Info("Ignoring with --force: %s", problem)
+	}
+
+	mapper, err := factory.ToRESTMapper()
+	if err != nil {
+		return fmt.Errorf("failed to create REST mapper: %w", err)
+	}
+	dryRun := options.dryRunStrategy == cmdutil.DryRunServer
+	options.reporter.Step("%s the KubeRay operator %s", options.verb(installedVersion), operator.Version)
+	if err := operator.Apply(ctx, k8sClient.DynamicClient(), mapper, objects, dryRun); err != nil {
+		options.reporter.Fail()
+		return err
+	}
+	options.reporter.Done()
+	if dryRun {
+		options.reporter.Info("The manifests of KubeRay %s are valid (server dry run)", operator.Version)
+		return nil
+	}
+	if options.noWait {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, options.timeout)
+	defer cancel()
+	options.reporter.Step("Waiting for the KubeRay operator to be available")
+	if err := operator.WaitUntilAvailable(ctx, k8sClient.DynamicClient(), objects); err != nil {
+		options.reporter.Fail()
+		return err
+	}
+	options.reporter.Done()
+	return nil
+}
+
+// verb describes what applying the manifests does
+func (options *OperatorInstallOptions) verb(installedVersion string) string {
+	switch {
+	case !options.upgrade:
+		return "Installing"
+	case installedVersion == operator.Version:
+		return "Reapplying"
+	case installedVersion == "":
+		return "Upgrading to"
+	default:
+		return fmt.Sprintf("Upgrading from %s to", installedVersion)
+	}
+}
+
+func formatProblems(problems []string) string {
+	var builder strings.Builder
+	for i, problem := range problems {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		fmt.Fprintf(&builder, "  - %s", problem)
+	}
+	return builder.String()
+}
+
+func (options *OperatorInstallOptions) printWarnings(warnings []string) {
+	for _, warning := range warnings {
+		options.reporter.Info("Warning: %s", warning)
+	}
+}
@@ -0,0 +1,44 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/operator"
+)
+
+func TestOperatorInstallDryRunClient(t *testing.T) {
+	testStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	options := NewOperatorInstallOptions(testStreams)
+	options.dryRunStrategy = cmdutil.DryRunClient
+
+	require.NoError(t, options.Validate())
+	require.NoError(t, options.Run(context.Background(), nil))
+	assert.True(t, bytes.Equal(operator.ManifestsYAML(), out.Bytes()))
+}
+
+func TestOperatorInstallVerb(t *testing.T) {
+	tests := []struct {
+		name             string
+		installedVersion string
+		expected         string
+		upgrade          bool
+	}{
+		{name: "install", expected: "Installing"},
+		{name: "upgrade", installedVersion: "v1.1.0", upgrade: true, expected: "Upgrading from v1.1.0 to"},
+		{name: "upgrade from unknown version", upgrade: true, expected: "Upgrading to"},
+		{name: "same version", installedVersion: operator.Version, upgrade: true, expected: "Reapplying"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			options := &OperatorInstallOptions{upgrade: tc.upgrade}
+			assert.Equal(t, tc.expected, options.verb(tc.installedVersion))
+		})
+	}
+}
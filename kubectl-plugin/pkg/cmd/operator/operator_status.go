@@ -0,0 +1,312 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/dynamic"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/image"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/operator"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/printer"
+)
+
+// operatorVersionLabel is set on the KubeRay operator Deployment by the Helm chart
+const operatorVersionLabel = "app.kubernetes.io/version"
+
+type OperatorStatusOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	ioStreams   *genericclioptions.IOStreams
+	outputFlags *printer.OutputFlags
+}
+
+// operatorStatus is the health of the KubeRay operator Deployments and the Ray CRDs
+type operatorStatus struct {
+	EmbeddedVersion string             `json:"embeddedVersion"`
+	Deployments     []deploymentStatus `json:"deployments"`
+	CRDs            []crdStatus        `json:"crds"`
+	Warnings        []string           `json:"warnings,omitempty"`
+}
+
+type deploymentStatus struct {
+	Namespace     string `json:"namespace"`
+	Name          string `json:"name"`
+	Version       string `json:"version,omitempty"`
+	Image         string `json:"image,omitempty"`
+	Replicas      int32  `json:"replicas"`
+	ReadyReplicas int32  `json:"readyReplicas"`
+	Available     bool   `json:"available"`
+	// Managed is true if the operator was installed by `kubectl ray operator install`
+	Managed bool `json:"managed"`
+}
+
+type crdStatus struct {
+	Name           string   `json:"name"`
+	StorageVersion string   `json:"storageVersion,omitempty"`
+	ServedVersions []string `json:"servedVersions,omitempty"`
+	StoredVersions []string `json:"storedVersions,omitempty"`
+	Installed      bool     `json:"installed"`
+	Established    bool     `json:"established"`
+}
+
+var (
+	operatorStatusLong = templates.LongDesc(`
+		Report the health of the KubeRay operator and the versions of the Ray CRDs.
+
+		For each KubeRay operator Deployment, the version, the ready replicas and whether it is available are reported. For
+		each Ray CRD, the served and storage versions, the versions in which objects are stored and whether it is
+		established are reported. Problems, such as an unavailable operator or a newer KubeRay version embedded in the
+		plugin, are reported as warnings.
+	`)
+
+	operatorStatusExample = templates.Examples(`
+		# Report the health of the KubeRay operator and its CRDs
+		kubectl ray operator status
+
+		# Report it as JSON
+		kubectl ray operator status -o json
+	`)
+)
+
+func NewOperatorStatusOptions(streams genericclioptions.IOStreams) *OperatorStatusOptions {
+	return &OperatorStatusOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+		outputFlags: printer.NewOutputFlags(printer.JSON, printer.YAML),
+	}
+}
+
+func NewOperatorStatusCommand(streams genericclioptions.IOStreams) *cobra.Command {
+	options := NewOperatorStatusOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:          "status",
+		Short:        "Report the health of the KubeRay operator and its CRDs",
+		Long:         operatorStatusLong,
+		Example:      operatorStatusExample,
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	options.outputFlags.AddFlags(cmd)
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *OperatorStatusOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	return options.outputFlags.Validate()
+}
+
+func (options *OperatorStatusOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	k8sClient, err := client.NewClient(factory)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	status, err := getOperatorStatus(ctx, k8sClient)
+	if err != nil {
+		return err
+	}
+	if options.outputFlags.IsStructured() {
+		return options.outputFlags.PrintData(status, options.ioStreams.Out)
+	}
+	return printOperatorStatus(status, options.ioStreams.Out)
+}
+
+func getOperatorStatus(ctx context.Context, k8sClient client.Client) (*operatorStatus, error) {
+	status := &operatorStatus{EmbeddedVersion: operator.Version}
+	deployments, err := operator.Deployments(ctx, k8sClient.KubernetesClient())
+	if err != nil {
+		return nil, err
+	}
+	for i := range deployments {
+		status.Deployments = append(status.Deployments, newDeploymentStatus(&deployments[i]))
+	}
+
+	objects, err := operator.Manifests()
+	if err != nil {
+		return nil, err
+	}
+	for _, crd := range operator.CRDs(objects) {
+		crdStatus, err := getCRDStatus(ctx, k8sClient.DynamicClient(), crd.GetName())
+		if err != nil {
+			return nil, err
+		}
+		status.CRDs = append(status.CRDs, crdStatus)
+	}
+
+	status.Warnings = statusWarnings(status)
+	return status, nil
+}
+
+func newDeploymentStatus(deployment *appsv1.Deployment) deploymentStatus {
+	status := deploymentStatus{
+		Namespace:     deployment.Namespace,
+		Name:          deployment.Name,
+		ReadyReplicas: deployment.Status.ReadyReplicas,
+		Managed:       operator.IsManaged(deployment),
+	}
+	if deployment.Spec.Replicas != nil {
+		status.Replicas = *deployment.Spec.Replicas
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentAvailable && condition.Status == "True" {
+			status.Available = true
+		}
+	}
+	if containers := deployment.Spec.Template.Spec.Containers; len(containers) > 0 {
+		status.Image = containers[0].Image
+	}
+	// The image tag is the version that is actually running, unless it is not a version, e.g. "nightly"
+	if reference, err := image.ParseReference(status.Image); err == nil {
+		if _, err := utilversion.ParseGeneric(reference.Tag); err == nil {
+			status.Version = reference.Tag
+		}
+	}
+	if status.Version == "" {
+		status.Version = deployment.Labels[operatorVersionLabel]
+	}
+	return status
+}
+
+func getCRDStatus(ctx context.Context, dynamicClient dynamic.Interface, name string) (crdStatus, error) {
+	status := crdStatus{Name: name}
+	crd, err := dynamicClient.Resource(operator.CRDGVR).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return status, nil
+	}
+	if err != nil {
+		return status, fmt.Errorf("failed to get CRD %s: %w", name, err)
+	}
+	status.Installed = true
+
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, item := range versions {
+		version, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		versionName, _ := version["name"].(string)
+		if served, _ := version["served"].(bool); served {
+			status.ServedVersions = append(status.ServedVersions, versionName)
+		}
+		if storage, _ := version["storage"].(bool); storage {
+			status.StorageVersion = versionName
+		}
+	}
+	status.StoredVersions, _, _ = unstructured.NestedStringSlice(crd.Object, "status", "storedVersions")
+	status.Established, err = operator.HasTrueCondition("Established")(crd)
+	return status, err
+}
+
+// statusWarnings returns the problems of the operator and the CRDs
+func statusWarnings(status *operatorStatus) []string {
+	var warnings []string
+	if len(status.Deployments) == 0 {
+		warnings = append(warnings, "KubeRay operator is not installed, use 'kubectl ray operator install' to install it")
+	}
+	if len(status.Deployments) > 1 {
+		warnings = append(warnings, fmt.Sprintf("%d KubeRay operators are installed, they reconcile the same custom resources unless they watch different namespaces", len(status.Deployments)))
+	}
+	for _, deployment := range status.Deployments {
+		if !deployment.Available {
+			warnings = append(warnings, fmt.Sprintf("KubeRay operator %s/%s is not available, %d/%d replicas are ready", deployment.Namespace, deployment.Name, deployment.ReadyReplicas, deployment.Replicas))
+		}
+		if operator.IsOlder(deployment.Version) {
+			if deployment.Managed {
+				warnings = append(warnings, fmt.Sprintf("KubeRay operator %s is older than %s, use 'kubectl ray operator upgrade' to upgrade it", deployment.Version, operator.Version))
+			} else {
+				warnings = append(warnings, fmt.Sprintf("KubeRay operator %s/%s %s is older than the plugin, which is tested with %s", deployment.Namespace, deployment.Name, deployment.Version, operator.Version))
+			}
+		}
+	}
+	for _, crd := range status.CRDs {
+		switch {
+		case !crd.Installed:
+			warnings = append(warnings, fmt.Sprintf("CRD %s is not installed", crd.Name))
+		case !crd.Established:
+			warnings = append(warnings, fmt.Sprintf("CRD %s is not established", crd.Name))
+		}
+	}
+	return warnings
+}
+
+func printOperatorStatus(status *operatorStatus, out io.Writer) error {
+	tablePrinter := printers.NewTablePrinter(printers.PrintOptions{})
+
+	if len(status.Deployments) > 0 {
+		deploymentTable := &metav1.Table{
+			ColumnDefinitions: []metav1.TableColumnDefinition{
+				{Name: "Namespace", Type: "string"},
+				{Name: "Name", Type: "string"},
+				{Name: "Version", Type: "string"},
+				{Name: "Ready", Type: "string"},
+				{Name: "Available", Type: "boolean"},
+				{Name: "Image", Type: "string"},
+			},
+		}
+		for _, deployment := range status.Deployments {
+			deploymentTable.Rows = append(deploymentTable.Rows, metav1.TableRow{
+				Cells: []interface{}{deployment.Namespace, deployment.Name, deployment.Version, fmt.Sprintf("%d/%d", deployment.ReadyReplicas, deployment.Replicas), deployment.Available, deployment.Image},
+			})
+		}
+		if err := tablePrinter.PrintObj(deploymentTable, out); err != nil {
+			return err
+		}
+		fmt.Fprintln(out)
+	}
+
+	crdTable := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "CRD", Type: "string"},
+			{Name: "Established", Type: "boolean"},
+			{Name: "Served", Type: "string"},
+			{Name: "Storage", Type: "string"},
+			{Name: "Stored", Type: "string"},
+		},
+	}
+	for _, crd := range status.CRDs {
+		if !crd.Installed {
+			crdTable.Rows = append(crdTable.Rows, metav1.TableRow{Cells: []interface{}{crd.Name, false, "<not installed>", "", ""}})
+			continue
+		}
+		crdTable.Rows = append(crdTable.Rows, metav1.TableRow{
+			Cells: []interface{}{crd.Name, crd.Established, strings.Join(crd.ServedVersions, ","), crd.StorageVersion, strings.Join(crd.StoredVersions, ",")},
+		})
+	}
+	if err := tablePrinter.PrintObj(crdTable, out); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nKubeRay version embedded in the plugin: %s\n", status.EmbeddedVersion)
+	for _, warning := range status.Warnings {
+		fmt.Fprintln(out, "Warning:", warning)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/operator"
+)
+
+func newTestOperatorDeployment(namespace, image string, available bool) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      operator.Name,
+			Namespace: namespace,
+			Labels:    map[string]string{"app.kubernetes.io/name": "kuberay"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "kuberay-operator", Image: image}}},
+			},
+		},
+	}
+	if available {
+		deployment.Status.ReadyReplicas = 1
+		deployment.Status.Conditions = []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue}}
+	}
+	return deployment
+}
+
+func newTestCRD(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": name},
+		"spec": map[string]interface{}{
+			"versions": []interface{}{
+				map[string]interface{}{"name": "v1", "served": true, "storage": true},
+				map[string]interface{}{"name": "v1alpha1", "served": true, "storage": false},
+			},
+		},
+		"status": map[string]interface{}{
+			"storedVersions": []interface{}{"v1"},
+			"conditions":     []interface{}{map[string]interface{}{"type": "Established", "status": "True"}},
+		},
+	}}
+}
+
+func TestGetOperatorStatus(t *testing.T) {
+	kubeClient := kubeFake.NewSimpleClientset(newTestOperatorDeployment(operator.Namespace, "quay.io/kuberay/operator:v1.1.0", false))
+	dynamicClient := dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		operator.CRDGVR: "CustomResourceDefinitionList",
+	}, newTestCRD("rayclusters.ray.io"), newTestCRD("rayjobs.ray.io"))
+
+	status, err := getOperatorStatus(context.Background(), client.NewClientForTesting(kubeClient, dynamicClient))
+	require.NoError(t, err)
+
+	assert.Equal(t, operator.Version, status.EmbeddedVersion)
+	assert.Equal(t, []deploymentStatus{{
+		Namespace: operator.Namespace,
+		Name:      operator.Name,
+		Version:   "v1.1.0",
+		Image:     "quay.io/kuberay/operator:v1.1.0",
+		Replicas:  1,
+		Managed:   true,
+	}}, status.Deployments)
+	assert.ElementsMatch(t, []crdStatus{
+		{Name: "rayclusters.ray.io", StorageVersion: "v1", ServedVersions: []string{"v1", "v1alpha1"}, StoredVersions: []string{"v1"}, Installed: true, Established: true},
+		{Name: "rayjobs.ray.io", StorageVersion: "v1", ServedVersions: []string{"v1", "v1alpha1"}, StoredVersions: []string{"v1"}, Installed: true, Established: true},
+		{Name: "rayservices.ray.io"},
+		{Name: "rayworkergroups.ray.io"},
+	}, status.CRDs)
+	assert.Equal(t, []string{
+		"KubeRay operator ray-system/kuberay-operator is not available, 0/1 replicas are ready",
+		"KubeRay operator v1.1.0 is older than " + operator.Version + ", use 'kubectl ray operator upgrade' to upgrade it",
+		"CRD rayservices.ray.io is not installed",
+		"CRD rayworkergroups.ray.io is not installed",
+	}, status.Warnings)
+
+	var out bytes.Buffer
+	require.NoError(t, printOperatorStatus(status, &out))
+	assert.Contains(t, out.String(), "ray-system   kuberay-operator   v1.1.0    0/1")
+	assert.Contains(t, out.String(), "rayservices.ray.io       false         <not installed>")
+	assert.Contains(t, out.String(), "Warning: CRD rayservices.ray.io is not installed")
+}
+
+func TestStatusWarnings(t *testing.T) {
+	helmDeployment := newDeploymentStatus(newTestOperatorDeployment("default", "quay.io/kuberay/operator:v1.0.0", true))
+	assert.False(t, helmDeployment.Managed)
+	assert.True(t, helmDeployment.Available)
+
+	tests := []struct {
+		name     string
+		status   operatorStatus
+		expected []string
+	}{
+		{
+			name:     "not installed",
+			status:   operatorStatus{},
+			expected: []string{"KubeRay operator is not installed, use 'kubectl ray operator install' to install it"},
+		},
+		{
+			name:     "older operator installed with Helm",
+			status:   operatorStatus{Deployments: []deploymentStatus{helmDeployment}},
+			expected: []string{"KubeRay operator default/kuberay-operator v1.0.0 is older than the plugin, which is tested with " + operator.Version},
+		},
+		{
+			name: "two operators",
+			status: operatorStatus{Deployments: []deploymentStatus{
+				newDeploymentStatus(newTestOperatorDeployment(operator.Namespace, "quay.io/kuberay/operator:"+operator.Version, true)),
+				newDeploymentStatus(newTestOperatorDeployment("default", "quay.io/kuberay/operator:nightly", true)),
+			}},
+			expected: []string{"2 KubeRay operators are installed, they reconcile the same custom resources unless they watch different namespaces"},
+		},
+		{
+			name:     "CRD not established",
+			status:   operatorStatus{Deployments: []deploymentStatus{helmDeployment}, CRDs: []crdStatus{{Name: "rayjobs.ray.io", Installed: true}}},
+			expected: []string{"KubeRay operator default/kuberay-operator v1.0.0 is older than the plugin, which is tested with " + operator.Version, "CRD rayjobs.ray.io is not established"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, statusWarnings(&tc.status))
+		})
+	}
+}
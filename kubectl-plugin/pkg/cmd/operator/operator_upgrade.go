@@ -0,0 +1,61 @@
+package operator
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/operator"
+)
+
+var (
+	operatorUpgradeLong = templates.LongDesc(`
+		Upgrade the KubeRay operator installed by 'kubectl ray operator install' and its CRDs to KubeRay ` + operator.Version + `.
+
+		Before the manifests are applied, the upgrade is checked for compatibility with the installed CRDs and the existing
+		custom resources. The upgrade is refused, unless '--force' is given, if
+		  - the installed operator is newer, since downgrades are not supported
+		  - an installed CRD stores objects in a version that the new CRD does not serve
+		  - the spec of an existing RayCluster, RayJob or RayService has fields that the new CRD would prune, or misses
+		    fields that it requires
+		Versions that the new CRDs no longer serve are reported as warnings.
+
+		An operator installed otherwise, e.g. with Helm, must be upgraded the way it was installed.
+	`)
+
+	operatorUpgradeExample = templates.Examples(`
+		# Upgrade the KubeRay operator to the version embedded in the plugin
+		kubectl ray operator upgrade
+
+		# Only check the compatibility of the upgrade and validate the manifests with the API server
+		kubectl ray operator upgrade --dry-run=server
+	`)
+)
+
+func NewOperatorUpgradeCommand(streams genericclioptions.IOStreams) *cobra.Command {
+	options := NewOperatorInstallOptions(streams)
+	options.upgrade = true
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:          "upgrade",
+		Short:        "Upgrade the KubeRay operator to the version embedded in the plugin",
+		Long:         operatorUpgradeLong,
+		Example:      operatorUpgradeExample,
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := options.Complete(cmd); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	addOperatorApplyFlags(cmd, options)
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
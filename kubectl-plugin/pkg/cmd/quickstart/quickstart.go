@@ -0,0 +1,304 @@
+package quickstart
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/generation"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/operator"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/progress"
+)
+
+const (
+	defaultClusterName       = "raycluster-quickstart"
+	defaultQuickstartTimeout = 10 * time.Minute
+)
+
+type QuickstartOptions struct {
+	configFlags    *genericclioptions.ConfigFlags
+	ioStreams      *genericclioptions.IOStreams
+	reporter       *progress.Reporter
+	namespace      string
+	clusterName    string
+	localCluster   string
+	timeout        time.Duration
+	force          bool
+	skipOperator   bool
+	skipRayCluster bool
+}
+
+var (
+	quickstartLong = templates.LongDesc(`
+		Bootstrap a local KubeRay environment.
+
+		The current context must be a local Kubernetes cluster created with kind, minikube or k3d, unless '--force' is given.
+		The command installs the KubeRay operator ` + operator.Version + ` in the ray-system namespace, or upgrades it to this
+		version, by applying the manifests embedded in the plugin. It then creates a small RayCluster, waits until it is
+		ready and prints the next steps.
+
+		An existing KubeRay operator in another namespace, e.g. one installed with Helm, is used as is.
+	`)
+
+	quickstartExample = templates.Examples(`
+		# Install the KubeRay operator in the kind cluster and create a RayCluster named raycluster-quickstart
+		kind create cluster
+		kubectl ray quickstart
+
+		# Create the RayCluster with a different name in the ray namespace
+		kubectl ray quickstart --name my-cluster -n ray
+
+		# Only install or upgrade the KubeRay operator
+		kubectl ray quickstart --skip-raycluster
+	`)
+)
+
+func NewQuickstartOptions(streams genericclioptions.IOStreams) *QuickstartOptions {
+	return &QuickstartOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+		clusterName: defaultClusterName,
+		timeout:     defaultQuickstartTimeout,
+	}
+}
+
+func NewQuickstartCommand(streams genericclioptions.IOStreams) *cobra.Command {
+	options := NewQuickstartOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:          "quickstart",
+		Short:        "Install the KubeRay operator and create a sample RayCluster in a local Kubernetes cluster",
+		Long:         quickstartLong,
+		Example:      quickstartExample,
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := options.Complete(); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	cmd.Flags().StringVar(&options.clusterName, "name", options.clusterName, "Name of the sample RayCluster")
+	cmd.Flags().BoolVar(&options.force, "force", options.force, "If present, run even if the current context is not a kind, minikube or k3d cluster")
+	cmd.Flags().BoolVar(&options.skipOperator, "skip-operator", options.skipOperator, "If present, do not install or upgrade the KubeRay operator")
+	cmd.Flags().BoolVar(&options.skipRayCluster, "skip-raycluster", options.skipRayCluster, "If present, do not create the sample RayCluster")
+	cmd.Flags().DurationVar(&options.timeout, "timeout", options.timeout, "Maximum time to wait for the KubeRay operator and the RayCluster to be ready")
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *QuickstartOptions) Complete() error {
+	if *options.configFlags.Namespace == "" {
+		options.namespace = "default"
+	} else {
+		options.namespace = *options.configFlags.Namespace
+	}
+	if options.reporter == nil {
+		options.reporter = progress.NewReporter(options.ioStreams.Out, progress.Auto)
+	}
+	return nil
+}
+
+func (options *QuickstartOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	currentContext := config.CurrentContext
+	if options.configFlags.Context != nil && *options.configFlags.Context != "" {
+		currentContext = *options.configFlags.Context
+	}
+	if len(currentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	options.localCluster = detectLocalCluster(currentContext)
+	if options.localCluster == "" && !options.force {
+		return fmt.Errorf("context %s is not a kind, minikube or k3d cluster, use --force to run the quickstart in it anyway", currentContext)
+	}
+	if options.skipOperator && options.skipRayCluster {
+		return fmt.Errorf("--skip-operator and --skip-raycluster leave nothing to do")
+	}
+	if options.timeout <= 0 {
+		return fmt.Errorf("--timeout must be positive")
+	}
+	return nil
+}
+
+// detectLocalCluster returns the tool that created the cluster of the context, or an empty string if the context is
+// not one of the contexts created by kind, minikube or k3d
+func detectLocalCluster(context string) string {
+	switch {
+	case strings.HasPrefix(context, "kind-"):
+		return "kind"
+	case context == "minikube":
+		return "minikube"
+	case strings.HasPrefix(context, "k3d-"):
+		return "k3d"
+	default:
+		return ""
+	}
+}
+
+func (options *QuickstartOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	defer options.reporter.Close()
+	ctx, cancel := context.WithTimeout(ctx, options.timeout)
+	defer cancel()
+
+	k8sClient, err := client.NewClient(factory)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	if options.localCluster != "" {
+		options.reporter.Info("Using the %s cluster of the current context", options.localCluster)
+	}
+
+	if !options.skipOperator {
+		mapper, err := factory.ToRESTMapper()
+		if err != nil {
+			return fmt.Errorf("failed to create REST mapper: %w", err)
+		}
+		if err := options.installOperator(ctx, k8sClient, mapper); err != nil {
+			return err
+		}
+	}
+	if !options.skipRayCluster {
+		if err := options.createRayCluster(ctx, k8sClient.DynamicClient()); err != nil {
+			return err
+		}
+	}
+	options.printNextSteps(options.ioStreams.Out)
+	return nil
+}
+
+// installOperator applies the embedded manifests of the KubeRay operator and waits until the CRDs are established
+// and the operator is available. An operator that runs outside of ray-system was installed otherwise, e.g. with Helm,
+// and is not touched, since a second operator would reconcile the same resources.
+func (options *QuickstartOptions) installOperator(ctx context.Context, k8sClient client.Client, mapper meta.RESTMapper) error {
+	deployments, err := operator.Deployments(ctx, k8sClient.KubernetesClient())
+	if err != nil {
+		return err
+	}
+	for _, deployment := range deployments {
+		if !operator.IsManaged(&deployment) {
+			options.reporter.Info("Using the KubeRay operator %s/%s that is already installed", deployment.Namespace, deployment.Name)
+			return nil
+		}
+	}
+
+	objects, err := operator.Manifests()
+	if err != nil {
+		return err
+	}
+	verb := "Installing"
+	var installedVersion string
+	if len(deployments) > 0 {
+		verb = "Upgrading to"
+		installedVersion, _ = k8sClient.GetKubeRayOperatorVersion(ctx)
+	}
+	check, err := operator.CheckUpgrade(ctx, k8sClient.DynamicClient(), installedVersion, objects)
+	if err != nil {
+		return err
+	}
+	if len(check.Problems) > 0 {
+		return fmt.Errorf("KubeRay %s is not compatible with the installed CRDs or custom resources, see 'kubectl ray operator upgrade --dry-run=server':\n  - %s", operator.Version, strings.Join(check.Problems, "\n  - "))
+	}
+
+	options.reporter.Step("%s the KubeRay operator %s", verb, operator.Version)
+	if err := operator.Apply(ctx, k8sClient.DynamicClient(), mapper, objects, false); err != nil {
+		options.reporter.Fail()
+		return err
+	}
+	options.reporter.Done()
+
+	options.reporter.Step("Waiting for the KubeRay operator to be available")
+	if err := operator.WaitUntilAvailable(ctx, k8sClient.DynamicClient(), objects); err != nil {
+		options.reporter.Fail()
+		return err
+	}
+	options.reporter.Done()
+	return nil
+}
+
+// createRayCluster creates the sample RayCluster unless it already exists, and waits until it is ready
+func (options *QuickstartOptions) createRayCluster(ctx context.Context, dynamicClient dynamic.Interface) error {
+	rayCluster, err := options.generateRayCluster()
+	if err != nil {
+		return fmt.Errorf("failed to generate RayCluster: %w", err)
+	}
+
+	options.reporter.Step("Creating RayCluster %s in namespace %s", options.clusterName, options.namespace)
+	_, err = dynamicClient.Resource(util.RayClusterGVR).Namespace(options.namespace).Create(ctx, rayCluster, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		options.reporter.Fail()
+		return fmt.Errorf("failed to create RayCluster %s: %w", options.clusterName, err)
+	}
+	options.reporter.Done()
+	if apierrors.IsAlreadyExists(err) {
+		options.reporter.Info("RayCluster %s already exists", options.clusterName)
+	}
+
+	options.reporter.Step("Waiting for RayCluster %s to be ready, which includes pulling the Ray image", options.clusterName)
+	if _, err := client.WaitForResource(ctx, dynamicClient, util.RayClusterGVR, options.namespace, options.clusterName, client.IsRayClusterReady); err != nil {
+		options.reporter.Fail()
+		return fmt.Errorf("RayCluster %s is not ready: %w", options.clusterName, err)
+	}
+	options.reporter.Done()
+	return nil
+}
+
+// generateRayCluster returns a RayCluster with one small worker, which fits in the resources of a local cluster
+func (options *QuickstartOptions) generateRayCluster() (*unstructured.Unstructured, error) {
+	rayClusterObject := generation.RayClusterYamlObject{
+		ClusterName: options.clusterName,
+		Namespace:   options.namespace,
+		RayClusterSpecObject: generation.RayClusterSpecObject{
+			RayVersion:     generation.DefaultRayVersion,
+			HeadCPU:        "1",
+			HeadMemory:     "2Gi",
+			WorkerCPU:      "1",
+			WorkerMemory:   "1Gi",
+			WorkerGPU:      "0",
+			WorkerReplicas: 1,
+		},
+	}
+	if err := rayClusterObject.Validate(); err != nil {
+		return nil, err
+	}
+	return generation.ConvertRayClusterApplyConfigToUnstructured(rayClusterObject.GenerateRayClusterApplyConfig())
+}
+
+func (options *QuickstartOptions) printNextSteps(out io.Writer) {
+	namespaceFlag := ""
+	if options.namespace != "default" {
+		namespaceFlag = " -n " + options.namespace
+	}
+	if options.skipRayCluster {
+		fmt.Fprintf(out, "\nThe KubeRay operator is ready. Next steps:\n\n")
+		fmt.Fprintf(out, "  # Create a RayCluster\n  kubectl ray cluster create my-cluster%s\n", namespaceFlag)
+		return
+	}
+	fmt.Fprintf(out, "\nRayCluster %s is ready. Next steps:\n\n", options.clusterName)
+	fmt.Fprintf(out, "  # Forward the Ray dashboard and client ports to localhost\n  kubectl ray session %s%s\n\n", options.clusterName, namespaceFlag)
+	fmt.Fprintf(out, "  # Submit a Ray job to the RayCluster\n  kubectl ray job submit --ray-cluster %s%s -- python -c \"import ray; ray.init(); print(ray.cluster_resources())\"\n\n", options.clusterName, namespaceFlag)
+	fmt.Fprintf(out, "  # Delete the RayCluster\n  kubectl ray delete %s%s\n", options.clusterName, namespaceFlag)
+}
@@ -0,0 +1,139 @@
+package quickstart
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/progress"
+)
+
+func TestDetectLocalCluster(t *testing.T) {
+	tests := map[string]string{
+		"kind-kind":                    "kind",
+		"kind-ray":                     "kind",
+		"minikube":                     "minikube",
+		"k3d-k3s-default":              "k3d",
+		"gke_project_us-central1_prod": "",
+		"kind":                         "",
+	}
+	for context, expected := range tests {
+		assert.Equal(t, expected, detectLocalCluster(context), context)
+	}
+}
+
+func TestQuickstartValidate(t *testing.T) {
+	fakeDir := t.TempDir()
+	config := &api.Config{
+		Clusters: map[string]*api.Cluster{
+			"kind-kind": {Server: "https://127.0.0.1:6443"},
+			"prod":      {Server: "https://prod.example.com"},
+		},
+		Contexts: map[string]*api.Context{
+			"kind-kind": {Cluster: "kind-kind"},
+			"prod":      {Cluster: "prod"},
+		},
+		CurrentContext: "kind-kind",
+	}
+	fakeFile := filepath.Join(fakeDir, ".kubeconfig")
+	require.NoError(t, clientcmd.WriteToFile(*config, fakeFile))
+
+	tests := []struct {
+		name        string
+		context     string
+		expectError string
+		force       bool
+	}{
+		{
+			name:    "current kind context",
+			context: "",
+		},
+		{
+			name:        "remote context",
+			context:     "prod",
+			expectError: "context prod is not a kind, minikube or k3d cluster, use --force to run the quickstart in it anyway",
+		},
+		{
+			name:    "remote context with --force",
+			context: "prod",
+			force:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			options := NewQuickstartOptions(genericclioptions.NewTestIOStreamsDiscard())
+			options.configFlags.KubeConfig = &fakeFile
+			options.configFlags.Context = &tc.context
+			options.force = tc.force
+			require.NoError(t, options.Complete())
+			err := options.Validate()
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestInstallOperatorUsesExistingOperator(t *testing.T) {
+	kubeClient := kubeFake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kuberay-operator",
+			Namespace: "default",
+			Labels:    map[string]string{"app.kubernetes.io/name": "kuberay-operator"},
+		},
+	})
+	dynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme())
+	var out bytes.Buffer
+	options := NewQuickstartOptions(genericclioptions.NewTestIOStreamsDiscard())
+	options.reporter = progress.NewReporter(&out, progress.Plain)
+
+	require.NoError(t, options.installOperator(context.Background(), client.NewClientForTesting(kubeClient, dynamicClient), meta.NewDefaultRESTMapper(nil)))
+	assert.Contains(t, out.String(), "Using the KubeRay operator default/kuberay-operator that is already installed")
+	assert.Empty(t, dynamicClient.Actions())
+}
+
+func TestCreateRayClusterExisting(t *testing.T) {
+	rayCluster := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "ray.io/v1",
+		"kind":       "RayCluster",
+		"metadata": map[string]interface{}{
+			"name":      defaultClusterName,
+			"namespace": "default",
+		},
+		"status": map[string]interface{}{
+			"state": "ready",
+		},
+	}}
+	dynamicClient := dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{util.RayClusterGVR: "RayClusterList"}, rayCluster)
+	var out bytes.Buffer
+	options := NewQuickstartOptions(genericclioptions.NewTestIOStreamsDiscard())
+	options.reporter = progress.NewReporter(&out, progress.Plain)
+	options.namespace = "default"
+
+	require.NoError(t, options.createRayCluster(context.Background(), dynamicClient))
+	assert.Contains(t, out.String(), "RayCluster raycluster-quickstart already exists")
+
+	out.Reset()
+	options.printNextSteps(&out)
+	assert.Contains(t, out.String(), "kubectl ray session raycluster-quickstart\n")
+	assert.Contains(t, out.String(), "kubectl ray job submit --ray-cluster raycluster-quickstart -- python")
+}
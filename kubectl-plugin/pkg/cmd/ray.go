@@ -6,10 +6,24 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/cluster"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/cp"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/create"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/dashboard"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/debug"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/delete"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/doctor"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/exec"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/get"
 	"github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/job"
 	"github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/log"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/operator"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/quickstart"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/service"
 	"github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/session"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/top"
 	"github.com/ray-project/kuberay/kubectl-plugin/pkg/cmd/version"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/config"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
 )
 
 func NewRayCommand(streams genericiooptions.IOStreams) *cobra.Command {
@@ -21,15 +35,37 @@ func NewRayCommand(streams genericiooptions.IOStreams) *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			cmd.HelpFunc()(cmd, args)
 		},
+		// Fill in the flags that were not given from the environment and ~/.kuberay/config.yaml
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			if err := logging.ValidateFlags(); err != nil {
+				return err
+			}
+			return config.ApplyDefaults(cmd)
+		},
 		CompletionOptions: cobra.CompletionOptions{
 			DisableDefaultCmd: true,
 		},
 	}
 
+	logging.SetOutput(streams.ErrOut)
+	logging.AddFlags(cmd.PersistentFlags())
+
 	cmd.AddCommand(cluster.NewClusterCommand(streams))
 	cmd.AddCommand(session.NewSessionCommand(streams))
+	cmd.AddCommand(dashboard.NewDashboardCommand(streams))
+	cmd.AddCommand(exec.NewExecCommand(streams))
+	cmd.AddCommand(cp.NewCpCommand(streams))
+	cmd.AddCommand(get.NewGetCommand(streams))
+	cmd.AddCommand(create.NewCreateCommand(streams))
+	cmd.AddCommand(delete.NewDeleteCommand(streams))
+	cmd.AddCommand(top.NewTopCommand(streams))
+	cmd.AddCommand(doctor.NewDoctorCommand(streams))
+	cmd.AddCommand(debug.NewDebugCommand(streams))
 	cmd.AddCommand(log.NewClusterLogCommand(streams))
 	cmd.AddCommand(job.NewJobCommand(streams))
+	cmd.AddCommand(service.NewServiceCommand(streams))
+	cmd.AddCommand(operator.NewOperatorCommand(streams))
+	cmd.AddCommand(quickstart.NewQuickstartCommand(streams))
 	cmd.AddCommand(version.NewVersionCommand(streams))
 
 	return cmd
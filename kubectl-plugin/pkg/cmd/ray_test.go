@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+// TestNewRayCommand builds the whole command tree, which panics if a command defines a flag twice, e.g. a flag that
+// clashes with the kubeconfig flags
+func TestNewRayCommand(t *testing.T) {
+	cmd := NewRayCommand(genericiooptions.NewTestIOStreamsDiscard())
+	assert.NotEmpty(t, cmd.Commands())
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+func NewServiceCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "service",
+		Short:        "Manage RayServices",
+		Aliases:      []string{"svc"},
+		SilenceUsage: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	cmd.AddCommand(NewServiceGetCommand(streams))
+	cmd.AddCommand(NewServiceStatusCommand(streams))
+	cmd.AddCommand(NewServiceRolloutCommand(streams))
+	cmd.AddCommand(NewServiceEndpointsCommand(streams))
+	return cmd
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/printer"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+type ServiceEndpointsOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	ioStreams   *genericiooptions.IOStreams
+	outputFlags *printer.OutputFlags
+	namespace   string
+	name        string
+}
+
+// serveEndpoints is the machine-readable output of the Serve endpoints of a RayService
+type serveEndpoints struct {
+	Service     string   `json:"service"`
+	Type        string   `json:"type"`
+	ClusterIP   string   `json:"clusterIP"`
+	URL         string   `json:"url,omitempty"`
+	ExternalIPs []string `json:"externalIPs,omitempty"`
+	Ports       []string `json:"ports"`
+	Endpoints   []string `json:"endpoints"`
+}
+
+var (
+	serviceEndpointsLong = templates.LongDesc(`
+		Show the Kubernetes service that routes traffic to the Ray Serve proxies of a RayService, its in-cluster URL,
+		and the addresses of the Ray Pods that are currently serving traffic.
+	`)
+
+	serviceEndpointsExample = templates.Examples(`
+		# Show the Serve endpoints of the RayService rayservice-sample
+		kubectl ray service endpoints rayservice-sample
+
+		# Print the Serve endpoints of the RayService as JSON
+		kubectl ray service endpoints rayservice-sample -o json
+	`)
+)
+
+func NewServiceEndpointsOptions(streams genericiooptions.IOStreams) *ServiceEndpointsOptions {
+	return &ServiceEndpointsOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+		outputFlags: printer.NewOutputFlags(printer.JSON, printer.YAML),
+	}
+}
+
+func NewServiceEndpointsCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	options := NewServiceEndpointsOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:          "endpoints NAME",
+		Short:        "Show the Serve endpoints of a RayService",
+		Long:         serviceEndpointsLong,
+		Example:      serviceEndpointsExample,
+		Aliases:      []string{"ep"},
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	options.outputFlags.AddFlags(cmd)
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *ServiceEndpointsOptions) Complete(args []string) error {
+	if *options.configFlags.Namespace == "" {
+		options.namespace = "default"
+	} else {
+		options.namespace = *options.configFlags.Namespace
+	}
+	options.name = args[0]
+	return nil
+}
+
+func (options *ServiceEndpointsOptions) Validate() error {
+	if err := validateContext(options.configFlags); err != nil {
+		return err
+	}
+	return options.outputFlags.Validate()
+}
+
+func (options *ServiceEndpointsOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	k8sClients, err := client.NewClient(factory)
+	if err != nil {
+		return fmt.Errorf("failed to initialize clientset: %w", err)
+	}
+	endpoints, err := getServeEndpoints(ctx, k8sClients, options.namespace, options.name)
+	if err != nil {
+		return err
+	}
+
+	if options.outputFlags.IsStructured() {
+		return options.outputFlags.PrintData(endpoints, options.ioStreams.Out)
+	}
+	return printServeEndpoints(endpoints, options.ioStreams.Out)
+}
+
+// getServeEndpoints returns the serve service of the RayService and the addresses of the Ray Pods behind it
+func getServeEndpoints(ctx context.Context, k8sClients client.Client, namespace, name string) (*serveEndpoints, error) {
+	rayService, err := getRayService(ctx, k8sClients.DynamicClient(), namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	svcName := serveServiceName(rayService)
+	svc, err := k8sClients.KubernetesClient().CoreV1().Services(namespace).Get(ctx, svcName, v1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("serve service %s of RayService %s does not exist yet, it is created once the Serve applications are running", svcName, name)
+		}
+		return nil, fmt.Errorf("unable to find serve service %s of RayService %s: %w", svcName, name, err)
+	}
+
+	result := &serveEndpoints{
+		Service:   svc.Name,
+		Type:      string(svc.Spec.Type),
+		ClusterIP: svc.Spec.ClusterIP,
+		Ports:     []string{},
+		Endpoints: []string{},
+	}
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			result.ExternalIPs = append(result.ExternalIPs, ingress.IP)
+		} else if ingress.Hostname != "" {
+			result.ExternalIPs = append(result.ExternalIPs, ingress.Hostname)
+		}
+	}
+	result.ExternalIPs = append(result.ExternalIPs, svc.Spec.ExternalIPs...)
+	for _, port := range svc.Spec.Ports {
+		result.Ports = append(result.Ports, fmt.Sprintf("%d/%s", port.Port, port.Protocol))
+		if port.Name == utils.ServingPortName {
+			result.URL = fmt.Sprintf("http://%s.%s.svc:%d", svc.Name, namespace, port.Port)
+		}
+	}
+
+	// The KubeRay operator only labels the Ray Pods with a healthy Serve proxy to receive traffic
+	svcEndpoints, err := k8sClients.KubernetesClient().CoreV1().Endpoints(namespace).Get(ctx, svcName, v1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, fmt.Errorf("unable to find endpoints of serve service %s: %w", svcName, err)
+	}
+	if err == nil {
+		result.Endpoints = endpointAddresses(svcEndpoints)
+	}
+	return result, nil
+}
+
+// endpointAddresses returns the ready addresses of the endpoints as IP:PORT
+func endpointAddresses(endpoints *corev1.Endpoints) []string {
+	addresses := []string{}
+	for _, subset := range endpoints.Subsets {
+		for _, address := range subset.Addresses {
+			for _, port := range subset.Ports {
+				addresses = append(addresses, net.JoinHostPort(address.IP, strconv.Itoa(int(port.Port))))
+			}
+		}
+	}
+	return addresses
+}
+
+func printServeEndpoints(endpoints *serveEndpoints, output io.Writer) error {
+	resTable := &v1.Table{
+		ColumnDefinitions: []v1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Type", Type: "string"},
+			{Name: "Cluster-IP", Type: "string"},
+			{Name: "External-IP", Type: "string"},
+			{Name: "Port(s)", Type: "string"},
+			{Name: "URL", Type: "string"},
+			{Name: "Endpoints", Type: "string"},
+		},
+		Rows: []v1.TableRow{{
+			Cells: []interface{}{
+				endpoints.Service,
+				endpoints.Type,
+				valueOrNone(endpoints.ClusterIP),
+				valueOrNone(strings.Join(endpoints.ExternalIPs, ",")),
+				valueOrNone(strings.Join(endpoints.Ports, ",")),
+				valueOrNone(endpoints.URL),
+				valueOrNone(strings.Join(endpoints.Endpoints, ",")),
+			},
+		}},
+	}
+	return printers.NewTablePrinter(printers.PrintOptions{}).PrintObj(resTable, output)
+}
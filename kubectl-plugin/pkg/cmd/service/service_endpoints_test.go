@@ -0,0 +1,61 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+)
+
+func TestGetServeEndpoints(t *testing.T) {
+	serveService := &corev1.Service{
+		ObjectMeta: v1.ObjectMeta{Name: "rayservice-sample-serve-svc", Namespace: "test"},
+		Spec: corev1.ServiceSpec{
+			Type:      corev1.ServiceTypeLoadBalancer,
+			ClusterIP: "10.96.0.10",
+			Ports:     []corev1.ServicePort{{Name: "serve", Port: 8000, Protocol: corev1.ProtocolTCP}},
+		},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: "203.0.113.10"}}},
+		},
+	}
+	svcEndpoints := &corev1.Endpoints{
+		ObjectMeta: v1.ObjectMeta{Name: "rayservice-sample-serve-svc", Namespace: "test"},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: "10.244.0.5"}, {IP: "10.244.0.6"}},
+			Ports:     []corev1.EndpointPort{{Name: "serve", Port: 8000}},
+		}},
+	}
+	dynamicClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), newTestRayService())
+
+	// The serve service is only created once the Serve applications are running
+	k8sClients := client.NewClientForTesting(kubeFake.NewSimpleClientset(), dynamicClient)
+	_, err := getServeEndpoints(context.Background(), k8sClients, "test", "rayservice-sample")
+	assert.EqualError(t, err, "serve service rayservice-sample-serve-svc of RayService rayservice-sample does not exist yet, it is created once the Serve applications are running")
+
+	k8sClients = client.NewClientForTesting(kubeFake.NewSimpleClientset(serveService, svcEndpoints), dynamicClient)
+	endpoints, err := getServeEndpoints(context.Background(), k8sClients, "test", "rayservice-sample")
+	assert.Nil(t, err)
+	assert.Equal(t, &serveEndpoints{
+		Service:     "rayservice-sample-serve-svc",
+		Type:        "LoadBalancer",
+		ClusterIP:   "10.96.0.10",
+		URL:         "http://rayservice-sample-serve-svc.test.svc:8000",
+		ExternalIPs: []string{"203.0.113.10"},
+		Ports:       []string{"8000/TCP"},
+		Endpoints:   []string{"10.244.0.5:8000", "10.244.0.6:8000"},
+	}, endpoints)
+
+	var resBuf bytes.Buffer
+	assert.Nil(t, printServeEndpoints(endpoints, &resBuf))
+	assert.Equal(t, "NAME                          TYPE           CLUSTER-IP   EXTERNAL-IP    PORT(S)    URL                                                ENDPOINTS\n"+
+		"rayservice-sample-serve-svc   LoadBalancer   10.96.0.10   203.0.113.10   8000/TCP   http://rayservice-sample-serve-svc.test.svc:8000   10.244.0.5:8000,10.244.0.6:8000\n", resBuf.String())
+}
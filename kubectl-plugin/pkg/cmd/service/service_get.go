@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/printer"
+)
+
+type ServiceGetOptions struct {
+	configFlags   *genericclioptions.ConfigFlags
+	ioStreams     *genericiooptions.IOStreams
+	outputFlags   *printer.OutputFlags
+	labelSelector string
+	args          []string
+	AllNamespaces bool
+}
+
+var (
+	serviceGetLong = templates.LongDesc(`
+		List RayServices with their service status, the names of the active and pending RayClusters, and the number of
+		Serve endpoints. A pending RayCluster is only shown while a zero-downtime upgrade is in progress.
+	`)
+
+	serviceGetExample = templates.Examples(`
+		# List RayServices in the current namespace
+		kubectl ray service get
+
+		# Get the RayService rayservice-sample
+		kubectl ray service get rayservice-sample
+
+		# List RayServices across all namespaces
+		kubectl ray service get --all-namespaces
+
+		# Print the RayServices with the label team=ml as YAML
+		kubectl ray service get -l team=ml -o yaml
+	`)
+)
+
+func NewServiceGetOptions(streams genericiooptions.IOStreams) *ServiceGetOptions {
+	return &ServiceGetOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+		outputFlags: printer.NewOutputFlags(printer.JSON, printer.YAML, printer.Name),
+	}
+}
+
+func NewServiceGetCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	options := NewServiceGetOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:          "get [NAME]",
+		Short:        "Get RayServices",
+		Long:         serviceGetLong,
+		Example:      serviceGetExample,
+		Aliases:      []string{"list"},
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	cmd.Flags().BoolVarP(&options.AllNamespaces, "all-namespaces", "A", options.AllNamespaces, "If present, list the RayServices across all namespaces. Namespace in current context is ignored even if specified with --namespace.")
+	cmd.Flags().StringVarP(&options.labelSelector, "selector", "l", options.labelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+	options.outputFlags.AddFlags(cmd)
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *ServiceGetOptions) Complete(args []string) error {
+	if *options.configFlags.Namespace == "" {
+		options.AllNamespaces = true
+	}
+
+	options.args = args
+	return nil
+}
+
+func (options *ServiceGetOptions) Validate() error {
+	if err := validateContext(options.configFlags); err != nil {
+		return err
+	}
+	if len(options.args) > 1 {
+		return fmt.Errorf("too many arguments, either one or no arguments are allowed")
+	}
+	return options.outputFlags.Validate()
+}
+
+func (options *ServiceGetOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	dynamicClient, err := client.NewDynamicClient(factory)
+	if err != nil {
+		return fmt.Errorf("dynamic client failed to initialize: %w", err)
+	}
+
+	listopts := v1.ListOptions{LabelSelector: options.labelSelector}
+	if len(options.args) == 1 {
+		listopts.FieldSelector = fmt.Sprintf("metadata.name=%s", options.args[0])
+	}
+
+	var rayServiceList *unstructured.UnstructuredList
+	if options.AllNamespaces {
+		rayServiceList, err = dynamicClient.Resource(util.RayServiceGVR).List(ctx, listopts)
+		if err != nil {
+			return fmt.Errorf("unable to retrieve RayServices for all namespaces: %w", err)
+		}
+	} else {
+		rayServiceList, err = dynamicClient.Resource(util.RayServiceGVR).Namespace(*options.configFlags.Namespace).List(ctx, listopts)
+		if err != nil {
+			return fmt.Errorf("unable to retrieve RayServices for namespace %s: %w", *options.configFlags.Namespace, err)
+		}
+	}
+
+	if options.outputFlags.IsStructured() {
+		return options.outputFlags.PrintObj(rayServiceList, options.ioStreams.Out)
+	}
+	return printServices(rayServiceList, options.ioStreams.Out)
+}
+
+func printServices(rayServiceList *unstructured.UnstructuredList, output io.Writer) error {
+	resultTablePrinter := printers.NewTablePrinter(printers.PrintOptions{})
+
+	resTable := &v1.Table{
+		ColumnDefinitions: []v1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Namespace", Type: "string"},
+			{Name: "Service Status", Type: "string"},
+			{Name: "Active Cluster", Type: "string"},
+			{Name: "Pending Cluster", Type: "string"},
+			{Name: "Applications", Type: "string"},
+			{Name: "Serve Endpoints", Type: "integer"},
+			{Name: "Age", Type: "string"},
+		},
+	}
+
+	for i := range rayServiceList.Items {
+		rayService, err := toRayService(&rayServiceList.Items[i])
+		if err != nil {
+			return err
+		}
+		age := duration.HumanDuration(time.Since(rayService.CreationTimestamp.Time))
+		if rayService.CreationTimestamp.Time.IsZero() {
+			age = "<unknown>"
+		}
+		activeStatus := rayService.Status.ActiveServiceStatus
+		resTable.Rows = append(resTable.Rows, v1.TableRow{
+			Cells: []interface{}{
+				rayService.Name,
+				rayService.Namespace,
+				valueOrNone(string(rayService.Status.ServiceStatus)),
+				valueOrNone(activeStatus.RayClusterName),
+				valueOrNone(rayService.Status.PendingServiceStatus.RayClusterName),
+				fmt.Sprintf("%d/%d", countRunningApplications(activeStatus), len(activeStatus.Applications)),
+				rayService.Status.NumServeEndpoints,
+				age,
+			},
+		})
+	}
+
+	return resultTablePrinter.PrintObj(resTable, output)
+}
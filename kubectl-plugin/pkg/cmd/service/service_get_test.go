@@ -0,0 +1,137 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/printer"
+)
+
+// newTestRayService returns a RayService named rayservice-sample that is being upgraded from the active to the
+// pending RayCluster
+func newTestRayService() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "ray.io/v1",
+			"kind":       "RayService",
+			"metadata": map[string]interface{}{
+				"name":       "rayservice-sample",
+				"namespace":  "test",
+				"generation": int64(2),
+			},
+			"status": map[string]interface{}{
+				"serviceStatus":      "Running",
+				"numServeEndpoints":  int64(2),
+				"observedGeneration": int64(2),
+				"activeServiceStatus": map[string]interface{}{
+					"rayClusterName": "rayservice-sample-raycluster-active",
+					"applicationStatuses": map[string]interface{}{
+						"fruit_app": map[string]interface{}{
+							"status": "RUNNING",
+							"serveDeploymentStatuses": map[string]interface{}{
+								"FruitMarket": map[string]interface{}{"status": "HEALTHY"},
+							},
+						},
+						"math_app": map[string]interface{}{"status": "RUNNING"},
+					},
+				},
+				"pendingServiceStatus": map[string]interface{}{
+					"rayClusterName": "rayservice-sample-raycluster-pending",
+					"applicationStatuses": map[string]interface{}{
+						"fruit_app": map[string]interface{}{
+							"status":  "DEPLOYING",
+							"message": "Deploying app 'fruit_app'",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestServiceGetValidate(t *testing.T) {
+	testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+
+	tests := []struct {
+		opts        *ServiceGetOptions
+		name        string
+		expectError string
+	}{
+		{
+			name: "single name",
+			opts: &ServiceGetOptions{args: []string{"rayservice-sample"}, outputFlags: printer.NewOutputFlags(printer.JSON)},
+		},
+		{
+			name:        "too many names",
+			opts:        &ServiceGetOptions{args: []string{"rayservice-a", "rayservice-b"}, outputFlags: printer.NewOutputFlags(printer.JSON)},
+			expectError: "too many arguments, either one or no arguments are allowed",
+		},
+		{
+			name:        "unsupported output format",
+			opts:        &ServiceGetOptions{outputFlags: &printer.OutputFlags{Format: "wide"}},
+			expectError: "unsupported output format \"wide\", must be one of: ",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.opts.configFlags = newFakeConfigFlags(t)
+			tc.opts.ioStreams = &testStreams
+			err := tc.opts.Validate()
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestServiceGetRun(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+	tf.FakeDynamicClient = fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), newTestRayService())
+
+	testStreams, _, resBuf, _ := genericiooptions.NewTestIOStreams()
+	options := NewServiceGetOptions(testStreams)
+	*options.configFlags.Namespace = "test"
+	assert.Nil(t, options.Complete(nil))
+	assert.Nil(t, options.Run(context.Background(), tf))
+
+	expected := bytes.NewBufferString("")
+	expected.WriteString("NAME                NAMESPACE   SERVICE STATUS   ACTIVE CLUSTER                        PENDING CLUSTER                        APPLICATIONS   SERVE ENDPOINTS   AGE\n")
+	expected.WriteString("rayservice-sample   test        Running          rayservice-sample-raycluster-active   rayservice-sample-raycluster-pending   2/2            2                 <unknown>\n")
+	assert.Equal(t, expected.String(), resBuf.String())
+}
+
+// newFakeConfigFlags returns config flags using a kubeconfig with a current context
+func newFakeConfigFlags(t *testing.T) *genericclioptions.ConfigFlags {
+	config := &api.Config{
+		Clusters: map[string]*api.Cluster{
+			"my-fake-cluster": {Server: "https://fake-kubernetes-cluster.example.com"},
+		},
+		Contexts: map[string]*api.Context{
+			"my-fake-context": {Cluster: "my-fake-cluster", AuthInfo: "my-fake-user"},
+		},
+		CurrentContext: "my-fake-context",
+		AuthInfos:      map[string]*api.AuthInfo{"my-fake-user": {}},
+	}
+	fakeFile := filepath.Join(t.TempDir(), ".kubeconfig")
+	assert.Nil(t, clientcmd.WriteToFile(*config, fakeFile))
+
+	configFlags := genericclioptions.NewConfigFlags(false)
+	configFlags.KubeConfig = &fakeFile
+	return configFlags
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+type ServiceRolloutStatusOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	ioStreams   *genericiooptions.IOStreams
+	namespace   string
+	name        string
+	timeout     time.Duration
+	watch       bool
+}
+
+var (
+	serviceRolloutStatusLong = templates.LongDesc(`
+		Show the status of the rollout of a RayService.
+
+		A zero-downtime upgrade of a RayService creates a pending RayCluster, deploys the Serve applications on it, and
+		switches the traffic to it once all applications are running. By default, the command watches the RayService
+		until the rollout completes and exits with a non-zero code if a Serve application fails to deploy. Use
+		'--watch=false' to only print the current status.
+	`)
+
+	serviceRolloutStatusExample = templates.Examples(`
+		# Wait until the rollout of the RayService rayservice-sample completes
+		kubectl ray service rollout status rayservice-sample
+
+		# Wait at most 10 minutes for the rollout to complete, e.g. in CI pipelines
+		kubectl ray service rollout status rayservice-sample --timeout 10m
+
+		# Print the current status of the rollout without waiting
+		kubectl ray service rollout status rayservice-sample --watch=false
+	`)
+)
+
+func NewServiceRolloutCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "rollout",
+		Short:        "Manage the rollout of RayServices",
+		SilenceUsage: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	cmd.AddCommand(NewServiceRolloutStatusCommand(streams))
+	return cmd
+}
+
+func NewServiceRolloutStatusOptions(streams genericiooptions.IOStreams) *ServiceRolloutStatusOptions {
+	return &ServiceRolloutStatusOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+		watch:       true,
+	}
+}
+
+func NewServiceRolloutStatusCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	options := NewServiceRolloutStatusOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:          "status NAME",
+		Short:        "Show the status of the rollout of a RayService",
+		Long:         serviceRolloutStatusLong,
+		Example:      serviceRolloutStatusExample,
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	cmd.Flags().BoolVarP(&options.watch, "watch", "w", options.watch, "Watch the status of the rollout until it completes")
+	cmd.Flags().DurationVar(&options.timeout, "timeout", options.timeout, "The length of time to wait for the rollout to complete, zero means never")
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *ServiceRolloutStatusOptions) Complete(args []string) error {
+	if *options.configFlags.Namespace == "" {
+		options.namespace = "default"
+	} else {
+		options.namespace = *options.configFlags.Namespace
+	}
+	options.name = args[0]
+	return nil
+}
+
+func (options *ServiceRolloutStatusOptions) Validate() error {
+	if err := validateContext(options.configFlags); err != nil {
+		return err
+	}
+	if options.timeout < 0 {
+		return fmt.Errorf("--timeout must not be negative, got %s", options.timeout)
+	}
+	return nil
+}
+
+func (options *ServiceRolloutStatusOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	dynamicClient, err := client.NewDynamicClient(factory)
+	if err != nil {
+		return fmt.Errorf("dynamic client failed to initialize: %w", err)
+	}
+
+	if !options.watch {
+		rayService, err := getRayService(ctx, dynamicClient, options.namespace, options.name)
+		if err != nil {
+			return err
+		}
+		message, _, err := rolloutStatus(rayService)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(options.ioStreams.Out, message)
+		return nil
+	}
+
+	waitCtx := ctx
+	if options.timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, options.timeout)
+		defer cancel()
+	}
+	// Like `kubectl rollout status`, a message is only printed when the status of the rollout changes
+	var lastMessage string
+	_, err = client.WaitForResource(waitCtx, dynamicClient, util.RayServiceGVR, options.namespace, options.name, func(obj *unstructured.Unstructured) (bool, error) {
+		rayService, err := toRayService(obj)
+		if err != nil {
+			return false, err
+		}
+		message, done, err := rolloutStatus(rayService)
+		if err != nil {
+			return false, err
+		}
+		if message != lastMessage {
+			fmt.Fprintln(options.ioStreams.Out, message)
+			lastMessage = message
+		}
+		return done, nil
+	})
+	if err != nil && errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out waiting for the rollout of RayService %s to complete after %s", options.name, options.timeout)
+	}
+	return err
+}
+
+// rolloutStatus returns a message describing the rollout of the RayService and whether the rollout completed. It returns
+// an error if a Serve application failed to deploy on the RayCluster that is being rolled out.
+func rolloutStatus(rayService *rayv1.RayService) (string, bool, error) {
+	if rayService.Status.ObservedGeneration < rayService.Generation {
+		return fmt.Sprintf("Waiting for the KubeRay operator to observe the latest spec of RayService %s...", rayService.Name), false, nil
+	}
+
+	// A pending RayCluster means that a zero-downtime upgrade is in progress
+	target := rayService.Status.ActiveServiceStatus
+	if pending := rayService.Status.PendingServiceStatus; pending.RayClusterName != "" {
+		target = pending
+	} else if target.RayClusterName == "" {
+		return fmt.Sprintf("Waiting for the RayCluster of RayService %s to be created...", rayService.Name), false, nil
+	}
+
+	for _, appName := range sortedKeys(target.Applications) {
+		app := target.Applications[appName]
+		if app.Status == rayv1.ApplicationStatusEnum.DEPLOY_FAILED {
+			return "", false, fmt.Errorf("rollout of RayService %s failed: Serve application %s failed to deploy on RayCluster %s: %s", rayService.Name, appName, target.RayClusterName, app.Message)
+		}
+	}
+
+	running := countRunningApplications(target)
+	if len(target.Applications) == 0 || running < len(target.Applications) {
+		return fmt.Sprintf("Waiting for the Serve applications on RayCluster %s to be running: %d of %d running...", target.RayClusterName, running, len(target.Applications)), false, nil
+	}
+	if target.RayClusterName != rayService.Status.ActiveServiceStatus.RayClusterName || rayService.Status.ServiceStatus != rayv1.Running {
+		return fmt.Sprintf("Waiting for RayService %s to switch the traffic to RayCluster %s...", rayService.Name, target.RayClusterName), false, nil
+	}
+	return fmt.Sprintf("RayService %s successfully rolled out to RayCluster %s", rayService.Name, target.RayClusterName), true, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestRolloutStatus(t *testing.T) {
+	tests := []struct {
+		update          func(rayService *rayv1.RayService)
+		name            string
+		expectedMessage string
+		expectedError   string
+		expectedDone    bool
+	}{
+		{
+			name:            "spec not observed",
+			update:          func(rayService *rayv1.RayService) { rayService.Generation = 3 },
+			expectedMessage: "Waiting for the KubeRay operator to observe the latest spec of RayService rayservice-sample...",
+		},
+		{
+			name:            "upgrade in progress",
+			update:          func(_ *rayv1.RayService) {},
+			expectedMessage: "Waiting for the Serve applications on RayCluster rayservice-sample-raycluster-pending to be running: 0 of 1 running...",
+		},
+		{
+			name: "upgrade failed",
+			update: func(rayService *rayv1.RayService) {
+				rayService.Status.PendingServiceStatus.Applications["fruit_app"] = rayv1.AppStatus{Status: rayv1.ApplicationStatusEnum.DEPLOY_FAILED, Message: "ImportError"}
+			},
+			expectedError: "rollout of RayService rayservice-sample failed: Serve application fruit_app failed to deploy on RayCluster rayservice-sample-raycluster-pending: ImportError",
+		},
+		{
+			name: "applications of the pending RayCluster are running",
+			update: func(rayService *rayv1.RayService) {
+				rayService.Status.PendingServiceStatus.Applications["fruit_app"] = rayv1.AppStatus{Status: rayv1.ApplicationStatusEnum.RUNNING}
+			},
+			expectedMessage: "Waiting for RayService rayservice-sample to switch the traffic to RayCluster rayservice-sample-raycluster-pending...",
+		},
+		{
+			name:            "upgrade completed",
+			update:          func(rayService *rayv1.RayService) { rayService.Status.PendingServiceStatus = rayv1.RayServiceStatus{} },
+			expectedMessage: "RayService rayservice-sample successfully rolled out to RayCluster rayservice-sample-raycluster-active",
+			expectedDone:    true,
+		},
+		{
+			name: "RayCluster not created yet",
+			update: func(rayService *rayv1.RayService) {
+				rayService.Status = rayv1.RayServiceStatuses{ObservedGeneration: 2}
+			},
+			expectedMessage: "Waiting for the RayCluster of RayService rayservice-sample to be created...",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rayService, err := toRayService(newTestRayService())
+			assert.Nil(t, err)
+			tc.update(rayService)
+
+			message, done, err := rolloutStatus(rayService)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedMessage, message)
+			assert.Equal(t, tc.expectedDone, done)
+		})
+	}
+}
+
+func TestServiceRolloutStatusRun(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+	tf.FakeDynamicClient = fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), newTestRayService())
+
+	testStreams, _, resBuf, _ := genericiooptions.NewTestIOStreams()
+	options := NewServiceRolloutStatusOptions(testStreams)
+	*options.configFlags.Namespace = "test"
+	assert.Nil(t, options.Complete([]string{"rayservice-sample"}))
+
+	options.watch = false
+	assert.Nil(t, options.Run(context.Background(), tf))
+	assert.Equal(t, "Waiting for the Serve applications on RayCluster rayservice-sample-raycluster-pending to be running: 0 of 1 running...\n", resBuf.String())
+
+	// The upgrade does not complete before the timeout
+	resBuf.Reset()
+	options.watch = true
+	options.timeout = 100 * time.Millisecond
+	err := options.Run(context.Background(), tf)
+	assert.EqualError(t, err, "timed out waiting for the rollout of RayService rayservice-sample to complete after 100ms")
+	assert.Equal(t, "Waiting for the Serve applications on RayCluster rayservice-sample-raycluster-pending to be running: 0 of 1 running...\n", resBuf.String())
+}
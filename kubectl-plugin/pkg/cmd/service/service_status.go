@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/printer"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+type ServiceStatusOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	ioStreams   *genericiooptions.IOStreams
+	outputFlags *printer.OutputFlags
+	namespace   string
+	name        string
+}
+
+var (
+	serviceStatusLong = templates.LongDesc(`
+		Show the status of a RayService: its service status, the active and pending RayClusters, and the status of every
+		Serve application and deployment on them, as reported by the KubeRay operator.
+	`)
+
+	serviceStatusExample = templates.Examples(`
+		# Show the status of the RayService rayservice-sample
+		kubectl ray service status rayservice-sample
+
+		# Print the status of the RayService as JSON
+		kubectl ray service status rayservice-sample -o json
+	`)
+)
+
+func NewServiceStatusOptions(streams genericiooptions.IOStreams) *ServiceStatusOptions {
+	return &ServiceStatusOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+		outputFlags: printer.NewOutputFlags(printer.JSON, printer.YAML),
+	}
+}
+
+func NewServiceStatusCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	options := NewServiceStatusOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:          "status NAME",
+		Short:        "Show the application and deployment statuses of a RayService",
+		Long:         serviceStatusLong,
+		Example:      serviceStatusExample,
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	options.outputFlags.AddFlags(cmd)
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *ServiceStatusOptions) Complete(args []string) error {
+	if *options.configFlags.Namespace == "" {
+		options.namespace = "default"
+	} else {
+		options.namespace = *options.configFlags.Namespace
+	}
+	options.name = args[0]
+	return nil
+}
+
+func (options *ServiceStatusOptions) Validate() error {
+	if err := validateContext(options.configFlags); err != nil {
+		return err
+	}
+	return options.outputFlags.Validate()
+}
+
+func (options *ServiceStatusOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	dynamicClient, err := client.NewDynamicClient(factory)
+	if err != nil {
+		return fmt.Errorf("dynamic client failed to initialize: %w", err)
+	}
+	rayService, err := getRayService(ctx, dynamicClient, options.namespace, options.name)
+	if err != nil {
+		return err
+	}
+
+	if options.outputFlags.IsStructured() {
+		return options.outputFlags.PrintData(rayService.Status, options.ioStreams.Out)
+	}
+	return printServiceStatus(rayService, options.ioStreams.Out)
+}
+
+// printServiceStatus prints the status of the RayService followed by a table of the Serve applications and
+// deployments on its active and pending RayClusters
+func printServiceStatus(rayService *rayv1.RayService, output io.Writer) error {
+	status := rayService.Status
+	writer := printers.GetNewTabWriter(output)
+	fmt.Fprintf(writer, "Name:\t%s\n", rayService.Name)
+	fmt.Fprintf(writer, "Namespace:\t%s\n", rayService.Namespace)
+	fmt.Fprintf(writer, "Service Status:\t%s\n", valueOrNone(string(status.ServiceStatus)))
+	fmt.Fprintf(writer, "Serve Endpoints:\t%d\n", status.NumServeEndpoints)
+	fmt.Fprintf(writer, "Active Cluster:\t%s\n", valueOrNone(status.ActiveServiceStatus.RayClusterName))
+	fmt.Fprintf(writer, "Pending Cluster:\t%s\n", valueOrNone(status.PendingServiceStatus.RayClusterName))
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	resTable := &v1.Table{
+		ColumnDefinitions: []v1.TableColumnDefinition{
+			{Name: "Cluster", Type: "string"},
+			{Name: "Application", Type: "string"},
+			{Name: "Deployment", Type: "string"},
+			{Name: "Status", Type: "string"},
+			{Name: "Message", Type: "string"},
+		},
+	}
+	resTable.Rows = append(resTable.Rows, applicationRows("active", status.ActiveServiceStatus)...)
+	resTable.Rows = append(resTable.Rows, applicationRows("pending", status.PendingServiceStatus)...)
+	if len(resTable.Rows) == 0 {
+		fmt.Fprintln(output, "\nNo Serve applications are reported yet.")
+		return nil
+	}
+	fmt.Fprintln(output)
+	return printers.NewTablePrinter(printers.PrintOptions{}).PrintObj(resTable, output)
+}
+
+// applicationRows returns a row for every Serve application of the RayCluster, each followed by rows for its deployments
+func applicationRows(cluster string, status rayv1.RayServiceStatus) []v1.TableRow {
+	var rows []v1.TableRow
+	for _, appName := range sortedKeys(status.Applications) {
+		app := status.Applications[appName]
+		rows = append(rows, v1.TableRow{Cells: []interface{}{cluster, appName, "-", app.Status, app.Message}})
+		for _, deploymentName := range sortedKeys(app.Deployments) {
+			deployment := app.Deployments[deploymentName]
+			rows = append(rows, v1.TableRow{Cells: []interface{}{cluster, appName, deploymentName, deployment.Status, deployment.Message}})
+		}
+	}
+	return rows
+}
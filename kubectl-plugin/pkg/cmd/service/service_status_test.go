@@ -0,0 +1,37 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintServiceStatus(t *testing.T) {
+	rayService, err := toRayService(newTestRayService())
+	assert.Nil(t, err)
+
+	var resBuf bytes.Buffer
+	assert.Nil(t, printServiceStatus(rayService, &resBuf))
+	expected := `Name:              rayservice-sample
+Namespace:         test
+Service Status:    Running
+Serve Endpoints:   2
+Active Cluster:    rayservice-sample-raycluster-active
+Pending Cluster:   rayservice-sample-raycluster-pending
+
+CLUSTER   APPLICATION   DEPLOYMENT    STATUS      MESSAGE
+active    fruit_app     -             RUNNING     
+active    fruit_app     FruitMarket   HEALTHY     
+active    math_app      -             RUNNING     
+pending   fruit_app     -             DEPLOYING   Deploying app 'fruit_app'
+`
+	assert.Equal(t, expected, resBuf.String())
+
+	// Without applications, no table is printed
+	rayService.Status.ActiveServiceStatus.Applications = nil
+	rayService.Status.PendingServiceStatus.Applications = nil
+	resBuf.Reset()
+	assert.Nil(t, printServiceStatus(rayService, &resBuf))
+	assert.Contains(t, resBuf.String(), "\nNo Serve applications are reported yet.\n")
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// validateContext returns an error if the kubeconfig has no current context
+func validateContext(configFlags *genericclioptions.ConfigFlags) error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	return nil
+}
+
+// getRayService returns the RayService with the given name
+func getRayService(ctx context.Context, dynamicClient dynamic.Interface, namespace, name string) (*rayv1.RayService, error) {
+	unstructuredRayService, err := dynamicClient.Resource(util.RayServiceGVR).Namespace(namespace).Get(ctx, name, v1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to find RayService %s: %w", name, err)
+	}
+	return toRayService(unstructuredRayService)
+}
+
+// toRayService converts the unstructured RayService so that its status can be read with the types of the KubeRay operator
+func toRayService(unstructuredRayService *unstructured.Unstructured) (*rayv1.RayService, error) {
+	var rayService rayv1.RayService
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredRayService.Object, &rayService); err != nil {
+		return nil, fmt.Errorf("failed to convert RayService %s: %w", unstructuredRayService.GetName(), err)
+	}
+	return &rayService, nil
+}
+
+// serveServiceName returns the name of the Kubernetes service that routes traffic to the Ray Serve proxies of the
+// RayService. The KubeRay operator uses the name of a custom serve service if one is given.
+func serveServiceName(rayService *rayv1.RayService) string {
+	if rayService.Spec.ServeService != nil && rayService.Spec.ServeService.Name != "" {
+		return rayService.Spec.ServeService.Name
+	}
+	return utils.GenerateServeServiceName(rayService.Name)
+}
+
+// sortedKeys returns the keys of the map in alphabetical order, so that applications and deployments are printed
+// in a stable order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// countRunningApplications returns the number of Serve applications in the status that are RUNNING
+func countRunningApplications(status rayv1.RayServiceStatus) int {
+	running := 0
+	for _, app := range status.Applications {
+		if app.Status == rayv1.ApplicationStatusEnum.RUNNING {
+			running++
+		}
+	}
+	return running
+}
+
+// valueOrNone returns value, or "<none>" like kubectl if it is empty
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
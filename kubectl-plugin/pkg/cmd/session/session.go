@@ -3,30 +3,36 @@ package session
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
 	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
 	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/completion"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/portforward"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/selector"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
-	"k8s.io/kubectl/pkg/cmd/portforward"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/templates"
 )
 
 type appPort struct {
-	name string
-	port int
+	name      string
+	port      int
+	localPort int
 }
 
 type SessionOptions struct {
-	configFlags  *genericclioptions.ConfigFlags
-	ioStreams    *genericiooptions.IOStreams
-	ResourceType util.ResourceType
-	ResourceName string
-	Namespace    string
+	configFlags    *genericclioptions.ConfigFlags
+	ioStreams      *genericiooptions.IOStreams
+	localPorts     map[string]int
+	ResourceType   util.ResourceType
+	ResourceName   string
+	Namespace      string
+	portSets       []string
+	appPorts       []appPort
+	nonInteractive bool
 }
 
 var (
@@ -42,6 +48,13 @@ var (
 		name: "Ray Serve",
 		port: 8000,
 	}
+
+	// namedPortSets maps the names accepted by --ports and --local-ports to the ports of the Ray head service
+	namedPortSets = map[string]appPort{
+		"dashboard": dashboardPort,
+		"client":    clientPort,
+		"serve":     servePort,
+	}
 )
 
 var (
@@ -49,6 +62,10 @@ var (
 		Forward local ports to the Ray resources.
 
 		Forward different local ports depending on the resource type: RayCluster, RayJob, or RayService.
+		Without a resource, the only RayCluster of the namespace is used. When there are several, one can be selected
+		interactively on a terminal, unless '--non-interactive' is set.
+		Use '--ports' to choose the forwarded ports from 'dashboard', 'client' and 'serve', and '--local-ports' to map them to different local ports.
+		Dropped connections, e.g. when the head Pod restarts, are re-established automatically.
 	`)
 
 	sessionExample = templates.Examples(`
@@ -58,11 +75,20 @@ var (
 		# Forward local ports to the RayCluster resource
 		kubectl ray session raycluster/my-raycluster
 
+		# Forward local ports to the only RayCluster of the namespace, or select one when there are several
+		kubectl ray session
+
 		# Forward local ports to the RayCluster used for the RayJob resource
 		kubectl ray session rayjob/my-rayjob
 
 		# Forward local ports to the RayCluster used for the RayService resource
 		kubectl ray session rayservice/my-rayservice
+
+		# Forward the dashboard, Ray Client and Serve ports of the RayCluster in one session
+		kubectl ray session my-raycluster --ports dashboard,client,serve
+
+		# Forward the dashboard port of the RayCluster to local port 18265
+		kubectl ray session my-raycluster --ports dashboard --local-ports dashboard=18265
 	`)
 )
 
@@ -79,7 +105,7 @@ func NewSessionCommand(streams genericiooptions.IOStreams) *cobra.Command {
 	factory := cmdutil.NewFactory(options.configFlags)
 
 	cmd := &cobra.Command{
-		Use:               "session (RAYCLUSTER | TYPE/NAME)",
+		Use:               "session [RAYCLUSTER | TYPE/NAME]",
 		Short:             "Forward local ports to the Ray resources.",
 		Long:              sessionLong,
 		Example:           sessionExample,
@@ -94,36 +120,27 @@ func NewSessionCommand(streams genericiooptions.IOStreams) *cobra.Command {
 			return options.Run(cmd.Context(), factory)
 		},
 	}
+	cmd.Flags().StringSliceVar(&options.portSets, "ports", options.portSets, "Comma separated list of ports to forward, from 'dashboard', 'client' and 'serve'. Defaults depend on the resource type")
+	cmd.Flags().StringToIntVar(&options.localPorts, "local-ports", options.localPorts, "Local ports to use for the forwarded ports, e.g. 'dashboard=18265,client=20001'. Defaults to the remote ports")
+	cmd.Flags().BoolVar(&options.nonInteractive, "non-interactive", options.nonInteractive, "If present, fail instead of prompting for the RayCluster when none is given and the namespace has several")
 	options.configFlags.AddFlags(cmd.Flags())
 	return cmd
 }
 
 func (options *SessionOptions) Complete(cmd *cobra.Command, args []string) error {
-	if len(args) != 1 {
+	if len(args) > 1 {
 		return cmdutil.UsageErrorf(cmd, "%s", cmd.Use)
 	}
 
-	typeAndName := strings.Split(args[0], "/")
-	if len(typeAndName) == 1 {
-		options.ResourceType = util.RayCluster
-		options.ResourceName = typeAndName[0]
-	} else {
-		if len(typeAndName) != 2 || typeAndName[1] == "" {
-			return cmdutil.UsageErrorf(cmd, "invalid resource type/name: %s", args[0])
-		}
-
-		switch typeAndName[0] {
-		case string(util.RayCluster):
-			options.ResourceType = util.RayCluster
-		case string(util.RayJob):
-			options.ResourceType = util.RayJob
-		case string(util.RayService):
-			options.ResourceType = util.RayService
-		default:
-			return cmdutil.UsageErrorf(cmd, "unsupported resource type: %s", typeAndName[0])
+	// Without a resource, the RayCluster is selected in Run
+	options.ResourceType, options.ResourceName = util.RayCluster, ""
+	if len(args) == 1 {
+		resourceType, resourceName, err := util.ParseRayResource(args[0])
+		if err != nil {
+			return cmdutil.UsageErrorf(cmd, "%s", err.Error())
 		}
-
-		options.ResourceName = typeAndName[1]
+		options.ResourceType = resourceType
+		options.ResourceName = resourceName
 	}
 
 	if *options.configFlags.Namespace == "" {
@@ -132,6 +149,17 @@ func (options *SessionOptions) Complete(cmd *cobra.Command, args []string) error
 		options.Namespace = *options.configFlags.Namespace
 	}
 
+	if len(options.portSets) == 0 {
+		switch options.ResourceType {
+		case util.RayCluster:
+			options.portSets = []string{"dashboard", "client"}
+		case util.RayJob:
+			options.portSets = []string{"dashboard"}
+		case util.RayService:
+			options.portSets = []string{"dashboard", "serve"}
+		}
+	}
+
 	return nil
 }
 
@@ -144,6 +172,38 @@ func (options *SessionOptions) Validate() error {
 	if len(config.CurrentContext) == 0 {
 		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
 	}
+	return options.resolveAppPorts()
+}
+
+// resolveAppPorts maps the port set names given with --ports and --local-ports to the ports to forward
+func (options *SessionOptions) resolveAppPorts() error {
+	options.appPorts = nil
+	for _, portSet := range options.portSets {
+		appPort, ok := namedPortSets[portSet]
+		if !ok {
+			return fmt.Errorf("unsupported port %q, must be one of dashboard, client or serve", portSet)
+		}
+		appPort.localPort = appPort.port
+		options.appPorts = append(options.appPorts, appPort)
+	}
+	for portSet, localPort := range options.localPorts {
+		if _, ok := namedPortSets[portSet]; !ok {
+			return fmt.Errorf("unsupported port %q in --local-ports, must be one of dashboard, client or serve", portSet)
+		}
+		if localPort <= 0 || localPort > 65535 {
+			return fmt.Errorf("local port %d for %s is out of range, must be between 1 and 65535", localPort, portSet)
+		}
+		found := false
+		for i, portSetName := range options.portSets {
+			if portSetName == portSet {
+				options.appPorts[i].localPort = localPort
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("local port given for %s, which is not forwarded", portSet)
+		}
+	}
 	return nil
 }
 
@@ -153,39 +213,47 @@ func (options *SessionOptions) Run(ctx context.Context, factory cmdutil.Factory)
 		return fmt.Errorf("failed to create client: %w", err)
 	}
 
-	svcName, err := k8sClient.GetRayHeadSvcName(ctx, options.Namespace, options.ResourceType, options.ResourceName)
-	if err != nil {
-		return err
-	}
-	fmt.Printf("Forwarding ports to service %s\n", svcName)
-
-	var appPorts []appPort
-	switch options.ResourceType {
-	case util.RayCluster:
-		appPorts = []appPort{dashboardPort, clientPort}
-	case util.RayJob:
-		appPorts = []appPort{dashboardPort}
-	case util.RayService:
-		appPorts = []appPort{dashboardPort, servePort}
-	default:
-		return fmt.Errorf("unsupported resource type: %s", options.ResourceType)
+	if options.ResourceName == "" {
+		options.ResourceName, err = selector.RayCluster(ctx, k8sClient.DynamicClient(), *options.ioStreams, options.Namespace, options.nonInteractive)
+		if err != nil {
+			return err
+		}
 	}
 
-	portForwardCmd := portforward.NewCmdPortForward(factory, *options.ioStreams)
-	args := []string{"service/" + svcName}
-	for _, appPort := range appPorts {
-		args = append(args, fmt.Sprintf("%d:%d", appPort.port, appPort.port))
+	target := func(ctx context.Context) (string, error) {
+		svcName, err := k8sClient.GetRayHeadSvcName(ctx, options.Namespace, options.ResourceType, options.ResourceName)
+		if err != nil {
+			return "", err
+		}
+		return "service/" + svcName, nil
 	}
-	portForwardCmd.SetArgs(args)
-
-	for _, appPort := range appPorts {
-		fmt.Printf("%s: http://localhost:%d\n", appPort.name, appPort.port)
+	forwarder := portforward.NewReconnectingPortForwarder(factory, *options.ioStreams, target, options.portForwardPorts())
+	logger := logging.New(options.ioStreams.Out)
+	printed := false
+	forwarder.OnReady = func(target string) {
+		if printed {
+			logger.Infof("Reconnected to %s", target)
+			return
+		}
+		printed = true
+		logger.Infof("Forwarding ports to %s", target)
+		for _, appPort := range options.appPorts {
+			logger.Infof("%s: http://localhost:%d", appPort.name, appPort.localPort)
+		}
 	}
-	fmt.Println()
 
-	if err := portForwardCmd.ExecuteContext(ctx); err != nil {
+	if err := forwarder.Run(ctx); err != nil {
 		return fmt.Errorf("failed to port-forward: %w", err)
 	}
 
 	return nil
 }
+
+// portForwardPorts returns the "LOCAL_PORT:REMOTE_PORT" mappings of the selected ports of the head service
+func (options *SessionOptions) portForwardPorts() []string {
+	var args []string
+	for _, appPort := range options.appPorts {
+		args = append(args, fmt.Sprintf("%d:%d", appPort.localPort, appPort.port))
+	}
+	return args
+}
@@ -19,12 +19,14 @@ func TestComplete(t *testing.T) {
 		expectedNamespace    string
 		expectedName         string
 		args                 []string
+		expectedPortSets     []string
 		hasErr               bool
 	}{
 		{
 			name:                 "valid raycluster without namespace",
 			namespace:            "",
 			args:                 []string{"raycluster/test-raycluster"},
+			expectedPortSets:     []string{"dashboard", "client"},
 			expectedResourceType: util.RayCluster,
 			expectedNamespace:    "default",
 			expectedName:         "test-raycluster",
@@ -34,6 +36,7 @@ func TestComplete(t *testing.T) {
 			name:                 "valid raycluster with namespace",
 			namespace:            "test-namespace",
 			args:                 []string{"raycluster/test-raycluster"},
+			expectedPortSets:     []string{"dashboard", "client"},
 			expectedResourceType: util.RayCluster,
 			expectedNamespace:    "test-namespace",
 			expectedName:         "test-raycluster",
@@ -43,6 +46,7 @@ func TestComplete(t *testing.T) {
 			name:                 "valid rayjob without namespace",
 			namespace:            "",
 			args:                 []string{"rayjob/test-rayjob"},
+			expectedPortSets:     []string{"dashboard"},
 			expectedResourceType: util.RayJob,
 			expectedNamespace:    "default",
 			expectedName:         "test-rayjob",
@@ -52,6 +56,7 @@ func TestComplete(t *testing.T) {
 			name:                 "valid rayservice without namespace",
 			namespace:            "",
 			args:                 []string{"rayservice/test-rayservice"},
+			expectedPortSets:     []string{"dashboard", "serve"},
 			expectedResourceType: util.RayService,
 			expectedNamespace:    "default",
 			expectedName:         "test-rayservice",
@@ -61,15 +66,21 @@ func TestComplete(t *testing.T) {
 			name:                 "no slash default to raycluster",
 			namespace:            "",
 			args:                 []string{"test-resource"},
+			expectedPortSets:     []string{"dashboard", "client"},
 			expectedResourceType: util.RayCluster,
 			expectedNamespace:    "default",
 			expectedName:         "test-resource",
 			hasErr:               false,
 		},
 		{
-			name:   "invalid args (no args)",
-			args:   []string{},
-			hasErr: true,
+			name:                 "no args selects a raycluster later",
+			namespace:            "",
+			args:                 []string{},
+			expectedPortSets:     []string{"dashboard", "client"},
+			expectedResourceType: util.RayCluster,
+			expectedNamespace:    "default",
+			expectedName:         "",
+			hasErr:               false,
 		},
 		{
 			name:   "invalid args (too many args)",
@@ -106,7 +117,64 @@ func TestComplete(t *testing.T) {
 				assert.Equal(t, tc.expectedNamespace, fakeSessionOptions.Namespace)
 				assert.Equal(t, tc.expectedResourceType, fakeSessionOptions.ResourceType)
 				assert.Equal(t, tc.expectedName, fakeSessionOptions.ResourceName)
+				assert.Equal(t, tc.expectedPortSets, fakeSessionOptions.portSets)
+			}
+		})
+	}
+}
+
+func TestResolveAppPorts(t *testing.T) {
+	tests := []struct {
+		localPorts    map[string]int
+		name          string
+		expectedError string
+		portSets      []string
+		expectedArgs  []string
+	}{
+		{
+			name:         "default ports of a RayCluster",
+			portSets:     []string{"dashboard", "client"},
+			expectedArgs: []string{"8265:8265", "10001:10001"},
+		},
+		{
+			name:         "all ports with custom local mapping",
+			portSets:     []string{"dashboard", "client", "serve"},
+			localPorts:   map[string]int{"dashboard": 18265, "serve": 18000},
+			expectedArgs: []string{"18265:8265", "10001:10001", "18000:8000"},
+		},
+		{
+			name:          "unsupported port set",
+			portSets:      []string{"dashboard", "metrics"},
+			expectedError: "unsupported port \"metrics\", must be one of dashboard, client or serve",
+		},
+		{
+			name:          "local port for a port that is not forwarded",
+			portSets:      []string{"dashboard"},
+			localPorts:    map[string]int{"serve": 18000},
+			expectedError: "local port given for serve, which is not forwarded",
+		},
+		{
+			name:          "local port out of range",
+			portSets:      []string{"dashboard"},
+			localPorts:    map[string]int{"dashboard": 70000},
+			expectedError: "local port 70000 for dashboard is out of range, must be between 1 and 65535",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+			fakeSessionOptions := NewSessionOptions(testStreams)
+			fakeSessionOptions.portSets = tc.portSets
+			fakeSessionOptions.localPorts = tc.localPorts
+
+			err := fakeSessionOptions.resolveAppPorts()
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
 			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedArgs, fakeSessionOptions.portForwardPorts())
 		})
 	}
 }
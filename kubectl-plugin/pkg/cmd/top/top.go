@@ -0,0 +1,357 @@
+package top
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/completion"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/config"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/dashboard"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/selector"
+)
+
+const (
+	portforwardReadyTimeout = 60 * time.Second
+	defaultRefreshInterval  = 5 * time.Second
+	// objectStoreMemory is the Ray resource of the object store memory in bytes
+	objectStoreMemory = "object_store_memory"
+)
+
+type TopOptions struct {
+	configFlags        *genericclioptions.ConfigFlags
+	ioStreams          *genericiooptions.IOStreams
+	ResourceType       util.ResourceType
+	ResourceName       string
+	Namespace          string
+	localDashboardPort int
+	interval           time.Duration
+	watch              bool
+	nonInteractive     bool
+}
+
+// groupUsage is the resource utilization of the Pods and Ray nodes of a head or worker group
+type groupUsage struct {
+	rayTotal     map[string]float64
+	rayAvailable map[string]float64
+	name         string
+	cpu          resource.Quantity
+	memory       resource.Quantity
+	pods         int
+	podsMetrics  int
+	rayNodes     int
+}
+
+var (
+	topLong = templates.LongDesc(`
+		Display the resource utilization of a RayCluster, or of the RayCluster used by a RayJob or RayService, per head and worker group.
+
+		The CPU and memory usage of the Pods is retrieved from the Metrics Server, and the Ray resources used by Ray tasks and actors,
+		such as CPUs, GPUs and object store memory, are retrieved from the Ray dashboard.
+
+		Without a resource, the only RayCluster of the namespace is used. When there are several, one can be selected
+		interactively on a terminal, unless '--non-interactive' is set.
+	`)
+
+	topExample = templates.Examples(`
+		# Display the resource utilization of the RayCluster
+		kubectl ray top my-raycluster
+
+		# Display the resource utilization of the only RayCluster of the namespace, or select one when there are several
+		kubectl ray top
+
+		# Refresh the resource utilization of the RayCluster used by the RayJob every 10 seconds
+		kubectl ray top rayjob/my-rayjob --watch --interval 10s
+	`)
+)
+
+func NewTopOptions(streams genericiooptions.IOStreams) *TopOptions {
+	return &TopOptions{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		ioStreams:   &streams,
+		interval:    defaultRefreshInterval,
+	}
+}
+
+func NewTopCommand(streams genericiooptions.IOStreams) *cobra.Command {
+	options := NewTopOptions(streams)
+	cmdFactory := cmdutil.NewFactory(options.configFlags)
+
+	cmd := &cobra.Command{
+		Use:               "top [RAYCLUSTER | TYPE/NAME] [--watch] [--interval DURATION]",
+		Short:             "Display resource utilization of a Ray resource",
+		Long:              topLong,
+		Example:           topExample,
+		SilenceUsage:      true,
+		ValidArgsFunction: completion.RayClusterResourceNameCompletionFunc(cmdFactory),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return options.Run(cmd.Context(), cmdFactory)
+		},
+	}
+	cmd.Flags().BoolVarP(&options.watch, "watch", "w", options.watch, "If present, refresh the resource utilization periodically")
+	cmd.Flags().DurationVar(&options.interval, "interval", options.interval, "Refresh interval when --watch is set")
+	cmd.Flags().IntVar(&options.localDashboardPort, "dashboard-port", options.localDashboardPort, "Local port used to forward the Ray dashboard. If not set, a free local port is selected automatically")
+	config.BindFlag(cmd.Flags(), "dashboard-port", config.DashboardPort)
+	cmd.Flags().BoolVar(&options.nonInteractive, "non-interactive", options.nonInteractive, "If present, fail instead of prompting for the RayCluster when none is given and the namespace has several")
+	options.configFlags.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (options *TopOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return cmdutil.UsageErrorf(cmd, "%s", cmd.Use)
+	}
+
+	// Without a resource, the RayCluster is selected in Run
+	options.ResourceType, options.ResourceName = util.RayCluster, ""
+	if len(args) == 1 {
+		resourceType, resourceName, err := util.ParseRayResource(args[0])
+		if err != nil {
+			return cmdutil.UsageErrorf(cmd, "%s", err.Error())
+		}
+		options.ResourceType = resourceType
+		options.ResourceName = resourceName
+	}
+
+	if *options.configFlags.Namespace == "" {
+		options.Namespace = "default"
+	} else {
+		options.Namespace = *options.configFlags.Namespace
+	}
+
+	if options.localDashboardPort == 0 {
+		freePort, err := util.GetFreeLocalPort()
+		if err != nil {
+			return fmt.Errorf("failed to find a free local port for the Ray dashboard: %w", err)
+		}
+		options.localDashboardPort = freePort
+	}
+	return nil
+}
+
+func (options *TopOptions) Validate() error {
+	// Overrides and binds the kube config then retrieves the merged result
+	config, err := options.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return fmt.Errorf("Error retrieving raw config: %w", err)
+	}
+	if len(config.CurrentContext) == 0 {
+		return fmt.Errorf("no context is currently set, use %q to select a new one", "kubectl config use-context <context>")
+	}
+	if options.interval <= 0 {
+		return fmt.Errorf("interval must be a positive duration, got %s", options.interval)
+	}
+	if options.localDashboardPort < 0 || options.localDashboardPort > 65535 {
+		return fmt.Errorf("dashboard port %d is out of range, must be between 0 and 65535", options.localDashboardPort)
+	}
+	return nil
+}
+
+func (options *TopOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	k8sClient, err := client.NewClient(factory)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	restConfig, err := factory.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get restconfig: %w", err)
+	}
+	metricsClient, err := metricsclientset.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create metrics client: %w", err)
+	}
+
+	if options.ResourceName == "" {
+		options.ResourceName, err = selector.RayCluster(ctx, k8sClient.DynamicClient(), *options.ioStreams, options.Namespace, options.nonInteractive)
+		if err != nil {
+			return err
+		}
+	}
+
+	clusterName, err := k8sClient.GetRayClusterName(ctx, options.Namespace, options.ResourceType, options.ResourceName)
+	if err != nil {
+		return err
+	}
+	svcName, err := k8sClient.GetRayHeadSvcName(ctx, options.Namespace, options.ResourceType, options.ResourceName)
+	if err != nil {
+		return err
+	}
+
+	portforwardctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if err := dashboard.PortForward(portforwardctx, factory, *options.ioStreams, svcName, options.localDashboardPort, portforwardReadyTimeout, nil); err != nil {
+		return err
+	}
+
+	for {
+		if err := options.printTop(ctx, k8sClient, metricsClient, clusterName); err != nil {
+			return err
+		}
+		if !options.watch {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(options.interval):
+		}
+		fmt.Fprintln(options.ioStreams.Out)
+	}
+}
+
+// printTop retrieves the current utilization of the RayCluster and prints it
+func (options *TopOptions) printTop(ctx context.Context, k8sClient client.Client, metricsClient metricsclientset.Interface, clusterName string) error {
+	labelSelector := fmt.Sprintf("ray.io/cluster=%s", clusterName)
+	pods, err := k8sClient.KubernetesClient().CoreV1().Pods(options.Namespace).List(ctx, v1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return fmt.Errorf("unable to list Pods of RayCluster %s: %w", clusterName, err)
+	}
+
+	var podMetrics []metricsv1beta1.PodMetrics
+	podMetricsList, err := metricsClient.MetricsV1beta1().PodMetricses(options.Namespace).List(ctx, v1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		logging.New(options.ioStreams.ErrOut).Warnf("unable to retrieve Pod metrics, is the Metrics Server installed? %v", err)
+	} else {
+		podMetrics = podMetricsList.Items
+	}
+
+	nodes, err := dashboard.ListNodes(ctx, dashboard.Address(options.localDashboardPort))
+	if err != nil {
+		return err
+	}
+
+	return printGroupUsages(aggregateGroupUsages(pods.Items, podMetrics, nodes), options.ioStreams.Out)
+}
+
+// aggregateGroupUsages sums the Pod metrics and the Ray resources of the alive Ray nodes per head and worker group.
+// Ray nodes are mapped to their Pod by IP address.
+func aggregateGroupUsages(pods []corev1.Pod, podMetrics []metricsv1beta1.PodMetrics, nodes []dashboard.Node) []*groupUsage {
+	usages := map[string]*groupUsage{}
+	groupsByPodName := map[string]*groupUsage{}
+	groupsByPodIP := map[string]*groupUsage{}
+	for _, pod := range pods {
+		groupName := pod.Labels["ray.io/group"]
+		usage, ok := usages[groupName]
+		if !ok {
+			usage = &groupUsage{
+				name:         groupName,
+				rayTotal:     map[string]float64{},
+				rayAvailable: map[string]float64{},
+			}
+			usages[groupName] = usage
+		}
+		usage.pods++
+		groupsByPodName[pod.Name] = usage
+		if pod.Status.PodIP != "" {
+			groupsByPodIP[pod.Status.PodIP] = usage
+		}
+	}
+
+	for _, podMetric := range podMetrics {
+		usage, ok := groupsByPodName[podMetric.Name]
+		if !ok {
+			continue
+		}
+		usage.podsMetrics++
+		for _, container := range podMetric.Containers {
+			usage.cpu.Add(container.Usage[corev1.ResourceCPU])
+			usage.memory.Add(container.Usage[corev1.ResourceMemory])
+		}
+	}
+
+	for _, node := range nodes {
+		if node.Raylet.State != "ALIVE" {
+			continue
+		}
+		ip := node.Raylet.NodeManagerAddress
+		if ip == "" {
+			ip = node.IP
+		}
+		usage, ok := groupsByPodIP[ip]
+		if !ok {
+			continue
+		}
+		usage.rayNodes++
+		for name, total := range node.Raylet.ResourcesTotal {
+			usage.rayTotal[name] += total
+		}
+		for name, available := range node.Raylet.ResourcesAvailable {
+			usage.rayAvailable[name] += available
+		}
+	}
+
+	result := make([]*groupUsage, 0, len(usages))
+	for _, usage := range usages {
+		result = append(result, usage)
+	}
+	// The head group comes first, followed by the worker groups by name
+	sort.Slice(result, func(i, j int) bool {
+		if (result[i].name == "headgroup") != (result[j].name == "headgroup") {
+			return result[i].name == "headgroup"
+		}
+		return result[i].name < result[j].name
+	})
+	return result
+}
+
+func printGroupUsages(usages []*groupUsage, output io.Writer) error {
+	resultTablePrinter := printers.NewTablePrinter(printers.PrintOptions{})
+
+	resTable := &v1.Table{
+		ColumnDefinitions: []v1.TableColumnDefinition{
+			{Name: "Group", Type: "string"},
+			{Name: "Pods", Type: "integer"},
+			{Name: "Ray Nodes", Type: "integer"},
+			{Name: "CPU(cores)", Type: "string"},
+			{Name: "Memory(bytes)", Type: "string"},
+			{Name: "Ray CPUs", Type: "string"},
+			{Name: "Ray GPUs", Type: "string"},
+			{Name: "Object Store", Type: "string"},
+		},
+	}
+
+	for _, usage := range usages {
+		cpu, memory := "<unknown>", "<unknown>"
+		if usage.podsMetrics > 0 {
+			cpu = fmt.Sprintf("%dm", usage.cpu.MilliValue())
+			memory = fmt.Sprintf("%dMi", usage.memory.Value()/(1024*1024))
+		}
+		resTable.Rows = append(resTable.Rows, v1.TableRow{
+			Cells: []interface{}{
+				usage.name,
+				usage.pods,
+				usage.rayNodes,
+				cpu,
+				memory,
+				fmt.Sprintf("%g/%g", usage.rayTotal["CPU"]-usage.rayAvailable["CPU"], usage.rayTotal["CPU"]),
+				fmt.Sprintf("%g/%g", usage.rayTotal["GPU"]-usage.rayAvailable["GPU"], usage.rayTotal["GPU"]),
+				fmt.Sprintf("%.0fMi/%.0fMi", (usage.rayTotal[objectStoreMemory]-usage.rayAvailable[objectStoreMemory])/(1024*1024), usage.rayTotal[objectStoreMemory]/(1024*1024)),
+			},
+		})
+	}
+
+	return resultTablePrinter.PrintObj(resTable, output)
+}
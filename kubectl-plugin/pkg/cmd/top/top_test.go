@@ -0,0 +1,146 @@
+package top
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/dashboard"
+)
+
+func TestRayTopComplete(t *testing.T) {
+	cmd := &cobra.Command{Use: "top"}
+	testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+
+	fakeTopOptions := NewTopOptions(testStreams)
+	fakeTopOptions.localDashboardPort = 18265
+
+	err := fakeTopOptions.Complete(cmd, []string{"rayservice/test-rayservice"})
+	assert.Nil(t, err)
+	assert.Equal(t, util.RayService, fakeTopOptions.ResourceType)
+	assert.Equal(t, "test-rayservice", fakeTopOptions.ResourceName)
+	assert.Equal(t, "default", fakeTopOptions.Namespace)
+	assert.Equal(t, defaultRefreshInterval, fakeTopOptions.interval)
+
+	// Without a resource, the RayCluster is selected when running the command
+	err = fakeTopOptions.Complete(cmd, []string{})
+	assert.Nil(t, err)
+	assert.Equal(t, util.RayCluster, fakeTopOptions.ResourceType)
+	assert.Equal(t, "", fakeTopOptions.ResourceName)
+
+	err = fakeTopOptions.Complete(cmd, []string{"my-raycluster", "other-raycluster"})
+	assert.NotNil(t, err)
+}
+
+func newPod(name, group, ip string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"ray.io/cluster": "raycluster", "ray.io/group": group},
+		},
+		Status: corev1.PodStatus{PodIP: ip},
+	}
+}
+
+func newPodMetrics(name, cpu, memory string) metricsv1beta1.PodMetrics {
+	return metricsv1beta1.PodMetrics{
+		ObjectMeta: v1.ObjectMeta{Name: name},
+		Window:     v1.Duration{Duration: time.Minute},
+		Containers: []metricsv1beta1.ContainerMetrics{
+			{
+				Name: "ray",
+				Usage: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				},
+			},
+		},
+	}
+}
+
+func TestAggregateGroupUsages(t *testing.T) {
+	pods := []corev1.Pod{
+		newPod("raycluster-head", "headgroup", "10.0.0.1"),
+		newPod("raycluster-worker-a", "workers", "10.0.0.2"),
+		newPod("raycluster-worker-b", "workers", "10.0.0.3"),
+		newPod("raycluster-gpu-a", "gpu-group", ""),
+	}
+	podMetrics := []metricsv1beta1.PodMetrics{
+		newPodMetrics("raycluster-head", "500m", "1Gi"),
+		newPodMetrics("raycluster-worker-a", "1", "512Mi"),
+		newPodMetrics("raycluster-worker-b", "250m", "512Mi"),
+		// Metrics of Pods which are not part of the RayCluster are ignored
+		newPodMetrics("other-pod", "4", "4Gi"),
+	}
+	nodes := []dashboard.Node{
+		{
+			Raylet: dashboard.Raylet{
+				State:              "ALIVE",
+				NodeManagerAddress: "10.0.0.1",
+				ResourcesTotal:     map[string]float64{"CPU": 1, objectStoreMemory: 512 * 1024 * 1024},
+				ResourcesAvailable: map[string]float64{"CPU": 1, objectStoreMemory: 256 * 1024 * 1024},
+			},
+		},
+		{
+			Raylet: dashboard.Raylet{
+				State:              "ALIVE",
+				NodeManagerAddress: "10.0.0.2",
+				ResourcesTotal:     map[string]float64{"CPU": 4},
+				ResourcesAvailable: map[string]float64{"CPU": 1},
+			},
+		},
+		{
+			Raylet: dashboard.Raylet{
+				State:              "ALIVE",
+				NodeManagerAddress: "10.0.0.3",
+				ResourcesTotal:     map[string]float64{"CPU": 4},
+				ResourcesAvailable: map[string]float64{"CPU": 4},
+			},
+		},
+		{
+			// Dead nodes are ignored even if a Pod reuses their IP
+			Raylet: dashboard.Raylet{
+				State:              "DEAD",
+				NodeManagerAddress: "10.0.0.3",
+				ResourcesTotal:     map[string]float64{"CPU": 4},
+			},
+		},
+	}
+
+	usages := aggregateGroupUsages(pods, podMetrics, nodes)
+	assert.Len(t, usages, 3)
+	assert.Equal(t, "headgroup", usages[0].name)
+	assert.Equal(t, "gpu-group", usages[1].name)
+	assert.Equal(t, "workers", usages[2].name)
+
+	assert.Equal(t, 2, usages[2].pods)
+	assert.Equal(t, 2, usages[2].podsMetrics)
+	assert.Equal(t, 2, usages[2].rayNodes)
+	assert.Equal(t, int64(1250), usages[2].cpu.MilliValue())
+	assert.Equal(t, float64(8), usages[2].rayTotal["CPU"])
+	assert.Equal(t, float64(5), usages[2].rayAvailable["CPU"])
+
+	assert.Equal(t, 1, usages[1].pods)
+	assert.Equal(t, 0, usages[1].podsMetrics)
+	assert.Equal(t, 0, usages[1].rayNodes)
+
+	var out bytes.Buffer
+	err := printGroupUsages(usages, &out)
+	assert.Nil(t, err)
+
+	expected := `GROUP       PODS   RAY NODES   CPU(CORES)   MEMORY(BYTES)   RAY CPUS   RAY GPUS   OBJECT STORE
+headgroup   1      1           500m         1024Mi          0/1        0/0        256Mi/512Mi
+gpu-group   1      0           <unknown>    <unknown>       0/0        0/0        0Mi/0Mi
+workers     2      2           1250m        1024Mi          3/8        0/0        0Mi/0Mi
+`
+	assert.Equal(t, expected, out.String())
+}
@@ -1,24 +1,109 @@
 package version
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"regexp"
+	"strings"
 
-	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/completion"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/printer"
 )
 
 var Version = "development"
 
+var (
+	crdGVR = schema.GroupVersionResource{
+		Group:    "apiextensions.k8s.io",
+		Version:  "v1",
+		Resource: "customresourcedefinitions",
+	}
+
+	// rayCRDNames are the CRDs the plugin works with
+	rayCRDNames = []string{
+		util.RayClusterGVR.Resource + "." + util.RayGroup,
+		util.RayJobGVR.Resource + "." + util.RayGroup,
+		util.RayServiceGVR.Resource + "." + util.RayGroup,
+	}
+
+	// rayVersionCommand prints e.g. "ray, version 2.9.0"
+	rayVersionCommand = []string{"ray", "--version"}
+	rayVersionPattern = regexp.MustCompile(`version\s+(\S+)`)
+
+	// minOperatorVersion is the first KubeRay release serving the ray.io/v1 API used by the plugin
+	minOperatorVersion = utilversion.MustParseGeneric("1.0.0")
+	// minRayVersion is the oldest Ray release supported by KubeRay v1
+	minRayVersion = utilversion.MustParseGeneric("2.0.0")
+)
+
 type VersionOptions struct {
-	configFlags *genericclioptions.ConfigFlags
-	ioStreams   *genericclioptions.IOStreams
+	configFlags  *genericclioptions.ConfigFlags
+	outputFlags  *printer.OutputFlags
+	ioStreams    *genericclioptions.IOStreams
+	ResourceType util.ResourceType
+	ResourceName string
+	Namespace    string
 }
 
+// versionInfo holds the versions of the components. Empty versions could not be determined.
+type versionInfo struct {
+	crdStoredVersions map[string][]string
+	pluginVersion     string
+	operatorVersion   string
+	clusterName       string
+	specRayVersion    string
+	headRayVersion    string
+}
+
+// versionOutput is the machine-readable output of the versions and the warnings
+type versionOutput struct {
+	CRDStoredVersions map[string][]string `json:"crdStoredVersions,omitempty"`
+	PluginVersion     string              `json:"pluginVersion"`
+	OperatorVersion   string              `json:"operatorVersion,omitempty"`
+	RayCluster        string              `json:"rayCluster,omitempty"`
+	RayVersion        string              `json:"rayVersion,omitempty"`
+	Warnings          []string            `json:"warnings,omitempty"`
+}
+
+var (
+	versionLong = templates.LongDesc(`
+		Output the version of the Ray kubectl plugin, the KubeRay operator and the stored versions of the Ray CRDs.
+
+		When a RayCluster, or a RayJob or RayService, is given, the Ray version running in the head Pod of the RayCluster is
+		reported as well. Known incompatibilities between the versions are reported as warnings.
+	`)
+
+	versionExample = templates.Examples(`
+		# Output the version of the plugin, the KubeRay operator and the Ray CRDs
+		kubectl ray version
+
+		# Also output the Ray version of the RayCluster
+		kubectl ray version my-raycluster
+
+		# Output the versions and warnings as JSON
+		kubectl ray version -o json
+	`)
+)
+
 func NewVersionOptions(streams genericclioptions.IOStreams) *VersionOptions {
 	return &VersionOptions{
 		configFlags: genericclioptions.NewConfigFlags(true),
+		outputFlags: printer.NewOutputFlags(printer.JSON, printer.YAML),
 		ioStreams:   &streams,
 	}
 }
@@ -28,25 +113,214 @@ func NewVersionCommand(streams genericclioptions.IOStreams) *cobra.Command {
 	cmdFactory := cmdutil.NewFactory(options.configFlags)
 
 	cmd := &cobra.Command{
-		Use:   "version",
-		Short: "Output the version of the Ray kubectl plugin and KubeRay operator",
-		RunE: func(cmd *cobra.Command, _ []string) error {
-			fmt.Println("kubectl ray plugin version:", Version)
-
-			kubeClient, err := client.NewClient(cmdFactory)
-			if err != nil {
-				return fmt.Errorf("failed to create client: %w", err)
+		Use:               "version [RAYCLUSTER | TYPE/NAME]",
+		Short:             "Output the version of the Ray kubectl plugin and KubeRay operator",
+		Long:              versionLong,
+		Example:           versionExample,
+		SilenceUsage:      true,
+		ValidArgsFunction: completion.RayClusterResourceNameCompletionFunc(cmdFactory),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(cmd, args); err != nil {
+				return err
 			}
-
-			operatorVersion, err := kubeClient.GetKubeRayOperatorVersion(cmd.Context())
-			if err != nil {
-				fmt.Println("Warning: KubeRay operator installation cannot be found - did you install it with the name \"kuberay-operator\"?")
-			} else {
-				fmt.Println("KubeRay operator version:", operatorVersion)
+			if err := options.outputFlags.Validate(); err != nil {
+				return err
 			}
-			return nil
+			return options.Run(cmd.Context(), cmdFactory)
 		},
 	}
-
+	options.outputFlags.AddFlags(cmd)
+	options.configFlags.AddFlags(cmd.Flags())
 	return cmd
 }
+
+func (options *VersionOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return cmdutil.UsageErrorf(cmd, "%s", cmd.Use)
+	}
+	if len(args) == 1 {
+		resourceType, resourceName, err := util.ParseRayResource(args[0])
+		if err != nil {
+			return cmdutil.UsageErrorf(cmd, "%s", err.Error())
+		}
+		options.ResourceType = resourceType
+		options.ResourceName = resourceName
+	}
+
+	if *options.configFlags.Namespace == "" {
+		options.Namespace = "default"
+	} else {
+		options.Namespace = *options.configFlags.Namespace
+	}
+	return nil
+}
+
+func (options *VersionOptions) Run(ctx context.Context, factory cmdutil.Factory) error {
+	out := options.ioStreams.Out
+	if options.outputFlags.IsStructured() {
+		// The versions and warnings are only printed in the requested format at the end
+		out = io.Discard
+	}
+	info := versionInfo{pluginVersion: Version}
+	fmt.Fprintln(out, "kubectl ray plugin version:", info.pluginVersion)
+
+	k8sClient, err := client.NewClient(factory)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	var warnings []string
+	warn := func(warning string) {
+		warnings = append(warnings, warning)
+		fmt.Fprintln(out, "Warning:", warning)
+	}
+
+	info.operatorVersion, err = k8sClient.GetKubeRayOperatorVersion(ctx)
+	if err != nil {
+		warn("KubeRay operator installation cannot be found - did you install it with the name \"kuberay-operator\"?")
+	} else {
+		fmt.Fprintln(out, "KubeRay operator version:", info.operatorVersion)
+	}
+
+	info.crdStoredVersions, err = getCRDStoredVersions(ctx, k8sClient.DynamicClient())
+	if err != nil {
+		warn(fmt.Sprintf("unable to get the Ray CRDs: %v", err))
+	} else {
+		fmt.Fprintln(out, "Ray CRD stored versions:")
+		for _, crdName := range rayCRDNames {
+			storedVersions, ok := info.crdStoredVersions[crdName]
+			if !ok {
+				fmt.Fprintf(out, "  %s: not installed\n", crdName)
+				continue
+			}
+			fmt.Fprintf(out, "  %s: %s\n", crdName, strings.Join(storedVersions, ", "))
+		}
+	}
+
+	if options.ResourceName != "" {
+		if err := options.getRayVersions(ctx, factory, k8sClient, &info); err != nil {
+			warn(fmt.Sprintf("unable to get the Ray version of %s %s: %v", options.ResourceType, options.ResourceName, err))
+		} else {
+			fmt.Fprintf(out, "Ray version of RayCluster %s: %s\n", info.clusterName, info.headRayVersion)
+		}
+	}
+
+	incompatibilities := compatibilityWarnings(info)
+	printWarnings(incompatibilities, out)
+
+	if options.outputFlags.IsStructured() {
+		return options.outputFlags.PrintData(versionOutput{
+			CRDStoredVersions: info.crdStoredVersions,
+			PluginVersion:     info.pluginVersion,
+			OperatorVersion:   info.operatorVersion,
+			RayCluster:        info.clusterName,
+			RayVersion:        info.headRayVersion,
+			Warnings:          append(warnings, incompatibilities...),
+		}, options.ioStreams.Out)
+	}
+	return nil
+}
+
+// getCRDStoredVersions returns the stored versions of the installed Ray CRDs by CRD name
+func getCRDStoredVersions(ctx context.Context, dynamicClient dynamic.Interface) (map[string][]string, error) {
+	storedVersions := map[string][]string{}
+	for _, crdName := range rayCRDNames {
+		crd, err := dynamicClient.Resource(crdGVR).Get(ctx, crdName, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		versions, _, _ := unstructured.NestedStringSlice(crd.Object, "status", "storedVersions")
+		storedVersions[crdName] = versions
+	}
+	return storedVersions, nil
+}
+
+// getRayVersions sets the Ray version of the RayCluster spec and the Ray version running in its head Pod
+func (options *VersionOptions) getRayVersions(ctx context.Context, factory cmdutil.Factory, k8sClient client.Client, info *versionInfo) error {
+	clusterName, err := k8sClient.GetRayClusterName(ctx, options.Namespace, options.ResourceType, options.ResourceName)
+	if err != nil {
+		return err
+	}
+	info.clusterName = clusterName
+
+	rayCluster, err := k8sClient.DynamicClient().Resource(util.RayClusterGVR).Namespace(options.Namespace).Get(ctx, clusterName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to find RayCluster %s: %w", clusterName, err)
+	}
+	info.specRayVersion, _, _ = unstructured.NestedString(rayCluster.Object, "spec", "rayVersion")
+
+	headPod, err := k8sClient.GetRayPod(ctx, options.Namespace, clusterName, "")
+	if err != nil {
+		return err
+	}
+	restConfig, err := factory.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get restconfig: %w", err)
+	}
+	info.headRayVersion, err = getHeadRayVersion(ctx, headPod, func(ctx context.Context, pod *corev1.Pod, command []string) (string, error) {
+		return client.ExecInPod(ctx, k8sClient.KubernetesClient(), restConfig, pod, command)
+	})
+	return err
+}
+
+// getHeadRayVersion runs `ray --version` in the head Pod
+func getHeadRayVersion(ctx context.Context, headPod *corev1.Pod, execInPod func(ctx context.Context, pod *corev1.Pod, command []string) (string, error)) (string, error) {
+	output, err := execInPod(ctx, headPod, rayVersionCommand)
+	if err != nil {
+		return "", fmt.Errorf("failed to run %q in Pod %s: %w", strings.Join(rayVersionCommand, " "), headPod.Name, err)
+	}
+	matches := rayVersionPattern.FindStringSubmatch(output)
+	if matches == nil {
+		return "", fmt.Errorf("unable to parse the Ray version from %q", strings.TrimSpace(output))
+	}
+	return matches[1], nil
+}
+
+// compatibilityWarnings returns the known incompatibilities between the versions
+func compatibilityWarnings(info versionInfo) []string {
+	var warnings []string
+
+	operatorVersion, operatorErr := utilversion.ParseGeneric(info.operatorVersion)
+	if operatorErr == nil && operatorVersion.LessThan(minOperatorVersion) {
+		warnings = append(warnings, fmt.Sprintf("KubeRay operator %s does not serve the %s/%s API used by the plugin, upgrade it to v%s or later", info.operatorVersion, util.RayGroup, util.RayVersion, minOperatorVersion))
+	}
+	pluginVersion, pluginErr := utilversion.ParseGeneric(info.pluginVersion)
+	if operatorErr == nil && pluginErr == nil && operatorVersion.AtLeast(minOperatorVersion) &&
+		(pluginVersion.Major() > operatorVersion.Major() || pluginVersion.Major() == operatorVersion.Major() && pluginVersion.Minor() > operatorVersion.Minor()) {
+		warnings = append(warnings, fmt.Sprintf("kubectl ray plugin %s is newer than KubeRay operator %s, some features may not be supported by the operator", info.pluginVersion, info.operatorVersion))
+	}
+
+	if info.crdStoredVersions != nil {
+		for _, crdName := range rayCRDNames {
+			storedVersions, ok := info.crdStoredVersions[crdName]
+			if !ok {
+				warnings = append(warnings, fmt.Sprintf("CRD %s is not installed", crdName))
+				continue
+			}
+			for _, storedVersion := range storedVersions {
+				if storedVersion != util.RayVersion {
+					warnings = append(warnings, fmt.Sprintf("CRD %s still stores objects in version %s, which may be removed in a future KubeRay release. Migrate them to %s and remove %s from status.storedVersions", crdName, storedVersion, util.RayVersion, storedVersion))
+				}
+			}
+		}
+	}
+
+	if rayVersion, err := utilversion.ParseGeneric(info.headRayVersion); err == nil {
+		if rayVersion.LessThan(minRayVersion) {
+			warnings = append(warnings, fmt.Sprintf("Ray %s is not supported by KubeRay v1, use Ray %s or later", info.headRayVersion, minRayVersion))
+		}
+		if specRayVersion, err := utilversion.ParseGeneric(info.specRayVersion); err == nil && !specRayVersion.EqualTo(rayVersion) {
+			warnings = append(warnings, fmt.Sprintf("spec.rayVersion %s of RayCluster %s does not match Ray %s running in the head Pod, the operator relies on spec.rayVersion to configure Ray", info.specRayVersion, info.clusterName, info.headRayVersion))
+		}
+	}
+	return warnings
+}
+
+func printWarnings(warnings []string, out io.Writer) {
+	for _, warning := range warnings {
+		fmt.Fprintln(out, "Warning:", warning)
+	}
+}
@@ -0,0 +1,133 @@
+package version
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestGetCRDStoredVersions(t *testing.T) {
+	newCRD := func(name string, storedVersions ...interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "apiextensions.k8s.io/v1",
+				"kind":       "CustomResourceDefinition",
+				"metadata":   map[string]interface{}{"name": name},
+				"status":     map[string]interface{}{"storedVersions": storedVersions},
+			},
+		}
+	}
+	dynamicClient := dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{crdGVR: "CustomResourceDefinitionList"},
+		newCRD("rayclusters.ray.io", "v1alpha1", "v1"),
+		newCRD("rayjobs.ray.io", "v1"),
+	)
+
+	storedVersions, err := getCRDStoredVersions(context.Background(), dynamicClient)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{
+		"rayclusters.ray.io": {"v1alpha1", "v1"},
+		"rayjobs.ray.io":     {"v1"},
+	}, storedVersions)
+}
+
+func TestGetHeadRayVersion(t *testing.T) {
+	headPod := &corev1.Pod{ObjectMeta: v1.ObjectMeta{Name: "raycluster-head"}}
+
+	rayVersion, err := getHeadRayVersion(context.Background(), headPod, func(_ context.Context, pod *corev1.Pod, command []string) (string, error) {
+		assert.Equal(t, "raycluster-head", pod.Name)
+		assert.Equal(t, []string{"ray", "--version"}, command)
+		return "ray, version 2.9.0\n", nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "2.9.0", rayVersion)
+
+	_, err = getHeadRayVersion(context.Background(), headPod, func(_ context.Context, _ *corev1.Pod, _ []string) (string, error) {
+		return "bash: ray: command not found", nil
+	})
+	assert.NotNil(t, err)
+}
+
+func TestCompatibilityWarnings(t *testing.T) {
+	compatibleCRDs := map[string][]string{
+		"rayclusters.ray.io": {"v1"},
+		"rayjobs.ray.io":     {"v1"},
+		"rayservices.ray.io": {"v1"},
+	}
+
+	tests := []struct {
+		name             string
+		info             versionInfo
+		expectedWarnings []string
+	}{
+		{
+			name: "compatible versions",
+			info: versionInfo{
+				pluginVersion:     "v1.2.0",
+				operatorVersion:   "v1.2.1",
+				crdStoredVersions: compatibleCRDs,
+				clusterName:       "raycluster",
+				specRayVersion:    "2.9.0",
+				headRayVersion:    "2.9.0",
+			},
+		},
+		{
+			name: "development plugin and unknown versions",
+			info: versionInfo{pluginVersion: "development"},
+		},
+		{
+			name: "operator does not serve ray.io/v1",
+			info: versionInfo{pluginVersion: "v1.2.0", operatorVersion: "v0.6.0", crdStoredVersions: compatibleCRDs},
+			expectedWarnings: []string{
+				"KubeRay operator v0.6.0 does not serve the ray.io/v1 API used by the plugin, upgrade it to v1.0.0 or later",
+			},
+		},
+		{
+			name: "plugin is newer than operator",
+			info: versionInfo{pluginVersion: "v1.3.0", operatorVersion: "v1.2.2", crdStoredVersions: compatibleCRDs},
+			expectedWarnings: []string{
+				"kubectl ray plugin v1.3.0 is newer than KubeRay operator v1.2.2, some features may not be supported by the operator",
+			},
+		},
+		{
+			name: "CRDs are outdated",
+			info: versionInfo{
+				pluginVersion: "development",
+				crdStoredVersions: map[string][]string{
+					"rayclusters.ray.io": {"v1alpha1", "v1"},
+					"rayjobs.ray.io":     {"v1"},
+				},
+			},
+			expectedWarnings: []string{
+				"CRD rayclusters.ray.io still stores objects in version v1alpha1, which may be removed in a future KubeRay release. Migrate them to v1 and remove v1alpha1 from status.storedVersions",
+				"CRD rayservices.ray.io is not installed",
+			},
+		},
+		{
+			name: "unsupported and mismatching Ray version",
+			info: versionInfo{
+				pluginVersion:  "development",
+				clusterName:    "raycluster",
+				specRayVersion: "2.9.0",
+				headRayVersion: "1.13.0",
+			},
+			expectedWarnings: []string{
+				"Ray 1.13.0 is not supported by KubeRay v1, use Ray 2.0.0 or later",
+				"spec.rayVersion 2.9.0 of RayCluster raycluster does not match Ray 1.13.0 running in the head Pod, the operator relies on spec.rayVersion to configure Ray",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedWarnings, compatibilityWarnings(tc.info))
+		})
+	}
+}
@@ -3,22 +3,32 @@ package client
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 )
 
+// operatorVersionLabel is set on the KubeRay operator Deployment by the Helm chart
+const operatorVersionLabel = "app.kubernetes.io/version"
+
 type Client interface {
 	KubernetesClient() kubernetes.Interface
 	DynamicClient() dynamic.Interface
 	// GetRayHeadSvcName retrieves the name of RayHead service for the given RayCluster, RayJob, or RayService.
 	GetRayHeadSvcName(ctx context.Context, namespace string, resourceType util.ResourceType, name string) (string, error)
 	GetKubeRayOperatorVersion(ctx context.Context) (string, error)
+	// GetRayClusterName retrieves the name of the RayCluster for the given RayCluster, RayJob, or RayService.
+	GetRayClusterName(ctx context.Context, namespace string, resourceType util.ResourceType, name string) (string, error)
+	// GetRayPod retrieves a running Pod of the RayCluster. The head Pod is returned if workerGroup is empty.
+	GetRayPod(ctx context.Context, namespace string, clusterName string, workerGroup string) (*corev1.Pod, error)
 }
 
 type k8sClient struct {
@@ -31,7 +41,7 @@ func NewClient(factory cmdutil.Factory) (Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	dynamicClient, err := factory.DynamicClient()
+	dynamicClient, err := NewDynamicClient(factory)
 	if err != nil {
 		return nil, err
 	}
@@ -41,6 +51,15 @@ func NewClient(factory cmdutil.Factory) (Client, error) {
 	}, nil
 }
 
+// NewDynamicClient returns the dynamic client of the factory, whose calls are retried on transient errors
+func NewDynamicClient(factory cmdutil.Factory) (dynamic.Interface, error) {
+	dynamicClient, err := factory.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	return NewRetryingDynamicClient(dynamicClient), nil
+}
+
 func NewClientForTesting(kubeClient kubernetes.Interface, dynamicClient dynamic.Interface) Client {
 	return &k8sClient{
 		kubeClient:    kubeClient,
@@ -73,13 +92,28 @@ func (c *k8sClient) GetKubeRayOperatorVersion(ctx context.Context) (string, erro
 		return "", fmt.Errorf("no containers found in KubeRay operator deployment")
 	}
 
+	// The image tag is the version that is actually running, unless it is not a version, e.g. "latest"
 	image := containers[0].Image
-	parts := strings.Split(image, ":")
-	if len(parts) < 2 {
-		return "", fmt.Errorf("unable to parse KubeRay operator version from image: %s", image)
+	if tag := imageTag(image); tag != "" {
+		if _, err := utilversion.ParseGeneric(tag); err == nil {
+			return tag, nil
+		}
 	}
+	if labelVersion := deployment.Items[0].Labels[operatorVersionLabel]; labelVersion != "" {
+		return labelVersion, nil
+	}
+	return "", fmt.Errorf("unable to parse KubeRay operator version from image: %s", image)
+}
 
-	return parts[len(parts)-1], nil
+// imageTag returns the tag of the image reference, or an empty string if it has none
+func imageTag(image string) string {
+	image = strings.Split(image, "@")[0]
+	// A colon before the last slash is the port of the registry, e.g. localhost:5000/kuberay/operator
+	name := image[strings.LastIndex(image, "/")+1:]
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		return name[i+1:]
+	}
+	return ""
 }
 
 func (c *k8sClient) GetRayHeadSvcName(ctx context.Context, namespace string, resourceType util.ResourceType, name string) (string, error) {
@@ -152,6 +186,57 @@ func (c *k8sClient) getRayHeadSvcNameByRayService(ctx context.Context, namespace
 	return svcName, nil
 }
 
+func (c *k8sClient) GetRayClusterName(ctx context.Context, namespace string, resourceType util.ResourceType, name string) (string, error) {
+	var clusterName string
+	switch resourceType {
+	case util.RayCluster:
+		return name, nil
+	case util.RayJob:
+		rayJob, err := c.DynamicClient().Resource(util.RayJobGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("unable to find RayJob %s: %w", name, err)
+		}
+		clusterName, _, _ = unstructured.NestedString(rayJob.Object, "status", "rayClusterName")
+	case util.RayService:
+		rayService, err := c.DynamicClient().Resource(util.RayServiceGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("unable to find RayService %s: %w", name, err)
+		}
+		clusterName, _, _ = unstructured.NestedString(rayService.Object, "status", "activeServiceStatus", "rayClusterName")
+	default:
+		return "", fmt.Errorf("unsupported resource type: %s", resourceType)
+	}
+	if clusterName == "" {
+		return "", fmt.Errorf("unable to find the RayCluster of %s %s", resourceType, name)
+	}
+	return clusterName, nil
+}
+
+func (c *k8sClient) GetRayPod(ctx context.Context, namespace string, clusterName string, workerGroup string) (*corev1.Pod, error) {
+	labelSelector := fmt.Sprintf("ray.io/cluster=%s,ray.io/node-type=head", clusterName)
+	if workerGroup != "" {
+		labelSelector = fmt.Sprintf("ray.io/cluster=%s,ray.io/node-type=worker,ray.io/group=%s", clusterName, workerGroup)
+	}
+	pods, err := c.kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list Pods of RayCluster %s: %w", clusterName, err)
+	}
+
+	// Pick the same Pod every time when there are several, e.g. replicas of a worker group
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase == corev1.PodRunning && pods.Items[i].DeletionTimestamp == nil {
+			return &pods.Items[i], nil
+		}
+	}
+	if workerGroup != "" {
+		return nil, fmt.Errorf("unable to find a running Pod in worker group %s of RayCluster %s", workerGroup, clusterName)
+	}
+	return nil, fmt.Errorf("unable to find a running head Pod of RayCluster %s", clusterName)
+}
+
 func (c *k8sClient) CreateRayCustomResource(ctx context.Context, namespace string, resourceType util.ResourceType, unstructuredCR *unstructured.Unstructured) (*unstructured.Unstructured, error) {
 	switch resourceType {
 	case util.RayCluster:
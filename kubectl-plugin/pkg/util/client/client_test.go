@@ -60,6 +60,51 @@ func TestGetKubeRayOperatorVersion(t *testing.T) {
 			},
 		},
 	}
+	labelObjects := []runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kuberay-operator-latest",
+				Namespace: "default",
+				Labels: map[string]string{
+					"app.kubernetes.io/name":    "kuberay-operator",
+					"app.kubernetes.io/version": "v1.2.1",
+				},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Image: "localhost:5000/kuberay/operator:latest",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	registryPortObjects := []runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kuberay-operator-registry",
+				Namespace: "default",
+				Labels: map[string]string{
+					"app.kubernetes.io/name": "kuberay-operator",
+				},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Image: "localhost:5000/kuberay/operator",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
 
 	tests := []struct {
 		name            string
@@ -85,6 +130,18 @@ func TestGetKubeRayOperatorVersion(t *testing.T) {
 			expectedError:   "",
 			kubeObjects:     kustomizeObjects,
 		},
+		{
+			name:            "fall back to the version label when the image tag is not a version",
+			expectedVersion: "v1.2.1",
+			expectedError:   "",
+			kubeObjects:     labelObjects,
+		},
+		{
+			name:            "image without tag and version label",
+			expectedVersion: "",
+			expectedError:   "unable to parse KubeRay operator version from image: localhost:5000/kuberay/operator",
+			kubeObjects:     registryPortObjects,
+		},
 	}
 
 	for _, tc := range tests {
@@ -352,3 +409,145 @@ func TestGetRayHeadSvcNameByRayService(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRayClusterName(t *testing.T) {
+	dynamicObjects := []runtime.Object{
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "ray.io/v1",
+				"kind":       "RayJob",
+				"metadata": map[string]interface{}{
+					"name":      "rayjob-default",
+					"namespace": "default",
+				},
+				"status": map[string]interface{}{
+					"rayClusterName": "rayjob-default-raycluster-xxxxx",
+				},
+			},
+		},
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "ray.io/v1",
+				"kind":       "RayService",
+				"metadata": map[string]interface{}{
+					"name":      "rayservice-default",
+					"namespace": "default",
+				},
+				"status": map[string]interface{}{
+					"activeServiceStatus": map[string]interface{}{
+						"rayClusterName": "rayservice-default-raycluster-xxxxx",
+					},
+				},
+			},
+		},
+	}
+
+	dynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), dynamicObjects...)
+	client := NewClientForTesting(kubeFake.NewSimpleClientset(), dynamicClient)
+
+	tests := []struct {
+		name         string
+		resourceType util.ResourceType
+		resourceName string
+		clusterName  string
+	}{
+		{
+			name:         "RayCluster",
+			resourceType: util.RayCluster,
+			resourceName: "raycluster-default",
+			clusterName:  "raycluster-default",
+		},
+		{
+			name:         "RayJob",
+			resourceType: util.RayJob,
+			resourceName: "rayjob-default",
+			clusterName:  "rayjob-default-raycluster-xxxxx",
+		},
+		{
+			name:         "RayService",
+			resourceType: util.RayService,
+			resourceName: "rayservice-default",
+			clusterName:  "rayservice-default-raycluster-xxxxx",
+		},
+		{
+			name:         "resource not found",
+			resourceType: util.RayJob,
+			resourceName: "rayjob-not-found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			clusterName, err := client.GetRayClusterName(context.Background(), "default", tc.resourceType, tc.resourceName)
+			if tc.clusterName == "" {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.clusterName, clusterName)
+			}
+		})
+	}
+}
+
+func TestGetRayPod(t *testing.T) {
+	newPod := func(name string, labels map[string]string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    labels,
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+	headLabels := map[string]string{"ray.io/cluster": "raycluster-default", "ray.io/node-type": "head", "ray.io/group": "headgroup"}
+	workerLabels := map[string]string{"ray.io/cluster": "raycluster-default", "ray.io/node-type": "worker", "ray.io/group": "gpu-group"}
+
+	kubeClientSet := kubeFake.NewSimpleClientset(
+		newPod("raycluster-default-head-xxxxx", headLabels, corev1.PodRunning),
+		newPod("raycluster-default-gpu-group-worker-aaaaa", workerLabels, corev1.PodPending),
+		newPod("raycluster-default-gpu-group-worker-bbbbb", workerLabels, corev1.PodRunning),
+		newPod("raycluster-default-gpu-group-worker-ccccc", workerLabels, corev1.PodRunning),
+	)
+	client := NewClientForTesting(kubeClientSet, dynamicFake.NewSimpleDynamicClient(runtime.NewScheme()))
+
+	tests := []struct {
+		name        string
+		clusterName string
+		workerGroup string
+		podName     string
+	}{
+		{
+			name:        "head Pod",
+			clusterName: "raycluster-default",
+			podName:     "raycluster-default-head-xxxxx",
+		},
+		{
+			name:        "first running worker Pod",
+			clusterName: "raycluster-default",
+			workerGroup: "gpu-group",
+			podName:     "raycluster-default-gpu-group-worker-bbbbb",
+		},
+		{
+			name:        "worker group not found",
+			clusterName: "raycluster-default",
+			workerGroup: "cpu-group",
+		},
+		{
+			name:        "RayCluster not found",
+			clusterName: "raycluster-not-found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod, err := client.GetRayPod(context.Background(), "default", tc.clusterName, tc.workerGroup)
+			if tc.podName == "" {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.podName, pod.Name)
+			}
+		})
+	}
+}
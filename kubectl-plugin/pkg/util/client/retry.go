@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
+)
+
+// retryBackoff is the backoff between the attempts of a call that failed with a transient error
+var retryBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 200 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+	Cap:      5 * time.Second,
+}
+
+// IsNotProcessedError returns whether the API server rejected the request without processing it, e.g. because of
+// throttling, so that retrying it cannot apply a change twice
+func IsNotProcessedError(err error) bool {
+	return k8serrors.IsTooManyRequests(err) || k8serrors.IsServiceUnavailable(err) || utilnet.IsConnectionRefused(err)
+}
+
+// IsTransientError returns whether the request failed with an error that is likely to go away when it is retried.
+// The request may have been processed, so only idempotent requests must be retried on these errors.
+func IsTransientError(err error) bool {
+	return IsNotProcessedError(err) ||
+		k8serrors.IsServerTimeout(err) ||
+		k8serrors.IsTimeout(err) ||
+		utilnet.IsConnectionReset(err) ||
+		utilnet.IsProbableEOF(err) ||
+		utilnet.IsTimeout(err) ||
+		utilnet.IsHTTP2ConnectionLost(err) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// Retry calls fn until it succeeds, fails with an error that retriable does not accept, the attempts are exhausted
+// or ctx is done. The delay suggested by the API server, e.g. with the Retry-After header of a 429, is respected.
+func Retry(ctx context.Context, retriable func(error) bool, fn func() error) error {
+	backoff := retryBackoff
+	for {
+		err := fn()
+		if err == nil || !retriable(err) || backoff.Steps <= 1 {
+			return err
+		}
+		delay := backoff.Step()
+		if seconds, ok := k8serrors.SuggestsClientDelay(err); ok && time.Duration(seconds)*time.Second > delay {
+			delay = time.Duration(seconds) * time.Second
+		}
+		logging.V(2).Infof("Retrying in %s after error: %v", delay.Round(time.Millisecond), err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// RetryOnConflict calls fn like Retry, and also retries it if it fails with a conflict. fn must read the latest
+// version of the object it updates on every attempt.
+func RetryOnConflict(ctx context.Context, fn func() error) error {
+	return Retry(ctx, func(err error) bool {
+		return k8serrors.IsConflict(err) || IsTransientError(err)
+	}, fn)
+}
+
+// retryValue calls fn like Retry and returns the value of its successful attempt
+func retryValue[T any](ctx context.Context, retriable func(error) bool, fn func() (T, error)) (T, error) {
+	var value T
+	err := Retry(ctx, retriable, func() error {
+		var err error
+		value, err = fn()
+		return err
+	})
+	return value, err
+}
+
+// NewRetryingDynamicClient wraps the dynamic client so that its calls are retried on transient errors. Reads and
+// server-side applies, which are idempotent, are retried on all transient errors, while the other writes are only
+// retried if the API server did not process them. Watches are not retried.
+func NewRetryingDynamicClient(dynamicClient dynamic.Interface) dynamic.Interface {
+	if _, ok := dynamicClient.(*retryingDynamicClient); ok {
+		return dynamicClient
+	}
+	return &retryingDynamicClient{dynamicClient: dynamicClient}
+}
+
+type retryingDynamicClient struct {
+	dynamicClient dynamic.Interface
+}
+
+func (c *retryingDynamicClient) Resource(resource schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	namespaceableResource := c.dynamicClient.Resource(resource)
+	return &retryingNamespaceableResource{
+		retryingResource:      retryingResource{ResourceInterface: namespaceableResource},
+		namespaceableResource: namespaceableResource,
+	}
+}
+
+type retryingNamespaceableResource struct {
+	namespaceableResource dynamic.NamespaceableResourceInterface
+	retryingResource
+}
+
+func (r *retryingNamespaceableResource) Namespace(namespace string) dynamic.ResourceInterface {
+	return &retryingResource{ResourceInterface: r.namespaceableResource.Namespace(namespace)}
+}
+
+// retryingResource retries the calls of the embedded ResourceInterface. Watch is inherited as is.
+type retryingResource struct {
+	dynamic.ResourceInterface
+}
+
+func (r *retryingResource) Create(ctx context.Context, obj *unstructured.Unstructured, options metav1.CreateOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return retryValue(ctx, IsNotProcessedError, func() (*unstructured.Unstructured, error) {
+		return r.ResourceInterface.Create(ctx, obj, options, subresources...)
+	})
+}
+
+func (r *retryingResource) Update(ctx context.Context, obj *unstructured.Unstructured, options metav1.UpdateOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return retryValue(ctx, IsNotProcessedError, func() (*unstructured.Unstructured, error) {
+		return r.ResourceInterface.Update(ctx, obj, options, subresources...)
+	})
+}
+
+func (r *retryingResource) UpdateStatus(ctx context.Context, obj *unstructured.Unstructured, options metav1.UpdateOptions) (*unstructured.Unstructured, error) {
+	return retryValue(ctx, IsNotProcessedError, func() (*unstructured.Unstructured, error) {
+		return r.ResourceInterface.UpdateStatus(ctx, obj, options)
+	})
+}
+
+func (r *retryingResource) Delete(ctx context.Context, name string, options metav1.DeleteOptions, subresources ...string) error {
+	return Retry(ctx, IsNotProcessedError, func() error {
+		return r.ResourceInterface.Delete(ctx, name, options, subresources...)
+	})
+}
+
+func (r *retryingResource) DeleteCollection(ctx context.Context, options metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	return Retry(ctx, IsNotProcessedError, func() error {
+		return r.ResourceInterface.DeleteCollection(ctx, options, listOptions)
+	})
+}
+
+func (r *retryingResource) Get(ctx context.Context, name string, options metav1.GetOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return retryValue(ctx, IsTransientError, func() (*unstructured.Unstructured, error) {
+		return r.ResourceInterface.Get(ctx, name, options, subresources...)
+	})
+}
+
+func (r *retryingResource) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	return retryValue(ctx, IsTransientError, func() (*unstructured.UnstructuredList, error) {
+		return r.ResourceInterface.List(ctx, opts)
+	})
+}
+
+func (r *retryingResource) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, options metav1.PatchOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return retryValue(ctx, IsNotProcessedError, func() (*unstructured.Unstructured, error) {
+		return r.ResourceInterface.Patch(ctx, name, pt, data, options, subresources...)
+	})
+}
+
+func (r *retryingResource) Apply(ctx context.Context, name string, obj *unstructured.Unstructured, options metav1.ApplyOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return retryValue(ctx, IsTransientError, func() (*unstructured.Unstructured, error) {
+		return r.ResourceInterface.Apply(ctx, name, obj, options, subresources...)
+	})
+}
+
+func (r *retryingResource) ApplyStatus(ctx context.Context, name string, obj *unstructured.Unstructured, options metav1.ApplyOptions) (*unstructured.Unstructured, error) {
+	return retryValue(ctx, IsTransientError, func() (*unstructured.Unstructured, error) {
+		return r.ResourceInterface.ApplyStatus(ctx, name, obj, options)
+	})
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+)
+
+func setFastRetryBackoff(t *testing.T) {
+	backoff := retryBackoff
+	retryBackoff.Duration = time.Millisecond
+	t.Cleanup(func() { retryBackoff = backoff })
+}
+
+// failingReactor fails the first failures calls of the verb with err and counts all calls
+func failingReactor(failures int, err error, calls *int) k8stesting.ReactionFunc {
+	return func(_ k8stesting.Action) (bool, runtime.Object, error) {
+		*calls++
+		if *calls <= failures {
+			return true, nil, err
+		}
+		return false, nil, nil
+	}
+}
+
+func TestRetryingDynamicClientGet(t *testing.T) {
+	setFastRetryBackoff(t)
+	fakeDynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), newTestRayJob(nil))
+	calls := 0
+	fakeDynamicClient.PrependReactor("get", "rayjobs", failingReactor(2, k8serrors.NewTooManyRequests("throttled", 0), &calls))
+
+	rayJob, err := NewRetryingDynamicClient(fakeDynamicClient).Resource(util.RayJobGVR).Namespace("default").Get(context.Background(), "rayjob-sample", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "rayjob-sample", rayJob.GetName())
+	assert.Equal(t, 3, calls)
+
+	// The attempts are limited
+	calls = 0
+	fakeDynamicClient.PrependReactor("get", "rayjobs", failingReactor(10, k8serrors.NewServerTimeout(util.RayJobGVR.GroupResource(), "get", 0), &calls))
+	_, err = NewRetryingDynamicClient(fakeDynamicClient).Resource(util.RayJobGVR).Namespace("default").Get(context.Background(), "rayjob-sample", metav1.GetOptions{})
+	assert.True(t, k8serrors.IsServerTimeout(err))
+	assert.Equal(t, retryBackoff.Steps, calls)
+}
+
+func TestRetryingDynamicClientCreate(t *testing.T) {
+	setFastRetryBackoff(t)
+	fakeDynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme())
+	retryingClient := NewRetryingDynamicClient(fakeDynamicClient)
+
+	// A throttled create was not processed and is retried
+	calls := 0
+	fakeDynamicClient.PrependReactor("create", "rayjobs", failingReactor(1, k8serrors.NewTooManyRequests("throttled", 0), &calls))
+	_, err := retryingClient.Resource(util.RayJobGVR).Namespace("default").Create(context.Background(), newTestRayJob(nil), metav1.CreateOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, calls)
+
+	// A create that timed out may have been processed and is not retried
+	calls = 0
+	fakeDynamicClient.PrependReactor("create", "rayjobs", failingReactor(1, k8serrors.NewServerTimeout(util.RayJobGVR.GroupResource(), "create", 0), &calls))
+	rayJob := &unstructured.Unstructured{Object: newTestRayJob(nil).Object}
+	rayJob.SetName("rayjob-other")
+	_, err = retryingClient.Resource(util.RayJobGVR).Namespace("default").Create(context.Background(), rayJob, metav1.CreateOptions{})
+	assert.True(t, k8serrors.IsServerTimeout(err))
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryOnConflict(t *testing.T) {
+	setFastRetryBackoff(t)
+	calls := 0
+	err := RetryOnConflict(context.Background(), func() error {
+		calls++
+		if calls < 3 {
+			return k8serrors.NewConflict(util.RayJobGVR.GroupResource(), "rayjob-sample", errors.New("the object has been modified"))
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, calls)
+
+	// Other errors are returned right away
+	calls = 0
+	err = RetryOnConflict(context.Background(), func() error {
+		calls++
+		return k8serrors.NewNotFound(util.RayJobGVR.GroupResource(), "rayjob-sample")
+	})
+	assert.True(t, k8serrors.IsNotFound(err))
+	assert.Equal(t, 1, calls)
+}
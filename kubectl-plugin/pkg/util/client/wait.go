@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+// UnstructuredConditionFunc returns true when the watched resource reached the desired state.
+type UnstructuredConditionFunc func(obj *unstructured.Unstructured) (bool, error)
+
+// WaitForResource watches a single namespaced resource until condition returns true, the resource is deleted, or ctx is done.
+// The current state of the resource is evaluated first, so a resource that already satisfies the condition returns immediately.
+func WaitForResource(ctx context.Context, dynamicClient dynamic.Interface, gvr schema.GroupVersionResource, namespace string, name string, condition UnstructuredConditionFunc) (*unstructured.Unstructured, error) {
+	listWatch := newSingleResourceListWatch(ctx, dynamicClient, gvr, namespace, name)
+
+	event, err := watchtools.UntilWithSync(ctx, listWatch, &unstructured.Unstructured{}, nil, func(event watch.Event) (bool, error) {
+		obj, ok := event.Object.(*unstructured.Unstructured)
+		if !ok {
+			return false, fmt.Errorf("unexpected object type %T", event.Object)
+		}
+		if obj.GetName() != name {
+			return false, nil
+		}
+		if event.Type == watch.Deleted {
+			return false, fmt.Errorf("%s %s/%s was deleted", gvr.Resource, namespace, name)
+		}
+		return condition(obj)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return event.Object.(*unstructured.Unstructured), nil
+}
+
+// WaitForResourceDeletion watches a single namespaced resource until it no longer exists or ctx is done.
+// It returns immediately if the resource does not exist.
+func WaitForResourceDeletion(ctx context.Context, dynamicClient dynamic.Interface, gvr schema.GroupVersionResource, namespace string, name string) error {
+	listWatch := newSingleResourceListWatch(ctx, dynamicClient, gvr, namespace, name)
+
+	precondition := func(store cache.Store) (bool, error) {
+		_, exists, err := store.GetByKey(namespace + "/" + name)
+		if err != nil {
+			return false, err
+		}
+		return !exists, nil
+	}
+	_, err := watchtools.UntilWithSync(ctx, listWatch, &unstructured.Unstructured{}, precondition, func(event watch.Event) (bool, error) {
+		return event.Type == watch.Deleted, nil
+	})
+	return err
+}
+
+// newSingleResourceListWatch returns a ListWatch that only lists and watches the resource with the given name
+func newSingleResourceListWatch(ctx context.Context, dynamicClient dynamic.Interface, gvr schema.GroupVersionResource, namespace string, name string) *cache.ListWatch {
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", name).String()
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return dynamicClient.Resource(gvr).Namespace(namespace).Watch(ctx, options)
+		},
+	}
+}
+
+// IsRayClusterReady reports whether the RayCluster has a true `Ready` condition or is in the `ready` state
+func IsRayClusterReady(rayCluster *unstructured.Unstructured) (bool, error) {
+	rayClusterConditions, _, err := unstructured.NestedSlice(rayCluster.Object, "status", "conditions")
+	if err != nil {
+		return false, err
+	}
+	for _, rayClusterCondition := range rayClusterConditions {
+		condition, ok := rayClusterCondition.(map[string]interface{})
+		if ok && condition["type"] == "Ready" && condition["status"] == string(metav1.ConditionTrue) {
+			return true, nil
+		}
+	}
+
+	rayClusterState, _, err := unstructured.NestedString(rayCluster.Object, "status", "state")
+	if err != nil {
+		return false, err
+	}
+	return rayClusterState == "ready", nil
+}
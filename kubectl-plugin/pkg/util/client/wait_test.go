@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+)
+
+func newTestRayJob(status map[string]interface{}) *unstructured.Unstructured {
+	rayJob := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "ray.io/v1",
+			"kind":       "RayJob",
+			"metadata": map[string]interface{}{
+				"name":      "rayjob-sample",
+				"namespace": "default",
+			},
+		},
+	}
+	if status != nil {
+		rayJob.Object["status"] = status
+	}
+	return rayJob
+}
+
+func hasClusterName(obj *unstructured.Unstructured) (bool, error) {
+	clusterName, _, err := unstructured.NestedString(obj.Object, "status", "rayClusterName")
+	return clusterName != "", err
+}
+
+func TestWaitForResource(t *testing.T) {
+	t.Run("condition already satisfied", func(t *testing.T) {
+		dynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), newTestRayJob(map[string]interface{}{"rayClusterName": "raycluster-sample"}))
+
+		obj, err := WaitForResource(context.Background(), dynamicClient, util.RayJobGVR, "default", "rayjob-sample", hasClusterName)
+		assert.NoError(t, err)
+		assert.Equal(t, "rayjob-sample", obj.GetName())
+	})
+
+	t.Run("condition satisfied after update", func(t *testing.T) {
+		dynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), newTestRayJob(nil))
+
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			_, err := dynamicClient.Resource(util.RayJobGVR).Namespace("default").Update(context.Background(), newTestRayJob(map[string]interface{}{"rayClusterName": "raycluster-sample"}), metav1.UpdateOptions{})
+			assert.NoError(t, err)
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		obj, err := WaitForResource(ctx, dynamicClient, util.RayJobGVR, "default", "rayjob-sample", hasClusterName)
+		assert.NoError(t, err)
+		clusterName, _, _ := unstructured.NestedString(obj.Object, "status", "rayClusterName")
+		assert.Equal(t, "raycluster-sample", clusterName)
+	})
+
+	t.Run("context deadline exceeded", func(t *testing.T) {
+		dynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), newTestRayJob(nil))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		_, err := WaitForResource(ctx, dynamicClient, util.RayJobGVR, "default", "rayjob-sample", hasClusterName)
+		assert.Error(t, err)
+	})
+}
+
+func TestWaitForResourceDeletion(t *testing.T) {
+	t.Run("resource does not exist", func(t *testing.T) {
+		dynamicClient := dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{util.RayJobGVR: "RayJobList"})
+
+		err := WaitForResourceDeletion(context.Background(), dynamicClient, util.RayJobGVR, "default", "rayjob-sample")
+		assert.NoError(t, err)
+	})
+
+	t.Run("resource deleted", func(t *testing.T) {
+		dynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), newTestRayJob(nil))
+
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			err := dynamicClient.Resource(util.RayJobGVR).Namespace("default").Delete(context.Background(), "rayjob-sample", metav1.DeleteOptions{})
+			assert.NoError(t, err)
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err := WaitForResourceDeletion(ctx, dynamicClient, util.RayJobGVR, "default", "rayjob-sample")
+		assert.NoError(t, err)
+	})
+
+	t.Run("context deadline exceeded", func(t *testing.T) {
+		dynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), newTestRayJob(nil))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		err := WaitForResourceDeletion(ctx, dynamicClient, util.RayJobGVR, "default", "rayjob-sample")
+		assert.Error(t, err)
+	})
+}
+
+func TestIsRayClusterReady(t *testing.T) {
+	tests := []struct {
+		status   map[string]interface{}
+		name     string
+		expected bool
+	}{
+		{
+			name:     "no status",
+			expected: false,
+		},
+		{
+			name:     "ready state",
+			status:   map[string]interface{}{"state": "ready"},
+			expected: true,
+		},
+		{
+			name: "ready condition",
+			status: map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Ready", "status": "True"},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "not ready",
+			status: map[string]interface{}{
+				"state": "",
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Ready", "status": "False"},
+				},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rayCluster := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			if tc.status != nil {
+				rayCluster.Object["status"] = tc.status
+			}
+			isReady, err := IsRayClusterReady(rayCluster)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, isReady)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+// Key is a setting of the plugin configuration file
+type Key string
+
+const (
+	Namespace     Key = "namespace"
+	Image         Key = "image"
+	DashboardPort Key = "dashboardPort"
+	LogStyle      Key = "logStyle"
+	Timeout       Key = "timeout"
+)
+
+// ConfigPathEnv overrides the location of the configuration file
+const ConfigPathEnv = "KUBERAY_CONFIG"
+
+// flagAnnotation marks the flags whose default is read from the environment and the configuration file
+const flagAnnotation = "kubectl-ray/config-key"
+
+// envVars are the environment variables of the settings, which take precedence over the configuration file
+var envVars = map[Key]string{
+	Namespace:     "KUBERAY_NAMESPACE",
+	Image:         "KUBERAY_IMAGE",
+	DashboardPort: "KUBERAY_DASHBOARD_PORT",
+	LogStyle:      "KUBERAY_LOG_STYLE",
+	Timeout:       "KUBERAY_TIMEOUT",
+}
+
+// Config holds the user defaults of ~/.kuberay/config.yaml
+type Config struct {
+	Namespace     string `json:"namespace,omitempty"`
+	Image         string `json:"image,omitempty"`
+	LogStyle      string `json:"logStyle,omitempty"`
+	Timeout       string `json:"timeout,omitempty"`
+	DashboardPort int    `json:"dashboardPort,omitempty"`
+}
+
+// DefaultPath returns the path of the configuration file, which is ~/.kuberay/config.yaml unless overridden
+// with the KUBERAY_CONFIG environment variable
+func DefaultPath() (string, error) {
+	if path := os.Getenv(ConfigPathEnv); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the home directory: %w", err)
+	}
+	return filepath.Join(home, ".kuberay", "config.yaml"), nil
+}
+
+// Load reads the configuration file. A missing file results in an empty configuration.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+func (c *Config) value(key Key) string {
+	switch key {
+	case Namespace:
+		return c.Namespace
+	case Image:
+		return c.Image
+	case DashboardPort:
+		if c.DashboardPort != 0 {
+			return strconv.Itoa(c.DashboardPort)
+		}
+	case LogStyle:
+		return c.LogStyle
+	case Timeout:
+		return c.Timeout
+	}
+	return ""
+}
+
+// BindFlag makes the flag default to the setting from the environment or the configuration file
+func BindFlag(flags *pflag.FlagSet, name string, key Key) {
+	if err := flags.SetAnnotation(name, flagAnnotation, []string{string(key)}); err != nil {
+		panic(err)
+	}
+}
+
+// ApplyDefaults sets the bound flags of the command that were not given on the command line, with
+// flag > environment > configuration file precedence. The namespace flag is always bound.
+func ApplyDefaults(cmd *cobra.Command) error {
+	path, err := DefaultPath()
+	if err != nil {
+		return err
+	}
+	config, err := Load(path)
+	if err != nil {
+		return err
+	}
+	return config.applyDefaults(cmd.Flags(), path)
+}
+
+func (c *Config) applyDefaults(flags *pflag.FlagSet, path string) error {
+	var errs []error
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Changed {
+			return
+		}
+		key := Key(flag.Name)
+		if keys := flag.Annotations[flagAnnotation]; len(keys) == 1 {
+			key = Key(keys[0])
+		} else if key != Namespace {
+			return
+		}
+
+		value, source := os.Getenv(envVars[key]), envVars[key]
+		if value == "" {
+			value, source = c.value(key), path
+		}
+		if value == "" {
+			return
+		}
+		if err := flag.Value.Set(value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value %q for --%s from %s: %w", value, flag.Name, source, err))
+		}
+	})
+	return errors.Join(errs...)
+}
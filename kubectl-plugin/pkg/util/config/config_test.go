@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	configDir := t.TempDir()
+
+	config, err := Load(filepath.Join(configDir, "missing.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, &Config{}, config)
+
+	configPath := filepath.Join(configDir, "config.yaml")
+	assert.Nil(t, os.WriteFile(configPath, []byte("namespace: ray\nimage: rayproject/ray:2.41.0\ndashboardPort: 18265\nlogStyle: record\ntimeout: 10m\n"), 0o600))
+	config, err = Load(configPath)
+	assert.Nil(t, err)
+	assert.Equal(t, &Config{
+		Namespace:     "ray",
+		Image:         "rayproject/ray:2.41.0",
+		DashboardPort: 18265,
+		LogStyle:      "record",
+		Timeout:       "10m",
+	}, config)
+
+	assert.Nil(t, os.WriteFile(configPath, []byte("namespaces: ray\n"), 0o600))
+	_, err = Load(configPath)
+	assert.ErrorContains(t, err, "failed to parse config file")
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv(ConfigPathEnv, "/tmp/kuberay.yaml")
+	path, err := DefaultPath()
+	assert.Nil(t, err)
+	assert.Equal(t, "/tmp/kuberay.yaml", path)
+}
+
+func TestApplyDefaults(t *testing.T) {
+	config := &Config{
+		Namespace:     "config-namespace",
+		Image:         "config-image",
+		DashboardPort: 18265,
+		Timeout:       "10m",
+	}
+
+	var namespace, image, unbound string
+	var dashboardPort int
+	var timeout time.Duration
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringVar(&namespace, "namespace", "", "")
+	flags.StringVar(&image, "image", "", "")
+	flags.StringVar(&unbound, "log-style", "", "")
+	flags.IntVar(&dashboardPort, "dashboard-port", 0, "")
+	flags.DurationVar(&timeout, "timeout", time.Minute, "")
+	BindFlag(flags, "image", Image)
+	BindFlag(flags, "dashboard-port", DashboardPort)
+	BindFlag(flags, "timeout", Timeout)
+
+	// The flag takes precedence over the environment, which takes precedence over the configuration file
+	assert.Nil(t, flags.Parse([]string{"--image", "flag-image"}))
+	t.Setenv("KUBERAY_IMAGE", "env-image")
+	t.Setenv("KUBERAY_DASHBOARD_PORT", "28265")
+	t.Setenv("KUBERAY_LOG_STYLE", "pretty")
+
+	err := config.applyDefaults(flags, "config.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, "config-namespace", namespace)
+	assert.Equal(t, "flag-image", image)
+	assert.Equal(t, 28265, dashboardPort)
+	assert.Equal(t, 10*time.Minute, timeout)
+	// Flags which are not bound keep their defaults
+	assert.Equal(t, "", unbound)
+	// Applied defaults are not reported as given on the command line
+	assert.False(t, flags.Changed("timeout"))
+
+	config.Timeout = "ten minutes"
+	err = config.applyDefaults(flags, "config.yaml")
+	assert.EqualError(t, err, "invalid value \"ten minutes\" for --timeout from config.yaml: time: invalid duration \"ten minutes\"")
+}
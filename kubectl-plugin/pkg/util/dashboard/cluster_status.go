@@ -0,0 +1,33 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// clusterStatusPath is the Ray dashboard API that returns the autoscaler view of the Ray cluster, as `ray status` does
+const clusterStatusPath = "/api/cluster_status"
+
+// GetClusterStatus returns the raw JSON cluster status of the Ray dashboard at the given address, e.g. "http://localhost:8265"
+func GetClusterStatus(ctx context.Context, address string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address+clusterStatusPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Ray cluster status: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Ray cluster status response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to get Ray cluster status, status code: %d, message: %s", resp.StatusCode, string(body))
+	}
+	return body, nil
+}
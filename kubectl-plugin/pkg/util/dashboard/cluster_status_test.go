@@ -0,0 +1,29 @@
+package dashboard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetClusterStatus(t *testing.T) {
+	response := `{"result": true, "msg": "Got cluster status.", "data": {"autoscalingStatus": null}}`
+	statusCode := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/cluster_status", r.URL.Path)
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	clusterStatus, err := GetClusterStatus(context.Background(), server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, response, string(clusterStatus))
+
+	statusCode = http.StatusInternalServerError
+	_, err = GetClusterStatus(context.Background(), server.URL)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,141 @@
+package dashboard
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// ConnectionOptions configures how the Ray dashboard is reached through the port-forward, e.g. when the dashboard
+// serves TLS or is behind an authenticating proxy. The zero value, like a nil *ConnectionOptions, uses plain HTTP.
+type ConnectionOptions struct {
+	CACert                string
+	ClientCert            string
+	ClientKey             string
+	ServerName            string
+	Token                 string
+	TLS                   bool
+	InsecureSkipTLSVerify bool
+}
+
+// AddFlags adds the flags of the dashboard connection to the flag set
+func (o *ConnectionOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(&o.TLS, "dashboard-tls", o.TLS, "If present, connect to the Ray dashboard over HTTPS. Implied by the other --dashboard TLS flags")
+	flags.StringVar(&o.CACert, "dashboard-ca-cert", o.CACert, "Path to a CA certificate file used to verify the certificate of the Ray dashboard")
+	flags.StringVar(&o.ClientCert, "dashboard-client-cert", o.ClientCert, "Path to a client certificate file for mutual TLS with the Ray dashboard")
+	flags.StringVar(&o.ClientKey, "dashboard-client-key", o.ClientKey, "Path to the key file of --dashboard-client-cert")
+	flags.StringVar(&o.ServerName, "dashboard-tls-server-name", o.ServerName, "Server name used to verify the certificate of the Ray dashboard, which is reached through localhost")
+	flags.BoolVar(&o.InsecureSkipTLSVerify, "dashboard-insecure-skip-tls-verify", o.InsecureSkipTLSVerify, "If present, the certificate of the Ray dashboard is not verified")
+	flags.StringVar(&o.Token, "dashboard-token", o.Token, "Bearer token sent in the Authorization header to the Ray dashboard, e.g. for an authenticating proxy")
+}
+
+// UseTLS reports whether the Ray dashboard is reached over HTTPS
+func (o *ConnectionOptions) UseTLS() bool {
+	return o != nil && (o.TLS || o.CACert != "" || o.ClientCert != "" || o.ServerName != "" || o.InsecureSkipTLSVerify)
+}
+
+// AuthorizationHeader returns the Authorization header carrying the bearer token, or "" if no token is set
+func (o *ConnectionOptions) AuthorizationHeader() string {
+	if o == nil || o.Token == "" {
+		return ""
+	}
+	return "Bearer " + o.Token
+}
+
+// Validate checks that the flags are consistent and that the certificate files can be loaded
+func (o *ConnectionOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if (o.ClientCert == "") != (o.ClientKey == "") {
+		return fmt.Errorf("--dashboard-client-cert and --dashboard-client-key must be given together")
+	}
+	if o.InsecureSkipTLSVerify && o.CACert != "" {
+		return fmt.Errorf("--dashboard-insecure-skip-tls-verify cannot be used together with --dashboard-ca-cert")
+	}
+	_, err := o.tlsConfig()
+	return err
+}
+
+// Address returns the address of the Ray dashboard forwarded to the given local port
+func (o *ConnectionOptions) Address(localPort int) string {
+	if o.UseTLS() {
+		return fmt.Sprintf("https://localhost:%d", localPort)
+	}
+	return Address(localPort)
+}
+
+// ServiceAddress returns the address of the Ray dashboard of the given Ray head service within the Kubernetes cluster
+func (o *ConnectionOptions) ServiceAddress(svcName, namespace string) string {
+	scheme := "http"
+	if o.UseTLS() {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s.%s.svc:%d", scheme, svcName, namespace, Port)
+}
+
+// HTTPClient returns an HTTP client for the Ray dashboard that uses the TLS configuration and sends the bearer token
+func (o *ConnectionOptions) HTTPClient(timeout time.Duration) (*http.Client, error) {
+	tlsConfig, err := o.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	var roundTripper http.RoundTripper = transport
+	if authorization := o.AuthorizationHeader(); authorization != "" {
+		roundTripper = &authorizationRoundTripper{next: transport, authorization: authorization}
+	}
+	return &http.Client{Transport: roundTripper, Timeout: timeout}, nil
+}
+
+// tlsConfig returns the TLS configuration, or nil if the Ray dashboard is reached over plain HTTP
+func (o *ConnectionOptions) tlsConfig() (*tls.Config, error) {
+	if !o.UseTLS() {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         o.ServerName,
+		InsecureSkipVerify: o.InsecureSkipTLSVerify, //nolint:gosec // only set when requested with --dashboard-insecure-skip-tls-verify
+	}
+	if o.CACert != "" {
+		caCert, err := os.ReadFile(o.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read --dashboard-ca-cert: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("--dashboard-ca-cert %s does not contain a PEM encoded certificate", o.CACert)
+		}
+	}
+	if o.ClientCert != "" {
+		clientCert, err := tls.LoadX509KeyPair(o.ClientCert, o.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load --dashboard-client-cert and --dashboard-client-key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+	return tlsConfig, nil
+}
+
+// authorizationRoundTripper adds the Authorization header to the requests that do not carry one
+type authorizationRoundTripper struct {
+	next          http.RoundTripper
+	authorization string
+}
+
+func (rt *authorizationRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Authorization") != "" {
+		return rt.next.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", rt.authorization)
+	return rt.next.RoundTrip(req)
+}
@@ -0,0 +1,103 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestConnectionOptionsAddress(t *testing.T) {
+	var conn *ConnectionOptions
+	assert.Equal(t, "http://localhost:8265", conn.Address(8265))
+	assert.Equal(t, "http://localhost:8265", (&ConnectionOptions{Token: "fake-token"}).Address(8265))
+	assert.Equal(t, "https://localhost:8265", (&ConnectionOptions{TLS: true}).Address(8265))
+	// The TLS flags imply --dashboard-tls
+	assert.Equal(t, "https://localhost:8265", (&ConnectionOptions{CACert: "/fake/ca.crt"}).Address(8265))
+}
+
+func TestConnectionOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		conn        ConnectionOptions
+		expectedErr string
+	}{
+		{
+			name: "plain HTTP",
+		},
+		{
+			name:        "client certificate without key",
+			conn:        ConnectionOptions{ClientCert: "/fake/tls.crt"},
+			expectedErr: "--dashboard-client-cert and --dashboard-client-key must be given together",
+		},
+		{
+			name:        "insecure with CA certificate",
+			conn:        ConnectionOptions{CACert: "/fake/ca.crt", InsecureSkipTLSVerify: true},
+			expectedErr: "--dashboard-insecure-skip-tls-verify cannot be used together with --dashboard-ca-cert",
+		},
+		{
+			name:        "missing CA certificate",
+			conn:        ConnectionOptions{CACert: "/fake/ca.crt"},
+			expectedErr: "failed to read --dashboard-ca-cert",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.conn.Validate()
+			if tc.expectedErr == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestJobClientWithTLSAndToken(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fake-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, utils.JobPath+"raysubmit_123", r.URL.Path)
+		assert.Nil(t, json.NewEncoder(w).Encode(utils.RayJobInfo{JobStatus: "RUNNING"}))
+	}))
+	defer server.Close()
+	port, err := strconv.Atoi(server.URL[strings.LastIndex(server.URL, ":")+1:])
+	assert.Nil(t, err)
+
+	caCert := filepath.Join(t.TempDir(), "ca.crt")
+	assert.Nil(t, os.WriteFile(caCert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+	// The certificate of the test server is issued for example.com, not localhost
+	conn := &ConnectionOptions{CACert: caCert, ServerName: "example.com", Token: "fake-token"}
+	assert.Nil(t, conn.Validate())
+
+	jobClient, err := NewJobClient(port, conn)
+	assert.Nil(t, err)
+	jobInfo, err := jobClient.GetJobInfo(context.Background(), "raysubmit_123")
+	assert.Nil(t, err)
+	assert.Equal(t, "RUNNING", string(jobInfo.JobStatus))
+
+	conn.Token = ""
+	jobClient, err = NewJobClient(port, conn)
+	assert.Nil(t, err)
+	_, err = jobClient.GetJobInfo(context.Background(), "raysubmit_123")
+	assert.ErrorContains(t, err, "the Ray dashboard rejected the request with status 401")
+
+	// The certificate is not trusted without the CA certificate
+	jobClient, err = NewJobClient(port, &ConnectionOptions{TLS: true, Token: "fake-token"})
+	assert.Nil(t, err)
+	_, err = jobClient.GetJobInfo(context.Background(), "raysubmit_123")
+	assert.ErrorContains(t, err, "certificate")
+}
@@ -0,0 +1,81 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/portforward"
+)
+
+const (
+	// Port is the port the Ray dashboard listens on in the Ray head Pod
+	Port = 8265
+
+	probeInterval = 1 * time.Second
+	probeTimeout  = 5 * time.Second
+)
+
+// Address returns the address of the Ray dashboard forwarded to the given local port
+func Address(localPort int) string {
+	return fmt.Sprintf("http://localhost:%d", localPort)
+}
+
+// PortForward forwards the local port to the Ray dashboard of the given Ray head service in the background,
+// and blocks until the dashboard responds or readyTimeout expires. Dropped connections are re-established
+// until ctx is done, which stops port forwarding. The dashboard is probed with the connection options, which may be nil.
+func PortForward(ctx context.Context, factory cmdutil.Factory, streams genericiooptions.IOStreams, svcName string, localPort int, readyTimeout time.Duration, conn *ConnectionOptions) error {
+	httpClient, err := conn.HTTPClient(probeTimeout)
+	if err != nil {
+		return err
+	}
+	address := conn.Address(localPort)
+	target := func(_ context.Context) (string, error) {
+		return "service/" + svcName, nil
+	}
+	forwarder := portforward.NewReconnectingPortForwarder(factory, streams, target, []string{fmt.Sprintf("%d:%d", localPort, Port)})
+
+	portForwardErr := make(chan error, 1)
+	go func() {
+		err := forwarder.Run(ctx)
+		if err != nil {
+			logging.New(streams.ErrOut).Warnf("port-forwarding the Ray dashboard failed: %v", err)
+		}
+		portForwardErr <- err
+	}()
+
+	readyCtx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+	err = wait.PollUntilContextCancel(readyCtx, probeInterval, true, func(ctx context.Context) (bool, error) {
+		select {
+		case err := <-portForwardErr:
+			if err == nil {
+				err = fmt.Errorf("port forwarding exited")
+			}
+			return false, fmt.Errorf("port forwarding stopped before the Ray dashboard was reachable: %w", err)
+		default:
+		}
+
+		probeRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+		if err != nil {
+			return false, err
+		}
+		resp, err := httpClient.Do(probeRequest)
+		if err != nil {
+			// Port forwarding is likely not established yet
+			return false, nil
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode >= 200 && resp.StatusCode < 300, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed waiting for the Ray dashboard at %s: %w", address, err)
+	}
+	return nil
+}
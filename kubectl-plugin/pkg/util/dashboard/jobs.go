@@ -0,0 +1,126 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
+)
+
+const jobClientTimeout = 10 * time.Second
+
+// JobClient is the part of the Ray Jobs API of the Ray dashboard used by the job commands
+type JobClient interface {
+	GetJobInfo(ctx context.Context, jobId string) (*JobInfo, error)
+	GetJobLog(ctx context.Context, jobName string) (*string, error)
+	StopJob(ctx context.Context, jobName string) error
+}
+
+// JobInfo is the status of a Ray job. It extends the RayJobInfo of the KubeRay operator with the exit code of the
+// driver, which the Ray dashboard reports since Ray 2.9.
+type JobInfo struct {
+	DriverExitCode *int `json:"driver_exit_code,omitempty"`
+	utils.RayJobInfo
+}
+
+// jobClient implements JobClient like the dashboard client of the KubeRay operator, which does not support TLS or
+// bearer tokens
+type jobClient struct {
+	httpClient *http.Client
+	address    string
+}
+
+// NewJobClient returns a client of the Ray Jobs API of the Ray dashboard forwarded to the given local port
+func NewJobClient(localPort int, conn *ConnectionOptions) (JobClient, error) {
+	httpClient, err := conn.HTTPClient(jobClientTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &jobClient{httpClient: httpClient, address: conn.Address(localPort)}, nil
+}
+
+func (c *jobClient) GetJobInfo(ctx context.Context, jobId string) (*JobInfo, error) {
+	body, statusCode, err := c.do(ctx, http.MethodGet, utils.JobPath+jobId)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("Job %s does not exist on the cluster", jobId)
+	}
+
+	var jobInfo JobInfo
+	if err := json.Unmarshal(body, &jobInfo); err != nil {
+		return nil, fmt.Errorf("GetJobInfo fail: %s", string(body))
+	}
+	return &jobInfo, nil
+}
+
+func (c *jobClient) GetJobLog(ctx context.Context, jobName string) (*string, error) {
+	body, statusCode, err := c.do(ctx, http.MethodGet, utils.JobPath+jobName+"/logs")
+	if err != nil {
+		return nil, err
+	}
+	if statusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	var jobLog utils.RayJobLogsResponse
+	if err := json.Unmarshal(body, &jobLog); err != nil {
+		return nil, fmt.Errorf("GetJobLog fail: %s", string(body))
+	}
+	return &jobLog.Logs, nil
+}
+
+func (c *jobClient) StopJob(ctx context.Context, jobName string) error {
+	body, _, err := c.do(ctx, http.MethodPost, utils.JobPath+jobName+"/stop")
+	if err != nil {
+		return err
+	}
+
+	var jobStopResp utils.RayJobStopResponse
+	if err := json.Unmarshal(body, &jobStopResp); err != nil {
+		return err
+	}
+	if !jobStopResp.Stopped {
+		jobInfo, err := c.GetJobInfo(ctx, jobName)
+		if err != nil {
+			return err
+		}
+		// Stopping a Ray job that already reached a terminal status is not an error
+		if !rayv1api.IsJobTerminal(jobInfo.JobStatus) {
+			return fmt.Errorf("Failed to stopped job: %v", jobInfo)
+		}
+	}
+	return nil
+}
+
+// do sends a request to the Ray dashboard and returns the response body and status code
+func (c *jobClient) do(ctx context.Context, method string, path string) ([]byte, int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.address+path, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	logging.V(3).InfoS("Ray dashboard request", "method", method, "url", req.URL.String(), "status", resp.StatusCode)
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, resp.StatusCode, fmt.Errorf("the Ray dashboard rejected the request with status %d, check --dashboard-token and the client certificate", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+	return body, resp.StatusCode, nil
+}
@@ -0,0 +1,67 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// nodesPath is the Ray dashboard API that returns a summary of the Ray nodes
+const nodesPath = "/nodes?view=summary"
+
+// Node is a Ray logical node as reported by the Ray dashboard
+type Node struct {
+	Raylet   Raylet `json:"raylet"`
+	Hostname string `json:"hostname"`
+	IP       string `json:"ip"`
+}
+
+// Raylet is the raylet information of a Ray node
+type Raylet struct {
+	ResourcesTotal     map[string]float64 `json:"resourcesTotal"`
+	ResourcesAvailable map[string]float64 `json:"resourcesAvailable"`
+	NodeID             string             `json:"nodeId"`
+	State              string             `json:"state"`
+	NodeManagerAddress string             `json:"nodeManagerAddress"`
+	IsHeadNode         bool               `json:"isHeadNode"`
+}
+
+type nodesResponse struct {
+	Msg  string `json:"msg"`
+	Data struct {
+		Summary []Node `json:"summary"`
+	} `json:"data"`
+	Result bool `json:"result"`
+}
+
+// ListNodes returns the Ray nodes of the Ray dashboard at the given address, e.g. "http://localhost:8265"
+func ListNodes(ctx context.Context, address string) ([]Node, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address+nodesPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Ray nodes: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Ray nodes response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to list Ray nodes, status code: %d, message: %s", resp.StatusCode, string(body))
+	}
+
+	var nodes nodesResponse
+	if err := json.Unmarshal(body, &nodes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Ray nodes response: %w", err)
+	}
+	if !nodes.Result {
+		return nil, fmt.Errorf("failed to list Ray nodes: %s", nodes.Msg)
+	}
+	return nodes.Data.Summary, nil
+}
@@ -0,0 +1,74 @@
+package dashboard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListNodes(t *testing.T) {
+	tests := []struct {
+		name          string
+		response      string
+		expectedNodes []Node
+		statusCode    int
+		hasErr        bool
+	}{
+		{
+			name:       "nodes",
+			statusCode: http.StatusOK,
+			response: `{"result": true, "msg": "Node summary fetched.", "data": {"summary": [
+				{"hostname": "raycluster-head-xxxxx", "ip": "10.0.0.1", "raylet": {"nodeId": "abc", "state": "ALIVE", "nodeManagerAddress": "10.0.0.1", "isHeadNode": true, "resourcesTotal": {"CPU": 2}, "resourcesAvailable": {"CPU": 1.5}}}
+			]}}`,
+			expectedNodes: []Node{
+				{
+					Hostname: "raycluster-head-xxxxx",
+					IP:       "10.0.0.1",
+					Raylet: Raylet{
+						NodeID:             "abc",
+						State:              "ALIVE",
+						NodeManagerAddress: "10.0.0.1",
+						IsHeadNode:         true,
+						ResourcesTotal:     map[string]float64{"CPU": 2},
+						ResourcesAvailable: map[string]float64{"CPU": 1.5},
+					},
+				},
+			},
+		},
+		{
+			name:       "dashboard error",
+			statusCode: http.StatusOK,
+			response:   `{"result": false, "msg": "failed"}`,
+			hasErr:     true,
+		},
+		{
+			name:       "unexpected status code",
+			statusCode: http.StatusInternalServerError,
+			response:   "internal error",
+			hasErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/nodes", r.URL.Path)
+				assert.Equal(t, "summary", r.URL.Query().Get("view"))
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			nodes, err := ListNodes(context.Background(), server.URL)
+			if tc.hasErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedNodes, nodes)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, 0, ExitCode(nil))
+	assert.Equal(t, 1, ExitCode(errors.New("failed")))
+
+	exitErr := &ExitError{Err: errors.New("job failed"), Code: 2}
+	assert.Equal(t, 2, ExitCode(exitErr))
+	assert.Equal(t, 2, ExitCode(fmt.Errorf("wrapped: %w", exitErr)))
+	assert.Equal(t, "job failed", exitErr.Error())
+}
@@ -0,0 +1,341 @@
+package generation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	rayv1ac "github.com/ray-project/kuberay/ray-operator/pkg/client/applyconfiguration/ray/v1"
+)
+
+const (
+	DefaultRayVersion = "2.37.0"
+	defaultImageRepo  = "rayproject/ray"
+	defaultGroupName  = "default-group"
+	// resourceNvidiaGPU is the resource name used by the NVIDIA device plugin
+	resourceNvidiaGPU corev1.ResourceName = "nvidia.com/gpu"
+)
+
+// RayClusterSpecObject holds the user facing knobs used to generate a RayClusterSpec
+type RayClusterSpecObject struct {
+	RayVersion    string
+	Image         string
+	HeadCPU       string
+	HeadMemory    string
+	WorkerGrpName string
+	WorkerCPU     string
+	WorkerMemory  string
+	WorkerGPU     string
+	// WorkerGroups replaces the single worker group described by the Worker* fields if set
+	WorkerGroups   []WorkerGroup
+	WorkerReplicas int32
+	// Autoscaler enables the in-tree autoscaler, which scales the worker groups between their min and max replicas
+	Autoscaler bool
+}
+
+// WorkerGroup holds the user facing knobs of a worker group. MinReplicas and MaxReplicas are only set if not nil.
+type WorkerGroup struct {
+	MinReplicas  *int32
+	MaxReplicas  *int32
+	NodeSelector map[string]string
+	Name         string
+	CPU          string
+	Memory       string
+	GPU          string
+	Tolerations  []corev1.Toleration
+	Replicas     int32
+}
+
+// RayClusterYamlObject holds the fields needed to generate a RayCluster CR
+type RayClusterYamlObject struct {
+	ClusterName string
+	Namespace   string
+	RayClusterSpecObject
+}
+
+// RayJobYamlObject holds the fields needed to generate a RayJob CR
+type RayJobYamlObject struct {
+	RayJobName     string
+	Namespace      string
+	SubmissionMode rayv1.JobSubmissionMode
+	RayClusterSpecObject
+}
+
+// GenerateRayJobApplyConfig generates the apply configuration of a RayJob using its RayClusterSpecObject
+func (rayJobObject *RayJobYamlObject) GenerateRayJobApplyConfig() *rayv1ac.RayJobApplyConfiguration {
+	rayJobApplyConfig := rayv1ac.RayJob(rayJobObject.RayJobName, rayJobObject.Namespace).
+		WithSpec(rayv1ac.RayJobSpec().
+			WithSubmissionMode(rayJobObject.SubmissionMode).
+			WithRayClusterSpec(rayJobObject.generateRayClusterSpec()))
+
+	return rayJobApplyConfig
+}
+
+// GenerateRayClusterApplyConfig generates the apply configuration of a RayCluster using its RayClusterSpecObject
+func (rayClusterObject *RayClusterYamlObject) GenerateRayClusterApplyConfig() *rayv1ac.RayClusterApplyConfiguration {
+	return rayv1ac.RayCluster(rayClusterObject.ClusterName, rayClusterObject.Namespace).
+		WithSpec(rayClusterObject.generateRayClusterSpec())
+}
+
+// ParseWorkerGroup parses the worker group shorthand NAME=VALUE[,NAME=VALUE...], e.g. "name=gpu,replicas=4,gpu=1".
+// The keys are name, replicas, min-replicas, max-replicas, cpu, memory and gpu. Missing keys are taken from defaults.
+func ParseWorkerGroup(shorthand string, defaults WorkerGroup) (WorkerGroup, error) {
+	workerGroup := defaults
+	for _, field := range strings.Split(shorthand, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(field), "=")
+		if !found || value == "" {
+			return WorkerGroup{}, fmt.Errorf("invalid worker group %q: expected KEY=VALUE, got %q", shorthand, field)
+		}
+		switch key {
+		case "name":
+			workerGroup.Name = value
+		case "cpu":
+			workerGroup.CPU = value
+		case "memory":
+			workerGroup.Memory = value
+		case "gpu":
+			workerGroup.GPU = value
+		case "replicas", "min-replicas", "max-replicas":
+			replicas, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return WorkerGroup{}, fmt.Errorf("invalid worker group %q: %s must be an integer, got %q", shorthand, key, value)
+			}
+			replicas32 := int32(replicas)
+			switch key {
+			case "replicas":
+				workerGroup.Replicas = replicas32
+			case "min-replicas":
+				workerGroup.MinReplicas = &replicas32
+			case "max-replicas":
+				workerGroup.MaxReplicas = &replicas32
+			}
+		default:
+			return WorkerGroup{}, fmt.Errorf("invalid worker group %q: unknown key %q, must be one of name, replicas, min-replicas, max-replicas, cpu, memory, gpu", shorthand, key)
+		}
+	}
+	if workerGroup.Name == "" {
+		return WorkerGroup{}, fmt.Errorf("invalid worker group %q: name is required", shorthand)
+	}
+	return workerGroup, nil
+}
+
+// Validate checks that the fields of the RayClusterSpecObject can be turned into a valid spec
+func (rayClusterSpecObject *RayClusterSpecObject) Validate() error {
+	quantities := map[string]string{
+		"head CPU":      rayClusterSpecObject.HeadCPU,
+		"head memory":   rayClusterSpecObject.HeadMemory,
+		"worker CPU":    rayClusterSpecObject.WorkerCPU,
+		"worker memory": rayClusterSpecObject.WorkerMemory,
+		"worker GPU":    rayClusterSpecObject.WorkerGPU,
+	}
+	for name, quantity := range quantities {
+		if quantity == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(quantity); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", name, quantity, err)
+		}
+	}
+	if rayClusterSpecObject.WorkerReplicas < 0 {
+		return fmt.Errorf("worker replicas must not be negative, got %d", rayClusterSpecObject.WorkerReplicas)
+	}
+
+	groupNames := map[string]bool{}
+	for _, workerGroup := range rayClusterSpecObject.WorkerGroups {
+		if groupNames[workerGroup.Name] {
+			return fmt.Errorf("duplicate worker group %q", workerGroup.Name)
+		}
+		groupNames[workerGroup.Name] = true
+		if err := workerGroup.validate(); err != nil {
+			return fmt.Errorf("invalid worker group %q: %w", workerGroup.Name, err)
+		}
+	}
+	return nil
+}
+
+func (workerGroup WorkerGroup) validate() error {
+	quantities := map[string]string{
+		"CPU":    workerGroup.CPU,
+		"memory": workerGroup.Memory,
+		"GPU":    workerGroup.GPU,
+	}
+	for name, quantity := range quantities {
+		if quantity == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(quantity); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", name, quantity, err)
+		}
+	}
+	if workerGroup.Replicas < 0 {
+		return fmt.Errorf("replicas must not be negative, got %d", workerGroup.Replicas)
+	}
+	if workerGroup.MinReplicas != nil && *workerGroup.MinReplicas < 0 {
+		return fmt.Errorf("min replicas must not be negative, got %d", *workerGroup.MinReplicas)
+	}
+	if workerGroup.MinReplicas != nil && workerGroup.MaxReplicas != nil && *workerGroup.MinReplicas > *workerGroup.MaxReplicas {
+		return fmt.Errorf("min replicas %d must not be greater than max replicas %d", *workerGroup.MinReplicas, *workerGroup.MaxReplicas)
+	}
+	return nil
+}
+
+// workerGroups returns the worker groups, or the single worker group described by the Worker* fields
+func (rayClusterSpecObject *RayClusterSpecObject) workerGroups() []WorkerGroup {
+	if len(rayClusterSpecObject.WorkerGroups) > 0 {
+		return rayClusterSpecObject.WorkerGroups
+	}
+	workerGroupName := rayClusterSpecObject.WorkerGrpName
+	if workerGroupName == "" {
+		workerGroupName = defaultGroupName
+	}
+	return []WorkerGroup{{
+		Name:     workerGroupName,
+		CPU:      rayClusterSpecObject.WorkerCPU,
+		Memory:   rayClusterSpecObject.WorkerMemory,
+		GPU:      rayClusterSpecObject.WorkerGPU,
+		Replicas: rayClusterSpecObject.WorkerReplicas,
+	}}
+}
+
+// Validate checks that the fields of the WorkerGroup can be turned into a valid worker group spec
+func (workerGroup WorkerGroup) Validate() error {
+	if workerGroup.Name == "" {
+		return fmt.Errorf("worker group name is required")
+	}
+	if err := workerGroup.validate(); err != nil {
+		return fmt.Errorf("invalid worker group %q: %w", workerGroup.Name, err)
+	}
+	return nil
+}
+
+// GenerateWorkerGroupSpec generates the apply configuration of a worker group whose Ray container uses the image
+func (workerGroup WorkerGroup) GenerateWorkerGroupSpec(image string) *rayv1ac.WorkerGroupSpecApplyConfiguration {
+	podSpec := corev1ac.PodSpec().
+		WithContainers(corev1ac.Container().
+			WithName("ray-worker").
+			WithImage(image).
+			WithResources(generateResources(workerGroup.CPU, workerGroup.Memory, workerGroup.GPU)))
+	if len(workerGroup.NodeSelector) > 0 {
+		podSpec = podSpec.WithNodeSelector(workerGroup.NodeSelector)
+	}
+	for _, toleration := range workerGroup.Tolerations {
+		tolerationApplyConfig := corev1ac.Toleration().
+			WithKey(toleration.Key).
+			WithOperator(toleration.Operator)
+		if toleration.Value != "" {
+			tolerationApplyConfig = tolerationApplyConfig.WithValue(toleration.Value)
+		}
+		if toleration.Effect != "" {
+			tolerationApplyConfig = tolerationApplyConfig.WithEffect(toleration.Effect)
+		}
+		podSpec = podSpec.WithTolerations(tolerationApplyConfig)
+	}
+
+	workerGroupSpec := rayv1ac.WorkerGroupSpec().
+		WithGroupName(workerGroup.Name).
+		WithReplicas(workerGroup.Replicas).
+		WithRayStartParams(map[string]string{}).
+		WithTemplate(corev1ac.PodTemplateSpec().WithSpec(podSpec))
+	if workerGroup.MinReplicas != nil {
+		workerGroupSpec = workerGroupSpec.WithMinReplicas(*workerGroup.MinReplicas)
+	}
+	if workerGroup.MaxReplicas != nil {
+		workerGroupSpec = workerGroupSpec.WithMaxReplicas(*workerGroup.MaxReplicas)
+	}
+	return workerGroupSpec
+}
+
+func (rayClusterSpecObject *RayClusterSpecObject) generateRayClusterSpec() *rayv1ac.RayClusterSpecApplyConfiguration {
+	rayVersion := rayClusterSpecObject.RayVersion
+	if rayVersion == "" {
+		rayVersion = DefaultRayVersion
+	}
+	image := rayClusterSpecObject.Image
+	if image == "" {
+		image = fmt.Sprintf("%s:%s", defaultImageRepo, rayVersion)
+	}
+	rayClusterSpec := rayv1ac.RayClusterSpec().
+		WithRayVersion(rayVersion).
+		WithHeadGroupSpec(rayv1ac.HeadGroupSpec().
+			WithRayStartParams(map[string]string{"dashboard-host": "0.0.0.0"}).
+			WithTemplate(corev1ac.PodTemplateSpec().
+				WithSpec(corev1ac.PodSpec().
+					WithContainers(corev1ac.Container().
+						WithName("ray-head").
+						WithImage(image).
+						WithResources(generateResources(rayClusterSpecObject.HeadCPU, rayClusterSpecObject.HeadMemory, "")).
+						WithPorts(
+							corev1ac.ContainerPort().WithContainerPort(6379).WithName("gcs-server"),
+							corev1ac.ContainerPort().WithContainerPort(8265).WithName("dashboard"),
+							corev1ac.ContainerPort().WithContainerPort(10001).WithName("client"),
+						)))))
+	if rayClusterSpecObject.Autoscaler {
+		rayClusterSpec = rayClusterSpec.WithEnableInTreeAutoscaling(true)
+	}
+
+	for _, workerGroup := range rayClusterSpecObject.workerGroups() {
+		rayClusterSpec = rayClusterSpec.WithWorkerGroupSpecs(workerGroup.GenerateWorkerGroupSpec(image))
+	}
+
+	return rayClusterSpec
+}
+
+// generateResources sets both requests and limits to the given quantities. Empty quantities are skipped.
+// GPUs are only set as limits since Kubernetes does not allow overcommitting extended resources.
+func generateResources(cpu, memory, gpu string) *corev1ac.ResourceRequirementsApplyConfiguration {
+	requests := corev1.ResourceList{}
+	limits := corev1.ResourceList{}
+	if cpu != "" {
+		requests[corev1.ResourceCPU] = resource.MustParse(cpu)
+		limits[corev1.ResourceCPU] = resource.MustParse(cpu)
+	}
+	if memory != "" {
+		requests[corev1.ResourceMemory] = resource.MustParse(memory)
+		limits[corev1.ResourceMemory] = resource.MustParse(memory)
+	}
+	if gpu != "" {
+		if gpuQuantity := resource.MustParse(gpu); !gpuQuantity.IsZero() {
+			limits[resourceNvidiaGPU] = gpuQuantity
+		}
+	}
+
+	resources := corev1ac.ResourceRequirements()
+	if len(requests) > 0 {
+		resources = resources.WithRequests(requests)
+	}
+	if len(limits) > 0 {
+		resources = resources.WithLimits(limits)
+	}
+	return resources
+}
+
+// ConvertRayClusterApplyConfigToUnstructured converts the RayCluster apply configuration so it can be created with the dynamic client
+func ConvertRayClusterApplyConfigToUnstructured(rayClusterApplyConfig *rayv1ac.RayClusterApplyConfiguration) (*unstructured.Unstructured, error) {
+	unstructuredRayCluster, err := runtime.DefaultUnstructuredConverter.ToUnstructured(rayClusterApplyConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: unstructuredRayCluster}, nil
+}
+
+// ConvertWorkerGroupSpecApplyConfigToUnstructured converts the worker group spec apply configuration so it can be
+// added to the spec of a RayCluster with a JSON patch
+func ConvertWorkerGroupSpecApplyConfigToUnstructured(workerGroupSpecApplyConfig *rayv1ac.WorkerGroupSpecApplyConfiguration) (map[string]interface{}, error) {
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(workerGroupSpecApplyConfig)
+}
+
+// ConvertRayJobApplyConfigToUnstructured converts the RayJob apply configuration so it can be created with the dynamic client
+func ConvertRayJobApplyConfigToUnstructured(rayJobApplyConfig *rayv1ac.RayJobApplyConfiguration) (*unstructured.Unstructured, error) {
+	unstructuredRayJob, err := runtime.DefaultUnstructuredConverter.ToUnstructured(rayJobApplyConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: unstructuredRayJob}, nil
+}
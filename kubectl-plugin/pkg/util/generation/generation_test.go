@@ -0,0 +1,190 @@
+package generation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestGenerateRayJobApplyConfig(t *testing.T) {
+	testRayJobYamlObject := RayJobYamlObject{
+		RayJobName:     "test-rayjob",
+		Namespace:      "default",
+		SubmissionMode: "InteractiveMode",
+		RayClusterSpecObject: RayClusterSpecObject{
+			RayVersion:     "2.37.0",
+			HeadCPU:        "1",
+			HeadMemory:     "5Gi",
+			WorkerReplicas: 3,
+			WorkerCPU:      "2",
+			WorkerMemory:   "10Gi",
+			WorkerGPU:      "1",
+		},
+	}
+
+	result := testRayJobYamlObject.GenerateRayJobApplyConfig()
+
+	assert.Equal(t, testRayJobYamlObject.RayJobName, *result.Name)
+	assert.Equal(t, testRayJobYamlObject.Namespace, *result.Namespace)
+	assert.Equal(t, rayv1.JobSubmissionMode("InteractiveMode"), *result.Spec.SubmissionMode)
+	assert.Equal(t, "2.37.0", *result.Spec.RayClusterSpec.RayVersion)
+
+	headContainer := result.Spec.RayClusterSpec.HeadGroupSpec.Template.Spec.Containers[0]
+	assert.Equal(t, "rayproject/ray:2.37.0", *headContainer.Image)
+	assert.Equal(t, resource.MustParse("1"), (*headContainer.Resources.Requests)[corev1.ResourceCPU])
+	assert.Equal(t, resource.MustParse("5Gi"), (*headContainer.Resources.Limits)[corev1.ResourceMemory])
+
+	workerGroupSpec := result.Spec.RayClusterSpec.WorkerGroupSpecs[0]
+	assert.Equal(t, int32(3), *workerGroupSpec.Replicas)
+	workerContainer := workerGroupSpec.Template.Spec.Containers[0]
+	assert.Equal(t, resource.MustParse("2"), (*workerContainer.Resources.Requests)[corev1.ResourceCPU])
+	assert.Equal(t, resource.MustParse("10Gi"), (*workerContainer.Resources.Requests)[corev1.ResourceMemory])
+	assert.Equal(t, resource.MustParse("1"), (*workerContainer.Resources.Limits)[resourceNvidiaGPU])
+	_, hasGPURequest := (*workerContainer.Resources.Requests)[resourceNvidiaGPU]
+	assert.False(t, hasGPURequest)
+}
+
+func TestGenerateRayJobApplyConfigCustomImage(t *testing.T) {
+	testRayJobYamlObject := RayJobYamlObject{
+		RayJobName: "test-rayjob",
+		RayClusterSpecObject: RayClusterSpecObject{
+			Image:     "my-registry/ray:custom",
+			WorkerGPU: "0",
+		},
+	}
+
+	result := testRayJobYamlObject.GenerateRayJobApplyConfig()
+	assert.Equal(t, DefaultRayVersion, *result.Spec.RayClusterSpec.RayVersion)
+	assert.Equal(t, "my-registry/ray:custom", *result.Spec.RayClusterSpec.HeadGroupSpec.Template.Spec.Containers[0].Image)
+
+	workerContainer := result.Spec.RayClusterSpec.WorkerGroupSpecs[0].Template.Spec.Containers[0]
+	assert.Equal(t, "my-registry/ray:custom", *workerContainer.Image)
+	assert.Nil(t, workerContainer.Resources.Limits)
+}
+
+func TestRayClusterSpecObjectValidate(t *testing.T) {
+	assert.Nil(t, (&RayClusterSpecObject{HeadCPU: "500m", WorkerMemory: "1Gi"}).Validate())
+	assert.NotNil(t, (&RayClusterSpecObject{HeadCPU: "one"}).Validate())
+	assert.NotNil(t, (&RayClusterSpecObject{WorkerReplicas: -1}).Validate())
+}
+
+func TestConvertRayJobApplyConfigToUnstructured(t *testing.T) {
+	testRayJobYamlObject := RayJobYamlObject{
+		RayJobName:     "test-rayjob",
+		Namespace:      "default",
+		SubmissionMode: "InteractiveMode",
+		RayClusterSpecObject: RayClusterSpecObject{
+			WorkerReplicas: 2,
+		},
+	}
+
+	result, err := ConvertRayJobApplyConfigToUnstructured(testRayJobYamlObject.GenerateRayJobApplyConfig())
+	assert.Nil(t, err)
+	assert.Equal(t, "RayJob", result.GetKind())
+	assert.Equal(t, "ray.io/v1", result.GetAPIVersion())
+	assert.Equal(t, "test-rayjob", result.GetName())
+
+	submissionMode, found, err := unstructured.NestedString(result.Object, "spec", "submissionMode")
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "InteractiveMode", submissionMode)
+}
+
+func TestGenerateRayClusterApplyConfig(t *testing.T) {
+	testRayClusterYamlObject := RayClusterYamlObject{
+		ClusterName: "test-raycluster",
+		Namespace:   "default",
+		RayClusterSpecObject: RayClusterSpecObject{
+			HeadCPU:    "1",
+			HeadMemory: "5Gi",
+			WorkerGroups: []WorkerGroup{
+				{Name: "cpu", Replicas: 2, CPU: "4"},
+				{Name: "gpu", Replicas: 1, MinReplicas: ptr.To[int32](0), MaxReplicas: ptr.To[int32](8), GPU: "1"},
+			},
+			Autoscaler: true,
+		},
+	}
+
+	result := testRayClusterYamlObject.GenerateRayClusterApplyConfig()
+
+	assert.Equal(t, "test-raycluster", *result.Name)
+	assert.Equal(t, "default", *result.Namespace)
+	assert.Equal(t, "RayCluster", *result.Kind)
+	assert.True(t, *result.Spec.EnableInTreeAutoscaling)
+
+	workerGroupSpecs := result.Spec.WorkerGroupSpecs
+	assert.Len(t, workerGroupSpecs, 2)
+	assert.Equal(t, "cpu", *workerGroupSpecs[0].GroupName)
+	assert.Equal(t, int32(2), *workerGroupSpecs[0].Replicas)
+	assert.Nil(t, workerGroupSpecs[0].MinReplicas)
+	assert.Equal(t, resource.MustParse("4"), (*workerGroupSpecs[0].Template.Spec.Containers[0].Resources.Requests)[corev1.ResourceCPU])
+	assert.Equal(t, "gpu", *workerGroupSpecs[1].GroupName)
+	assert.Equal(t, int32(0), *workerGroupSpecs[1].MinReplicas)
+	assert.Equal(t, int32(8), *workerGroupSpecs[1].MaxReplicas)
+	assert.Equal(t, resource.MustParse("1"), (*workerGroupSpecs[1].Template.Spec.Containers[0].Resources.Limits)[resourceNvidiaGPU])
+}
+
+func TestGenerateWorkerGroupSpec(t *testing.T) {
+	workerGroup := WorkerGroup{
+		Name:         "gpu",
+		GPU:          "1",
+		Replicas:     2,
+		NodeSelector: map[string]string{"cloud.google.com/gke-accelerator": "nvidia-l4"},
+		Tolerations: []corev1.Toleration{
+			{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+		},
+	}
+
+	result := workerGroup.GenerateWorkerGroupSpec("rayproject/ray:2.37.0-gpu")
+
+	assert.Equal(t, "gpu", *result.GroupName)
+	assert.Equal(t, int32(2), *result.Replicas)
+	assert.Equal(t, "rayproject/ray:2.37.0-gpu", *result.Template.Spec.Containers[0].Image)
+	assert.Equal(t, map[string]string{"cloud.google.com/gke-accelerator": "nvidia-l4"}, result.Template.Spec.NodeSelector)
+	assert.Len(t, result.Template.Spec.Tolerations, 1)
+	assert.Equal(t, "nvidia.com/gpu", *result.Template.Spec.Tolerations[0].Key)
+	assert.Equal(t, corev1.TolerationOpExists, *result.Template.Spec.Tolerations[0].Operator)
+	assert.Nil(t, result.Template.Spec.Tolerations[0].Value)
+	assert.Equal(t, corev1.TaintEffectNoSchedule, *result.Template.Spec.Tolerations[0].Effect)
+
+	assert.EqualError(t, WorkerGroup{}.Validate(), "worker group name is required")
+}
+
+func TestParseWorkerGroup(t *testing.T) {
+	defaults := WorkerGroup{CPU: "2", Memory: "4Gi", GPU: "0", Replicas: 1}
+
+	workerGroup, err := ParseWorkerGroup("name=gpu,replicas=4,gpu=1", defaults)
+	assert.Nil(t, err)
+	assert.Equal(t, WorkerGroup{Name: "gpu", CPU: "2", Memory: "4Gi", GPU: "1", Replicas: 4}, workerGroup)
+
+	workerGroup, err = ParseWorkerGroup("name=cpu, min-replicas=1, max-replicas=10", defaults)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), *workerGroup.MinReplicas)
+	assert.Equal(t, int32(10), *workerGroup.MaxReplicas)
+
+	tests := map[string]string{
+		"replicas=4":                 "invalid worker group \"replicas=4\": name is required",
+		"name=gpu,replicas=four":     "invalid worker group \"name=gpu,replicas=four\": replicas must be an integer, got \"four\"",
+		"name=gpu,disk=10Gi":         "invalid worker group \"name=gpu,disk=10Gi\": unknown key \"disk\", must be one of name, replicas, min-replicas, max-replicas, cpu, memory, gpu",
+		"name=gpu,gpu":               "invalid worker group \"name=gpu,gpu\": expected KEY=VALUE, got \"gpu\"",
+		"name=gpu,max-replicas=1e10": "invalid worker group \"name=gpu,max-replicas=1e10\": max-replicas must be an integer, got \"1e10\"",
+	}
+	for shorthand, expectedError := range tests {
+		_, err := ParseWorkerGroup(shorthand, defaults)
+		assert.EqualError(t, err, expectedError)
+	}
+}
+
+func TestRayClusterSpecObjectValidateWorkerGroups(t *testing.T) {
+	assert.Nil(t, (&RayClusterSpecObject{WorkerGroups: []WorkerGroup{{Name: "cpu"}, {Name: "gpu", GPU: "1"}}}).Validate())
+	assert.EqualError(t, (&RayClusterSpecObject{WorkerGroups: []WorkerGroup{{Name: "cpu"}, {Name: "cpu"}}}).Validate(), "duplicate worker group \"cpu\"")
+	assert.NotNil(t, (&RayClusterSpecObject{WorkerGroups: []WorkerGroup{{Name: "gpu", GPU: "one"}}}).Validate())
+	assert.EqualError(t, (&RayClusterSpecObject{WorkerGroups: []WorkerGroup{{Name: "gpu", MinReplicas: ptr.To[int32](2), MaxReplicas: ptr.To[int32](1)}}}).Validate(),
+		"invalid worker group \"gpu\": min replicas 2 must not be greater than max replicas 1")
+}
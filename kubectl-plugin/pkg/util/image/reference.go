@@ -0,0 +1,97 @@
+package image
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// dockerHub is the registry of images whose name does not start with a registry host
+	dockerHub = "docker.io"
+	// dockerHubAPI is the host of the registry API of Docker Hub
+	dockerHubAPI = "registry-1.docker.io"
+	defaultTag   = "latest"
+)
+
+// Reference is a parsed container image reference such as rayproject/ray:2.37.0
+type Reference struct {
+	// Registry is the host of the registry, e.g. docker.io or us-docker.pkg.dev
+	Registry string
+	// Repository is the path of the image in the registry, e.g. library/python for python
+	Repository string
+	Tag        string
+	// Digest is set if the image is already pinned, e.g. sha256:0123...
+	Digest string
+}
+
+// ParseReference parses an image reference the way the container runtimes do: a name without a registry host is an
+// image of Docker Hub, a Docker Hub name without a namespace is in library/, and the tag defaults to latest.
+func ParseReference(image string) (Reference, error) {
+	if image == "" || strings.ContainsAny(image, " \t\n") {
+		return Reference{}, fmt.Errorf("invalid image reference %q", image)
+	}
+	var reference Reference
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, reference.Digest = name[:i], name[i+1:]
+		if !strings.Contains(reference.Digest, ":") {
+			return Reference{}, fmt.Errorf("invalid digest of image reference %q", image)
+		}
+	}
+	if HasTag(name) {
+		i := strings.LastIndex(name, ":")
+		name, reference.Tag = name[:i], name[i+1:]
+		if reference.Tag == "" {
+			return Reference{}, fmt.Errorf("invalid image reference %q", image)
+		}
+	}
+
+	registry, repository, found := strings.Cut(name, "/")
+	if !found || (!strings.ContainsAny(registry, ".:") && registry != "localhost") {
+		registry, repository = dockerHub, name
+	}
+	if registry == dockerHub && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+	if repository == "" {
+		return Reference{}, fmt.Errorf("invalid image reference %q", image)
+	}
+	if reference.Tag == "" && reference.Digest == "" {
+		reference.Tag = defaultTag
+	}
+	reference.Registry = registry
+	reference.Repository = repository
+	return reference, nil
+}
+
+// HasTag reports whether the image reference has a tag. The tag follows the last colon that is after the last slash,
+// since the host of a registry can have a port.
+func HasTag(image string) bool {
+	name, _, _ := strings.Cut(image, "@")
+	return strings.LastIndex(name, ":") > strings.LastIndex(name, "/")
+}
+
+// Name returns the fully qualified name of the image without tag and digest, e.g. docker.io/rayproject/ray
+func (r Reference) Name() string {
+	return r.Registry + "/" + r.Repository
+}
+
+// String returns the fully qualified reference, e.g. docker.io/rayproject/ray:2.37.0
+func (r Reference) String() string {
+	s := r.Name()
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// apiHost returns the host of the registry API
+func (r Reference) apiHost() string {
+	if r.Registry == dockerHub {
+		return dockerHubAPI
+	}
+	return r.Registry
+}
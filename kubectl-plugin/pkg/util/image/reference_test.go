@@ -0,0 +1,38 @@
+package image
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		image       string
+		expected    Reference
+		expectError bool
+	}{
+		{image: "python", expected: Reference{Registry: "docker.io", Repository: "library/python", Tag: "latest"}},
+		{image: "rayproject/ray:2.37.0", expected: Reference{Registry: "docker.io", Repository: "rayproject/ray", Tag: "2.37.0"}},
+		{image: "localhost:5000/ray:dev", expected: Reference{Registry: "localhost:5000", Repository: "ray", Tag: "dev"}},
+		{image: "us-docker.pkg.dev/project/repo/ray", expected: Reference{Registry: "us-docker.pkg.dev", Repository: "project/repo/ray", Tag: "latest"}},
+		{image: "rayproject/ray:2.37.0@" + testDigest, expected: Reference{Registry: "docker.io", Repository: "rayproject/ray", Tag: "2.37.0", Digest: testDigest}},
+		{image: "rayproject/ray@" + testDigest, expected: Reference{Registry: "docker.io", Repository: "rayproject/ray", Digest: testDigest}},
+		{image: "", expectError: true},
+		{image: "rayproject/ray:", expectError: true},
+		{image: "rayproject/ray@1234", expectError: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.image, func(t *testing.T) {
+			reference, err := ParseReference(tc.image)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, reference)
+		})
+	}
+	assert.Equal(t, "docker.io/library/python:latest", Reference{Registry: "docker.io", Repository: "library/python", Tag: "latest"}.String())
+}
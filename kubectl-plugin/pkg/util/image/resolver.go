@@ -0,0 +1,239 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
+)
+
+// registryTimeout bounds each request to a registry
+const registryTimeout = 30 * time.Second
+
+// manifestMediaTypes are accepted when resolving a tag, so that the digest of a multi-architecture image is the digest
+// of its index, which is what the container runtimes report and pull
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// Resolver resolves the tags of container images to digests. The registry of the image is asked first with an
+// anonymous HEAD request of the manifest. If the registry cannot be reached or requires credentials, the digest is
+// looked up in the images that the nodes of the Kubernetes cluster have pulled.
+type Resolver struct {
+	httpClient *http.Client
+	kubeClient kubernetes.Interface
+	// nodeImages maps the fully qualified tagged names of the images of the nodes to their digests
+	nodeImages map[string][]string
+	// scheme of the registry API, which is only changed in tests
+	scheme string
+}
+
+// NewResolver returns a Resolver that falls back to the images of the nodes of kubeClient, which can be nil
+func NewResolver(kubeClient kubernetes.Interface) *Resolver {
+	return &Resolver{
+		httpClient: &http.Client{Timeout: registryTimeout},
+		kubeClient: kubeClient,
+		scheme:     "https",
+	}
+}
+
+// Pin returns the image pinned to the digest of its tag, e.g. rayproject/ray:2.37.0@sha256:0123... The tag is kept
+// for readability, while the container runtimes pull the digest. An image that is already pinned is returned as is.
+func (r *Resolver) Pin(ctx context.Context, image string) (string, error) {
+	reference, err := ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+	if reference.Digest != "" {
+		return image, nil
+	}
+
+	digest, registryErr := r.registryDigest(ctx, reference)
+	if registryErr != nil {
+		logging.V(2).Infof("Unable to resolve image %s with its registry, looking it up on the nodes: %v", image, registryErr)
+		var nodeErr error
+		if digest, nodeErr = r.nodeDigest(ctx, reference); nodeErr != nil {
+			return "", fmt.Errorf("unable to resolve the digest of image %s: %w", image, errors.Join(registryErr, nodeErr))
+		}
+	}
+	if !HasTag(image) {
+		image += ":" + reference.Tag
+	}
+	return image + "@" + digest, nil
+}
+
+// registryDigest asks the registry for the digest of the manifest of the tag
+func (r *Resolver) registryDigest(ctx context.Context, reference Reference) (string, error) {
+	manifestURL := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", r.scheme, reference.apiHost(), reference.Repository, reference.Tag)
+	resp, err := r.headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		// Public images of most registries require an anonymous token
+		token, err := r.anonymousToken(ctx, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return "", err
+		}
+		if resp, err = r.headManifest(ctx, manifestURL, token); err != nil {
+			return "", err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry %s returned %s for %s", reference.Registry, resp.Status, reference)
+	}
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if !strings.HasPrefix(digest, "sha256:") {
+		return "", fmt.Errorf("registry %s did not return the digest of %s", reference.Registry, reference)
+	}
+	return digest, nil
+}
+
+func (r *Resolver) headManifest(ctx context.Context, manifestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// anonymousToken requests a pull token from the authorization server of a Bearer challenge, e.g.
+// Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:rayproject/ray:pull"
+func (r *Resolver) anonymousToken(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("registry requires credentials")
+	}
+	values := parseChallengeParams(params)
+	realm := values["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("invalid authentication challenge of the registry: %s", challenge)
+	}
+	query := url.Values{}
+	for _, key := range []string{"service", "scope"} {
+		if values[key] != "" {
+			query.Set(key, values[key])
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry denied anonymous access: %s", resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("invalid token response of the registry: %w", err)
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	return token.AccessToken, nil
+}
+
+// parseChallengeParams parses the comma separated key="value" parameters of a WWW-Authenticate header
+func parseChallengeParams(params string) map[string]string {
+	values := map[string]string{}
+	for params != "" {
+		key, rest, found := strings.Cut(strings.TrimLeft(params, ", "), "=")
+		if !found {
+			break
+		}
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		values[strings.ToLower(strings.TrimSpace(key))] = value
+		params = rest
+	}
+	return values
+}
+
+// nodeDigest looks the tag up in the images pulled by the nodes, which report the digest of the tag when it was pulled
+func (r *Resolver) nodeDigest(ctx context.Context, reference Reference) (string, error) {
+	if r.kubeClient == nil {
+		return "", fmt.Errorf("no node has pulled %s", reference)
+	}
+	if r.nodeImages == nil {
+		nodes, err := r.kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to list the nodes: %w", err)
+		}
+		r.nodeImages = map[string][]string{}
+		for _, node := range nodes.Items {
+			for _, nodeImage := range node.Status.Images {
+				var tagged []Reference
+				digests := map[string]string{}
+				for _, name := range nodeImage.Names {
+					nodeReference, err := ParseReference(name)
+					if err != nil {
+						continue
+					}
+					if nodeReference.Digest != "" {
+						digests[nodeReference.Name()] = nodeReference.Digest
+					} else {
+						tagged = append(tagged, nodeReference)
+					}
+				}
+				for _, taggedReference := range tagged {
+					if digest, ok := digests[taggedReference.Name()]; ok && !contains(r.nodeImages[taggedReference.String()], digest) {
+						r.nodeImages[taggedReference.String()] = append(r.nodeImages[taggedReference.String()], digest)
+					}
+				}
+			}
+		}
+	}
+
+	digests := r.nodeImages[reference.String()]
+	switch len(digests) {
+	case 0:
+		return "", fmt.Errorf("no node has pulled %s", reference)
+	case 1:
+		return digests[0], nil
+	default:
+		sort.Strings(digests)
+		return "", fmt.Errorf("the nodes have pulled different digests of %s: %s", reference, strings.Join(digests, ", "))
+	}
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
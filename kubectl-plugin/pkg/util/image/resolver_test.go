@@ -0,0 +1,102 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+)
+
+const testDigest = "sha256:2d4e0bd8f2c5a5b0e5e8b3c4d7f9a1b2c3d4e5f60718293a4b5c6d7e8f901234"
+
+// newTestRegistry returns a registry that requires an anonymous token and knows ray:2.37.0
+func newTestRegistry(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			assert.Equal(t, "repository:rayproject/ray:pull", r.URL.Query().Get("scope"))
+			fmt.Fprint(w, `{"token": "anonymous"}`)
+		case r.Header.Get("Authorization") != "Bearer anonymous":
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:rayproject/ray:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.Method == http.MethodHead && r.URL.Path == "/v2/rayproject/ray/manifests/2.37.0":
+			assert.Contains(t, r.Header.Get("Accept"), "application/vnd.oci.image.index.v1+json")
+			w.Header().Set("Docker-Content-Digest", testDigest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestResolverPinWithRegistry(t *testing.T) {
+	server := newTestRegistry(t)
+	registry := strings.TrimPrefix(server.URL, "https://")
+	resolver := NewResolver(nil)
+	resolver.httpClient = server.Client()
+
+	pinned, err := resolver.Pin(context.Background(), registry+"/rayproject/ray:2.37.0")
+	assert.Nil(t, err)
+	assert.Equal(t, registry+"/rayproject/ray:2.37.0@"+testDigest, pinned)
+
+	// Pinned images are kept
+	pinned, err = resolver.Pin(context.Background(), "rayproject/ray@"+testDigest)
+	assert.Nil(t, err)
+	assert.Equal(t, "rayproject/ray@"+testDigest, pinned)
+
+	_, err = resolver.Pin(context.Background(), registry+"/rayproject/ray:missing")
+	assert.ErrorContains(t, err, "unable to resolve the digest of image "+registry+"/rayproject/ray:missing: registry "+registry+" returned 404 Not Found")
+}
+
+func TestResolverPinWithNodes(t *testing.T) {
+	otherDigest := "sha256:" + strings.Repeat("0", 64)
+	kubeClientSet := kubeFake.NewSimpleClientset(
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+			Status: corev1.NodeStatus{Images: []corev1.ContainerImage{
+				{Names: []string{"docker.io/rayproject/ray@" + testDigest, "docker.io/rayproject/ray:2.37.0"}},
+				{Names: []string{"private.example.com/ray@" + testDigest, "private.example.com/ray:dev"}},
+			}},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-2"},
+			Status: corev1.NodeStatus{Images: []corev1.ContainerImage{
+				{Names: []string{"docker.io/rayproject/ray@" + testDigest, "docker.io/rayproject/ray:2.37.0"}},
+				{Names: []string{"private.example.com/ray@" + otherDigest, "private.example.com/ray:dev"}},
+			}},
+		},
+	)
+	// The registry is not reachable
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	server.Close()
+	resolver := NewResolver(kubeClientSet)
+	resolver.httpClient = server.Client()
+	resolver.scheme = "http"
+
+	pinned, err := resolver.Pin(context.Background(), "rayproject/ray:2.37.0")
+	assert.Nil(t, err)
+	assert.Equal(t, "rayproject/ray:2.37.0@"+testDigest, pinned)
+
+	_, err = resolver.Pin(context.Background(), "private.example.com/ray:dev")
+	assert.ErrorContains(t, err, "the nodes have pulled different digests of private.example.com/ray:dev")
+
+	_, err = resolver.Pin(context.Background(), "rayproject/ray:2.38.0")
+	assert.ErrorContains(t, err, "no node has pulled docker.io/rayproject/ray:2.38.0")
+}
+
+func TestParseChallengeParams(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:rayproject/ray:pull",
+	}, parseChallengeParams(`realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:rayproject/ray:pull"`))
+}
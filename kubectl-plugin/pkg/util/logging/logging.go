@@ -0,0 +1,166 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Format is the format of the log messages of the plugin
+type Format string
+
+const (
+	// Text writes the messages as plain lines, which is what humans read by default
+	Text Format = "text"
+	// JSON writes each message as a JSON object with its time, level and attributes, which is suited for automation
+	JSON Format = "json"
+)
+
+var (
+	verbosity int
+	format    = Text
+	// defaultOut is where the package level functions write, which is the error stream of the plugin
+	defaultOut io.Writer = os.Stderr
+)
+
+// AddFlags adds the --v and --log-format flags, which apply to all the commands of the plugin
+func AddFlags(flags *pflag.FlagSet) {
+	flags.IntVar(&verbosity, "v", verbosity, "Number for the log level verbosity. Higher levels show more details, e.g. 2 shows the retried API calls")
+	flags.StringVar((*string)(&format), "log-format", string(format), "Format of the log messages: text or json")
+}
+
+// ValidateFlags checks the values given with --v and --log-format
+func ValidateFlags() error {
+	if verbosity < 0 {
+		return fmt.Errorf("invalid --v %d, must not be negative", verbosity)
+	}
+	switch format {
+	case Text, JSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid --log-format %q, must be one of text or json", format)
+	}
+}
+
+// Configure sets the verbosity and the format of the log messages
+func Configure(v int, f Format) {
+	verbosity = v
+	format = f
+}
+
+// SetOutput sets where the package level functions write
+func SetOutput(out io.Writer) {
+	defaultOut = out
+}
+
+// CurrentFormat returns the format of the log messages
+func CurrentFormat() Format {
+	return format
+}
+
+// Logger writes log messages to the stream of a command
+type Logger struct {
+	out io.Writer
+}
+
+// New returns a Logger that writes to out
+func New(out io.Writer) *Logger {
+	return &Logger{out: out}
+}
+
+// Infof logs a message that is always shown
+func (l *Logger) Infof(msg string, args ...interface{}) {
+	l.log(slog.LevelInfo, 0, fmt.Sprintf(msg, args...))
+}
+
+// InfoS logs a message that is always shown, with attributes given as alternating keys and values
+func (l *Logger) InfoS(msg string, keysAndValues ...interface{}) {
+	l.log(slog.LevelInfo, 0, msg, keysAndValues...)
+}
+
+// Warnf logs a warning, which is prefixed with "Warning:" in the text format
+func (l *Logger) Warnf(msg string, args ...interface{}) {
+	l.log(slog.LevelWarn, 0, fmt.Sprintf(msg, args...))
+}
+
+// V returns a logger for details that are only shown when --v is at least level
+func (l *Logger) V(level int) Verbose {
+	return Verbose{logger: l, level: level}
+}
+
+// Verbose logs the messages of a verbosity level
+type Verbose struct {
+	logger *Logger
+	level  int
+}
+
+// Enabled returns whether the messages of the level are shown
+func (v Verbose) Enabled() bool {
+	return v.level <= verbosity
+}
+
+// Infof logs a message if the level is enabled
+func (v Verbose) Infof(msg string, args ...interface{}) {
+	if v.Enabled() {
+		v.logger.log(slog.LevelInfo, v.level, fmt.Sprintf(msg, args...))
+	}
+}
+
+// InfoS logs a message with attributes if the level is enabled
+func (v Verbose) InfoS(msg string, keysAndValues ...interface{}) {
+	if v.Enabled() {
+		v.logger.log(slog.LevelInfo, v.level, msg, keysAndValues...)
+	}
+}
+
+// Infof logs a message to the error stream of the plugin
+func Infof(msg string, args ...interface{}) {
+	New(defaultOut).Infof(msg, args...)
+}
+
+// Warnf logs a warning to the error stream of the plugin
+func Warnf(msg string, args ...interface{}) {
+	New(defaultOut).Warnf(msg, args...)
+}
+
+// V returns a logger for details of the error stream of the plugin that are only shown when --v is at least level
+func V(level int) Verbose {
+	return New(defaultOut).V(level)
+}
+
+func (l *Logger) log(level slog.Level, v int, msg string, keysAndValues ...interface{}) {
+	record := slog.NewRecord(time.Now(), level, msg, 0)
+	record.Add(keysAndValues...)
+
+	if format == JSON {
+		if v > 0 {
+			record.AddAttrs(slog.Int("v", v))
+		}
+		// Each record is a single write, so that concurrent messages are not interleaved
+		_ = slog.NewJSONHandler(l.out, nil).Handle(context.Background(), record)
+		return
+	}
+
+	var line strings.Builder
+	if level >= slog.LevelWarn {
+		line.WriteString("Warning: ")
+	}
+	line.WriteString(msg)
+	record.Attrs(func(attr slog.Attr) bool {
+		value := attr.Value.String()
+		if strings.ContainsAny(value, " \t\n\"=") {
+			value = strconv.Quote(value)
+		}
+		fmt.Fprintf(&line, " %s=%s", attr.Key, value)
+		return true
+	})
+	line.WriteString("\n")
+	_, _ = io.WriteString(l.out, line.String())
+}
@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFlags(t *testing.T) {
+	defer Configure(0, Text)
+
+	Configure(2, JSON)
+	assert.Nil(t, ValidateFlags())
+
+	Configure(-1, Text)
+	assert.EqualError(t, ValidateFlags(), "invalid --v -1, must not be negative")
+
+	Configure(0, "yaml")
+	assert.EqualError(t, ValidateFlags(), "invalid --log-format \"yaml\", must be one of text or json")
+}
+
+func logMessages(logger *Logger) {
+	logger.Infof("Submitting Ray job %s", "raysubmit_123")
+	logger.Warnf("failed to record the result of Ray job %s", "raysubmit_123")
+	logger.V(1).InfoS("Ray dashboard request", "method", "GET", "url", "http://localhost:8265/api/jobs/", "status", 200)
+	logger.V(2).Infof("Retrying in %s", "200ms")
+}
+
+func TestLoggerText(t *testing.T) {
+	defer Configure(0, Text)
+
+	out := &bytes.Buffer{}
+	Configure(0, Text)
+	logMessages(New(out))
+	assert.Equal(t, "Submitting Ray job raysubmit_123\nWarning: failed to record the result of Ray job raysubmit_123\n", out.String())
+
+	out.Reset()
+	Configure(1, Text)
+	logMessages(New(out))
+	assert.Equal(t, "Submitting Ray job raysubmit_123\n"+
+		"Warning: failed to record the result of Ray job raysubmit_123\n"+
+		"Ray dashboard request method=GET url=http://localhost:8265/api/jobs/ status=200\n", out.String())
+}
+
+func TestLoggerJSON(t *testing.T) {
+	defer Configure(0, Text)
+
+	out := &bytes.Buffer{}
+	Configure(2, JSON)
+	logMessages(New(out))
+
+	decoder := json.NewDecoder(out)
+	var records []map[string]interface{}
+	for decoder.More() {
+		record := map[string]interface{}{}
+		assert.Nil(t, decoder.Decode(&record))
+		assert.NotEmpty(t, record["time"])
+		delete(record, "time")
+		records = append(records, record)
+	}
+	assert.Equal(t, []map[string]interface{}{
+		{"level": "INFO", "msg": "Submitting Ray job raysubmit_123"},
+		{"level": "WARN", "msg": "failed to record the result of Ray job raysubmit_123"},
+		{"level": "INFO", "msg": "Ray dashboard request", "method": "GET", "url": "http://localhost:8265/api/jobs/", "status": float64(200), "v": float64(1)},
+		{"level": "INFO", "msg": "Retrying in 200ms", "v": float64(2)},
+	}, records)
+}
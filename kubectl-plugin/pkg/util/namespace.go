@@ -0,0 +1,21 @@
+package util
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ManifestNamespace returns the namespace of an object read from a manifest. The namespace of the manifest is used
+// unless a different namespace is given explicitly with --namespace, which is an error as in `kubectl apply`.
+// namespace is the namespace of the command, which is only used if the manifest does not set one.
+func ManifestNamespace(obj *unstructured.Unstructured, namespace string, namespaceFromFlag bool) (string, error) {
+	manifestNamespace := obj.GetNamespace()
+	if manifestNamespace == "" {
+		return namespace, nil
+	}
+	if namespaceFromFlag && namespace != manifestNamespace {
+		return "", fmt.Errorf("the namespace from the provided object %q does not match the namespace %q. You must pass '--namespace=%s' to perform this operation", manifestNamespace, namespace, manifestNamespace)
+	}
+	return manifestNamespace, nil
+}
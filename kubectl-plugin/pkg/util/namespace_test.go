@@ -0,0 +1,59 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestManifestNamespace(t *testing.T) {
+	tests := []struct {
+		name              string
+		manifestNamespace string
+		namespace         string
+		expectedNamespace string
+		expectError       string
+		namespaceFromFlag bool
+	}{
+		{
+			name:              "manifest without namespace uses the namespace of the command",
+			namespace:         "default",
+			expectedNamespace: "default",
+		},
+		{
+			name:              "manifest namespace takes precedence over the default namespace",
+			manifestNamespace: "ml-team",
+			namespace:         "default",
+			expectedNamespace: "ml-team",
+		},
+		{
+			name:              "matching --namespace",
+			manifestNamespace: "ml-team",
+			namespace:         "ml-team",
+			namespaceFromFlag: true,
+			expectedNamespace: "ml-team",
+		},
+		{
+			name:              "conflicting --namespace",
+			manifestNamespace: "ml-team",
+			namespace:         "default",
+			namespaceFromFlag: true,
+			expectError:       "the namespace from the provided object \"ml-team\" does not match the namespace \"default\". You must pass '--namespace=ml-team' to perform this operation",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{}
+			obj.SetNamespace(tc.manifestNamespace)
+			namespace, err := ManifestNamespace(obj, tc.namespace, tc.namespaceFromFlag)
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedNamespace, namespace)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package util
+
+import (
+	"fmt"
+	"net"
+)
+
+// GetFreeLocalPort asks the kernel for a free ephemeral port on the loopback interface.
+// The port is released before returning, so there is a small window where another process could claim it.
+func GetFreeLocalPort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+
+	addr, ok := listener.Addr().(*net.TCPAddr)
+	if !ok {
+		return 0, fmt.Errorf("unexpected listener address type %T", listener.Addr())
+	}
+	return addr.Port, nil
+}
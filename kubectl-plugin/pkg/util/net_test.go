@@ -0,0 +1,20 @@
+package util
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFreeLocalPort(t *testing.T) {
+	port, err := GetFreeLocalPort()
+	assert.Nil(t, err)
+	assert.Greater(t, port, 0)
+
+	// The returned port should be usable right away
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	assert.Nil(t, err)
+	listener.Close()
+}
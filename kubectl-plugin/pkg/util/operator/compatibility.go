@@ -0,0 +1,221 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/dynamic"
+)
+
+// maxProblemsPerResource bounds the schema problems reported for a single custom resource
+const maxProblemsPerResource = 5
+
+// UpgradeCheck is the outcome of checking whether the installed operator, its CRDs and the existing custom resources
+// can be upgraded to the embedded manifests
+type UpgradeCheck struct {
+	// Problems would break the existing custom resources or the operator, and block the upgrade
+	Problems []string
+	// Warnings are worth knowing but do not block the upgrade
+	Warnings []string
+}
+
+// IsOlder reports whether the operator version is older than the embedded manifests. All releases are older than the
+// nightly manifests of master, and versions that are not releases, e.g. nightly, are never older.
+func IsOlder(version string) bool {
+	installed, err := utilversion.ParseGeneric(version)
+	if err != nil {
+		return false
+	}
+	embedded, err := utilversion.ParseGeneric(Version)
+	return err != nil || installed.LessThan(embedded)
+}
+
+// checkDowngrade checks that the operator installedVersion is not newer than the embeddedVersion. The nightly
+// manifests of master are newer than all releases, so an upgrade to them is never a downgrade.
+func checkDowngrade(installedVersion string, embeddedVersion string, check *UpgradeCheck) {
+	embedded, err := utilversion.ParseGeneric(embeddedVersion)
+	if installedVersion == "" || err != nil {
+		return
+	}
+	installed, err := utilversion.ParseGeneric(installedVersion)
+	if err != nil {
+		check.Warnings = append(check.Warnings, fmt.Sprintf("unable to compare the installed KubeRay operator version %s with %s", installedVersion, embeddedVersion))
+	} else if installed.GreaterThan(embedded) {
+		check.Problems = append(check.Problems, fmt.Sprintf("KubeRay operator %s is newer than %s, downgrading it is not supported", installedVersion, embeddedVersion))
+	}
+}
+
+// crdVersion is a version of a CRD
+type crdVersion struct {
+	// specSchema is the OpenAPI schema of spec
+	specSchema map[string]interface{}
+	served     bool
+	storage    bool
+}
+
+// CheckUpgrade checks the upgrade from the operator installedVersion, which is empty if the operator is not installed,
+// and the installed CRDs to the objects of the embedded manifests:
+//   - a downgrade is a problem, since older CRDs may not know the fields that the newer operator has written
+//   - a version in status.storedVersions of an installed CRD that the new CRD does not serve is a problem, since the
+//     objects stored in that version could no longer be read
+//   - the spec of each existing custom resource is validated against the schema of the new CRD: fields that the new
+//     schema would prune and required fields that are missing are problems
+//   - a version that is served by the installed CRD but no longer by the new CRD is a warning, since clients that use it
+//     will fail
+func CheckUpgrade(ctx context.Context, dynamicClient dynamic.Interface, installedVersion string, objects []*unstructured.Unstructured) (*UpgradeCheck, error) {
+	check := &UpgradeCheck{}
+	checkDowngrade(installedVersion, Version, check)
+
+	for _, crd := range CRDs(objects) {
+		installedCRD, err := dynamicClient.Resource(CRDGVR).Get(ctx, crd.GetName(), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get CRD %s: %w", crd.GetName(), err)
+		}
+		if err := checkCRDUpgrade(ctx, dynamicClient, installedCRD, crd, check); err != nil {
+			return nil, err
+		}
+	}
+	return check, nil
+}
+
+func checkCRDUpgrade(ctx context.Context, dynamicClient dynamic.Interface, installedCRD, crd *unstructured.Unstructured, check *UpgradeCheck) error {
+	name := crd.GetName()
+	installedVersions, err := crdVersions(installedCRD)
+	if err != nil {
+		return fmt.Errorf("invalid installed CRD %s: %w", name, err)
+	}
+	newVersions, err := crdVersions(crd)
+	if err != nil {
+		return fmt.Errorf("invalid CRD %s: %w", name, err)
+	}
+
+	storedVersions, _, _ := unstructured.NestedStringSlice(installedCRD.Object, "status", "storedVersions")
+	for _, storedVersion := range storedVersions {
+		if version, ok := newVersions[storedVersion]; !ok || !version.served {
+			check.Problems = append(check.Problems, fmt.Sprintf("CRD %s stores objects in version %s, which KubeRay %s does not serve. Migrate them to the storage version and remove %s from status.storedVersions first", name, storedVersion, Version, storedVersion))
+		}
+	}
+	for _, versionName := range sortedVersionNames(installedVersions) {
+		if version, ok := newVersions[versionName]; installedVersions[versionName].served && (!ok || !version.served) {
+			check.Warnings = append(check.Warnings, fmt.Sprintf("KubeRay %s no longer serves version %s of CRD %s, clients that use it will fail", Version, versionName, name))
+		}
+	}
+
+	// The existing custom resources are read with the storage version of the new CRD if the installed CRD serves it
+	var storageVersion string
+	var specSchema map[string]interface{}
+	for versionName, version := range newVersions {
+		if version.storage {
+			storageVersion, specSchema = versionName, version.specSchema
+		}
+	}
+	if storageVersion == "" || specSchema == nil || !installedVersions[storageVersion].served {
+		return nil
+	}
+	group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+	plural, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "plural")
+	kind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "kind")
+	resources, err := dynamicClient.Resource(schema.GroupVersionResource{Group: group, Version: storageVersion, Resource: plural}).Namespace("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", plural, err)
+	}
+	for _, resource := range resources.Items {
+		spec, ok := resource.Object["spec"]
+		if !ok {
+			continue
+		}
+		var problems []string
+		checkSchema(specSchema, spec, "spec", func(problem string) {
+			problems = append(problems, problem)
+		})
+		if len(problems) > maxProblemsPerResource {
+			problems = append(problems[:maxProblemsPerResource], fmt.Sprintf("and %d more", len(problems)-maxProblemsPerResource))
+		}
+		if len(problems) > 0 {
+			check.Problems = append(check.Problems, fmt.Sprintf("%s %s/%s does not match the schema of KubeRay %s: %s", kind, resource.GetNamespace(), resource.GetName(), Version, strings.Join(problems, "; ")))
+		}
+	}
+	return nil
+}
+
+// crdVersions returns the versions of the CRD by name
+func crdVersions(crd *unstructured.Unstructured) (map[string]crdVersion, error) {
+	items, _, err := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	if err != nil {
+		return nil, err
+	}
+	versions := map[string]crdVersion{}
+	for _, item := range items {
+		version, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := version["name"].(string)
+		served, _ := version["served"].(bool)
+		storage, _ := version["storage"].(bool)
+		specSchema, _, _ := unstructured.NestedMap(version, "schema", "openAPIV3Schema", "properties", "spec")
+		versions[name] = crdVersion{specSchema: specSchema, served: served, storage: storage}
+	}
+	return versions, nil
+}
+
+func sortedVersionNames(versions map[string]crdVersion) []string {
+	names := make([]string, 0, len(versions))
+	for name := range versions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkSchema reports the fields of value that the structural OpenAPI schema would prune and the required fields that
+// are missing. Fields of objects that preserve unknown fields are not checked.
+func checkSchema(openAPISchema map[string]interface{}, value interface{}, path string, report func(problem string)) {
+	if preserve, _ := openAPISchema["x-kubernetes-preserve-unknown-fields"].(bool); preserve {
+		return
+	}
+	switch value := value.(type) {
+	case map[string]interface{}:
+		properties, hasProperties := openAPISchema["properties"].(map[string]interface{})
+		required, _ := openAPISchema["required"].([]interface{})
+		for _, item := range required {
+			if field, ok := item.(string); ok {
+				if _, found := value[field]; !found {
+					report(fmt.Sprintf("required field %s.%s is missing", path, field))
+				}
+			}
+		}
+		fields := make([]string, 0, len(value))
+		for field := range value {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			if propertySchema, ok := properties[field].(map[string]interface{}); ok {
+				checkSchema(propertySchema, value[field], path+"."+field, report)
+			} else if additionalSchema, ok := openAPISchema["additionalProperties"].(map[string]interface{}); ok {
+				checkSchema(additionalSchema, value[field], path+"."+field, report)
+			} else if hasProperties {
+				report(fmt.Sprintf("unknown field %s.%s would be pruned", path, field))
+			}
+		}
+	case []interface{}:
+		itemSchema, ok := openAPISchema["items"].(map[string]interface{})
+		if !ok {
+			return
+		}
+		for i, item := range value {
+			checkSchema(itemSchema, item, fmt.Sprintf("%s[%d]", path, i), report)
+		}
+	}
+}
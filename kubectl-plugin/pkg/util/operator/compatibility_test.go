@@ -0,0 +1,218 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+)
+
+var testSpecSchema = map[string]interface{}{
+	"type":     "object",
+	"required": []interface{}{"headGroupSpec"},
+	"properties": map[string]interface{}{
+		"rayVersion": map[string]interface{}{"type": "string"},
+		"headGroupSpec": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"rayStartParams": map[string]interface{}{
+					"type":                 "object",
+					"additionalProperties": map[string]interface{}{"type": "string"},
+				},
+				"template": map[string]interface{}{
+					"type":                                 "object",
+					"x-kubernetes-preserve-unknown-fields": true,
+				},
+			},
+		},
+		"workerGroupSpecs": map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"groupName"},
+				"properties": map[string]interface{}{
+					"groupName": map[string]interface{}{"type": "string"},
+				},
+			},
+		},
+	},
+}
+
+func newTestCRD(storedVersions []string, versions ...map[string]interface{}) *unstructured.Unstructured {
+	items := make([]interface{}, 0, len(versions))
+	for _, version := range versions {
+		items = append(items, version)
+	}
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": "rayclusters.ray.io"},
+		"spec": map[string]interface{}{
+			"group":    "ray.io",
+			"names":    map[string]interface{}{"plural": "rayclusters", "kind": "RayCluster"},
+			"versions": items,
+		},
+	}}
+	if storedVersions != nil {
+		stored := make([]interface{}, 0, len(storedVersions))
+		for _, storedVersion := range storedVersions {
+			stored = append(stored, storedVersion)
+		}
+		crd.Object["status"] = map[string]interface{}{"storedVersions": stored}
+	}
+	return crd
+}
+
+func newTestCRDVersion(name string, served, storage bool) map[string]interface{} {
+	return map[string]interface{}{
+		"name":    name,
+		"served":  served,
+		"storage": storage,
+		"schema": map[string]interface{}{
+			"openAPIV3Schema": map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{"spec": runtime.DeepCopyJSONValue(testSpecSchema)},
+			},
+		},
+	}
+}
+
+func newTestRayCluster(name string, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "ray.io/v1",
+		"kind":       "RayCluster",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+		"spec":       spec,
+	}}
+}
+
+func newTestDynamicClient(objects ...runtime.Object) *dynamicFake.FakeDynamicClient {
+	return dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		CRDGVR: "CustomResourceDefinitionList",
+		{Group: "ray.io", Version: "v1", Resource: "rayclusters"}: "RayClusterList",
+	}, objects...)
+}
+
+func TestCheckUpgrade(t *testing.T) {
+	newCRD := newTestCRD(nil, newTestCRDVersion("v1", true, true))
+	validSpec := map[string]interface{}{
+		"rayVersion": "2.37.0",
+		"headGroupSpec": map[string]interface{}{
+			"rayStartParams": map[string]interface{}{"num-cpus": "1"},
+			"template":       map[string]interface{}{"spec": map[string]interface{}{"containers": []interface{}{}}},
+		},
+		"workerGroupSpecs": []interface{}{map[string]interface{}{"groupName": "cpu"}},
+	}
+
+	tests := []struct {
+		name             string
+		installedVersion string
+		objects          []runtime.Object
+		expectProblems   []string
+		expectWarnings   []string
+	}{
+		{
+			name: "not installed",
+		},
+		{
+			name:             "compatible custom resources",
+			installedVersion: "v1.1.0",
+			objects: []runtime.Object{
+				newTestCRD([]string{"v1"}, newTestCRDVersion("v1", true, true), newTestCRDVersion("v1alpha1", true, false)),
+				newTestRayCluster("valid", validSpec),
+			},
+			expectWarnings: []string{"KubeRay " + Version + " no longer serves version v1alpha1 of CRD rayclusters.ray.io, clients that use it will fail"},
+		},
+		{
+			name: "stored version no longer served",
+			objects: []runtime.Object{
+				newTestCRD([]string{"v1alpha1", "v1"}, newTestCRDVersion("v1", true, true), newTestCRDVersion("v1alpha1", true, false)),
+			},
+			expectProblems: []string{"CRD rayclusters.ray.io stores objects in version v1alpha1, which KubeRay " + Version + " does not serve. Migrate them to the storage version and remove v1alpha1 from status.storedVersions first"},
+			expectWarnings: []string{"KubeRay " + Version + " no longer serves version v1alpha1 of CRD rayclusters.ray.io, clients that use it will fail"},
+		},
+		{
+			name: "custom resource does not match the new schema",
+			objects: []runtime.Object{
+				newTestCRD([]string{"v1"}, newTestCRDVersion("v1", true, true)),
+				newTestRayCluster("invalid", map[string]interface{}{
+					"enableInTreeAutoscaling": true,
+					"workerGroupSpecs":        []interface{}{map[string]interface{}{"replicas": int64(1)}},
+				}),
+			},
+			expectProblems: []string{"RayCluster default/invalid does not match the schema of KubeRay " + Version + ": required field spec.headGroupSpec is missing; unknown field spec.enableInTreeAutoscaling would be pruned; required field spec.workerGroupSpecs[0].groupName is missing; unknown field spec.workerGroupSpecs[0].replicas would be pruned"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			check, err := CheckUpgrade(context.Background(), newTestDynamicClient(tc.objects...), tc.installedVersion, []*unstructured.Unstructured{newCRD})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectProblems, check.Problems)
+			assert.Equal(t, tc.expectWarnings, check.Warnings)
+		})
+	}
+}
+
+func TestCheckDowngrade(t *testing.T) {
+	tests := []struct {
+		name             string
+		installedVersion string
+		embeddedVersion  string
+		expectProblems   []string
+		expectWarnings   []string
+	}{
+		{
+			name:            "not installed",
+			embeddedVersion: "v1.2.2",
+		},
+		{
+			name:             "upgrade",
+			installedVersion: "v1.1.0",
+			embeddedVersion:  "v1.2.2",
+		},
+		{
+			name:             "downgrade",
+			installedVersion: "v9.0.0",
+			embeddedVersion:  "v1.2.2",
+			expectProblems:   []string{"KubeRay operator v9.0.0 is newer than v1.2.2, downgrading it is not supported"},
+		},
+		{
+			name:             "unknown installed version",
+			installedVersion: "dev",
+			embeddedVersion:  "v1.2.2",
+			expectWarnings:   []string{"unable to compare the installed KubeRay operator version dev with v1.2.2"},
+		},
+		{
+			name:             "nightly",
+			installedVersion: "v9.0.0",
+			embeddedVersion:  "nightly",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			check := &UpgradeCheck{}
+			checkDowngrade(tc.installedVersion, tc.embeddedVersion, check)
+			assert.Equal(t, tc.expectProblems, check.Problems)
+			assert.Equal(t, tc.expectWarnings, check.Warnings)
+		})
+	}
+}
+
+func TestCheckSchemaLimitsProblems(t *testing.T) {
+	spec := map[string]interface{}{"headGroupSpec": map[string]interface{}{}}
+	for _, field := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		spec[field] = true
+	}
+	newCRD := newTestCRD(nil, newTestCRDVersion("v1", true, true))
+	dynamicClient := newTestDynamicClient(newTestCRD([]string{"v1"}, newTestCRDVersion("v1", true, true)), newTestRayCluster("invalid", spec))
+
+	check, err := CheckUpgrade(context.Background(), dynamicClient, "", []*unstructured.Unstructured{newCRD})
+	require.NoError(t, err)
+	require.Len(t, check.Problems, 1)
+	assert.Contains(t, check.Problems[0], "unknown field spec.e would be pruned; and 2 more")
+}
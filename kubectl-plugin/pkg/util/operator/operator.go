@@ -0,0 +1,167 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/client"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
+)
+
+const (
+	// Version is the KubeRay release of the embedded manifests, or nightly for the manifests of master, see
+	// `make kubectl-plugin` in ray-operator
+	Version = "nightly"
+	// Namespace and Name of the operator Deployment of the embedded manifests
+	Namespace = "ray-system"
+	Name      = "kuberay-operator"
+	// LabelSelector selects the operator Deployments installed with the manifests or the Helm chart
+	LabelSelector = "app.kubernetes.io/name in (kuberay-operator,kuberay)"
+	// fieldManager owns the fields of the applied resources
+	fieldManager = "kubectl-ray"
+)
+
+// manifests are the pinned manifests of the KubeRay operator and its CRDs
+//
+//go:embed manifests/kuberay-operator.yaml
+var manifests []byte
+
+var (
+	CRDGVR = schema.GroupVersionResource{
+		Group:    "apiextensions.k8s.io",
+		Version:  "v1",
+		Resource: "customresourcedefinitions",
+	}
+	crdGroupKind  = schema.GroupKind{Group: CRDGVR.Group, Kind: "CustomResourceDefinition"}
+	deploymentGVR = appsv1.SchemeGroupVersion.WithResource("deployments")
+)
+
+// Manifests returns the objects of the embedded manifests, in the order in which they are applied
+func Manifests() ([]*unstructured.Unstructured, error) {
+	objects, err := decodeManifests(manifests)
+	if err != nil {
+		return nil, fmt.Errorf("invalid embedded KubeRay operator manifests: %w", err)
+	}
+	return objects, nil
+}
+
+// ManifestsYAML returns the embedded manifests, e.g. to review or apply them with other tools
+func ManifestsYAML() []byte {
+	return manifests
+}
+
+// CRDs returns the CRDs of the objects
+func CRDs(objects []*unstructured.Unstructured) []*unstructured.Unstructured {
+	var crds []*unstructured.Unstructured
+	for _, object := range objects {
+		if object.GroupVersionKind().GroupKind() == crdGroupKind {
+			crds = append(crds, object)
+		}
+	}
+	return crds
+}
+
+// decodeManifests decodes the objects of a multi-document YAML manifest
+func decodeManifests(manifests []byte) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifests), 4096)
+	for {
+		object := &unstructured.Unstructured{}
+		if err := decoder.Decode(&object.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objects, nil
+			}
+			return nil, err
+		}
+		if len(object.Object) == 0 {
+			continue
+		}
+		if object.GetKind() == "" || object.GetName() == "" {
+			return nil, fmt.Errorf("object without kind or name in manifests")
+		}
+		objects = append(objects, object)
+	}
+}
+
+// Deployments returns the KubeRay operator Deployments of all namespaces
+func Deployments(ctx context.Context, kubeClient kubernetes.Interface) ([]appsv1.Deployment, error) {
+	deployments, err := kubeClient.AppsV1().Deployments("").List(ctx, metav1.ListOptions{LabelSelector: LabelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list KubeRay operator deployments: %w", err)
+	}
+	return deployments.Items, nil
+}
+
+// IsManaged reports whether the Deployment was installed from the embedded manifests, rather than e.g. with Helm
+func IsManaged(deployment *appsv1.Deployment) bool {
+	return deployment.Namespace == Namespace && deployment.Name == Name
+}
+
+// Apply creates or updates the objects with server-side apply. The conflicts with other field managers are forced, so
+// that the objects are upgraded to the embedded version. With dryRun, the API server validates the objects without
+// persisting them.
+func Apply(ctx context.Context, dynamicClient dynamic.Interface, mapper meta.RESTMapper, objects []*unstructured.Unstructured, dryRun bool) error {
+	applyOptions := metav1.ApplyOptions{FieldManager: fieldManager, Force: true}
+	if dryRun {
+		applyOptions.DryRun = []string{metav1.DryRunAll}
+	}
+	for _, object := range objects {
+		gvk := object.GroupVersionKind()
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return fmt.Errorf("failed to map %s %s: %w", gvk.Kind, object.GetName(), err)
+		}
+		var resource dynamic.ResourceInterface = dynamicClient.Resource(mapping.Resource)
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			resource = dynamicClient.Resource(mapping.Resource).Namespace(object.GetNamespace())
+		}
+		if _, err := resource.Apply(ctx, object.GetName(), object, applyOptions); err != nil {
+			return fmt.Errorf("failed to apply %s %s: %w", gvk.Kind, object.GetName(), err)
+		}
+		logging.V(2).Infof("Applied %s %s", gvk.Kind, object.GetName())
+	}
+	return nil
+}
+
+// WaitUntilAvailable waits until the CRDs of the objects are established and the operator Deployment is available
+func WaitUntilAvailable(ctx context.Context, dynamicClient dynamic.Interface, objects []*unstructured.Unstructured) error {
+	for _, crd := range CRDs(objects) {
+		if _, err := client.WaitForResource(ctx, dynamicClient, CRDGVR, "", crd.GetName(), HasTrueCondition("Established")); err != nil {
+			return fmt.Errorf("CRD %s is not established: %w", crd.GetName(), err)
+		}
+	}
+	if _, err := client.WaitForResource(ctx, dynamicClient, deploymentGVR, Namespace, Name, HasTrueCondition(string(appsv1.DeploymentAvailable))); err != nil {
+		return fmt.Errorf("KubeRay operator %s/%s is not available: %w", Namespace, Name, err)
+	}
+	return nil
+}
+
+// HasTrueCondition returns a condition function that reports whether the status condition of the given type is true
+func HasTrueCondition(conditionType string) client.UnstructuredConditionFunc {
+	return func(object *unstructured.Unstructured) (bool, error) {
+		conditions, _, err := unstructured.NestedSlice(object.Object, "status", "conditions")
+		if err != nil {
+			return false, err
+		}
+		for _, item := range conditions {
+			condition, ok := item.(map[string]interface{})
+			if ok && condition["type"] == conditionType && condition["status"] == string(metav1.ConditionTrue) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
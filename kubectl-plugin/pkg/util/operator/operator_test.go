@@ -0,0 +1,102 @@
+package operator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestManifests(t *testing.T) {
+	objects, err := Manifests()
+	require.NoError(t, err)
+
+	var crds []string
+	for _, crd := range CRDs(objects) {
+		crds = append(crds, crd.GetName())
+	}
+	assert.ElementsMatch(t, []string{"rayclusters.ray.io", "rayjobs.ray.io", "rayservices.ray.io", "rayworkergroups.ray.io"}, crds)
+
+	var deployment *unstructured.Unstructured
+	for _, object := range objects {
+		if object.GetKind() == "Deployment" {
+			deployment = object
+		}
+	}
+	require.NotNil(t, deployment)
+	assert.Equal(t, Name, deployment.GetName())
+	assert.Equal(t, Namespace, deployment.GetNamespace())
+
+	containers, _, err := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+	require.NoError(t, err)
+	require.Len(t, containers, 1)
+	image := containers[0].(map[string]interface{})["image"].(string)
+	assert.True(t, strings.HasSuffix(image, ":"+Version), "operator image %s is not pinned to %s", image, Version)
+}
+
+func TestApply(t *testing.T) {
+	objects, err := decodeManifests([]byte(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: ray-system
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: kuberay-operator
+  namespace: ray-system
+`))
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, meta.RESTScopeRoot)
+	mapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), meta.RESTScopeNamespace)
+
+	for _, dryRun := range []bool{false, true} {
+		dynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme())
+		var applied []string
+		dynamicClient.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			patchAction := action.(k8stesting.PatchAction)
+			applied = append(applied, patchAction.GetResource().Resource+" "+patchAction.GetNamespace()+"/"+patchAction.GetName()+" "+string(patchAction.GetPatchType()))
+			return true, &unstructured.Unstructured{Object: map[string]interface{}{}}, nil
+		})
+
+		require.NoError(t, Apply(context.Background(), dynamicClient, mapper, objects, dryRun))
+		assert.Equal(t, []string{
+			"namespaces /ray-system application/apply-patch+yaml",
+			"deployments ray-system/kuberay-operator application/apply-patch+yaml",
+		}, applied)
+	}
+
+	_, err = decodeManifests([]byte("apiVersion: v1\nkind: Namespace\n"))
+	assert.Error(t, err)
+}
+
+func TestHasTrueCondition(t *testing.T) {
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "NamesAccepted", "status": "True"},
+				map[string]interface{}{"type": "Established", "status": "False"},
+			},
+		},
+	}}
+	established, err := HasTrueCondition("Established")(crd)
+	require.NoError(t, err)
+	assert.False(t, established)
+
+	namesAccepted, err := HasTrueCondition("NamesAccepted")(crd)
+	require.NoError(t, err)
+	assert.True(t, namesAccepted)
+}
@@ -0,0 +1,155 @@
+package portforward
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/kubectl/pkg/cmd/portforward"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
+)
+
+const (
+	// podRunningTimeout is how long to wait for a running Pod behind the target on each connection attempt
+	podRunningTimeout = 60 * time.Second
+	// DefaultMaxRetries is the number of consecutive failed reconnection attempts before giving up
+	DefaultMaxRetries = 8
+)
+
+// TargetFunc returns the resource to forward ports to, e.g. "service/NAME". It is called before every connection
+// attempt, so a restarted head Pod, or a head service that changed, is picked up when reconnecting.
+type TargetFunc func(ctx context.Context) (string, error)
+
+// forwardFunc forwards ports to the target until the connection is lost or ctx is done. It calls ready once the
+// ports are forwarded, and returns nil if port forwarding was stopped on purpose.
+type forwardFunc func(ctx context.Context, target string, ports []string, ready func()) error
+
+// ReconnectingPortForwarder forwards local ports to a target and re-establishes the tunnel with exponential backoff
+// when the connection drops, e.g. on a flaky network or when the head Pod restarts.
+type ReconnectingPortForwarder struct {
+	streams genericiooptions.IOStreams
+	target  TargetFunc
+	forward forwardFunc
+	// OnReady is called every time the ports are forwarded, including after reconnecting
+	OnReady func(target string)
+	ports   []string
+	// Backoff controls the delay between reconnection attempts
+	Backoff wait.Backoff
+	// MaxRetries is the number of consecutive failed reconnection attempts before Run gives up
+	MaxRetries int
+}
+
+// DefaultBackoff returns the backoff used between reconnection attempts: 1s doubling up to 30s
+func DefaultBackoff() wait.Backoff {
+	return wait.Backoff{
+		Duration: 1 * time.Second,
+		Factor:   2,
+		Jitter:   0.1,
+		Cap:      30 * time.Second,
+		// Steps must be large enough for the delay to reach Cap
+		Steps: DefaultMaxRetries,
+	}
+}
+
+// NewReconnectingPortForwarder returns a port forwarder for the given ports in "[LOCAL_PORT:]REMOTE_PORT" format
+func NewReconnectingPortForwarder(factory cmdutil.Factory, streams genericiooptions.IOStreams, target TargetFunc, ports []string) *ReconnectingPortForwarder {
+	return &ReconnectingPortForwarder{
+		streams:    streams,
+		target:     target,
+		ports:      ports,
+		forward:    kubectlForward(factory, streams),
+		Backoff:    DefaultBackoff(),
+		MaxRetries: DefaultMaxRetries,
+	}
+}
+
+// Run forwards the ports until ctx is done or port forwarding is interrupted. If the first connection cannot be
+// established, the error is returned right away. Once connected, a dropped connection is retried until MaxRetries
+// consecutive attempts have failed.
+func (f *ReconnectingPortForwarder) Run(ctx context.Context) error {
+	connectedOnce := false
+	failedAttempts := 0
+	backoff := f.Backoff
+	for {
+		connected := false
+		target, err := f.target(ctx)
+		if err == nil {
+			err = f.forward(ctx, target, f.ports, func() {
+				connected = true
+				if f.OnReady != nil {
+					f.OnReady(target)
+				}
+			})
+			if err == nil {
+				// Port forwarding was stopped on purpose
+				return nil
+			}
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		if !connectedOnce && !connected {
+			return err
+		}
+
+		connectedOnce = true
+		if connected {
+			failedAttempts = 0
+			backoff = f.Backoff
+		}
+		failedAttempts++
+		if failedAttempts > f.MaxRetries {
+			return fmt.Errorf("giving up port forwarding after %d failed attempts: %w", f.MaxRetries, err)
+		}
+
+		delay := backoff.Step()
+		logging.New(f.streams.ErrOut).Warnf("port forwarding lost: %v. Reconnecting in %s (attempt %d/%d)...", err, delay.Round(time.Millisecond), failedAttempts, f.MaxRetries)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+	}
+}
+
+// kubectlForward forwards ports the same way as `kubectl port-forward`, but returns errors instead of exiting
+// and stops when ctx is done.
+func kubectlForward(factory cmdutil.Factory, streams genericiooptions.IOStreams) forwardFunc {
+	return func(ctx context.Context, target string, ports []string, ready func()) error {
+		// PortForwardOptions.Complete reads the Pod running timeout from the command flags
+		cmd := &cobra.Command{}
+		cmdutil.AddPodRunningTimeoutFlag(cmd, podRunningTimeout)
+
+		opts := portforward.NewDefaultPortForwardOptions(streams)
+		opts.Address = []string{"localhost"}
+		if err := opts.Complete(factory, cmd, append([]string{target}, ports...)); err != nil {
+			return err
+		}
+		if err := opts.Validate(); err != nil {
+			return err
+		}
+
+		forwardCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case <-opts.ReadyChannel:
+				ready()
+			case <-forwardCtx.Done():
+			}
+		}()
+		err := opts.RunPortForwardContext(forwardCtx)
+		cancel()
+		wg.Wait()
+		return err
+	}
+}
@@ -0,0 +1,109 @@
+package portforward
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+// fakeResult is the outcome of a single connection attempt of the fake forwardFunc
+type fakeResult struct {
+	err       error
+	connected bool
+}
+
+func newTestForwarder(results []fakeResult) (*ReconnectingPortForwarder, *[]string) {
+	testStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+	var targets []string
+	calls := 0
+	f := &ReconnectingPortForwarder{
+		streams: testStreams,
+		target: func(_ context.Context) (string, error) {
+			return "service/test-svc", nil
+		},
+		forward: func(_ context.Context, target string, _ []string, ready func()) error {
+			targets = append(targets, target)
+			result := results[calls]
+			calls++
+			if result.connected {
+				ready()
+			}
+			return result.err
+		},
+		Backoff:    wait.Backoff{Duration: time.Millisecond, Factor: 2, Steps: 3},
+		MaxRetries: 3,
+	}
+	return f, &targets
+}
+
+func TestReconnectingPortForwarderRun(t *testing.T) {
+	lostConnection := errors.New("lost connection to pod")
+
+	t.Run("stopped on purpose", func(t *testing.T) {
+		f, targets := newTestForwarder([]fakeResult{{connected: true}})
+		assert.NoError(t, f.Run(context.Background()))
+		assert.Len(t, *targets, 1)
+	})
+
+	t.Run("first connection fails", func(t *testing.T) {
+		f, targets := newTestForwarder([]fakeResult{{err: errors.New("pod not running")}})
+		assert.EqualError(t, f.Run(context.Background()), "pod not running")
+		assert.Len(t, *targets, 1)
+	})
+
+	t.Run("reconnects after a dropped connection", func(t *testing.T) {
+		f, targets := newTestForwarder([]fakeResult{
+			{connected: true, err: lostConnection},
+			{err: errors.New("pod not running")},
+			{connected: true},
+		})
+		readyCount := 0
+		f.OnReady = func(_ string) { readyCount++ }
+		assert.NoError(t, f.Run(context.Background()))
+		assert.Len(t, *targets, 3)
+		assert.Equal(t, 2, readyCount)
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		f, targets := newTestForwarder([]fakeResult{
+			{connected: true, err: lostConnection},
+			{err: lostConnection},
+			{err: lostConnection},
+			{err: lostConnection},
+		})
+		err := f.Run(context.Background())
+		assert.EqualError(t, err, "giving up port forwarding after 3 failed attempts: lost connection to pod")
+		assert.Len(t, *targets, 4)
+	})
+
+	t.Run("successful reconnection resets retries", func(t *testing.T) {
+		f, targets := newTestForwarder([]fakeResult{
+			{connected: true, err: lostConnection},
+			{err: lostConnection},
+			{err: lostConnection},
+			{connected: true, err: lostConnection},
+			{err: lostConnection},
+			{connected: true},
+		})
+		assert.NoError(t, f.Run(context.Background()))
+		assert.Len(t, *targets, 6)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		f, targets := newTestForwarder([]fakeResult{{connected: true, err: lostConnection}})
+		f.forward = func(_ context.Context, target string, _ []string, ready func()) error {
+			*targets = append(*targets, target)
+			ready()
+			cancel()
+			return lostConnection
+		}
+		assert.NoError(t, f.Run(ctx))
+		assert.Len(t, *targets, 1)
+	})
+}
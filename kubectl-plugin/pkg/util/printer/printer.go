@@ -0,0 +1,87 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/yaml"
+)
+
+// Output formats of the -o/--output flag
+const (
+	JSON = "json"
+	YAML = "yaml"
+	Name = "name"
+)
+
+// OutputFlags is the -o/--output flag of commands that print a human readable table by default,
+// and machine-readable output for the given formats
+type OutputFlags struct {
+	Format  string
+	formats []string
+}
+
+// NewOutputFlags returns the output flags accepting the given formats
+func NewOutputFlags(formats ...string) *OutputFlags {
+	return &OutputFlags{formats: formats}
+}
+
+func (f *OutputFlags) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.Format, "output", "o", f.Format, fmt.Sprintf("Output format. One of: (%s)", strings.Join(f.formats, ", ")))
+}
+
+func (f *OutputFlags) Validate() error {
+	if f.Format != "" && !slices.Contains(f.formats, f.Format) {
+		return fmt.Errorf("unsupported output format %q, must be one of: %s", f.Format, strings.Join(f.formats, ", "))
+	}
+	return nil
+}
+
+// IsStructured returns whether machine-readable output is requested. Commands should then only print the result
+// to stdout and report progress on stderr.
+func (f *OutputFlags) IsStructured() bool {
+	return f.Format != ""
+}
+
+// PrintObj prints a Kubernetes object, or an unstructured list, in the requested format
+func (f *OutputFlags) PrintObj(obj runtime.Object, out io.Writer) error {
+	var printer printers.ResourcePrinter
+	switch f.Format {
+	case JSON:
+		printer = &printers.JSONPrinter{}
+	case YAML:
+		printer = &printers.YAMLPrinter{}
+	case Name:
+		printer = &printers.NamePrinter{}
+	default:
+		return fmt.Errorf("unsupported output format %q", f.Format)
+	}
+	return printer.PrintObj(obj, out)
+}
+
+// PrintData prints data that is not a Kubernetes object, e.g. Ray nodes reported by the Ray dashboard, as JSON or YAML
+func (f *OutputFlags) PrintData(data interface{}, out io.Writer) error {
+	var output []byte
+	var err error
+	switch f.Format {
+	case JSON:
+		// Indent like the JSON printer of Kubernetes objects
+		output, err = json.MarshalIndent(data, "", "    ")
+		output = append(output, '\n')
+	case YAML:
+		output, err = yaml.Marshal(data)
+	default:
+		return fmt.Errorf("unsupported output format %q", f.Format)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(output)
+	return err
+}
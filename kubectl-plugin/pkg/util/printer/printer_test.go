@@ -0,0 +1,123 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newRayJob(name string) unstructured.Unstructured {
+	return unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "ray.io/v1",
+			"kind":       "RayJob",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "default",
+			},
+		},
+	}
+}
+
+func TestOutputFlagsValidate(t *testing.T) {
+	cmd := &cobra.Command{}
+	outputFlags := NewOutputFlags(JSON, YAML)
+	outputFlags.AddFlags(cmd)
+
+	assert.Nil(t, outputFlags.Validate())
+	assert.False(t, outputFlags.IsStructured())
+
+	assert.Nil(t, cmd.Flags().Set("output", "json"))
+	assert.Nil(t, outputFlags.Validate())
+	assert.True(t, outputFlags.IsStructured())
+
+	assert.Nil(t, cmd.Flags().Set("output", "name"))
+	assert.EqualError(t, outputFlags.Validate(), `unsupported output format "name", must be one of: json, yaml`)
+}
+
+func TestPrintObj(t *testing.T) {
+	rayJob := newRayJob("rayjob-sample")
+	rayJobList := &unstructured.UnstructuredList{
+		Object: map[string]interface{}{"apiVersion": "ray.io/v1", "kind": "RayJobList"},
+		Items:  []unstructured.Unstructured{newRayJob("rayjob-a"), newRayJob("rayjob-b")},
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+		list     bool
+	}{
+		{
+			name:   "json",
+			format: JSON,
+			expected: `{
+    "apiVersion": "ray.io/v1",
+    "kind": "RayJob",
+    "metadata": {
+        "name": "rayjob-sample",
+        "namespace": "default"
+    }
+}
+`,
+		},
+		{
+			name:   "yaml",
+			format: YAML,
+			expected: `apiVersion: ray.io/v1
+kind: RayJob
+metadata:
+  name: rayjob-sample
+  namespace: default
+`,
+		},
+		{
+			name:     "name",
+			format:   Name,
+			expected: "rayjob.ray.io/rayjob-sample\n",
+		},
+		{
+			name:     "name of list",
+			format:   Name,
+			list:     true,
+			expected: "rayjob.ray.io/rayjob-a\nrayjob.ray.io/rayjob-b\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			outputFlags := &OutputFlags{Format: tc.format}
+			var err error
+			if tc.list {
+				err = outputFlags.PrintObj(rayJobList, &out)
+			} else {
+				err = outputFlags.PrintObj(rayJob.DeepCopy(), &out)
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestPrintData(t *testing.T) {
+	data := []struct {
+		NodeID string `json:"nodeId"`
+	}{{NodeID: "abc"}}
+
+	var out bytes.Buffer
+	err := (&OutputFlags{Format: JSON}).PrintData(data, &out)
+	assert.Nil(t, err)
+	assert.Equal(t, "[\n    {\n        \"nodeId\": \"abc\"\n    }\n]\n", out.String())
+
+	out.Reset()
+	err = (&OutputFlags{Format: YAML}).PrintData(data, &out)
+	assert.Nil(t, err)
+	assert.Equal(t, "- nodeId: abc\n", out.String())
+
+	err = (&OutputFlags{Format: Name}).PrintData(data, &out)
+	assert.NotNil(t, err)
+}
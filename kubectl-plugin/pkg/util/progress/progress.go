@@ -0,0 +1,195 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"k8s.io/cli-runtime/pkg/printers"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
+)
+
+// Mode is how the progress of a command is reported
+type Mode string
+
+const (
+	// Auto reports Fancy progress on a terminal and Plain progress otherwise, or log records with --log-format json
+	Auto Mode = ""
+	// Plain prints a line when a step starts, which is suited for logs
+	Plain Mode = "plain"
+	// Fancy shows a spinner with the elapsed time on the current step and a check mark once it completes
+	Fancy Mode = "fancy"
+	// None does not report progress
+	None Mode = "none"
+	// logMode reports the steps and their output as log records with a progress attribute, which automation can parse
+	logMode Mode = "log"
+)
+
+const spinnerInterval = 100 * time.Millisecond
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// ParseMode parses the value of a --progress flag
+func ParseMode(value string) (Mode, error) {
+	switch mode := Mode(value); mode {
+	case Auto, Plain, Fancy, None:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid --progress %q, must be one of plain, fancy or none", value)
+	}
+}
+
+// Reporter reports the steps of a long-running command. It is safe for concurrent use.
+type Reporter struct {
+	out     io.Writer
+	log     *logging.Logger
+	stop    chan struct{}
+	started time.Time
+	step    string
+	mode    Mode
+	frame   int
+	mu      sync.Mutex
+}
+
+// NewReporter returns a Reporter that writes to out
+func NewReporter(out io.Writer, mode Mode) *Reporter {
+	if mode == Auto {
+		switch {
+		case logging.CurrentFormat() == logging.JSON:
+			mode = logMode
+		case printers.IsTerminal(out):
+			mode = Fancy
+		default:
+			mode = Plain
+		}
+	}
+	return &Reporter{out: out, log: logging.New(out), mode: mode}
+}
+
+// Step completes the current step and starts the next one
+func (r *Reporter) Step(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.finish("✓")
+
+	r.step = fmt.Sprintf(format, args...)
+	r.started = time.Now()
+	switch r.mode {
+	case Plain:
+		fmt.Fprintf(r.out, "%s...\n", r.step)
+	case Fancy:
+		r.draw()
+		r.stop = make(chan struct{})
+		go r.spin(r.stop)
+	case logMode:
+		r.log.InfoS(r.step, "progress", "step")
+	}
+}
+
+// Done completes the current step
+func (r *Reporter) Done() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.finish("✓")
+}
+
+// Fail marks the current step as failed. The error itself is reported by the command.
+func (r *Reporter) Fail() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.finish("✗")
+}
+
+// Close fails the current step if it was not completed, so that no spinner is left behind when a command returns early
+func (r *Reporter) Close() {
+	r.Fail()
+}
+
+// Info prints a detail of the current step, e.g. why its pods are not scheduled
+func (r *Reporter) Info(format string, args ...interface{}) {
+	switch r.mode {
+	case None:
+		return
+	case logMode:
+		r.log.InfoS(fmt.Sprintf(format, args...), "progress", "info")
+		return
+	}
+	r.Output("  " + fmt.Sprintf(format, args...))
+}
+
+// Output prints a line of output of the command, e.g. a log line of the Ray job, which is shown in every mode
+func (r *Reporter) Output(line string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mode == logMode {
+		r.log.InfoS(line, "progress", "output")
+		return
+	}
+	if r.mode == Fancy && r.step != "" {
+		fmt.Fprintf(r.out, "\r\033[K%s\n", line)
+		r.draw()
+		return
+	}
+	fmt.Fprintln(r.out, line)
+}
+
+// Verbose returns a writer for details that are only shown in plain mode, such as the messages of a port-forward
+func (r *Reporter) Verbose() io.Writer {
+	if r.mode == Plain {
+		return r.out
+	}
+	return io.Discard
+}
+
+// spin redraws the current step until stop is closed
+func (r *Reporter) spin(stop chan struct{}) {
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		r.mu.Lock()
+		select {
+		case <-stop:
+			// The step completed while waiting for the lock
+		default:
+			r.frame++
+			r.draw()
+		}
+		r.mu.Unlock()
+	}
+}
+
+// draw redraws the line of the current step. It must be called with the lock held.
+func (r *Reporter) draw() {
+	fmt.Fprintf(r.out, "\r\033[K%s %s (%s)", spinnerFrames[r.frame%len(spinnerFrames)], r.step, r.elapsed())
+}
+
+// finish ends the current step with the symbol. It must be called with the lock held.
+func (r *Reporter) finish(symbol string) {
+	if r.step == "" {
+		return
+	}
+	switch r.mode {
+	case Fancy:
+		close(r.stop)
+		fmt.Fprintf(r.out, "\r\033[K%s %s (%s)\n", symbol, r.step, r.elapsed())
+	case logMode:
+		status := "done"
+		if symbol == "✗" {
+			status = "failed"
+		}
+		r.log.InfoS(r.step, "progress", status, "elapsed", r.elapsed().String())
+	}
+	r.step = ""
+}
+
+func (r *Reporter) elapsed() time.Duration {
+	return time.Since(r.started).Truncate(time.Second)
+}
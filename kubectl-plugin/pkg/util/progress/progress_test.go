@@ -0,0 +1,111 @@
+package progress
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
+)
+
+func TestParseMode(t *testing.T) {
+	for _, value := range []string{"", "plain", "fancy", "none"} {
+		mode, err := ParseMode(value)
+		assert.Nil(t, err)
+		assert.Equal(t, Mode(value), mode)
+	}
+	_, err := ParseMode("quiet")
+	assert.EqualError(t, err, "invalid --progress \"quiet\", must be one of plain, fancy or none")
+}
+
+func reportSteps(reporter *Reporter) {
+	reporter.Step("Creating RayJob %s", "rayjob-sample")
+	reporter.Step("Waiting for RayCluster %s to be ready", "rayjob-sample-raycluster")
+	reporter.Info("pod %s: FailedScheduling", "rayjob-sample-raycluster-head")
+	reporter.Output("log line")
+	reporter.Fail()
+	reporter.Close()
+}
+
+func TestReporter(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     Mode
+		expected string
+	}{
+		{
+			name: "plain",
+			mode: Plain,
+			expected: "Creating RayJob rayjob-sample...\n" +
+				"Waiting for RayCluster rayjob-sample-raycluster to be ready...\n" +
+				"  pod rayjob-sample-raycluster-head: FailedScheduling\n" +
+				"log line\n",
+		},
+		{
+			name:     "none only prints output",
+			mode:     None,
+			expected: "log line\n",
+		},
+		{
+			name:     "auto is plain without a terminal",
+			mode:     Auto,
+			expected: "Creating RayJob rayjob-sample...\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			reportSteps(NewReporter(out, tc.mode))
+			assert.True(t, strings.HasPrefix(out.String(), tc.expected), out.String())
+		})
+	}
+}
+
+func TestReporterFancy(t *testing.T) {
+	out := &bytes.Buffer{}
+	reporter := NewReporter(out, Fancy)
+	// The spinner is not redrawn between the steps, so the output does not depend on timing
+	reportSteps(reporter)
+
+	assert.Equal(t, "\r\033[K⠋ Creating RayJob rayjob-sample (0s)"+
+		"\r\033[K✓ Creating RayJob rayjob-sample (0s)\n"+
+		"\r\033[K⠋ Waiting for RayCluster rayjob-sample-raycluster to be ready (0s)"+
+		"\r\033[K  pod rayjob-sample-raycluster-head: FailedScheduling\n"+
+		"\r\033[K⠋ Waiting for RayCluster rayjob-sample-raycluster to be ready (0s)"+
+		"\r\033[Klog line\n"+
+		"\r\033[K⠋ Waiting for RayCluster rayjob-sample-raycluster to be ready (0s)"+
+		"\r\033[K✗ Waiting for RayCluster rayjob-sample-raycluster to be ready (0s)\n", out.String())
+	assert.Equal(t, io.Discard, reporter.Verbose())
+}
+
+func TestReporterJSONLogFormat(t *testing.T) {
+	defer logging.Configure(0, logging.Text)
+	logging.Configure(0, logging.JSON)
+
+	out := &bytes.Buffer{}
+	reporter := NewReporter(out, Auto)
+	reportSteps(reporter)
+
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		record := map[string]interface{}{}
+		assert.Nil(t, json.Unmarshal([]byte(line), &record))
+		delete(record, "time")
+		delete(record, "elapsed")
+		records = append(records, record)
+	}
+	assert.Equal(t, []map[string]interface{}{
+		{"level": "INFO", "msg": "Creating RayJob rayjob-sample", "progress": "step"},
+		{"level": "INFO", "msg": "Creating RayJob rayjob-sample", "progress": "done"},
+		{"level": "INFO", "msg": "Waiting for RayCluster rayjob-sample-raycluster to be ready", "progress": "step"},
+		{"level": "INFO", "msg": "pod rayjob-sample-raycluster-head: FailedScheduling", "progress": "info"},
+		{"level": "INFO", "msg": "log line", "progress": "output"},
+		{"level": "INFO", "msg": "Waiting for RayCluster rayjob-sample-raycluster to be ready", "progress": "failed"},
+	}, records)
+	assert.Equal(t, io.Discard, reporter.Verbose())
+}
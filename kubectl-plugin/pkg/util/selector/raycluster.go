@@ -0,0 +1,43 @@
+package selector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util/logging"
+)
+
+// RayCluster returns the name of the RayCluster to use when none is given on the command line. The only RayCluster of
+// the namespace is used, and the user is prompted to select one when there are several and the streams are a terminal.
+// With nonInteractive set, or without a terminal, several RayClusters are an error listing their names instead.
+func RayCluster(ctx context.Context, dynamicClient dynamic.Interface, streams genericiooptions.IOStreams, namespace string, nonInteractive bool) (string, error) {
+	rayClusters, err := dynamicClient.Resource(util.RayClusterGVR).Namespace(namespace).List(ctx, v1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve RayClusters for namespace %s: %w", namespace, err)
+	}
+	names := make([]string, 0, len(rayClusters.Items))
+	for _, rayCluster := range rayClusters.Items {
+		names = append(names, rayCluster.GetName())
+	}
+	sort.Strings(names)
+
+	switch {
+	case len(names) == 0:
+		return "", fmt.Errorf("no RayCluster found in namespace %s", namespace)
+	case len(names) == 1:
+		logging.New(streams.ErrOut).Infof("Using RayCluster %s", names[0])
+		return names[0], nil
+	case nonInteractive || !IsInteractive(streams.In, streams.ErrOut):
+		return "", fmt.Errorf("namespace %s has %d RayClusters, specify one of: %s", namespace, len(names), strings.Join(names, ", "))
+	}
+
+	fmt.Fprintf(streams.ErrOut, "Namespace %s has %d RayClusters:\n", namespace, len(names))
+	return Select(streams.In, streams.ErrOut, "Select a RayCluster", names)
+}
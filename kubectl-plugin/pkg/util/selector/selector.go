@@ -0,0 +1,108 @@
+package selector
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+// IsInteractive returns whether the user can be prompted, i.e. whether both the input and the output are terminals.
+// It is a variable so that tests can simulate a terminal.
+var IsInteractive = func(in io.Reader, out io.Writer) bool {
+	return printers.IsTerminal(in) && printers.IsTerminal(out)
+}
+
+// Select prompts the user on out to pick one of the items, which are listed with a number, and reads the answer from in.
+// The answer is either the number of an item, the exact name of an item, or a filter that narrows down the list to the
+// items fuzzily matching it. The item is selected as soon as a single one matches, and an empty answer lists all items again.
+func Select(in io.Reader, out io.Writer, prompt string, items []string) (string, error) {
+	if len(items) == 0 {
+		return "", fmt.Errorf("nothing to select from")
+	}
+
+	reader := bufio.NewReader(in)
+	candidates := items
+	for {
+		for i, item := range candidates {
+			fmt.Fprintf(out, "%3d) %s\n", i+1, item)
+		}
+		fmt.Fprintf(out, "%s [1-%d or filter]: ", prompt, len(candidates))
+
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("failed to read the selection: %w", err)
+		}
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			if err != nil {
+				fmt.Fprintln(out)
+				return "", fmt.Errorf("no selection was made")
+			}
+			candidates = items
+			continue
+		}
+
+		if index, convErr := strconv.Atoi(answer); convErr == nil {
+			if index >= 1 && index <= len(candidates) {
+				return candidates[index-1], nil
+			}
+			fmt.Fprintf(out, "%d is not between 1 and %d\n", index, len(candidates))
+		} else {
+			for _, item := range items {
+				if item == answer {
+					return item, nil
+				}
+			}
+			matches := Filter(items, answer)
+			switch len(matches) {
+			case 0:
+				fmt.Fprintf(out, "Nothing matches %q\n", answer)
+			case 1:
+				return matches[0], nil
+			default:
+				candidates = matches
+			}
+		}
+
+		if err != nil {
+			fmt.Fprintln(out)
+			return "", fmt.Errorf("no selection was made")
+		}
+	}
+}
+
+// Filter returns the items fuzzily matching the pattern, ignoring case. The items containing the pattern come first,
+// followed by the items containing the characters of the pattern in order, e.g. "rcs" matches "raycluster-sample".
+func Filter(items []string, pattern string) []string {
+	pattern = strings.ToLower(pattern)
+	var substringMatches, subsequenceMatches []string
+	for _, item := range items {
+		lowerItem := strings.ToLower(item)
+		if strings.Contains(lowerItem, pattern) {
+			substringMatches = append(substringMatches, item)
+		} else if isSubsequence(pattern, lowerItem) {
+			subsequenceMatches = append(subsequenceMatches, item)
+		}
+	}
+	return append(substringMatches, subsequenceMatches...)
+}
+
+// isSubsequence returns whether the characters of pattern appear in s in the same order
+func isSubsequence(pattern, s string) bool {
+	patternRunes := []rune(pattern)
+	i := 0
+	for _, r := range s {
+		if i == len(patternRunes) {
+			break
+		}
+		if r == patternRunes[i] {
+			i++
+		}
+	}
+	return i == len(patternRunes)
+}
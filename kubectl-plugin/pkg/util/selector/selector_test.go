@@ -0,0 +1,116 @@
+package selector
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+)
+
+var testItems = []string{"raycluster-sample", "raycluster-gpu", "ml-training", "Serve-Cluster"}
+
+func TestFilter(t *testing.T) {
+	assert.Equal(t, []string{"raycluster-sample", "raycluster-gpu"}, Filter(testItems, "raycluster"))
+	assert.Equal(t, []string{"Serve-Cluster"}, Filter(testItems, "serve"))
+	// The substring matches come before the subsequence matches
+	assert.Equal(t, []string{"ml-training", "raycluster-sample"}, Filter(testItems, "ml"))
+	assert.Equal(t, []string{"raycluster-sample"}, Filter(testItems, "rcsa"))
+	assert.Empty(t, Filter(testItems, "tpu-v5"))
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expected      string
+		expectedError string
+	}{
+		{name: "number", input: "3\n", expected: "ml-training"},
+		{name: "exact name", input: "raycluster-gpu\n", expected: "raycluster-gpu"},
+		{name: "single match", input: "gpu\n", expected: "raycluster-gpu"},
+		{name: "filter then number", input: "raycluster\n2\n", expected: "raycluster-gpu"},
+		{name: "reset filter", input: "raycluster\n\n4\n", expected: "Serve-Cluster"},
+		{name: "number out of range", input: "5\n1\n", expected: "raycluster-sample"},
+		{name: "no match", input: "tpu-v5\n1\n", expected: "raycluster-sample"},
+		{name: "answer without newline", input: "training", expected: "ml-training"},
+		{name: "end of input", input: "raycluster\n", expectedError: "no selection was made"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			selected, err := Select(strings.NewReader(tc.input), &out, "Select a RayCluster", testItems)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, selected)
+		})
+	}
+
+	var out bytes.Buffer
+	_, err := Select(strings.NewReader("raycluster\n2\n"), &out, "Select a RayCluster", testItems)
+	assert.Nil(t, err)
+	assert.Equal(t, "  1) raycluster-sample\n  2) raycluster-gpu\n  3) ml-training\n  4) Serve-Cluster\nSelect a RayCluster [1-4 or filter]: "+
+		"  1) raycluster-sample\n  2) raycluster-gpu\nSelect a RayCluster [1-2 or filter]: ", out.String())
+}
+
+func newTestRayCluster(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "ray.io/v1",
+			"kind":       "RayCluster",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "test",
+			},
+		},
+	}
+}
+
+func newFakeDynamicClient(names ...string) *dynamicFake.FakeDynamicClient {
+	objects := make([]runtime.Object, 0, len(names))
+	for _, name := range names {
+		objects = append(objects, newTestRayCluster(name))
+	}
+	return dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{util.RayClusterGVR: "RayClusterList"}, objects...)
+}
+
+func TestRayCluster(t *testing.T) {
+	defer func(isInteractive func(io.Reader, io.Writer) bool) { IsInteractive = isInteractive }(IsInteractive)
+	interactive := false
+	IsInteractive = func(io.Reader, io.Writer) bool { return interactive }
+
+	streams, in, _, errOut := genericiooptions.NewTestIOStreams()
+	_, err := RayCluster(context.Background(), newFakeDynamicClient(), streams, "test", false)
+	assert.EqualError(t, err, "no RayCluster found in namespace test")
+
+	name, err := RayCluster(context.Background(), newFakeDynamicClient("raycluster-sample"), streams, "test", false)
+	assert.Nil(t, err)
+	assert.Equal(t, "raycluster-sample", name)
+	assert.Equal(t, "Using RayCluster raycluster-sample\n", errOut.String())
+
+	dynamicClient := newFakeDynamicClient("raycluster-sample", "raycluster-gpu")
+	_, err = RayCluster(context.Background(), dynamicClient, streams, "test", false)
+	assert.EqualError(t, err, "namespace test has 2 RayClusters, specify one of: raycluster-gpu, raycluster-sample")
+
+	interactive = true
+	_, err = RayCluster(context.Background(), dynamicClient, streams, "test", true)
+	assert.EqualError(t, err, "namespace test has 2 RayClusters, specify one of: raycluster-gpu, raycluster-sample")
+
+	in.WriteString("sample\n")
+	name, err = RayCluster(context.Background(), dynamicClient, streams, "test", false)
+	assert.Nil(t, err)
+	assert.Equal(t, "raycluster-sample", name)
+}
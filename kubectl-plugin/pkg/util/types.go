@@ -1,6 +1,10 @@
 package util
 
 import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
@@ -34,3 +38,63 @@ var RayServiceGVR = schema.GroupVersionResource{
 	Version:  RayVersion,
 	Resource: "rayservices",
 }
+
+// ParseRayResource parses a "NAME" or "TYPE/NAME" argument. The resource type defaults to RayCluster.
+func ParseRayResource(arg string) (ResourceType, string, error) {
+	typeAndName := strings.Split(arg, "/")
+	if len(typeAndName) == 1 {
+		return RayCluster, typeAndName[0], nil
+	}
+	if len(typeAndName) != 2 || typeAndName[1] == "" {
+		return "", "", fmt.Errorf("invalid resource type/name: %s", arg)
+	}
+
+	switch typeAndName[0] {
+	case string(RayCluster):
+		return RayCluster, typeAndName[1], nil
+	case string(RayJob):
+		return RayJob, typeAndName[1], nil
+	case string(RayService):
+		return RayService, typeAndName[1], nil
+	default:
+		return "", "", fmt.Errorf("unsupported resource type: %s", typeAndName[0])
+	}
+}
+
+// ParseKeyValues parses the KEY=VALUE values of a repeated flag
+func ParseKeyValues(flag string, args []string) (map[string]string, error) {
+	keyValues := make(map[string]string, len(args))
+	for _, arg := range args {
+		key, value, found := strings.Cut(arg, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid --%s %q, expected KEY=VALUE", flag, arg)
+		}
+		keyValues[key] = value
+	}
+	return keyValues, nil
+}
+
+// ParseTolerations parses the KEY[=VALUE][:EFFECT] values of the --toleration flag
+func ParseTolerations(args []string) ([]corev1.Toleration, error) {
+	var tolerations []corev1.Toleration
+	for _, arg := range args {
+		keyValue, effect, _ := strings.Cut(arg, ":")
+		key, value, hasValue := strings.Cut(keyValue, "=")
+		if key == "" {
+			return nil, fmt.Errorf("invalid --toleration %q, expected KEY[=VALUE][:EFFECT]", arg)
+		}
+		toleration := corev1.Toleration{Key: key, Operator: corev1.TolerationOpExists}
+		if hasValue {
+			toleration.Operator = corev1.TolerationOpEqual
+			toleration.Value = value
+		}
+		switch taintEffect := corev1.TaintEffect(effect); taintEffect {
+		case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+			toleration.Effect = taintEffect
+		default:
+			return nil, fmt.Errorf("invalid --toleration %q, effect must be one of NoSchedule, PreferNoSchedule or NoExecute", arg)
+		}
+		tolerations = append(tolerations, toleration)
+	}
+	return tolerations, nil
+}
@@ -0,0 +1,21 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestParseTolerations(t *testing.T) {
+	tolerations, err := ParseTolerations([]string{"nvidia.com/gpu:NoSchedule", "pool=gpu", "dedicated=ml:NoExecute"})
+	assert.Nil(t, err)
+	assert.Equal(t, []corev1.Toleration{
+		{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+		{Key: "pool", Operator: corev1.TolerationOpEqual, Value: "gpu"},
+		{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "ml", Effect: corev1.TaintEffectNoExecute},
+	}, tolerations)
+
+	_, err = ParseTolerations([]string{"=gpu"})
+	assert.EqualError(t, err, "invalid --toleration \"=gpu\", expected KEY[=VALUE][:EFFECT]")
+}
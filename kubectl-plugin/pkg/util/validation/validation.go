@@ -0,0 +1,219 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/dynamic"
+)
+
+var crdGVR = schema.GroupVersionResource{
+	Group:    "apiextensions.k8s.io",
+	Version:  "v1",
+	Resource: "customresourcedefinitions",
+}
+
+// FetchCRDSchema returns the OpenAPI v3 schema of the custom resource version from its CRD in the cluster
+func FetchCRDSchema(ctx context.Context, dynamicClient dynamic.Interface, gvr schema.GroupVersionResource) (map[string]interface{}, error) {
+	crdName := gvr.Resource + "." + gvr.Group
+	crd, err := dynamicClient.Resource(crdGVR).Get(ctx, crdName, v1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CRD %s: %w", crdName, err)
+	}
+
+	versions, _, err := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the versions of CRD %s: %w", crdName, err)
+	}
+	for _, version := range versions {
+		version, ok := version.(map[string]interface{})
+		if !ok || version["name"] != gvr.Version {
+			continue
+		}
+		openAPISchema, found, err := unstructured.NestedMap(version, "schema", "openAPIV3Schema")
+		if err != nil || !found {
+			return nil, fmt.Errorf("CRD %s has no schema for version %s", crdName, gvr.Version)
+		}
+		return openAPISchema, nil
+	}
+	return nil, fmt.Errorf("CRD %s does not serve version %s", crdName, gvr.Version)
+}
+
+// ValidateObject validates the object against the OpenAPI v3 schema of a CRD. It checks the subset of the schema that
+// is used by the KubeRay CRDs: types, enums, required fields, minimum and maximum, and unknown fields, which the API
+// server would otherwise silently drop.
+func ValidateObject(openAPISchema map[string]interface{}, obj map[string]interface{}) field.ErrorList {
+	return validateObject(openAPISchema, obj, nil)
+}
+
+func validateValue(valueSchema map[string]interface{}, value interface{}, path *field.Path) field.ErrorList {
+	// Null values are dropped by the API server
+	if value == nil {
+		return nil
+	}
+	if intOrString, _ := valueSchema["x-kubernetes-int-or-string"].(bool); intOrString {
+		switch value.(type) {
+		case string, int64:
+			return nil
+		case float64:
+			if isInteger(value) {
+				return nil
+			}
+		}
+		return field.ErrorList{field.Invalid(path, value, "must be an integer or a string")}
+	}
+
+	var allErrs field.ErrorList
+	valueType, _ := valueSchema["type"].(string)
+	if valueType != "" && !hasType(value, valueType) {
+		return field.ErrorList{field.Invalid(path, value, fmt.Sprintf("must be of type %s", valueType))}
+	}
+
+	if enum, ok := valueSchema["enum"].([]interface{}); ok && !contains(enum, value) {
+		allowed := make([]string, 0, len(enum))
+		for _, enumValue := range enum {
+			allowed = append(allowed, fmt.Sprint(enumValue))
+		}
+		allErrs = append(allErrs, field.NotSupported(path, value, allowed))
+	}
+	if number, ok := toFloat(value); ok {
+		if minimum, ok := toFloat(valueSchema["minimum"]); ok && number < minimum {
+			allErrs = append(allErrs, field.Invalid(path, value, fmt.Sprintf("must be greater than or equal to %v", valueSchema["minimum"])))
+		}
+		if maximum, ok := toFloat(valueSchema["maximum"]); ok && number > maximum {
+			allErrs = append(allErrs, field.Invalid(path, value, fmt.Sprintf("must be less than or equal to %v", valueSchema["maximum"])))
+		}
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		allErrs = append(allErrs, validateObject(valueSchema, value, path)...)
+	case []interface{}:
+		if itemSchema, ok := valueSchema["items"].(map[string]interface{}); ok {
+			for i, item := range value {
+				allErrs = append(allErrs, validateValue(itemSchema, item, path.Index(i))...)
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateObject(objectSchema map[string]interface{}, obj map[string]interface{}, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if required, ok := objectSchema["required"].([]interface{}); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				if _, found := obj[name]; !found {
+					allErrs = append(allErrs, field.Required(path.Child(name), ""))
+				}
+			}
+		}
+	}
+
+	// Validate the fields in a stable order
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if propertySchema, ok := property(objectSchema, key); ok {
+			allErrs = append(allErrs, validateValue(propertySchema, obj[key], path.Child(key))...)
+			continue
+		}
+		if additionalSchema, ok := objectSchema["additionalProperties"].(map[string]interface{}); ok {
+			allErrs = append(allErrs, validateValue(additionalSchema, obj[key], path.Key(key))...)
+			continue
+		}
+		if allowsUnknownFields(objectSchema) {
+			continue
+		}
+		allErrs = append(allErrs, field.Forbidden(path.Child(key), "unknown field"))
+	}
+	return allErrs
+}
+
+func property(objectSchema map[string]interface{}, name string) (map[string]interface{}, bool) {
+	properties, _ := objectSchema["properties"].(map[string]interface{})
+	propertySchema, ok := properties[name].(map[string]interface{})
+	return propertySchema, ok
+}
+
+// allowsUnknownFields reports whether the object schema accepts fields that are not listed in its properties
+func allowsUnknownFields(objectSchema map[string]interface{}) bool {
+	if preserve, _ := objectSchema["x-kubernetes-preserve-unknown-fields"].(bool); preserve {
+		return true
+	}
+	if additional, ok := objectSchema["additionalProperties"].(bool); ok {
+		return additional
+	}
+	// Objects without properties, e.g. metadata, are not further specified by the schema
+	_, hasProperties := objectSchema["properties"]
+	return !hasProperties
+}
+
+func hasType(value interface{}, valueType string) bool {
+	switch valueType {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "integer":
+		return isInteger(value)
+	case "number":
+		_, ok := toFloat(value)
+		return ok
+	default:
+		return true
+	}
+}
+
+func isInteger(value interface{}) bool {
+	switch value := value.(type) {
+	case int64, int32, int:
+		return true
+	case float64:
+		return value == math.Trunc(value)
+	default:
+		return false
+	}
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case int64:
+		return float64(value), true
+	case int32:
+		return float64(value), true
+	case int:
+		return float64(value), true
+	case float64:
+		return value, true
+	default:
+		return 0, false
+	}
+}
+
+func contains(enum []interface{}, value interface{}) bool {
+	for _, enumValue := range enum {
+		if fmt.Sprint(enumValue) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
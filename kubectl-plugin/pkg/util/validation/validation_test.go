@@ -0,0 +1,170 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/ray-project/kuberay/kubectl-plugin/pkg/util"
+)
+
+// testSchema is a reduced RayJob schema
+var testSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"apiVersion": map[string]interface{}{"type": "string"},
+		"kind":       map[string]interface{}{"type": "string"},
+		"metadata":   map[string]interface{}{"type": "object"},
+		"spec": map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"entrypoint"},
+			"properties": map[string]interface{}{
+				"entrypoint":     map[string]interface{}{"type": "string"},
+				"submissionMode": map[string]interface{}{"type": "string", "enum": []interface{}{"K8sJobMode", "HTTPMode", "InteractiveMode"}},
+				"shutdownAfterJobFinishes": map[string]interface{}{
+					"type": "boolean",
+				},
+				"backoffLimit": map[string]interface{}{"type": "integer", "minimum": int64(0), "maximum": int64(10)},
+				"rayClusterSpec": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"workerGroupSpecs": map[string]interface{}{
+							"type": "array",
+							"items": map[string]interface{}{
+								"type": "object",
+								"properties": map[string]interface{}{
+									"replicas": map[string]interface{}{"type": "integer"},
+									"rayStartParams": map[string]interface{}{
+										"type":                 "object",
+										"additionalProperties": map[string]interface{}{"type": "string"},
+									},
+									"template": map[string]interface{}{
+										"type":                                 "object",
+										"x-kubernetes-preserve-unknown-fields": true,
+									},
+								},
+							},
+						},
+					},
+				},
+				"resources": map[string]interface{}{
+					"type": "object",
+					"additionalProperties": map[string]interface{}{
+						"x-kubernetes-int-or-string": true,
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestValidateObject(t *testing.T) {
+	tests := []struct {
+		spec           map[string]interface{}
+		name           string
+		expectedErrors []string
+	}{
+		{
+			name: "valid",
+			spec: map[string]interface{}{
+				"entrypoint":     "python main.py",
+				"submissionMode": "InteractiveMode",
+				"backoffLimit":   int64(3),
+				"resources":      map[string]interface{}{"cpu": int64(1), "memory": "1Gi"},
+				"rayClusterSpec": map[string]interface{}{
+					"workerGroupSpecs": []interface{}{
+						map[string]interface{}{
+							"replicas":       int64(2),
+							"rayStartParams": map[string]interface{}{"num-cpus": "1"},
+							"template":       map[string]interface{}{"anything": "goes"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "misspelled field",
+			spec: map[string]interface{}{
+				"entrypoint":     "python main.py",
+				"submisionMode":  "InteractiveMode",
+				"rayClusterSpec": map[string]interface{}{"workerGroupSpec": []interface{}{}},
+			},
+			expectedErrors: []string{
+				"spec.rayClusterSpec.workerGroupSpec: Forbidden: unknown field",
+				"spec.submisionMode: Forbidden: unknown field",
+			},
+		},
+		{
+			name: "invalid values",
+			spec: map[string]interface{}{
+				"submissionMode":           "InteractiveModes",
+				"shutdownAfterJobFinishes": "yes",
+				"backoffLimit":             int64(11),
+				"resources":                map[string]interface{}{"cpu": 0.5},
+				"rayClusterSpec": map[string]interface{}{
+					"workerGroupSpecs": []interface{}{
+						map[string]interface{}{"replicas": "2", "rayStartParams": map[string]interface{}{"num-cpus": int64(1)}},
+					},
+				},
+			},
+			expectedErrors: []string{
+				"spec.entrypoint: Required value",
+				"spec.backoffLimit: Invalid value: 11: must be less than or equal to 10",
+				"spec.rayClusterSpec.workerGroupSpecs[0].rayStartParams[num-cpus]: Invalid value: 1: must be of type string",
+				"spec.rayClusterSpec.workerGroupSpecs[0].replicas: Invalid value: \"2\": must be of type integer",
+				"spec.resources[cpu]: Invalid value: 0.5: must be an integer or a string",
+				"spec.shutdownAfterJobFinishes: Invalid value: \"yes\": must be of type boolean",
+				"spec.submissionMode: Unsupported value: \"InteractiveModes\": supported values: \"K8sJobMode\", \"HTTPMode\", \"InteractiveMode\"",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := map[string]interface{}{
+				"apiVersion": "ray.io/v1",
+				"kind":       "RayJob",
+				"metadata":   map[string]interface{}{"name": "rayjob-sample"},
+				"spec":       tc.spec,
+			}
+			errs := ValidateObject(testSchema, obj)
+			var messages []string
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+			assert.Equal(t, tc.expectedErrors, messages)
+		})
+	}
+}
+
+func TestFetchCRDSchema(t *testing.T) {
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": "rayjobs.ray.io"},
+		"spec": map[string]interface{}{
+			"versions": []interface{}{
+				map[string]interface{}{
+					"name":   "v1alpha1",
+					"schema": map[string]interface{}{"openAPIV3Schema": map[string]interface{}{"type": "object", "description": "old"}},
+				},
+				map[string]interface{}{
+					"name":   "v1",
+					"schema": map[string]interface{}{"openAPIV3Schema": map[string]interface{}{"type": "object", "description": "current"}},
+				},
+			},
+		},
+	}}
+	dynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), crd)
+
+	rayJobSchema, err := FetchCRDSchema(context.Background(), dynamicClient, util.RayJobGVR)
+	assert.Nil(t, err)
+	assert.Equal(t, "current", rayJobSchema["description"])
+
+	_, err = FetchCRDSchema(context.Background(), dynamicClient, util.RayServiceGVR)
+	assert.EqualError(t, err, "failed to get CRD rayservices.ray.io: customresourcedefinitions.apiextensions.k8s.io \"rayservices.ray.io\" not found")
+}
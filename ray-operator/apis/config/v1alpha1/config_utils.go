@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/volcano"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/yunikorn"
@@ -28,3 +30,60 @@ func ValidateBatchSchedulerConfig(logger logr.Logger, config Configuration) erro
 
 	return nil
 }
+
+func ValidateShardConfig(config Configuration) error {
+	if config.ShardCount < 0 {
+		return fmt.Errorf("shard count must not be negative, shardCount=%d", config.ShardCount)
+	}
+	if config.ShardCount > 1 && (config.ShardIndex < 0 || config.ShardIndex >= config.ShardCount) {
+		return fmt.Errorf("shard index must be between 0 and %d, shardIndex=%d", config.ShardCount-1, config.ShardIndex)
+	}
+	return nil
+}
+
+func ValidatePodDefaultsConfig(config Configuration) error {
+	for i, podDefaults := range config.PodDefaults {
+		if _, err := labels.Parse(podDefaults.NamespaceSelector); err != nil {
+			return fmt.Errorf("invalid namespace selector of pod defaults %d, namespaceSelector=%q: %w", i, podDefaults.NamespaceSelector, err)
+		}
+	}
+	return nil
+}
+
+func ValidateDashboardClientConfig(config Configuration) error {
+	if config.DashboardClient != nil {
+		if config.DashboardClient.Timeout.Duration < 0 {
+			return fmt.Errorf("dashboard client timeout must not be negative, timeout=%s", config.DashboardClient.Timeout.Duration)
+		}
+		if config.DashboardClient.MaxRetries < 0 {
+			return fmt.Errorf("dashboard client max retries must not be negative, maxRetries=%d", config.DashboardClient.MaxRetries)
+		}
+	}
+	if config.EnableRayClusterWorkloadStatus && config.RayClusterWorkloadStatusInterval.Duration <= 0 {
+		return fmt.Errorf("ray cluster workload status interval must be positive, rayClusterWorkloadStatusInterval=%s", config.RayClusterWorkloadStatusInterval.Duration)
+	}
+	_, err := config.DashboardClientOptions()
+	return err
+}
+
+func ValidateTracingConfig(config Configuration) error {
+	if config.Tracing != nil && config.Tracing.Endpoint == "" {
+		return fmt.Errorf("tracing endpoint must be set if tracing is configured")
+	}
+	return nil
+}
+
+func ValidateRequeueConfig(config Configuration) error {
+	intervals := map[string]metav1.Duration{
+		"rayClusterRequeueInterval": config.RayClusterRequeueInterval,
+		"rayJobRequeueInterval":     config.RayJobRequeueInterval,
+		"rayServiceRequeueInterval": config.RayServiceRequeueInterval,
+		"statusResyncInterval":      config.StatusResyncInterval,
+	}
+	for name, interval := range intervals {
+		if interval.Duration < 0 {
+			return fmt.Errorf("requeue interval must not be negative, %s=%s", name, interval.Duration)
+		}
+	}
+	return nil
+}
@@ -2,9 +2,11 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/logr/testr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/volcano"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/yunikorn"
@@ -92,3 +94,215 @@ func TestValidateBatchSchedulerConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateShardConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Configuration
+		wantErr bool
+	}{
+		{
+			name:    "sharding disabled",
+			config:  Configuration{},
+			wantErr: false,
+		},
+		{
+			name:    "valid shard index",
+			config:  Configuration{ShardCount: 3, ShardIndex: 2},
+			wantErr: false,
+		},
+		{
+			name:    "negative shard count",
+			config:  Configuration{ShardCount: -1},
+			wantErr: true,
+		},
+		{
+			name:    "shard index out of range",
+			config:  Configuration{ShardCount: 3, ShardIndex: 3},
+			wantErr: true,
+		},
+		{
+			name:    "negative shard index",
+			config:  Configuration{ShardCount: 3, ShardIndex: -1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateShardConfig(tt.config); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateShardConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePodDefaultsConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Configuration
+		wantErr bool
+	}{
+		{
+			name:    "no pod defaults",
+			config:  Configuration{},
+			wantErr: false,
+		},
+		{
+			name:    "pod defaults for all namespaces",
+			config:  Configuration{PodDefaults: []PodDefaults{{Labels: map[string]string{"team": "ml"}}}},
+			wantErr: false,
+		},
+		{
+			name:    "valid namespace selector",
+			config:  Configuration{PodDefaults: []PodDefaults{{NamespaceSelector: "team in (ml,data)"}}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid namespace selector",
+			config:  Configuration{PodDefaults: []PodDefaults{{}, {NamespaceSelector: "team in"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePodDefaultsConfig(tt.config); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePodDefaultsConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDashboardClientConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Configuration
+		wantErr bool
+	}{
+		{
+			name:    "no dashboard client config",
+			config:  Configuration{},
+			wantErr: false,
+		},
+		{
+			name:    "valid dashboard client config",
+			config:  Configuration{DashboardClient: &DashboardClientConfig{Timeout: metav1.Duration{Duration: 5 * time.Second}, MaxRetries: 3, EnableTLS: true}},
+			wantErr: false,
+		},
+		{
+			name:    "negative max retries",
+			config:  Configuration{DashboardClient: &DashboardClientConfig{MaxRetries: -1}},
+			wantErr: true,
+		},
+		{
+			name:    "missing CA file",
+			config:  Configuration{DashboardClient: &DashboardClientConfig{EnableTLS: true, CAFile: "/non-existent/ca.crt"}},
+			wantErr: true,
+		},
+		{
+			name:    "workload status without interval",
+			config:  Configuration{EnableRayClusterWorkloadStatus: true},
+			wantErr: true,
+		},
+		{
+			name:    "workload status with interval",
+			config:  Configuration{EnableRayClusterWorkloadStatus: true, RayClusterWorkloadStatusInterval: metav1.Duration{Duration: time.Minute}},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateDashboardClientConfig(tt.config); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDashboardClientConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRequeueConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Configuration
+		wantErr bool
+	}{
+		{
+			name:    "default requeue intervals",
+			config:  Configuration{},
+			wantErr: false,
+		},
+		{
+			name:    "valid requeue intervals",
+			config:  Configuration{RayJobRequeueInterval: metav1.Duration{Duration: 10 * time.Second}, StatusResyncInterval: metav1.Duration{Duration: time.Minute}},
+			wantErr: false,
+		},
+		{
+			name:    "negative requeue interval",
+			config:  Configuration{RayServiceRequeueInterval: metav1.Duration{Duration: -time.Second}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateRequeueConfig(tt.config); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRequeueConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTracingConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Configuration
+		wantErr bool
+	}{
+		{
+			name:    "tracing disabled",
+			config:  Configuration{},
+			wantErr: false,
+		},
+		{
+			name:    "valid tracing endpoint",
+			config:  Configuration{Tracing: &TracingConfig{Endpoint: "otel-collector.observability:4317"}},
+			wantErr: false,
+		},
+		{
+			name:    "missing tracing endpoint",
+			config:  Configuration{Tracing: &TracingConfig{Insecure: true}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateTracingConfig(tt.config); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTracingConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigurationScope(t *testing.T) {
+	scope, err := Configuration{ShardCount: 3, ShardIndex: 1}.Scope()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if scope.NamespaceSelector != nil || scope.Shard.Count != 3 || scope.Shard.Index != 1 {
+		t.Errorf("unexpected scope: %+v", scope)
+	}
+
+	scope, err = Configuration{WatchNamespaceSelector: "ray.io/enabled=true"}.Scope()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if scope.NamespaceSelector == nil || scope.NamespaceSelector.String() != "ray.io/enabled=true" {
+		t.Errorf("unexpected namespace selector: %v", scope.NamespaceSelector)
+	}
+
+	if _, err = (Configuration{WatchNamespaceSelector: "ray.io/enabled in"}).Scope(); err == nil {
+		t.Error("expected an error for an invalid namespace selector")
+	}
+}
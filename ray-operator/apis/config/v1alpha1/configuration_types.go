@@ -1,9 +1,18 @@
 package v1alpha1
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 )
@@ -32,6 +41,11 @@ type Configuration struct {
 	// If empty, all namespaces will be watched.
 	WatchNamespace string `json:"watchNamespace,omitempty"`
 
+	// WatchNamespaceSelector is a label selector of the namespaces whose custom resources are reconciled, e.g.
+	// "ray.io/enabled=true", so that namespaces can be onboarded and offboarded without restarting the operator.
+	// If empty, the custom resources of all the watched namespaces are reconciled.
+	WatchNamespaceSelector string `json:"watchNamespaceSelector,omitempty"`
+
 	// LogFile is a path to a local file for synchronizing logs.
 	LogFile string `json:"logFile,omitempty"`
 
@@ -47,6 +61,17 @@ type Configuration struct {
 	// based on the given name, currently, supported values are volcano and yunikorn.
 	BatchScheduler string `json:"batchScheduler,omitempty"`
 
+	// HeadPlacement places the head Pods of the RayClusters that do not set the ray.io/disable-head-placement
+	// annotation to "true" on stable nodes, and spreads them across zones. It is disabled if it is not set.
+	HeadPlacement *HeadPlacementConfig `json:"headPlacement,omitempty"`
+
+	// DashboardClient configures the HTTP clients that all the controllers use to connect to the Ray dashboards.
+	DashboardClient *DashboardClientConfig `json:"dashboardClient,omitempty"`
+
+	// Tracing exports OpenTelemetry spans of the reconciliations, and of the requests that the controllers send to the
+	// Kubernetes API server and to the Ray dashboards. It is disabled if it is not set.
+	Tracing *TracingConfig `json:"tracing,omitempty"`
+
 	// HeadSidecarContainers includes specification for a sidecar container
 	// to inject into every Head pod.
 	HeadSidecarContainers []corev1.Container `json:"headSidecarContainers,omitempty"`
@@ -55,9 +80,76 @@ type Configuration struct {
 	// to inject into every Worker pod.
 	WorkerSidecarContainers []corev1.Container `json:"workerSidecarContainers,omitempty"`
 
+	// PodDefaults are injected into the head and worker Pods of the RayClusters in the namespaces that they
+	// select, so that platform settings such as CA bundles and proxies do not need to be repeated in every
+	// RayCluster. The settings of a RayCluster take precedence over the Pod defaults, and earlier Pod defaults
+	// take precedence over later ones.
+	PodDefaults []PodDefaults `json:"podDefaults,omitempty"`
+
 	// ReconcileConcurrency is the max concurrency for each reconciler.
 	ReconcileConcurrency int `json:"reconcileConcurrency,omitempty"`
 
+	// RayClusterConcurrency is the max concurrency for the RayCluster reconciler.
+	// Defaults to ReconcileConcurrency if not set.
+	RayClusterConcurrency int `json:"rayClusterConcurrency,omitempty"`
+
+	// RayJobConcurrency is the max concurrency for the RayJob reconciler.
+	// Defaults to ReconcileConcurrency if not set.
+	RayJobConcurrency int `json:"rayJobConcurrency,omitempty"`
+
+	// KubeAPIBurst is the maximum burst of requests the operator sends to the Kubernetes API server.
+	KubeAPIBurst int `json:"kubeAPIBurst,omitempty"`
+
+	// RateLimiterBaseDelay is the delay before a failed reconcile request is retried for the first time.
+	// The delay doubles on every subsequent failure of the same request.
+	RateLimiterBaseDelay metav1.Duration `json:"rateLimiterBaseDelay,omitempty"`
+
+	// RateLimiterMaxDelay is the maximum delay before a failed reconcile request is retried.
+	RateLimiterMaxDelay metav1.Duration `json:"rateLimiterMaxDelay,omitempty"`
+
+	// RateLimiterQPS is the overall rate at which each reconciler dequeues requests.
+	RateLimiterQPS float64 `json:"rateLimiterQPS,omitempty"`
+
+	// RateLimiterBurst is the burst of requests each reconciler can dequeue above RateLimiterQPS.
+	RateLimiterBurst int `json:"rateLimiterBurst,omitempty"`
+
+	// RayClusterWorkloadStatusInterval is how often the workload status of a RayCluster, i.e. its active jobs,
+	// running tasks, alive actors, Serve applications and resource utilization, is refreshed from its Ray dashboard.
+	// It is only used if EnableRayClusterWorkloadStatus is set.
+	RayClusterWorkloadStatusInterval metav1.Duration `json:"rayClusterWorkloadStatusInterval,omitempty"`
+
+	// RayClusterRequeueInterval is how often a RayCluster is reconciled if no change of it or of its resources
+	// triggers a reconciliation. The RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV environment variable, if it is set,
+	// overrides it for backward compatibility. Defaults to 300 seconds.
+	RayClusterRequeueInterval metav1.Duration `json:"rayClusterRequeueInterval,omitempty"`
+
+	// RayJobRequeueInterval is how often the status of a RayJob is polled from its Ray dashboard while the RayJob is
+	// in progress. Defaults to 3 seconds.
+	RayJobRequeueInterval metav1.Duration `json:"rayJobRequeueInterval,omitempty"`
+
+	// RayServiceRequeueInterval is how often the status of the Serve applications of a RayService is polled from its
+	// Ray dashboards while the RayService is in progress. Defaults to 2 seconds.
+	RayServiceRequeueInterval metav1.Duration `json:"rayServiceRequeueInterval,omitempty"`
+
+	// StatusResyncInterval is how often a RayJob is reconciled while it waits only on changes that the operator
+	// watches, e.g. a running RayJob in the K8sJobMode, whose submitter Job completes with the Ray job. It must be
+	// longer than the RayJob requeue interval to reduce the load on the API server and the Ray dashboards. If it is 0,
+	// such RayJobs are polled at the RayJob requeue interval. RayServices are always polled at their requeue interval,
+	// because the health of their Ray Serve applications is only known from the Ray dashboards.
+	StatusResyncInterval metav1.Duration `json:"statusResyncInterval,omitempty"`
+
+	// ShardCount is the number of shards of a sharded operator deployment, in which each operator replica
+	// reconciles the custom resources of one shard with its own leader election lease. A custom resource
+	// belongs to the shard in its ray.io/shard label, or to the shard of the hash of its namespace if it
+	// does not have one. Sharding is disabled if ShardCount is 0 or 1.
+	ShardCount int `json:"shardCount,omitempty"`
+
+	// ShardIndex is the shard, from 0 to ShardCount-1, that this operator replica reconciles.
+	ShardIndex int `json:"shardIndex,omitempty"`
+
+	// KubeAPIQPS is the maximum QPS the operator sends to the Kubernetes API server.
+	KubeAPIQPS float32 `json:"kubeAPIQPS,omitempty"`
+
 	// EnableBatchScheduler enables the batch scheduler. Currently this is supported
 	// by Volcano to support gang scheduling.
 	EnableBatchScheduler bool `json:"enableBatchScheduler,omitempty"`
@@ -70,12 +162,146 @@ type Configuration struct {
 
 	// DeleteRayJobAfterJobFinishes deletes the RayJob CR itself if shutdownAfterJobFinishes is set to true.
 	DeleteRayJobAfterJobFinishes bool `json:"deleteRayJobAfterJobFinishes,omitempty"`
+
+	// EnablePodDisruptionBudgets creates PodDisruptionBudgets for the head Pod and the worker groups of the
+	// RayClusters that do not set enablePodDisruptionBudgets themselves.
+	EnablePodDisruptionBudgets bool `json:"enablePodDisruptionBudgets,omitempty"`
+
+	// EnableRayClusterWorkloadStatus reports the workload status of the RayClusters in their status. It is disabled by
+	// default so that the operator does not send requests to the Ray dashboards of idle RayClusters.
+	EnableRayClusterWorkloadStatus bool `json:"enableRayClusterWorkloadStatus,omitempty"`
+
+	// EnablePrometheusMonitors creates Prometheus Operator monitors for the metrics of the RayClusters that do not
+	// set prometheusMonitors.enabled themselves.
+	EnablePrometheusMonitors bool `json:"enablePrometheusMonitors,omitempty"`
+}
+
+// DashboardClientConfig configures the HTTP clients of the Ray dashboards.
+type DashboardClientConfig struct {
+	// CAFile is the path to the PEM-encoded CA certificates that verify the certificates of the Ray dashboards if
+	// TLS is enabled. The system CA certificates are used if it is empty.
+	CAFile string `json:"caFile,omitempty"`
+
+	// Timeout is the timeout of each request to a Ray dashboard, including its retries. Defaults to 2 seconds.
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+
+	// MaxRetries is the number of times that a GET request to a Ray dashboard that fails with a connection error or
+	// a 5xx status code is retried. The requests are not retried if it is 0.
+	MaxRetries int `json:"maxRetries,omitempty"`
+
+	// EnableTLS connects to the Ray dashboards with HTTPS, e.g. when they are behind a TLS proxy. It is ignored if
+	// UseKubernetesProxy is set.
+	EnableTLS bool `json:"enableTLS,omitempty"`
+
+	// InsecureSkipVerify does not verify the certificates of the Ray dashboards if TLS is enabled.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// TracingConfig configures the OTLP exporter of the OpenTelemetry spans of the KubeRay operator. The standard
+// OTEL_EXPORTER_OTLP_* and OTEL_TRACES_SAMPLER environment variables configure the exporter and the sampler further.
+type TracingConfig struct {
+	// Endpoint is the host and port of the OTLP gRPC receiver that the spans are exported to, e.g.
+	// otel-collector.observability:4317.
+	Endpoint string `json:"endpoint"`
+
+	// Insecure exports the spans without TLS.
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// HeadPlacementConfig is the default placement of the head Pods of RayClusters. It adds a required node affinity that
+// keeps the head Pods off spot nodes, and a preferred Pod anti-affinity that spreads the head Pods of different
+// RayClusters across zones, to the affinity of the head Pod template.
+type HeadPlacementConfig struct {
+	// SpotNodeLabels are the labels of the spot nodes that the head Pods are not scheduled on. Defaults to the spot
+	// node labels of GKE, EKS, AKS and Karpenter.
+	SpotNodeLabels map[string]string `json:"spotNodeLabels,omitempty"`
+
+	// Zones pins the head Pods to these zones. If it is empty, the head Pods can be scheduled in any zone.
+	Zones []string `json:"zones,omitempty"`
+}
+
+// PodDefaults are the labels, environment variables, volumes and image pull secrets injected into the Ray Pods of
+// the RayClusters in the selected namespaces. An environment variable, volume, volume mount or label that a Ray Pod
+// already has is not overridden.
+type PodDefaults struct {
+	// Labels are added to the Ray Pods.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// NamespaceSelector is a label selector of the namespaces whose Ray Pods get the defaults, e.g.
+	// "team=ml". If empty, the defaults are injected into the Ray Pods of all the namespaces.
+	NamespaceSelector string `json:"namespaceSelector,omitempty"`
+
+	// Env is added to the containers and init containers of the Ray Pods.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Volumes are added to the Ray Pods.
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// VolumeMounts are added to the containers and init containers of the Ray Pods.
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// ImagePullSecrets are added to the Ray Pods.
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 }
 
 func (config Configuration) GetDashboardClient(mgr manager.Manager) func() utils.RayDashboardClientInterface {
-	return utils.GetRayDashboardClientFunc(mgr, config.UseKubernetesProxy)
+	// The dashboard client configs are validated by ValidateDashboardClientConfig when the operator starts.
+	options, _ := config.DashboardClientOptions()
+	return utils.GetRayDashboardClientFuncWithOptions(mgr, config.UseKubernetesProxy, options)
+}
+
+// DashboardClientOptions returns the options of the HTTP clients of the Ray dashboards.
+func (config Configuration) DashboardClientOptions() (utils.DashboardClientOptions, error) {
+	var options utils.DashboardClientOptions
+	if config.DashboardClient == nil {
+		return options, nil
+	}
+	options.Timeout = config.DashboardClient.Timeout.Duration
+	options.MaxRetries = config.DashboardClient.MaxRetries
+	if !config.DashboardClient.EnableTLS {
+		return options, nil
+	}
+
+	options.TLSConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.DashboardClient.InsecureSkipVerify, //nolint:gosec // Opt-in for self-signed dashboard certificates.
+	}
+	if config.DashboardClient.CAFile != "" {
+		caData, err := os.ReadFile(config.DashboardClient.CAFile)
+		if err != nil {
+			return options, fmt.Errorf("failed to read the CA file of the dashboard client: %w", err)
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caData) {
+			return options, fmt.Errorf("no PEM-encoded CA certificates found in %s", config.DashboardClient.CAFile)
+		}
+		options.TLSConfig.RootCAs = rootCAs
+	}
+	return options, nil
 }
 
 func (config Configuration) GetHttpProxyClient(mgr manager.Manager) func() utils.RayHttpProxyClientInterface {
 	return utils.GetRayHttpProxyClientFunc(mgr, config.UseKubernetesProxy)
 }
+
+// Scope returns the scope of the custom resources that the operator reconciles.
+func (config Configuration) Scope() (utils.Scope, error) {
+	scope := utils.Scope{Shard: utils.Shard{Index: config.ShardIndex, Count: config.ShardCount}}
+	if config.WatchNamespaceSelector != "" {
+		selector, err := labels.Parse(config.WatchNamespaceSelector)
+		if err != nil {
+			return scope, fmt.Errorf("invalid watch namespace selector %q: %w", config.WatchNamespaceSelector, err)
+		}
+		scope.NamespaceSelector = selector
+	}
+	return scope, nil
+}
+
+// NewRateLimiter returns a rate limiter for the work queue of a reconciler. Each reconciler needs its own
+// rate limiter so that the reconcilers do not share the same token bucket.
+func (config Configuration) NewRateLimiter() workqueue.TypedRateLimiter[reconcile.Request] {
+	return workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](config.RateLimiterBaseDelay.Duration, config.RateLimiterMaxDelay.Duration),
+		&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(config.RateLimiterQPS), config.RateLimiterBurst)},
+	)
+}
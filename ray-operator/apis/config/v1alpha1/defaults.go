@@ -1,6 +1,9 @@
 package v1alpha1
 
 import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 )
@@ -10,8 +13,46 @@ const (
 	DefaultProbeAddr            = ":8082"
 	DefaultEnableLeaderElection = true
 	DefaultReconcileConcurrency = 1
+
+	// The client-side rate limit of the requests to the Kubernetes API server, which matches the
+	// client-go defaults. Operators managing thousands of RayClusters usually raise the reconciler
+	// concurrency, and should raise these limits along with it (e.g. 50 QPS and 100 burst for a
+	// concurrency of 10), since each reconcile sends several requests to the API server.
+	DefaultKubeAPIQPS   = 20
+	DefaultKubeAPIBurst = 30
+
+	// The rate limiter of each reconciler, which matches the controller-runtime defaults. Failed
+	// requests are retried with an exponential backoff from the base delay up to the max delay, and
+	// all requests are dequeued at no more than the QPS with the given burst.
+	DefaultRateLimiterBaseDelay = 5 * time.Millisecond
+	DefaultRateLimiterMaxDelay  = 1000 * time.Second
+	DefaultRateLimiterQPS       = 10
+	DefaultRateLimiterBurst     = 100
+
+	// DefaultRayClusterWorkloadStatusInterval is how often the workload status of a RayCluster is refreshed from its
+	// Ray dashboard by default.
+	DefaultRayClusterWorkloadStatusInterval = 30 * time.Second
+
+	// The intervals at which the custom resources are requeued if nothing else triggers their reconciliation. A
+	// RayCluster is only requeued as a safety net, while the RayJobs and RayServices in progress poll the Ray
+	// dashboards for their status.
+	DefaultRayClusterRequeueInterval = 300 * time.Second
+	DefaultRayJobRequeueInterval     = 3 * time.Second
+	DefaultRayServiceRequeueInterval = 2 * time.Second
 )
 
+// DefaultSpotNodeLabels returns the labels of the spot nodes of GKE, EKS, AKS and Karpenter, which the head Pods are not
+// scheduled on by default.
+func DefaultSpotNodeLabels() map[string]string {
+	return map[string]string{
+		"cloud.google.com/gke-spot":             "true",
+		"cloud.google.com/gke-preemptible":      "true",
+		"eks.amazonaws.com/capacityType":        "SPOT",
+		"kubernetes.azure.com/scalesetpriority": "spot",
+		"karpenter.sh/capacity-type":            "spot",
+	}
+}
+
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
 	scheme.AddTypeDefaultingFunc(&Configuration{}, func(obj interface{}) {
 		SetDefaults_Configuration(obj.(*Configuration))
@@ -36,4 +77,56 @@ func SetDefaults_Configuration(cfg *Configuration) {
 	if cfg.ReconcileConcurrency == 0 {
 		cfg.ReconcileConcurrency = DefaultReconcileConcurrency
 	}
+
+	if cfg.RayClusterConcurrency == 0 {
+		cfg.RayClusterConcurrency = cfg.ReconcileConcurrency
+	}
+
+	if cfg.RayJobConcurrency == 0 {
+		cfg.RayJobConcurrency = cfg.ReconcileConcurrency
+	}
+
+	if cfg.KubeAPIQPS == 0 {
+		cfg.KubeAPIQPS = DefaultKubeAPIQPS
+	}
+
+	if cfg.KubeAPIBurst == 0 {
+		cfg.KubeAPIBurst = DefaultKubeAPIBurst
+	}
+
+	if cfg.RateLimiterBaseDelay.Duration == 0 {
+		cfg.RateLimiterBaseDelay = metav1.Duration{Duration: DefaultRateLimiterBaseDelay}
+	}
+
+	if cfg.RateLimiterMaxDelay.Duration == 0 {
+		cfg.RateLimiterMaxDelay = metav1.Duration{Duration: DefaultRateLimiterMaxDelay}
+	}
+
+	if cfg.RateLimiterQPS == 0 {
+		cfg.RateLimiterQPS = DefaultRateLimiterQPS
+	}
+
+	if cfg.RateLimiterBurst == 0 {
+		cfg.RateLimiterBurst = DefaultRateLimiterBurst
+	}
+
+	if cfg.RayClusterWorkloadStatusInterval.Duration == 0 {
+		cfg.RayClusterWorkloadStatusInterval = metav1.Duration{Duration: DefaultRayClusterWorkloadStatusInterval}
+	}
+
+	if cfg.RayClusterRequeueInterval.Duration == 0 {
+		cfg.RayClusterRequeueInterval = metav1.Duration{Duration: DefaultRayClusterRequeueInterval}
+	}
+
+	if cfg.RayJobRequeueInterval.Duration == 0 {
+		cfg.RayJobRequeueInterval = metav1.Duration{Duration: DefaultRayJobRequeueInterval}
+	}
+
+	if cfg.RayServiceRequeueInterval.Duration == 0 {
+		cfg.RayServiceRequeueInterval = metav1.Duration{Duration: DefaultRayServiceRequeueInterval}
+	}
+
+	if cfg.HeadPlacement != nil && cfg.HeadPlacement.SpotNodeLabels == nil {
+		cfg.HeadPlacement.SpotNodeLabels = DefaultSpotNodeLabels()
+	}
 }
@@ -18,6 +18,21 @@ func (in *Configuration) DeepCopyInto(out *Configuration) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.HeadPlacement != nil {
+		in, out := &in.HeadPlacement, &out.HeadPlacement
+		*out = new(HeadPlacementConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DashboardClient != nil {
+		in, out := &in.DashboardClient, &out.DashboardClient
+		*out = new(DashboardClientConfig)
+		**out = **in
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(TracingConfig)
+		**out = **in
+	}
 	if in.HeadSidecarContainers != nil {
 		in, out := &in.HeadSidecarContainers, &out.HeadSidecarContainers
 		*out = make([]v1.Container, len(*in))
@@ -32,6 +47,20 @@ func (in *Configuration) DeepCopyInto(out *Configuration) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PodDefaults != nil {
+		in, out := &in.PodDefaults, &out.PodDefaults
+		*out = make([]PodDefaults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.RateLimiterBaseDelay = in.RateLimiterBaseDelay
+	out.RateLimiterMaxDelay = in.RateLimiterMaxDelay
+	out.RayClusterWorkloadStatusInterval = in.RayClusterWorkloadStatusInterval
+	out.RayClusterRequeueInterval = in.RayClusterRequeueInterval
+	out.RayJobRequeueInterval = in.RayJobRequeueInterval
+	out.RayServiceRequeueInterval = in.RayServiceRequeueInterval
+	out.StatusResyncInterval = in.StatusResyncInterval
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Configuration.
@@ -51,3 +80,109 @@ func (in *Configuration) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardClientConfig) DeepCopyInto(out *DashboardClientConfig) {
+	*out = *in
+	out.Timeout = in.Timeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardClientConfig.
+func (in *DashboardClientConfig) DeepCopy() *DashboardClientConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardClientConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeadPlacementConfig) DeepCopyInto(out *HeadPlacementConfig) {
+	*out = *in
+	if in.SpotNodeLabels != nil {
+		in, out := &in.SpotNodeLabels, &out.SpotNodeLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeadPlacementConfig.
+func (in *HeadPlacementConfig) DeepCopy() *HeadPlacementConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HeadPlacementConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDefaults) DeepCopyInto(out *PodDefaults) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDefaults.
+func (in *PodDefaults) DeepCopy() *PodDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingConfig) DeepCopyInto(out *TracingConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingConfig.
+func (in *TracingConfig) DeepCopy() *TracingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,275 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+// The ports of the Ray head container that the head service exposes by default. They match the defaults of the
+// KubeRay operator, which exposes the same ports if the Ray head container declares none.
+const (
+	defaultClientPort    int32 = 10001
+	defaultGcsServerPort int32 = 6379
+	defaultDashboardPort int32 = 8265
+	defaultMetricsPort   int32 = 8080
+	defaultServingPort   int32 = 8000
+
+	clientPortName    = "client"
+	gcsServerPortName = "redis"
+	dashboardPortName = "dashboard"
+	metricsPortName   = "metrics"
+	servingPortName   = "serve"
+)
+
+const (
+	// DefaultIdleTimeoutSeconds is the idle timeout of the Ray autoscaler if AutoscalerOptions.IdleTimeoutSeconds is not set
+	DefaultIdleTimeoutSeconds int32 = 60
+	// DefaultUpscalingMode is the upscaling mode of the Ray autoscaler if AutoscalerOptions.UpscalingMode is not set
+	DefaultUpscalingMode UpscalingMode = "Default"
+
+	// objectStoreMemoryProportion is the proportion of the memory of a Ray node that Ray reserves for the object
+	// store by default, see DEFAULT_OBJECT_STORE_MEMORY_PROPORTION of Ray
+	objectStoreMemoryProportion = 0.3
+	// maxDefaultObjectStoreMemory caps the default object store memory, see DEFAULT_OBJECT_STORE_MAX_MEMORY_BYTES of Ray
+	maxDefaultObjectStoreMemory int64 = 200 * 1000 * 1000 * 1000
+
+	// defaultWorkerGroupNamePrefix prefixes the names generated for worker groups without a name
+	defaultWorkerGroupNamePrefix = "workergroup"
+)
+
+// rayGPUResourceName is the Ray resource of GPUs, which Ray nodes are started with through num-gpus
+const rayGPUResourceName = "GPU"
+
+// customAcceleratorToRayResource maps the container resources of accelerators that Ray does not count as GPUs to the
+// custom Ray resources that Ray tasks and actors request them with
+var customAcceleratorToRayResource = map[string]string{
+	"aws.amazon.com/neuron":     "neuron_cores",
+	"aws.amazon.com/neuroncore": "neuron_cores",
+	"google.com/tpu":            "TPU",
+}
+
+// SetRayClusterDefaults sets the defaults of the spec of the RayCluster that the KubeRay operator would otherwise
+// apply at reconcile time, so that the spec shows the effective configuration:
+//   - empty rayStartParams of the head and worker groups
+//   - the ports of the Ray head container exposed by the head service
+//   - the idle timeout and upscaling mode of the Ray autoscaler if in-tree autoscaling is enabled
+//   - the names of the worker groups without a name
+func SetRayClusterDefaults(spec *RayClusterSpec) {
+	headGroupSpec := &spec.HeadGroupSpec
+	if headGroupSpec.RayStartParams == nil {
+		headGroupSpec.RayStartParams = map[string]string{}
+	}
+	if containers := headGroupSpec.Template.Spec.Containers; len(containers) > 0 {
+		setDefaultHeadContainerPorts(&containers[0])
+	}
+
+	if spec.EnableInTreeAutoscaling != nil && *spec.EnableInTreeAutoscaling {
+		if spec.AutoscalerOptions == nil {
+			spec.AutoscalerOptions = &AutoscalerOptions{}
+		}
+		if spec.AutoscalerOptions.IdleTimeoutSeconds == nil {
+			spec.AutoscalerOptions.IdleTimeoutSeconds = ptr.To(DefaultIdleTimeoutSeconds)
+		}
+		if spec.AutoscalerOptions.UpscalingMode == nil {
+			spec.AutoscalerOptions.UpscalingMode = ptr.To(DefaultUpscalingMode)
+		}
+	}
+
+	groupNames := map[string]bool{}
+	for _, workerGroupSpec := range spec.WorkerGroupSpecs {
+		groupNames[workerGroupSpec.GroupName] = true
+	}
+	for i := range spec.WorkerGroupSpecs {
+		workerGroupSpec := &spec.WorkerGroupSpecs[i]
+		if workerGroupSpec.GroupName == "" {
+			workerGroupSpec.GroupName = uniqueWorkerGroupName(groupNames, i)
+			groupNames[workerGroupSpec.GroupName] = true
+		}
+		if workerGroupSpec.RayStartParams == nil {
+			workerGroupSpec.RayStartParams = map[string]string{}
+		}
+	}
+}
+
+// SetRayStartParamsDefaultsFromResources sets num-cpus, memory, num-gpus, object-store-memory and the custom
+// accelerator resources of the rayStartParams of a Ray node from the resources of its Ray container, unless they are
+// set. The KubeRay operator calls it when it builds the Ray Pods, so that the spec does not keep values derived from
+// resources that change later. num-cpus is taken from the CPU limit, or the CPU request if there is no limit, and rounded up. memory is taken
+// from the memory limit. num-gpus is taken from the first resource limit, in alphabetical order, whose name ends with
+// "gpu", e.g. nvidia.com/gpu. The first custom accelerator limit, e.g. aws.amazon.com/neuron or google.com/tpu, is
+// added to the resources of the Ray node unless it already has a custom accelerator resource. It returns an error if
+// the resources rayStartParam is not a JSON object of resource quantities, after setting the other defaults.
+func SetRayStartParamsDefaultsFromResources(rayStartParams map[string]string, resources corev1.ResourceRequirements) error {
+	if _, ok := rayStartParams["num-cpus"]; !ok {
+		cpu := resources.Limits[corev1.ResourceCPU]
+		if cpu.IsZero() {
+			// Fall back to CPU request if limit is not specified
+			cpu = resources.Requests[corev1.ResourceCPU]
+		}
+		if !cpu.IsZero() {
+			rayStartParams["num-cpus"] = strconv.FormatInt(cpu.Value(), 10)
+		}
+	}
+
+	if _, ok := rayStartParams["memory"]; !ok {
+		memory := resources.Limits[corev1.ResourceMemory]
+		if !memory.IsZero() {
+			rayStartParams["memory"] = strconv.FormatInt(memory.Value(), 10)
+		}
+	}
+
+	resourceNames := make([]string, 0, len(resources.Limits))
+	for resourceName := range resources.Limits {
+		resourceNames = append(resourceNames, string(resourceName))
+	}
+	sort.Strings(resourceNames)
+
+	if _, ok := rayStartParams["num-gpus"]; !ok {
+		for _, resourceName := range resourceNames {
+			gpu := resources.Limits[corev1.ResourceName(resourceName)]
+			if strings.HasSuffix(resourceName, "gpu") && !gpu.IsZero() {
+				rayStartParams["num-gpus"] = strconv.FormatInt(gpu.Value(), 10)
+				break
+			}
+		}
+	}
+
+	setDefaultObjectStoreMemory(rayStartParams, resources)
+
+	return setDefaultCustomAcceleratorResources(rayStartParams, resources.Limits, resourceNames)
+}
+
+// setDefaultObjectStoreMemory sets object-store-memory to the share of the memory limit of the Ray container that Ray
+// reserves for the object store by default
+func setDefaultObjectStoreMemory(rayStartParams map[string]string, resources corev1.ResourceRequirements) {
+	if _, ok := rayStartParams["object-store-memory"]; ok {
+		return
+	}
+	memory := resources.Limits[corev1.ResourceMemory]
+	if memory.IsZero() {
+		return
+	}
+	objectStoreMemory := int64(float64(memory.Value()) * objectStoreMemoryProportion)
+	if objectStoreMemory > maxDefaultObjectStoreMemory {
+		objectStoreMemory = maxDefaultObjectStoreMemory
+	}
+	rayStartParams["object-store-memory"] = strconv.FormatInt(objectStoreMemory, 10)
+}
+
+// setDefaultCustomAcceleratorResources adds the first custom accelerator of the resource limits, in the order of
+// resourceNames, to the resources rayStartParam, unless the resources already include a custom accelerator
+func setDefaultCustomAcceleratorResources(rayStartParams map[string]string, limits corev1.ResourceList, resourceNames []string) error {
+	for _, resourceName := range resourceNames {
+		rayResourceName, ok := customAcceleratorToRayResource[resourceName]
+		quantity := limits[corev1.ResourceName(resourceName)]
+		if !ok || quantity.IsZero() {
+			continue
+		}
+
+		rayResources, err := getRayResources(rayStartParams)
+		if err != nil {
+			return err
+		}
+		for _, customAcceleratorRayResource := range customAcceleratorToRayResource {
+			if _, ok := rayResources[customAcceleratorRayResource]; ok {
+				return nil
+			}
+		}
+
+		rayResources[rayResourceName] = quantity.AsApproximateFloat64()
+		return setRayResources(rayStartParams, rayResources)
+	}
+	return nil
+}
+
+// SetRayStartParamsDefaultsFromResourceClaims sets num-gpus and the custom resources of the rayStartParams of a Ray
+// node from the Ray resources of its resource claims, unless they are set. The quantities of a Ray resource in several
+// claims are added up.
+func SetRayStartParamsDefaultsFromResourceClaims(rayStartParams map[string]string, claims []RayResourceClaim) error {
+	claimedResources := map[string]resource.Quantity{}
+	for _, claim := range claims {
+		for rayResourceName, quantity := range claim.RayResources {
+			total := claimedResources[rayResourceName]
+			total.Add(quantity)
+			claimedResources[rayResourceName] = total
+		}
+	}
+
+	if gpu, ok := claimedResources[rayGPUResourceName]; ok {
+		delete(claimedResources, rayGPUResourceName)
+		if _, ok := rayStartParams["num-gpus"]; !ok {
+			rayStartParams["num-gpus"] = strconv.FormatInt(gpu.Value(), 10)
+		}
+	}
+	if len(claimedResources) == 0 {
+		return nil
+	}
+
+	rayResources, err := getRayResources(rayStartParams)
+	if err != nil {
+		return err
+	}
+	for rayResourceName, quantity := range claimedResources {
+		if _, ok := rayResources[rayResourceName]; !ok {
+			rayResources[rayResourceName] = quantity.AsApproximateFloat64()
+		}
+	}
+	return setRayResources(rayStartParams, rayResources)
+}
+
+// getRayResources returns the custom resources of the resources rayStartParam
+func getRayResources(rayStartParams map[string]string) (map[string]float64, error) {
+	rayResources := map[string]float64{}
+	if resourcesStr, ok := rayStartParams["resources"]; ok {
+		// Trim any surrounding quotes (single, double, or backticks) and spaces
+		if err := json.Unmarshal([]byte(strings.Trim(resourcesStr, "'\"` ")), &rayResources); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the resources rayStartParam %s: %w", resourcesStr, err)
+		}
+	}
+	return rayResources, nil
+}
+
+// setRayResources sets the resources rayStartParam to the custom resources
+func setRayResources(rayStartParams map[string]string, rayResources map[string]float64) error {
+	resourcesJSON, err := json.Marshal(rayResources)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the resources rayStartParam: %w", err)
+	}
+	rayStartParams["resources"] = fmt.Sprintf("'%s'", resourcesJSON)
+	return nil
+}
+
+// setDefaultHeadContainerPorts adds the default ports to a Ray head container that declares none, and the metrics
+// port if it is missing
+func setDefaultHeadContainerPorts(container *corev1.Container) {
+	if len(container.Ports) == 0 {
+		container.Ports = []corev1.ContainerPort{
+			{Name: gcsServerPortName, ContainerPort: defaultGcsServerPort, Protocol: corev1.ProtocolTCP},
+			{Name: dashboardPortName, ContainerPort: defaultDashboardPort, Protocol: corev1.ProtocolTCP},
+			{Name: clientPortName, ContainerPort: defaultClientPort, Protocol: corev1.ProtocolTCP},
+			{Name: servingPortName, ContainerPort: defaultServingPort, Protocol: corev1.ProtocolTCP},
+		}
+	}
+	for _, port := range container.Ports {
+		if port.Name == metricsPortName {
+			return
+		}
+	}
+	container.Ports = append(container.Ports, corev1.ContainerPort{Name: metricsPortName, ContainerPort: defaultMetricsPort, Protocol: corev1.ProtocolTCP})
+}
+
+// uniqueWorkerGroupName returns a worker group name based on the index of the worker group that is not in groupNames
+func uniqueWorkerGroupName(groupNames map[string]bool, index int) string {
+	name := fmt.Sprintf("%s-%d", defaultWorkerGroupNamePrefix, index)
+	for suffix := 1; groupNames[name]; suffix++ {
+		name = fmt.Sprintf("%s-%d-%d", defaultWorkerGroupNamePrefix, index, suffix)
+	}
+	return name
+}
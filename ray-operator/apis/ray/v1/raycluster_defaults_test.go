@@ -0,0 +1,166 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+func newDefaultingTestContainer(resources corev1.ResourceRequirements) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "ray", Image: "rayproject/ray:2.9.0", Resources: resources}},
+		},
+	}
+}
+
+func TestSetRayClusterDefaults(t *testing.T) {
+	cluster := &RayCluster{
+		Spec: RayClusterSpec{
+			EnableInTreeAutoscaling: ptr.To(true),
+			HeadGroupSpec: HeadGroupSpec{
+				Template: newDefaultingTestContainer(corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1500m"),
+						corev1.ResourceMemory: resource.MustParse("10G"),
+					},
+				}),
+			},
+			WorkerGroupSpecs: []WorkerGroupSpec{
+				{
+					RayStartParams: map[string]string{"num-cpus": "4"},
+					Template: newDefaultingTestContainer(corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+						Limits:   corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+					}),
+				},
+				{GroupName: "workergroup-0"},
+			},
+		},
+	}
+	cluster.Default()
+
+	// The rayStartParams derived from the resources of the Ray containers are set when the Pods are built.
+	assert.Equal(t, map[string]string{}, cluster.Spec.HeadGroupSpec.RayStartParams)
+	assert.Equal(t, []corev1.ContainerPort{
+		{Name: "redis", ContainerPort: 6379, Protocol: corev1.ProtocolTCP},
+		{Name: "dashboard", ContainerPort: 8265, Protocol: corev1.ProtocolTCP},
+		{Name: "client", ContainerPort: 10001, Protocol: corev1.ProtocolTCP},
+		{Name: "serve", ContainerPort: 8000, Protocol: corev1.ProtocolTCP},
+		{Name: "metrics", ContainerPort: 8080, Protocol: corev1.ProtocolTCP},
+	}, cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports)
+	assert.Equal(t, &AutoscalerOptions{
+		IdleTimeoutSeconds: ptr.To(DefaultIdleTimeoutSeconds),
+		UpscalingMode:      ptr.To(DefaultUpscalingMode),
+	}, cluster.Spec.AutoscalerOptions)
+
+	assert.Equal(t, "workergroup-0-1", cluster.Spec.WorkerGroupSpecs[0].GroupName)
+	assert.Equal(t, map[string]string{"num-cpus": "4"}, cluster.Spec.WorkerGroupSpecs[0].RayStartParams)
+	assert.Equal(t, "workergroup-0", cluster.Spec.WorkerGroupSpecs[1].GroupName)
+	assert.Equal(t, map[string]string{}, cluster.Spec.WorkerGroupSpecs[1].RayStartParams)
+}
+
+func TestSetRayClusterDefaultsKeepsUserValues(t *testing.T) {
+	ports := []corev1.ContainerPort{{Name: "gcs", ContainerPort: 6380}, {Name: "metrics", ContainerPort: 9090}}
+	template := newDefaultingTestContainer(corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Ti")},
+	})
+	template.Spec.Containers[0].Ports = ports
+	spec := RayClusterSpec{
+		HeadGroupSpec: HeadGroupSpec{
+			RayStartParams: map[string]string{"memory": "1000"},
+			Template:       template,
+		},
+	}
+	SetRayClusterDefaults(&spec)
+
+	assert.Equal(t, ports, spec.HeadGroupSpec.Template.Spec.Containers[0].Ports)
+	assert.Equal(t, map[string]string{"memory": "1000"}, spec.HeadGroupSpec.RayStartParams)
+	assert.Nil(t, spec.AutoscalerOptions)
+}
+
+func TestSetRayStartParamsDefaultsFromResources(t *testing.T) {
+	tests := []struct {
+		rayStartParams map[string]string
+		limits         corev1.ResourceList
+		expected       map[string]string
+		name           string
+		expectError    bool
+	}{
+		{
+			name:           "TPUs",
+			rayStartParams: map[string]string{},
+			limits: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("8"),
+				"google.com/tpu":   resource.MustParse("4"),
+			},
+			expected: map[string]string{"num-cpus": "8", "resources": `'{"TPU":4}'`},
+		},
+		{
+			name:           "Neuron cores added to the existing resources",
+			rayStartParams: map[string]string{"resources": `'{"custom_resource":2}'`},
+			limits:         corev1.ResourceList{"aws.amazon.com/neuroncore": resource.MustParse("2")},
+			expected:       map[string]string{"resources": `'{"custom_resource":2,"neuron_cores":2}'`},
+		},
+		{
+			name:           "Neuron devices",
+			rayStartParams: map[string]string{},
+			limits:         corev1.ResourceList{"aws.amazon.com/neuron": resource.MustParse("1")},
+			expected:       map[string]string{"resources": `'{"neuron_cores":1}'`},
+		},
+		{
+			name:           "existing custom accelerator resource",
+			rayStartParams: map[string]string{"resources": `'{"TPU":1}'`},
+			limits:         corev1.ResourceList{"google.com/tpu": resource.MustParse("4")},
+			expected:       map[string]string{"resources": `'{"TPU":1}'`},
+		},
+		{
+			name:           "invalid resources",
+			rayStartParams: map[string]string{"resources": "{"},
+			limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("1G"),
+				"google.com/tpu":      resource.MustParse("4"),
+			},
+			expected:    map[string]string{"memory": "1000000000", "object-store-memory": "300000000", "resources": "{"},
+			expectError: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := SetRayStartParamsDefaultsFromResources(tc.rayStartParams, corev1.ResourceRequirements{Limits: tc.limits})
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, tc.rayStartParams)
+		})
+	}
+}
+
+func TestSetRayStartParamsDefaultsFromResourceClaims(t *testing.T) {
+	claims := []RayResourceClaim{
+		{Name: "gpus", RayResources: map[string]resource.Quantity{"GPU": resource.MustParse("2"), "accelerator_type:H100": resource.MustParse("1")}},
+		{Name: "more-gpus", RayResources: map[string]resource.Quantity{"GPU": resource.MustParse("2")}},
+	}
+
+	rayStartParams := map[string]string{"resources": `'{"custom_resource":2}'`}
+	require.NoError(t, SetRayStartParamsDefaultsFromResourceClaims(rayStartParams, claims))
+	assert.Equal(t, map[string]string{
+		"num-gpus":  "4",
+		"resources": `'{"accelerator_type:H100":1,"custom_resource":2}'`,
+	}, rayStartParams)
+
+	// The rayStartParams set by the user are kept.
+	rayStartParams = map[string]string{"num-gpus": "1", "resources": `'{"accelerator_type:H100":0.5}'`}
+	require.NoError(t, SetRayStartParamsDefaultsFromResourceClaims(rayStartParams, claims))
+	assert.Equal(t, map[string]string{"num-gpus": "1", "resources": `'{"accelerator_type:H100":0.5}'`}, rayStartParams)
+
+	rayStartParams = map[string]string{"resources": "{"}
+	require.Error(t, SetRayStartParamsDefaultsFromResourceClaims(rayStartParams, claims))
+	assert.Equal(t, "4", rayStartParams["num-gpus"])
+}
@@ -4,6 +4,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -19,30 +20,333 @@ type RayClusterSpec struct {
 	HeadServiceAnnotations map[string]string  `json:"headServiceAnnotations,omitempty"`
 	// EnableInTreeAutoscaling indicates whether operator should create in tree autoscaling configs
 	EnableInTreeAutoscaling *bool `json:"enableInTreeAutoscaling,omitempty"`
+	// IdleTimeoutSeconds is the number of seconds that the Ray cluster must be idle, with no active job, running task,
+	// or alive actor, before the KubeRay operator terminates the RayCluster as specified by IdleTimeoutAction.
+	// If it is not set, the RayCluster is never terminated for being idle. It is ignored for the RayClusters of RayJobs
+	// and RayServices, which manage the lifecycle of their RayClusters themselves.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	IdleTimeoutSeconds *int32 `json:"idleTimeoutSeconds,omitempty"`
+	// EnablePodDisruptionBudgets indicates whether the KubeRay operator creates PodDisruptionBudgets for the Pods of
+	// the RayCluster, so that voluntary disruptions such as node drains do not evict the head Pod, and evict the Pods
+	// of each worker group within its DisruptionBudget. If it is not set, the default of the KubeRay operator is used.
+	// +optional
+	EnablePodDisruptionBudgets *bool `json:"enablePodDisruptionBudgets,omitempty"`
+	// Security configures the security of the Ray cluster.
+	// +optional
+	Security *RayClusterSecurity `json:"security,omitempty"`
+	// GcsFaultToleranceOptions configures GCS fault tolerance. Setting it enables GCS fault tolerance, like the
+	// ray.io/ft-enabled annotation.
+	// +optional
+	GcsFaultToleranceOptions *GcsFaultToleranceOptions `json:"gcsFaultToleranceOptions,omitempty"`
+	// PrometheusMonitors configures the Prometheus Operator ServiceMonitor and PodMonitor that scrape the metrics of
+	// the Ray cluster.
+	// +optional
+	PrometheusMonitors *PrometheusMonitorOptions `json:"prometheusMonitors,omitempty"`
+	// Logging configures a log collection sidecar that forwards the Ray logs of the head and worker Pods to a log
+	// backend, so that the logs survive the deletion of the Pods.
+	// +optional
+	Logging *RayClusterLogging `json:"logging,omitempty"`
+	// ServiceMesh makes the Pods of the RayCluster compatible with a service mesh that injects a proxy sidecar into
+	// them: the Ray containers wait for the proxy to start, the GCS and worker ports of Ray are excluded from the
+	// redirection to the proxy, and the proxy of the RayJob submitter Pod is terminated once the submission finishes.
+	// +optional
+	ServiceMesh *ServiceMeshOptions `json:"serviceMesh,omitempty"`
+	// WaitForHead configures how the wait-gcs-ready init container of the worker Pods waits for the head service to
+	// resolve and for the GCS server to be ready before the Ray workers start.
+	// +optional
+	WaitForHead *WaitForHeadOptions `json:"waitForHead,omitempty"`
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 	// HeadGroupSpecs are the spec for the head pod
 	HeadGroupSpec HeadGroupSpec `json:"headGroupSpec"`
 	// RayVersion is used to determine the command for the Kubernetes Job managed by RayJob
 	RayVersion string `json:"rayVersion,omitempty"`
+	// IdleTimeoutAction is Delete or Suspend. It is the action that the KubeRay operator takes on the RayCluster once
+	// the Ray cluster has been idle for IdleTimeoutSeconds. The default is Delete.
+	// +optional
+	IdleTimeoutAction IdleTimeoutAction `json:"idleTimeoutAction,omitempty"`
 	// WorkerGroupSpecs are the specs for the worker pods
 	WorkerGroupSpecs []WorkerGroupSpec `json:"workerGroupSpecs,omitempty"`
 }
 
+// PrometheusMonitorOptions configures the Prometheus Operator monitors of the metrics of a Ray cluster
+type PrometheusMonitorOptions struct {
+	// Labels are added to the monitors, so that the monitor selectors of Prometheus select them, e.g.
+	// release: prometheus for the kube-prometheus-stack Helm chart.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Enabled indicates whether the KubeRay operator creates a ServiceMonitor for the metrics of the head Pod and a
+	// PodMonitor for the metrics of the worker Pods. If it is not set, the default of the KubeRay operator is used.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Interval is the interval at which Prometheus scrapes the metrics, e.g. 30s. If it is not set, the scrape
+	// interval of Prometheus is used.
+	// +kubebuilder:validation:Pattern=`^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$`
+	// +optional
+	Interval string `json:"interval,omitempty"`
+}
+
+// RayClusterLogging configures a log collection sidecar, e.g. Fluent Bit, that the KubeRay operator injects into the
+// head and worker Pods. The sidecar shares the Ray log directory /tmp/ray with the Ray container, and its POD_NAME,
+// POD_NAMESPACE and RAY_CLUSTER_NAME environment variables can be used to tag the log records.
+type RayClusterLogging struct {
+	// Image is the image of the log collection sidecar. Defaults to fluent/fluent-bit:3.1.
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// Resources are the compute resources of the log collection sidecar.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// ConfigMapName is the name of the ConfigMap in the namespace of the RayCluster with the configuration of the log
+	// collection sidecar, e.g. the fluent-bit.conf of Fluent Bit with its inputs and outputs.
+	// +kubebuilder:validation:MinLength=1
+	ConfigMapName string `json:"configMapName"`
+	// ConfigMountPath is the directory of the log collection sidecar where the ConfigMap is mounted. Defaults to
+	// /fluent-bit/etc/, where Fluent Bit reads its configuration.
+	// +optional
+	ConfigMountPath string `json:"configMountPath,omitempty"`
+}
+
+// ServiceMeshOptions configures the compatibility of a Ray cluster with a service mesh
+type ServiceMeshOptions struct {
+	// Type is the service mesh that injects its proxy into the Pods, Istio or Linkerd.
+	Type ServiceMeshType `json:"type"`
+}
+
+// WaitForHeadOptions configures the exponential backoff with which the worker Pods wait for the head of a Ray cluster.
+// Once the worker Pods have waited for TimeoutSeconds, the init container fails with a message that tells whether the
+// head service could not be resolved or the GCS server was not ready, the KubeRay operator sets the
+// ray.io/head-reachable condition of the Pods and records an event, and the kubelet restarts the init container.
+type WaitForHeadOptions struct {
+	// InitialBackoffSeconds is the delay before the first retry. The delay doubles after each retry. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	InitialBackoffSeconds *int32 `json:"initialBackoffSeconds,omitempty"`
+	// MaxBackoffSeconds is the maximum delay between two retries. Defaults to 30.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxBackoffSeconds *int32 `json:"maxBackoffSeconds,omitempty"`
+	// TimeoutSeconds is the maximum time that the worker Pods wait for the head before the init container fails.
+	// Defaults to 600.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Istio;Linkerd
+type ServiceMeshType string
+
+const (
+	// IstioServiceMesh configures the Pods with the annotations of the Istio sidecar injector.
+	IstioServiceMesh ServiceMeshType = "Istio"
+	// LinkerdServiceMesh configures the Pods with the annotations of the Linkerd proxy injector.
+	LinkerdServiceMesh ServiceMeshType = "Linkerd"
+)
+
+// RayClusterSecurity configures the security of a Ray cluster
+type RayClusterSecurity struct {
+	// TLS configures TLS for the gRPC connections between the Ray nodes.
+	// +optional
+	TLS *RayClusterTLS `json:"tls,omitempty"`
+}
+
+// RayClusterTLS configures TLS between the Ray nodes with a CA that cert-manager issues for the RayCluster.
+// The KubeRay operator creates a cert-manager Certificate of the CA, and an init container of each Ray Pod signs a
+// certificate with the CA that is valid for the IP of the Pod. The operator mounts the certificate into the Ray
+// containers and sets their RAY_USE_TLS environment variables. The certificates of the Pods are signed when the Pods
+// start, so Pods must be recreated before the CA certificate expires. cert-manager must be installed in the
+// Kubernetes cluster, and the images of the Ray containers must provide openssl.
+type RayClusterTLS struct {
+	// IssuerRef is the cert-manager issuer of the CA of the RayCluster. It must be set if TLS is enabled.
+	// +optional
+	IssuerRef *CertificateIssuerReference `json:"issuerRef,omitempty"`
+	// Enabled indicates whether the Ray nodes connect to each other with TLS.
+	Enabled bool `json:"enabled"`
+}
+
+// CertificateIssuerReference references a cert-manager Issuer or ClusterIssuer
+type CertificateIssuerReference struct {
+	// Name is the name of the issuer. An Issuer must be in the namespace of the RayCluster.
+	Name string `json:"name"`
+	// Kind is the kind of the issuer, e.g. Issuer or ClusterIssuer. The default is Issuer.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Group is the API group of the issuer. The default is cert-manager.io.
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
+// GcsFaultToleranceOptions configures GCS fault tolerance, which stores the metadata of the GCS server in Redis so
+// that the Ray cluster survives the failure of its head Pod. The KubeRay operator sets the Redis address and
+// credentials of the Ray head container, and checks that it can connect to Redis in the GCSFaultToleranceReady
+// condition.
+type GcsFaultToleranceOptions struct {
+	// RedisUsername is the username of the Redis server.
+	// +optional
+	RedisUsername *RedisCredential `json:"redisUsername,omitempty"`
+	// RedisPassword is the password of the Redis server.
+	// +optional
+	RedisPassword *RedisCredential `json:"redisPassword,omitempty"`
+	// RedisCABundle is the key of a Secret with the CA bundle that verifies the certificate of a Redis server with TLS.
+	// The KubeRay operator mounts it into the head Pod. It can only be set if RedisAddress uses the rediss scheme.
+	// +optional
+	RedisCABundle *corev1.SecretKeySelector `json:"redisCABundle,omitempty"`
+	// RedisCleanup configures the Job that deletes the GCS metadata of the RayCluster from Redis once the RayCluster
+	// is deleted.
+	// +optional
+	RedisCleanup *RedisCleanupOptions `json:"redisCleanup,omitempty"`
+	// RedisAddress is the address of the Redis server, e.g. redis:6379. Use the rediss scheme, e.g.
+	// rediss://redis:6379, to connect to the Redis server with TLS. In the Sentinel and Cluster modes, it is a
+	// comma-separated list of endpoints, e.g. redis-0:26379,redis-1:26379. Ray connects to the first endpoint,
+	// and the KubeRay operator falls back to the other endpoints to check and clean up Redis.
+	RedisAddress string `json:"redisAddress"`
+	// RedisMode is the deployment mode of Redis: Standalone, Sentinel or Cluster. The default is Standalone.
+	// +optional
+	RedisMode RedisMode `json:"redisMode,omitempty"`
+	// RestartWorkersAfterReconnectTimeout restarts the worker Pods whose Raylets gave up reconnecting to the GCS
+	// server while the head Pod was recovering, i.e. whose RAY_gcs_rpc_server_reconnect_timeout_s is shorter than
+	// the downtime of the head Pod. Otherwise, these worker Pods are only replaced once their Ray container exits.
+	// +optional
+	RestartWorkersAfterReconnectTimeout bool `json:"restartWorkersAfterReconnectTimeout,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Standalone;Sentinel;Cluster
+type RedisMode string
+
+const (
+	// StandaloneRedisMode means that RedisAddress is the address of a Redis server.
+	StandaloneRedisMode RedisMode = "Standalone"
+	// SentinelRedisMode means that RedisAddress lists Redis Sentinels, from which Ray finds the Redis master.
+	SentinelRedisMode RedisMode = "Sentinel"
+	// ClusterRedisMode means that RedisAddress lists nodes of a Redis Cluster.
+	ClusterRedisMode RedisMode = "Cluster"
+)
+
+// RedisCleanupOptions configures the Redis cleanup Job of a RayCluster with GCS fault tolerance
+type RedisCleanupOptions struct {
+	// BackoffLimit is the number of retries of the Redis cleanup Job. Kubernetes delays the retries with an
+	// exponential backoff. The default is 2.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	// Image is the image of the Redis cleanup Job, which must have the Ray version of the RayCluster installed.
+	// The default is the image of the Ray head container.
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// RedisCredential is a Redis username or password, which is either set directly or read from a Secret
+type RedisCredential struct {
+	// ValueFrom is the source of the credential, e.g. a key of a Secret.
+	// +optional
+	ValueFrom *corev1.EnvVarSource `json:"valueFrom,omitempty"`
+	// Value is the credential. Prefer a Secret in ValueFrom for passwords.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Delete;Suspend
+type IdleTimeoutAction string
+
+const (
+	// DeleteIdleTimeoutAction deletes an idle RayCluster.
+	DeleteIdleTimeoutAction IdleTimeoutAction = "Delete"
+	// SuspendIdleTimeoutAction suspends an idle RayCluster, which deletes its Pods but keeps the RayCluster.
+	SuspendIdleTimeoutAction IdleTimeoutAction = "Suspend"
+)
+
 // HeadGroupSpec are the spec for the head pod
 type HeadGroupSpec struct {
 	// ServiceType is Kubernetes service type of the head service. it will be used by the workers to connect to the head pod
 	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
-	// HeadService is the Kubernetes service of the head pod.
+	// HeadService is the Kubernetes service of the head pod. Its type, annotations, ports and external traffic policy
+	// are kept in sync with the head service after the RayCluster is created. Its ports are added to the default ports
+	// of the head Pod, and replace the default ports with the same name or port number.
 	HeadService *corev1.Service `json:"headService,omitempty"`
 	// EnableIngress indicates whether operator should create ingress object for head service or not.
 	EnableIngress *bool `json:"enableIngress,omitempty"`
+	// Ingress configures the Ingress or the Gateway API HTTPRoutes that expose the Ray dashboard and Ray Serve. It
+	// cannot be set together with EnableIngress.
+	// +optional
+	Ingress *HeadIngressOptions `json:"ingress,omitempty"`
 	// RayStartParams are the params of the start command: node-manager-port, object-store-memory, ...
 	RayStartParams map[string]string `json:"rayStartParams"`
+	// ResourceClaims are the Dynamic Resource Allocation claims of the Ray container of the head Pod.
+	// +optional
+	ResourceClaims []RayResourceClaim `json:"resourceClaims,omitempty"`
+	// LogVolume is the volume that the KubeRay operator mounts at the Ray log and temporary directory /tmp/ray of the
+	// Ray container of the head Pod, instead of the writable layer of the container.
+	// +optional
+	LogVolume *RayVolume `json:"logVolume,omitempty"`
+	// SpillVolume is the volume that the KubeRay operator mounts at /tmp/ray-spill of the Ray container of the head
+	// Pod, where Ray spills objects when the object store is full.
+	// +optional
+	SpillVolume *RayVolume `json:"spillVolume,omitempty"`
+	// Probes configures the probes that the KubeRay operator injects into the Ray container of the head Pod, which
+	// check the health of the GCS server with ray health-check and the health of the raylet.
+	// +optional
+	Probes *RayProbeOptions `json:"probes,omitempty"`
+	// SidecarContainers are added to the head Pod after the autoscaler and the sidecar containers of the operator
+	// configuration. If the NativeSidecarContainers feature gate is enabled, they are Kubernetes native sidecar
+	// containers that start in their order before the Ray container and stop in the reverse order after it.
+	// +optional
+	SidecarContainers []corev1.Container `json:"sidecarContainers,omitempty"`
 	// Template is the exact pod template used in K8s depoyments, statefulsets, etc.
 	Template corev1.PodTemplateSpec `json:"template"`
 }
 
+// HeadIngressOptions configures the Ingress or the Gateway API HTTPRoutes that the KubeRay operator creates to expose
+// the Ray dashboard and Ray Serve. The Ingress of a RayService routes to the head and serve services of the RayService,
+// so that it keeps working when the RayCluster is upgraded.
+type HeadIngressOptions struct {
+	// Annotations are added to the Ingress or the HTTPRoutes, e.g. the authentication annotations of an ingress
+	// controller.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// IngressClassName is the IngressClass of the Ingress.
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+	// Gateway is the Gateway that the HTTPRoutes are attached to. It is required if Type is HTTPRoute.
+	// +optional
+	Gateway *GatewayReference `json:"gateway,omitempty"`
+	// Type is Ingress or HTTPRoute. The default is Ingress.
+	// +optional
+	Type IngressType `json:"type,omitempty"`
+	// DashboardHost is the host name of the Ray dashboard. If it is not set, the Ray dashboard is exposed on all hosts.
+	// +optional
+	DashboardHost string `json:"dashboardHost,omitempty"`
+	// ServeHost is the host name of Ray Serve. If it is not set, Ray Serve is not exposed.
+	// +optional
+	ServeHost string `json:"serveHost,omitempty"`
+	// TLSSecretName is the Secret with the TLS certificate of DashboardHost and ServeHost, which the Ingress
+	// terminates TLS with. For HTTPRoutes, TLS is terminated by the listeners of the Gateway.
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+}
+
+// GatewayReference refers to a Gateway of the Gateway API
+type GatewayReference struct {
+	// Name is the name of the Gateway.
+	Name string `json:"name"`
+	// Namespace is the namespace of the Gateway. The default is the namespace of the RayCluster or RayService.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// SectionName is the name of the listener of the Gateway. If it is not set, the HTTPRoutes are attached to all
+	// listeners of the Gateway.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Ingress;HTTPRoute
+type IngressType string
+
+const (
+	// KubernetesIngressType exposes the Ray dashboard and Ray Serve with a Kubernetes Ingress.
+	KubernetesIngressType IngressType = "Ingress"
+	// HTTPRouteIngressType exposes the Ray dashboard and Ray Serve with Gateway API HTTPRoutes.
+	HTTPRouteIngressType IngressType = "HTTPRoute"
+)
+
 // WorkerGroupSpec are the specs for the worker pods
 type WorkerGroupSpec struct {
 	// we can have multiple worker groups, we distinguish them by name
@@ -58,6 +362,74 @@ type WorkerGroupSpec struct {
 	MaxReplicas *int32 `json:"maxReplicas"`
 	// RayStartParams are the params of the start command: address, object-store-memory, ...
 	RayStartParams map[string]string `json:"rayStartParams"`
+	// UpdateStrategy defines how the Pods of this worker group are replaced when its Pod template or
+	// rayStartParams change. The default is OnDelete.
+	// +optional
+	UpdateStrategy *WorkerGroupUpdateStrategy `json:"updateStrategy,omitempty"`
+	// DrainGracePeriodSeconds is the maximum number of seconds that the KubeRay operator waits for the running tasks
+	// and actors of a Ray worker node to finish before it deletes the worker Pod to scale down or update the worker
+	// group. The KubeRay operator asks the GCS server to drain the Ray node, so that no new tasks or actors are
+	// scheduled on it. If it is not set or 0, worker Pods are deleted right away.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DrainGracePeriodSeconds *int32 `json:"drainGracePeriodSeconds,omitempty"`
+	// IdleTimeoutSeconds is the number of seconds that a worker Pod of this group must be idle before the Ray
+	// autoscaler scales it down. It overrides autoscalerOptions.idleTimeoutSeconds for this group, and can only be
+	// set with autoscaler v2. It is not read by the KubeRay operator but by the Ray autoscaler.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	IdleTimeoutSeconds *int32 `json:"idleTimeoutSeconds,omitempty"`
+	// UpscalingMode is Conservative to rate-limit the upscaling of this group, so that it has at most as many
+	// pending worker Pods as running ones, and at least one. The KubeRay operator enforces it when it creates the
+	// worker Pods, because autoscalerOptions.upscalingMode applies to the whole Ray cluster. Default and Aggressive
+	// do not rate-limit the group. It cannot be set if WorkloadType is StatefulSet.
+	// +optional
+	UpscalingMode *UpscalingMode `json:"upscalingMode,omitempty"`
+	// DisruptionBudget configures the PodDisruptionBudget of the worker group, if the RayCluster has
+	// PodDisruptionBudgets enabled. The default allows one worker Pod to be unavailable.
+	// +optional
+	DisruptionBudget *WorkerGroupDisruptionBudget `json:"disruptionBudget,omitempty"`
+	// ResourceClaims are the Dynamic Resource Allocation claims of the Ray container of the worker Pods.
+	// +optional
+	ResourceClaims []RayResourceClaim `json:"resourceClaims,omitempty"`
+	// LogVolume is the volume that the KubeRay operator mounts at the Ray log and temporary directory /tmp/ray of the
+	// Ray container of each worker Pod. It keeps the Ray logs and temporary files from filling the disk of the node.
+	// +optional
+	LogVolume *RayVolume `json:"logVolume,omitempty"`
+	// SpillVolume is the volume that the KubeRay operator mounts at /tmp/ray-spill of the Ray container of each worker
+	// Pod, where Ray spills objects when the object store is full.
+	// +optional
+	SpillVolume *RayVolume `json:"spillVolume,omitempty"`
+	// Probes configures the probes that the KubeRay operator injects into the Ray container of the worker Pods, which
+	// check the health of the raylet.
+	// +optional
+	Probes *RayProbeOptions `json:"probes,omitempty"`
+	// RecreatePolicy controls whether and how fast the KubeRay operator recreates the worker Pods that fail, e.g.
+	// because they were evicted or their Ray container was OOMKilled with the Never restart policy. If it is not
+	// set, failed worker Pods are deleted and recreated right away. It cannot be set if WorkloadType is StatefulSet.
+	// +optional
+	RecreatePolicy *WorkerGroupRecreatePolicy `json:"recreatePolicy,omitempty"`
+	// SidecarContainers are added to the worker Pods after the sidecar containers of the operator configuration. If
+	// the NativeSidecarContainers feature gate is enabled, they are Kubernetes native sidecar containers that start in
+	// their order before the Ray container and stop in the reverse order after it.
+	// +optional
+	SidecarContainers []corev1.Container `json:"sidecarContainers,omitempty"`
+	// SpotFallback schedules the worker Pods on spot nodes, and falls back to on-demand nodes for a while when spot
+	// worker Pods are preempted repeatedly. It cannot be set if WorkloadType is StatefulSet.
+	// +optional
+	SpotFallback *SpotFallbackOptions `json:"spotFallback,omitempty"`
+	// VolumeClaimTemplates are the PersistentVolumeClaims that are created for each worker Pod and kept across
+	// restarts of the Pod. They can only be set if WorkloadType is StatefulSet.
+	// +optional
+	VolumeClaimTemplates []corev1.PersistentVolumeClaim `json:"volumeClaimTemplates,omitempty"`
+	// VolumeClaimRetentionPolicy is Retain or Delete. It defines whether the PersistentVolumeClaims of
+	// VolumeClaimTemplates are kept or deleted when the RayCluster is deleted, and can only be set with
+	// VolumeClaimTemplates. The default is Retain.
+	// +optional
+	VolumeClaimRetentionPolicy VolumeClaimRetentionPolicy `json:"volumeClaimRetentionPolicy,omitempty"`
+	// WorkloadType is Pod or StatefulSet. The default is Pod.
+	// +optional
+	WorkloadType WorkerGroupWorkloadType `json:"workloadType,omitempty"`
 	// Template is a pod template for the worker
 	Template corev1.PodTemplateSpec `json:"template"`
 	// ScaleStrategy defines which pods to remove
@@ -73,6 +445,229 @@ type ScaleStrategy struct {
 	WorkersToDelete []string `json:"workersToDelete,omitempty"`
 }
 
+// WorkerGroupDisruptionBudget configures the PodDisruptionBudget of a worker group. At most one of MaxUnavailable
+// and MinAvailable can be set.
+type WorkerGroupDisruptionBudget struct {
+	// MaxUnavailable is the number or percentage of worker Pods of the group that can be unavailable after an eviction.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// MinAvailable is the number or percentage of worker Pods of the group that must still be available after an
+	// eviction.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+}
+
+// RayResourceClaim is a Dynamic Resource Allocation claim of the Ray container, e.g. of GPUs. The KubeRay operator adds
+// the claim to the resourceClaims of the Pods and to the resource claims of the Ray container, and starts the Ray node
+// with the Ray resources of the devices that the claim allocates. Exactly one of ResourceClaimName and
+// ResourceClaimTemplateName must be set.
+type RayResourceClaim struct {
+	// RayResources are the Ray resources of the devices that the claim allocates, e.g. 2 for GPU. GPU sets num-gpus
+	// of the rayStartParams and the other resources are added to the custom resources of the Ray node, unless the
+	// rayStartParams already set them. If it is not set, the Ray resources are derived from the devices that the
+	// ResourceClaim allocates, or that the ResourceClaimTemplate requests, whose device classes are named gpu.*,
+	// tpu.* or neuron.*.
+	// +optional
+	RayResources map[string]resource.Quantity `json:"rayResources,omitempty"`
+	// ResourceClaimName is the name of a ResourceClaim in the namespace of the RayCluster that the Pods share.
+	// +optional
+	ResourceClaimName *string `json:"resourceClaimName,omitempty"`
+	// ResourceClaimTemplateName is the name of a ResourceClaimTemplate in the namespace of the RayCluster, from which
+	// a ResourceClaim is created for each Pod.
+	// +optional
+	ResourceClaimTemplateName *string `json:"resourceClaimTemplateName,omitempty"`
+	// Name identifies the claim in the Pods. It must be unique among the resource claims of the Pods.
+	Name string `json:"name"`
+}
+
+// RayProbeOptions configures the probes that the KubeRay operator injects into the Ray container of a group. The
+// probes of the Ray container in the Pod template take precedence, and no probes are injected if the
+// ENABLE_PROBES_INJECTION environment variable of the KubeRay operator is false.
+type RayProbeOptions struct {
+	// Startup adds a startup probe with the health checks of the liveness probe, which holds off the liveness and
+	// readiness probes until the Ray node has started, so that slow-starting Ray nodes are not restarted. It is not
+	// injected if it is not set.
+	// +optional
+	Startup *RayProbe `json:"startup,omitempty"`
+	// Liveness overrides the timing and the failure threshold of the liveness probe.
+	// +optional
+	Liveness *RayProbe `json:"liveness,omitempty"`
+	// Readiness overrides the timing and the failure threshold of the readiness probe.
+	// +optional
+	Readiness *RayProbe `json:"readiness,omitempty"`
+}
+
+// RayProbe overrides the timing and the failure threshold of a probe. The defaults of the KubeRay operator are used
+// for the fields that are not set.
+type RayProbe struct {
+	// InitialDelaySeconds is the number of seconds after the Ray container has started before the probe is run.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+	// TimeoutSeconds is the number of seconds after which the probe times out.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// PeriodSeconds is how often the probe is run.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	// FailureThreshold is the number of consecutive failures after which the probe fails.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// RayVolume is a volume that the KubeRay operator mounts into the Ray container of each Pod of a group. Exactly one of
+// EmptyDir, HostPath and VolumeClaimTemplate must be set.
+type RayVolume struct {
+	// EmptyDir is an emptyDir volume, whose size can be limited with its sizeLimit. It is deleted with the Pod.
+	// +optional
+	EmptyDir *corev1.EmptyDirVolumeSource `json:"emptyDir,omitempty"`
+	// HostPath is a directory of the node, which the Pods on the node share. Each Pod mounts the subdirectory named
+	// after the Pod. The KubeRay operator never deletes its content.
+	// +optional
+	HostPath *corev1.HostPathVolumeSource `json:"hostPath,omitempty"`
+	// VolumeClaimTemplate is the template of the PersistentVolumeClaim that is created for each Pod.
+	// +optional
+	VolumeClaimTemplate *corev1.PersistentVolumeClaimTemplate `json:"volumeClaimTemplate,omitempty"`
+	// CleanupPolicy is DeleteWithPod or DeleteWithCluster. It defines when the PersistentVolumeClaims of
+	// VolumeClaimTemplate are deleted, and can only be set with VolumeClaimTemplate. The default is DeleteWithPod.
+	// +optional
+	CleanupPolicy RayVolumeCleanupPolicy `json:"cleanupPolicy,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=DeleteWithPod;DeleteWithCluster
+type RayVolumeCleanupPolicy string
+
+const (
+	// DeleteWithPodCleanupPolicy deletes the PersistentVolumeClaim of a Pod with the Pod, as a generic ephemeral volume.
+	DeleteWithPodCleanupPolicy RayVolumeCleanupPolicy = "DeleteWithPod"
+	// DeleteWithClusterCleanupPolicy keeps the PersistentVolumeClaim of a Pod after the Pod is deleted, e.g. to
+	// inspect the Ray logs of a failed Pod, and deletes it with the RayCluster. It cannot be used with StatefulSet
+	// worker groups, which keep PersistentVolumeClaims with volumeClaimTemplates.
+	DeleteWithClusterCleanupPolicy RayVolumeCleanupPolicy = "DeleteWithCluster"
+)
+
+// +kubebuilder:validation:Enum=Retain;Delete
+type VolumeClaimRetentionPolicy string
+
+const (
+	// RetainVolumeClaimRetentionPolicy keeps the PersistentVolumeClaims of a StatefulSet worker group after the
+	// RayCluster is deleted, e.g. to reuse them with a new RayCluster of the same name.
+	RetainVolumeClaimRetentionPolicy VolumeClaimRetentionPolicy = "Retain"
+	// DeleteVolumeClaimRetentionPolicy deletes the PersistentVolumeClaims of a StatefulSet worker group before the
+	// finalizer of the RayCluster is removed.
+	DeleteVolumeClaimRetentionPolicy VolumeClaimRetentionPolicy = "Delete"
+)
+
+// WorkerGroupRecreatePolicy controls the recreation of the failed worker Pods of a worker group. The failed Pods are
+// recreated right away the first time, and with an exponential backoff if Pods keep failing. The consecutive failures
+// are forgotten once no Pod of the worker group has failed for twice MaxBackoffSeconds.
+type WorkerGroupRecreatePolicy struct {
+	// InitialBackoffSeconds is the delay before the failed Pods are recreated after the second consecutive failure.
+	// The delay doubles after each consecutive failure. Defaults to 10.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialBackoffSeconds *int32 `json:"initialBackoffSeconds,omitempty"`
+	// MaxBackoffSeconds is the maximum delay before the failed Pods are recreated. Defaults to 300.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxBackoffSeconds *int32 `json:"maxBackoffSeconds,omitempty"`
+	// Type is Always or Never. Never keeps the failed Pods for debugging instead of recreating them, so they still
+	// count towards the replicas of the worker group. The default is Always.
+	// +optional
+	Type WorkerGroupRecreatePolicyType `json:"type,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Always;Never
+type WorkerGroupRecreatePolicyType string
+
+const (
+	// AlwaysWorkerGroupRecreatePolicyType recreates the failed worker Pods.
+	AlwaysWorkerGroupRecreatePolicyType WorkerGroupRecreatePolicyType = "Always"
+	// NeverWorkerGroupRecreatePolicyType keeps the failed worker Pods.
+	NeverWorkerGroupRecreatePolicyType WorkerGroupRecreatePolicyType = "Never"
+)
+
+// SpotFallbackOptions schedules the worker Pods of a worker group on spot nodes. Once PreemptionThreshold spot worker
+// Pods have been preempted within CooldownSeconds of each other, new worker Pods are scheduled on on-demand nodes for
+// CooldownSeconds, and then on spot nodes again. The running worker Pods are not moved. The node placements are added
+// to the node selector and tolerations of the Pod template, and the ray.io/capacity-type label of the worker Pods is
+// spot or on-demand.
+type SpotFallbackOptions struct {
+	// PreemptionThreshold is the number of preempted spot worker Pods after which the worker group falls back to
+	// on-demand nodes. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PreemptionThreshold *int32 `json:"preemptionThreshold,omitempty"`
+	// CooldownSeconds is how long the worker group stays on on-demand nodes after it falls back. Defaults to 1800.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CooldownSeconds *int32 `json:"cooldownSeconds,omitempty"`
+	// Spot is the node placement of the worker Pods on spot nodes.
+	Spot NodePlacement `json:"spot"`
+	// OnDemand is the node placement of the worker Pods on on-demand nodes.
+	OnDemand NodePlacement `json:"onDemand"`
+}
+
+// NodePlacement is a node selector and tolerations that schedule Pods on a set of nodes.
+type NodePlacement struct {
+	// NodeSelector are the node labels that the nodes must have.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations are the tolerations of the taints of the nodes.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Pod;StatefulSet
+type WorkerGroupWorkloadType string
+
+const (
+	// PodWorkerGroupWorkloadType lets the KubeRay operator create and delete the worker Pods itself.
+	PodWorkerGroupWorkloadType WorkerGroupWorkloadType = "Pod"
+	// StatefulSetWorkerGroupWorkloadType manages the worker Pods with a StatefulSet. The Pods have stable names and
+	// PersistentVolumeClaims, and scaling down removes the Pods with the highest ordinals first. The Ray autoscaler
+	// cannot scale the worker group, because it deletes specific worker Pods.
+	StatefulSetWorkerGroupWorkloadType WorkerGroupWorkloadType = "StatefulSet"
+)
+
+// +kubebuilder:validation:Enum=OnDelete;RollingUpdate
+type WorkerGroupUpdateStrategyType string
+
+const (
+	// OnDeleteWorkerGroupUpdateStrategyType keeps existing worker Pods when the worker group changes. Only Pods
+	// created after the change, e.g. to replace deleted Pods, use the new Pod template.
+	OnDeleteWorkerGroupUpdateStrategyType WorkerGroupUpdateStrategyType = "OnDelete"
+	// RollingUpdateWorkerGroupUpdateStrategyType gradually replaces the worker Pods that are out of date.
+	RollingUpdateWorkerGroupUpdateStrategyType WorkerGroupUpdateStrategyType = "RollingUpdate"
+)
+
+// WorkerGroupUpdateStrategy defines how the Pods of a worker group are replaced when the worker group changes
+type WorkerGroupUpdateStrategy struct {
+	// RollingUpdate configures the RollingUpdate strategy. It can only be set if Type is RollingUpdate.
+	// +optional
+	RollingUpdate *RollingUpdateWorkerGroup `json:"rollingUpdate,omitempty"`
+	// Type is OnDelete or RollingUpdate. The default is OnDelete.
+	// +optional
+	Type WorkerGroupUpdateStrategyType `json:"type,omitempty"`
+}
+
+// RollingUpdateWorkerGroup configures the rolling update of a worker group. Out-of-date Pods are only deleted
+// while enough Pods are available, and Pods that are terminating, e.g. while a preStop hook drains the Ray node,
+// count as unavailable, so the update waits for them before replacing more Pods.
+type RollingUpdateWorkerGroup struct {
+	// MaxUnavailable is the maximum number of Pods of the worker group, or the percentage of its desired Pods,
+	// that can be unavailable during the update. The default is 1, or 0 if MaxSurge is set.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// MaxSurge is the maximum number of Pods, or the percentage of its desired Pods, that can be created above the
+	// desired number of Pods of the worker group during the update. The default is 0.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+}
+
 // AutoscalerOptions specifies optional configuration for the Ray autoscaler.
 type AutoscalerOptions struct {
 	// Resources specifies optional resource request and limit overrides for the autoscaler container.
@@ -95,6 +690,10 @@ type AutoscalerOptions struct {
 	// Aggressive: An alias for Default; upscaling is not rate-limited.
 	// It is not read by the KubeRay operator but by the Ray autoscaler.
 	UpscalingMode *UpscalingMode `json:"upscalingMode,omitempty"`
+	// Version is the version of the Ray autoscaler, v1 or v2. Autoscaler v2 needs Ray 2.10.0 or later. The default
+	// is v1, unless the Ray head container sets the RAY_enable_autoscaler_v2 environment variable.
+	// +optional
+	Version *AutoscalerVersion `json:"version,omitempty"`
 	// Optional list of environment variables to set in the autoscaler container.
 	Env []corev1.EnvVar `json:"env,omitempty"`
 	// Optional list of sources to populate environment variables in the autoscaler container.
@@ -106,6 +705,31 @@ type AutoscalerOptions struct {
 // +kubebuilder:validation:Enum=Default;Aggressive;Conservative
 type UpscalingMode string
 
+// ConservativeUpscalingMode rate-limits upscaling, unlike DefaultUpscalingMode.
+const ConservativeUpscalingMode UpscalingMode = "Conservative"
+
+// +kubebuilder:validation:Enum=v1;v2
+type AutoscalerVersion string
+
+const (
+	AutoscalerVersionV1 AutoscalerVersion = "v1"
+	AutoscalerVersionV2 AutoscalerVersion = "v2"
+)
+
+// IsAutoscalerV2Enabled returns whether a RayCluster runs the Ray autoscaler v2, either because it sets
+// autoscalerOptions.version to v2 or because its Ray head container enables it with the RAY_enable_autoscaler_v2
+// environment variable.
+func IsAutoscalerV2Enabled(spec *RayClusterSpec) bool {
+	if spec.EnableInTreeAutoscaling == nil || !*spec.EnableInTreeAutoscaling {
+		return false
+	}
+	if spec.AutoscalerOptions != nil && spec.AutoscalerOptions.Version != nil {
+		return *spec.AutoscalerOptions.Version == AutoscalerVersionV2
+	}
+	value, _ := getHeadContainerEnv(spec, autoscalerV2EnvName)
+	return isEnvEnabled(value)
+}
+
 // The overall state of the Ray cluster.
 type ClusterState string
 
@@ -132,6 +756,8 @@ type RayClusterStatus struct {
 	DesiredGPU resource.Quantity `json:"desiredGPU,omitempty"`
 	// DesiredTPU indicates total desired TPUs for the cluster
 	DesiredTPU resource.Quantity `json:"desiredTPU,omitempty"`
+	// ReadyGPU indicates the total GPUs of the Ray Pods that are running and ready
+	ReadyGPU resource.Quantity `json:"readyGPU,omitempty"`
 	// LastUpdateTime indicates last update timestamp for this cluster status.
 	// +nullable
 	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
@@ -139,6 +765,10 @@ type RayClusterStatus struct {
 	StateTransitionTimes map[ClusterState]*metav1.Time `json:"stateTransitionTimes,omitempty"`
 	// Service Endpoints
 	Endpoints map[string]string `json:"endpoints,omitempty"`
+	// Workload is the latest snapshot of the workload and the resource utilization of the Ray cluster, which the
+	// Ray dashboard reports. It is only reported if the operator enables the RayCluster workload status.
+	// +optional
+	Workload *RayClusterWorkloadStatus `json:"workload,omitempty"`
 	// Head info
 	Head HeadInfo `json:"head,omitempty"`
 	// Reason provides more information about current State
@@ -151,6 +781,17 @@ type RayClusterStatus struct {
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 
+	// WorkerGroupStatuses breaks down the worker replicas of the cluster by worker group.
+	// +listType=map
+	// +listMapKey=groupName
+	WorkerGroupStatuses []WorkerGroupStatus `json:"workerGroupStatuses,omitempty"`
+
+	// CleanupSteps reports the progress of the steps that clean up the resources of the cluster that Kubernetes
+	// does not garbage collect, e.g. its storage namespace in Redis, while the RayCluster is being deleted.
+	// +listType=map
+	// +listMapKey=name
+	CleanupSteps []CleanupStepStatus `json:"cleanupSteps,omitempty"`
+
 	// ReadyWorkerReplicas indicates how many worker replicas are ready in the cluster
 	ReadyWorkerReplicas int32 `json:"readyWorkerReplicas,omitempty"`
 	// AvailableWorkerReplicas indicates how many replicas are available in the cluster
@@ -174,6 +815,16 @@ const (
 	RayClusterPodsProvisioning     = "RayClusterPodsProvisioning"
 	HeadPodNotFound                = "HeadPodNotFound"
 	HeadPodRunningAndReady         = "HeadPodRunningAndReady"
+	RayClusterNoActiveWorkload     = "NoActiveWorkload"
+	RayClusterActiveWorkload       = "ActiveWorkload"
+	RedisConnected                 = "RedisConnected"
+	RedisConnectionFailed          = "RedisConnectionFailed"
+	HeadPodRestarted               = "HeadPodRestarted"
+	HeadPodRecovered               = "HeadPodRecovered"
+	WorkerPodsKeepFailing          = "WorkerPodsKeepFailing"
+	AutoscalerRunning              = "AutoscalerRunning"
+	AutoscalerContainerNotFound    = "AutoscalerContainerNotFound"
+	AllPodsRunningAndReady         = "AllPodsRunningAndReady"
 	// UnknownReason says that the reason for the condition is unknown.
 	UnknownReason = "Unknown"
 )
@@ -184,12 +835,39 @@ const (
 	RayClusterProvisioned RayClusterConditionType = "RayClusterProvisioned"
 	// HeadPodReady indicates whether RayCluster's head Pod is ready for requests.
 	HeadPodReady RayClusterConditionType = "HeadPodReady"
+	// RayClusterReady indicates whether the head Pod and all the desired worker Pods of the RayCluster are running and
+	// ready. Unlike RayClusterProvisioned, it turns false again when a Ray Pod stops being ready.
+	RayClusterReady RayClusterConditionType = "Ready"
 	// RayClusterReplicaFailure is added in a RayCluster when one of its pods fails to be created or deleted.
 	RayClusterReplicaFailure RayClusterConditionType = "ReplicaFailure"
+	// RayClusterBatchSchedulingRejected is added in a RayCluster when its batch scheduler rejects it, e.g. because
+	// it requests more resources than the capability of its Volcano queue. Its reason is the reason of the rejection.
+	RayClusterBatchSchedulingRejected RayClusterConditionType = "BatchSchedulingRejected"
+	// RayClusterQuotaExceeded is added in a RayCluster when its Pods would exceed the quota of its namespace, which is
+	// defined by the ConfigMaps with the ray.io/quota label. See the RayNamespaceQuota feature gate.
+	RayClusterQuotaExceeded RayClusterConditionType = "QuotaExceeded"
 	// RayClusterSuspending is set to true when a user sets .Spec.Suspend to true, ensuring the atomicity of the suspend operation.
 	RayClusterSuspending RayClusterConditionType = "RayClusterSuspending"
 	// RayClusterSuspended is set to true when all Pods belonging to a suspending RayCluster are deleted. Note that RayClusterSuspending and RayClusterSuspended cannot both be true at the same time.
 	RayClusterSuspended RayClusterConditionType = "RayClusterSuspended"
+	// RayClusterIdle is set to true when the Ray cluster of a RayCluster with IdleTimeoutSeconds has no active job,
+	// running task, or alive actor. Its LastTransitionTime is when the Ray cluster became idle, and its message
+	// records when the RayCluster will be terminated.
+	RayClusterIdle RayClusterConditionType = "RayClusterIdle"
+	// GCSFaultToleranceReady indicates whether the KubeRay operator can connect to the Redis server of a RayCluster
+	// with GcsFaultToleranceOptions.
+	GCSFaultToleranceReady RayClusterConditionType = "GCSFaultToleranceReady"
+	// HeadPodRecovering is set to true when the head Pod of a RayCluster with GCS fault tolerance restarts, and back
+	// to false once the new head Pod is ready. Its LastTransitionTime is when the recovery started or ended.
+	HeadPodRecovering RayClusterConditionType = "HeadPodRecovering"
+	// WorkerGroupCrashLoop is set to true when the failed worker Pods of a worker group have been recreated
+	// repeatedly without a pause, which usually means that the Pods fail for a reason that recreating them does not
+	// fix, e.g. too little memory. Its message lists the worker groups.
+	WorkerGroupCrashLoop RayClusterConditionType = "CrashLoop"
+	// AutoscalerReady indicates whether the autoscaler container of the head Pod of a RayCluster with in-tree
+	// autoscaling is running. Its reason is the reason that the container is waiting or terminated otherwise, e.g.
+	// CrashLoopBackOff.
+	AutoscalerReady RayClusterConditionType = "AutoscalerReady"
 )
 
 // HeadInfo gives info about head
@@ -198,8 +876,89 @@ type HeadInfo struct {
 	ServiceIP   string `json:"serviceIP,omitempty"`
 	PodName     string `json:"podName,omitempty"`
 	ServiceName string `json:"serviceName,omitempty"`
+	// DashboardURL is the in-cluster URL of the Ray dashboard, served by the head service.
+	DashboardURL string `json:"dashboardURL,omitempty"`
+}
+
+// WorkerGroupStatus is the observed state of the worker Pods of a worker group.
+type WorkerGroupStatus struct {
+	// LastTransitionTime is the last time the desired or ready replicas of the group changed.
+	// +nullable
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+	// LastRecreationTime is the last time the failed worker Pods of the group were recreated.
+	// +nullable
+	LastRecreationTime *metav1.Time `json:"lastRecreationTime,omitempty"`
+	// LastPreemptionTime is the last time a spot worker Pod of a group with spot fallback was preempted.
+	// +nullable
+	LastPreemptionTime *metav1.Time `json:"lastPreemptionTime,omitempty"`
+	// SpotFallbackTime is when the group fell back to on-demand nodes. It is not set while the group is on spot nodes.
+	// +nullable
+	SpotFallbackTime *metav1.Time `json:"spotFallbackTime,omitempty"`
+	// GroupName is the name of the worker group.
+	GroupName string `json:"groupName"`
+	// DesiredReplicas is the number of worker Pods the group should have.
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+	// ReadyReplicas is the number of worker Pods of the group that are running and ready.
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// FailedReplicas is the number of worker Pods of the group that have failed.
+	FailedReplicas int32 `json:"failedReplicas,omitempty"`
+	// Recreations is the number of consecutive recreations of the failed worker Pods of the group, which delay the
+	// next recreation according to the recreate policy of the group. It is reset once no worker Pod of the group has
+	// failed for twice the maximum backoff.
+	Recreations int32 `json:"recreations,omitempty"`
+	// Preemptions is the number of spot worker Pods of a group with spot fallback that have been preempted within
+	// CooldownSeconds of each other. The group falls back to on-demand nodes once it reaches PreemptionThreshold.
+	Preemptions int32 `json:"preemptions,omitempty"`
+}
+
+// RayClusterWorkloadStatus is a snapshot of the workload and the resource utilization of a Ray cluster.
+type RayClusterWorkloadStatus struct {
+	// LastUpdateTime is when the snapshot was taken.
+	// +nullable
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+	// UsedResources are the logical resources of the Ray cluster, e.g. CPU and GPU, that its tasks and actors use,
+	// keyed by their names in Ray. They are only reported if the Ray autoscaler is enabled.
+	UsedResources corev1.ResourceList `json:"usedResources,omitempty"`
+	// TotalResources are the logical resources of the alive Ray nodes, keyed by their names in Ray. They are only
+	// reported if the Ray autoscaler is enabled.
+	TotalResources corev1.ResourceList `json:"totalResources,omitempty"`
+	// ActiveJobs is the number of Ray jobs that are pending or running.
+	ActiveJobs int32 `json:"activeJobs"`
+	// RunningTasks is the number of Ray tasks that are running.
+	RunningTasks int32 `json:"runningTasks"`
+	// AliveActors is the number of Ray actors that are alive.
+	AliveActors int32 `json:"aliveActors"`
+	// ServeApplications is the number of Ray Serve applications that are deployed.
+	ServeApplications int32 `json:"serveApplications"`
 }
 
+// CleanupStepStatus is the observed state of a step of the cleanup of a RayCluster that is being deleted.
+type CleanupStepStatus struct {
+	// LastTransitionTime is the last time the state of the step changed.
+	// +nullable
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Name is the name of the step, e.g. RedisCleanup.
+	Name string `json:"name"`
+	// State is Running, Completed, Failed or Skipped.
+	State CleanupStepState `json:"state,omitempty"`
+	// Message is a human readable message about the progress or the result of the step.
+	Message string `json:"message,omitempty"`
+}
+
+type CleanupStepState string
+
+const (
+	// CleanupStepRunning means that the step has started and is waiting for resources to be deleted.
+	CleanupStepRunning CleanupStepState = "Running"
+	// CleanupStepCompleted means that the step has deleted all its resources.
+	CleanupStepCompleted CleanupStepState = "Completed"
+	// CleanupStepFailed means that the step gave up, and that its resources may have to be deleted manually.
+	CleanupStepFailed CleanupStepState = "Failed"
+	// CleanupStepSkipped means that the step did not run because the RayCluster has the ray.io/force-delete
+	// annotation.
+	CleanupStepSkipped CleanupStepState = "Skipped"
+)
+
 // RayNodeType  the type of a ray node: head/worker
 type RayNodeType string
 
@@ -1,11 +1,17 @@
 package v1
 
 import (
+	"fmt"
 	"regexp"
+	"sort"
+	"strings"
 
+	semver "github.com/Masterminds/semver/v3"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -17,6 +23,22 @@ import (
 var (
 	rayclusterlog = logf.Log.WithName("raycluster-resource")
 	nameRegex, _  = regexp.Compile("^[a-z]([-a-z0-9]*[a-z0-9])?$")
+	// imageTagVersionRegex matches the Ray version at the start of the tags of the Ray images, e.g. 2.9.0-py310-gpu.
+	imageTagVersionRegex = regexp.MustCompile(`^(\d+\.\d+\.\d+)(-|$)`)
+)
+
+const (
+	// rayFTEnabledAnnotationKey is the annotation that enables GCS fault tolerance, see utils.RayFTEnabledAnnotationKey
+	rayFTEnabledAnnotationKey = "ray.io/ft-enabled"
+	// redisAddressEnvName is the environment variable of the Ray head container with the address of the Redis server
+	// that GCS fault tolerance stores the GCS metadata in
+	redisAddressEnvName = "RAY_REDIS_ADDRESS"
+	// autoscalerV2EnvName is the environment variable of the Ray head container that enables autoscaler v2
+	autoscalerV2EnvName = "RAY_enable_autoscaler_v2"
+	// minimumSupportedRayVersion is the oldest Ray version that the KubeRay operator supports
+	minimumSupportedRayVersion = "2.0.0"
+	// autoscalerV2MinimumRayVersion is the oldest Ray version with autoscaler v2
+	autoscalerV2MinimumRayVersion = "2.10.0"
 )
 
 func (r *RayCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
@@ -25,6 +47,16 @@ func (r *RayCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
 		Complete()
 }
 
+//+kubebuilder:webhook:path=/mutate-ray-io-v1-raycluster,mutating=true,failurePolicy=fail,sideEffects=None,groups=ray.io,resources=rayclusters,verbs=create;update,versions=v1,name=mraycluster.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &RayCluster{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *RayCluster) Default() {
+	rayclusterlog.Info("default", "name", r.Name)
+	SetRayClusterDefaults(&r.Spec)
+}
+
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 //+kubebuilder:webhook:path=/validate-ray-io-v1-raycluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=ray.io,resources=rayclusters,verbs=create;update,versions=v1,name=vraycluster.kb.io,admissionReviewVersions=v1
 
@@ -33,13 +65,13 @@ var _ webhook.Validator = &RayCluster{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *RayCluster) ValidateCreate() (admission.Warnings, error) {
 	rayclusterlog.Info("validate create", "name", r.Name)
-	return nil, r.validateRayCluster()
+	return r.rayVersionWarnings(), r.validateRayCluster()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *RayCluster) ValidateUpdate(_ runtime.Object) (admission.Warnings, error) {
 	rayclusterlog.Info("validate update", "name", r.Name)
-	return nil, r.validateRayCluster()
+	return r.rayVersionWarnings(), r.validateRayCluster()
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -55,8 +87,29 @@ func (r *RayCluster) validateRayCluster() error {
 		allErrs = append(allErrs, err)
 	}
 
-	if err := r.validateWorkerGroups(); err != nil {
-		allErrs = append(allErrs, err)
+	allErrs = append(allErrs, r.validateWorkerGroups()...)
+	allErrs = append(allErrs, r.validateRayStartParams()...)
+	allErrs = append(allErrs, r.validateContainerNames()...)
+	allErrs = append(allErrs, r.validateResourceClaims()...)
+	allErrs = append(allErrs, r.validateRayVolumes()...)
+
+	allErrs = append(allErrs, r.validateGCSFaultTolerance()...)
+	allErrs = append(allErrs, r.validateHeadIngress()...)
+	allErrs = append(allErrs, r.validateAutoscaler()...)
+	allErrs = append(allErrs, r.validateRayVersion()...)
+
+	if r.Spec.IdleTimeoutAction != "" && r.Spec.IdleTimeoutSeconds == nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("idleTimeoutAction"), "idleTimeoutAction can only be set if idleTimeoutSeconds is set"))
+	}
+
+	if waitForHead := r.Spec.WaitForHead; waitForHead != nil && waitForHead.InitialBackoffSeconds != nil && waitForHead.MaxBackoffSeconds != nil &&
+		*waitForHead.MaxBackoffSeconds < *waitForHead.InitialBackoffSeconds {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("waitForHead", "maxBackoffSeconds"), *waitForHead.MaxBackoffSeconds, "maxBackoffSeconds must not be less than initialBackoffSeconds"))
+	}
+
+	if security := r.Spec.Security; security != nil && security.TLS != nil && security.TLS.Enabled &&
+		(security.TLS.IssuerRef == nil || security.TLS.IssuerRef.Name == "") {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("security", "tls", "issuerRef", "name"), "cert-manager needs an issuer to issue the CA of the Ray cluster"))
 	}
 
 	if len(allErrs) == 0 {
@@ -75,15 +128,492 @@ func (r *RayCluster) validateName() *field.Error {
 	return nil
 }
 
-func (r *RayCluster) validateWorkerGroups() *field.Error {
+func (r *RayCluster) validateWorkerGroups() field.ErrorList {
+	var allErrs field.ErrorList
 	workerGroupNames := make(map[string]bool)
 
 	for i, workerGroup := range r.Spec.WorkerGroupSpecs {
+		path := field.NewPath("spec").Child("workerGroupSpecs").Index(i)
 		if _, ok := workerGroupNames[workerGroup.GroupName]; ok {
-			return field.Invalid(field.NewPath("spec").Child("workerGroupSpecs").Index(i), workerGroup, "worker group names must be unique")
+			allErrs = append(allErrs, field.Invalid(path.Child("groupName"), workerGroup.GroupName, "worker group names must be unique"))
 		}
 		workerGroupNames[workerGroup.GroupName] = true
+
+		if workerGroup.MinReplicas != nil && workerGroup.MaxReplicas != nil && *workerGroup.MinReplicas > *workerGroup.MaxReplicas {
+			allErrs = append(allErrs, field.Invalid(path.Child("minReplicas"), *workerGroup.MinReplicas,
+				fmt.Sprintf("minReplicas must not be greater than maxReplicas (%d), lower minReplicas or raise maxReplicas", *workerGroup.MaxReplicas)))
+		}
+
+		if workerGroup.UpdateStrategy != nil {
+			allErrs = append(allErrs, validateWorkerGroupUpdateStrategy(workerGroup.UpdateStrategy, path.Child("updateStrategy"))...)
+		}
+
+		if workerGroup.DisruptionBudget != nil {
+			allErrs = append(allErrs, validateWorkerGroupDisruptionBudget(workerGroup.DisruptionBudget, path.Child("disruptionBudget"))...)
+		}
+
+		if policy := workerGroup.RecreatePolicy; policy != nil && policy.InitialBackoffSeconds != nil && policy.MaxBackoffSeconds != nil &&
+			*policy.MaxBackoffSeconds < *policy.InitialBackoffSeconds {
+			allErrs = append(allErrs, field.Invalid(path.Child("recreatePolicy", "maxBackoffSeconds"), *policy.MaxBackoffSeconds, "maxBackoffSeconds must not be less than initialBackoffSeconds"))
+		}
+
+		if workerGroup.WorkloadType == StatefulSetWorkerGroupWorkloadType {
+			allErrs = append(allErrs, r.validateStatefulSetWorkerGroup(&r.Spec.WorkerGroupSpecs[i], path)...)
+		} else if len(workerGroup.VolumeClaimTemplates) > 0 {
+			allErrs = append(allErrs, field.Forbidden(path.Child("volumeClaimTemplates"), "volumeClaimTemplates can only be set if workloadType is StatefulSet"))
+		}
+		if workerGroup.VolumeClaimRetentionPolicy != "" && len(workerGroup.VolumeClaimTemplates) == 0 {
+			allErrs = append(allErrs, field.Forbidden(path.Child("volumeClaimRetentionPolicy"), "volumeClaimRetentionPolicy can only be set with volumeClaimTemplates"))
+		}
 	}
 
-	return nil
+	return allErrs
+}
+
+// validateStatefulSetWorkerGroup rejects the settings of a worker group that a StatefulSet cannot honor
+func (r *RayCluster) validateStatefulSetWorkerGroup(workerGroup *WorkerGroupSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.EnableInTreeAutoscaling != nil && *r.Spec.EnableInTreeAutoscaling {
+		allErrs = append(allErrs, field.Forbidden(path.Child("workloadType"), "the Ray autoscaler cannot scale StatefulSet worker groups, because it deletes specific worker Pods"))
+	}
+	if workerGroup.DrainGracePeriodSeconds != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("drainGracePeriodSeconds"), "StatefulSet worker groups do not drain Ray nodes, use a preStop hook instead"))
+	}
+	if workerGroup.UpdateStrategy != nil && workerGroup.UpdateStrategy.RollingUpdate != nil && workerGroup.UpdateStrategy.RollingUpdate.MaxSurge != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("updateStrategy", "rollingUpdate", "maxSurge"), "StatefulSets do not support maxSurge"))
+	}
+	if workerGroup.RecreatePolicy != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("recreatePolicy"), "the StatefulSet recreates the failed worker Pods of StatefulSet worker groups"))
+	}
+	if workerGroup.SpotFallback != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("spotFallback"), "the Pods of a StatefulSet share the node placement of its Pod template"))
+	}
+	if workerGroup.UpscalingMode != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("upscalingMode"), "the StatefulSet creates the Pods of StatefulSet worker groups"))
+	}
+
+	return allErrs
+}
+
+// validateRayVolumes rejects log and spill volumes that do not have exactly one volume source, and cleanup policies
+// that cannot be applied to them
+func (r *RayCluster) validateRayVolumes() field.ErrorList {
+	var allErrs field.ErrorList
+
+	headPath := field.NewPath("spec").Child("headGroupSpec")
+	allErrs = append(allErrs, validateRayVolume(r.Spec.HeadGroupSpec.LogVolume, headPath.Child("logVolume"), false)...)
+	allErrs = append(allErrs, validateRayVolume(r.Spec.HeadGroupSpec.SpillVolume, headPath.Child("spillVolume"), false)...)
+	for i, workerGroup := range r.Spec.WorkerGroupSpecs {
+		path := field.NewPath("spec").Child("workerGroupSpecs").Index(i)
+		isStatefulSet := workerGroup.WorkloadType == StatefulSetWorkerGroupWorkloadType
+		allErrs = append(allErrs, validateRayVolume(workerGroup.LogVolume, path.Child("logVolume"), isStatefulSet)...)
+		allErrs = append(allErrs, validateRayVolume(workerGroup.SpillVolume, path.Child("spillVolume"), isStatefulSet)...)
+	}
+
+	return allErrs
+}
+
+func validateRayVolume(volume *RayVolume, path *field.Path, isStatefulSet bool) field.ErrorList {
+	var allErrs field.ErrorList
+	if volume == nil {
+		return allErrs
+	}
+
+	sources := 0
+	for _, isSet := range []bool{volume.EmptyDir != nil, volume.HostPath != nil, volume.VolumeClaimTemplate != nil} {
+		if isSet {
+			sources++
+		}
+	}
+	if sources != 1 {
+		allErrs = append(allErrs, field.Invalid(path, sources, "exactly one of emptyDir, hostPath and volumeClaimTemplate must be set"))
+	}
+	if volume.CleanupPolicy != "" && volume.VolumeClaimTemplate == nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("cleanupPolicy"), "cleanupPolicy can only be set with volumeClaimTemplate"))
+	}
+	if volume.CleanupPolicy == DeleteWithClusterCleanupPolicy && isStatefulSet {
+		allErrs = append(allErrs, field.Forbidden(path.Child("cleanupPolicy"), "StatefulSet worker groups cannot keep the claims of deleted Pods, use volumeClaimTemplates instead"))
+	}
+
+	return allErrs
+}
+
+// validateWorkerGroupUpdateStrategy rejects rolling update parameters with which the controller cannot replace Pods
+func validateWorkerGroupUpdateStrategy(updateStrategy *WorkerGroupUpdateStrategy, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	rollingUpdate := updateStrategy.RollingUpdate
+	if rollingUpdate == nil {
+		return allErrs
+	}
+	if updateStrategy.Type != RollingUpdateWorkerGroupUpdateStrategyType {
+		allErrs = append(allErrs, field.Forbidden(path.Child("rollingUpdate"), "rollingUpdate can only be set if type is RollingUpdate"))
+	}
+
+	rollingUpdatePath := path.Child("rollingUpdate")
+	maxUnavailable, err := validateIntOrPercent(rollingUpdate.MaxUnavailable, rollingUpdatePath.Child("maxUnavailable"))
+	if err != nil {
+		allErrs = append(allErrs, err)
+	}
+	maxSurge, err := validateIntOrPercent(rollingUpdate.MaxSurge, rollingUpdatePath.Child("maxSurge"))
+	if err != nil {
+		allErrs = append(allErrs, err)
+	}
+	// The controller defaults maxUnavailable to 1 only if neither field is set.
+	if (rollingUpdate.MaxUnavailable != nil || rollingUpdate.MaxSurge != nil) && maxUnavailable == 0 && maxSurge == 0 {
+		allErrs = append(allErrs, field.Forbidden(rollingUpdatePath, "maxUnavailable and maxSurge must not both be 0, or the update cannot replace any Pod"))
+	}
+
+	return allErrs
+}
+
+// validateWorkerGroupDisruptionBudget rejects disruption budgets that a PodDisruptionBudget cannot express
+func validateWorkerGroupDisruptionBudget(disruptionBudget *WorkerGroupDisruptionBudget, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if disruptionBudget.MaxUnavailable != nil && disruptionBudget.MinAvailable != nil {
+		allErrs = append(allErrs, field.Forbidden(path, "maxUnavailable and minAvailable cannot both be set"))
+	}
+	if _, err := validateIntOrPercent(disruptionBudget.MaxUnavailable, path.Child("maxUnavailable")); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if _, err := validateIntOrPercent(disruptionBudget.MinAvailable, path.Child("minAvailable")); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	return allErrs
+}
+
+// validateIntOrPercent returns the value of a number or a percentage of Pods, with percentages scaled to 100 Pods
+func validateIntOrPercent(value *intstr.IntOrString, path *field.Path) (int, *field.Error) {
+	if value == nil {
+		return 0, nil
+	}
+	scaled, err := intstr.GetScaledValueFromIntOrPercent(value, 100, true)
+	if err != nil {
+		return 0, field.Invalid(path, value.String(), err.Error())
+	}
+	if scaled < 0 {
+		return 0, field.Invalid(path, value.String(), "must not be negative")
+	}
+	return scaled, nil
+}
+
+// validateRayStartParams rejects rayStartParams that the KubeRay operator sets itself, because overriding them breaks
+// how the Ray nodes join the cluster or how the operator manages the Ray container
+func (r *RayCluster) validateRayStartParams() field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, validateReservedRayStartParams(r.Spec.HeadGroupSpec.RayStartParams, field.NewPath("spec").Child("headGroupSpec").Child("rayStartParams"))...)
+	for i, workerGroup := range r.Spec.WorkerGroupSpecs {
+		allErrs = append(allErrs, validateReservedRayStartParams(workerGroup.RayStartParams, field.NewPath("spec").Child("workerGroupSpecs").Index(i).Child("rayStartParams"))...)
+	}
+
+	return allErrs
+}
+
+func validateReservedRayStartParams(rayStartParams map[string]string, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// Sort the keys so that the errors are reported in a stable order
+	keys := make([]string, 0, len(rayStartParams))
+	for key := range rayStartParams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		switch strings.TrimPrefix(key, "--") {
+		case "address":
+			allErrs = append(allErrs, field.Forbidden(path.Key(key), "the KubeRay operator sets the address of the Ray head to the head service, remove it from rayStartParams"))
+		case "block":
+			// The operator always sets block to true, so only other values are an override
+			if rayStartParams[key] != "true" {
+				allErrs = append(allErrs, field.Forbidden(path.Key(key), "the KubeRay operator runs ray start with --block to keep the Ray container running, remove it from rayStartParams"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateContainerNames rejects Pod templates whose containers and init containers share a name, which Kubernetes
+// would only report when the operator creates the Pods
+func (r *RayCluster) validateContainerNames() field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, validatePodTemplateContainerNames(&r.Spec.HeadGroupSpec.Template, field.NewPath("spec").Child("headGroupSpec").Child("template"))...)
+	for i := range r.Spec.WorkerGroupSpecs {
+		allErrs = append(allErrs, validatePodTemplateContainerNames(&r.Spec.WorkerGroupSpecs[i].Template, field.NewPath("spec").Child("workerGroupSpecs").Index(i).Child("template"))...)
+	}
+
+	return allErrs
+}
+
+func validatePodTemplateContainerNames(template *corev1.PodTemplateSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	containerNames := make(map[string]bool)
+
+	for i, container := range template.Spec.InitContainers {
+		if containerNames[container.Name] {
+			allErrs = append(allErrs, field.Duplicate(path.Child("spec").Child("initContainers").Index(i).Child("name"), container.Name))
+		}
+		containerNames[container.Name] = true
+	}
+	for i, container := range template.Spec.Containers {
+		if containerNames[container.Name] {
+			allErrs = append(allErrs, field.Duplicate(path.Child("spec").Child("containers").Index(i).Child("name"), container.Name))
+		}
+		containerNames[container.Name] = true
+	}
+
+	return allErrs
+}
+
+// validateResourceClaims rejects resource claims that the KubeRay operator cannot add to the head and worker Pods
+func (r *RayCluster) validateResourceClaims() field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, validateRayResourceClaims(r.Spec.HeadGroupSpec.ResourceClaims, &r.Spec.HeadGroupSpec.Template, field.NewPath("spec").Child("headGroupSpec").Child("resourceClaims"))...)
+	for i := range r.Spec.WorkerGroupSpecs {
+		workerGroup := &r.Spec.WorkerGroupSpecs[i]
+		allErrs = append(allErrs, validateRayResourceClaims(workerGroup.ResourceClaims, &workerGroup.Template, field.NewPath("spec").Child("workerGroupSpecs").Index(i).Child("resourceClaims"))...)
+	}
+
+	return allErrs
+}
+
+func validateRayResourceClaims(claims []RayResourceClaim, template *corev1.PodTemplateSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// The claims are added to the resource claims of the Pod template, so their names must not be taken
+	claimNames := make(map[string]bool)
+	for _, podResourceClaim := range template.Spec.ResourceClaims {
+		claimNames[podResourceClaim.Name] = true
+	}
+
+	for i, claim := range claims {
+		claimPath := path.Index(i)
+		if claim.Name == "" {
+			allErrs = append(allErrs, field.Required(claimPath.Child("name"), ""))
+		} else if claimNames[claim.Name] {
+			allErrs = append(allErrs, field.Duplicate(claimPath.Child("name"), claim.Name))
+		}
+		claimNames[claim.Name] = true
+
+		if (claim.ResourceClaimName == nil) == (claim.ResourceClaimTemplateName == nil) {
+			allErrs = append(allErrs, field.Forbidden(claimPath, "exactly one of resourceClaimName and resourceClaimTemplateName must be set"))
+		}
+
+		// Sort the Ray resources so that the errors are reported in a stable order
+		rayResourceNames := make([]string, 0, len(claim.RayResources))
+		for rayResourceName := range claim.RayResources {
+			rayResourceNames = append(rayResourceNames, rayResourceName)
+		}
+		sort.Strings(rayResourceNames)
+		for _, rayResourceName := range rayResourceNames {
+			if quantity := claim.RayResources[rayResourceName]; quantity.Sign() < 0 {
+				allErrs = append(allErrs, field.Invalid(claimPath.Child("rayResources").Key(rayResourceName), quantity.String(), "must not be negative"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateGCSFaultTolerance rejects RayClusters that enable GCS fault tolerance without the Redis address the GCS
+// server stores its metadata in, because the head Pod would fail to start
+func (r *RayCluster) validateGCSFaultTolerance() field.ErrorList {
+	options := r.Spec.GcsFaultToleranceOptions
+	ftAnnotation, hasFTAnnotation := r.Annotations[rayFTEnabledAnnotationKey]
+	envPath := field.NewPath("spec").Child("headGroupSpec").Child("template").Child("spec").Child("containers").Index(0).Child("env")
+	if options == nil {
+		if strings.ToLower(ftAnnotation) != "true" || r.headContainerHasRedisAddress() {
+			return nil
+		}
+		return field.ErrorList{field.Required(envPath,
+			fmt.Sprintf("GCS fault tolerance is enabled by the %s annotation, set the %s environment variable of the Ray head container to the address of a Redis server or disable GCS fault tolerance", rayFTEnabledAnnotationKey, redisAddressEnvName))}
+	}
+
+	var allErrs field.ErrorList
+	path := field.NewPath("spec").Child("gcsFaultToleranceOptions")
+	if hasFTAnnotation && strings.ToLower(ftAnnotation) != "true" {
+		allErrs = append(allErrs, field.Forbidden(path, fmt.Sprintf("gcsFaultToleranceOptions enables GCS fault tolerance, which the %s annotation disables", rayFTEnabledAnnotationKey)))
+	}
+	if options.RedisAddress == "" {
+		allErrs = append(allErrs, field.Required(path.Child("redisAddress"), ""))
+	}
+	if r.headContainerHasRedisAddress() {
+		allErrs = append(allErrs, field.Forbidden(envPath, fmt.Sprintf("the KubeRay operator sets the %s environment variable from gcsFaultToleranceOptions.redisAddress", redisAddressEnvName)))
+	}
+	if _, ok := r.Spec.HeadGroupSpec.RayStartParams["redis-password"]; ok && options.RedisPassword != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("headGroupSpec").Child("rayStartParams").Key("redis-password"),
+			"the KubeRay operator sets the Redis password from gcsFaultToleranceOptions.redisPassword"))
+	}
+	allErrs = append(allErrs, validateRedisCredential(options.RedisUsername, path.Child("redisUsername"))...)
+	allErrs = append(allErrs, validateRedisCredential(options.RedisPassword, path.Child("redisPassword"))...)
+	if options.RedisCABundle != nil && !strings.HasPrefix(options.RedisAddress, "rediss://") {
+		allErrs = append(allErrs, field.Forbidden(path.Child("redisCABundle"), "redisCABundle can only be set if redisAddress uses the rediss scheme"))
+	}
+	return allErrs
+}
+
+// validateHeadIngress rejects ingress options that the KubeRay operator cannot turn into an Ingress or HTTPRoutes.
+func (r *RayCluster) validateHeadIngress() field.ErrorList {
+	options := r.Spec.HeadGroupSpec.Ingress
+	if options == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	path := field.NewPath("spec").Child("headGroupSpec").Child("ingress")
+	if enableIngress := r.Spec.HeadGroupSpec.EnableIngress; enableIngress != nil && *enableIngress {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("headGroupSpec").Child("enableIngress"), "enableIngress cannot be set together with ingress"))
+	}
+	if options.ServeHost != "" && options.ServeHost == options.DashboardHost {
+		allErrs = append(allErrs, field.Invalid(path.Child("serveHost"), options.ServeHost, "serveHost must be different from dashboardHost"))
+	}
+	if options.Type == HTTPRouteIngressType {
+		if options.Gateway == nil || options.Gateway.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("gateway", "name"), "HTTPRoutes need a Gateway to attach to"))
+		}
+		if options.TLSSecretName != "" {
+			allErrs = append(allErrs, field.Forbidden(path.Child("tlsSecretName"), "the Gateway terminates TLS of HTTPRoutes"))
+		}
+		if options.IngressClassName != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child("ingressClassName"), "ingressClassName can only be set if type is Ingress"))
+		}
+	} else if options.Gateway != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("gateway"), "gateway can only be set if type is HTTPRoute"))
+	}
+	return allErrs
+}
+
+// validateRayVersion rejects the features of a RayCluster that its Ray version does not have, because the Ray Pods
+// would fail to start or the feature would silently not work. Ray versions that cannot be parsed, e.g. nightly, are
+// not checked.
+func (r *RayCluster) validateRayVersion() field.ErrorList {
+	version, err := semver.NewVersion(r.Spec.RayVersion)
+	if err != nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	if IsAutoscalerV2Enabled(&r.Spec) && version.LessThan(semver.MustParse(autoscalerV2MinimumRayVersion)) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("rayVersion"),
+			fmt.Sprintf("autoscaler v2 needs Ray %s or later, but rayVersion is %s", autoscalerV2MinimumRayVersion, r.Spec.RayVersion)))
+	}
+	return allErrs
+}
+
+// rayVersionWarnings warns about a Ray version that the KubeRay operator does not support or cannot parse, and about
+// Ray containers whose image tags have a different Ray version than rayVersion, which the KubeRay operator uses to
+// decide how to run Ray.
+func (r *RayCluster) rayVersionWarnings() admission.Warnings {
+	if r.Spec.RayVersion == "" {
+		return nil
+	}
+	version, err := semver.NewVersion(r.Spec.RayVersion)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("spec.rayVersion %q is not a Ray version, the KubeRay operator cannot check whether it supports it", r.Spec.RayVersion)}
+	}
+
+	var warnings admission.Warnings
+	if version.LessThan(semver.MustParse(minimumSupportedRayVersion)) {
+		warnings = append(warnings, fmt.Sprintf("spec.rayVersion %s is older than Ray %s, the oldest Ray version that the KubeRay operator supports", r.Spec.RayVersion, minimumSupportedRayVersion))
+	}
+	checkImage := func(template *corev1.PodTemplateSpec, path *field.Path) {
+		if len(template.Spec.Containers) == 0 {
+			return
+		}
+		// The Ray container is the first container of the Pod template.
+		imageVersion := getImageTagVersion(template.Spec.Containers[0].Image)
+		if imageVersion != nil && !imageVersion.Equal(version) {
+			warnings = append(warnings, fmt.Sprintf("%s: the image %s has Ray %s, but spec.rayVersion is %s",
+				path.Child("template", "spec", "containers").Index(0).Child("image"), template.Spec.Containers[0].Image, imageVersion, r.Spec.RayVersion))
+		}
+	}
+	checkImage(&r.Spec.HeadGroupSpec.Template, field.NewPath("spec").Child("headGroupSpec"))
+	for i := range r.Spec.WorkerGroupSpecs {
+		checkImage(&r.Spec.WorkerGroupSpecs[i].Template, field.NewPath("spec").Child("workerGroupSpecs").Index(i))
+	}
+	return warnings
+}
+
+// getImageTagVersion returns the Ray version of the tag of an image, e.g. 2.9.0 for rayproject/ray:2.9.0-py310-gpu,
+// or nil if the tag does not start with a version, e.g. nightly or latest.
+func getImageTagVersion(image string) *semver.Version {
+	image, _, _ = strings.Cut(image, "@")
+	// The tag is after the last colon that is not part of the registry host and port.
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return nil
+	}
+	match := imageTagVersionRegex.FindStringSubmatch(image[i+1:])
+	if match == nil {
+		return nil
+	}
+	version, err := semver.NewVersion(match[1])
+	if err != nil {
+		return nil
+	}
+	return version
+}
+
+// validateAutoscaler rejects autoscaler v2 settings that the autoscaler of a RayCluster would not read, and an
+// autoscaler version that contradicts the RAY_enable_autoscaler_v2 environment variable of the Ray head container.
+func (r *RayCluster) validateAutoscaler() field.ErrorList {
+	var allErrs field.ErrorList
+	if options := r.Spec.AutoscalerOptions; options != nil && options.Version != nil {
+		if value, ok := getHeadContainerEnv(&r.Spec, autoscalerV2EnvName); ok && isEnvEnabled(value) != (*options.Version == AutoscalerVersionV2) {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("headGroupSpec").Child("template").Child("spec").Child("containers").Index(0).Child("env"),
+				fmt.Sprintf("the %s environment variable contradicts autoscalerOptions.version %s", autoscalerV2EnvName, *options.Version)))
+		}
+	}
+	if !IsAutoscalerV2Enabled(&r.Spec) {
+		for i, workerGroup := range r.Spec.WorkerGroupSpecs {
+			if workerGroup.IdleTimeoutSeconds != nil {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("workerGroupSpecs").Index(i).Child("idleTimeoutSeconds"),
+					"idleTimeoutSeconds of worker groups can only be set with autoscaler v2"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// getHeadContainerEnv returns the value of an environment variable of the Ray head container, and whether the Ray
+// head container sets it.
+func getHeadContainerEnv(spec *RayClusterSpec, name string) (string, bool) {
+	if containers := spec.HeadGroupSpec.Template.Spec.Containers; len(containers) > 0 {
+		for _, env := range containers[0].Env {
+			if env.Name == name {
+				return env.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// isEnvEnabled returns whether the value of a Ray feature flag environment variable enables the feature.
+func isEnvEnabled(value string) bool {
+	return value == "1" || strings.ToLower(value) == "true"
+}
+
+func (r *RayCluster) headContainerHasRedisAddress() bool {
+	if containers := r.Spec.HeadGroupSpec.Template.Spec.Containers; len(containers) > 0 {
+		for _, env := range containers[0].Env {
+			if env.Name == redisAddressEnvName && (env.Value != "" || env.ValueFrom != nil) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func validateRedisCredential(credential *RedisCredential, path *field.Path) field.ErrorList {
+	if credential == nil || (credential.Value == "") != (credential.ValueFrom == nil) {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(path, "exactly one of value and valueFrom must be set")}
 }
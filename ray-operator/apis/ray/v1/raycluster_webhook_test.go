@@ -0,0 +1,531 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func newValidationTestRayCluster() *RayCluster {
+	return &RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"},
+		Spec: RayClusterSpec{
+			HeadGroupSpec: HeadGroupSpec{
+				RayStartParams: map[string]string{"block": "true"},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head"}}},
+				},
+			},
+			WorkerGroupSpecs: []WorkerGroupSpec{
+				{
+					GroupName:      "cpu",
+					MinReplicas:    ptr.To[int32](1),
+					MaxReplicas:    ptr.To[int32](2),
+					RayStartParams: map[string]string{},
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							InitContainers: []corev1.Container{{Name: "wait-gcs-ready"}},
+							Containers:     []corev1.Container{{Name: "ray-worker"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateRayCluster(t *testing.T) {
+	tests := []struct {
+		mutate   func(*RayCluster)
+		name     string
+		expected []string
+	}{
+		{
+			name:   "valid",
+			mutate: func(*RayCluster) {},
+		},
+		{
+			name: "duplicate worker group names",
+			mutate: func(r *RayCluster) {
+				r.Spec.WorkerGroupSpecs = append(r.Spec.WorkerGroupSpecs, *r.Spec.WorkerGroupSpecs[0].DeepCopy())
+			},
+			expected: []string{`spec.workerGroupSpecs[1].groupName: Invalid value: "cpu": worker group names must be unique`},
+		},
+		{
+			name: "minReplicas greater than maxReplicas",
+			mutate: func(r *RayCluster) {
+				r.Spec.WorkerGroupSpecs[0].MinReplicas = ptr.To[int32](3)
+			},
+			expected: []string{"spec.workerGroupSpecs[0].minReplicas: Invalid value: 3: minReplicas must not be greater than maxReplicas (2)"},
+		},
+		{
+			name: "reserved rayStartParams",
+			mutate: func(r *RayCluster) {
+				r.Spec.HeadGroupSpec.RayStartParams["block"] = "false"
+				r.Spec.WorkerGroupSpecs[0].RayStartParams["--address"] = "ray-head:6379"
+			},
+			expected: []string{
+				"spec.headGroupSpec.rayStartParams[block]: Forbidden: the KubeRay operator runs ray start with --block",
+				"spec.workerGroupSpecs[0].rayStartParams[--address]: Forbidden: the KubeRay operator sets the address of the Ray head",
+			},
+		},
+		{
+			name: "container name collision",
+			mutate: func(r *RayCluster) {
+				r.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[0].Name = "wait-gcs-ready"
+			},
+			expected: []string{`spec.workerGroupSpecs[0].template.spec.containers[0].name: Duplicate value: "wait-gcs-ready"`},
+		},
+		{
+			name: "rolling update",
+			mutate: func(r *RayCluster) {
+				r.Spec.WorkerGroupSpecs[0].UpdateStrategy = &WorkerGroupUpdateStrategy{
+					Type:          RollingUpdateWorkerGroupUpdateStrategyType,
+					RollingUpdate: &RollingUpdateWorkerGroup{MaxUnavailable: ptr.To(intstr.FromString("25%")), MaxSurge: ptr.To(intstr.FromInt32(1))},
+				}
+			},
+		},
+		{
+			name: "rollingUpdate without RollingUpdate type",
+			mutate: func(r *RayCluster) {
+				r.Spec.WorkerGroupSpecs[0].UpdateStrategy = &WorkerGroupUpdateStrategy{
+					Type:          OnDeleteWorkerGroupUpdateStrategyType,
+					RollingUpdate: &RollingUpdateWorkerGroup{},
+				}
+			},
+			expected: []string{"spec.workerGroupSpecs[0].updateStrategy.rollingUpdate: Forbidden: rollingUpdate can only be set if type is RollingUpdate"},
+		},
+		{
+			name: "rolling update without maxUnavailable and maxSurge",
+			mutate: func(r *RayCluster) {
+				r.Spec.WorkerGroupSpecs[0].UpdateStrategy = &WorkerGroupUpdateStrategy{
+					Type:          RollingUpdateWorkerGroupUpdateStrategyType,
+					RollingUpdate: &RollingUpdateWorkerGroup{MaxUnavailable: ptr.To(intstr.FromString("0%")), MaxSurge: ptr.To(intstr.FromString("ten"))},
+				}
+			},
+			expected: []string{
+				`spec.workerGroupSpecs[0].updateStrategy.rollingUpdate.maxSurge: Invalid value: "ten"`,
+				"spec.workerGroupSpecs[0].updateStrategy.rollingUpdate: Forbidden: maxUnavailable and maxSurge must not both be 0",
+			},
+		},
+		{
+			name: "StatefulSet worker group",
+			mutate: func(r *RayCluster) {
+				r.Spec.WorkerGroupSpecs[0].WorkloadType = StatefulSetWorkerGroupWorkloadType
+				r.Spec.WorkerGroupSpecs[0].VolumeClaimTemplates = []corev1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "cache"}}}
+				r.Spec.WorkerGroupSpecs[0].VolumeClaimRetentionPolicy = DeleteVolumeClaimRetentionPolicy
+			},
+		},
+		{
+			name: "volumeClaimTemplates without StatefulSet workload type",
+			mutate: func(r *RayCluster) {
+				r.Spec.WorkerGroupSpecs[0].VolumeClaimTemplates = []corev1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "cache"}}}
+			},
+			expected: []string{"spec.workerGroupSpecs[0].volumeClaimTemplates: Forbidden: volumeClaimTemplates can only be set if workloadType is StatefulSet"},
+		},
+		{
+			name: "volumeClaimRetentionPolicy without volumeClaimTemplates",
+			mutate: func(r *RayCluster) {
+				r.Spec.WorkerGroupSpecs[0].VolumeClaimRetentionPolicy = DeleteVolumeClaimRetentionPolicy
+			},
+			expected: []string{"spec.workerGroupSpecs[0].volumeClaimRetentionPolicy: Forbidden: volumeClaimRetentionPolicy can only be set with volumeClaimTemplates"},
+		},
+		{
+			name: "StatefulSet worker group with unsupported settings",
+			mutate: func(r *RayCluster) {
+				r.Spec.EnableInTreeAutoscaling = ptr.To(true)
+				r.Spec.WorkerGroupSpecs[0].WorkloadType = StatefulSetWorkerGroupWorkloadType
+				r.Spec.WorkerGroupSpecs[0].DrainGracePeriodSeconds = ptr.To[int32](60)
+				r.Spec.WorkerGroupSpecs[0].UpdateStrategy = &WorkerGroupUpdateStrategy{
+					Type:          RollingUpdateWorkerGroupUpdateStrategyType,
+					RollingUpdate: &RollingUpdateWorkerGroup{MaxSurge: ptr.To(intstr.FromInt32(1))},
+				}
+				r.Spec.WorkerGroupSpecs[0].RecreatePolicy = &WorkerGroupRecreatePolicy{Type: AlwaysWorkerGroupRecreatePolicyType}
+				r.Spec.WorkerGroupSpecs[0].SpotFallback = &SpotFallbackOptions{}
+			},
+			expected: []string{
+				"spec.workerGroupSpecs[0].workloadType: Forbidden: the Ray autoscaler cannot scale StatefulSet worker groups",
+				"spec.workerGroupSpecs[0].drainGracePeriodSeconds: Forbidden: StatefulSet worker groups do not drain Ray nodes",
+				"spec.workerGroupSpecs[0].updateStrategy.rollingUpdate.maxSurge: Forbidden: StatefulSets do not support maxSurge",
+				"spec.workerGroupSpecs[0].recreatePolicy: Forbidden: the StatefulSet recreates the failed worker Pods",
+				"spec.workerGroupSpecs[0].spotFallback: Forbidden: the Pods of a StatefulSet share the node placement",
+			},
+		},
+		{
+			name: "recreatePolicy",
+			mutate: func(r *RayCluster) {
+				r.Spec.WorkerGroupSpecs[0].RecreatePolicy = &WorkerGroupRecreatePolicy{
+					Type:                  AlwaysWorkerGroupRecreatePolicyType,
+					InitialBackoffSeconds: ptr.To[int32](10),
+					MaxBackoffSeconds:     ptr.To[int32](60),
+				}
+			},
+		},
+		{
+			name: "recreatePolicy with maxBackoffSeconds less than initialBackoffSeconds",
+			mutate: func(r *RayCluster) {
+				r.Spec.WorkerGroupSpecs[0].RecreatePolicy = &WorkerGroupRecreatePolicy{
+					Type:                  AlwaysWorkerGroupRecreatePolicyType,
+					InitialBackoffSeconds: ptr.To[int32](60),
+					MaxBackoffSeconds:     ptr.To[int32](10),
+				}
+			},
+			expected: []string{"spec.workerGroupSpecs[0].recreatePolicy.maxBackoffSeconds: Invalid value: 10: maxBackoffSeconds must not be less than initialBackoffSeconds"},
+		},
+		{
+			name: "disruption budget",
+			mutate: func(r *RayCluster) {
+				r.Spec.EnablePodDisruptionBudgets = ptr.To(true)
+				r.Spec.WorkerGroupSpecs[0].DisruptionBudget = &WorkerGroupDisruptionBudget{MaxUnavailable: ptr.To(intstr.FromString("50%"))}
+			},
+		},
+		{
+			name: "disruption budget with both maxUnavailable and minAvailable",
+			mutate: func(r *RayCluster) {
+				r.Spec.WorkerGroupSpecs[0].DisruptionBudget = &WorkerGroupDisruptionBudget{
+					MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+					MinAvailable:   ptr.To(intstr.FromInt32(-1)),
+				}
+			},
+			expected: []string{
+				"spec.workerGroupSpecs[0].disruptionBudget: Forbidden: maxUnavailable and minAvailable cannot both be set",
+				`spec.workerGroupSpecs[0].disruptionBudget.minAvailable: Invalid value: "-1": must not be negative`,
+			},
+		},
+		{
+			name: "resource claims",
+			mutate: func(r *RayCluster) {
+				r.Spec.WorkerGroupSpecs[0].ResourceClaims = []RayResourceClaim{
+					{Name: "gpus", ResourceClaimTemplateName: ptr.To("two-gpus"), RayResources: map[string]resource.Quantity{"GPU": resource.MustParse("2")}},
+				}
+			},
+		},
+		{
+			name: "invalid resource claims",
+			mutate: func(r *RayCluster) {
+				r.Spec.HeadGroupSpec.Template.Spec.ResourceClaims = []corev1.PodResourceClaim{{Name: "gpus"}}
+				r.Spec.HeadGroupSpec.ResourceClaims = []RayResourceClaim{{Name: "gpus", ResourceClaimName: ptr.To("shared-gpus")}}
+				r.Spec.WorkerGroupSpecs[0].ResourceClaims = []RayResourceClaim{
+					{Name: "gpus", RayResources: map[string]resource.Quantity{"GPU": resource.MustParse("-1")}},
+				}
+			},
+			expected: []string{
+				`spec.headGroupSpec.resourceClaims[0].name: Duplicate value: "gpus"`,
+				"spec.workerGroupSpecs[0].resourceClaims[0]: Forbidden: exactly one of resourceClaimName and resourceClaimTemplateName must be set",
+				`spec.workerGroupSpecs[0].resourceClaims[0].rayResources[GPU]: Invalid value: "-1": must not be negative`,
+			},
+		},
+		{
+			name: "idle timeout",
+			mutate: func(r *RayCluster) {
+				r.Spec.IdleTimeoutSeconds = ptr.To[int32](600)
+				r.Spec.IdleTimeoutAction = SuspendIdleTimeoutAction
+			},
+		},
+		{
+			name: "idleTimeoutAction without idleTimeoutSeconds",
+			mutate: func(r *RayCluster) {
+				r.Spec.IdleTimeoutAction = DeleteIdleTimeoutAction
+			},
+			expected: []string{"spec.idleTimeoutAction: Forbidden: idleTimeoutAction can only be set if idleTimeoutSeconds is set"},
+		},
+		{
+			name: "waitForHead",
+			mutate: func(r *RayCluster) {
+				r.Spec.WaitForHead = &WaitForHeadOptions{InitialBackoffSeconds: ptr.To[int32](5), MaxBackoffSeconds: ptr.To[int32](5)}
+			},
+		},
+		{
+			name: "waitForHead with maxBackoffSeconds less than initialBackoffSeconds",
+			mutate: func(r *RayCluster) {
+				r.Spec.WaitForHead = &WaitForHeadOptions{InitialBackoffSeconds: ptr.To[int32](10), MaxBackoffSeconds: ptr.To[int32](5)}
+			},
+			expected: []string{"spec.waitForHead.maxBackoffSeconds: Invalid value: 5: maxBackoffSeconds must not be less than initialBackoffSeconds"},
+		},
+		{
+			name: "TLS",
+			mutate: func(r *RayCluster) {
+				r.Spec.Security = &RayClusterSecurity{TLS: &RayClusterTLS{Enabled: true, IssuerRef: &CertificateIssuerReference{Name: "ray-ca"}}}
+			},
+		},
+		{
+			name: "TLS without issuer",
+			mutate: func(r *RayCluster) {
+				r.Spec.Security = &RayClusterSecurity{TLS: &RayClusterTLS{Enabled: true}}
+			},
+			expected: []string{"spec.security.tls.issuerRef.name: Required value: cert-manager needs an issuer"},
+		},
+		{
+			name: "GCS fault tolerance without Redis address",
+			mutate: func(r *RayCluster) {
+				r.Annotations = map[string]string{"ray.io/ft-enabled": "true"}
+			},
+			expected: []string{"spec.headGroupSpec.template.spec.containers[0].env: Required value: GCS fault tolerance is enabled by the ray.io/ft-enabled annotation"},
+		},
+		{
+			name: "GCS fault tolerance with Redis address",
+			mutate: func(r *RayCluster) {
+				r.Annotations = map[string]string{"ray.io/ft-enabled": "true"}
+				r.Spec.HeadGroupSpec.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "RAY_REDIS_ADDRESS", Value: "redis:6379"}}
+			},
+		},
+		{
+			name: "GCS fault tolerance options",
+			mutate: func(r *RayCluster) {
+				r.Annotations = map[string]string{"ray.io/ft-enabled": "true"}
+				r.Spec.GcsFaultToleranceOptions = &GcsFaultToleranceOptions{
+					RedisAddress: "rediss://redis:6379",
+					RedisPassword: &RedisCredential{ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "redis"}, Key: "password"},
+					}},
+					RedisCABundle: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "redis-ca"}, Key: "ca.crt"},
+				}
+			},
+		},
+		{
+			name: "invalid GCS fault tolerance options",
+			mutate: func(r *RayCluster) {
+				r.Annotations = map[string]string{"ray.io/ft-enabled": "false"}
+				r.Spec.HeadGroupSpec.RayStartParams["redis-password"] = "5241590000000000"
+				r.Spec.HeadGroupSpec.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "RAY_REDIS_ADDRESS", Value: "redis:6379"}}
+				r.Spec.GcsFaultToleranceOptions = &GcsFaultToleranceOptions{
+					RedisUsername: &RedisCredential{},
+					RedisPassword: &RedisCredential{Value: "5241590000000000"},
+					RedisCABundle: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "redis-ca"}, Key: "ca.crt"},
+				}
+			},
+			expected: []string{
+				"spec.gcsFaultToleranceOptions: Forbidden: gcsFaultToleranceOptions enables GCS fault tolerance, which the ray.io/ft-enabled annotation disables",
+				"spec.gcsFaultToleranceOptions.redisAddress: Required value",
+				"spec.headGroupSpec.template.spec.containers[0].env: Forbidden: the KubeRay operator sets the RAY_REDIS_ADDRESS environment variable",
+				"spec.headGroupSpec.rayStartParams[redis-password]: Forbidden",
+				"spec.gcsFaultToleranceOptions.redisUsername: Forbidden: exactly one of value and valueFrom must be set",
+				"spec.gcsFaultToleranceOptions.redisCABundle: Forbidden: redisCABundle can only be set if redisAddress uses the rediss scheme",
+			},
+		},
+		{
+			name: "Ingress",
+			mutate: func(r *RayCluster) {
+				r.Spec.HeadGroupSpec.Ingress = &HeadIngressOptions{
+					IngressClassName: ptr.To("nginx"),
+					DashboardHost:    "dashboard.example.com",
+					ServeHost:        "serve.example.com",
+					TLSSecretName:    "example-tls",
+				}
+			},
+		},
+		{
+			name: "HTTPRoutes",
+			mutate: func(r *RayCluster) {
+				r.Spec.HeadGroupSpec.Ingress = &HeadIngressOptions{
+					Type:          HTTPRouteIngressType,
+					Gateway:       &GatewayReference{Name: "gateway", Namespace: "gateway-system"},
+					DashboardHost: "dashboard.example.com",
+				}
+			},
+		},
+		{
+			name: "invalid Ingress",
+			mutate: func(r *RayCluster) {
+				r.Spec.HeadGroupSpec.EnableIngress = ptr.To(true)
+				r.Spec.HeadGroupSpec.Ingress = &HeadIngressOptions{
+					Gateway:       &GatewayReference{Name: "gateway"},
+					DashboardHost: "ray.example.com",
+					ServeHost:     "ray.example.com",
+				}
+			},
+			expected: []string{
+				"spec.headGroupSpec.enableIngress: Forbidden: enableIngress cannot be set together with ingress",
+				`spec.headGroupSpec.ingress.serveHost: Invalid value: "ray.example.com": serveHost must be different from dashboardHost`,
+				"spec.headGroupSpec.ingress.gateway: Forbidden: gateway can only be set if type is HTTPRoute",
+			},
+		},
+		{
+			name: "invalid HTTPRoutes",
+			mutate: func(r *RayCluster) {
+				r.Spec.HeadGroupSpec.Ingress = &HeadIngressOptions{
+					Type:             HTTPRouteIngressType,
+					IngressClassName: ptr.To("nginx"),
+					TLSSecretName:    "example-tls",
+				}
+			},
+			expected: []string{
+				"spec.headGroupSpec.ingress.gateway.name: Required value: HTTPRoutes need a Gateway to attach to",
+				"spec.headGroupSpec.ingress.tlsSecretName: Forbidden: the Gateway terminates TLS of HTTPRoutes",
+				"spec.headGroupSpec.ingress.ingressClassName: Forbidden: ingressClassName can only be set if type is Ingress",
+			},
+		},
+		{
+			name: "log and spill volumes",
+			mutate: func(r *RayCluster) {
+				r.Spec.HeadGroupSpec.LogVolume = &RayVolume{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: ptr.To(resource.MustParse("10Gi"))}}
+				r.Spec.WorkerGroupSpecs[0].SpillVolume = &RayVolume{
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{},
+					CleanupPolicy:       DeleteWithClusterCleanupPolicy,
+				}
+			},
+		},
+		{
+			name: "invalid log and spill volumes",
+			mutate: func(r *RayCluster) {
+				r.Spec.HeadGroupSpec.LogVolume = &RayVolume{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+					HostPath: &corev1.HostPathVolumeSource{Path: "/mnt/ray"},
+				}
+				r.Spec.HeadGroupSpec.SpillVolume = &RayVolume{
+					HostPath:      &corev1.HostPathVolumeSource{Path: "/mnt/spill"},
+					CleanupPolicy: DeleteWithPodCleanupPolicy,
+				}
+				r.Spec.WorkerGroupSpecs[0].WorkloadType = StatefulSetWorkerGroupWorkloadType
+				r.Spec.WorkerGroupSpecs[0].LogVolume = &RayVolume{
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{},
+					CleanupPolicy:       DeleteWithClusterCleanupPolicy,
+				}
+			},
+			expected: []string{
+				"spec.headGroupSpec.logVolume: Invalid value: 2: exactly one of emptyDir, hostPath and volumeClaimTemplate must be set",
+				"spec.headGroupSpec.spillVolume.cleanupPolicy: Forbidden: cleanupPolicy can only be set with volumeClaimTemplate",
+				"spec.workerGroupSpecs[0].logVolume.cleanupPolicy: Forbidden: StatefulSet worker groups cannot keep the claims of deleted Pods",
+			},
+		},
+		{
+			name: "StatefulSet worker group with an upscaling mode",
+			mutate: func(r *RayCluster) {
+				r.Spec.WorkerGroupSpecs[0].WorkloadType = StatefulSetWorkerGroupWorkloadType
+				r.Spec.WorkerGroupSpecs[0].UpscalingMode = ptr.To(ConservativeUpscalingMode)
+			},
+			expected: []string{"spec.workerGroupSpecs[0].upscalingMode: Forbidden: the StatefulSet creates the Pods of StatefulSet worker groups"},
+		},
+		{
+			name: "autoscaler v2 with an old Ray version",
+			mutate: func(r *RayCluster) {
+				r.Spec.RayVersion = "2.9.3"
+				r.Spec.EnableInTreeAutoscaling = ptr.To(true)
+				r.Spec.HeadGroupSpec.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "RAY_enable_autoscaler_v2", Value: "1"}}
+			},
+			expected: []string{"spec.rayVersion: Forbidden: autoscaler v2 needs Ray 2.10.0 or later, but rayVersion is 2.9.3"},
+		},
+		{
+			name: "autoscaler version v2 with an old Ray version",
+			mutate: func(r *RayCluster) {
+				r.Spec.RayVersion = "2.9.3"
+				r.Spec.EnableInTreeAutoscaling = ptr.To(true)
+				r.Spec.AutoscalerOptions = &AutoscalerOptions{Version: ptr.To(AutoscalerVersionV2)}
+			},
+			expected: []string{"spec.rayVersion: Forbidden: autoscaler v2 needs Ray 2.10.0 or later, but rayVersion is 2.9.3"},
+		},
+		{
+			name: "autoscaler v2 with worker group idle timeouts",
+			mutate: func(r *RayCluster) {
+				r.Spec.RayVersion = "2.10.0"
+				r.Spec.EnableInTreeAutoscaling = ptr.To(true)
+				r.Spec.AutoscalerOptions = &AutoscalerOptions{Version: ptr.To(AutoscalerVersionV2)}
+				r.Spec.WorkerGroupSpecs[0].IdleTimeoutSeconds = ptr.To[int32](300)
+			},
+		},
+		{
+			name: "worker group idle timeout without autoscaler v2",
+			mutate: func(r *RayCluster) {
+				r.Spec.EnableInTreeAutoscaling = ptr.To(true)
+				r.Spec.WorkerGroupSpecs[0].IdleTimeoutSeconds = ptr.To[int32](300)
+			},
+			expected: []string{"spec.workerGroupSpecs[0].idleTimeoutSeconds: Forbidden: idleTimeoutSeconds of worker groups can only be set with autoscaler v2"},
+		},
+		{
+			name: "autoscaler version contradicting the environment variable",
+			mutate: func(r *RayCluster) {
+				r.Spec.EnableInTreeAutoscaling = ptr.To(true)
+				r.Spec.AutoscalerOptions = &AutoscalerOptions{Version: ptr.To(AutoscalerVersionV1)}
+				r.Spec.HeadGroupSpec.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "RAY_enable_autoscaler_v2", Value: "true"}}
+			},
+			expected: []string{"spec.headGroupSpec.template.spec.containers[0].env: Forbidden: the RAY_enable_autoscaler_v2 environment variable contradicts autoscalerOptions.version v1"},
+		},
+		{
+			name: "autoscaler v2 with a nightly Ray version",
+			mutate: func(r *RayCluster) {
+				r.Spec.RayVersion = "nightly"
+				r.Spec.HeadGroupSpec.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "RAY_enable_autoscaler_v2", Value: "1"}}
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rayCluster := newValidationTestRayCluster()
+			tc.mutate(rayCluster)
+			err := rayCluster.validateRayCluster()
+			if len(tc.expected) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, expected := range tc.expected {
+				assert.Contains(t, err.Error(), expected)
+			}
+		})
+	}
+}
+
+func TestRayVersionWarnings(t *testing.T) {
+	tests := []struct {
+		mutate   func(*RayCluster)
+		name     string
+		expected []string
+	}{
+		{
+			name:   "no Ray version",
+			mutate: func(*RayCluster) {},
+		},
+		{
+			name: "matching images",
+			mutate: func(r *RayCluster) {
+				r.Spec.RayVersion = "2.9.0"
+				r.Spec.HeadGroupSpec.Template.Spec.Containers[0].Image = "rayproject/ray:2.9.0"
+				r.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[0].Image = "localhost:5000/rayproject/ray:2.9.0-py310-gpu"
+			},
+		},
+		{
+			name: "images without a Ray version",
+			mutate: func(r *RayCluster) {
+				r.Spec.RayVersion = "2.9.0"
+				r.Spec.HeadGroupSpec.Template.Spec.Containers[0].Image = "rayproject/ray:nightly"
+				r.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[0].Image = "localhost:5000/ray"
+			},
+		},
+		{
+			name: "mismatched image",
+			mutate: func(r *RayCluster) {
+				r.Spec.RayVersion = "2.9.0"
+				r.Spec.HeadGroupSpec.Template.Spec.Containers[0].Image = "rayproject/ray:2.9.0"
+				r.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[0].Image = "rayproject/ray:2.10.0-gpu@sha256:0123456789abcdef"
+			},
+			expected: []string{"spec.workerGroupSpecs[0].template.spec.containers[0].image: the image rayproject/ray:2.10.0-gpu@sha256:0123456789abcdef has Ray 2.10.0, but spec.rayVersion is 2.9.0"},
+		},
+		{
+			name: "unsupported Ray version",
+			mutate: func(r *RayCluster) {
+				r.Spec.RayVersion = "1.13.0"
+			},
+			expected: []string{"spec.rayVersion 1.13.0 is older than Ray 2.0.0, the oldest Ray version that the KubeRay operator supports"},
+		},
+		{
+			name: "invalid Ray version",
+			mutate: func(r *RayCluster) {
+				r.Spec.RayVersion = "latest"
+			},
+			expected: []string{`spec.rayVersion "latest" is not a Ray version, the KubeRay operator cannot check whether it supports it`},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rayCluster := newValidationTestRayCluster()
+			tc.mutate(rayCluster)
+			assert.ElementsMatch(t, tc.expected, rayCluster.rayVersionWarnings())
+		})
+	}
+}
@@ -43,6 +43,9 @@ const (
 	JobDeploymentStatusSuspended    JobDeploymentStatus = "Suspended"
 	JobDeploymentStatusRetrying     JobDeploymentStatus = "Retrying"
 	JobDeploymentStatusWaiting      JobDeploymentStatus = "Waiting"
+	// JobDeploymentStatusScheduled is the status of a RayJob with a schedule, which creates RayJob runs instead of
+	// running itself.
+	JobDeploymentStatusScheduled JobDeploymentStatus = "Scheduled"
 )
 
 // JobFailedReason indicates the reason the RayJob changes its JobDeploymentStatus to 'Failed'
@@ -52,6 +55,31 @@ const (
 	SubmissionFailed JobFailedReason = "SubmissionFailed"
 	DeadlineExceeded JobFailedReason = "DeadlineExceeded"
 	AppFailed        JobFailedReason = "AppFailed"
+	// AttemptDeadlineExceeded means that an attempt of the RayJob ran for longer than AttemptDeadlineSeconds. Unlike
+	// DeadlineExceeded, the RayJob is retried if it has not reached its BackoffLimit.
+	AttemptDeadlineExceeded JobFailedReason = "AttemptDeadlineExceeded"
+)
+
+type RayJobConditionType string
+
+const (
+	// RayJobReady indicates whether the Ray job is running on a ready RayCluster. Its reason is the JobDeploymentStatus
+	// of the RayJob, or New before the RayJob is initialized.
+	RayJobReady RayJobConditionType = "Ready"
+	// RayJobComplete is added to a RayJob when its Ray job succeeds or is stopped, like the Complete condition of a
+	// Kubernetes Job.
+	RayJobComplete RayJobConditionType = "Complete"
+	// RayJobFailed is added to a RayJob when it fails. Its reason is the JobFailedReason of the RayJob.
+	RayJobFailed RayJobConditionType = "Failed"
+	// RayJobSuspended indicates whether the RayCluster of a suspended RayJob is deleted.
+	RayJobSuspended RayJobConditionType = "Suspended"
+)
+
+// Custom Reason for RayJobCondition
+const (
+	RayJobNew       = "New"
+	RayJobSucceeded = "JobSucceeded"
+	RayJobStopped   = "JobStopped"
 )
 
 type JobSubmissionMode string
@@ -62,6 +90,64 @@ const (
 	InteractiveMode JobSubmissionMode = "InteractiveMode" // Don't submit job in KubeRay. Instead, wait for user to submit job and provide the job submission ID.
 )
 
+// ConcurrencyPolicy describes how the KubeRay operator handles the runs of a RayJob with a schedule that would
+// overlap.
+// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+type ConcurrencyPolicy string
+
+const (
+	// AllowConcurrent starts the runs of a RayJob on schedule, even if the previous runs are still active.
+	AllowConcurrent ConcurrencyPolicy = "Allow"
+	// ForbidConcurrent skips a run of a RayJob if a previous run is still active.
+	ForbidConcurrent ConcurrencyPolicy = "Forbid"
+	// ReplaceConcurrent deletes the active runs of a RayJob before starting a new run.
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+)
+
+// DeletionPolicy specifies which resources of a RayJob the KubeRay operator deletes once the RayJob finishes.
+// +kubebuilder:validation:Enum=DeleteCluster;DeleteWorkers;DeleteSelf;DeleteNone
+type DeletionPolicy string
+
+const (
+	// DeleteClusterDeletionPolicy deletes the RayCluster of the RayJob.
+	DeleteClusterDeletionPolicy DeletionPolicy = "DeleteCluster"
+	// DeleteWorkersDeletionPolicy scales the worker groups of the RayCluster to zero and keeps its head Pod, e.g. to
+	// access the Ray dashboard of the finished RayJob.
+	DeleteWorkersDeletionPolicy DeletionPolicy = "DeleteWorkers"
+	// DeleteSelfDeletionPolicy deletes the RayJob, together with its RayCluster and submitter Kubernetes Job.
+	DeleteSelfDeletionPolicy DeletionPolicy = "DeleteSelf"
+	// DeleteNoneDeletionPolicy deletes nothing.
+	DeleteNoneDeletionPolicy DeletionPolicy = "DeleteNone"
+)
+
+// RetryClusterPolicy specifies what happens to the RayCluster of a RayJob when the RayJob is retried.
+// +kubebuilder:validation:Enum=Recreate;Reuse
+type RetryClusterPolicy string
+
+const (
+	// RecreateRetryClusterPolicy deletes the RayCluster of the failed attempt and creates a new RayCluster for the retry.
+	RecreateRetryClusterPolicy RetryClusterPolicy = "Recreate"
+	// ReuseRetryClusterPolicy submits the Ray job of the retry to the RayCluster of the failed attempt.
+	ReuseRetryClusterPolicy RetryClusterPolicy = "Reuse"
+)
+
+// RayJobRetryPolicy configures the retries of a RayJob that fails within its BackoffLimit.
+type RayJobRetryPolicy struct {
+	// InitialBackoffSeconds is the delay before the first retry. The delay doubles after each retry. If it is not set,
+	// the RayJob is retried immediately.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialBackoffSeconds *int32 `json:"initialBackoffSeconds,omitempty"`
+	// MaxBackoffSeconds is the maximum delay between two attempts. Defaults to 600.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxBackoffSeconds *int32 `json:"maxBackoffSeconds,omitempty"`
+	// ClusterPolicy is Recreate or Reuse. It specifies whether a retry runs on a new RayCluster or on the RayCluster
+	// of the failed attempt. The default is Recreate. A RayJob with a ClusterSelector always reuses its RayCluster.
+	// +optional
+	ClusterPolicy RetryClusterPolicy `json:"clusterPolicy,omitempty"`
+}
+
 type SubmitterConfig struct {
 	// BackoffLimit of the submitter k8s job.
 	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
@@ -71,11 +157,22 @@ type SubmitterConfig struct {
 type RayJobSpec struct {
 	// ActiveDeadlineSeconds is the duration in seconds that the RayJob may be active before
 	// KubeRay actively tries to terminate the RayJob; value must be positive integer.
+	// The deadline covers all the attempts of the RayJob and restarts when a suspended RayJob is resumed.
+	// Once it has passed, KubeRay stops the Ray job and marks the RayJob Failed with the DeadlineExceeded reason.
 	ActiveDeadlineSeconds *int32 `json:"activeDeadlineSeconds,omitempty"`
+	// AttemptDeadlineSeconds is the duration in seconds that each attempt of the RayJob may be active. Once it
+	// has passed, KubeRay stops the Ray job and the attempt fails with the AttemptDeadlineExceeded reason, which is
+	// retried within the BackoffLimit.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	AttemptDeadlineSeconds *int32 `json:"attemptDeadlineSeconds,omitempty"`
 	// Specifies the number of retries before marking this job failed.
-	// Each retry creates a new RayCluster.
+	// Each retry creates a new RayCluster, unless RetryPolicy specifies otherwise.
 	// +kubebuilder:default:=0
 	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	// RetryPolicy configures the backoff between the retries of the RayJob and whether they reuse its RayCluster.
+	// +optional
+	RetryPolicy *RayJobRetryPolicy `json:"retryPolicy,omitempty"`
 	// RayClusterSpec is the cluster template to run the job
 	RayClusterSpec *RayClusterSpec `json:"rayClusterSpec,omitempty"`
 	// SubmitterPodTemplate is the template for the pod that will run `ray job submit`.
@@ -86,6 +183,19 @@ type RayJobSpec struct {
 	ClusterSelector map[string]string `json:"clusterSelector,omitempty"`
 	// Configurations of submitter k8s job.
 	SubmitterConfig *SubmitterConfig `json:"submitterConfig,omitempty"`
+	// StartingDeadlineSeconds is the deadline in seconds for starting a run of a RayJob with a schedule if it misses
+	// its scheduled time. Runs that miss their deadline are skipped. If it is not set, runs have no deadline.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+	// SuccessfulJobsHistoryLimit is the number of completed runs of a RayJob with a schedule to keep. Defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
+	// FailedJobsHistoryLimit is the number of failed runs of a RayJob with a schedule to keep. Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 	Entrypoint string `json:"entrypoint,omitempty"`
@@ -96,19 +206,36 @@ type RayJobSpec struct {
 	JobId string `json:"jobId,omitempty"`
 	// SubmissionMode specifies how RayJob submits the Ray job to the RayCluster.
 	// In "K8sJobMode", the KubeRay operator creates a submitter Kubernetes Job to submit the Ray job.
-	// In "HTTPMode", the KubeRay operator sends a request to the RayCluster to create a Ray job.
+	// In "HTTPMode", the KubeRay operator sends a request to the RayCluster to create a Ray job and polls
+	// its status without creating a submitter Kubernetes Job. If the RayCluster rejects the request as invalid,
+	// the RayJob fails.
 	// In "InteractiveMode", the KubeRay operator waits for a user to submit a job to the Ray cluster.
 	// +kubebuilder:default:=K8sJobMode
 	SubmissionMode JobSubmissionMode `json:"submissionMode,omitempty"`
 	// EntrypointResources specifies the custom resources and quantities to reserve for the
 	// entrypoint command.
 	EntrypointResources string `json:"entrypointResources,omitempty"`
+	// Schedule is a cron expression, e.g. "0 * * * *", in UTC. If it is set, the RayJob does not run itself. Like a
+	// Kubernetes CronJob, it creates a RayJob run with the rest of its spec at each scheduled time instead, and suspend
+	// pauses the creation of the runs. The runs are named after the RayJob and their scheduled time.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+	// ConcurrencyPolicy is Allow, Forbid or Replace. It specifies how the runs of a RayJob with a schedule that would
+	// overlap are handled. The default is Allow.
+	// +optional
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	// DeletionPolicy is DeleteCluster, DeleteWorkers, DeleteSelf or DeleteNone. It specifies which resources are
+	// deleted TTLSecondsAfterFinished after the RayJob finishes. If it is not set, the RayCluster is deleted if
+	// ShutdownAfterJobFinishes is true. With DeleteWorkers or DeleteNone, the RayCluster is also kept when the RayJob
+	// is deleted and must then be deleted by the user. It cannot be set together with ShutdownAfterJobFinishes.
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 	// EntrypointNumCpus specifies the number of cpus to reserve for the entrypoint command.
 	EntrypointNumCpus float32 `json:"entrypointNumCpus,omitempty"`
 	// EntrypointNumGpus specifies the number of gpus to reserve for the entrypoint command.
 	EntrypointNumGpus float32 `json:"entrypointNumGpus,omitempty"`
 	// TTLSecondsAfterFinished is the TTL to clean up RayCluster.
-	// It's only working when ShutdownAfterJobFinishes set to true.
+	// It's only working when ShutdownAfterJobFinishes set to true or DeletionPolicy is set.
 	// +kubebuilder:default:=0
 	TTLSecondsAfterFinished int32 `json:"ttlSecondsAfterFinished,omitempty"`
 	// ShutdownAfterJobFinishes will determine whether to delete the ray cluster once rayJob succeed or failed.
@@ -121,6 +248,26 @@ type RayJobSpec struct {
 	Suspend bool `json:"suspend,omitempty"`
 }
 
+// RayJobAttemptStatus is the record of a failed attempt of a RayJob.
+type RayJobAttemptStatus struct {
+	// StartTime is the time when the attempt started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// EndTime is the time when the attempt failed.
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+	// RayClusterName is the name of the RayCluster that ran the attempt.
+	RayClusterName string `json:"rayClusterName,omitempty"`
+	// JobId is the submission ID of the Ray job of the attempt.
+	JobId string `json:"jobId,omitempty"`
+	// JobStatus is the status of the Ray job of the attempt when it failed.
+	JobStatus JobStatus `json:"jobStatus,omitempty"`
+	// Reason is the reason why the attempt failed.
+	Reason JobFailedReason `json:"reason,omitempty"`
+	// Message is a human-readable message about the failure.
+	Message string `json:"message,omitempty"`
+	// Attempt is the number of the attempt, starting from 1.
+	Attempt int32 `json:"attempt"`
+}
+
 // RayJobStatus defines the observed state of RayJob
 type RayJobStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -132,8 +279,11 @@ type RayJobStatus struct {
 	JobDeploymentStatus JobDeploymentStatus `json:"jobDeploymentStatus,omitempty"`
 	Reason              JobFailedReason     `json:"reason,omitempty"`
 	Message             string              `json:"message,omitempty"`
-	// StartTime is the time when JobDeploymentStatus transitioned from 'New' to 'Initializing'.
+	// StartTime is the time when JobDeploymentStatus first transitioned from 'New' to 'Initializing'. It is not
+	// reset by retries, but it is reset when a suspended RayJob is resumed.
 	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// AttemptStartTime is the time when the current attempt of the RayJob transitioned from 'New' to 'Initializing'.
+	AttemptStartTime *metav1.Time `json:"attemptStartTime,omitempty"`
 	// EndTime is the time when JobDeploymentStatus transitioned to 'Complete' status.
 	// This occurs when the Ray job reaches a terminal state (SUCCEEDED, FAILED, STOPPED)
 	// or the submitter Job has failed.
@@ -144,6 +294,20 @@ type RayJobStatus struct {
 	// Failed is the number of times this job failed.
 	// +kubebuilder:default:=0
 	Failed *int32 `json:"failed,omitempty"`
+	// LastScheduleTime is the scheduled time of the latest run of a RayJob with a schedule.
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// LastSuccessfulTime is the time when the latest successful run of a RayJob with a schedule completed.
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+	// Represents the latest available observations of a RayJob's current state.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+	// ActiveRuns are the names of the active runs of a RayJob with a schedule.
+	ActiveRuns []string `json:"activeRuns,omitempty"`
+	// FailedAttempts are the records of the latest failed attempts of the RayJob, up to 10.
+	FailedAttempts []RayJobAttemptStatus `json:"failedAttempts,omitempty"`
 	// RayClusterStatus is the status of the RayCluster running the job.
 	RayClusterStatus RayClusterStatus `json:"rayClusterStatus,omitempty"`
 
@@ -18,6 +18,36 @@ const (
 	Restarting                       ServiceStatus = "Restarting"
 	FailedToUpdateServingPodLabel    ServiceStatus = "FailedToUpdateServingPodLabel"
 	FailedToUpdateService            ServiceStatus = "FailedToUpdateService"
+	FailedToUpdateIngress            ServiceStatus = "FailedToUpdateIngress"
+)
+
+// RayServicePhase summarizes the rollout progress of a RayService.
+type RayServicePhase string
+
+const (
+	// RayServicePhaseInitializing means that no RayCluster has served the Ray Serve applications of the RayService yet.
+	RayServicePhaseInitializing RayServicePhase = "Initializing"
+	// RayServicePhaseRunning means that the active RayCluster serves the Ray Serve applications of the RayService.
+	RayServicePhaseRunning RayServicePhase = "Running"
+	// RayServicePhaseUpgrading means that a pending RayCluster is being prepared to replace the active RayCluster.
+	RayServicePhaseUpgrading RayServicePhase = "Upgrading"
+)
+
+type RayServiceConditionType string
+
+const (
+	// RayServiceReady indicates whether the Ray Serve applications of a RayService are ready to serve requests.
+	// Its reason is the ServiceStatus of the RayService, or Initializing before the RayService has one.
+	RayServiceReady RayServiceConditionType = "Ready"
+	// UpgradeInProgress indicates whether a pending RayCluster is being prepared to replace the active RayCluster.
+	UpgradeInProgress RayServiceConditionType = "UpgradeInProgress"
+)
+
+// Custom Reason for RayServiceCondition
+const (
+	RayServiceInitializing  = "Initializing"
+	PendingRayClusterExists = "PendingRayClusterExists"
+	NoPendingRayCluster     = "NoPendingRayCluster"
 )
 
 // These statuses should match Ray Serve's application statuses
@@ -68,8 +98,16 @@ type RayServiceStatuses struct {
 	// LastUpdateTime represents the timestamp when the RayService status was last updated.
 	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
 	// ServiceStatus indicates the current RayService status.
-	ServiceStatus       ServiceStatus    `json:"serviceStatus,omitempty"`
-	ActiveServiceStatus RayServiceStatus `json:"activeServiceStatus,omitempty"`
+	ServiceStatus ServiceStatus `json:"serviceStatus,omitempty"`
+	// Phase summarizes the rollout progress of the RayService for tools such as Argo CD and Flux.
+	Phase RayServicePhase `json:"phase,omitempty"`
+	// Represents the latest available observations of a RayService's current state.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions          []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+	ActiveServiceStatus RayServiceStatus   `json:"activeServiceStatus,omitempty"`
 	// Pending Service Status indicates a RayCluster will be created or is being created.
 	PendingServiceStatus RayServiceStatus `json:"pendingServiceStatus,omitempty"`
 	// NumServeEndpoints indicates the number of Ray Pods that are actively serving or have been selected by the serve service.
@@ -268,6 +268,7 @@ var expected = `{
             "desiredMemory": "0",
             "desiredGPU": "0",
             "desiredTPU": "0",
+            "readyGPU": "0",
             "head":{}
          }
       },
@@ -277,6 +278,7 @@ var expected = `{
             "desiredMemory": "0",
             "desiredGPU": "0",
             "desiredTPU": "0",
+            "readyGPU": "0",
             "head":{}
          }
       }
@@ -0,0 +1,73 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RayWorkerGroupSpec defines the desired state of RayWorkerGroup
+type RayWorkerGroupSpec struct {
+	// Replicas is the desired number of Pods of the worker group. It is clamped to the minReplicas and maxReplicas
+	// of the worker group. If it is not set, the RayWorkerGroup does not change the replicas of the worker group.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// RayClusterName is the name of the RayCluster, in the namespace of the RayWorkerGroup, that the worker group belongs to.
+	RayClusterName string `json:"rayClusterName"`
+	// GroupName is the name of the worker group in the RayCluster.
+	GroupName string `json:"groupName"`
+}
+
+// RayWorkerGroupStatus defines the observed state of RayWorkerGroup
+type RayWorkerGroupStatus struct {
+	// Selector is the label selector of the Pods of the worker group, in the string format expected by the scale
+	// subresource.
+	// +optional
+	Selector string `json:"selector,omitempty"`
+	// Reason is a human-readable message explaining why the worker group cannot be scaled, if it cannot.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// ObservedGeneration is the most recent generation observed for this RayWorkerGroup. It corresponds to the
+	// RayWorkerGroup's generation, which is updated on mutation by the API Server.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Replicas is the number of Pods of the worker group.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// DesiredReplicas is the replicas of the worker group in the RayCluster.
+	// +optional
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=all
+// +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="ray cluster name",type=string,JSONPath=".spec.rayClusterName",priority=0
+// +kubebuilder:printcolumn:name="group name",type=string,JSONPath=".spec.groupName",priority=0
+// +kubebuilder:printcolumn:name="desired replicas",type=integer,JSONPath=".status.desiredReplicas",priority=0
+// +kubebuilder:printcolumn:name="replicas",type=integer,JSONPath=".status.replicas",priority=0
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp",priority=0
+// +kubebuilder:printcolumn:name="reason",type=string,JSONPath=".status.reason",priority=1
+// +genclient
+// RayWorkerGroup is the Schema for the rayworkergroups API. It exposes a worker group of a RayCluster through the
+// scale subresource, so that a HorizontalPodAutoscaler or a KEDA ScaledObject can scale it.
+type RayWorkerGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RayWorkerGroupSpec   `json:"spec,omitempty"`
+	Status RayWorkerGroupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RayWorkerGroupList contains a list of RayWorkerGroup
+type RayWorkerGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RayWorkerGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RayWorkerGroup{}, &RayWorkerGroupList{})
+}
@@ -2,6 +2,8 @@ package schedulerinterface
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -24,6 +26,35 @@ type BatchScheduler interface {
 	// AddMetadataToPod enriches Pod specs with metadata necessary to tie them to the scheduler.
 	// For example, setting labels for queues / priority, and setting schedulerName.
 	AddMetadataToPod(app *rayv1.RayCluster, groupName string, pod *corev1.Pod)
+
+	// CleanupOnDeletion deletes the resources created by DoBatchSchedulingOnSubmission when the RayCluster is
+	// deleted or suspended, so that they do not hold the resources of the batch scheduler.
+	CleanupOnDeletion(ctx context.Context, app *rayv1.RayCluster) error
+}
+
+type errBatchSchedulingRejected struct {
+	reason  string
+	message string
+}
+
+func (e *errBatchSchedulingRejected) Error() string {
+	return e.message
+}
+
+// NewBatchSchedulingRejectedError returns the error of DoBatchSchedulingOnSubmission when the batch scheduler
+// cannot admit the RayCluster, e.g. because it requests more resources than its queue can provide.
+func NewBatchSchedulingRejectedError(reason string, format string, args ...interface{}) error {
+	return &errBatchSchedulingRejected{reason: reason, message: fmt.Sprintf(format, args...)}
+}
+
+// BatchSchedulingRejectedReason returns the reason of an error created by NewBatchSchedulingRejectedError,
+// or an empty string for any other error.
+func BatchSchedulingRejectedReason(err error) string {
+	var rejected *errBatchSchedulingRejected
+	if errors.As(err, &rejected) {
+		return rejected.reason
+	}
+	return ""
 }
 
 // BatchSchedulerFactory handles initial setup of the scheduler plugin by registering the
@@ -59,6 +90,10 @@ func (d *DefaultBatchScheduler) DoBatchSchedulingOnSubmission(_ context.Context,
 func (d *DefaultBatchScheduler) AddMetadataToPod(_ *rayv1.RayCluster, _ string, _ *corev1.Pod) {
 }
 
+func (d *DefaultBatchScheduler) CleanupOnDeletion(_ context.Context, _ *rayv1.RayCluster) error {
+	return nil
+}
+
 func (df *DefaultBatchSchedulerFactory) New(_ *rest.Config) (BatchScheduler, error) {
 	return &DefaultBatchScheduler{}, nil
 }
@@ -79,17 +79,19 @@ func getSchedulerFactory(rayConfigs configapi.Configuration) (schedulerinterface
 	return factory, nil
 }
 
+// GetSchedulerForCluster returns the batch scheduler of the RayCluster. The ray.io/scheduler-name label of the
+// RayCluster selects either the batch scheduler enabled in the operator or the default Kubernetes scheduler,
+// and the enabled batch scheduler is used if the label is not set.
 func (batch *SchedulerManager) GetSchedulerForCluster(app *rayv1.RayCluster) (schedulerinterface.BatchScheduler, error) {
-	// for backwards compatibility
-	if batch.rayConfigs.EnableBatchScheduler {
-		if schedulerName, ok := app.ObjectMeta.Labels[utils.RaySchedulerName]; ok {
-			if schedulerName == volcano.GetPluginName() {
-				return batch.scheduler, nil
-			}
-		}
+	schedulerName, ok := app.ObjectMeta.Labels[utils.RaySchedulerName]
+	if !ok || schedulerName == batch.scheduler.Name() {
+		return batch.scheduler, nil
 	}
-
-	return batch.scheduler, nil
+	if schedulerName == schedulerinterface.GetDefaultPluginName() {
+		return &schedulerinterface.DefaultBatchScheduler{}, nil
+	}
+	return nil, fmt.Errorf("the scheduler %s of RayCluster %s/%s is not enabled in the operator, enabled scheduler=%s",
+		schedulerName, app.Namespace, app.Name, batch.scheduler.Name())
 }
 
 func (batch *SchedulerManager) ConfigureReconciler(b *builder.Builder) *builder.Builder {
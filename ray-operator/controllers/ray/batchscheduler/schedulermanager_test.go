@@ -5,11 +5,14 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	schedulerinterface "github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/interface"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/volcano"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/yunikorn"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 )
 
 func TestGetSchedulerFactory(t *testing.T) {
@@ -141,3 +144,58 @@ func TestGetSchedulerFactory(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSchedulerForCluster(t *testing.T) {
+	tests := []struct {
+		labels         map[string]string
+		name           string
+		batchScheduler string
+		want           string
+		expectedErrMsg string
+	}{
+		{
+			name:           "no scheduler label",
+			batchScheduler: yunikorn.GetPluginName(),
+			want:           yunikorn.GetPluginName(),
+		},
+		{
+			name:           "scheduler label set to the enabled scheduler",
+			batchScheduler: yunikorn.GetPluginName(),
+			labels:         map[string]string{utils.RaySchedulerName: yunikorn.GetPluginName()},
+			want:           yunikorn.GetPluginName(),
+		},
+		{
+			name:           "scheduler label set to the default scheduler",
+			batchScheduler: yunikorn.GetPluginName(),
+			labels:         map[string]string{utils.RaySchedulerName: schedulerinterface.GetDefaultPluginName()},
+			want:           schedulerinterface.GetDefaultPluginName(),
+		},
+		{
+			name:           "scheduler label set to a scheduler not enabled",
+			batchScheduler: yunikorn.GetPluginName(),
+			labels:         map[string]string{utils.RaySchedulerName: volcano.GetPluginName()},
+			expectedErrMsg: "the scheduler volcano of RayCluster default/raycluster is not enabled in the operator, enabled scheduler=yunikorn",
+		},
+		{
+			name:           "no batch scheduler enabled",
+			labels:         map[string]string{utils.RaySchedulerName: yunikorn.GetPluginName()},
+			expectedErrMsg: "the scheduler yunikorn of RayCluster default/raycluster is not enabled in the operator, enabled scheduler=default",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, err := NewSchedulerManager(v1alpha1.Configuration{BatchScheduler: tt.batchScheduler}, nil)
+			assert.NoError(t, err)
+			rayCluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default", Labels: tt.labels}}
+
+			scheduler, err := manager.GetSchedulerForCluster(rayCluster)
+			if len(tt.expectedErrMsg) > 0 {
+				assert.EqualError(t, err, tt.expectedErrMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, scheduler.Name())
+		})
+	}
+}
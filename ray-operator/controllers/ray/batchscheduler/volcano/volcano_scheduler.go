@@ -3,6 +3,7 @@ package volcano
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -32,6 +33,13 @@ const (
 	QueueNameLabelKey = "volcano.sh/queue-name"
 )
 
+// Reasons of the rejection of a RayCluster by its Volcano queue
+const (
+	QueueNotFoundReason           = "QueueNotFound"
+	QueueNotOpenReason            = "QueueNotOpen"
+	QueueCapabilityExceededReason = "QueueCapabilityExceeded"
+)
+
 type VolcanoBatchScheduler struct {
 	extensionClient apiextensionsclient.Interface
 	volcanoClient   volcanoclient.Interface
@@ -59,23 +67,26 @@ func (v *VolcanoBatchScheduler) DoBatchSchedulingOnSubmission(ctx context.Contex
 		totalResource = utils.CalculateMinResources(app)
 	}
 
-	return v.syncPodGroup(app, minMember, totalResource)
+	return v.syncPodGroup(ctx, app, minMember, totalResource)
 }
 
 func getAppPodGroupName(app *rayv1.RayCluster) string {
 	return fmt.Sprintf("ray-%s-pg", app.Name)
 }
 
-func (v *VolcanoBatchScheduler) syncPodGroup(app *rayv1.RayCluster, size int32, totalResource corev1.ResourceList) error {
+func (v *VolcanoBatchScheduler) syncPodGroup(ctx context.Context, app *rayv1.RayCluster, size int32, totalResource corev1.ResourceList) error {
 	podGroupName := getAppPodGroupName(app)
-	if pg, err := v.volcanoClient.SchedulingV1beta1().PodGroups(app.Namespace).Get(context.TODO(), podGroupName, metav1.GetOptions{}); err != nil {
+	if pg, err := v.volcanoClient.SchedulingV1beta1().PodGroups(app.Namespace).Get(ctx, podGroupName, metav1.GetOptions{}); err != nil {
 		if !errors.IsNotFound(err) {
 			return err
 		}
 
 		podGroup := createPodGroup(app, podGroupName, size, totalResource)
+		if err := v.validateQueue(ctx, app, podGroup.Spec.Queue, totalResource); err != nil {
+			return err
+		}
 		if _, err := v.volcanoClient.SchedulingV1beta1().PodGroups(app.Namespace).Create(
-			context.TODO(), &podGroup, metav1.CreateOptions{},
+			ctx, &podGroup, metav1.CreateOptions{},
 		); err != nil {
 			if errors.IsAlreadyExists(err) {
 				v.log.Info("pod group already exists, no need to create")
@@ -86,11 +97,15 @@ func (v *VolcanoBatchScheduler) syncPodGroup(app *rayv1.RayCluster, size int32,
 			return err
 		}
 	} else {
-		if pg.Spec.MinMember != size || !quotav1.Equals(*pg.Spec.MinResources, totalResource) {
+		if pg.Spec.MinMember != size || pg.Spec.MinResources == nil || !quotav1.Equals(*pg.Spec.MinResources, totalResource) {
+			// the RayCluster is scaled, check that its queue can still provide the resources before updating the PodGroup
+			if err := v.validateQueue(ctx, app, pg.Spec.Queue, totalResource); err != nil {
+				return err
+			}
 			pg.Spec.MinMember = size
 			pg.Spec.MinResources = &totalResource
 			if _, err := v.volcanoClient.SchedulingV1beta1().PodGroups(app.Namespace).Update(
-				context.TODO(), pg, metav1.UpdateOptions{},
+				ctx, pg, metav1.UpdateOptions{},
 			); err != nil {
 				v.log.Error(err, "Pod group UPDATE error!", "podGroup", podGroupName)
 				return err
@@ -100,6 +115,51 @@ func (v *VolcanoBatchScheduler) syncPodGroup(app *rayv1.RayCluster, size int32,
 	return nil
 }
 
+// validateQueue checks that the Volcano queue of the RayCluster is open and that the minimum resources of its
+// PodGroup do not exceed the capability of the queue, which Volcano would never admit.
+func (v *VolcanoBatchScheduler) validateQueue(ctx context.Context, app *rayv1.RayCluster, queueName string, totalResource corev1.ResourceList) error {
+	if queueName == "" {
+		queueName = v1beta1.DefaultQueue
+	}
+	queue, err := v.volcanoClient.SchedulingV1beta1().Queues().Get(ctx, queueName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return schedulerinterface.NewBatchSchedulingRejectedError(QueueNotFoundReason,
+				"the Volcano queue %s of RayCluster %s/%s does not exist", queueName, app.Namespace, app.Name)
+		}
+		return err
+	}
+
+	if queue.Status.State != "" && queue.Status.State != v1beta1.QueueStateOpen {
+		return schedulerinterface.NewBatchSchedulingRejectedError(QueueNotOpenReason,
+			"the Volcano queue %s of RayCluster %s/%s is %s", queueName, app.Namespace, app.Name, queue.Status.State)
+	}
+
+	if len(queue.Spec.Capability) > 0 {
+		if ok, exceeded := quotav1.LessThanOrEqual(totalResource, queue.Spec.Capability); !ok {
+			slices.Sort(exceeded)
+			return schedulerinterface.NewBatchSchedulingRejectedError(QueueCapabilityExceededReason,
+				"RayCluster %s/%s requests more %v than the capability of the Volcano queue %s",
+				app.Namespace, app.Name, exceeded, queueName)
+		}
+	}
+	return nil
+}
+
+// CleanupOnDeletion deletes the PodGroup of the RayCluster, which releases its resources in the Volcano queue.
+func (v *VolcanoBatchScheduler) CleanupOnDeletion(ctx context.Context, app *rayv1.RayCluster) error {
+	podGroupName := getAppPodGroupName(app)
+	if err := v.volcanoClient.SchedulingV1beta1().PodGroups(app.Namespace).Delete(ctx, podGroupName, metav1.DeleteOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		v.log.Error(err, "Pod group DELETE error!", "podGroup", podGroupName)
+		return err
+	}
+	v.log.Info("Deleted pod group", "podGroup", podGroupName)
+	return nil
+}
+
 func createPodGroup(
 	app *rayv1.RayCluster,
 	podGroupName string,
@@ -5,12 +5,18 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	volcanofake "volcano.sh/apis/pkg/client/clientset/versioned/fake"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	schedulerinterface "github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/interface"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 )
 
@@ -96,3 +102,88 @@ func TestCreatePodGroup(t *testing.T) {
 	// 2 GPUs total
 	a.Equal("2", pg.Spec.MinResources.Name("nvidia.com/gpu", resource.BinarySI).String())
 }
+
+func TestDoBatchSchedulingOnSubmissionQueue(t *testing.T) {
+	ctx := context.Background()
+	newRayCluster := func(queue string, replicas int32) *rayv1.RayCluster {
+		container := corev1.Container{
+			Name: "ray",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), "nvidia.com/gpu": resource.MustParse("1")},
+			},
+		}
+		return &rayv1.RayCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default", Labels: map[string]string{QueueNameLabelKey: queue}},
+			Spec: rayv1.RayClusterSpec{
+				HeadGroupSpec: rayv1.HeadGroupSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{container}}}},
+				WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+					{
+						GroupName:   "gpu",
+						Replicas:    ptr.To(replicas),
+						MinReplicas: ptr.To[int32](0),
+						MaxReplicas: ptr.To[int32](10),
+						Template:    corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{container}}},
+					},
+				},
+			},
+		}
+	}
+	newQueue := func(name string, state v1beta1.QueueState) *v1beta1.Queue {
+		return &v1beta1.Queue{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1beta1.QueueSpec{Capability: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("4")}},
+			Status:     v1beta1.QueueStatus{State: state},
+		}
+	}
+	volcanoClient := volcanofake.NewSimpleClientset(newQueue("gpu-queue", v1beta1.QueueStateOpen), newQueue("closed-queue", v1beta1.QueueStateClosed))
+	scheduler := &VolcanoBatchScheduler{volcanoClient: volcanoClient, log: logf.Log.WithName("volcano")}
+	getPodGroup := func(rayCluster *rayv1.RayCluster) (*v1beta1.PodGroup, error) {
+		return volcanoClient.SchedulingV1beta1().PodGroups(rayCluster.Namespace).Get(ctx, getAppPodGroupName(rayCluster), metav1.GetOptions{})
+	}
+
+	// The queue does not exist.
+	err := scheduler.DoBatchSchedulingOnSubmission(ctx, newRayCluster("missing-queue", 1))
+	assert.Equal(t, QueueNotFoundReason, schedulerinterface.BatchSchedulingRejectedReason(err))
+
+	// The queue is closed.
+	err = scheduler.DoBatchSchedulingOnSubmission(ctx, newRayCluster("closed-queue", 1))
+	assert.Equal(t, QueueNotOpenReason, schedulerinterface.BatchSchedulingRejectedReason(err))
+
+	// The RayCluster requests 5 GPUs from a queue with 4 GPUs, and its PodGroup is not created.
+	err = scheduler.DoBatchSchedulingOnSubmission(ctx, newRayCluster("gpu-queue", 4))
+	assert.Equal(t, QueueCapabilityExceededReason, schedulerinterface.BatchSchedulingRejectedReason(err))
+	assert.EqualError(t, err, "RayCluster default/raycluster requests more [nvidia.com/gpu] than the capability of the Volcano queue gpu-queue")
+	_, err = getPodGroup(newRayCluster("gpu-queue", 4))
+	assert.True(t, errors.IsNotFound(err))
+
+	// The RayCluster fits in the queue.
+	rayCluster := newRayCluster("gpu-queue", 2)
+	require.NoError(t, scheduler.DoBatchSchedulingOnSubmission(ctx, rayCluster))
+	podGroup, err := getPodGroup(rayCluster)
+	require.NoError(t, err)
+	assert.Equal(t, "gpu-queue", podGroup.Spec.Queue)
+	assert.Equal(t, int32(3), podGroup.Spec.MinMember)
+	assert.Equal(t, "3", podGroup.Spec.MinResources.Name("nvidia.com/gpu", resource.DecimalSI).String())
+
+	// The RayCluster is scaled up, and the minResources of its PodGroup are updated.
+	rayCluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](3)
+	require.NoError(t, scheduler.DoBatchSchedulingOnSubmission(ctx, rayCluster))
+	podGroup, err = getPodGroup(rayCluster)
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), podGroup.Spec.MinMember)
+	assert.Equal(t, "4", podGroup.Spec.MinResources.Name("nvidia.com/gpu", resource.DecimalSI).String())
+
+	// The RayCluster is scaled beyond the capability of its queue, and its PodGroup is not updated.
+	rayCluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](4)
+	err = scheduler.DoBatchSchedulingOnSubmission(ctx, rayCluster)
+	assert.Equal(t, QueueCapabilityExceededReason, schedulerinterface.BatchSchedulingRejectedReason(err))
+	podGroup, err = getPodGroup(rayCluster)
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), podGroup.Spec.MinMember)
+
+	// The PodGroup is deleted with the RayCluster.
+	require.NoError(t, scheduler.CleanupOnDeletion(ctx, rayCluster))
+	_, err = getPodGroup(rayCluster)
+	assert.True(t, errors.IsNotFound(err))
+	require.NoError(t, scheduler.CleanupOnDeletion(ctx, rayCluster))
+}
@@ -107,6 +107,11 @@ func (y *YuniKornScheduler) populateTaskGroupsAnnotationToPod(app *rayv1.RayClus
 		"RayCluster", app.Name, "Namespace", app.Namespace)
 }
 
+func (y *YuniKornScheduler) CleanupOnDeletion(_ context.Context, _ *rayv1.RayCluster) error {
+	// yunikorn doesn't create any resources for the RayCluster
+	return nil
+}
+
 func (yf *YuniKornSchedulerFactory) New(_ *rest.Config) (schedulerinterface.BatchScheduler, error) {
 	return &YuniKornScheduler{
 		log: logf.Log.WithName(SchedulerName),
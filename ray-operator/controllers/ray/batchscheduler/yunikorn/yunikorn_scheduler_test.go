@@ -164,6 +164,18 @@ func TestPopulateGangSchedulingAnnotations(t *testing.T) {
 	assert.Equal(t, resource.MustParse("1"), workerGroup.MinResource["nvidia.com/gpu"])
 }
 
+func TestNewTaskGroupsFromAppMultiHost(t *testing.T) {
+	rayCluster := createRayClusterWithLabels("ray-cluster-multi-host", "test", nil)
+	addHeadPodSpec(rayCluster, v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")})
+	addWorkerPodSpec(rayCluster, "tpu-group", 2, 2, 4, v1.ResourceList{"google.com/tpu": resource.MustParse("4")})
+	rayCluster.Spec.WorkerGroupSpecs[0].NumOfHosts = 4
+
+	// every replica of the multi-host worker group is numOfHosts Pods
+	taskGroups := newTaskGroupsFromApp(rayCluster)
+	assert.Equal(t, int32(1), taskGroups.getTaskGroup(utils.RayNodeHeadGroupLabelValue).MinMember)
+	assert.Equal(t, int32(8), taskGroups.getTaskGroup("tpu-group").MinMember)
+}
+
 func createRayClusterWithLabels(name string, namespace string, labels map[string]string) *rayv1.RayCluster {
 	rayCluster := &rayv1.RayCluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -52,11 +52,15 @@ func newTaskGroupsFromApp(app *v1.RayCluster) *TaskGroups {
 	// worker groups
 	for _, workerGroupSpec := range app.Spec.WorkerGroupSpecs {
 		workerMinResource := utils.CalculatePodResource(workerGroupSpec.Template.Spec)
-		minWorkers := workerGroupSpec.MinReplicas
+		// each replica of a multi-host worker group consists of numOfHosts Pods
+		minWorkers := *workerGroupSpec.MinReplicas
+		if workerGroupSpec.NumOfHosts > 1 {
+			minWorkers *= workerGroupSpec.NumOfHosts
+		}
 		taskGroups.addTaskGroup(
 			TaskGroup{
 				Name:         workerGroupSpec.GroupName,
-				MinMember:    *minWorkers,
+				MinMember:    minWorkers,
 				MinResource:  utils.ConvertResourceListToMapString(workerMinResource),
 				NodeSelector: workerGroupSpec.Template.Spec.NodeSelector,
 				Tolerations:  workerGroupSpec.Template.Spec.Tolerations,
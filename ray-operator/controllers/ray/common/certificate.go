@@ -0,0 +1,199 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+const (
+	RayTLSVolumeName        = "ray-tls"
+	RayTLSVolumeMountPath   = "/etc/ray/tls"
+	RayTLSCAVolumeName      = "ray-tls-ca"
+	RayTLSCAVolumeMountPath = "/etc/ray/tls-ca"
+	RayTLSContainerName     = "ray-tls"
+
+	defaultCertificateIssuerKind  = "Issuer"
+	defaultCertificateIssuerGroup = "cert-manager.io"
+
+	// rayTLSSubjectAltNamesEnvKey is the environment variable of the init container that lists the subject
+	// alternative names of the certificate of the Pod in the format of openssl.
+	rayTLSSubjectAltNamesEnvKey = "RAY_TLS_SUBJECT_ALT_NAMES"
+)
+
+// signRayTLSCertificateScript generates the private key of the Ray processes of a Pod, and signs their certificate
+// with the CA of the RayCluster. The certificate is followed by the CA certificate, so that Ray processes verify it
+// with the issuer of the CA.
+var signRayTLSCertificateScript = fmt.Sprintf(`set -e
+cd %[1]s
+openssl req -new -newkey rsa:2048 -nodes -keyout tls.key -subj "/CN=${POD_NAME}" -out tls.csr
+printf "subjectAltName=%%s\nextendedKeyUsage=serverAuth,clientAuth\n" "${%[3]s}" > tls.ext
+openssl x509 -req -in tls.csr -CA %[2]s/tls.crt -CAkey %[2]s/tls.key -set_serial "0x$(openssl rand -hex 16)" -days 365 -extfile tls.ext -out tls.crt
+cat %[2]s/tls.crt >> tls.crt
+cp %[2]s/ca.crt ca.crt
+rm tls.csr tls.ext
+`, RayTLSVolumeMountPath, RayTLSCAVolumeMountPath, rayTLSSubjectAltNamesEnvKey)
+
+// CertificateGVK is the GroupVersionKind of cert-manager Certificates. The KubeRay operator does not depend on
+// cert-manager, so Certificates are created as unstructured objects from the Certificate type below.
+var CertificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+// Certificate mirrors the subset of the cert-manager Certificate API that the KubeRay operator uses.
+// Reference: https://cert-manager.io/docs/reference/api-docs/#cert-manager.io/v1.Certificate
+type Certificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              CertificateSpec `json:"spec"`
+}
+
+type CertificateSpec struct {
+	IssuerRef  rayv1.CertificateIssuerReference `json:"issuerRef"`
+	SecretName string                           `json:"secretName"`
+	CommonName string                           `json:"commonName,omitempty"`
+	Usages     []string                         `json:"usages,omitempty"`
+	IsCA       bool                             `json:"isCA,omitempty"`
+}
+
+// IsTLSEnabled checks if the RayCluster requests the TLS certificate of its Ray processes from cert-manager.
+func IsTLSEnabled(instance rayv1.RayCluster) bool {
+	return instance.Spec.Security != nil && instance.Spec.Security.TLS != nil && instance.Spec.Security.TLS.Enabled
+}
+
+// GetTLSCASecretName returns the name of the Secret where cert-manager stores the CA certificate of the RayCluster.
+func GetTLSCASecretName(instance rayv1.RayCluster) string {
+	return utils.CheckName(fmt.Sprintf("%s-tls-ca", instance.Name))
+}
+
+// BuildCertificate builds the cert-manager Certificate of the CA of a RayCluster. The init container of each Pod of
+// the RayCluster signs the certificate of its Ray processes with the CA, because the certificate must be valid for the
+// IP of the Pod, which cert-manager does not know.
+func BuildCertificate(instance rayv1.RayCluster) *Certificate {
+	issuerRef := *instance.Spec.Security.TLS.IssuerRef
+	if issuerRef.Kind == "" {
+		issuerRef.Kind = defaultCertificateIssuerKind
+	}
+	if issuerRef.Group == "" {
+		issuerRef.Group = defaultCertificateIssuerGroup
+	}
+
+	name := GetTLSCASecretName(instance)
+	return &Certificate{
+		TypeMeta: metav1.TypeMeta{APIVersion: CertificateGVK.GroupVersion().String(), Kind: CertificateGVK.Kind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: instance.Namespace,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:                instance.Name,
+				utils.KubernetesApplicationNameLabelKey: utils.ApplicationName,
+				utils.KubernetesCreatedByLabelKey:       utils.ComponentName,
+			},
+		},
+		Spec: CertificateSpec{
+			IssuerRef:  issuerRef,
+			SecretName: name,
+			CommonName: name,
+			IsCA:       true,
+			Usages:     []string{"cert sign", "crl sign", "digital signature"},
+		},
+	}
+}
+
+// addRayTLS adds the init container that signs the TLS certificate of the Ray processes of a Pod template, mounts
+// the certificate into the Ray container, and enables TLS between Ray processes. Besides localhost and the IP of the
+// Pod, the certificate of the head Pod is valid for the head service. Environment variables that users set in the
+// Ray container are left unchanged.
+func addRayTLS(ctx context.Context, instance rayv1.RayCluster, podTemplate *corev1.PodTemplateSpec, rayNodeType rayv1.RayNodeType) {
+	if !IsTLSEnabled(instance) {
+		return
+	}
+
+	subjectAltNames := []string{"DNS:localhost", "IP:127.0.0.1", "IP:$(POD_IP)"}
+	if rayNodeType == rayv1.HeadNode {
+		// The name of the head service of a RayCluster is always valid.
+		headSvcName, _ := utils.GenerateHeadServiceName(utils.RayClusterCRD, instance.Spec, instance.Name)
+		for _, dnsName := range []string{
+			headSvcName,
+			fmt.Sprintf("%s.%s", headSvcName, instance.Namespace),
+			fmt.Sprintf("%s.%s.svc", headSvcName, instance.Namespace),
+			utils.GenerateFQDNServiceName(ctx, instance, instance.Namespace),
+		} {
+			subjectAltNames = append(subjectAltNames, "DNS:"+dnsName)
+		}
+	}
+
+	rayContainer := podTemplate.Spec.Containers[utils.RayContainerIndex]
+	tlsContainer := corev1.Container{
+		Name:            RayTLSContainerName,
+		Image:           rayContainer.Image,
+		ImagePullPolicy: rayContainer.ImagePullPolicy,
+		Command:         []string{"/bin/bash", "-c", "--"},
+		Args:            []string{signRayTLSCertificateScript},
+		SecurityContext: rayContainer.SecurityContext.DeepCopy(),
+		Env: []corev1.EnvVar{
+			{Name: "POD_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+			{Name: "POD_IP", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"}}},
+			{Name: rayTLSSubjectAltNamesEnvKey, Value: strings.Join(subjectAltNames, ",")},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: RayTLSVolumeName, MountPath: RayTLSVolumeMountPath},
+			{Name: RayTLSCAVolumeName, MountPath: RayTLSCAVolumeMountPath, ReadOnly: true},
+		},
+		// Signing the certificate takes a moment, so the resources are hard-coded like those of the init container
+		// that waits for the GCS server.
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("200m"),
+				corev1.ResourceMemory: resource.MustParse("256Mi"),
+			},
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("200m"),
+				corev1.ResourceMemory: resource.MustParse("256Mi"),
+			},
+		},
+	}
+	// The certificate is signed before any other init container runs, because they may connect to Ray processes.
+	podTemplate.Spec.InitContainers = append([]corev1.Container{tlsContainer}, podTemplate.Spec.InitContainers...)
+
+	podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes,
+		corev1.Volume{
+			Name:         RayTLSVolumeName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+		},
+		corev1.Volume{
+			Name:         RayTLSCAVolumeName,
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: GetTLSCASecretName(instance)}},
+		},
+	)
+	addRayTLSToContainer(&podTemplate.Spec.Containers[utils.RayContainerIndex])
+}
+
+// addRayTLSToContainer mounts the TLS certificate into a container that connects to Ray processes, and sets the
+// environment variables of Ray TLS authentication.
+func addRayTLSToContainer(container *corev1.Container) {
+	if !checkIfVolumeMounted(container, RayTLSVolumeMountPath) {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      RayTLSVolumeName,
+			MountPath: RayTLSVolumeMountPath,
+			ReadOnly:  true,
+		})
+	}
+	for _, env := range []corev1.EnvVar{
+		{Name: utils.RAY_USE_TLS, Value: "1"},
+		{Name: utils.RAY_TLS_SERVER_CERT, Value: path.Join(RayTLSVolumeMountPath, corev1.TLSCertKey)},
+		{Name: utils.RAY_TLS_SERVER_KEY, Value: path.Join(RayTLSVolumeMountPath, corev1.TLSPrivateKeyKey)},
+		{Name: utils.RAY_TLS_CA_CERT, Value: path.Join(RayTLSVolumeMountPath, "ca.crt")},
+	} {
+		if !utils.EnvVarExists(env.Name, container.Env) {
+			container.Env = append(container.Env, env)
+		}
+	}
+}
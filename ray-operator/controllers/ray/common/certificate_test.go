@@ -0,0 +1,120 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func newTLSTestRayCluster() rayv1.RayCluster {
+	return rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"},
+		Spec: rayv1.RayClusterSpec{
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				RayStartParams: map[string]string{},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head", Image: "rayproject/ray:2.9.0"}}},
+				},
+			},
+			WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+				{
+					GroupName:      "cpu",
+					RayStartParams: map[string]string{},
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{
+							Name:  "ray-worker",
+							Image: "rayproject/ray:2.9.0",
+							Env:   []corev1.EnvVar{{Name: utils.RAY_TLS_CA_CERT, Value: "/etc/ca/ca.crt"}},
+						}}},
+					},
+				},
+			},
+			Security: &rayv1.RayClusterSecurity{
+				TLS: &rayv1.RayClusterTLS{Enabled: true, IssuerRef: &rayv1.CertificateIssuerReference{Name: "ray-ca", Kind: "ClusterIssuer"}},
+			},
+		},
+	}
+}
+
+func TestBuildCertificate(t *testing.T) {
+	cluster := newTLSTestRayCluster()
+
+	certificate := BuildCertificate(cluster)
+	assert.Equal(t, "raycluster-tls-ca", certificate.Name)
+	assert.Equal(t, "default", certificate.Namespace)
+	assert.Equal(t, "raycluster", certificate.Labels[utils.RayClusterLabelKey])
+	assert.Equal(t, "raycluster-tls-ca", certificate.Spec.SecretName)
+	assert.Equal(t, rayv1.CertificateIssuerReference{Name: "ray-ca", Kind: "ClusterIssuer", Group: "cert-manager.io"}, certificate.Spec.IssuerRef)
+	assert.True(t, certificate.Spec.IsCA)
+}
+
+func TestDefaultPodTemplatesWithTLS(t *testing.T) {
+	ctx := context.Background()
+	cluster := newTLSTestRayCluster()
+	cluster.Spec.EnableInTreeAutoscaling = ptr.To(true)
+	expectedVolumes := []corev1.Volume{
+		{
+			Name:         RayTLSVolumeName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+		},
+		{
+			Name:         RayTLSCAVolumeName,
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "raycluster-tls-ca"}},
+		},
+	}
+	expectedVolumeMount := corev1.VolumeMount{Name: RayTLSVolumeName, MountPath: RayTLSVolumeMountPath, ReadOnly: true}
+	envValue := func(container corev1.Container, name string) string {
+		for _, env := range container.Env {
+			if env.Name == name {
+				return env.Value
+			}
+		}
+		return ""
+	}
+
+	headTemplate := DefaultHeadPodTemplate(ctx, cluster, cluster.Spec.HeadGroupSpec, "raycluster-head", "6379")
+	assert.Subset(t, headTemplate.Spec.Volumes, expectedVolumes)
+	// The init container signs the certificate of the head Pod for its IP and the head service.
+	tlsContainer := headTemplate.Spec.InitContainers[0]
+	assert.Equal(t, RayTLSContainerName, tlsContainer.Name)
+	assert.Equal(t, "rayproject/ray:2.9.0", tlsContainer.Image)
+	assert.Equal(t, "DNS:localhost,IP:127.0.0.1,IP:$(POD_IP),DNS:raycluster-head-svc,DNS:raycluster-head-svc.default,"+
+		"DNS:raycluster-head-svc.default.svc,DNS:raycluster-head-svc.default.svc.cluster.local", envValue(tlsContainer, rayTLSSubjectAltNamesEnvKey))
+	assert.Contains(t, tlsContainer.VolumeMounts, corev1.VolumeMount{Name: RayTLSCAVolumeName, MountPath: RayTLSCAVolumeMountPath, ReadOnly: true})
+	for _, container := range headTemplate.Spec.Containers {
+		assert.Contains(t, container.VolumeMounts, expectedVolumeMount, container.Name)
+		// Only the init container mounts the CA.
+		assert.NotContains(t, container.VolumeMounts, corev1.VolumeMount{Name: RayTLSCAVolumeName, MountPath: RayTLSCAVolumeMountPath, ReadOnly: true}, container.Name)
+		assert.Equal(t, "1", envValue(container, utils.RAY_USE_TLS), container.Name)
+		assert.Equal(t, "/etc/ray/tls/tls.crt", envValue(container, utils.RAY_TLS_SERVER_CERT), container.Name)
+		assert.Equal(t, "/etc/ray/tls/tls.key", envValue(container, utils.RAY_TLS_SERVER_KEY), container.Name)
+		assert.Equal(t, "/etc/ray/tls/ca.crt", envValue(container, utils.RAY_TLS_CA_CERT), container.Name)
+	}
+
+	workerTemplate := DefaultWorkerPodTemplate(ctx, cluster, cluster.Spec.WorkerGroupSpecs[0], "raycluster-worker", "raycluster-head-svc.default.svc.cluster.local", "6379")
+	assert.Subset(t, workerTemplate.Spec.Volumes, expectedVolumes)
+	// The certificate of a worker Pod is only valid for its IP, and it is signed before the init container that
+	// waits for the GCS server connects to it with TLS.
+	assert.Equal(t, RayTLSContainerName, workerTemplate.Spec.InitContainers[0].Name)
+	assert.Equal(t, "DNS:localhost,IP:127.0.0.1,IP:$(POD_IP)", envValue(workerTemplate.Spec.InitContainers[0], rayTLSSubjectAltNamesEnvKey))
+	for _, container := range append(workerTemplate.Spec.InitContainers[1:], workerTemplate.Spec.Containers...) {
+		assert.Contains(t, container.VolumeMounts, expectedVolumeMount, container.Name)
+		assert.Equal(t, "1", envValue(container, utils.RAY_USE_TLS), container.Name)
+		// The environment variables that users set are left unchanged.
+		assert.Equal(t, "/etc/ca/ca.crt", envValue(container, utils.RAY_TLS_CA_CERT), container.Name)
+	}
+
+	// The Pod templates of the RayCluster are not modified.
+	assert.Empty(t, cluster.Spec.HeadGroupSpec.Template.Spec.Volumes)
+	assert.Empty(t, cluster.Spec.HeadGroupSpec.Template.Spec.InitContainers)
+	assert.Empty(t, cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Env)
+	assert.Empty(t, cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[utils.RayContainerIndex].VolumeMounts)
+	assert.Len(t, cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[utils.RayContainerIndex].Env, 1)
+}
@@ -0,0 +1,4 @@
+// Package common builds the Kubernetes objects of Ray resources. Its copies of third-party resource types, such as
+// cert-manager Certificates and Prometheus Operator ServiceMonitors, are not KubeRay CRDs.
+// +kubebuilder:skip
+package common
@@ -8,6 +8,7 @@ import (
 
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
@@ -15,6 +16,47 @@ import (
 
 const IngressClassAnnotationKey = "kubernetes.io/ingress.class"
 
+// HTTPRouteGVK is the GroupVersionKind of Gateway API HTTPRoutes. The KubeRay operator does not depend on the Gateway
+// API, so HTTPRoutes are created as unstructured objects from the HTTPRoute type below.
+var HTTPRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+
+// HTTPRoute mirrors the subset of the Gateway API HTTPRoute that the KubeRay operator uses.
+// Reference: https://gateway-api.sigs.k8s.io/reference/spec/#gateway.networking.k8s.io/v1.HTTPRoute
+type HTTPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              HTTPRouteSpec `json:"spec"`
+}
+
+type HTTPRouteSpec struct {
+	ParentRefs []HTTPRouteParentReference `json:"parentRefs,omitempty"`
+	Hostnames  []string                   `json:"hostnames,omitempty"`
+	Rules      []HTTPRouteRule            `json:"rules,omitempty"`
+}
+
+type HTTPRouteParentReference struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace,omitempty"`
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+type HTTPRouteRule struct {
+	BackendRefs []HTTPBackendRef `json:"backendRefs,omitempty"`
+}
+
+type HTTPBackendRef struct {
+	Name string `json:"name"`
+	Port int32  `json:"port"`
+}
+
+// IngressBackends are the services and ports that the Ingress or the HTTPRoutes of HeadIngressOptions route to.
+type IngressBackends struct {
+	DashboardService string
+	ServeService     string
+	DashboardPort    int32
+	ServePort        int32
+}
+
 // BuildIngressForHeadService Builds the ingress for head service dashboard.
 // This is used to expose dashboard for external traffic.
 func BuildIngressForHeadService(ctx context.Context, cluster rayv1.RayCluster) (*networkingv1.Ingress, error) {
@@ -99,3 +141,139 @@ func BuildIngressForHeadService(ctx context.Context, cluster rayv1.RayCluster) (
 
 	return ingress, nil
 }
+
+// GetIngressBackendsForRayCluster routes the Ray dashboard and Ray Serve to the head service of a RayCluster.
+func GetIngressBackendsForRayCluster(cluster rayv1.RayCluster) (IngressBackends, error) {
+	headSvcName, err := utils.GenerateHeadServiceName(utils.RayClusterCRD, cluster.Spec, cluster.Name)
+	if err != nil {
+		return IngressBackends{}, err
+	}
+	backends := IngressBackends{DashboardService: headSvcName, ServeService: headSvcName}
+	backends.DashboardPort, backends.ServePort = getIngressBackendPorts(cluster)
+	return backends, nil
+}
+
+// GetIngressBackendsForRayService routes the Ray dashboard and Ray Serve to the head and serve services of a
+// RayService, which select the Pods of the RayCluster that serves the traffic.
+func GetIngressBackendsForRayService(rayService rayv1.RayService) (IngressBackends, error) {
+	headSvcName, err := utils.GenerateHeadServiceName(utils.RayServiceCRD, rayService.Spec.RayClusterSpec, rayService.Name)
+	if err != nil {
+		return IngressBackends{}, err
+	}
+	backends := IngressBackends{DashboardService: headSvcName, ServeService: utils.GenerateServeServiceName(rayService.Name)}
+	backends.DashboardPort, backends.ServePort = getIngressBackendPorts(rayv1.RayCluster{Spec: rayService.Spec.RayClusterSpec})
+	return backends, nil
+}
+
+func getIngressBackendPorts(cluster rayv1.RayCluster) (dashboardPort int32, servePort int32) {
+	dashboardPort, servePort = utils.DefaultDashboardPort, utils.DefaultServingPort
+	servicePorts := getServicePorts(cluster)
+	if port, ok := servicePorts[utils.DashboardPortName]; ok {
+		dashboardPort = port
+	}
+	if port, ok := servicePorts[utils.ServingPortName]; ok {
+		servePort = port
+	}
+	return dashboardPort, servePort
+}
+
+// BuildManagedIngress builds the Ingress of HeadIngressOptions for the owner named in objectMeta. It routes ServeHost
+// to Ray Serve and DashboardHost to the Ray dashboard.
+func BuildManagedIngress(options rayv1.HeadIngressOptions, objectMeta metav1.ObjectMeta, backends IngressBackends) *networkingv1.Ingress {
+	pathType := networkingv1.PathTypePrefix
+	newRule := func(host string, service string, port int32) networkingv1.IngressRule {
+		return networkingv1.IngressRule{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:     "/",
+						PathType: &pathType,
+						Backend: networkingv1.IngressBackend{
+							Service: &networkingv1.IngressServiceBackend{
+								Name: service,
+								Port: networkingv1.ServiceBackendPort{Number: port},
+							},
+						},
+					}},
+				},
+			},
+		}
+	}
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: *objectMeta.DeepCopy(),
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: options.IngressClassName,
+		},
+	}
+	ingress.Name = utils.CheckName(utils.GenerateIngressName(objectMeta.Name))
+	ingress.Annotations = map[string]string{}
+	for key, value := range options.Annotations {
+		ingress.Annotations[key] = value
+	}
+	var hosts []string
+	// Rules with a host take precedence over a rule without a host, so the order of the rules does not matter.
+	if options.ServeHost != "" {
+		ingress.Spec.Rules = append(ingress.Spec.Rules, newRule(options.ServeHost, backends.ServeService, backends.ServePort))
+		hosts = append(hosts, options.ServeHost)
+	}
+	ingress.Spec.Rules = append(ingress.Spec.Rules, newRule(options.DashboardHost, backends.DashboardService, backends.DashboardPort))
+	if options.DashboardHost != "" {
+		hosts = append(hosts, options.DashboardHost)
+	}
+	if options.TLSSecretName != "" {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: hosts, SecretName: options.TLSSecretName}}
+	}
+	return ingress
+}
+
+// GetDashboardHTTPRouteName returns the name of the HTTPRoute of the Ray dashboard.
+func GetDashboardHTTPRouteName(name string) string {
+	return utils.CheckName(fmt.Sprintf("%s-dashboard", name))
+}
+
+// GetServeHTTPRouteName returns the name of the HTTPRoute of Ray Serve.
+func GetServeHTTPRouteName(name string) string {
+	return utils.CheckName(fmt.Sprintf("%s-serve", name))
+}
+
+// BuildHTTPRoutes builds the HTTPRoutes of HeadIngressOptions for the owner named in objectMeta: one for the Ray dashboard, and one for Ray Serve if
+// ServeHost is set. HTTPRoutes with a host name take precedence over an HTTPRoute without one on the same Gateway.
+func BuildHTTPRoutes(options rayv1.HeadIngressOptions, objectMeta metav1.ObjectMeta, backends IngressBackends) ([]*HTTPRoute, error) {
+	if options.Gateway == nil || options.Gateway.Name == "" {
+		return nil, fmt.Errorf("the gateway of the HTTPRoutes of %s/%s is not set", objectMeta.Namespace, objectMeta.Name)
+	}
+	parentRef := HTTPRouteParentReference{
+		Name:        options.Gateway.Name,
+		Namespace:   options.Gateway.Namespace,
+		SectionName: options.Gateway.SectionName,
+	}
+	newRoute := func(name string, host string, service string, port int32) *HTTPRoute {
+		route := &HTTPRoute{
+			TypeMeta:   metav1.TypeMeta{APIVersion: HTTPRouteGVK.GroupVersion().String(), Kind: HTTPRouteGVK.Kind},
+			ObjectMeta: *objectMeta.DeepCopy(),
+			Spec: HTTPRouteSpec{
+				ParentRefs: []HTTPRouteParentReference{parentRef},
+				Rules:      []HTTPRouteRule{{BackendRefs: []HTTPBackendRef{{Name: service, Port: port}}}},
+			},
+		}
+		route.Name = name
+		route.Annotations = map[string]string{}
+		for key, value := range options.Annotations {
+			route.Annotations[key] = value
+		}
+		if host != "" {
+			route.Spec.Hostnames = []string{host}
+		}
+		return route
+	}
+
+	routes := []*HTTPRoute{
+		newRoute(GetDashboardHTTPRouteName(objectMeta.Name), options.DashboardHost, backends.DashboardService, backends.DashboardPort),
+	}
+	if options.ServeHost != "" {
+		routes = append(routes, newRoute(GetServeHTTPRouteName(objectMeta.Name), options.ServeHost, backends.ServeService, backends.ServePort))
+	}
+	return routes, nil
+}
@@ -10,9 +10,12 @@ import (
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 var instanceWithIngressEnabled = &rayv1.RayCluster{
@@ -131,3 +134,109 @@ func TestBuildIngressForHeadService(t *testing.T) {
 		}
 	}
 }
+
+func TestGetIngressBackends(t *testing.T) {
+	cluster := rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"},
+		Spec: rayv1.RayClusterSpec{
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{
+						Name:  "ray-head",
+						Ports: []corev1.ContainerPort{{Name: utils.DashboardPortName, ContainerPort: 8266}},
+					}}},
+				},
+			},
+		},
+	}
+
+	backends, err := GetIngressBackendsForRayCluster(cluster)
+	require.NoError(t, err)
+	assert.Equal(t, IngressBackends{
+		DashboardService: "raycluster-head-svc",
+		ServeService:     "raycluster-head-svc",
+		DashboardPort:    8266,
+		ServePort:        utils.DefaultServingPort,
+	}, backends)
+
+	rayService := rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayservice", Namespace: "default"},
+		Spec:       rayv1.RayServiceSpec{RayClusterSpec: cluster.Spec},
+	}
+	backends, err = GetIngressBackendsForRayService(rayService)
+	require.NoError(t, err)
+	assert.Equal(t, IngressBackends{
+		DashboardService: "rayservice-head-svc",
+		ServeService:     "rayservice-serve-svc",
+		DashboardPort:    8266,
+		ServePort:        utils.DefaultServingPort,
+	}, backends)
+}
+
+func TestBuildManagedIngress(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{Name: "raycluster", Namespace: "default", Labels: map[string]string{utils.RayClusterLabelKey: "raycluster"}}
+	backends := IngressBackends{DashboardService: "raycluster-head-svc", ServeService: "raycluster-serve-svc", DashboardPort: 8265, ServePort: 8000}
+	options := rayv1.HeadIngressOptions{
+		Annotations:      map[string]string{"nginx.ingress.kubernetes.io/auth-url": "https://auth.example.com/oauth2/auth"},
+		IngressClassName: ptr.To("nginx"),
+		DashboardHost:    "dashboard.example.com",
+		ServeHost:        "serve.example.com",
+		TLSSecretName:    "example-tls",
+	}
+
+	ingress := BuildManagedIngress(options, objectMeta, backends)
+	assert.Equal(t, "raycluster-head-ingress", ingress.Name)
+	assert.Equal(t, "default", ingress.Namespace)
+	assert.Equal(t, objectMeta.Labels, ingress.Labels)
+	assert.Equal(t, options.Annotations, ingress.Annotations)
+	assert.Equal(t, ptr.To("nginx"), ingress.Spec.IngressClassName)
+	require.Len(t, ingress.Spec.Rules, 2)
+	assert.Equal(t, "serve.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, networkingv1.ServiceBackendPort{Number: 8000}, ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port)
+	assert.Equal(t, "raycluster-serve-svc", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+	assert.Equal(t, "dashboard.example.com", ingress.Spec.Rules[1].Host)
+	assert.Equal(t, "raycluster-head-svc", ingress.Spec.Rules[1].HTTP.Paths[0].Backend.Service.Name)
+	assert.Equal(t, []networkingv1.IngressTLS{{Hosts: []string{"serve.example.com", "dashboard.example.com"}, SecretName: "example-tls"}}, ingress.Spec.TLS)
+
+	// Without hosts, only the Ray dashboard is exposed, on all hosts.
+	ingress = BuildManagedIngress(rayv1.HeadIngressOptions{}, objectMeta, backends)
+	require.Len(t, ingress.Spec.Rules, 1)
+	assert.Empty(t, ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "raycluster-head-svc", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+	assert.Nil(t, ingress.Spec.IngressClassName)
+	assert.Empty(t, ingress.Spec.TLS)
+}
+
+func TestBuildHTTPRoutes(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{Name: "rayservice", Namespace: "default"}
+	backends := IngressBackends{DashboardService: "rayservice-head-svc", ServeService: "rayservice-serve-svc", DashboardPort: 8265, ServePort: 8000}
+
+	_, err := BuildHTTPRoutes(rayv1.HeadIngressOptions{Type: rayv1.HTTPRouteIngressType}, objectMeta, backends)
+	require.Error(t, err)
+
+	options := rayv1.HeadIngressOptions{
+		Type:        rayv1.HTTPRouteIngressType,
+		Annotations: map[string]string{"example.com/auth": "oidc"},
+		Gateway:     &rayv1.GatewayReference{Name: "gateway", Namespace: "gateway-system", SectionName: "https"},
+		ServeHost:   "serve.example.com",
+	}
+	routes, err := BuildHTTPRoutes(options, objectMeta, backends)
+	require.NoError(t, err)
+	require.Len(t, routes, 2)
+	parentRefs := []HTTPRouteParentReference{{Name: "gateway", Namespace: "gateway-system", SectionName: "https"}}
+
+	assert.Equal(t, "rayservice-dashboard", routes[0].Name)
+	assert.Equal(t, HTTPRouteGVK, routes[0].GroupVersionKind())
+	assert.Equal(t, options.Annotations, routes[0].Annotations)
+	assert.Equal(t, HTTPRouteSpec{
+		ParentRefs: parentRefs,
+		Rules:      []HTTPRouteRule{{BackendRefs: []HTTPBackendRef{{Name: "rayservice-head-svc", Port: 8265}}}},
+	}, routes[0].Spec)
+
+	assert.Equal(t, "rayservice-serve", routes[1].Name)
+	assert.Equal(t, HTTPRouteSpec{
+		ParentRefs: parentRefs,
+		Hostnames:  []string{"serve.example.com"},
+		Rules:      []HTTPRouteRule{{BackendRefs: []HTTPBackendRef{{Name: "rayservice-serve-svc", Port: 8000}}}},
+	}, routes[1].Spec)
+}
@@ -3,6 +3,7 @@ package common
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	semver "github.com/Masterminds/semver/v3"
@@ -143,3 +144,29 @@ func GetDefaultSubmitterTemplate(rayClusterInstance *rayv1.RayCluster) corev1.Po
 		},
 	}
 }
+
+// AddServiceMeshToSubmitterTemplate makes the submitter wait for the proxy of the service mesh to start, and terminates
+// the proxy once the submitter exits, so that the submitter Pod completes and the Kubernetes Job finishes. The exit
+// code of the submitter is preserved.
+func AddServiceMeshToSubmitterTemplate(submitterTemplate *corev1.PodTemplateSpec, serviceMesh *rayv1.ServiceMeshOptions) {
+	annotations := serviceMeshAwaitProxyAnnotations(serviceMesh.Type)
+	var shutdownURL string
+	switch serviceMesh.Type {
+	case rayv1.IstioServiceMesh:
+		shutdownURL = "http://localhost:15020/quitquitquit"
+	case rayv1.LinkerdServiceMesh:
+		shutdownURL = "http://localhost:4191/shutdown"
+		annotations[utils.LinkerdProxyAdminShutdownAnnotationKey] = "enabled"
+	}
+	setMissingAnnotations(&submitterTemplate.ObjectMeta, annotations)
+
+	submitterContainer := &submitterTemplate.Spec.Containers[utils.RayContainerIndex]
+	command := append(slices.Clone(submitterContainer.Command), submitterContainer.Args...)
+	quotedCommand := make([]string, 0, len(command))
+	for _, arg := range command {
+		quotedCommand = append(quotedCommand, "'"+strings.ReplaceAll(arg, "'", `'\''`)+"'")
+	}
+	submitterContainer.Command = []string{"/bin/bash", "-c", fmt.Sprintf(
+		"%s; exit_code=$?; wget -q -O /dev/null --post-data '' %s; exit $exit_code", strings.Join(quotedCommand, " "), shutdownURL)}
+	submitterContainer.Args = nil
+}
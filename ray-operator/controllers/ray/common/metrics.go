@@ -1,9 +1,15 @@
 package common
 
 import (
+	"context"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 )
 
 // Define all the prometheus counters for all clusters
@@ -36,6 +42,55 @@ var (
 		},
 		[]string{"namespace"},
 	)
+	clustersProvisionedDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ray_operator_clusters_provisioned_duration_seconds",
+			Help:    "The time from the creation of clusters until all of their Pods are ready for the first time",
+			Buckets: prometheus.ExponentialBuckets(5, 2, 10),
+		},
+		[]string{"namespace"},
+	)
+	podsCreatedCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ray_operator_pods_created_total",
+			Help: "Counts number of Pods created for clusters",
+		},
+		[]string{"namespace", "node_type"},
+	)
+	podsDeletedCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ray_operator_pods_deleted_total",
+			Help: "Counts number of Pods deleted from clusters",
+		},
+		[]string{"namespace", "node_type"},
+	)
+	headPodRestartsCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ray_operator_head_pod_restarts_total",
+			Help: "Counts number of head Pods recreated after the previous head Pod of the cluster was gone",
+		},
+		[]string{"namespace"},
+	)
+)
+
+// The gauges of the custom resources are collected from the cache of the KubeRay operator when Prometheus scrapes
+// the metrics, so that they do not depend on which custom resources were reconciled since the operator started.
+var (
+	rayClustersDesc = prometheus.NewDesc(
+		"ray_operator_rayclusters",
+		"Number of RayClusters by state",
+		[]string{"namespace", "state"}, nil,
+	)
+	rayClusterConditionsDesc = prometheus.NewDesc(
+		"ray_operator_raycluster_conditions",
+		"Number of RayClusters by status condition",
+		[]string{"namespace", "condition", "status"}, nil,
+	)
+	rayJobsDesc = prometheus.NewDesc(
+		"ray_operator_rayjobs",
+		"Number of RayJobs by deployment status",
+		[]string{"namespace", "deployment_status"}, nil,
+	)
 )
 
 func init() {
@@ -43,7 +98,11 @@ func init() {
 	metrics.Registry.MustRegister(clustersCreatedCount,
 		clustersDeletedCount,
 		clustersSuccessfulCount,
-		clustersFailedCount)
+		clustersFailedCount,
+		clustersProvisionedDuration,
+		podsCreatedCount,
+		podsDeletedCount,
+		headPodRestartsCount)
 }
 
 func CreatedClustersCounterInc(namespace string) {
@@ -62,3 +121,74 @@ func SuccessfulClustersCounterInc(namespace string) {
 func FailedClustersCounterInc(namespace string) {
 	clustersFailedCount.WithLabelValues(namespace).Inc()
 }
+
+func ObserveClusterProvisionedDuration(namespace string, duration time.Duration) {
+	clustersProvisionedDuration.WithLabelValues(namespace).Observe(duration.Seconds())
+}
+
+func CreatedPodsCounterInc(namespace string, nodeType rayv1.RayNodeType) {
+	podsCreatedCount.WithLabelValues(namespace, string(nodeType)).Inc()
+}
+
+func DeletedPodsCounterAdd(namespace string, nodeType rayv1.RayNodeType, count int) {
+	podsDeletedCount.WithLabelValues(namespace, string(nodeType)).Add(float64(count))
+}
+
+func HeadPodRestartsCounterInc(namespace string) {
+	headPodRestartsCount.WithLabelValues(namespace).Inc()
+}
+
+// RayResourceCollector reports the number of RayClusters by state and status condition, and the number of RayJobs by
+// deployment status.
+type RayResourceCollector struct {
+	reader client.Reader
+}
+
+// NewRayResourceCollector creates a RayResourceCollector that lists the custom resources with the reader, which
+// should read from the cache of the manager.
+func NewRayResourceCollector(reader client.Reader) *RayResourceCollector {
+	return &RayResourceCollector{reader: reader}
+}
+
+func (c *RayResourceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- rayClustersDesc
+	ch <- rayClusterConditionsDesc
+	ch <- rayJobsDesc
+}
+
+func (c *RayResourceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx := context.Background()
+
+	rayClusters := rayv1.RayClusterList{}
+	if err := c.reader.List(ctx, &rayClusters); err != nil {
+		ch <- prometheus.NewInvalidMetric(rayClustersDesc, err)
+	} else {
+		states := map[[2]string]int{}
+		conditions := map[[3]string]int{}
+		for _, rayCluster := range rayClusters.Items {
+			states[[2]string{rayCluster.Namespace, string(rayCluster.Status.State)}]++ //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
+			for _, condition := range rayCluster.Status.Conditions {
+				conditions[[3]string{rayCluster.Namespace, condition.Type, string(condition.Status)}]++
+			}
+		}
+		for labels, count := range states {
+			ch <- prometheus.MustNewConstMetric(rayClustersDesc, prometheus.GaugeValue, float64(count), labels[:]...)
+		}
+		for labels, count := range conditions {
+			ch <- prometheus.MustNewConstMetric(rayClusterConditionsDesc, prometheus.GaugeValue, float64(count), labels[:]...)
+		}
+	}
+
+	rayJobs := rayv1.RayJobList{}
+	if err := c.reader.List(ctx, &rayJobs); err != nil {
+		ch <- prometheus.NewInvalidMetric(rayJobsDesc, err)
+	} else {
+		deploymentStatuses := map[[2]string]int{}
+		for _, rayJob := range rayJobs.Items {
+			deploymentStatuses[[2]string{rayJob.Namespace, string(rayJob.Status.JobDeploymentStatus)}]++
+		}
+		for labels, count := range deploymentStatuses {
+			ch <- prometheus.MustNewConstMetric(rayJobsDesc, prometheus.GaugeValue, float64(count), labels[:]...)
+		}
+	}
+}
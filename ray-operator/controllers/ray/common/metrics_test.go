@@ -0,0 +1,64 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestRayResourceCollector(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	provisioned := metav1.Condition{Type: string(rayv1.RayClusterProvisioned), Status: metav1.ConditionTrue}
+	runtimeObjects := []runtime.Object{
+		&rayv1.RayCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "raycluster-1", Namespace: "default"},
+			Status:     rayv1.RayClusterStatus{State: rayv1.Ready, Conditions: []metav1.Condition{provisioned}}, //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
+		},
+		&rayv1.RayCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "raycluster-2", Namespace: "default"},
+			Status:     rayv1.RayClusterStatus{State: rayv1.Ready, Conditions: []metav1.Condition{provisioned}}, //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
+		},
+		&rayv1.RayCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "raycluster-3", Namespace: "team-a"},
+			Status:     rayv1.RayClusterStatus{State: rayv1.Suspended}, //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
+		},
+		&rayv1.RayJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "rayjob", Namespace: "team-a"},
+			Status:     rayv1.RayJobStatus{JobDeploymentStatus: rayv1.JobDeploymentStatusRunning},
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+
+	expected := `
+# HELP ray_operator_raycluster_conditions Number of RayClusters by status condition
+# TYPE ray_operator_raycluster_conditions gauge
+ray_operator_raycluster_conditions{condition="RayClusterProvisioned",namespace="default",status="True"} 2
+# HELP ray_operator_rayclusters Number of RayClusters by state
+# TYPE ray_operator_rayclusters gauge
+ray_operator_rayclusters{namespace="default",state="ready"} 2
+ray_operator_rayclusters{namespace="team-a",state="suspended"} 1
+# HELP ray_operator_rayjobs Number of RayJobs by deployment status
+# TYPE ray_operator_rayjobs gauge
+ray_operator_rayjobs{deployment_status="Running",namespace="team-a"} 1
+`
+	require.NoError(t, testutil.CollectAndCompare(NewRayResourceCollector(fakeClient), strings.NewReader(expected)))
+}
+
+func TestPodsCounters(t *testing.T) {
+	createdHeadPods := testutil.ToFloat64(podsCreatedCount.WithLabelValues("metrics-test", string(rayv1.HeadNode)))
+	deletedWorkerPods := testutil.ToFloat64(podsDeletedCount.WithLabelValues("metrics-test", string(rayv1.WorkerNode)))
+
+	CreatedPodsCounterInc("metrics-test", rayv1.HeadNode)
+	DeletedPodsCounterAdd("metrics-test", rayv1.WorkerNode, 3)
+	assert.Equal(t, createdHeadPods+1, testutil.ToFloat64(podsCreatedCount.WithLabelValues("metrics-test", string(rayv1.HeadNode))))
+	assert.Equal(t, deletedWorkerPods+3, testutil.ToFloat64(podsDeletedCount.WithLabelValues("metrics-test", string(rayv1.WorkerNode))))
+}
@@ -0,0 +1,133 @@
+package common
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// ServiceMonitorGVK and PodMonitorGVK are the GroupVersionKinds of Prometheus Operator monitors. The KubeRay operator
+// does not depend on the Prometheus Operator, so monitors are created as unstructured objects from the types below.
+var (
+	ServiceMonitorGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}
+	PodMonitorGVK     = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PodMonitor"}
+)
+
+// ServiceMonitor mirrors the subset of the Prometheus Operator ServiceMonitor API that the KubeRay operator uses.
+// Reference: https://prometheus-operator.dev/docs/api-reference/api/#monitoring.coreos.com/v1.ServiceMonitor
+type ServiceMonitor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ServiceMonitorSpec `json:"spec"`
+}
+
+type ServiceMonitorSpec struct {
+	Endpoints []MetricsEndpoint    `json:"endpoints"`
+	Selector  metav1.LabelSelector `json:"selector"`
+}
+
+// PodMonitor mirrors the subset of the Prometheus Operator PodMonitor API that the KubeRay operator uses.
+// Reference: https://prometheus-operator.dev/docs/api-reference/api/#monitoring.coreos.com/v1.PodMonitor
+type PodMonitor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              PodMonitorSpec `json:"spec"`
+}
+
+type PodMonitorSpec struct {
+	PodMetricsEndpoints []MetricsEndpoint    `json:"podMetricsEndpoints"`
+	Selector            metav1.LabelSelector `json:"selector"`
+}
+
+type MetricsEndpoint struct {
+	Port        string          `json:"port"`
+	Interval    string          `json:"interval,omitempty"`
+	Relabelings []RelabelConfig `json:"relabelings,omitempty"`
+}
+
+type RelabelConfig struct {
+	TargetLabel  string   `json:"targetLabel,omitempty"`
+	SourceLabels []string `json:"sourceLabels,omitempty"`
+}
+
+// metricsRelabelings add the labels that the Grafana dashboards of Ray filter the metrics by.
+var metricsRelabelings = []RelabelConfig{
+	{SourceLabels: []string{"__meta_kubernetes_pod_label_ray_io_cluster"}, TargetLabel: "ray_io_cluster"},
+	{SourceLabels: []string{"__meta_kubernetes_pod_label_ray_io_node_type"}, TargetLabel: "ray_io_node_type"},
+	{SourceLabels: []string{"__meta_kubernetes_pod_label_ray_io_group"}, TargetLabel: "ray_io_group"},
+}
+
+// GetHeadServiceMonitorName returns the name of the ServiceMonitor of the metrics of the head Pod.
+func GetHeadServiceMonitorName(cluster rayv1.RayCluster) string {
+	return utils.CheckName(fmt.Sprintf("%s-%s-monitor", cluster.Name, rayv1.HeadNode))
+}
+
+// GetWorkerPodMonitorName returns the name of the PodMonitor of the metrics of the worker Pods.
+func GetWorkerPodMonitorName(cluster rayv1.RayCluster) string {
+	return utils.CheckName(fmt.Sprintf("%s-%s-monitor", cluster.Name, rayv1.WorkerNode))
+}
+
+// BuildServiceMonitorForHeadService builds the ServiceMonitor that scrapes the metrics ports of the head service: the
+// metrics of the Ray head node, and the metrics of the Ray autoscaler and the Ray dashboard if the head service
+// exposes them.
+func BuildServiceMonitorForHeadService(cluster rayv1.RayCluster) *ServiceMonitor {
+	servicePorts := getServicePorts(cluster)
+	var endpoints []MetricsEndpoint
+	for _, portName := range []string{utils.MetricsPortName, utils.AutoscalerMetricsPortName, utils.DashboardMetricsPortName} {
+		if _, ok := servicePorts[portName]; ok {
+			endpoints = append(endpoints, buildMetricsEndpoint(cluster, portName))
+		}
+	}
+	return &ServiceMonitor{
+		TypeMeta:   metav1.TypeMeta{APIVersion: ServiceMonitorGVK.GroupVersion().String(), Kind: ServiceMonitorGVK.Kind},
+		ObjectMeta: buildMonitorObjectMeta(cluster, GetHeadServiceMonitorName(cluster)),
+		Spec: ServiceMonitorSpec{
+			Endpoints: endpoints,
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{
+				utils.RayClusterLabelKey:  cluster.Name,
+				utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+			}},
+		},
+	}
+}
+
+// BuildPodMonitorForWorkerPods builds the PodMonitor that scrapes the metrics port of the worker Pods, which the
+// KubeRay operator adds to every worker Pod.
+func BuildPodMonitorForWorkerPods(cluster rayv1.RayCluster) *PodMonitor {
+	return &PodMonitor{
+		TypeMeta:   metav1.TypeMeta{APIVersion: PodMonitorGVK.GroupVersion().String(), Kind: PodMonitorGVK.Kind},
+		ObjectMeta: buildMonitorObjectMeta(cluster, GetWorkerPodMonitorName(cluster)),
+		Spec: PodMonitorSpec{
+			PodMetricsEndpoints: []MetricsEndpoint{buildMetricsEndpoint(cluster, utils.MetricsPortName)},
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{
+				utils.RayClusterLabelKey:  cluster.Name,
+				utils.RayNodeTypeLabelKey: string(rayv1.WorkerNode),
+			}},
+		},
+	}
+}
+
+func buildMonitorObjectMeta(cluster rayv1.RayCluster, name string) metav1.ObjectMeta {
+	labels := map[string]string{}
+	if options := cluster.Spec.PrometheusMonitors; options != nil {
+		for key, value := range options.Labels {
+			labels[key] = value
+		}
+	}
+	labels[utils.RayClusterLabelKey] = cluster.Name
+	labels[utils.KubernetesApplicationNameLabelKey] = utils.ApplicationName
+	labels[utils.KubernetesCreatedByLabelKey] = utils.ComponentName
+	return metav1.ObjectMeta{Name: name, Namespace: cluster.Namespace, Labels: labels}
+}
+
+func buildMetricsEndpoint(cluster rayv1.RayCluster, portName string) MetricsEndpoint {
+	endpoint := MetricsEndpoint{Port: portName, Relabelings: metricsRelabelings}
+	if options := cluster.Spec.PrometheusMonitors; options != nil {
+		endpoint.Interval = options.Interval
+	}
+	return endpoint
+}
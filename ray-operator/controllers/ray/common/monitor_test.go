@@ -0,0 +1,86 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func newMonitorTestRayCluster() rayv1.RayCluster {
+	return rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"},
+		Spec: rayv1.RayClusterSpec{
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{
+						Name: "ray-head",
+						Ports: []corev1.ContainerPort{
+							{Name: utils.DashboardPortName, ContainerPort: utils.DefaultDashboardPort},
+							{Name: utils.DashboardMetricsPortName, ContainerPort: 44227},
+						},
+					}}},
+				},
+			},
+			PrometheusMonitors: &rayv1.PrometheusMonitorOptions{
+				Enabled:  ptr.To(true),
+				Labels:   map[string]string{"release": "prometheus"},
+				Interval: "30s",
+			},
+		},
+	}
+}
+
+func TestBuildServiceMonitorForHeadService(t *testing.T) {
+	cluster := newMonitorTestRayCluster()
+
+	serviceMonitor := BuildServiceMonitorForHeadService(cluster)
+	assert.Equal(t, "raycluster-head-monitor", serviceMonitor.Name)
+	assert.Equal(t, "default", serviceMonitor.Namespace)
+	assert.Equal(t, ServiceMonitorGVK, serviceMonitor.GroupVersionKind())
+	assert.Equal(t, "prometheus", serviceMonitor.Labels["release"])
+	assert.Equal(t, "raycluster", serviceMonitor.Labels[utils.RayClusterLabelKey])
+	assert.Equal(t, map[string]string{
+		utils.RayClusterLabelKey:  "raycluster",
+		utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+	}, serviceMonitor.Spec.Selector.MatchLabels)
+	// The head service exposes the metrics port by default, and the metrics port of the dashboard that the head
+	// container defines. The autoscaler metrics port is not exposed.
+	require.Len(t, serviceMonitor.Spec.Endpoints, 2)
+	assert.Equal(t, utils.MetricsPortName, serviceMonitor.Spec.Endpoints[0].Port)
+	assert.Equal(t, utils.DashboardMetricsPortName, serviceMonitor.Spec.Endpoints[1].Port)
+	for _, endpoint := range serviceMonitor.Spec.Endpoints {
+		assert.Equal(t, "30s", endpoint.Interval)
+		assert.Contains(t, endpoint.Relabelings, RelabelConfig{
+			SourceLabels: []string{"__meta_kubernetes_pod_label_ray_io_cluster"},
+			TargetLabel:  "ray_io_cluster",
+		})
+	}
+}
+
+func TestBuildPodMonitorForWorkerPods(t *testing.T) {
+	cluster := newMonitorTestRayCluster()
+	cluster.Spec.PrometheusMonitors = nil
+
+	podMonitor := BuildPodMonitorForWorkerPods(cluster)
+	assert.Equal(t, "raycluster-worker-monitor", podMonitor.Name)
+	assert.Equal(t, PodMonitorGVK, podMonitor.GroupVersionKind())
+	assert.NotContains(t, podMonitor.Labels, "release")
+	assert.Equal(t, map[string]string{
+		utils.RayClusterLabelKey:  "raycluster",
+		utils.RayNodeTypeLabelKey: string(rayv1.WorkerNode),
+	}, podMonitor.Spec.Selector.MatchLabels)
+	require.Len(t, podMonitor.Spec.PodMetricsEndpoints, 1)
+	assert.Equal(t, utils.MetricsPortName, podMonitor.Spec.PodMetricsEndpoints[0].Port)
+	assert.Empty(t, podMonitor.Spec.PodMetricsEndpoints[0].Interval)
+	assert.Contains(t, podMonitor.Spec.PodMetricsEndpoints[0].Relabelings, RelabelConfig{
+		SourceLabels: []string{"__meta_kubernetes_pod_label_ray_io_group"},
+		TargetLabel:  "ray_io_group",
+	})
+}
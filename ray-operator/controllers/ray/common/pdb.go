@@ -0,0 +1,60 @@
+package common
+
+import (
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// BuildPodDisruptionBudgetForHeadPod builds the PodDisruptionBudget of the head Pod. It allows no voluntary disruption,
+// because evicting the head Pod kills the GCS and the running jobs of the Ray cluster.
+func BuildPodDisruptionBudgetForHeadPod(cluster rayv1.RayCluster) *policyv1.PodDisruptionBudget {
+	selectorLabels := map[string]string{
+		utils.RayClusterLabelKey:  cluster.Name,
+		utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+	}
+	maxUnavailable := intstr.FromInt32(0)
+	return buildPodDisruptionBudget(cluster, utils.CheckName(fmt.Sprintf("%s-%s-pdb", cluster.Name, rayv1.HeadNode)),
+		selectorLabels, policyv1.PodDisruptionBudgetSpec{MaxUnavailable: &maxUnavailable})
+}
+
+// BuildPodDisruptionBudgetForWorkerGroup builds the PodDisruptionBudget of a worker group from its DisruptionBudget.
+// By default, one worker Pod of the group can be unavailable.
+func BuildPodDisruptionBudgetForWorkerGroup(cluster rayv1.RayCluster, worker rayv1.WorkerGroupSpec) *policyv1.PodDisruptionBudget {
+	selectorLabels := map[string]string{
+		utils.RayClusterLabelKey:   cluster.Name,
+		utils.RayNodeGroupLabelKey: worker.GroupName,
+		utils.RayNodeTypeLabelKey:  string(rayv1.WorkerNode),
+	}
+	spec := policyv1.PodDisruptionBudgetSpec{MaxUnavailable: ptr.To(intstr.FromInt32(1))}
+	if budget := worker.DisruptionBudget; budget != nil && (budget.MaxUnavailable != nil || budget.MinAvailable != nil) {
+		spec = policyv1.PodDisruptionBudgetSpec{MaxUnavailable: budget.MaxUnavailable, MinAvailable: budget.MinAvailable}
+	}
+	return buildPodDisruptionBudget(cluster, utils.CheckName(fmt.Sprintf("%s-%s-%s-pdb", cluster.Name, worker.GroupName, rayv1.WorkerNode)),
+		selectorLabels, spec)
+}
+
+func buildPodDisruptionBudget(cluster rayv1.RayCluster, name string, selectorLabels map[string]string, spec policyv1.PodDisruptionBudgetSpec) *policyv1.PodDisruptionBudget {
+	labels := map[string]string{
+		utils.KubernetesApplicationNameLabelKey: utils.ApplicationName,
+		utils.KubernetesCreatedByLabelKey:       utils.ComponentName,
+	}
+	for key, value := range selectorLabels {
+		labels[key] = value
+	}
+	spec.Selector = &metav1.LabelSelector{MatchLabels: selectorLabels}
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cluster.Namespace,
+			Labels:    labels,
+		},
+		Spec: spec,
+	}
+}
@@ -0,0 +1,76 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestBuildPodDisruptionBudgetForHeadPod(t *testing.T) {
+	cluster := rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"}}
+
+	pdb := BuildPodDisruptionBudgetForHeadPod(cluster)
+	assert.Equal(t, "raycluster-head-pdb", pdb.Name)
+	assert.Equal(t, "default", pdb.Namespace)
+	assert.Equal(t, ptr.To(intstr.FromInt32(0)), pdb.Spec.MaxUnavailable)
+	assert.Nil(t, pdb.Spec.MinAvailable)
+	assert.Equal(t, map[string]string{
+		utils.RayClusterLabelKey:  "raycluster",
+		utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+	}, pdb.Spec.Selector.MatchLabels)
+	assert.Equal(t, "raycluster", pdb.Labels[utils.RayClusterLabelKey])
+	assert.Equal(t, utils.ComponentName, pdb.Labels[utils.KubernetesCreatedByLabelKey])
+}
+
+func TestBuildPodDisruptionBudgetForWorkerGroup(t *testing.T) {
+	cluster := rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"}}
+	tests := []struct {
+		disruptionBudget       *rayv1.WorkerGroupDisruptionBudget
+		expectedMaxUnavailable *intstr.IntOrString
+		expectedMinAvailable   *intstr.IntOrString
+		name                   string
+	}{
+		{
+			name:                   "default disruption budget",
+			expectedMaxUnavailable: ptr.To(intstr.FromInt32(1)),
+		},
+		{
+			name:                   "empty disruption budget",
+			disruptionBudget:       &rayv1.WorkerGroupDisruptionBudget{},
+			expectedMaxUnavailable: ptr.To(intstr.FromInt32(1)),
+		},
+		{
+			name:                   "maxUnavailable",
+			disruptionBudget:       &rayv1.WorkerGroupDisruptionBudget{MaxUnavailable: ptr.To(intstr.FromString("25%"))},
+			expectedMaxUnavailable: ptr.To(intstr.FromString("25%")),
+		},
+		{
+			name:                 "minAvailable",
+			disruptionBudget:     &rayv1.WorkerGroupDisruptionBudget{MinAvailable: ptr.To(intstr.FromInt32(2))},
+			expectedMinAvailable: ptr.To(intstr.FromInt32(2)),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			worker := rayv1.WorkerGroupSpec{
+				GroupName:        "gpu",
+				DisruptionBudget: tc.disruptionBudget,
+			}
+			pdb := BuildPodDisruptionBudgetForWorkerGroup(cluster, worker)
+			assert.Equal(t, "raycluster-gpu-worker-pdb", pdb.Name)
+			assert.Equal(t, tc.expectedMaxUnavailable, pdb.Spec.MaxUnavailable)
+			assert.Equal(t, tc.expectedMinAvailable, pdb.Spec.MinAvailable)
+			assert.Equal(t, map[string]string{
+				utils.RayClusterLabelKey:   "raycluster",
+				utils.RayNodeGroupLabelKey: "gpu",
+				utils.RayNodeTypeLabelKey:  string(rayv1.WorkerNode),
+			}, pdb.Spec.Selector.MatchLabels)
+		})
+	}
+}
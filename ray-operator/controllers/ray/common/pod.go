@@ -3,21 +3,28 @@ package common
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
+	"path"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/ray-project/kuberay/ray-operator/pkg/features"
 
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
 
+	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 
 	corev1 "k8s.io/api/core/v1"
+	resourcev1alpha3 "k8s.io/api/resource/v1alpha3"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -34,14 +41,22 @@ const (
 	// If set to true, kuberay auto injects an init container waiting for ray GCS.
 	// If false, you will need to inject your own init container to ensure ray GCS is up before the ray workers start.
 	EnableInitContainerInjectionEnvKey = "ENABLE_INIT_CONTAINER_INJECTION"
-	NeuronCoreContainerResourceName    = "aws.amazon.com/neuroncore"
-	NeuronCoreRayResourceName          = "neuron_cores"
+	// The CA bundle of a Redis server with TLS is mounted into the head Pod for GCS fault tolerance.
+	RedisCAVolumeName      = "redis-ca"
+	RedisCAVolumeMountPath = "/etc/ray/redis-ca"
+	// The log collection sidecar of spec.logging reads the Ray logs from the Ray log volume and its configuration from
+	// a ConfigMap.
+	LogCollectorContainerName          = "log-collector"
+	LogCollectorConfigVolumeName       = "log-collector-config"
+	DefaultLogCollectorImage           = "fluent/fluent-bit:3.1"
+	DefaultLogCollectorConfigMountPath = "/fluent-bit/etc/"
+	// Objects are spilled to the spill volume of a group instead of the Ray log directory.
+	RaySpillVolumeName      = "ray-spill"
+	RaySpillVolumeMountPath = "/tmp/ray-spill"
+	// The init container of the worker Pods that waits for the head service to resolve and the GCS server to be ready.
+	WaitGcsReadyContainerName = "wait-gcs-ready"
 )
 
-var customAcceleratorToRayResourceMap = map[string]string{
-	NeuronCoreContainerResourceName: NeuronCoreRayResourceName,
-}
-
 // Get the port required to connect to the Ray cluster by worker nodes and drivers
 // started within the cluster.
 // For Ray >= 1.11.0 this is the GCS server port. For Ray < 1.11.0 it is the Redis port.
@@ -57,10 +72,10 @@ func GetHeadPort(headStartParams map[string]string) string {
 	return headPort
 }
 
-// Check if the RayCluster has GCS fault tolerance enabled.
+// Check if the RayCluster has GCS fault tolerance enabled, by the annotation or by GcsFaultToleranceOptions.
 func IsGCSFaultToleranceEnabled(instance rayv1.RayCluster) bool {
 	v, ok := instance.Annotations[utils.RayFTEnabledAnnotationKey]
-	return ok && strings.ToLower(v) == "true"
+	return (ok && strings.ToLower(v) == "true") || instance.Spec.GcsFaultToleranceOptions != nil
 }
 
 // Check if overwrites the container command.
@@ -100,7 +115,7 @@ func DefaultHeadPodTemplate(ctx context.Context, instance rayv1.RayCluster, head
 	// TODO (Dmitri) The argument headPort is essentially unused;
 	// headPort is passed into setMissingRayStartParams but unused there for the head pod.
 	// To mitigate this awkwardness and reduce code redundancy, unify head and worker pod configuration logic.
-	podTemplate := headSpec.Template
+	podTemplate := copyPodTemplate(headSpec.Template)
 	podTemplate.GenerateName = podName
 	// Pods created by RayCluster should be restricted to the namespace of the RayCluster.
 	// This ensures privilege of KubeRay users are contained within the namespace of the RayCluster.
@@ -111,6 +126,10 @@ func DefaultHeadPodTemplate(ctx context.Context, instance rayv1.RayCluster, head
 	}
 	podTemplate.Labels = labelPod(rayv1.HeadNode, instance.Name, utils.RayNodeHeadGroupLabelValue, instance.Spec.HeadGroupSpec.Template.ObjectMeta.Labels)
 	headSpec.RayStartParams = setMissingRayStartParams(ctx, headSpec.RayStartParams, rayv1.HeadNode, headPort, "")
+	addRayResourceClaims(ctx, &podTemplate, headSpec.ResourceClaims, headSpec.RayStartParams)
+	addRayTLS(ctx, instance, &podTemplate, rayv1.HeadNode)
+	addGCSFaultToleranceOptions(instance, &podTemplate, headSpec.RayStartParams)
+	addRayVolumes(&podTemplate, headSpec.LogVolume, headSpec.SpillVolume, false)
 
 	initTemplateAnnotations(instance, &podTemplate)
 
@@ -128,8 +147,24 @@ func DefaultHeadPodTemplate(ctx context.Context, instance rayv1.RayCluster, head
 		autoscalerContainer := BuildAutoscalerContainer(autoscalerImage)
 		// Merge the user overrides from autoscalerOptions into the autoscaler container config.
 		mergeAutoscalerOverrides(&autoscalerContainer, instance.Spec.AutoscalerOptions)
-		podTemplate.Spec.Containers = append(podTemplate.Spec.Containers, autoscalerContainer)
+		// Both the GCS server in the Ray head container and the autoscaler need to run Autoscaler V2.
+		if rayv1.IsAutoscalerV2Enabled(&instance.Spec) {
+			autoscalerV2Env := corev1.EnvVar{Name: utils.RAY_ENABLE_AUTOSCALER_V2, Value: "1"}
+			if !utils.EnvVarExists(utils.RAY_ENABLE_AUTOSCALER_V2, podTemplate.Spec.Containers[utils.RayContainerIndex].Env) {
+				podTemplate.Spec.Containers[utils.RayContainerIndex].Env = append(podTemplate.Spec.Containers[utils.RayContainerIndex].Env, autoscalerV2Env)
+			}
+			if !utils.EnvVarExists(utils.RAY_ENABLE_AUTOSCALER_V2, autoscalerContainer.Env) {
+				autoscalerContainer.Env = append(autoscalerContainer.Env, autoscalerV2Env)
+			}
+		}
+		// The autoscaler connects to the GCS server, so it needs the TLS certificate too.
+		if IsTLSEnabled(instance) {
+			addRayTLSToContainer(&autoscalerContainer)
+		}
+		AddSidecarContainers(&podTemplate, autoscalerContainer)
 	}
+	addLogCollector(instance, &podTemplate)
+	addProbes(ctx, instance, &podTemplate, rayv1.HeadNode, headPort, headSpec.Probes)
 
 	// If the metrics port does not exist in the Ray container, add a default one for Prometheus.
 	isMetricsPortExists := utils.FindContainerPort(&podTemplate.Spec.Containers[utils.RayContainerIndex], utils.MetricsPortName, -1) != -1
@@ -141,6 +176,8 @@ func DefaultHeadPodTemplate(ctx context.Context, instance rayv1.RayCluster, head
 		podTemplate.Spec.Containers[utils.RayContainerIndex].Ports = append(podTemplate.Spec.Containers[utils.RayContainerIndex].Ports, metricsPort)
 	}
 
+	addServiceMesh(instance, &podTemplate, headSpec.RayStartParams, headPort)
+
 	return podTemplate
 }
 
@@ -158,13 +195,21 @@ func getEnableProbesInjection() bool {
 	return true
 }
 
+// copyPodTemplate returns a deep copy of the Pod template of a group. The Pods are built by modifying the copy and the
+// Pod built from it in place, so that none of the slices, maps and pointers of the RayCluster are modified.
+func copyPodTemplate(template corev1.PodTemplateSpec) corev1.PodTemplateSpec {
+	return *template.DeepCopy()
+}
+
 // DefaultWorkerPodTemplate sets the config values
 func DefaultWorkerPodTemplate(ctx context.Context, instance rayv1.RayCluster, workerSpec rayv1.WorkerGroupSpec, podName string, fqdnRayIP string, headPort string) corev1.PodTemplateSpec {
-	podTemplate := workerSpec.Template
+	podTemplate := copyPodTemplate(workerSpec.Template)
 	podTemplate.GenerateName = podName
 	// Pods created by RayCluster should be restricted to the namespace of the RayCluster.
 	// This ensures privilege of KubeRay users are contained within the namespace of the RayCluster.
 	podTemplate.ObjectMeta.Namespace = instance.Namespace
+	// Add TLS before injecting the init container, which inherits the environment variables and volume mounts of the Ray container.
+	addRayTLS(ctx, instance, &podTemplate, rayv1.WorkerNode)
 
 	// The Ray worker should only start once the GCS server is ready.
 	// only inject init container only when ENABLE_INIT_CONTAINER_INJECTION is true
@@ -174,31 +219,11 @@ func DefaultWorkerPodTemplate(ctx context.Context, instance rayv1.RayCluster, wo
 		// Do not modify `deepCopyRayContainer` anywhere.
 		deepCopyRayContainer := podTemplate.Spec.Containers[utils.RayContainerIndex].DeepCopy()
 		initContainer := corev1.Container{
-			Name:            "wait-gcs-ready",
+			Name:            WaitGcsReadyContainerName,
 			Image:           podTemplate.Spec.Containers[utils.RayContainerIndex].Image,
 			ImagePullPolicy: podTemplate.Spec.Containers[utils.RayContainerIndex].ImagePullPolicy,
 			Command:         []string{"/bin/bash", "-lc", "--"},
-			Args: []string{
-				fmt.Sprintf(`
-					SECONDS=0
-					while true; do
-						if (( SECONDS <= 120 )); then
-							if ray health-check --address %s:%s > /dev/null 2>&1; then
-								echo "GCS is ready."
-								break
-							fi
-							echo "$SECONDS seconds elapsed: Waiting for GCS to be ready."
-						else
-							if ray health-check --address %s:%s; then
-								echo "GCS is ready. Any error messages above can be safely ignored."
-								break
-							fi
-							echo "$SECONDS seconds elapsed: Still waiting for GCS to be ready. For troubleshooting, refer to the FAQ at https://github.com/ray-project/kuberay/blob/master/docs/guidance/FAQ.md."
-						fi
-						sleep 5
-					done
-				`, fqdnRayIP, headPort, fqdnRayIP, headPort),
-			},
+			Args:            []string{waitForHeadScript(instance.Spec.WaitForHead, fqdnRayIP, headPort)},
 			SecurityContext: podTemplate.Spec.Containers[utils.RayContainerIndex].SecurityContext.DeepCopy(),
 			// This init container requires certain environment variables to establish a secure connection with the Ray head using TLS authentication.
 			// Additionally, some of these environment variables may reference files stored in volumes, so we need to include both the `Env` and `VolumeMounts` fields here.
@@ -231,6 +256,16 @@ func DefaultWorkerPodTemplate(ctx context.Context, instance rayv1.RayCluster, wo
 	}
 	podTemplate.Labels = labelPod(rayv1.WorkerNode, instance.Name, workerSpec.GroupName, workerSpec.Template.ObjectMeta.Labels)
 	workerSpec.RayStartParams = setMissingRayStartParams(ctx, workerSpec.RayStartParams, rayv1.WorkerNode, headPort, fqdnRayIP)
+	addRayResourceClaims(ctx, &podTemplate, workerSpec.ResourceClaims, workerSpec.RayStartParams)
+	addRayVolumes(&podTemplate, workerSpec.LogVolume, workerSpec.SpillVolume, workerSpec.WorkloadType == rayv1.StatefulSetWorkerGroupWorkloadType)
+	addLogCollector(instance, &podTemplate)
+	addProbes(ctx, instance, &podTemplate, rayv1.WorkerNode, headPort, workerSpec.Probes)
+
+	// Autoscaler V2 identifies a Ray node by its Pod, so a worker Pod must not restart its Ray node in place. The
+	// Pods of a StatefulSet can only restart their containers.
+	if rayv1.IsAutoscalerV2Enabled(&instance.Spec) && podTemplate.Spec.RestartPolicy == "" && workerSpec.WorkloadType != rayv1.StatefulSetWorkerGroupWorkloadType {
+		podTemplate.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
 
 	initTemplateAnnotations(instance, &podTemplate)
 
@@ -244,47 +279,244 @@ func DefaultWorkerPodTemplate(ctx context.Context, instance rayv1.RayCluster, wo
 		podTemplate.Spec.Containers[utils.RayContainerIndex].Ports = append(podTemplate.Spec.Containers[utils.RayContainerIndex].Ports, metricsPort)
 	}
 
+	addServiceMesh(instance, &podTemplate, workerSpec.RayStartParams, headPort)
+
 	return podTemplate
 }
 
-func initLivenessAndReadinessProbe(rayContainer *corev1.Container, rayNodeType rayv1.RayNodeType, creatorCRDType utils.CRDType) {
+// waitForHeadScript returns the script of the wait-gcs-ready init container, which waits for the head service to resolve
+// and for the GCS server to be ready with an exponential backoff. Once it has waited for the timeout, it exits with an
+// exit code and a termination message that tell whether the head service could not be resolved or the GCS server was
+// not ready, so that the KubeRay operator can report why the worker Pod cannot reach the head.
+func waitForHeadScript(options *rayv1.WaitForHeadOptions, fqdnRayIP string, headPort string) string {
+	initialBackoffSeconds := int32(utils.DefaultWaitForHeadInitialBackoffSeconds)
+	maxBackoffSeconds := int32(utils.DefaultWaitForHeadMaxBackoffSeconds)
+	timeoutSeconds := int32(utils.DefaultWaitForHeadTimeoutSeconds)
+	if options != nil {
+		initialBackoffSeconds = ptr.Deref(options.InitialBackoffSeconds, initialBackoffSeconds)
+		maxBackoffSeconds = ptr.Deref(options.MaxBackoffSeconds, maxBackoffSeconds)
+		timeoutSeconds = ptr.Deref(options.TimeoutSeconds, timeoutSeconds)
+	}
+	return fmt.Sprintf(`
+					SECONDS=0
+					backoff=%[3]d
+					while true; do
+						if ! getent hosts %[1]s > /dev/null 2>&1; then
+							exit_code=%[6]d
+							reason="The head service %[1]s cannot be resolved. Check that the head Service exists and that the DNS of the Kubernetes cluster works."
+						elif output=$(ray health-check --address %[1]s:%[2]s 2>&1); then
+							echo "GCS is ready."
+							break
+						else
+							exit_code=%[7]d
+							reason="The GCS server at %[1]s:%[2]s is not ready: ${output##*$'\n'}."
+						fi
+						if (( SECONDS >= %[5]d )); then
+							echo "Gave up waiting for the head after $SECONDS seconds. $reason For troubleshooting, refer to the FAQ at https://github.com/ray-project/kuberay/blob/master/docs/guidance/FAQ.md." | tee /dev/termination-log
+							exit $exit_code
+						fi
+						echo "$SECONDS seconds elapsed: Waiting for the head. $reason Retrying in $backoff seconds."
+						sleep $backoff
+						backoff=$(( backoff * 2 < %[4]d ? backoff * 2 : %[4]d ))
+					done
+				`, fqdnRayIP, headPort, initialBackoffSeconds, maxBackoffSeconds, timeoutSeconds,
+		utils.WaitForHeadUnresolvableExitCode, utils.WaitForHeadGcsNotReadyExitCode)
+}
+
+// GenerateWorkerGroupPodTemplateHash returns the hash of the fields of a worker group spec that its Pods are built from.
+// It must be called before the rayStartParams of the worker group are completed, e.g. by DefaultWorkerPodTemplate.
+func GenerateWorkerGroupPodTemplateHash(workerSpec rayv1.WorkerGroupSpec) (string, error) {
+	return utils.GenerateJsonHash(struct {
+		RayStartParams map[string]string
+		// ResourceClaims is omitted if empty, so that the hash of worker groups without resource claims is unchanged.
+		ResourceClaims []rayv1.RayResourceClaim `json:",omitempty"`
+		// SidecarContainers is omitted if empty for the same reason.
+		SidecarContainers []corev1.Container `json:",omitempty"`
+		Template          corev1.PodTemplateSpec
+	}{
+		RayStartParams:    workerSpec.RayStartParams,
+		ResourceClaims:    workerSpec.ResourceClaims,
+		SidecarContainers: workerSpec.SidecarContainers,
+		Template:          workerSpec.Template,
+	})
+}
+
+// addRayResourceClaims adds the resource claims of a group to the resource claims of its Pods and of their Ray
+// container, and sets num-gpus and the custom resources of the rayStartParams from the Ray resources of the claims.
+func addRayResourceClaims(ctx context.Context, podTemplate *corev1.PodTemplateSpec, claims []rayv1.RayResourceClaim, rayStartParams map[string]string) {
+	if len(claims) == 0 {
+		return
+	}
+	log := ctrl.LoggerFrom(ctx)
+
+	rayContainer := &podTemplate.Spec.Containers[utils.RayContainerIndex]
+	for _, claim := range claims {
+		podTemplate.Spec.ResourceClaims = append(podTemplate.Spec.ResourceClaims, corev1.PodResourceClaim{
+			Name:                      claim.Name,
+			ResourceClaimName:         claim.ResourceClaimName,
+			ResourceClaimTemplateName: claim.ResourceClaimTemplateName,
+		})
+		rayContainer.Resources.Claims = append(rayContainer.Resources.Claims, corev1.ResourceClaim{Name: claim.Name})
+	}
+
+	if err := rayv1.SetRayStartParamsDefaultsFromResourceClaims(rayStartParams, claims); err != nil {
+		log.Error(err, "failed to set the rayStartParams from the Ray resources of the resource claims")
+	}
+}
+
+// deviceClassToRayResource maps the first DNS label of the names of the device classes of accelerators, e.g.
+// gpu.nvidia.com, to the Ray resources of their devices
+var deviceClassToRayResource = map[string]string{
+	"gpu":    "GPU",
+	"tpu":    "TPU",
+	"neuron": "neuron_cores",
+}
+
+// RayResourcesFromDeviceClaim returns the Ray resources of the accelerators that a resource claim allocates. The devices
+// of an allocated claim are counted. Otherwise, the devices of the requests for an exact count of devices are
+// counted, because that is how many devices the claim allocates. Devices of other classes are not Ray resources.
+func RayResourcesFromDeviceClaim(spec resourcev1alpha3.ResourceClaimSpec, allocation *resourcev1alpha3.AllocationResult) map[string]resource.Quantity {
+	numDevices := map[string]int64{}
+	if allocation != nil {
+		for _, result := range allocation.Devices.Results {
+			numDevices[result.Request]++
+		}
+	} else {
+		for _, request := range spec.Devices.Requests {
+			if request.AllocationMode == "" || request.AllocationMode == resourcev1alpha3.DeviceAllocationModeExactCount {
+				numDevices[request.Name] = max(request.Count, 1)
+			}
+		}
+	}
+
+	rayResources := map[string]resource.Quantity{}
+	for _, request := range spec.Devices.Requests {
+		rayResourceName, ok := deviceClassToRayResource[strings.SplitN(request.DeviceClassName, ".", 2)[0]]
+		if !ok || numDevices[request.Name] == 0 {
+			continue
+		}
+		quantity := rayResources[rayResourceName]
+		quantity.Add(*resource.NewQuantity(numDevices[request.Name], resource.DecimalSI))
+		rayResources[rayResourceName] = quantity
+	}
+	return rayResources
+}
+
+// addGCSFaultToleranceOptions sets the Redis address and credentials of the Ray container of the head Pod from the
+// GcsFaultToleranceOptions of the RayCluster, and mounts the CA bundle of a Redis server with TLS.
+func addGCSFaultToleranceOptions(instance rayv1.RayCluster, podTemplate *corev1.PodTemplateSpec, rayStartParams map[string]string) {
+	options := instance.Spec.GcsFaultToleranceOptions
+	if options == nil {
+		return
+	}
+
+	rayContainer := &podTemplate.Spec.Containers[utils.RayContainerIndex]
+	rayContainer.Env = append(rayContainer.Env, corev1.EnvVar{Name: utils.RAY_REDIS_ADDRESS, Value: options.RedisAddress})
+	// The credentials are passed to `ray start` through environment variables, which can read them from Secrets.
+	if options.RedisUsername != nil {
+		rayContainer.Env = append(rayContainer.Env, corev1.EnvVar{
+			Name:      utils.REDIS_USERNAME,
+			Value:     options.RedisUsername.Value,
+			ValueFrom: options.RedisUsername.ValueFrom.DeepCopy(),
+		})
+		rayStartParams["redis-username"] = "$" + utils.REDIS_USERNAME
+	}
+	if options.RedisPassword != nil {
+		rayContainer.Env = append(rayContainer.Env, corev1.EnvVar{
+			Name:      utils.REDIS_PASSWORD,
+			Value:     options.RedisPassword.Value,
+			ValueFrom: options.RedisPassword.ValueFrom.DeepCopy(),
+		})
+		rayStartParams["redis-password"] = "$" + utils.REDIS_PASSWORD
+	}
+	if caBundle := options.RedisCABundle; caBundle != nil {
+		podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, corev1.Volume{
+			Name: RedisCAVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: caBundle.Name,
+					Items:      []corev1.KeyToPath{{Key: caBundle.Key, Path: caBundle.Key}},
+					Optional:   caBundle.Optional,
+				},
+			},
+		})
+		rayContainer.VolumeMounts = append(rayContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      RedisCAVolumeName,
+			MountPath: RedisCAVolumeMountPath,
+			ReadOnly:  true,
+		})
+		rayContainer.Env = append(rayContainer.Env, corev1.EnvVar{Name: utils.RAY_REDIS_CA_CERT, Value: path.Join(RedisCAVolumeMountPath, caBundle.Key)})
+	}
+}
+
+// addProbes injects the probes into the Ray container of the Pod template if the user has not explicitly disabled them.
+// The feature flag `ENABLE_PROBES_INJECTION` will be removed if this feature is stable enough.
+func addProbes(ctx context.Context, instance rayv1.RayCluster, podTemplate *corev1.PodTemplateSpec, rayNodeType rayv1.RayNodeType, headPort string, probes *rayv1.RayProbeOptions) {
+	enableProbesInjection := getEnableProbesInjection()
+	ctrl.LoggerFrom(ctx).Info("Probes injection feature flag", "enabled", enableProbesInjection)
+	if !enableProbesInjection {
+		return
+	}
+
+	// Configure the readiness and liveness probes for the Ray container. These probes
+	// play a crucial role in KubeRay health checks. Without them, certain failures,
+	// such as the Raylet process crashing, may go undetected.
+	creatorCRDType := utils.GetCRDType(instance.Labels[utils.RayOriginatedFromCRDLabelKey])
+	initLivenessAndReadinessProbe(&podTemplate.Spec.Containers[utils.RayContainerIndex], rayNodeType, creatorCRDType, headPort, probes)
+}
+
+// initLivenessAndReadinessProbe sets the liveness and readiness probes of the Ray container, and its startup probe if
+// the probe options configure it, unless the Ray container already has them. The options override the defaults of
+// the timing and the failure thresholds.
+func initLivenessAndReadinessProbe(rayContainer *corev1.Container, rayNodeType rayv1.RayNodeType, creatorCRDType utils.CRDType, headPort string, probes *rayv1.RayProbeOptions) {
+	if probes == nil {
+		probes = &rayv1.RayProbeOptions{}
+	}
 	rayAgentRayletHealthCommand := fmt.Sprintf(
 		utils.BaseWgetHealthCommand,
 		utils.DefaultReadinessProbeTimeoutSeconds,
 		utils.DefaultDashboardAgentListenPort,
 		utils.RayAgentRayletHealthPath,
 	)
-	rayDashboardGCSHealthCommand := fmt.Sprintf(
-		utils.BaseWgetHealthCommand,
-		utils.DefaultReadinessProbeFailureThreshold,
-		utils.DefaultDashboardPort,
-		utils.RayDashboardGCSHealthPath,
-	)
+	rayGCSHealthCommand := fmt.Sprintf(utils.RayHealthCheckCommand, headPort)
 
 	// Generally, the liveness and readiness probes perform the same checks.
 	// For head node => Check GCS and Raylet status.
 	// For worker node => Check Raylet status.
 	commands := []string{}
 	if rayNodeType == rayv1.HeadNode {
-		commands = append(commands, rayAgentRayletHealthCommand, rayDashboardGCSHealthCommand)
+		commands = append(commands, rayAgentRayletHealthCommand, rayGCSHealthCommand)
 	} else {
 		commands = append(commands, rayAgentRayletHealthCommand)
 	}
 
-	if rayContainer.LivenessProbe == nil {
-		probeTimeout := int32(utils.DefaultLivenessProbeTimeoutSeconds)
-		if rayNodeType == rayv1.HeadNode {
-			probeTimeout = int32(utils.DefaultHeadLivenessProbeTimeoutSeconds)
+	livenessProbeTimeout := int32(utils.DefaultLivenessProbeTimeoutSeconds)
+	if rayNodeType == rayv1.HeadNode {
+		livenessProbeTimeout = int32(utils.DefaultHeadLivenessProbeTimeoutSeconds)
+	}
+
+	if rayContainer.StartupProbe == nil && probes.Startup != nil {
+		rayContainer.StartupProbe = &corev1.Probe{
+			InitialDelaySeconds: utils.DefaultStartupProbeInitialDelaySeconds,
+			TimeoutSeconds:      livenessProbeTimeout,
+			PeriodSeconds:       utils.DefaultStartupProbePeriodSeconds,
+			SuccessThreshold:    1,
+			FailureThreshold:    utils.DefaultStartupProbeFailureThreshold,
 		}
+		rayContainer.StartupProbe.Exec = &corev1.ExecAction{Command: []string{"bash", "-c", strings.Join(commands, " && ")}}
+		overrideProbe(rayContainer.StartupProbe, probes.Startup)
+	}
 
+	if rayContainer.LivenessProbe == nil {
 		rayContainer.LivenessProbe = &corev1.Probe{
 			InitialDelaySeconds: utils.DefaultLivenessProbeInitialDelaySeconds,
-			TimeoutSeconds:      probeTimeout,
+			TimeoutSeconds:      livenessProbeTimeout,
 			PeriodSeconds:       utils.DefaultLivenessProbePeriodSeconds,
 			SuccessThreshold:    utils.DefaultLivenessProbeSuccessThreshold,
 			FailureThreshold:    utils.DefaultLivenessProbeFailureThreshold,
 		}
 		rayContainer.LivenessProbe.Exec = &corev1.ExecAction{Command: []string{"bash", "-c", strings.Join(commands, " && ")}}
+		overrideProbe(rayContainer.LivenessProbe, probes.Liveness)
 	}
 
 	if rayContainer.ReadinessProbe == nil {
@@ -315,6 +547,26 @@ func initLivenessAndReadinessProbe(rayContainer *corev1.Container, rayNodeType r
 			commands = append(commands, rayServeProxyHealthCommand)
 			rayContainer.ReadinessProbe.Exec = &corev1.ExecAction{Command: []string{"bash", "-c", strings.Join(commands, " && ")}}
 		}
+		overrideProbe(rayContainer.ReadinessProbe, probes.Readiness)
+	}
+}
+
+// overrideProbe overrides the timing and the failure threshold of a probe with the fields of the options that are set.
+func overrideProbe(probe *corev1.Probe, options *rayv1.RayProbe) {
+	if options == nil {
+		return
+	}
+	if options.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *options.InitialDelaySeconds
+	}
+	if options.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *options.TimeoutSeconds
+	}
+	if options.PeriodSeconds != nil {
+		probe.PeriodSeconds = *options.PeriodSeconds
+	}
+	if options.FailureThreshold != nil {
+		probe.FailureThreshold = *options.FailureThreshold
 	}
 }
 
@@ -349,9 +601,13 @@ func BuildPod(ctx context.Context, podTemplateSpec corev1.PodTemplateSpec, rayNo
 		// We need a shared log volume to enable this information flow.
 		// Specifically, this is required for the event-logging functionality
 		// introduced in https://github.com/ray-project/ray/pull/13434.
-		autoscalerContainerIndex := getAutoscalerContainerIndex(pod)
 		addEmptyDir(ctx, &pod.Spec.Containers[utils.RayContainerIndex], &pod, RayLogVolumeName, RayLogVolumeMountPath, corev1.StorageMediumDefault)
-		addEmptyDir(ctx, &pod.Spec.Containers[autoscalerContainerIndex], &pod, RayLogVolumeName, RayLogVolumeMountPath, corev1.StorageMediumDefault)
+		mountRayLogVolume(&pod, getAutoscalerContainer(&pod), false)
+	}
+	// The log collection sidecar reads the logs that the Ray container writes to the Ray log volume.
+	if logCollector := findContainer(&pod, LogCollectorContainerName); logCollector != nil {
+		addEmptyDir(ctx, &pod.Spec.Containers[utils.RayContainerIndex], &pod, RayLogVolumeName, RayLogVolumeMountPath, corev1.StorageMediumDefault)
+		mountRayLogVolume(&pod, logCollector, true)
 	}
 
 	var cmd, args string
@@ -395,17 +651,6 @@ func BuildPod(ctx context.Context, podTemplateSpec corev1.PodTemplateSpec, rayNo
 	}
 	setContainerEnvVars(&pod, rayNodeType, rayStartParams, fqdnRayIP, headPort, rayStartCmd, creatorCRDType)
 
-	// Inject probes into the Ray containers if the user has not explicitly disabled them.
-	// The feature flag `ENABLE_PROBES_INJECTION` will be removed if this feature is stable enough.
-	enableProbesInjection := getEnableProbesInjection()
-	log.Info("Probes injection feature flag", "enabled", enableProbesInjection)
-	if enableProbesInjection {
-		// Configure the readiness and liveness probes for the Ray container. These probes
-		// play a crucial role in KubeRay health checks. Without them, certain failures,
-		// such as the Raylet process crashing, may go undetected.
-		initLivenessAndReadinessProbe(&pod.Spec.Containers[utils.RayContainerIndex], rayNodeType, creatorCRDType)
-	}
-
 	return pod
 }
 
@@ -502,18 +747,379 @@ func convertCmdToString(cmdArr []string) (cmd string) {
 	return cmdAggr.String()
 }
 
-func getAutoscalerContainerIndex(pod corev1.Pod) (autoscalerContainerIndex int) {
+// AddSidecarContainers adds the sidecar containers to the Pod template after its existing containers. If the
+// NativeSidecarContainers feature gate is enabled, they are added as Kubernetes native sidecar containers, i.e. init
+// containers with restartPolicy Always, so that they start before the Ray container in their order and are stopped
+// after it in the reverse order, and do not keep the Pod running once the Ray container exits.
+func AddSidecarContainers(podTemplate *corev1.PodTemplateSpec, sidecars ...corev1.Container) {
+	if !features.Enabled(features.NativeSidecarContainers) {
+		podTemplate.Spec.Containers = append(podTemplate.Spec.Containers, sidecars...)
+		return
+	}
+	for _, sidecar := range sidecars {
+		sidecar.RestartPolicy = ptr.To(corev1.ContainerRestartPolicyAlways)
+		podTemplate.Spec.InitContainers = append(podTemplate.Spec.InitContainers, sidecar)
+	}
+}
+
+// getAutoscalerContainer returns the autoscaler container of the head Pod, which is a native sidecar container if the
+// NativeSidecarContainers feature gate is enabled.
+func getAutoscalerContainer(pod *corev1.Pod) *corev1.Container {
 	// we identify the autoscaler container based on its name
-	for i, container := range pod.Spec.Containers {
-		if container.Name == AutoscalerContainerName {
-			return i
-		}
+	if container := findContainer(pod, AutoscalerContainerName); container != nil {
+		return container
 	}
 
 	// This should be unreachable.
 	panic("Autoscaler container not found!")
 }
 
+// findContainer returns the container or native sidecar container of the Pod with the name, or nil if there is none.
+func findContainer(pod *corev1.Pod, name string) *corev1.Container {
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == name {
+			return &pod.Spec.Containers[i]
+		}
+	}
+	for i := range pod.Spec.InitContainers {
+		if pod.Spec.InitContainers[i].Name == name {
+			return &pod.Spec.InitContainers[i]
+		}
+	}
+	return nil
+}
+
+// addLogCollector adds the log collection sidecar of spec.logging to the Pod template. The Ray log volume that it
+// shares with the Ray container is added by BuildPod.
+func addLogCollector(instance rayv1.RayCluster, podTemplate *corev1.PodTemplateSpec) {
+	logging := instance.Spec.Logging
+	if logging == nil {
+		return
+	}
+
+	image := DefaultLogCollectorImage
+	if logging.Image != nil {
+		image = *logging.Image
+	}
+	configMountPath := DefaultLogCollectorConfigMountPath
+	if logging.ConfigMountPath != "" {
+		configMountPath = logging.ConfigMountPath
+	}
+	container := corev1.Container{
+		Name:  LogCollectorContainerName,
+		Image: image,
+		Env: []corev1.EnvVar{
+			{
+				Name:      "POD_NAME",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+			},
+			{
+				Name:      "POD_NAMESPACE",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}},
+			},
+			{Name: utils.RAY_CLUSTER_NAME, Value: instance.Name},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: LogCollectorConfigVolumeName, MountPath: configMountPath, ReadOnly: true},
+		},
+	}
+	if logging.Resources != nil {
+		container.Resources = *logging.Resources
+	}
+
+	podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, corev1.Volume{
+		Name: LogCollectorConfigVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: logging.ConfigMapName},
+			},
+		},
+	})
+	AddSidecarContainers(podTemplate, container)
+}
+
+// addServiceMesh annotates the Pod template for the service mesh of the RayCluster. The Ray container waits for the
+// proxy to start, because Ray connects to the GCS server as soon as it starts. The GCS port, which the wait-gcs-ready
+// init container connects to before the proxy starts, and the ports on which the Ray workers connect to each other
+// are excluded from the redirection to the proxy.
+func addServiceMesh(instance rayv1.RayCluster, podTemplate *corev1.PodTemplateSpec, rayStartParams map[string]string, headPort string) {
+	serviceMesh := instance.Spec.ServiceMesh
+	if serviceMesh == nil {
+		return
+	}
+	annotations := serviceMeshAwaitProxyAnnotations(serviceMesh.Type)
+	switch serviceMesh.Type {
+	case rayv1.IstioServiceMesh:
+		// Istio does not support port ranges, so only the ports of the Ray container are redirected to the proxy.
+		var inboundPorts []string
+		for _, port := range podTemplate.Spec.Containers[utils.RayContainerIndex].Ports {
+			if p := strconv.Itoa(int(port.ContainerPort)); p != headPort {
+				inboundPorts = append(inboundPorts, p)
+			}
+		}
+		annotations[utils.IstioIncludeInboundPortsAnnotationKey] = strings.Join(inboundPorts, ",")
+		annotations[utils.IstioExcludeOutboundPortsAnnotationKey] = headPort
+	case rayv1.LinkerdServiceMesh:
+		minWorkerPort, ok := rayStartParams["min-worker-port"]
+		if !ok {
+			minWorkerPort = strconv.Itoa(utils.DefaultMinWorkerPort)
+		}
+		maxWorkerPort, ok := rayStartParams["max-worker-port"]
+		if !ok {
+			maxWorkerPort = strconv.Itoa(utils.DefaultMaxWorkerPort)
+		}
+		skipPorts := fmt.Sprintf("%s,%s-%s", headPort, minWorkerPort, maxWorkerPort)
+		annotations[utils.LinkerdSkipInboundPortsAnnotationKey] = skipPorts
+		annotations[utils.LinkerdSkipOutboundPortsAnnotationKey] = skipPorts
+	}
+	setMissingAnnotations(&podTemplate.ObjectMeta, annotations)
+}
+
+// serviceMeshAwaitProxyAnnotations returns the annotations that make the containers of a Pod wait for the proxy of the
+// service mesh to start.
+func serviceMeshAwaitProxyAnnotations(serviceMeshType rayv1.ServiceMeshType) map[string]string {
+	switch serviceMeshType {
+	case rayv1.IstioServiceMesh:
+		return map[string]string{utils.IstioProxyConfigAnnotationKey: `{"holdApplicationUntilProxyStarts":true}`}
+	case rayv1.LinkerdServiceMesh:
+		return map[string]string{utils.LinkerdProxyAwaitAnnotationKey: "enabled"}
+	}
+	return map[string]string{}
+}
+
+// setMissingAnnotations sets the annotations that the object does not have yet, so that users can override them.
+func setMissingAnnotations(meta *metav1.ObjectMeta, annotations map[string]string) {
+	if meta.Annotations == nil {
+		meta.Annotations = make(map[string]string)
+	}
+	for key, value := range annotations {
+		if _, ok := meta.Annotations[key]; !ok {
+			meta.Annotations[key] = value
+		}
+	}
+}
+
+// AddHeadPlacement keeps a head Pod off the nodes with any of the spot node labels, pins it to the zones if there are
+// any, and prefers the zones without the head Pods of other RayClusters. The node affinity is added to each required
+// node selector term of the Pod template, so that the terms of the Pod template still apply.
+func AddHeadPlacement(pod *corev1.Pod, spotNodeLabels map[string]string, zones []string) {
+	keys := make([]string, 0, len(spotNodeLabels))
+	for key := range spotNodeLabels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var expressions []corev1.NodeSelectorRequirement
+	for _, key := range keys {
+		expressions = append(expressions, corev1.NodeSelectorRequirement{Key: key, Operator: corev1.NodeSelectorOpNotIn, Values: []string{spotNodeLabels[key]}})
+	}
+	if len(zones) > 0 {
+		expressions = append(expressions, corev1.NodeSelectorRequirement{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: zones})
+	}
+
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+	affinity := pod.Spec.Affinity
+	if len(expressions) > 0 {
+		if affinity.NodeAffinity == nil {
+			affinity.NodeAffinity = &corev1.NodeAffinity{}
+		}
+		required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+		if required == nil || len(required.NodeSelectorTerms) == 0 {
+			affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: expressions}},
+			}
+		} else {
+			for i := range required.NodeSelectorTerms {
+				required.NodeSelectorTerms[i].MatchExpressions = append(required.NodeSelectorTerms[i].MatchExpressions, expressions...)
+			}
+		}
+	}
+	if affinity.PodAntiAffinity == nil {
+		affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+	}
+	affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, corev1.WeightedPodAffinityTerm{
+		Weight: 100,
+		PodAffinityTerm: corev1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{
+				utils.RayNodeTypeLabelKey:         string(rayv1.HeadNode),
+				utils.KubernetesCreatedByLabelKey: utils.ComponentName,
+			}},
+			// The head Pods of the RayClusters of all namespaces are spread.
+			NamespaceSelector: &metav1.LabelSelector{},
+			TopologyKey:       corev1.LabelTopologyZone,
+		},
+	})
+}
+
+// AddNodePlacement adds the node selector and tolerations of a node placement to a Pod, and labels the Pod with the
+// capacity type of the nodes, spot or on-demand.
+func AddNodePlacement(pod *corev1.Pod, placement rayv1.NodePlacement, capacityType string) {
+	if len(placement.NodeSelector) > 0 {
+		if pod.Spec.NodeSelector == nil {
+			pod.Spec.NodeSelector = make(map[string]string, len(placement.NodeSelector))
+		}
+		maps.Copy(pod.Spec.NodeSelector, placement.NodeSelector)
+	}
+	if len(placement.Tolerations) > 0 {
+		pod.Spec.Tolerations = append(pod.Spec.Tolerations, placement.Tolerations...)
+	}
+	if pod.Labels == nil {
+		pod.Labels = make(map[string]string)
+	}
+	pod.Labels[utils.RayNodeCapacityTypeLabelKey] = capacityType
+}
+
+// AddPodDefaults adds the labels, environment variables, volumes, volume mounts and image pull secrets of the Pod
+// defaults to a Pod, unless the Pod or its containers already have them, so that the settings of a RayCluster take
+// precedence over the Pod defaults.
+func AddPodDefaults(pod *corev1.Pod, podDefaults configapi.PodDefaults) {
+	if len(podDefaults.Labels) > 0 {
+		if pod.Labels == nil {
+			pod.Labels = make(map[string]string, len(podDefaults.Labels))
+		}
+		for key, value := range podDefaults.Labels {
+			if _, ok := pod.Labels[key]; !ok {
+				pod.Labels[key] = value
+			}
+		}
+	}
+	if len(podDefaults.Env) > 0 || len(podDefaults.VolumeMounts) > 0 {
+		for i := range pod.Spec.InitContainers {
+			addContainerDefaults(&pod.Spec.InitContainers[i], podDefaults)
+		}
+		for i := range pod.Spec.Containers {
+			addContainerDefaults(&pod.Spec.Containers[i], podDefaults)
+		}
+	}
+	if len(podDefaults.Volumes) > 0 {
+		for _, volume := range podDefaults.Volumes {
+			if !slices.ContainsFunc(pod.Spec.Volumes, func(v corev1.Volume) bool { return v.Name == volume.Name }) {
+				pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
+			}
+		}
+	}
+	if len(podDefaults.ImagePullSecrets) > 0 {
+		for _, secret := range podDefaults.ImagePullSecrets {
+			if !slices.Contains(pod.Spec.ImagePullSecrets, secret) {
+				pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets, secret)
+			}
+		}
+	}
+}
+
+// addContainerDefaults adds the environment variables and volume mounts of the Pod defaults to a container, unless
+// it already has an environment variable with the same name or a volume mount at the same path.
+func addContainerDefaults(container *corev1.Container, podDefaults configapi.PodDefaults) {
+	for _, envVar := range podDefaults.Env {
+		if !slices.ContainsFunc(container.Env, func(e corev1.EnvVar) bool { return e.Name == envVar.Name }) {
+			container.Env = append(container.Env, envVar)
+		}
+	}
+	for _, volumeMount := range podDefaults.VolumeMounts {
+		if !slices.ContainsFunc(container.VolumeMounts, func(m corev1.VolumeMount) bool { return m.MountPath == volumeMount.MountPath }) {
+			container.VolumeMounts = append(container.VolumeMounts, volumeMount)
+		}
+	}
+}
+
+// addRayVolumes mounts the log and spill volumes of a group into the Ray container, and points Ray at the spill
+// volume. The PersistentVolumeClaims of volumes with the DeleteWithCluster cleanup policy are named after the Pod, so
+// their claim names are set by BuildRayVolumeClaims. StatefulSet worker groups cannot name the Pods, so their claims
+// are always deleted with the Pods.
+func addRayVolumes(podTemplate *corev1.PodTemplateSpec, logVolume *rayv1.RayVolume, spillVolume *rayv1.RayVolume, isStatefulSet bool) {
+	if logVolume == nil && spillVolume == nil {
+		return
+	}
+
+	rayContainer := &podTemplate.Spec.Containers[utils.RayContainerIndex]
+	for _, volume := range []struct {
+		spec      *rayv1.RayVolume
+		name      string
+		mountPath string
+	}{
+		{spec: logVolume, name: RayLogVolumeName, mountPath: RayLogVolumeMountPath},
+		{spec: spillVolume, name: RaySpillVolumeName, mountPath: RaySpillVolumeMountPath},
+	} {
+		if volume.spec == nil || checkIfVolumeMounted(rayContainer, volume.mountPath) {
+			continue
+		}
+		var source corev1.VolumeSource
+		mount := corev1.VolumeMount{Name: volume.name, MountPath: volume.mountPath}
+		switch {
+		case volume.spec.EmptyDir != nil:
+			source.EmptyDir = volume.spec.EmptyDir.DeepCopy()
+		case volume.spec.HostPath != nil:
+			// The Pods on the same node share the directory of the node, so each Pod mounts a subdirectory named
+			// after the Pod.
+			source.HostPath = volume.spec.HostPath.DeepCopy()
+			mount.SubPathExpr = fmt.Sprintf("$(%s)", utils.RAY_POD_NAME)
+			if !utils.EnvVarExists(utils.RAY_POD_NAME, rayContainer.Env) {
+				rayContainer.Env = append(rayContainer.Env, corev1.EnvVar{
+					Name:      utils.RAY_POD_NAME,
+					ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+				})
+			}
+		case volume.spec.VolumeClaimTemplate != nil && volume.spec.CleanupPolicy == rayv1.DeleteWithClusterCleanupPolicy && !isStatefulSet:
+			source.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{}
+		case volume.spec.VolumeClaimTemplate != nil:
+			source.Ephemeral = &corev1.EphemeralVolumeSource{VolumeClaimTemplate: volume.spec.VolumeClaimTemplate.DeepCopy()}
+		default:
+			continue
+		}
+		podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, corev1.Volume{Name: volume.name, VolumeSource: source})
+		rayContainer.VolumeMounts = append(rayContainer.VolumeMounts, mount)
+	}
+
+	if spillVolume != nil && !utils.EnvVarExists(utils.RAY_OBJECT_SPILLING_CONFIG, rayContainer.Env) {
+		rayContainer.Env = append(rayContainer.Env, corev1.EnvVar{
+			Name:  utils.RAY_OBJECT_SPILLING_CONFIG,
+			Value: fmt.Sprintf(`{"type":"filesystem","params":{"directory_path":"%s"}}`, RaySpillVolumeMountPath),
+		})
+	}
+}
+
+// BuildRayVolumeClaims returns the PersistentVolumeClaims of the log and spill volumes of a Pod with the
+// DeleteWithCluster cleanup policy, which the KubeRay operator creates before the Pod. The claims are named after the
+// Pod, so the Pod is given a name if it only has a generateName.
+func BuildRayVolumeClaims(pod *corev1.Pod, logVolume *rayv1.RayVolume, spillVolume *rayv1.RayVolume) []corev1.PersistentVolumeClaim {
+	var claims []corev1.PersistentVolumeClaim
+	for i := range pod.Spec.Volumes {
+		volume := &pod.Spec.Volumes[i]
+		if volume.PersistentVolumeClaim == nil || volume.PersistentVolumeClaim.ClaimName != "" {
+			continue
+		}
+		var spec *rayv1.RayVolume
+		switch volume.Name {
+		case RayLogVolumeName:
+			spec = logVolume
+		case RaySpillVolumeName:
+			spec = spillVolume
+		}
+		if spec == nil || spec.VolumeClaimTemplate == nil {
+			continue
+		}
+
+		if pod.Name == "" {
+			pod.Name = pod.GenerateName + rand.String(5)
+		}
+		claim := corev1.PersistentVolumeClaim{
+			ObjectMeta: *spec.VolumeClaimTemplate.ObjectMeta.DeepCopy(),
+			Spec:       *spec.VolumeClaimTemplate.Spec.DeepCopy(),
+		}
+		claim.Name = fmt.Sprintf("%s-%s", pod.Name, volume.Name)
+		claim.Namespace = pod.Namespace
+		if claim.Labels == nil {
+			claim.Labels = make(map[string]string)
+		}
+		claim.Labels[utils.RayClusterLabelKey] = pod.Labels[utils.RayClusterLabelKey]
+		claim.Labels[utils.RayNodeGroupLabelKey] = pod.Labels[utils.RayNodeGroupLabelKey]
+		volume.PersistentVolumeClaim.ClaimName = claim.Name
+		claims = append(claims, claim)
+	}
+	return claims
+}
+
 // labelPod returns the labels for selecting the resources
 // belonging to the given RayCluster CR name.
 func labelPod(rayNodeType rayv1.RayNodeType, rayClusterName string, groupName string, labels map[string]string) (ret map[string]string) {
@@ -744,28 +1350,9 @@ func generateRayStartCommand(ctx context.Context, nodeType rayv1.RayNodeType, ra
 	log := ctrl.LoggerFrom(ctx)
 
 	log.Info("generateRayStartCommand", "nodeType", nodeType, "rayStartParams", rayStartParams, "Ray container resource", resource)
-	if _, ok := rayStartParams["num-cpus"]; !ok {
-		cpu := resource.Limits[corev1.ResourceCPU]
-		if !cpu.IsZero() {
-			rayStartParams["num-cpus"] = strconv.FormatInt(cpu.Value(), 10)
-		} else {
-			// Fall back to CPU request if limit is not specified
-			cpu := resource.Requests[corev1.ResourceCPU]
-			if !cpu.IsZero() {
-				rayStartParams["num-cpus"] = strconv.FormatInt(cpu.Value(), 10)
-			}
-		}
-	}
-
-	if _, ok := rayStartParams["memory"]; !ok {
-		memory := resource.Limits[corev1.ResourceMemory]
-		if !memory.IsZero() {
-			rayStartParams["memory"] = strconv.FormatInt(memory.Value(), 10)
-		}
-	}
-
-	// Add GPU and custom accelerator resources to rayStartParams if not already present.
-	if err := addWellKnownAcceleratorResources(rayStartParams, resource.Limits); err != nil {
+	// Set num-cpus, memory, num-gpus, object-store-memory and the custom accelerator resources from the Ray container
+	// resources unless they are set in the rayStartParams.
+	if err := rayv1.SetRayStartParamsDefaultsFromResources(rayStartParams, resource); err != nil {
 		log.Error(err, "failed to add accelerator resources to rayStartParams")
 	}
 
@@ -782,92 +1369,6 @@ func generateRayStartCommand(ctx context.Context, nodeType rayv1.RayNodeType, ra
 	return rayStartCmd
 }
 
-func addWellKnownAcceleratorResources(rayStartParams map[string]string, resourceLimits corev1.ResourceList) error {
-	if len(resourceLimits) == 0 {
-		return nil
-	}
-
-	resourcesMap, err := getResourcesMap(rayStartParams)
-	if err != nil {
-		return fmt.Errorf("failed to get resources map from rayStartParams: %w", err)
-	}
-
-	// Flag to track if any custom accelerator resource are present/added in rayStartParams resources.
-	isCustomAcceleratorResourceAdded := isCustomAcceleratorPresentInResources(resourcesMap)
-
-	// Create a sorted slice of resource keys
-	// Needed for consistent looping and adding first found custom accelerator resource to ray start params
-	sortedResourceKeys := getSortedResourceKeys(resourceLimits)
-
-	for _, resourceKeyString := range sortedResourceKeys {
-		resourceValue := resourceLimits[corev1.ResourceName(resourceKeyString)]
-
-		// Scan for resource keys ending with "gpu" like "nvidia.com/gpu"
-		if _, ok := rayStartParams["num-gpus"]; !ok {
-			if strings.HasSuffix(resourceKeyString, "gpu") && !resourceValue.IsZero() {
-				rayStartParams["num-gpus"] = strconv.FormatInt(resourceValue.Value(), 10)
-			}
-		}
-
-		// Add the first encountered custom accelerator resource from the resource limits to the rayStartParams if not already present
-		if !isCustomAcceleratorResourceAdded {
-			if rayResourceName, ok := customAcceleratorToRayResourceMap[resourceKeyString]; ok && !resourceValue.IsZero() {
-				if _, exists := resourcesMap[rayResourceName]; !exists {
-					resourcesMap[rayResourceName] = resourceValue.AsApproximateFloat64()
-
-					// Update the resources map in the rayStartParams
-					updatedResourcesStr, err := json.Marshal(resourcesMap)
-					if err != nil {
-						return fmt.Errorf("failed to marshal resources map to string: %w", err)
-					}
-
-					rayStartParams["resources"] = fmt.Sprintf("'%s'", updatedResourcesStr)
-				}
-				isCustomAcceleratorResourceAdded = true
-			}
-		}
-	}
-
-	return nil
-}
-
-func isCustomAcceleratorPresentInResources(resourcesMap map[string]float64) bool {
-	// Check whether there exists any custom accelerator resources specified as part of rayStartParams
-	if len(resourcesMap) > 0 {
-		for _, customAcceleratorRayResource := range customAcceleratorToRayResourceMap {
-			if _, ok := resourcesMap[customAcceleratorRayResource]; ok {
-				return true
-			}
-		}
-	}
-
-	return false
-}
-
-func getResourcesMap(rayStartParams map[string]string) (map[string]float64, error) {
-	var resources map[string]float64
-	if resourcesStr, ok := rayStartParams["resources"]; !ok {
-		resources = make(map[string]float64)
-	} else {
-		// Trim any surrounding quotes (single, double, or backticks) and spaces
-		resourcesStr = strings.Trim(resourcesStr, "'\"` ")
-		err := json.Unmarshal([]byte(resourcesStr), &resources)
-		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal resources %w", err)
-		}
-	}
-	return resources, nil
-}
-
-func getSortedResourceKeys(resourceLimits corev1.ResourceList) []string {
-	sortedResourceKeys := make([]string, 0, len(resourceLimits))
-	for resourceKey := range resourceLimits {
-		sortedResourceKeys = append(sortedResourceKeys, string(resourceKey))
-	}
-	sort.Strings(sortedResourceKeys)
-	return sortedResourceKeys
-}
-
 func convertParamMap(rayStartParams map[string]string) (s string) {
 	// Order rayStartParams keys for consistent ray start command flags generation
 	keys := make([]string, 0, len(rayStartParams))
@@ -920,6 +1421,31 @@ func addEmptyDir(ctx context.Context, container *corev1.Container, pod *corev1.P
 	container.VolumeMounts = append(container.VolumeMounts, mountedVolume)
 }
 
+// mountRayLogVolume mounts the volume that the Ray container of a Pod mounts at the log directory, which may be
+// provided by the user, into another container at the same path. A hostPath log volume is mounted at the subdirectory
+// of the Pod, so the container gets the environment variable of the Pod name too.
+func mountRayLogVolume(pod *corev1.Pod, container *corev1.Container, readOnly bool) {
+	if checkIfVolumeMounted(container, RayLogVolumeMountPath) {
+		return
+	}
+	rayContainer := &pod.Spec.Containers[utils.RayContainerIndex]
+	for _, volumeMount := range rayContainer.VolumeMounts {
+		if volumeMount.MountPath != RayLogVolumeMountPath {
+			continue
+		}
+		volumeMount.ReadOnly = readOnly
+		container.VolumeMounts = append(container.VolumeMounts, volumeMount)
+		if volumeMount.SubPathExpr != "" && !utils.EnvVarExists(utils.RAY_POD_NAME, container.Env) {
+			for _, env := range rayContainer.Env {
+				if env.Name == utils.RAY_POD_NAME {
+					container.Env = append(container.Env, env)
+				}
+			}
+		}
+		return
+	}
+}
+
 // Format an emptyDir volume.
 // When the storage medium is memory, set the size limit based on container resources.
 // For other media, don't set a size limit.
@@ -10,16 +10,20 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/ray-project/kuberay/ray-operator/pkg/features"
 
 	corev1 "k8s.io/api/core/v1"
+	resourcev1alpha3 "k8s.io/api/resource/v1alpha3"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 )
 
@@ -384,7 +388,7 @@ func TestBuildPod(t *testing.T) {
 	workerRayStartCommandEnv := getEnvVar(rayContainer, utils.KUBERAY_GEN_RAY_START_CMD)
 	assert.True(t, strings.Contains(workerRayStartCommandEnv.Value, "ray start"))
 
-	expectedCommandArg := splitAndSort("ulimit -n 65536; ray start --block --dashboard-agent-listen-port=52365 --memory=1073741824 --num-cpus=1 --num-gpus=3 --address=raycluster-sample-head-svc.default.svc.cluster.local:6379 --port=6379 --metrics-export-port=8080")
+	expectedCommandArg := splitAndSort("ulimit -n 65536; ray start --block --dashboard-agent-listen-port=52365 --memory=1073741824 --num-cpus=1 --num-gpus=3 --object-store-memory=322122547 --address=raycluster-sample-head-svc.default.svc.cluster.local:6379 --port=6379 --metrics-export-port=8080")
 	actualCommandArg := splitAndSort(pod.Spec.Containers[0].Args[0])
 	if !reflect.DeepEqual(expectedCommandArg, actualCommandArg) {
 		t.Fatalf("Expected `%v` but got `%v`", expectedCommandArg, actualCommandArg)
@@ -424,7 +428,7 @@ func TestBuildPod_WithNoCPULimits(t *testing.T) {
 	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
 	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
 	pod := BuildPod(ctx, podTemplateSpec, rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", nil, utils.GetCRDType(""), "")
-	expectedCommandArg := splitAndSort("ulimit -n 65536; ray start --head --block --dashboard-agent-listen-port=52365 --memory=1073741824 --num-cpus=2 --metrics-export-port=8080 --dashboard-host=0.0.0.0")
+	expectedCommandArg := splitAndSort("ulimit -n 65536; ray start --head --block --dashboard-agent-listen-port=52365 --memory=1073741824 --num-cpus=2 --object-store-memory=322122547 --metrics-export-port=8080 --dashboard-host=0.0.0.0")
 	actualCommandArg := splitAndSort(pod.Spec.Containers[0].Args[0])
 	if !reflect.DeepEqual(expectedCommandArg, actualCommandArg) {
 		t.Fatalf("Expected `%v` but got `%v`", expectedCommandArg, actualCommandArg)
@@ -436,7 +440,7 @@ func TestBuildPod_WithNoCPULimits(t *testing.T) {
 	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
 	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, worker, podName, fqdnRayIP, "6379")
 	pod = BuildPod(ctx, podTemplateSpec, rayv1.WorkerNode, worker.RayStartParams, "6379", nil, utils.GetCRDType(""), fqdnRayIP)
-	expectedCommandArg = splitAndSort("ulimit -n 65536; ray start --block --dashboard-agent-listen-port=52365 --memory=1073741824 --num-cpus=2 --num-gpus=3 --address=raycluster-sample-head-svc.default.svc.cluster.local:6379 --port=6379 --metrics-export-port=8080")
+	expectedCommandArg = splitAndSort("ulimit -n 65536; ray start --block --dashboard-agent-listen-port=52365 --memory=1073741824 --num-cpus=2 --num-gpus=3 --object-store-memory=322122547 --address=raycluster-sample-head-svc.default.svc.cluster.local:6379 --port=6379 --metrics-export-port=8080")
 	actualCommandArg = splitAndSort(pod.Spec.Containers[0].Args[0])
 	if !reflect.DeepEqual(expectedCommandArg, actualCommandArg) {
 		t.Fatalf("Expected `%v` but got `%v`", expectedCommandArg, actualCommandArg)
@@ -522,8 +526,7 @@ func TestBuildPod_WithAutoscalerEnabled(t *testing.T) {
 	if !(numContainers == expectedNumContainers) {
 		t.Fatalf("Expected `%v` container but got `%v`", expectedNumContainers, numContainers)
 	}
-	index := getAutoscalerContainerIndex(pod)
-	actualContainer := pod.Spec.Containers[index]
+	actualContainer := *getAutoscalerContainer(&pod)
 	expectedContainer := autoscalerContainer
 	if !reflect.DeepEqual(expectedContainer, actualContainer) {
 		t.Fatalf("Expected `%v` but got `%v`", expectedContainer, actualContainer)
@@ -699,8 +702,7 @@ func TestBuildPodWithAutoscalerOptions(t *testing.T) {
 	expectedContainer.Env = append(expectedContainer.Env, customEnv...)
 	expectedContainer.VolumeMounts = append(customVolumeMounts, expectedContainer.VolumeMounts...)
 	expectedContainer.SecurityContext = &customSecurityContext
-	index := getAutoscalerContainerIndex(pod)
-	actualContainer := pod.Spec.Containers[index]
+	actualContainer := *getAutoscalerContainer(&pod)
 	if !reflect.DeepEqual(expectedContainer, actualContainer) {
 		t.Fatalf("Expected `%v` but got `%v`", expectedContainer, actualContainer)
 	}
@@ -752,8 +754,7 @@ func TestHeadPodTemplate_AutoscalerImage(t *testing.T) {
 	pod := corev1.Pod{
 		Spec: podTemplateSpec.Spec,
 	}
-	autoscalerContainerIndex := getAutoscalerContainerIndex(pod)
-	assert.Equal(t, expectedAutoscalerImage, podTemplateSpec.Spec.Containers[autoscalerContainerIndex].Image)
+	assert.Equal(t, expectedAutoscalerImage, getAutoscalerContainer(&pod).Image)
 
 	// Case 2: If `AutoscalerOptions.Image` is set, the Autoscaler container should use the specified image.
 	customAutoscalerImage := "custom-autoscaler-xxx"
@@ -764,8 +765,89 @@ func TestHeadPodTemplate_AutoscalerImage(t *testing.T) {
 	}
 	podTemplateSpec = DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
 	pod.Spec = podTemplateSpec.Spec
-	autoscalerContainerIndex = getAutoscalerContainerIndex(pod)
-	assert.Equal(t, customAutoscalerImage, podTemplateSpec.Spec.Containers[autoscalerContainerIndex].Image)
+	assert.Equal(t, customAutoscalerImage, getAutoscalerContainer(&pod).Image)
+}
+
+func TestPodTemplate_AutoscalerV2(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := instance.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = &trueFlag
+	cluster.Spec.AutoscalerOptions = &rayv1.AutoscalerOptions{Version: ptr.To(rayv1.AutoscalerVersionV2)}
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	autoscalerV2Env := corev1.EnvVar{Name: utils.RAY_ENABLE_AUTOSCALER_V2, Value: "1"}
+
+	// Both the Ray head container and the autoscaler container enable autoscaler v2.
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster.DeepCopy(), cluster.Spec.HeadGroupSpec, podName, "6379")
+	pod := corev1.Pod{Spec: podTemplateSpec.Spec}
+	assert.Contains(t, podTemplateSpec.Spec.Containers[utils.RayContainerIndex].Env, autoscalerV2Env)
+	assert.Contains(t, getAutoscalerContainer(&pod).Env, autoscalerV2Env)
+
+	// The worker Pods do not restart their Ray nodes in place, unless the Pod template sets a restart policy.
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, *worker.DeepCopy(), podName, fqdnRayIP, "6379")
+	assert.Equal(t, corev1.RestartPolicyNever, podTemplateSpec.Spec.RestartPolicy)
+	worker.Template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, *worker.DeepCopy(), podName, fqdnRayIP, "6379")
+	assert.Equal(t, corev1.RestartPolicyOnFailure, podTemplateSpec.Spec.RestartPolicy)
+
+	// Autoscaler v1 does not change the Pods.
+	cluster.Spec.AutoscalerOptions.Version = ptr.To(rayv1.AutoscalerVersionV1)
+	podTemplateSpec = DefaultHeadPodTemplate(ctx, *cluster.DeepCopy(), cluster.Spec.HeadGroupSpec, podName, "6379")
+	assert.False(t, utils.EnvVarExists(utils.RAY_ENABLE_AUTOSCALER_V2, podTemplateSpec.Spec.Containers[utils.RayContainerIndex].Env))
+	worker = cluster.Spec.WorkerGroupSpecs[0]
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, *worker.DeepCopy(), podName, fqdnRayIP, "6379")
+	assert.Empty(t, podTemplateSpec.Spec.RestartPolicy)
+}
+
+func TestHeadPodTemplate_NativeSidecarContainers(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.NativeSidecarContainers, true)
+	ctx := context.Background()
+
+	cluster := instance.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = &trueFlag
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+
+	// The autoscaler is injected as a native sidecar container instead of a regular container.
+	assert.Len(t, podTemplateSpec.Spec.Containers, len(cluster.Spec.HeadGroupSpec.Template.Spec.Containers))
+	assert.Len(t, podTemplateSpec.Spec.InitContainers, len(cluster.Spec.HeadGroupSpec.Template.Spec.InitContainers)+1)
+	autoscalerContainer := podTemplateSpec.Spec.InitContainers[len(podTemplateSpec.Spec.InitContainers)-1]
+	assert.Equal(t, AutoscalerContainerName, autoscalerContainer.Name)
+	assert.Equal(t, ptr.To(corev1.ContainerRestartPolicyAlways), autoscalerContainer.RestartPolicy)
+
+	// The autoscaler shares the log volume with the Ray container.
+	pod := BuildPod(ctx, podTemplateSpec, rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", &trueFlag, utils.RayClusterCRD, "")
+	assert.Contains(t, getAutoscalerContainer(&pod).VolumeMounts, corev1.VolumeMount{Name: RayLogVolumeName, MountPath: RayLogVolumeMountPath})
+}
+
+func TestAddSidecarContainers(t *testing.T) {
+	sidecars := []corev1.Container{{Name: "proxy"}, {Name: "log-shipper"}}
+	podTemplate := func() *corev1.PodTemplateSpec {
+		return &corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init"}},
+			Containers:     []corev1.Container{{Name: "ray-head"}},
+		}}
+	}
+
+	// Without the feature gate, the sidecars are appended to the containers.
+	template := podTemplate()
+	AddSidecarContainers(template, sidecars...)
+	assert.Equal(t, []corev1.Container{{Name: "ray-head"}, {Name: "proxy"}, {Name: "log-shipper"}}, template.Spec.Containers)
+	assert.Equal(t, []corev1.Container{{Name: "init"}}, template.Spec.InitContainers)
+
+	// With the feature gate, the sidecars are appended to the init containers in their order and always restarted.
+	features.SetFeatureGateDuringTest(t, features.NativeSidecarContainers, true)
+	template = podTemplate()
+	AddSidecarContainers(template, sidecars...)
+	assert.Equal(t, []corev1.Container{{Name: "ray-head"}}, template.Spec.Containers)
+	assert.Equal(t, []corev1.Container{
+		{Name: "init"},
+		{Name: "proxy", RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways)},
+		{Name: "log-shipper", RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways)},
+	}, template.Spec.InitContainers)
+	assert.Nil(t, sidecars[0].RestartPolicy)
 }
 
 // If no service account is specified in the RayCluster,
@@ -843,6 +925,100 @@ func TestDefaultWorkerPodTemplateWithName(t *testing.T) {
 	assert.Equal(t, worker, expectedWorker)
 }
 
+func TestDefaultWorkerPodTemplateWithResourceClaims(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := instance.DeepCopy()
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	worker.Template.Spec.ResourceClaims = []corev1.PodResourceClaim{{Name: "shared-memory", ResourceClaimName: ptr.To("shm")}}
+	worker.ResourceClaims = []rayv1.RayResourceClaim{
+		{Name: "gpus", ResourceClaimTemplateName: ptr.To("two-gpus"), RayResources: map[string]resource.Quantity{"GPU": resource.MustParse("2")}},
+		{Name: "tpus", ResourceClaimName: ptr.To("tpu-slice"), RayResources: map[string]resource.Quantity{"TPU": resource.MustParse("4")}},
+	}
+	worker.RayStartParams = map[string]string{}
+	podName := cluster.Name + utils.DashSymbol + string(rayv1.WorkerNode) + utils.DashSymbol + worker.GroupName + utils.DashSymbol + utils.FormatInt32(0)
+
+	podTemplateSpec := DefaultWorkerPodTemplate(ctx, *cluster, worker, podName, fqdnRayIP, "6379")
+	assert.Equal(t, []corev1.PodResourceClaim{
+		{Name: "shared-memory", ResourceClaimName: ptr.To("shm")},
+		{Name: "gpus", ResourceClaimTemplateName: ptr.To("two-gpus")},
+		{Name: "tpus", ResourceClaimName: ptr.To("tpu-slice")},
+	}, podTemplateSpec.Spec.ResourceClaims)
+	assert.Equal(t, []corev1.ResourceClaim{{Name: "gpus"}, {Name: "tpus"}}, podTemplateSpec.Spec.Containers[utils.RayContainerIndex].Resources.Claims)
+	assert.Equal(t, "2", worker.RayStartParams["num-gpus"])
+	assert.Equal(t, `'{"TPU":4}'`, worker.RayStartParams["resources"])
+	// The Pod template of the worker group is not modified.
+	assert.Len(t, worker.Template.Spec.ResourceClaims, 1)
+	assert.Empty(t, worker.Template.Spec.Containers[utils.RayContainerIndex].Resources.Claims)
+}
+
+func TestRayResourcesFromDeviceClaim(t *testing.T) {
+	spec := resourcev1alpha3.ResourceClaimSpec{Devices: resourcev1alpha3.DeviceClaim{Requests: []resourcev1alpha3.DeviceRequest{
+		{Name: "gpus", DeviceClassName: "gpu.nvidia.com", Count: 4},
+		{Name: "more-gpus", DeviceClassName: "gpu.example.com"},
+		{Name: "all-tpus", DeviceClassName: "tpu.google.com", AllocationMode: resourcev1alpha3.DeviceAllocationModeAll},
+		{Name: "nics", DeviceClassName: "nic.example.com", Count: 2},
+	}}}
+
+	// The devices of the requests for an exact count of accelerators are counted before the claim is allocated.
+	rayResources := RayResourcesFromDeviceClaim(spec, nil)
+	assert.Len(t, rayResources, 1)
+	gpus := rayResources["GPU"]
+	assert.Equal(t, int64(5), gpus.Value())
+
+	// The allocated devices are counted.
+	allocation := &resourcev1alpha3.AllocationResult{Devices: resourcev1alpha3.DeviceAllocationResult{Results: []resourcev1alpha3.DeviceRequestAllocationResult{
+		{Request: "gpus", Device: "gpu-0"},
+		{Request: "all-tpus", Device: "tpu-0"},
+		{Request: "all-tpus", Device: "tpu-1"},
+		{Request: "nics", Device: "nic-0"},
+	}}}
+	rayResources = RayResourcesFromDeviceClaim(spec, allocation)
+	assert.Len(t, rayResources, 2)
+	gpus, tpus := rayResources["GPU"], rayResources["TPU"]
+	assert.Equal(t, int64(1), gpus.Value())
+	assert.Equal(t, int64(2), tpus.Value())
+}
+
+func TestBuildPodWithGCSFaultToleranceOptions(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := instance.DeepCopy()
+	cluster.Spec.GcsFaultToleranceOptions = &rayv1.GcsFaultToleranceOptions{
+		RedisAddress:  "rediss://redis:6379",
+		RedisUsername: &rayv1.RedisCredential{Value: "ray"},
+		RedisPassword: &rayv1.RedisCredential{ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "redis"}, Key: "password"},
+		}},
+		RedisCABundle: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "redis-tls"}, Key: "ca.crt"},
+	}
+	assert.True(t, IsGCSFaultToleranceEnabled(*cluster))
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	pod := BuildPod(ctx, podTemplateSpec, rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", nil, utils.GetCRDType(""), "")
+
+	rayContainer := pod.Spec.Containers[utils.RayContainerIndex]
+	checkContainerEnv(t, rayContainer, utils.RAY_REDIS_ADDRESS, "rediss://redis:6379")
+	checkContainerEnv(t, rayContainer, utils.REDIS_USERNAME, "ray")
+	assert.Equal(t, cluster.Spec.GcsFaultToleranceOptions.RedisPassword.ValueFrom, getEnvVar(rayContainer, utils.REDIS_PASSWORD).ValueFrom)
+	checkContainerEnv(t, rayContainer, utils.RAY_REDIS_CA_CERT, "/etc/ray/redis-ca/ca.crt")
+	assert.Equal(t, "true", pod.Annotations[utils.RayFTEnabledAnnotationKey])
+	rayStartCommand := getEnvVar(rayContainer, utils.KUBERAY_GEN_RAY_START_CMD).Value
+	assert.Contains(t, rayStartCommand, "--redis-password=$REDIS_PASSWORD")
+	assert.Contains(t, rayStartCommand, "--redis-username=$REDIS_USERNAME")
+
+	assert.Contains(t, pod.Spec.Volumes, corev1.Volume{
+		Name: RedisCAVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: "redis-tls", Items: []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}}},
+		},
+	})
+	assert.Contains(t, rayContainer.VolumeMounts, corev1.VolumeMount{Name: RedisCAVolumeName, MountPath: RedisCAVolumeMountPath, ReadOnly: true})
+	// The Pod template of the head group is not modified.
+	assert.Nil(t, getEnvVar(cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex], utils.RAY_REDIS_ADDRESS))
+}
+
 func containerPortExists(ports []corev1.ContainerPort, containerPort int32) error {
 	name := utils.MetricsPortName
 	for _, port := range ports {
@@ -1180,7 +1356,7 @@ func TestInitLivenessAndReadinessProbe(t *testing.T) {
 
 	rayContainer.LivenessProbe = &httpGetProbe
 	rayContainer.ReadinessProbe = &httpGetProbe
-	initLivenessAndReadinessProbe(rayContainer, rayv1.HeadNode, "")
+	initLivenessAndReadinessProbe(rayContainer, rayv1.HeadNode, "", "6379", nil)
 	assert.NotNil(t, rayContainer.LivenessProbe.HTTPGet)
 	assert.NotNil(t, rayContainer.ReadinessProbe.HTTPGet)
 	assert.Nil(t, rayContainer.LivenessProbe.Exec)
@@ -1191,7 +1367,7 @@ func TestInitLivenessAndReadinessProbe(t *testing.T) {
 	// implying that an additional serve health check will be added to the readiness probe.
 	rayContainer.LivenessProbe = nil
 	rayContainer.ReadinessProbe = nil
-	initLivenessAndReadinessProbe(rayContainer, rayv1.WorkerNode, utils.RayServiceCRD)
+	initLivenessAndReadinessProbe(rayContainer, rayv1.WorkerNode, utils.RayServiceCRD, "6379", nil)
 	assert.NotNil(t, rayContainer.LivenessProbe.Exec)
 	assert.NotNil(t, rayContainer.ReadinessProbe.Exec)
 	assert.False(t, strings.Contains(strings.Join(rayContainer.LivenessProbe.Exec.Command, " "), utils.RayServeProxyHealthPath))
@@ -1204,7 +1380,7 @@ func TestInitLivenessAndReadinessProbe(t *testing.T) {
 	// implying that an additional serve health check will be added to the readiness probe.
 	rayContainer.LivenessProbe = nil
 	rayContainer.ReadinessProbe = nil
-	initLivenessAndReadinessProbe(rayContainer, rayv1.HeadNode, utils.RayServiceCRD)
+	initLivenessAndReadinessProbe(rayContainer, rayv1.HeadNode, utils.RayServiceCRD, "6379", nil)
 	assert.NotNil(t, rayContainer.LivenessProbe.Exec)
 	assert.NotNil(t, rayContainer.ReadinessProbe.Exec)
 	// head pod should not have Ray Serve proxy health probes
@@ -1212,16 +1388,49 @@ func TestInitLivenessAndReadinessProbe(t *testing.T) {
 	assert.False(t, strings.Contains(strings.Join(rayContainer.ReadinessProbe.Exec.Command, " "), utils.RayServeProxyHealthPath))
 	assert.Equal(t, int32(5), rayContainer.LivenessProbe.TimeoutSeconds)
 	assert.Equal(t, int32(5), rayContainer.ReadinessProbe.TimeoutSeconds)
+	// The head Pod checks the GCS server with ray health-check, and has no startup probe by default.
+	assert.Contains(t, rayContainer.LivenessProbe.Exec.Command[2], "ray health-check --address localhost:6379")
+	assert.Nil(t, rayContainer.StartupProbe)
+
+	// Test 4: The probe options add a startup probe and override the thresholds of the injected probes.
+	rayContainer.LivenessProbe = nil
+	rayContainer.ReadinessProbe = nil
+	initLivenessAndReadinessProbe(rayContainer, rayv1.WorkerNode, utils.RayServiceCRD, "6379", &rayv1.RayProbeOptions{
+		Startup:   &rayv1.RayProbe{FailureThreshold: ptr.To[int32](360)},
+		Liveness:  &rayv1.RayProbe{InitialDelaySeconds: ptr.To[int32](0), TimeoutSeconds: ptr.To[int32](10)},
+		Readiness: &rayv1.RayProbe{PeriodSeconds: ptr.To[int32](30), FailureThreshold: ptr.To[int32](3)},
+	})
+	assert.Equal(t, rayContainer.LivenessProbe.Exec, rayContainer.StartupProbe.Exec)
+	assert.Equal(t, int32(utils.DefaultStartupProbePeriodSeconds), rayContainer.StartupProbe.PeriodSeconds)
+	assert.Equal(t, int32(360), rayContainer.StartupProbe.FailureThreshold)
+	assert.Equal(t, int32(0), rayContainer.LivenessProbe.InitialDelaySeconds)
+	assert.Equal(t, int32(10), rayContainer.LivenessProbe.TimeoutSeconds)
+	assert.Equal(t, int32(utils.DefaultLivenessProbeFailureThreshold), rayContainer.LivenessProbe.FailureThreshold)
+	assert.Equal(t, int32(30), rayContainer.ReadinessProbe.PeriodSeconds)
+	// The options take precedence over the failure threshold of the Ray Serve readiness probe.
+	assert.Equal(t, int32(3), rayContainer.ReadinessProbe.FailureThreshold)
+	assert.NotContains(t, rayContainer.LivenessProbe.Exec.Command[2], "ray health-check")
+
+	// Test 5: The probes are injected into the Pod templates of the groups with their probe options.
+	cluster.Spec.WorkerGroupSpecs[0].Probes = &rayv1.RayProbeOptions{Startup: &rayv1.RayProbe{}}
+	workerTemplate := DefaultWorkerPodTemplate(context.Background(), *cluster, cluster.Spec.WorkerGroupSpecs[0], "worker", "", "6379")
+	assert.NotNil(t, workerTemplate.Spec.Containers[utils.RayContainerIndex].StartupProbe)
+	assert.NotNil(t, workerTemplate.Spec.Containers[utils.RayContainerIndex].LivenessProbe)
+	assert.Nil(t, cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[utils.RayContainerIndex].LivenessProbe)
+	os.Setenv(utils.ENABLE_PROBES_INJECTION, "false")
+	defer os.Unsetenv(utils.ENABLE_PROBES_INJECTION)
+	workerTemplate = DefaultWorkerPodTemplate(context.Background(), *cluster, cluster.Spec.WorkerGroupSpecs[0], "worker", "", "6379")
+	assert.Nil(t, workerTemplate.Spec.Containers[utils.RayContainerIndex].StartupProbe)
+	assert.Nil(t, workerTemplate.Spec.Containers[utils.RayContainerIndex].LivenessProbe)
 }
 
 func TestGenerateRayStartCommand(t *testing.T) {
 	tests := []struct {
-		rayStartParams                        map[string]string
-		mockCustomAcceleratorToRayResourceMap map[string]string
-		name                                  string
-		expected                              string
-		nodeType                              rayv1.RayNodeType
-		resource                              corev1.ResourceRequirements
+		rayStartParams map[string]string
+		name           string
+		expected       string
+		nodeType       rayv1.RayNodeType
+		resource       corev1.ResourceRequirements
 	}{
 		{
 			name:           "WorkerNode with GPU",
@@ -1263,17 +1472,37 @@ func TestGenerateRayStartCommand(t *testing.T) {
 			rayStartParams: map[string]string{},
 			resource: corev1.ResourceRequirements{
 				Limits: corev1.ResourceList{
-					"cloud-tpus.google.com/v3":  resource.MustParse("8"),
+					"google.com/tpu":            resource.MustParse("8"),
 					"aws.amazon.com/neuroncore": resource.MustParse("4"),
 					"nvidia.com/gpu":            resource.MustParse("1"),
 				},
 			},
-			mockCustomAcceleratorToRayResourceMap: map[string]string{
-				NeuronCoreContainerResourceName: NeuronCoreRayResourceName,
-				"cloud-tpus.google.com/v3":      "tpu",
-			},
 			expected: `ray start --head  --num-gpus=1  --resources='{"neuron_cores":4}' `,
 		},
+		{
+			name:           "WorkerNode with TPUs",
+			nodeType:       rayv1.WorkerNode,
+			rayStartParams: map[string]string{},
+			resource: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					"google.com/tpu": resource.MustParse("4"),
+				},
+			},
+			expected: `ray start  --resources='{"TPU":4}' `,
+		},
+		{
+			name:           "WorkerNode with CPU, memory and TPUs",
+			nodeType:       rayv1.WorkerNode,
+			rayStartParams: map[string]string{},
+			resource: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("8"),
+					corev1.ResourceMemory: resource.MustParse("10G"),
+					"google.com/tpu":      resource.MustParse("4"),
+				},
+			},
+			expected: `ray start  --memory=10000000000  --num-cpus=8  --object-store-memory=3000000000  --resources='{"TPU":4}' `,
+		},
 		{
 			name:     "HeadNode with existing resources",
 			nodeType: rayv1.HeadNode,
@@ -1324,17 +1553,288 @@ func TestGenerateRayStartCommand(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Mock the customAcceleratorToRayResourceMap with the value specified in the test
-			if tt.mockCustomAcceleratorToRayResourceMap != nil {
-				originalCustomAcceleratorToRayResourceMap := customAcceleratorToRayResourceMap
-				customAcceleratorToRayResourceMap = tt.mockCustomAcceleratorToRayResourceMap
-				defer func() {
-					customAcceleratorToRayResourceMap = originalCustomAcceleratorToRayResourceMap
-				}()
-			}
-
 			result := generateRayStartCommand(context.TODO(), tt.nodeType, tt.rayStartParams, tt.resource)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
 }
+
+func TestLogCollector(t *testing.T) {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	cluster.Spec.Logging = &rayv1.RayClusterLogging{
+		ConfigMapName: "fluent-bit-config",
+		Resources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+		},
+	}
+	headPodName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	workerPodName := cluster.Name + utils.DashSymbol + "worker" + utils.DashSymbol + utils.FormatInt32(0)
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	pods := map[rayv1.RayNodeType]corev1.Pod{
+		rayv1.HeadNode: BuildPod(ctx, DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, headPodName, "6379"),
+			rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", nil, utils.RayClusterCRD, ""),
+		rayv1.WorkerNode: BuildPod(ctx, DefaultWorkerPodTemplate(ctx, *cluster, cluster.Spec.WorkerGroupSpecs[0], workerPodName, fqdnRayIP, "6379"),
+			rayv1.WorkerNode, cluster.Spec.WorkerGroupSpecs[0].RayStartParams, "6379", nil, utils.RayClusterCRD, fqdnRayIP),
+	}
+
+	for nodeType, pod := range pods {
+		logCollector := findContainer(&pod, LogCollectorContainerName)
+		require.NotNil(t, logCollector, nodeType)
+		assert.Equal(t, LogCollectorContainerName, pod.Spec.Containers[len(pod.Spec.Containers)-1].Name, nodeType)
+		assert.Equal(t, DefaultLogCollectorImage, logCollector.Image, nodeType)
+		assert.Equal(t, *cluster.Spec.Logging.Resources, logCollector.Resources, nodeType)
+		checkContainerEnv(t, *logCollector, "POD_NAME", "metadata.name")
+		checkContainerEnv(t, *logCollector, "POD_NAMESPACE", "metadata.namespace")
+		checkContainerEnv(t, *logCollector, utils.RAY_CLUSTER_NAME, cluster.Name)
+
+		// The log collector reads its configuration from the ConfigMap and the logs from the volume of the Ray container.
+		assert.Contains(t, logCollector.VolumeMounts, corev1.VolumeMount{Name: LogCollectorConfigVolumeName, MountPath: DefaultLogCollectorConfigMountPath, ReadOnly: true}, nodeType)
+		assert.Contains(t, logCollector.VolumeMounts, corev1.VolumeMount{Name: RayLogVolumeName, MountPath: RayLogVolumeMountPath, ReadOnly: true}, nodeType)
+		assert.Contains(t, pod.Spec.Containers[utils.RayContainerIndex].VolumeMounts, corev1.VolumeMount{Name: RayLogVolumeName, MountPath: RayLogVolumeMountPath}, nodeType)
+		assert.True(t, checkIfVolumeExists(&pod, RayLogVolumeName), nodeType)
+		assert.Contains(t, pod.Spec.Volumes, corev1.Volume{
+			Name: LogCollectorConfigVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "fluent-bit-config"}},
+			},
+		}, nodeType)
+	}
+	// The Pod templates of the RayCluster are not modified.
+	assert.Equal(t, instance.Spec.HeadGroupSpec.Template.Spec, cluster.Spec.HeadGroupSpec.Template.Spec)
+	assert.Equal(t, instance.Spec.WorkerGroupSpecs[0].Template.Spec, cluster.Spec.WorkerGroupSpecs[0].Template.Spec)
+
+	// The log collector shares a volume that the user mounts at the log directory of the Ray container.
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].VolumeMounts = []corev1.VolumeMount{{Name: "logs", MountPath: RayLogVolumeMountPath}}
+	cluster.Spec.Logging.Image = ptr.To("fluent/fluent-bit:latest")
+	cluster.Spec.Logging.ConfigMountPath = "/etc/fluent-bit"
+	features.SetFeatureGateDuringTest(t, features.NativeSidecarContainers, true)
+	pod := BuildPod(ctx, DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, headPodName, "6379"),
+		rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", nil, utils.RayClusterCRD, "")
+	logCollector := pod.Spec.InitContainers[len(pod.Spec.InitContainers)-1]
+	assert.Equal(t, LogCollectorContainerName, logCollector.Name)
+	assert.Equal(t, ptr.To(corev1.ContainerRestartPolicyAlways), logCollector.RestartPolicy)
+	assert.Equal(t, "fluent/fluent-bit:latest", logCollector.Image)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: LogCollectorConfigVolumeName, MountPath: "/etc/fluent-bit", ReadOnly: true},
+		{Name: "logs", MountPath: RayLogVolumeMountPath, ReadOnly: true},
+	}, logCollector.VolumeMounts)
+	assert.False(t, checkIfVolumeExists(&pod, RayLogVolumeName))
+}
+
+func TestRayVolumes(t *testing.T) {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = ptr.To(true)
+	cluster.Spec.HeadGroupSpec.LogVolume = &rayv1.RayVolume{HostPath: &corev1.HostPathVolumeSource{Path: "/mnt/ray"}}
+	cluster.Spec.HeadGroupSpec.SpillVolume = &rayv1.RayVolume{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: ptr.To(resource.MustParse("10Gi"))}}
+	claimTemplate := &corev1.PersistentVolumeClaimTemplate{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "ray"}},
+		Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: ptr.To("fast")},
+	}
+	cluster.Spec.WorkerGroupSpecs[0].LogVolume = &rayv1.RayVolume{VolumeClaimTemplate: claimTemplate}
+	cluster.Spec.WorkerGroupSpecs[0].SpillVolume = &rayv1.RayVolume{VolumeClaimTemplate: claimTemplate, CleanupPolicy: rayv1.DeleteWithClusterCleanupPolicy}
+
+	// The head Pod mounts the subdirectory of the host path named after the Pod at the log directory, and spills
+	// objects to the emptyDir volume.
+	headPodName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol)
+	podTemplate := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, headPodName, "6379")
+	rayContainer := podTemplate.Spec.Containers[utils.RayContainerIndex]
+	logVolumeMount := corev1.VolumeMount{Name: RayLogVolumeName, MountPath: RayLogVolumeMountPath, SubPathExpr: "$(RAY_POD_NAME)"}
+	podNameEnv := corev1.EnvVar{Name: utils.RAY_POD_NAME, ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}}
+	assert.Contains(t, rayContainer.VolumeMounts, logVolumeMount)
+	assert.Contains(t, rayContainer.Env, podNameEnv)
+	assert.Contains(t, rayContainer.VolumeMounts, corev1.VolumeMount{Name: RaySpillVolumeName, MountPath: RaySpillVolumeMountPath})
+	assert.Contains(t, podTemplate.Spec.Volumes, corev1.Volume{Name: RayLogVolumeName, VolumeSource: corev1.VolumeSource{HostPath: cluster.Spec.HeadGroupSpec.LogVolume.HostPath}})
+	assert.Contains(t, podTemplate.Spec.Volumes, corev1.Volume{Name: RaySpillVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: cluster.Spec.HeadGroupSpec.SpillVolume.EmptyDir}})
+	checkContainerEnv(t, rayContainer, utils.RAY_OBJECT_SPILLING_CONFIG, `{"type":"filesystem","params":{"directory_path":"/tmp/ray-spill"}}`)
+	// The Pod template of the RayCluster is not modified.
+	assert.Equal(t, instance.Spec.HeadGroupSpec.Template.Spec, cluster.Spec.HeadGroupSpec.Template.Spec)
+	pod := BuildPod(ctx, podTemplate, rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", ptr.To(true), utils.RayClusterCRD, "")
+	assert.Empty(t, BuildRayVolumeClaims(&pod, cluster.Spec.HeadGroupSpec.LogVolume, cluster.Spec.HeadGroupSpec.SpillVolume))
+	// The autoscaler writes to the same subdirectory of the host path.
+	assert.Contains(t, getAutoscalerContainer(&pod).VolumeMounts, logVolumeMount)
+	assert.Contains(t, getAutoscalerContainer(&pod).Env, podNameEnv)
+	assert.Empty(t, pod.Name)
+
+	// The log claim of a worker Pod is deleted with the Pod, and the spill claim is named after the Pod.
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	workerPodName := cluster.Name + utils.DashSymbol + "worker" + utils.DashSymbol
+	podTemplate = DefaultWorkerPodTemplate(ctx, *cluster, worker, workerPodName, "", "6379")
+	pod = BuildPod(ctx, podTemplate, rayv1.WorkerNode, worker.RayStartParams, "6379", nil, utils.RayClusterCRD, "")
+	assert.Contains(t, pod.Spec.Volumes, corev1.Volume{Name: RayLogVolumeName, VolumeSource: corev1.VolumeSource{Ephemeral: &corev1.EphemeralVolumeSource{VolumeClaimTemplate: claimTemplate}}})
+	claims := BuildRayVolumeClaims(&pod, worker.LogVolume, worker.SpillVolume)
+	require.Len(t, claims, 1)
+	assert.True(t, strings.HasPrefix(pod.Name, workerPodName))
+	assert.Len(t, pod.Name, len(workerPodName)+5)
+	assert.Equal(t, pod.Name+"-"+RaySpillVolumeName, claims[0].Name)
+	assert.Equal(t, pod.Namespace, claims[0].Namespace)
+	assert.Equal(t, map[string]string{"app": "ray", utils.RayClusterLabelKey: cluster.Name, utils.RayNodeGroupLabelKey: worker.GroupName}, claims[0].Labels)
+	assert.Equal(t, claimTemplate.Spec, claims[0].Spec)
+	assert.Contains(t, pod.Spec.Volumes, corev1.Volume{
+		Name:         RaySpillVolumeName,
+		VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claims[0].Name}},
+	})
+	// The labels of the template are not modified.
+	assert.Equal(t, map[string]string{"app": "ray"}, claimTemplate.Labels)
+
+	// StatefulSet worker groups cannot keep the claims of deleted Pods.
+	worker.WorkloadType = rayv1.StatefulSetWorkerGroupWorkloadType
+	podTemplate = DefaultWorkerPodTemplate(ctx, *cluster, worker, workerPodName, "", "6379")
+	assert.Contains(t, podTemplate.Spec.Volumes, corev1.Volume{Name: RaySpillVolumeName, VolumeSource: corev1.VolumeSource{Ephemeral: &corev1.EphemeralVolumeSource{VolumeClaimTemplate: claimTemplate}}})
+}
+
+func TestServiceMesh(t *testing.T) {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	cluster.Spec.ServiceMesh = &rayv1.ServiceMeshOptions{Type: rayv1.IstioServiceMesh}
+	cluster.Spec.HeadGroupSpec.Template.Annotations = map[string]string{utils.IstioIncludeInboundPortsAnnotationKey: "8265"}
+	headPodName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol)
+	workerPodName := cluster.Name + utils.DashSymbol + "worker" + utils.DashSymbol
+
+	// The annotations of the Pod template take precedence, and the GCS port is not redirected to the Istio proxy.
+	podTemplate := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, headPodName, "6379")
+	assert.Equal(t, `{"holdApplicationUntilProxyStarts":true}`, podTemplate.Annotations[utils.IstioProxyConfigAnnotationKey])
+	assert.Equal(t, "8265", podTemplate.Annotations[utils.IstioIncludeInboundPortsAnnotationKey])
+	assert.Equal(t, "6379", podTemplate.Annotations[utils.IstioExcludeOutboundPortsAnnotationKey])
+	// The annotations of the RayCluster are not modified.
+	assert.NotContains(t, cluster.Spec.HeadGroupSpec.Template.Annotations, utils.IstioProxyConfigAnnotationKey)
+
+	// Only the ports of the Ray container of a worker Pod are redirected to the Istio proxy.
+	podTemplate = DefaultWorkerPodTemplate(ctx, *cluster, cluster.Spec.WorkerGroupSpecs[0], workerPodName, "", "6379")
+	assert.Equal(t, "8080", podTemplate.Annotations[utils.IstioIncludeInboundPortsAnnotationKey])
+	assert.Equal(t, "6379", podTemplate.Annotations[utils.IstioExcludeOutboundPortsAnnotationKey])
+
+	// Linkerd skips the GCS port and the worker ports.
+	cluster.Spec.ServiceMesh.Type = rayv1.LinkerdServiceMesh
+	cluster.Spec.WorkerGroupSpecs[0].RayStartParams["min-worker-port"] = "20000"
+	cluster.Spec.WorkerGroupSpecs[0].RayStartParams["max-worker-port"] = "29999"
+	podTemplate = DefaultWorkerPodTemplate(ctx, *cluster, cluster.Spec.WorkerGroupSpecs[0], workerPodName, "", "6379")
+	assert.Equal(t, "enabled", podTemplate.Annotations[utils.LinkerdProxyAwaitAnnotationKey])
+	assert.Equal(t, "6379,20000-29999", podTemplate.Annotations[utils.LinkerdSkipInboundPortsAnnotationKey])
+	assert.Equal(t, "6379,20000-29999", podTemplate.Annotations[utils.LinkerdSkipOutboundPortsAnnotationKey])
+	assert.NotContains(t, podTemplate.Annotations, utils.IstioProxyConfigAnnotationKey)
+}
+
+func TestWaitForHeadScript(t *testing.T) {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	workerPodName := cluster.Name + utils.DashSymbol + "worker" + utils.DashSymbol
+
+	// The init container waits with the default backoff and gives up after the default timeout.
+	podTemplate := DefaultWorkerPodTemplate(ctx, *cluster, cluster.Spec.WorkerGroupSpecs[0], workerPodName, fqdnRayIP, "6379")
+	initContainer := podTemplate.Spec.InitContainers[len(podTemplate.Spec.InitContainers)-1]
+	assert.Equal(t, WaitGcsReadyContainerName, initContainer.Name)
+	script := initContainer.Args[0]
+	assert.Contains(t, script, "getent hosts "+fqdnRayIP)
+	assert.Contains(t, script, "ray health-check --address "+fqdnRayIP+":6379")
+	assert.Contains(t, script, "backoff=1\n")
+	assert.Contains(t, script, "backoff=$(( backoff * 2 < 30 ? backoff * 2 : 30 ))")
+	assert.Contains(t, script, "if (( SECONDS >= 600 )); then")
+	assert.Contains(t, script, "exit_code=2\n")
+	assert.Contains(t, script, "exit_code=3\n")
+
+	cluster.Spec.WaitForHead = &rayv1.WaitForHeadOptions{
+		InitialBackoffSeconds: ptr.To[int32](2),
+		MaxBackoffSeconds:     ptr.To[int32](60),
+		TimeoutSeconds:        ptr.To[int32](1800),
+	}
+	podTemplate = DefaultWorkerPodTemplate(ctx, *cluster, cluster.Spec.WorkerGroupSpecs[0], workerPodName, fqdnRayIP, "6379")
+	script = podTemplate.Spec.InitContainers[len(podTemplate.Spec.InitContainers)-1].Args[0]
+	assert.Contains(t, script, "backoff=2\n")
+	assert.Contains(t, script, "backoff=$(( backoff * 2 < 60 ? backoff * 2 : 60 ))")
+	assert.Contains(t, script, "if (( SECONDS >= 1800 )); then")
+}
+
+func TestAddNodePlacement(t *testing.T) {
+	cluster := instance.DeepCopy()
+	template := &cluster.Spec.WorkerGroupSpecs[0].Template
+	template.Spec.NodeSelector = map[string]string{"accelerator": "nvidia-l4"}
+	template.Spec.Tolerations = []corev1.Toleration{{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists}}
+	spotToleration := corev1.Toleration{Key: "cloud.google.com/gke-spot", Operator: corev1.TolerationOpEqual, Value: "true", Effect: corev1.TaintEffectNoSchedule}
+
+	// The node placement is added to the node selector and tolerations of the Pod template.
+	pod := corev1.Pod{ObjectMeta: *template.ObjectMeta.DeepCopy(), Spec: *template.Spec.DeepCopy()}
+	AddNodePlacement(&pod, rayv1.NodePlacement{
+		NodeSelector: map[string]string{"cloud.google.com/gke-spot": "true"},
+		Tolerations:  []corev1.Toleration{spotToleration},
+	}, utils.SpotCapacityType)
+	assert.Equal(t, map[string]string{"accelerator": "nvidia-l4", "cloud.google.com/gke-spot": "true"}, pod.Spec.NodeSelector)
+	assert.Equal(t, []corev1.Toleration{template.Spec.Tolerations[0], spotToleration}, pod.Spec.Tolerations)
+	assert.Equal(t, utils.SpotCapacityType, pod.Labels[utils.RayNodeCapacityTypeLabelKey])
+
+	// An empty node placement only labels the Pod.
+	pod = corev1.Pod{ObjectMeta: *template.ObjectMeta.DeepCopy(), Spec: *template.Spec.DeepCopy()}
+	AddNodePlacement(&pod, rayv1.NodePlacement{}, utils.OnDemandCapacityType)
+	assert.Equal(t, template.Spec.NodeSelector, pod.Spec.NodeSelector)
+	assert.Equal(t, template.Spec.Tolerations, pod.Spec.Tolerations)
+	assert.Equal(t, utils.OnDemandCapacityType, pod.Labels[utils.RayNodeCapacityTypeLabelKey])
+}
+
+func TestAddHeadPlacement(t *testing.T) {
+	cluster := instance.DeepCopy()
+	template := &cluster.Spec.HeadGroupSpec.Template
+	gpuTerm := corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "accelerator", Operator: corev1.NodeSelectorOpExists}}}
+	template.Spec.Affinity = &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{gpuTerm}},
+	}}
+	spotNodeLabels := map[string]string{"karpenter.sh/capacity-type": "spot", "cloud.google.com/gke-spot": "true"}
+
+	// The spot node labels and the zones are added to the required node selector terms of the Pod template.
+	pod := corev1.Pod{ObjectMeta: *template.ObjectMeta.DeepCopy(), Spec: *template.Spec.DeepCopy()}
+	AddHeadPlacement(&pod, spotNodeLabels, []string{"us-central1-a", "us-central1-b"})
+	terms := pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	assert.Len(t, terms, 1)
+	assert.Equal(t, []corev1.NodeSelectorRequirement{
+		gpuTerm.MatchExpressions[0],
+		{Key: "cloud.google.com/gke-spot", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"true"}},
+		{Key: "karpenter.sh/capacity-type", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"spot"}},
+		{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: []string{"us-central1-a", "us-central1-b"}},
+	}, terms[0].MatchExpressions)
+	antiAffinity := pod.Spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	assert.Len(t, antiAffinity, 1)
+	assert.Equal(t, corev1.LabelTopologyZone, antiAffinity[0].PodAffinityTerm.TopologyKey)
+	assert.Equal(t, string(rayv1.HeadNode), antiAffinity[0].PodAffinityTerm.LabelSelector.MatchLabels[utils.RayNodeTypeLabelKey])
+
+	// Without an affinity in the Pod template, the head Pod gets a new required node selector term.
+	template.Spec.Affinity = nil
+	pod = corev1.Pod{ObjectMeta: *template.ObjectMeta.DeepCopy(), Spec: *template.Spec.DeepCopy()}
+	AddHeadPlacement(&pod, spotNodeLabels, nil)
+	terms = pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	assert.Len(t, terms, 1)
+	assert.Len(t, terms[0].MatchExpressions, 2)
+}
+
+func TestAddPodDefaults(t *testing.T) {
+	cluster := instance.DeepCopy()
+	template := &cluster.Spec.WorkerGroupSpecs[0].Template
+	template.Labels = map[string]string{"team": "ml"}
+	template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "HTTPS_PROXY", Value: "http://proxy.ml:3128"}}
+	template.Spec.Containers[0].VolumeMounts = nil
+	template.Spec.Volumes = nil
+	template.Spec.InitContainers = []corev1.Container{{Name: "init"}}
+	template.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "ml-registry"}}
+	caBundle := corev1.Volume{Name: "ca-bundle", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "ca-bundle"},
+	}}}
+	caBundleMount := corev1.VolumeMount{Name: "ca-bundle", MountPath: "/etc/ssl/certs/ca-bundle.crt", SubPath: "ca-bundle.crt"}
+	noProxy := corev1.EnvVar{Name: "NO_PROXY", Value: ".svc,.cluster.local"}
+
+	pod := corev1.Pod{ObjectMeta: *template.ObjectMeta.DeepCopy(), Spec: *template.Spec.DeepCopy()}
+	AddPodDefaults(&pod, configapi.PodDefaults{
+		Labels:           map[string]string{"team": "platform", "cost-center": "1234"},
+		Env:              []corev1.EnvVar{{Name: "HTTPS_PROXY", Value: "http://proxy:3128"}, noProxy},
+		Volumes:          []corev1.Volume{caBundle},
+		VolumeMounts:     []corev1.VolumeMount{caBundleMount},
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "ml-registry"}, {Name: "platform-registry"}},
+	})
+	// The settings of the Pod template take precedence over the Pod defaults.
+	assert.Equal(t, map[string]string{"team": "ml", "cost-center": "1234"}, pod.Labels)
+	assert.Equal(t, []corev1.EnvVar{template.Spec.Containers[0].Env[0], noProxy}, pod.Spec.Containers[0].Env)
+	assert.Equal(t, []corev1.VolumeMount{caBundleMount}, pod.Spec.Containers[0].VolumeMounts)
+	assert.Equal(t, []corev1.EnvVar{{Name: "HTTPS_PROXY", Value: "http://proxy:3128"}, noProxy}, pod.Spec.InitContainers[0].Env)
+	assert.Equal(t, []corev1.VolumeMount{caBundleMount}, pod.Spec.InitContainers[0].VolumeMounts)
+	assert.Equal(t, []corev1.Volume{caBundle}, pod.Spec.Volumes)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "ml-registry"}, {Name: "platform-registry"}}, pod.Spec.ImagePullSecrets)
+}
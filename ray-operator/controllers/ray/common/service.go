@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 
@@ -76,6 +77,10 @@ func BuildServiceForHeadPod(ctx context.Context, cluster rayv1.RayCluster, label
 		svcPort := corev1.ServicePort{Name: name, Port: port, AppProtocol: &defaultAppProtocol}
 		ports = append(ports, svcPort)
 	}
+	// Sort the ports by name, so that the head service is the same in every reconciliation.
+	sort.SliceStable(ports, func(i, j int) bool {
+		return ports[i].Name < ports[j].Name
+	})
 	if cluster.Spec.HeadGroupSpec.HeadService != nil {
 		// Use the provided "custom" HeadService.
 		// Deep copy the HeadService to avoid modifying the original object
@@ -100,8 +105,15 @@ func BuildServiceForHeadPod(ctx context.Context, cluster rayv1.RayCluster, label
 			headService.ObjectMeta.Annotations[k] = v
 		}
 
-		// Append default ports.
-		headService.Spec.Ports = append(headService.Spec.Ports, ports...)
+		// Append default ports. The ports of the custom HeadService take precedence over the default ports with the
+		// same name or port number, e.g. to expose the dashboard through another port of a LoadBalancer.
+		for _, port := range ports {
+			if !slices.ContainsFunc(headService.Spec.Ports, func(customPort corev1.ServicePort) bool {
+				return customPort.Name == port.Name || customPort.Port == port.Port
+			}) {
+				headService.Spec.Ports = append(headService.Spec.Ports, port)
+			}
+		}
 
 		setLabelsforUserProvidedService(headService, labelsForService)
 		setNameforUserProvidedService(ctx, headService, defaultName)
@@ -411,6 +411,14 @@ func TestUserSpecifiedHeadService(t *testing.T) {
 			t.Errorf("User port not found: %v", p)
 		}
 	}
+	// The default client port is replaced by the user port with the same name.
+	clientPorts := 0
+	for _, hp := range headService.Spec.Ports {
+		if hp.Name == utils.ClientPortName {
+			clientPorts++
+		}
+	}
+	assert.Equal(t, 1, clientPorts)
 
 	validateServiceTypeForUserSpecifiedService(headService, userType, t)
 	validateLabelsForUserSpecifiedService(headService, userLabels, t)
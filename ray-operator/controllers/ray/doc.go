@@ -0,0 +1,4 @@
+// Package ray contains the controllers of the KubeRay CRDs. Its copies of third-party resource types, such as Kueue
+// Workloads, are not KubeRay CRDs.
+// +kubebuilder:skip
+package ray
@@ -0,0 +1,137 @@
+package expectations
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// HeadGroup is the group of the expectations of the head Pod.
+const HeadGroup = ""
+
+// ExpectationsTimeout is how long an expectation is waited for before it is considered satisfied, so that a RayCluster
+// does not stop scaling if the informer never observes a Pod, e.g. because it was created and deleted before the
+// informer observed it. It matches the timeout of the ReplicaSet controller.
+const ExpectationsTimeout = 5 * time.Minute
+
+type ScaleAction string
+
+const (
+	Create ScaleAction = "Create"
+	Delete ScaleAction = "Delete"
+)
+
+// ScaleExpectations tracks the Pods that the operator has created or deleted but that the informer cache has not
+// observed yet. A group should not be scaled while its expectations are not satisfied, because the Pods listed from
+// the cache do not include the creations and deletions that are in flight, and scaling the group again would create
+// or delete too many Pods.
+type ScaleExpectations interface {
+	// ExpectScalePod records that a Pod of a group of a RayCluster has been created or deleted.
+	ExpectScalePod(namespace, rayClusterName, group, podName string, action ScaleAction)
+	// IsSatisfied returns whether the informer cache has observed all the creations and deletions of the Pods of a
+	// group of a RayCluster.
+	IsSatisfied(ctx context.Context, namespace, rayClusterName, group string) bool
+	// Delete removes all the expectations of a RayCluster.
+	Delete(namespace, rayClusterName string)
+}
+
+// NewRayClusterScaleExpectation returns ScaleExpectations that check the expected Pods with the reader, which should
+// read from the same informer cache that the reconciler lists Pods from.
+func NewRayClusterScaleExpectation(reader client.Reader) ScaleExpectations {
+	return &rayClusterScaleExpectation{
+		reader:       reader,
+		expectations: make(map[types.NamespacedName]map[string]map[string]podExpectation),
+	}
+}
+
+type podExpectation struct {
+	expectedAt time.Time
+	action     ScaleAction
+}
+
+type rayClusterScaleExpectation struct {
+	reader client.Reader
+	// expectations maps RayClusters to their groups, which map the names of Pods to their expected actions.
+	expectations map[types.NamespacedName]map[string]map[string]podExpectation
+	mu           sync.Mutex
+}
+
+func (e *rayClusterScaleExpectation) ExpectScalePod(namespace, rayClusterName, group, podName string, action ScaleAction) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	key := types.NamespacedName{Namespace: namespace, Name: rayClusterName}
+	if e.expectations[key] == nil {
+		e.expectations[key] = make(map[string]map[string]podExpectation)
+	}
+	if e.expectations[key][group] == nil {
+		e.expectations[key][group] = make(map[string]podExpectation)
+	}
+	e.expectations[key][group][podName] = podExpectation{action: action, expectedAt: time.Now()}
+}
+
+func (e *rayClusterScaleExpectation) IsSatisfied(ctx context.Context, namespace, rayClusterName, group string) bool {
+	logger := ctrl.LoggerFrom(ctx)
+	key := types.NamespacedName{Namespace: namespace, Name: rayClusterName}
+
+	// Copy the expectations so that the lock is not held while reading the Pods.
+	e.mu.Lock()
+	pending := make(map[string]podExpectation, len(e.expectations[key][group]))
+	for podName, expectation := range e.expectations[key][group] {
+		pending[podName] = expectation
+	}
+	e.mu.Unlock()
+
+	var satisfied []string
+	for podName, expectation := range pending {
+		if e.isPodSatisfied(ctx, namespace, podName, expectation) {
+			satisfied = append(satisfied, podName)
+			continue
+		}
+		logger.Info("Waiting for the informer cache to observe the Pod", "group", group, "Pod", podName, "action", expectation.action)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, podName := range satisfied {
+		// The Pod may have been created or deleted again while the lock was not held.
+		if e.expectations[key][group][podName] == pending[podName] {
+			delete(e.expectations[key][group], podName)
+		}
+	}
+	if len(e.expectations[key][group]) == 0 {
+		delete(e.expectations[key], group)
+	}
+	if len(e.expectations[key]) == 0 {
+		delete(e.expectations, key)
+	}
+	return len(e.expectations[key][group]) == 0
+}
+
+// isPodSatisfied returns whether the reader has observed the action on the Pod, or the expectation timed out.
+func (e *rayClusterScaleExpectation) isPodSatisfied(ctx context.Context, namespace, podName string, expectation podExpectation) bool {
+	if time.Since(expectation.expectedAt) > ExpectationsTimeout {
+		return true
+	}
+	pod := &corev1.Pod{}
+	err := e.reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: podName}, pod)
+	switch expectation.action {
+	case Create:
+		return err == nil
+	case Delete:
+		return errors.IsNotFound(err) || (err == nil && !pod.DeletionTimestamp.IsZero())
+	}
+	return true
+}
+
+func (e *rayClusterScaleExpectation) Delete(namespace, rayClusterName string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.expectations, types.NamespacedName{Namespace: namespace, Name: rayClusterName})
+}
@@ -0,0 +1,64 @@
+package expectations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRayClusterScaleExpectation(t *testing.T) {
+	ctx := context.Background()
+	pod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	}
+	fakeClient := clientFake.NewClientBuilder().Build()
+	exp := NewRayClusterScaleExpectation(fakeClient)
+
+	// A group without expectations is satisfied.
+	assert.True(t, exp.IsSatisfied(ctx, "default", "raycluster", HeadGroup))
+
+	// The creation of a Pod is satisfied once the cache observes the Pod.
+	exp.ExpectScalePod("default", "raycluster", HeadGroup, "head", Create)
+	exp.ExpectScalePod("default", "raycluster", "group", "worker-1", Create)
+	assert.False(t, exp.IsSatisfied(ctx, "default", "raycluster", HeadGroup))
+	assert.False(t, exp.IsSatisfied(ctx, "default", "raycluster", "group"))
+	// The expectations of other RayClusters are independent.
+	assert.True(t, exp.IsSatisfied(ctx, "other", "raycluster", HeadGroup))
+	require.NoError(t, fakeClient.Create(ctx, pod("head")))
+	assert.True(t, exp.IsSatisfied(ctx, "default", "raycluster", HeadGroup))
+	assert.False(t, exp.IsSatisfied(ctx, "default", "raycluster", "group"))
+	require.NoError(t, fakeClient.Create(ctx, pod("worker-1")))
+	assert.True(t, exp.IsSatisfied(ctx, "default", "raycluster", "group"))
+
+	// The deletion of a Pod is satisfied once the cache observes that the Pod is terminating or deleted.
+	terminating := pod("worker-2")
+	terminating.Finalizers = []string{"ray.io/test"}
+	require.NoError(t, fakeClient.Create(ctx, terminating))
+	exp.ExpectScalePod("default", "raycluster", "group", "worker-1", Delete)
+	exp.ExpectScalePod("default", "raycluster", "group", "worker-2", Delete)
+	assert.False(t, exp.IsSatisfied(ctx, "default", "raycluster", "group"))
+	require.NoError(t, fakeClient.Delete(ctx, pod("worker-1")))
+	require.NoError(t, fakeClient.Delete(ctx, terminating))
+	assert.True(t, exp.IsSatisfied(ctx, "default", "raycluster", "group"))
+
+	// The expectations time out if the cache never observes the Pod.
+	exp.ExpectScalePod("default", "raycluster", "group", "worker-3", Create)
+	assert.False(t, exp.IsSatisfied(ctx, "default", "raycluster", "group"))
+	impl := exp.(*rayClusterScaleExpectation)
+	key := types.NamespacedName{Namespace: "default", Name: "raycluster"}
+	impl.expectations[key]["group"]["worker-3"] = podExpectation{action: Create, expectedAt: time.Now().Add(-ExpectationsTimeout - time.Second)}
+	assert.True(t, exp.IsSatisfied(ctx, "default", "raycluster", "group"))
+
+	// Deleting the RayCluster removes its expectations.
+	exp.ExpectScalePod("default", "raycluster", "group", "worker-4", Create)
+	exp.Delete("default", "raycluster")
+	assert.True(t, exp.IsSatisfied(ctx, "default", "raycluster", "group"))
+	assert.Empty(t, impl.expectations)
+}
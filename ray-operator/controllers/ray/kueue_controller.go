@@ -0,0 +1,529 @@
+package ray
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/ray-project/kuberay/ray-operator/pkg/features"
+)
+
+var (
+	kueueWorkloadGVK       = schema.GroupVersionKind{Group: "kueue.x-k8s.io", Version: "v1beta1", Kind: "Workload"}
+	kueueResourceFlavorGVK = schema.GroupVersionKind{Group: "kueue.x-k8s.io", Version: "v1beta1", Kind: "ResourceFlavor"}
+)
+
+const (
+	// The name of the pod set of the Ray head Pod
+	kueueHeadPodSetName = "head"
+	// The name of the pod set of the Pod that submits the Ray job of a RayJob in K8sJobMode
+	kueueSubmitterPodSetName = "submitter"
+
+	// Conditions of Kueue Workloads
+	kueueWorkloadQuotaReserved = "QuotaReserved"
+	kueueWorkloadAdmitted      = "Admitted"
+	kueueWorkloadEvicted       = "Evicted"
+	kueueWorkloadFinished      = "Finished"
+)
+
+// The following types mirror the subset of the Kueue v1beta1 API that the KubeRay operator uses, so that the
+// operator does not depend on Kueue. Reference: https://github.com/kubernetes-sigs/kueue/blob/main/apis/kueue/v1beta1/workload_types.go
+
+// kueueWorkload is a Kueue Workload
+type kueueWorkload struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              kueueWorkloadSpec   `json:"spec"`
+	Status            kueueWorkloadStatus `json:"status,omitempty"`
+}
+
+type kueueWorkloadSpec struct {
+	QueueName string        `json:"queueName,omitempty"`
+	PodSets   []kueuePodSet `json:"podSets"`
+}
+
+// kueuePodSet is a group of Pods with the same template that Kueue admits together
+type kueuePodSet struct {
+	Name     string                 `json:"name"`
+	Template corev1.PodTemplateSpec `json:"template"`
+	Count    int32                  `json:"count"`
+}
+
+type kueueWorkloadStatus struct {
+	Admission  *kueueAdmission    `json:"admission,omitempty"`
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+type kueueAdmission struct {
+	PodSetAssignments []kueuePodSetAssignment `json:"podSetAssignments,omitempty"`
+}
+
+// kueuePodSetAssignment is the ResourceFlavor that Kueue assigned to each resource of the Pods of a pod set
+type kueuePodSetAssignment struct {
+	Flavors map[corev1.ResourceName]string `json:"flavors,omitempty"`
+	Name    string                         `json:"name"`
+}
+
+// kueueJob is a RayCluster or a RayJob that Kueue admits as a Workload
+type kueueJob interface {
+	// object returns the RayCluster or the RayJob
+	object() client.Object
+	// kind returns the kind of the job
+	kind() string
+	isSuspended() bool
+	setSuspended(suspended bool)
+	// isFinished returns whether the job has finished, so that its Workload releases its quota
+	isFinished() bool
+	// rayClusterSpec returns the spec of the RayCluster of the job, which the Kueue integration mutates in place
+	rayClusterSpec() *rayv1.RayClusterSpec
+	// podSets returns the pod sets of the Workload of the job
+	podSets(ctx context.Context) []kueuePodSet
+	// validate returns why Kueue cannot admit the job, if it cannot
+	validate() error
+}
+
+type kueueRayCluster struct {
+	*rayv1.RayCluster
+}
+
+func (j kueueRayCluster) object() client.Object { return j.RayCluster }
+
+func (j kueueRayCluster) kind() string { return "RayCluster" }
+
+func (j kueueRayCluster) isSuspended() bool { return j.Spec.Suspend != nil && *j.Spec.Suspend }
+
+func (j kueueRayCluster) setSuspended(suspended bool) { j.Spec.Suspend = &suspended }
+
+func (j kueueRayCluster) isFinished() bool { return false }
+
+func (j kueueRayCluster) rayClusterSpec() *rayv1.RayClusterSpec { return &j.Spec }
+
+func (j kueueRayCluster) podSets(ctx context.Context) []kueuePodSet {
+	return rayClusterPodSets(ctx, &j.Spec)
+}
+
+func (j kueueRayCluster) validate() error {
+	if j.Spec.EnableInTreeAutoscaling != nil && *j.Spec.EnableInTreeAutoscaling {
+		return fmt.Errorf("a RayCluster with in-tree autoscaling enabled cannot be admitted by Kueue")
+	}
+	return nil
+}
+
+type kueueRayJob struct {
+	*rayv1.RayJob
+}
+
+func (j kueueRayJob) object() client.Object { return j.RayJob }
+
+func (j kueueRayJob) kind() string { return "RayJob" }
+
+func (j kueueRayJob) isSuspended() bool { return j.Spec.Suspend }
+
+func (j kueueRayJob) setSuspended(suspended bool) { j.Spec.Suspend = suspended }
+
+func (j kueueRayJob) isFinished() bool {
+	return j.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusComplete || j.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusFailed
+}
+
+func (j kueueRayJob) rayClusterSpec() *rayv1.RayClusterSpec { return j.Spec.RayClusterSpec }
+
+func (j kueueRayJob) podSets(ctx context.Context) []kueuePodSet {
+	podSets := rayClusterPodSets(ctx, j.Spec.RayClusterSpec)
+	if j.Spec.SubmissionMode == rayv1.K8sJobMode {
+		submitterTemplate := common.GetDefaultSubmitterTemplate(&rayv1.RayCluster{Spec: *j.Spec.RayClusterSpec})
+		if j.Spec.SubmitterPodTemplate != nil {
+			submitterTemplate = *j.Spec.SubmitterPodTemplate.DeepCopy()
+		}
+		podSets = append(podSets, kueuePodSet{Name: kueueSubmitterPodSetName, Count: 1, Template: submitterTemplate})
+	}
+	return podSets
+}
+
+func (j kueueRayJob) validate() error {
+	// The same limitations as the suspend operation of RayJobs, see validateRayJobSpec.
+	if j.Spec.RayClusterSpec == nil {
+		return fmt.Errorf("a RayJob without rayClusterSpec cannot be admitted by Kueue")
+	}
+	if !isRayClusterDeletedAfterJobFinishes(j.RayJob) {
+		return fmt.Errorf("a RayJob with shutdownAfterJobFinishes set to false cannot be admitted by Kueue, unless its deletionPolicy is DeleteCluster or DeleteSelf")
+	}
+	if j.Spec.RayClusterSpec.EnableInTreeAutoscaling != nil && *j.Spec.RayClusterSpec.EnableInTreeAutoscaling {
+		return fmt.Errorf("a RayJob with in-tree autoscaling enabled cannot be admitted by Kueue")
+	}
+	return nil
+}
+
+// rayClusterPodSets returns a pod set for the head Pod and a pod set for the Pods of each worker group
+func rayClusterPodSets(ctx context.Context, spec *rayv1.RayClusterSpec) []kueuePodSet {
+	podSets := []kueuePodSet{{Name: kueueHeadPodSetName, Count: 1, Template: *spec.HeadGroupSpec.Template.DeepCopy()}}
+	for _, worker := range spec.WorkerGroupSpecs {
+		podSets = append(podSets, kueuePodSet{
+			Name:     strings.ToLower(worker.GroupName),
+			Count:    utils.GetWorkerGroupDesiredReplicas(ctx, worker) * max(worker.NumOfHosts, 1),
+			Template: *worker.Template.DeepCopy(),
+		})
+	}
+	return podSets
+}
+
+// podTemplatesByPodSet returns the Pod templates of the RayCluster spec by the names of their pod sets
+func podTemplatesByPodSet(spec *rayv1.RayClusterSpec) map[string]*corev1.PodTemplateSpec {
+	templates := map[string]*corev1.PodTemplateSpec{kueueHeadPodSetName: &spec.HeadGroupSpec.Template}
+	for i := range spec.WorkerGroupSpecs {
+		templates[strings.ToLower(spec.WorkerGroupSpecs[i].GroupName)] = &spec.WorkerGroupSpecs[i].Template
+	}
+	return templates
+}
+
+// KueueWorkloadReconciler creates a Kueue Workload for each RayCluster or RayJob with the kueue.x-k8s.io/queue-name
+// label, keeps the RayCluster or the RayJob suspended until Kueue admits the Workload, and suspends it again when
+// Kueue evicts the Workload, e.g. to preempt it.
+type KueueWorkloadReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	newJob   func() kueueJob
+}
+
+// NewKueueRayClusterReconciler returns a new reconcile.Reconciler for the Kueue Workloads of RayClusters
+func NewKueueRayClusterReconciler(mgr manager.Manager) *KueueWorkloadReconciler {
+	return &KueueWorkloadReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("kueue-raycluster-controller"),
+		newJob:   func() kueueJob { return kueueRayCluster{&rayv1.RayCluster{}} },
+	}
+}
+
+// NewKueueRayJobReconciler returns a new reconcile.Reconciler for the Kueue Workloads of RayJobs
+func NewKueueRayJobReconciler(mgr manager.Manager) *KueueWorkloadReconciler {
+	return &KueueWorkloadReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("kueue-rayjob-controller"),
+		newJob:   func() kueueJob { return kueueRayJob{&rayv1.RayJob{}} },
+	}
+}
+
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=resourceflavors,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ray.io,resources=rayclusters,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ray.io,resources=rayjobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
+
+// [WARNING]: There MUST be a newline after kubebuilder markers.
+// Reconcile creates the Kueue Workload of a RayCluster or a RayJob and suspends or resumes the RayCluster or the
+// RayJob depending on whether Kueue admitted the Workload.
+func (r *KueueWorkloadReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	job := r.newJob()
+	obj := job.object()
+	if err := r.Get(ctx, request.NamespacedName, obj); err != nil {
+		// Request object not found, could have been deleted after reconcile request. Stop reconciliation.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !isKueueJob(job) {
+		return ctrl.Result{}, nil
+	}
+	if err := job.validate(); err != nil {
+		logger.Info("Kueue cannot admit the job", "reason", err.Error())
+		r.Recorder.Eventf(obj, corev1.EventTypeWarning, string(utils.InvalidKueueJob), "%v", err)
+		return ctrl.Result{}, nil
+	}
+
+	workload, err := getKueueWorkload(ctx, r.Client, job)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	if workload == nil {
+		if job.isFinished() {
+			return ctrl.Result{}, nil
+		}
+		if !job.isSuspended() {
+			if err := r.suspend(ctx, job, nil, "the Kueue Workload has not been created yet"); err != nil {
+				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+			}
+		}
+		return ctrl.Result{}, r.createWorkload(ctx, job)
+	}
+
+	if job.isFinished() {
+		return ctrl.Result{}, r.finishWorkload(ctx, job, workload)
+	}
+
+	if !isKueueWorkloadAdmitted(workload) {
+		if !job.isSuspended() {
+			reason := "the Kueue Workload is not admitted"
+			if evicted := meta.FindStatusCondition(workload.Status.Conditions, kueueWorkloadEvicted); evicted != nil && evicted.Status == metav1.ConditionTrue {
+				reason = fmt.Sprintf("the Kueue Workload was evicted: %s", evicted.Message)
+			}
+			return ctrl.Result{}, r.suspend(ctx, job, workload, reason)
+		}
+		// Kueue does not allow changing the pod sets of a Workload that has reserved quota.
+		if workload.Annotations[utils.KueuePodSetsHashAnnotationKey] != kueuePodSetsHash(ctx, job) &&
+			!meta.IsStatusConditionTrue(workload.Status.Conditions, kueueWorkloadQuotaReserved) {
+			return ctrl.Result{}, r.deleteWorkload(ctx, job, workload)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if job.isSuspended() {
+		if err := r.resume(ctx, job, workload); err != nil {
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// kueueWorkloadName returns the name of the Kueue Workload of the job
+func kueueWorkloadName(job kueueJob) string {
+	return utils.CheckName(fmt.Sprintf("%s-%s", strings.ToLower(job.kind()), job.object().GetName()))
+}
+
+// kueuePodSetsHash returns the hash of the pod sets of the Workload of the job
+func kueuePodSetsHash(ctx context.Context, job kueueJob) string {
+	hash, err := utils.GenerateJsonHash(job.podSets(ctx))
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to hash the pod sets of the Kueue Workload")
+	}
+	return hash
+}
+
+// isKueueJob returns whether Kueue admits the job through a Workload of its own
+func isKueueJob(job kueueJob) bool {
+	obj := job.object()
+	if obj.GetLabels()[utils.KueueQueueNameLabelKey] == "" || !obj.GetDeletionTimestamp().IsZero() {
+		return false
+	}
+	// The RayClusters of RayJobs and RayServices are admitted with their owners. The runs of a RayJob with a schedule
+	// are admitted instead of the RayJob.
+	if owner := metav1.GetControllerOf(obj); owner != nil && owner.APIVersion == rayv1.GroupVersion.String() && job.kind() != "RayJob" {
+		return false
+	}
+	if rayJob, ok := obj.(*rayv1.RayJob); ok && rayJob.Spec.Schedule != "" {
+		return false
+	}
+	return true
+}
+
+// isKueueWorkloadAdmitted returns whether Kueue admitted the Workload and has not evicted it
+func isKueueWorkloadAdmitted(workload *kueueWorkload) bool {
+	return meta.IsStatusConditionTrue(workload.Status.Conditions, kueueWorkloadAdmitted) &&
+		!meta.IsStatusConditionTrue(workload.Status.Conditions, kueueWorkloadEvicted)
+}
+
+// waitsForKueueAdmission returns whether the unsuspended job must not run yet, because the Kueue integration is
+// enabled and Kueue has not admitted its Workload. A job that is created unsuspended runs only once the
+// KueueWorkloadReconciler has suspended it, created its Workload and resumed it when Kueue admits the Workload.
+func waitsForKueueAdmission(ctx context.Context, c client.Reader, job kueueJob) (bool, error) {
+	if !features.Enabled(features.KueueIntegration) || job.isSuspended() || !isKueueJob(job) || job.validate() != nil {
+		return false, nil
+	}
+	workload, err := getKueueWorkload(ctx, c, job)
+	if err != nil {
+		return false, err
+	}
+	return workload == nil || !isKueueWorkloadAdmitted(workload), nil
+}
+
+// getKueueWorkload returns the Kueue Workload of the job, or nil if it does not exist
+func getKueueWorkload(ctx context.Context, c client.Reader, job kueueJob) (*kueueWorkload, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(kueueWorkloadGVK)
+	if err := c.Get(ctx, types.NamespacedName{Namespace: job.object().GetNamespace(), Name: kueueWorkloadName(job)}, obj); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	workload := &kueueWorkload{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, workload); err != nil {
+		return nil, err
+	}
+	return workload, nil
+}
+
+func (r *KueueWorkloadReconciler) createWorkload(ctx context.Context, job kueueJob) error {
+	logger := ctrl.LoggerFrom(ctx)
+	workload := &kueueWorkload{
+		TypeMeta: metav1.TypeMeta{APIVersion: kueueWorkloadGVK.GroupVersion().String(), Kind: kueueWorkloadGVK.Kind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        kueueWorkloadName(job),
+			Namespace:   job.object().GetNamespace(),
+			Annotations: map[string]string{utils.KueuePodSetsHashAnnotationKey: kueuePodSetsHash(ctx, job)},
+		},
+		Spec: kueueWorkloadSpec{
+			QueueName: job.object().GetLabels()[utils.KueueQueueNameLabelKey],
+			PodSets:   job.podSets(ctx),
+		},
+	}
+	if err := controllerutil.SetControllerReference(job.object(), workload, r.Scheme); err != nil {
+		return err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(workload)
+	if err != nil {
+		return err
+	}
+	obj := &unstructured.Unstructured{Object: content}
+	// Kueue sets the status of the Workload.
+	unstructured.RemoveNestedField(obj.Object, "status")
+	if err := r.Create(ctx, obj); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+		r.Recorder.Eventf(job.object(), corev1.EventTypeWarning, string(utils.FailedToCreateKueueWorkload),
+			"Failed to create Kueue Workload %s/%s, %v", workload.Namespace, workload.Name, err)
+		return err
+	}
+	logger.Info("Created Kueue Workload", "name", workload.Name, "queueName", workload.Spec.QueueName)
+	r.Recorder.Eventf(job.object(), corev1.EventTypeNormal, string(utils.CreatedKueueWorkload),
+		"Created Kueue Workload %s/%s in queue %s", workload.Namespace, workload.Name, workload.Spec.QueueName)
+	return nil
+}
+
+func (r *KueueWorkloadReconciler) deleteWorkload(ctx context.Context, job kueueJob, workload *kueueWorkload) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(kueueWorkloadGVK)
+	obj.SetNamespace(workload.Namespace)
+	obj.SetName(workload.Name)
+	if err := r.Delete(ctx, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	ctrl.LoggerFrom(ctx).Info("Deleted the Kueue Workload because its pod sets changed", "name", workload.Name)
+	r.Recorder.Eventf(job.object(), corev1.EventTypeNormal, string(utils.DeletedKueueWorkload),
+		"Deleted Kueue Workload %s/%s because the pod sets of the %s changed", workload.Namespace, workload.Name, job.kind())
+	return nil
+}
+
+// finishWorkload sets the Finished condition of the Workload of a finished job, so that Kueue releases its quota
+func (r *KueueWorkloadReconciler) finishWorkload(ctx context.Context, job kueueJob, workload *kueueWorkload) error {
+	if meta.IsStatusConditionTrue(workload.Status.Conditions, kueueWorkloadFinished) {
+		return nil
+	}
+	meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+		Type:    kueueWorkloadFinished,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Finished",
+		Message: fmt.Sprintf("The %s has finished", job.kind()),
+	})
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(workload)
+	if err != nil {
+		return err
+	}
+	return r.Status().Update(ctx, &unstructured.Unstructured{Object: content})
+}
+
+// suspend suspends the job and restores the node selectors of its Pod templates, which resume changed to the node
+// labels of the ResourceFlavors that Kueue assigned, from the pod sets of the Workload
+func (r *KueueWorkloadReconciler) suspend(ctx context.Context, job kueueJob, workload *kueueWorkload, reason string) error {
+	if workload != nil {
+		templates := podTemplatesByPodSet(job.rayClusterSpec())
+		for _, podSet := range workload.Spec.PodSets {
+			if template, ok := templates[podSet.Name]; ok {
+				template.Spec.NodeSelector = podSet.Template.Spec.NodeSelector
+			}
+		}
+	}
+	job.setSuspended(true)
+	obj := job.object()
+	if err := r.Update(ctx, obj); err != nil {
+		return err
+	}
+	ctrl.LoggerFrom(ctx).Info("Suspended the job for Kueue", "reason", reason)
+	r.Recorder.Eventf(obj, corev1.EventTypeNormal, string(utils.SuspendedByKueue),
+		"Suspended %s %s/%s because %s", job.kind(), obj.GetNamespace(), obj.GetName(), reason)
+	return nil
+}
+
+// resume adds the node labels of the ResourceFlavors that Kueue assigned to each pod set to the node selectors of
+// its Pod template, and resumes the job
+func (r *KueueWorkloadReconciler) resume(ctx context.Context, job kueueJob, workload *kueueWorkload) error {
+	templates := podTemplatesByPodSet(job.rayClusterSpec())
+	if workload.Status.Admission != nil {
+		for _, assignment := range workload.Status.Admission.PodSetAssignments {
+			template, ok := templates[assignment.Name]
+			if !ok {
+				continue
+			}
+			for _, flavorName := range assignment.Flavors {
+				nodeLabels, err := r.getResourceFlavorNodeLabels(ctx, flavorName)
+				if err != nil {
+					return err
+				}
+				if len(nodeLabels) > 0 && template.Spec.NodeSelector == nil {
+					template.Spec.NodeSelector = make(map[string]string, len(nodeLabels))
+				}
+				for key, value := range nodeLabels {
+					template.Spec.NodeSelector[key] = value
+				}
+			}
+		}
+	}
+	job.setSuspended(false)
+	obj := job.object()
+	if err := r.Update(ctx, obj); err != nil {
+		return err
+	}
+	ctrl.LoggerFrom(ctx).Info("Resumed the job admitted by Kueue", "workload", workload.Name)
+	r.Recorder.Eventf(obj, corev1.EventTypeNormal, string(utils.AdmittedByKueue),
+		"Kueue admitted Workload %s/%s, resumed %s %s/%s", workload.Namespace, workload.Name, job.kind(), obj.GetNamespace(), obj.GetName())
+	return nil
+}
+
+// getResourceFlavorNodeLabels returns the node labels of the Kueue ResourceFlavor
+func (r *KueueWorkloadReconciler) getResourceFlavorNodeLabels(ctx context.Context, name string) (map[string]string, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(kueueResourceFlavorGVK)
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, obj); err != nil {
+		return nil, err
+	}
+	nodeLabels, _, err := unstructured.NestedStringMap(obj.Object, "spec", "nodeLabels")
+	return nodeLabels, err
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KueueWorkloadReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int, rateLimiter workqueue.TypedRateLimiter[reconcile.Request], scope utils.Scope) error {
+	job := r.newJob()
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(kueueWorkloadGVK)
+	name := "kueue-" + strings.ToLower(job.kind())
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(job.object()).
+		Owns(workload).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: reconcileConcurrency,
+			RateLimiter:             rateLimiter,
+			LogConstructor: func(request *reconcile.Request) logr.Logger {
+				logger := ctrl.Log.WithName("controllers").WithName("Kueue" + job.kind())
+				if request != nil {
+					logger = logger.WithValues(job.kind(), request.NamespacedName)
+				}
+				return logger
+			},
+		})
+	return scope.Complete(b, mgr, job.object(), r)
+}
@@ -0,0 +1,291 @@
+package ray
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/ray-project/kuberay/ray-operator/pkg/features"
+)
+
+func newKueueTestRayClusterSpec() *rayv1.RayClusterSpec {
+	return &rayv1.RayClusterSpec{
+		HeadGroupSpec: rayv1.HeadGroupSpec{
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head", Image: "rayproject/ray:2.9.0"}}}},
+		},
+		WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+			{
+				GroupName:   "GPU",
+				Replicas:    ptr.To[int32](2),
+				MinReplicas: ptr.To[int32](0),
+				MaxReplicas: ptr.To[int32](4),
+				NumOfHosts:  2,
+				Template:    corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-worker"}}}},
+			},
+		},
+	}
+}
+
+func newKueueTestReconciler(newJob func() kueueJob, objects ...runtime.Object) *KueueWorkloadReconciler {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	newScheme.AddKnownTypeWithName(kueueWorkloadGVK, &unstructured.Unstructured{})
+	newScheme.AddKnownTypeWithName(kueueWorkloadGVK.GroupVersion().WithKind(kueueWorkloadGVK.Kind+"List"), &unstructured.UnstructuredList{})
+	newScheme.AddKnownTypeWithName(kueueResourceFlavorGVK, &unstructured.Unstructured{})
+
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(kueueWorkloadGVK)
+	fakeClient := clientFake.NewClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(objects...).
+		WithStatusSubresource(workload).
+		Build()
+	return &KueueWorkloadReconciler{
+		Client:   fakeClient,
+		Scheme:   newScheme,
+		Recorder: record.NewFakeRecorder(100),
+		newJob:   newJob,
+	}
+}
+
+// setKueueWorkloadStatus sets the status of the Kueue Workload, as Kueue would
+func setKueueWorkloadStatus(ctx context.Context, t *testing.T, r *KueueWorkloadReconciler, job kueueJob, status kueueWorkloadStatus) {
+	workload, err := getKueueWorkload(ctx, r.Client, job)
+	require.NoError(t, err)
+	require.NotNil(t, workload)
+	workload.Status = status
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(workload)
+	require.NoError(t, err)
+	require.NoError(t, r.Status().Update(ctx, &unstructured.Unstructured{Object: content}))
+}
+
+func TestKueueRayClusterReconcile(t *testing.T) {
+	ctx := context.Background()
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default", Labels: map[string]string{utils.KueueQueueNameLabelKey: "user-queue"}},
+		Spec:       *newKueueTestRayClusterSpec(),
+	}
+	resourceFlavor := &unstructured.Unstructured{}
+	resourceFlavor.SetGroupVersionKind(kueueResourceFlavorGVK)
+	resourceFlavor.SetName("a100")
+	require.NoError(t, unstructured.SetNestedStringMap(resourceFlavor.Object, map[string]string{"gpu-type": "a100"}, "spec", "nodeLabels"))
+	r := newKueueTestReconciler(func() kueueJob { return kueueRayCluster{&rayv1.RayCluster{}} }, rayCluster, resourceFlavor)
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "raycluster"}}
+	getRayCluster := func() kueueRayCluster {
+		job := kueueRayCluster{&rayv1.RayCluster{}}
+		require.NoError(t, r.Get(ctx, request.NamespacedName, job.RayCluster))
+		return job
+	}
+
+	// The RayCluster is suspended until Kueue admits its Workload.
+	_, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	job := getRayCluster()
+	assert.True(t, job.isSuspended())
+	workload, err := getKueueWorkload(ctx, r.Client, job)
+	require.NoError(t, err)
+	require.NotNil(t, workload)
+	assert.Equal(t, "raycluster-raycluster", workload.Name)
+	assert.Equal(t, "user-queue", workload.Spec.QueueName)
+	require.Len(t, workload.Spec.PodSets, 2)
+	assert.Equal(t, "head", workload.Spec.PodSets[0].Name)
+	assert.Equal(t, int32(1), workload.Spec.PodSets[0].Count)
+	assert.Equal(t, "gpu", workload.Spec.PodSets[1].Name)
+	assert.Equal(t, int32(4), workload.Spec.PodSets[1].Count)
+	assert.Equal(t, "raycluster", metav1.GetControllerOf(workload).Name)
+
+	// Kueue admits the Workload, and the RayCluster is resumed on the nodes of the assigned ResourceFlavor.
+	setKueueWorkloadStatus(ctx, t, r, job, kueueWorkloadStatus{
+		Admission: &kueueAdmission{PodSetAssignments: []kueuePodSetAssignment{{Name: "gpu", Flavors: map[corev1.ResourceName]string{"nvidia.com/gpu": "a100"}}}},
+		Conditions: []metav1.Condition{
+			{Type: kueueWorkloadQuotaReserved, Status: metav1.ConditionTrue, Reason: "QuotaReserved", LastTransitionTime: metav1.Now()},
+			{Type: kueueWorkloadAdmitted, Status: metav1.ConditionTrue, Reason: "Admitted", LastTransitionTime: metav1.Now()},
+		},
+	})
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	job = getRayCluster()
+	assert.False(t, job.isSuspended())
+	assert.Equal(t, map[string]string{"gpu-type": "a100"}, job.Spec.WorkerGroupSpecs[0].Template.Spec.NodeSelector)
+	assert.Nil(t, job.Spec.HeadGroupSpec.Template.Spec.NodeSelector)
+
+	// Kueue evicts the Workload to preempt it, and the RayCluster is suspended with its original node selectors.
+	setKueueWorkloadStatus(ctx, t, r, job, kueueWorkloadStatus{
+		Conditions: []metav1.Condition{
+			{Type: kueueWorkloadEvicted, Status: metav1.ConditionTrue, Reason: "Preempted", Message: "Preempted to accommodate a higher priority Workload", LastTransitionTime: metav1.Now()},
+		},
+	})
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	job = getRayCluster()
+	assert.True(t, job.isSuspended())
+	assert.Nil(t, job.Spec.WorkerGroupSpecs[0].Template.Spec.NodeSelector)
+
+	// The pod sets of the suspended RayCluster change, and its Workload is recreated.
+	job.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](3)
+	require.NoError(t, r.Update(ctx, job.RayCluster))
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	workload, err = getKueueWorkload(ctx, r.Client, job)
+	require.NoError(t, err)
+	assert.Nil(t, workload)
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	workload, err = getKueueWorkload(ctx, r.Client, job)
+	require.NoError(t, err)
+	require.NotNil(t, workload)
+	assert.Equal(t, int32(6), workload.Spec.PodSets[1].Count)
+}
+
+func TestKueueRayClusterReconcileSkipped(t *testing.T) {
+	ctx := context.Background()
+	newRayCluster := func(name string, labels map[string]string) *rayv1.RayCluster {
+		return &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels}, Spec: *newKueueTestRayClusterSpec()}
+	}
+	// A RayCluster without the queue name label.
+	unqueued := newRayCluster("unqueued", nil)
+	// A RayCluster of a RayJob, which is admitted with the RayJob.
+	owned := newRayCluster("owned", map[string]string{utils.KueueQueueNameLabelKey: "user-queue"})
+	owned.OwnerReferences = []metav1.OwnerReference{{APIVersion: rayv1.GroupVersion.String(), Kind: "RayJob", Name: "rayjob", UID: "uid", Controller: ptr.To(true)}}
+	// A RayCluster that Kueue cannot admit.
+	autoscaling := newRayCluster("autoscaling", map[string]string{utils.KueueQueueNameLabelKey: "user-queue"})
+	autoscaling.Spec.EnableInTreeAutoscaling = ptr.To(true)
+	r := newKueueTestReconciler(func() kueueJob { return kueueRayCluster{&rayv1.RayCluster{}} }, unqueued, owned, autoscaling)
+
+	for _, rayCluster := range []*rayv1.RayCluster{unqueued, owned, autoscaling} {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(rayCluster)})
+		require.NoError(t, err)
+		job := kueueRayCluster{&rayv1.RayCluster{}}
+		require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(rayCluster), job.RayCluster))
+		assert.False(t, job.isSuspended(), rayCluster.Name)
+		workload, err := getKueueWorkload(ctx, r.Client, job)
+		require.NoError(t, err)
+		assert.Nil(t, workload, rayCluster.Name)
+	}
+}
+
+func TestKueueRayJobReconcile(t *testing.T) {
+	ctx := context.Background()
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayjob", Namespace: "default", Labels: map[string]string{utils.KueueQueueNameLabelKey: "user-queue"}},
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec:           newKueueTestRayClusterSpec(),
+			ShutdownAfterJobFinishes: true,
+			SubmissionMode:           rayv1.K8sJobMode,
+		},
+	}
+	r := newKueueTestReconciler(func() kueueJob { return kueueRayJob{&rayv1.RayJob{}} }, rayJob)
+	request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(rayJob)}
+
+	_, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	job := kueueRayJob{&rayv1.RayJob{}}
+	require.NoError(t, r.Get(ctx, request.NamespacedName, job.RayJob))
+	assert.True(t, job.isSuspended())
+	workload, err := getKueueWorkload(ctx, r.Client, job)
+	require.NoError(t, err)
+	require.NotNil(t, workload)
+	assert.Equal(t, "rayjob-rayjob", workload.Name)
+	require.Len(t, workload.Spec.PodSets, 3)
+	assert.Equal(t, "submitter", workload.Spec.PodSets[2].Name)
+
+	// The Workload of a finished RayJob releases its quota.
+	job.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusComplete
+	require.NoError(t, r.Update(ctx, job.RayJob))
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	workload, err = getKueueWorkload(ctx, r.Client, job)
+	require.NoError(t, err)
+	assert.True(t, meta.IsStatusConditionTrue(workload.Status.Conditions, kueueWorkloadFinished))
+}
+
+func TestKueueScheduledRayJobReconcile(t *testing.T) {
+	ctx := context.Background()
+	newRayJob := func(name string) *rayv1.RayJob {
+		return &rayv1.RayJob{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{utils.KueueQueueNameLabelKey: "user-queue"}},
+			Spec: rayv1.RayJobSpec{
+				RayClusterSpec:           newKueueTestRayClusterSpec(),
+				ShutdownAfterJobFinishes: true,
+				SubmissionMode:           rayv1.K8sJobMode,
+			},
+		}
+	}
+	// A RayJob with a schedule is not admitted itself.
+	scheduled := newRayJob("scheduled")
+	scheduled.Spec.Schedule = "0 * * * *"
+	// The runs of the RayJob are admitted.
+	run := newRayJob("scheduled-28000000")
+	run.OwnerReferences = []metav1.OwnerReference{{APIVersion: rayv1.GroupVersion.String(), Kind: "RayJob", Name: "scheduled", UID: "uid", Controller: ptr.To(true)}}
+	r := newKueueTestReconciler(func() kueueJob { return kueueRayJob{&rayv1.RayJob{}} }, scheduled, run)
+
+	for _, rayJob := range []*rayv1.RayJob{scheduled, run} {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(rayJob)})
+		require.NoError(t, err)
+		job := kueueRayJob{&rayv1.RayJob{}}
+		require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(rayJob), job.RayJob))
+		workload, err := getKueueWorkload(ctx, r.Client, job)
+		require.NoError(t, err)
+		assert.Equal(t, rayJob == run, workload != nil, rayJob.Name)
+		assert.Equal(t, rayJob == run, job.isSuspended(), rayJob.Name)
+	}
+}
+
+func TestWaitsForKueueAdmission(t *testing.T) {
+	ctx := context.Background()
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default", Labels: map[string]string{utils.KueueQueueNameLabelKey: "user-queue"}},
+		Spec:       *newKueueTestRayClusterSpec(),
+	}
+	r := newKueueTestReconciler(func() kueueJob { return kueueRayCluster{&rayv1.RayCluster{}} }, rayCluster)
+	job := kueueRayCluster{rayCluster}
+
+	// The RayCluster runs as before if the Kueue integration is disabled.
+	waiting, err := waitsForKueueAdmission(ctx, r.Client, job)
+	require.NoError(t, err)
+	assert.False(t, waiting)
+
+	features.SetFeatureGateDuringTest(t, features.KueueIntegration, true)
+	// The RayCluster was created unsuspended and its Workload does not exist yet.
+	waiting, err = waitsForKueueAdmission(ctx, r.Client, job)
+	require.NoError(t, err)
+	assert.True(t, waiting)
+
+	// The Workload is created, but Kueue has not admitted it yet.
+	_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(rayCluster)})
+	require.NoError(t, err)
+	waiting, err = waitsForKueueAdmission(ctx, r.Client, kueueRayCluster{rayCluster.DeepCopy()})
+	require.NoError(t, err)
+	assert.True(t, waiting)
+
+	// Kueue admits the Workload.
+	setKueueWorkloadStatus(ctx, t, r, job, kueueWorkloadStatus{
+		Conditions: []metav1.Condition{{Type: kueueWorkloadAdmitted, Status: metav1.ConditionTrue, Reason: "Admitted", LastTransitionTime: metav1.Now()}},
+	})
+	waiting, err = waitsForKueueAdmission(ctx, r.Client, job)
+	require.NoError(t, err)
+	assert.False(t, waiting)
+
+	// A RayCluster without the queue name label does not wait for Kueue.
+	unqueued := rayCluster.DeepCopy()
+	unqueued.Labels = nil
+	waiting, err = waitsForKueueAdmission(ctx, r.Client, kueueRayCluster{unqueued})
+	require.NoError(t, err)
+	assert.False(t, waiting)
+}
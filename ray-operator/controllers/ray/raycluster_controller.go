@@ -4,27 +4,40 @@ import (
 	"context"
 	errstd "errors"
 	"fmt"
+	"math"
+	"net"
 	"os"
 	"reflect"
 	"runtime"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 
 	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler"
+	schedulerinterface "github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/interface"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/expectations"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 	"github.com/ray-project/kuberay/ray-operator/pkg/features"
 
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 
@@ -35,12 +48,16 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	resourcev1alpha3 "k8s.io/api/resource/v1alpha3"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/rand"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	controller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -52,6 +69,12 @@ type reconcileFunc func(context.Context, *rayv1.RayCluster) error
 
 var (
 	DefaultRequeueDuration = 2 * time.Second
+	// redisDialTimeout is the timeout of the connection to the Redis server of GCS fault tolerance.
+	redisDialTimeout = 3 * time.Second
+	// drainWorkerPodsRequeueDuration is how often the Ray nodes of draining worker Pods are checked.
+	drainWorkerPodsRequeueDuration = 5 * time.Second
+	// namespaceQuotaRequeueDuration is how often a RayCluster that exceeds the quota of its namespace checks it again.
+	namespaceQuotaRequeueDuration = 30 * time.Second
 
 	// Definition of a index field for pod name
 	podUIDIndexField = "metadata.uid"
@@ -118,14 +141,22 @@ func NewReconciler(ctx context.Context, mgr manager.Manager, options RayClusterR
 	schedulerMgr.AddToScheme(mgr.GetScheme())
 
 	return &RayClusterReconciler{
-		Client:            mgr.GetClient(),
-		Scheme:            mgr.GetScheme(),
-		Recorder:          mgr.GetEventRecorderFor("raycluster-controller"),
-		BatchSchedulerMgr: schedulerMgr,
-		IsOpenShift:       isOpenShift,
-
-		headSidecarContainers:   options.HeadSidecarContainers,
-		workerSidecarContainers: options.WorkerSidecarContainers,
+		Client:                     utils.NewTracedClient(mgr.GetClient()),
+		Scheme:                     mgr.GetScheme(),
+		Recorder:                   mgr.GetEventRecorderFor("raycluster-controller"),
+		BatchSchedulerMgr:          schedulerMgr,
+		IsOpenShift:                isOpenShift,
+		dashboardClientFunc:        rayConfigs.GetDashboardClient(mgr),
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(mgr.GetClient()),
+
+		headPlacement:              options.HeadPlacement,
+		headSidecarContainers:      options.HeadSidecarContainers,
+		workerSidecarContainers:    options.WorkerSidecarContainers,
+		podDefaults:                options.PodDefaults,
+		enablePodDisruptionBudgets: options.EnablePodDisruptionBudgets,
+		enablePrometheusMonitors:   options.EnablePrometheusMonitors,
+		workloadStatusInterval:     options.WorkloadStatusInterval,
+		requeueInterval:            options.RequeueInterval,
 	}
 }
 
@@ -137,16 +168,46 @@ type RayClusterReconciler struct {
 	Scheme            *k8sruntime.Scheme
 	Recorder          record.EventRecorder
 	BatchSchedulerMgr *batchscheduler.SchedulerManager
-
+	// dashboardClientFunc creates the clients of the Ray dashboards, which report the workload of Ray nodes to drain.
+	dashboardClientFunc func() utils.RayDashboardClientInterface
+	// dialRedis connects to the Redis servers of GCS fault tolerance. If it is nil, a net.Dialer is used.
+	dialRedis func(ctx context.Context, network, address string) (net.Conn, error)
+	// drainRayNode asks the GCS servers of RayClusters to drain Ray nodes. If it is nil, utils.DrainRayNode is used.
+	drainRayNode func(ctx context.Context, address string, nodeID string, reason utils.DrainNodeReason, reasonMessage string, deadline time.Time) error
+	// rayClusterScaleExpectation tracks the Pods created and deleted by the reconciler that the informer cache has not
+	// observed yet, so that a group is not scaled again based on stale Pods.
+	rayClusterScaleExpectation expectations.ScaleExpectations
+
+	// headPlacement is the default placement of the head Pods of RayClusters. It is disabled if it is nil.
+	headPlacement           *configapi.HeadPlacementConfig
 	headSidecarContainers   []corev1.Container
 	workerSidecarContainers []corev1.Container
+	// podDefaults are injected into the Ray Pods of the RayClusters in the namespaces that they select.
+	podDefaults []configapi.PodDefaults
+
+	// workloadStatusInterval is how often the workload status of RayClusters is refreshed from their Ray dashboards.
+	// The workload status is not reported if it is 0.
+	workloadStatusInterval time.Duration
+	// requeueInterval is how often RayClusters are reconciled if nothing else triggers their reconciliation. If it is
+	// 0, RAYCLUSTER_DEFAULT_REQUEUE_SECONDS is used.
+	requeueInterval time.Duration
 
 	IsOpenShift bool
+	// enablePodDisruptionBudgets is the default of the EnablePodDisruptionBudgets of RayClusters.
+	enablePodDisruptionBudgets bool
+	// enablePrometheusMonitors is the default of the PrometheusMonitors.Enabled of RayClusters.
+	enablePrometheusMonitors bool
 }
 
 type RayClusterReconcilerOptions struct {
-	HeadSidecarContainers   []corev1.Container
-	WorkerSidecarContainers []corev1.Container
+	HeadPlacement              *configapi.HeadPlacementConfig
+	HeadSidecarContainers      []corev1.Container
+	WorkerSidecarContainers    []corev1.Container
+	PodDefaults                []configapi.PodDefaults
+	WorkloadStatusInterval     time.Duration
+	RequeueInterval            time.Duration
+	EnablePodDisruptionBudgets bool
+	EnablePrometheusMonitors   bool
 }
 
 // Reconcile reads that state of the cluster for a RayCluster object and makes changes based on it
@@ -156,18 +217,28 @@ type RayClusterReconcilerOptions struct {
 // +kubebuilder:rbac:groups=ray.io,resources=rayclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ray.io,resources=rayclusters/finalizers,verbs=update
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;delete;deletecollection;patch
+// +kubebuilder:rbac:groups=resource.k8s.io,resources=resourceclaims;resourceclaimtemplates,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete;deletecollection
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete;deletecollection
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;delete;patch
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;create;update;patch
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors,verbs=get;create;update;delete;patch
 // +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=services/proxy,verbs=get;update;patch;create
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;delete;patch
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=extensions,resources=ingresses,verbs=get;list;watch;create;update;delete;patch
-// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;delete
-// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;delete;update
-// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;create;update;delete;patch
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;delete;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;delete;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;delete;patch
 
 // [WARNING]: There MUST be a newline after kubebuilder markers.
 
@@ -185,6 +256,7 @@ func (r *RayClusterReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 	// No match found
 	if errors.IsNotFound(err) {
 		logger.Info("Read request instance not found error!")
+		r.rayClusterScaleExpectation.Delete(request.Namespace, request.Name)
 	} else {
 		logger.Error(err, "Read request instance error!")
 	}
@@ -198,18 +270,40 @@ func (r *RayClusterReconciler) deleteAllPods(ctx context.Context, filters common
 		return pods, err
 	}
 	active := 0
+	activeByNodeType := map[rayv1.RayNodeType]int{}
 	for _, pod := range pods.Items {
 		if pod.DeletionTimestamp.IsZero() {
 			active++
+			activeByNodeType[rayv1.RayNodeType(pod.Labels[utils.RayNodeTypeLabelKey])]++
 		}
 	}
 	if active > 0 {
 		logger.Info("Deleting all Pods with labels", "filters", filters, "Number of active Pods", active)
-		return pods, r.DeleteAllOf(ctx, &corev1.Pod{}, filters.ToDeleteOptions()...)
+		if err := r.DeleteAllOf(ctx, &corev1.Pod{}, filters.ToDeleteOptions()...); err != nil {
+			return pods, err
+		}
+		for nodeType, count := range activeByNodeType {
+			common.DeletedPodsCounterAdd(pods.Items[0].Namespace, nodeType, count)
+		}
 	}
 	return pods, nil
 }
 
+// deletePod deletes a Pod of a group of the RayCluster, expects the informer cache to observe the deletion, and counts
+// the deletion in the metrics of the KubeRay operator. The group of the head Pod is expectations.HeadGroup.
+func (r *RayClusterReconciler) deletePod(ctx context.Context, instance *rayv1.RayCluster, pod *corev1.Pod, group string) error {
+	if err := r.Delete(ctx, pod); err != nil {
+		return err
+	}
+	r.rayClusterScaleExpectation.ExpectScalePod(instance.Namespace, instance.Name, group, pod.Name, expectations.Delete)
+	nodeType := rayv1.WorkerNode
+	if group == expectations.HeadGroup {
+		nodeType = rayv1.HeadNode
+	}
+	common.DeletedPodsCounterAdd(instance.Namespace, nodeType, 1)
+	return nil
+}
+
 func (r *RayClusterReconciler) validateRayClusterStatus(instance *rayv1.RayCluster) error {
 	suspending := meta.IsStatusConditionTrue(instance.Status.Conditions, string(rayv1.RayClusterSuspending))
 	suspended := meta.IsStatusConditionTrue(instance.Status.Conditions, string(rayv1.RayClusterSuspended))
@@ -233,107 +327,51 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 	// Please do NOT modify `originalRayClusterInstance` in the following code.
 	originalRayClusterInstance := instance.DeepCopy()
 
-	// The `enableGCSFTRedisCleanup` is a feature flag introduced in KubeRay v1.0.0. It determines whether
-	// the Redis cleanup job should be activated. Users can disable the feature by setting the environment
-	// variable `ENABLE_GCS_FT_REDIS_CLEANUP` to `false`, and undertake the Redis storage namespace cleanup
-	// manually after the RayCluster CR deletion.
-	enableGCSFTRedisCleanup := strings.ToLower(os.Getenv(utils.ENABLE_GCS_FT_REDIS_CLEANUP)) != "false"
-
-	if enableGCSFTRedisCleanup && common.IsGCSFaultToleranceEnabled(*instance) {
-		if instance.DeletionTimestamp.IsZero() {
-			if !controllerutil.ContainsFinalizer(instance, utils.GCSFaultToleranceRedisCleanupFinalizer) {
-				logger.Info(
-					"GCS fault tolerance has been enabled. Implementing a finalizer to ensure that Redis is properly cleaned up once the RayCluster custom resource (CR) is deleted.",
-					"finalizer", utils.GCSFaultToleranceRedisCleanupFinalizer)
-				controllerutil.AddFinalizer(instance, utils.GCSFaultToleranceRedisCleanupFinalizer)
-				if err := r.Update(ctx, instance); err != nil {
-					err = fmt.Errorf("Failed to add the finalizer %s to the RayCluster: %w", utils.GCSFaultToleranceRedisCleanupFinalizer, err)
-					return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
-				}
-				// Only start the RayCluster reconciliation after the finalizer is added.
-				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, nil
-			}
-		} else {
-			logger.Info(
-				fmt.Sprintf("The RayCluster with GCS enabled, %s, is being deleted. Start to handle the Redis cleanup finalizer %s.",
-					instance.Name, utils.GCSFaultToleranceRedisCleanupFinalizer),
-				"DeletionTimestamp", instance.ObjectMeta.DeletionTimestamp)
-
-			// Delete the head Pod if it exists.
-			headPods, err := r.deleteAllPods(ctx, common.RayClusterHeadPodsAssociationOptions(instance))
-			if err != nil {
-				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
-			}
-			// Delete all worker Pods if they exist.
-			if _, err = r.deleteAllPods(ctx, common.RayClusterWorkerPodsAssociationOptions(instance)); err != nil {
-				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
-			}
-			if len(headPods.Items) > 0 {
-				logger.Info(fmt.Sprintf(
-					"Wait for the head Pod %s to be terminated before initiating the Redis cleanup process. "+
-						"The storage namespace %s in Redis cannot be fully deleted if the GCS process on the head Pod is still writing to it.",
-					headPods.Items[0].Name, headPods.Items[0].Annotations[utils.RayExternalStorageNSAnnotationKey]))
-				// Requeue after 10 seconds because it takes much longer than DefaultRequeueDuration (2 seconds) for the head Pod to be terminated.
-				return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
-			}
-
-			// We can start the Redis cleanup process now because the head Pod has been terminated.
-			filterLabels := client.MatchingLabels{utils.RayClusterLabelKey: instance.Name, utils.RayNodeTypeLabelKey: string(rayv1.RedisCleanupNode)}
-			redisCleanupJobs := batchv1.JobList{}
-			if err := r.List(ctx, &redisCleanupJobs, client.InNamespace(instance.Namespace), filterLabels); err != nil {
-				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
-			}
-
-			if len(redisCleanupJobs.Items) != 0 {
-				// Check whether the Redis cleanup Job has been completed.
-				redisCleanupJob := redisCleanupJobs.Items[0]
-				logger.Info("Redis cleanup Job status", "Job name", redisCleanupJob.Name,
-					"Active", redisCleanupJob.Status.Active, "Succeeded", redisCleanupJob.Status.Succeeded, "Failed", redisCleanupJob.Status.Failed)
-				if condition, finished := utils.IsJobFinished(&redisCleanupJob); finished {
-					controllerutil.RemoveFinalizer(instance, utils.GCSFaultToleranceRedisCleanupFinalizer)
-					if err := r.Update(ctx, instance); err != nil {
-						return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
-					}
-					switch condition {
-					case batchv1.JobComplete:
-						logger.Info(fmt.Sprintf(
-							"The Redis cleanup Job %s has been completed. "+
-								"The storage namespace %s in Redis has been fully deleted.",
-							redisCleanupJob.Name, redisCleanupJob.Annotations[utils.RayExternalStorageNSAnnotationKey]))
-					case batchv1.JobFailed:
-						logger.Info(fmt.Sprintf(
-							"The Redis cleanup Job %s has failed, requeue the RayCluster CR after 5 minute. "+
-								"You should manually delete the storage namespace %s in Redis and remove the RayCluster's finalizer. "+
-								"Please check https://docs.ray.io/en/master/cluster/kubernetes/user-guides/kuberay-gcs-ft.html for more details.",
-							redisCleanupJob.Name, redisCleanupJob.Annotations[utils.RayExternalStorageNSAnnotationKey]))
-					}
-					return ctrl.Result{}, nil
-				}
-				// the redisCleanupJob is still running
-				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, nil
-			}
-			redisCleanupJob := r.buildRedisCleanupJob(ctx, *instance)
-			if err := r.Create(ctx, &redisCleanupJob); err != nil {
-				if errors.IsAlreadyExists(err) {
-					logger.Info("Redis cleanup Job already exists. Requeue the RayCluster CR.")
-					return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, nil
-				}
-				r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateRedisCleanupJob),
-					"Failed to create Redis cleanup Job %s/%s, %v", redisCleanupJob.Namespace, redisCleanupJob.Name, err)
-				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
-			}
-			logger.Info("Created Redis cleanup Job", "name", redisCleanupJob.Name)
-			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedRedisCleanupJob),
-				"Created Redis cleanup Job %s/%s", redisCleanupJob.Namespace, redisCleanupJob.Name)
+	cleanupSteps := r.cleanupSteps()
+	if instance.DeletionTimestamp.IsZero() {
+		added, err := r.addCleanupFinalizers(ctx, instance, cleanupSteps)
+		if err != nil {
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+		if added {
+			// Only start the RayCluster reconciliation after the finalizers are added.
 			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, nil
 		}
+	} else if hasCleanupFinalizers(instance, cleanupSteps) {
+		logger.Info("The RayCluster is being deleted. Start to run its cleanup steps.", "DeletionTimestamp", instance.DeletionTimestamp)
+		return r.reconcileCleanup(ctx, instance, cleanupSteps)
 	}
 
 	if instance.DeletionTimestamp != nil && !instance.DeletionTimestamp.IsZero() {
 		logger.Info("RayCluster is being deleted, just ignore", "cluster name", request.Name)
+		if err := r.cleanupBatchScheduling(ctx, instance); err != nil {
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
 		return ctrl.Result{}, nil
 	}
 
+	// Do not create the resources of a RayCluster before Kueue admits it.
+	if waiting, err := waitsForKueueAdmission(ctx, r.Client, kueueRayCluster{instance}); err != nil || waiting {
+		logger.Info("Wait for Kueue to admit the RayCluster", "cluster name", request.Name, "error", err)
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+
+	terminated, idleTimeoutRemaining, idleTimeoutErr := r.reconcileIdleTimeout(ctx, instance)
+	if idleTimeoutErr != nil {
+		logger.Error(idleTimeoutErr, "Failed to reconcile the idle timeout")
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, idleTimeoutErr
+	}
+	if terminated {
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, nil
+	}
+	workloadStatusRemaining := r.reconcileWorkloadStatus(ctx, instance)
+
+	r.reconcileGCSFaultToleranceReady(ctx, instance)
+	if err := r.reconcileHeadPodRecovery(ctx, instance); err != nil {
+		logger.Error(err, "Failed to reconcile the recovery of the head Pod")
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+
 	reconcileFuncs := []reconcileFunc{
 		r.reconcileAutoscalerServiceAccount,
 		r.reconcileAutoscalerRole,
@@ -342,7 +380,9 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 		r.reconcileHeadService,
 		r.reconcileHeadlessService,
 		r.reconcileServeService,
-		r.reconcilePods,
+		r.reconcilePodDisruptionBudgets,
+		r.reconcileCertificate,
+		r.reconcilePrometheusMonitors,
 	}
 
 	for _, fn := range reconcileFuncs {
@@ -352,6 +392,15 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 			break
 		}
 	}
+	var podsRemaining time.Duration
+	if reconcileErr == nil {
+		if podsRemaining, reconcileErr = r.reconcilePods(ctx, instance); errstd.Is(reconcileErr, utils.ErrNamespaceQuotaExceeded) {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.NamespaceQuotaExceeded),
+				"RayCluster %s/%s exceeds the quota of its namespace, %v", instance.Namespace, instance.Name, reconcileErr)
+		} else if reconcileErr != nil {
+			logger.Error(reconcileErr, "Error reconcile resources", "function name", "reconcilePods")
+		}
+	}
 
 	// Calculate the new status for the RayCluster. Note that the function will deep copy `instance` instead of mutating it.
 	newInstance, calculateErr := r.calculateStatus(ctx, instance, reconcileErr)
@@ -363,9 +412,11 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 		inconsistent, updateErr = r.updateRayClusterStatus(ctx, originalRayClusterInstance, newInstance)
 	}
 
-	// Return error based on order.
+	// Return error based on order. Only other RayClusters can free the quota of the namespace, so a RayCluster that
+	// exceeds it waits for the quota without an error.
+	quotaExceeded := errstd.Is(reconcileErr, utils.ErrNamespaceQuotaExceeded)
 	var err error
-	if reconcileErr != nil {
+	if reconcileErr != nil && !quotaExceeded {
 		err = reconcileErr
 	} else if calculateErr != nil {
 		err = calculateErr
@@ -379,16 +430,362 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
 	}
 
-	// Unconditionally requeue after the number of seconds specified in the
-	// environment variable RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV. If the
-	// environment variable is not set, requeue after the default value.
-	requeueAfterSeconds, err := strconv.Atoi(os.Getenv(utils.RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV))
+	// Unconditionally requeue after the requeue interval in the ray.io/requeue-interval annotation of the RayCluster,
+	// or otherwise after the number of seconds specified in the environment variable
+	// RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV, or after the requeue interval of the operator.
+	requeueAfter := r.requeueInterval
+	if requeueAfterSeconds, err := strconv.Atoi(os.Getenv(utils.RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV)); err == nil {
+		requeueAfter = time.Duration(requeueAfterSeconds) * time.Second
+	} else if requeueAfter == 0 {
+		requeueAfter = utils.RAYCLUSTER_DEFAULT_REQUEUE_SECONDS * time.Second
+	}
+	requeueAfter = utils.GetRequeueInterval(instance, requeueAfter)
+	// Requeue the idle RayCluster in time to terminate it when its idle timeout expires.
+	if idleTimeoutRemaining > 0 && idleTimeoutRemaining < requeueAfter {
+		requeueAfter = idleTimeoutRemaining
+	}
+	// Requeue the RayCluster in time to refresh its workload status.
+	if workloadStatusRemaining > 0 && workloadStatusRemaining < requeueAfter {
+		requeueAfter = workloadStatusRemaining
+	}
+	// Requeue the RayCluster in time to check the Ray nodes of its draining worker Pods again.
+	if podsRemaining > 0 && podsRemaining < requeueAfter {
+		requeueAfter = podsRemaining
+	}
+	// Requeue the RayCluster in time to check the quota of its namespace again.
+	if quotaExceeded && namespaceQuotaRequeueDuration < requeueAfter {
+		requeueAfter = namespaceQuotaRequeueDuration
+	}
+	logger.Info("Unconditional requeue after", "cluster name", request.Name, "seconds", requeueAfter.Seconds())
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reconcileGCSFaultToleranceReady checks that the KubeRay operator can open a TCP connection to the Redis server of a
+// RayCluster with GcsFaultToleranceOptions, and records the result in the GCSFaultToleranceReady condition. The Ray
+// Pods are reconciled even if Redis is unreachable, because the head Pod retries to connect to Redis.
+func (r *RayClusterReconciler) reconcileGCSFaultToleranceReady(ctx context.Context, instance *rayv1.RayCluster) {
+	logger := ctrl.LoggerFrom(ctx)
+	options := instance.Spec.GcsFaultToleranceOptions
+	if options == nil || (instance.Spec.Suspend != nil && *instance.Spec.Suspend) {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, string(rayv1.GCSFaultToleranceReady))
+		return
+	}
+
+	dialRedis := r.dialRedis
+	if dialRedis == nil {
+		dialRedis = (&net.Dialer{}).DialContext
+	}
+	// Ray only connects to the first endpoint of a standalone Redis, while any Sentinel or node of a Redis Cluster
+	// can serve Ray.
+	addresses, err := utils.GetRedisHostPorts(options.RedisAddress)
+	if err == nil && (options.RedisMode == "" || options.RedisMode == rayv1.StandaloneRedisMode) {
+		addresses = addresses[:1]
+	}
+	for _, address := range addresses {
+		var conn net.Conn
+		dialCtx, cancel := context.WithTimeout(ctx, redisDialTimeout)
+		conn, err = dialRedis(dialCtx, "tcp", address)
+		cancel()
+		if err == nil {
+			conn.Close()
+			break
+		}
+	}
+	if err != nil {
+		logger.Info("Failed to connect to the Redis server of GCS fault tolerance", "redisAddress", options.RedisAddress, "error", err)
+		if !meta.IsStatusConditionFalse(instance.Status.Conditions, string(rayv1.GCSFaultToleranceReady)) {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.RedisConnectionFailed),
+				"Failed to connect to the Redis server %s of GCS fault tolerance, %v", options.RedisAddress, err)
+		}
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    string(rayv1.GCSFaultToleranceReady),
+			Status:  metav1.ConditionFalse,
+			Reason:  rayv1.RedisConnectionFailed,
+			Message: fmt.Sprintf("Failed to connect to the Redis server %s: %v", options.RedisAddress, err),
+		})
+		return
+	}
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    string(rayv1.GCSFaultToleranceReady),
+		Status:  metav1.ConditionTrue,
+		Reason:  rayv1.RedisConnected,
+		Message: fmt.Sprintf("Connected to the Redis server %s", options.RedisAddress),
+	})
+}
+
+// reconcileHeadPodRecovery records the restarts of the head Pod of a RayCluster with GCS fault tolerance in the
+// HeadPodRecovering condition. The worker Pods keep running while the head Pod restarts, and their Raylets reconnect
+// to the new GCS server unless RAY_gcs_rpc_server_reconnect_timeout_s expires first. With
+// RestartWorkersAfterReconnectTimeout, the KubeRay operator deletes the worker Pods whose timeout expired once the
+// head Pod recovers, so that they are recreated right away instead of waiting for their Raylets to exit.
+func (r *RayClusterReconciler) reconcileHeadPodRecovery(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if !common.IsGCSFaultToleranceEnabled(*instance) || (instance.Spec.Suspend != nil && *instance.Spec.Suspend) {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, string(rayv1.HeadPodRecovering))
+		return nil
+	}
+
+	headPod, err := common.GetRayClusterHeadPod(ctx, r, instance)
+	if err != nil {
+		return err
+	}
+	headPodReady := headPod != nil && utils.IsRunningAndReady(headPod)
+	condition := meta.FindStatusCondition(instance.Status.Conditions, string(rayv1.HeadPodRecovering))
+	if condition == nil {
+		// The head Pod has not been ready yet, so it has nothing to recover from.
+		if headPodReady {
+			meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+				Type:    string(rayv1.HeadPodRecovering),
+				Status:  metav1.ConditionFalse,
+				Reason:  rayv1.HeadPodRunningAndReady,
+				Message: "The head Pod is running and ready",
+			})
+		}
+		return nil
+	}
+
+	if condition.Status != metav1.ConditionTrue {
+		// The head Pod may also have been replaced by a new one that is already ready.
+		headPodReplaced := headPod != nil && instance.Status.Head.PodName != "" && instance.Status.Head.PodName != headPod.Name
+		if headPodReady && !headPodReplaced {
+			return nil
+		}
+		// The recovery started at the latest when the new head Pod was created.
+		recoveryStartTime := metav1.Now()
+		if headPodReplaced && headPod.CreationTimestamp.Before(&recoveryStartTime) {
+			recoveryStartTime = headPod.CreationTimestamp
+		}
+		logger.Info("The head Pod restarted", "headPod", instance.Status.Head.PodName)
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.HeadPodRestarted),
+			"The head Pod %s restarted; the worker Pods reconnect to the GCS server once the head Pod recovers", instance.Status.Head.PodName)
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:               string(rayv1.HeadPodRecovering),
+			Status:             metav1.ConditionTrue,
+			Reason:             rayv1.HeadPodRestarted,
+			Message:            "The head Pod restarted and is not ready yet",
+			LastTransitionTime: recoveryStartTime,
+		})
+		condition = meta.FindStatusCondition(instance.Status.Conditions, string(rayv1.HeadPodRecovering))
+	}
+	if !headPodReady {
+		return nil
+	}
+
+	// The head Pod recovered.
+	downtime := time.Since(condition.LastTransitionTime.Time).Round(time.Second)
+	numRestartedWorkers := 0
+	if instance.Spec.GcsFaultToleranceOptions != nil && instance.Spec.GcsFaultToleranceOptions.RestartWorkersAfterReconnectTimeout {
+		workerPods := corev1.PodList{}
+		filterLabels := client.MatchingLabels{utils.RayClusterLabelKey: instance.Name, utils.RayNodeTypeLabelKey: string(rayv1.WorkerNode)}
+		if err := r.List(ctx, &workerPods, client.InNamespace(instance.Namespace), filterLabels); err != nil {
+			return err
+		}
+		for _, workerPod := range workerPods.Items {
+			// Worker Pods created during the recovery connect to the new GCS server.
+			if workerPod.DeletionTimestamp != nil || !workerPod.CreationTimestamp.Before(&condition.LastTransitionTime) {
+				continue
+			}
+			if timeout := gcsReconnectTimeout(workerPod); timeout >= downtime {
+				continue
+			}
+			if err := r.deletePod(ctx, instance, &workerPod, workerPod.Labels[utils.RayNodeGroupLabelKey]); err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod),
+					"Failed deleting worker Pod %s/%s whose GCS reconnection timed out, %v", workerPod.Namespace, workerPod.Name, err)
+				return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+			}
+			numRestartedWorkers++
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedWorkerPod),
+				"Deleted worker Pod %s/%s whose GCS reconnection timed out", workerPod.Namespace, workerPod.Name)
+		}
+	}
+	message := fmt.Sprintf("The head Pod recovered after %s, and %d worker Pods were restarted", downtime, numRestartedWorkers)
+	logger.Info(message)
+	r.Recorder.Event(instance, corev1.EventTypeNormal, string(utils.HeadPodRecovered), message)
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    string(rayv1.HeadPodRecovering),
+		Status:  metav1.ConditionFalse,
+		Reason:  rayv1.HeadPodRecovered,
+		Message: message,
+	})
+	return nil
+}
+
+// gcsReconnectTimeout returns how long the Raylet of a worker Pod tries to reconnect to the GCS server before it exits.
+func gcsReconnectTimeout(workerPod corev1.Pod) time.Duration {
+	timeoutSeconds := utils.DefaultRayGcsReconnectTimeoutS
+	for _, env := range workerPod.Spec.Containers[utils.RayContainerIndex].Env {
+		if env.Name != utils.RAY_GCS_RPC_SERVER_RECONNECT_TIMEOUT_S {
+			continue
+		}
+		if seconds, err := strconv.Atoi(env.Value); err == nil {
+			timeoutSeconds = seconds
+		}
+	}
+	return time.Duration(timeoutSeconds) * time.Second
+}
+
+// isOwnedByRayJobOrRayService returns whether the controller of the RayCluster is a RayJob or a RayService.
+func isOwnedByRayJobOrRayService(instance *rayv1.RayCluster) bool {
+	owner := metav1.GetControllerOf(instance)
+	return owner != nil && owner.APIVersion == rayv1.GroupVersion.String() && (owner.Kind == "RayJob" || owner.Kind == "RayService")
+}
+
+// reconcileIdleTimeout polls the Ray dashboard for the workload of a RayCluster with IdleTimeoutSeconds, records
+// since when the Ray cluster has been idle in the RayClusterIdle condition, and deletes or suspends the RayCluster
+// once it has been idle for IdleTimeoutSeconds. It returns whether the RayCluster was terminated and, if the Ray
+// cluster is idle, the time left until the RayCluster is terminated. The RayClusters of RayJobs and RayServices are
+// never terminated, because their owners manage their lifecycle.
+func (r *RayClusterReconciler) reconcileIdleTimeout(ctx context.Context, instance *rayv1.RayCluster) (bool, time.Duration, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	if instance.Spec.IdleTimeoutSeconds == nil || (instance.Spec.Suspend != nil && *instance.Spec.Suspend) || isOwnedByRayJobOrRayService(instance) {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, string(rayv1.RayClusterIdle))
+		return false, 0, nil
+	}
+
+	// The workload of the Ray cluster is unknown until the head Pod is ready, so the idle time is not counted.
+	headPod, err := common.GetRayClusterHeadPod(ctx, r, instance)
+	if err != nil {
+		return false, 0, err
+	}
+	if headPod == nil || !utils.IsRunningAndReady(headPod) {
+		return false, 0, nil
+	}
+	rayDashboardClient, err := r.newRayDashboardClient(ctx, instance)
+	if err != nil {
+		logger.Info("Failed to create the Ray dashboard client, skip the idle timeout", "error", err)
+		return false, 0, nil
+	}
+	workload, err := rayDashboardClient.GetClusterWorkload(ctx)
+	if err != nil {
+		// Never terminate a RayCluster whose workload is unknown.
+		logger.Info("Failed to get the workload of the Ray cluster, skip the idle timeout", "error", err)
+		return false, 0, nil
+	}
+	if !workload.IsIdle() {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    string(rayv1.RayClusterIdle),
+			Status:  metav1.ConditionFalse,
+			Reason:  rayv1.RayClusterActiveWorkload,
+			Message: "The Ray cluster has active jobs, running tasks, or alive actors",
+		})
+		return false, 0, nil
+	}
+
+	action := instance.Spec.IdleTimeoutAction
+	if action == "" {
+		action = rayv1.DeleteIdleTimeoutAction
+	}
+	idleTimeout := time.Duration(*instance.Spec.IdleTimeoutSeconds) * time.Second
+	idleCondition := meta.FindStatusCondition(instance.Status.Conditions, string(rayv1.RayClusterIdle))
+	if idleCondition == nil || idleCondition.Status != metav1.ConditionTrue {
+		now := metav1.Now()
+		terminationTime := now.Add(idleTimeout).UTC().Format(time.RFC3339)
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:               string(rayv1.RayClusterIdle),
+			Status:             metav1.ConditionTrue,
+			Reason:             rayv1.RayClusterNoActiveWorkload,
+			LastTransitionTime: now,
+			Message: fmt.Sprintf("The Ray cluster has no active job, running task, or alive actor. "+
+				"The action %s will be taken on the RayCluster at %s unless the Ray cluster becomes active", action, terminationTime),
+		})
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.RayClusterIdle),
+			"The Ray cluster of RayCluster %s/%s is idle, the action %s will be taken at %s", instance.Namespace, instance.Name, action, terminationTime)
+		return false, idleTimeout, nil
+	}
+	if remaining := time.Until(idleCondition.LastTransitionTime.Add(idleTimeout)); remaining > 0 {
+		return false, remaining, nil
+	}
+
+	logger.Info("The RayCluster has been idle for longer than the idle timeout", "idleTimeoutSeconds", *instance.Spec.IdleTimeoutSeconds, "action", action)
+	if action == rayv1.SuspendIdleTimeoutAction {
+		instance.Spec.Suspend = ptr.To(true)
+		if err := r.Update(ctx, instance); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToTerminateIdleRayCluster),
+				"Failed to suspend idle RayCluster %s/%s, %v", instance.Namespace, instance.Name, err)
+			return false, 0, err
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.SuspendedIdleRayCluster),
+			"Suspended RayCluster %s/%s after it was idle for %d seconds", instance.Namespace, instance.Name, *instance.Spec.IdleTimeoutSeconds)
+		return true, 0, nil
+	}
+	if err := r.Delete(ctx, instance); err != nil && !errors.IsNotFound(err) {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToTerminateIdleRayCluster),
+			"Failed to delete idle RayCluster %s/%s, %v", instance.Namespace, instance.Name, err)
+		return false, 0, err
+	}
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedIdleRayCluster),
+		"Deleted RayCluster %s/%s after it was idle for %d seconds", instance.Namespace, instance.Name, *instance.Spec.IdleTimeoutSeconds)
+	return true, 0, nil
+}
+
+// reconcileWorkloadStatus refreshes the workload status of the RayCluster from its Ray dashboard once the last snapshot
+// is older than the workload status interval. It returns the time left until the next refresh, or 0 if the workload
+// status is not reported. The workload status is best effort, so the last snapshot is kept if the Ray dashboard fails.
+func (r *RayClusterReconciler) reconcileWorkloadStatus(ctx context.Context, instance *rayv1.RayCluster) time.Duration {
+	logger := ctrl.LoggerFrom(ctx)
+	if r.workloadStatusInterval <= 0 || (instance.Spec.Suspend != nil && *instance.Spec.Suspend) {
+		instance.Status.Workload = nil
+		return 0
+	}
+	if workload := instance.Status.Workload; workload != nil && workload.LastUpdateTime != nil {
+		if remaining := time.Until(workload.LastUpdateTime.Add(r.workloadStatusInterval)); remaining > 0 {
+			return remaining
+		}
+	}
+
+	headPod, err := common.GetRayClusterHeadPod(ctx, r, instance)
 	if err != nil {
-		logger.Info(fmt.Sprintf("Environment variable %s is not set, using default value of %d seconds", utils.RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV, utils.RAYCLUSTER_DEFAULT_REQUEUE_SECONDS), "cluster name", request.Name)
-		requeueAfterSeconds = utils.RAYCLUSTER_DEFAULT_REQUEUE_SECONDS
+		logger.Info("Failed to get the head Pod, skip the workload status", "error", err)
+		return 0
+	}
+	if headPod == nil || !utils.IsRunningAndReady(headPod) {
+		return 0
+	}
+	rayDashboardClient, err := r.newRayDashboardClient(ctx, instance)
+	if err != nil {
+		logger.Info("Failed to create the Ray dashboard client, skip the workload status", "error", err)
+		return 0
+	}
+	clusterWorkload, err := rayDashboardClient.GetClusterWorkload(ctx)
+	if err != nil {
+		logger.Info("Failed to get the workload of the Ray cluster, skip the workload status", "error", err)
+		return 0
+	}
+
+	now := metav1.Now()
+	workload := &rayv1.RayClusterWorkloadStatus{
+		LastUpdateTime: &now,
+		ActiveJobs:     int32(clusterWorkload.NumActiveJobs),   //nolint:gosec // The number of jobs fits in an int32.
+		RunningTasks:   int32(clusterWorkload.NumRunningTasks), //nolint:gosec // The number of tasks fits in an int32.
+		AliveActors:    int32(clusterWorkload.NumAliveActors),  //nolint:gosec // The number of actors fits in an int32.
+	}
+	// Ray Serve may not be running on the Ray cluster, in which case it has no Serve application.
+	if serveDetails, err := rayDashboardClient.GetServeDetails(ctx); err != nil {
+		logger.Info("Failed to get the Serve applications of the Ray cluster", "error", err)
+	} else {
+		workload.ServeApplications = int32(len(serveDetails.Applications)) //nolint:gosec // The number of applications fits in an int32.
+	}
+	if utilization, err := rayDashboardClient.GetClusterUtilization(ctx); err != nil {
+		logger.Info("Failed to get the resource utilization of the Ray cluster", "error", err)
+	} else if utilization != nil {
+		workload.UsedResources = rayResourcesToResourceList(utilization.Used)
+		workload.TotalResources = rayResourcesToResourceList(utilization.Total)
+	}
+	instance.Status.Workload = workload
+	return r.workloadStatusInterval
+}
+
+// rayResourcesToResourceList converts the amounts of the logical resources of a Ray cluster, which can be fractional,
+// to a ResourceList keyed by the names of the resources in Ray.
+func rayResourcesToResourceList(resources map[string]float64) corev1.ResourceList {
+	resourceList := corev1.ResourceList{}
+	for name, amount := range resources {
+		resourceList[corev1.ResourceName(name)] = *resource.NewMilliQuantity(int64(math.Round(amount*1000)), resource.DecimalSI)
 	}
-	logger.Info("Unconditional requeue after", "cluster name", request.Name, "seconds", requeueAfterSeconds)
-	return ctrl.Result{RequeueAfter: time.Duration(requeueAfterSeconds) * time.Second}, nil
+	return resourceList
 }
 
 // Checks whether the old and new RayClusterStatus are inconsistent by comparing different fields. If the only
@@ -434,12 +831,36 @@ func (r *RayClusterReconciler) inconsistentRayClusterStatus(ctx context.Context,
 		logger.Info("inconsistentRayClusterStatus", "old conditions", oldStatus.Conditions, "new conditions", newStatus.Conditions)
 		return true
 	}
+	if !reflect.DeepEqual(oldStatus.Workload, newStatus.Workload) {
+		logger.Info("inconsistentRayClusterStatus", "old workload", oldStatus.Workload, "new workload", newStatus.Workload)
+		return true
+	}
+	if !reflect.DeepEqual(oldStatus.WorkerGroupStatuses, newStatus.WorkerGroupStatuses) {
+		logger.Info("inconsistentRayClusterStatus", "old worker group statuses", oldStatus.WorkerGroupStatuses, "new worker group statuses", newStatus.WorkerGroupStatuses)
+		return true
+	}
 	return false
 }
 
 func (r *RayClusterReconciler) reconcileIngress(ctx context.Context, instance *rayv1.RayCluster) error {
 	logger := ctrl.LoggerFrom(ctx)
 	logger.Info("Reconciling Ingress")
+	if options := instance.Spec.HeadGroupSpec.Ingress; options != nil {
+		// The RayService reconciles the Ingress of its RayClusters, because it routes to the RayCluster that serves.
+		if getCreatorCRDType(*instance) == utils.RayServiceCRD {
+			return nil
+		}
+		backends, err := common.GetIngressBackendsForRayCluster(*instance)
+		if err != nil {
+			return err
+		}
+		labels := map[string]string{
+			utils.RayClusterLabelKey:                instance.Name,
+			utils.KubernetesApplicationNameLabelKey: utils.ApplicationName,
+			utils.KubernetesCreatedByLabelKey:       utils.ComponentName,
+		}
+		return reconcileManagedIngress(ctx, r.Client, r.Recorder, r.Scheme, instance, *options, labels, backends)
+	}
 	if instance.Spec.HeadGroupSpec.EnableIngress == nil || !*instance.Spec.HeadGroupSpec.EnableIngress {
 		return nil
 	}
@@ -516,6 +937,172 @@ func (r *RayClusterReconciler) reconcileIngressKubernetes(ctx context.Context, i
 	return nil
 }
 
+// reconcileManagedIngress creates or updates the Ingress or the Gateway API HTTPRoutes of HeadIngressOptions, which
+// route to the Ray dashboard and Ray Serve of the owner. The objects of the other type that the owner controls are
+// deleted, so that users can switch between the types. The objects are garbage collected with the owner.
+func reconcileManagedIngress(ctx context.Context, c client.Client, recorder record.EventRecorder, scheme *k8sruntime.Scheme, owner client.Object, options rayv1.HeadIngressOptions, labels map[string]string, backends common.IngressBackends) error {
+	objectMeta := metav1.ObjectMeta{Name: owner.GetName(), Namespace: owner.GetNamespace(), Labels: labels}
+	routeNames := []string{common.GetDashboardHTTPRouteName(owner.GetName()), common.GetServeHTTPRouteName(owner.GetName())}
+
+	if options.Type == rayv1.HTTPRouteIngressType {
+		ingress := &networkingv1.Ingress{}
+		key := types.NamespacedName{Namespace: owner.GetNamespace(), Name: utils.CheckName(utils.GenerateIngressName(owner.GetName()))}
+		if err := c.Get(ctx, key, ingress); err == nil && metav1.IsControlledBy(ingress, owner) {
+			if err := c.Delete(ctx, ingress); err != nil && !errors.IsNotFound(err) {
+				recorder.Eventf(owner, corev1.EventTypeWarning, string(utils.FailedToDeleteIngress),
+					"Failed deleting Ingress %s/%s, %v", ingress.Namespace, ingress.Name, err)
+				return err
+			}
+			recorder.Eventf(owner, corev1.EventTypeNormal, string(utils.DeletedIngress),
+				"Deleted Ingress %s/%s", ingress.Namespace, ingress.Name)
+		} else if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+
+		routes, err := common.BuildHTTPRoutes(options, objectMeta, backends)
+		if err != nil {
+			return err
+		}
+		for _, route := range routes {
+			if err := reconcileHTTPRoute(ctx, c, recorder, scheme, owner, route); err != nil {
+				return err
+			}
+		}
+		// The HTTPRoute of Ray Serve is only created if the serve host is set.
+		return deleteHTTPRoutes(ctx, c, recorder, owner, routeNames[len(routes):])
+	}
+
+	if err := deleteHTTPRoutes(ctx, c, recorder, owner, routeNames); err != nil {
+		return err
+	}
+	desired := common.BuildManagedIngress(options, objectMeta, backends)
+	if err := controllerutil.SetControllerReference(owner, desired, scheme); err != nil {
+		return err
+	}
+	ingress := &networkingv1.Ingress{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, ingress); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if err := applyObject(ctx, c, desired); err != nil {
+			if errors.IsAlreadyExists(err) {
+				return nil
+			}
+			recorder.Eventf(owner, corev1.EventTypeWarning, string(utils.FailedToCreateIngress),
+				"Failed creating Ingress %s/%s, %v", desired.Namespace, desired.Name, err)
+			return err
+		}
+		recorder.Eventf(owner, corev1.EventTypeNormal, string(utils.CreatedIngress),
+			"Created Ingress %s/%s", desired.Namespace, desired.Name)
+		return nil
+	}
+
+	if !metav1.IsControlledBy(ingress, owner) {
+		return nil
+	}
+	// The ingress class that the default IngressClass admission plugin sets is kept.
+	if desired.Spec.IngressClassName == nil {
+		desired.Spec.IngressClassName = ingress.Spec.IngressClassName
+	}
+	// Only the annotations that the KubeRay operator sets are compared, because ingress controllers and cert-manager
+	// may add their own, which server-side apply keeps.
+	updated := !reflect.DeepEqual(ingress.Spec, desired.Spec)
+	for key, value := range desired.Annotations {
+		if ingress.Annotations[key] != value {
+			updated = true
+		}
+	}
+	if !updated {
+		return nil
+	}
+	if err := applyObject(ctx, c, desired); err != nil {
+		recorder.Eventf(owner, corev1.EventTypeWarning, string(utils.FailedToUpdateIngress),
+			"Failed updating Ingress %s/%s, %v", ingress.Namespace, ingress.Name, err)
+		return err
+	}
+	recorder.Eventf(owner, corev1.EventTypeNormal, string(utils.UpdatedIngress),
+		"Updated Ingress %s/%s", ingress.Namespace, ingress.Name)
+	return nil
+}
+
+// reconcileHTTPRoute creates an HTTPRoute, or updates the fields that the KubeRay operator sets if the owner controls
+// it.
+func reconcileHTTPRoute(ctx context.Context, c client.Client, recorder record.EventRecorder, scheme *k8sruntime.Scheme, owner client.Object, desired *common.HTTPRoute) error {
+	if err := controllerutil.SetControllerReference(owner, desired, scheme); err != nil {
+		return err
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(common.HTTPRouteGVK)
+	if err := c.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, obj); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if err := applyUnstructured(ctx, c, desired); err != nil {
+			if errors.IsAlreadyExists(err) {
+				return nil
+			}
+			recorder.Eventf(owner, corev1.EventTypeWarning, string(utils.FailedToCreateHTTPRoute),
+				"Failed creating HTTPRoute %s/%s, %v", desired.Namespace, desired.Name, err)
+			return err
+		}
+		recorder.Eventf(owner, corev1.EventTypeNormal, string(utils.CreatedHTTPRoute),
+			"Created HTTPRoute %s/%s", desired.Namespace, desired.Name)
+		return nil
+	}
+
+	route := &common.HTTPRoute{}
+	if err := k8sruntime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, route); err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(route, owner) {
+		return nil
+	}
+	updated := !reflect.DeepEqual(route.Spec, desired.Spec)
+	for key, value := range desired.Annotations {
+		if route.Annotations[key] != value {
+			updated = true
+		}
+	}
+	if !updated {
+		return nil
+	}
+	if err := applyUnstructured(ctx, c, desired); err != nil {
+		recorder.Eventf(owner, corev1.EventTypeWarning, string(utils.FailedToUpdateHTTPRoute),
+			"Failed updating HTTPRoute %s/%s, %v", desired.Namespace, desired.Name, err)
+		return err
+	}
+	recorder.Eventf(owner, corev1.EventTypeNormal, string(utils.UpdatedHTTPRoute),
+		"Updated HTTPRoute %s/%s", desired.Namespace, desired.Name)
+	return nil
+}
+
+// deleteHTTPRoutes deletes the HTTPRoutes with the given names that the owner controls. It does nothing if the
+// Gateway API is not installed.
+func deleteHTTPRoutes(ctx context.Context, c client.Client, recorder record.EventRecorder, owner client.Object, names []string) error {
+	for _, name := range names {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(common.HTTPRouteGVK)
+		if err := c.Get(ctx, types.NamespacedName{Namespace: owner.GetNamespace(), Name: name}, obj); err != nil {
+			if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+			return err
+		}
+		if !metav1.IsControlledBy(obj, owner) {
+			continue
+		}
+		if err := c.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+			recorder.Eventf(owner, corev1.EventTypeWarning, string(utils.FailedToDeleteHTTPRoute),
+				"Failed deleting HTTPRoute %s/%s, %v", obj.GetNamespace(), name, err)
+			return err
+		}
+		recorder.Eventf(owner, corev1.EventTypeNormal, string(utils.DeletedHTTPRoute),
+			"Deleted HTTPRoute %s/%s", obj.GetNamespace(), name)
+	}
+	return nil
+}
+
 // Return nil only when the head service successfully created or already exists.
 func (r *RayClusterReconciler) reconcileHeadService(ctx context.Context, instance *rayv1.RayCluster) error {
 	logger := ctrl.LoggerFrom(ctx)
@@ -530,7 +1117,7 @@ func (r *RayClusterReconciler) reconcileHeadService(ctx context.Context, instanc
 	if len(services.Items) != 0 {
 		if len(services.Items) == 1 {
 			logger.Info("reconcileHeadService", "1 head service found", services.Items[0].Name)
-			return nil
+			return r.updateHeadService(ctx, instance, &services.Items[0])
 		}
 		// This should never happen. This protects against the case that users manually create service with the same label.
 		if len(services.Items) > 1 {
@@ -539,23 +1126,7 @@ func (r *RayClusterReconciler) reconcileHeadService(ctx context.Context, instanc
 		}
 	} else {
 		// Create head service if there's no existing one in the cluster.
-		labels := make(map[string]string)
-		if val, ok := instance.Spec.HeadGroupSpec.Template.ObjectMeta.Labels[utils.KubernetesApplicationNameLabelKey]; ok {
-			labels[utils.KubernetesApplicationNameLabelKey] = val
-		}
-		annotations := make(map[string]string)
-		// TODO (kevin85421): KubeRay has already exposed the entire head service (#1040) to users.
-		// We may consider deprecating this field when we bump the CRD version.
-		for k, v := range instance.Spec.HeadServiceAnnotations {
-			annotations[k] = v
-		}
-		headSvc, err := common.BuildServiceForHeadPod(ctx, *instance, labels, annotations)
-		// TODO (kevin85421): Provide a detailed and actionable error message. For example, which port is missing?
-		if len(headSvc.Spec.Ports) == 0 {
-			logger.Info("Ray head service does not have any ports set up. Service specification: %v", headSvc.Spec)
-			return fmt.Errorf("Ray head service does not have any ports set up. Service specification: %v", headSvc.Spec)
-		}
-
+		headSvc, err := r.buildHeadService(ctx, instance)
 		if err != nil {
 			return err
 		}
@@ -568,11 +1139,123 @@ func (r *RayClusterReconciler) reconcileHeadService(ctx context.Context, instanc
 	return nil
 }
 
-// Return nil only when the serve service successfully created or already exists.
-func (r *RayClusterReconciler) reconcileServeService(ctx context.Context, instance *rayv1.RayCluster) error {
-	// Only reconcile the K8s service for Ray Serve when the "ray.io/enable-serve-service" annotation is set to true.
-	if enableServeServiceValue, exist := instance.Annotations[utils.EnableServeServiceKey]; !exist || enableServeServiceValue != utils.EnableServeServiceTrue {
-		return nil
+func (r *RayClusterReconciler) buildHeadService(ctx context.Context, instance *rayv1.RayCluster) (*corev1.Service, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	labels := make(map[string]string)
+	if val, ok := instance.Spec.HeadGroupSpec.Template.ObjectMeta.Labels[utils.KubernetesApplicationNameLabelKey]; ok {
+		labels[utils.KubernetesApplicationNameLabelKey] = val
+	}
+	annotations := make(map[string]string)
+	// TODO (kevin85421): KubeRay has already exposed the entire head service (#1040) to users.
+	// We may consider deprecating this field when we bump the CRD version.
+	for k, v := range instance.Spec.HeadServiceAnnotations {
+		annotations[k] = v
+	}
+	headSvc, err := common.BuildServiceForHeadPod(ctx, *instance, labels, annotations)
+	if err != nil {
+		return nil, err
+	}
+	// TODO (kevin85421): Provide a detailed and actionable error message. For example, which port is missing?
+	if len(headSvc.Spec.Ports) == 0 {
+		logger.Info("Ray head service does not have any ports set up. Service specification: %v", headSvc.Spec)
+		return nil, fmt.Errorf("Ray head service does not have any ports set up. Service specification: %v", headSvc.Spec)
+	}
+	return headSvc, nil
+}
+
+// updateHeadService keeps the type, ports, external traffic policy and annotations of an existing head service in
+// sync with the RayCluster, so that users can expose the head Pod through a LoadBalancer or extra ports after the
+// RayCluster is created. The node ports and the cluster IP that Kubernetes allocated are kept. A headless head
+// service cannot be changed into a service with a cluster IP and vice versa, so it is deleted and recreated in the
+// next reconciliation.
+func (r *RayClusterReconciler) updateHeadService(ctx context.Context, instance *rayv1.RayCluster, headSvc *corev1.Service) error {
+	logger := ctrl.LoggerFrom(ctx)
+	desiredSvc, err := r.buildHeadService(ctx, instance)
+	if err != nil {
+		return err
+	}
+
+	if (headSvc.Spec.ClusterIP == corev1.ClusterIPNone) != (desiredSvc.Spec.ClusterIP == corev1.ClusterIPNone) {
+		logger.Info("Deleting the head service to change its cluster IP", "name", headSvc.Name, "type", desiredSvc.Spec.Type)
+		if err := r.Delete(ctx, headSvc); err != nil && !errors.IsNotFound(err) {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteService),
+				"Failed deleting service %s/%s to change its type to %s, %v", headSvc.Namespace, headSvc.Name, desiredSvc.Spec.Type, err)
+			return err
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedService),
+			"Deleted service %s/%s to change its type to %s", headSvc.Namespace, headSvc.Name, desiredSvc.Spec.Type)
+		return nil
+	}
+
+	updatedSvc := headSvc.DeepCopy()
+	updatedSvc.Spec.Type = desiredSvc.Spec.Type
+	if updatedSvc.Spec.Type == "" {
+		updatedSvc.Spec.Type = corev1.ServiceTypeClusterIP
+	}
+	updatedSvc.Spec.Ports = desiredSvc.Spec.Ports
+	for i, port := range updatedSvc.Spec.Ports {
+		// Default the ports like the API server does, so that the head service is not updated in every reconciliation.
+		if port.Protocol == "" {
+			updatedSvc.Spec.Ports[i].Protocol = corev1.ProtocolTCP
+		}
+		if port.TargetPort == (intstr.IntOrString{}) {
+			updatedSvc.Spec.Ports[i].TargetPort = intstr.FromInt32(port.Port)
+		}
+	}
+	if updatedSvc.Spec.Type == corev1.ServiceTypeClusterIP {
+		// Node ports and external traffic policies are only valid for NodePort and LoadBalancer services.
+		updatedSvc.Spec.ExternalTrafficPolicy = ""
+	} else {
+		for i, port := range updatedSvc.Spec.Ports {
+			if port.NodePort != 0 {
+				continue
+			}
+			for _, existingPort := range headSvc.Spec.Ports {
+				if existingPort.Name == port.Name {
+					updatedSvc.Spec.Ports[i].NodePort = existingPort.NodePort
+				}
+			}
+		}
+		if desiredSvc.Spec.ExternalTrafficPolicy != "" {
+			updatedSvc.Spec.ExternalTrafficPolicy = desiredSvc.Spec.ExternalTrafficPolicy
+		}
+	}
+	// Annotations that other controllers, e.g. cloud load balancer controllers, add to the service are kept.
+	for k, v := range desiredSvc.Annotations {
+		if updatedSvc.Annotations == nil {
+			updatedSvc.Annotations = make(map[string]string)
+		}
+		updatedSvc.Annotations[k] = v
+	}
+	if reflect.DeepEqual(updatedSvc.Spec, headSvc.Spec) && reflect.DeepEqual(updatedSvc.Annotations, headSvc.Annotations) {
+		return nil
+	}
+
+	// Server-side apply only changes the fields of the desired head service, so the allocated node ports and cluster
+	// IP, and the annotations of other controllers are kept.
+	desiredSvc.Name = headSvc.Name
+	if err := controllerutil.SetControllerReference(instance, desiredSvc, r.Scheme); err != nil {
+		return err
+	}
+	if err := applyObject(ctx, r.Client, desiredSvc); err != nil {
+		if errors.IsAlreadyExists(err) {
+			logger.Info("The head service is not controlled by the RayCluster, no need to update", "name", headSvc.Name)
+			return nil
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToUpdateService),
+			"Failed updating service %s/%s, %v", updatedSvc.Namespace, updatedSvc.Name, err)
+		return err
+	}
+	logger.Info("Updated the head service of the RayCluster", "name", updatedSvc.Name)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.UpdatedService), "Updated service %s/%s", updatedSvc.Namespace, updatedSvc.Name)
+	return nil
+}
+
+// Return nil only when the serve service successfully created or already exists.
+func (r *RayClusterReconciler) reconcileServeService(ctx context.Context, instance *rayv1.RayCluster) error {
+	// Only reconcile the K8s service for Ray Serve when the "ray.io/enable-serve-service" annotation is set to true.
+	if enableServeServiceValue, exist := instance.Annotations[utils.EnableServeServiceKey]; !exist || enableServeServiceValue != utils.EnableServeServiceTrue {
+		return nil
 	}
 
 	// Retrieve the Service from the Kubernetes cluster with the name and namespace.
@@ -592,17 +1275,21 @@ func (r *RayClusterReconciler) reconcileServeService(ctx context.Context, instan
 			return err
 		}
 		// create service
-		return r.Create(ctx, svc)
+		if err := applyObject(ctx, r.Client, svc); err != nil && !errors.IsAlreadyExists(err) {
+			return err
+		}
+		return nil
 	}
 	return err
 }
 
-// Return nil only when the headless service for multi-host worker groups is successfully created or already exists.
+// Return nil only when the headless service for multi-host and StatefulSet worker groups is successfully created or already exists.
 func (r *RayClusterReconciler) reconcileHeadlessService(ctx context.Context, instance *rayv1.RayCluster) error {
-	// Check if there are worker groups with NumOfHosts > 1 in the cluster
+	// Check if there are worker groups with NumOfHosts > 1 in the cluster. The headless service also gives the Pods
+	// of StatefulSet worker groups their stable DNS names.
 	isMultiHost := false
 	for _, workerGroup := range instance.Spec.WorkerGroupSpecs {
-		if workerGroup.NumOfHosts > 1 {
+		if workerGroup.NumOfHosts > 1 || workerGroup.WorkloadType == rayv1.StatefulSetWorkerGroupWorkloadType {
 			isMultiHost = true
 			break
 		}
@@ -631,7 +1318,254 @@ func (r *RayClusterReconciler) reconcileHeadlessService(ctx context.Context, ins
 	return nil
 }
 
-func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv1.RayCluster) error {
+// reconcilePodDisruptionBudgets creates or updates the PodDisruptionBudgets of the head Pod and the worker groups if the
+// RayCluster has PodDisruptionBudgets enabled, and deletes the PodDisruptionBudgets that are no longer needed.
+func (r *RayClusterReconciler) reconcilePodDisruptionBudgets(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	desiredPDBs := make(map[string]*policyv1.PodDisruptionBudget)
+	if ptr.Deref(instance.Spec.EnablePodDisruptionBudgets, r.enablePodDisruptionBudgets) {
+		pdb := common.BuildPodDisruptionBudgetForHeadPod(*instance)
+		desiredPDBs[pdb.Name] = pdb
+		for _, worker := range instance.Spec.WorkerGroupSpecs {
+			pdb := common.BuildPodDisruptionBudgetForWorkerGroup(*instance, worker)
+			desiredPDBs[pdb.Name] = pdb
+		}
+	}
+
+	pdbs := policyv1.PodDisruptionBudgetList{}
+	if err := r.List(ctx, &pdbs, client.InNamespace(instance.Namespace), client.MatchingLabels{utils.RayClusterLabelKey: instance.Name}); err != nil {
+		return err
+	}
+	for i := range pdbs.Items {
+		pdb := &pdbs.Items[i]
+		if !metav1.IsControlledBy(pdb, instance) {
+			continue
+		}
+		desiredPDB, ok := desiredPDBs[pdb.Name]
+		if !ok {
+			if err := r.Delete(ctx, pdb); err != nil && !errors.IsNotFound(err) {
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeletePodDisruptionBudget),
+					"Failed deleting PodDisruptionBudget %s/%s, %v", pdb.Namespace, pdb.Name, err)
+				return err
+			}
+			logger.Info("reconcilePodDisruptionBudgets", "Deleted PodDisruptionBudget", pdb.Name)
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedPodDisruptionBudget),
+				"Deleted PodDisruptionBudget %s/%s", pdb.Namespace, pdb.Name)
+			continue
+		}
+		delete(desiredPDBs, pdb.Name)
+		if reflect.DeepEqual(pdb.Spec.MaxUnavailable, desiredPDB.Spec.MaxUnavailable) && reflect.DeepEqual(pdb.Spec.MinAvailable, desiredPDB.Spec.MinAvailable) {
+			continue
+		}
+		if err := controllerutil.SetControllerReference(instance, desiredPDB, r.Scheme); err != nil {
+			return err
+		}
+		if err := applyObject(ctx, r.Client, desiredPDB); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToUpdatePodDisruptionBudget),
+				"Failed updating PodDisruptionBudget %s/%s, %v", pdb.Namespace, pdb.Name, err)
+			return err
+		}
+		logger.Info("reconcilePodDisruptionBudgets", "Updated PodDisruptionBudget", pdb.Name)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.UpdatedPodDisruptionBudget),
+			"Updated PodDisruptionBudget %s/%s", pdb.Namespace, pdb.Name)
+	}
+
+	for _, pdb := range desiredPDBs {
+		if err := controllerutil.SetControllerReference(instance, pdb, r.Scheme); err != nil {
+			return err
+		}
+		if err := applyObject(ctx, r.Client, pdb); err != nil {
+			if errors.IsAlreadyExists(err) {
+				continue
+			}
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreatePodDisruptionBudget),
+				"Failed creating PodDisruptionBudget %s/%s, %v", pdb.Namespace, pdb.Name, err)
+			return err
+		}
+		logger.Info("reconcilePodDisruptionBudgets", "Created PodDisruptionBudget", pdb.Name)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedPodDisruptionBudget),
+			"Created PodDisruptionBudget %s/%s", pdb.Namespace, pdb.Name)
+	}
+	return nil
+}
+
+// reconcileCertificate creates or updates the cert-manager Certificate of the CA of the RayCluster if it has TLS
+// enabled. cert-manager stores the CA in the Secret that the init containers of the Pods of the RayCluster mount to
+// sign the certificates of their Ray processes. The Certificate is garbage collected with the RayCluster.
+func (r *RayClusterReconciler) reconcileCertificate(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if !common.IsTLSEnabled(*instance) {
+		return nil
+	}
+
+	desired := common.BuildCertificate(*instance)
+	if err := controllerutil.SetControllerReference(instance, desired, r.Scheme); err != nil {
+		return err
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(common.CertificateGVK)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, obj); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if err := applyUnstructured(ctx, r.Client, desired); err != nil {
+			if errors.IsAlreadyExists(err) {
+				return nil
+			}
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateCertificate),
+				"Failed creating Certificate %s/%s, %v", desired.Namespace, desired.Name, err)
+			return err
+		}
+		logger.Info("reconcileCertificate", "Created Certificate", desired.Name)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedCertificate),
+			"Created Certificate %s/%s", desired.Namespace, desired.Name)
+		return nil
+	}
+
+	certificate := &common.Certificate{}
+	if err := k8sruntime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, certificate); err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(certificate, instance) || reflect.DeepEqual(certificate.Spec, desired.Spec) {
+		return nil
+	}
+	if err := applyUnstructured(ctx, r.Client, desired); err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToUpdateCertificate),
+			"Failed updating Certificate %s/%s, %v", desired.Namespace, desired.Name, err)
+		return err
+	}
+	logger.Info("reconcileCertificate", "Updated Certificate", desired.Name)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.UpdatedCertificate),
+		"Updated Certificate %s/%s", desired.Namespace, desired.Name)
+	return nil
+}
+
+// reconcilePrometheusMonitors creates or updates the Prometheus Operator ServiceMonitor of the head Pod and PodMonitor
+// of the worker Pods if the RayCluster or the KubeRay operator enables them. If the RayCluster disables them in
+// prometheusMonitors, the monitors that it controls are deleted. The monitors are garbage collected with the RayCluster.
+func (r *RayClusterReconciler) reconcilePrometheusMonitors(ctx context.Context, instance *rayv1.RayCluster) error {
+	options := instance.Spec.PrometheusMonitors
+	enabled := r.enablePrometheusMonitors
+	if options != nil {
+		enabled = ptr.Deref(options.Enabled, r.enablePrometheusMonitors)
+	}
+
+	monitors := []struct {
+		desired metav1.Object
+		current metav1.Object
+		gvk     schema.GroupVersionKind
+	}{
+		{common.BuildServiceMonitorForHeadService(*instance), &common.ServiceMonitor{}, common.ServiceMonitorGVK},
+		{common.BuildPodMonitorForWorkerPods(*instance), &common.PodMonitor{}, common.PodMonitorGVK},
+	}
+	for _, monitor := range monitors {
+		if enabled {
+			if err := r.reconcilePrometheusMonitor(ctx, instance, monitor.desired, monitor.current, monitor.gvk); err != nil {
+				return err
+			}
+		} else if options != nil {
+			// Only RayClusters that configure the monitors look them up, so that the Prometheus Operator is not
+			// needed to run RayClusters without monitors.
+			if err := r.deletePrometheusMonitor(ctx, instance, monitor.desired.GetName(), monitor.gvk); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *RayClusterReconciler) reconcilePrometheusMonitor(ctx context.Context, instance *rayv1.RayCluster, desired metav1.Object, current metav1.Object, gvk schema.GroupVersionKind) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if err := controllerutil.SetControllerReference(instance, desired, r.Scheme); err != nil {
+		return err
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}, obj); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if err := applyUnstructured(ctx, r.Client, desired); err != nil {
+			if errors.IsAlreadyExists(err) {
+				return nil
+			}
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreatePrometheusMonitor),
+				"Failed creating %s %s/%s, %v", gvk.Kind, desired.GetNamespace(), desired.GetName(), err)
+			return err
+		}
+		logger.Info("reconcilePrometheusMonitors", "Created "+gvk.Kind, desired.GetName())
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedPrometheusMonitor),
+			"Created %s %s/%s", gvk.Kind, desired.GetNamespace(), desired.GetName())
+		return nil
+	}
+
+	// The current monitor is decoded into the type of the desired monitor, which drops the fields that the KubeRay
+	// operator does not set, such as the defaults of the relabelings.
+	if err := k8sruntime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, current); err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(current, instance) {
+		return nil
+	}
+	desiredContent, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return err
+	}
+	currentContent, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(current)
+	if err != nil {
+		return err
+	}
+	updated := !reflect.DeepEqual(currentContent["spec"], desiredContent["spec"])
+	for key, value := range desired.GetLabels() {
+		if current.GetLabels()[key] != value {
+			updated = true
+		}
+	}
+	if !updated {
+		return nil
+	}
+	if err := applyUnstructured(ctx, r.Client, desired); err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToUpdatePrometheusMonitor),
+			"Failed updating %s %s/%s, %v", gvk.Kind, desired.GetNamespace(), desired.GetName(), err)
+		return err
+	}
+	logger.Info("reconcilePrometheusMonitors", "Updated "+gvk.Kind, desired.GetName())
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.UpdatedPrometheusMonitor),
+		"Updated %s %s/%s", gvk.Kind, desired.GetNamespace(), desired.GetName())
+	return nil
+}
+
+func (r *RayClusterReconciler) deletePrometheusMonitor(ctx context.Context, instance *rayv1.RayCluster, name string, gvk schema.GroupVersionKind) error {
+	logger := ctrl.LoggerFrom(ctx)
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: name}, obj); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(obj, instance) {
+		return nil
+	}
+	if err := r.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeletePrometheusMonitor),
+			"Failed deleting %s %s/%s, %v", gvk.Kind, instance.Namespace, name, err)
+		return err
+	}
+	logger.Info("reconcilePrometheusMonitors", "Deleted "+gvk.Kind, name)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedPrometheusMonitor),
+		"Deleted %s %s/%s", gvk.Kind, instance.Namespace, name)
+	return nil
+}
+
+// reconcilePods creates and deletes the Pods of the RayCluster. It returns how long to wait before reconciling the
+// RayCluster again if it waits for the Ray nodes of worker Pods to drain or for the backoff of failed worker Pods, or 0
+// otherwise.
+func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv1.RayCluster) (time.Duration, error) {
 	logger := ctrl.LoggerFrom(ctx)
 
 	// if RayCluster is suspending, delete all pods and skip reconcile
@@ -639,48 +1573,64 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 	statusConditionGateEnabled := features.Enabled(features.RayClusterStatusConditions)
 	if suspendStatus == rayv1.RayClusterSuspending ||
 		(!statusConditionGateEnabled && instance.Spec.Suspend != nil && *instance.Spec.Suspend) {
+		// Delete the StatefulSets of worker groups first, so that they do not recreate the deleted Pods.
+		if err := r.DeleteAllOf(ctx, &appsv1.StatefulSet{}, client.InNamespace(instance.Namespace),
+			client.MatchingLabels{utils.RayClusterLabelKey: instance.Name}); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerStatefulSet),
+				"Failed deleting worker StatefulSets due to suspension for RayCluster %s/%s, %v",
+				instance.Namespace, instance.Name, err)
+			return 0, errstd.Join(utils.ErrFailedDeleteAllPods, err)
+		}
 		if _, err := r.deleteAllPods(ctx, common.RayClusterAllPodsAssociationOptions(instance)); err != nil {
 			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeletePod),
 				"Failed deleting Pods due to suspension for RayCluster %s/%s, %v",
 				instance.Namespace, instance.Name, err)
-			return errstd.Join(utils.ErrFailedDeleteAllPods, err)
+			return 0, errstd.Join(utils.ErrFailedDeleteAllPods, err)
 		}
 
 		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedPod),
 			"Deleted Pods for RayCluster %s/%s due to suspension",
 			instance.Namespace, instance.Name)
-		return nil
+		// Release the resources that the batch scheduler reserved for the suspended RayCluster.
+		return 0, r.cleanupBatchScheduling(ctx, instance)
 	}
 
 	if statusConditionGateEnabled {
 		if suspendStatus == rayv1.RayClusterSuspended {
-			return nil // stop reconcilePods because the cluster is suspended.
+			return 0, nil // stop reconcilePods because the cluster is suspended.
 		}
 		// (suspendStatus != rayv1.RayClusterSuspending) is always true here because it has been checked above.
 		if instance.Spec.Suspend != nil && *instance.Spec.Suspend {
-			return nil // stop reconcilePods because the cluster is going to suspend.
+			return 0, nil // stop reconcilePods because the cluster is going to suspend.
 		}
 	}
 
 	// check if all the pods exist
 	headPods := corev1.PodList{}
 	if err := r.List(ctx, &headPods, common.RayClusterHeadPodsAssociationOptions(instance).ToListOptions()...); err != nil {
-		return err
+		return 0, err
 	}
 	// check if the batch scheduler integration is enabled
 	// call the scheduler plugin if so
 	if r.BatchSchedulerMgr != nil {
 		if scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(instance); err == nil {
 			if err := scheduler.DoBatchSchedulingOnSubmission(ctx, instance); err != nil {
-				return err
+				if reason := schedulerinterface.BatchSchedulingRejectedReason(err); reason != "" {
+					r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.BatchSchedulingRejected),
+						"The %s batch scheduler rejected RayCluster %s/%s, %v", scheduler.Name(), instance.Namespace, instance.Name, err)
+				}
+				return 0, err
 			}
 		} else {
-			return err
+			return 0, err
 		}
 	}
 
 	// Reconcile head Pod
-	if len(headPods.Items) == 1 {
+	if !r.rayClusterScaleExpectation.IsSatisfied(ctx, instance.Namespace, instance.Name, expectations.HeadGroup) {
+		// The Pod event that satisfies the expectations triggers the next reconciliation.
+		logger.Info("reconcilePods", "The informer cache has not observed the creation or deletion of the head Pod", instance.Name)
+	} else if len(headPods.Items) == 1 {
 		headPod := headPods.Items[0]
 		logger.Info("reconcilePods", "Found 1 head Pod", headPod.Name, "Pod status", headPod.Status.Phase,
 			"Pod status reason", headPod.Status.Reason,
@@ -690,26 +1640,34 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 		shouldDelete, reason := shouldDeletePod(headPod, rayv1.HeadNode)
 		logger.Info("reconcilePods", "head Pod", headPod.Name, "shouldDelete", shouldDelete, "reason", reason)
 		if shouldDelete {
-			if err := r.Delete(ctx, &headPod); err != nil {
+			if err := r.deletePod(ctx, instance, &headPod, expectations.HeadGroup); err != nil {
 				r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteHeadPod),
 					"Failed deleting head Pod %s/%s; Pod status: %s; Pod restart policy: %s; Ray container terminated status: %v, %v",
 					headPod.Namespace, headPod.Name, headPod.Status.Phase, headPod.Spec.RestartPolicy, getRayContainerStateTerminated(headPod), err)
-				return errstd.Join(utils.ErrFailedDeleteHeadPod, err)
+				return 0, errstd.Join(utils.ErrFailedDeleteHeadPod, err)
 			}
 			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedHeadPod),
 				"Deleted head Pod %s/%s; Pod status: %s; Pod restart policy: %s; Ray container terminated status: %v",
 				headPod.Namespace, headPod.Name, headPod.Status.Phase, headPod.Spec.RestartPolicy, getRayContainerStateTerminated(headPod))
-			return errstd.New(reason)
+			return 0, errstd.New(reason)
 		}
 	} else if len(headPods.Items) == 0 {
 		// Create head Pod if it does not exist.
 		logger.Info("reconcilePods", "Found 0 head Pods; creating a head Pod for the RayCluster.", instance.Name)
+		if err := r.checkNamespaceQuota(ctx, instance); err != nil {
+			return 0, err
+		}
 		common.CreatedClustersCounterInc(instance.Namespace)
 		if err := r.createHeadPod(ctx, *instance); err != nil {
 			common.FailedClustersCounterInc(instance.Namespace)
-			return errstd.Join(utils.ErrFailedCreateHeadPod, err)
+			return 0, errstd.Join(utils.ErrFailedCreateHeadPod, err)
 		}
 		common.SuccessfulClustersCounterInc(instance.Namespace)
+		// The status still records the previous head Pod if it was deleted or evicted, but not if the RayCluster was
+		// suspended, because the status is updated while the RayCluster is suspended.
+		if instance.Status.Head.PodName != "" {
+			common.HeadPodRestartsCounterInc(instance.Namespace)
+		}
 	} else if len(headPods.Items) > 1 {
 		logger.Info("reconcilePods", fmt.Sprintf("Found %d head Pods; deleting extra head Pods.", len(headPods.Items)), instance.Name)
 		// TODO (kevin85421): In-place update may not be a good idea.
@@ -724,48 +1682,100 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 		}
 		// delete all the extra head pod pods
 		for _, extraHeadPodToDelete := range headPods.Items {
-			if err := r.Delete(ctx, &extraHeadPodToDelete); err != nil {
-				return errstd.Join(utils.ErrFailedDeleteHeadPod, err)
+			if err := r.deletePod(ctx, instance, &extraHeadPodToDelete, expectations.HeadGroup); err != nil {
+				return 0, errstd.Join(utils.ErrFailedDeleteHeadPod, err)
 			}
 		}
 	}
 
 	// Reconcile worker pods now
+	if err := r.deleteStaleWorkerGroupStatefulSets(ctx, instance); err != nil {
+		return 0, err
+	}
+	// numDrainingWorkerPods is the number of worker Pods that wait for their Ray nodes to drain before they are deleted.
+	numDrainingWorkerPods := 0
+	// recreateRemaining is how long the failed worker Pods of the worker groups wait to be recreated, at the least.
+	var recreateRemaining time.Duration
 	for _, worker := range instance.Spec.WorkerGroupSpecs {
 		// workerReplicas will store the target number of pods for this worker group.
 		var workerReplicas int32 = utils.GetWorkerGroupDesiredReplicas(ctx, worker)
 		logger.Info("reconcilePods", "desired workerReplicas (always adhering to minReplicas/maxReplica)", workerReplicas, "worker group", worker.GroupName, "maxReplicas", worker.MaxReplicas, "minReplicas", worker.MinReplicas, "replicas", worker.Replicas)
 
+		// The StatefulSet of the worker group creates and deletes its Pods.
+		if worker.WorkloadType == rayv1.StatefulSetWorkerGroupWorkloadType {
+			if err := r.reconcileWorkerGroupStatefulSet(ctx, instance, worker); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		if !r.rayClusterScaleExpectation.IsSatisfied(ctx, instance.Namespace, instance.Name, worker.GroupName) {
+			logger.Info("reconcilePods", "The informer cache has not observed the creation or deletion of worker Pods of group", worker.GroupName)
+			continue
+		}
+
+		r.reconcileSpotFallback(instance, worker)
+
 		workerPods := corev1.PodList{}
 		if err := r.List(ctx, &workerPods, common.RayClusterGroupPodsAssociationOptions(instance, worker.GroupName).ToListOptions()...); err != nil {
-			return err
+			return 0, err
+		}
+		if err := r.updateHeadReachableConditions(ctx, instance, workerPods.Items); err != nil {
+			return 0, err
+		}
+
+		// The Pods of each replica of a multi-host worker group are created and deleted together.
+		if features.Enabled(features.RayMultiHostIndexing) && worker.NumOfHosts > 1 {
+			if err := r.reconcileMultiHostWorkerGroup(ctx, instance, worker, workerPods.Items); err != nil {
+				return 0, err
+			}
+			continue
 		}
 
-		// Delete unhealthy worker Pods.
+		// Delete unhealthy worker Pods, unless the recreate policy of the worker group keeps them or delays their recreation.
 		deletedWorkers := make(map[string]struct{})
 		deleted := struct{}{}
 		numDeletedUnhealthyWorkerPods := 0
+		recreateAfter := recreateFailedWorkerPodsAfter(instance, worker)
 		for _, workerPod := range workerPods.Items {
 			shouldDelete, reason := shouldDeletePod(workerPod, rayv1.WorkerNode)
 			logger.Info("reconcilePods", "worker Pod", workerPod.Name, "shouldDelete", shouldDelete, "reason", reason)
+			if shouldDelete && worker.RecreatePolicy != nil && worker.RecreatePolicy.Type == rayv1.NeverWorkerGroupRecreatePolicyType {
+				logger.Info("reconcilePods", "The recreate policy of the worker group keeps the failed worker Pod", workerPod.Name)
+				continue
+			}
+			if shouldDelete && recreateAfter > 0 {
+				logger.Info("reconcilePods", "The failed worker Pod is recreated after a backoff", workerPod.Name, "backoff", recreateAfter)
+				if recreateRemaining == 0 || recreateAfter < recreateRemaining {
+					recreateRemaining = recreateAfter
+				}
+				break
+			}
 			if shouldDelete {
 				numDeletedUnhealthyWorkerPods++
 				deletedWorkers[workerPod.Name] = deleted
-				if err := r.Delete(ctx, &workerPod); err != nil {
+				if err := r.deletePod(ctx, instance, &workerPod, worker.GroupName); err != nil {
 					r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod),
 						"Failed deleting worker Pod %s/%s; Pod status: %s; Pod restart policy: %s; Ray container terminated status: %v, %v",
 						workerPod.Namespace, workerPod.Name, workerPod.Status.Phase, workerPod.Spec.RestartPolicy, getRayContainerStateTerminated(workerPod), err)
-					return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+					return 0, errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
 				}
 				r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedWorkerPod),
 					"Deleted worker Pod %s/%s; Pod status: %s; Pod restart policy: %s; Ray container terminated status: %v",
 					workerPod.Namespace, workerPod.Name, workerPod.Status.Phase, workerPod.Spec.RestartPolicy, getRayContainerStateTerminated(workerPod))
+				if worker.SpotFallback != nil && workerPod.Labels[utils.RayNodeCapacityTypeLabelKey] == utils.SpotCapacityType && isPodPreempted(workerPod) {
+					r.recordSpotPreemption(instance, worker)
+				}
 			}
 		}
 
 		// If we delete unhealthy Pods, we will not create new Pods in this reconciliation.
 		if numDeletedUnhealthyWorkerPods > 0 {
-			return fmt.Errorf("Delete %d unhealthy worker Pods", numDeletedUnhealthyWorkerPods)
+			r.recordWorkerPodsRecreation(instance, worker)
+			return 0, fmt.Errorf("Delete %d unhealthy worker Pods", numDeletedUnhealthyWorkerPods)
+		}
+		if recreateAfter <= 0 {
+			resetWorkerGroupRecreations(instance, worker)
 		}
 
 		// Always remove the specified WorkersToDelete - regardless of the value of Replicas.
@@ -776,11 +1786,11 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 			pod.Name = podsToDelete
 			pod.Namespace = utils.GetNamespace(instance.ObjectMeta)
 			logger.Info("Deleting pod", "namespace", pod.Namespace, "name", pod.Name)
-			if err := r.Delete(ctx, &pod); err != nil {
+			if err := r.deletePod(ctx, instance, &pod, worker.GroupName); err != nil {
 				if !errors.IsNotFound(err) {
 					logger.Info("reconcilePods", "Fail to delete Pod", pod.Name, "error", err)
 					r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod), "Failed deleting pod %s/%s, %v", pod.Namespace, pod.Name, err)
-					return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+					return 0, errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
 				}
 				logger.Info("reconcilePods", "The worker Pod has already been deleted", pod.Name)
 			} else {
@@ -803,18 +1813,39 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 			worker.NumOfHosts = 1
 		}
 		numExpectedPods := int(workerReplicas * worker.NumOfHosts)
+
+		// While a rolling update replaces out-of-date Pods, it also scales the worker group to the desired number of Pods.
+		if isWorkerGroupRollingUpdate(worker) {
+			updating, numDrainingPods, err := r.rollingUpdateWorkerPods(ctx, instance, worker, runningPods.Items, numExpectedPods)
+			if err != nil {
+				return 0, err
+			}
+			numDrainingWorkerPods += numDrainingPods
+			if updating {
+				continue
+			}
+		}
+
 		diff := numExpectedPods - len(runningPods.Items)
 
 		logger.Info("reconcilePods", "workerReplicas", workerReplicas, "NumOfHosts", worker.NumOfHosts, "runningPods", len(runningPods.Items), "diff", diff)
 
 		if diff > 0 {
 			// pods need to be added
+			// The rest of the worker Pods are added once the pending worker Pods run, whose status updates requeue the RayCluster.
+			if numPodsToCreate := getNumWorkerPodsToCreate(worker, runningPods.Items, diff); numPodsToCreate < diff {
+				logger.Info("reconcilePods", "The upscaling mode of the worker group limits the number of workers to add", numPodsToCreate, "Worker group", worker.GroupName)
+				diff = numPodsToCreate
+			}
 			logger.Info("reconcilePods", "Number workers to add", diff, "Worker group", worker.GroupName)
+			if err := r.checkNamespaceQuota(ctx, instance); err != nil {
+				return 0, err
+			}
 			// create all workers of this group
 			for i := 0; i < diff; i++ {
 				logger.Info("reconcilePods", "creating worker for group", worker.GroupName, fmt.Sprintf("index %d", i), fmt.Sprintf("in total %d", diff))
 				if err := r.createWorkerPod(ctx, *instance, *worker.DeepCopy()); err != nil {
-					return errstd.Join(utils.ErrFailedCreateWorkerPod, err)
+					return 0, errstd.Join(utils.ErrFailedCreateWorkerPod, err)
 				}
 			}
 		} else if diff == 0 {
@@ -842,13 +1873,25 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 				// diff < 0 means that we need to delete some Pods to meet the desired number of replicas.
 				randomlyRemovedWorkers := -diff
 				logger.Info("reconcilePods", "Number workers to delete randomly", randomlyRemovedWorkers, "Worker group", worker.GroupName)
+				// Delete the Pods whose Ray nodes are idle before the Pods that run tasks and actors.
+				if err := r.sortWorkerPodsForScaleDown(ctx, instance, runningPods.Items); err != nil {
+					return 0, err
+				}
 				for i := 0; i < randomlyRemovedWorkers; i++ {
 					randomPodToDelete := runningPods.Items[i]
+					drained, err := r.drainWorkerPod(ctx, instance, worker, &randomPodToDelete)
+					if err != nil {
+						return 0, err
+					}
+					if !drained {
+						numDrainingWorkerPods++
+						continue
+					}
 					logger.Info("Randomly deleting Pod", "progress", fmt.Sprintf("%d / %d", i+1, randomlyRemovedWorkers), "with name", randomPodToDelete.Name)
-					if err := r.Delete(ctx, &randomPodToDelete); err != nil {
+					if err := r.deletePod(ctx, instance, &randomPodToDelete, worker.GroupName); err != nil {
 						if !errors.IsNotFound(err) {
 							r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod), "Failed deleting Pod %s/%s, %v", randomPodToDelete.Namespace, randomPodToDelete.Name, err)
-							return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+							return 0, errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
 						}
 						logger.Info("reconcilePods", "The worker Pod has already been deleted", randomPodToDelete.Name)
 					}
@@ -859,56 +1902,618 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 			}
 		}
 	}
-	return nil
-}
-
-// shouldDeletePod returns whether the Pod should be deleted and the reason
-//
-// @param pod: The Pod to be checked.
-// @param nodeType: The type of the node that the Pod belongs to (head or worker).
-//
-// @return: shouldDelete (bool), reason (string)
-// (1) shouldDelete: Whether the Pod should be deleted.
-// (2) reason: The reason why the Pod should or should not be deleted.
-func shouldDeletePod(pod corev1.Pod, nodeType rayv1.RayNodeType) (bool, string) {
-	// Based on the logic of the change of the status of the K8S pod, the following judgment is made.
-	// https://github.com/kubernetes/kubernetes/blob/3361895612dac57044d5dacc029d2ace1865479c/pkg/kubelet/kubelet_pods.go#L1556
 
-	// If the Pod's status is `Failed` or `Succeeded`, the Pod will not restart and we can safely delete it.
-	if pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded {
-		reason := fmt.Sprintf(
-			"The %s Pod %s status is %s which is a terminal state. "+
-				"KubeRay will delete the Pod and create new Pods in the next reconciliation if necessary.",
-			nodeType, pod.Name, pod.Status.Phase)
-		return true, reason
+	// Requeue to check the Ray nodes again, because their workload changes without any change to the Pods.
+	if numDrainingWorkerPods > 0 {
+		logger.Info("reconcilePods", "Waiting for the Ray nodes of worker Pods to drain", numDrainingWorkerPods)
+		if recreateRemaining == 0 || drainWorkerPodsRequeueDuration < recreateRemaining {
+			return drainWorkerPodsRequeueDuration, nil
+		}
+	}
+	// Requeue to recreate the failed worker Pods once their backoff has passed.
+	if recreateRemaining > 0 {
+		logger.Info("reconcilePods", "Waiting to recreate the failed worker Pods", recreateRemaining)
 	}
+	return recreateRemaining, nil
+}
 
-	rayContainerTerminated := getRayContainerStateTerminated(pod)
-	if pod.Status.Phase == corev1.PodRunning && rayContainerTerminated != nil {
-		if pod.Spec.RestartPolicy == corev1.RestartPolicyNever {
-			reason := fmt.Sprintf(
-				"The Pod status of the %s Pod %s is %s, and the Ray container terminated status is %v. "+
-					"The container is unable to restart due to its restart policy %s, so KubeRay will delete it.",
-				nodeType, pod.Name, pod.Status.Phase, rayContainerTerminated, pod.Spec.RestartPolicy)
-			return true, reason
+// getNumWorkerPodsToCreate returns how many of the diff missing worker Pods of a worker group to create now. A worker
+// group with the Conservative upscaling mode has at most as many pending worker Pods as running ones, and at least one.
+func getNumWorkerPodsToCreate(worker rayv1.WorkerGroupSpec, workerPods []corev1.Pod, diff int) int {
+	if worker.UpscalingMode == nil || *worker.UpscalingMode != rayv1.ConservativeUpscalingMode {
+		return diff
+	}
+	numPendingPods, numRunningPods := 0, 0
+	for _, pod := range workerPods {
+		switch pod.Status.Phase {
+		case "", corev1.PodPending:
+			numPendingPods++
+		case corev1.PodRunning:
+			numRunningPods++
 		}
-		// If restart policy is set to `Always` or `OnFailure`, KubeRay will not delete the Pod.
-		reason := fmt.Sprintf(
-			"The Pod status of the %s Pod %s is %s, and the Ray container terminated status is %v. "+
-				"However, KubeRay will not delete the Pod because its restartPolicy is set to %s and it should be able to restart automatically.",
-			nodeType, pod.Name, pod.Status.Phase, rayContainerTerminated, pod.Spec.RestartPolicy)
-		return false, reason
 	}
+	return max(min(diff, max(numRunningPods, 1)-numPendingPods), 0)
+}
 
-	// TODO (kevin85421): Consider deleting a Pod if its Ray container restarts excessively, as this might
-	// suggest an unhealthy Kubernetes node. Deleting and then recreating the Pod might allow it to be
-	// scheduled on a different node.
-	//
-	// (1) Head Pod:
-	// It's aggressive to delete a head Pod that is not in a terminated state (i.e., `Failed` or `Succeeded`).
-	// We should only delete a head Pod when GCS fault tolerance is enabled, and drain the head Pod before
-	// deleting it.
-	//
+// reconcileWorkerGroupStatefulSet creates the StatefulSet of a worker group with the StatefulSet workload type, or
+// updates its replicas and Pod template to match the worker group.
+func (r *RayClusterReconciler) reconcileWorkerGroupStatefulSet(ctx context.Context, instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	desiredStatefulSet, err := r.buildWorkerGroupStatefulSet(ctx, *instance, worker)
+	if err != nil {
+		return err
+	}
+
+	statefulSet := appsv1.StatefulSet{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(&desiredStatefulSet), &statefulSet); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if err := applyObject(ctx, r.Client, &desiredStatefulSet); err != nil {
+			if errors.IsAlreadyExists(err) {
+				return nil
+			}
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateWorkerStatefulSet),
+				"Failed creating StatefulSet %s/%s for worker group %s, %v", desiredStatefulSet.Namespace, desiredStatefulSet.Name, worker.GroupName, err)
+			return errstd.Join(utils.ErrFailedCreateWorkerPod, err)
+		}
+		logger.Info("reconcileWorkerGroupStatefulSet", "Created StatefulSet", desiredStatefulSet.Name, "worker group", worker.GroupName)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedWorkerStatefulSet),
+			"Created StatefulSet %s/%s for worker group %s", desiredStatefulSet.Namespace, desiredStatefulSet.Name, worker.GroupName)
+		return nil
+	}
+
+	// Like for Pod worker groups, only changes to the worker group spec change the Pod template hash, so other changes
+	// to the RayCluster do not roll the worker Pods. The volume claim templates of a StatefulSet cannot be updated.
+	podTemplateHash := desiredStatefulSet.Spec.Template.Annotations[utils.RayWorkerGroupPodTemplateHashAnnotationKey]
+	if ptr.Deref(statefulSet.Spec.Replicas, 1) == *desiredStatefulSet.Spec.Replicas &&
+		statefulSet.Spec.Template.Annotations[utils.RayWorkerGroupPodTemplateHashAnnotationKey] == podTemplateHash &&
+		statefulSet.Spec.UpdateStrategy.Type == desiredStatefulSet.Spec.UpdateStrategy.Type {
+		return nil
+	}
+	desiredStatefulSet.Spec.VolumeClaimTemplates = statefulSet.Spec.VolumeClaimTemplates
+	if err := applyObject(ctx, r.Client, &desiredStatefulSet); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToUpdateWorkerStatefulSet),
+			"Failed updating StatefulSet %s/%s for worker group %s, %v", statefulSet.Namespace, statefulSet.Name, worker.GroupName, err)
+		return err
+	}
+	logger.Info("reconcileWorkerGroupStatefulSet", "Updated StatefulSet", statefulSet.Name, "worker group", worker.GroupName, "replicas", *desiredStatefulSet.Spec.Replicas)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.UpdatedWorkerStatefulSet),
+		"Updated StatefulSet %s/%s for worker group %s with %d replicas", statefulSet.Namespace, statefulSet.Name, worker.GroupName, *desiredStatefulSet.Spec.Replicas)
+	return nil
+}
+
+// deleteStaleWorkerGroupStatefulSets deletes the StatefulSets of worker groups that were removed from the RayCluster
+// or no longer use the StatefulSet workload type. The StatefulSets are deleted in the foreground, so the RayCluster is
+// reconciled again once their Pods are gone.
+func (r *RayClusterReconciler) deleteStaleWorkerGroupStatefulSets(ctx context.Context, instance *rayv1.RayCluster) error {
+	statefulSets := appsv1.StatefulSetList{}
+	if err := r.List(ctx, &statefulSets, client.InNamespace(instance.Namespace), client.MatchingLabels{utils.RayClusterLabelKey: instance.Name}); err != nil {
+		return err
+	}
+	statefulSetGroups := make(map[string]struct{})
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		if worker.WorkloadType == rayv1.StatefulSetWorkerGroupWorkloadType {
+			statefulSetGroups[worker.GroupName] = struct{}{}
+		}
+	}
+	for _, statefulSet := range statefulSets.Items {
+		if _, ok := statefulSetGroups[statefulSet.Labels[utils.RayNodeGroupLabelKey]]; ok || !statefulSet.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, &statefulSet, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil && !errors.IsNotFound(err) {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerStatefulSet),
+				"Failed deleting StatefulSet %s/%s, %v", statefulSet.Namespace, statefulSet.Name, err)
+			return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedWorkerStatefulSet),
+			"Deleted StatefulSet %s/%s of removed worker group %s", statefulSet.Namespace, statefulSet.Name, statefulSet.Labels[utils.RayNodeGroupLabelKey])
+	}
+	return nil
+}
+
+// reconcileMultiHostWorkerGroup reconciles a worker group whose replicas span multiple hosts, e.g. TPU slices. Each
+// replica is a group of numOfHosts Pods that share a replica name and a replica index. A replica is all or nothing:
+// if one of its Pods fails, is deleted, or is in the workersToDelete of the worker group, all the Pods of the replica
+// are deleted, and missing replicas are created as a whole. Replicas are not replaced when the worker group changes.
+func (r *RayClusterReconciler) reconcileMultiHostWorkerGroup(ctx context.Context, instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec, workerPods []corev1.Pod) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	replicaPods := make(map[string][]corev1.Pod)
+	for _, pod := range workerPods {
+		replicaName := pod.Labels[utils.RayWorkerReplicaNameLabelKey]
+		replicaPods[replicaName] = append(replicaPods[replicaName], pod)
+	}
+	workersToDelete := make(map[string]struct{}, len(worker.ScaleStrategy.WorkersToDelete))
+	for _, podName := range worker.ScaleStrategy.WorkersToDelete {
+		workersToDelete[podName] = struct{}{}
+	}
+
+	numDeletedUnhealthyReplicas := 0
+	// replicaIndices maps the names of the healthy replicas to their indices.
+	replicaIndices := make(map[string]int)
+	for replicaName, pods := range replicaPods {
+		reason, unhealthy := getWorkerReplicaDeletionReason(worker, replicaName, pods, workersToDelete)
+		if reason == "" {
+			replicaIndex, err := strconv.Atoi(pods[0].Labels[utils.RayWorkerReplicaIndexLabelKey])
+			if err == nil {
+				replicaIndices[replicaName] = replicaIndex
+				continue
+			}
+			reason, unhealthy = "The replica has no valid replica index", true
+		}
+		if unhealthy {
+			numDeletedUnhealthyReplicas++
+		}
+		if err := r.deleteWorkerReplica(ctx, instance, worker, replicaName, pods, reason); err != nil {
+			return err
+		}
+	}
+
+	// If we delete unhealthy replicas, we will not create new replicas in this reconciliation.
+	if numDeletedUnhealthyReplicas > 0 {
+		return fmt.Errorf("delete %d unhealthy replicas of worker group %s", numDeletedUnhealthyReplicas, worker.GroupName)
+	}
+
+	diff := int(utils.GetWorkerGroupDesiredReplicas(ctx, worker)) - len(replicaIndices)
+	logger.Info("reconcileMultiHostWorkerGroup", "worker group", worker.GroupName, "replicas", len(replicaIndices), "diff", diff)
+	if diff > 0 {
+		if err := r.checkNamespaceQuota(ctx, instance); err != nil {
+			return err
+		}
+		// New replicas take the lowest replica indices that are not in use.
+		usedReplicaIndices := make(map[int]struct{}, len(replicaIndices))
+		for _, replicaIndex := range replicaIndices {
+			usedReplicaIndices[replicaIndex] = struct{}{}
+		}
+		for replicaIndex := 0; diff > 0; replicaIndex++ {
+			if _, ok := usedReplicaIndices[replicaIndex]; ok {
+				continue
+			}
+			if err := r.createWorkerReplica(ctx, instance, worker, replicaIndex); err != nil {
+				return errstd.Join(utils.ErrFailedCreateWorkerPod, err)
+			}
+			diff--
+		}
+	} else if diff < 0 {
+		// Like for other worker groups, the Ray autoscaler decides which replicas to delete if it is enabled.
+		enableInTreeAutoscaling := (instance.Spec.EnableInTreeAutoscaling != nil) && (*instance.Spec.EnableInTreeAutoscaling)
+		if enableInTreeAutoscaling && strings.ToLower(os.Getenv(utils.ENABLE_RANDOM_POD_DELETE)) != "true" {
+			logger.Info(fmt.Sprintf("Random Pod deletion is disabled for cluster %s. The only decision-maker for Pod deletions is Autoscaler.", instance.Name))
+			return nil
+		}
+		// Delete the replicas with the highest replica indices.
+		replicaNames := make([]string, 0, len(replicaIndices))
+		for replicaName := range replicaIndices {
+			replicaNames = append(replicaNames, replicaName)
+		}
+		sort.Slice(replicaNames, func(i, j int) bool {
+			return replicaIndices[replicaNames[i]] > replicaIndices[replicaNames[j]]
+		})
+		for _, replicaName := range replicaNames[:-diff] {
+			if err := r.deleteWorkerReplica(ctx, instance, worker, replicaName, replicaPods[replicaName], "The worker group scales down"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getWorkerReplicaDeletionReason returns why the Pods of a replica of a multi-host worker group should be deleted, or
+// an empty string if the replica is complete and healthy, and whether the replica is deleted because it is unhealthy.
+func getWorkerReplicaDeletionReason(worker rayv1.WorkerGroupSpec, replicaName string, pods []corev1.Pod, workersToDelete map[string]struct{}) (string, bool) {
+	if replicaName == "" {
+		return "The worker Pods do not belong to a replica", true
+	}
+	if len(pods) != int(worker.NumOfHosts) {
+		return fmt.Sprintf("The replica has %d of %d worker Pods", len(pods), worker.NumOfHosts), true
+	}
+	for _, pod := range pods {
+		if !pod.DeletionTimestamp.IsZero() {
+			return fmt.Sprintf("Worker Pod %s is terminating", pod.Name), true
+		}
+		if shouldDelete, reason := shouldDeletePod(pod, rayv1.WorkerNode); shouldDelete {
+			return reason, true
+		}
+	}
+	for _, pod := range pods {
+		if _, ok := workersToDelete[pod.Name]; ok {
+			return fmt.Sprintf("Worker Pod %s is in workersToDelete", pod.Name), false
+		}
+	}
+	return "", false
+}
+
+// createWorkerReplica creates the numOfHosts worker Pods of a replica of a multi-host worker group
+func (r *RayClusterReconciler) createWorkerReplica(ctx context.Context, instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec, replicaIndex int) error {
+	replicaName := utils.CheckLabel(fmt.Sprintf("%s-%s", worker.GroupName, rand.String(5)))
+	for hostIndex := 0; hostIndex < int(worker.NumOfHosts); hostIndex++ {
+		pod, err := r.buildWorkerPod(ctx, *instance, *worker.DeepCopy())
+		if err != nil {
+			return err
+		}
+		pod.Labels[utils.RayWorkerReplicaNameLabelKey] = replicaName
+		pod.Labels[utils.RayWorkerReplicaIndexLabelKey] = strconv.Itoa(replicaIndex)
+		pod.Labels[utils.RayHostIndexLabelKey] = strconv.Itoa(hostIndex)
+		if err := r.submitWorkerPod(ctx, *instance, worker, pod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteWorkerReplica deletes all the worker Pods of a replica of a multi-host worker group
+func (r *RayClusterReconciler) deleteWorkerReplica(ctx context.Context, instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec, replicaName string, pods []corev1.Pod, reason string) error {
+	logger := ctrl.LoggerFrom(ctx)
+	logger.Info("deleteWorkerReplica", "worker group", worker.GroupName, "replica", replicaName, "reason", reason)
+	for _, pod := range pods {
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.deletePod(ctx, instance, &pod, worker.GroupName); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod),
+				"Failed deleting worker Pod %s/%s of replica %s, %v", pod.Namespace, pod.Name, replicaName, err)
+			return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedWorkerPod),
+			"Deleted worker Pod %s/%s of replica %s of worker group %s; %s", pod.Namespace, pod.Name, replicaName, worker.GroupName, reason)
+	}
+	return nil
+}
+
+// isWorkerGroupRollingUpdate returns whether the worker group uses the RollingUpdate strategy
+func isWorkerGroupRollingUpdate(worker rayv1.WorkerGroupSpec) bool {
+	return worker.UpdateStrategy != nil && worker.UpdateStrategy.Type == rayv1.RollingUpdateWorkerGroupUpdateStrategyType
+}
+
+// getRollingUpdateMaxSurgeAndMaxUnavailable returns the number of Pods that a rolling update of the worker group can
+// create above and remove below the expected number of Pods. Like Deployments, maxSurge is rounded up and maxUnavailable
+// is rounded down, and maxUnavailable is raised to 1 if both are 0 so that the update always makes progress.
+func getRollingUpdateMaxSurgeAndMaxUnavailable(worker rayv1.WorkerGroupSpec, numExpectedPods int) (int, int, error) {
+	maxSurgeValue := intstr.FromInt32(0)
+	maxUnavailableValue := intstr.FromInt32(1)
+	if rollingUpdate := worker.UpdateStrategy.RollingUpdate; rollingUpdate != nil {
+		if rollingUpdate.MaxSurge != nil {
+			maxSurgeValue = *rollingUpdate.MaxSurge
+			maxUnavailableValue = intstr.FromInt32(0)
+		}
+		if rollingUpdate.MaxUnavailable != nil {
+			maxUnavailableValue = *rollingUpdate.MaxUnavailable
+		}
+	}
+
+	maxSurge, err := intstr.GetScaledValueFromIntOrPercent(&maxSurgeValue, numExpectedPods, true)
+	if err != nil {
+		return 0, 0, err
+	}
+	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(&maxUnavailableValue, numExpectedPods, false)
+	if err != nil {
+		return 0, 0, err
+	}
+	if maxSurge == 0 && maxUnavailable == 0 {
+		maxUnavailable = 1
+	}
+	return maxSurge, maxUnavailable, nil
+}
+
+// rollingUpdateWorkerPods replaces the worker Pods that were built from an older version of the worker group spec,
+// and returns whether any such Pods remain and the number of them that wait for their Ray nodes to drain.
+// Pods without the Pod template hash annotation are out of date.
+//
+// New Pods are created as long as the worker group has fewer than numExpectedPods+maxSurge Pods. Out-of-date Pods
+// that are not ready are deleted right away, and ready ones only while at least numExpectedPods-maxUnavailable Pods
+// stay available. Out-of-date Pods are drained by drainWorkerPod before they are deleted, and terminating Pods count
+// as unavailable, so the update also waits for Ray nodes that drain in a preStop hook before it deletes more Pods.
+func (r *RayClusterReconciler) rollingUpdateWorkerPods(ctx context.Context, instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec, workerPods []corev1.Pod, numExpectedPods int) (bool, int, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	podTemplateHash, err := common.GenerateWorkerGroupPodTemplateHash(worker)
+	if err != nil {
+		return false, 0, err
+	}
+
+	var outdatedPods []corev1.Pod
+	numUpdatedPods, numAvailablePods := 0, 0
+	for _, pod := range workerPods {
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if utils.IsRunningAndReady(&pod) {
+			numAvailablePods++
+		}
+		if pod.Annotations[utils.RayWorkerGroupPodTemplateHashAnnotationKey] == podTemplateHash {
+			numUpdatedPods++
+		} else {
+			outdatedPods = append(outdatedPods, pod)
+		}
+	}
+	if len(outdatedPods) == 0 {
+		return false, 0, nil
+	}
+
+	maxSurge, maxUnavailable, err := getRollingUpdateMaxSurgeAndMaxUnavailable(worker, numExpectedPods)
+	if err != nil {
+		return false, 0, err
+	}
+	numPodsToCreate := min(numExpectedPods+maxSurge-len(workerPods), numExpectedPods-numUpdatedPods)
+	numPodsToDelete := numAvailablePods - (numExpectedPods - maxUnavailable)
+	logger.Info("rollingUpdateWorkerPods", "worker group", worker.GroupName, "outdatedPods", len(outdatedPods), "updatedPods", numUpdatedPods,
+		"availablePods", numAvailablePods, "maxSurge", maxSurge, "maxUnavailable", maxUnavailable, "podsToCreate", numPodsToCreate)
+
+	if numPodsToCreate > 0 {
+		if err := r.checkNamespaceQuota(ctx, instance); err != nil {
+			return true, 0, err
+		}
+	}
+	for i := 0; i < numPodsToCreate; i++ {
+		if err := r.createWorkerPod(ctx, *instance, *worker.DeepCopy()); err != nil {
+			return true, 0, errstd.Join(utils.ErrFailedCreateWorkerPod, err)
+		}
+	}
+
+	// Keep deleting the Pods that are already draining instead of draining other Pods.
+	sortDrainingPodsFirst(outdatedPods)
+	numDrainingPods := 0
+	for _, pod := range outdatedPods {
+		isAvailable := utils.IsRunningAndReady(&pod)
+		if isAvailable {
+			// A Pod that is draining is still available, but it will be deleted, so it uses up the budget.
+			if numPodsToDelete <= 0 {
+				continue
+			}
+			numPodsToDelete--
+		}
+		drained, err := r.drainWorkerPod(ctx, instance, worker, &pod)
+		if err != nil {
+			return true, numDrainingPods, err
+		}
+		if !drained {
+			numDrainingPods++
+			continue
+		}
+		logger.Info("rollingUpdateWorkerPods", "Deleting out-of-date worker Pod", pod.Name, "available", isAvailable)
+		if err := r.deletePod(ctx, instance, &pod, worker.GroupName); err != nil {
+			if !errors.IsNotFound(err) {
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod), "Failed deleting Pod %s/%s, %v", pod.Namespace, pod.Name, err)
+				return true, numDrainingPods, errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+			}
+			logger.Info("rollingUpdateWorkerPods", "The worker Pod has already been deleted", pod.Name)
+			continue
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedWorkerPod),
+			"Deleted out-of-date Pod %s/%s for the rolling update of worker group %s", pod.Namespace, pod.Name, worker.GroupName)
+	}
+	return true, numDrainingPods, nil
+}
+
+// sortDrainingPodsFirst moves the Pods whose Ray nodes are draining to the front, keeping the order of the others
+func sortDrainingPodsFirst(pods []corev1.Pod) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		_, iDraining := pods[i].Annotations[utils.RayNodeDrainStartTimeAnnotationKey]
+		_, jDraining := pods[j].Annotations[utils.RayNodeDrainStartTimeAnnotationKey]
+		return iDraining && !jDraining
+	})
+}
+
+// sortWorkerPodsForScaleDown orders the worker Pods of a worker group that scales down so that the Pods to delete
+// come first: the Pods whose Ray nodes are already draining, then the Pods that are not running, then the running Pods
+// by the number of running tasks and alive actors of their Ray nodes. The number is stored in the pod-deletion-cost
+// annotation of the running Pods, so the previous order is kept if the Ray dashboard is unreachable.
+func (r *RayClusterReconciler) sortWorkerPodsForScaleDown(ctx context.Context, instance *rayv1.RayCluster, pods []corev1.Pod) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	rayDashboardClient, err := r.newRayDashboardClient(ctx, instance)
+	if err != nil {
+		logger.Info("Failed to create the Ray dashboard client, ranking the worker Pods by their previous pod deletion costs", "error", err)
+	}
+	for i := range pods {
+		pod := &pods[i]
+		if rayDashboardClient == nil || pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+		workload, err := rayDashboardClient.GetNodeWorkload(ctx, pod.Status.PodIP)
+		if err != nil {
+			logger.Info("sortWorkerPodsForScaleDown", "Failed to get the workload of the Ray node of worker Pod", pod.Name, "error", err)
+			continue
+		}
+		cost := 0
+		if workload != nil {
+			cost = workload.NumRunningTasks + workload.NumAliveActors
+		}
+		if pod.Annotations[utils.PodDeletionCostAnnotationKey] == strconv.Itoa(cost) {
+			continue
+		}
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[utils.PodDeletionCostAnnotationKey] = strconv.Itoa(cost)
+		if err := r.Patch(ctx, pod, patch); err != nil {
+			return err
+		}
+	}
+
+	// scaleDownRank returns the rank of a Pod in the order of deletion, and the pod deletion cost among running Pods.
+	scaleDownRank := func(pod *corev1.Pod) (int, int) {
+		if _, ok := pod.Annotations[utils.RayNodeDrainStartTimeAnnotationKey]; ok {
+			return 0, 0
+		}
+		if pod.Status.Phase != corev1.PodRunning {
+			return 1, 0
+		}
+		// Pods without a valid cost, e.g. the ones that were never ranked, have a cost of 0 like in Kubernetes.
+		cost, _ := strconv.Atoi(pod.Annotations[utils.PodDeletionCostAnnotationKey])
+		return 2, cost
+	}
+	sort.SliceStable(pods, func(i, j int) bool {
+		iRank, iCost := scaleDownRank(&pods[i])
+		jRank, jCost := scaleDownRank(&pods[j])
+		if iRank != jRank {
+			return iRank < jRank
+		}
+		return iCost < jCost
+	})
+	return nil
+}
+
+// drainWorkerPod marks the Ray node of the worker Pod as draining and returns whether the Pod can be deleted, i.e.
+// whether the Ray node has no running tasks and no alive actors, or the drain grace period of the worker group has
+// elapsed since the Pod was marked. Ray still schedules new work on a draining node, so the grace period bounds how
+// long a busy node delays the deletion.
+func (r *RayClusterReconciler) drainWorkerPod(ctx context.Context, instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec, pod *corev1.Pod) (bool, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	if worker.DrainGracePeriodSeconds == nil || *worker.DrainGracePeriodSeconds == 0 {
+		return true, nil
+	}
+	// A Pod that is not running has no Ray node to drain.
+	if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+		return true, nil
+	}
+
+	drainStartTime, err := time.Parse(time.RFC3339, pod.Annotations[utils.RayNodeDrainStartTimeAnnotationKey])
+	if err != nil {
+		drainStartTime = time.Now()
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[utils.RayNodeDrainStartTimeAnnotationKey] = drainStartTime.Format(time.RFC3339)
+		if err := r.Patch(ctx, pod, patch); err != nil {
+			return false, err
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DrainingWorkerPod),
+			"Draining the Ray node of worker Pod %s/%s for up to %d seconds before deleting the Pod", pod.Namespace, pod.Name, *worker.DrainGracePeriodSeconds)
+		r.requestRayNodeDrain(ctx, instance, pod, drainStartTime.Add(time.Duration(*worker.DrainGracePeriodSeconds)*time.Second))
+	}
+	if time.Since(drainStartTime) >= time.Duration(*worker.DrainGracePeriodSeconds)*time.Second {
+		logger.Info("drainWorkerPod", "The drain grace period has elapsed for worker Pod", pod.Name)
+		return true, nil
+	}
+
+	workload, err := r.getRayNodeWorkload(ctx, instance, pod.Status.PodIP)
+	if err != nil {
+		// Keep waiting until the grace period elapses if the Ray dashboard is unreachable.
+		logger.Info("drainWorkerPod", "Failed to get the workload of the Ray node of worker Pod", pod.Name, "error", err)
+	} else if workload == nil || (workload.NumRunningTasks == 0 && workload.NumAliveActors == 0) {
+		return true, nil
+	} else {
+		logger.Info("drainWorkerPod", "Waiting for the Ray node to drain", pod.Name, "nodeID", workload.NodeID,
+			"runningTasks", workload.NumRunningTasks, "aliveActors", workload.NumAliveActors)
+	}
+	return false, nil
+}
+
+// requestRayNodeDrain asks the GCS server of the RayCluster to stop scheduling tasks and actors on the Ray node of the
+// worker Pod, so that the node drains by the deadline. Failures are only logged, because the worker Pod is deleted
+// when its drain grace period elapses anyway.
+func (r *RayClusterReconciler) requestRayNodeDrain(ctx context.Context, instance *rayv1.RayCluster, pod *corev1.Pod, deadline time.Time) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	// The KubeRay operator has no client certificate to connect to the GCS server of a RayCluster with TLS.
+	if common.IsTLSEnabled(*instance) {
+		logger.Info("requestRayNodeDrain", "Cannot connect to the GCS server of a RayCluster with TLS to drain the Ray node of worker Pod", pod.Name)
+		return
+	}
+	workload, err := r.getRayNodeWorkload(ctx, instance, pod.Status.PodIP)
+	if err != nil || workload == nil {
+		logger.Info("requestRayNodeDrain", "Failed to get the ID of the Ray node of worker Pod", pod.Name, "error", err)
+		return
+	}
+	gcsAddress, err := utils.FetchHeadServiceURL(ctx, r.Client, instance, utils.RedisPortName)
+	if err != nil {
+		logger.Info("requestRayNodeDrain", "Failed to get the address of the GCS server to drain the Ray node of worker Pod", pod.Name, "error", err)
+		return
+	}
+	drainRayNode := r.drainRayNode
+	if drainRayNode == nil {
+		drainRayNode = utils.DrainRayNode
+	}
+	reasonMessage := fmt.Sprintf("KubeRay is deleting worker Pod %s/%s", pod.Namespace, pod.Name)
+	if err := drainRayNode(ctx, gcsAddress, workload.NodeID, utils.DrainNodeReasonPreemption, reasonMessage, deadline); err != nil {
+		logger.Info("requestRayNodeDrain", "Failed to drain the Ray node of worker Pod", pod.Name, "nodeID", workload.NodeID, "error", err)
+	}
+}
+
+// getRayNodeWorkload returns the workload of the Ray node with the given IP, or nil if there is no such alive node
+func (r *RayClusterReconciler) getRayNodeWorkload(ctx context.Context, instance *rayv1.RayCluster, nodeIP string) (*utils.RayNodeWorkload, error) {
+	rayDashboardClient, err := r.newRayDashboardClient(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	return rayDashboardClient.GetNodeWorkload(ctx, nodeIP)
+}
+
+// newRayDashboardClient returns a client of the Ray dashboard of the RayCluster
+func (r *RayClusterReconciler) newRayDashboardClient(ctx context.Context, instance *rayv1.RayCluster) (utils.RayDashboardClientInterface, error) {
+	if r.dashboardClientFunc == nil {
+		return nil, errstd.New("no Ray dashboard client is configured")
+	}
+	dashboardURL, err := utils.FetchHeadServiceURL(ctx, r.Client, instance, utils.DashboardPortName)
+	if err != nil {
+		return nil, err
+	}
+	rayDashboardClient := r.dashboardClientFunc()
+	if err := rayDashboardClient.InitClient(ctx, dashboardURL, instance); err != nil {
+		return nil, err
+	}
+	return rayDashboardClient, nil
+}
+
+// shouldDeletePod returns whether the Pod should be deleted and the reason
+//
+// @param pod: The Pod to be checked.
+// @param nodeType: The type of the node that the Pod belongs to (head or worker).
+//
+// @return: shouldDelete (bool), reason (string)
+// (1) shouldDelete: Whether the Pod should be deleted.
+// (2) reason: The reason why the Pod should or should not be deleted.
+func shouldDeletePod(pod corev1.Pod, nodeType rayv1.RayNodeType) (bool, string) {
+	// Based on the logic of the change of the status of the K8S pod, the following judgment is made.
+	// https://github.com/kubernetes/kubernetes/blob/3361895612dac57044d5dacc029d2ace1865479c/pkg/kubelet/kubelet_pods.go#L1556
+
+	// If the Pod's status is `Failed` or `Succeeded`, the Pod will not restart and we can safely delete it.
+	if pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded {
+		reason := fmt.Sprintf(
+			"The %s Pod %s status is %s which is a terminal state. "+
+				"KubeRay will delete the Pod and create new Pods in the next reconciliation if necessary.",
+			nodeType, pod.Name, pod.Status.Phase)
+		return true, reason
+	}
+
+	rayContainerTerminated := getRayContainerStateTerminated(pod)
+	if pod.Status.Phase == corev1.PodRunning && rayContainerTerminated != nil {
+		if pod.Spec.RestartPolicy == corev1.RestartPolicyNever {
+			reason := fmt.Sprintf(
+				"The Pod status of the %s Pod %s is %s, and the Ray container terminated status is %v. "+
+					"The container is unable to restart due to its restart policy %s, so KubeRay will delete it.",
+				nodeType, pod.Name, pod.Status.Phase, rayContainerTerminated, pod.Spec.RestartPolicy)
+			return true, reason
+		}
+		// If restart policy is set to `Always` or `OnFailure`, KubeRay will not delete the Pod.
+		reason := fmt.Sprintf(
+			"The Pod status of the %s Pod %s is %s, and the Ray container terminated status is %v. "+
+				"However, KubeRay will not delete the Pod because its restartPolicy is set to %s and it should be able to restart automatically.",
+			nodeType, pod.Name, pod.Status.Phase, rayContainerTerminated, pod.Spec.RestartPolicy)
+		return false, reason
+	}
+
+	// TODO (kevin85421): Consider deleting a Pod if its Ray container restarts excessively, as this might
+	// suggest an unhealthy Kubernetes node. Deleting and then recreating the Pod might allow it to be
+	// scheduled on a different node.
+	//
+	// (1) Head Pod:
+	// It's aggressive to delete a head Pod that is not in a terminated state (i.e., `Failed` or `Succeeded`).
+	// We should only delete a head Pod when GCS fault tolerance is enabled, and drain the head Pod before
+	// deleting it.
+	//
 	// (2) Worker Pod:
 	// Compared to deleting a head Pod, removing a worker Pod is less aggressive and aligns more closely with
 	// the behavior of the Ray Autoscaler. Nevertheless, we should still carefully drain the node before deleting
@@ -916,207 +2521,1008 @@ func shouldDeletePod(pod corev1.Pod, nodeType rayv1.RayNodeType) (bool, string)
 	// the Ray Autoscaler will not delete any worker Pods that have never been registered with the Ray cluster.
 	// Therefore, we may need to address the Ray Autoscaler's blind spots.
 
-	reason := fmt.Sprintf(
-		"KubeRay does not need to delete the %s Pod %s. The Pod status is %s, and the Ray container terminated status is %v.",
-		nodeType, pod.Name, pod.Status.Phase, rayContainerTerminated)
-	return false, reason
+	reason := fmt.Sprintf(
+		"KubeRay does not need to delete the %s Pod %s. The Pod status is %s, and the Ray container terminated status is %v.",
+		nodeType, pod.Name, pod.Status.Phase, rayContainerTerminated)
+	return false, reason
+}
+
+// `ContainerStatuses` does not guarantee the order of the containers. Therefore, we need to find the Ray
+// container's status by name. See the following links for more details:
+// (1) https://discuss.kubernetes.io/t/pod-spec-containers-and-pod-status-containerstatuses-can-have-a-different-order-why/25273
+// (2) https://github.com/kubernetes/kubernetes/blob/03762cbcb52b2a4394e4d795f9d3517a78a5e1a2/pkg/api/v1/pod/util.go#L261-L268
+func getRayContainerStateTerminated(pod corev1.Pod) *corev1.ContainerStateTerminated {
+	rayContainerName := pod.Spec.Containers[utils.RayContainerIndex].Name
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Name == rayContainerName {
+			return containerStatus.State.Terminated
+		}
+	}
+	// If the Ray container isn't found, we'll assume it hasn't terminated. This scenario
+	// typically arises during testing (`raycluster_controller_test.go`) as `envtest` lacks
+	// a Pod controller, preventing automatic Pod status updates.
+	return nil
+}
+
+// updateHeadReachableConditions sets the ray.io/head-reachable condition of the worker Pods from the last termination of
+// their wait-gcs-ready init containers, which exit with the reason in their termination message once they give up
+// waiting for the head. An event is recorded when a worker Pod cannot reach the head.
+func (r *RayClusterReconciler) updateHeadReachableConditions(ctx context.Context, instance *rayv1.RayCluster, workerPods []corev1.Pod) error {
+	logger := ctrl.LoggerFrom(ctx)
+	for i := range workerPods {
+		pod := &workerPods[i]
+		condition, ok := getHeadReachableCondition(pod)
+		if !ok {
+			continue
+		}
+		index := slices.IndexFunc(pod.Status.Conditions, func(c corev1.PodCondition) bool { return c.Type == condition.Type })
+		if index == -1 && condition.Status == corev1.ConditionTrue {
+			continue
+		}
+		if index != -1 {
+			existing := pod.Status.Conditions[index]
+			if existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message {
+				continue
+			}
+		}
+
+		pod = pod.DeepCopy()
+		if index == -1 {
+			pod.Status.Conditions = append(pod.Status.Conditions, condition)
+		} else {
+			pod.Status.Conditions[index] = condition
+		}
+		if err := r.Status().Update(ctx, pod); err != nil {
+			return err
+		}
+		logger.Info("Updated the head reachable condition of the worker Pod", "Pod", pod.Name, "status", condition.Status, "reason", condition.Reason)
+		if condition.Status == corev1.ConditionFalse {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.WorkerPodHeadUnreachable),
+				"Worker Pod %s/%s cannot reach the head: %s", pod.Namespace, pod.Name, condition.Message)
+		}
+	}
+	return nil
+}
+
+// getHeadReachableCondition returns the ray.io/head-reachable condition of a worker Pod, and false if its wait-gcs-ready
+// init container has neither succeeded nor given up.
+func getHeadReachableCondition(pod *corev1.Pod) (corev1.PodCondition, bool) {
+	for _, status := range pod.Status.InitContainerStatuses {
+		if status.Name != common.WaitGcsReadyContainerName {
+			continue
+		}
+		terminated := status.State.Terminated
+		if terminated == nil {
+			// The init container is retried after it gave up.
+			terminated = status.LastTerminationState.Terminated
+		}
+		if terminated == nil {
+			return corev1.PodCondition{}, false
+		}
+		condition := corev1.PodCondition{
+			Type:               utils.RayHeadReachablePodConditionType,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Message:            strings.TrimSpace(terminated.Message),
+		}
+		switch terminated.ExitCode {
+		case 0:
+			condition.Status = corev1.ConditionTrue
+			condition.Reason = utils.GcsServerReadyReason
+			condition.Message = ""
+		case utils.WaitForHeadUnresolvableExitCode:
+			condition.Reason = utils.HeadServiceUnresolvableReason
+		case utils.WaitForHeadGcsNotReadyExitCode:
+			condition.Reason = utils.GcsServerNotReadyReason
+		default:
+			// The init container was killed, e.g. when the Pod is deleted, so it did not give up.
+			return corev1.PodCondition{}, false
+		}
+		return condition, true
+	}
+	return corev1.PodCondition{}, false
+}
+
+// applyObject creates or updates an object with server-side apply. The field manager of the KubeRay operator only owns
+// the fields that the object sets, so the fields that other managers such as GitOps tools, admission plugins and
+// cloud controllers set are kept, and concurrent changes to other fields do not conflict. Conflicts over the fields
+// that the object sets are forced, because the KubeRay operator has to converge them. An existing object is only
+// applied if the controller of obj also controls it, so that objects that users or other controllers created are
+// never taken over; an AlreadyExists error is returned for them instead.
+func applyObject(ctx context.Context, c client.Client, obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return err
+	}
+	var existing client.Object = &unstructured.Unstructured{}
+	if _, ok := obj.(*unstructured.Unstructured); !ok {
+		typed, err := c.Scheme().New(gvk)
+		if err != nil {
+			return err
+		}
+		existing = typed.(client.Object)
+	}
+	existing.GetObjectKind().SetGroupVersionKind(gvk)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing); err == nil {
+		owner := metav1.GetControllerOf(obj)
+		if controller := metav1.GetControllerOf(existing); owner == nil || controller == nil || controller.UID != owner.UID {
+			return errors.NewAlreadyExists(schema.GroupResource{Group: gvk.Group, Resource: gvk.Kind}, obj.GetName())
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+	return c.Patch(ctx, obj, client.Apply, client.FieldOwner(utils.FieldManager), client.ForceOwnership)
+}
+
+// applyUnstructured applies an object of a custom resource whose Go types the KubeRay operator does not import, e.g.
+// an HTTPRoute or a Certificate, as unstructured content with applyObject.
+func applyUnstructured(ctx context.Context, c client.Client, obj interface{}) error {
+	content, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	return applyObject(ctx, c, &unstructured.Unstructured{Object: content})
+}
+
+// applyPod creates a Pod with applyObject. Server-side apply does not support generateName, so the name is generated
+// like the API server does.
+func applyPod(ctx context.Context, c client.Client, pod *corev1.Pod) error {
+	if pod.Name == "" {
+		pod.Name = pod.GenerateName + rand.String(5)
+	}
+	return applyObject(ctx, c, pod)
+}
+
+func (r *RayClusterReconciler) createHeadIngress(ctx context.Context, ingress *networkingv1.Ingress, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	// making sure the name is valid
+	ingress.Name = utils.CheckName(ingress.Name)
+	if err := controllerutil.SetControllerReference(instance, ingress, r.Scheme); err != nil {
+		return err
+	}
+
+	if err := applyObject(ctx, r.Client, ingress); err != nil {
+		if errors.IsAlreadyExists(err) {
+			logger.Info("Ingress already exists, no need to create")
+			return nil
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateIngress), "Failed creating ingress %s/%s, %v", ingress.Namespace, ingress.Name, err)
+		return err
+	}
+	logger.Info("Created ingress for RayCluster", "name", ingress.Name)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedIngress), "Created ingress %s/%s", ingress.Namespace, ingress.Name)
+	return nil
+}
+
+func (r *RayClusterReconciler) createHeadRoute(ctx context.Context, route *routev1.Route, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	// making sure the name is valid
+	route.Name = utils.CheckRouteName(ctx, route.Name, route.Namespace)
+
+	if err := applyObject(ctx, r.Client, route); err != nil {
+		if errors.IsAlreadyExists(err) {
+			logger.Info("Route already exists, no need to create")
+			return nil
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateRoute), "Failed creating route %s/%s, %v", route.Namespace, route.Name, err)
+		return err
+	}
+	logger.Info("Created route for RayCluster", "name", route.Name)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedRoute), "Created route %s/%s", route.Namespace, route.Name)
+	return nil
+}
+
+func (r *RayClusterReconciler) createService(ctx context.Context, svc *corev1.Service, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	// making sure the name is valid
+	svc.Name = utils.CheckName(svc.Name)
+	if err := controllerutil.SetControllerReference(instance, svc, r.Scheme); err != nil {
+		return err
+	}
+
+	if err := applyObject(ctx, r.Client, svc); err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateService), "Failed creating service %s/%s, %v", svc.Namespace, svc.Name, err)
+		return err
+	}
+	logger.Info("Created service for RayCluster", "name", svc.Name)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedService), "Created service %s/%s", svc.Namespace, svc.Name)
+	return nil
 }
 
-// `ContainerStatuses` does not guarantee the order of the containers. Therefore, we need to find the Ray
-// container's status by name. See the following links for more details:
-// (1) https://discuss.kubernetes.io/t/pod-spec-containers-and-pod-status-containerstatuses-can-have-a-different-order-why/25273
-// (2) https://github.com/kubernetes/kubernetes/blob/03762cbcb52b2a4394e4d795f9d3517a78a5e1a2/pkg/api/v1/pod/util.go#L261-L268
-func getRayContainerStateTerminated(pod corev1.Pod) *corev1.ContainerStateTerminated {
-	rayContainerName := pod.Spec.Containers[utils.RayContainerIndex].Name
-	for _, containerStatus := range pod.Status.ContainerStatuses {
-		if containerStatus.Name == rayContainerName {
-			return containerStatus.State.Terminated
+// cleanupBatchScheduling deletes the resources that the batch scheduler created for the RayCluster
+func (r *RayClusterReconciler) cleanupBatchScheduling(ctx context.Context, instance *rayv1.RayCluster) error {
+	if r.BatchSchedulerMgr == nil {
+		return nil
+	}
+	// the batch scheduler has not created any resources for a RayCluster whose scheduler is not enabled
+	if scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(instance); err == nil {
+		if err := scheduler.CleanupOnDeletion(ctx, instance); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCleanupBatchScheduling),
+				"Failed to clean up the %s batch scheduling resources of RayCluster %s/%s, %v", scheduler.Name(), instance.Namespace, instance.Name, err)
+			return err
 		}
 	}
-	// If the Ray container isn't found, we'll assume it hasn't terminated. This scenario
-	// typically arises during testing (`raycluster_controller_test.go`) as `envtest` lacks
-	// a Pod controller, preventing automatic Pod status updates.
 	return nil
 }
 
-func (r *RayClusterReconciler) createHeadIngress(ctx context.Context, ingress *networkingv1.Ingress, instance *rayv1.RayCluster) error {
+func (r *RayClusterReconciler) createHeadPod(ctx context.Context, instance rayv1.RayCluster) error {
 	logger := ctrl.LoggerFrom(ctx)
 
-	// making sure the name is valid
-	ingress.Name = utils.CheckName(ingress.Name)
-	if err := controllerutil.SetControllerReference(instance, ingress, r.Scheme); err != nil {
+	// build the pod then create it
+	pod, err := r.buildHeadPod(ctx, instance)
+	if err != nil {
 		return err
 	}
+	// check if the batch scheduler integration is enabled
+	// call the scheduler plugin if so
+	if r.BatchSchedulerMgr != nil {
+		if scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(&instance); err == nil {
+			scheduler.AddMetadataToPod(&instance, utils.RayNodeHeadGroupLabelValue, &pod)
+		} else {
+			return err
+		}
+	}
 
-	if err := r.Create(ctx, ingress); err != nil {
-		if errors.IsAlreadyExists(err) {
-			logger.Info("Ingress already exists, no need to create")
-			return nil
+	claims, err := r.createRayVolumeClaims(ctx, instance, &pod, instance.Spec.HeadGroupSpec.LogVolume, instance.Spec.HeadGroupSpec.SpillVolume)
+	if err != nil {
+		return err
+	}
+	if err := applyPod(ctx, r.Client, &pod); err != nil {
+		r.Recorder.Eventf(&instance, corev1.EventTypeWarning, string(utils.FailedToCreateHeadPod), "Failed to create head Pod %s/%s, %v", pod.Namespace, pod.Name, err)
+		r.deleteRayVolumeClaims(ctx, claims)
+		return err
+	}
+	r.rayClusterScaleExpectation.ExpectScalePod(instance.Namespace, instance.Name, expectations.HeadGroup, pod.Name, expectations.Create)
+	common.CreatedPodsCounterInc(instance.Namespace, rayv1.HeadNode)
+	logger.Info("Created head Pod for RayCluster", "name", pod.Name)
+	r.Recorder.Eventf(&instance, corev1.EventTypeNormal, string(utils.CreatedHeadPod), "Created head Pod %s/%s", pod.Namespace, pod.Name)
+	return nil
+}
+
+func (r *RayClusterReconciler) createWorkerPod(ctx context.Context, instance rayv1.RayCluster, worker rayv1.WorkerGroupSpec) error {
+	// build the pod then create it
+	pod, err := r.buildWorkerPod(ctx, instance, worker)
+	if err != nil {
+		return err
+	}
+	return r.submitWorkerPod(ctx, instance, worker, pod)
+}
+
+// submitWorkerPod adds the metadata of the batch scheduler to a worker Pod built by buildWorkerPod and creates the Pod
+func (r *RayClusterReconciler) submitWorkerPod(ctx context.Context, instance rayv1.RayCluster, worker rayv1.WorkerGroupSpec, pod corev1.Pod) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	if r.BatchSchedulerMgr != nil {
+		if scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(&instance); err == nil {
+			scheduler.AddMetadataToPod(&instance, worker.GroupName, &pod)
+		} else {
+			return err
 		}
-		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateIngress), "Failed creating ingress %s/%s, %v", ingress.Namespace, ingress.Name, err)
+	}
+
+	claims, err := r.createRayVolumeClaims(ctx, instance, &pod, worker.LogVolume, worker.SpillVolume)
+	if err != nil {
 		return err
 	}
-	logger.Info("Created ingress for RayCluster", "name", ingress.Name)
-	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedIngress), "Created ingress %s/%s", ingress.Namespace, ingress.Name)
+	if err := applyPod(ctx, r.Client, &pod); err != nil {
+		r.Recorder.Eventf(&instance, corev1.EventTypeWarning, string(utils.FailedToCreateWorkerPod), "Failed to create worker Pod %s/%s, %v", pod.Namespace, pod.Name, err)
+		r.deleteRayVolumeClaims(ctx, claims)
+		return err
+	}
+	r.rayClusterScaleExpectation.ExpectScalePod(instance.Namespace, instance.Name, worker.GroupName, pod.Name, expectations.Create)
+	common.CreatedPodsCounterInc(instance.Namespace, rayv1.WorkerNode)
+	logger.Info("Created worker Pod for RayCluster", "name", pod.Name)
+	r.Recorder.Eventf(&instance, corev1.EventTypeNormal, string(utils.CreatedWorkerPod), "Created worker Pod %s/%s", pod.Namespace, pod.Name)
 	return nil
 }
 
-func (r *RayClusterReconciler) createHeadRoute(ctx context.Context, route *routev1.Route, instance *rayv1.RayCluster) error {
+// createRayVolumeClaims creates the PersistentVolumeClaims of the log and spill volumes of a Pod with the
+// DeleteWithCluster cleanup policy, and returns the claims that it created. They are owned by the RayCluster, so they
+// are kept after the Pod is deleted and are garbage collected with the RayCluster. If it fails, the claims that it
+// already created are deleted, because the Pod that would use them is not created.
+func (r *RayClusterReconciler) createRayVolumeClaims(ctx context.Context, instance rayv1.RayCluster, pod *corev1.Pod, logVolume *rayv1.RayVolume, spillVolume *rayv1.RayVolume) ([]corev1.PersistentVolumeClaim, error) {
 	logger := ctrl.LoggerFrom(ctx)
 
-	// making sure the name is valid
-	route.Name = utils.CheckRouteName(ctx, route.Name, route.Namespace)
+	var created []corev1.PersistentVolumeClaim
+	for _, claim := range common.BuildRayVolumeClaims(pod, logVolume, spillVolume) {
+		if err := controllerutil.SetControllerReference(&instance, &claim, r.Scheme); err != nil {
+			r.deleteRayVolumeClaims(ctx, created)
+			return nil, err
+		}
+		if err := applyObject(ctx, r.Client, &claim); err != nil {
+			if errors.IsAlreadyExists(err) {
+				continue
+			}
+			r.Recorder.Eventf(&instance, corev1.EventTypeWarning, string(utils.FailedToCreatePersistentVolumeClaim), "Failed to create PersistentVolumeClaim %s/%s, %v", claim.Namespace, claim.Name, err)
+			r.deleteRayVolumeClaims(ctx, created)
+			return nil, err
+		}
+		created = append(created, claim)
+		logger.Info("Created PersistentVolumeClaim for RayCluster", "name", claim.Name)
+		r.Recorder.Eventf(&instance, corev1.EventTypeNormal, string(utils.CreatedPersistentVolumeClaim), "Created PersistentVolumeClaim %s/%s", claim.Namespace, claim.Name)
+	}
+	return created, nil
+}
 
-	if err := r.Create(ctx, route); err != nil {
-		if errors.IsAlreadyExists(err) {
-			logger.Info("Route already exists, no need to create")
-			return nil
+// deleteRayVolumeClaims deletes the PersistentVolumeClaims that createRayVolumeClaims created for a Pod that was not
+// created. Failures are only logged, since the claims are garbage collected with the RayCluster anyway.
+func (r *RayClusterReconciler) deleteRayVolumeClaims(ctx context.Context, claims []corev1.PersistentVolumeClaim) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	for i := range claims {
+		if err := r.Delete(ctx, &claims[i]); client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "Failed to delete the PersistentVolumeClaim of a Pod that was not created", "name", claims[i].Name)
+			continue
+		}
+		logger.Info("Deleted the PersistentVolumeClaim of a Pod that was not created", "name", claims[i].Name)
+	}
+}
+
+// Build head instance pod(s).
+func (r *RayClusterReconciler) buildHeadPod(ctx context.Context, instance rayv1.RayCluster) (corev1.Pod, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	podName := utils.PodGenerateName(instance.Name, rayv1.HeadNode)
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, instance, instance.Namespace) // Fully Qualified Domain Name
+	// The Ray head port used by workers to connect to the cluster (GCS server port for Ray >= 1.11.0, Redis port for older Ray.)
+	headPort := common.GetHeadPort(instance.Spec.HeadGroupSpec.RayStartParams)
+	autoscalingEnabled := instance.Spec.EnableInTreeAutoscaling
+	headSpec := instance.Spec.HeadGroupSpec
+	headSpec.ResourceClaims = r.resolveRayResourceClaims(ctx, instance.Namespace, headSpec.ResourceClaims)
+	podConf := common.DefaultHeadPodTemplate(ctx, instance, headSpec, podName, headPort)
+	common.AddSidecarContainers(&podConf, r.headSidecarContainers...)
+	common.AddSidecarContainers(&podConf, headSpec.SidecarContainers...)
+	logger.Info("head pod labels", "labels", podConf.Labels)
+	creatorCRDType := getCreatorCRDType(instance)
+	pod := common.BuildPod(ctx, podConf, rayv1.HeadNode, instance.Spec.HeadGroupSpec.RayStartParams, headPort, autoscalingEnabled, creatorCRDType, fqdnRayIP)
+	if r.headPlacement != nil && instance.Annotations[utils.RayDisableHeadPlacementAnnotationKey] != "true" {
+		common.AddHeadPlacement(&pod, r.headPlacement.SpotNodeLabels, r.headPlacement.Zones)
+	}
+	if err := r.addPodDefaults(ctx, instance, &pod); err != nil {
+		return corev1.Pod{}, err
+	}
+	// Set raycluster instance as the owner and controller
+	if err := controllerutil.SetControllerReference(&instance, &pod, r.Scheme); err != nil {
+		logger.Error(err, "Failed to set controller reference for raycluster pod")
+	}
+
+	return pod, nil
+}
+
+// resolveRayResourceClaims returns the resource claims of a group, where the claims without Ray resources have the Ray
+// resources of the accelerators that their ResourceClaim or ResourceClaimTemplate allocates. A claim whose devices
+// cannot be looked up keeps no Ray resources, so that Ray detects the accelerators of the node itself.
+func (r *RayClusterReconciler) resolveRayResourceClaims(ctx context.Context, namespace string, claims []rayv1.RayResourceClaim) []rayv1.RayResourceClaim {
+	logger := ctrl.LoggerFrom(ctx)
+	resolvedClaims := slices.Clone(claims)
+	for i := range resolvedClaims {
+		claim := &resolvedClaims[i]
+		if len(claim.RayResources) > 0 {
+			continue
+		}
+		switch {
+		case claim.ResourceClaimName != nil:
+			resourceClaim := &resourcev1alpha3.ResourceClaim{}
+			if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: *claim.ResourceClaimName}, resourceClaim); err != nil {
+				logger.Info("Failed to get the ResourceClaim to derive its Ray resources", "claim", claim.Name, "error", err)
+				continue
+			}
+			claim.RayResources = common.RayResourcesFromDeviceClaim(resourceClaim.Spec, resourceClaim.Status.Allocation)
+		case claim.ResourceClaimTemplateName != nil:
+			template := &resourcev1alpha3.ResourceClaimTemplate{}
+			if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: *claim.ResourceClaimTemplateName}, template); err != nil {
+				logger.Info("Failed to get the ResourceClaimTemplate to derive its Ray resources", "claim", claim.Name, "error", err)
+				continue
+			}
+			claim.RayResources = common.RayResourcesFromDeviceClaim(template.Spec.Spec, nil)
+		}
+	}
+	return resolvedClaims
+}
+
+func getCreatorCRDType(instance rayv1.RayCluster) utils.CRDType {
+	return utils.GetCRDType(instance.Labels[utils.RayOriginatedFromCRDLabelKey])
+}
+
+// Build worker instance pods.
+func (r *RayClusterReconciler) buildWorkerPod(ctx context.Context, instance rayv1.RayCluster, worker rayv1.WorkerGroupSpec) (corev1.Pod, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	podName := utils.PodGenerateName(fmt.Sprintf("%s-%s", instance.Name, worker.GroupName), rayv1.WorkerNode)
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, instance, instance.Namespace) // Fully Qualified Domain Name
+
+	// The Ray head port used by workers to connect to the cluster (GCS server port for Ray >= 1.11.0, Redis port for older Ray.)
+	headPort := common.GetHeadPort(instance.Spec.HeadGroupSpec.RayStartParams)
+	autoscalingEnabled := instance.Spec.EnableInTreeAutoscaling
+	// Hash the worker group spec before DefaultWorkerPodTemplate completes its rayStartParams.
+	podTemplateHash, err := common.GenerateWorkerGroupPodTemplateHash(worker)
+	if err != nil {
+		logger.Error(err, "Failed to generate the Pod template hash of the worker group", "worker group", worker.GroupName)
+	}
+	worker.ResourceClaims = r.resolveRayResourceClaims(ctx, instance.Namespace, worker.ResourceClaims)
+	podTemplateSpec := common.DefaultWorkerPodTemplate(ctx, instance, worker, podName, fqdnRayIP, headPort)
+	common.AddSidecarContainers(&podTemplateSpec, r.workerSidecarContainers...)
+	common.AddSidecarContainers(&podTemplateSpec, worker.SidecarContainers...)
+	creatorCRDType := getCreatorCRDType(instance)
+	pod := common.BuildPod(ctx, podTemplateSpec, rayv1.WorkerNode, worker.RayStartParams, headPort, autoscalingEnabled, creatorCRDType, fqdnRayIP)
+	if podTemplateHash != "" {
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[utils.RayWorkerGroupPodTemplateHashAnnotationKey] = podTemplateHash
+	}
+	if worker.SpotFallback != nil {
+		if isSpotFallbackActive(&instance, worker) {
+			common.AddNodePlacement(&pod, worker.SpotFallback.OnDemand, utils.OnDemandCapacityType)
+		} else {
+			common.AddNodePlacement(&pod, worker.SpotFallback.Spot, utils.SpotCapacityType)
+		}
+	}
+	if err := r.addPodDefaults(ctx, instance, &pod); err != nil {
+		return corev1.Pod{}, err
+	}
+	// Set raycluster instance as the owner and controller
+	if err := controllerutil.SetControllerReference(&instance, &pod, r.Scheme); err != nil {
+		logger.Error(err, "Failed to set controller reference for raycluster pod")
+	}
+
+	return pod, nil
+}
+
+// getRayClusterReadyCondition returns the Ready condition of the RayCluster from the readiness of its head Pod and its
+// worker replicas, which must be calculated first.
+func getRayClusterReadyCondition(instance *rayv1.RayCluster, headPodReady bool) metav1.Condition {
+	condition := metav1.Condition{
+		Type:   string(rayv1.RayClusterReady),
+		Status: metav1.ConditionFalse,
+		Reason: rayv1.RayClusterPodsProvisioning,
+	}
+	switch {
+	case instance.Spec.Suspend != nil && *instance.Spec.Suspend:
+		condition.Reason = string(rayv1.RayClusterSuspended)
+		condition.Message = "RayCluster is suspended"
+	case !headPodReady:
+		condition.Message = "Head Pod is not ready"
+	case instance.Status.ReadyWorkerReplicas < instance.Status.DesiredWorkerReplicas:
+		condition.Message = fmt.Sprintf("%d of %d worker Pods are ready", instance.Status.ReadyWorkerReplicas, instance.Status.DesiredWorkerReplicas)
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = rayv1.AllPodsRunningAndReady
+		condition.Message = "All Ray Pods are running and ready"
+	}
+	return condition
+}
+
+// getAutoscalerReadyCondition returns the AutoscalerReady condition for the status of the autoscaler container of a
+// head Pod, which is a native sidecar container if the NativeSidecarContainers feature gate is enabled.
+func getAutoscalerReadyCondition(headPod *corev1.Pod) metav1.Condition {
+	condition := metav1.Condition{
+		Type:    string(rayv1.AutoscalerReady),
+		Status:  metav1.ConditionFalse,
+		Reason:  rayv1.AutoscalerContainerNotFound,
+		Message: "The head Pod has no autoscaler container",
+	}
+	statuses := append(slices.Clone(headPod.Status.ContainerStatuses), headPod.Status.InitContainerStatuses...)
+	index := slices.IndexFunc(statuses, func(status corev1.ContainerStatus) bool { return status.Name == common.AutoscalerContainerName })
+	if index < 0 {
+		return condition
+	}
+	status := statuses[index]
+	condition.Reason = rayv1.UnknownReason
+	condition.Message = fmt.Sprintf("The autoscaler container has restarted %d times", status.RestartCount)
+	switch {
+	case status.State.Running != nil:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = rayv1.AutoscalerRunning
+	case status.State.Waiting != nil && status.State.Waiting.Reason != "":
+		condition.Reason = status.State.Waiting.Reason
+		if status.State.Waiting.Message != "" {
+			condition.Message = status.State.Waiting.Message
+		}
+	case status.State.Terminated != nil && status.State.Terminated.Reason != "":
+		condition.Reason = status.State.Terminated.Reason
+		if status.State.Terminated.Message != "" {
+			condition.Message = status.State.Terminated.Message
+		}
+	}
+	return condition
+}
+
+// addPodDefaults adds the Pod defaults that select the namespace of the RayCluster to a Ray Pod. The namespace
+// selectors have been validated when the operator starts. It returns an error if the namespace cannot be fetched, so
+// that the Pod is not created without the Pod defaults that would select it.
+func (r *RayClusterReconciler) addPodDefaults(ctx context.Context, instance rayv1.RayCluster, pod *corev1.Pod) error {
+	logger := ctrl.LoggerFrom(ctx)
+	var namespace *corev1.Namespace
+	for _, podDefaults := range r.podDefaults {
+		if podDefaults.NamespaceSelector != "" {
+			selector, err := labels.Parse(podDefaults.NamespaceSelector)
+			if err != nil {
+				logger.Error(err, "Invalid namespace selector of the Pod defaults", "selector", podDefaults.NamespaceSelector)
+				continue
+			}
+			if namespace == nil {
+				namespace = &corev1.Namespace{}
+				if err := r.Get(ctx, types.NamespacedName{Name: instance.Namespace}, namespace); err != nil {
+					return fmt.Errorf("failed to get namespace %s for the Pod defaults: %w", instance.Namespace, err)
+				}
+			}
+			if !selector.Matches(labels.Set(namespace.Labels)) {
+				continue
+			}
+		}
+		common.AddPodDefaults(pod, podDefaults)
+	}
+	return nil
+}
+
+// buildWorkerGroupStatefulSet builds the StatefulSet of a worker group with the StatefulSet workload type. Its Pod
+// template is the worker Pod that buildWorkerPod builds, and the RollingUpdate strategy of the worker group maps to
+// the RollingUpdate strategy of the StatefulSet.
+func (r *RayClusterReconciler) buildWorkerGroupStatefulSet(ctx context.Context, instance rayv1.RayCluster, worker rayv1.WorkerGroupSpec) (appsv1.StatefulSet, error) {
+	pod, err := r.buildWorkerPod(ctx, instance, worker)
+	if err != nil {
+		return appsv1.StatefulSet{}, err
+	}
+	replicas := utils.GetWorkerGroupDesiredReplicas(ctx, worker) * max(worker.NumOfHosts, 1)
+
+	updateStrategy := appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType}
+	if isWorkerGroupRollingUpdate(worker) {
+		updateStrategy.Type = appsv1.RollingUpdateStatefulSetStrategyType
+		if rollingUpdate := worker.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.MaxUnavailable != nil {
+			updateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{MaxUnavailable: rollingUpdate.MaxUnavailable}
+		}
+	}
+
+	selectorLabels := map[string]string{
+		utils.RayClusterLabelKey:   instance.Name,
+		utils.RayNodeGroupLabelKey: worker.GroupName,
+		utils.RayNodeTypeLabelKey:  string(rayv1.WorkerNode),
+	}
+	statefulSet := appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.CheckName(fmt.Sprintf("%s-%s-%s", instance.Name, worker.GroupName, rayv1.WorkerNode)),
+			Namespace: instance.Namespace,
+			Labels:    selectorLabels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: selectorLabels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: pod.Labels, Annotations: pod.Annotations},
+				Spec:       pod.Spec,
+			},
+			VolumeClaimTemplates: worker.VolumeClaimTemplates,
+			ServiceName:          utils.CheckName(common.BuildHeadlessServiceForRayCluster(instance).Name),
+			// Start and stop the Ray worker nodes in parallel. Scaling down still removes the highest ordinals.
+			PodManagementPolicy: appsv1.ParallelPodManagement,
+			UpdateStrategy:      updateStrategy,
+		},
+	}
+	if err := controllerutil.SetControllerReference(&instance, &statefulSet, r.Scheme); err != nil {
+		return statefulSet, err
+	}
+	return statefulSet, nil
+}
+
+// Names of the cleanup steps of RayClusters in the CleanupSteps of the RayCluster status.
+const (
+	redisCleanupStepName        = "RedisCleanup"
+	loadBalancerCleanupStepName = "LoadBalancerCleanup"
+	volumeClaimCleanupStepName  = "VolumeClaimCleanup"
+)
+
+// rayClusterCleanupStep is a step of the cleanup of a RayCluster that is being deleted. It deletes the resources of
+// the RayCluster that Kubernetes does not garbage collect, or that should not outlive the RayCluster. Each step has
+// its own finalizer, which is added to the RayCluster if the step is enabled, and removed once the step is done.
+type rayClusterCleanupStep struct {
+	// enabled returns whether the RayCluster needs the step.
+	enabled func(instance *rayv1.RayCluster) bool
+	// run runs the step once. It returns the Running state while it waits for resources to be deleted, and the
+	// Completed or Failed state once it is done.
+	run       func(ctx context.Context, instance *rayv1.RayCluster) (cleanupStepResult, error)
+	name      string
+	finalizer string
+}
+
+type cleanupStepResult struct {
+	state   rayv1.CleanupStepState
+	message string
+	// requeueAfter is how long to wait before running a step in the Running state again.
+	requeueAfter time.Duration
+}
+
+// cleanupSteps returns the cleanup steps of RayClusters in the order in which they run.
+func (r *RayClusterReconciler) cleanupSteps() []rayClusterCleanupStep {
+	return []rayClusterCleanupStep{
+		{name: redisCleanupStepName, finalizer: utils.GCSFaultToleranceRedisCleanupFinalizer, enabled: isRedisCleanupEnabled, run: r.cleanupRedis},
+		{name: loadBalancerCleanupStepName, finalizer: utils.LoadBalancerCleanupFinalizer, enabled: isLoadBalancerCleanupEnabled, run: r.cleanupLoadBalancers},
+		{name: volumeClaimCleanupStepName, finalizer: utils.VolumeClaimCleanupFinalizer, enabled: isVolumeClaimCleanupEnabled, run: r.cleanupVolumeClaims},
+	}
+}
+
+func hasCleanupFinalizers(instance *rayv1.RayCluster, steps []rayClusterCleanupStep) bool {
+	for _, step := range steps {
+		if controllerutil.ContainsFinalizer(instance, step.finalizer) {
+			return true
+		}
+	}
+	return false
+}
+
+// addCleanupFinalizers adds the finalizers of the enabled cleanup steps to the RayCluster, and returns whether it
+// added any of them.
+func (r *RayClusterReconciler) addCleanupFinalizers(ctx context.Context, instance *rayv1.RayCluster, steps []rayClusterCleanupStep) (bool, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	added := false
+	for _, step := range steps {
+		if step.enabled(instance) && controllerutil.AddFinalizer(instance, step.finalizer) {
+			logger.Info("Adding a finalizer to run a cleanup step once the RayCluster is deleted", "step", step.name, "finalizer", step.finalizer)
+			added = true
+		}
+	}
+	if !added {
+		return false, nil
+	}
+	if err := r.Update(ctx, instance); err != nil {
+		return false, fmt.Errorf("Failed to add the cleanup finalizers to the RayCluster: %w", err)
+	}
+	return true, nil
+}
+
+// reconcileCleanup runs the cleanup steps whose finalizers the RayCluster still has one at a time, reports their
+// progress in the CleanupSteps of the RayCluster status, and removes the finalizer of each step once it is done.
+// If the RayCluster has the ray.io/force-delete annotation, the remaining steps are skipped instead.
+func (r *RayClusterReconciler) reconcileCleanup(ctx context.Context, instance *rayv1.RayCluster, steps []rayClusterCleanupStep) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	if err := r.cleanupBatchScheduling(ctx, instance); err != nil {
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+
+	oldCleanupSteps := instance.Status.DeepCopy().CleanupSteps
+	forceDelete := strings.ToLower(instance.Annotations[utils.RayForceDeleteAnnotationKey]) == "true"
+	var doneFinalizers, skippedSteps []string
+	var result ctrl.Result
+	var stepErr error
+	for _, step := range steps {
+		if !controllerutil.ContainsFinalizer(instance, step.finalizer) {
+			continue
+		}
+		if forceDelete {
+			setCleanupStepStatus(instance, step.name, rayv1.CleanupStepSkipped,
+				fmt.Sprintf("The step is skipped because the RayCluster has the %s annotation.", utils.RayForceDeleteAnnotationKey))
+			doneFinalizers = append(doneFinalizers, step.finalizer)
+			skippedSteps = append(skippedSteps, step.name)
+			continue
+		}
+
+		stepResult, err := step.run(ctx, instance)
+		if err != nil {
+			logger.Error(err, "Failed to run the cleanup step", "step", step.name)
+			setCleanupStepStatus(instance, step.name, rayv1.CleanupStepRunning, err.Error())
+			result, stepErr = ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+			break
+		}
+		setCleanupStepStatus(instance, step.name, stepResult.state, stepResult.message)
+		if stepResult.state == rayv1.CleanupStepRunning {
+			result = ctrl.Result{RequeueAfter: stepResult.requeueAfter}
+			break
+		}
+		if stepResult.state == rayv1.CleanupStepFailed {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedCleanupStep),
+				"Cleanup step %s failed: %s", step.name, stepResult.message)
+		} else {
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CompletedCleanupStep),
+				"Completed cleanup step %s: %s", step.name, stepResult.message)
+		}
+		doneFinalizers = append(doneFinalizers, step.finalizer)
+	}
+	if len(skippedSteps) > 0 {
+		logger.Info("Skipping the cleanup steps of the RayCluster", "annotation", utils.RayForceDeleteAnnotationKey, "steps", skippedSteps)
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.SkippedCleanupSteps),
+			"Skipped cleanup steps %s because of the %s annotation", strings.Join(skippedSteps, ", "), utils.RayForceDeleteAnnotationKey)
+	}
+
+	// Update the status before removing the finalizers, because the RayCluster is gone once it has no finalizer left.
+	if !reflect.DeepEqual(oldCleanupSteps, instance.Status.CleanupSteps) {
+		if err := r.Status().Update(ctx, instance); err != nil {
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+	}
+	if len(doneFinalizers) > 0 {
+		for _, finalizer := range doneFinalizers {
+			controllerutil.RemoveFinalizer(instance, finalizer)
+		}
+		if err := r.Update(ctx, instance); err != nil {
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+	}
+	return result, stepErr
+}
+
+// setCleanupStepStatus sets the state and the message of a cleanup step in the status of the RayCluster.
+func setCleanupStepStatus(instance *rayv1.RayCluster, name string, state rayv1.CleanupStepState, message string) {
+	for i := range instance.Status.CleanupSteps {
+		step := &instance.Status.CleanupSteps[i]
+		if step.Name != name {
+			continue
+		}
+		if step.State != state {
+			now := metav1.Now()
+			step.LastTransitionTime = &now
 		}
-		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateRoute), "Failed creating route %s/%s, %v", route.Namespace, route.Name, err)
-		return err
+		step.State = state
+		step.Message = message
+		return
 	}
-	logger.Info("Created route for RayCluster", "name", route.Name)
-	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedRoute), "Created route %s/%s", route.Namespace, route.Name)
-	return nil
+	now := metav1.Now()
+	instance.Status.CleanupSteps = append(instance.Status.CleanupSteps, rayv1.CleanupStepStatus{
+		Name:               name,
+		State:              state,
+		Message:            message,
+		LastTransitionTime: &now,
+	})
 }
 
-func (r *RayClusterReconciler) createService(ctx context.Context, svc *corev1.Service, instance *rayv1.RayCluster) error {
+func isRedisCleanupEnabled(instance *rayv1.RayCluster) bool {
+	// The `enableGCSFTRedisCleanup` is a feature flag introduced in KubeRay v1.0.0. It determines whether
+	// the Redis cleanup job should be activated. Users can disable the feature by setting the environment
+	// variable `ENABLE_GCS_FT_REDIS_CLEANUP` to `false`, and undertake the Redis storage namespace cleanup
+	// manually after the RayCluster CR deletion.
+	enableGCSFTRedisCleanup := strings.ToLower(os.Getenv(utils.ENABLE_GCS_FT_REDIS_CLEANUP)) != "false"
+	return enableGCSFTRedisCleanup && common.IsGCSFaultToleranceEnabled(*instance)
+}
+
+// cleanupRedis deletes the storage namespace of the GCS of the RayCluster in Redis with a Kubernetes Job, once the
+// head Pod has been terminated.
+func (r *RayClusterReconciler) cleanupRedis(ctx context.Context, instance *rayv1.RayCluster) (cleanupStepResult, error) {
 	logger := ctrl.LoggerFrom(ctx)
 
-	// making sure the name is valid
-	svc.Name = utils.CheckName(svc.Name)
-	if err := controllerutil.SetControllerReference(instance, svc, r.Scheme); err != nil {
-		return err
+	// Delete the head Pod if it exists.
+	headPods, err := r.deleteAllPods(ctx, common.RayClusterHeadPodsAssociationOptions(instance))
+	if err != nil {
+		return cleanupStepResult{}, err
 	}
-
-	if err := r.Create(ctx, svc); err != nil {
-		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateService), "Failed creating service %s/%s, %v", svc.Namespace, svc.Name, err)
-		return err
+	// Delete all worker Pods if they exist.
+	if _, err = r.deleteAllPods(ctx, common.RayClusterWorkerPodsAssociationOptions(instance)); err != nil {
+		return cleanupStepResult{}, err
+	}
+	if len(headPods.Items) > 0 {
+		logger.Info(fmt.Sprintf(
+			"Wait for the head Pod %s to be terminated before initiating the Redis cleanup process. "+
+				"The storage namespace %s in Redis cannot be fully deleted if the GCS process on the head Pod is still writing to it.",
+			headPods.Items[0].Name, headPods.Items[0].Annotations[utils.RayExternalStorageNSAnnotationKey]))
+		return cleanupStepResult{
+			state:   rayv1.CleanupStepRunning,
+			message: fmt.Sprintf("Waiting for the head Pod %s to be terminated.", headPods.Items[0].Name),
+			// Requeue after 10 seconds because it takes much longer than DefaultRequeueDuration (2 seconds) for the head Pod to be terminated.
+			requeueAfter: 10 * time.Second,
+		}, nil
 	}
-	logger.Info("Created service for RayCluster", "name", svc.Name)
-	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedService), "Created service %s/%s", svc.Namespace, svc.Name)
-	return nil
-}
 
-func (r *RayClusterReconciler) createHeadPod(ctx context.Context, instance rayv1.RayCluster) error {
-	logger := ctrl.LoggerFrom(ctx)
+	// We can start the Redis cleanup process now because the head Pod has been terminated.
+	filterLabels := client.MatchingLabels{utils.RayClusterLabelKey: instance.Name, utils.RayNodeTypeLabelKey: string(rayv1.RedisCleanupNode)}
+	redisCleanupJobs := batchv1.JobList{}
+	if err := r.List(ctx, &redisCleanupJobs, client.InNamespace(instance.Namespace), filterLabels); err != nil {
+		return cleanupStepResult{}, err
+	}
 
-	// build the pod then create it
-	pod := r.buildHeadPod(ctx, instance)
-	// check if the batch scheduler integration is enabled
-	// call the scheduler plugin if so
-	if r.BatchSchedulerMgr != nil {
-		if scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(&instance); err == nil {
-			scheduler.AddMetadataToPod(&instance, utils.RayNodeHeadGroupLabelValue, &pod)
-		} else {
-			return err
+	if len(redisCleanupJobs.Items) != 0 {
+		// Check whether the Redis cleanup Job has been completed.
+		redisCleanupJob := redisCleanupJobs.Items[0]
+		storageNamespace := redisCleanupJob.Annotations[utils.RayExternalStorageNSAnnotationKey]
+		logger.Info("Redis cleanup Job status", "Job name", redisCleanupJob.Name,
+			"Active", redisCleanupJob.Status.Active, "Succeeded", redisCleanupJob.Status.Succeeded, "Failed", redisCleanupJob.Status.Failed)
+		condition, finished := utils.IsJobFinished(&redisCleanupJob)
+		if !finished {
+			return cleanupStepResult{
+				state:        rayv1.CleanupStepRunning,
+				message:      fmt.Sprintf("Waiting for the Redis cleanup Job %s to finish.", redisCleanupJob.Name),
+				requeueAfter: DefaultRequeueDuration,
+			}, nil
 		}
+		if condition == batchv1.JobComplete {
+			logger.Info(fmt.Sprintf(
+				"The Redis cleanup Job %s has been completed. "+
+					"The storage namespace %s in Redis has been fully deleted.",
+				redisCleanupJob.Name, storageNamespace))
+			return cleanupStepResult{
+				state:   rayv1.CleanupStepCompleted,
+				message: fmt.Sprintf("The Redis cleanup Job %s deleted the storage namespace %s in Redis.", redisCleanupJob.Name, storageNamespace),
+			}, nil
+		}
+		logger.Info(fmt.Sprintf(
+			"The Redis cleanup Job %s has failed. "+
+				"You should manually delete the storage namespace %s in Redis. "+
+				"Please check https://docs.ray.io/en/master/cluster/kubernetes/user-guides/kuberay-gcs-ft.html for more details.",
+			redisCleanupJob.Name, storageNamespace))
+		return cleanupStepResult{
+			state:   rayv1.CleanupStepFailed,
+			message: fmt.Sprintf("The Redis cleanup Job %s failed. Delete the storage namespace %s in Redis manually.", redisCleanupJob.Name, storageNamespace),
+		}, nil
 	}
 
-	if err := r.Create(ctx, &pod); err != nil {
-		r.Recorder.Eventf(&instance, corev1.EventTypeWarning, string(utils.FailedToCreateHeadPod), "Failed to create head Pod %s/%s, %v", pod.Namespace, pod.Name, err)
-		return err
+	redisCleanupJob, err := r.buildRedisCleanupJob(ctx, *instance)
+	if err != nil {
+		return cleanupStepResult{}, err
 	}
-	logger.Info("Created head Pod for RayCluster", "name", pod.Name)
-	r.Recorder.Eventf(&instance, corev1.EventTypeNormal, string(utils.CreatedHeadPod), "Created head Pod %s/%s", pod.Namespace, pod.Name)
-	return nil
-}
-
-func (r *RayClusterReconciler) createWorkerPod(ctx context.Context, instance rayv1.RayCluster, worker rayv1.WorkerGroupSpec) error {
-	logger := ctrl.LoggerFrom(ctx)
-
-	// build the pod then create it
-	pod := r.buildWorkerPod(ctx, instance, worker)
-	if r.BatchSchedulerMgr != nil {
-		if scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(&instance); err == nil {
-			scheduler.AddMetadataToPod(&instance, worker.GroupName, &pod)
-		} else {
-			return err
+	if err := r.Create(ctx, &redisCleanupJob); err != nil {
+		if errors.IsAlreadyExists(err) {
+			logger.Info("Redis cleanup Job already exists. Requeue the RayCluster CR.")
+			return cleanupStepResult{
+				state:        rayv1.CleanupStepRunning,
+				message:      fmt.Sprintf("Waiting for the Redis cleanup Job %s to finish.", redisCleanupJob.Name),
+				requeueAfter: DefaultRequeueDuration,
+			}, nil
 		}
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateRedisCleanupJob),
+			"Failed to create Redis cleanup Job %s/%s, %v", redisCleanupJob.Namespace, redisCleanupJob.Name, err)
+		return cleanupStepResult{}, err
 	}
+	logger.Info("Created Redis cleanup Job", "name", redisCleanupJob.Name)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedRedisCleanupJob),
+		"Created Redis cleanup Job %s/%s", redisCleanupJob.Namespace, redisCleanupJob.Name)
+	return cleanupStepResult{
+		state:        rayv1.CleanupStepRunning,
+		message:      fmt.Sprintf("Created the Redis cleanup Job %s.", redisCleanupJob.Name),
+		requeueAfter: DefaultRequeueDuration,
+	}, nil
+}
 
-	if err := r.Create(ctx, &pod); err != nil {
-		r.Recorder.Eventf(&instance, corev1.EventTypeWarning, string(utils.FailedToCreateWorkerPod), "Failed to create worker Pod %s/%s, %v", pod.Namespace, pod.Name, err)
-		return err
-	}
-	logger.Info("Created worker Pod for RayCluster", "name", pod.Name)
-	r.Recorder.Eventf(&instance, corev1.EventTypeNormal, string(utils.CreatedWorkerPod), "Created worker Pod %s/%s", pod.Namespace, pod.Name)
-	return nil
+func isLoadBalancerCleanupEnabled(instance *rayv1.RayCluster) bool {
+	return instance.Spec.HeadGroupSpec.ServiceType == corev1.ServiceTypeLoadBalancer
 }
 
-// Build head instance pod(s).
-func (r *RayClusterReconciler) buildHeadPod(ctx context.Context, instance rayv1.RayCluster) corev1.Pod {
-	logger := ctrl.LoggerFrom(ctx)
-	podName := utils.PodGenerateName(instance.Name, rayv1.HeadNode)
-	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, instance, instance.Namespace) // Fully Qualified Domain Name
-	// The Ray head port used by workers to connect to the cluster (GCS server port for Ray >= 1.11.0, Redis port for older Ray.)
-	headPort := common.GetHeadPort(instance.Spec.HeadGroupSpec.RayStartParams)
-	autoscalingEnabled := instance.Spec.EnableInTreeAutoscaling
-	podConf := common.DefaultHeadPodTemplate(ctx, instance, instance.Spec.HeadGroupSpec, podName, headPort)
-	if len(r.headSidecarContainers) > 0 {
-		podConf.Spec.Containers = append(podConf.Spec.Containers, r.headSidecarContainers...)
+// cleanupLoadBalancers deletes the Services of type LoadBalancer of the RayCluster, and waits until they are gone.
+// The service controller of the cloud provider only removes the finalizer of such a Service once it has released
+// its load balancer, so the RayCluster is not gone before its load balancers are.
+func (r *RayClusterReconciler) cleanupLoadBalancers(ctx context.Context, instance *rayv1.RayCluster) (cleanupStepResult, error) {
+	services := corev1.ServiceList{}
+	if err := r.List(ctx, &services, client.InNamespace(instance.Namespace)); err != nil {
+		return cleanupStepResult{}, err
 	}
-	logger.Info("head pod labels", "labels", podConf.Labels)
-	creatorCRDType := getCreatorCRDType(instance)
-	pod := common.BuildPod(ctx, podConf, rayv1.HeadNode, instance.Spec.HeadGroupSpec.RayStartParams, headPort, autoscalingEnabled, creatorCRDType, fqdnRayIP)
-	// Set raycluster instance as the owner and controller
-	if err := controllerutil.SetControllerReference(&instance, &pod, r.Scheme); err != nil {
-		logger.Error(err, "Failed to set controller reference for raycluster pod")
+	var remaining []string
+	for i := range services.Items {
+		service := &services.Items[i]
+		if service.Spec.Type != corev1.ServiceTypeLoadBalancer || !metav1.IsControlledBy(service, instance) {
+			continue
+		}
+		remaining = append(remaining, service.Name)
+		if !service.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, service); err != nil && !errors.IsNotFound(err) {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteService),
+				"Failed to delete Service %s/%s, %v", service.Namespace, service.Name, err)
+			return cleanupStepResult{}, err
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedService),
+			"Deleted Service %s/%s", service.Namespace, service.Name)
 	}
-
-	return pod
+	if len(remaining) > 0 {
+		return cleanupStepResult{
+			state:        rayv1.CleanupStepRunning,
+			message:      fmt.Sprintf("Waiting for the load balancers of the Services %s to be released.", strings.Join(remaining, ", ")),
+			requeueAfter: DefaultRequeueDuration,
+		}, nil
+	}
+	return cleanupStepResult{state: rayv1.CleanupStepCompleted, message: "The load balancers of the RayCluster have been released."}, nil
 }
 
-func getCreatorCRDType(instance rayv1.RayCluster) utils.CRDType {
-	return utils.GetCRDType(instance.Labels[utils.RayOriginatedFromCRDLabelKey])
+func isVolumeClaimCleanupEnabled(instance *rayv1.RayCluster) bool {
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		if worker.VolumeClaimRetentionPolicy == rayv1.DeleteVolumeClaimRetentionPolicy && len(worker.VolumeClaimTemplates) > 0 {
+			return true
+		}
+	}
+	return false
 }
 
-// Build worker instance pods.
-func (r *RayClusterReconciler) buildWorkerPod(ctx context.Context, instance rayv1.RayCluster, worker rayv1.WorkerGroupSpec) corev1.Pod {
-	logger := ctrl.LoggerFrom(ctx)
-	podName := utils.PodGenerateName(fmt.Sprintf("%s-%s", instance.Name, worker.GroupName), rayv1.WorkerNode)
-	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, instance, instance.Namespace) // Fully Qualified Domain Name
+// cleanupVolumeClaims deletes the PersistentVolumeClaims of the StatefulSet worker groups with the Delete volume
+// claim retention policy, and waits until they are gone. It deletes the StatefulSets of the groups first, so that
+// they do not recreate the Pods that use the claims.
+func (r *RayClusterReconciler) cleanupVolumeClaims(ctx context.Context, instance *rayv1.RayCluster) (cleanupStepResult, error) {
+	var remaining []string
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		if worker.VolumeClaimRetentionPolicy != rayv1.DeleteVolumeClaimRetentionPolicy || len(worker.VolumeClaimTemplates) == 0 {
+			continue
+		}
+		// The StatefulSet controller labels the PersistentVolumeClaims with the selector labels of the StatefulSet.
+		selectorLabels := client.MatchingLabels{
+			utils.RayClusterLabelKey:   instance.Name,
+			utils.RayNodeGroupLabelKey: worker.GroupName,
+			utils.RayNodeTypeLabelKey:  string(rayv1.WorkerNode),
+		}
+		if err := r.DeleteAllOf(ctx, &appsv1.StatefulSet{}, client.InNamespace(instance.Namespace), selectorLabels); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerStatefulSet),
+				"Failed deleting the StatefulSet of worker group %s of RayCluster %s/%s, %v", worker.GroupName, instance.Namespace, instance.Name, err)
+			return cleanupStepResult{}, err
+		}
 
-	// The Ray head port used by workers to connect to the cluster (GCS server port for Ray >= 1.11.0, Redis port for older Ray.)
-	headPort := common.GetHeadPort(instance.Spec.HeadGroupSpec.RayStartParams)
-	autoscalingEnabled := instance.Spec.EnableInTreeAutoscaling
-	podTemplateSpec := common.DefaultWorkerPodTemplate(ctx, instance, worker, podName, fqdnRayIP, headPort)
-	if len(r.workerSidecarContainers) > 0 {
-		podTemplateSpec.Spec.Containers = append(podTemplateSpec.Spec.Containers, r.workerSidecarContainers...)
+		claims := corev1.PersistentVolumeClaimList{}
+		if err := r.List(ctx, &claims, client.InNamespace(instance.Namespace), selectorLabels); err != nil {
+			return cleanupStepResult{}, err
+		}
+		active := 0
+		for _, claim := range claims.Items {
+			remaining = append(remaining, claim.Name)
+			if claim.DeletionTimestamp.IsZero() {
+				active++
+			}
+		}
+		if active > 0 {
+			if err := r.DeleteAllOf(ctx, &corev1.PersistentVolumeClaim{}, client.InNamespace(instance.Namespace), selectorLabels); err != nil {
+				return cleanupStepResult{}, err
+			}
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedPersistentVolumeClaims),
+				"Deleted %d PersistentVolumeClaims of worker group %s", active, worker.GroupName)
+		}
 	}
-	creatorCRDType := getCreatorCRDType(instance)
-	pod := common.BuildPod(ctx, podTemplateSpec, rayv1.WorkerNode, worker.RayStartParams, headPort, autoscalingEnabled, creatorCRDType, fqdnRayIP)
-	// Set raycluster instance as the owner and controller
-	if err := controllerutil.SetControllerReference(&instance, &pod, r.Scheme); err != nil {
-		logger.Error(err, "Failed to set controller reference for raycluster pod")
+	if len(remaining) > 0 {
+		return cleanupStepResult{
+			state:        rayv1.CleanupStepRunning,
+			message:      fmt.Sprintf("Waiting for the PersistentVolumeClaims %s to be deleted.", strings.Join(remaining, ", ")),
+			requeueAfter: DefaultRequeueDuration,
+		}, nil
 	}
-
-	return pod
+	return cleanupStepResult{state: rayv1.CleanupStepCompleted, message: "The PersistentVolumeClaims of the worker groups with the Delete volume claim retention policy have been deleted."}, nil
 }
 
-func (r *RayClusterReconciler) buildRedisCleanupJob(ctx context.Context, instance rayv1.RayCluster) batchv1.Job {
+func (r *RayClusterReconciler) buildRedisCleanupJob(ctx context.Context, instance rayv1.RayCluster) (batchv1.Job, error) {
 	logger := ctrl.LoggerFrom(ctx)
 
-	pod := r.buildHeadPod(ctx, instance)
+	pod, err := r.buildHeadPod(ctx, instance)
+	if err != nil {
+		return batchv1.Job{}, err
+	}
 	pod.Labels[utils.RayNodeTypeLabelKey] = string(rayv1.RedisCleanupNode)
 
 	// Only keep the Ray container in the Redis cleanup Job.
 	pod.Spec.Containers = []corev1.Container{pod.Spec.Containers[utils.RayContainerIndex]}
 	pod.Spec.Containers[utils.RayContainerIndex].Command = []string{"/bin/bash", "-lc", "--"}
+	// The Job cleans up a standalone Redis through the first endpoint of RAY_REDIS_ADDRESS, which Ray connects to,
+	// and tries the endpoints of Redis Sentinel and Redis Cluster one by one until the cleanup succeeds.
+	redisAddresses := "${RAY_REDIS_ADDRESS%%,*}"
+	redisCleanup := &rayv1.RedisCleanupOptions{}
+	if options := instance.Spec.GcsFaultToleranceOptions; options != nil {
+		if options.RedisMode == rayv1.SentinelRedisMode || options.RedisMode == rayv1.ClusterRedisMode {
+			redisAddresses = "${RAY_REDIS_ADDRESS//,/ }"
+		}
+		if options.RedisCleanup != nil {
+			redisCleanup = options.RedisCleanup
+		}
+	}
 	pod.Spec.Containers[utils.RayContainerIndex].Args = []string{
 		"echo \"To get more information about manually delete the storage namespace in Redis and remove the RayCluster's finalizer, please check https://docs.ray.io/en/master/cluster/kubernetes/user-guides/kuberay-gcs-ft.html for more details.\" && " +
+			"for redis_address in " + redisAddresses + "; do " +
 			"python -c " +
 			"\"from ray._private.gcs_utils import cleanup_redis_storage; " +
 			"from urllib.parse import urlparse; " +
 			"import os; " +
 			"import sys; " +
-			"redis_address = os.getenv('RAY_REDIS_ADDRESS', '').split(',')[0]; " +
+			"redis_address = sys.argv[1].strip(); " +
 			"redis_address = redis_address if '://' in redis_address else 'redis://' + redis_address; " +
 			"parsed = urlparse(redis_address); " +
-			"sys.exit(1) if not cleanup_redis_storage(host=parsed.hostname, port=parsed.port, password=os.getenv('REDIS_PASSWORD', parsed.password), use_ssl=parsed.scheme=='rediss', storage_namespace=os.getenv('RAY_external_storage_namespace')) else None\"",
+			"sys.exit(1) if not cleanup_redis_storage(host=parsed.hostname, port=parsed.port, password=os.getenv('REDIS_PASSWORD', parsed.password), use_ssl=parsed.scheme=='rediss', storage_namespace=os.getenv('RAY_external_storage_namespace'), " +
+			"**({'username': os.getenv('REDIS_USERNAME')} if os.getenv('REDIS_USERNAME') else {})) else None\" \"$redis_address\" && exit 0; " +
+			"done; exit 1",
+	}
+	if redisCleanup.Image != "" {
+		pod.Spec.Containers[utils.RayContainerIndex].Image = redisCleanup.Image
+	}
+	// Retry the cleanup in case Redis is temporarily unavailable. The Job is still bounded by ActiveDeadlineSeconds.
+	backoffLimit := ptr.To[int32](2)
+	if redisCleanup.BackoffLimit != nil {
+		backoffLimit = redisCleanup.BackoffLimit
 	}
 
-	// Disable liveness and readiness probes because the Job will not launch processes like Raylet and GCS.
+	// Disable the probes because the Job will not launch processes like Raylet and GCS.
+	pod.Spec.Containers[utils.RayContainerIndex].StartupProbe = nil
 	pod.Spec.Containers[utils.RayContainerIndex].LivenessProbe = nil
 	pod.Spec.Containers[utils.RayContainerIndex].ReadinessProbe = nil
 
@@ -1154,7 +3560,7 @@ func (r *RayClusterReconciler) buildRedisCleanupJob(ctx context.Context, instanc
 			Annotations: pod.Annotations,
 		},
 		Spec: batchv1.JobSpec{
-			BackoffLimit: ptr.To[int32](0),
+			BackoffLimit: backoffLimit,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: pod.ObjectMeta,
 				Spec:       pod.Spec,
@@ -1168,11 +3574,11 @@ func (r *RayClusterReconciler) buildRedisCleanupJob(ctx context.Context, instanc
 		logger.Error(err, "Failed to set controller reference for the Redis cleanup Job.")
 	}
 
-	return redisCleanupJob
+	return redisCleanupJob, nil
 }
 
 // SetupWithManager builds the reconciler.
-func (r *RayClusterReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int) error {
+func (r *RayClusterReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int, rateLimiter workqueue.TypedRateLimiter[reconcile.Request], scope utils.Scope) error {
 	b := ctrl.NewControllerManagedBy(mgr).
 		For(&rayv1.RayCluster{}, builder.WithPredicates(predicate.Or(
 			predicate.GenerationChangedPredicate{},
@@ -1180,24 +3586,26 @@ func (r *RayClusterReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcu
 			predicate.AnnotationChangedPredicate{},
 		))).
 		Owns(&corev1.Pod{}).
-		Owns(&corev1.Service{})
+		Owns(&corev1.Service{}).
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&policyv1.PodDisruptionBudget{})
 
 	if r.BatchSchedulerMgr != nil {
 		r.BatchSchedulerMgr.ConfigureReconciler(b)
 	}
 
-	return b.
-		WithOptions(controller.Options{
-			MaxConcurrentReconciles: reconcileConcurrency,
-			LogConstructor: func(request *reconcile.Request) logr.Logger {
-				logger := ctrl.Log.WithName("controllers").WithName("RayCluster")
-				if request != nil {
-					logger = logger.WithValues("RayCluster", request.NamespacedName)
-				}
-				return logger
-			},
-		}).
-		Complete(r)
+	b.WithOptions(controller.Options{
+		MaxConcurrentReconciles: reconcileConcurrency,
+		RateLimiter:             rateLimiter,
+		LogConstructor: func(request *reconcile.Request) logr.Logger {
+			logger := ctrl.Log.WithName("controllers").WithName("RayCluster")
+			if request != nil {
+				logger = logger.WithValues("RayCluster", request.NamespacedName)
+			}
+			return logger
+		},
+	})
+	return scope.Complete(b, mgr, &rayv1.RayCluster{}, r)
 }
 
 func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *rayv1.RayCluster, reconcileErr error) (*rayv1.RayCluster, error) {
@@ -1225,6 +3633,36 @@ func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *ra
 			// if reconcileErr == nil, we can safely remove the RayClusterReplicaFailure condition.
 			meta.RemoveStatusCondition(&newInstance.Status.Conditions, string(rayv1.RayClusterReplicaFailure))
 		}
+		if reason := schedulerinterface.BatchSchedulingRejectedReason(reconcileErr); reason != "" {
+			meta.SetStatusCondition(&newInstance.Status.Conditions, metav1.Condition{
+				Type:    string(rayv1.RayClusterBatchSchedulingRejected),
+				Status:  metav1.ConditionTrue,
+				Reason:  reason,
+				Message: reconcileErr.Error(),
+			})
+		} else if reconcileErr == nil {
+			meta.RemoveStatusCondition(&newInstance.Status.Conditions, string(rayv1.RayClusterBatchSchedulingRejected))
+		}
+		if errstd.Is(reconcileErr, utils.ErrNamespaceQuotaExceeded) {
+			meta.SetStatusCondition(&newInstance.Status.Conditions, metav1.Condition{
+				Type:    string(rayv1.RayClusterQuotaExceeded),
+				Status:  metav1.ConditionTrue,
+				Reason:  string(rayv1.RayClusterQuotaExceeded),
+				Message: reconcileErr.Error(),
+			})
+		} else if reconcileErr == nil {
+			meta.RemoveStatusCondition(&newInstance.Status.Conditions, string(rayv1.RayClusterQuotaExceeded))
+		}
+		if groups := getCrashLoopingWorkerGroups(newInstance); len(groups) > 0 {
+			meta.SetStatusCondition(&newInstance.Status.Conditions, metav1.Condition{
+				Type:    string(rayv1.WorkerGroupCrashLoop),
+				Status:  metav1.ConditionTrue,
+				Reason:  rayv1.WorkerPodsKeepFailing,
+				Message: fmt.Sprintf("The failed worker Pods of groups %s have been recreated at least %d times in a row", strings.Join(groups, ", "), utils.WorkerGroupCrashLoopThreshold),
+			})
+		} else {
+			meta.RemoveStatusCondition(&newInstance.Status.Conditions, string(rayv1.WorkerGroupCrashLoop))
+		}
 	}
 
 	// TODO (kevin85421): ObservedGeneration should be used to determine whether to update this CR or not.
@@ -1247,6 +3685,8 @@ func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *ra
 	newInstance.Status.DesiredMemory = totalResources[corev1.ResourceMemory]
 	newInstance.Status.DesiredGPU = sumGPUs(totalResources)
 	newInstance.Status.DesiredTPU = totalResources[corev1.ResourceName("google.com/tpu")]
+	newInstance.Status.ReadyGPU = calculateReadyGPUs(runtimePods)
+	newInstance.Status.WorkerGroupStatuses = calculateWorkerGroupStatuses(ctx, newInstance, runtimePods)
 
 	if utils.CheckAllPodsRunning(ctx, runtimePods) {
 		newInstance.Status.State = rayv1.Ready //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
@@ -1270,6 +3710,18 @@ func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *ra
 			headPodReadyCondition := utils.FindHeadPodReadyCondition(headPod)
 			meta.SetStatusCondition(&newInstance.Status.Conditions, headPodReadyCondition)
 		}
+		if newInstance.Spec.EnableInTreeAutoscaling == nil || !*newInstance.Spec.EnableInTreeAutoscaling {
+			meta.RemoveStatusCondition(&newInstance.Status.Conditions, string(rayv1.AutoscalerReady))
+		} else if headPod == nil {
+			meta.SetStatusCondition(&newInstance.Status.Conditions, metav1.Condition{
+				Type:    string(rayv1.AutoscalerReady),
+				Status:  metav1.ConditionFalse,
+				Reason:  rayv1.HeadPodNotFound,
+				Message: "Head Pod not found",
+			})
+		} else {
+			meta.SetStatusCondition(&newInstance.Status.Conditions, getAutoscalerReadyCondition(headPod))
+		}
 
 		suspendStatus := utils.FindRayClusterSuspendStatus(newInstance)
 		if !meta.IsStatusConditionTrue(newInstance.Status.Conditions, string(rayv1.RayClusterProvisioned)) && suspendStatus != rayv1.RayClusterSuspended {
@@ -1339,6 +3791,17 @@ func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *ra
 				})
 			}
 		}
+
+	}
+
+	// The Ready condition is set regardless of the RayClusterStatusConditions feature gate, because GitOps tools and
+	// kubectl wait rely on it.
+	headPodReady := slices.ContainsFunc(runtimePods.Items, func(pod corev1.Pod) bool {
+		return pod.Labels[utils.RayNodeTypeLabelKey] == string(rayv1.HeadNode) && utils.IsRunningAndReady(&pod)
+	})
+	meta.SetStatusCondition(&newInstance.Status.Conditions, getRayClusterReadyCondition(newInstance, headPodReady))
+	for i := range newInstance.Status.Conditions {
+		newInstance.Status.Conditions[i].ObservedGeneration = newInstance.Generation
 	}
 
 	if newInstance.Spec.Suspend != nil && *newInstance.Spec.Suspend && len(runtimePods.Items) == 0 {
@@ -1453,9 +3916,30 @@ func (r *RayClusterReconciler) updateHeadInfo(ctx context.Context, instance *ray
 	instance.Status.Head.ServiceIP = ip
 	instance.Status.Head.ServiceName = name
 
+	dashboardURL, err := r.getDashboardURL(ctx, instance.Namespace, name)
+	if err != nil {
+		return err
+	}
+	instance.Status.Head.DashboardURL = dashboardURL
+
 	return nil
 }
 
+// getDashboardURL returns the in-cluster URL of the Ray dashboard served by the head service, or an empty string
+// if the head service has no dashboard port.
+func (r *RayClusterReconciler) getDashboardURL(ctx context.Context, namespace string, headServiceName string) (string, error) {
+	headService := &corev1.Service{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: headServiceName}, headService); err != nil {
+		return "", err
+	}
+	for _, port := range headService.Spec.Ports {
+		if port.Name == utils.DashboardPortName {
+			return fmt.Sprintf("http://%s.%s.svc.%s:%d", headServiceName, namespace, utils.GetClusterDomainName(), port.Port), nil
+		}
+	}
+	return "", nil
+}
+
 func (r *RayClusterReconciler) reconcileAutoscalerServiceAccount(ctx context.Context, instance *rayv1.RayCluster) error {
 	logger := ctrl.LoggerFrom(ctx)
 	if instance.Spec.EnableInTreeAutoscaling == nil || !*instance.Spec.EnableInTreeAutoscaling {
@@ -1499,7 +3983,7 @@ func (r *RayClusterReconciler) reconcileAutoscalerServiceAccount(ctx context.Con
 			return err
 		}
 
-		if err := r.Create(ctx, serviceAccount); err != nil {
+		if err := applyObject(ctx, r.Client, serviceAccount); err != nil {
 			if errors.IsAlreadyExists(err) {
 				logger.Info("Pod service account already exist, no need to create")
 				return nil
@@ -1541,7 +4025,7 @@ func (r *RayClusterReconciler) reconcileAutoscalerRole(ctx context.Context, inst
 			return err
 		}
 
-		if err := r.Create(ctx, role); err != nil {
+		if err := applyObject(ctx, r.Client, role); err != nil {
 			if errors.IsAlreadyExists(err) {
 				logger.Info("role already exist, no need to create")
 				return nil
@@ -1583,7 +4067,7 @@ func (r *RayClusterReconciler) reconcileAutoscalerRoleBinding(ctx context.Contex
 			return err
 		}
 
-		if err := r.Create(ctx, roleBinding); err != nil {
+		if err := applyObject(ctx, r.Client, roleBinding); err != nil {
 			if errors.IsAlreadyExists(err) {
 				logger.Info("role binding already exist, no need to create")
 				return nil
@@ -1611,10 +4095,82 @@ func (r *RayClusterReconciler) updateRayClusterStatus(ctx context.Context, origi
 	err := r.Status().Update(ctx, newInstance)
 	if err != nil {
 		logger.Info("Error updating status", "name", originalRayClusterInstance.Name, "error", err, "RayCluster", newInstance)
+		return inconsistent, err
+	}
+	// All Pods of the RayCluster are ready for the first time.
+	if readyTime := newInstance.Status.StateTransitionTimes[rayv1.Ready]; readyTime != nil && originalRayClusterInstance.Status.StateTransitionTimes[rayv1.Ready] == nil { //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
+		common.ObserveClusterProvisionedDuration(newInstance.Namespace, readyTime.Sub(newInstance.CreationTimestamp.Time))
 	}
 	return inconsistent, err
 }
 
+// checkNamespaceQuota returns an error wrapping utils.ErrNamespaceQuotaExceeded if the Pods of the RayCluster, along
+// with the Ray Pods of the other RayClusters in its namespace, would request more resources than the quota of the
+// namespace. The quota is the lowest limit of each resource in the ConfigMaps with the ray.io/quota label. It is
+// checked right before Pods are created, so that a RayCluster that exceeds the quota can still delete its Pods.
+func (r *RayClusterReconciler) checkNamespaceQuota(ctx context.Context, instance *rayv1.RayCluster) error {
+	if !features.Enabled(features.RayNamespaceQuota) {
+		return nil
+	}
+	configMaps := corev1.ConfigMapList{}
+	if err := r.List(ctx, &configMaps, client.InNamespace(instance.Namespace), client.HasLabels{utils.RayQuotaLabelKey}); err != nil {
+		return err
+	}
+	quota := map[string]resource.Quantity{}
+	for _, configMap := range configMaps.Items {
+		for _, key := range []string{utils.RayQuotaCPUKey, utils.RayQuotaMemoryKey, utils.RayQuotaGPUKey} {
+			value, ok := configMap.Data[key]
+			if !ok {
+				continue
+			}
+			limit, err := resource.ParseQuantity(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s quota %q in ConfigMap %s/%s: %w", key, value, configMap.Namespace, configMap.Name, err)
+			}
+			if current, ok := quota[key]; !ok || limit.Cmp(current) < 0 {
+				quota[key] = limit
+			}
+		}
+	}
+	if len(quota) == 0 {
+		return nil
+	}
+
+	// The RayCluster is accounted for with its desired Pods rather than its existing ones.
+	pods := corev1.PodList{}
+	if err := r.List(ctx, &pods, client.InNamespace(instance.Namespace), client.MatchingLabels{utils.RayNodeLabelKey: "yes"}); err != nil {
+		return err
+	}
+	requests := utils.CalculateDesiredResources(instance)
+	for _, pod := range pods.Items {
+		if pod.Labels[utils.RayClusterLabelKey] == instance.Name || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for name, quantity := range utils.CalculatePodResource(pod.Spec) {
+			total := requests[name]
+			total.Add(quantity)
+			requests[name] = total
+		}
+	}
+	used := map[string]resource.Quantity{
+		utils.RayQuotaCPUKey:    requests[corev1.ResourceCPU],
+		utils.RayQuotaMemoryKey: requests[corev1.ResourceMemory],
+		utils.RayQuotaGPUKey:    sumGPUs(requests),
+	}
+	var exceeded []string
+	for _, key := range []string{utils.RayQuotaCPUKey, utils.RayQuotaMemoryKey, utils.RayQuotaGPUKey} {
+		limit, ok := quota[key]
+		requested := used[key]
+		if ok && requested.Cmp(limit) > 0 {
+			exceeded = append(exceeded, fmt.Sprintf("%s: requested %s, limited to %s", key, requested.String(), limit.String()))
+		}
+	}
+	if len(exceeded) > 0 {
+		return fmt.Errorf("%w (%s)", utils.ErrNamespaceQuotaExceeded, strings.Join(exceeded, ", "))
+	}
+	return nil
+}
+
 // sumGPUs sums the GPUs in the given resource list.
 func sumGPUs(resources map[corev1.ResourceName]resource.Quantity) resource.Quantity {
 	totalGPUs := resource.Quantity{}
@@ -1627,3 +4183,219 @@ func sumGPUs(resources map[corev1.ResourceName]resource.Quantity) resource.Quant
 
 	return totalGPUs
 }
+
+// calculateReadyGPUs returns the total GPUs of the Ray Pods that are running and ready.
+func calculateReadyGPUs(pods corev1.PodList) resource.Quantity {
+	readyGPUs := resource.Quantity{}
+	for _, pod := range pods.Items {
+		if utils.IsRunningAndReady(&pod) {
+			gpus := sumGPUs(utils.CalculatePodResource(pod.Spec))
+			readyGPUs.Add(gpus)
+		}
+	}
+	return readyGPUs
+}
+
+// calculateWorkerGroupStatuses breaks down the worker Pods of the RayCluster by worker group. The LastTransitionTime
+// of a group is carried over from the current status unless its desired or ready replicas changed.
+func calculateWorkerGroupStatuses(ctx context.Context, instance *rayv1.RayCluster, pods corev1.PodList) []rayv1.WorkerGroupStatus {
+	if len(instance.Spec.WorkerGroupSpecs) == 0 {
+		return nil
+	}
+	previousStatuses := make(map[string]rayv1.WorkerGroupStatus, len(instance.Status.WorkerGroupStatuses))
+	for _, status := range instance.Status.WorkerGroupStatuses {
+		previousStatuses[status.GroupName] = status
+	}
+
+	now := metav1.Now()
+	statuses := make([]rayv1.WorkerGroupStatus, 0, len(instance.Spec.WorkerGroupSpecs))
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		status := rayv1.WorkerGroupStatus{
+			GroupName:       worker.GroupName,
+			DesiredReplicas: utils.GetWorkerGroupDesiredReplicas(ctx, worker),
+		}
+		for _, pod := range pods.Items {
+			if pod.Labels[utils.RayNodeTypeLabelKey] != string(rayv1.WorkerNode) || pod.Labels[utils.RayNodeGroupLabelKey] != worker.GroupName {
+				continue
+			}
+			if pod.Status.Phase == corev1.PodFailed {
+				status.FailedReplicas++
+			} else if utils.IsRunningAndReady(&pod) {
+				status.ReadyReplicas++
+			}
+		}
+
+		previous, ok := previousStatuses[worker.GroupName]
+		status.Recreations = previous.Recreations
+		status.LastRecreationTime = previous.LastRecreationTime
+		status.Preemptions = previous.Preemptions
+		status.LastPreemptionTime = previous.LastPreemptionTime
+		status.SpotFallbackTime = previous.SpotFallbackTime
+		if ok && previous.DesiredReplicas == status.DesiredReplicas && previous.ReadyReplicas == status.ReadyReplicas {
+			status.LastTransitionTime = previous.LastTransitionTime
+		} else {
+			status.LastTransitionTime = &now
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// getRecreateBackoff returns the initial and the maximum backoff before the failed worker Pods of a worker group are
+// recreated. Without a recreate policy, the failed worker Pods are recreated immediately.
+func getRecreateBackoff(worker rayv1.WorkerGroupSpec) (initial time.Duration, maximum time.Duration) {
+	maximum = time.Duration(utils.DefaultRecreateMaxBackoffSeconds) * time.Second
+	if worker.RecreatePolicy == nil {
+		return 0, maximum
+	}
+	initial = time.Duration(ptr.Deref(worker.RecreatePolicy.InitialBackoffSeconds, utils.DefaultRecreateInitialBackoffSeconds)) * time.Second
+	maximum = time.Duration(ptr.Deref(worker.RecreatePolicy.MaxBackoffSeconds, utils.DefaultRecreateMaxBackoffSeconds)) * time.Second
+	return initial, maximum
+}
+
+// getWorkerGroupStatus returns the status of a worker group in the status of the RayCluster, or nil if it has none.
+func getWorkerGroupStatus(instance *rayv1.RayCluster, groupName string) *rayv1.WorkerGroupStatus {
+	for i := range instance.Status.WorkerGroupStatuses {
+		if instance.Status.WorkerGroupStatuses[i].GroupName == groupName {
+			return &instance.Status.WorkerGroupStatuses[i]
+		}
+	}
+	return nil
+}
+
+// getOrAddWorkerGroupStatus returns the status of a worker group in the status of the RayCluster, and adds it first if
+// it has none.
+func getOrAddWorkerGroupStatus(instance *rayv1.RayCluster, groupName string) *rayv1.WorkerGroupStatus {
+	if status := getWorkerGroupStatus(instance, groupName); status != nil {
+		return status
+	}
+	instance.Status.WorkerGroupStatuses = append(instance.Status.WorkerGroupStatuses, rayv1.WorkerGroupStatus{GroupName: groupName})
+	return &instance.Status.WorkerGroupStatuses[len(instance.Status.WorkerGroupStatuses)-1]
+}
+
+// recreateFailedWorkerPodsAfter returns how long the failed worker Pods of a worker group wait before they are
+// recreated. The backoff starts at the initial backoff after the first recreation and doubles up to the maximum
+// backoff with every consecutive recreation.
+func recreateFailedWorkerPodsAfter(instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec) time.Duration {
+	status := getWorkerGroupStatus(instance, worker.GroupName)
+	if status == nil || status.Recreations == 0 || status.LastRecreationTime == nil {
+		return 0
+	}
+	backoff, maximum := getRecreateBackoff(worker)
+	for i := int32(1); i < status.Recreations && backoff < maximum; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, maximum)
+	return time.Until(status.LastRecreationTime.Add(backoff))
+}
+
+// recordWorkerPodsRecreation records in the status of the RayCluster that the failed worker Pods of a worker group are
+// recreated, and reports the worker group once its worker Pods keep failing.
+func (r *RayClusterReconciler) recordWorkerPodsRecreation(instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec) {
+	status := getOrAddWorkerGroupStatus(instance, worker.GroupName)
+	status.Recreations++
+	status.LastRecreationTime = ptr.To(metav1.Now())
+	if status.Recreations == utils.WorkerGroupCrashLoopThreshold {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.WorkerGroupCrashLoop),
+			"The failed worker Pods of group %s have been recreated %d times in a row", worker.GroupName, status.Recreations)
+	}
+}
+
+// resetWorkerGroupRecreations forgets the recreations of the failed worker Pods of a worker group once no worker Pod
+// has failed for twice the maximum backoff.
+func resetWorkerGroupRecreations(instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec) {
+	status := getWorkerGroupStatus(instance, worker.GroupName)
+	if status == nil || status.LastRecreationTime == nil {
+		return
+	}
+	_, maximum := getRecreateBackoff(worker)
+	if time.Since(status.LastRecreationTime.Time) > 2*maximum {
+		status.Recreations = 0
+		status.LastRecreationTime = nil
+	}
+}
+
+// getCrashLoopingWorkerGroups returns the worker groups of a RayCluster whose failed worker Pods have been recreated
+// at least WorkerGroupCrashLoopThreshold times in a row.
+func getCrashLoopingWorkerGroups(instance *rayv1.RayCluster) []string {
+	var groups []string
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		if status := getWorkerGroupStatus(instance, worker.GroupName); status != nil && status.Recreations >= utils.WorkerGroupCrashLoopThreshold {
+			groups = append(groups, worker.GroupName)
+		}
+	}
+	return groups
+}
+
+// getSpotFallbackThresholdAndCooldown returns the number of preemptions after which a worker group falls back to
+// on-demand nodes, and how long it stays on on-demand nodes.
+func getSpotFallbackThresholdAndCooldown(spotFallback *rayv1.SpotFallbackOptions) (int32, time.Duration) {
+	threshold := ptr.Deref(spotFallback.PreemptionThreshold, utils.DefaultSpotFallbackPreemptionThreshold)
+	cooldown := time.Duration(ptr.Deref(spotFallback.CooldownSeconds, utils.DefaultSpotFallbackCooldownSeconds)) * time.Second
+	return threshold, cooldown
+}
+
+// isSpotFallbackActive returns whether the new worker Pods of a worker group with spot fallback are scheduled on
+// on-demand nodes, according to the status of the RayCluster.
+func isSpotFallbackActive(instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec) bool {
+	status := getWorkerGroupStatus(instance, worker.GroupName)
+	if status == nil || status.SpotFallbackTime == nil {
+		return false
+	}
+	_, cooldown := getSpotFallbackThresholdAndCooldown(worker.SpotFallback)
+	return time.Since(status.SpotFallbackTime.Time) < cooldown
+}
+
+// recordSpotPreemption records in the status of the RayCluster that a spot worker Pod of a worker group was preempted.
+// Once PreemptionThreshold spot worker Pods have been preempted within CooldownSeconds of each other, the worker group
+// falls back to on-demand nodes.
+func (r *RayClusterReconciler) recordSpotPreemption(instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec) {
+	status := getOrAddWorkerGroupStatus(instance, worker.GroupName)
+	threshold, cooldown := getSpotFallbackThresholdAndCooldown(worker.SpotFallback)
+	if status.LastPreemptionTime == nil || time.Since(status.LastPreemptionTime.Time) > cooldown {
+		status.Preemptions = 0
+	}
+	status.Preemptions++
+	status.LastPreemptionTime = ptr.To(metav1.Now())
+	if status.Preemptions >= threshold && status.SpotFallbackTime == nil {
+		status.Preemptions = 0
+		status.SpotFallbackTime = status.LastPreemptionTime
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FellBackToOnDemandNodes),
+			"Worker group %s falls back to on-demand nodes for %s, because %d of its spot worker Pods were preempted", worker.GroupName, cooldown, threshold)
+	}
+}
+
+// reconcileSpotFallback returns a worker group to spot nodes once it has been on on-demand nodes for CooldownSeconds,
+// and forgets the preemptions of worker groups without spot fallback.
+func (r *RayClusterReconciler) reconcileSpotFallback(instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec) {
+	status := getWorkerGroupStatus(instance, worker.GroupName)
+	if status == nil {
+		return
+	}
+	if worker.SpotFallback == nil {
+		status.Preemptions = 0
+		status.LastPreemptionTime = nil
+		status.SpotFallbackTime = nil
+		return
+	}
+	_, cooldown := getSpotFallbackThresholdAndCooldown(worker.SpotFallback)
+	if status.SpotFallbackTime != nil && time.Since(status.SpotFallbackTime.Time) >= cooldown {
+		status.SpotFallbackTime = nil
+		status.Preemptions = 0
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.ReturnedToSpotNodes),
+			"Worker group %s returns to spot nodes after %s on on-demand nodes", worker.GroupName, cooldown)
+	}
+}
+
+// isPodPreempted returns whether a Pod was terminated because its node was shut down or reclaimed, e.g. a spot node.
+func isPodPreempted(pod corev1.Pod) bool {
+	if pod.Status.Reason == "NodeShutdown" || pod.Status.Reason == "Terminated" {
+		return true
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.DisruptionTarget && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -454,8 +454,7 @@ var _ = Context("Inside the default namespace", func() {
 
 		BeforeAll(func() {
 			if withConditionEnabled {
-				cleanUpFunc := features.SetFeatureGateDuringTest(GinkgoTB(), features.RayClusterStatusConditions, true)
-				DeferCleanup(cleanUpFunc)
+				features.SetFeatureGateDuringTest(GinkgoTB(), features.RayClusterStatusConditions, true)
 			}
 		})
 
@@ -625,8 +624,7 @@ var _ = Context("Inside the default namespace", func() {
 		numPods := 4 // 1 Head + 3 Workers
 
 		BeforeAll(func() {
-			cleanUpFunc := features.SetFeatureGateDuringTest(GinkgoTB(), features.RayClusterStatusConditions, true)
-			DeferCleanup(cleanUpFunc)
+			features.SetFeatureGateDuringTest(GinkgoTB(), features.RayClusterStatusConditions, true)
 		})
 
 		It("Create a RayCluster custom resource", func() {
@@ -969,8 +967,7 @@ var _ = Context("Inside the default namespace", func() {
 
 	Describe("RayCluster with RayClusterStatusConditions feature gate enabled", func() {
 		BeforeEach(func() {
-			cleanUpFunc := features.SetFeatureGateDuringTest(GinkgoTB(), features.RayClusterStatusConditions, true)
-			DeferCleanup(cleanUpFunc)
+			features.SetFeatureGateDuringTest(GinkgoTB(), features.RayClusterStatusConditions, true)
 		})
 
 		It("Should handle HeadPodReady and RayClusterProvisioned conditions correctly", func(ctx SpecContext) {
@@ -18,13 +18,20 @@ package ray
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	schedulerinterface "github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/interface"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/expectations"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 	"github.com/ray-project/kuberay/ray-operator/pkg/client/clientset/versioned/scheme"
 	"github.com/ray-project/kuberay/ray-operator/pkg/features"
@@ -32,23 +39,32 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	"github.com/stretchr/testify/assert"
 
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	resourcev1alpha3 "k8s.io/api/resource/v1alpha3"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	"k8s.io/apimachinery/pkg/util/managedfields/managedfieldstest"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -76,6 +92,57 @@ var (
 	workersToDelete         []string
 )
 
+// serverSideApplyFuncs emulates server-side apply for the fake client, which does not support it. A field manager
+// merges the applied object into the stored object and records the fields of each manager in the managed fields like
+// the API server does, so the fields that the applied object no longer sets are removed, the fields of other managers
+// are kept, and conflicts with other managers are detected unless ownership is forced.
+var serverSideApplyFuncs = interceptor.Funcs{
+	Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+		if patch.Type() != types.ApplyPatchType {
+			return c.Patch(ctx, obj, patch, opts...)
+		}
+		patchOptions := &client.PatchOptions{}
+		patchOptions.ApplyOptions(opts)
+		gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+		if err != nil {
+			return err
+		}
+		applied, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+
+		existing := obj.DeepCopyObject().(client.Object)
+		live := map[string]interface{}{}
+		err = c.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+		exists := err == nil
+		if exists {
+			if live, err = runtime.DefaultUnstructuredConverter.ToUnstructured(existing); err != nil {
+				return err
+			}
+		} else if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		liveObj := &unstructured.Unstructured{Object: live}
+		liveObj.SetGroupVersionKind(gvk)
+		appliedObj := &unstructured.Unstructured{Object: applied}
+		appliedObj.SetGroupVersionKind(gvk)
+
+		fieldManager := managedfieldstest.NewFakeFieldManager(managedfields.NewDeducedTypeConverter(), gvk)
+		merged, err := fieldManager.Apply(liveObj, appliedObj, patchOptions.FieldManager, ptr.Deref(patchOptions.Force, false))
+		if err != nil {
+			return err
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(merged.(runtime.Unstructured).UnstructuredContent(), obj); err != nil {
+			return err
+		}
+		if !exists {
+			return c.Create(ctx, obj)
+		}
+		return c.Update(ctx, obj)
+	},
+}
+
 func setupTest(t *testing.T) {
 	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
 	namespaceStr = "default"
@@ -495,12 +562,13 @@ func TestReconcile_RemoveWorkersToDelete_RandomDelete(t *testing.T) {
 			assert.Equal(t, expectedNumWorkersToDelete, len(testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete))
 
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: &record.FakeRecorder{},
-				Scheme:   scheme.Scheme,
+				Client:                     fakeClient,
+				rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+				Recorder:                   &record.FakeRecorder{},
+				Scheme:                     scheme.Scheme,
 			}
 
-			err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+			_, err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
 			assert.Nil(t, err, "Fail to reconcile Pods")
 			err = fakeClient.List(ctx, &podList, &client.ListOptions{
 				LabelSelector: workerSelector,
@@ -588,12 +656,13 @@ func TestReconcile_RemoveWorkersToDelete_NoRandomDelete(t *testing.T) {
 			assert.Equal(t, expectedNumWorkersToDelete, len(testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete)-tc.numNonExistPods)
 
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: &record.FakeRecorder{},
-				Scheme:   scheme.Scheme,
+				Client:                     fakeClient,
+				rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+				Recorder:                   &record.FakeRecorder{},
+				Scheme:                     scheme.Scheme,
 			}
 
-			err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+			_, err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
 			assert.Nil(t, err, "Fail to reconcile Pods")
 			err = fakeClient.List(ctx, &podList, &client.ListOptions{
 				LabelSelector: workerSelector,
@@ -636,12 +705,13 @@ func TestReconcile_RandomDelete_OK(t *testing.T) {
 	assert.Equal(t, len(testPods), len(podList.Items), "Init pod list len is wrong")
 
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 
-	err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	_, err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
 	assert.Nil(t, err, "Fail to reconcile Pods")
 
 	err = fakeClient.List(ctx, &podList, &client.ListOptions{
@@ -699,14 +769,15 @@ func TestReconcile_PodDeleted_Diff0_OK(t *testing.T) {
 
 	// Initialize a new RayClusterReconciler.
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 
 	// Since the desired state of the workerGroup is 3 replicas,
 	// the controller will not create or delete any worker Pods.
-	err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	_, err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
 	assert.Nil(t, err, "Fail to reconcile Pods")
 
 	err = fakeClient.List(ctx, &podList, &client.ListOptions{
@@ -756,14 +827,15 @@ func TestReconcile_PodDeleted_DiffLess0_OK(t *testing.T) {
 
 	// Initialize a new RayClusterReconciler.
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 
 	// Since the desired state of the workerGroup is 3 replicas, the controller
 	// will delete a worker Pod randomly to reach the goal state.
-	err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	_, err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
 	assert.Nil(t, err, "Fail to reconcile Pods")
 
 	err = fakeClient.List(ctx, &podList, &client.ListOptions{
@@ -811,14 +883,15 @@ func TestReconcile_Diff0_WorkersToDelete_OK(t *testing.T) {
 
 	// Initialize a new RayClusterReconciler.
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 
 	// Pod3 and Pod4 should be deleted because of the workersToDelete.
 	// Hence, no failed Pods should exist in `podList`.
-	err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	_, err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
 	assert.Nil(t, err, "Fail to reconcile Pods")
 
 	err = fakeClient.List(ctx, &podList, &client.ListOptions{
@@ -883,9 +956,10 @@ func TestReconcile_PodCrash_DiffLess0_OK(t *testing.T) {
 
 			// Initialize a new RayClusterReconciler.
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: &record.FakeRecorder{},
-				Scheme:   scheme.Scheme,
+				Client:                     fakeClient,
+				rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+				Recorder:                   &record.FakeRecorder{},
+				Scheme:                     scheme.Scheme,
 			}
 
 			if tc.enableRandomPodDelete {
@@ -899,7 +973,7 @@ func TestReconcile_PodCrash_DiffLess0_OK(t *testing.T) {
 			//  After the deletion, the number of worker Pods should be 3.
 			// Case 2: enableRandomPodDelete is false.
 			//  Only the Pod in the `workersToDelete` will be deleted. After the deletion, the number of worker Pods should be 4.
-			err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+			_, err = testRayClusterReconciler.reconcilePods(ctx, cluster)
 			assert.Nil(t, err, "Fail to reconcile Pods")
 
 			err = fakeClient.List(ctx, &podList, &client.ListOptions{
@@ -959,12 +1033,13 @@ func TestReconcile_PodEvicted_DiffLess0_OK(t *testing.T) {
 			assert.Nil(t, err, "Fail to update head Pod status")
 
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: &record.FakeRecorder{},
-				Scheme:   scheme.Scheme,
+				Client:                     fakeClient,
+				rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+				Recorder:                   &record.FakeRecorder{},
+				Scheme:                     scheme.Scheme,
 			}
 
-			err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+			_, err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
 			// The head Pod with the status `Failed` will be deleted, and the function will return an
 			// error to requeue the request with a short delay. If the function returns nil, the controller
 			// will requeue the request after RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV (default: 300) seconds.
@@ -1008,7 +1083,7 @@ func TestReconcileHeadService(t *testing.T) {
 
 	// Initialize a fake client with newScheme and runtimeObjects.
 	runtimeObjects := []runtime.Object{cluster}
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).WithInterceptorFuncs(serverSideApplyFuncs).Build()
 	ctx := context.TODO()
 	headServiceSelector := labels.SelectorFromSet(map[string]string{
 		utils.RayClusterLabelKey:  cluster.Name,
@@ -1017,9 +1092,10 @@ func TestReconcileHeadService(t *testing.T) {
 
 	// Initialize RayCluster reconciler.
 	r := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 
 	// Case 1: Head service does not exist.
@@ -1065,6 +1141,73 @@ func TestReconcileHeadService(t *testing.T) {
 	assert.NotNil(t, err, "Reconciler should report an error when there are two head services")
 }
 
+func TestReconcileHeadService_UpdateHeadService(t *testing.T) {
+	setupTest(t)
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	cluster := testRayCluster.DeepCopy()
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster).WithInterceptorFuncs(serverSideApplyFuncs).Build()
+	ctx := context.TODO()
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
+	}
+	getHeadService := func() *corev1.Service {
+		serviceList := corev1.ServiceList{}
+		err := fakeClient.List(ctx, &serviceList, client.InNamespace(cluster.Namespace), client.MatchingLabels{
+			utils.RayClusterLabelKey:  cluster.Name,
+			utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+		})
+		assert.Nil(t, err)
+		if len(serviceList.Items) == 0 {
+			return nil
+		}
+		return &serviceList.Items[0]
+	}
+
+	// The head service is headless by default.
+	assert.Nil(t, r.reconcileHeadService(ctx, cluster))
+	assert.Equal(t, corev1.ClusterIPNone, getHeadService().Spec.ClusterIP)
+
+	// The head service is exposed through a LoadBalancer. The headless head service is recreated.
+	cluster.Spec.HeadGroupSpec.HeadService = &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-type": "nlb"}},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+	}
+	assert.Nil(t, r.reconcileHeadService(ctx, cluster))
+	assert.Nil(t, getHeadService())
+	assert.Nil(t, r.reconcileHeadService(ctx, cluster))
+	headService := getHeadService()
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, headService.Spec.Type)
+	assert.Equal(t, "nlb", headService.Annotations["service.beta.kubernetes.io/aws-load-balancer-type"])
+
+	// Kubernetes allocates node ports, which are kept when an extra port and an external traffic policy are added.
+	headService.Spec.Ports[0].NodePort = 30000
+	assert.Nil(t, fakeClient.Update(ctx, headService))
+	cluster.Spec.HeadGroupSpec.HeadService.Spec.Ports = []corev1.ServicePort{{Name: "grpc", Port: 50051}}
+	cluster.Spec.HeadGroupSpec.HeadService.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyLocal
+	assert.Nil(t, r.reconcileHeadService(ctx, cluster))
+	updatedHeadService := getHeadService()
+	assert.Equal(t, corev1.ServiceExternalTrafficPolicyLocal, updatedHeadService.Spec.ExternalTrafficPolicy)
+	assert.Equal(t, "grpc", updatedHeadService.Spec.Ports[0].Name)
+	assert.Contains(t, updatedHeadService.Spec.Ports, corev1.ServicePort{
+		Name:        headService.Spec.Ports[0].Name,
+		Port:        headService.Spec.Ports[0].Port,
+		NodePort:    30000,
+		Protocol:    corev1.ProtocolTCP,
+		TargetPort:  intstr.FromInt32(headService.Spec.Ports[0].Port),
+		AppProtocol: headService.Spec.Ports[0].AppProtocol,
+	})
+
+	// The head service is not updated again if the RayCluster does not change.
+	assert.Nil(t, r.reconcileHeadService(ctx, cluster))
+	assert.Equal(t, updatedHeadService.ResourceVersion, getHeadService().ResourceVersion)
+}
+
 func TestReconcileHeadlessService(t *testing.T) {
 	setupTest(t)
 
@@ -1081,14 +1224,15 @@ func TestReconcileHeadlessService(t *testing.T) {
 
 	// Initialize a fake client with newScheme and runtimeObjects.
 	runtimeObjects := []runtime.Object{cluster}
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).WithInterceptorFuncs(serverSideApplyFuncs).Build()
 	ctx := context.TODO()
 
 	// Initialize RayCluster reconciler.
 	r := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 
 	headlessServiceSelector := labels.SelectorFromSet(map[string]string{
@@ -1149,7 +1293,7 @@ func getNotFailedPodItemNum(podList corev1.PodList) int {
 func TestReconcile_AutoscalerServiceAccount(t *testing.T) {
 	setupTest(t)
 
-	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).WithInterceptorFuncs(serverSideApplyFuncs).Build()
 	ctx := context.Background()
 	saNamespacedName := types.NamespacedName{
 		Name:      utils.GetHeadGroupServiceAccountName(testRayCluster),
@@ -1161,9 +1305,10 @@ func TestReconcile_AutoscalerServiceAccount(t *testing.T) {
 	assert.True(t, k8serrors.IsNotFound(err), "Head group service account should not exist yet")
 
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 
 	err = testRayClusterReconciler.reconcileAutoscalerServiceAccount(ctx, testRayCluster)
@@ -1194,9 +1339,10 @@ func TestReconcile_Autoscaler_ServiceAccountName(t *testing.T) {
 
 	// Initialize the reconciler
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 
 	// If users specify ServiceAccountName for the head Pod, they need to create a ServiceAccount themselves.
@@ -1214,9 +1360,10 @@ func TestReconcile_Autoscaler_ServiceAccountName(t *testing.T) {
 
 	// Initialize the reconciler
 	testRayClusterReconciler = &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 
 	err = testRayClusterReconciler.reconcileAutoscalerServiceAccount(ctx, cluster)
@@ -1226,7 +1373,7 @@ func TestReconcile_Autoscaler_ServiceAccountName(t *testing.T) {
 func TestReconcile_AutoscalerRoleBinding(t *testing.T) {
 	setupTest(t)
 
-	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).WithInterceptorFuncs(serverSideApplyFuncs).Build()
 	ctx := context.Background()
 
 	rbNamespacedName := types.NamespacedName{
@@ -1239,9 +1386,10 @@ func TestReconcile_AutoscalerRoleBinding(t *testing.T) {
 	assert.True(t, k8serrors.IsNotFound(err), "autoscaler RoleBinding should not exist yet")
 
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 
 	err = testRayClusterReconciler.reconcileAutoscalerRoleBinding(ctx, testRayCluster)
@@ -1275,9 +1423,10 @@ func TestReconcile_UpdateClusterReason(t *testing.T) {
 	assert.Empty(t, cluster.Status.Reason, "Cluster reason should be empty")
 
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 	reason := "test reason"
 
@@ -1298,9 +1447,10 @@ func TestUpdateEndpoints(t *testing.T) {
 	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testServices...).Build()
 	ctx := context.Background()
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 
 	if err := testRayClusterReconciler.updateEndpoints(ctx, testRayCluster); err != nil {
@@ -1437,9 +1587,10 @@ func TestGetHeadServiceIPAndName(t *testing.T) {
 			fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(tc.services...).Build()
 
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: &record.FakeRecorder{},
-				Scheme:   scheme.Scheme,
+				Client:                     fakeClient,
+				rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+				Recorder:                   &record.FakeRecorder{},
+				Scheme:                     scheme.Scheme,
 			}
 
 			ip, name, err := testRayClusterReconciler.getHeadServiceIPAndName(context.TODO(), testRayCluster)
@@ -1467,9 +1618,10 @@ func TestGetHeadServiceIPAndNameOnHeadlessService(t *testing.T) {
 	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(headService).WithRuntimeObjects(testPods...).Build()
 
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 
 	ip, name, err := testRayClusterReconciler.getHeadServiceIPAndName(context.TODO(), testRayCluster)
@@ -1520,9 +1672,10 @@ func TestUpdateStatusObservedGeneration(t *testing.T) {
 
 	// Initialize RayCluster reconciler.
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 
 	// Compare the values of `Generation` and `ObservedGeneration` to check if they match.
@@ -1556,9 +1709,10 @@ func TestReconcile_UpdateClusterState(t *testing.T) {
 	assert.Empty(t, cluster.Status.State, "Cluster state should be empty") //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
 
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   newScheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     newScheme,
 	}
 
 	state := rayv1.Ready
@@ -1578,9 +1732,10 @@ func TestInconsistentRayClusterStatus(t *testing.T) {
 	_ = rayv1.AddToScheme(newScheme)
 	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects().Build()
 	r := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 
 	// Mock data
@@ -1671,6 +1826,10 @@ func TestInconsistentRayClusterStatus(t *testing.T) {
 	newStatus = oldStatus.DeepCopy()
 	meta.SetStatusCondition(&newStatus.Conditions, metav1.Condition{Type: string(rayv1.RayClusterReplicaFailure), Status: metav1.ConditionTrue})
 	assert.True(t, r.inconsistentRayClusterStatus(ctx, oldStatus, *newStatus))
+	// Case 13: `Workload` is different => return true
+	newStatus = oldStatus.DeepCopy()
+	newStatus.Workload = &rayv1.RayClusterWorkloadStatus{ActiveJobs: 1}
+	assert.True(t, r.inconsistentRayClusterStatus(ctx, oldStatus, *newStatus))
 }
 
 func TestCalculateStatus(t *testing.T) {
@@ -1714,9 +1873,10 @@ func TestCalculateStatus(t *testing.T) {
 
 	// Initialize a RayCluster reconciler.
 	r := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 
 	// Test head information
@@ -1725,20 +1885,26 @@ func TestCalculateStatus(t *testing.T) {
 	assert.Equal(t, headNodeIP, newInstance.Status.Head.PodIP)
 	assert.Equal(t, headServiceIP, newInstance.Status.Head.ServiceIP)
 	assert.Equal(t, headService.Name, newInstance.Status.Head.ServiceName)
+	assert.Equal(t, fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", headService.Name, namespaceStr, utils.DefaultDashboardPort), newInstance.Status.Head.DashboardURL)
 	assert.NotNil(t, newInstance.Status.StateTransitionTimes, "Cluster state transition timestamp should be created")
 	assert.Equal(t, newInstance.Status.LastUpdateTime, newInstance.Status.StateTransitionTimes[rayv1.Ready])
 
 	// Test reconcilePodsErr with the feature gate disabled
 	newInstance, err = r.calculateStatus(ctx, testRayCluster, errors.Join(utils.ErrFailedCreateHeadPod, errors.New("invalid")))
 	assert.Nil(t, err)
-	assert.Empty(t, newInstance.Status.Conditions)
+	// Only the Ready condition is set without the feature gate.
+	assert.Len(t, newInstance.Status.Conditions, 1)
+	assert.True(t, meta.IsStatusConditionFalse(newInstance.Status.Conditions, string(rayv1.RayClusterReady)))
 
 	// enable feature gate for the following tests
-	defer features.SetFeatureGateDuringTest(t, features.RayClusterStatusConditions, true)()
+	features.SetFeatureGateDuringTest(t, features.RayClusterStatusConditions, true)
 
 	// Test CheckRayHeadRunningAndReady with head pod running and ready
 	newInstance, _ = r.calculateStatus(ctx, testRayCluster, nil)
 	assert.True(t, meta.IsStatusConditionPresentAndEqual(newInstance.Status.Conditions, string(rayv1.HeadPodReady), metav1.ConditionTrue))
+	for _, condition := range newInstance.Status.Conditions {
+		assert.Equal(t, testRayCluster.Generation, condition.ObservedGeneration)
+	}
 
 	// Test CheckRayHeadRunningAndReady with head pod not ready
 	headPod.Status.Conditions = []corev1.PodCondition{
@@ -1765,11 +1931,29 @@ func TestCalculateStatus(t *testing.T) {
 	newInstance, err = r.calculateStatus(ctx, testRayCluster, errors.Join(utils.ErrFailedCreateHeadPod, errors.New("invalid")))
 	assert.Nil(t, err)
 	assert.True(t, meta.IsStatusConditionPresentAndEqual(newInstance.Status.Conditions, string(rayv1.RayClusterReplicaFailure), metav1.ConditionTrue))
+
+	// Test the rejection of the batch scheduler with the feature gate enabled
+	newInstance, err = r.calculateStatus(ctx, testRayCluster, schedulerinterface.NewBatchSchedulingRejectedError("QueueNotFound", "the queue does not exist"))
+	assert.Nil(t, err)
+	condition := meta.FindStatusCondition(newInstance.Status.Conditions, string(rayv1.RayClusterBatchSchedulingRejected))
+	assert.NotNil(t, condition)
+	assert.Equal(t, "QueueNotFound", condition.Reason)
+	newInstance, err = r.calculateStatus(ctx, newInstance, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, meta.FindStatusCondition(newInstance.Status.Conditions, string(rayv1.RayClusterBatchSchedulingRejected)))
+
+	// Test the namespace quota with the feature gate enabled
+	newInstance, err = r.calculateStatus(ctx, testRayCluster, fmt.Errorf("%w (cpu: requested 4, limited to 2)", utils.ErrNamespaceQuotaExceeded))
+	assert.Nil(t, err)
+	assert.True(t, meta.IsStatusConditionPresentAndEqual(newInstance.Status.Conditions, string(rayv1.RayClusterQuotaExceeded), metav1.ConditionTrue))
+	newInstance, err = r.calculateStatus(ctx, newInstance, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, meta.FindStatusCondition(newInstance.Status.Conditions, string(rayv1.RayClusterQuotaExceeded)))
 }
 
 func TestRayClusterProvisionedCondition(t *testing.T) {
 	setupTest(t)
-	defer features.SetFeatureGateDuringTest(t, features.RayClusterStatusConditions, true)()
+	features.SetFeatureGateDuringTest(t, features.RayClusterStatusConditions, true)
 
 	newScheme := runtime.NewScheme()
 	_ = rayv1.AddToScheme(newScheme)
@@ -1822,9 +2006,10 @@ func TestRayClusterProvisionedCondition(t *testing.T) {
 	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
 	ctx := context.Background()
 	r := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 
 	// Initially, neither head Pod nor worker Pod are ready. The RayClusterProvisioned condition should not be present.
@@ -1901,9 +2086,10 @@ func TestStateTransitionTimes_NoStateChange(t *testing.T) {
 
 	// Initialize a RayCluster reconciler.
 	r := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 
 	preUpdateTime := metav1.Now()
@@ -1939,7 +2125,7 @@ func Test_TerminatedWorkers_NoAutoscaler(t *testing.T) {
 	oldNumWorkerPods := len(testPods) - numHeadPods
 
 	// Initialize a fake client with newScheme and runtimeObjects.
-	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).WithInterceptorFuncs(serverSideApplyFuncs).Build()
 	ctx := context.Background()
 
 	// Get the pod list from the fake client.
@@ -1957,14 +2143,15 @@ func Test_TerminatedWorkers_NoAutoscaler(t *testing.T) {
 
 	// Initialize a new RayClusterReconciler.
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
 	}
 
 	// Since the desired state of the workerGroup is 3 replicas, the controller
 	// will delete 2 worker Pods.
-	err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	_, err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
 	assert.Nil(t, err, "Fail to reconcile Pods")
 
 	err = fakeClient.List(ctx, &podList, &client.ListOptions{
@@ -1983,7 +2170,7 @@ func Test_TerminatedWorkers_NoAutoscaler(t *testing.T) {
 	// The function will return an error to requeue the request after a brief delay. Moreover, if there are unhealthy worker
 	// Pods to be deleted, the controller won't create new worker Pods during the same reconcile loop. As a result, the number of worker
 	// Pods will be (expectedNumWorkerPods - 1) after the reconcile loop.
-	err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	_, err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
 	assert.NotNil(t, err)
 	err = fakeClient.List(ctx, &podList, &client.ListOptions{
 		LabelSelector: workerSelector,
@@ -1995,7 +2182,7 @@ func Test_TerminatedWorkers_NoAutoscaler(t *testing.T) {
 	// Reconcile again, and the controller will create a new worker Pod to reach the goal state of the workerGroup.
 	// Note that the status of new worker Pod created by the fake client is empty, so we need to set all worker
 	// Pods to running state manually to avoid the new Pod being deleted in the next `reconcilePods` call.
-	err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	_, err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
 	assert.Nil(t, err)
 	err = fakeClient.List(ctx, &podList, &client.ListOptions{
 		LabelSelector: workerSelector,
@@ -2023,7 +2210,7 @@ func Test_TerminatedWorkers_NoAutoscaler(t *testing.T) {
 	// The function will return an error to requeue the request after a brief delay. Moreover, if there are unhealthy worker
 	// Pods to be deleted, the controller won't create new worker Pods during the same reconcile loop. As a result, the number of worker
 	// Pods will be (expectedNumWorkerPods - 1) after the reconcile loop.
-	err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	_, err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
 	assert.NotNil(t, err)
 	err = fakeClient.List(ctx, &podList, &client.ListOptions{
 		LabelSelector: workerSelector,
@@ -2033,7 +2220,7 @@ func Test_TerminatedWorkers_NoAutoscaler(t *testing.T) {
 	assert.Equal(t, expectedNumWorkerPods-1, len(podList.Items))
 
 	// Reconcile again, and the controller will create a new worker Pod to reach the goal state of the workerGroup.
-	err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	_, err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
 	assert.Nil(t, err)
 	err = fakeClient.List(ctx, &podList, &client.ListOptions{
 		LabelSelector: workerSelector,
@@ -2050,6 +2237,7 @@ func Test_TerminatedHead_RestartPolicy(t *testing.T) {
 	newScheme := runtime.NewScheme()
 	_ = rayv1.AddToScheme(newScheme)
 	_ = corev1.AddToScheme(newScheme)
+	_ = appsv1.AddToScheme(newScheme)
 
 	// Only one head Pod and no worker Pods in the RayCluster.
 	runtimeObjects := testPods[0:1]
@@ -2058,6 +2246,7 @@ func Test_TerminatedHead_RestartPolicy(t *testing.T) {
 	fakeClient := clientFake.NewClientBuilder().
 		WithScheme(newScheme).
 		WithRuntimeObjects(runtimeObjects...).
+		WithInterceptorFuncs(serverSideApplyFuncs).
 		Build()
 	ctx := context.Background()
 
@@ -2080,20 +2269,21 @@ func Test_TerminatedHead_RestartPolicy(t *testing.T) {
 
 	// Initialize a new RayClusterReconciler.
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   newScheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     newScheme,
 	}
 
 	// The head Pod will be deleted regardless restart policy.
-	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	_, err = testRayClusterReconciler.reconcilePods(ctx, cluster)
 	assert.NotNil(t, err)
 	err = fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
 	assert.Nil(t, err, "Fail to get pod list")
 	assert.Equal(t, 0, len(podList.Items))
 
 	// The new head Pod will be created in this reconcile loop.
-	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	_, err = testRayClusterReconciler.reconcilePods(ctx, cluster)
 	assert.Nil(t, err)
 	err = fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
 	assert.Nil(t, err, "Fail to get pod list")
@@ -2116,14 +2306,14 @@ func Test_TerminatedHead_RestartPolicy(t *testing.T) {
 
 	// The head Pod will be deleted and the controller will return an error
 	// instead of creating a new head Pod in the same reconcile loop.
-	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	_, err = testRayClusterReconciler.reconcilePods(ctx, cluster)
 	assert.NotNil(t, err)
 	err = fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
 	assert.Nil(t, err, "Fail to get pod list")
 	assert.Equal(t, 0, len(podList.Items))
 
 	// The new head Pod will be created in this reconcile loop.
-	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	_, err = testRayClusterReconciler.reconcilePods(ctx, cluster)
 	assert.Nil(t, err)
 	err = fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
 	assert.Nil(t, err, "Fail to get pod list")
@@ -2137,6 +2327,7 @@ func Test_RunningPods_RayContainerTerminated(t *testing.T) {
 	newScheme := runtime.NewScheme()
 	_ = rayv1.AddToScheme(newScheme)
 	_ = corev1.AddToScheme(newScheme)
+	_ = appsv1.AddToScheme(newScheme)
 
 	// Only one head Pod and no worker Pods in the RayCluster.
 	runtimeObjects := testPods[0:1]
@@ -2146,6 +2337,7 @@ func Test_RunningPods_RayContainerTerminated(t *testing.T) {
 		WithScheme(newScheme).
 		WithRuntimeObjects(runtimeObjects...).
 		WithStatusSubresource(cluster).
+		WithInterceptorFuncs(serverSideApplyFuncs).
 		Build()
 	ctx := context.Background()
 
@@ -2177,21 +2369,22 @@ func Test_RunningPods_RayContainerTerminated(t *testing.T) {
 
 	// Initialize a new RayClusterReconciler.
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   newScheme,
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     newScheme,
 	}
 
 	// The head Pod will be deleted and the controller will return an error
 	// instead of creating a new head Pod in the same reconcile loop.
-	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	_, err = testRayClusterReconciler.reconcilePods(ctx, cluster)
 	assert.NotNil(t, err)
 	err = fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
 	assert.Nil(t, err, "Fail to get pod list")
 	assert.Equal(t, 0, len(podList.Items))
 
 	// The new head Pod will be created in this reconcile loop.
-	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	_, err = testRayClusterReconciler.reconcilePods(ctx, cluster)
 	assert.Nil(t, err)
 	err = fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
 	assert.Nil(t, err, "Fail to get pod list")
@@ -2331,6 +2524,8 @@ func Test_RedisCleanupFeatureFlag(t *testing.T) {
 	newScheme := runtime.NewScheme()
 	_ = rayv1.AddToScheme(newScheme)
 	_ = corev1.AddToScheme(newScheme)
+	_ = appsv1.AddToScheme(newScheme)
+	_ = policyv1.AddToScheme(newScheme)
 
 	// Prepare a RayCluster with the GCS FT enabled and Autoscaling disabled.
 	gcsFTEnabledCluster := testRayCluster.DeepCopy()
@@ -2375,13 +2570,15 @@ func Test_RedisCleanupFeatureFlag(t *testing.T) {
 				WithScheme(newScheme).
 				WithObjects(cluster).
 				WithStatusSubresource(cluster).
+				WithInterceptorFuncs(serverSideApplyFuncs).
 				Build()
 
 			// Initialize the reconciler
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: &record.FakeRecorder{},
-				Scheme:   newScheme,
+				Client:                     fakeClient,
+				rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+				Recorder:                   &record.FakeRecorder{},
+				Scheme:                     newScheme,
 			}
 
 			rayClusterList := rayv1.RayClusterList{}
@@ -2460,6 +2657,7 @@ func TestEvents_RedisCleanup(t *testing.T) {
 				return clientFake.NewClientBuilder().
 					WithScheme(newScheme).
 					WithRuntimeObjects([]runtime.Object{obj}...).
+					WithStatusSubresource(obj).
 					Build()
 			},
 			errInjected: nil,
@@ -2469,6 +2667,7 @@ func TestEvents_RedisCleanup(t *testing.T) {
 				return clientFake.NewClientBuilder().
 					WithScheme(newScheme).
 					WithRuntimeObjects([]runtime.Object{obj}...).
+					WithStatusSubresource(obj).
 					WithInterceptorFuncs(interceptor.Funcs{
 						Create: func(_ context.Context, _ client.WithWatch, _ client.Object, _ ...client.CreateOption) error {
 							return errInjected
@@ -2493,9 +2692,10 @@ func TestEvents_RedisCleanup(t *testing.T) {
 			recorder := record.NewFakeRecorder(100)
 
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: recorder,
-				Scheme:   newScheme,
+				Client:                     fakeClient,
+				rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+				Recorder:                   recorder,
+				Scheme:                     newScheme,
 			}
 
 			request := ctrl.Request{NamespacedName: types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}}
@@ -2631,9 +2831,10 @@ func Test_RedisCleanup(t *testing.T) {
 			}
 
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: &record.FakeRecorder{},
-				Scheme:   newScheme,
+				Client:                     fakeClient,
+				rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+				Recorder:                   &record.FakeRecorder{},
+				Scheme:                     newScheme,
 			}
 
 			// Check Job
@@ -2680,6 +2881,170 @@ func Test_RedisCleanup(t *testing.T) {
 	}
 }
 
+func TestReconcileCleanup_ForceDelete(t *testing.T) {
+	setupTest(t)
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	_ = batchv1.AddToScheme(newScheme)
+
+	// Prepare a RayCluster that is being deleted, with the finalizers of the Redis and load balancer cleanup steps
+	// and the ray.io/force-delete annotation.
+	cluster := testRayCluster.DeepCopy()
+	cluster.Annotations = map[string]string{
+		utils.RayFTEnabledAnnotationKey:   "true",
+		utils.RayForceDeleteAnnotationKey: "true",
+	}
+	controllerutil.AddFinalizer(cluster, utils.GCSFaultToleranceRedisCleanupFinalizer)
+	controllerutil.AddFinalizer(cluster, utils.LoadBalancerCleanupFinalizer)
+	now := metav1.Now()
+	cluster.DeletionTimestamp = &now
+
+	ctx := context.Background()
+	fakeClient := clientFake.NewClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(cluster).
+		WithStatusSubresource(cluster).
+		Build()
+	recorder := record.NewFakeRecorder(100)
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   recorder,
+		Scheme:                     newScheme,
+	}
+
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}}
+	_, err := testRayClusterReconciler.rayClusterReconcile(ctx, request, cluster)
+	assert.Nil(t, err)
+
+	// The steps are skipped, so no Redis cleanup Job is created, and the RayCluster is deleted.
+	assert.Len(t, cluster.Status.CleanupSteps, 2)
+	for _, step := range cluster.Status.CleanupSteps {
+		assert.Equal(t, rayv1.CleanupStepSkipped, step.State)
+	}
+	jobList := batchv1.JobList{}
+	err = fakeClient.List(ctx, &jobList, client.InNamespace(namespaceStr))
+	assert.Nil(t, err, "Fail to get Job list")
+	assert.Empty(t, jobList.Items)
+	rayClusterList := rayv1.RayClusterList{}
+	err = fakeClient.List(ctx, &rayClusterList, client.InNamespace(namespaceStr))
+	assert.Nil(t, err, "Fail to get RayCluster list")
+	assert.Empty(t, rayClusterList.Items)
+	assert.Contains(t, <-recorder.Events, string(utils.SkippedCleanupSteps))
+}
+
+func TestReconcileCleanup_LoadBalancersAndVolumeClaims(t *testing.T) {
+	setupTest(t)
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	_ = appsv1.AddToScheme(newScheme)
+
+	// Prepare a RayCluster with a head Service of type LoadBalancer and a StatefulSet worker group whose
+	// PersistentVolumeClaims are deleted with the RayCluster.
+	cluster := testRayCluster.DeepCopy()
+	cluster.UID = "raycluster-uid"
+	cluster.Spec.HeadGroupSpec.ServiceType = corev1.ServiceTypeLoadBalancer
+	groupName := cluster.Spec.WorkerGroupSpecs[0].GroupName
+	cluster.Spec.WorkerGroupSpecs[0].WorkloadType = rayv1.StatefulSetWorkerGroupWorkloadType
+	cluster.Spec.WorkerGroupSpecs[0].VolumeClaimTemplates = []corev1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "cache"}}}
+	cluster.Spec.WorkerGroupSpecs[0].VolumeClaimRetentionPolicy = rayv1.DeleteVolumeClaimRetentionPolicy
+	assert.True(t, isLoadBalancerCleanupEnabled(cluster))
+	assert.True(t, isVolumeClaimCleanupEnabled(cluster))
+	controllerutil.AddFinalizer(cluster, utils.LoadBalancerCleanupFinalizer)
+	controllerutil.AddFinalizer(cluster, utils.VolumeClaimCleanupFinalizer)
+	now := metav1.Now()
+	cluster.DeletionTimestamp = &now
+
+	ownerReferences := []metav1.OwnerReference{{
+		APIVersion: rayv1.GroupVersion.String(),
+		Kind:       "RayCluster",
+		Name:       cluster.Name,
+		UID:        cluster.UID,
+		Controller: ptr.To(true),
+	}}
+	loadBalancerService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "head-svc", Namespace: namespaceStr, OwnerReferences: ownerReferences},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+	}
+	headlessService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "headless-svc", Namespace: namespaceStr, OwnerReferences: ownerReferences},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIP: corev1.ClusterIPNone},
+	}
+	statefulSetClaim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cache-worker-0",
+			Namespace: namespaceStr,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:   cluster.Name,
+				utils.RayNodeGroupLabelKey: groupName,
+				utils.RayNodeTypeLabelKey:  string(rayv1.WorkerNode),
+			},
+		},
+	}
+	// The claims of RayVolumes are garbage collected with the RayCluster, so the cleanup step leaves them alone.
+	rayVolumeClaim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "worker-pod-log",
+			Namespace: namespaceStr,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:   cluster.Name,
+				utils.RayNodeGroupLabelKey: groupName,
+			},
+		},
+	}
+
+	ctx := context.Background()
+	fakeClient := clientFake.NewClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(cluster, loadBalancerService, headlessService, statefulSetClaim, rayVolumeClaim).
+		WithStatusSubresource(cluster).
+		Build()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   record.NewFakeRecorder(100),
+		Scheme:                     newScheme,
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}}
+
+	// The first reconciliation deletes the Service of type LoadBalancer and waits for it to be gone.
+	_, err := testRayClusterReconciler.rayClusterReconcile(ctx, request, cluster)
+	assert.Nil(t, err)
+	assert.Len(t, cluster.Status.CleanupSteps, 1)
+	assert.Equal(t, loadBalancerCleanupStepName, cluster.Status.CleanupSteps[0].Name)
+	assert.Equal(t, rayv1.CleanupStepRunning, cluster.Status.CleanupSteps[0].State)
+	assert.Equal(t, []string{utils.LoadBalancerCleanupFinalizer, utils.VolumeClaimCleanupFinalizer}, cluster.Finalizers)
+	serviceList := corev1.ServiceList{}
+	err = fakeClient.List(ctx, &serviceList, client.InNamespace(namespaceStr))
+	assert.Nil(t, err, "Fail to get Service list")
+	assert.Len(t, serviceList.Items, 1)
+	assert.Equal(t, headlessService.Name, serviceList.Items[0].Name)
+
+	// The second reconciliation completes the load balancer cleanup and deletes the claims of the StatefulSet.
+	_, err = testRayClusterReconciler.rayClusterReconcile(ctx, request, cluster)
+	assert.Nil(t, err)
+	assert.Len(t, cluster.Status.CleanupSteps, 2)
+	assert.Equal(t, rayv1.CleanupStepCompleted, cluster.Status.CleanupSteps[0].State)
+	assert.Equal(t, volumeClaimCleanupStepName, cluster.Status.CleanupSteps[1].Name)
+	assert.Equal(t, rayv1.CleanupStepRunning, cluster.Status.CleanupSteps[1].State)
+	assert.Equal(t, []string{utils.VolumeClaimCleanupFinalizer}, cluster.Finalizers)
+	claimList := corev1.PersistentVolumeClaimList{}
+	err = fakeClient.List(ctx, &claimList, client.InNamespace(namespaceStr))
+	assert.Nil(t, err, "Fail to get PersistentVolumeClaim list")
+	assert.Len(t, claimList.Items, 1)
+	assert.Equal(t, rayVolumeClaim.Name, claimList.Items[0].Name)
+
+	// The third reconciliation completes the volume claim cleanup, and the RayCluster is deleted.
+	_, err = testRayClusterReconciler.rayClusterReconcile(ctx, request, cluster)
+	assert.Nil(t, err)
+	rayClusterList := rayv1.RayClusterList{}
+	err = fakeClient.List(ctx, &rayClusterList, client.InNamespace(namespaceStr))
+	assert.Nil(t, err, "Fail to get RayCluster list")
+	assert.Empty(t, rayClusterList.Items)
+}
+
 func TestReconcile_Replicas_Optional(t *testing.T) {
 	setupTest(t)
 
@@ -2746,14 +3111,15 @@ func TestReconcile_Replicas_Optional(t *testing.T) {
 
 			// Initialize a new RayClusterReconciler.
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: &record.FakeRecorder{},
-				Scheme:   scheme.Scheme,
+				Client:                     fakeClient,
+				rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+				Recorder:                   &record.FakeRecorder{},
+				Scheme:                     scheme.Scheme,
 			}
 
 			// Since the desired state of the workerGroup is 1 replica,
 			// the controller will delete 4 worker Pods.
-			err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+			_, err = testRayClusterReconciler.reconcilePods(ctx, cluster)
 			assert.Nil(t, err, "Fail to reconcile Pods")
 
 			err = fakeClient.List(ctx, &podList, &client.ListOptions{
@@ -2827,7 +3193,7 @@ func TestReconcile_Multihost_Replicas(t *testing.T) {
 			oldNumWorkerPods := len(testPods) - numHeadPods
 
 			// Initialize a fake client with newScheme and runtimeObjects.
-			fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+			fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).WithInterceptorFuncs(serverSideApplyFuncs).Build()
 			ctx := context.Background()
 
 			// Get the pod list from the fake client.
@@ -2838,14 +3204,15 @@ func TestReconcile_Multihost_Replicas(t *testing.T) {
 
 			// Initialize a new RayClusterReconciler.
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: &record.FakeRecorder{},
-				Scheme:   scheme.Scheme,
+				Client:                     fakeClient,
+				rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+				Recorder:                   &record.FakeRecorder{},
+				Scheme:                     scheme.Scheme,
 			}
 
 			// Since the desired state of the workerGroup is 1 replica,
 			// the controller will delete 4 worker Pods.
-			err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+			_, err = testRayClusterReconciler.reconcilePods(ctx, cluster)
 			assert.Nil(t, err, "Fail to reconcile Pods")
 
 			err = fakeClient.List(ctx, &podList, &client.ListOptions{
@@ -2895,7 +3262,7 @@ func TestReconcile_NumOfHosts(t *testing.T) {
 
 			// Initialize a fake client with newScheme and runtimeObjects.
 			// The fake client will start with 1 head pod and 0 worker pods.
-			fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods[0]).Build()
+			fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods[0]).WithInterceptorFuncs(serverSideApplyFuncs).Build()
 			ctx := context.Background()
 
 			// Get the pod list from the fake client.
@@ -2906,12 +3273,13 @@ func TestReconcile_NumOfHosts(t *testing.T) {
 
 			// Initialize a new RayClusterReconciler.
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: &record.FakeRecorder{},
-				Scheme:   scheme.Scheme,
+				Client:                     fakeClient,
+				rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+				Recorder:                   &record.FakeRecorder{},
+				Scheme:                     scheme.Scheme,
 			}
 
-			err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+			_, err = testRayClusterReconciler.reconcilePods(ctx, cluster)
 			assert.Nil(t, err, "Fail to reconcile Pods")
 
 			err = fakeClient.List(ctx, &podList, &client.ListOptions{
@@ -2930,105 +3298,923 @@ func TestReconcile_NumOfHosts(t *testing.T) {
 	}
 }
 
-func TestSumGPUs(t *testing.T) {
-	nvidiaGPUResourceName := corev1.ResourceName("nvidia.com/gpu")
-	googleTPUResourceName := corev1.ResourceName("google.com/tpu")
+func TestReconcile_WorkerGroupUpdateStrategy(t *testing.T) {
+	setupTest(t)
 
-	tests := map[string]struct {
-		input    map[corev1.ResourceName]resource.Quantity
-		expected resource.Quantity
-	}{
-		"no GPUs specified": {
-			map[corev1.ResourceName]resource.Quantity{
-				corev1.ResourceCPU: resource.MustParse("1"),
-			},
-			resource.MustParse("0"),
-		},
-		"one GPU type specified": {
-			map[corev1.ResourceName]resource.Quantity{
-				corev1.ResourceCPU:    resource.MustParse("1"),
-				nvidiaGPUResourceName: resource.MustParse("1"),
-				googleTPUResourceName: resource.MustParse("1"),
-			},
-			resource.MustParse("1"),
-		},
-		"multiple GPUs specified": {
-			map[corev1.ResourceName]resource.Quantity{
-				corev1.ResourceCPU:                 resource.MustParse("1"),
-				nvidiaGPUResourceName:              resource.MustParse("3"),
-				corev1.ResourceName("foo.bar/gpu"): resource.MustParse("2"),
-				googleTPUResourceName:              resource.MustParse("1"),
-			},
-			resource.MustParse("5"),
-		},
-	}
+	// This test makes some assumptions about the testRayCluster object.
+	// (1) 1 workerGroup (2) The goal state of the workerGroup is 3 replicas.
+	assert.Equal(t, 1, len(testRayCluster.Spec.WorkerGroupSpecs), "This test assumes only one worker group.")
+	assert.Equal(t, int32(3), *testRayCluster.Spec.WorkerGroupSpecs[0].Replicas, "This test assumes the expected number of worker pods is 3.")
+	testRayCluster.Spec.EnableInTreeAutoscaling = ptr.To(false)
+	testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
 
-	for name, tc := range tests {
-		t.Run(name, func(t *testing.T) {
-			result := sumGPUs(tc.input)
-			assert.True(t, tc.expected.Equal(result), "GPU number is wrong")
-		})
+	ctx := context.Background()
+	listWorkerPods := func(t *testing.T, fakeClient client.Client) []corev1.Pod {
+		podList := corev1.PodList{}
+		err := fakeClient.List(ctx, &podList, &client.ListOptions{LabelSelector: workerSelector, Namespace: namespaceStr})
+		assert.Nil(t, err, "Fail to get pod list")
+		return podList.Items
+	}
+	// markWorkerPodsReady simulates the kubelet starting the worker Pods and their readiness probes succeeding.
+	markWorkerPodsReady := func(t *testing.T, fakeClient client.Client) {
+		for _, pod := range listWorkerPods(t, fakeClient) {
+			pod.Status.Phase = corev1.PodRunning
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+			assert.Nil(t, fakeClient.Status().Update(ctx, &pod), "Fail to update pod status")
+		}
+	}
+	countOutdatedWorkerPods := func(t *testing.T, fakeClient client.Client, worker rayv1.WorkerGroupSpec) int {
+		podTemplateHash, err := common.GenerateWorkerGroupPodTemplateHash(worker)
+		assert.Nil(t, err)
+		numOutdatedPods := 0
+		for _, pod := range listWorkerPods(t, fakeClient) {
+			if pod.Annotations[utils.RayWorkerGroupPodTemplateHashAnnotationKey] != podTemplateHash {
+				numOutdatedPods++
+			}
+		}
+		return numOutdatedPods
 	}
+
+	t.Run("OnDelete", func(t *testing.T) {
+		cluster := testRayCluster.DeepCopy()
+		fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods[0]).WithInterceptorFuncs(serverSideApplyFuncs).Build()
+		r := &RayClusterReconciler{Client: fakeClient, Recorder: &record.FakeRecorder{}, Scheme: scheme.Scheme, rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient)}
+
+		_, err := r.reconcilePods(ctx, cluster)
+		assert.Nil(t, err)
+		markWorkerPodsReady(t, fakeClient)
+
+		// Without an update strategy, changing the Pod template does not replace the existing Pods.
+		cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[0].Image = "rayproject/ray:nightly"
+		_, err = r.reconcilePods(ctx, cluster)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(listWorkerPods(t, fakeClient)))
+		assert.Equal(t, 3, countOutdatedWorkerPods(t, fakeClient, cluster.Spec.WorkerGroupSpecs[0]))
+	})
+
+	t.Run("RollingUpdate with the default maxUnavailable", func(t *testing.T) {
+		cluster := testRayCluster.DeepCopy()
+		cluster.Spec.WorkerGroupSpecs[0].UpdateStrategy = &rayv1.WorkerGroupUpdateStrategy{Type: rayv1.RollingUpdateWorkerGroupUpdateStrategyType}
+		fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods[0]).WithInterceptorFuncs(serverSideApplyFuncs).Build()
+		r := &RayClusterReconciler{Client: fakeClient, Recorder: &record.FakeRecorder{}, Scheme: scheme.Scheme, rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient)}
+
+		_, err := r.reconcilePods(ctx, cluster)
+		assert.Nil(t, err)
+		markWorkerPodsReady(t, fakeClient)
+		_, err = r.reconcilePods(ctx, cluster)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(listWorkerPods(t, fakeClient)))
+		assert.Equal(t, 0, countOutdatedWorkerPods(t, fakeClient, cluster.Spec.WorkerGroupSpecs[0]))
+
+		cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[0].Image = "rayproject/ray:nightly"
+		for numOutdatedPods := 3; numOutdatedPods > 0; numOutdatedPods-- {
+			// At most 1 Pod is unavailable, so only 1 out-of-date Pod is deleted.
+			_, err = r.reconcilePods(ctx, cluster)
+			assert.Nil(t, err)
+			assert.Equal(t, 2, len(listWorkerPods(t, fakeClient)))
+			assert.Equal(t, numOutdatedPods-1, countOutdatedWorkerPods(t, fakeClient, cluster.Spec.WorkerGroupSpecs[0]))
+
+			// The replacement Pod is created, but no other Pod is deleted until it is ready.
+			_, err = r.reconcilePods(ctx, cluster)
+			assert.Nil(t, err)
+			_, err = r.reconcilePods(ctx, cluster)
+			assert.Nil(t, err)
+			assert.Equal(t, 3, len(listWorkerPods(t, fakeClient)))
+			assert.Equal(t, numOutdatedPods-1, countOutdatedWorkerPods(t, fakeClient, cluster.Spec.WorkerGroupSpecs[0]))
+			markWorkerPodsReady(t, fakeClient)
+		}
+	})
+
+	t.Run("RollingUpdate with maxSurge", func(t *testing.T) {
+		cluster := testRayCluster.DeepCopy()
+		cluster.Spec.WorkerGroupSpecs[0].UpdateStrategy = &rayv1.WorkerGroupUpdateStrategy{
+			Type:          rayv1.RollingUpdateWorkerGroupUpdateStrategyType,
+			RollingUpdate: &rayv1.RollingUpdateWorkerGroup{MaxSurge: ptr.To(intstr.FromInt32(2))},
+		}
+		fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods[0]).WithInterceptorFuncs(serverSideApplyFuncs).Build()
+		r := &RayClusterReconciler{Client: fakeClient, Recorder: &record.FakeRecorder{}, Scheme: scheme.Scheme, rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient)}
+
+		_, err := r.reconcilePods(ctx, cluster)
+		assert.Nil(t, err)
+		markWorkerPodsReady(t, fakeClient)
+
+		// No Pod can be unavailable, so 2 new Pods are created before any out-of-date Pod is deleted.
+		cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[0].Image = "rayproject/ray:nightly"
+		_, err = r.reconcilePods(ctx, cluster)
+		assert.Nil(t, err)
+		assert.Equal(t, 5, len(listWorkerPods(t, fakeClient)))
+		assert.Equal(t, 3, countOutdatedWorkerPods(t, fakeClient, cluster.Spec.WorkerGroupSpecs[0]))
+
+		markWorkerPodsReady(t, fakeClient)
+		_, err = r.reconcilePods(ctx, cluster)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(listWorkerPods(t, fakeClient)))
+		assert.Equal(t, 1, countOutdatedWorkerPods(t, fakeClient, cluster.Spec.WorkerGroupSpecs[0]))
+
+		_, err = r.reconcilePods(ctx, cluster)
+		assert.Nil(t, err)
+		markWorkerPodsReady(t, fakeClient)
+		_, err = r.reconcilePods(ctx, cluster)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(listWorkerPods(t, fakeClient)))
+		assert.Equal(t, 0, countOutdatedWorkerPods(t, fakeClient, cluster.Spec.WorkerGroupSpecs[0]))
+	})
 }
 
-func TestDeleteAllPods(t *testing.T) {
-	newScheme := runtime.NewScheme()
-	_ = corev1.AddToScheme(newScheme)
-	ns := "tmp-ns"
-	ts := metav1.Now()
-	filter := map[string]string{"app": "tmp"}
+func TestReconcile_DrainWorkerPods(t *testing.T) {
+	setupTest(t)
 
-	p1 := &corev1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "alive",
-			Namespace: ns,
-			Labels:    filter,
+	// This test makes some assumptions about the testRayCluster object.
+	// (1) 1 workerGroup (2) The goal state of the workerGroup is 3 replicas.
+	assert.Equal(t, 1, len(testRayCluster.Spec.WorkerGroupSpecs), "This test assumes only one worker group.")
+	assert.Equal(t, int32(3), *testRayCluster.Spec.WorkerGroupSpecs[0].Replicas, "This test assumes the expected number of worker pods is 3.")
+	testRayCluster.Spec.EnableInTreeAutoscaling = ptr.To(false)
+	testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
+	testRayCluster.Spec.WorkerGroupSpecs[0].DrainGracePeriodSeconds = ptr.To[int32](600)
+
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods[0], testServices[0]).WithInterceptorFuncs(serverSideApplyFuncs).Build()
+	fakeRayDashboardClient := &utils.FakeRayDashboardClient{}
+	// drainedNodeIDs are the IDs of the Ray nodes that the GCS server is asked to drain.
+	var drainedNodeIDs []string
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   record.NewFakeRecorder(100),
+		Scheme:                     scheme.Scheme,
+		dashboardClientFunc:        func() utils.RayDashboardClientInterface { return fakeRayDashboardClient },
+		drainRayNode: func(_ context.Context, _ string, nodeID string, reason utils.DrainNodeReason, _ string, deadline time.Time) error {
+			assert.Equal(t, utils.DrainNodeReasonPreemption, reason)
+			assert.WithinDuration(t, time.Now().Add(600*time.Second), deadline, time.Minute)
+			drainedNodeIDs = append(drainedNodeIDs, nodeID)
+			return nil
 		},
 	}
-	p2 := &corev1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:              "deleted",
-			Namespace:         ns,
-			Labels:            filter,
-			DeletionTimestamp: &ts,
-			Finalizers:        []string{"tmp"},
-		},
+
+	listWorkerPods := func() []corev1.Pod {
+		podList := corev1.PodList{}
+		err := fakeClient.List(ctx, &podList, &client.ListOptions{LabelSelector: workerSelector, Namespace: namespaceStr})
+		assert.Nil(t, err, "Fail to get pod list")
+		return podList.Items
 	}
-	p3 := &corev1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "other",
-			Namespace: ns,
-			Labels:    map[string]string{"app": "other"},
-		},
+	listDrainingWorkerPods := func() []string {
+		var names []string
+		for _, pod := range listWorkerPods() {
+			if _, ok := pod.Annotations[utils.RayNodeDrainStartTimeAnnotationKey]; ok {
+				names = append(names, pod.Name)
+			}
+		}
+		return names
+	}
+	setNodeWorkload := func(numRunningTasks int) {
+		getNodeWorkload := func(_ context.Context, nodeIP string) (*utils.RayNodeWorkload, error) {
+			return &utils.RayNodeWorkload{NodeID: nodeIP, NumRunningTasks: numRunningTasks}, nil
+		}
+		fakeRayDashboardClient.GetNodeWorkloadMock.Store(&getNodeWorkload)
 	}
 
-	fakeClient := clientFake.NewClientBuilder().
-		WithScheme(newScheme).
-		WithRuntimeObjects(p1, p2, p3).
-		Build()
+	// Create 3 worker Pods and simulate their Ray nodes joining the cluster.
+	_, err := r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	for i, pod := range listWorkerPods() {
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.PodIP = fmt.Sprintf("10.0.0.%d", i+1)
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		assert.Nil(t, fakeClient.Status().Update(ctx, &pod), "Fail to update pod status")
+	}
 
-	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   newScheme,
+	// Scale down while the Ray nodes run tasks. The Pod is marked as draining but not deleted.
+	setNodeWorkload(1)
+	cluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](2)
+	requeueAfter, err := r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, drainWorkerPodsRequeueDuration, requeueAfter)
+	assert.Equal(t, 3, len(listWorkerPods()))
+	drainingPods := listDrainingWorkerPods()
+	assert.Equal(t, 1, len(drainingPods))
+	assert.Equal(t, 1, len(drainedNodeIDs))
+
+	// The same Pod keeps draining.
+	requeueAfter, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, drainWorkerPodsRequeueDuration, requeueAfter)
+	assert.Equal(t, drainingPods, listDrainingWorkerPods())
+	assert.Equal(t, 1, len(drainedNodeIDs), "The drain of the Ray node is only requested once")
+
+	// Once the Ray node is idle, the Pod is deleted.
+	setNodeWorkload(0)
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(listWorkerPods()))
+	assert.Empty(t, listDrainingWorkerPods())
+
+	// A busy Ray node is deleted once the drain grace period has elapsed.
+	setNodeWorkload(1)
+	cluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](1)
+	requeueAfter, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, drainWorkerPodsRequeueDuration, requeueAfter)
+	for _, pod := range listWorkerPods() {
+		if _, ok := pod.Annotations[utils.RayNodeDrainStartTimeAnnotationKey]; ok {
+			pod.Annotations[utils.RayNodeDrainStartTimeAnnotationKey] = time.Now().Add(-time.Hour).Format(time.RFC3339)
+			assert.Nil(t, fakeClient.Update(ctx, &pod), "Fail to update pod")
+		}
 	}
-	ctx := context.Background()
-	// The first `deleteAllPods` function call should delete the "alive" Pod.
-	pods, err := testRayClusterReconciler.deleteAllPods(ctx, common.AssociationOptions{client.InNamespace(ns), client.MatchingLabels(filter)})
+	_, err = r.reconcilePods(ctx, cluster)
 	assert.Nil(t, err)
-	assert.Equal(t, 2, len(pods.Items))
-	assert.Subset(t, []string{"alive", "deleted"}, []string{pods.Items[0].Name, pods.Items[1].Name})
-	// The second `deleteAllPods` function call should delete no Pods because none are active.
-	pods, err = testRayClusterReconciler.deleteAllPods(ctx, common.AssociationOptions{client.InNamespace(ns), client.MatchingLabels(filter)})
+	assert.Equal(t, 1, len(listWorkerPods()))
+}
+
+func TestReconcile_ScaleDownIdleWorkerPodsFirst(t *testing.T) {
+	setupTest(t)
+
+	// This test makes some assumptions about the testRayCluster object.
+	// (1) 1 workerGroup (2) The goal state of the workerGroup is 3 replicas.
+	assert.Equal(t, 1, len(testRayCluster.Spec.WorkerGroupSpecs), "This test assumes only one worker group.")
+	assert.Equal(t, int32(3), *testRayCluster.Spec.WorkerGroupSpecs[0].Replicas, "This test assumes the expected number of worker pods is 3.")
+	testRayCluster.Spec.EnableInTreeAutoscaling = ptr.To(false)
+	testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
+
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods[0], testServices[0]).WithInterceptorFuncs(serverSideApplyFuncs).Build()
+	fakeRayDashboardClient := &utils.FakeRayDashboardClient{}
+	// drainedNodeIDs are the IDs of the Ray nodes that the GCS server is asked to drain.
+	var drainedNodeIDs []string
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   record.NewFakeRecorder(100),
+		Scheme:                     scheme.Scheme,
+		dashboardClientFunc:        func() utils.RayDashboardClientInterface { return fakeRayDashboardClient },
+		drainRayNode: func(_ context.Context, _ string, nodeID string, reason utils.DrainNodeReason, _ string, deadline time.Time) error {
+			assert.Equal(t, utils.DrainNodeReasonPreemption, reason)
+			assert.WithinDuration(t, time.Now().Add(600*time.Second), deadline, time.Minute)
+			drainedNodeIDs = append(drainedNodeIDs, nodeID)
+			return nil
+		},
+	}
+
+	listWorkerPods := func() []corev1.Pod {
+		podList := corev1.PodList{}
+		err := fakeClient.List(ctx, &podList, &client.ListOptions{LabelSelector: workerSelector, Namespace: namespaceStr})
+		assert.Nil(t, err, "Fail to get pod list")
+		return podList.Items
+	}
+
+	// Create 3 worker Pods and simulate their Ray nodes joining the cluster. Only the Ray node of the first Pod runs tasks.
+	_, err := r.reconcilePods(ctx, cluster)
 	assert.Nil(t, err)
-	assert.Equal(t, 1, len(pods.Items))
-	assert.Equal(t, "deleted", pods.Items[0].Name)
-	// Make sure that the above `deleteAllPods` calls didn't remove other Pods.
-	pods = corev1.PodList{}
-	err = fakeClient.List(ctx, &pods, client.InNamespace(ns))
+	busyPodIP := "10.0.0.1"
+	var busyPodName string
+	for i, pod := range listWorkerPods() {
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.PodIP = fmt.Sprintf("10.0.0.%d", i+1)
+		if pod.Status.PodIP == busyPodIP {
+			busyPodName = pod.Name
+		}
+		assert.Nil(t, fakeClient.Status().Update(ctx, &pod), "Fail to update pod status")
+	}
+	getNodeWorkload := func(_ context.Context, nodeIP string) (*utils.RayNodeWorkload, error) {
+		if nodeIP == busyPodIP {
+			return &utils.RayNodeWorkload{NodeID: nodeIP, NumRunningTasks: 2, NumAliveActors: 1}, nil
+		}
+		return &utils.RayNodeWorkload{NodeID: nodeIP}, nil
+	}
+	fakeRayDashboardClient.GetNodeWorkloadMock.Store(&getNodeWorkload)
+
+	// Scale down to 1 replica. The Pods with idle Ray nodes are deleted.
+	cluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](1)
+	_, err = r.reconcilePods(ctx, cluster)
 	assert.Nil(t, err)
-	assert.Equal(t, 2, len(pods.Items))
-	assert.Subset(t, []string{"deleted", "other"}, []string{pods.Items[0].Name, pods.Items[1].Name})
+	workerPods := listWorkerPods()
+	assert.Equal(t, 1, len(workerPods))
+	assert.Equal(t, busyPodName, workerPods[0].Name)
+	assert.Equal(t, "3", workerPods[0].Annotations[utils.PodDeletionCostAnnotationKey])
+}
+
+func TestReconcileIdleTimeout(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+	headPod := testPods[0].(*corev1.Pod).DeepCopy()
+	headPod.Status.Phase = corev1.PodRunning
+	headPod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.IdleTimeoutSeconds = ptr.To[int32](60)
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster, headPod, testServices[0]).Build()
+	fakeRayDashboardClient := &utils.FakeRayDashboardClient{}
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   record.NewFakeRecorder(100),
+		Scheme:                     newScheme,
+		dashboardClientFunc:        func() utils.RayDashboardClientInterface { return fakeRayDashboardClient },
+	}
+	getClusterWorkload := func(_ context.Context) (*utils.RayClusterWorkload, error) {
+		return &utils.RayClusterWorkload{NumActiveJobs: 1}, nil
+	}
+	fakeRayDashboardClient.GetClusterWorkloadMock.Store(&getClusterWorkload)
+
+	// The Ray cluster runs a job.
+	terminated, remaining, err := r.reconcileIdleTimeout(ctx, cluster)
+	assert.Nil(t, err)
+	assert.False(t, terminated)
+	assert.Zero(t, remaining)
+	assert.True(t, meta.IsStatusConditionFalse(cluster.Status.Conditions, string(rayv1.RayClusterIdle)))
+
+	// The Ray cluster becomes idle, and the RayCluster will be deleted after the idle timeout.
+	fakeRayDashboardClient.GetClusterWorkloadMock.Store(nil)
+	terminated, remaining, err = r.reconcileIdleTimeout(ctx, cluster)
+	assert.Nil(t, err)
+	assert.False(t, terminated)
+	assert.Equal(t, 60*time.Second, remaining)
+	idleCondition := meta.FindStatusCondition(cluster.Status.Conditions, string(rayv1.RayClusterIdle))
+	assert.NotNil(t, idleCondition)
+	assert.Equal(t, metav1.ConditionTrue, idleCondition.Status)
+	assert.Contains(t, idleCondition.Message, "The action Delete will be taken")
+
+	// The idle time is counted from when the Ray cluster became idle.
+	idleCondition.LastTransitionTime = metav1.NewTime(time.Now().Add(-30 * time.Second))
+	terminated, remaining, err = r.reconcileIdleTimeout(ctx, cluster)
+	assert.Nil(t, err)
+	assert.False(t, terminated)
+	assert.InDelta(t, 30*time.Second, remaining, float64(time.Second))
+
+	// The RayCluster is suspended once the idle timeout expires.
+	cluster.Spec.IdleTimeoutAction = rayv1.SuspendIdleTimeoutAction
+	meta.FindStatusCondition(cluster.Status.Conditions, string(rayv1.RayClusterIdle)).LastTransitionTime = metav1.NewTime(time.Now().Add(-time.Minute))
+	terminated, _, err = r.reconcileIdleTimeout(ctx, cluster)
+	assert.Nil(t, err)
+	assert.True(t, terminated)
+	suspendedCluster := &rayv1.RayCluster{}
+	assert.Nil(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(cluster), suspendedCluster))
+	assert.True(t, *suspendedCluster.Spec.Suspend)
+
+	// The RayClusterIdle condition is removed from the suspended RayCluster.
+	cluster.Status.Conditions = []metav1.Condition{*idleCondition}
+	terminated, _, err = r.reconcileIdleTimeout(ctx, cluster)
+	assert.Nil(t, err)
+	assert.False(t, terminated)
+	assert.Nil(t, meta.FindStatusCondition(cluster.Status.Conditions, string(rayv1.RayClusterIdle)))
+
+	// The RayCluster is deleted once the idle timeout expires.
+	cluster.Spec.Suspend = ptr.To(false)
+	cluster.Spec.IdleTimeoutAction = rayv1.DeleteIdleTimeoutAction
+	idleCondition.LastTransitionTime = metav1.NewTime(time.Now().Add(-time.Minute))
+	cluster.Status.Conditions = []metav1.Condition{*idleCondition}
+	terminated, _, err = r.reconcileIdleTimeout(ctx, cluster)
+	assert.Nil(t, err)
+	assert.True(t, terminated)
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(cluster), &rayv1.RayCluster{})
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestReconcileIdleTimeoutOwnedRayCluster(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+	headPod := testPods[0].(*corev1.Pod).DeepCopy()
+	headPod.Status.Phase = corev1.PodRunning
+	headPod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	fakeRayDashboardClient := &utils.FakeRayDashboardClient{}
+
+	for _, kind := range []string{"RayJob", "RayService"} {
+		t.Run(kind, func(t *testing.T) {
+			cluster := testRayCluster.DeepCopy()
+			cluster.Spec.IdleTimeoutSeconds = ptr.To[int32](60)
+			cluster.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: rayv1.GroupVersion.String(),
+				Kind:       kind,
+				Name:       "owner",
+				UID:        "owner-uid",
+				Controller: ptr.To(true),
+			}}
+			idleCondition := metav1.Condition{
+				Type:               string(rayv1.RayClusterIdle),
+				Status:             metav1.ConditionTrue,
+				Reason:             rayv1.RayClusterNoActiveWorkload,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+			}
+			cluster.Status.Conditions = []metav1.Condition{idleCondition}
+			fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster, headPod, testServices[0]).Build()
+			r := &RayClusterReconciler{
+				Client:                     fakeClient,
+				rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+				Recorder:                   record.NewFakeRecorder(100),
+				Scheme:                     newScheme,
+				dashboardClientFunc:        func() utils.RayDashboardClientInterface { return fakeRayDashboardClient },
+			}
+
+			// The idle Ray cluster is neither deleted nor suspended, because its owner manages its lifecycle.
+			terminated, remaining, err := r.reconcileIdleTimeout(ctx, cluster)
+			assert.Nil(t, err)
+			assert.False(t, terminated)
+			assert.Zero(t, remaining)
+			assert.Nil(t, meta.FindStatusCondition(cluster.Status.Conditions, string(rayv1.RayClusterIdle)))
+			existingCluster := &rayv1.RayCluster{}
+			assert.Nil(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(cluster), existingCluster))
+			assert.Nil(t, existingCluster.Spec.Suspend)
+		})
+	}
+}
+
+func TestReconcileWorkloadStatus(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+	headPod := testPods[0].(*corev1.Pod).DeepCopy()
+	headPod.Status.Phase = corev1.PodRunning
+	headPod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	cluster := testRayCluster.DeepCopy()
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster, headPod, testServices[0]).Build()
+	fakeRayDashboardClient := &utils.FakeRayDashboardClient{}
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   record.NewFakeRecorder(100),
+		Scheme:                     newScheme,
+		dashboardClientFunc:        func() utils.RayDashboardClientInterface { return fakeRayDashboardClient },
+	}
+
+	// The workload status is not reported in the low-overhead mode.
+	assert.Zero(t, r.reconcileWorkloadStatus(ctx, cluster))
+	assert.Nil(t, cluster.Status.Workload)
+
+	// The workload status is refreshed from the Ray dashboard.
+	r.workloadStatusInterval = time.Minute
+	getClusterWorkload := func(_ context.Context) (*utils.RayClusterWorkload, error) {
+		return &utils.RayClusterWorkload{NumActiveJobs: 1, NumRunningTasks: 3, NumAliveActors: 2}, nil
+	}
+	fakeRayDashboardClient.GetClusterWorkloadMock.Store(&getClusterWorkload)
+	getClusterUtilization := func(_ context.Context) (*utils.RayClusterUtilization, error) {
+		return &utils.RayClusterUtilization{
+			Used:  map[string]float64{"CPU": 1.5, "GPU": 1},
+			Total: map[string]float64{"CPU": 4, "GPU": 2},
+		}, nil
+	}
+	fakeRayDashboardClient.GetClusterUtilizationMock.Store(&getClusterUtilization)
+	assert.Equal(t, time.Minute, r.reconcileWorkloadStatus(ctx, cluster))
+	workload := cluster.Status.Workload
+	assert.NotNil(t, workload)
+	assert.Equal(t, int32(1), workload.ActiveJobs)
+	assert.Equal(t, int32(3), workload.RunningTasks)
+	assert.Equal(t, int32(2), workload.AliveActors)
+	assert.Zero(t, workload.ServeApplications)
+	assert.Equal(t, "1500m", workload.UsedResources.Name("CPU", resource.DecimalSI).String())
+	assert.Equal(t, "2", workload.TotalResources.Name("GPU", resource.DecimalSI).String())
+
+	// The snapshot is not refreshed until it is older than the interval, even if the Ray dashboard fails.
+	getClusterWorkloadErr := func(_ context.Context) (*utils.RayClusterWorkload, error) {
+		return nil, fmt.Errorf("the Ray dashboard is unavailable")
+	}
+	fakeRayDashboardClient.GetClusterWorkloadMock.Store(&getClusterWorkloadErr)
+	assert.InDelta(t, time.Minute, r.reconcileWorkloadStatus(ctx, cluster), float64(time.Second))
+	assert.Same(t, workload, cluster.Status.Workload)
+
+	// The last snapshot is kept if the Ray dashboard fails.
+	workload.LastUpdateTime = &metav1.Time{Time: time.Now().Add(-2 * time.Minute)}
+	assert.Zero(t, r.reconcileWorkloadStatus(ctx, cluster))
+	assert.Same(t, workload, cluster.Status.Workload)
+
+	// The workload status is removed from the suspended RayCluster.
+	cluster.Spec.Suspend = ptr.To(true)
+	assert.Zero(t, r.reconcileWorkloadStatus(ctx, cluster))
+	assert.Nil(t, cluster.Status.Workload)
+}
+
+func TestSortWorkerPodsForScaleDown(t *testing.T) {
+	newPod := func(name string, phase corev1.PodPhase, annotations map[string]string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespaceStr, Annotations: annotations},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+	pods := []corev1.Pod{
+		newPod("busy", corev1.PodRunning, map[string]string{utils.PodDeletionCostAnnotationKey: "5"}),
+		newPod("unranked", corev1.PodRunning, nil),
+		newPod("pending", corev1.PodPending, nil),
+		newPod("idle", corev1.PodRunning, map[string]string{utils.PodDeletionCostAnnotationKey: "0"}),
+		newPod("draining", corev1.PodRunning, map[string]string{utils.RayNodeDrainStartTimeAnnotationKey: time.Now().Format(time.RFC3339)}),
+	}
+
+	// Without a Ray dashboard client, the Pods are sorted by their previous pod deletion costs.
+	r := &RayClusterReconciler{Recorder: record.NewFakeRecorder(100)}
+	assert.Nil(t, r.sortWorkerPodsForScaleDown(context.Background(), testRayCluster.DeepCopy(), pods))
+	var names []string
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	assert.Equal(t, []string{"draining", "pending", "unranked", "idle", "busy"}, names)
+}
+
+func TestReconcile_StatefulSetWorkerGroup(t *testing.T) {
+	setupTest(t)
+
+	// This test makes some assumptions about the testRayCluster object.
+	// (1) 1 workerGroup (2) The goal state of the workerGroup is 3 replicas.
+	assert.Equal(t, 1, len(testRayCluster.Spec.WorkerGroupSpecs), "This test assumes only one worker group.")
+	assert.Equal(t, int32(3), *testRayCluster.Spec.WorkerGroupSpecs[0].Replicas, "This test assumes the expected number of worker pods is 3.")
+	testRayCluster.Spec.EnableInTreeAutoscaling = ptr.To(false)
+	testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
+	testRayCluster.Spec.WorkerGroupSpecs[0].WorkloadType = rayv1.StatefulSetWorkerGroupWorkloadType
+	testRayCluster.Spec.WorkerGroupSpecs[0].VolumeClaimTemplates = []corev1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "cache"}}}
+
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods[0]).WithInterceptorFuncs(serverSideApplyFuncs).Build()
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   record.NewFakeRecorder(100),
+		Scheme:                     scheme.Scheme,
+	}
+
+	getStatefulSet := func() (*appsv1.StatefulSet, error) {
+		statefulSet := &appsv1.StatefulSet{}
+		err := fakeClient.Get(ctx, types.NamespacedName{Namespace: namespaceStr, Name: cluster.Name + "-" + groupNameStr + "-worker"}, statefulSet)
+		return statefulSet, err
+	}
+	listWorkerPods := func() []corev1.Pod {
+		podList := corev1.PodList{}
+		err := fakeClient.List(ctx, &podList, &client.ListOptions{LabelSelector: workerSelector, Namespace: namespaceStr})
+		assert.Nil(t, err, "Fail to get pod list")
+		return podList.Items
+	}
+
+	// The StatefulSet of the worker group is created instead of worker Pods.
+	_, err := r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Empty(t, listWorkerPods())
+	statefulSet, err := getStatefulSet()
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), *statefulSet.Spec.Replicas)
+	assert.Equal(t, cluster.Name+"-"+utils.HeadlessServiceSuffix, statefulSet.Spec.ServiceName)
+	assert.Equal(t, appsv1.OnDeleteStatefulSetStrategyType, statefulSet.Spec.UpdateStrategy.Type)
+	assert.Equal(t, "cache", statefulSet.Spec.VolumeClaimTemplates[0].Name)
+	assert.Equal(t, groupNameStr, statefulSet.Spec.Template.Labels[utils.RayNodeGroupLabelKey])
+	assert.Equal(t, cluster.Name, statefulSet.OwnerReferences[0].Name)
+	podTemplateHash := statefulSet.Spec.Template.Annotations[utils.RayWorkerGroupPodTemplateHashAnnotationKey]
+	assert.NotEmpty(t, podTemplateHash)
+
+	// The headless service gives the Pods of the StatefulSet stable DNS names.
+	assert.Nil(t, r.reconcileHeadlessService(ctx, cluster))
+	serviceList := corev1.ServiceList{}
+	assert.Nil(t, fakeClient.List(ctx, &serviceList, client.InNamespace(namespaceStr)))
+	assert.Equal(t, 1, len(serviceList.Items))
+	assert.Equal(t, statefulSet.Spec.ServiceName, serviceList.Items[0].Name)
+
+	// Scaling the worker group and changing its Pod template update the StatefulSet.
+	cluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](1)
+	cluster.Spec.WorkerGroupSpecs[0].Template.Labels = map[string]string{"ray.io/test": "updated"}
+	cluster.Spec.WorkerGroupSpecs[0].UpdateStrategy = &rayv1.WorkerGroupUpdateStrategy{Type: rayv1.RollingUpdateWorkerGroupUpdateStrategyType}
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	statefulSet, err = getStatefulSet()
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), *statefulSet.Spec.Replicas)
+	assert.Equal(t, "updated", statefulSet.Spec.Template.Labels["ray.io/test"])
+	assert.NotEqual(t, podTemplateHash, statefulSet.Spec.Template.Annotations[utils.RayWorkerGroupPodTemplateHashAnnotationKey])
+	assert.Equal(t, appsv1.RollingUpdateStatefulSetStrategyType, statefulSet.Spec.UpdateStrategy.Type)
+
+	// Switching the worker group back to the Pod workload type deletes the StatefulSet.
+	cluster.Spec.WorkerGroupSpecs[0].WorkloadType = rayv1.PodWorkerGroupWorkloadType
+	cluster.Spec.WorkerGroupSpecs[0].VolumeClaimTemplates = nil
+	cluster.Spec.WorkerGroupSpecs[0].UpdateStrategy = nil
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	_, err = getStatefulSet()
+	assert.True(t, k8serrors.IsNotFound(err))
+	assert.Equal(t, 1, len(listWorkerPods()))
+}
+
+func TestReconcile_MultiHostWorkerGroup(t *testing.T) {
+	setupTest(t)
+	features.SetFeatureGateDuringTest(t, features.RayMultiHostIndexing, true)
+
+	// This test makes some assumptions about the testRayCluster object.
+	// (1) 1 workerGroup (2) The goal state of the workerGroup is 3 replicas.
+	assert.Equal(t, 1, len(testRayCluster.Spec.WorkerGroupSpecs), "This test assumes only one worker group.")
+	assert.Equal(t, int32(3), *testRayCluster.Spec.WorkerGroupSpecs[0].Replicas, "This test assumes the expected number of worker pods is 3.")
+	testRayCluster.Spec.EnableInTreeAutoscaling = ptr.To(false)
+	testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
+	testRayCluster.Spec.WorkerGroupSpecs[0].NumOfHosts = 2
+
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods[0]).WithInterceptorFuncs(serverSideApplyFuncs).Build()
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   record.NewFakeRecorder(100),
+		Scheme:                     scheme.Scheme,
+	}
+
+	listWorkerPods := func() []corev1.Pod {
+		podList := corev1.PodList{}
+		err := fakeClient.List(ctx, &podList, &client.ListOptions{LabelSelector: workerSelector, Namespace: namespaceStr})
+		assert.Nil(t, err, "Fail to get pod list")
+		return podList.Items
+	}
+	// listReplicas maps the replica indices to the host indices of their Pods.
+	listReplicas := func() map[string][]string {
+		replicaNames := make(map[string]string)
+		replicas := make(map[string][]string)
+		for _, pod := range listWorkerPods() {
+			replicaIndex := pod.Labels[utils.RayWorkerReplicaIndexLabelKey]
+			if replicaName, ok := replicaNames[replicaIndex]; ok {
+				assert.Equal(t, replicaName, pod.Labels[utils.RayWorkerReplicaNameLabelKey], "The Pods of a replica must share the replica name")
+			}
+			replicaNames[replicaIndex] = pod.Labels[utils.RayWorkerReplicaNameLabelKey]
+			replicas[replicaIndex] = append(replicas[replicaIndex], pod.Labels[utils.RayHostIndexLabelKey])
+		}
+		for _, hostIndices := range replicas {
+			sort.Strings(hostIndices)
+		}
+		return replicas
+	}
+	getReplicaPods := func(replicaIndex string) []corev1.Pod {
+		var pods []corev1.Pod
+		for _, pod := range listWorkerPods() {
+			if pod.Labels[utils.RayWorkerReplicaIndexLabelKey] == replicaIndex {
+				pods = append(pods, pod)
+			}
+		}
+		return pods
+	}
+
+	// Each replica is created with 2 Pods.
+	_, err := r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{"0": {"0", "1"}, "1": {"0", "1"}, "2": {"0", "1"}}, listReplicas())
+
+	// If a Pod of a replica fails, the whole replica is deleted and then recreated with the same replica index.
+	failedPod := getReplicaPods("1")[0]
+	failedPod.Status.Phase = corev1.PodFailed
+	assert.Nil(t, fakeClient.Status().Update(ctx, &failedPod), "Fail to update pod status")
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.ErrorContains(t, err, "delete 1 unhealthy replicas")
+	assert.Equal(t, map[string][]string{"0": {"0", "1"}, "2": {"0", "1"}}, listReplicas())
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{"0": {"0", "1"}, "1": {"0", "1"}, "2": {"0", "1"}}, listReplicas())
+
+	// If a Pod of a replica is deleted, the rest of the replica is deleted as well.
+	deletedPod := getReplicaPods("0")[0]
+	assert.Nil(t, fakeClient.Delete(ctx, &deletedPod))
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.ErrorContains(t, err, "delete 1 unhealthy replicas")
+	assert.Equal(t, map[string][]string{"1": {"0", "1"}, "2": {"0", "1"}}, listReplicas())
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(listReplicas()))
+
+	// A Pod in workersToDelete deletes its replica.
+	cluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](2)
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{getReplicaPods("0")[1].Name}
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{"1": {"0", "1"}, "2": {"0", "1"}}, listReplicas())
+
+	// Scaling down deletes the replicas with the highest replica indices.
+	cluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](1)
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{"1": {"0", "1"}}, listReplicas())
+}
+
+func TestGetRollingUpdateMaxSurgeAndMaxUnavailable(t *testing.T) {
+	tests := map[string]struct {
+		rollingUpdate          *rayv1.RollingUpdateWorkerGroup
+		expectedMaxSurge       int
+		expectedMaxUnavailable int
+	}{
+		"defaults": {
+			expectedMaxSurge:       0,
+			expectedMaxUnavailable: 1,
+		},
+		"maxSurge without maxUnavailable": {
+			rollingUpdate:          &rayv1.RollingUpdateWorkerGroup{MaxSurge: ptr.To(intstr.FromInt32(2))},
+			expectedMaxSurge:       2,
+			expectedMaxUnavailable: 0,
+		},
+		"percentages are rounded": {
+			rollingUpdate: &rayv1.RollingUpdateWorkerGroup{
+				MaxSurge:       ptr.To(intstr.FromString("25%")),
+				MaxUnavailable: ptr.To(intstr.FromString("25%")),
+			},
+			expectedMaxSurge:       3,
+			expectedMaxUnavailable: 2,
+		},
+		"both are 0": {
+			rollingUpdate: &rayv1.RollingUpdateWorkerGroup{
+				MaxSurge:       ptr.To(intstr.FromInt32(0)),
+				MaxUnavailable: ptr.To(intstr.FromString("0%")),
+			},
+			expectedMaxSurge:       0,
+			expectedMaxUnavailable: 1,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			worker := rayv1.WorkerGroupSpec{
+				UpdateStrategy: &rayv1.WorkerGroupUpdateStrategy{
+					Type:          rayv1.RollingUpdateWorkerGroupUpdateStrategyType,
+					RollingUpdate: tc.rollingUpdate,
+				},
+			}
+			maxSurge, maxUnavailable, err := getRollingUpdateMaxSurgeAndMaxUnavailable(worker, 10)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedMaxSurge, maxSurge)
+			assert.Equal(t, tc.expectedMaxUnavailable, maxUnavailable)
+		})
+	}
+}
+
+func TestCalculateWorkerGroupStatuses(t *testing.T) {
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	groupName := cluster.Spec.WorkerGroupSpecs[0].GroupName
+	cluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To(int32(3))
+	cluster.Spec.WorkerGroupSpecs[0].MinReplicas = ptr.To(int32(0))
+	cluster.Spec.WorkerGroupSpecs[0].MaxReplicas = ptr.To(int32(5))
+
+	workerPod := func(ready bool, phase corev1.PodPhase) corev1.Pod {
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					utils.RayNodeTypeLabelKey:  string(rayv1.WorkerNode),
+					utils.RayNodeGroupLabelKey: groupName,
+				},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+		if ready {
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		}
+		return pod
+	}
+	pods := corev1.PodList{Items: []corev1.Pod{
+		workerPod(true, corev1.PodRunning),
+		workerPod(false, corev1.PodRunning),
+		workerPod(false, corev1.PodFailed),
+	}}
+
+	statuses := calculateWorkerGroupStatuses(ctx, cluster, pods)
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, groupName, statuses[0].GroupName)
+	assert.Equal(t, int32(3), statuses[0].DesiredReplicas)
+	assert.Equal(t, int32(1), statuses[0].ReadyReplicas)
+	assert.Equal(t, int32(1), statuses[0].FailedReplicas)
+	assert.NotNil(t, statuses[0].LastTransitionTime)
+
+	// The transition time is kept while the desired and ready replicas of the group do not change.
+	lastTransitionTime := metav1.NewTime(time.Now().Add(-time.Hour))
+	statuses[0].LastTransitionTime = &lastTransitionTime
+	cluster.Status.WorkerGroupStatuses = statuses
+	statuses = calculateWorkerGroupStatuses(ctx, cluster, pods)
+	assert.Equal(t, lastTransitionTime, *statuses[0].LastTransitionTime)
+
+	pods.Items[1] = workerPod(true, corev1.PodRunning)
+	statuses = calculateWorkerGroupStatuses(ctx, cluster, pods)
+	assert.Equal(t, int32(2), statuses[0].ReadyReplicas)
+	assert.True(t, statuses[0].LastTransitionTime.After(lastTransitionTime.Time))
+}
+
+func TestCalculateReadyGPUs(t *testing.T) {
+	gpuPod := func(ready bool) corev1.Pod {
+		pod := corev1.Pod{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("2")},
+					},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if ready {
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		}
+		return pod
+	}
+	pods := corev1.PodList{Items: []corev1.Pod{gpuPod(true), gpuPod(true), gpuPod(false)}}
+	readyGPUs := calculateReadyGPUs(pods)
+	assert.Equal(t, int64(4), readyGPUs.Value())
+}
+
+func TestSumGPUs(t *testing.T) {
+	nvidiaGPUResourceName := corev1.ResourceName("nvidia.com/gpu")
+	googleTPUResourceName := corev1.ResourceName("google.com/tpu")
+
+	tests := map[string]struct {
+		input    map[corev1.ResourceName]resource.Quantity
+		expected resource.Quantity
+	}{
+		"no GPUs specified": {
+			map[corev1.ResourceName]resource.Quantity{
+				corev1.ResourceCPU: resource.MustParse("1"),
+			},
+			resource.MustParse("0"),
+		},
+		"one GPU type specified": {
+			map[corev1.ResourceName]resource.Quantity{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				nvidiaGPUResourceName: resource.MustParse("1"),
+				googleTPUResourceName: resource.MustParse("1"),
+			},
+			resource.MustParse("1"),
+		},
+		"multiple GPUs specified": {
+			map[corev1.ResourceName]resource.Quantity{
+				corev1.ResourceCPU:                 resource.MustParse("1"),
+				nvidiaGPUResourceName:              resource.MustParse("3"),
+				corev1.ResourceName("foo.bar/gpu"): resource.MustParse("2"),
+				googleTPUResourceName:              resource.MustParse("1"),
+			},
+			resource.MustParse("5"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := sumGPUs(tc.input)
+			assert.True(t, tc.expected.Equal(result), "GPU number is wrong")
+		})
+	}
+}
+
+func TestDeleteAllPods(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(newScheme)
+	ns := "tmp-ns"
+	ts := metav1.Now()
+	filter := map[string]string{"app": "tmp"}
+
+	p1 := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "alive",
+			Namespace: ns,
+			Labels:    filter,
+		},
+	}
+	p2 := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "deleted",
+			Namespace:         ns,
+			Labels:            filter,
+			DeletionTimestamp: &ts,
+			Finalizers:        []string{"tmp"},
+		},
+	}
+	p3 := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other",
+			Namespace: ns,
+			Labels:    map[string]string{"app": "other"},
+		},
+	}
+
+	fakeClient := clientFake.NewClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(p1, p2, p3).
+		Build()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     newScheme,
+	}
+	ctx := context.Background()
+	// The first `deleteAllPods` function call should delete the "alive" Pod.
+	pods, err := testRayClusterReconciler.deleteAllPods(ctx, common.AssociationOptions{client.InNamespace(ns), client.MatchingLabels(filter)})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(pods.Items))
+	assert.Subset(t, []string{"alive", "deleted"}, []string{pods.Items[0].Name, pods.Items[1].Name})
+	// The second `deleteAllPods` function call should delete no Pods because none are active.
+	pods, err = testRayClusterReconciler.deleteAllPods(ctx, common.AssociationOptions{client.InNamespace(ns), client.MatchingLabels(filter)})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(pods.Items))
+	assert.Equal(t, "deleted", pods.Items[0].Name)
+	// Make sure that the above `deleteAllPods` calls didn't remove other Pods.
+	pods = corev1.PodList{}
+	err = fakeClient.List(ctx, &pods, client.InNamespace(ns))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(pods.Items))
+	assert.Subset(t, []string{"deleted", "other"}, []string{pods.Items[0].Name, pods.Items[1].Name})
 }
 
 func TestEvents_FailedPodCreation(t *testing.T) {
@@ -3115,14 +4301,15 @@ func TestEvents_FailedPodCreation(t *testing.T) {
 
 			// Initialize a new RayClusterReconciler.
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: recorder,
-				Scheme:   scheme.Scheme,
+				Client:                     fakeClient,
+				rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+				Recorder:                   recorder,
+				Scheme:                     scheme.Scheme,
 			}
 
 			// Since the desired state of the workerGroup is 3 replicas,
 			// the controller will try to create one worker pod.
-			err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+			_, err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
 			// We should get an error here because of simulating a pod creation failure.
 			assert.NotNil(t, err, "unexpected error")
 
@@ -3141,3 +4328,1409 @@ func TestEvents_FailedPodCreation(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcilePodDisruptionBudgets(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	cluster.UID = "raycluster-uid"
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	_ = policyv1.AddToScheme(newScheme)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster).WithInterceptorFuncs(serverSideApplyFuncs).Build()
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   record.NewFakeRecorder(100),
+		Scheme:                     newScheme,
+		enablePodDisruptionBudgets: true,
+	}
+	listPDBs := func() map[string]policyv1.PodDisruptionBudget {
+		pdbs := policyv1.PodDisruptionBudgetList{}
+		assert.Nil(t, fakeClient.List(ctx, &pdbs, client.InNamespace(namespaceStr)))
+		result := make(map[string]policyv1.PodDisruptionBudget)
+		for _, pdb := range pdbs.Items {
+			result[pdb.Name] = pdb
+		}
+		return result
+	}
+	headPDBName := common.BuildPodDisruptionBudgetForHeadPod(*cluster).Name
+	workerPDBName := common.BuildPodDisruptionBudgetForWorkerGroup(*cluster, cluster.Spec.WorkerGroupSpecs[0]).Name
+
+	// The operator enables PodDisruptionBudgets by default.
+	assert.Nil(t, r.reconcilePodDisruptionBudgets(ctx, cluster))
+	pdbs := listPDBs()
+	assert.Len(t, pdbs, 2)
+	assert.Equal(t, ptr.To(intstr.FromInt32(0)), pdbs[headPDBName].Spec.MaxUnavailable)
+	assert.Equal(t, ptr.To(intstr.FromInt32(1)), pdbs[workerPDBName].Spec.MaxUnavailable)
+	assert.True(t, metav1.IsControlledBy(ptr.To(pdbs[headPDBName]), cluster))
+
+	// The disruption budget of the worker group changes.
+	cluster.Spec.WorkerGroupSpecs[0].DisruptionBudget = &rayv1.WorkerGroupDisruptionBudget{MinAvailable: ptr.To(intstr.FromString("50%"))}
+	assert.Nil(t, r.reconcilePodDisruptionBudgets(ctx, cluster))
+	pdbs = listPDBs()
+	assert.Nil(t, pdbs[workerPDBName].Spec.MaxUnavailable)
+	assert.Equal(t, ptr.To(intstr.FromString("50%")), pdbs[workerPDBName].Spec.MinAvailable)
+
+	// The worker group is removed.
+	cluster.Spec.WorkerGroupSpecs = nil
+	assert.Nil(t, r.reconcilePodDisruptionBudgets(ctx, cluster))
+	pdbs = listPDBs()
+	assert.Len(t, pdbs, 1)
+	assert.Contains(t, pdbs, headPDBName)
+
+	// The RayCluster disables PodDisruptionBudgets.
+	cluster.Spec.EnablePodDisruptionBudgets = ptr.To(false)
+	assert.Nil(t, r.reconcilePodDisruptionBudgets(ctx, cluster))
+	assert.Empty(t, listPDBs())
+}
+
+func TestReconcileGCSFaultToleranceReady(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	var dialedAddress, unreachableAddress string
+	var dialErr error
+	recorder := record.NewFakeRecorder(100)
+	r := &RayClusterReconciler{
+		Recorder: recorder,
+		dialRedis: func(_ context.Context, _, address string) (net.Conn, error) {
+			dialedAddress = address
+			if dialErr != nil {
+				return nil, dialErr
+			}
+			if address == unreachableAddress {
+				return nil, errors.New("connection refused")
+			}
+			conn, peer := net.Pipe()
+			peer.Close()
+			return conn, nil
+		},
+	}
+	getCondition := func() *metav1.Condition {
+		return meta.FindStatusCondition(cluster.Status.Conditions, string(rayv1.GCSFaultToleranceReady))
+	}
+
+	// The RayCluster does not have GcsFaultToleranceOptions.
+	r.reconcileGCSFaultToleranceReady(ctx, cluster)
+	assert.Nil(t, getCondition())
+
+	// The Redis server is reachable.
+	cluster.Spec.GcsFaultToleranceOptions = &rayv1.GcsFaultToleranceOptions{RedisAddress: "rediss://redis.default.svc"}
+	r.reconcileGCSFaultToleranceReady(ctx, cluster)
+	assert.Equal(t, "redis.default.svc:6379", dialedAddress)
+	assert.Equal(t, metav1.ConditionTrue, getCondition().Status)
+	assert.Equal(t, rayv1.RedisConnected, getCondition().Reason)
+
+	// The Redis server is unreachable, and an event is emitted once.
+	dialErr = errors.New("connection refused")
+	r.reconcileGCSFaultToleranceReady(ctx, cluster)
+	r.reconcileGCSFaultToleranceReady(ctx, cluster)
+	assert.Equal(t, metav1.ConditionFalse, getCondition().Status)
+	assert.Equal(t, rayv1.RedisConnectionFailed, getCondition().Reason)
+	assert.Contains(t, getCondition().Message, "connection refused")
+	assert.Len(t, recorder.Events, 1)
+
+	// The KubeRay operator falls back to the other Sentinels of Redis Sentinel.
+	dialErr = nil
+	unreachableAddress = "redis-0:26379"
+	cluster.Spec.GcsFaultToleranceOptions = &rayv1.GcsFaultToleranceOptions{
+		RedisAddress: "redis-0:26379,redis-1:26379",
+		RedisMode:    rayv1.SentinelRedisMode,
+	}
+	r.reconcileGCSFaultToleranceReady(ctx, cluster)
+	assert.Equal(t, "redis-1:26379", dialedAddress)
+	assert.Equal(t, metav1.ConditionTrue, getCondition().Status)
+
+	// Ray only connects to the first endpoint of a standalone Redis.
+	cluster.Spec.GcsFaultToleranceOptions.RedisMode = rayv1.StandaloneRedisMode
+	r.reconcileGCSFaultToleranceReady(ctx, cluster)
+	assert.Equal(t, "redis-0:26379", dialedAddress)
+	assert.Equal(t, metav1.ConditionFalse, getCondition().Status)
+
+	// The RayCluster is suspended.
+	cluster.Spec.Suspend = ptr.To(true)
+	r.reconcileGCSFaultToleranceReady(ctx, cluster)
+	assert.Nil(t, getCondition())
+}
+
+func TestReconcileCertificate(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	cluster.UID = "raycluster-uid"
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	newScheme.AddKnownTypeWithName(common.CertificateGVK, &unstructured.Unstructured{})
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster).WithInterceptorFuncs(serverSideApplyFuncs).Build()
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   record.NewFakeRecorder(100),
+		Scheme:                     newScheme,
+	}
+	getCertificate := func() *common.Certificate {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(common.CertificateGVK)
+		err := fakeClient.Get(ctx, types.NamespacedName{Namespace: namespaceStr, Name: common.GetTLSCASecretName(*cluster)}, obj)
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		assert.Nil(t, err)
+		certificate := &common.Certificate{}
+		assert.Nil(t, runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, certificate))
+		return certificate
+	}
+
+	// The RayCluster does not enable TLS.
+	assert.Nil(t, r.reconcileCertificate(ctx, cluster))
+	assert.Nil(t, getCertificate())
+
+	// The RayCluster enables TLS.
+	cluster.Spec.Security = &rayv1.RayClusterSecurity{
+		TLS: &rayv1.RayClusterTLS{Enabled: true, IssuerRef: &rayv1.CertificateIssuerReference{Name: "ray-ca"}},
+	}
+	assert.Nil(t, r.reconcileCertificate(ctx, cluster))
+	certificate := getCertificate()
+	assert.NotNil(t, certificate)
+	assert.Equal(t, "ray-ca", certificate.Spec.IssuerRef.Name)
+	assert.True(t, certificate.Spec.IsCA)
+	assert.Equal(t, common.GetTLSCASecretName(*cluster), certificate.Spec.SecretName)
+	assert.True(t, metav1.IsControlledBy(certificate, cluster))
+
+	// The issuer of the RayCluster changes.
+	cluster.Spec.Security.TLS.IssuerRef = &rayv1.CertificateIssuerReference{Name: "ray-cluster-ca", Kind: "ClusterIssuer"}
+	assert.Nil(t, r.reconcileCertificate(ctx, cluster))
+	certificate = getCertificate()
+	assert.Equal(t, rayv1.CertificateIssuerReference{Name: "ray-cluster-ca", Kind: "ClusterIssuer", Group: "cert-manager.io"}, certificate.Spec.IssuerRef)
+}
+
+func TestReconcileIngress_ManagedIngress(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	cluster.UID = "raycluster-uid"
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	_ = networkingv1.AddToScheme(newScheme)
+	newScheme.AddKnownTypeWithName(common.HTTPRouteGVK, &unstructured.Unstructured{})
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster).WithInterceptorFuncs(serverSideApplyFuncs).Build()
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   record.NewFakeRecorder(100),
+		Scheme:                     newScheme,
+	}
+	getIngress := func() *networkingv1.Ingress {
+		ingress := &networkingv1.Ingress{}
+		err := fakeClient.Get(ctx, types.NamespacedName{Namespace: namespaceStr, Name: utils.GenerateIngressName(cluster.Name)}, ingress)
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		assert.Nil(t, err)
+		return ingress
+	}
+	getHTTPRoute := func(name string) *common.HTTPRoute {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(common.HTTPRouteGVK)
+		err := fakeClient.Get(ctx, types.NamespacedName{Namespace: namespaceStr, Name: name}, obj)
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		assert.Nil(t, err)
+		route := &common.HTTPRoute{}
+		assert.Nil(t, runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, route))
+		return route
+	}
+
+	// The RayCluster exposes the Ray dashboard and Ray Serve with an Ingress.
+	cluster.Spec.HeadGroupSpec.Ingress = &rayv1.HeadIngressOptions{
+		Annotations:   map[string]string{"nginx.ingress.kubernetes.io/auth-url": "https://auth.example.com/oauth2/auth"},
+		DashboardHost: "dashboard.example.com",
+		ServeHost:     "serve.example.com",
+	}
+	assert.Nil(t, r.reconcileIngress(ctx, cluster))
+	ingress := getIngress()
+	assert.NotNil(t, ingress)
+	assert.True(t, metav1.IsControlledBy(ingress, cluster))
+	assert.Equal(t, "https://auth.example.com/oauth2/auth", ingress.Annotations["nginx.ingress.kubernetes.io/auth-url"])
+	assert.Len(t, ingress.Spec.Rules, 2)
+
+	// The Ingress is updated, and the annotations that other controllers add are kept.
+	ingress.Annotations["example.com/added-by"] = "ingress-controller"
+	ingress.Spec.IngressClassName = ptr.To("nginx")
+	assert.Nil(t, fakeClient.Update(ctx, ingress))
+	cluster.Spec.HeadGroupSpec.Ingress.ServeHost = ""
+	cluster.Spec.HeadGroupSpec.Ingress.TLSSecretName = "example-tls"
+	assert.Nil(t, r.reconcileIngress(ctx, cluster))
+	ingress = getIngress()
+	assert.Equal(t, "ingress-controller", ingress.Annotations["example.com/added-by"])
+	assert.Equal(t, ptr.To("nginx"), ingress.Spec.IngressClassName)
+	assert.Len(t, ingress.Spec.Rules, 1)
+	assert.Equal(t, []networkingv1.IngressTLS{{Hosts: []string{"dashboard.example.com"}, SecretName: "example-tls"}}, ingress.Spec.TLS)
+
+	// The RayCluster switches to HTTPRoutes, so the Ingress is deleted.
+	cluster.Spec.HeadGroupSpec.Ingress = &rayv1.HeadIngressOptions{
+		Type:      rayv1.HTTPRouteIngressType,
+		Gateway:   &rayv1.GatewayReference{Name: "gateway"},
+		ServeHost: "serve.example.com",
+	}
+	assert.Nil(t, r.reconcileIngress(ctx, cluster))
+	assert.Nil(t, getIngress())
+	dashboardRoute := getHTTPRoute(common.GetDashboardHTTPRouteName(cluster.Name))
+	assert.NotNil(t, dashboardRoute)
+	assert.True(t, metav1.IsControlledBy(dashboardRoute, cluster))
+	assert.Equal(t, []common.HTTPRouteParentReference{{Name: "gateway"}}, dashboardRoute.Spec.ParentRefs)
+	serveRoute := getHTTPRoute(common.GetServeHTTPRouteName(cluster.Name))
+	assert.NotNil(t, serveRoute)
+	assert.Equal(t, []string{"serve.example.com"}, serveRoute.Spec.Hostnames)
+
+	// The Gateway changes and Ray Serve is no longer exposed.
+	cluster.Spec.HeadGroupSpec.Ingress.Gateway = &rayv1.GatewayReference{Name: "gateway", Namespace: "gateway-system"}
+	cluster.Spec.HeadGroupSpec.Ingress.ServeHost = ""
+	assert.Nil(t, r.reconcileIngress(ctx, cluster))
+	dashboardRoute = getHTTPRoute(common.GetDashboardHTTPRouteName(cluster.Name))
+	assert.Equal(t, []common.HTTPRouteParentReference{{Name: "gateway", Namespace: "gateway-system"}}, dashboardRoute.Spec.ParentRefs)
+	assert.Nil(t, getHTTPRoute(common.GetServeHTTPRouteName(cluster.Name)))
+
+	// The RayService reconciles the Ingress of the RayClusters that it creates.
+	cluster.Labels = map[string]string{utils.RayOriginatedFromCRDLabelKey: utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD)}
+	cluster.Spec.HeadGroupSpec.Ingress = &rayv1.HeadIngressOptions{}
+	assert.Nil(t, r.reconcileIngress(ctx, cluster))
+	assert.Nil(t, getIngress())
+}
+
+func TestReconcilePrometheusMonitors(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	cluster.UID = "raycluster-uid"
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	newScheme.AddKnownTypeWithName(common.ServiceMonitorGVK, &unstructured.Unstructured{})
+	newScheme.AddKnownTypeWithName(common.PodMonitorGVK, &unstructured.Unstructured{})
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster).WithInterceptorFuncs(serverSideApplyFuncs).Build()
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   record.NewFakeRecorder(100),
+		Scheme:                     newScheme,
+	}
+	getMonitor := func(gvk schema.GroupVersionKind, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		err := fakeClient.Get(ctx, types.NamespacedName{Namespace: namespaceStr, Name: name}, obj)
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		assert.Nil(t, err)
+		return obj
+	}
+	serviceMonitorName := common.GetHeadServiceMonitorName(*cluster)
+	podMonitorName := common.GetWorkerPodMonitorName(*cluster)
+
+	// Neither the RayCluster nor the KubeRay operator enables the monitors.
+	assert.Nil(t, r.reconcilePrometheusMonitors(ctx, cluster))
+	assert.Nil(t, getMonitor(common.ServiceMonitorGVK, serviceMonitorName))
+	assert.Nil(t, getMonitor(common.PodMonitorGVK, podMonitorName))
+
+	// The KubeRay operator enables the monitors by default.
+	r.enablePrometheusMonitors = true
+	assert.Nil(t, r.reconcilePrometheusMonitors(ctx, cluster))
+	serviceMonitor := getMonitor(common.ServiceMonitorGVK, serviceMonitorName)
+	assert.NotNil(t, serviceMonitor)
+	assert.True(t, metav1.IsControlledBy(serviceMonitor, cluster))
+	podMonitor := getMonitor(common.PodMonitorGVK, podMonitorName)
+	assert.NotNil(t, podMonitor)
+	assert.True(t, metav1.IsControlledBy(podMonitor, cluster))
+
+	// The labels and the scrape interval of the RayCluster are applied, and the fields that other controllers set are kept.
+	assert.Nil(t, unstructured.SetNestedField(podMonitor.Object, "ray", "spec", "jobLabel"))
+	assert.Nil(t, fakeClient.Update(ctx, podMonitor))
+	cluster.Spec.PrometheusMonitors = &rayv1.PrometheusMonitorOptions{Labels: map[string]string{"release": "prometheus"}, Interval: "30s"}
+	assert.Nil(t, r.reconcilePrometheusMonitors(ctx, cluster))
+	podMonitor = getMonitor(common.PodMonitorGVK, podMonitorName)
+	assert.Equal(t, "prometheus", podMonitor.GetLabels()["release"])
+	jobLabel, _, _ := unstructured.NestedString(podMonitor.Object, "spec", "jobLabel")
+	assert.Equal(t, "ray", jobLabel)
+	endpoints, _, _ := unstructured.NestedSlice(podMonitor.Object, "spec", "podMetricsEndpoints")
+	assert.Len(t, endpoints, 1)
+	assert.Equal(t, "30s", endpoints[0].(map[string]interface{})["interval"])
+
+	// The RayCluster disables the monitors.
+	cluster.Spec.PrometheusMonitors.Enabled = ptr.To(false)
+	assert.Nil(t, r.reconcilePrometheusMonitors(ctx, cluster))
+	assert.Nil(t, getMonitor(common.ServiceMonitorGVK, serviceMonitorName))
+	assert.Nil(t, getMonitor(common.PodMonitorGVK, podMonitorName))
+}
+
+func TestBuildRedisCleanupJob(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.GcsFaultToleranceOptions = &rayv1.GcsFaultToleranceOptions{RedisAddress: "redis-0:6379,redis-1:6379"}
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	r := &RayClusterReconciler{Scheme: newScheme}
+
+	// By default, the Job cleans up the first endpoint with the image of the Ray head container, and retries twice.
+	job, err := r.buildRedisCleanupJob(ctx, *cluster)
+	assert.Nil(t, err)
+	container := job.Spec.Template.Spec.Containers[utils.RayContainerIndex]
+	assert.Equal(t, cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Image, container.Image)
+	assert.Contains(t, container.Args[0], "for redis_address in ${RAY_REDIS_ADDRESS%%,*}; do")
+	assert.Equal(t, int32(2), *job.Spec.BackoffLimit)
+
+	// In the Cluster mode, the Job tries every endpoint with the configured image and retries.
+	cluster.Spec.GcsFaultToleranceOptions.RedisMode = rayv1.ClusterRedisMode
+	cluster.Spec.GcsFaultToleranceOptions.RedisCleanup = &rayv1.RedisCleanupOptions{
+		BackoffLimit: ptr.To[int32](5),
+		Image:        "rayproject/ray:redis-cleanup",
+	}
+	job, err = r.buildRedisCleanupJob(ctx, *cluster)
+	assert.Nil(t, err)
+	container = job.Spec.Template.Spec.Containers[utils.RayContainerIndex]
+	assert.Equal(t, "rayproject/ray:redis-cleanup", container.Image)
+	assert.Contains(t, container.Args[0], "for redis_address in ${RAY_REDIS_ADDRESS//,/ }; do")
+	assert.Equal(t, int32(5), *job.Spec.BackoffLimit)
+}
+
+func TestReconcileHeadPodRecovery(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.GcsFaultToleranceOptions = &rayv1.GcsFaultToleranceOptions{
+		RedisAddress:                        "redis:6379",
+		RestartWorkersAfterReconnectTimeout: true,
+	}
+	cluster.Status.Head.PodName = headNodeName
+	headPod := testPods[0].(*corev1.Pod).DeepCopy()
+	headPod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	newWorkerPod := func(name string, creationTime time.Time, reconnectTimeoutS string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespaceStr,
+				CreationTimestamp: metav1.NewTime(creationTime),
+				Labels: map[string]string{
+					utils.RayClusterLabelKey:  instanceName,
+					utils.RayNodeTypeLabelKey: string(rayv1.WorkerNode),
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:  "ray-worker",
+					Image: "rayproject/ray",
+					Env:   []corev1.EnvVar{{Name: utils.RAY_GCS_RPC_SERVER_RECONNECT_TIMEOUT_S, Value: reconnectTimeoutS}},
+				}},
+			},
+		}
+	}
+	oldTime := time.Now().Add(-time.Hour)
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(
+		cluster,
+		headPod,
+		newWorkerPod("worker-timed-out", oldTime, "30"),
+		newWorkerPod("worker-reconnecting", oldTime, "600"),
+		newWorkerPod("worker-new", time.Now().Add(time.Hour), "30"),
+	).Build()
+	recorder := record.NewFakeRecorder(100)
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   recorder,
+		Scheme:                     newScheme,
+	}
+	getCondition := func() *metav1.Condition {
+		return meta.FindStatusCondition(cluster.Status.Conditions, string(rayv1.HeadPodRecovering))
+	}
+	setHeadPodReady := func(status corev1.ConditionStatus) {
+		headPod.Status.Conditions[0].Status = status
+		assert.Nil(t, fakeClient.Status().Update(ctx, headPod))
+	}
+	listWorkerPodNames := func() []string {
+		pods := corev1.PodList{}
+		assert.Nil(t, fakeClient.List(ctx, &pods, client.MatchingLabels{utils.RayNodeTypeLabelKey: string(rayv1.WorkerNode)}))
+		names := []string{}
+		for _, pod := range pods.Items {
+			names = append(names, pod.Name)
+		}
+		return names
+	}
+
+	// The head Pod is ready.
+	assert.Nil(t, r.reconcileHeadPodRecovery(ctx, cluster))
+	assert.Equal(t, metav1.ConditionFalse, getCondition().Status)
+	assert.Equal(t, rayv1.HeadPodRunningAndReady, getCondition().Reason)
+
+	// The head Pod restarts.
+	setHeadPodReady(corev1.ConditionFalse)
+	assert.Nil(t, r.reconcileHeadPodRecovery(ctx, cluster))
+	assert.Equal(t, metav1.ConditionTrue, getCondition().Status)
+	assert.Equal(t, rayv1.HeadPodRestarted, getCondition().Reason)
+	assert.Len(t, recorder.Events, 1)
+
+	// The head Pod recovers after 2 minutes. Only the worker Pod whose GCS reconnection timed out is restarted.
+	getCondition().LastTransitionTime = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	setHeadPodReady(corev1.ConditionTrue)
+	assert.Nil(t, r.reconcileHeadPodRecovery(ctx, cluster))
+	assert.Equal(t, metav1.ConditionFalse, getCondition().Status)
+	assert.Equal(t, rayv1.HeadPodRecovered, getCondition().Reason)
+	assert.Contains(t, getCondition().Message, "1 worker Pods were restarted")
+	assert.ElementsMatch(t, []string{"worker-reconnecting", "worker-new"}, listWorkerPodNames())
+
+	// The head Pod is replaced by a new head Pod between two reconciliations.
+	cluster.Status.Head.PodName = "old-head-pod"
+	assert.Nil(t, r.reconcileHeadPodRecovery(ctx, cluster))
+	assert.Equal(t, metav1.ConditionFalse, getCondition().Status)
+	assert.Equal(t, rayv1.HeadPodRecovered, getCondition().Reason)
+
+	// The condition is removed from a RayCluster without GCS fault tolerance.
+	cluster.Spec.GcsFaultToleranceOptions = nil
+	assert.Nil(t, r.reconcileHeadPodRecovery(ctx, cluster))
+	assert.Nil(t, getCondition())
+}
+
+func TestReconcilePods_ScaleExpectations(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = nil
+	numPods := 1 + int(*cluster.Spec.WorkerGroupSpecs[0].Replicas)
+	ctx := context.Background()
+	fakeClient := clientFake.NewClientBuilder().WithInterceptorFuncs(serverSideApplyFuncs).Build()
+	// staleClient simulates an informer cache that has not observed the Pods created by the reconciler yet.
+	staleClient := clientFake.NewClientBuilder().WithInterceptorFuncs(serverSideApplyFuncs).Build()
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(staleClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
+	}
+
+	_, err := r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	podList := corev1.PodList{}
+	assert.Nil(t, fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr)))
+	assert.Len(t, podList.Items, numPods)
+	createdPods := podList.Items
+
+	// The Pods listed from the stale cache do not include the created Pods, so they must not be created again.
+	r.Client = staleClient
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Nil(t, staleClient.List(ctx, &podList, client.InNamespace(namespaceStr)))
+	assert.Empty(t, podList.Items)
+	assert.False(t, r.rayClusterScaleExpectation.IsSatisfied(ctx, cluster.Namespace, cluster.Name, expectations.HeadGroup))
+	assert.False(t, r.rayClusterScaleExpectation.IsSatisfied(ctx, cluster.Namespace, cluster.Name, cluster.Spec.WorkerGroupSpecs[0].GroupName))
+
+	// Once the cache observes the created Pods, the expectations are satisfied and the RayCluster is reconciled again.
+	for _, pod := range createdPods {
+		pod.ResourceVersion = ""
+		assert.Nil(t, staleClient.Create(ctx, &pod))
+	}
+	assert.True(t, r.rayClusterScaleExpectation.IsSatisfied(ctx, cluster.Namespace, cluster.Name, expectations.HeadGroup))
+	assert.True(t, r.rayClusterScaleExpectation.IsSatisfied(ctx, cluster.Namespace, cluster.Name, cluster.Spec.WorkerGroupSpecs[0].GroupName))
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Nil(t, staleClient.List(ctx, &podList, client.InNamespace(namespaceStr)))
+	assert.Len(t, podList.Items, numPods)
+}
+
+func TestReconcilePods_NamespaceQuota(t *testing.T) {
+	setupTest(t)
+	features.SetFeatureGateDuringTest(t, features.RayNamespaceQuota, true)
+
+	// The RayCluster requests 1 CPU for its head Pod and 1 CPU and 1 GPU for each worker Pod.
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = nil
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Resources.Requests = corev1.ResourceList{
+		corev1.ResourceCPU: resource.MustParse("1"),
+	}
+	cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[0].Resources.Requests = corev1.ResourceList{
+		corev1.ResourceCPU: resource.MustParse("1"),
+		"nvidia.com/gpu":   resource.MustParse("1"),
+	}
+	numPods := 1 + int(*cluster.Spec.WorkerGroupSpecs[0].Replicas)
+	// The Pods of the other RayClusters in the namespace request 2 CPUs.
+	otherPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other-head",
+			Namespace: namespaceStr,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey: "other",
+				utils.RayNodeLabelKey:    "yes",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "ray-head",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				},
+			}},
+		},
+	}
+	quota := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ray-quota",
+			Namespace: namespaceStr,
+			Labels:    map[string]string{utils.RayQuotaLabelKey: "true"},
+		},
+		Data: map[string]string{
+			utils.RayQuotaCPUKey: strconv.Itoa(numPods + 1),
+			utils.RayQuotaGPUKey: strconv.Itoa(numPods - 1),
+		},
+	}
+	ctx := context.Background()
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(otherPod, quota).WithInterceptorFuncs(serverSideApplyFuncs).Build()
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
+	}
+	listPods := func() []corev1.Pod {
+		podList := corev1.PodList{}
+		assert.Nil(t, fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr), client.MatchingLabels{utils.RayClusterLabelKey: cluster.Name}))
+		return podList.Items
+	}
+
+	// The RayCluster and the other RayClusters would request numPods+2 CPUs, which exceeds the quota.
+	_, err := r.reconcilePods(ctx, cluster)
+	assert.ErrorIs(t, err, utils.ErrNamespaceQuotaExceeded)
+	assert.Contains(t, err.Error(), fmt.Sprintf("cpu: requested %d", numPods+2))
+	assert.Empty(t, listPods())
+
+	// The Pods are created once the quota allows them.
+	quota.Data[utils.RayQuotaCPUKey] = strconv.Itoa(numPods + 2)
+	assert.Nil(t, fakeClient.Update(ctx, quota))
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Len(t, listPods(), numPods)
+
+	// The quota is only checked before Pods are created, so a lower quota doesn't affect the existing Pods.
+	lowerQuota := quota.DeepCopy()
+	lowerQuota.Name = "lower-ray-quota"
+	lowerQuota.ResourceVersion = ""
+	lowerQuota.Data = map[string]string{utils.RayQuotaGPUKey: strconv.Itoa(numPods - 2)}
+	assert.Nil(t, fakeClient.Create(ctx, lowerQuota))
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Len(t, listPods(), numPods)
+
+	// The lowest limit of the ConfigMaps applies, and the GPUs of all the GPU resources are counted.
+	assert.Nil(t, fakeClient.DeleteAllOf(ctx, &corev1.Pod{}, client.InNamespace(namespaceStr), client.MatchingLabels{utils.RayClusterLabelKey: cluster.Name}))
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.ErrorIs(t, err, utils.ErrNamespaceQuotaExceeded)
+	assert.Contains(t, err.Error(), fmt.Sprintf("gpu: requested %d, limited to %d", numPods-1, numPods-2))
+
+	// Invalid quotas are reported.
+	lowerQuota.Data[utils.RayQuotaMemoryKey] = "a lot"
+	assert.Nil(t, fakeClient.Update(ctx, lowerQuota))
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, utils.ErrNamespaceQuotaExceeded)
+}
+
+func TestApplyObject(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(newScheme)
+	var patchType types.PatchType
+	var patchOptions client.PatchOptions
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			patchType = patch.Type()
+			patchOptions.ApplyOptions(opts)
+			return serverSideApplyFuncs.Patch(ctx, c, obj, patch, opts...)
+		},
+	}).Build()
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default", ResourceVersion: "1"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "port", Port: 80}}},
+	}
+	assert.Nil(t, applyObject(context.Background(), fakeClient, svc))
+
+	// The object is applied with the field manager of the KubeRay operator, which forces conflicts.
+	assert.Equal(t, types.ApplyPatchType, patchType)
+	assert.Equal(t, utils.FieldManager, patchOptions.FieldManager)
+	assert.True(t, *patchOptions.Force)
+	assert.Equal(t, corev1.SchemeGroupVersion.WithKind("Service"), svc.GetObjectKind().GroupVersionKind())
+	assert.Nil(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(svc), &corev1.Service{}))
+}
+
+func TestApplyObject_Ownership(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	cluster.UID = "cluster-uid"
+	foreignSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "foreign", Namespace: namespaceStr},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "user", Port: 8080}}},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(foreignSvc).WithInterceptorFuncs(serverSideApplyFuncs).Build()
+
+	// An object that the RayCluster does not control is not taken over.
+	desired := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "foreign", Namespace: namespaceStr},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "dashboard", Port: 8265}}},
+	}
+	assert.Nil(t, controllerutil.SetControllerReference(cluster, desired, scheme.Scheme))
+	err := applyObject(ctx, fakeClient, desired)
+	assert.True(t, k8serrors.IsAlreadyExists(err), "unexpected error %v", err)
+	svc := &corev1.Service{}
+	assert.Nil(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(foreignSvc), svc))
+	assert.Empty(t, svc.OwnerReferences)
+	assert.Equal(t, "user", svc.Spec.Ports[0].Name)
+
+	// The fields that another field manager sets on an object that the RayCluster controls are kept, and the fields
+	// that the KubeRay operator no longer sets are removed.
+	desired = &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "owned", Namespace: namespaceStr, Labels: map[string]string{"ray.io/key": "value"}},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "dashboard", Port: 8265}}},
+	}
+	assert.Nil(t, controllerutil.SetControllerReference(cluster, desired, scheme.Scheme))
+	assert.Nil(t, applyObject(ctx, fakeClient, desired.DeepCopy()))
+	annotation := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "owned", Namespace: namespaceStr, Annotations: map[string]string{"gitops": "true"}}}
+	assert.Nil(t, fakeClient.Patch(ctx, annotation, client.Apply, client.FieldOwner("gitops")))
+
+	desired.Labels = nil
+	assert.Nil(t, applyObject(ctx, fakeClient, desired.DeepCopy()))
+	assert.Nil(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(desired), svc))
+	assert.Empty(t, svc.Labels)
+	assert.Equal(t, "true", svc.Annotations["gitops"])
+	assert.True(t, metav1.IsControlledBy(svc, cluster))
+}
+
+func TestReconcilePods_RayVolumes(t *testing.T) {
+	setupTest(t)
+
+	// The worker Pods spill objects to PersistentVolumeClaims that are kept until the RayCluster is deleted.
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = nil
+	cluster.Spec.HeadGroupSpec.LogVolume = &rayv1.RayVolume{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+	cluster.Spec.WorkerGroupSpecs[0].SpillVolume = &rayv1.RayVolume{
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("100Gi")},
+				},
+			},
+		},
+		CleanupPolicy: rayv1.DeleteWithClusterCleanupPolicy,
+	}
+	ctx := context.Background()
+	fakeClient := clientFake.NewClientBuilder().WithInterceptorFuncs(serverSideApplyFuncs).Build()
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
+	}
+	_, err := r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+
+	podList := corev1.PodList{}
+	assert.Nil(t, fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr)))
+	claimList := corev1.PersistentVolumeClaimList{}
+	assert.Nil(t, fakeClient.List(ctx, &claimList, client.InNamespace(namespaceStr)))
+	claims := make(map[string]corev1.PersistentVolumeClaim)
+	for _, claim := range claimList.Items {
+		claims[claim.Name] = claim
+	}
+	assert.Len(t, podList.Items, 1+int(*cluster.Spec.WorkerGroupSpecs[0].Replicas))
+	assert.Len(t, claims, int(*cluster.Spec.WorkerGroupSpecs[0].Replicas))
+	for _, pod := range podList.Items {
+		var spillVolume *corev1.Volume
+		for i := range pod.Spec.Volumes {
+			if pod.Spec.Volumes[i].Name == common.RaySpillVolumeName {
+				spillVolume = &pod.Spec.Volumes[i]
+			}
+		}
+		if pod.Labels[utils.RayNodeTypeLabelKey] == string(rayv1.HeadNode) {
+			assert.Nil(t, spillVolume)
+			continue
+		}
+		// Each worker Pod mounts the claim that is named after it and owned by the RayCluster.
+		if !assert.NotNil(t, spillVolume) {
+			continue
+		}
+		claimName := pod.Name + "-" + common.RaySpillVolumeName
+		assert.Equal(t, claimName, spillVolume.PersistentVolumeClaim.ClaimName)
+		assert.Contains(t, claims, claimName)
+		assert.Equal(t, cluster.Name, claims[claimName].Labels[utils.RayClusterLabelKey])
+		assert.True(t, metav1.IsControlledBy(ptr.To(claims[claimName]), cluster))
+	}
+
+	// The claims of a Pod that cannot be created are deleted.
+	fakeClient = clientFake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if _, ok := obj.(*corev1.Pod); ok {
+				return k8serrors.NewForbidden(corev1.Resource("pods"), obj.GetName(), fmt.Errorf("exceeded quota"))
+			}
+			return serverSideApplyFuncs.Patch(ctx, c, obj, patch, opts...)
+		},
+	}).Build()
+	r.Client = fakeClient
+	err = r.createWorkerPod(ctx, *cluster, cluster.Spec.WorkerGroupSpecs[0])
+	assert.True(t, k8serrors.IsForbidden(err))
+	claimList = corev1.PersistentVolumeClaimList{}
+	assert.Nil(t, fakeClient.List(ctx, &claimList, client.InNamespace(namespaceStr)))
+	assert.Empty(t, claimList.Items)
+}
+
+func TestUpdateHeadReachableConditions(t *testing.T) {
+	setupTest(t)
+
+	workerPod := func(name string, status corev1.ContainerStatus) *corev1.Pod {
+		status.Name = common.WaitGcsReadyContainerName
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespaceStr},
+			Status:     corev1.PodStatus{InitContainerStatuses: []corev1.ContainerStatus{status}},
+		}
+	}
+	terminated := func(exitCode int32, message string) corev1.ContainerState {
+		return corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode, Message: message}}
+	}
+	ctx := context.Background()
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(
+		// The init container gave up because the head service cannot be resolved, and is retried.
+		workerPod("unresolvable", corev1.ContainerStatus{
+			State:                corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			LastTerminationState: terminated(utils.WaitForHeadUnresolvableExitCode, "The head service cannot be resolved.\n"),
+		}),
+		workerPod("gcs-not-ready", corev1.ContainerStatus{State: terminated(utils.WaitForHeadGcsNotReadyExitCode, "The GCS server is not ready.")}),
+		workerPod("waiting", corev1.ContainerStatus{State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}),
+		workerPod("ready", corev1.ContainerStatus{State: terminated(0, "")}),
+	).Build()
+	recorder := record.NewFakeRecorder(100)
+	r := &RayClusterReconciler{Client: fakeClient, Recorder: recorder, Scheme: scheme.Scheme}
+
+	podList := corev1.PodList{}
+	headReachableConditions := func() map[string]corev1.PodCondition {
+		assert.Nil(t, fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr)))
+		conditions := make(map[string]corev1.PodCondition)
+		for _, pod := range podList.Items {
+			for _, condition := range pod.Status.Conditions {
+				if condition.Type == utils.RayHeadReachablePodConditionType {
+					conditions[pod.Name] = condition
+				}
+			}
+		}
+		return conditions
+	}
+	headReachableConditions()
+	assert.Nil(t, r.updateHeadReachableConditions(ctx, testRayCluster, podList.Items))
+	conditions := headReachableConditions()
+	// The Pods that are waiting for the head or never failed to reach it do not get the condition.
+	assert.Len(t, conditions, 2)
+	assert.Equal(t, corev1.ConditionFalse, conditions["unresolvable"].Status)
+	assert.Equal(t, utils.HeadServiceUnresolvableReason, conditions["unresolvable"].Reason)
+	assert.Equal(t, "The head service cannot be resolved.", conditions["unresolvable"].Message)
+	assert.Equal(t, corev1.ConditionFalse, conditions["gcs-not-ready"].Status)
+	assert.Equal(t, utils.GcsServerNotReadyReason, conditions["gcs-not-ready"].Reason)
+	assert.Len(t, recorder.Events, 2)
+
+	// The condition is not updated again, and becomes True once the init container succeeds.
+	for i := range podList.Items {
+		if podList.Items[i].Name == "gcs-not-ready" {
+			podList.Items[i].Status.InitContainerStatuses[0].State = terminated(0, "")
+		}
+	}
+	assert.Nil(t, r.updateHeadReachableConditions(ctx, testRayCluster, podList.Items))
+	conditions = headReachableConditions()
+	assert.Equal(t, corev1.ConditionFalse, conditions["unresolvable"].Status)
+	assert.Equal(t, corev1.ConditionTrue, conditions["gcs-not-ready"].Status)
+	assert.Equal(t, utils.GcsServerReadyReason, conditions["gcs-not-ready"].Reason)
+	assert.Len(t, recorder.Events, 2)
+}
+
+func TestReconcile_WorkerGroupRecreatePolicy(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = nil
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
+	expectedNumWorkerPods := int(*cluster.Spec.WorkerGroupSpecs[0].Replicas)
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(append(testPods, testServices[0])...).WithInterceptorFuncs(serverSideApplyFuncs).Build()
+	recorder := record.NewFakeRecorder(100)
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   recorder,
+		Scheme:                     scheme.Scheme,
+	}
+
+	podList := corev1.PodList{}
+	listWorkerPods := func() {
+		err := fakeClient.List(ctx, &podList, &client.ListOptions{LabelSelector: workerSelector, Namespace: namespaceStr})
+		assert.Nil(t, err, "Fail to get Pod list")
+	}
+	// setWorkerPodsRunning sets all worker Pods to running, except for one failed worker Pod if failOne is true.
+	setWorkerPodsRunning := func(failOne bool) {
+		listWorkerPods()
+		for i, pod := range podList.Items {
+			pod.Status.Phase = corev1.PodRunning
+			if failOne && i == 0 {
+				pod.Status.Phase = corev1.PodFailed
+			}
+			assert.Nil(t, fakeClient.Status().Update(ctx, &pod))
+		}
+	}
+	setWorkerPodsRunning(false)
+	_, err := r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	listWorkerPods()
+	assert.Equal(t, expectedNumWorkerPods, len(podList.Items))
+
+	// The recreate policy Never keeps the failed worker Pod.
+	setWorkerPodsRunning(true)
+	cluster.Spec.WorkerGroupSpecs[0].RecreatePolicy = &rayv1.WorkerGroupRecreatePolicy{Type: rayv1.NeverWorkerGroupRecreatePolicyType}
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	listWorkerPods()
+	assert.Equal(t, expectedNumWorkerPods, len(podList.Items))
+	assert.Nil(t, getWorkerGroupStatus(cluster, cluster.Spec.WorkerGroupSpecs[0].GroupName))
+
+	// The first failed worker Pod is recreated immediately.
+	cluster.Spec.WorkerGroupSpecs[0].RecreatePolicy = &rayv1.WorkerGroupRecreatePolicy{
+		Type:                  rayv1.AlwaysWorkerGroupRecreatePolicyType,
+		InitialBackoffSeconds: ptr.To[int32](10),
+		MaxBackoffSeconds:     ptr.To[int32](30),
+	}
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.NotNil(t, err)
+	listWorkerPods()
+	assert.Equal(t, expectedNumWorkerPods-1, len(podList.Items))
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	listWorkerPods()
+	assert.Equal(t, expectedNumWorkerPods, len(podList.Items))
+
+	status := getWorkerGroupStatus(cluster, cluster.Spec.WorkerGroupSpecs[0].GroupName)
+	if assert.NotNil(t, status) {
+		assert.Equal(t, int32(1), status.Recreations)
+		assert.NotNil(t, status.LastRecreationTime)
+	}
+
+	// The next failed worker Pod waits for the initial backoff, and the controller requeues the RayCluster after it.
+	setWorkerPodsRunning(true)
+	requeueAfter, err := r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.InDelta(t, 10*time.Second, requeueAfter, float64(time.Second))
+	listWorkerPods()
+	assert.Equal(t, expectedNumWorkerPods, len(podList.Items))
+
+	// The recreations are persisted in the status of the RayCluster.
+	newInstance, err := r.calculateStatus(ctx, cluster, nil)
+	assert.Nil(t, err)
+	status = getWorkerGroupStatus(newInstance, cluster.Spec.WorkerGroupSpecs[0].GroupName)
+	if assert.NotNil(t, status) {
+		assert.Equal(t, int32(1), status.Recreations)
+		assert.InDelta(t, 10*time.Second, recreateFailedWorkerPodsAfter(newInstance, cluster.Spec.WorkerGroupSpecs[0]), float64(time.Second))
+	}
+
+	// The failed worker Pod is recreated once the backoff has passed.
+	status = getWorkerGroupStatus(cluster, cluster.Spec.WorkerGroupSpecs[0].GroupName)
+	status.LastRecreationTime = ptr.To(metav1.NewTime(time.Now().Add(-time.Minute)))
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.NotNil(t, err)
+	listWorkerPods()
+	assert.Equal(t, expectedNumWorkerPods-1, len(podList.Items))
+
+	// The backoff doubles with every consecutive recreation up to the maximum backoff.
+	now := time.Now()
+	for count, expected := range map[int32]time.Duration{2: 20 * time.Second, 3: 30 * time.Second, 10: 30 * time.Second} {
+		status.Recreations, status.LastRecreationTime = count, ptr.To(metav1.NewTime(now))
+		assert.InDelta(t, expected, recreateFailedWorkerPodsAfter(cluster, cluster.Spec.WorkerGroupSpecs[0]), float64(time.Second))
+	}
+
+	// The recreations are forgotten once no worker Pod has failed for twice the maximum backoff.
+	status.Recreations, status.LastRecreationTime = 3, ptr.To(metav1.NewTime(now.Add(-30*time.Second)))
+	resetWorkerGroupRecreations(cluster, cluster.Spec.WorkerGroupSpecs[0])
+	assert.Equal(t, int32(3), status.Recreations)
+	status.LastRecreationTime = ptr.To(metav1.NewTime(now.Add(-2 * time.Minute)))
+	resetWorkerGroupRecreations(cluster, cluster.Spec.WorkerGroupSpecs[0])
+	assert.Equal(t, int32(0), status.Recreations)
+	assert.Nil(t, status.LastRecreationTime)
+
+	// The worker group is reported with the CrashLoop condition once its worker Pods keep failing.
+	features.SetFeatureGateDuringTest(t, features.RayClusterStatusConditions, true)
+	recorder = record.NewFakeRecorder(100)
+	r.Recorder = recorder
+	status.Recreations, status.LastRecreationTime = utils.WorkerGroupCrashLoopThreshold-1, ptr.To(metav1.NewTime(now))
+	r.recordWorkerPodsRecreation(cluster, cluster.Spec.WorkerGroupSpecs[0])
+	assert.Contains(t, <-recorder.Events, string(utils.WorkerGroupCrashLoop))
+	newInstance, err = r.calculateStatus(ctx, cluster, nil)
+	assert.Nil(t, err)
+	condition := meta.FindStatusCondition(newInstance.Status.Conditions, string(rayv1.WorkerGroupCrashLoop))
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, rayv1.WorkerPodsKeepFailing, condition.Reason)
+		assert.Contains(t, condition.Message, cluster.Spec.WorkerGroupSpecs[0].GroupName)
+	}
+
+	// The condition is removed once the recreations are forgotten.
+	status = getWorkerGroupStatus(newInstance, cluster.Spec.WorkerGroupSpecs[0].GroupName)
+	status.LastRecreationTime = ptr.To(metav1.NewTime(now.Add(-2 * time.Minute)))
+	resetWorkerGroupRecreations(newInstance, cluster.Spec.WorkerGroupSpecs[0])
+	newInstance, err = r.calculateStatus(ctx, newInstance, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, meta.FindStatusCondition(newInstance.Status.Conditions, string(rayv1.WorkerGroupCrashLoop)))
+}
+
+func TestReconcile_WorkerGroupSpotFallback(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = nil
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
+	cluster.Spec.WorkerGroupSpecs[0].SpotFallback = &rayv1.SpotFallbackOptions{
+		Spot:                rayv1.NodePlacement{NodeSelector: map[string]string{"cloud.google.com/gke-spot": "true"}},
+		OnDemand:            rayv1.NodePlacement{NodeSelector: map[string]string{"cloud.google.com/gke-provisioning": "standard"}},
+		PreemptionThreshold: ptr.To[int32](2),
+		CooldownSeconds:     ptr.To[int32](600),
+	}
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods[0], testServices[0]).WithInterceptorFuncs(serverSideApplyFuncs).Build()
+	recorder := record.NewFakeRecorder(100)
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   recorder,
+		Scheme:                     scheme.Scheme,
+	}
+
+	podList := corev1.PodList{}
+	listWorkerPods := func() {
+		err := fakeClient.List(ctx, &podList, &client.ListOptions{LabelSelector: workerSelector, Namespace: namespaceStr})
+		assert.Nil(t, err, "Fail to get Pod list")
+	}
+	// preemptWorkerPod sets all worker Pods to running, except for one worker Pod whose spot node is reclaimed.
+	preemptWorkerPod := func() {
+		listWorkerPods()
+		for i, pod := range podList.Items {
+			pod.Status.Phase = corev1.PodRunning
+			if i == 0 {
+				pod.Status.Phase = corev1.PodFailed
+				pod.Status.Reason = "Terminated"
+				pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue, Reason: "TerminationByKubelet"}}
+			}
+			assert.Nil(t, fakeClient.Status().Update(ctx, &pod))
+		}
+	}
+
+	// The worker Pods are scheduled on spot nodes.
+	_, err := r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	listWorkerPods()
+	assert.Equal(t, int(*worker.Replicas), len(podList.Items))
+	for _, pod := range podList.Items {
+		assert.Equal(t, utils.SpotCapacityType, pod.Labels[utils.RayNodeCapacityTypeLabelKey])
+		assert.Equal(t, "true", pod.Spec.NodeSelector["cloud.google.com/gke-spot"])
+	}
+
+	// The first preemption keeps the worker group on spot nodes.
+	preemptWorkerPod()
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.NotNil(t, err)
+	assert.False(t, isSpotFallbackActive(cluster, worker))
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	listWorkerPods()
+	assert.Equal(t, int(*worker.Replicas), len(podList.Items))
+
+	// The second preemption falls back to on-demand nodes, and the new worker Pod is scheduled on an on-demand node.
+	preemptWorkerPod()
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.NotNil(t, err)
+	assert.True(t, isSpotFallbackActive(cluster, worker))
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	assert.Contains(t, strings.Join(events, "\n"), string(utils.FellBackToOnDemandNodes))
+
+	// The fallback is persisted in the status of the RayCluster, so that a fresh reconciler, e.g. after the operator
+	// restarts, schedules the new worker Pod on an on-demand node.
+	newInstance, err := r.calculateStatus(ctx, cluster, nil)
+	assert.Nil(t, err)
+	status := getWorkerGroupStatus(newInstance, worker.GroupName)
+	if assert.NotNil(t, status) {
+		assert.NotNil(t, status.SpotFallbackTime)
+		assert.NotNil(t, status.LastPreemptionTime)
+		assert.Equal(t, int32(0), status.Preemptions)
+	}
+	cluster = newInstance
+	r = &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   recorder,
+		Scheme:                     scheme.Scheme,
+	}
+	assert.True(t, isSpotFallbackActive(cluster, worker))
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	listWorkerPods()
+	numOnDemandWorkerPods := 0
+	for _, pod := range podList.Items {
+		if pod.Labels[utils.RayNodeCapacityTypeLabelKey] == utils.OnDemandCapacityType {
+			numOnDemandWorkerPods++
+			assert.Equal(t, "standard", pod.Spec.NodeSelector["cloud.google.com/gke-provisioning"])
+			assert.NotContains(t, pod.Spec.NodeSelector, "cloud.google.com/gke-spot")
+		}
+	}
+	assert.Equal(t, 1, numOnDemandWorkerPods)
+
+	// The worker group returns to spot nodes after the cooldown.
+	recorder = record.NewFakeRecorder(100)
+	r.Recorder = recorder
+	status = getWorkerGroupStatus(cluster, worker.GroupName)
+	status.SpotFallbackTime = ptr.To(metav1.NewTime(time.Now().Add(-10 * time.Minute)))
+	r.reconcileSpotFallback(cluster, worker)
+	assert.False(t, isSpotFallbackActive(cluster, worker))
+	assert.Nil(t, status.SpotFallbackTime)
+	assert.Contains(t, <-recorder.Events, string(utils.ReturnedToSpotNodes))
+
+	// The preemptions are forgotten once the worker group has no spot fallback.
+	status.Preemptions = 1
+	cluster.Spec.WorkerGroupSpecs[0].SpotFallback = nil
+	r.reconcileSpotFallback(cluster, cluster.Spec.WorkerGroupSpecs[0])
+	assert.Equal(t, int32(0), status.Preemptions)
+	assert.Nil(t, status.LastPreemptionTime)
+}
+
+func TestIsPodPreempted(t *testing.T) {
+	pod := corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Evicted"}}
+	assert.False(t, isPodPreempted(pod))
+	pod.Status.Reason = "NodeShutdown"
+	assert.True(t, isPodPreempted(pod))
+	pod.Status.Reason = ""
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue, Reason: "DeletionByPodGC"}}
+	assert.True(t, isPodPreempted(pod))
+}
+
+func TestReconcile_WorkerGroupUpscalingMode(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = nil
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
+	cluster.Spec.WorkerGroupSpecs[0].UpscalingMode = ptr.To(rayv1.ConservativeUpscalingMode)
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).WithInterceptorFuncs(serverSideApplyFuncs).Build()
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		Recorder:                   record.NewFakeRecorder(100),
+		Scheme:                     scheme.Scheme,
+	}
+
+	podList := corev1.PodList{}
+	listWorkerPods := func() {
+		err := fakeClient.List(ctx, &podList, &client.ListOptions{LabelSelector: workerSelector, Namespace: namespaceStr})
+		assert.Nil(t, err, "Fail to get Pod list")
+	}
+	listWorkerPods()
+	numRunningPods := len(podList.Items)
+	for _, pod := range podList.Items {
+		pod.Status.Phase = corev1.PodRunning
+		assert.Nil(t, fakeClient.Status().Update(ctx, &pod))
+	}
+
+	// The group creates at most as many pending worker Pods as it has running ones.
+	cluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To(int32(numRunningPods * 3))
+	cluster.Spec.WorkerGroupSpecs[0].MaxReplicas = ptr.To(int32(numRunningPods * 3))
+	_, err := r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	listWorkerPods()
+	assert.Len(t, podList.Items, numRunningPods*2)
+
+	// The group does not upscale further until the pending worker Pods run.
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	listWorkerPods()
+	assert.Len(t, podList.Items, numRunningPods*2)
+
+	// Without the Conservative upscaling mode, all the missing worker Pods are created.
+	cluster.Spec.WorkerGroupSpecs[0].UpscalingMode = nil
+	_, err = r.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	listWorkerPods()
+	assert.Len(t, podList.Items, numRunningPods*3)
+}
+
+func TestGetNumWorkerPodsToCreate(t *testing.T) {
+	running := corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodRunning}}
+	pending := corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodPending}}
+	worker := rayv1.WorkerGroupSpec{UpscalingMode: ptr.To(rayv1.ConservativeUpscalingMode)}
+
+	assert.Equal(t, 1, getNumWorkerPodsToCreate(worker, nil, 5))
+	assert.Equal(t, 0, getNumWorkerPodsToCreate(worker, []corev1.Pod{pending}, 5))
+	assert.Equal(t, 2, getNumWorkerPodsToCreate(worker, []corev1.Pod{running, running, running, pending}, 5))
+	assert.Equal(t, 1, getNumWorkerPodsToCreate(worker, []corev1.Pod{running, running, running}, 1))
+	assert.Equal(t, 0, getNumWorkerPodsToCreate(worker, []corev1.Pod{running, pending, pending}, 5))
+	// Only the running worker Pods count, not the ones that have terminated.
+	succeeded := corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodSucceeded}}
+	assert.Equal(t, 0, getNumWorkerPodsToCreate(worker, []corev1.Pod{succeeded, succeeded, pending}, 5))
+	assert.Equal(t, 1, getNumWorkerPodsToCreate(worker, []corev1.Pod{succeeded, succeeded, running}, 5))
+
+	worker.UpscalingMode = ptr.To(rayv1.UpscalingMode("Default"))
+	assert.Equal(t, 5, getNumWorkerPodsToCreate(worker, []corev1.Pod{pending}, 5))
+}
+
+func TestGetRayClusterReadyCondition(t *testing.T) {
+	tests := []struct {
+		cluster        *rayv1.RayCluster
+		name           string
+		expectedReason string
+		expectedStatus metav1.ConditionStatus
+		headPodReady   bool
+	}{
+		{
+			name: "All Ray Pods are ready",
+			cluster: &rayv1.RayCluster{Status: rayv1.RayClusterStatus{
+				DesiredWorkerReplicas: 2,
+				ReadyWorkerReplicas:   2,
+			}},
+			headPodReady:   true,
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: rayv1.AllPodsRunningAndReady,
+		},
+		{
+			name: "Worker Pods are not ready",
+			cluster: &rayv1.RayCluster{Status: rayv1.RayClusterStatus{
+				DesiredWorkerReplicas: 2,
+				ReadyWorkerReplicas:   1,
+			}},
+			headPodReady:   true,
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: rayv1.RayClusterPodsProvisioning,
+		},
+		{
+			name:           "Head Pod is not ready",
+			cluster:        &rayv1.RayCluster{},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: rayv1.RayClusterPodsProvisioning,
+		},
+		{
+			name:           "RayCluster is suspended",
+			cluster:        &rayv1.RayCluster{Spec: rayv1.RayClusterSpec{Suspend: ptr.To(true)}},
+			headPodReady:   true,
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: string(rayv1.RayClusterSuspended),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			condition := getRayClusterReadyCondition(tc.cluster, tc.headPodReady)
+			assert.Equal(t, string(rayv1.RayClusterReady), condition.Type)
+			assert.Equal(t, tc.expectedStatus, condition.Status)
+			assert.Equal(t, tc.expectedReason, condition.Reason)
+		})
+	}
+}
+
+func TestGetAutoscalerReadyCondition(t *testing.T) {
+	tests := []struct {
+		name           string
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+		status         corev1.PodStatus
+	}{
+		{
+			name:           "no autoscaler container",
+			status:         corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "ray-head"}}},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: rayv1.AutoscalerContainerNotFound,
+		},
+		{
+			name: "running autoscaler container",
+			status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				{Name: common.AutoscalerContainerName, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			}},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: rayv1.AutoscalerRunning,
+		},
+		{
+			name: "crash looping native sidecar autoscaler container",
+			status: corev1.PodStatus{InitContainerStatuses: []corev1.ContainerStatus{
+				{Name: common.AutoscalerContainerName, RestartCount: 5, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			}},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: "CrashLoopBackOff",
+		},
+		{
+			name: "terminated autoscaler container",
+			status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				{Name: common.AutoscalerContainerName, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}}},
+			}},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: "Error",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			condition := getAutoscalerReadyCondition(&corev1.Pod{Status: tc.status})
+			assert.Equal(t, string(rayv1.AutoscalerReady), condition.Type)
+			assert.Equal(t, tc.expectedStatus, condition.Status)
+			assert.Equal(t, tc.expectedReason, condition.Reason)
+		})
+	}
+}
+
+func TestBuildHeadPod_HeadPlacement(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	r := &RayClusterReconciler{Scheme: scheme.Scheme}
+
+	// The head placement is disabled by default.
+	pod, err := r.buildHeadPod(ctx, *cluster)
+	assert.Nil(t, err)
+	assert.Nil(t, pod.Spec.Affinity)
+
+	r.headPlacement = &configapi.HeadPlacementConfig{SpotNodeLabels: configapi.DefaultSpotNodeLabels()}
+	pod, err = r.buildHeadPod(ctx, *cluster)
+	assert.Nil(t, err)
+	if assert.NotNil(t, pod.Spec.Affinity) {
+		assert.Len(t, pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions, len(configapi.DefaultSpotNodeLabels()))
+		assert.Len(t, pod.Spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, 1)
+	}
+
+	// The affinity of the RayCluster is not modified.
+	assert.Nil(t, cluster.Spec.HeadGroupSpec.Template.Spec.Affinity)
+
+	// A RayCluster opts out with an annotation.
+	cluster.Annotations = map[string]string{utils.RayDisableHeadPlacementAnnotationKey: "true"}
+	pod, err = r.buildHeadPod(ctx, *cluster)
+	assert.Nil(t, err)
+	assert.Nil(t, pod.Spec.Affinity)
+}
+
+func TestBuildPod_SidecarContainers(t *testing.T) {
+	setupTest(t)
+	features.SetFeatureGateDuringTest(t, features.NativeSidecarContainers, true)
+
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.HeadGroupSpec.SidecarContainers = []corev1.Container{{Name: "head-proxy"}}
+	cluster.Spec.WorkerGroupSpecs[0].SidecarContainers = []corev1.Container{{Name: "worker-proxy"}, {Name: "worker-log-shipper"}}
+	r := &RayClusterReconciler{
+		Scheme:                  scheme.Scheme,
+		headSidecarContainers:   []corev1.Container{{Name: "fluentbit"}},
+		workerSidecarContainers: []corev1.Container{{Name: "fluentbit"}},
+	}
+	sidecarNames := func(pod corev1.Pod, err error) []string {
+		assert.Nil(t, err)
+		var names []string
+		for _, container := range pod.Spec.InitContainers {
+			if ptr.Deref(container.RestartPolicy, "") == corev1.ContainerRestartPolicyAlways {
+				names = append(names, container.Name)
+			}
+		}
+		return names
+	}
+
+	// The sidecar containers of the groups start after the autoscaler and the sidecar containers of the operator
+	// configuration.
+	assert.Equal(t, []string{"autoscaler", "fluentbit", "head-proxy"}, sidecarNames(r.buildHeadPod(ctx, *cluster)))
+	assert.Equal(t, []string{"fluentbit", "worker-proxy", "worker-log-shipper"}, sidecarNames(r.buildWorkerPod(ctx, *cluster, cluster.Spec.WorkerGroupSpecs[0])))
+	assert.Nil(t, cluster.Spec.WorkerGroupSpecs[0].SidecarContainers[0].RestartPolicy)
+}
+
+func TestBuildPod_PodDefaults(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cluster.Namespace, Labels: map[string]string{"team": "ml"}}}
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(namespace).Build()
+	r := &RayClusterReconciler{
+		Client: fakeClient,
+		Scheme: scheme.Scheme,
+		podDefaults: []configapi.PodDefaults{
+			{Env: []corev1.EnvVar{{Name: "HTTPS_PROXY", Value: "http://proxy:3128"}}},
+			{NamespaceSelector: "team=ml", Labels: map[string]string{"cost-center": "ml"}},
+			{NamespaceSelector: "team=web", ImagePullSecrets: []corev1.LocalObjectReference{{Name: "web-registry"}}},
+		},
+	}
+
+	// The Pod defaults without a namespace selector and the Pod defaults that select the namespace are injected.
+	headPod, err := r.buildHeadPod(ctx, *cluster)
+	assert.Nil(t, err)
+	workerPod, err := r.buildWorkerPod(ctx, *cluster, cluster.Spec.WorkerGroupSpecs[0])
+	assert.Nil(t, err)
+	for _, pod := range []corev1.Pod{headPod, workerPod} {
+		assert.Contains(t, pod.Spec.Containers[utils.RayContainerIndex].Env, corev1.EnvVar{Name: "HTTPS_PROXY", Value: "http://proxy:3128"})
+		assert.Equal(t, "ml", pod.Labels["cost-center"])
+		assert.Empty(t, pod.Spec.ImagePullSecrets)
+	}
+	// The Pod templates of the RayCluster are not modified.
+	assert.Equal(t, testRayCluster.Spec.HeadGroupSpec.Template, cluster.Spec.HeadGroupSpec.Template)
+	assert.Equal(t, testRayCluster.Spec.WorkerGroupSpecs[0].Template, cluster.Spec.WorkerGroupSpecs[0].Template)
+
+	// The Pods are not built without the Pod defaults if the namespace cannot be fetched.
+	r.Client = clientFake.NewClientBuilder().Build()
+	_, err = r.buildHeadPod(ctx, *cluster)
+	assert.ErrorContains(t, err, "failed to get namespace")
+	_, err = r.buildWorkerPod(ctx, *cluster, cluster.Spec.WorkerGroupSpecs[0])
+	assert.ErrorContains(t, err, "failed to get namespace")
+}
+
+func TestBuildPod_RayResourcesFromDeviceClaims(t *testing.T) {
+	setupTest(t)
+
+	ctx := context.Background()
+	cluster := testRayCluster.DeepCopy()
+	gpuRequests := resourcev1alpha3.DeviceClaim{Requests: []resourcev1alpha3.DeviceRequest{{Name: "gpus", DeviceClassName: "gpu.nvidia.com", Count: 2}}}
+	template := &resourcev1alpha3.ResourceClaimTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "two-gpus", Namespace: cluster.Namespace},
+		Spec:       resourcev1alpha3.ResourceClaimTemplateSpec{Spec: resourcev1alpha3.ResourceClaimSpec{Devices: gpuRequests}},
+	}
+	// The shared ResourceClaim allocated a single device of the request.
+	claim := &resourcev1alpha3.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared-gpu", Namespace: cluster.Namespace},
+		Spec:       resourcev1alpha3.ResourceClaimSpec{Devices: gpuRequests},
+		Status: resourcev1alpha3.ResourceClaimStatus{Allocation: &resourcev1alpha3.AllocationResult{
+			Devices: resourcev1alpha3.DeviceAllocationResult{Results: []resourcev1alpha3.DeviceRequestAllocationResult{{Request: "gpus", Driver: "gpu.nvidia.com", Pool: "node-1", Device: "gpu-0"}}},
+		}},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(template, claim).Build()
+	r := &RayClusterReconciler{Client: fakeClient, Scheme: scheme.Scheme}
+
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	worker.RayStartParams = map[string]string{}
+	worker.ResourceClaims = []rayv1.RayResourceClaim{{Name: "gpus", ResourceClaimTemplateName: ptr.To("two-gpus")}}
+	pod, err := r.buildWorkerPod(ctx, *cluster, worker)
+	assert.Nil(t, err)
+	assert.Contains(t, pod.Spec.Containers[utils.RayContainerIndex].Args[0], "--num-gpus=2")
+
+	cluster.Spec.HeadGroupSpec.RayStartParams = map[string]string{}
+	cluster.Spec.HeadGroupSpec.ResourceClaims = []rayv1.RayResourceClaim{{Name: "gpu", ResourceClaimName: ptr.To("shared-gpu")}}
+	pod, err = r.buildHeadPod(ctx, *cluster)
+	assert.Nil(t, err)
+	assert.Contains(t, pod.Spec.Containers[utils.RayContainerIndex].Args[0], "--num-gpus=1")
+	// The Ray resources derived from the claims are not stored in the RayCluster.
+	assert.Nil(t, cluster.Spec.HeadGroupSpec.ResourceClaims[0].RayResources)
+}
@@ -2,17 +2,23 @@ package ray
 
 import (
 	"context"
+	errstd "errors"
 	"fmt"
 	"os"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/robfig/cron/v3"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -33,6 +39,15 @@ const (
 	RayJobDefaultRequeueDuration    = 3 * time.Second
 	RayJobDefaultClusterSelectorKey = "ray.io/cluster"
 	PythonUnbufferedEnvVarName      = "PYTHONUNBUFFERED"
+
+	// The default numbers of completed and failed runs of a RayJob with a schedule to keep, like those of CronJobs.
+	DefaultSuccessfulJobsHistoryLimit int32 = 3
+	DefaultFailedJobsHistoryLimit     int32 = 1
+
+	// DefaultMaxRetryBackoffSeconds is the default maximum delay between two attempts of a RayJob.
+	DefaultMaxRetryBackoffSeconds int32 = 600
+	// MaxFailedAttempts is the number of the latest failed attempts recorded in the status of a RayJob.
+	MaxFailedAttempts = 10
 )
 
 // RayJobReconciler reconciles a RayJob object
@@ -42,16 +57,31 @@ type RayJobReconciler struct {
 	Recorder record.EventRecorder
 
 	dashboardClientFunc func() utils.RayDashboardClientInterface
+
+	// requeueInterval is how often the status of a RayJob in progress is polled. If it is 0,
+	// RayJobDefaultRequeueDuration is used.
+	requeueInterval time.Duration
+	// statusResyncInterval is how often a RayJob that waits only on changes that the reconciler watches is
+	// reconciled. If it is 0, such RayJobs are polled at the requeue interval.
+	statusResyncInterval time.Duration
+}
+
+type RayJobReconcilerOptions struct {
+	RequeueInterval      time.Duration
+	StatusResyncInterval time.Duration
 }
 
 // NewRayJobReconciler returns a new reconcile.Reconciler
-func NewRayJobReconciler(_ context.Context, mgr manager.Manager, provider utils.ClientProvider) *RayJobReconciler {
+func NewRayJobReconciler(_ context.Context, mgr manager.Manager, options RayJobReconcilerOptions, provider utils.ClientProvider) *RayJobReconciler {
 	dashboardClientFunc := provider.GetDashboardClient(mgr)
 	return &RayJobReconciler{
-		Client:              mgr.GetClient(),
+		Client:              utils.NewTracedClient(mgr.GetClient()),
 		Scheme:              mgr.GetScheme(),
 		Recorder:            mgr.GetEventRecorderFor("rayjob-controller"),
 		dashboardClientFunc: dashboardClientFunc,
+
+		requeueInterval:      options.RequeueInterval,
+		statusResyncInterval: options.StatusResyncInterval,
 	}
 }
 
@@ -65,9 +95,9 @@ func NewRayJobReconciler(_ context.Context, mgr manager.Manager, provider utils.
 // +kubebuilder:rbac:groups=core,resources=services/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core,resources=services/proxy,verbs=get;update;patch;create
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
-// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;delete
-// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;delete;update
-// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;delete;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;delete;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;delete;patch
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 
 // [WARNING]: There MUST be a newline after kubebuilder markers.
@@ -114,6 +144,11 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 			}
 		}
 
+		// The RayCluster is garbage collected with the RayJob, unless the deletion policy keeps it.
+		if err := r.keepRayClusterIfNeeded(ctx, rayJobInstance); err != nil {
+			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		}
+
 		logger.Info("Remove the finalizer no matter StopJob() succeeds or not.", "finalizer", utils.RayJobStopJobFinalizer)
 		controllerutil.RemoveFinalizer(rayJobInstance, utils.RayJobStopJobFinalizer)
 		err := r.Update(ctx, rayJobInstance)
@@ -138,6 +173,11 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
 	}
 
+	// A RayJob with a schedule creates RayJob runs instead of running itself.
+	if rayJobInstance.Spec.Schedule != "" {
+		return r.reconcileScheduledRayJob(ctx, rayJobInstance)
+	}
+
 	// Please do NOT modify `originalRayJobInstance` in the following code.
 	originalRayJobInstance := rayJobInstance.DeepCopy()
 
@@ -167,6 +207,12 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 			break
 		}
 
+		// Do not create the RayCluster of a RayJob before Kueue admits the RayJob.
+		if waiting, err := waitsForKueueAdmission(ctx, r.Client, kueueRayJob{rayJobInstance}); err != nil || waiting {
+			logger.Info("Wait for Kueue to admit the RayJob before creating its RayCluster.", "error", err)
+			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		}
+
 		var rayClusterInstance *rayv1.RayCluster
 		if rayClusterInstance, err = r.getOrCreateRayClusterInstance(ctx, rayJobInstance); err != nil {
 			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
@@ -176,7 +222,9 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		if clientURL := rayJobInstance.Status.DashboardURL; clientURL == "" {
 			if rayClusterInstance.Status.State != rayv1.Ready { //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
 				logger.Info("Wait for the RayCluster.Status.State to be ready before submitting the job.", "RayCluster", rayClusterInstance.Name, "State", rayClusterInstance.Status.State) //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
-				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+				// The status changes of the RayCluster trigger the reconciliation of the RayJob, unless the RayJob uses an
+				// existing RayCluster that it does not own.
+				return ctrl.Result{RequeueAfter: r.requeueAfter(rayJobInstance, len(rayJobInstance.Spec.ClusterSelector) == 0)}, err
 			}
 
 			if clientURL, err = utils.FetchHeadServiceURL(ctx, r.Client, rayClusterInstance, utils.DashboardPortName); err != nil || clientURL == "" {
@@ -204,7 +252,8 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 	case rayv1.JobDeploymentStatusWaiting:
 		// Try to get the Ray job id from rayJob.Spec.JobId
 		if rayJobInstance.Spec.JobId == "" {
-			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
+			// The update of the spec that sets the Ray job ID triggers the reconciliation of the RayJob.
+			return ctrl.Result{RequeueAfter: r.requeueAfter(rayJobInstance, true)}, nil
 		}
 
 		rayJobInstance.Status.JobId = rayJobInstance.Spec.JobId
@@ -249,16 +298,33 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		}
 
 		jobInfo, err := rayDashboardClient.GetJobInfo(ctx, rayJobInstance.Status.JobId)
-		if err != nil {
-			// If the Ray job was not found, GetJobInfo returns a BadRequest error.
-			if rayJobInstance.Spec.SubmissionMode == rayv1.HTTPMode && errors.IsBadRequest(err) {
-				logger.Info("The Ray job was not found. Submit a Ray job via an HTTP request.", "JobId", rayJobInstance.Status.JobId)
-				if _, err := rayDashboardClient.SubmitJob(ctx, rayJobInstance); err != nil {
-					logger.Error(err, "Failed to submit the Ray job", "JobId", rayJobInstance.Status.JobId)
-					return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		// If the Ray job was not found, GetJobInfo returns a BadRequest error.
+		if err != nil && rayJobInstance.Spec.SubmissionMode == rayv1.HTTPMode && errors.IsBadRequest(err) {
+			logger.Info("The Ray job was not found. Submit a Ray job via an HTTP request.", "JobId", rayJobInstance.Status.JobId)
+			if _, err = rayDashboardClient.SubmitJob(ctx, rayJobInstance); err == nil {
+				r.Recorder.Eventf(rayJobInstance, corev1.EventTypeNormal, string(utils.SubmittedRayJob), "Submitted the Ray job %s", rayJobInstance.Status.JobId)
+				return ctrl.Result{RequeueAfter: r.requeueAfter(rayJobInstance, false)}, nil
+			}
+			if !errstd.Is(err, utils.ErrRayJobAlreadySubmitted) {
+				r.Recorder.Eventf(rayJobInstance, corev1.EventTypeWarning, string(utils.FailedToSubmitRayJob), "Failed to submit the Ray job %s: %v", rayJobInstance.Status.JobId, err)
+				// The Ray dashboard rejected the submission, e.g. because of an invalid runtime environment. Resubmitting
+				// the same request fails again, so the RayJob fails instead. It can still be retried within its backoffLimit.
+				if errors.IsBadRequest(err) {
+					logger.Info("The Ray dashboard rejected the Ray job. Transition the status to `Failed`.", "JobId", rayJobInstance.Status.JobId, "error", err.Error())
+					rayJobInstance.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusFailed
+					rayJobInstance.Status.Reason = rayv1.SubmissionFailed
+					rayJobInstance.Status.Message = fmt.Sprintf("Failed to submit the Ray job. Error: %v", err)
+					break
 				}
-				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
+				logger.Error(err, "Failed to submit the Ray job", "JobId", rayJobInstance.Status.JobId)
+				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
 			}
+			// A previous submission of the Ray job succeeded, e.g. in a reconciliation whose status update failed, but
+			// the Ray dashboard had not recorded it yet. Get the info of the Ray job again instead of failing the RayJob.
+			logger.Info("The Ray job has already been submitted. Get its info again.", "JobId", rayJobInstance.Status.JobId)
+			jobInfo, err = rayDashboardClient.GetJobInfo(ctx, rayJobInstance.Status.JobId)
+		}
+		if err != nil {
 			logger.Error(err, "Failed to get job info", "JobId", rayJobInstance.Status.JobId)
 			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
 		}
@@ -300,9 +366,12 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		// TODO (kevin85421): Currently, Ray doesn't have a best practice to stop a Ray job gracefully. At this moment,
 		// KubeRay doesn't stop the Ray job before suspending the RayJob. If users want to stop the Ray job by SIGTERM,
 		// users need to set the Pod's preStop hook by themselves.
-		isClusterDeleted, err := r.deleteClusterResources(ctx, rayJobInstance)
-		if err != nil {
-			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		reuseCluster := rayJobInstance.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusRetrying && isRayClusterReusedOnRetry(rayJobInstance)
+		isClusterDeleted := true
+		if !reuseCluster {
+			if isClusterDeleted, err = r.deleteClusterResources(ctx, rayJobInstance); err != nil {
+				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+			}
 		}
 		isJobDeleted, err := r.deleteSubmitterJob(ctx, rayJobInstance)
 		if err != nil {
@@ -311,13 +380,23 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		if !isClusterDeleted || !isJobDeleted {
 			logger.Info("The release of the compute resources has not been completed yet. " +
 				"Wait for the resources to be deleted before the status transitions to avoid a resource leak.")
-			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
+			return ctrl.Result{RequeueAfter: r.requeueAfter(rayJobInstance, false)}, nil
+		}
+
+		// The compute resources of the failed attempt are released before waiting for the backoff.
+		if rayJobInstance.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusRetrying {
+			if retryTime := nextRetryTime(rayJobInstance); time.Now().Before(retryTime) {
+				logger.Info("Wait for the backoff before retrying the RayJob", "retryTime", retryTime)
+				return ctrl.Result{RequeueAfter: time.Until(retryTime)}, nil
+			}
 		}
 
-		// Reset the RayCluster and Ray job related status.
-		rayJobInstance.Status.RayClusterStatus = rayv1.RayClusterStatus{}
-		rayJobInstance.Status.RayClusterName = ""
-		rayJobInstance.Status.DashboardURL = ""
+		// Reset the RayCluster and Ray job related status. A retry that reuses the RayCluster keeps its status.
+		if !reuseCluster {
+			rayJobInstance.Status.RayClusterStatus = rayv1.RayClusterStatus{}
+			rayJobInstance.Status.RayClusterName = ""
+			rayJobInstance.Status.DashboardURL = ""
+		}
 		rayJobInstance.Status.JobId = ""
 		rayJobInstance.Status.Message = ""
 		rayJobInstance.Status.Reason = ""
@@ -325,6 +404,8 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		rayJobInstance.Status.JobStatus = rayv1.JobStatusNew
 
 		if rayJobInstance.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusSuspending {
+			// Like that of a Kubernetes Job, the ActiveDeadlineSeconds of a RayJob restarts when it is resumed.
+			rayJobInstance.Status.StartTime = nil
 			rayJobInstance.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusSuspended
 		}
 		if rayJobInstance.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusRetrying {
@@ -337,18 +418,19 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 			rayJobInstance.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusNew
 			break
 		}
-		// TODO (kevin85421): We may not need to requeue the RayJob if it has already been suspended.
-		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
+		// The update of the spec that resumes the RayJob triggers its reconciliation.
+		return ctrl.Result{RequeueAfter: r.requeueAfter(rayJobInstance, true)}, nil
 	case rayv1.JobDeploymentStatusComplete, rayv1.JobDeploymentStatusFailed:
 		// If this RayJob uses an existing RayCluster (i.e., ClusterSelector is set), we should not delete the RayCluster.
-		logger.Info(string(rayJobInstance.Status.JobDeploymentStatus), "RayJob", rayJobInstance.Name, "ShutdownAfterJobFinishes", rayJobInstance.Spec.ShutdownAfterJobFinishes, "ClusterSelector", rayJobInstance.Spec.ClusterSelector)
-		if rayJobInstance.Spec.ShutdownAfterJobFinishes && len(rayJobInstance.Spec.ClusterSelector) == 0 {
+		deletionPolicy := getDeletionPolicy(rayJobInstance)
+		logger.Info(string(rayJobInstance.Status.JobDeploymentStatus), "RayJob", rayJobInstance.Name, "DeletionPolicy", deletionPolicy, "ClusterSelector", rayJobInstance.Spec.ClusterSelector)
+		if deletionPolicy != rayv1.DeleteNoneDeletionPolicy {
 			ttlSeconds := rayJobInstance.Spec.TTLSecondsAfterFinished
 			nowTime := time.Now()
 			shutdownTime := rayJobInstance.Status.EndTime.Add(time.Duration(ttlSeconds) * time.Second)
 			logger.Info(
 				fmt.Sprintf("RayJob is %s", rayJobInstance.Status.JobDeploymentStatus),
-				"shutdownAfterJobFinishes", rayJobInstance.Spec.ShutdownAfterJobFinishes,
+				"deletionPolicy", deletionPolicy,
 				"ttlSecondsAfterFinished", ttlSeconds,
 				"Status.endTime", rayJobInstance.Status.EndTime,
 				"Now", nowTime,
@@ -358,10 +440,13 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 				logger.Info(fmt.Sprintf("shutdownTime not reached, requeue this RayJob for %d seconds", delta))
 				return ctrl.Result{RequeueAfter: time.Duration(delta) * time.Second}, nil
 			}
-			if s := os.Getenv(utils.DELETE_RAYJOB_CR_AFTER_JOB_FINISHES); strings.ToLower(s) == "true" {
+			switch deletionPolicy {
+			case rayv1.DeleteSelfDeletionPolicy:
 				err = r.Client.Delete(ctx, rayJobInstance)
 				logger.Info("RayJob is deleted")
-			} else {
+			case rayv1.DeleteWorkersDeletionPolicy:
+				err = r.deleteRayClusterWorkers(ctx, rayJobInstance)
+			default:
 				// We only need to delete the RayCluster. We don't need to delete the submitter Kubernetes Job so that users can still access
 				// the driver logs. In addition, a completed Kubernetes Job does not actually use any compute resources.
 				_, err = r.deleteClusterResources(ctx, rayJobInstance)
@@ -375,7 +460,7 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		return ctrl.Result{}, nil
 	default:
 		logger.Info("Unknown JobDeploymentStatus", "JobDeploymentStatus", rayJobInstance.Status.JobDeploymentStatus)
-		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
+		return ctrl.Result{RequeueAfter: r.requeueAfter(rayJobInstance, false)}, nil
 	}
 	checkBackoffLimitAndUpdateStatusIfNeeded(ctx, rayJobInstance)
 
@@ -385,7 +470,242 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		logger.Info("Failed to update RayJob status", "error", err)
 		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
 	}
-	return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
+	return ctrl.Result{RequeueAfter: r.requeueAfter(rayJobInstance, isWaitingForSubmitterJob(originalRayJobInstance, rayJobInstance))}, nil
+}
+
+// requeueAfter returns when to reconcile the RayJob again if nothing else triggers its reconciliation. The status of
+// the RayJob is polled at the requeue interval in its ray.io/requeue-interval annotation, or otherwise at the requeue
+// interval of the reconciler. If the RayJob waits only on changes that the reconciler watches, it is reconciled at the
+// status resync interval instead, if it is longer. The RayJob is always reconciled in time to enforce its
+// ActiveDeadlineSeconds and AttemptDeadlineSeconds.
+func (r *RayJobReconciler) requeueAfter(rayJob *rayv1.RayJob, watched bool) time.Duration {
+	requeueInterval := r.requeueInterval
+	if requeueInterval == 0 {
+		requeueInterval = RayJobDefaultRequeueDuration
+	}
+	requeueAfter := utils.GetRequeueInterval(rayJob, requeueInterval)
+	if watched && r.statusResyncInterval > requeueAfter {
+		requeueAfter = r.statusResyncInterval
+	}
+	for _, deadline := range []time.Time{activeDeadline(rayJob), attemptDeadline(rayJob)} {
+		if remaining := time.Until(deadline); !deadline.IsZero() && remaining > 0 && remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+	}
+	return requeueAfter
+}
+
+// isWaitingForSubmitterJob returns whether the Ray job of a running RayJob in the K8sJobMode was already running in the
+// last reconciliation. The submitter Kubernetes Job follows the logs of the Ray job until it finishes, so the completion
+// of the submitter Job, which the reconciler watches, signals the end of the Ray job and polling its status is not needed.
+func isWaitingForSubmitterJob(originalRayJob *rayv1.RayJob, rayJob *rayv1.RayJob) bool {
+	return rayJob.Spec.SubmissionMode == rayv1.K8sJobMode &&
+		rayJob.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusRunning &&
+		originalRayJob.Status.JobStatus == rayv1.JobStatusRunning &&
+		rayJob.Status.JobStatus == rayv1.JobStatusRunning
+}
+
+// reconcileScheduledRayJob reconciles a RayJob with a schedule. Like the CronJob controller, it creates a RayJob run
+// at each scheduled time according to the concurrency policy of the RayJob, and deletes the oldest finished runs
+// beyond the history limits. The RayJob owns its runs, so that their status changes trigger its reconciliation.
+func (r *RayJobReconciler) reconcileScheduledRayJob(ctx context.Context, rayJob *rayv1.RayJob) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	originalRayJob := rayJob.DeepCopy()
+	schedule, err := cron.ParseStandard(rayJob.Spec.Schedule)
+	if err != nil {
+		// The schedule has been validated by validateRayJobSpec.
+		return ctrl.Result{}, err
+	}
+
+	runs := rayv1.RayJobList{}
+	if err := r.List(ctx, &runs, client.InNamespace(rayJob.Namespace), client.MatchingLabels{
+		utils.RayOriginatedFromCRNameLabelKey: rayJob.Name,
+		utils.RayOriginatedFromCRDLabelKey:    utils.RayOriginatedFromCRDLabelValue(utils.RayJobCRD),
+	}); err != nil {
+		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+	}
+	var activeRuns, successfulRuns, failedRuns []*rayv1.RayJob
+	for i := range runs.Items {
+		run := &runs.Items[i]
+		if !metav1.IsControlledBy(run, rayJob) || !run.DeletionTimestamp.IsZero() {
+			continue
+		}
+		switch run.Status.JobDeploymentStatus {
+		case rayv1.JobDeploymentStatusComplete:
+			successfulRuns = append(successfulRuns, run)
+			if run.Status.JobStatus == rayv1.JobStatusSucceeded && run.Status.EndTime != nil &&
+				(rayJob.Status.LastSuccessfulTime == nil || rayJob.Status.LastSuccessfulTime.Before(run.Status.EndTime)) {
+				rayJob.Status.LastSuccessfulTime = run.Status.EndTime.DeepCopy()
+			}
+		case rayv1.JobDeploymentStatusFailed:
+			failedRuns = append(failedRuns, run)
+		default:
+			activeRuns = append(activeRuns, run)
+		}
+	}
+
+	if err := r.deleteRayJobRuns(ctx, rayJob, oldestRayJobRuns(successfulRuns, ptr.Deref(rayJob.Spec.SuccessfulJobsHistoryLimit, DefaultSuccessfulJobsHistoryLimit))); err != nil {
+		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+	}
+	if err := r.deleteRayJobRuns(ctx, rayJob, oldestRayJobRuns(failedRuns, ptr.Deref(rayJob.Spec.FailedJobsHistoryLimit, DefaultFailedJobsHistoryLimit))); err != nil {
+		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+	}
+
+	now := time.Now().UTC()
+	scheduledTime, tooManyMissed := mostRecentScheduleTime(rayJob, schedule, now)
+	if tooManyMissed {
+		logger.Info("More than the maximum number of runs of the RayJob were missed", "maxMissedSchedules", maxMissedSchedules, "scheduledTime", scheduledTime)
+		r.Recorder.Eventf(rayJob, corev1.EventTypeWarning, string(utils.TooManyMissedRayJobSchedules),
+			"More than %d runs were missed. Only the run scheduled at %s is considered. Check the clock skew or set startingDeadlineSeconds",
+			maxMissedSchedules, scheduledTime.Format(time.RFC3339))
+	}
+	switch {
+	case rayJob.Spec.Suspend:
+		logger.Info("The RayJob is suspended. Skip creating its runs.")
+	case scheduledTime.IsZero():
+		// No run is due.
+	case rayJob.Spec.StartingDeadlineSeconds != nil && scheduledTime.Add(time.Duration(*rayJob.Spec.StartingDeadlineSeconds)*time.Second).Before(now):
+		logger.Info("The run of the RayJob missed its starting deadline", "scheduledTime", scheduledTime)
+		r.Recorder.Eventf(rayJob, corev1.EventTypeWarning, string(utils.MissedRayJobSchedule),
+			"Missed the run scheduled at %s, which is more than %d seconds ago", scheduledTime.Format(time.RFC3339), *rayJob.Spec.StartingDeadlineSeconds)
+	case rayJob.Spec.ConcurrencyPolicy == rayv1.ForbidConcurrent && len(activeRuns) > 0:
+		logger.Info("The previous run of the RayJob is still active. Skip creating a new run.", "scheduledTime", scheduledTime, "activeRuns", len(activeRuns))
+	default:
+		if rayJob.Spec.ConcurrencyPolicy == rayv1.ReplaceConcurrent {
+			if err := r.deleteRayJobRuns(ctx, rayJob, activeRuns); err != nil {
+				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+			}
+			activeRuns = nil
+		}
+		run, err := r.constructRayJobRun(rayJob, scheduledTime)
+		if err != nil {
+			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		}
+		// The run already exists if the status update failed after its creation, in which case it has been listed.
+		if err := r.Create(ctx, run); err == nil {
+			r.Recorder.Eventf(rayJob, corev1.EventTypeNormal, string(utils.CreatedRayJobRun), "Created RayJob run %s/%s", run.Namespace, run.Name)
+			activeRuns = append(activeRuns, run)
+		} else if !errors.IsAlreadyExists(err) {
+			r.Recorder.Eventf(rayJob, corev1.EventTypeWarning, string(utils.FailedToCreateRayJobRun), "Failed to create RayJob run %s/%s: %v", run.Namespace, run.Name, err)
+			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		}
+		rayJob.Status.LastScheduleTime = &metav1.Time{Time: scheduledTime}
+	}
+
+	rayJob.Status.ActiveRuns = nil
+	for _, run := range activeRuns {
+		rayJob.Status.ActiveRuns = append(rayJob.Status.ActiveRuns, run.Name)
+	}
+	sort.Strings(rayJob.Status.ActiveRuns)
+	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusScheduled
+	rayJob.Status.ObservedGeneration = rayJob.Generation
+	setRayJobConditions(rayJob)
+	if !reflect.DeepEqual(originalRayJob.Status, rayJob.Status) {
+		if err := r.Status().Update(ctx, rayJob); err != nil {
+			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		}
+	}
+
+	nextScheduleTime := schedule.Next(now)
+	if rayJob.Spec.Suspend || nextScheduleTime.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: nextScheduleTime.Sub(now)}, nil
+}
+
+// maxMissedSchedules is the maximum number of scheduled times that mostRecentScheduleTime walks through at once, like
+// in the CronJob controller, so that a RayJob that has not been reconciled for a long time does not block its
+// reconciliation.
+const maxMissedSchedules = 100
+
+// mostRecentScheduleTime returns the latest scheduled time of a RayJob with a schedule up to now, after the scheduled
+// time of its latest run or after its creation if it has no run yet. It returns the zero time if no run is due. If
+// more than maxMissedSchedules runs were missed, it only walks through the latest scheduled times, which are within
+// the duration of the first maxMissedSchedules ones before now, and reports it.
+func mostRecentScheduleTime(rayJob *rayv1.RayJob, schedule cron.Schedule, now time.Time) (time.Time, bool) {
+	earliest := rayJob.CreationTimestamp.Time
+	if rayJob.Status.LastScheduleTime != nil {
+		earliest = rayJob.Status.LastScheduleTime.Time
+	}
+	first := schedule.Next(earliest.UTC())
+	mostRecent, ok := walkSchedule(schedule, first, now)
+	if ok {
+		return mostRecent, false
+	}
+	if latest, _ := walkSchedule(schedule, schedule.Next(now.Add(-mostRecent.Sub(first))), now); !latest.IsZero() {
+		mostRecent = latest
+	}
+	return mostRecent, true
+}
+
+// walkSchedule returns the latest scheduled time from start up to now, walking through at most maxMissedSchedules
+// scheduled times. It returns false if it stopped before now.
+func walkSchedule(schedule cron.Schedule, start time.Time, now time.Time) (time.Time, bool) {
+	var mostRecent time.Time
+	t := start
+	for missed := 0; !t.IsZero() && !t.After(now); missed++ {
+		if missed == maxMissedSchedules {
+			return mostRecent, false
+		}
+		mostRecent = t
+		t = schedule.Next(t)
+	}
+	return mostRecent, true
+}
+
+// oldestRayJobRuns returns the oldest runs beyond the history limit.
+func oldestRayJobRuns(runs []*rayv1.RayJob, historyLimit int32) []*rayv1.RayJob {
+	if len(runs) <= int(historyLimit) {
+		return nil
+	}
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].CreationTimestamp.Before(&runs[j].CreationTimestamp)
+	})
+	return runs[:len(runs)-int(historyLimit)]
+}
+
+func (r *RayJobReconciler) deleteRayJobRuns(ctx context.Context, rayJob *rayv1.RayJob, runs []*rayv1.RayJob) error {
+	for _, run := range runs {
+		if err := r.Delete(ctx, run, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			r.Recorder.Eventf(rayJob, corev1.EventTypeWarning, string(utils.FailedToDeleteRayJobRun), "Failed to delete RayJob run %s/%s: %v", run.Namespace, run.Name, err)
+			return err
+		}
+		r.Recorder.Eventf(rayJob, corev1.EventTypeNormal, string(utils.DeletedRayJobRun), "Deleted RayJob run %s/%s", run.Namespace, run.Name)
+	}
+	return nil
+}
+
+// constructRayJobRun returns the run of a RayJob with a schedule at the scheduled time. The run has the spec of the
+// RayJob without the schedule, and is named after the RayJob and the scheduled time in minutes since the epoch, like
+// the Jobs of CronJobs, so that a run is not created twice.
+func (r *RayJobReconciler) constructRayJobRun(rayJob *rayv1.RayJob, scheduledTime time.Time) (*rayv1.RayJob, error) {
+	labels := make(map[string]string, len(rayJob.Labels)+2)
+	for key, value := range rayJob.Labels {
+		labels[key] = value
+	}
+	labels[utils.RayOriginatedFromCRNameLabelKey] = rayJob.Name
+	labels[utils.RayOriginatedFromCRDLabelKey] = utils.RayOriginatedFromCRDLabelValue(utils.RayJobCRD)
+	run := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-%d", rayJob.Name, scheduledTime.Unix()/60),
+			Namespace:   rayJob.Namespace,
+			Labels:      labels,
+			Annotations: rayJob.Annotations,
+		},
+		Spec: *rayJob.Spec.DeepCopy(),
+	}
+	run.Spec.Schedule = ""
+	run.Spec.ConcurrencyPolicy = ""
+	run.Spec.StartingDeadlineSeconds = nil
+	run.Spec.SuccessfulJobsHistoryLimit = nil
+	run.Spec.FailedJobsHistoryLimit = nil
+	// The runs admitted by Kueue are created suspended until Kueue admits them.
+	run.Spec.Suspend = labels[utils.KueueQueueNameLabelKey] != ""
+
+	if err := ctrl.SetControllerReference(rayJob, run, r.Scheme); err != nil {
+		return nil, err
+	}
+	return run, nil
 }
 
 // checkBackoffLimitAndUpdateStatusIfNeeded determines if a RayJob is eligible for retry based on the configured backoff limit,
@@ -405,6 +725,7 @@ func checkBackoffLimitAndUpdateStatusIfNeeded(ctx context.Context, rayJob *rayv1
 
 	if rayJob.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusFailed {
 		failedCount++
+		recordFailedAttempt(rayJob, failedCount+succeededCount)
 	}
 
 	if rayJob.Status.JobStatus == rayv1.JobStatusSucceeded && rayJob.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusComplete {
@@ -430,6 +751,149 @@ func checkBackoffLimitAndUpdateStatusIfNeeded(ctx context.Context, rayJob *rayv1
 	}
 }
 
+// recordFailedAttempt records the attempt of a RayJob that has just failed in its status. Only the latest
+// MaxFailedAttempts attempts are kept.
+func recordFailedAttempt(rayJob *rayv1.RayJob, attempt int32) {
+	rayJob.Status.FailedAttempts = append(rayJob.Status.FailedAttempts, rayv1.RayJobAttemptStatus{
+		Attempt:        attempt,
+		StartTime:      rayJob.Status.AttemptStartTime.DeepCopy(),
+		EndTime:        &metav1.Time{Time: time.Now()},
+		RayClusterName: rayJob.Status.RayClusterName,
+		JobId:          rayJob.Status.JobId,
+		JobStatus:      rayJob.Status.JobStatus,
+		Reason:         rayJob.Status.Reason,
+		Message:        rayJob.Status.Message,
+	})
+	if len(rayJob.Status.FailedAttempts) > MaxFailedAttempts {
+		rayJob.Status.FailedAttempts = rayJob.Status.FailedAttempts[len(rayJob.Status.FailedAttempts)-MaxFailedAttempts:]
+	}
+}
+
+// isRayClusterReusedOnRetry returns whether the retries of a RayJob run on the RayCluster of the failed attempt. The
+// RayCluster of a RayJob with a ClusterSelector is not managed by the RayJob, so it is always reused.
+func isRayClusterReusedOnRetry(rayJob *rayv1.RayJob) bool {
+	if len(rayJob.Spec.ClusterSelector) != 0 {
+		return true
+	}
+	return rayJob.Spec.RetryPolicy != nil && rayJob.Spec.RetryPolicy.ClusterPolicy == rayv1.ReuseRetryClusterPolicy
+}
+
+// retryBackoff returns the delay before the retry of a RayJob after the given number of failed attempts. The delay
+// starts at InitialBackoffSeconds and doubles after each retry, up to MaxBackoffSeconds.
+func retryBackoff(rayJob *rayv1.RayJob, failed int32) time.Duration {
+	policy := rayJob.Spec.RetryPolicy
+	if policy == nil || policy.InitialBackoffSeconds == nil {
+		return 0
+	}
+	backoff := time.Duration(*policy.InitialBackoffSeconds) * time.Second
+	maxBackoff := time.Duration(ptr.Deref(policy.MaxBackoffSeconds, DefaultMaxRetryBackoffSeconds)) * time.Second
+	for i := int32(1); i < failed && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
+
+// nextRetryTime returns when a RayJob in the Retrying status can start its next attempt.
+func nextRetryTime(rayJob *rayv1.RayJob) time.Time {
+	if len(rayJob.Status.FailedAttempts) == 0 {
+		return time.Time{}
+	}
+	lastAttempt := rayJob.Status.FailedAttempts[len(rayJob.Status.FailedAttempts)-1]
+	if lastAttempt.EndTime == nil {
+		return time.Time{}
+	}
+	return lastAttempt.EndTime.Add(retryBackoff(rayJob, ptr.Deref(rayJob.Status.Failed, 0)))
+}
+
+// getDeletionPolicy returns the DeletionPolicy of a RayJob. If it is not set, it is derived from ShutdownAfterJobFinishes
+// and the DELETE_RAYJOB_CR_AFTER_JOB_FINISHES environment variable. The RayCluster of a RayJob with a ClusterSelector
+// is never deleted.
+func getDeletionPolicy(rayJob *rayv1.RayJob) rayv1.DeletionPolicy {
+	if rayJob.Spec.DeletionPolicy != "" {
+		return rayJob.Spec.DeletionPolicy
+	}
+	if !rayJob.Spec.ShutdownAfterJobFinishes || len(rayJob.Spec.ClusterSelector) != 0 {
+		return rayv1.DeleteNoneDeletionPolicy
+	}
+	if s := os.Getenv(utils.DELETE_RAYJOB_CR_AFTER_JOB_FINISHES); strings.ToLower(s) == "true" {
+		return rayv1.DeleteSelfDeletionPolicy
+	}
+	return rayv1.DeleteClusterDeletionPolicy
+}
+
+// isRayClusterDeletedAfterJobFinishes returns whether the RayCluster of a RayJob is deleted once the RayJob finishes.
+func isRayClusterDeletedAfterJobFinishes(rayJob *rayv1.RayJob) bool {
+	deletionPolicy := getDeletionPolicy(rayJob)
+	return deletionPolicy == rayv1.DeleteClusterDeletionPolicy || deletionPolicy == rayv1.DeleteSelfDeletionPolicy
+}
+
+// deleteRayClusterWorkers scales the worker groups of the RayCluster of a finished RayJob to zero. The head Pod is
+// kept, so that users can still access the Ray dashboard.
+func (r *RayJobReconciler) deleteRayClusterWorkers(ctx context.Context, rayJob *rayv1.RayJob) error {
+	logger := ctrl.LoggerFrom(ctx)
+	rayCluster := &rayv1.RayCluster{}
+	if err := r.Get(ctx, common.RayJobRayClusterNamespacedName(rayJob), rayCluster); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	updated := false
+	for i := range rayCluster.Spec.WorkerGroupSpecs {
+		workerGroup := &rayCluster.Spec.WorkerGroupSpecs[i]
+		if ptr.Deref(workerGroup.Replicas, 0) != 0 || ptr.Deref(workerGroup.MinReplicas, 0) != 0 {
+			workerGroup.Replicas = ptr.To[int32](0)
+			workerGroup.MinReplicas = ptr.To[int32](0)
+			updated = true
+		}
+	}
+	if !updated {
+		return nil
+	}
+	if err := r.Update(ctx, rayCluster); err != nil {
+		r.Recorder.Eventf(rayJob, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkers), "Failed to delete the workers of RayCluster %s/%s: %v", rayCluster.Namespace, rayCluster.Name, err)
+		return err
+	}
+	logger.Info("The worker groups of the RayCluster are scaled to zero", "RayCluster", rayCluster.Name)
+	r.Recorder.Eventf(rayJob, corev1.EventTypeNormal, string(utils.DeletedWorkers), "Deleted the workers of RayCluster %s/%s", rayCluster.Namespace, rayCluster.Name)
+	return nil
+}
+
+// keepRayClusterIfNeeded removes the owner reference of a RayJob that is being deleted from its RayCluster if the
+// DeletionPolicy of the RayJob is DeleteWorkers or DeleteNone, so that the RayCluster is not garbage collected with
+// the RayJob. With DeleteWorkers, the workers of the RayCluster are deleted.
+func (r *RayJobReconciler) keepRayClusterIfNeeded(ctx context.Context, rayJob *rayv1.RayJob) error {
+	logger := ctrl.LoggerFrom(ctx)
+	deletionPolicy := rayJob.Spec.DeletionPolicy
+	if (deletionPolicy != rayv1.DeleteWorkersDeletionPolicy && deletionPolicy != rayv1.DeleteNoneDeletionPolicy) ||
+		len(rayJob.Spec.ClusterSelector) != 0 || rayJob.Status.RayClusterName == "" {
+		return nil
+	}
+	if deletionPolicy == rayv1.DeleteWorkersDeletionPolicy {
+		if err := r.deleteRayClusterWorkers(ctx, rayJob); err != nil {
+			return err
+		}
+	}
+
+	rayCluster := &rayv1.RayCluster{}
+	if err := r.Get(ctx, common.RayJobRayClusterNamespacedName(rayJob), rayCluster); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	ownerReferences := make([]metav1.OwnerReference, 0, len(rayCluster.OwnerReferences))
+	for _, ownerReference := range rayCluster.OwnerReferences {
+		if ownerReference.UID != rayJob.UID {
+			ownerReferences = append(ownerReferences, ownerReference)
+		}
+	}
+	if len(ownerReferences) == len(rayCluster.OwnerReferences) {
+		return nil
+	}
+	rayCluster.OwnerReferences = ownerReferences
+	if err := r.Update(ctx, rayCluster); err != nil {
+		return err
+	}
+	logger.Info("The RayCluster is kept after the deletion of the RayJob", "RayCluster", rayCluster.Name, "DeletionPolicy", deletionPolicy)
+	return nil
+}
+
 // createK8sJobIfNeed creates a Kubernetes Job for the RayJob if it doesn't exist.
 func (r *RayJobReconciler) createK8sJobIfNeed(ctx context.Context, rayJobInstance *rayv1.RayJob, rayClusterInstance *rayv1.RayCluster) error {
 	logger := ctrl.LoggerFrom(ctx)
@@ -476,6 +940,11 @@ func (r *RayJobReconciler) getSubmitterTemplate(ctx context.Context, rayJobInsta
 		logger.Info("User-provided command is used", "command", submitterTemplate.Spec.Containers[utils.RayContainerIndex].Command)
 	}
 
+	// The proxy of the service mesh would keep the submitter Pod running after the submission finishes.
+	if rayClusterInstance != nil && rayClusterInstance.Spec.ServiceMesh != nil {
+		common.AddServiceMeshToSubmitterTemplate(&submitterTemplate, rayClusterInstance.Spec.ServiceMesh)
+	}
+
 	// Set PYTHONUNBUFFERED=1 for real-time logging
 	submitterTemplate.Spec.Containers[utils.RayContainerIndex].Env = append(submitterTemplate.Spec.Containers[utils.RayContainerIndex].Env, corev1.EnvVar{
 		Name:  PythonUnbufferedEnvVarName,
@@ -611,14 +1080,16 @@ func (r *RayJobReconciler) deleteClusterResources(ctx context.Context, rayJobIns
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *RayJobReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int) error {
-	return ctrl.NewControllerManagedBy(mgr).
+func (r *RayJobReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int, rateLimiter workqueue.TypedRateLimiter[reconcile.Request], scope utils.Scope) error {
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&rayv1.RayJob{}).
 		Owns(&rayv1.RayCluster{}).
+		Owns(&rayv1.RayJob{}).
 		Owns(&corev1.Service{}).
 		Owns(&batchv1.Job{}).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: reconcileConcurrency,
+			RateLimiter:             rateLimiter,
 			LogConstructor: func(request *reconcile.Request) logr.Logger {
 				logger := ctrl.Log.WithName("controllers").WithName("RayJob")
 				if request != nil {
@@ -626,13 +1097,13 @@ func (r *RayJobReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurren
 				}
 				return logger
 			},
-		}).
-		Complete(r)
+		})
+	return scope.Complete(b, mgr, &rayv1.RayJob{}, r)
 }
 
 // This function is the sole place where `JobDeploymentStatusInitializing` is defined. It initializes `Status.JobId` and `Status.RayClusterName`
 // prior to job submissions and RayCluster creations. This is used to avoid duplicate job submissions and cluster creations. In addition, this
-// function also sets `Status.StartTime` to support `ActiveDeadlineSeconds`.
+// function also sets `Status.StartTime` and `Status.AttemptStartTime` to support `ActiveDeadlineSeconds` and `AttemptDeadlineSeconds`.
 // This function will set or generate JobId if SubmissionMode is not InteractiveMode.
 func (r *RayJobReconciler) initRayJobStatusIfNeed(ctx context.Context, rayJob *rayv1.RayJob) error {
 	logger := ctrl.LoggerFrom(ctx)
@@ -670,7 +1141,12 @@ func (r *RayJobReconciler) initRayJobStatusIfNeed(ctx context.Context, rayJob *r
 		rayJob.Status.JobStatus = rayv1.JobStatusNew
 	}
 	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusInitializing
-	rayJob.Status.StartTime = &metav1.Time{Time: time.Now()}
+	now := metav1.Now()
+	// The retries of a RayJob keep its StartTime, so that ActiveDeadlineSeconds covers all of its attempts.
+	if rayJob.Status.StartTime == nil {
+		rayJob.Status.StartTime = &now
+	}
+	rayJob.Status.AttemptStartTime = &now
 	return nil
 }
 
@@ -681,13 +1157,18 @@ func (r *RayJobReconciler) updateRayJobStatus(ctx context.Context, oldRayJob *ra
 	logger.Info("updateRayJobStatus", "oldRayJobStatus", oldRayJobStatus, "newRayJobStatus", newRayJobStatus)
 	// If a status field is crucial for the RayJob state machine, it MUST be
 	// updated with a distinct JobStatus or JobDeploymentStatus value.
-	if oldRayJobStatus.JobStatus != newRayJobStatus.JobStatus ||
-		oldRayJobStatus.JobDeploymentStatus != newRayJobStatus.JobDeploymentStatus {
-
-		if newRayJobStatus.JobDeploymentStatus == rayv1.JobDeploymentStatusComplete || newRayJobStatus.JobDeploymentStatus == rayv1.JobDeploymentStatusFailed {
+	isStatusChanged := oldRayJobStatus.JobStatus != newRayJobStatus.JobStatus ||
+		oldRayJobStatus.JobDeploymentStatus != newRayJobStatus.JobDeploymentStatus
+	// The status is also updated when the RayJob spec changes, so that observedGeneration tells GitOps tools
+	// whether the status reflects the latest spec.
+	if isStatusChanged || oldRayJobStatus.ObservedGeneration != newRayJob.Generation {
+		if isStatusChanged && (newRayJobStatus.JobDeploymentStatus == rayv1.JobDeploymentStatusComplete || newRayJobStatus.JobDeploymentStatus == rayv1.JobDeploymentStatusFailed) {
 			newRayJob.Status.EndTime = &metav1.Time{Time: time.Now()}
 		}
 
+		newRayJob.Status.ObservedGeneration = newRayJob.Generation
+		setRayJobConditions(newRayJob)
+
 		logger.Info("updateRayJobStatus", "old JobStatus", oldRayJobStatus.JobStatus, "new JobStatus", newRayJobStatus.JobStatus,
 			"old JobDeploymentStatus", oldRayJobStatus.JobDeploymentStatus, "new JobDeploymentStatus", newRayJobStatus.JobDeploymentStatus)
 		if err := r.Status().Update(ctx, newRayJob); err != nil {
@@ -697,6 +1178,67 @@ func (r *RayJobReconciler) updateRayJobStatus(ctx context.Context, oldRayJob *ra
 	return nil
 }
 
+// setRayJobConditions sets the conditions of the RayJob from its JobDeploymentStatus and JobStatus. Like the
+// conditions of a Kubernetes Job, Complete and Failed are only present when they are true.
+func setRayJobConditions(rayJob *rayv1.RayJob) {
+	jobDeploymentStatus := rayJob.Status.JobDeploymentStatus
+	reason := string(jobDeploymentStatus)
+	if jobDeploymentStatus == rayv1.JobDeploymentStatusNew {
+		reason = rayv1.RayJobNew
+	}
+	meta.SetStatusCondition(&rayJob.Status.Conditions, metav1.Condition{
+		Type:   string(rayv1.RayJobReady),
+		Status: conditionStatus(jobDeploymentStatus == rayv1.JobDeploymentStatusRunning),
+		Reason: reason,
+	})
+	meta.SetStatusCondition(&rayJob.Status.Conditions, metav1.Condition{
+		Type:   string(rayv1.RayJobSuspended),
+		Status: conditionStatus(jobDeploymentStatus == rayv1.JobDeploymentStatusSuspended),
+		Reason: reason,
+	})
+
+	if jobDeploymentStatus == rayv1.JobDeploymentStatusComplete {
+		completeReason := rayv1.RayJobSucceeded
+		if rayJob.Status.JobStatus != rayv1.JobStatusSucceeded {
+			completeReason = rayv1.RayJobStopped
+		}
+		meta.SetStatusCondition(&rayJob.Status.Conditions, metav1.Condition{
+			Type:    string(rayv1.RayJobComplete),
+			Status:  metav1.ConditionTrue,
+			Reason:  completeReason,
+			Message: rayJob.Status.Message,
+		})
+	} else {
+		meta.RemoveStatusCondition(&rayJob.Status.Conditions, string(rayv1.RayJobComplete))
+	}
+
+	if jobDeploymentStatus == rayv1.JobDeploymentStatusFailed {
+		failedReason := string(rayJob.Status.Reason)
+		if failedReason == "" {
+			failedReason = string(rayv1.JobDeploymentStatusFailed)
+		}
+		meta.SetStatusCondition(&rayJob.Status.Conditions, metav1.Condition{
+			Type:    string(rayv1.RayJobFailed),
+			Status:  metav1.ConditionTrue,
+			Reason:  failedReason,
+			Message: rayJob.Status.Message,
+		})
+	} else {
+		meta.RemoveStatusCondition(&rayJob.Status.Conditions, string(rayv1.RayJobFailed))
+	}
+
+	for i := range rayJob.Status.Conditions {
+		rayJob.Status.Conditions[i].ObservedGeneration = rayJob.Generation
+	}
+}
+
+func conditionStatus(isTrue bool) metav1.ConditionStatus {
+	if isTrue {
+		return metav1.ConditionTrue
+	}
+	return metav1.ConditionFalse
+}
+
 func (r *RayJobReconciler) getOrCreateRayClusterInstance(ctx context.Context, rayJobInstance *rayv1.RayJob) (*rayv1.RayCluster, error) {
 	logger := ctrl.LoggerFrom(ctx)
 	rayClusterNamespacedName := common.RayJobRayClusterNamespacedName(rayJobInstance)
@@ -803,27 +1345,110 @@ func (r *RayJobReconciler) checkK8sJobAndUpdateStatusIfNeeded(ctx context.Contex
 
 func (r *RayJobReconciler) checkActiveDeadlineAndUpdateStatusIfNeeded(ctx context.Context, rayJob *rayv1.RayJob) bool {
 	logger := ctrl.LoggerFrom(ctx)
-	if rayJob.Spec.ActiveDeadlineSeconds == nil || time.Now().Before(rayJob.Status.StartTime.Add(time.Duration(*rayJob.Spec.ActiveDeadlineSeconds)*time.Second)) {
+	now := time.Now()
+	if deadline := activeDeadline(rayJob); !deadline.IsZero() && !now.Before(deadline) {
+		logger.Info("The RayJob has passed the activeDeadlineSeconds. Transition the status to `Failed`.", "StartTime", rayJob.Status.StartTime, "ActiveDeadlineSeconds", *rayJob.Spec.ActiveDeadlineSeconds)
+		rayJob.Status.Reason = rayv1.DeadlineExceeded
+		rayJob.Status.Message = fmt.Sprintf("The RayJob has passed the activeDeadlineSeconds. StartTime: %v. ActiveDeadlineSeconds: %d", rayJob.Status.StartTime, *rayJob.Spec.ActiveDeadlineSeconds)
+	} else if deadline := attemptDeadline(rayJob); !deadline.IsZero() && !now.Before(deadline) {
+		logger.Info("The attempt of the RayJob has passed the attemptDeadlineSeconds. Transition the status to `Failed`.", "AttemptStartTime", rayJob.Status.AttemptStartTime, "AttemptDeadlineSeconds", *rayJob.Spec.AttemptDeadlineSeconds)
+		rayJob.Status.Reason = rayv1.AttemptDeadlineExceeded
+		rayJob.Status.Message = fmt.Sprintf("The attempt of the RayJob has passed the attemptDeadlineSeconds. AttemptStartTime: %v. AttemptDeadlineSeconds: %d", rayJob.Status.AttemptStartTime, *rayJob.Spec.AttemptDeadlineSeconds)
+	} else {
 		return false
 	}
 
-	logger.Info("The RayJob has passed the activeDeadlineSeconds. Transition the status to `Failed`.", "StartTime", rayJob.Status.StartTime, "ActiveDeadlineSeconds", *rayJob.Spec.ActiveDeadlineSeconds)
+	// The RayCluster may outlive the RayJob, e.g. if it is reused or shutdownAfterJobFinishes is false, so the
+	// Ray job is stopped to release its resources.
+	r.stopRayJob(ctx, rayJob)
 	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusFailed
-	rayJob.Status.Reason = rayv1.DeadlineExceeded
-	rayJob.Status.Message = fmt.Sprintf("The RayJob has passed the activeDeadlineSeconds. StartTime: %v. ActiveDeadlineSeconds: %d", rayJob.Status.StartTime, *rayJob.Spec.ActiveDeadlineSeconds)
 	return true
 }
 
+// activeDeadline returns when the RayJob passes its ActiveDeadlineSeconds, or the zero time if it has no deadline.
+func activeDeadline(rayJob *rayv1.RayJob) time.Time {
+	if rayJob.Spec.ActiveDeadlineSeconds == nil || rayJob.Status.StartTime == nil {
+		return time.Time{}
+	}
+	return rayJob.Status.StartTime.Add(time.Duration(*rayJob.Spec.ActiveDeadlineSeconds) * time.Second)
+}
+
+// attemptDeadline returns when the current attempt of the RayJob passes its AttemptDeadlineSeconds, or the zero time
+// if it has no deadline.
+func attemptDeadline(rayJob *rayv1.RayJob) time.Time {
+	if rayJob.Spec.AttemptDeadlineSeconds == nil || rayJob.Status.AttemptStartTime == nil {
+		return time.Time{}
+	}
+	return rayJob.Status.AttemptStartTime.Add(time.Duration(*rayJob.Spec.AttemptDeadlineSeconds) * time.Second)
+}
+
+// stopRayJob stops the Ray job of the RayJob via the Ray dashboard. The Ray job has not been submitted yet if the
+// dashboard URL is unknown. Failures are reported with an event, and the RayCluster is still shut down according to
+// the RayJob spec.
+func (r *RayJobReconciler) stopRayJob(ctx context.Context, rayJob *rayv1.RayJob) {
+	logger := ctrl.LoggerFrom(ctx)
+	if rayJob.Status.JobId == "" || rayJob.Status.DashboardURL == "" || r.dashboardClientFunc == nil {
+		return
+	}
+
+	rayClusterInstance := &rayv1.RayCluster{}
+	if err := r.Get(ctx, common.RayJobRayClusterNamespacedName(rayJob), rayClusterInstance); err != nil {
+		logger.Error(err, "Failed to get the RayCluster to stop the Ray job", "JobId", rayJob.Status.JobId)
+		r.Recorder.Eventf(rayJob, corev1.EventTypeWarning, string(utils.FailedToStopRayJob), "Failed to stop Ray job %s: %v", rayJob.Status.JobId, err)
+		return
+	}
+	rayDashboardClient := r.dashboardClientFunc()
+	err := rayDashboardClient.InitClient(ctx, rayJob.Status.DashboardURL, rayClusterInstance)
+	if err == nil {
+		err = rayDashboardClient.StopJob(ctx, rayJob.Status.JobId)
+	}
+	if err != nil {
+		logger.Error(err, "Failed to stop the Ray job", "JobId", rayJob.Status.JobId)
+		r.Recorder.Eventf(rayJob, corev1.EventTypeWarning, string(utils.FailedToStopRayJob), "Failed to stop Ray job %s: %v", rayJob.Status.JobId, err)
+		return
+	}
+	r.Recorder.Eventf(rayJob, corev1.EventTypeNormal, string(utils.StoppedRayJob), "Stopped Ray job %s", rayJob.Status.JobId)
+}
+
 func validateRayJobSpec(rayJob *rayv1.RayJob) error {
 	// KubeRay has some limitations for the suspend operation. The limitations are a subset of the limitations of
 	// Kueue (https://kueue.sigs.k8s.io/docs/tasks/run_rayjobs/#c-limitations). For example, KubeRay allows users
-	// to suspend a RayJob with autoscaling enabled, but Kueue doesn't.
-	if rayJob.Spec.Suspend && !rayJob.Spec.ShutdownAfterJobFinishes {
-		return fmt.Errorf("a RayJob with shutdownAfterJobFinishes set to false is not allowed to be suspended")
+	// to suspend a RayJob with autoscaling enabled, but Kueue doesn't. Suspending a RayJob with a schedule only
+	// pauses the creation of its runs.
+	if rayJob.Spec.Suspend && !isRayClusterDeletedAfterJobFinishes(rayJob) && rayJob.Spec.Schedule == "" {
+		return fmt.Errorf("a RayJob with shutdownAfterJobFinishes set to false is not allowed to be suspended, unless its deletionPolicy is DeleteCluster or DeleteSelf")
 	}
-	if rayJob.Spec.Suspend && len(rayJob.Spec.ClusterSelector) != 0 {
+	if rayJob.Spec.Suspend && len(rayJob.Spec.ClusterSelector) != 0 && rayJob.Spec.Schedule == "" {
 		return fmt.Errorf("the ClusterSelector mode doesn't support the suspend operation")
 	}
+	if rayJob.Spec.Schedule != "" {
+		if _, err := cron.ParseStandard(rayJob.Spec.Schedule); err != nil {
+			return fmt.Errorf("invalid schedule: %w", err)
+		}
+		// The schedule is in UTC, like the rest of the times of the RayJob.
+		if strings.Contains(rayJob.Spec.Schedule, "TZ") {
+			return fmt.Errorf("invalid schedule: CRON_TZ and TZ are not supported")
+		}
+		// Each run submits its own Ray job, and no user is there to submit the Ray jobs of the runs.
+		if rayJob.Spec.JobId != "" {
+			return fmt.Errorf("jobId must not be set for a RayJob with a schedule")
+		}
+		if rayJob.Spec.SubmissionMode == rayv1.InteractiveMode {
+			return fmt.Errorf("a RayJob with a schedule does not support the InteractiveMode")
+		}
+	}
+	switch rayJob.Spec.ConcurrencyPolicy {
+	case "", rayv1.AllowConcurrent, rayv1.ForbidConcurrent, rayv1.ReplaceConcurrent:
+	default:
+		return fmt.Errorf("concurrencyPolicy must be one of Allow, Forbid and Replace, got %s", rayJob.Spec.ConcurrencyPolicy)
+	}
+	if rayJob.Spec.StartingDeadlineSeconds != nil && *rayJob.Spec.StartingDeadlineSeconds < 0 {
+		return fmt.Errorf("startingDeadlineSeconds must not be negative")
+	}
+	if (rayJob.Spec.SuccessfulJobsHistoryLimit != nil && *rayJob.Spec.SuccessfulJobsHistoryLimit < 0) ||
+		(rayJob.Spec.FailedJobsHistoryLimit != nil && *rayJob.Spec.FailedJobsHistoryLimit < 0) {
+		return fmt.Errorf("successfulJobsHistoryLimit and failedJobsHistoryLimit must not be negative")
+	}
 	if rayJob.Spec.RayClusterSpec == nil && len(rayJob.Spec.ClusterSelector) == 0 {
 		return fmt.Errorf("one of RayClusterSpec or ClusterSelector must be set")
 	}
@@ -835,9 +1460,45 @@ func validateRayJobSpec(rayJob *rayv1.RayJob) error {
 	if rayJob.Spec.ActiveDeadlineSeconds != nil && *rayJob.Spec.ActiveDeadlineSeconds <= 0 {
 		return fmt.Errorf("activeDeadlineSeconds must be a positive integer")
 	}
+	if rayJob.Spec.AttemptDeadlineSeconds != nil && *rayJob.Spec.AttemptDeadlineSeconds <= 0 {
+		return fmt.Errorf("attemptDeadlineSeconds must be a positive integer")
+	}
 	if rayJob.Spec.BackoffLimit != nil && *rayJob.Spec.BackoffLimit < 0 {
 		return fmt.Errorf("backoffLimit must be a positive integer")
 	}
+	switch rayJob.Spec.DeletionPolicy {
+	case "":
+	case rayv1.DeleteClusterDeletionPolicy, rayv1.DeleteWorkersDeletionPolicy, rayv1.DeleteSelfDeletionPolicy, rayv1.DeleteNoneDeletionPolicy:
+		if rayJob.Spec.ShutdownAfterJobFinishes {
+			return fmt.Errorf("shutdownAfterJobFinishes must not be set together with deletionPolicy")
+		}
+		// The RayCluster of a RayJob with a ClusterSelector is not managed by the RayJob.
+		if len(rayJob.Spec.ClusterSelector) != 0 && rayJob.Spec.DeletionPolicy != rayv1.DeleteSelfDeletionPolicy && rayJob.Spec.DeletionPolicy != rayv1.DeleteNoneDeletionPolicy {
+			return fmt.Errorf("the deletionPolicy of a RayJob with a ClusterSelector must be DeleteSelf or DeleteNone, got %s", rayJob.Spec.DeletionPolicy)
+		}
+		// The autoscaler would scale the worker groups up again.
+		if rayJob.Spec.DeletionPolicy == rayv1.DeleteWorkersDeletionPolicy && rayJob.Spec.RayClusterSpec != nil &&
+			ptr.Deref(rayJob.Spec.RayClusterSpec.EnableInTreeAutoscaling, false) {
+			return fmt.Errorf("the DeleteWorkers deletionPolicy does not support RayClusters with autoscaling enabled")
+		}
+	default:
+		return fmt.Errorf("deletionPolicy must be one of DeleteCluster, DeleteWorkers, DeleteSelf and DeleteNone, got %s", rayJob.Spec.DeletionPolicy)
+	}
+	if policy := rayJob.Spec.RetryPolicy; policy != nil {
+		switch policy.ClusterPolicy {
+		case "", rayv1.RecreateRetryClusterPolicy, rayv1.ReuseRetryClusterPolicy:
+		default:
+			return fmt.Errorf("retryPolicy.clusterPolicy must be one of Recreate and Reuse, got %s", policy.ClusterPolicy)
+		}
+		if (policy.InitialBackoffSeconds != nil && *policy.InitialBackoffSeconds < 0) ||
+			(policy.MaxBackoffSeconds != nil && *policy.MaxBackoffSeconds < 0) {
+			return fmt.Errorf("retryPolicy.initialBackoffSeconds and retryPolicy.maxBackoffSeconds must not be negative")
+		}
+		// The Ray job of each attempt needs a new submission ID on a reused RayCluster.
+		if policy.ClusterPolicy == rayv1.ReuseRetryClusterPolicy && rayJob.Spec.JobId != "" {
+			return fmt.Errorf("jobId must not be set when retryPolicy.clusterPolicy is Reuse")
+		}
+	}
 	return nil
 }
 
@@ -845,6 +1506,11 @@ func validateRayJobStatus(rayJob *rayv1.RayJob) error {
 	if rayJob.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusWaiting && rayJob.Spec.SubmissionMode != rayv1.InteractiveMode {
 		return fmt.Errorf("invalid RayJob State: JobDeploymentStatus cannot be `Waiting` when SubmissionMode is not InteractiveMode")
 	}
+	// The schedule of a RayJob cannot be added once the RayJob runs, or removed once it creates runs.
+	if (rayJob.Spec.Schedule != "") != (rayJob.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusScheduled) &&
+		rayJob.Status.JobDeploymentStatus != rayv1.JobDeploymentStatusNew {
+		return fmt.Errorf("invalid RayJob State: JobDeploymentStatus must be `Scheduled` if and only if the RayJob has a schedule")
+	}
 
 	return nil
 }
@@ -3,17 +3,24 @@ package ray
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/robfig/cron/v3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
@@ -194,6 +201,17 @@ func TestGetSubmitterTemplate(t *testing.T) {
 	envVar, found = utils.EnvVarByName(utils.RAY_JOB_SUBMISSION_ID, submitterTemplate.Spec.Containers[utils.RayContainerIndex].Env)
 	assert.True(t, found)
 	assert.Equal(t, "test-job-id", envVar.Value)
+
+	// Test 7: The submitter of a RayCluster with a service mesh terminates the proxy once it exits
+	rayClusterInstance.Spec.ServiceMesh = &rayv1.ServiceMeshOptions{Type: rayv1.IstioServiceMesh}
+	submitterTemplate, err = r.getSubmitterTemplate(ctx, rayJobInstanceWithoutTemplate, rayClusterInstance)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"/bin/bash", "-c",
+		"'ray' 'job' 'submit' '--address' 'http://test-url' '--submission-id' 'test-job-id' '--' 'echo' 'hello' 'world'; exit_code=$?; " +
+			"wget -q -O /dev/null --post-data '' http://localhost:15020/quitquitquit; exit $exit_code",
+	}, submitterTemplate.Spec.Containers[utils.RayContainerIndex].Command)
+	assert.Equal(t, `{"holdApplicationUntilProxyStarts":true}`, submitterTemplate.Annotations[utils.IstioProxyConfigAnnotationKey])
 }
 
 func TestUpdateStatusToSuspendingIfNeeded(t *testing.T) {
@@ -283,6 +301,7 @@ func TestUpdateRayJobStatus(t *testing.T) {
 
 	tests := map[string]struct {
 		isJobDeploymentStatusChanged bool
+		isGenerationChanged          bool
 	}{
 		"JobDeploymentStatus is not changed": {
 			isJobDeploymentStatusChanged: false,
@@ -290,6 +309,9 @@ func TestUpdateRayJobStatus(t *testing.T) {
 		"JobDeploymentStatus is changed": {
 			isJobDeploymentStatusChanged: true,
 		},
+		"Generation is changed": {
+			isGenerationChanged: true,
+		},
 	}
 
 	for name, tc := range tests {
@@ -312,6 +334,9 @@ func TestUpdateRayJobStatus(t *testing.T) {
 			if tc.isJobDeploymentStatusChanged {
 				newRayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusSuspending
 			}
+			if tc.isGenerationChanged {
+				newRayJob.Generation = 2
+			}
 
 			// Initialize a new RayClusterReconciler.
 			testRayJobReconciler := &RayJobReconciler{
@@ -325,7 +350,11 @@ func TestUpdateRayJobStatus(t *testing.T) {
 
 			err = fakeClient.Get(ctx, types.NamespacedName{Namespace: newRayJob.Namespace, Name: newRayJob.Name}, newRayJob)
 			assert.NoError(t, err)
-			assert.Equal(t, newRayJob.Status.Message == newMessage, tc.isJobDeploymentStatusChanged)
+			isStatusUpdated := tc.isJobDeploymentStatusChanged || tc.isGenerationChanged
+			assert.Equal(t, isStatusUpdated, newRayJob.Status.Message == newMessage)
+			if tc.isGenerationChanged {
+				assert.Equal(t, int64(2), newRayJob.Status.ObservedGeneration)
+			}
 		})
 	}
 }
@@ -374,6 +403,125 @@ func TestValidateRayJobSpec(t *testing.T) {
 		},
 	})
 	assert.Error(t, err, "The RayJob is invalid because the backoffLimit must be a positive integer.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			Schedule:       "0 * * * *",
+			Suspend:        true,
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.NoError(t, err, "The RayJob is valid because suspending a RayJob with a schedule only pauses its runs.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			Schedule:       "0 * * *",
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the schedule is not a valid cron expression.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			Schedule:       "CRON_TZ=Europe/Paris 0 * * * *",
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the schedule is in UTC.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			Schedule:       "0 * * * *",
+			JobId:          "my-job",
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the runs of a RayJob with a schedule cannot share a jobId.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			Schedule:          "0 * * * *",
+			ConcurrencyPolicy: "Queue",
+			RayClusterSpec:    &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the concurrencyPolicy is unknown.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			Schedule:                   "0 * * * *",
+			SuccessfulJobsHistoryLimit: ptr.To[int32](-1),
+			RayClusterSpec:             &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the successfulJobsHistoryLimit is negative.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RetryPolicy:    &rayv1.RayJobRetryPolicy{ClusterPolicy: "Keep"},
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the retryPolicy.clusterPolicy is unknown.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			JobId:          "my-job",
+			RetryPolicy:    &rayv1.RayJobRetryPolicy{ClusterPolicy: rayv1.ReuseRetryClusterPolicy},
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the attempts on a reused RayCluster cannot share a jobId.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			AttemptDeadlineSeconds: ptr.To[int32](0),
+			RayClusterSpec:         &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the attemptDeadlineSeconds must be a positive integer.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			Suspend:        true,
+			DeletionPolicy: rayv1.DeleteClusterDeletionPolicy,
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.NoError(t, err, "The RayJob is valid because its RayCluster is deleted once it finishes.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			DeletionPolicy: "DeleteHead",
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the deletionPolicy is unknown.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			ShutdownAfterJobFinishes: true,
+			DeletionPolicy:           rayv1.DeleteWorkersDeletionPolicy,
+			RayClusterSpec:           &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because shutdownAfterJobFinishes and deletionPolicy are both set.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			DeletionPolicy:  rayv1.DeleteClusterDeletionPolicy,
+			ClusterSelector: map[string]string{RayJobDefaultClusterSelectorKey: "shared"},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because it cannot delete a RayCluster that it does not manage.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			DeletionPolicy: rayv1.DeleteWorkersDeletionPolicy,
+			RayClusterSpec: &rayv1.RayClusterSpec{EnableInTreeAutoscaling: ptr.To(true)},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the autoscaler would scale the deleted workers up again.")
 }
 
 func TestFailedToCreateRayJobSubmitterEvent(t *testing.T) {
@@ -575,3 +723,748 @@ func TestFailedDeleteRayClusterEvent(t *testing.T) {
 
 	assert.Truef(t, foundFailureEvent, "Expected event to be generated for cluster deletion failure, got events: %s", strings.Join(events, "\n"))
 }
+
+func TestSetRayJobConditions(t *testing.T) {
+	rayJob := &rayv1.RayJob{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+
+	setRayJobConditions(rayJob)
+	readyCondition := meta.FindStatusCondition(rayJob.Status.Conditions, string(rayv1.RayJobReady))
+	assert.Equal(t, metav1.ConditionFalse, readyCondition.Status)
+	assert.Equal(t, rayv1.RayJobNew, readyCondition.Reason)
+	assert.Equal(t, int64(2), readyCondition.ObservedGeneration)
+	assert.True(t, meta.IsStatusConditionFalse(rayJob.Status.Conditions, string(rayv1.RayJobSuspended)))
+
+	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusRunning
+	setRayJobConditions(rayJob)
+	assert.True(t, meta.IsStatusConditionTrue(rayJob.Status.Conditions, string(rayv1.RayJobReady)))
+	assert.Nil(t, meta.FindStatusCondition(rayJob.Status.Conditions, string(rayv1.RayJobComplete)))
+	assert.Nil(t, meta.FindStatusCondition(rayJob.Status.Conditions, string(rayv1.RayJobFailed)))
+
+	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusFailed
+	rayJob.Status.Reason = rayv1.DeadlineExceeded
+	setRayJobConditions(rayJob)
+	assert.True(t, meta.IsStatusConditionFalse(rayJob.Status.Conditions, string(rayv1.RayJobReady)))
+	failedCondition := meta.FindStatusCondition(rayJob.Status.Conditions, string(rayv1.RayJobFailed))
+	assert.Equal(t, metav1.ConditionTrue, failedCondition.Status)
+	assert.Equal(t, string(rayv1.DeadlineExceeded), failedCondition.Reason)
+
+	// A retried RayJob is not failed anymore.
+	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusRetrying
+	rayJob.Status.Reason = ""
+	setRayJobConditions(rayJob)
+	assert.Nil(t, meta.FindStatusCondition(rayJob.Status.Conditions, string(rayv1.RayJobFailed)))
+
+	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusComplete
+	rayJob.Status.JobStatus = rayv1.JobStatusSucceeded
+	setRayJobConditions(rayJob)
+	completeCondition := meta.FindStatusCondition(rayJob.Status.Conditions, string(rayv1.RayJobComplete))
+	assert.Equal(t, metav1.ConditionTrue, completeCondition.Status)
+	assert.Equal(t, rayv1.RayJobSucceeded, completeCondition.Reason)
+
+	rayJob.Status.JobStatus = rayv1.JobStatusStopped
+	setRayJobConditions(rayJob)
+	assert.Equal(t, rayv1.RayJobStopped, meta.FindStatusCondition(rayJob.Status.Conditions, string(rayv1.RayJobComplete)).Reason)
+
+	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusSuspended
+	setRayJobConditions(rayJob)
+	assert.True(t, meta.IsStatusConditionTrue(rayJob.Status.Conditions, string(rayv1.RayJobSuspended)))
+	assert.Nil(t, meta.FindStatusCondition(rayJob.Status.Conditions, string(rayv1.RayJobComplete)))
+}
+
+func TestRayJobRequeueAfter(t *testing.T) {
+	rayJob := &rayv1.RayJob{}
+
+	r := &RayJobReconciler{}
+	assert.Equal(t, RayJobDefaultRequeueDuration, r.requeueAfter(rayJob, false))
+	assert.Equal(t, RayJobDefaultRequeueDuration, r.requeueAfter(rayJob, true))
+
+	r = &RayJobReconciler{requeueInterval: 10 * time.Second, statusResyncInterval: 5 * time.Minute}
+	assert.Equal(t, 10*time.Second, r.requeueAfter(rayJob, false))
+	assert.Equal(t, 5*time.Minute, r.requeueAfter(rayJob, true))
+
+	// The ray.io/requeue-interval annotation overrides the requeue interval of the reconciler.
+	rayJob.Annotations = map[string]string{utils.RayRequeueIntervalAnnotationKey: "20s"}
+	assert.Equal(t, 20*time.Second, r.requeueAfter(rayJob, false))
+
+	// The RayJob is reconciled in time to enforce its ActiveDeadlineSeconds.
+	rayJob.Spec.ActiveDeadlineSeconds = ptr.To[int32](60)
+	rayJob.Status.StartTime = &metav1.Time{Time: time.Now().Add(-55 * time.Second)}
+	requeueAfter := r.requeueAfter(rayJob, true)
+	assert.Greater(t, requeueAfter, time.Duration(0))
+	assert.LessOrEqual(t, requeueAfter, 5*time.Second)
+
+	// Once the deadline has passed, the RayJob is requeued at the requeue interval.
+	rayJob.Status.StartTime = &metav1.Time{Time: time.Now().Add(-2 * time.Minute)}
+	assert.Equal(t, 20*time.Second, r.requeueAfter(rayJob, false))
+
+	// The RayJob is also reconciled in time to enforce the AttemptDeadlineSeconds of its current attempt.
+	rayJob.Spec.AttemptDeadlineSeconds = ptr.To[int32](30)
+	rayJob.Status.AttemptStartTime = &metav1.Time{Time: time.Now().Add(-20 * time.Second)}
+	requeueAfter = r.requeueAfter(rayJob, false)
+	assert.Greater(t, requeueAfter, time.Duration(0))
+	assert.LessOrEqual(t, requeueAfter, 10*time.Second)
+}
+
+func TestIsWaitingForSubmitterJob(t *testing.T) {
+	newRayJob := func(submissionMode rayv1.JobSubmissionMode, jobStatus rayv1.JobStatus) *rayv1.RayJob {
+		return &rayv1.RayJob{
+			Spec: rayv1.RayJobSpec{SubmissionMode: submissionMode},
+			Status: rayv1.RayJobStatus{
+				JobDeploymentStatus: rayv1.JobDeploymentStatusRunning,
+				JobStatus:           jobStatus,
+			},
+		}
+	}
+
+	assert.True(t, isWaitingForSubmitterJob(newRayJob(rayv1.K8sJobMode, rayv1.JobStatusRunning), newRayJob(rayv1.K8sJobMode, rayv1.JobStatusRunning)))
+	// The status of the Ray job changed in this reconciliation.
+	assert.False(t, isWaitingForSubmitterJob(newRayJob(rayv1.K8sJobMode, rayv1.JobStatusPending), newRayJob(rayv1.K8sJobMode, rayv1.JobStatusRunning)))
+	assert.False(t, isWaitingForSubmitterJob(newRayJob(rayv1.K8sJobMode, rayv1.JobStatusRunning), newRayJob(rayv1.K8sJobMode, rayv1.JobStatusSucceeded)))
+	// Without a submitter Kubernetes Job, the status of the Ray job is polled.
+	assert.False(t, isWaitingForSubmitterJob(newRayJob(rayv1.HTTPMode, rayv1.JobStatusRunning), newRayJob(rayv1.HTTPMode, rayv1.JobStatusRunning)))
+}
+
+func TestReconcileScheduledRayJob(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+
+	now := time.Now().UTC()
+	newRayJob := func(concurrencyPolicy rayv1.ConcurrencyPolicy) *rayv1.RayJob {
+		return &rayv1.RayJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "scheduled",
+				Namespace:         "default",
+				UID:               "scheduled-uid",
+				Labels:            map[string]string{"team": "ml"},
+				CreationTimestamp: metav1.NewTime(now.Add(-2 * time.Minute)),
+			},
+			Spec: rayv1.RayJobSpec{
+				Schedule:          "* * * * *",
+				ConcurrencyPolicy: concurrencyPolicy,
+				Entrypoint:        "python train.py",
+				RayClusterSpec:    &rayv1.RayClusterSpec{},
+			},
+		}
+	}
+	newRun := func(rayJob *rayv1.RayJob, name string, age time.Duration, status rayv1.JobDeploymentStatus) *rayv1.RayJob {
+		run := &rayv1.RayJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: rayJob.Namespace,
+				Labels: map[string]string{
+					utils.RayOriginatedFromCRNameLabelKey: rayJob.Name,
+					utils.RayOriginatedFromCRDLabelKey:    utils.RayOriginatedFromCRDLabelValue(utils.RayJobCRD),
+				},
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Status: rayv1.RayJobStatus{JobDeploymentStatus: status},
+		}
+		_ = ctrl.SetControllerReference(rayJob, run, newScheme)
+		return run
+	}
+	setup := func(objects ...client.Object) (*RayJobReconciler, client.Client) {
+		fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithObjects(objects...).WithStatusSubresource(objects...).Build()
+		return &RayJobReconciler{Client: fakeClient, Recorder: record.NewFakeRecorder(100), Scheme: newScheme}, fakeClient
+	}
+	listRuns := func(fakeClient client.Client) []string {
+		runs := rayv1.RayJobList{}
+		_ = fakeClient.List(context.Background(), &runs, client.MatchingLabels{utils.RayOriginatedFromCRNameLabelKey: "scheduled"})
+		var names []string
+		for _, run := range runs.Items {
+			names = append(names, run.Name)
+		}
+		return names
+	}
+	// The runs are named after the RayJob and their scheduled time in minutes.
+	runName := func(rayJob *rayv1.RayJob) string {
+		return fmt.Sprintf("scheduled-%d", rayJob.Status.LastScheduleTime.Unix()/60)
+	}
+
+	t.Run("creates a run at the most recent scheduled time", func(t *testing.T) {
+		rayJob := newRayJob("")
+		r, fakeClient := setup(rayJob)
+		result, err := r.reconcileScheduledRayJob(context.Background(), rayJob)
+		require.NoError(t, err)
+		assert.Greater(t, result.RequeueAfter, time.Duration(0))
+		assert.LessOrEqual(t, result.RequeueAfter, time.Minute)
+		require.NotNil(t, rayJob.Status.LastScheduleTime)
+		assert.WithinDuration(t, now, rayJob.Status.LastScheduleTime.Time, time.Minute)
+		expectedRunName := runName(rayJob)
+		assert.Equal(t, []string{expectedRunName}, listRuns(fakeClient))
+
+		run := &rayv1.RayJob{}
+		require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: expectedRunName}, run))
+		assert.Empty(t, run.Spec.Schedule)
+		assert.Equal(t, "python train.py", run.Spec.Entrypoint)
+		assert.Equal(t, "ml", run.Labels["team"])
+		assert.True(t, metav1.IsControlledBy(run, rayJob))
+
+		assert.Equal(t, rayv1.JobDeploymentStatusScheduled, rayJob.Status.JobDeploymentStatus)
+		assert.Equal(t, []string{expectedRunName}, rayJob.Status.ActiveRuns)
+
+		// The run is not created twice.
+		_, err = r.reconcileScheduledRayJob(context.Background(), rayJob)
+		require.NoError(t, err)
+		assert.Equal(t, []string{expectedRunName}, listRuns(fakeClient))
+	})
+
+	t.Run("Forbid skips the run while a previous run is active", func(t *testing.T) {
+		rayJob := newRayJob(rayv1.ForbidConcurrent)
+		r, fakeClient := setup(rayJob, newRun(rayJob, "scheduled-active", time.Hour, rayv1.JobDeploymentStatusRunning))
+		_, err := r.reconcileScheduledRayJob(context.Background(), rayJob)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"scheduled-active"}, listRuns(fakeClient))
+		assert.Equal(t, []string{"scheduled-active"}, rayJob.Status.ActiveRuns)
+		assert.Nil(t, rayJob.Status.LastScheduleTime)
+	})
+
+	t.Run("Replace deletes the active runs", func(t *testing.T) {
+		rayJob := newRayJob(rayv1.ReplaceConcurrent)
+		r, fakeClient := setup(rayJob, newRun(rayJob, "scheduled-active", time.Hour, rayv1.JobDeploymentStatusRunning))
+		_, err := r.reconcileScheduledRayJob(context.Background(), rayJob)
+		require.NoError(t, err)
+		require.NotNil(t, rayJob.Status.LastScheduleTime)
+		assert.Equal(t, []string{runName(rayJob)}, listRuns(fakeClient))
+		assert.Equal(t, []string{runName(rayJob)}, rayJob.Status.ActiveRuns)
+	})
+
+	t.Run("deletes the oldest finished runs beyond the history limits", func(t *testing.T) {
+		rayJob := newRayJob("")
+		rayJob.Spec.Suspend = true
+		rayJob.Spec.SuccessfulJobsHistoryLimit = ptr.To[int32](1)
+		r, fakeClient := setup(rayJob,
+			newRun(rayJob, "scheduled-complete-1", 3*time.Hour, rayv1.JobDeploymentStatusComplete),
+			newRun(rayJob, "scheduled-complete-2", 2*time.Hour, rayv1.JobDeploymentStatusComplete),
+			newRun(rayJob, "scheduled-failed-1", 5*time.Hour, rayv1.JobDeploymentStatusFailed),
+			newRun(rayJob, "scheduled-failed-2", 4*time.Hour, rayv1.JobDeploymentStatusFailed),
+		)
+		result, err := r.reconcileScheduledRayJob(context.Background(), rayJob)
+		require.NoError(t, err)
+		// A suspended RayJob creates no run and is not requeued.
+		assert.Equal(t, time.Duration(0), result.RequeueAfter)
+		assert.ElementsMatch(t, []string{"scheduled-complete-2", "scheduled-failed-2"}, listRuns(fakeClient))
+	})
+
+	t.Run("skips the runs that missed their starting deadline", func(t *testing.T) {
+		rayJob := newRayJob("")
+		rayJob.Spec.Schedule = "0 0 1 1 *"
+		rayJob.Spec.StartingDeadlineSeconds = ptr.To[int64](60)
+		rayJob.CreationTimestamp = metav1.NewTime(time.Date(now.Year()-1, time.June, 1, 0, 0, 0, 0, time.UTC))
+		r, fakeClient := setup(rayJob)
+		_, err := r.reconcileScheduledRayJob(context.Background(), rayJob)
+		require.NoError(t, err)
+		assert.Empty(t, listRuns(fakeClient))
+		assert.Nil(t, rayJob.Status.LastScheduleTime)
+		event := <-r.Recorder.(*record.FakeRecorder).Events
+		assert.Contains(t, event, string(utils.MissedRayJobSchedule))
+	})
+
+	t.Run("creates only the latest run after too many missed runs", func(t *testing.T) {
+		rayJob := newRayJob("")
+		rayJob.CreationTimestamp = metav1.NewTime(now.Add(-24 * time.Hour))
+		r, fakeClient := setup(rayJob)
+		_, err := r.reconcileScheduledRayJob(context.Background(), rayJob)
+		require.NoError(t, err)
+		event := <-r.Recorder.(*record.FakeRecorder).Events
+		assert.Contains(t, event, string(utils.TooManyMissedRayJobSchedules))
+		require.NotNil(t, rayJob.Status.LastScheduleTime)
+		assert.WithinDuration(t, now, rayJob.Status.LastScheduleTime.Time, time.Minute)
+		assert.Len(t, listRuns(fakeClient), 1)
+	})
+}
+
+func TestMostRecentScheduleTime(t *testing.T) {
+	schedule, err := cron.ParseStandard("0 * * * *")
+	require.NoError(t, err)
+	now := time.Date(2024, time.June, 10, 12, 30, 0, 0, time.UTC)
+	rayJob := &rayv1.RayJob{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-150 * time.Minute))}}
+
+	// No run is due before the first scheduled time.
+	scheduledTime, tooManyMissed := mostRecentScheduleTime(rayJob, schedule, now.Add(-121*time.Minute))
+	assert.True(t, scheduledTime.IsZero())
+	assert.False(t, tooManyMissed)
+
+	// The latest of the missed runs is due.
+	scheduledTime, tooManyMissed = mostRecentScheduleTime(rayJob, schedule, now)
+	assert.Equal(t, time.Date(2024, time.June, 10, 12, 0, 0, 0, time.UTC), scheduledTime)
+	assert.False(t, tooManyMissed)
+
+	// The walk through the missed runs is capped, but still finds the latest of them.
+	rayJob.Status.LastScheduleTime = &metav1.Time{Time: now.Add(-365 * 24 * time.Hour)}
+	scheduledTime, tooManyMissed = mostRecentScheduleTime(rayJob, schedule, now)
+	assert.Equal(t, time.Date(2024, time.June, 10, 12, 0, 0, 0, time.UTC), scheduledTime)
+	assert.True(t, tooManyMissed)
+}
+
+func TestCheckBackoffLimitAndUpdateStatusIfNeeded(t *testing.T) {
+	startTime := metav1.NewTime(time.Now().Add(-time.Minute))
+	rayJob := &rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{BackoffLimit: ptr.To[int32](1)},
+		Status: rayv1.RayJobStatus{
+			JobDeploymentStatus: rayv1.JobDeploymentStatusFailed,
+			JobStatus:           rayv1.JobStatusFailed,
+			Reason:              rayv1.AppFailed,
+			Message:             "Job entrypoint command failed with exit code 1",
+			RayClusterName:      "rayjob-cluster-1",
+			JobId:               "rayjob-1",
+			AttemptStartTime:    &startTime,
+		},
+	}
+
+	checkBackoffLimitAndUpdateStatusIfNeeded(context.Background(), rayJob)
+	assert.Equal(t, rayv1.JobDeploymentStatusRetrying, rayJob.Status.JobDeploymentStatus)
+	assert.Equal(t, int32(1), *rayJob.Status.Failed)
+	require.Len(t, rayJob.Status.FailedAttempts, 1)
+	attempt := rayJob.Status.FailedAttempts[0]
+	assert.Equal(t, int32(1), attempt.Attempt)
+	assert.Equal(t, "rayjob-cluster-1", attempt.RayClusterName)
+	assert.Equal(t, "rayjob-1", attempt.JobId)
+	assert.Equal(t, rayv1.JobStatusFailed, attempt.JobStatus)
+	assert.Equal(t, rayv1.AppFailed, attempt.Reason)
+	assert.Equal(t, rayJob.Status.Message, attempt.Message)
+	assert.Equal(t, startTime, *attempt.StartTime)
+	assert.NotNil(t, attempt.EndTime)
+
+	// The second failure exhausts the backoff limit and is recorded as well.
+	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusFailed
+	checkBackoffLimitAndUpdateStatusIfNeeded(context.Background(), rayJob)
+	assert.Equal(t, rayv1.JobDeploymentStatusFailed, rayJob.Status.JobDeploymentStatus)
+	require.Len(t, rayJob.Status.FailedAttempts, 2)
+	assert.Equal(t, int32(2), rayJob.Status.FailedAttempts[1].Attempt)
+
+	// Unlike the ActiveDeadlineSeconds of the RayJob, the AttemptDeadlineSeconds of an attempt is retried.
+	rayJob.Spec.BackoffLimit = ptr.To[int32](20)
+	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusFailed
+	rayJob.Status.Reason = rayv1.AttemptDeadlineExceeded
+	checkBackoffLimitAndUpdateStatusIfNeeded(context.Background(), rayJob)
+	assert.Equal(t, rayv1.JobDeploymentStatusRetrying, rayJob.Status.JobDeploymentStatus)
+	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusFailed
+	rayJob.Status.Reason = rayv1.DeadlineExceeded
+	checkBackoffLimitAndUpdateStatusIfNeeded(context.Background(), rayJob)
+	assert.Equal(t, rayv1.JobDeploymentStatusFailed, rayJob.Status.JobDeploymentStatus)
+
+	// Only the latest attempts are kept.
+	for i := 0; i < MaxFailedAttempts; i++ {
+		rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusFailed
+		checkBackoffLimitAndUpdateStatusIfNeeded(context.Background(), rayJob)
+	}
+	require.Len(t, rayJob.Status.FailedAttempts, MaxFailedAttempts)
+	assert.Equal(t, int32(5), rayJob.Status.FailedAttempts[0].Attempt)
+	assert.Equal(t, int32(MaxFailedAttempts+4), rayJob.Status.FailedAttempts[MaxFailedAttempts-1].Attempt)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	rayJob := &rayv1.RayJob{}
+	assert.Equal(t, time.Duration(0), retryBackoff(rayJob, 3), "RayJobs without a RetryPolicy are retried immediately")
+
+	rayJob.Spec.RetryPolicy = &rayv1.RayJobRetryPolicy{
+		InitialBackoffSeconds: ptr.To[int32](10),
+		MaxBackoffSeconds:     ptr.To[int32](60),
+	}
+	assert.Equal(t, 10*time.Second, retryBackoff(rayJob, 1))
+	assert.Equal(t, 20*time.Second, retryBackoff(rayJob, 2))
+	assert.Equal(t, 40*time.Second, retryBackoff(rayJob, 3))
+	assert.Equal(t, 60*time.Second, retryBackoff(rayJob, 4))
+	assert.Equal(t, 60*time.Second, retryBackoff(rayJob, 100))
+
+	rayJob.Spec.RetryPolicy.MaxBackoffSeconds = nil
+	assert.Equal(t, time.Duration(DefaultMaxRetryBackoffSeconds)*time.Second, retryBackoff(rayJob, 100))
+}
+
+func TestReconcileRetryingRayJob(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = batchv1.AddToScheme(newScheme)
+
+	newRayJob := func(retryPolicy *rayv1.RayJobRetryPolicy, failedAt time.Time) *rayv1.RayJob {
+		return &rayv1.RayJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "retrying",
+				Namespace:  "default",
+				Finalizers: []string{utils.RayJobStopJobFinalizer},
+			},
+			Spec: rayv1.RayJobSpec{
+				BackoffLimit:   ptr.To[int32](2),
+				RetryPolicy:    retryPolicy,
+				Entrypoint:     "python train.py",
+				RayClusterSpec: &rayv1.RayClusterSpec{},
+			},
+			Status: rayv1.RayJobStatus{
+				JobDeploymentStatus: rayv1.JobDeploymentStatusRetrying,
+				JobStatus:           rayv1.JobStatusFailed,
+				RayClusterName:      "retrying-cluster",
+				DashboardURL:        "retrying-head-svc.default.svc.cluster.local:8265",
+				JobId:               "retrying-1",
+				Failed:              ptr.To[int32](1),
+				FailedAttempts: []rayv1.RayJobAttemptStatus{
+					{Attempt: 1, EndTime: &metav1.Time{Time: failedAt}},
+				},
+			},
+		}
+	}
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "retrying-cluster", Namespace: "default"},
+	}
+	reconcile := func(rayJob *rayv1.RayJob) (ctrl.Result, client.Client) {
+		fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithObjects(rayJob, rayCluster.DeepCopy()).WithStatusSubresource(rayJob).Build()
+		r := &RayJobReconciler{Client: fakeClient, Recorder: record.NewFakeRecorder(100), Scheme: newScheme}
+		result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(rayJob)})
+		require.NoError(t, err)
+		return result, fakeClient
+	}
+	getRayJob := func(fakeClient client.Client) *rayv1.RayJob {
+		rayJob := &rayv1.RayJob{}
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "retrying"}, rayJob))
+		return rayJob
+	}
+
+	t.Run("recreates the RayCluster by default", func(t *testing.T) {
+		_, fakeClient := reconcile(newRayJob(nil, time.Now()))
+		err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(rayCluster), &rayv1.RayCluster{})
+		assert.True(t, apierrors.IsNotFound(err), "The RayCluster of the failed attempt should be deleted")
+	})
+
+	t.Run("reuses the RayCluster", func(t *testing.T) {
+		_, fakeClient := reconcile(newRayJob(&rayv1.RayJobRetryPolicy{ClusterPolicy: rayv1.ReuseRetryClusterPolicy}, time.Now()))
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(rayCluster), &rayv1.RayCluster{}))
+
+		rayJob := getRayJob(fakeClient)
+		assert.Equal(t, rayv1.JobDeploymentStatusNew, rayJob.Status.JobDeploymentStatus)
+		assert.Equal(t, "retrying-cluster", rayJob.Status.RayClusterName)
+		assert.NotEmpty(t, rayJob.Status.DashboardURL)
+		assert.Empty(t, rayJob.Status.JobId, "Each attempt submits a Ray job with a new submission ID")
+	})
+
+	t.Run("waits for the backoff", func(t *testing.T) {
+		retryPolicy := &rayv1.RayJobRetryPolicy{
+			ClusterPolicy:         rayv1.ReuseRetryClusterPolicy,
+			InitialBackoffSeconds: ptr.To[int32](60),
+		}
+		result, fakeClient := reconcile(newRayJob(retryPolicy, time.Now()))
+		assert.Greater(t, result.RequeueAfter, 55*time.Second)
+		assert.LessOrEqual(t, result.RequeueAfter, 60*time.Second)
+		assert.Equal(t, rayv1.JobDeploymentStatusRetrying, getRayJob(fakeClient).Status.JobDeploymentStatus)
+
+		_, fakeClient = reconcile(newRayJob(retryPolicy, time.Now().Add(-2*time.Minute)))
+		assert.Equal(t, rayv1.JobDeploymentStatusNew, getRayJob(fakeClient).Status.JobDeploymentStatus)
+	})
+}
+
+func TestReconcileHTTPModeRayJobSubmission(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = batchv1.AddToScheme(newScheme)
+
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "http-mode",
+			Namespace:  "default",
+			Finalizers: []string{utils.RayJobStopJobFinalizer},
+		},
+		Spec: rayv1.RayJobSpec{
+			SubmissionMode: rayv1.HTTPMode,
+			Entrypoint:     "python train.py",
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+		},
+		Status: rayv1.RayJobStatus{
+			JobDeploymentStatus: rayv1.JobDeploymentStatusRunning,
+			JobStatus:           rayv1.JobStatusNew,
+			RayClusterName:      "http-mode-cluster",
+			DashboardURL:        "http-mode-head-svc.default.svc.cluster.local:8265",
+			JobId:               "http-mode-1",
+			StartTime:           &metav1.Time{Time: time.Now()},
+			AttemptStartTime:    &metav1.Time{Time: time.Now()},
+		},
+	}
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "http-mode-cluster", Namespace: "default"},
+	}
+	reconcile := func(submitJob func(context.Context, *rayv1.RayJob) (string, error)) (*rayv1.RayJob, *record.FakeRecorder, error) {
+		fakeRayDashboardClient := &utils.FakeRayDashboardClient{}
+		// The Ray job is not found before the first submission attempt.
+		var found bool
+		getJobInfo := func(context.Context, string) (*utils.RayJobInfo, error) {
+			if !found {
+				found = true
+				return nil, apierrors.NewBadRequest("Job http-mode-1 does not exist")
+			}
+			return &utils.RayJobInfo{JobStatus: rayv1.JobStatusRunning}, nil
+		}
+		fakeRayDashboardClient.GetJobInfoMock.Store(&getJobInfo)
+		fakeRayDashboardClient.SubmitJobMock.Store(&submitJob)
+		fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithObjects(rayJob.DeepCopy(), rayCluster.DeepCopy()).WithStatusSubresource(rayJob).Build()
+		recorder := record.NewFakeRecorder(100)
+		r := &RayJobReconciler{
+			Client:              fakeClient,
+			Recorder:            recorder,
+			Scheme:              newScheme,
+			dashboardClientFunc: func() utils.RayDashboardClientInterface { return fakeRayDashboardClient },
+		}
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(rayJob)})
+		updated := &rayv1.RayJob{}
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(rayJob), updated))
+		return updated, recorder, err
+	}
+
+	t.Run("submits the Ray job", func(t *testing.T) {
+		var submitted bool
+		updated, recorder, err := reconcile(func(context.Context, *rayv1.RayJob) (string, error) {
+			submitted = true
+			return "http-mode-1", nil
+		})
+		require.NoError(t, err)
+		assert.True(t, submitted)
+		assert.Equal(t, rayv1.JobDeploymentStatusRunning, updated.Status.JobDeploymentStatus)
+		assert.Contains(t, <-recorder.Events, string(utils.SubmittedRayJob))
+	})
+
+	t.Run("fails if the Ray dashboard rejects the Ray job", func(t *testing.T) {
+		updated, recorder, err := reconcile(func(context.Context, *rayv1.RayJob) (string, error) {
+			return "", apierrors.NewBadRequest("SubmitJob fail: 400 Bad Request invalid runtime_env")
+		})
+		require.NoError(t, err)
+		assert.Equal(t, rayv1.JobDeploymentStatusFailed, updated.Status.JobDeploymentStatus)
+		assert.Equal(t, rayv1.SubmissionFailed, updated.Status.Reason)
+		assert.Contains(t, updated.Status.Message, "invalid runtime_env")
+		assert.Contains(t, <-recorder.Events, string(utils.FailedToSubmitRayJob))
+	})
+
+	t.Run("gets the info of a Ray job that has already been submitted", func(t *testing.T) {
+		updated, recorder, err := reconcile(func(context.Context, *rayv1.RayJob) (string, error) {
+			return "", fmt.Errorf("%w: SubmitJob fail: 400 Bad Request Job with submission_id http-mode-1 already exists", utils.ErrRayJobAlreadySubmitted)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, rayv1.JobDeploymentStatusRunning, updated.Status.JobDeploymentStatus)
+		assert.Equal(t, rayv1.JobStatusRunning, updated.Status.JobStatus)
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("requeues on transient errors", func(t *testing.T) {
+		updated, _, err := reconcile(func(context.Context, *rayv1.RayJob) (string, error) {
+			return "", errors.New("connection refused")
+		})
+		require.Error(t, err)
+		assert.Equal(t, rayv1.JobDeploymentStatusRunning, updated.Status.JobDeploymentStatus)
+	})
+}
+
+func TestCheckActiveDeadlineAndUpdateStatusIfNeeded(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "deadline-cluster", Namespace: "default"},
+	}
+	newRayJob := func(startedAgo time.Duration, attemptStartedAgo time.Duration) *rayv1.RayJob {
+		return &rayv1.RayJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "deadline", Namespace: "default"},
+			Spec: rayv1.RayJobSpec{
+				ActiveDeadlineSeconds:  ptr.To[int32](600),
+				AttemptDeadlineSeconds: ptr.To[int32](60),
+			},
+			Status: rayv1.RayJobStatus{
+				JobDeploymentStatus: rayv1.JobDeploymentStatusRunning,
+				RayClusterName:      "deadline-cluster",
+				DashboardURL:        "deadline-head-svc.default.svc.cluster.local:8265",
+				JobId:               "deadline-1",
+				StartTime:           &metav1.Time{Time: time.Now().Add(-startedAgo)},
+				AttemptStartTime:    &metav1.Time{Time: time.Now().Add(-attemptStartedAgo)},
+			},
+		}
+	}
+	setup := func(stopJob func(context.Context, string) error) (*RayJobReconciler, *record.FakeRecorder) {
+		fakeRayDashboardClient := &utils.FakeRayDashboardClient{}
+		fakeRayDashboardClient.StopJobMock.Store(&stopJob)
+		recorder := record.NewFakeRecorder(100)
+		return &RayJobReconciler{
+			Client:              clientFake.NewClientBuilder().WithScheme(newScheme).WithObjects(rayCluster).Build(),
+			Recorder:            recorder,
+			Scheme:              newScheme,
+			dashboardClientFunc: func() utils.RayDashboardClientInterface { return fakeRayDashboardClient },
+		}, recorder
+	}
+
+	t.Run("within the deadlines", func(t *testing.T) {
+		r, _ := setup(func(context.Context, string) error {
+			t.Error("The Ray job should not be stopped")
+			return nil
+		})
+		rayJob := newRayJob(2*time.Minute, 30*time.Second)
+		assert.False(t, r.checkActiveDeadlineAndUpdateStatusIfNeeded(context.Background(), rayJob))
+		assert.Equal(t, rayv1.JobDeploymentStatusRunning, rayJob.Status.JobDeploymentStatus)
+	})
+
+	t.Run("attempt deadline exceeded", func(t *testing.T) {
+		var stoppedJobId string
+		r, recorder := setup(func(_ context.Context, jobId string) error {
+			stoppedJobId = jobId
+			return nil
+		})
+		rayJob := newRayJob(2*time.Minute, 2*time.Minute)
+		assert.True(t, r.checkActiveDeadlineAndUpdateStatusIfNeeded(context.Background(), rayJob))
+		assert.Equal(t, rayv1.JobDeploymentStatusFailed, rayJob.Status.JobDeploymentStatus)
+		assert.Equal(t, rayv1.AttemptDeadlineExceeded, rayJob.Status.Reason)
+		assert.Equal(t, "deadline-1", stoppedJobId)
+		assert.Contains(t, <-recorder.Events, string(utils.StoppedRayJob))
+	})
+
+	t.Run("active deadline exceeded", func(t *testing.T) {
+		r, _ := setup(func(context.Context, string) error { return nil })
+		rayJob := newRayJob(20*time.Minute, 2*time.Minute)
+		assert.True(t, r.checkActiveDeadlineAndUpdateStatusIfNeeded(context.Background(), rayJob))
+		assert.Equal(t, rayv1.JobDeploymentStatusFailed, rayJob.Status.JobDeploymentStatus)
+		assert.Equal(t, rayv1.DeadlineExceeded, rayJob.Status.Reason)
+	})
+
+	t.Run("fails even if the Ray job cannot be stopped", func(t *testing.T) {
+		r, recorder := setup(func(context.Context, string) error { return errors.New("dashboard unavailable") })
+		rayJob := newRayJob(20*time.Minute, 2*time.Minute)
+		assert.True(t, r.checkActiveDeadlineAndUpdateStatusIfNeeded(context.Background(), rayJob))
+		assert.Equal(t, rayv1.JobDeploymentStatusFailed, rayJob.Status.JobDeploymentStatus)
+		assert.Contains(t, <-recorder.Events, string(utils.FailedToStopRayJob))
+	})
+}
+
+func TestInitRayJobStatusIfNeedKeepsStartTime(t *testing.T) {
+	startTime := metav1.NewTime(time.Now().Add(-time.Hour))
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "retried", Namespace: "default"},
+		Status: rayv1.RayJobStatus{
+			JobDeploymentStatus: rayv1.JobDeploymentStatusNew,
+			JobStatus:           rayv1.JobStatusNew,
+			StartTime:           &startTime,
+		},
+	}
+
+	r := &RayJobReconciler{}
+	require.NoError(t, r.initRayJobStatusIfNeed(context.Background(), rayJob))
+	assert.Equal(t, rayv1.JobDeploymentStatusInitializing, rayJob.Status.JobDeploymentStatus)
+	assert.Equal(t, startTime, *rayJob.Status.StartTime, "A retry keeps the StartTime of the RayJob")
+	require.NotNil(t, rayJob.Status.AttemptStartTime)
+	assert.True(t, rayJob.Status.AttemptStartTime.After(startTime.Time))
+}
+
+func TestGetDeletionPolicy(t *testing.T) {
+	rayJob := &rayv1.RayJob{}
+	assert.Equal(t, rayv1.DeleteNoneDeletionPolicy, getDeletionPolicy(rayJob))
+
+	rayJob.Spec.ShutdownAfterJobFinishes = true
+	assert.Equal(t, rayv1.DeleteClusterDeletionPolicy, getDeletionPolicy(rayJob))
+
+	t.Setenv(utils.DELETE_RAYJOB_CR_AFTER_JOB_FINISHES, "true")
+	assert.Equal(t, rayv1.DeleteSelfDeletionPolicy, getDeletionPolicy(rayJob))
+
+	// The RayCluster of a RayJob with a ClusterSelector is not deleted.
+	rayJob.Spec.ClusterSelector = map[string]string{RayJobDefaultClusterSelectorKey: "shared"}
+	assert.Equal(t, rayv1.DeleteNoneDeletionPolicy, getDeletionPolicy(rayJob))
+
+	rayJob.Spec.DeletionPolicy = rayv1.DeleteWorkersDeletionPolicy
+	assert.Equal(t, rayv1.DeleteWorkersDeletionPolicy, getDeletionPolicy(rayJob))
+}
+
+func TestRayJobDeletionPolicy(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+
+	newRayJob := func(deletionPolicy rayv1.DeletionPolicy) *rayv1.RayJob {
+		return &rayv1.RayJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "finished",
+				Namespace:  "default",
+				UID:        "finished-uid",
+				Finalizers: []string{utils.RayJobStopJobFinalizer},
+			},
+			Spec: rayv1.RayJobSpec{
+				DeletionPolicy: deletionPolicy,
+				RayClusterSpec: &rayv1.RayClusterSpec{},
+			},
+			Status: rayv1.RayJobStatus{
+				JobDeploymentStatus: rayv1.JobDeploymentStatusComplete,
+				JobStatus:           rayv1.JobStatusSucceeded,
+				RayClusterName:      "finished-cluster",
+				EndTime:             &metav1.Time{Time: time.Now().Add(-time.Minute)},
+			},
+		}
+	}
+	newRayCluster := func(rayJob *rayv1.RayJob) *rayv1.RayCluster {
+		rayCluster := &rayv1.RayCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "finished-cluster", Namespace: "default"},
+			Spec: rayv1.RayClusterSpec{
+				WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+					{GroupName: "gpu", Replicas: ptr.To[int32](4), MinReplicas: ptr.To[int32](2), MaxReplicas: ptr.To[int32](8)},
+				},
+			},
+		}
+		_ = ctrl.SetControllerReference(rayJob, rayCluster, newScheme)
+		return rayCluster
+	}
+	setup := func(rayJob *rayv1.RayJob) (*RayJobReconciler, client.Client) {
+		fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithObjects(rayJob, newRayCluster(rayJob)).WithStatusSubresource(rayJob).Build()
+		return &RayJobReconciler{Client: fakeClient, Recorder: record.NewFakeRecorder(100), Scheme: newScheme}, fakeClient
+	}
+	getRayCluster := func(fakeClient client.Client) (*rayv1.RayCluster, error) {
+		rayCluster := &rayv1.RayCluster{}
+		err := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "finished-cluster"}, rayCluster)
+		return rayCluster, err
+	}
+	reconcile := func(r *RayJobReconciler, rayJob *rayv1.RayJob) {
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(rayJob)})
+		require.NoError(t, err)
+	}
+
+	t.Run("DeleteCluster", func(t *testing.T) {
+		rayJob := newRayJob(rayv1.DeleteClusterDeletionPolicy)
+		r, fakeClient := setup(rayJob)
+		reconcile(r, rayJob)
+		_, err := getRayCluster(fakeClient)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("DeleteWorkers", func(t *testing.T) {
+		rayJob := newRayJob(rayv1.DeleteWorkersDeletionPolicy)
+		r, fakeClient := setup(rayJob)
+		reconcile(r, rayJob)
+		rayCluster, err := getRayCluster(fakeClient)
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), *rayCluster.Spec.WorkerGroupSpecs[0].Replicas)
+		assert.Equal(t, int32(0), *rayCluster.Spec.WorkerGroupSpecs[0].MinReplicas)
+		assert.Equal(t, int32(8), *rayCluster.Spec.WorkerGroupSpecs[0].MaxReplicas)
+	})
+
+	t.Run("DeleteSelf", func(t *testing.T) {
+		rayJob := newRayJob(rayv1.DeleteSelfDeletionPolicy)
+		r, fakeClient := setup(rayJob)
+		reconcile(r, rayJob)
+		deleted := &rayv1.RayJob{}
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(rayJob), deleted))
+		assert.False(t, deleted.DeletionTimestamp.IsZero(), "The RayJob is being deleted")
+	})
+
+	t.Run("DeleteNone", func(t *testing.T) {
+		rayJob := newRayJob(rayv1.DeleteNoneDeletionPolicy)
+		r, fakeClient := setup(rayJob)
+		reconcile(r, rayJob)
+		rayCluster, err := getRayCluster(fakeClient)
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), *rayCluster.Spec.WorkerGroupSpecs[0].Replicas)
+	})
+
+	t.Run("keeps the RayCluster when the RayJob is deleted", func(t *testing.T) {
+		for _, deletionPolicy := range []rayv1.DeletionPolicy{rayv1.DeleteWorkersDeletionPolicy, rayv1.DeleteNoneDeletionPolicy} {
+			rayJob := newRayJob(deletionPolicy)
+			r, fakeClient := setup(rayJob)
+			require.NoError(t, r.keepRayClusterIfNeeded(context.Background(), rayJob))
+			rayCluster, err := getRayCluster(fakeClient)
+			require.NoError(t, err)
+			assert.Empty(t, rayCluster.OwnerReferences, deletionPolicy)
+			assert.Equal(t, deletionPolicy == rayv1.DeleteWorkersDeletionPolicy, *rayCluster.Spec.WorkerGroupSpecs[0].Replicas == 0, deletionPolicy)
+		}
+
+		rayJob := newRayJob(rayv1.DeleteClusterDeletionPolicy)
+		r, fakeClient := setup(rayJob)
+		require.NoError(t, r.keepRayClusterIfNeeded(context.Background(), rayJob))
+		rayCluster, err := getRayCluster(fakeClient)
+		require.NoError(t, err)
+		assert.Len(t, rayCluster.OwnerReferences, 1, "The RayCluster is garbage collected with the RayJob")
+	})
+}
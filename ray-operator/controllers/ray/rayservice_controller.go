@@ -31,6 +31,7 @@ import (
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -62,14 +63,22 @@ type RayServiceReconciler struct {
 
 	dashboardClientFunc func() utils.RayDashboardClientInterface
 	httpProxyClientFunc func() utils.RayHttpProxyClientInterface
+
+	// requeueInterval is how often the status of a RayService in progress is polled. If it is 0,
+	// ServiceDefaultRequeueDuration is used.
+	requeueInterval time.Duration
+}
+
+type RayServiceReconcilerOptions struct {
+	RequeueInterval time.Duration
 }
 
 // NewRayServiceReconciler returns a new reconcile.Reconciler
-func NewRayServiceReconciler(_ context.Context, mgr manager.Manager, provider utils.ClientProvider) *RayServiceReconciler {
+func NewRayServiceReconciler(_ context.Context, mgr manager.Manager, options RayServiceReconcilerOptions, provider utils.ClientProvider) *RayServiceReconciler {
 	dashboardClientFunc := provider.GetDashboardClient(mgr)
 	httpProxyClientFunc := provider.GetHttpProxyClient(mgr)
 	return &RayServiceReconciler{
-		Client:                       mgr.GetClient(),
+		Client:                       utils.NewTracedClient(mgr.GetClient()),
 		Scheme:                       mgr.GetScheme(),
 		Recorder:                     mgr.GetEventRecorderFor("rayservice-controller"),
 		ServeConfigs:                 cmap.New[string](),
@@ -77,6 +86,8 @@ func NewRayServiceReconciler(_ context.Context, mgr manager.Manager, provider ut
 
 		dashboardClientFunc: dashboardClientFunc,
 		httpProxyClientFunc: httpProxyClientFunc,
+
+		requeueInterval: options.RequeueInterval,
 	}
 }
 
@@ -98,9 +109,10 @@ func NewRayServiceReconciler(_ context.Context, mgr manager.Manager, provider ut
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;delete;patch
 // +kubebuilder:rbac:groups=extensions,resources=ingresses,verbs=get;list;watch;create;update;delete;patch
-// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;delete
-// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;delete;update
-// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;create;update;delete;patch
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;delete;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;delete;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;delete;patch
 
 // [WARNING]: There MUST be a newline after kubebuilder markers.
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -148,12 +160,13 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 	// Check if we need to create pending RayCluster.
 	if rayServiceInstance.Status.PendingServiceStatus.RayClusterName != "" && pendingRayClusterInstance == nil {
 		// Update RayService Status since reconcileRayCluster may mark RayCluster restart.
+		summarizeRayServiceStatus(rayServiceInstance)
 		if errStatus := r.Status().Update(ctx, rayServiceInstance); errStatus != nil {
 			logger.Error(errStatus, "Fail to update status of RayService after RayCluster changes", "rayServiceInstance", rayServiceInstance)
 			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
 		}
 		logger.Info("Done reconcileRayCluster update status, enter next loop to create new ray cluster.")
-		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
+		return ctrl.Result{RequeueAfter: r.requeueAfter(rayServiceInstance)}, nil
 	}
 
 	/*
@@ -193,9 +206,10 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 	}
 
 	if !isReady {
-		logger.Info("Ray Serve applications are not ready to serve requests", "requeue_duration", ServiceDefaultRequeueDuration.String())
-		r.Recorder.Eventf(rayServiceInstance, "Normal", "ServiceNotReady", "The service is not ready yet. Controller will perform a round of actions in %s.", ServiceDefaultRequeueDuration)
-		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
+		requeueAfter := r.requeueAfter(rayServiceInstance)
+		logger.Info("Ray Serve applications are not ready to serve requests", "requeue_duration", requeueAfter.String())
+		r.Recorder.Eventf(rayServiceInstance, "Normal", "ServiceNotReady", "The service is not ready yet. Controller will perform a round of actions in %s.", requeueAfter)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
 	}
 
 	// Get the ready Ray cluster instance for service and ingress update.
@@ -226,6 +240,10 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 			err = r.updateState(ctx, rayServiceInstance, rayv1.FailedToUpdateService, err)
 			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
 		}
+		if err := r.reconcileIngress(ctx, rayServiceInstance); err != nil {
+			err = r.updateState(ctx, rayServiceInstance, rayv1.FailedToUpdateIngress, err)
+			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
+		}
 	}
 
 	if err := r.calculateStatus(ctx, rayServiceInstance); err != nil {
@@ -233,6 +251,7 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 	}
 
 	// Final status update for any CR modification.
+	summarizeRayServiceStatus(rayServiceInstance)
 	if r.inconsistentRayServiceStatuses(ctx, originalRayServiceInstance.Status, rayServiceInstance.Status) {
 		rayServiceInstance.Status.LastUpdateTime = &metav1.Time{Time: time.Now()}
 		if errStatus := r.Status().Update(ctx, rayServiceInstance); errStatus != nil {
@@ -241,7 +260,20 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 		}
 	}
 
-	return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
+	// A ready RayService is polled at the requeue interval too, because the health of its Ray Serve applications
+	// changes without any change to the resources that the reconciler watches.
+	return ctrl.Result{RequeueAfter: r.requeueAfter(rayServiceInstance)}, nil
+}
+
+// requeueAfter returns when to reconcile the RayService again if nothing else triggers its reconciliation. The status
+// of the RayService is polled at the requeue interval in its ray.io/requeue-interval annotation, or otherwise at the
+// requeue interval of the reconciler.
+func (r *RayServiceReconciler) requeueAfter(rayService *rayv1.RayService) time.Duration {
+	requeueInterval := r.requeueInterval
+	if requeueInterval == 0 {
+		requeueInterval = ServiceDefaultRequeueDuration
+	}
+	return utils.GetRequeueInterval(rayService, requeueInterval)
 }
 
 func validateRayServiceSpec(rayService *rayv1.RayService) error {
@@ -333,6 +365,16 @@ func (r *RayServiceReconciler) inconsistentRayServiceStatuses(ctx context.Contex
 		return true
 	}
 
+	if oldStatus.Phase != newStatus.Phase {
+		logger.Info(fmt.Sprintf("inconsistentRayServiceStatus RayService Phase changed from %s to %s", oldStatus.Phase, newStatus.Phase))
+		return true
+	}
+
+	if !reflect.DeepEqual(oldStatus.Conditions, newStatus.Conditions) {
+		logger.Info("inconsistentRayServiceStatus RayService Conditions changed")
+		return true
+	}
+
 	if oldStatus.NumServeEndpoints != newStatus.NumServeEndpoints {
 		logger.Info(fmt.Sprintf("inconsistentRayServiceStatus RayService NumServeEndpoints changed from %d to %d", oldStatus.NumServeEndpoints, newStatus.NumServeEndpoints))
 		return true
@@ -352,8 +394,8 @@ func (r *RayServiceReconciler) inconsistentRayServiceStatuses(ctx context.Contex
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *RayServiceReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int) error {
-	return ctrl.NewControllerManagedBy(mgr).
+func (r *RayServiceReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int, rateLimiter workqueue.TypedRateLimiter[reconcile.Request], scope utils.Scope) error {
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&rayv1.RayService{}, builder.WithPredicates(predicate.Or(
 			predicate.GenerationChangedPredicate{},
 			predicate.LabelChangedPredicate{},
@@ -364,6 +406,7 @@ func (r *RayServiceReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcu
 		Owns(&networkingv1.Ingress{}).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: reconcileConcurrency,
+			RateLimiter:             rateLimiter,
 			LogConstructor: func(request *reconcile.Request) logr.Logger {
 				logger := ctrl.Log.WithName("controllers").WithName("RayService")
 				if request != nil {
@@ -371,8 +414,8 @@ func (r *RayServiceReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcu
 				}
 				return logger
 			},
-		}).
-		Complete(r)
+		})
+	return scope.Complete(b, mgr, &rayv1.RayService{}, r)
 }
 
 func (r *RayServiceReconciler) getRayServiceInstance(ctx context.Context, request ctrl.Request) (*rayv1.RayService, error) {
@@ -389,8 +432,50 @@ func (r *RayServiceReconciler) getRayServiceInstance(ctx context.Context, reques
 	return rayServiceInstance, nil
 }
 
+// summarizeRayServiceStatus sets the phase and the conditions of the RayService from its active and pending
+// RayClusters.
+func summarizeRayServiceStatus(rayService *rayv1.RayService) {
+	activeRayClusterName := rayService.Status.ActiveServiceStatus.RayClusterName
+	pendingRayClusterName := rayService.Status.PendingServiceStatus.RayClusterName
+	switch {
+	case activeRayClusterName != "" && pendingRayClusterName != "":
+		rayService.Status.Phase = rayv1.RayServicePhaseUpgrading
+	case activeRayClusterName != "":
+		rayService.Status.Phase = rayv1.RayServicePhaseRunning
+	default:
+		rayService.Status.Phase = rayv1.RayServicePhaseInitializing
+	}
+
+	readyReason := string(rayService.Status.ServiceStatus)
+	if readyReason == "" {
+		readyReason = rayv1.RayServiceInitializing
+	}
+	meta.SetStatusCondition(&rayService.Status.Conditions, metav1.Condition{
+		Type:   string(rayv1.RayServiceReady),
+		Status: conditionStatus(rayService.Status.ServiceStatus == rayv1.Running),
+		Reason: readyReason,
+	})
+
+	upgradeCondition := metav1.Condition{
+		Type:   string(rayv1.UpgradeInProgress),
+		Status: metav1.ConditionFalse,
+		Reason: rayv1.NoPendingRayCluster,
+	}
+	if pendingRayClusterName != "" {
+		upgradeCondition.Status = metav1.ConditionTrue
+		upgradeCondition.Reason = rayv1.PendingRayClusterExists
+		upgradeCondition.Message = fmt.Sprintf("The pending RayCluster %s will replace the active RayCluster once it is ready", pendingRayClusterName)
+	}
+	meta.SetStatusCondition(&rayService.Status.Conditions, upgradeCondition)
+
+	for i := range rayService.Status.Conditions {
+		rayService.Status.Conditions[i].ObservedGeneration = rayService.Generation
+	}
+}
+
 func (r *RayServiceReconciler) updateState(ctx context.Context, rayServiceInstance *rayv1.RayService, status rayv1.ServiceStatus, err error) error {
 	rayServiceInstance.Status.ServiceStatus = status
+	summarizeRayServiceStatus(rayServiceInstance)
 	if errStatus := r.Status().Update(ctx, rayServiceInstance); errStatus != nil {
 		return fmtErrors.Errorf("combined error: %v %v", err, errStatus)
 	}
@@ -971,6 +1056,27 @@ func (r *RayServiceReconciler) updateRayClusterInfo(ctx context.Context, rayServ
 	}
 }
 
+// reconcileIngress creates or updates the Ingress or the HTTPRoutes of the headGroupSpec.ingress of the RayService.
+// They route to the head and serve services of the RayService, which select the RayCluster that serves the traffic,
+// so they do not change during zero downtime upgrades.
+func (r *RayServiceReconciler) reconcileIngress(ctx context.Context, rayServiceInstance *rayv1.RayService) error {
+	options := rayServiceInstance.Spec.RayClusterSpec.HeadGroupSpec.Ingress
+	if options == nil {
+		return nil
+	}
+	backends, err := common.GetIngressBackendsForRayService(*rayServiceInstance)
+	if err != nil {
+		return err
+	}
+	labels := map[string]string{
+		utils.RayOriginatedFromCRNameLabelKey:   rayServiceInstance.Name,
+		utils.RayOriginatedFromCRDLabelKey:      utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD),
+		utils.KubernetesApplicationNameLabelKey: utils.ApplicationName,
+		utils.KubernetesCreatedByLabelKey:       utils.ComponentName,
+	}
+	return reconcileManagedIngress(ctx, r.Client, r.Recorder, r.Scheme, rayServiceInstance, *options, labels, backends)
+}
+
 func (r *RayServiceReconciler) reconcileServices(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster, serviceType utils.ServiceType) error {
 	logger := ctrl.LoggerFrom(ctx)
 	logger.Info(
@@ -1006,15 +1112,12 @@ func (r *RayServiceReconciler) reconcileServices(ctx context.Context, rayService
 			return nil
 		}
 
-		// ClusterIP is immutable. Starting from Kubernetes v1.21.5, if the new service does not specify a ClusterIP,
-		// Kubernetes will assign the ClusterIP of the old service to the new one. However, to maintain compatibility
-		// with older versions of Kubernetes, we need to assign the ClusterIP here.
-		newSvc.Spec.ClusterIP = oldSvc.Spec.ClusterIP
-
-		// TODO (kevin85421): Consider not only the updates of the Spec but also the ObjectMeta.
-		oldSvc.Spec = *newSvc.Spec.DeepCopy()
+		// Server-side apply keeps the cluster IP, which is immutable, and the fields that other managers set.
 		logger.Info(fmt.Sprintf("Update Kubernetes Service serviceType %v", serviceType))
-		if updateErr := r.Update(ctx, oldSvc); updateErr != nil {
+		if err := ctrl.SetControllerReference(rayServiceInstance, newSvc, r.Scheme); err != nil {
+			return err
+		}
+		if updateErr := applyObject(ctx, r.Client, newSvc); updateErr != nil {
 			return updateErr
 		}
 	} else if errors.IsNotFound(err) {
@@ -1022,7 +1125,7 @@ func (r *RayServiceReconciler) reconcileServices(ctx context.Context, rayService
 		if err := ctrl.SetControllerReference(rayServiceInstance, newSvc, r.Scheme); err != nil {
 			return err
 		}
-		if createErr := r.Create(ctx, newSvc); createErr != nil {
+		if createErr := applyObject(ctx, r.Client, newSvc); createErr != nil {
 			if errors.IsAlreadyExists(createErr) {
 				logger.Info("The Kubernetes Service already exists, no need to create.")
 				return nil
@@ -1140,6 +1243,7 @@ func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceIns
 		r.Recorder.Event(rayServiceInstance, "Normal", "Running", "The Serve application is now running and healthy.")
 	} else {
 		rayServiceInstance.Status.ServiceStatus = rayv1.WaitForServeDeploymentReady
+		summarizeRayServiceStatus(rayServiceInstance)
 		if err := r.Status().Update(ctx, rayServiceInstance); err != nil {
 			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, false, err
 		}
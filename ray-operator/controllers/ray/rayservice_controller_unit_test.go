@@ -12,6 +12,7 @@ import (
 	cmap "github.com/orcaman/concurrent-map/v2"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
@@ -394,7 +395,7 @@ func TestReconcileServices_UpdateService(t *testing.T) {
 
 	// Initialize a fake client with newScheme and runtimeObjects.
 	runtimeObjects := []runtime.Object{}
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).WithInterceptorFuncs(serverSideApplyFuncs).Build()
 
 	// Initialize RayCluster reconciler.
 	r := &RayServiceReconciler{
@@ -869,3 +870,52 @@ func initFakeDashboardClient(appName string, deploymentStatus string, appStatus
 	fakeDashboardClient.SetMultiApplicationStatuses(map[string]*utils.ServeApplicationStatus{appName: &status})
 	return &fakeDashboardClient
 }
+
+func TestSummarizeRayServiceStatus(t *testing.T) {
+	rayService := &rayv1.RayService{ObjectMeta: metav1.ObjectMeta{Generation: 3}}
+
+	// The conditions are set regardless of the RayClusterStatusConditions feature gate.
+	summarizeRayServiceStatus(rayService)
+	assert.Equal(t, rayv1.RayServicePhaseInitializing, rayService.Status.Phase)
+	readyCondition := meta.FindStatusCondition(rayService.Status.Conditions, string(rayv1.RayServiceReady))
+	assert.Equal(t, metav1.ConditionFalse, readyCondition.Status)
+	assert.Equal(t, rayv1.RayServiceInitializing, readyCondition.Reason)
+	assert.Equal(t, int64(3), readyCondition.ObservedGeneration)
+	assert.True(t, meta.IsStatusConditionFalse(rayService.Status.Conditions, string(rayv1.UpgradeInProgress)))
+
+	rayService.Status.ServiceStatus = rayv1.Running
+	rayService.Status.ActiveServiceStatus.RayClusterName = "active-cluster"
+	summarizeRayServiceStatus(rayService)
+	assert.Equal(t, rayv1.RayServicePhaseRunning, rayService.Status.Phase)
+	assert.True(t, meta.IsStatusConditionFalse(rayService.Status.Conditions, string(rayv1.UpgradeInProgress)))
+
+	rayService.Status.PendingServiceStatus.RayClusterName = "pending-cluster"
+	summarizeRayServiceStatus(rayService)
+	assert.Equal(t, rayv1.RayServicePhaseUpgrading, rayService.Status.Phase)
+	readyCondition = meta.FindStatusCondition(rayService.Status.Conditions, string(rayv1.RayServiceReady))
+	assert.Equal(t, metav1.ConditionTrue, readyCondition.Status)
+	assert.Equal(t, string(rayv1.Running), readyCondition.Reason)
+	upgradeCondition := meta.FindStatusCondition(rayService.Status.Conditions, string(rayv1.UpgradeInProgress))
+	assert.Equal(t, metav1.ConditionTrue, upgradeCondition.Status)
+	assert.Equal(t, rayv1.PendingRayClusterExists, upgradeCondition.Reason)
+
+	rayService.Status.ServiceStatus = rayv1.FailedToUpdateService
+	summarizeRayServiceStatus(rayService)
+	readyCondition = meta.FindStatusCondition(rayService.Status.Conditions, string(rayv1.RayServiceReady))
+	assert.Equal(t, metav1.ConditionFalse, readyCondition.Status)
+	assert.Equal(t, string(rayv1.FailedToUpdateService), readyCondition.Reason)
+}
+
+func TestRayServiceRequeueAfter(t *testing.T) {
+	rayService := &rayv1.RayService{}
+
+	r := &RayServiceReconciler{}
+	assert.Equal(t, ServiceDefaultRequeueDuration, r.requeueAfter(rayService))
+
+	r = &RayServiceReconciler{requeueInterval: 10 * time.Second}
+	assert.Equal(t, 10*time.Second, r.requeueAfter(rayService))
+
+	// The ray.io/requeue-interval annotation overrides the requeue interval of the reconciler.
+	rayService.Annotations = map[string]string{utils.RayRequeueIntervalAnnotationKey: "20s"}
+	assert.Equal(t, 20*time.Second, r.requeueAfter(rayService))
+}
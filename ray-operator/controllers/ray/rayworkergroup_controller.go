@@ -0,0 +1,209 @@
+package ray
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// Definition of an index field for the RayCluster that a RayWorkerGroup scales
+const rayWorkerGroupRayClusterNameIndexField = "spec.rayClusterName"
+
+// RayWorkerGroupReconciler reconciles a RayWorkerGroup object
+type RayWorkerGroupReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// NewRayWorkerGroupReconciler returns a new reconcile.Reconciler
+func NewRayWorkerGroupReconciler(ctx context.Context, mgr manager.Manager) *RayWorkerGroupReconciler {
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &rayv1.RayWorkerGroup{}, rayWorkerGroupRayClusterNameIndexField, func(rawObj client.Object) []string {
+		rayWorkerGroup := rawObj.(*rayv1.RayWorkerGroup)
+		return []string{rayWorkerGroup.Spec.RayClusterName}
+	}); err != nil {
+		panic(err)
+	}
+
+	return &RayWorkerGroupReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("rayworkergroup-controller"),
+	}
+}
+
+// +kubebuilder:rbac:groups=ray.io,resources=rayworkergroups,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ray.io,resources=rayworkergroups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ray.io,resources=rayclusters,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
+
+// [WARNING]: There MUST be a newline after kubebuilder markers.
+// Reconcile copies the replicas of a RayWorkerGroup, which a HorizontalPodAutoscaler or a KEDA ScaledObject sets
+// through the scale subresource, to the worker group of the RayCluster, and reports the Pods of the worker group
+// in the RayWorkerGroup status.
+func (r *RayWorkerGroupReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	rayWorkerGroup := &rayv1.RayWorkerGroup{}
+	if err := r.Get(ctx, request.NamespacedName, rayWorkerGroup); err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request. Stop reconciliation.
+			logger.Info("RayWorkerGroup resource not found. Ignoring since object must be deleted", "name", request.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		logger.Error(err, "Failed to get RayWorkerGroup")
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+
+	newStatus, err := r.scaleWorkerGroup(ctx, rayWorkerGroup)
+	if err != nil {
+		logger.Error(err, "Failed to scale the worker group", "RayCluster", rayWorkerGroup.Spec.RayClusterName, "groupName", rayWorkerGroup.Spec.GroupName)
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+
+	if newStatus != rayWorkerGroup.Status {
+		logger.Info("Update RayWorkerGroup status", "oldStatus", rayWorkerGroup.Status, "newStatus", newStatus)
+		rayWorkerGroup.Status = newStatus
+		if err := r.Status().Update(ctx, rayWorkerGroup); err != nil {
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// scaleWorkerGroup updates the replicas of the worker group of the RayCluster to the replicas of the RayWorkerGroup
+// and returns the new status of the RayWorkerGroup. It does not scale worker groups of RayClusters with in-tree
+// autoscaling enabled, because the Ray autoscaler owns their replicas.
+func (r *RayWorkerGroupReconciler) scaleWorkerGroup(ctx context.Context, rayWorkerGroup *rayv1.RayWorkerGroup) (rayv1.RayWorkerGroupStatus, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	status := rayv1.RayWorkerGroupStatus{
+		ObservedGeneration: rayWorkerGroup.Generation,
+		Selector:           rayWorkerGroupSelector(rayWorkerGroup).String(),
+	}
+
+	rayCluster := &rayv1.RayCluster{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: rayWorkerGroup.Namespace, Name: rayWorkerGroup.Spec.RayClusterName}, rayCluster); err != nil {
+		if errors.IsNotFound(err) {
+			status.Reason = fmt.Sprintf("RayCluster %s not found", rayWorkerGroup.Spec.RayClusterName)
+			return status, nil
+		}
+		return status, err
+	}
+
+	workerGroupIndex := -1
+	for i, workerGroupSpec := range rayCluster.Spec.WorkerGroupSpecs {
+		if workerGroupSpec.GroupName == rayWorkerGroup.Spec.GroupName {
+			workerGroupIndex = i
+			break
+		}
+	}
+	if workerGroupIndex == -1 {
+		status.Reason = fmt.Sprintf("RayCluster %s has no worker group %s", rayCluster.Name, rayWorkerGroup.Spec.GroupName)
+		return status, nil
+	}
+	workerGroupSpec := &rayCluster.Spec.WorkerGroupSpecs[workerGroupIndex]
+
+	if rayWorkerGroup.Spec.Replicas != nil {
+		if rayCluster.Spec.EnableInTreeAutoscaling != nil && *rayCluster.Spec.EnableInTreeAutoscaling {
+			status.Reason = fmt.Sprintf("RayCluster %s has in-tree autoscaling enabled, the Ray autoscaler scales its worker groups", rayCluster.Name)
+		} else if workerGroupSpec.Replicas == nil || *workerGroupSpec.Replicas != *rayWorkerGroup.Spec.Replicas {
+			logger.Info("Scale the worker group", "RayCluster", rayCluster.Name, "groupName", workerGroupSpec.GroupName, "replicas", *rayWorkerGroup.Spec.Replicas)
+			replicas := *rayWorkerGroup.Spec.Replicas
+			workerGroupSpec.Replicas = &replicas
+			if err := r.Update(ctx, rayCluster); err != nil {
+				return status, err
+			}
+			r.Recorder.Eventf(rayWorkerGroup, corev1.EventTypeNormal, string(utils.ScaledWorkerGroup),
+				"Scaled worker group %s of RayCluster %s/%s to %d replicas", workerGroupSpec.GroupName, rayCluster.Namespace, rayCluster.Name, replicas)
+		}
+	}
+	// The RayCluster controller clamps the replicas to minReplicas and maxReplicas.
+	status.DesiredReplicas = utils.GetWorkerGroupDesiredReplicas(ctx, *workerGroupSpec)
+
+	pods := corev1.PodList{}
+	if err := r.List(ctx, &pods, client.InNamespace(rayWorkerGroup.Namespace), client.MatchingLabelsSelector{Selector: rayWorkerGroupSelector(rayWorkerGroup)}); err != nil {
+		return status, err
+	}
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp.IsZero() {
+			status.Replicas++
+		}
+	}
+	return status, nil
+}
+
+// rayWorkerGroupSelector returns the label selector of the Pods of the worker group that a RayWorkerGroup scales
+func rayWorkerGroupSelector(rayWorkerGroup *rayv1.RayWorkerGroup) labels.Selector {
+	return labels.SelectorFromSet(labels.Set{
+		utils.RayClusterLabelKey:   rayWorkerGroup.Spec.RayClusterName,
+		utils.RayNodeGroupLabelKey: rayWorkerGroup.Spec.GroupName,
+		utils.RayNodeTypeLabelKey:  string(rayv1.WorkerNode),
+	})
+}
+
+// rayWorkerGroupsForRayCluster maps a RayCluster to the RayWorkerGroups that scale its worker groups
+func (r *RayWorkerGroupReconciler) rayWorkerGroupsForRayCluster(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.rayWorkerGroupRequests(ctx, obj.GetNamespace(), obj.GetName())
+}
+
+// rayWorkerGroupsForPod maps a worker Pod to the RayWorkerGroups that scale the worker groups of its RayCluster
+func (r *RayWorkerGroupReconciler) rayWorkerGroupsForPod(ctx context.Context, obj client.Object) []reconcile.Request {
+	podLabels := obj.GetLabels()
+	if podLabels[utils.RayNodeTypeLabelKey] != string(rayv1.WorkerNode) || podLabels[utils.RayClusterLabelKey] == "" {
+		return nil
+	}
+	return r.rayWorkerGroupRequests(ctx, obj.GetNamespace(), podLabels[utils.RayClusterLabelKey])
+}
+
+func (r *RayWorkerGroupReconciler) rayWorkerGroupRequests(ctx context.Context, namespace string, rayClusterName string) []reconcile.Request {
+	rayWorkerGroups := rayv1.RayWorkerGroupList{}
+	if err := r.List(ctx, &rayWorkerGroups, client.InNamespace(namespace), client.MatchingFields{rayWorkerGroupRayClusterNameIndexField: rayClusterName}); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to list RayWorkerGroups", "RayCluster", rayClusterName)
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(rayWorkerGroups.Items))
+	for _, rayWorkerGroup := range rayWorkerGroups.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: rayWorkerGroup.Namespace, Name: rayWorkerGroup.Name}})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RayWorkerGroupReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int, rateLimiter workqueue.TypedRateLimiter[reconcile.Request], scope utils.Scope) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&rayv1.RayWorkerGroup{}).
+		Watches(&rayv1.RayCluster{}, handler.EnqueueRequestsFromMapFunc(r.rayWorkerGroupsForRayCluster)).
+		// Watch the worker Pods to keep the replicas in the RayWorkerGroup status up to date.
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.rayWorkerGroupsForPod)).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: reconcileConcurrency,
+			RateLimiter:             rateLimiter,
+			LogConstructor: func(request *reconcile.Request) logr.Logger {
+				logger := ctrl.Log.WithName("controllers").WithName("RayWorkerGroup")
+				if request != nil {
+					logger = logger.WithValues("RayWorkerGroup", request.NamespacedName)
+				}
+				return logger
+			},
+		})
+	return scope.Complete(b, mgr, &rayv1.RayWorkerGroup{}, r)
+}
@@ -0,0 +1,168 @@
+package ray
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func newRayWorkerGroupTestObjects(replicas *int32, enableInTreeAutoscaling bool) (*rayv1.RayWorkerGroup, *rayv1.RayCluster, []runtime.Object) {
+	rayWorkerGroup := &rayv1.RayWorkerGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "workers", Namespace: "default", Generation: 2},
+		Spec:       rayv1.RayWorkerGroupSpec{RayClusterName: "raycluster", GroupName: "cpu", Replicas: replicas},
+	}
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"},
+		Spec: rayv1.RayClusterSpec{
+			EnableInTreeAutoscaling: ptr.To(enableInTreeAutoscaling),
+			WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+				{GroupName: "gpu", Replicas: ptr.To[int32](1), MinReplicas: ptr.To[int32](0), MaxReplicas: ptr.To[int32](1)},
+				{GroupName: "cpu", Replicas: ptr.To[int32](1), MinReplicas: ptr.To[int32](1), MaxReplicas: ptr.To[int32](5)},
+			},
+		},
+	}
+	newPod := func(name, groupName string, deleting bool) *corev1.Pod {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:   "raycluster",
+				utils.RayNodeGroupLabelKey: groupName,
+				utils.RayNodeTypeLabelKey:  string(rayv1.WorkerNode),
+			},
+		}}
+		if deleting {
+			pod.DeletionTimestamp = ptr.To(metav1.Now())
+			pod.Finalizers = []string{"test"}
+		}
+		return pod
+	}
+	objects := []runtime.Object{
+		rayWorkerGroup,
+		rayCluster,
+		newPod("cpu-1", "cpu", false),
+		newPod("cpu-2", "cpu", true),
+		newPod("gpu-1", "gpu", false),
+	}
+	return rayWorkerGroup, rayCluster, objects
+}
+
+func newRayWorkerGroupTestReconciler(objects []runtime.Object) *RayWorkerGroupReconciler {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	fakeClient := clientFake.NewClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(objects...).
+		WithStatusSubresource(&rayv1.RayWorkerGroup{}).
+		WithIndex(&rayv1.RayWorkerGroup{}, rayWorkerGroupRayClusterNameIndexField, func(rawObj client.Object) []string {
+			return []string{rawObj.(*rayv1.RayWorkerGroup).Spec.RayClusterName}
+		}).
+		Build()
+	return &RayWorkerGroupReconciler{
+		Client:   fakeClient,
+		Scheme:   newScheme,
+		Recorder: record.NewFakeRecorder(100),
+	}
+}
+
+func TestRayWorkerGroupReconcile(t *testing.T) {
+	tests := []struct {
+		replicas                *int32
+		name                    string
+		expectedReason          string
+		expectedClusterReplicas int32
+		expectedDesiredReplicas int32
+		enableInTreeAutoscaling bool
+	}{
+		{
+			name:                    "scale up",
+			replicas:                ptr.To[int32](3),
+			expectedClusterReplicas: 3,
+			expectedDesiredReplicas: 3,
+		},
+		{
+			name:                    "scale beyond maxReplicas",
+			replicas:                ptr.To[int32](10),
+			expectedClusterReplicas: 10,
+			expectedDesiredReplicas: 5,
+		},
+		{
+			name:                    "replicas not set",
+			expectedClusterReplicas: 1,
+			expectedDesiredReplicas: 1,
+		},
+		{
+			name:                    "in-tree autoscaling enabled",
+			replicas:                ptr.To[int32](3),
+			enableInTreeAutoscaling: true,
+			expectedClusterReplicas: 1,
+			expectedDesiredReplicas: 1,
+			expectedReason:          "RayCluster raycluster has in-tree autoscaling enabled, the Ray autoscaler scales its worker groups",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			rayWorkerGroup, rayCluster, objects := newRayWorkerGroupTestObjects(tc.replicas, tc.enableInTreeAutoscaling)
+			r := newRayWorkerGroupTestReconciler(objects)
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: rayWorkerGroup.Name}})
+			require.NoError(t, err)
+
+			require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(rayCluster), rayCluster))
+			assert.Equal(t, tc.expectedClusterReplicas, *rayCluster.Spec.WorkerGroupSpecs[1].Replicas)
+			assert.Equal(t, int32(1), *rayCluster.Spec.WorkerGroupSpecs[0].Replicas)
+
+			require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(rayWorkerGroup), rayWorkerGroup))
+			assert.Equal(t, rayv1.RayWorkerGroupStatus{
+				Selector:           "ray.io/cluster=raycluster,ray.io/group=cpu,ray.io/node-type=worker",
+				Reason:             tc.expectedReason,
+				ObservedGeneration: 2,
+				Replicas:           1,
+				DesiredReplicas:    tc.expectedDesiredReplicas,
+			}, rayWorkerGroup.Status)
+		})
+	}
+}
+
+func TestRayWorkerGroupReconcileMissingWorkerGroup(t *testing.T) {
+	ctx := context.Background()
+	rayWorkerGroup, _, objects := newRayWorkerGroupTestObjects(ptr.To[int32](3), false)
+	rayWorkerGroup.Spec.GroupName = "tpu"
+	r := newRayWorkerGroupTestReconciler(objects)
+
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: rayWorkerGroup.Name}})
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(rayWorkerGroup), rayWorkerGroup))
+	assert.Equal(t, "RayCluster raycluster has no worker group tpu", rayWorkerGroup.Status.Reason)
+	assert.Equal(t, int32(0), rayWorkerGroup.Status.Replicas)
+}
+
+func TestRayWorkerGroupsForPod(t *testing.T) {
+	rayWorkerGroup, _, objects := newRayWorkerGroupTestObjects(nil, false)
+	r := newRayWorkerGroupTestReconciler(objects)
+	expected := []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "default", Name: rayWorkerGroup.Name}}}
+
+	workerPod := objects[2].(*corev1.Pod)
+	assert.Equal(t, expected, r.rayWorkerGroupsForPod(context.Background(), workerPod))
+
+	headPod := workerPod.DeepCopy()
+	headPod.Labels[utils.RayNodeTypeLabelKey] = string(rayv1.HeadNode)
+	assert.Empty(t, r.rayWorkerGroupsForPod(context.Background(), headPod))
+}
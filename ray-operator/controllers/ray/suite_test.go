@@ -122,14 +122,14 @@ var _ = BeforeSuite(func(ctx SpecContext) {
 		},
 	}
 	configs := configapi.Configuration{}
-	err = NewReconciler(ctx, mgr, options, configs).SetupWithManager(mgr, 1)
+	err = NewReconciler(ctx, mgr, options, configs).SetupWithManager(mgr, 1, nil, utils.Scope{})
 	Expect(err).NotTo(HaveOccurred(), "failed to setup RayCluster controller")
 
 	testClientProvider := TestClientProvider{}
-	err = NewRayServiceReconciler(ctx, mgr, testClientProvider).SetupWithManager(mgr, 1)
+	err = NewRayServiceReconciler(ctx, mgr, RayServiceReconcilerOptions{}, testClientProvider).SetupWithManager(mgr, 1, nil, utils.Scope{})
 	Expect(err).NotTo(HaveOccurred(), "failed to setup RayService controller")
 
-	err = NewRayJobReconciler(ctx, mgr, testClientProvider).SetupWithManager(mgr, 1)
+	err = NewRayJobReconciler(ctx, mgr, RayJobReconcilerOptions{}, testClientProvider).SetupWithManager(mgr, 1, nil, utils.Scope{})
 	Expect(err).NotTo(HaveOccurred(), "failed to setup RayJob controller")
 
 	go func() {
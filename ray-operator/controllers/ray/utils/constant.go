@@ -27,6 +27,28 @@ const (
 	NumWorkerGroupsKey                       = "ray.io/num-worker-groups"
 	KubeRayVersion                           = "ray.io/kuberay-version"
 
+	// The ConfigMaps with the RayQuotaLabelKey label define the quota of the Ray Pods of their namespace. Their
+	// RayQuotaCPUKey, RayQuotaMemoryKey and RayQuotaGPUKey keys limit the total requests of the Ray Pods.
+	RayQuotaLabelKey  = "ray.io/quota"
+	RayQuotaCPUKey    = "cpu"
+	RayQuotaMemoryKey = "memory"
+	RayQuotaGPUKey    = "gpu"
+
+	// ShardLabelKey assigns a KubeRay custom resource to a shard of a sharded operator deployment. See Shard.
+	ShardLabelKey = "ray.io/shard"
+
+	// The Pods of a replica of a multi-host worker group share the replica name and replica index labels, and each
+	// Pod has a distinct host index from 0 to numOfHosts-1. See the RayMultiHostIndexing feature gate.
+	RayWorkerReplicaNameLabelKey  = "ray.io/worker-group-replica-name"
+	RayWorkerReplicaIndexLabelKey = "ray.io/replica-index"
+	RayHostIndexLabelKey          = "ray.io/host-index"
+
+	// The worker Pods of worker groups with spot fallback are labeled with the capacity type of the nodes that they are
+	// scheduled on, spot or on-demand.
+	RayNodeCapacityTypeLabelKey = "ray.io/capacity-type"
+	SpotCapacityType            = "spot"
+	OnDemandCapacityType        = "on-demand"
+
 	// In KubeRay, the Ray container must be the first application container in a head or worker Pod.
 	RayContainerIndex = 0
 
@@ -45,9 +67,62 @@ const (
 	// `KUBERAY_GEN_RAY_START_CMD`.
 	RayOverwriteContainerCmdAnnotationKey = "ray.io/overwrite-container-cmd"
 
+	// If this annotation of a RayCluster is set to "true", the KubeRay operator does not add the default head placement
+	// of its configuration to the head Pod.
+	RayDisableHeadPlacementAnnotationKey = "ray.io/disable-head-placement"
+
+	// The hash of the worker group spec that a worker Pod was built from. The RollingUpdate strategy of a worker group
+	// replaces the Pods whose hash differs from the hash of the current worker group spec.
+	RayWorkerGroupPodTemplateHashAnnotationKey = "ray.io/pod-template-hash"
+
+	// The time at which the KubeRay operator started to drain the Ray node of a worker Pod before deleting the Pod.
+	// The operator deletes the Pod once the Ray node is idle or the drain grace period of the worker group has elapsed.
+	RayNodeDrainStartTimeAnnotationKey = "ray.io/drain-start-time"
+
+	// If this annotation of a RayCluster that is being deleted is set to "true", the KubeRay operator skips the
+	// remaining cleanup steps of the RayCluster and removes their finalizers, e.g. when Redis is unreachable.
+	RayForceDeleteAnnotationKey = "ray.io/force-delete"
+
+	// The interval, e.g. "30s", at which the KubeRay operator requeues a RayCluster, RayJob or RayService, which
+	// overrides the requeue interval of the operator for the custom resource.
+	RayRequeueIntervalAnnotationKey = "ray.io/requeue-interval"
+
+	// The Kubernetes annotation that ranks Pods for deletion. When the KubeRay operator scales down a worker group, it
+	// sets the annotation to the number of running tasks and alive actors of the Ray node of each worker Pod, and
+	// deletes the Pods with the lowest cost first.
+	PodDeletionCostAnnotationKey = "controller.kubernetes.io/pod-deletion-cost"
+
+	// The condition of a worker Pod that the KubeRay operator sets to False once the wait-gcs-ready init container gives
+	// up waiting for the head, with the reason why, and back to True once the init container succeeds.
+	RayHeadReachablePodConditionType = "ray.io/head-reachable"
+	HeadServiceUnresolvableReason    = "HeadServiceUnresolvable"
+	GcsServerNotReadyReason          = "GcsServerNotReady"
+	GcsServerReadyReason             = "GcsServerReady"
+
+	// The label of a RayCluster or a RayJob with the name of the Kueue LocalQueue that admits it. See the
+	// KueueIntegration feature gate.
+	KueueQueueNameLabelKey = "kueue.x-k8s.io/queue-name"
+	// The hash of the pod sets of a Kueue Workload. The KubeRay operator recreates a Workload that has not reserved
+	// quota yet when the pod sets of its RayCluster or RayJob change.
+	KueuePodSetsHashAnnotationKey = "ray.io/kueue-pod-sets-hash"
+
+	// The annotations of the Istio sidecar injector and the Linkerd proxy injector that the KubeRay operator sets on
+	// the Pods of a RayCluster with a service mesh. The annotations that users set on the Pod templates take precedence.
+	IstioProxyConfigAnnotationKey          = "proxy.istio.io/config"
+	IstioIncludeInboundPortsAnnotationKey  = "traffic.sidecar.istio.io/includeInboundPorts"
+	IstioExcludeOutboundPortsAnnotationKey = "traffic.sidecar.istio.io/excludeOutboundPorts"
+	LinkerdProxyAwaitAnnotationKey         = "config.linkerd.io/proxy-await"
+	LinkerdSkipInboundPortsAnnotationKey   = "config.linkerd.io/skip-inbound-ports"
+	LinkerdSkipOutboundPortsAnnotationKey  = "config.linkerd.io/skip-outbound-ports"
+	LinkerdProxyAdminShutdownAnnotationKey = "config.alpha.linkerd.io/proxy-admin-shutdown"
+
 	// Finalizers for GCS fault tolerance
 	GCSFaultToleranceRedisCleanupFinalizer = "ray.io/gcs-ft-redis-cleanup-finalizer"
 
+	// Finalizers for the cleanup of the load balancers and the PersistentVolumeClaims of a RayCluster
+	LoadBalancerCleanupFinalizer = "ray.io/load-balancer-cleanup-finalizer"
+	VolumeClaimCleanupFinalizer  = "ray.io/volume-claim-cleanup-finalizer"
+
 	// EnableServeServiceKey is exclusively utilized to indicate if a RayCluster is directly used for serving.
 	// See https://github.com/ray-project/kuberay/pull/1672 for more details.
 	EnableServeServiceKey  = "ray.io/enable-serve-service"
@@ -72,6 +147,10 @@ const (
 	DefaultMetricsPort              = 8080
 	DefaultDashboardAgentListenPort = 52365
 	DefaultServingPort              = 8000
+	// The default range of the ports that the Ray workers listen on, see the min-worker-port and max-worker-port
+	// options of ray start.
+	DefaultMinWorkerPort = 10002
+	DefaultMaxWorkerPort = 19999
 
 	ClientPortName    = "client"
 	RedisPortName     = "redis"
@@ -79,6 +158,10 @@ const (
 	MetricsPortName   = "metrics"
 	ServingPortName   = "serve"
 
+	// The ports of the metrics of the Ray autoscaler and the Ray dashboard, which users add to the Ray head container.
+	AutoscalerMetricsPortName = "as-metrics"
+	DashboardMetricsPortName  = "dash-metrics"
+
 	// The default AppProtocol for Kubernetes service
 	DefaultServiceAppProtocol = "tcp"
 
@@ -88,6 +171,10 @@ const (
 	// The default name for kuberay operator
 	ComponentName = "kuberay-operator"
 
+	// The field manager of the KubeRay operator, which owns the fields that the operator sets on the objects it
+	// creates and applies.
+	FieldManager = "kuberay-operator"
+
 	// The default suffix for Headless Service for multi-host worker groups.
 	// The full name will be of the form "${RayCluster_Name}-headless-worker-svc".
 	HeadlessServiceSuffix = "headless-worker-svc"
@@ -99,6 +186,9 @@ const (
 	RAY_PORT                                = "RAY_PORT"
 	RAY_ADDRESS                             = "RAY_ADDRESS"
 	REDIS_PASSWORD                          = "REDIS_PASSWORD"
+	REDIS_USERNAME                          = "REDIS_USERNAME"
+	RAY_REDIS_ADDRESS                       = "RAY_REDIS_ADDRESS"
+	RAY_REDIS_CA_CERT                       = "RAY_REDIS_CA_CERT"
 	RAY_DASHBOARD_ENABLE_K8S_DISK_USAGE     = "RAY_DASHBOARD_ENABLE_K8S_DISK_USAGE"
 	RAY_EXTERNAL_STORAGE_NS                 = "RAY_external_storage_namespace"
 	RAY_GCS_RPC_SERVER_RECONNECT_TIMEOUT_S  = "RAY_gcs_rpc_server_reconnect_timeout_s"
@@ -111,6 +201,13 @@ const (
 	RAYCLUSTER_DEFAULT_REQUEUE_SECONDS      = 300
 	KUBERAY_GEN_RAY_START_CMD               = "KUBERAY_GEN_RAY_START_CMD"
 
+	// Environment variables for TLS authentication between Ray processes.
+	// Reference: https://docs.ray.io/en/latest/ray-core/configure.html#tls-authentication
+	RAY_USE_TLS         = "RAY_USE_TLS"
+	RAY_TLS_SERVER_CERT = "RAY_TLS_SERVER_CERT"
+	RAY_TLS_SERVER_KEY  = "RAY_TLS_SERVER_KEY"
+	RAY_TLS_CA_CERT     = "RAY_TLS_CA_CERT"
+
 	// Environment variables for RayJob submitter Kubernetes Job.
 	// Example: ray job submit --address=http://$RAY_DASHBOARD_ADDRESS --submission-id=$RAY_JOB_SUBMISSION_ID ...
 	RAY_DASHBOARD_ADDRESS = "RAY_DASHBOARD_ADDRESS"
@@ -121,6 +218,14 @@ const (
 	RAY_CLOUD_INSTANCE_ID = "RAY_CLOUD_INSTANCE_ID"
 	// The value of RAY_NODE_TYPE_NAME is the name of the node group (i.e., the value of the "ray.io/group" label).
 	RAY_NODE_TYPE_NAME = "RAY_NODE_TYPE_NAME"
+	// RAY_ENABLE_AUTOSCALER_V2 enables Autoscaler V2 in the Ray head and the autoscaler containers.
+	RAY_ENABLE_AUTOSCALER_V2 = "RAY_enable_autoscaler_v2"
+
+	// Environment variable of the object spilling configuration of the raylet, which points Ray at the spill volume.
+	// Reference: https://docs.ray.io/en/latest/ray-core/objects/object-spilling.html
+	RAY_OBJECT_SPILLING_CONFIG = "RAY_object_spilling_config"
+	// RAY_POD_NAME is the name of the Pod, which hostPath log and spill volumes are mounted at a subdirectory of.
+	RAY_POD_NAME = "RAY_POD_NAME"
 
 	// This KubeRay operator environment variable is used to determine if random Pod
 	// deletion should be enabled. Note that this only takes effect when autoscaling
@@ -146,12 +251,14 @@ const (
 
 	// Ray core default configurations
 	DefaultWorkerRayGcsReconnectTimeoutS = "600"
+	// DefaultRayGcsReconnectTimeoutS is the default of RAY_gcs_rpc_server_reconnect_timeout_s in Ray.
+	DefaultRayGcsReconnectTimeoutS = 60
 
 	LOCAL_HOST = "127.0.0.1"
 	// Ray FT default readiness probe values
 	DefaultReadinessProbeInitialDelaySeconds = 10
 	DefaultReadinessProbeTimeoutSeconds      = 2
-	// Probe timeout for Head pod needs to be longer as it queries two endpoints (api/local_raylet_healthz & api/gcs_healthz)
+	// Probe timeout for Head pod needs to be longer as it checks the raylet and runs ray health-check for the GCS server
 	DefaultHeadReadinessProbeTimeoutSeconds = 5
 	DefaultReadinessProbePeriodSeconds      = 5
 	DefaultReadinessProbeSuccessThreshold   = 1
@@ -161,21 +268,48 @@ const (
 	// Ray FT default liveness probe values
 	DefaultLivenessProbeInitialDelaySeconds = 30
 	DefaultLivenessProbeTimeoutSeconds      = 2
-	// Probe timeout for Head pod needs to be longer as it queries two endpoints (api/local_raylet_healthz & api/gcs_healthz)
+	// Probe timeout for Head pod needs to be longer as it checks the raylet and runs ray health-check for the GCS server
 	DefaultHeadLivenessProbeTimeoutSeconds = 5
 	DefaultLivenessProbePeriodSeconds      = 5
 	DefaultLivenessProbeSuccessThreshold   = 1
 	DefaultLivenessProbeFailureThreshold   = 120
 
+	// Default startup probe values, which tolerate Ray nodes that take up to 10 minutes to start
+	DefaultStartupProbeInitialDelaySeconds = 10
+	DefaultStartupProbePeriodSeconds       = 5
+	DefaultStartupProbeFailureThreshold    = 120
+
+	// Default backoff of the wait-gcs-ready init container of the worker Pods, which gives up after 10 minutes
+	DefaultWaitForHeadInitialBackoffSeconds = 1
+	DefaultWaitForHeadMaxBackoffSeconds     = 30
+	DefaultWaitForHeadTimeoutSeconds        = 600
+	// The exit codes of the wait-gcs-ready init container when it gives up, because the head service cannot be
+	// resolved or the GCS server is not ready.
+	WaitForHeadUnresolvableExitCode = 2
+	WaitForHeadGcsNotReadyExitCode  = 3
+
+	// Default backoff before the failed worker Pods of a worker group with a recreate policy are recreated
+	DefaultRecreateInitialBackoffSeconds = 10
+	DefaultRecreateMaxBackoffSeconds     = 300
+	// The number of consecutive recreations of the failed worker Pods of a worker group after which the RayCluster
+	// reports the worker group with the CrashLoop condition
+	WorkerGroupCrashLoopThreshold = 5
+
+	// Default spot fallback, which falls back to on-demand nodes for 30 minutes after 3 preemptions
+	DefaultSpotFallbackPreemptionThreshold = 3
+	DefaultSpotFallbackCooldownSeconds     = 1800
+
 	// Ray health check related configurations
 	// Note: Since the Raylet process and the dashboard agent process are fate-sharing,
 	// only one of them needs to be checked. So, RayAgentRayletHealthPath accesses the dashboard agent's API endpoint
 	// to check the health of the Raylet process.
+	// The health of the GCS server is checked with ray health-check, which connects to the GCS server directly instead
+	// of through the dashboard.
 	// TODO (kevin85421): Should we take the dashboard process into account?
-	RayAgentRayletHealthPath  = "api/local_raylet_healthz"
-	RayDashboardGCSHealthPath = "api/gcs_healthz"
-	RayServeProxyHealthPath   = "-/healthz"
-	BaseWgetHealthCommand     = "wget -T %d -q -O- http://localhost:%d/%s | grep success"
+	RayAgentRayletHealthPath = "api/local_raylet_healthz"
+	RayServeProxyHealthPath  = "-/healthz"
+	BaseWgetHealthCommand    = "wget -T %d -q -O- http://localhost:%d/%s | grep success"
+	RayHealthCheckCommand    = "ray health-check --address localhost:%s > /dev/null 2>&1"
 
 	// Finalizers for RayJob
 	RayJobStopJobFinalizer = "ray.io/rayjob-finalizer"
@@ -220,6 +354,13 @@ var (
 	ErrFailedCreateWorkerPod = &errRayClusterReplicaFailure{reason: "FailedCreateWorkerPod"}
 )
 
+// ErrNamespaceQuotaExceeded is the marker used by the calculateStatus() for setting the RayClusterQuotaExceeded condition.
+var ErrNamespaceQuotaExceeded = errors.New("namespace quota exceeded")
+
+// ErrRayJobAlreadySubmitted is returned by SubmitJob if the Ray dashboard already has a Ray job with the same
+// submission ID.
+var ErrRayJobAlreadySubmitted = errors.New("the Ray job has already been submitted")
+
 func RayClusterReplicaFailureReason(err error) string {
 	var failure *errRayClusterReplicaFailure
 	if errors.As(err, &failure) {
@@ -233,23 +374,80 @@ type K8sEventType string
 
 const (
 	// RayCluster event list
-	InvalidRayClusterStatus K8sEventType = "InvalidRayClusterStatus"
+	InvalidRayClusterStatus         K8sEventType = "InvalidRayClusterStatus"
+	RayClusterIdle                  K8sEventType = "RayClusterIdle"
+	DeletedIdleRayCluster           K8sEventType = "DeletedIdleRayCluster"
+	SuspendedIdleRayCluster         K8sEventType = "SuspendedIdleRayCluster"
+	FailedToTerminateIdleRayCluster K8sEventType = "FailedToTerminateIdleRayCluster"
+	BatchSchedulingRejected         K8sEventType = "BatchSchedulingRejected"
+	NamespaceQuotaExceeded          K8sEventType = "NamespaceQuotaExceeded"
+	FailedToCleanupBatchScheduling  K8sEventType = "FailedToCleanupBatchScheduling"
+	RedisConnectionFailed           K8sEventType = "RedisConnectionFailed"
 	// Head Pod event list
 	CreatedHeadPod        K8sEventType = "CreatedHeadPod"
 	FailedToCreateHeadPod K8sEventType = "FailedToCreateHeadPod"
 	DeletedHeadPod        K8sEventType = "DeletedHeadPod"
 	FailedToDeleteHeadPod K8sEventType = "FailedToDeleteHeadPod"
+	HeadPodRestarted      K8sEventType = "HeadPodRestarted"
+	HeadPodRecovered      K8sEventType = "HeadPodRecovered"
 
 	// Worker Pod event list
-	CreatedWorkerPod        K8sEventType = "CreatedWorkerPod"
-	FailedToCreateWorkerPod K8sEventType = "FailedToCreateWorkerPod"
-	DeletedWorkerPod        K8sEventType = "DeletedWorkerPod"
-	FailedToDeleteWorkerPod K8sEventType = "FailedToDeleteWorkerPod"
+	CreatedWorkerPod         K8sEventType = "CreatedWorkerPod"
+	FailedToCreateWorkerPod  K8sEventType = "FailedToCreateWorkerPod"
+	DeletedWorkerPod         K8sEventType = "DeletedWorkerPod"
+	FailedToDeleteWorkerPod  K8sEventType = "FailedToDeleteWorkerPod"
+	DrainingWorkerPod        K8sEventType = "DrainingWorkerPod"
+	WorkerPodHeadUnreachable K8sEventType = "WorkerPodHeadUnreachable"
+	WorkerGroupCrashLoop     K8sEventType = "WorkerGroupCrashLoop"
+
+	// Worker group spot fallback event list
+	FellBackToOnDemandNodes K8sEventType = "FellBackToOnDemandNodes"
+	ReturnedToSpotNodes     K8sEventType = "ReturnedToSpotNodes"
+
+	// Worker StatefulSet event list
+	CreatedWorkerStatefulSet        K8sEventType = "CreatedWorkerStatefulSet"
+	FailedToCreateWorkerStatefulSet K8sEventType = "FailedToCreateWorkerStatefulSet"
+	UpdatedWorkerStatefulSet        K8sEventType = "UpdatedWorkerStatefulSet"
+	FailedToUpdateWorkerStatefulSet K8sEventType = "FailedToUpdateWorkerStatefulSet"
+	DeletedWorkerStatefulSet        K8sEventType = "DeletedWorkerStatefulSet"
+	FailedToDeleteWorkerStatefulSet K8sEventType = "FailedToDeleteWorkerStatefulSet"
+
+	// PodDisruptionBudget event list
+	CreatedPodDisruptionBudget        K8sEventType = "CreatedPodDisruptionBudget"
+	FailedToCreatePodDisruptionBudget K8sEventType = "FailedToCreatePodDisruptionBudget"
+	UpdatedPodDisruptionBudget        K8sEventType = "UpdatedPodDisruptionBudget"
+	FailedToUpdatePodDisruptionBudget K8sEventType = "FailedToUpdatePodDisruptionBudget"
+	DeletedPodDisruptionBudget        K8sEventType = "DeletedPodDisruptionBudget"
+	FailedToDeletePodDisruptionBudget K8sEventType = "FailedToDeletePodDisruptionBudget"
+
+	// PersistentVolumeClaim event list
+	CreatedPersistentVolumeClaim        K8sEventType = "CreatedPersistentVolumeClaim"
+	FailedToCreatePersistentVolumeClaim K8sEventType = "FailedToCreatePersistentVolumeClaim"
+	DeletedPersistentVolumeClaims       K8sEventType = "DeletedPersistentVolumeClaims"
+
+	// Certificate event list
+	CreatedCertificate        K8sEventType = "CreatedCertificate"
+	FailedToCreateCertificate K8sEventType = "FailedToCreateCertificate"
+	UpdatedCertificate        K8sEventType = "UpdatedCertificate"
+	FailedToUpdateCertificate K8sEventType = "FailedToUpdateCertificate"
+
+	// Prometheus Operator monitor event list
+	CreatedPrometheusMonitor        K8sEventType = "CreatedPrometheusMonitor"
+	FailedToCreatePrometheusMonitor K8sEventType = "FailedToCreatePrometheusMonitor"
+	UpdatedPrometheusMonitor        K8sEventType = "UpdatedPrometheusMonitor"
+	FailedToUpdatePrometheusMonitor K8sEventType = "FailedToUpdatePrometheusMonitor"
+	DeletedPrometheusMonitor        K8sEventType = "DeletedPrometheusMonitor"
+	FailedToDeletePrometheusMonitor K8sEventType = "FailedToDeletePrometheusMonitor"
 
 	// Redis Cleanup Job event list
 	CreatedRedisCleanupJob        K8sEventType = "CreatedRedisCleanupJob"
 	FailedToCreateRedisCleanupJob K8sEventType = "FailedToCreateRedisCleanupJob"
 
+	// RayCluster cleanup event list
+	CompletedCleanupStep K8sEventType = "CompletedCleanupStep"
+	FailedCleanupStep    K8sEventType = "FailedCleanupStep"
+	SkippedCleanupSteps  K8sEventType = "SkippedCleanupSteps"
+
 	// RayJob event list
 	InvalidRayJobSpec             K8sEventType = "InvalidRayJobSpec"
 	InvalidRayJobStatus           K8sEventType = "InvalidRayJobStatus"
@@ -261,10 +459,33 @@ const (
 	DeletedRayCluster             K8sEventType = "DeletedRayCluster"
 	FailedToCreateRayCluster      K8sEventType = "FailedToCreateRayCluster"
 	FailedToDeleteRayCluster      K8sEventType = "FailedToDeleteRayCluster"
+	DeletedWorkers                K8sEventType = "DeletedWorkers"
+	FailedToDeleteWorkers         K8sEventType = "FailedToDeleteWorkers"
+	CreatedRayJobRun              K8sEventType = "CreatedRayJobRun"
+	DeletedRayJobRun              K8sEventType = "DeletedRayJobRun"
+	FailedToCreateRayJobRun       K8sEventType = "FailedToCreateRayJobRun"
+	FailedToDeleteRayJobRun       K8sEventType = "FailedToDeleteRayJobRun"
+	MissedRayJobSchedule          K8sEventType = "MissedRayJobSchedule"
+	TooManyMissedRayJobSchedules  K8sEventType = "TooManyMissedRayJobSchedules"
+	StoppedRayJob                 K8sEventType = "StoppedRayJob"
+	FailedToStopRayJob            K8sEventType = "FailedToStopRayJob"
+	SubmittedRayJob               K8sEventType = "SubmittedRayJob"
+	FailedToSubmitRayJob          K8sEventType = "FailedToSubmitRayJob"
 
 	// RayService event list
 	InvalidRayServiceSpec K8sEventType = "InvalidRayServiceSpec"
 
+	// RayWorkerGroup event list
+	ScaledWorkerGroup K8sEventType = "ScaledWorkerGroup"
+
+	// Kueue event list
+	InvalidKueueJob             K8sEventType = "InvalidKueueJob"
+	CreatedKueueWorkload        K8sEventType = "CreatedKueueWorkload"
+	FailedToCreateKueueWorkload K8sEventType = "FailedToCreateKueueWorkload"
+	DeletedKueueWorkload        K8sEventType = "DeletedKueueWorkload"
+	AdmittedByKueue             K8sEventType = "AdmittedByKueue"
+	SuspendedByKueue            K8sEventType = "SuspendedByKueue"
+
 	// Generic Pod event list
 	DeletedPod        K8sEventType = "DeletedPod"
 	FailedToDeletePod K8sEventType = "FailedToDeletePod"
@@ -272,6 +493,18 @@ const (
 	// Ingress event list
 	CreatedIngress        K8sEventType = "CreatedIngress"
 	FailedToCreateIngress K8sEventType = "FailedToCreateIngress"
+	UpdatedIngress        K8sEventType = "UpdatedIngress"
+	FailedToUpdateIngress K8sEventType = "FailedToUpdateIngress"
+	DeletedIngress        K8sEventType = "DeletedIngress"
+	FailedToDeleteIngress K8sEventType = "FailedToDeleteIngress"
+
+	// HTTPRoute event list
+	CreatedHTTPRoute        K8sEventType = "CreatedHTTPRoute"
+	FailedToCreateHTTPRoute K8sEventType = "FailedToCreateHTTPRoute"
+	UpdatedHTTPRoute        K8sEventType = "UpdatedHTTPRoute"
+	FailedToUpdateHTTPRoute K8sEventType = "FailedToUpdateHTTPRoute"
+	DeletedHTTPRoute        K8sEventType = "DeletedHTTPRoute"
+	FailedToDeleteHTTPRoute K8sEventType = "FailedToDeleteHTTPRoute"
 
 	// Route event list
 	CreatedRoute        K8sEventType = "CreatedRoute"
@@ -280,6 +513,10 @@ const (
 	// Service event list
 	CreatedService        K8sEventType = "CreatedService"
 	FailedToCreateService K8sEventType = "FailedToCreateService"
+	UpdatedService        K8sEventType = "UpdatedService"
+	FailedToUpdateService K8sEventType = "FailedToUpdateService"
+	DeletedService        K8sEventType = "DeletedService"
+	FailedToDeleteService K8sEventType = "FailedToDeleteService"
 
 	// ServiceAccount event list
 	CreatedServiceAccount            K8sEventType = "CreatedServiceAccount"
@@ -3,9 +3,12 @@ package utils
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/yaml"
@@ -28,6 +31,20 @@ var (
 	DeployPathV2     = "/api/serve/applications/"
 	// Job URL paths
 	JobPath = "/api/jobs/"
+	// State API URL paths
+	NodesPath  = "/api/v0/nodes"
+	TasksPath  = "/api/v0/tasks"
+	ActorsPath = "/api/v0/actors"
+	// Cluster status URL path
+	ClusterStatusPath = "/api/cluster_status"
+)
+
+const (
+	// DefaultDashboardClientTimeout is the default timeout of the requests to the Ray dashboards.
+	DefaultDashboardClientTimeout = 2 * time.Second
+	// dashboardClientRetryBackoff is the delay before the first retry of a failed request to a Ray dashboard. The
+	// delay doubles on every subsequent retry of the same request.
+	dashboardClientRetryBackoff = 100 * time.Millisecond
 )
 
 type RayDashboardClientInterface interface {
@@ -43,6 +60,10 @@ type RayDashboardClientInterface interface {
 	GetJobLog(ctx context.Context, jobName string) (*string, error)
 	StopJob(ctx context.Context, jobName string) error
 	DeleteJob(ctx context.Context, jobName string) error
+	// State API
+	GetNodeWorkload(ctx context.Context, nodeIP string) (*RayNodeWorkload, error)
+	GetClusterWorkload(ctx context.Context) (*RayClusterWorkload, error)
+	GetClusterUtilization(ctx context.Context) (*RayClusterUtilization, error)
 }
 
 type BaseDashboardClient struct {
@@ -50,11 +71,30 @@ type BaseDashboardClient struct {
 	dashboardURL string
 }
 
+// DashboardClientOptions configures the HTTP clients of the Ray dashboards.
+type DashboardClientOptions struct {
+	// TLSConfig is used to connect to the Ray dashboards with HTTPS, e.g. when they are behind a TLS proxy. The Ray
+	// dashboards are connected to with HTTP if it is nil. It is ignored if the Kubernetes proxy is used.
+	TLSConfig *tls.Config
+	// Timeout is the timeout of each request, including its retries. DefaultDashboardClientTimeout is used if it is 0.
+	Timeout time.Duration
+	// MaxRetries is the number of times that a GET request that fails with a connection error or a 5xx status code is
+	// retried. Other requests are not retried, because they may not be idempotent.
+	MaxRetries int
+}
+
 func GetRayDashboardClientFunc(mgr ctrl.Manager, useKubernetesProxy bool) func() RayDashboardClientInterface {
+	return GetRayDashboardClientFuncWithOptions(mgr, useKubernetesProxy, DashboardClientOptions{})
+}
+
+// GetRayDashboardClientFuncWithOptions returns a function that creates the clients of the Ray dashboards with the
+// given options, which all the controllers share.
+func GetRayDashboardClientFuncWithOptions(mgr ctrl.Manager, useKubernetesProxy bool, options DashboardClientOptions) func() RayDashboardClientInterface {
 	return func() RayDashboardClientInterface {
 		return &RayDashboardClient{
 			mgr:                mgr,
 			useKubernetesProxy: useKubernetesProxy,
+			options:            options,
 		}
 	}
 }
@@ -62,9 +102,48 @@ func GetRayDashboardClientFunc(mgr ctrl.Manager, useKubernetesProxy bool) func()
 type RayDashboardClient struct {
 	mgr ctrl.Manager
 	BaseDashboardClient
+	options            DashboardClientOptions
 	useKubernetesProxy bool
 }
 
+// retryRoundTripper retries the GET requests that fail with a connection error or a 5xx status code, e.g. while the
+// Ray dashboard restarts, with an exponential backoff.
+type retryRoundTripper struct {
+	// base sends the requests. If it is nil, http.DefaultTransport is used.
+	base       http.RoundTripper
+	maxRetries int
+}
+
+func (t *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	backoff := dashboardClientRetryBackoff
+	for retries := 0; retries < t.maxRetries && req.Method == http.MethodGet && (err != nil || resp.StatusCode >= http.StatusInternalServerError); retries++ {
+		if err == nil {
+			resp.Body.Close()
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		resp, err = base.RoundTrip(req)
+	}
+	return resp, err
+}
+
+// withRetries wraps the transport of the HTTP client so that it retries failed GET requests if MaxRetries is set.
+func (r *RayDashboardClient) withRetries(transport http.RoundTripper) http.RoundTripper {
+	if r.options.MaxRetries <= 0 {
+		return transport
+	}
+	return &retryRoundTripper{base: transport, maxRetries: r.options.MaxRetries}
+}
+
 // FetchHeadServiceURL fetches the URL that consists of the FQDN for the RayCluster's head service
 // and the port with the given port name (defaultPortName).
 func FetchHeadServiceURL(ctx context.Context, cli client.Client, rayCluster *rayv1.RayCluster, defaultPortName string) (string, error) {
@@ -122,16 +201,31 @@ func (r *RayDashboardClient) InitClient(ctx context.Context, url string, rayClus
 			}
 		}
 
-		r.client = r.mgr.GetHTTPClient()
+		proxyClient := *r.mgr.GetHTTPClient()
+		proxyClient.Transport = withTracing(r.withRetries(proxyClient.Transport))
+		r.client = &proxyClient
 		r.dashboardURL = fmt.Sprintf("%s/api/v1/namespaces/%s/services/%s:dashboard/proxy", r.mgr.GetConfig().Host, rayCluster.Namespace, headSvcName)
 		return nil
 	}
 
+	timeout := r.options.Timeout
+	if timeout == 0 {
+		timeout = DefaultDashboardClientTimeout
+	}
+	var transport http.RoundTripper
+	scheme := "http://"
+	if r.options.TLSConfig != nil {
+		tlsTransport := http.DefaultTransport.(*http.Transport).Clone()
+		tlsTransport.TLSClientConfig = r.options.TLSConfig
+		transport = tlsTransport
+		scheme = "https://"
+	}
 	r.client = &http.Client{
-		Timeout: 2 * time.Second,
+		Transport: withTracing(r.withRetries(transport)),
+		Timeout:   timeout,
 	}
 
-	r.dashboardURL = "http://" + url
+	r.dashboardURL = scheme + url
 	return nil
 }
 
@@ -314,10 +408,13 @@ func (r *RayDashboardClient) ListJobs(ctx context.Context) (*[]RayJobInfo, error
 	return &jobInfo, nil
 }
 
+// SubmitJob submits the Ray job of the RayJob. It returns a BadRequest error if the RayJob cannot be converted to a
+// job submission request or if the Ray dashboard rejects the request as invalid, so that callers can tell permanent
+// failures apart from transient ones, and ErrRayJobAlreadySubmitted if the Ray job has already been submitted.
 func (r *RayDashboardClient) SubmitJob(ctx context.Context, rayJob *rayv1.RayJob) (jobId string, err error) {
 	request, err := ConvertRayJobToReq(rayJob)
 	if err != nil {
-		return "", err
+		return "", errors.NewBadRequest(err.Error())
 	}
 	return r.SubmitJobReq(ctx, request, &rayJob.Name)
 }
@@ -346,6 +443,14 @@ func (r *RayDashboardClient) SubmitJobReq(ctx context.Context, request *RayJobRe
 
 	body, _ := io.ReadAll(resp.Body)
 
+	// The Ray dashboard rejects a duplicate submission ID and an invalid request, e.g. an invalid runtime environment,
+	// with a 400 response. Only the latter fails in the same way on every retry.
+	if resp.StatusCode == http.StatusBadRequest {
+		if strings.Contains(string(body), "already exists") {
+			return "", fmt.Errorf("%w: SubmitJob fail: %s %s", ErrRayJobAlreadySubmitted, resp.Status, string(body))
+		}
+		return "", errors.NewBadRequest(fmt.Sprintf("SubmitJob fail: %s %s", resp.Status, string(body)))
+	}
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
 		return "", fmt.Errorf("SubmitJob fail: %s %s", resp.Status, string(body))
 	}
@@ -449,6 +554,171 @@ func (r *RayDashboardClient) DeleteJob(ctx context.Context, jobName string) erro
 	return nil
 }
 
+// rayStateFilter is a filter of the list endpoints of the Ray state API, e.g. node_ip=10.0.0.1
+type rayStateFilter struct {
+	key       string
+	predicate string
+	value     string
+}
+
+// rayStateListResponse is the response of the list endpoints of the Ray state API.
+// Reference to https://github.com/ray-project/ray/blob/master/python/ray/util/state/common.py (ListApiResponse)
+type rayStateListResponse[T any] struct {
+	Msg  string `json:"msg"`
+	Data struct {
+		Result struct {
+			Result []T `json:"result"`
+		} `json:"result"`
+	} `json:"data"`
+	Result bool `json:"result"`
+}
+
+// RayNodeState is a Ray node returned by the /api/v0/nodes endpoint of the Ray state API.
+type RayNodeState struct {
+	NodeID string `json:"node_id"`
+	NodeIP string `json:"node_ip"`
+	State  string `json:"state"`
+}
+
+// RayNodeWorkload is the work that runs on a Ray node. The counts are capped by the default limit of the Ray state API.
+type RayNodeWorkload struct {
+	NodeID          string
+	NumRunningTasks int
+	NumAliveActors  int
+}
+
+// RayClusterWorkload is the work that runs on a Ray cluster. The counts of tasks and actors are capped by the default
+// limit of the Ray state API.
+type RayClusterWorkload struct {
+	NumActiveJobs   int
+	NumRunningTasks int
+	NumAliveActors  int
+}
+
+// IsIdle returns whether no job, task, or actor runs on the Ray cluster.
+func (w *RayClusterWorkload) IsIdle() bool {
+	return w.NumActiveJobs == 0 && w.NumRunningTasks == 0 && w.NumAliveActors == 0
+}
+
+// RayClusterUtilization is the logical resources, e.g. CPU and GPU, of the alive Ray nodes of a Ray cluster, and how
+// much of them the tasks and actors of the Ray cluster use. The resources of the Ray nodes themselves, e.g.
+// node:10.0.0.1, are excluded.
+type RayClusterUtilization struct {
+	Used  map[string]float64
+	Total map[string]float64
+}
+
+// rayClusterStatusResponse is the response of the /api/cluster_status endpoint of the Ray dashboard, whose cluster
+// status is the last load metrics report of the Ray autoscaler.
+type rayClusterStatusResponse struct {
+	Data struct {
+		ClusterStatus *struct {
+			LoadMetricsReport struct {
+				// Usage maps each resource to how much of it is used and the total amount of it.
+				Usage map[string][]float64 `json:"usage"`
+			} `json:"loadMetricsReport"`
+		} `json:"clusterStatus"`
+	} `json:"data"`
+	Msg    string `json:"msg"`
+	Result bool   `json:"result"`
+}
+
+// listRayState lists the resources of a list endpoint of the Ray state API that match all the filters.
+func listRayState[T any](ctx context.Context, r *RayDashboardClient, path string, filters ...rayStateFilter) ([]T, error) {
+	query := url.Values{}
+	for _, filter := range filters {
+		query.Add("filter_keys", filter.key)
+		query.Add("filter_predicates", filter.predicate)
+		query.Add("filter_values", filter.value)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.dashboardURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("list %s failed with status code %d: %s", path, resp.StatusCode, string(body))
+	}
+
+	var listResp rayStateListResponse[T]
+	if err = json.Unmarshal(body, &listResp); err != nil {
+		return nil, fmt.Errorf("list %s fail: %s", path, string(body))
+	}
+	if !listResp.Result {
+		return nil, fmt.Errorf("list %s fail: %s", path, listResp.Msg)
+	}
+	return listResp.Data.Result.Result, nil
+}
+
+// GetNodeWorkload returns the running tasks and alive actors of the alive Ray node with the given IP,
+// or nil if there is no such node.
+func (r *RayDashboardClient) GetNodeWorkload(ctx context.Context, nodeIP string) (*RayNodeWorkload, error) {
+	nodes, err := listRayState[RayNodeState](ctx, r, NodesPath,
+		rayStateFilter{key: "node_ip", predicate: "=", value: nodeIP},
+		rayStateFilter{key: "state", predicate: "=", value: "ALIVE"})
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+
+	nodeID := nodes[0].NodeID
+	tasks, err := listRayState[map[string]interface{}](ctx, r, TasksPath,
+		rayStateFilter{key: "node_id", predicate: "=", value: nodeID},
+		rayStateFilter{key: "state", predicate: "=", value: "RUNNING"})
+	if err != nil {
+		return nil, err
+	}
+	actors, err := listRayState[map[string]interface{}](ctx, r, ActorsPath,
+		rayStateFilter{key: "node_id", predicate: "=", value: nodeID},
+		rayStateFilter{key: "state", predicate: "=", value: "ALIVE"})
+	if err != nil {
+		return nil, err
+	}
+	return &RayNodeWorkload{NodeID: nodeID, NumRunningTasks: len(tasks), NumAliveActors: len(actors)}, nil
+}
+
+// GetClusterWorkload returns the non-terminal jobs, running tasks, and alive actors of the Ray cluster.
+func (r *RayDashboardClient) GetClusterWorkload(ctx context.Context) (*RayClusterWorkload, error) {
+	jobs, err := r.ListJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workload := &RayClusterWorkload{}
+	if jobs != nil {
+		for _, job := range *jobs {
+			if !rayv1.IsJobTerminal(job.JobStatus) {
+				workload.NumActiveJobs++
+			}
+		}
+	}
+
+	tasks, err := listRayState[map[string]interface{}](ctx, r, TasksPath,
+		rayStateFilter{key: "state", predicate: "=", value: "RUNNING"})
+	if err != nil {
+		return nil, err
+	}
+	actors, err := listRayState[map[string]interface{}](ctx, r, ActorsPath,
+		rayStateFilter{key: "state", predicate: "=", value: "ALIVE"})
+	if err != nil {
+		return nil, err
+	}
+	workload.NumRunningTasks = len(tasks)
+	workload.NumAliveActors = len(actors)
+	return workload, nil
+}
+
 func ConvertRayJobToReq(rayJob *rayv1.RayJob) (*RayJobRequest, error) {
 	req := &RayJobRequest{
 		Entrypoint:   rayJob.Spec.Entrypoint,
@@ -480,3 +750,47 @@ func UnmarshalRuntimeEnvYAML(runtimeEnvYAML string) (RuntimeEnvType, error) {
 	}
 	return runtimeEnv, nil
 }
+
+// GetClusterUtilization returns the resource utilization of the Ray cluster, or nil if the Ray autoscaler has not
+// reported it, e.g. because the RayCluster does not enable autoscaling.
+func (r *RayDashboardClient) GetClusterUtilization(ctx context.Context) (*RayClusterUtilization, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.dashboardURL+ClusterStatusPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GetClusterUtilization fail: %s %s", resp.Status, string(body))
+	}
+
+	var statusResp rayClusterStatusResponse
+	if err = json.Unmarshal(body, &statusResp); err != nil {
+		return nil, fmt.Errorf("GetClusterUtilization fail: %s", string(body))
+	}
+	if !statusResp.Result {
+		return nil, fmt.Errorf("GetClusterUtilization fail: %s", statusResp.Msg)
+	}
+	if statusResp.Data.ClusterStatus == nil || len(statusResp.Data.ClusterStatus.LoadMetricsReport.Usage) == 0 {
+		return nil, nil
+	}
+
+	utilization := &RayClusterUtilization{Used: map[string]float64{}, Total: map[string]float64{}}
+	for name, usage := range statusResp.Data.ClusterStatus.LoadMetricsReport.Usage {
+		if strings.HasPrefix(name, "node:") || len(usage) != 2 {
+			continue
+		}
+		utilization.Used[name] = usage[0]
+		utilization.Total[name] = usage[1]
+	}
+	return utilization, nil
+}
@@ -2,12 +2,17 @@ package utils
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 
 	"github.com/jarcoal/httpmock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
@@ -129,6 +134,36 @@ var _ = Describe("RayFrameworkGenerator", func() {
 		Expect(err.Error()).To(ContainSubstring("Ray misbehaved"))
 	})
 
+	It("Test submit job rejected by the Ray dashboard", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("POST", rayDashboardClient.dashboardURL+JobPath,
+			func(_ *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(400, "invalid runtime_env"), nil
+			})
+
+		_, err := rayDashboardClient.SubmitJob(context.TODO(), rayJob)
+		Expect(err).To(HaveOccurred())
+		Expect(errors.IsBadRequest(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("invalid runtime_env"))
+
+		httpmock.RegisterResponder("POST", rayDashboardClient.dashboardURL+JobPath,
+			func(_ *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(400, "Job with submission_id already exists"), nil
+			})
+		_, err = rayDashboardClient.SubmitJob(context.TODO(), rayJob)
+		Expect(err).To(MatchError(ErrRayJobAlreadySubmitted))
+		Expect(errors.IsBadRequest(err)).To(BeFalse())
+
+		httpmock.RegisterResponder("POST", rayDashboardClient.dashboardURL+JobPath,
+			func(_ *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(503, "Service Unavailable"), nil
+			})
+		_, err = rayDashboardClient.SubmitJob(context.TODO(), rayJob)
+		Expect(err).To(HaveOccurred())
+		Expect(errors.IsBadRequest(err)).To(BeFalse())
+	})
+
 	It("Test stop job", func() {
 		httpmock.Activate()
 		defer httpmock.DeactivateAndReset()
@@ -171,4 +206,150 @@ var _ = Describe("RayFrameworkGenerator", func() {
 		err := rayDashboardClient.StopJob(context.TODO(), "stop-job-1")
 		Expect(err).ToNot(HaveOccurred())
 	})
+
+	It("Test getting the workload of a Ray node", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		listResponse := func(items ...map[string]string) httpmock.Responder {
+			body := map[string]interface{}{
+				"result": true,
+				"msg":    "",
+				"data":   map[string]interface{}{"result": map[string]interface{}{"total": len(items), "result": items}},
+			}
+			bodyBytes, _ := json.Marshal(body)
+			return httpmock.NewBytesResponder(200, bodyBytes)
+		}
+		httpmock.RegisterResponderWithQuery("GET", rayDashboardClient.dashboardURL+NodesPath,
+			"filter_keys=node_ip&filter_keys=state&filter_predicates=%3D&filter_predicates=%3D&filter_values=10.0.0.1&filter_values=ALIVE",
+			listResponse(map[string]string{"node_id": "node-1", "node_ip": "10.0.0.1", "state": "ALIVE"}))
+		httpmock.RegisterResponderWithQuery("GET", rayDashboardClient.dashboardURL+NodesPath,
+			"filter_keys=node_ip&filter_keys=state&filter_predicates=%3D&filter_predicates=%3D&filter_values=10.0.0.2&filter_values=ALIVE",
+			listResponse())
+		httpmock.RegisterResponderWithQuery("GET", rayDashboardClient.dashboardURL+TasksPath,
+			"filter_keys=node_id&filter_keys=state&filter_predicates=%3D&filter_predicates=%3D&filter_values=node-1&filter_values=RUNNING",
+			listResponse(map[string]string{"task_id": "task-1"}, map[string]string{"task_id": "task-2"}))
+		httpmock.RegisterResponderWithQuery("GET", rayDashboardClient.dashboardURL+ActorsPath,
+			"filter_keys=node_id&filter_keys=state&filter_predicates=%3D&filter_predicates=%3D&filter_values=node-1&filter_values=ALIVE",
+			listResponse(map[string]string{"actor_id": "actor-1"}))
+
+		workload, err := rayDashboardClient.GetNodeWorkload(context.TODO(), "10.0.0.1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*workload).To(Equal(RayNodeWorkload{NodeID: "node-1", NumRunningTasks: 2, NumAliveActors: 1}))
+
+		workload, err = rayDashboardClient.GetNodeWorkload(context.TODO(), "10.0.0.2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(workload).To(BeNil())
+	})
+
+	It("Test getting the workload of a Ray cluster", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		listResponse := func(items ...map[string]string) httpmock.Responder {
+			body := map[string]interface{}{
+				"result": true,
+				"msg":    "",
+				"data":   map[string]interface{}{"result": map[string]interface{}{"total": len(items), "result": items}},
+			}
+			bodyBytes, _ := json.Marshal(body)
+			return httpmock.NewBytesResponder(200, bodyBytes)
+		}
+		jobsBytes, _ := json.Marshal([]RayJobInfo{{JobStatus: rayv1.JobStatusSucceeded}, {JobStatus: rayv1.JobStatusRunning}})
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+JobPath, httpmock.NewBytesResponder(200, jobsBytes))
+		httpmock.RegisterResponderWithQuery("GET", rayDashboardClient.dashboardURL+TasksPath,
+			"filter_keys=state&filter_predicates=%3D&filter_values=RUNNING",
+			listResponse(map[string]string{"task_id": "task-1"}))
+		httpmock.RegisterResponderWithQuery("GET", rayDashboardClient.dashboardURL+ActorsPath,
+			"filter_keys=state&filter_predicates=%3D&filter_values=ALIVE",
+			listResponse())
+
+		workload, err := rayDashboardClient.GetClusterWorkload(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*workload).To(Equal(RayClusterWorkload{NumActiveJobs: 1, NumRunningTasks: 1}))
+		Expect(workload.IsIdle()).To(BeFalse())
+	})
+
+	It("Test getting the resource utilization of a Ray cluster", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		body := map[string]interface{}{
+			"result": true,
+			"msg":    "",
+			"data": map[string]interface{}{
+				"clusterStatus": map[string]interface{}{
+					"loadMetricsReport": map[string]interface{}{
+						"usage": map[string][]float64{
+							"CPU":              {1.5, 4},
+							"GPU":              {0, 1},
+							"node:10.0.0.1":    {0, 1},
+							"node:__internal_": {0, 1},
+						},
+					},
+				},
+			},
+		}
+		bodyBytes, _ := json.Marshal(body)
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+ClusterStatusPath, httpmock.NewBytesResponder(200, bodyBytes))
+
+		utilization, err := rayDashboardClient.GetClusterUtilization(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*utilization).To(Equal(RayClusterUtilization{
+			Used:  map[string]float64{"CPU": 1.5, "GPU": 0},
+			Total: map[string]float64{"CPU": 4, "GPU": 1},
+		}))
+
+		// The Ray autoscaler does not report the cluster status if it is not enabled.
+		bodyBytes, _ = json.Marshal(map[string]interface{}{"result": true, "msg": "", "data": map[string]interface{}{"clusterStatus": nil}})
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+ClusterStatusPath, httpmock.NewBytesResponder(200, bodyBytes))
+		utilization, err = rayDashboardClient.GetClusterUtilization(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(utilization).To(BeNil())
+	})
+
+	It("Test retrying failed requests", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		rayDashboardClient = &RayDashboardClient{options: DashboardClientOptions{MaxRetries: 2}}
+		Expect(rayDashboardClient.InitClient(context.Background(), "127.0.0.1:8090", nil)).To(Succeed())
+
+		jobsBytes, _ := json.Marshal([]RayJobInfo{{JobStatus: rayv1.JobStatusRunning}})
+		attempts := 0
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+JobPath,
+			func(_ *http.Request) (*http.Response, error) {
+				attempts++
+				if attempts < 3 {
+					return httpmock.NewStringResponse(503, "the Ray dashboard is restarting"), nil
+				}
+				return httpmock.NewBytesResponse(200, jobsBytes), nil
+			})
+		jobs, err := rayDashboardClient.ListJobs(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*jobs).To(HaveLen(1))
+		Expect(attempts).To(Equal(3))
+
+		// The requests that are not idempotent are not retried.
+		attempts = 0
+		httpmock.RegisterResponder("POST", rayDashboardClient.dashboardURL+JobPath+"stop-job-1/stop",
+			func(_ *http.Request) (*http.Response, error) {
+				attempts++
+				return httpmock.NewStringResponse(503, "the Ray dashboard is restarting"), nil
+			})
+		Expect(rayDashboardClient.StopJob(context.TODO(), "stop-job-1")).ToNot(Succeed())
+		Expect(attempts).To(Equal(1))
+	})
+
+	It("Test connecting to a Ray dashboard with TLS", func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("[]"))
+		}))
+		defer server.Close()
+		rootCAs := x509.NewCertPool()
+		rootCAs.AddCert(server.Certificate())
+
+		rayDashboardClient = &RayDashboardClient{options: DashboardClientOptions{TLSConfig: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}}}
+		Expect(rayDashboardClient.InitClient(context.Background(), strings.TrimPrefix(server.URL, "https://"), nil)).To(Succeed())
+		Expect(rayDashboardClient.dashboardURL).To(Equal(server.URL))
+		jobs, err := rayDashboardClient.ListJobs(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*jobs).To(BeEmpty())
+	})
 })
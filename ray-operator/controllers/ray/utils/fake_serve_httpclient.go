@@ -12,6 +12,16 @@ import (
 type FakeRayDashboardClient struct {
 	multiAppStatuses map[string]*ServeApplicationStatus
 	GetJobInfoMock   atomic.Pointer[func(context.Context, string) (*RayJobInfo, error)]
+	// SubmitJobMock submits the Ray job of a RayJob. Without it, submitting a Ray job always succeeds.
+	SubmitJobMock atomic.Pointer[func(context.Context, *rayv1.RayJob) (string, error)]
+	// StopJobMock stops a Ray job. Without it, stopping a Ray job always succeeds.
+	StopJobMock atomic.Pointer[func(context.Context, string) error]
+	// GetNodeWorkloadMock returns the workload of a Ray node. Without it, no Ray node has any workload.
+	GetNodeWorkloadMock atomic.Pointer[func(context.Context, string) (*RayNodeWorkload, error)]
+	// GetClusterWorkloadMock returns the workload of the Ray cluster. Without it, the Ray cluster is idle.
+	GetClusterWorkloadMock atomic.Pointer[func(context.Context) (*RayClusterWorkload, error)]
+	// GetClusterUtilizationMock returns the resource utilization of the Ray cluster. Without it, it is not reported.
+	GetClusterUtilizationMock atomic.Pointer[func(context.Context) (*RayClusterUtilization, error)]
 	BaseDashboardClient
 	serveDetails ServeDetails
 }
@@ -59,7 +69,10 @@ func (r *FakeRayDashboardClient) ListJobs(ctx context.Context) (*[]RayJobInfo, e
 	return nil, nil
 }
 
-func (r *FakeRayDashboardClient) SubmitJob(_ context.Context, _ *rayv1.RayJob) (jobId string, err error) {
+func (r *FakeRayDashboardClient) SubmitJob(ctx context.Context, rayJob *rayv1.RayJob) (jobId string, err error) {
+	if mock := r.SubmitJobMock.Load(); mock != nil {
+		return (*mock)(ctx, rayJob)
+	}
 	return "", nil
 }
 
@@ -72,10 +85,34 @@ func (r *FakeRayDashboardClient) GetJobLog(_ context.Context, _ string) (*string
 	return &lg, nil
 }
 
-func (r *FakeRayDashboardClient) StopJob(_ context.Context, _ string) (err error) {
+func (r *FakeRayDashboardClient) StopJob(ctx context.Context, jobName string) (err error) {
+	if mock := r.StopJobMock.Load(); mock != nil {
+		return (*mock)(ctx, jobName)
+	}
 	return nil
 }
 
 func (r *FakeRayDashboardClient) DeleteJob(_ context.Context, _ string) error {
 	return nil
 }
+
+func (r *FakeRayDashboardClient) GetNodeWorkload(ctx context.Context, nodeIP string) (*RayNodeWorkload, error) {
+	if mock := r.GetNodeWorkloadMock.Load(); mock != nil {
+		return (*mock)(ctx, nodeIP)
+	}
+	return nil, nil
+}
+
+func (r *FakeRayDashboardClient) GetClusterWorkload(ctx context.Context) (*RayClusterWorkload, error) {
+	if mock := r.GetClusterWorkloadMock.Load(); mock != nil {
+		return (*mock)(ctx)
+	}
+	return &RayClusterWorkload{}, nil
+}
+
+func (r *FakeRayDashboardClient) GetClusterUtilization(ctx context.Context) (*RayClusterUtilization, error) {
+	if mock := r.GetClusterUtilizationMock.Load(); mock != nil {
+		return (*mock)(ctx)
+	}
+	return nil, nil
+}
@@ -0,0 +1,142 @@
+package utils
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// drainNodeMethod is the DrainNode RPC of the autoscaler state service of the GCS server. It stops scheduling
+// new tasks and actors on a Ray node, which is drained by the deadline.
+// https://github.com/ray-project/ray/blob/master/src/ray/protobuf/autoscaler.proto
+const drainNodeMethod = "/ray.rpc.autoscaler.AutoscalerStateService/DrainNode"
+
+// DrainNodeReason is the reason to drain a Ray node.
+type DrainNodeReason int32
+
+const (
+	DrainNodeReasonIdleTermination DrainNodeReason = 1
+	DrainNodeReasonPreemption      DrainNodeReason = 2
+)
+
+// gcsTimeout is the timeout of the requests to the GCS server.
+const gcsTimeout = 5 * time.Second
+
+// DrainRayNode asks the GCS server at address to drain the Ray node with the hex ID nodeID by the deadline. It returns
+// an error if the GCS server rejects the request.
+func DrainRayNode(ctx context.Context, address string, nodeID string, reason DrainNodeReason, reasonMessage string, deadline time.Time) error {
+	binaryNodeID, err := hex.DecodeString(nodeID)
+	if err != nil {
+		return fmt.Errorf("invalid Ray node ID %q: %w", nodeID, err)
+	}
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, gcsTimeout)
+	defer cancel()
+	request := &drainNodeRequest{
+		nodeID:              binaryNodeID,
+		reason:              reason,
+		reasonMessage:       reasonMessage,
+		deadlineTimestampMs: deadline.UnixMilli(),
+	}
+	reply := &drainNodeReply{}
+	if err := conn.Invoke(ctx, drainNodeMethod, request, reply, grpc.ForceCodec(gcsCodec{})); err != nil {
+		return err
+	}
+	if !reply.isAccepted {
+		return fmt.Errorf("the GCS server rejected draining Ray node %s: %s", nodeID, reply.rejectionReasonMessage)
+	}
+	return nil
+}
+
+// drainNodeRequest is the DrainNodeRequest message of autoscaler.proto.
+type drainNodeRequest struct {
+	reasonMessage       string
+	nodeID              []byte
+	deadlineTimestampMs int64
+	reason              DrainNodeReason
+}
+
+func (r *drainNodeRequest) marshal() []byte {
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendBytes(b, r.nodeID)
+	b = protowire.AppendTag(b, 2, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(r.reason))
+	b = protowire.AppendTag(b, 3, protowire.BytesType)
+	b = protowire.AppendString(b, r.reasonMessage)
+	b = protowire.AppendTag(b, 4, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(r.deadlineTimestampMs))
+	return b
+}
+
+// drainNodeReply is the DrainNodeReply message of autoscaler.proto.
+type drainNodeReply struct {
+	rejectionReasonMessage string
+	isAccepted             bool
+}
+
+func (r *drainNodeReply) unmarshal(b []byte) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		switch {
+		case num == 1 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			r.isAccepted = v != 0
+			b = b[n:]
+		case num == 2 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			r.rejectionReasonMessage = v
+			b = b[n:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			b = b[n:]
+		}
+	}
+	return nil
+}
+
+// gcsCodec encodes the messages of the GCS server without the generated Go code of the Ray protobufs.
+type gcsCodec struct{}
+
+func (gcsCodec) Marshal(v interface{}) ([]byte, error) {
+	request, ok := v.(*drainNodeRequest)
+	if !ok {
+		return nil, fmt.Errorf("unexpected request type %T", v)
+	}
+	return request.marshal(), nil
+}
+
+func (gcsCodec) Unmarshal(data []byte, v interface{}) error {
+	reply, ok := v.(*drainNodeReply)
+	if !ok {
+		return fmt.Errorf("unexpected reply type %T", v)
+	}
+	return reply.unmarshal(data)
+}
+
+func (gcsCodec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// rawCodec passes the encoded messages through, so that the fake GCS server can decode them itself.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	return *v.(*[]byte), nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	*v.(*[]byte) = data
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+// startFakeGCSServer starts a GCS server that decodes the fields of the DrainNode requests and replies with reply.
+func startFakeGCSServer(t *testing.T, reply []byte) (string, chan map[protowire.Number]interface{}) {
+	requests := make(chan map[protowire.Number]interface{}, 1)
+	server := grpc.NewServer(grpc.ForceServerCodec(rawCodec{}), grpc.UnknownServiceHandler(func(_ interface{}, stream grpc.ServerStream) error {
+		method, _ := grpc.MethodFromServerStream(stream)
+		if method != drainNodeMethod {
+			return fmt.Errorf("unexpected method %s", method)
+		}
+		var request []byte
+		if err := stream.RecvMsg(&request); err != nil {
+			return err
+		}
+		fields := map[protowire.Number]interface{}{}
+		for len(request) > 0 {
+			num, typ, n := protowire.ConsumeTag(request)
+			request = request[n:]
+			if typ == protowire.VarintType {
+				v, n := protowire.ConsumeVarint(request)
+				fields[num] = v
+				request = request[n:]
+			} else {
+				v, n := protowire.ConsumeBytes(request)
+				fields[num] = v
+				request = request[n:]
+			}
+		}
+		requests <- fields
+		return stream.SendMsg(&reply)
+	}))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String(), requests
+}
+
+func TestDrainRayNode(t *testing.T) {
+	deadline := time.UnixMilli(1718000000000)
+	accepted := protowire.AppendVarint(protowire.AppendTag(nil, 1, protowire.VarintType), 1)
+	address, requests := startFakeGCSServer(t, accepted)
+
+	err := DrainRayNode(context.Background(), address, "0a1b", DrainNodeReasonPreemption, "scale down", deadline)
+	require.NoError(t, err)
+	request := <-requests
+	assert.Equal(t, []byte{0x0a, 0x1b}, request[1])
+	assert.Equal(t, uint64(DrainNodeReasonPreemption), request[2])
+	assert.Equal(t, []byte("scale down"), request[3])
+	assert.Equal(t, uint64(deadline.UnixMilli()), request[4])
+}
+
+func TestDrainRayNodeRejected(t *testing.T) {
+	rejected := protowire.AppendString(protowire.AppendTag(nil, 2, protowire.BytesType), "the node is the head node")
+	address, _ := startFakeGCSServer(t, rejected)
+
+	err := DrainRayNode(context.Background(), address, "0a1b", DrainNodeReasonPreemption, "scale down", time.Now())
+	assert.ErrorContains(t, err, "the node is the head node")
+}
+
+func TestDrainRayNodeInvalidNodeID(t *testing.T) {
+	err := DrainRayNode(context.Background(), "127.0.0.1:0", "not-hex", DrainNodeReasonPreemption, "scale down", time.Now())
+	assert.ErrorContains(t, err, "invalid Ray node ID")
+}
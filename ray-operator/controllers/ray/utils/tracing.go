@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TracerName is the name of the OpenTelemetry tracer of the KubeRay operator. The spans are exported if the
+// operator configures a tracer provider, and are dropped otherwise.
+const TracerName = "github.com/ray-project/kuberay/ray-operator"
+
+// NewTracedReconciler returns a reconciler that records a span for each reconciliation of a custom resource of the
+// given kind. The spans of the requests that the reconciler sends are children of the span of the reconciliation.
+func NewTracedReconciler(r reconcile.Reconciler, kind string) reconcile.Reconciler {
+	return &tracedReconciler{Reconciler: r, kind: kind}
+}
+
+type tracedReconciler struct {
+	reconcile.Reconciler
+	kind string
+}
+
+func (r *tracedReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	ctx, span := otel.Tracer(TracerName).Start(ctx, "Reconcile "+r.kind, trace.WithAttributes(
+		attribute.String("kuberay.namespace", request.Namespace),
+		attribute.String("kuberay.name", request.Name),
+	))
+	defer span.End()
+
+	result, err := r.Reconciler.Reconcile(ctx, request)
+	span.SetAttributes(attribute.String("kuberay.requeue_after", result.RequeueAfter.String()))
+	endSpan(span, err)
+	return result, err
+}
+
+// NewTracedClient returns a client that records a span for each request that creates, updates, patches or deletes an
+// object. Reads are not traced, because they are served by the informer cache.
+func NewTracedClient(c client.Client) client.Client {
+	return &tracedClient{Client: c}
+}
+
+type tracedClient struct {
+	client.Client
+}
+
+func (c *tracedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	ctx, span := startObjectSpan(ctx, c.Client, "Create", obj)
+	defer span.End()
+	return endSpan(span, c.Client.Create(ctx, obj, opts...))
+}
+
+func (c *tracedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	ctx, span := startObjectSpan(ctx, c.Client, "Update", obj)
+	defer span.End()
+	return endSpan(span, c.Client.Update(ctx, obj, opts...))
+}
+
+func (c *tracedClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	ctx, span := startObjectSpan(ctx, c.Client, "Patch", obj)
+	defer span.End()
+	return endSpan(span, c.Client.Patch(ctx, obj, patch, opts...))
+}
+
+func (c *tracedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	ctx, span := startObjectSpan(ctx, c.Client, "Delete", obj)
+	defer span.End()
+	return endSpan(span, c.Client.Delete(ctx, obj, opts...))
+}
+
+func (c *tracedClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	ctx, span := startObjectSpan(ctx, c.Client, "DeleteAllOf", obj)
+	defer span.End()
+	return endSpan(span, c.Client.DeleteAllOf(ctx, obj, opts...))
+}
+
+func (c *tracedClient) Status() client.SubResourceWriter {
+	return &tracedSubResourceWriter{SubResourceWriter: c.Client.Status(), client: c.Client, subResource: "status"}
+}
+
+func (c *tracedClient) SubResource(subResource string) client.SubResourceClient {
+	return &tracedSubResourceClient{SubResourceClient: c.Client.SubResource(subResource), client: c.Client, subResource: subResource}
+}
+
+// tracedSubResourceWriter records a span for each request that updates or patches a subresource of an object.
+type tracedSubResourceWriter struct {
+	client.SubResourceWriter
+	client      client.Client
+	subResource string
+}
+
+func (w *tracedSubResourceWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	ctx, span := startObjectSpan(ctx, w.client, "Update "+w.subResource+" of", obj)
+	defer span.End()
+	return endSpan(span, w.SubResourceWriter.Update(ctx, obj, opts...))
+}
+
+func (w *tracedSubResourceWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	ctx, span := startObjectSpan(ctx, w.client, "Patch "+w.subResource+" of", obj)
+	defer span.End()
+	return endSpan(span, w.SubResourceWriter.Patch(ctx, obj, patch, opts...))
+}
+
+type tracedSubResourceClient struct {
+	client.SubResourceClient
+	client      client.Client
+	subResource string
+}
+
+func (c *tracedSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	ctx, span := startObjectSpan(ctx, c.client, "Update "+c.subResource+" of", obj)
+	defer span.End()
+	return endSpan(span, c.SubResourceClient.Update(ctx, obj, opts...))
+}
+
+func (c *tracedSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	ctx, span := startObjectSpan(ctx, c.client, "Patch "+c.subResource+" of", obj)
+	defer span.End()
+	return endSpan(span, c.SubResourceClient.Patch(ctx, obj, patch, opts...))
+}
+
+// startObjectSpan starts the span of a request for an object, which is named after the verb and the kind of the object,
+// e.g. "Create Pod".
+func startObjectSpan(ctx context.Context, c client.Client, verb string, obj client.Object) (context.Context, trace.Span) {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		kind = gvk.Kind
+	}
+	return otel.Tracer(TracerName).Start(ctx, verb+" "+kind, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("kuberay.namespace", obj.GetNamespace()),
+		attribute.String("kuberay.name", obj.GetName()),
+	))
+}
+
+// endSpan records the error of a span, if any, and returns it.
+func endSpan(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// withTracing wraps the transport of an HTTP client so that it records a span for each request. If transport is nil,
+// http.DefaultTransport is used like in http.Client.
+func withTracing(transport http.RoundTripper) http.RoundTripper {
+	if transport == nil {
+		transport = defaultTransport{}
+	}
+	return otelhttp.NewTransport(transport)
+}
+
+// defaultTransport sends the requests with the http.DefaultTransport at the time of the request.
+type defaultTransport struct{}
+
+func (defaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// setupTracing records the spans of the test in memory.
+func setupTracing(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestTracedReconciler(t *testing.T) {
+	recorder := setupTracing(t)
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "raycluster-head", Namespace: "default"}}
+	fakeClient := NewTracedClient(clientFake.NewClientBuilder().WithScheme(scheme.Scheme).Build())
+	reconciler := NewTracedReconciler(reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+		if err := fakeClient.Create(ctx, pod.DeepCopy()); err != nil {
+			return reconcile.Result{}, err
+		}
+		// The Pod already exists.
+		return reconcile.Result{}, fakeClient.Create(ctx, pod.DeepCopy())
+	}), "RayCluster")
+
+	_, err := reconciler.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "raycluster"}})
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	reconcileSpan := spans[2]
+	assert.Equal(t, "Reconcile RayCluster", reconcileSpan.Name())
+	assert.Equal(t, codes.Error, reconcileSpan.Status().Code)
+	for i, span := range spans[:2] {
+		assert.Equal(t, "Create Pod", span.Name())
+		// The spans of the requests are children of the span of the reconciliation.
+		assert.Equal(t, reconcileSpan.SpanContext().SpanID(), span.Parent().SpanID())
+		if i == 1 {
+			assert.Equal(t, codes.Error, span.Status().Code)
+		} else {
+			assert.Equal(t, codes.Unset, span.Status().Code)
+		}
+	}
+}
+
+func TestTracedClientStatus(t *testing.T) {
+	recorder := setupTracing(t)
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "raycluster-head", Namespace: "default"}}
+	fakeClient := NewTracedClient(clientFake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(pod).WithStatusSubresource(pod).Build())
+
+	pod.Status.Phase = corev1.PodRunning
+	require.NoError(t, fakeClient.Status().Update(context.Background(), pod))
+	require.NoError(t, fakeClient.Delete(context.Background(), pod))
+	require.Error(t, fakeClient.Delete(context.Background(), pod))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "Update status of Pod", spans[0].Name())
+	assert.Equal(t, "Delete Pod", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+}
+
+func TestWithTracing(t *testing.T) {
+	recorder := setupTracing(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: withTracing(nil)}
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "HTTP GET", spans[0].Name())
+}
@@ -32,35 +32,31 @@ go install "${CODEGEN_PKG}"/cmd/lister-gen
 go install "${CODEGEN_PKG}"/cmd/informer-gen
 
 "${GOPATH}"/bin/applyconfiguration-gen \
-  --input-dirs github.com/ray-project/kuberay/ray-operator/apis/ray/v1 \
   --external-applyconfigurations k8s.io/api/core/v1.PodTemplateSpec:k8s.io/client-go/applyconfigurations/core/v1 \
-  --output-package github.com/ray-project/kuberay/ray-operator/pkg/client/applyconfiguration \
+  --output-dir pkg/client/applyconfiguration \
+  --output-pkg github.com/ray-project/kuberay/ray-operator/pkg/client/applyconfiguration \
   --go-header-file hack/boilerplate.go.txt \
-  --output-base "${SCRIPT_ROOT}" \
-  --trim-path-prefix ${ROOT_PKG}
+  github.com/ray-project/kuberay/ray-operator/apis/ray/v1
 
 "${GOPATH}"/bin/client-gen \
   --input github.com/ray-project/kuberay/ray-operator/apis/ray/v1 \
   --input-base="" \
   --apply-configuration-package=github.com/ray-project/kuberay/ray-operator/pkg/client/applyconfiguration \
   --clientset-name "versioned"  \
-  --output-package github.com/ray-project/kuberay/ray-operator/pkg/client/clientset \
-  --go-header-file hack/boilerplate.go.txt \
-  --output-base "${SCRIPT_ROOT}" \
-  --trim-path-prefix ${ROOT_PKG}
+  --output-dir pkg/client/clientset \
+  --output-pkg github.com/ray-project/kuberay/ray-operator/pkg/client/clientset \
+  --go-header-file hack/boilerplate.go.txt
 
 "${GOPATH}"/bin/lister-gen \
-  --input-dirs github.com/ray-project/kuberay/ray-operator/apis/ray/v1 \
-  --output-package github.com/ray-project/kuberay/ray-operator/pkg/client/listers \
+  --output-dir pkg/client/listers \
+  --output-pkg github.com/ray-project/kuberay/ray-operator/pkg/client/listers \
   --go-header-file hack/boilerplate.go.txt \
-  --output-base "${SCRIPT_ROOT}" \
-  --trim-path-prefix ${ROOT_PKG}
+  github.com/ray-project/kuberay/ray-operator/apis/ray/v1
 
 "${GOPATH}"/bin/informer-gen \
-  --input-dirs github.com/ray-project/kuberay/ray-operator/apis/ray/v1 \
   --versioned-clientset-package github.com/ray-project/kuberay/ray-operator/pkg/client/clientset/versioned \
   --listers-package github.com/ray-project/kuberay/ray-operator/pkg/client/listers \
-  --output-package github.com/ray-project/kuberay/ray-operator/pkg/client/informers \
+  --output-dir pkg/client/informers \
+  --output-pkg github.com/ray-project/kuberay/ray-operator/pkg/client/informers \
   --go-header-file hack/boilerplate.go.txt \
-  --output-base "${SCRIPT_ROOT}" \
-  --trim-path-prefix ${ROOT_PKG}
+  github.com/ray-project/kuberay/ray-operator/apis/ray/v1
@@ -1,19 +1,28 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/go-logr/zapr"
 	routev1 "github.com/openshift/api/route/v1"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
@@ -27,11 +36,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	k8szap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 	"github.com/ray-project/kuberay/ray-operator/pkg/features"
 	// +kubebuilder:scaffold:imports
@@ -58,12 +69,38 @@ func main() {
 	var leaderElectionNamespace string
 	var probeAddr string
 	var reconcileConcurrency int
+	var rayClusterConcurrency int
+	var rayJobConcurrency int
+	var kubeAPIQPS float64
+	var kubeAPIBurst int
+	var rateLimiterBaseDelay time.Duration
+	var rateLimiterMaxDelay time.Duration
+	var rateLimiterQPS float64
+	var rateLimiterBurst int
+	var shardCount int
+	var shardIndex int
 	var watchNamespace string
+	var watchNamespaceSelector string
 	var forcedClusterUpgrade bool
 	var logFile string
 	var logFileEncoder string
 	var logStdoutEncoder string
 	var useKubernetesProxy bool
+	var enablePodDisruptionBudgets bool
+	var enablePrometheusMonitors bool
+	var enableHeadPlacement bool
+	var dashboardClientTimeout time.Duration
+	var dashboardClientMaxRetries int
+	var dashboardClientCAFile string
+	var enableDashboardClientTLS bool
+	var tracingEndpoint string
+	var enableTracingInsecure bool
+	var enableRayClusterWorkloadStatus bool
+	var rayClusterWorkloadStatusInterval time.Duration
+	var rayClusterRequeueInterval time.Duration
+	var rayJobRequeueInterval time.Duration
+	var rayServiceRequeueInterval time.Duration
+	var statusResyncInterval time.Duration
 	var configFile string
 	var featureGates string
 	var enableBatchScheduler bool
@@ -77,11 +114,29 @@ func main() {
 	flag.StringVar(&leaderElectionNamespace, "leader-election-namespace", "",
 		"Namespace where the leader election resource lives. Defaults to the pod namespace if not set.")
 	flag.IntVar(&reconcileConcurrency, "reconcile-concurrency", configapi.DefaultReconcileConcurrency, "max concurrency for reconciling")
+	flag.IntVar(&rayClusterConcurrency, "ray-cluster-concurrency", 0,
+		"max concurrency for reconciling RayClusters. Defaults to --reconcile-concurrency if not set.")
+	flag.IntVar(&rayJobConcurrency, "ray-job-concurrency", 0,
+		"max concurrency for reconciling RayJobs. Defaults to --reconcile-concurrency if not set.")
+	flag.Float64Var(&kubeAPIQPS, "kube-api-qps", configapi.DefaultKubeAPIQPS, "max QPS of the requests to the Kubernetes API server.")
+	flag.IntVar(&kubeAPIBurst, "kube-api-burst", configapi.DefaultKubeAPIBurst, "max burst of the requests to the Kubernetes API server.")
+	flag.DurationVar(&rateLimiterBaseDelay, "rate-limiter-base-delay", configapi.DefaultRateLimiterBaseDelay,
+		"delay before a failed reconcile request is retried for the first time.")
+	flag.DurationVar(&rateLimiterMaxDelay, "rate-limiter-max-delay", configapi.DefaultRateLimiterMaxDelay,
+		"max delay before a failed reconcile request is retried.")
+	flag.Float64Var(&rateLimiterQPS, "rate-limiter-qps", configapi.DefaultRateLimiterQPS, "max QPS at which each reconciler dequeues requests.")
+	flag.IntVar(&rateLimiterBurst, "rate-limiter-burst", configapi.DefaultRateLimiterBurst, "max burst at which each reconciler dequeues requests.")
+	flag.IntVar(&shardCount, "shard-count", 1,
+		"The number of shards of a sharded operator deployment. Each operator replica reconciles the custom resources of one shard.")
+	flag.IntVar(&shardIndex, "shard-index", 0,
+		"The shard from 0 to shard-count-1 that this operator replica reconciles. A custom resource belongs to the shard in its ray.io/shard label, or otherwise to the shard of the hash of its namespace.")
 	flag.StringVar(
 		&watchNamespace,
 		"watch-namespace",
 		"",
 		"Specify a list of namespaces to watch for custom resources, separated by commas. If left empty, all namespaces will be watched.")
+	flag.StringVar(&watchNamespaceSelector, "watch-namespace-selector", "",
+		"A label selector of the namespaces whose custom resources are reconciled, e.g. ray.io/enabled=true. If left empty, the custom resources of all the watched namespaces are reconciled.")
 	flag.BoolVar(&forcedClusterUpgrade, "forced-cluster-upgrade", false,
 		"(Deprecated) Forced cluster upgrade flag")
 	flag.StringVar(&logFile, "log-file-path", "",
@@ -97,6 +152,36 @@ func main() {
 	flag.StringVar(&configFile, "config", "", "Path to structured config file. Flags are ignored if config file is set.")
 	flag.BoolVar(&useKubernetesProxy, "use-kubernetes-proxy", false,
 		"Use Kubernetes proxy subresource when connecting to the Ray Head node.")
+	flag.BoolVar(&enablePodDisruptionBudgets, "enable-pod-disruption-budgets", false,
+		"Create PodDisruptionBudgets for the head Pod and the worker groups of RayClusters that do not set enablePodDisruptionBudgets.")
+	flag.BoolVar(&enablePrometheusMonitors, "enable-prometheus-monitors", false,
+		"Create Prometheus Operator ServiceMonitors and PodMonitors for the metrics of RayClusters that do not set prometheusMonitors.enabled.")
+	flag.BoolVar(&enableHeadPlacement, "enable-head-placement", false,
+		"Keep the head Pods of RayClusters off spot nodes and spread them across zones, unless a RayCluster sets the ray.io/disable-head-placement annotation to true.")
+	flag.DurationVar(&dashboardClientTimeout, "dashboard-client-timeout", utils.DefaultDashboardClientTimeout,
+		"Timeout of each request to the Ray dashboards, including its retries.")
+	flag.IntVar(&dashboardClientMaxRetries, "dashboard-client-max-retries", 0,
+		"The number of times that a GET request to a Ray dashboard that fails with a connection error or a 5xx status code is retried.")
+	flag.BoolVar(&enableDashboardClientTLS, "enable-dashboard-client-tls", false,
+		"Connect to the Ray dashboards with HTTPS. It is ignored if --use-kubernetes-proxy is set.")
+	flag.StringVar(&dashboardClientCAFile, "dashboard-client-ca-file", "",
+		"Path to the PEM-encoded CA certificates that verify the certificates of the Ray dashboards. The system CA certificates are used if it is not set.")
+	flag.StringVar(&tracingEndpoint, "tracing-endpoint", "",
+		"The host and port of the OTLP gRPC receiver that OpenTelemetry spans are exported to. Tracing is disabled if it is empty.")
+	flag.BoolVar(&enableTracingInsecure, "enable-tracing-insecure", false,
+		"Export OpenTelemetry spans without TLS.")
+	flag.BoolVar(&enableRayClusterWorkloadStatus, "enable-ray-cluster-workload-status", false,
+		"Report the active jobs, running tasks, alive actors, Serve applications and resource utilization of RayClusters in their status.")
+	flag.DurationVar(&rayClusterWorkloadStatusInterval, "ray-cluster-workload-status-interval", configapi.DefaultRayClusterWorkloadStatusInterval,
+		"How often the workload status of a RayCluster is refreshed from its Ray dashboard.")
+	flag.DurationVar(&rayClusterRequeueInterval, "ray-cluster-requeue-interval", configapi.DefaultRayClusterRequeueInterval,
+		"How often a RayCluster is reconciled if nothing else triggers its reconciliation. The RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV environment variable overrides it if it is set.")
+	flag.DurationVar(&rayJobRequeueInterval, "ray-job-requeue-interval", configapi.DefaultRayJobRequeueInterval,
+		"How often the status of a RayJob in progress is polled from its Ray dashboard.")
+	flag.DurationVar(&rayServiceRequeueInterval, "ray-service-requeue-interval", configapi.DefaultRayServiceRequeueInterval,
+		"How often the status of a RayService in progress is polled from its Ray dashboards.")
+	flag.DurationVar(&statusResyncInterval, "status-resync-interval", 0,
+		"How often a RayJob is reconciled while it waits only on changes that the operator watches. If it is 0, such RayJobs are polled at the RayJob requeue interval.")
 	flag.StringVar(&featureGates, "feature-gates", "", "A set of key=value pairs that describe feature gates. E.g. FeatureOne=true,FeatureTwo=false,...")
 
 	opts := k8szap.Options{
@@ -119,14 +204,47 @@ func main() {
 		config.EnableLeaderElection = &enableLeaderElection
 		config.LeaderElectionNamespace = leaderElectionNamespace
 		config.ReconcileConcurrency = reconcileConcurrency
+		config.RayClusterConcurrency = rayClusterConcurrency
+		config.RayJobConcurrency = rayJobConcurrency
+		config.KubeAPIQPS = float32(kubeAPIQPS)
+		config.KubeAPIBurst = kubeAPIBurst
+		config.RateLimiterBaseDelay = metav1.Duration{Duration: rateLimiterBaseDelay}
+		config.RateLimiterMaxDelay = metav1.Duration{Duration: rateLimiterMaxDelay}
+		config.RateLimiterQPS = rateLimiterQPS
+		config.RateLimiterBurst = rateLimiterBurst
+		config.ShardCount = shardCount
+		config.ShardIndex = shardIndex
 		config.WatchNamespace = watchNamespace
+		config.WatchNamespaceSelector = watchNamespaceSelector
 		config.LogFile = logFile
 		config.LogFileEncoder = logFileEncoder
 		config.LogStdoutEncoder = logStdoutEncoder
 		config.EnableBatchScheduler = enableBatchScheduler
 		config.BatchScheduler = batchScheduler
 		config.UseKubernetesProxy = useKubernetesProxy
+		config.EnablePodDisruptionBudgets = enablePodDisruptionBudgets
+		config.EnablePrometheusMonitors = enablePrometheusMonitors
+		config.DashboardClient = &configapi.DashboardClientConfig{
+			Timeout:    metav1.Duration{Duration: dashboardClientTimeout},
+			MaxRetries: dashboardClientMaxRetries,
+			EnableTLS:  enableDashboardClientTLS,
+			CAFile:     dashboardClientCAFile,
+		}
+		if tracingEndpoint != "" {
+			config.Tracing = &configapi.TracingConfig{Endpoint: tracingEndpoint, Insecure: enableTracingInsecure}
+		}
+		config.EnableRayClusterWorkloadStatus = enableRayClusterWorkloadStatus
+		config.RayClusterWorkloadStatusInterval = metav1.Duration{Duration: rayClusterWorkloadStatusInterval}
+		config.RayClusterRequeueInterval = metav1.Duration{Duration: rayClusterRequeueInterval}
+		config.RayJobRequeueInterval = metav1.Duration{Duration: rayJobRequeueInterval}
+		config.RayServiceRequeueInterval = metav1.Duration{Duration: rayServiceRequeueInterval}
+		config.StatusResyncInterval = metav1.Duration{Duration: statusResyncInterval}
+		if enableHeadPlacement {
+			config.HeadPlacement = &configapi.HeadPlacementConfig{}
+		}
 		config.DeleteRayJobAfterJobFinishes = os.Getenv(utils.DELETE_RAYJOB_CR_AFTER_JOB_FINISHES) == "true"
+		// Default the flags that are not set, e.g. the per-controller concurrency to --reconcile-concurrency.
+		configapi.SetDefaults_Configuration(&config)
 	}
 
 	stdoutEncoder, err := newLogEncoder(logStdoutEncoder)
@@ -167,6 +285,17 @@ func main() {
 		exitOnError(err, "batch scheduler configs validation failed")
 	}
 
+	exitOnError(configapi.ValidateShardConfig(config), "shard configs validation failed")
+	exitOnError(configapi.ValidatePodDefaultsConfig(config), "pod defaults configs validation failed")
+	exitOnError(configapi.ValidateDashboardClientConfig(config), "dashboard client configs validation failed")
+	exitOnError(configapi.ValidateRequeueConfig(config), "requeue configs validation failed")
+	exitOnError(configapi.ValidateTracingConfig(config), "tracing configs validation failed")
+	scope, err := config.Scope()
+	exitOnError(err, "watch namespace selector validation failed")
+	if scope.NamespaceSelector != nil {
+		setupLog.Info("Only reconcile custom resources in the namespaces selected by the selector", "selector", config.WatchNamespaceSelector)
+	}
+
 	if err := utilfeature.DefaultMutableFeatureGate.Set(featureGates); err != nil {
 		exitOnError(err, "Unable to set flag gates for known features")
 	}
@@ -183,7 +312,7 @@ func main() {
 		},
 		HealthProbeBindAddress:  config.ProbeAddr,
 		LeaderElection:          *config.EnableLeaderElection,
-		LeaderElectionID:        "ray-operator-leader",
+		LeaderElectionID:        leaderElectionID(config),
 		LeaderElectionNamespace: config.LeaderElectionNamespace,
 	}
 
@@ -215,20 +344,54 @@ func main() {
 	setupLog.Info("Setup manager")
 	restConfig := ctrl.GetConfigOrDie()
 	restConfig.UserAgent = userAgent
+	restConfig.QPS = config.KubeAPIQPS
+	restConfig.Burst = config.KubeAPIBurst
 	mgr, err := ctrl.NewManager(restConfig, options)
 	exitOnError(err, "unable to start manager")
 
 	rayClusterOptions := ray.RayClusterReconcilerOptions{
-		HeadSidecarContainers:   config.HeadSidecarContainers,
-		WorkerSidecarContainers: config.WorkerSidecarContainers,
+		HeadPlacement:              config.HeadPlacement,
+		HeadSidecarContainers:      config.HeadSidecarContainers,
+		WorkerSidecarContainers:    config.WorkerSidecarContainers,
+		PodDefaults:                config.PodDefaults,
+		EnablePodDisruptionBudgets: config.EnablePodDisruptionBudgets,
+		EnablePrometheusMonitors:   config.EnablePrometheusMonitors,
+		RequeueInterval:            config.RayClusterRequeueInterval.Duration,
+	}
+	if config.EnableRayClusterWorkloadStatus {
+		rayClusterOptions.WorkloadStatusInterval = config.RayClusterWorkloadStatusInterval.Duration
 	}
 	ctx := ctrl.SetupSignalHandler()
-	exitOnError(ray.NewReconciler(ctx, mgr, rayClusterOptions, config).SetupWithManager(mgr, config.ReconcileConcurrency),
+	var tracerProvider *sdktrace.TracerProvider
+	if config.Tracing != nil {
+		tracerProvider, err = newTracerProvider(ctx, *config.Tracing)
+		exitOnError(err, "unable to create tracer provider")
+		otel.SetTracerProvider(tracerProvider)
+		setupLog.Info("Export OpenTelemetry spans", "endpoint", config.Tracing.Endpoint)
+	}
+	exitOnError(ray.NewReconciler(ctx, mgr, rayClusterOptions, config).SetupWithManager(mgr, config.RayClusterConcurrency, config.NewRateLimiter(), scope),
 		"unable to create controller", "controller", "RayCluster")
-	exitOnError(ray.NewRayServiceReconciler(ctx, mgr, config).SetupWithManager(mgr, config.ReconcileConcurrency),
+	rayServiceOptions := ray.RayServiceReconcilerOptions{
+		RequeueInterval: config.RayServiceRequeueInterval.Duration,
+	}
+	rayJobOptions := ray.RayJobReconcilerOptions{
+		RequeueInterval:      config.RayJobRequeueInterval.Duration,
+		StatusResyncInterval: config.StatusResyncInterval.Duration,
+	}
+	exitOnError(ray.NewRayServiceReconciler(ctx, mgr, rayServiceOptions, config).SetupWithManager(mgr, config.ReconcileConcurrency, config.NewRateLimiter(), scope),
 		"unable to create controller", "controller", "RayService")
-	exitOnError(ray.NewRayJobReconciler(ctx, mgr, config).SetupWithManager(mgr, config.ReconcileConcurrency),
+	exitOnError(ray.NewRayJobReconciler(ctx, mgr, rayJobOptions, config).SetupWithManager(mgr, config.RayJobConcurrency, config.NewRateLimiter(), scope),
 		"unable to create controller", "controller", "RayJob")
+	if features.Enabled(features.RayWorkerGroup) {
+		exitOnError(ray.NewRayWorkerGroupReconciler(ctx, mgr).SetupWithManager(mgr, config.ReconcileConcurrency, config.NewRateLimiter(), scope),
+			"unable to create controller", "controller", "RayWorkerGroup")
+	}
+	if features.Enabled(features.KueueIntegration) {
+		exitOnError(ray.NewKueueRayClusterReconciler(mgr).SetupWithManager(mgr, config.ReconcileConcurrency, config.NewRateLimiter(), scope),
+			"unable to create controller", "controller", "KueueRayCluster")
+		exitOnError(ray.NewKueueRayJobReconciler(mgr).SetupWithManager(mgr, config.ReconcileConcurrency, config.NewRateLimiter(), scope),
+			"unable to create controller", "controller", "KueueRayJob")
+	}
 
 	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
 		exitOnError((&rayv1.RayCluster{}).SetupWebhookWithManager(mgr),
@@ -236,11 +399,21 @@ func main() {
 	}
 	// +kubebuilder:scaffold:builder
 
+	// Report the number of RayClusters and RayJobs by state from the cache of the manager.
+	metrics.Registry.MustRegister(common.NewRayResourceCollector(mgr.GetClient()))
+
 	exitOnError(mgr.AddHealthzCheck("healthz", healthz.Ping), "unable to set up health check")
 	exitOnError(mgr.AddReadyzCheck("readyz", healthz.Ping), "unable to set up ready check")
 
 	setupLog.Info("starting manager")
-	exitOnError(mgr.Start(ctx), "problem running manager")
+	err = mgr.Start(ctx)
+	// Flush the spans that are not exported yet before the operator exits.
+	if tracerProvider != nil {
+		if err := tracerProvider.Shutdown(context.Background()); err != nil {
+			setupLog.Error(err, "failed to shut down tracer provider")
+		}
+	}
+	exitOnError(err, "problem running manager")
 }
 
 func cacheSelectors() (map[client.Object]cache.ByObject, error) {
@@ -250,11 +423,28 @@ func cacheSelectors() (map[client.Object]cache.ByObject, error) {
 	}
 	selector := labels.NewSelector().Add(*label)
 
+	// KubeRay only reads the ConfigMaps that define the quotas of namespaces.
+	quotaLabel, err := labels.NewRequirement(utils.RayQuotaLabelKey, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	quotaSelector := labels.NewSelector().Add(*quotaLabel)
+
 	return map[client.Object]cache.ByObject{
-		&batchv1.Job{}: {Label: selector},
+		&batchv1.Job{}:      {Label: selector},
+		&corev1.ConfigMap{}: {Label: quotaSelector},
 	}, nil
 }
 
+// leaderElectionID returns the ID of the leader election lease, which is distinct for each shard so that the
+// replicas of all the shards are active at the same time.
+func leaderElectionID(config configapi.Configuration) string {
+	if config.ShardCount > 1 {
+		return fmt.Sprintf("ray-operator-leader-shard-%d", config.ShardIndex)
+	}
+	return "ray-operator-leader"
+}
+
 func exitOnError(err error, msg string, keysAndValues ...interface{}) {
 	if err != nil {
 		setupLog.Error(err, msg, keysAndValues...)
@@ -275,6 +465,26 @@ func decodeConfig(configData []byte, scheme *runtime.Scheme) (configapi.Configur
 	return cfg, nil
 }
 
+// newTracerProvider returns a tracer provider that exports the spans of the KubeRay operator to the OTLP gRPC receiver
+// of the tracing config in batches.
+func newTracerProvider(ctx context.Context, config configapi.TracingConfig) (*sdktrace.TracerProvider, error) {
+	options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.Endpoint)}
+	if config.Insecure {
+		options = append(options, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(utils.ComponentName),
+			semconv.ServiceVersion(utils.KUBERAY_VERSION),
+		)),
+	), nil
+}
+
 // newLogEncoder returns a zapcore.Encoder based on the encoder type ('json' or 'console')
 func newLogEncoder(encoderType string) (zapcore.Encoder, error) {
 	pe := zap.NewProductionEncoderConfig()
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,10 +31,22 @@ kind: Configuration
 					Kind:       "Configuration",
 					APIVersion: "config.ray.io/v1alpha1",
 				},
-				MetricsAddr:          ":8080",
-				ProbeAddr:            ":8082",
-				EnableLeaderElection: ptr.To(true),
-				ReconcileConcurrency: 1,
+				MetricsAddr:                      ":8080",
+				ProbeAddr:                        ":8082",
+				EnableLeaderElection:             ptr.To(true),
+				ReconcileConcurrency:             1,
+				RayClusterConcurrency:            1,
+				RayJobConcurrency:                1,
+				KubeAPIQPS:                       configapi.DefaultKubeAPIQPS,
+				KubeAPIBurst:                     configapi.DefaultKubeAPIBurst,
+				RateLimiterBaseDelay:             metav1.Duration{Duration: configapi.DefaultRateLimiterBaseDelay},
+				RateLimiterMaxDelay:              metav1.Duration{Duration: configapi.DefaultRateLimiterMaxDelay},
+				RateLimiterQPS:                   configapi.DefaultRateLimiterQPS,
+				RateLimiterBurst:                 configapi.DefaultRateLimiterBurst,
+				RayClusterWorkloadStatusInterval: metav1.Duration{Duration: configapi.DefaultRayClusterWorkloadStatusInterval},
+				RayClusterRequeueInterval:        metav1.Duration{Duration: configapi.DefaultRayClusterRequeueInterval},
+				RayJobRequeueInterval:            metav1.Duration{Duration: configapi.DefaultRayJobRequeueInterval},
+				RayServiceRequeueInterval:        metav1.Duration{Duration: configapi.DefaultRayServiceRequeueInterval},
 			},
 			expectErr: false,
 		},
@@ -51,10 +64,22 @@ reconcileConcurrency: 1
 					Kind:       "Configuration",
 					APIVersion: "config.ray.io/v1alpha1",
 				},
-				MetricsAddr:          ":8080",
-				ProbeAddr:            ":8082",
-				EnableLeaderElection: ptr.To(true),
-				ReconcileConcurrency: 1,
+				MetricsAddr:                      ":8080",
+				ProbeAddr:                        ":8082",
+				EnableLeaderElection:             ptr.To(true),
+				ReconcileConcurrency:             1,
+				RayClusterConcurrency:            1,
+				RayJobConcurrency:                1,
+				KubeAPIQPS:                       configapi.DefaultKubeAPIQPS,
+				KubeAPIBurst:                     configapi.DefaultKubeAPIBurst,
+				RateLimiterBaseDelay:             metav1.Duration{Duration: configapi.DefaultRateLimiterBaseDelay},
+				RateLimiterMaxDelay:              metav1.Duration{Duration: configapi.DefaultRateLimiterMaxDelay},
+				RateLimiterQPS:                   configapi.DefaultRateLimiterQPS,
+				RateLimiterBurst:                 configapi.DefaultRateLimiterBurst,
+				RayClusterWorkloadStatusInterval: metav1.Duration{Duration: configapi.DefaultRayClusterWorkloadStatusInterval},
+				RayClusterRequeueInterval:        metav1.Duration{Duration: configapi.DefaultRayClusterRequeueInterval},
+				RayJobRequeueInterval:            metav1.Duration{Duration: configapi.DefaultRayJobRequeueInterval},
+				RayServiceRequeueInterval:        metav1.Duration{Duration: configapi.DefaultRayServiceRequeueInterval},
 			},
 			expectErr: false,
 		},
@@ -78,10 +103,22 @@ workerSidecarContainers:
 					Kind:       "Configuration",
 					APIVersion: "config.ray.io/v1alpha1",
 				},
-				MetricsAddr:          ":8080",
-				ProbeAddr:            ":8082",
-				EnableLeaderElection: ptr.To(true),
-				ReconcileConcurrency: 1,
+				MetricsAddr:                      ":8080",
+				ProbeAddr:                        ":8082",
+				EnableLeaderElection:             ptr.To(true),
+				ReconcileConcurrency:             1,
+				RayClusterConcurrency:            1,
+				RayJobConcurrency:                1,
+				KubeAPIQPS:                       configapi.DefaultKubeAPIQPS,
+				KubeAPIBurst:                     configapi.DefaultKubeAPIBurst,
+				RateLimiterBaseDelay:             metav1.Duration{Duration: configapi.DefaultRateLimiterBaseDelay},
+				RateLimiterMaxDelay:              metav1.Duration{Duration: configapi.DefaultRateLimiterMaxDelay},
+				RateLimiterQPS:                   configapi.DefaultRateLimiterQPS,
+				RateLimiterBurst:                 configapi.DefaultRateLimiterBurst,
+				RayClusterWorkloadStatusInterval: metav1.Duration{Duration: configapi.DefaultRayClusterWorkloadStatusInterval},
+				RayClusterRequeueInterval:        metav1.Duration{Duration: configapi.DefaultRayClusterRequeueInterval},
+				RayJobRequeueInterval:            metav1.Duration{Duration: configapi.DefaultRayJobRequeueInterval},
+				RayServiceRequeueInterval:        metav1.Duration{Duration: configapi.DefaultRayServiceRequeueInterval},
 				HeadSidecarContainers: []corev1.Container{
 					{
 						Name:  "fluentbit",
@@ -97,6 +134,200 @@ workerSidecarContainers:
 			},
 			expectErr: false,
 		},
+		{
+			name: "config file with per-controller concurrency and rate limits",
+			configData: `apiVersion: config.ray.io/v1alpha1
+kind: Configuration
+reconcileConcurrency: 2
+rayClusterConcurrency: 10
+kubeAPIQPS: 50
+kubeAPIBurst: 100
+rateLimiterBaseDelay: 100ms
+rateLimiterMaxDelay: 5m
+rateLimiterQPS: 50
+rateLimiterBurst: 500
+`,
+			expectedConfig: configapi.Configuration{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Configuration",
+					APIVersion: "config.ray.io/v1alpha1",
+				},
+				MetricsAddr:                      ":8080",
+				ProbeAddr:                        ":8082",
+				EnableLeaderElection:             ptr.To(true),
+				ReconcileConcurrency:             2,
+				RayClusterConcurrency:            10,
+				RayJobConcurrency:                2,
+				KubeAPIQPS:                       50,
+				KubeAPIBurst:                     100,
+				RateLimiterBaseDelay:             metav1.Duration{Duration: 100 * time.Millisecond},
+				RateLimiterMaxDelay:              metav1.Duration{Duration: 5 * time.Minute},
+				RateLimiterQPS:                   50,
+				RateLimiterBurst:                 500,
+				RayClusterWorkloadStatusInterval: metav1.Duration{Duration: configapi.DefaultRayClusterWorkloadStatusInterval},
+				RayClusterRequeueInterval:        metav1.Duration{Duration: configapi.DefaultRayClusterRequeueInterval},
+				RayJobRequeueInterval:            metav1.Duration{Duration: configapi.DefaultRayJobRequeueInterval},
+				RayServiceRequeueInterval:        metav1.Duration{Duration: configapi.DefaultRayServiceRequeueInterval},
+			},
+			expectErr: false,
+		},
+		{
+			name: "config file with sharding and watch namespace selector",
+			configData: `apiVersion: config.ray.io/v1alpha1
+kind: Configuration
+watchNamespaceSelector: ray.io/enabled=true
+shardCount: 3
+shardIndex: 1
+`,
+			expectedConfig: configapi.Configuration{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Configuration",
+					APIVersion: "config.ray.io/v1alpha1",
+				},
+				MetricsAddr:                      ":8080",
+				ProbeAddr:                        ":8082",
+				EnableLeaderElection:             ptr.To(true),
+				ReconcileConcurrency:             1,
+				RayClusterConcurrency:            1,
+				RayJobConcurrency:                1,
+				KubeAPIQPS:                       20,
+				KubeAPIBurst:                     30,
+				RateLimiterBaseDelay:             metav1.Duration{Duration: 5 * time.Millisecond},
+				RateLimiterMaxDelay:              metav1.Duration{Duration: 1000 * time.Second},
+				RateLimiterQPS:                   10,
+				RateLimiterBurst:                 100,
+				RayClusterWorkloadStatusInterval: metav1.Duration{Duration: configapi.DefaultRayClusterWorkloadStatusInterval},
+				RayClusterRequeueInterval:        metav1.Duration{Duration: configapi.DefaultRayClusterRequeueInterval},
+				RayJobRequeueInterval:            metav1.Duration{Duration: configapi.DefaultRayJobRequeueInterval},
+				RayServiceRequeueInterval:        metav1.Duration{Duration: configapi.DefaultRayServiceRequeueInterval},
+				WatchNamespaceSelector:           "ray.io/enabled=true",
+				ShardCount:                       3,
+				ShardIndex:                       1,
+			},
+			expectErr: false,
+		},
+		{
+			name: "config file with head placement",
+			configData: `apiVersion: config.ray.io/v1alpha1
+kind: Configuration
+headPlacement:
+  zones:
+  - us-central1-a
+`,
+			expectedConfig: configapi.Configuration{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Configuration",
+					APIVersion: "config.ray.io/v1alpha1",
+				},
+				MetricsAddr:                      ":8080",
+				ProbeAddr:                        ":8082",
+				EnableLeaderElection:             ptr.To(true),
+				ReconcileConcurrency:             1,
+				RayClusterConcurrency:            1,
+				RayJobConcurrency:                1,
+				KubeAPIQPS:                       20,
+				KubeAPIBurst:                     30,
+				RateLimiterBaseDelay:             metav1.Duration{Duration: 5 * time.Millisecond},
+				RateLimiterMaxDelay:              metav1.Duration{Duration: 1000 * time.Second},
+				RateLimiterQPS:                   10,
+				RateLimiterBurst:                 100,
+				RayClusterWorkloadStatusInterval: metav1.Duration{Duration: configapi.DefaultRayClusterWorkloadStatusInterval},
+				RayClusterRequeueInterval:        metav1.Duration{Duration: configapi.DefaultRayClusterRequeueInterval},
+				RayJobRequeueInterval:            metav1.Duration{Duration: configapi.DefaultRayJobRequeueInterval},
+				RayServiceRequeueInterval:        metav1.Duration{Duration: configapi.DefaultRayServiceRequeueInterval},
+				HeadPlacement: &configapi.HeadPlacementConfig{
+					SpotNodeLabels: configapi.DefaultSpotNodeLabels(),
+					Zones:          []string{"us-central1-a"},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "config file with pod defaults",
+			configData: `apiVersion: config.ray.io/v1alpha1
+kind: Configuration
+podDefaults:
+- namespaceSelector: team=ml
+  env:
+  - name: HTTPS_PROXY
+    value: http://proxy:3128
+  imagePullSecrets:
+  - name: ml-registry
+`,
+			expectedConfig: configapi.Configuration{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Configuration",
+					APIVersion: "config.ray.io/v1alpha1",
+				},
+				MetricsAddr:                      ":8080",
+				ProbeAddr:                        ":8082",
+				EnableLeaderElection:             ptr.To(true),
+				ReconcileConcurrency:             1,
+				RayClusterConcurrency:            1,
+				RayJobConcurrency:                1,
+				KubeAPIQPS:                       20,
+				KubeAPIBurst:                     30,
+				RateLimiterBaseDelay:             metav1.Duration{Duration: 5 * time.Millisecond},
+				RateLimiterMaxDelay:              metav1.Duration{Duration: 1000 * time.Second},
+				RateLimiterQPS:                   10,
+				RateLimiterBurst:                 100,
+				RayClusterWorkloadStatusInterval: metav1.Duration{Duration: configapi.DefaultRayClusterWorkloadStatusInterval},
+				RayClusterRequeueInterval:        metav1.Duration{Duration: configapi.DefaultRayClusterRequeueInterval},
+				RayJobRequeueInterval:            metav1.Duration{Duration: configapi.DefaultRayJobRequeueInterval},
+				RayServiceRequeueInterval:        metav1.Duration{Duration: configapi.DefaultRayServiceRequeueInterval},
+				PodDefaults: []configapi.PodDefaults{
+					{
+						NamespaceSelector: "team=ml",
+						Env:               []corev1.EnvVar{{Name: "HTTPS_PROXY", Value: "http://proxy:3128"}},
+						ImagePullSecrets:  []corev1.LocalObjectReference{{Name: "ml-registry"}},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "config file with dashboard client and workload status",
+			configData: `apiVersion: config.ray.io/v1alpha1
+kind: Configuration
+dashboardClient:
+  timeout: 5s
+  maxRetries: 3
+  enableTLS: true
+  caFile: /etc/kuberay/dashboard-ca/ca.crt
+enableRayClusterWorkloadStatus: true
+rayClusterWorkloadStatusInterval: 1m
+`,
+			expectedConfig: configapi.Configuration{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Configuration",
+					APIVersion: "config.ray.io/v1alpha1",
+				},
+				MetricsAddr:           ":8080",
+				ProbeAddr:             ":8082",
+				EnableLeaderElection:  ptr.To(true),
+				ReconcileConcurrency:  1,
+				RayClusterConcurrency: 1,
+				RayJobConcurrency:     1,
+				KubeAPIQPS:            configapi.DefaultKubeAPIQPS,
+				KubeAPIBurst:          configapi.DefaultKubeAPIBurst,
+				RateLimiterBaseDelay:  metav1.Duration{Duration: configapi.DefaultRateLimiterBaseDelay},
+				RateLimiterMaxDelay:   metav1.Duration{Duration: configapi.DefaultRateLimiterMaxDelay},
+				RateLimiterQPS:        configapi.DefaultRateLimiterQPS,
+				RateLimiterBurst:      configapi.DefaultRateLimiterBurst,
+				DashboardClient: &configapi.DashboardClientConfig{
+					Timeout:    metav1.Duration{Duration: 5 * time.Second},
+					MaxRetries: 3,
+					EnableTLS:  true,
+					CAFile:     "/etc/kuberay/dashboard-ca/ca.crt",
+				},
+				EnableRayClusterWorkloadStatus:   true,
+				RayClusterWorkloadStatusInterval: metav1.Duration{Duration: time.Minute},
+				RayClusterRequeueInterval:        metav1.Duration{Duration: configapi.DefaultRayClusterRequeueInterval},
+				RayJobRequeueInterval:            metav1.Duration{Duration: configapi.DefaultRayJobRequeueInterval},
+				RayServiceRequeueInterval:        metav1.Duration{Duration: configapi.DefaultRayServiceRequeueInterval},
+			},
+			expectErr: false,
+		},
 		{
 			name: "unknown filed ignored",
 			configData: `apiVersion: config.ray.io/v1alpha1
@@ -112,10 +343,22 @@ unknownfield: 1
 					Kind:       "Configuration",
 					APIVersion: "config.ray.io/v1alpha1",
 				},
-				MetricsAddr:          ":8080",
-				ProbeAddr:            ":8082",
-				EnableLeaderElection: ptr.To(true),
-				ReconcileConcurrency: 1,
+				MetricsAddr:                      ":8080",
+				ProbeAddr:                        ":8082",
+				EnableLeaderElection:             ptr.To(true),
+				ReconcileConcurrency:             1,
+				RayClusterConcurrency:            1,
+				RayJobConcurrency:                1,
+				KubeAPIQPS:                       configapi.DefaultKubeAPIQPS,
+				KubeAPIBurst:                     configapi.DefaultKubeAPIBurst,
+				RateLimiterBaseDelay:             metav1.Duration{Duration: configapi.DefaultRateLimiterBaseDelay},
+				RateLimiterMaxDelay:              metav1.Duration{Duration: configapi.DefaultRateLimiterMaxDelay},
+				RateLimiterQPS:                   configapi.DefaultRateLimiterQPS,
+				RateLimiterBurst:                 configapi.DefaultRateLimiterBurst,
+				RayClusterWorkloadStatusInterval: metav1.Duration{Duration: configapi.DefaultRayClusterWorkloadStatusInterval},
+				RayClusterRequeueInterval:        metav1.Duration{Duration: configapi.DefaultRayClusterRequeueInterval},
+				RayJobRequeueInterval:            metav1.Duration{Duration: configapi.DefaultRayJobRequeueInterval},
+				RayServiceRequeueInterval:        metav1.Duration{Duration: configapi.DefaultRayServiceRequeueInterval},
 			},
 			expectErr: false,
 		},
@@ -189,3 +432,35 @@ reconcileConcurrency: true
 		})
 	}
 }
+
+func Test_leaderElectionID(t *testing.T) {
+	testcases := []struct {
+		name       string
+		config     configapi.Configuration
+		expectedID string
+	}{
+		{
+			name:       "sharding disabled",
+			config:     configapi.Configuration{},
+			expectedID: "ray-operator-leader",
+		},
+		{
+			name:       "single shard",
+			config:     configapi.Configuration{ShardCount: 1},
+			expectedID: "ray-operator-leader",
+		},
+		{
+			name:       "sharded",
+			config:     configapi.Configuration{ShardCount: 3, ShardIndex: 2},
+			expectedID: "ray-operator-leader-shard-2",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if id := leaderElectionID(testcase.config); id != testcase.expectedID {
+				t.Errorf("unexpected leader election ID %q, expected %q", id, testcase.expectedID)
+			}
+		})
+	}
+}
@@ -6,7 +6,7 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// AppStatusApplyConfiguration represents an declarative configuration of the AppStatus type for use
+// AppStatusApplyConfiguration represents a declarative configuration of the AppStatus type for use
 // with apply.
 type AppStatusApplyConfiguration struct {
 	HealthLastUpdateTime *v1.Time                                           `json:"healthLastUpdateTime,omitempty"`
@@ -15,7 +15,7 @@ type AppStatusApplyConfiguration struct {
 	Message              *string                                            `json:"message,omitempty"`
 }
 
-// AppStatusApplyConfiguration constructs an declarative configuration of the AppStatus type for use with
+// AppStatusApplyConfiguration constructs a declarative configuration of the AppStatus type for use with
 // apply.
 func AppStatus() *AppStatusApplyConfiguration {
 	return &AppStatusApplyConfiguration{}
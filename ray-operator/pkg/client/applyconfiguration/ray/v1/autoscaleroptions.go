@@ -7,7 +7,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 )
 
-// AutoscalerOptionsApplyConfiguration represents an declarative configuration of the AutoscalerOptions type for use
+// AutoscalerOptionsApplyConfiguration represents a declarative configuration of the AutoscalerOptions type for use
 // with apply.
 type AutoscalerOptionsApplyConfiguration struct {
 	Resources          *v1.ResourceRequirements `json:"resources,omitempty"`
@@ -16,12 +16,13 @@ type AutoscalerOptionsApplyConfiguration struct {
 	SecurityContext    *v1.SecurityContext      `json:"securityContext,omitempty"`
 	IdleTimeoutSeconds *int32                   `json:"idleTimeoutSeconds,omitempty"`
 	UpscalingMode      *rayv1.UpscalingMode     `json:"upscalingMode,omitempty"`
+	Version            *rayv1.AutoscalerVersion `json:"version,omitempty"`
 	Env                []v1.EnvVar              `json:"env,omitempty"`
 	EnvFrom            []v1.EnvFromSource       `json:"envFrom,omitempty"`
 	VolumeMounts       []v1.VolumeMount         `json:"volumeMounts,omitempty"`
 }
 
-// AutoscalerOptionsApplyConfiguration constructs an declarative configuration of the AutoscalerOptions type for use with
+// AutoscalerOptionsApplyConfiguration constructs a declarative configuration of the AutoscalerOptions type for use with
 // apply.
 func AutoscalerOptions() *AutoscalerOptionsApplyConfiguration {
 	return &AutoscalerOptionsApplyConfiguration{}
@@ -75,6 +76,14 @@ func (b *AutoscalerOptionsApplyConfiguration) WithUpscalingMode(value rayv1.Upsc
 	return b
 }
 
+// WithVersion sets the Version field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Version field is set to the value of the last call.
+func (b *AutoscalerOptionsApplyConfiguration) WithVersion(value rayv1.AutoscalerVersion) *AutoscalerOptionsApplyConfiguration {
+	b.Version = &value
+	return b
+}
+
 // WithEnv adds the given value to the Env field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Env field.
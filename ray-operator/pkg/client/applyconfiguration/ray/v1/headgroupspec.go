@@ -7,17 +7,23 @@ import (
 	corev1 "k8s.io/client-go/applyconfigurations/core/v1"
 )
 
-// HeadGroupSpecApplyConfiguration represents an declarative configuration of the HeadGroupSpec type for use
+// HeadGroupSpecApplyConfiguration represents a declarative configuration of the HeadGroupSpec type for use
 // with apply.
 type HeadGroupSpecApplyConfiguration struct {
-	ServiceType    *v1.ServiceType                           `json:"serviceType,omitempty"`
-	HeadService    *v1.Service                               `json:"headService,omitempty"`
-	EnableIngress  *bool                                     `json:"enableIngress,omitempty"`
-	RayStartParams map[string]string                         `json:"rayStartParams,omitempty"`
-	Template       *corev1.PodTemplateSpecApplyConfiguration `json:"template,omitempty"`
+	ServiceType       *v1.ServiceType                           `json:"serviceType,omitempty"`
+	HeadService       *v1.Service                               `json:"headService,omitempty"`
+	EnableIngress     *bool                                     `json:"enableIngress,omitempty"`
+	Ingress           *HeadIngressOptionsApplyConfiguration     `json:"ingress,omitempty"`
+	RayStartParams    map[string]string                         `json:"rayStartParams,omitempty"`
+	ResourceClaims    []RayResourceClaimApplyConfiguration      `json:"resourceClaims,omitempty"`
+	LogVolume         *RayVolumeApplyConfiguration              `json:"logVolume,omitempty"`
+	SpillVolume       *RayVolumeApplyConfiguration              `json:"spillVolume,omitempty"`
+	Probes            *RayProbeOptionsApplyConfiguration        `json:"probes,omitempty"`
+	SidecarContainers []v1.Container                            `json:"sidecarContainers,omitempty"`
+	Template          *corev1.PodTemplateSpecApplyConfiguration `json:"template,omitempty"`
 }
 
-// HeadGroupSpecApplyConfiguration constructs an declarative configuration of the HeadGroupSpec type for use with
+// HeadGroupSpecApplyConfiguration constructs a declarative configuration of the HeadGroupSpec type for use with
 // apply.
 func HeadGroupSpec() *HeadGroupSpecApplyConfiguration {
 	return &HeadGroupSpecApplyConfiguration{}
@@ -47,6 +53,14 @@ func (b *HeadGroupSpecApplyConfiguration) WithEnableIngress(value bool) *HeadGro
 	return b
 }
 
+// WithIngress sets the Ingress field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Ingress field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithIngress(value *HeadIngressOptionsApplyConfiguration) *HeadGroupSpecApplyConfiguration {
+	b.Ingress = value
+	return b
+}
+
 // WithRayStartParams puts the entries into the RayStartParams field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the RayStartParams field,
@@ -61,6 +75,53 @@ func (b *HeadGroupSpecApplyConfiguration) WithRayStartParams(entries map[string]
 	return b
 }
 
+// WithResourceClaims adds the given value to the ResourceClaims field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ResourceClaims field.
+func (b *HeadGroupSpecApplyConfiguration) WithResourceClaims(values ...*RayResourceClaimApplyConfiguration) *HeadGroupSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResourceClaims")
+		}
+		b.ResourceClaims = append(b.ResourceClaims, *values[i])
+	}
+	return b
+}
+
+// WithLogVolume sets the LogVolume field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LogVolume field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithLogVolume(value *RayVolumeApplyConfiguration) *HeadGroupSpecApplyConfiguration {
+	b.LogVolume = value
+	return b
+}
+
+// WithSpillVolume sets the SpillVolume field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SpillVolume field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithSpillVolume(value *RayVolumeApplyConfiguration) *HeadGroupSpecApplyConfiguration {
+	b.SpillVolume = value
+	return b
+}
+
+// WithProbes sets the Probes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Probes field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithProbes(value *RayProbeOptionsApplyConfiguration) *HeadGroupSpecApplyConfiguration {
+	b.Probes = value
+	return b
+}
+
+// WithSidecarContainers adds the given value to the SidecarContainers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the SidecarContainers field.
+func (b *HeadGroupSpecApplyConfiguration) WithSidecarContainers(values ...v1.Container) *HeadGroupSpecApplyConfiguration {
+	for i := range values {
+		b.SidecarContainers = append(b.SidecarContainers, values[i])
+	}
+	return b
+}
+
 // WithTemplate sets the Template field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Template field is set to the value of the last call.
@@ -2,16 +2,17 @@
 
 package v1
 
-// HeadInfoApplyConfiguration represents an declarative configuration of the HeadInfo type for use
+// HeadInfoApplyConfiguration represents a declarative configuration of the HeadInfo type for use
 // with apply.
 type HeadInfoApplyConfiguration struct {
-	PodIP       *string `json:"podIP,omitempty"`
-	ServiceIP   *string `json:"serviceIP,omitempty"`
-	PodName     *string `json:"podName,omitempty"`
-	ServiceName *string `json:"serviceName,omitempty"`
+	PodIP        *string `json:"podIP,omitempty"`
+	ServiceIP    *string `json:"serviceIP,omitempty"`
+	PodName      *string `json:"podName,omitempty"`
+	ServiceName  *string `json:"serviceName,omitempty"`
+	DashboardURL *string `json:"dashboardURL,omitempty"`
 }
 
-// HeadInfoApplyConfiguration constructs an declarative configuration of the HeadInfo type for use with
+// HeadInfoApplyConfiguration constructs a declarative configuration of the HeadInfo type for use with
 // apply.
 func HeadInfo() *HeadInfoApplyConfiguration {
 	return &HeadInfoApplyConfiguration{}
@@ -48,3 +49,11 @@ func (b *HeadInfoApplyConfiguration) WithServiceName(value string) *HeadInfoAppl
 	b.ServiceName = &value
 	return b
 }
+
+// WithDashboardURL sets the DashboardURL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DashboardURL field is set to the value of the last call.
+func (b *HeadInfoApplyConfiguration) WithDashboardURL(value string) *HeadInfoApplyConfiguration {
+	b.DashboardURL = &value
+	return b
+}
@@ -8,7 +8,7 @@ import (
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
-// RayClusterApplyConfiguration represents an declarative configuration of the RayCluster type for use
+// RayClusterApplyConfiguration represents a declarative configuration of the RayCluster type for use
 // with apply.
 type RayClusterApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
@@ -17,7 +17,7 @@ type RayClusterApplyConfiguration struct {
 	Status                           *RayClusterStatusApplyConfiguration `json:"status,omitempty"`
 }
 
-// RayCluster constructs an declarative configuration of the RayCluster type for use with
+// RayCluster constructs a declarative configuration of the RayCluster type for use with
 // apply.
 func RayCluster(name, namespace string) *RayClusterApplyConfiguration {
 	b := &RayClusterApplyConfiguration{}
@@ -201,3 +201,9 @@ func (b *RayClusterApplyConfiguration) WithStatus(value *RayClusterStatusApplyCo
 	b.Status = value
 	return b
 }
+
+// GetName retrieves the value of the Name field in the declarative configuration.
+func (b *RayClusterApplyConfiguration) GetName() *string {
+	b.ensureObjectMetaApplyConfigurationExists()
+	return b.Name
+}
@@ -2,19 +2,32 @@
 
 package v1
 
-// RayClusterSpecApplyConfiguration represents an declarative configuration of the RayClusterSpec type for use
+import (
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// RayClusterSpecApplyConfiguration represents a declarative configuration of the RayClusterSpec type for use
 // with apply.
 type RayClusterSpecApplyConfiguration struct {
-	Suspend                 *bool                                `json:"suspend,omitempty"`
-	AutoscalerOptions       *AutoscalerOptionsApplyConfiguration `json:"autoscalerOptions,omitempty"`
-	HeadServiceAnnotations  map[string]string                    `json:"headServiceAnnotations,omitempty"`
-	EnableInTreeAutoscaling *bool                                `json:"enableInTreeAutoscaling,omitempty"`
-	HeadGroupSpec           *HeadGroupSpecApplyConfiguration     `json:"headGroupSpec,omitempty"`
-	RayVersion              *string                              `json:"rayVersion,omitempty"`
-	WorkerGroupSpecs        []WorkerGroupSpecApplyConfiguration  `json:"workerGroupSpecs,omitempty"`
+	Suspend                    *bool                                       `json:"suspend,omitempty"`
+	AutoscalerOptions          *AutoscalerOptionsApplyConfiguration        `json:"autoscalerOptions,omitempty"`
+	HeadServiceAnnotations     map[string]string                           `json:"headServiceAnnotations,omitempty"`
+	EnableInTreeAutoscaling    *bool                                       `json:"enableInTreeAutoscaling,omitempty"`
+	IdleTimeoutSeconds         *int32                                      `json:"idleTimeoutSeconds,omitempty"`
+	EnablePodDisruptionBudgets *bool                                       `json:"enablePodDisruptionBudgets,omitempty"`
+	Security                   *RayClusterSecurityApplyConfiguration       `json:"security,omitempty"`
+	GcsFaultToleranceOptions   *GcsFaultToleranceOptionsApplyConfiguration `json:"gcsFaultToleranceOptions,omitempty"`
+	PrometheusMonitors         *PrometheusMonitorOptionsApplyConfiguration `json:"prometheusMonitors,omitempty"`
+	Logging                    *RayClusterLoggingApplyConfiguration        `json:"logging,omitempty"`
+	ServiceMesh                *ServiceMeshOptionsApplyConfiguration       `json:"serviceMesh,omitempty"`
+	WaitForHead                *WaitForHeadOptionsApplyConfiguration       `json:"waitForHead,omitempty"`
+	HeadGroupSpec              *HeadGroupSpecApplyConfiguration            `json:"headGroupSpec,omitempty"`
+	RayVersion                 *string                                     `json:"rayVersion,omitempty"`
+	IdleTimeoutAction          *rayv1.IdleTimeoutAction                    `json:"idleTimeoutAction,omitempty"`
+	WorkerGroupSpecs           []WorkerGroupSpecApplyConfiguration         `json:"workerGroupSpecs,omitempty"`
 }
 
-// RayClusterSpecApplyConfiguration constructs an declarative configuration of the RayClusterSpec type for use with
+// RayClusterSpecApplyConfiguration constructs a declarative configuration of the RayClusterSpec type for use with
 // apply.
 func RayClusterSpec() *RayClusterSpecApplyConfiguration {
 	return &RayClusterSpecApplyConfiguration{}
@@ -58,6 +71,70 @@ func (b *RayClusterSpecApplyConfiguration) WithEnableInTreeAutoscaling(value boo
 	return b
 }
 
+// WithIdleTimeoutSeconds sets the IdleTimeoutSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IdleTimeoutSeconds field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithIdleTimeoutSeconds(value int32) *RayClusterSpecApplyConfiguration {
+	b.IdleTimeoutSeconds = &value
+	return b
+}
+
+// WithEnablePodDisruptionBudgets sets the EnablePodDisruptionBudgets field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EnablePodDisruptionBudgets field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithEnablePodDisruptionBudgets(value bool) *RayClusterSpecApplyConfiguration {
+	b.EnablePodDisruptionBudgets = &value
+	return b
+}
+
+// WithSecurity sets the Security field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Security field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithSecurity(value *RayClusterSecurityApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.Security = value
+	return b
+}
+
+// WithGcsFaultToleranceOptions sets the GcsFaultToleranceOptions field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GcsFaultToleranceOptions field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithGcsFaultToleranceOptions(value *GcsFaultToleranceOptionsApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.GcsFaultToleranceOptions = value
+	return b
+}
+
+// WithPrometheusMonitors sets the PrometheusMonitors field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PrometheusMonitors field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithPrometheusMonitors(value *PrometheusMonitorOptionsApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.PrometheusMonitors = value
+	return b
+}
+
+// WithLogging sets the Logging field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Logging field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithLogging(value *RayClusterLoggingApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.Logging = value
+	return b
+}
+
+// WithServiceMesh sets the ServiceMesh field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceMesh field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithServiceMesh(value *ServiceMeshOptionsApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.ServiceMesh = value
+	return b
+}
+
+// WithWaitForHead sets the WaitForHead field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WaitForHead field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithWaitForHead(value *WaitForHeadOptionsApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.WaitForHead = value
+	return b
+}
+
 // WithHeadGroupSpec sets the HeadGroupSpec field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the HeadGroupSpec field is set to the value of the last call.
@@ -74,6 +151,14 @@ func (b *RayClusterSpecApplyConfiguration) WithRayVersion(value string) *RayClus
 	return b
 }
 
+// WithIdleTimeoutAction sets the IdleTimeoutAction field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IdleTimeoutAction field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithIdleTimeoutAction(value rayv1.IdleTimeoutAction) *RayClusterSpecApplyConfiguration {
+	b.IdleTimeoutAction = &value
+	return b
+}
+
 // WithWorkerGroupSpecs adds the given value to the WorkerGroupSpecs field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the WorkerGroupSpecs field.
@@ -6,31 +6,36 @@ import (
 	v1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	applyconfigurationsmetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
-// RayClusterStatusApplyConfiguration represents an declarative configuration of the RayClusterStatus type for use
+// RayClusterStatusApplyConfiguration represents a declarative configuration of the RayClusterStatus type for use
 // with apply.
 type RayClusterStatusApplyConfiguration struct {
-	State                   *v1.ClusterState                 `json:"state,omitempty"`
-	DesiredCPU              *resource.Quantity               `json:"desiredCPU,omitempty"`
-	DesiredMemory           *resource.Quantity               `json:"desiredMemory,omitempty"`
-	DesiredGPU              *resource.Quantity               `json:"desiredGPU,omitempty"`
-	DesiredTPU              *resource.Quantity               `json:"desiredTPU,omitempty"`
-	LastUpdateTime          *metav1.Time                     `json:"lastUpdateTime,omitempty"`
-	StateTransitionTimes    map[v1.ClusterState]*metav1.Time `json:"stateTransitionTimes,omitempty"`
-	Endpoints               map[string]string                `json:"endpoints,omitempty"`
-	Head                    *HeadInfoApplyConfiguration      `json:"head,omitempty"`
-	Reason                  *string                          `json:"reason,omitempty"`
-	Conditions              []metav1.Condition               `json:"conditions,omitempty"`
-	ReadyWorkerReplicas     *int32                           `json:"readyWorkerReplicas,omitempty"`
-	AvailableWorkerReplicas *int32                           `json:"availableWorkerReplicas,omitempty"`
-	DesiredWorkerReplicas   *int32                           `json:"desiredWorkerReplicas,omitempty"`
-	MinWorkerReplicas       *int32                           `json:"minWorkerReplicas,omitempty"`
-	MaxWorkerReplicas       *int32                           `json:"maxWorkerReplicas,omitempty"`
-	ObservedGeneration      *int64                           `json:"observedGeneration,omitempty"`
-}
-
-// RayClusterStatusApplyConfiguration constructs an declarative configuration of the RayClusterStatus type for use with
+	State                   *v1.ClusterState                                        `json:"state,omitempty"`
+	DesiredCPU              *resource.Quantity                                      `json:"desiredCPU,omitempty"`
+	DesiredMemory           *resource.Quantity                                      `json:"desiredMemory,omitempty"`
+	DesiredGPU              *resource.Quantity                                      `json:"desiredGPU,omitempty"`
+	DesiredTPU              *resource.Quantity                                      `json:"desiredTPU,omitempty"`
+	ReadyGPU                *resource.Quantity                                      `json:"readyGPU,omitempty"`
+	LastUpdateTime          *metav1.Time                                            `json:"lastUpdateTime,omitempty"`
+	StateTransitionTimes    map[v1.ClusterState]*metav1.Time                        `json:"stateTransitionTimes,omitempty"`
+	Endpoints               map[string]string                                       `json:"endpoints,omitempty"`
+	Workload                *RayClusterWorkloadStatusApplyConfiguration             `json:"workload,omitempty"`
+	Head                    *HeadInfoApplyConfiguration                             `json:"head,omitempty"`
+	Reason                  *string                                                 `json:"reason,omitempty"`
+	Conditions              []applyconfigurationsmetav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
+	WorkerGroupStatuses     []WorkerGroupStatusApplyConfiguration                   `json:"workerGroupStatuses,omitempty"`
+	CleanupSteps            []CleanupStepStatusApplyConfiguration                   `json:"cleanupSteps,omitempty"`
+	ReadyWorkerReplicas     *int32                                                  `json:"readyWorkerReplicas,omitempty"`
+	AvailableWorkerReplicas *int32                                                  `json:"availableWorkerReplicas,omitempty"`
+	DesiredWorkerReplicas   *int32                                                  `json:"desiredWorkerReplicas,omitempty"`
+	MinWorkerReplicas       *int32                                                  `json:"minWorkerReplicas,omitempty"`
+	MaxWorkerReplicas       *int32                                                  `json:"maxWorkerReplicas,omitempty"`
+	ObservedGeneration      *int64                                                  `json:"observedGeneration,omitempty"`
+}
+
+// RayClusterStatusApplyConfiguration constructs a declarative configuration of the RayClusterStatus type for use with
 // apply.
 func RayClusterStatus() *RayClusterStatusApplyConfiguration {
 	return &RayClusterStatusApplyConfiguration{}
@@ -76,6 +81,14 @@ func (b *RayClusterStatusApplyConfiguration) WithDesiredTPU(value resource.Quant
 	return b
 }
 
+// WithReadyGPU sets the ReadyGPU field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReadyGPU field is set to the value of the last call.
+func (b *RayClusterStatusApplyConfiguration) WithReadyGPU(value resource.Quantity) *RayClusterStatusApplyConfiguration {
+	b.ReadyGPU = &value
+	return b
+}
+
 // WithLastUpdateTime sets the LastUpdateTime field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the LastUpdateTime field is set to the value of the last call.
@@ -112,6 +125,14 @@ func (b *RayClusterStatusApplyConfiguration) WithEndpoints(entries map[string]st
 	return b
 }
 
+// WithWorkload sets the Workload field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Workload field is set to the value of the last call.
+func (b *RayClusterStatusApplyConfiguration) WithWorkload(value *RayClusterWorkloadStatusApplyConfiguration) *RayClusterStatusApplyConfiguration {
+	b.Workload = value
+	return b
+}
+
 // WithHead sets the Head field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Head field is set to the value of the last call.
@@ -131,9 +152,38 @@ func (b *RayClusterStatusApplyConfiguration) WithReason(value string) *RayCluste
 // WithConditions adds the given value to the Conditions field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Conditions field.
-func (b *RayClusterStatusApplyConfiguration) WithConditions(values ...metav1.Condition) *RayClusterStatusApplyConfiguration {
+func (b *RayClusterStatusApplyConfiguration) WithConditions(values ...*applyconfigurationsmetav1.ConditionApplyConfiguration) *RayClusterStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
+// WithWorkerGroupStatuses adds the given value to the WorkerGroupStatuses field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the WorkerGroupStatuses field.
+func (b *RayClusterStatusApplyConfiguration) WithWorkerGroupStatuses(values ...*WorkerGroupStatusApplyConfiguration) *RayClusterStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithWorkerGroupStatuses")
+		}
+		b.WorkerGroupStatuses = append(b.WorkerGroupStatuses, *values[i])
+	}
+	return b
+}
+
+// WithCleanupSteps adds the given value to the CleanupSteps field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CleanupSteps field.
+func (b *RayClusterStatusApplyConfiguration) WithCleanupSteps(values ...*CleanupStepStatusApplyConfiguration) *RayClusterStatusApplyConfiguration {
 	for i := range values {
-		b.Conditions = append(b.Conditions, values[i])
+		if values[i] == nil {
+			panic("nil value passed to WithCleanupSteps")
+		}
+		b.CleanupSteps = append(b.CleanupSteps, *values[i])
 	}
 	return b
 }
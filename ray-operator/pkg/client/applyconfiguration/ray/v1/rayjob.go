@@ -8,7 +8,7 @@ import (
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
-// RayJobApplyConfiguration represents an declarative configuration of the RayJob type for use
+// RayJobApplyConfiguration represents a declarative configuration of the RayJob type for use
 // with apply.
 type RayJobApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
@@ -17,7 +17,7 @@ type RayJobApplyConfiguration struct {
 	Status                           *RayJobStatusApplyConfiguration `json:"status,omitempty"`
 }
 
-// RayJob constructs an declarative configuration of the RayJob type for use with
+// RayJob constructs a declarative configuration of the RayJob type for use with
 // apply.
 func RayJob(name, namespace string) *RayJobApplyConfiguration {
 	b := &RayJobApplyConfiguration{}
@@ -201,3 +201,9 @@ func (b *RayJobApplyConfiguration) WithStatus(value *RayJobStatusApplyConfigurat
 	b.Status = value
 	return b
 }
+
+// GetName retrieves the value of the Name field in the declarative configuration.
+func (b *RayJobApplyConfiguration) GetName() *string {
+	b.ensureObjectMetaApplyConfigurationExists()
+	return b.Name
+}
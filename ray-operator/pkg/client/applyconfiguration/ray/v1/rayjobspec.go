@@ -7,29 +7,37 @@ import (
 	corev1 "k8s.io/client-go/applyconfigurations/core/v1"
 )
 
-// RayJobSpecApplyConfiguration represents an declarative configuration of the RayJobSpec type for use
+// RayJobSpecApplyConfiguration represents a declarative configuration of the RayJobSpec type for use
 // with apply.
 type RayJobSpecApplyConfiguration struct {
-	ActiveDeadlineSeconds    *int32                                    `json:"activeDeadlineSeconds,omitempty"`
-	BackoffLimit             *int32                                    `json:"backoffLimit,omitempty"`
-	RayClusterSpec           *RayClusterSpecApplyConfiguration         `json:"rayClusterSpec,omitempty"`
-	SubmitterPodTemplate     *corev1.PodTemplateSpecApplyConfiguration `json:"submitterPodTemplate,omitempty"`
-	Metadata                 map[string]string                         `json:"metadata,omitempty"`
-	ClusterSelector          map[string]string                         `json:"clusterSelector,omitempty"`
-	SubmitterConfig          *SubmitterConfigApplyConfiguration        `json:"submitterConfig,omitempty"`
-	Entrypoint               *string                                   `json:"entrypoint,omitempty"`
-	RuntimeEnvYAML           *string                                   `json:"runtimeEnvYAML,omitempty"`
-	JobId                    *string                                   `json:"jobId,omitempty"`
-	SubmissionMode           *rayv1.JobSubmissionMode                  `json:"submissionMode,omitempty"`
-	EntrypointResources      *string                                   `json:"entrypointResources,omitempty"`
-	EntrypointNumCpus        *float32                                  `json:"entrypointNumCpus,omitempty"`
-	EntrypointNumGpus        *float32                                  `json:"entrypointNumGpus,omitempty"`
-	TTLSecondsAfterFinished  *int32                                    `json:"ttlSecondsAfterFinished,omitempty"`
-	ShutdownAfterJobFinishes *bool                                     `json:"shutdownAfterJobFinishes,omitempty"`
-	Suspend                  *bool                                     `json:"suspend,omitempty"`
-}
-
-// RayJobSpecApplyConfiguration constructs an declarative configuration of the RayJobSpec type for use with
+	ActiveDeadlineSeconds      *int32                                    `json:"activeDeadlineSeconds,omitempty"`
+	AttemptDeadlineSeconds     *int32                                    `json:"attemptDeadlineSeconds,omitempty"`
+	BackoffLimit               *int32                                    `json:"backoffLimit,omitempty"`
+	RetryPolicy                *RayJobRetryPolicyApplyConfiguration      `json:"retryPolicy,omitempty"`
+	RayClusterSpec             *RayClusterSpecApplyConfiguration         `json:"rayClusterSpec,omitempty"`
+	SubmitterPodTemplate       *corev1.PodTemplateSpecApplyConfiguration `json:"submitterPodTemplate,omitempty"`
+	Metadata                   map[string]string                         `json:"metadata,omitempty"`
+	ClusterSelector            map[string]string                         `json:"clusterSelector,omitempty"`
+	SubmitterConfig            *SubmitterConfigApplyConfiguration        `json:"submitterConfig,omitempty"`
+	StartingDeadlineSeconds    *int64                                    `json:"startingDeadlineSeconds,omitempty"`
+	SuccessfulJobsHistoryLimit *int32                                    `json:"successfulJobsHistoryLimit,omitempty"`
+	FailedJobsHistoryLimit     *int32                                    `json:"failedJobsHistoryLimit,omitempty"`
+	Entrypoint                 *string                                   `json:"entrypoint,omitempty"`
+	RuntimeEnvYAML             *string                                   `json:"runtimeEnvYAML,omitempty"`
+	JobId                      *string                                   `json:"jobId,omitempty"`
+	SubmissionMode             *rayv1.JobSubmissionMode                  `json:"submissionMode,omitempty"`
+	EntrypointResources        *string                                   `json:"entrypointResources,omitempty"`
+	Schedule                   *string                                   `json:"schedule,omitempty"`
+	ConcurrencyPolicy          *rayv1.ConcurrencyPolicy                  `json:"concurrencyPolicy,omitempty"`
+	DeletionPolicy             *rayv1.DeletionPolicy                     `json:"deletionPolicy,omitempty"`
+	EntrypointNumCpus          *float32                                  `json:"entrypointNumCpus,omitempty"`
+	EntrypointNumGpus          *float32                                  `json:"entrypointNumGpus,omitempty"`
+	TTLSecondsAfterFinished    *int32                                    `json:"ttlSecondsAfterFinished,omitempty"`
+	ShutdownAfterJobFinishes   *bool                                     `json:"shutdownAfterJobFinishes,omitempty"`
+	Suspend                    *bool                                     `json:"suspend,omitempty"`
+}
+
+// RayJobSpecApplyConfiguration constructs a declarative configuration of the RayJobSpec type for use with
 // apply.
 func RayJobSpec() *RayJobSpecApplyConfiguration {
 	return &RayJobSpecApplyConfiguration{}
@@ -43,6 +51,14 @@ func (b *RayJobSpecApplyConfiguration) WithActiveDeadlineSeconds(value int32) *R
 	return b
 }
 
+// WithAttemptDeadlineSeconds sets the AttemptDeadlineSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AttemptDeadlineSeconds field is set to the value of the last call.
+func (b *RayJobSpecApplyConfiguration) WithAttemptDeadlineSeconds(value int32) *RayJobSpecApplyConfiguration {
+	b.AttemptDeadlineSeconds = &value
+	return b
+}
+
 // WithBackoffLimit sets the BackoffLimit field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the BackoffLimit field is set to the value of the last call.
@@ -51,6 +67,14 @@ func (b *RayJobSpecApplyConfiguration) WithBackoffLimit(value int32) *RayJobSpec
 	return b
 }
 
+// WithRetryPolicy sets the RetryPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RetryPolicy field is set to the value of the last call.
+func (b *RayJobSpecApplyConfiguration) WithRetryPolicy(value *RayJobRetryPolicyApplyConfiguration) *RayJobSpecApplyConfiguration {
+	b.RetryPolicy = value
+	return b
+}
+
 // WithRayClusterSpec sets the RayClusterSpec field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the RayClusterSpec field is set to the value of the last call.
@@ -103,6 +127,30 @@ func (b *RayJobSpecApplyConfiguration) WithSubmitterConfig(value *SubmitterConfi
 	return b
 }
 
+// WithStartingDeadlineSeconds sets the StartingDeadlineSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartingDeadlineSeconds field is set to the value of the last call.
+func (b *RayJobSpecApplyConfiguration) WithStartingDeadlineSeconds(value int64) *RayJobSpecApplyConfiguration {
+	b.StartingDeadlineSeconds = &value
+	return b
+}
+
+// WithSuccessfulJobsHistoryLimit sets the SuccessfulJobsHistoryLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SuccessfulJobsHistoryLimit field is set to the value of the last call.
+func (b *RayJobSpecApplyConfiguration) WithSuccessfulJobsHistoryLimit(value int32) *RayJobSpecApplyConfiguration {
+	b.SuccessfulJobsHistoryLimit = &value
+	return b
+}
+
+// WithFailedJobsHistoryLimit sets the FailedJobsHistoryLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailedJobsHistoryLimit field is set to the value of the last call.
+func (b *RayJobSpecApplyConfiguration) WithFailedJobsHistoryLimit(value int32) *RayJobSpecApplyConfiguration {
+	b.FailedJobsHistoryLimit = &value
+	return b
+}
+
 // WithEntrypoint sets the Entrypoint field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Entrypoint field is set to the value of the last call.
@@ -143,6 +191,30 @@ func (b *RayJobSpecApplyConfiguration) WithEntrypointResources(value string) *Ra
 	return b
 }
 
+// WithSchedule sets the Schedule field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Schedule field is set to the value of the last call.
+func (b *RayJobSpecApplyConfiguration) WithSchedule(value string) *RayJobSpecApplyConfiguration {
+	b.Schedule = &value
+	return b
+}
+
+// WithConcurrencyPolicy sets the ConcurrencyPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConcurrencyPolicy field is set to the value of the last call.
+func (b *RayJobSpecApplyConfiguration) WithConcurrencyPolicy(value rayv1.ConcurrencyPolicy) *RayJobSpecApplyConfiguration {
+	b.ConcurrencyPolicy = &value
+	return b
+}
+
+// WithDeletionPolicy sets the DeletionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeletionPolicy field is set to the value of the last call.
+func (b *RayJobSpecApplyConfiguration) WithDeletionPolicy(value rayv1.DeletionPolicy) *RayJobSpecApplyConfiguration {
+	b.DeletionPolicy = &value
+	return b
+}
+
 // WithEntrypointNumCpus sets the EntrypointNumCpus field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the EntrypointNumCpus field is set to the value of the last call.
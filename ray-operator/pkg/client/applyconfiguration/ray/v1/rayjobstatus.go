@@ -5,27 +5,34 @@ package v1
 import (
 	v1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	applyconfigurationsmetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
-// RayJobStatusApplyConfiguration represents an declarative configuration of the RayJobStatus type for use
+// RayJobStatusApplyConfiguration represents a declarative configuration of the RayJobStatus type for use
 // with apply.
 type RayJobStatusApplyConfiguration struct {
-	JobId               *string                             `json:"jobId,omitempty"`
-	RayClusterName      *string                             `json:"rayClusterName,omitempty"`
-	DashboardURL        *string                             `json:"dashboardURL,omitempty"`
-	JobStatus           *v1.JobStatus                       `json:"jobStatus,omitempty"`
-	JobDeploymentStatus *v1.JobDeploymentStatus             `json:"jobDeploymentStatus,omitempty"`
-	Reason              *v1.JobFailedReason                 `json:"reason,omitempty"`
-	Message             *string                             `json:"message,omitempty"`
-	StartTime           *metav1.Time                        `json:"startTime,omitempty"`
-	EndTime             *metav1.Time                        `json:"endTime,omitempty"`
-	Succeeded           *int32                              `json:"succeeded,omitempty"`
-	Failed              *int32                              `json:"failed,omitempty"`
-	RayClusterStatus    *RayClusterStatusApplyConfiguration `json:"rayClusterStatus,omitempty"`
-	ObservedGeneration  *int64                              `json:"observedGeneration,omitempty"`
-}
-
-// RayJobStatusApplyConfiguration constructs an declarative configuration of the RayJobStatus type for use with
+	JobId               *string                                                 `json:"jobId,omitempty"`
+	RayClusterName      *string                                                 `json:"rayClusterName,omitempty"`
+	DashboardURL        *string                                                 `json:"dashboardURL,omitempty"`
+	JobStatus           *v1.JobStatus                                           `json:"jobStatus,omitempty"`
+	JobDeploymentStatus *v1.JobDeploymentStatus                                 `json:"jobDeploymentStatus,omitempty"`
+	Reason              *v1.JobFailedReason                                     `json:"reason,omitempty"`
+	Message             *string                                                 `json:"message,omitempty"`
+	StartTime           *metav1.Time                                            `json:"startTime,omitempty"`
+	AttemptStartTime    *metav1.Time                                            `json:"attemptStartTime,omitempty"`
+	EndTime             *metav1.Time                                            `json:"endTime,omitempty"`
+	Succeeded           *int32                                                  `json:"succeeded,omitempty"`
+	Failed              *int32                                                  `json:"failed,omitempty"`
+	LastScheduleTime    *metav1.Time                                            `json:"lastScheduleTime,omitempty"`
+	LastSuccessfulTime  *metav1.Time                                            `json:"lastSuccessfulTime,omitempty"`
+	Conditions          []applyconfigurationsmetav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
+	ActiveRuns          []string                                                `json:"activeRuns,omitempty"`
+	FailedAttempts      []RayJobAttemptStatusApplyConfiguration                 `json:"failedAttempts,omitempty"`
+	RayClusterStatus    *RayClusterStatusApplyConfiguration                     `json:"rayClusterStatus,omitempty"`
+	ObservedGeneration  *int64                                                  `json:"observedGeneration,omitempty"`
+}
+
+// RayJobStatusApplyConfiguration constructs a declarative configuration of the RayJobStatus type for use with
 // apply.
 func RayJobStatus() *RayJobStatusApplyConfiguration {
 	return &RayJobStatusApplyConfiguration{}
@@ -95,6 +102,14 @@ func (b *RayJobStatusApplyConfiguration) WithStartTime(value metav1.Time) *RayJo
 	return b
 }
 
+// WithAttemptStartTime sets the AttemptStartTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AttemptStartTime field is set to the value of the last call.
+func (b *RayJobStatusApplyConfiguration) WithAttemptStartTime(value metav1.Time) *RayJobStatusApplyConfiguration {
+	b.AttemptStartTime = &value
+	return b
+}
+
 // WithEndTime sets the EndTime field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the EndTime field is set to the value of the last call.
@@ -119,6 +134,58 @@ func (b *RayJobStatusApplyConfiguration) WithFailed(value int32) *RayJobStatusAp
 	return b
 }
 
+// WithLastScheduleTime sets the LastScheduleTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastScheduleTime field is set to the value of the last call.
+func (b *RayJobStatusApplyConfiguration) WithLastScheduleTime(value metav1.Time) *RayJobStatusApplyConfiguration {
+	b.LastScheduleTime = &value
+	return b
+}
+
+// WithLastSuccessfulTime sets the LastSuccessfulTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastSuccessfulTime field is set to the value of the last call.
+func (b *RayJobStatusApplyConfiguration) WithLastSuccessfulTime(value metav1.Time) *RayJobStatusApplyConfiguration {
+	b.LastSuccessfulTime = &value
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *RayJobStatusApplyConfiguration) WithConditions(values ...*applyconfigurationsmetav1.ConditionApplyConfiguration) *RayJobStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
+// WithActiveRuns adds the given value to the ActiveRuns field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ActiveRuns field.
+func (b *RayJobStatusApplyConfiguration) WithActiveRuns(values ...string) *RayJobStatusApplyConfiguration {
+	for i := range values {
+		b.ActiveRuns = append(b.ActiveRuns, values[i])
+	}
+	return b
+}
+
+// WithFailedAttempts adds the given value to the FailedAttempts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FailedAttempts field.
+func (b *RayJobStatusApplyConfiguration) WithFailedAttempts(values ...*RayJobAttemptStatusApplyConfiguration) *RayJobStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFailedAttempts")
+		}
+		b.FailedAttempts = append(b.FailedAttempts, *values[i])
+	}
+	return b
+}
+
 // WithRayClusterStatus sets the RayClusterStatus field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the RayClusterStatus field is set to the value of the last call.
@@ -8,7 +8,7 @@ import (
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
-// RayServiceApplyConfiguration represents an declarative configuration of the RayService type for use
+// RayServiceApplyConfiguration represents a declarative configuration of the RayService type for use
 // with apply.
 type RayServiceApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
@@ -17,7 +17,7 @@ type RayServiceApplyConfiguration struct {
 	Status                           *RayServiceStatusesApplyConfiguration `json:"status,omitempty"`
 }
 
-// RayService constructs an declarative configuration of the RayService type for use with
+// RayService constructs a declarative configuration of the RayService type for use with
 // apply.
 func RayService(name, namespace string) *RayServiceApplyConfiguration {
 	b := &RayServiceApplyConfiguration{}
@@ -201,3 +201,9 @@ func (b *RayServiceApplyConfiguration) WithStatus(value *RayServiceStatusesApply
 	b.Status = value
 	return b
 }
+
+// GetName retrieves the value of the Name field in the declarative configuration.
+func (b *RayServiceApplyConfiguration) GetName() *string {
+	b.ensureObjectMetaApplyConfigurationExists()
+	return b.Name
+}
@@ -6,7 +6,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 )
 
-// RayServiceSpecApplyConfiguration represents an declarative configuration of the RayServiceSpec type for use
+// RayServiceSpecApplyConfiguration represents a declarative configuration of the RayServiceSpec type for use
 // with apply.
 type RayServiceSpecApplyConfiguration struct {
 	ServiceUnhealthySecondThreshold    *int32                            `json:"serviceUnhealthySecondThreshold,omitempty"`
@@ -16,7 +16,7 @@ type RayServiceSpecApplyConfiguration struct {
 	RayClusterSpec                     *RayClusterSpecApplyConfiguration `json:"rayClusterConfig,omitempty"`
 }
 
-// RayServiceSpecApplyConfiguration constructs an declarative configuration of the RayServiceSpec type for use with
+// RayServiceSpecApplyConfiguration constructs a declarative configuration of the RayServiceSpec type for use with
 // apply.
 func RayServiceSpec() *RayServiceSpecApplyConfiguration {
 	return &RayServiceSpecApplyConfiguration{}
@@ -5,20 +5,23 @@ package v1
 import (
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
-// RayServiceStatusesApplyConfiguration represents an declarative configuration of the RayServiceStatuses type for use
+// RayServiceStatusesApplyConfiguration represents a declarative configuration of the RayServiceStatuses type for use
 // with apply.
 type RayServiceStatusesApplyConfiguration struct {
-	LastUpdateTime       *v1.Time                            `json:"lastUpdateTime,omitempty"`
-	ServiceStatus        *rayv1.ServiceStatus                `json:"serviceStatus,omitempty"`
-	ActiveServiceStatus  *RayServiceStatusApplyConfiguration `json:"activeServiceStatus,omitempty"`
-	PendingServiceStatus *RayServiceStatusApplyConfiguration `json:"pendingServiceStatus,omitempty"`
-	NumServeEndpoints    *int32                              `json:"numServeEndpoints,omitempty"`
-	ObservedGeneration   *int64                              `json:"observedGeneration,omitempty"`
+	LastUpdateTime       *v1.Time                             `json:"lastUpdateTime,omitempty"`
+	ServiceStatus        *rayv1.ServiceStatus                 `json:"serviceStatus,omitempty"`
+	Phase                *rayv1.RayServicePhase               `json:"phase,omitempty"`
+	Conditions           []metav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
+	ActiveServiceStatus  *RayServiceStatusApplyConfiguration  `json:"activeServiceStatus,omitempty"`
+	PendingServiceStatus *RayServiceStatusApplyConfiguration  `json:"pendingServiceStatus,omitempty"`
+	NumServeEndpoints    *int32                               `json:"numServeEndpoints,omitempty"`
+	ObservedGeneration   *int64                               `json:"observedGeneration,omitempty"`
 }
 
-// RayServiceStatusesApplyConfiguration constructs an declarative configuration of the RayServiceStatuses type for use with
+// RayServiceStatusesApplyConfiguration constructs a declarative configuration of the RayServiceStatuses type for use with
 // apply.
 func RayServiceStatuses() *RayServiceStatusesApplyConfiguration {
 	return &RayServiceStatusesApplyConfiguration{}
@@ -40,6 +43,27 @@ func (b *RayServiceStatusesApplyConfiguration) WithServiceStatus(value rayv1.Ser
 	return b
 }
 
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *RayServiceStatusesApplyConfiguration) WithPhase(value rayv1.RayServicePhase) *RayServiceStatusesApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *RayServiceStatusesApplyConfiguration) WithConditions(values ...*metav1.ConditionApplyConfiguration) *RayServiceStatusesApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
 // WithActiveServiceStatus sets the ActiveServiceStatus field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ActiveServiceStatus field is set to the value of the last call.
@@ -6,7 +6,7 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// ServeDeploymentStatusApplyConfiguration represents an declarative configuration of the ServeDeploymentStatus type for use
+// ServeDeploymentStatusApplyConfiguration represents a declarative configuration of the ServeDeploymentStatus type for use
 // with apply.
 type ServeDeploymentStatusApplyConfiguration struct {
 	HealthLastUpdateTime *v1.Time `json:"healthLastUpdateTime,omitempty"`
@@ -14,7 +14,7 @@ type ServeDeploymentStatusApplyConfiguration struct {
 	Message              *string  `json:"message,omitempty"`
 }
 
-// ServeDeploymentStatusApplyConfiguration constructs an declarative configuration of the ServeDeploymentStatus type for use with
+// ServeDeploymentStatusApplyConfiguration constructs a declarative configuration of the ServeDeploymentStatus type for use with
 // apply.
 func ServeDeploymentStatus() *ServeDeploymentStatusApplyConfiguration {
 	return &ServeDeploymentStatusApplyConfiguration{}
@@ -3,23 +3,40 @@
 package v1
 
 import (
-	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	corev1 "k8s.io/api/core/v1"
+	applyconfigurationscorev1 "k8s.io/client-go/applyconfigurations/core/v1"
 )
 
-// WorkerGroupSpecApplyConfiguration represents an declarative configuration of the WorkerGroupSpec type for use
+// WorkerGroupSpecApplyConfiguration represents a declarative configuration of the WorkerGroupSpec type for use
 // with apply.
 type WorkerGroupSpecApplyConfiguration struct {
-	GroupName      *string                               `json:"groupName,omitempty"`
-	Replicas       *int32                                `json:"replicas,omitempty"`
-	MinReplicas    *int32                                `json:"minReplicas,omitempty"`
-	MaxReplicas    *int32                                `json:"maxReplicas,omitempty"`
-	RayStartParams map[string]string                     `json:"rayStartParams,omitempty"`
-	Template       *v1.PodTemplateSpecApplyConfiguration `json:"template,omitempty"`
-	ScaleStrategy  *ScaleStrategyApplyConfiguration      `json:"scaleStrategy,omitempty"`
-	NumOfHosts     *int32                                `json:"numOfHosts,omitempty"`
+	GroupName                  *string                                                      `json:"groupName,omitempty"`
+	Replicas                   *int32                                                       `json:"replicas,omitempty"`
+	MinReplicas                *int32                                                       `json:"minReplicas,omitempty"`
+	MaxReplicas                *int32                                                       `json:"maxReplicas,omitempty"`
+	RayStartParams             map[string]string                                            `json:"rayStartParams,omitempty"`
+	UpdateStrategy             *WorkerGroupUpdateStrategyApplyConfiguration                 `json:"updateStrategy,omitempty"`
+	DrainGracePeriodSeconds    *int32                                                       `json:"drainGracePeriodSeconds,omitempty"`
+	IdleTimeoutSeconds         *int32                                                       `json:"idleTimeoutSeconds,omitempty"`
+	UpscalingMode              *rayv1.UpscalingMode                                         `json:"upscalingMode,omitempty"`
+	DisruptionBudget           *WorkerGroupDisruptionBudgetApplyConfiguration               `json:"disruptionBudget,omitempty"`
+	ResourceClaims             []RayResourceClaimApplyConfiguration                         `json:"resourceClaims,omitempty"`
+	LogVolume                  *RayVolumeApplyConfiguration                                 `json:"logVolume,omitempty"`
+	SpillVolume                *RayVolumeApplyConfiguration                                 `json:"spillVolume,omitempty"`
+	Probes                     *RayProbeOptionsApplyConfiguration                           `json:"probes,omitempty"`
+	RecreatePolicy             *WorkerGroupRecreatePolicyApplyConfiguration                 `json:"recreatePolicy,omitempty"`
+	SidecarContainers          []corev1.Container                                           `json:"sidecarContainers,omitempty"`
+	SpotFallback               *SpotFallbackOptionsApplyConfiguration                       `json:"spotFallback,omitempty"`
+	VolumeClaimTemplates       []corev1.PersistentVolumeClaim                               `json:"volumeClaimTemplates,omitempty"`
+	VolumeClaimRetentionPolicy *rayv1.VolumeClaimRetentionPolicy                            `json:"volumeClaimRetentionPolicy,omitempty"`
+	WorkloadType               *rayv1.WorkerGroupWorkloadType                               `json:"workloadType,omitempty"`
+	Template                   *applyconfigurationscorev1.PodTemplateSpecApplyConfiguration `json:"template,omitempty"`
+	ScaleStrategy              *ScaleStrategyApplyConfiguration                             `json:"scaleStrategy,omitempty"`
+	NumOfHosts                 *int32                                                       `json:"numOfHosts,omitempty"`
 }
 
-// WorkerGroupSpecApplyConfiguration constructs an declarative configuration of the WorkerGroupSpec type for use with
+// WorkerGroupSpecApplyConfiguration constructs a declarative configuration of the WorkerGroupSpec type for use with
 // apply.
 func WorkerGroupSpec() *WorkerGroupSpecApplyConfiguration {
 	return &WorkerGroupSpecApplyConfiguration{}
@@ -71,10 +88,139 @@ func (b *WorkerGroupSpecApplyConfiguration) WithRayStartParams(entries map[strin
 	return b
 }
 
+// WithUpdateStrategy sets the UpdateStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpdateStrategy field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithUpdateStrategy(value *WorkerGroupUpdateStrategyApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.UpdateStrategy = value
+	return b
+}
+
+// WithDrainGracePeriodSeconds sets the DrainGracePeriodSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DrainGracePeriodSeconds field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithDrainGracePeriodSeconds(value int32) *WorkerGroupSpecApplyConfiguration {
+	b.DrainGracePeriodSeconds = &value
+	return b
+}
+
+// WithIdleTimeoutSeconds sets the IdleTimeoutSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IdleTimeoutSeconds field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithIdleTimeoutSeconds(value int32) *WorkerGroupSpecApplyConfiguration {
+	b.IdleTimeoutSeconds = &value
+	return b
+}
+
+// WithUpscalingMode sets the UpscalingMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpscalingMode field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithUpscalingMode(value rayv1.UpscalingMode) *WorkerGroupSpecApplyConfiguration {
+	b.UpscalingMode = &value
+	return b
+}
+
+// WithDisruptionBudget sets the DisruptionBudget field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DisruptionBudget field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithDisruptionBudget(value *WorkerGroupDisruptionBudgetApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.DisruptionBudget = value
+	return b
+}
+
+// WithResourceClaims adds the given value to the ResourceClaims field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ResourceClaims field.
+func (b *WorkerGroupSpecApplyConfiguration) WithResourceClaims(values ...*RayResourceClaimApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResourceClaims")
+		}
+		b.ResourceClaims = append(b.ResourceClaims, *values[i])
+	}
+	return b
+}
+
+// WithLogVolume sets the LogVolume field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LogVolume field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithLogVolume(value *RayVolumeApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.LogVolume = value
+	return b
+}
+
+// WithSpillVolume sets the SpillVolume field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SpillVolume field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithSpillVolume(value *RayVolumeApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.SpillVolume = value
+	return b
+}
+
+// WithProbes sets the Probes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Probes field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithProbes(value *RayProbeOptionsApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.Probes = value
+	return b
+}
+
+// WithRecreatePolicy sets the RecreatePolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RecreatePolicy field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithRecreatePolicy(value *WorkerGroupRecreatePolicyApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.RecreatePolicy = value
+	return b
+}
+
+// WithSidecarContainers adds the given value to the SidecarContainers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the SidecarContainers field.
+func (b *WorkerGroupSpecApplyConfiguration) WithSidecarContainers(values ...corev1.Container) *WorkerGroupSpecApplyConfiguration {
+	for i := range values {
+		b.SidecarContainers = append(b.SidecarContainers, values[i])
+	}
+	return b
+}
+
+// WithSpotFallback sets the SpotFallback field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SpotFallback field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithSpotFallback(value *SpotFallbackOptionsApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.SpotFallback = value
+	return b
+}
+
+// WithVolumeClaimTemplates adds the given value to the VolumeClaimTemplates field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the VolumeClaimTemplates field.
+func (b *WorkerGroupSpecApplyConfiguration) WithVolumeClaimTemplates(values ...corev1.PersistentVolumeClaim) *WorkerGroupSpecApplyConfiguration {
+	for i := range values {
+		b.VolumeClaimTemplates = append(b.VolumeClaimTemplates, values[i])
+	}
+	return b
+}
+
+// WithVolumeClaimRetentionPolicy sets the VolumeClaimRetentionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the VolumeClaimRetentionPolicy field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithVolumeClaimRetentionPolicy(value rayv1.VolumeClaimRetentionPolicy) *WorkerGroupSpecApplyConfiguration {
+	b.VolumeClaimRetentionPolicy = &value
+	return b
+}
+
+// WithWorkloadType sets the WorkloadType field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WorkloadType field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithWorkloadType(value rayv1.WorkerGroupWorkloadType) *WorkerGroupSpecApplyConfiguration {
+	b.WorkloadType = &value
+	return b
+}
+
 // WithTemplate sets the Template field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Template field is set to the value of the last call.
-func (b *WorkerGroupSpecApplyConfiguration) WithTemplate(value *v1.PodTemplateSpecApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+func (b *WorkerGroupSpecApplyConfiguration) WithTemplate(value *applyconfigurationscorev1.PodTemplateSpecApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
 	b.Template = value
 	return b
 }
@@ -4,8 +4,11 @@ package applyconfiguration
 
 import (
 	v1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	internal "github.com/ray-project/kuberay/ray-operator/pkg/client/applyconfiguration/internal"
 	rayv1 "github.com/ray-project/kuberay/ray-operator/pkg/client/applyconfiguration/ray/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	testing "k8s.io/client-go/testing"
 )
 
 // ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
@@ -17,22 +20,54 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &rayv1.AppStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("AutoscalerOptions"):
 		return &rayv1.AutoscalerOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("CertificateIssuerReference"):
+		return &rayv1.CertificateIssuerReferenceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("CleanupStepStatus"):
+		return &rayv1.CleanupStepStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("GatewayReference"):
+		return &rayv1.GatewayReferenceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("GcsFaultToleranceOptions"):
+		return &rayv1.GcsFaultToleranceOptionsApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("HeadGroupSpec"):
 		return &rayv1.HeadGroupSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("HeadInfo"):
 		return &rayv1.HeadInfoApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("HeadIngressOptions"):
+		return &rayv1.HeadIngressOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("NodePlacement"):
+		return &rayv1.NodePlacementApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PrometheusMonitorOptions"):
+		return &rayv1.PrometheusMonitorOptionsApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayCluster"):
 		return &rayv1.RayClusterApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayClusterLogging"):
+		return &rayv1.RayClusterLoggingApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayClusterSecurity"):
+		return &rayv1.RayClusterSecurityApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayClusterSpec"):
 		return &rayv1.RayClusterSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayClusterStatus"):
 		return &rayv1.RayClusterStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayClusterTLS"):
+		return &rayv1.RayClusterTLSApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayClusterWorkloadStatus"):
+		return &rayv1.RayClusterWorkloadStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayJob"):
 		return &rayv1.RayJobApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayJobAttemptStatus"):
+		return &rayv1.RayJobAttemptStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayJobRetryPolicy"):
+		return &rayv1.RayJobRetryPolicyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayJobSpec"):
 		return &rayv1.RayJobSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayJobStatus"):
 		return &rayv1.RayJobStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayProbe"):
+		return &rayv1.RayProbeApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayProbeOptions"):
+		return &rayv1.RayProbeOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayResourceClaim"):
+		return &rayv1.RayResourceClaimApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayService"):
 		return &rayv1.RayServiceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayServiceSpec"):
@@ -41,15 +76,47 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &rayv1.RayServiceStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayServiceStatuses"):
 		return &rayv1.RayServiceStatusesApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayVolume"):
+		return &rayv1.RayVolumeApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayWorkerGroup"):
+		return &rayv1.RayWorkerGroupApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayWorkerGroupSpec"):
+		return &rayv1.RayWorkerGroupSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayWorkerGroupStatus"):
+		return &rayv1.RayWorkerGroupStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RedisCleanupOptions"):
+		return &rayv1.RedisCleanupOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RedisCredential"):
+		return &rayv1.RedisCredentialApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RollingUpdateWorkerGroup"):
+		return &rayv1.RollingUpdateWorkerGroupApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ScaleStrategy"):
 		return &rayv1.ScaleStrategyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ServeDeploymentStatus"):
 		return &rayv1.ServeDeploymentStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServiceMeshOptions"):
+		return &rayv1.ServiceMeshOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("SpotFallbackOptions"):
+		return &rayv1.SpotFallbackOptionsApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("SubmitterConfig"):
 		return &rayv1.SubmitterConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("WaitForHeadOptions"):
+		return &rayv1.WaitForHeadOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("WorkerGroupDisruptionBudget"):
+		return &rayv1.WorkerGroupDisruptionBudgetApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("WorkerGroupRecreatePolicy"):
+		return &rayv1.WorkerGroupRecreatePolicyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("WorkerGroupSpec"):
 		return &rayv1.WorkerGroupSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("WorkerGroupStatus"):
+		return &rayv1.WorkerGroupStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("WorkerGroupUpdateStrategy"):
+		return &rayv1.WorkerGroupUpdateStrategyApplyConfiguration{}
 
 	}
 	return nil
 }
+
+func NewTypeConverter(scheme *runtime.Scheme) *testing.TypeConverter {
+	return &testing.TypeConverter{Scheme: scheme, TypeResolver: internal.Parser()}
+}
@@ -24,6 +24,10 @@ func (c *FakeRayV1) RayServices(namespace string) v1.RayServiceInterface {
 	return &FakeRayServices{c, namespace}
 }
 
+func (c *FakeRayV1) RayWorkerGroups(namespace string) v1.RayWorkerGroupInterface {
+	return &FakeRayWorkerGroups{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeRayV1) RESTClient() rest.Interface {
@@ -28,22 +28,24 @@ var rayclustersKind = v1.SchemeGroupVersion.WithKind("RayCluster")
 
 // Get takes name of the rayCluster, and returns the corresponding rayCluster object, and an error if there is any.
 func (c *FakeRayClusters) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.RayCluster, err error) {
+	emptyResult := &v1.RayCluster{}
 	obj, err := c.Fake.
-		Invokes(testing.NewGetAction(rayclustersResource, c.ns, name), &v1.RayCluster{})
+		Invokes(testing.NewGetActionWithOptions(rayclustersResource, c.ns, name, options), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayCluster), err
 }
 
 // List takes label and field selectors, and returns the list of RayClusters that match those selectors.
 func (c *FakeRayClusters) List(ctx context.Context, opts metav1.ListOptions) (result *v1.RayClusterList, err error) {
+	emptyResult := &v1.RayClusterList{}
 	obj, err := c.Fake.
-		Invokes(testing.NewListAction(rayclustersResource, rayclustersKind, c.ns, opts), &v1.RayClusterList{})
+		Invokes(testing.NewListActionWithOptions(rayclustersResource, rayclustersKind, c.ns, opts), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 
 	label, _, _ := testing.ExtractFromListOptions(opts)
@@ -62,40 +64,43 @@ func (c *FakeRayClusters) List(ctx context.Context, opts metav1.ListOptions) (re
 // Watch returns a watch.Interface that watches the requested rayClusters.
 func (c *FakeRayClusters) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
 	return c.Fake.
-		InvokesWatch(testing.NewWatchAction(rayclustersResource, c.ns, opts))
+		InvokesWatch(testing.NewWatchActionWithOptions(rayclustersResource, c.ns, opts))
 
 }
 
 // Create takes the representation of a rayCluster and creates it.  Returns the server's representation of the rayCluster, and an error, if there is any.
 func (c *FakeRayClusters) Create(ctx context.Context, rayCluster *v1.RayCluster, opts metav1.CreateOptions) (result *v1.RayCluster, err error) {
+	emptyResult := &v1.RayCluster{}
 	obj, err := c.Fake.
-		Invokes(testing.NewCreateAction(rayclustersResource, c.ns, rayCluster), &v1.RayCluster{})
+		Invokes(testing.NewCreateActionWithOptions(rayclustersResource, c.ns, rayCluster, opts), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayCluster), err
 }
 
 // Update takes the representation of a rayCluster and updates it. Returns the server's representation of the rayCluster, and an error, if there is any.
 func (c *FakeRayClusters) Update(ctx context.Context, rayCluster *v1.RayCluster, opts metav1.UpdateOptions) (result *v1.RayCluster, err error) {
+	emptyResult := &v1.RayCluster{}
 	obj, err := c.Fake.
-		Invokes(testing.NewUpdateAction(rayclustersResource, c.ns, rayCluster), &v1.RayCluster{})
+		Invokes(testing.NewUpdateActionWithOptions(rayclustersResource, c.ns, rayCluster, opts), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayCluster), err
 }
 
 // UpdateStatus was generated because the type contains a Status member.
 // Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
-func (c *FakeRayClusters) UpdateStatus(ctx context.Context, rayCluster *v1.RayCluster, opts metav1.UpdateOptions) (*v1.RayCluster, error) {
+func (c *FakeRayClusters) UpdateStatus(ctx context.Context, rayCluster *v1.RayCluster, opts metav1.UpdateOptions) (result *v1.RayCluster, err error) {
+	emptyResult := &v1.RayCluster{}
 	obj, err := c.Fake.
-		Invokes(testing.NewUpdateSubresourceAction(rayclustersResource, "status", c.ns, rayCluster), &v1.RayCluster{})
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(rayclustersResource, "status", c.ns, rayCluster, opts), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayCluster), err
 }
@@ -110,7 +115,7 @@ func (c *FakeRayClusters) Delete(ctx context.Context, name string, opts metav1.D
 
 // DeleteCollection deletes a collection of objects.
 func (c *FakeRayClusters) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
-	action := testing.NewDeleteCollectionAction(rayclustersResource, c.ns, listOpts)
+	action := testing.NewDeleteCollectionActionWithOptions(rayclustersResource, c.ns, opts, listOpts)
 
 	_, err := c.Fake.Invokes(action, &v1.RayClusterList{})
 	return err
@@ -118,11 +123,12 @@ func (c *FakeRayClusters) DeleteCollection(ctx context.Context, opts metav1.Dele
 
 // Patch applies the patch and returns the patched rayCluster.
 func (c *FakeRayClusters) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.RayCluster, err error) {
+	emptyResult := &v1.RayCluster{}
 	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(rayclustersResource, c.ns, name, pt, data, subresources...), &v1.RayCluster{})
+		Invokes(testing.NewPatchSubresourceActionWithOptions(rayclustersResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayCluster), err
 }
@@ -140,11 +146,12 @@ func (c *FakeRayClusters) Apply(ctx context.Context, rayCluster *rayv1.RayCluste
 	if name == nil {
 		return nil, fmt.Errorf("rayCluster.Name must be provided to Apply")
 	}
+	emptyResult := &v1.RayCluster{}
 	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(rayclustersResource, c.ns, *name, types.ApplyPatchType, data), &v1.RayCluster{})
+		Invokes(testing.NewPatchSubresourceActionWithOptions(rayclustersResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions()), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayCluster), err
 }
@@ -163,11 +170,12 @@ func (c *FakeRayClusters) ApplyStatus(ctx context.Context, rayCluster *rayv1.Ray
 	if name == nil {
 		return nil, fmt.Errorf("rayCluster.Name must be provided to Apply")
 	}
+	emptyResult := &v1.RayCluster{}
 	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(rayclustersResource, c.ns, *name, types.ApplyPatchType, data, "status"), &v1.RayCluster{})
+		Invokes(testing.NewPatchSubresourceActionWithOptions(rayclustersResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions(), "status"), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayCluster), err
 }
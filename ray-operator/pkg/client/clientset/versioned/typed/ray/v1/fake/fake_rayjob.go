@@ -28,22 +28,24 @@ var rayjobsKind = v1.SchemeGroupVersion.WithKind("RayJob")
 
 // Get takes name of the rayJob, and returns the corresponding rayJob object, and an error if there is any.
 func (c *FakeRayJobs) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.RayJob, err error) {
+	emptyResult := &v1.RayJob{}
 	obj, err := c.Fake.
-		Invokes(testing.NewGetAction(rayjobsResource, c.ns, name), &v1.RayJob{})
+		Invokes(testing.NewGetActionWithOptions(rayjobsResource, c.ns, name, options), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayJob), err
 }
 
 // List takes label and field selectors, and returns the list of RayJobs that match those selectors.
 func (c *FakeRayJobs) List(ctx context.Context, opts metav1.ListOptions) (result *v1.RayJobList, err error) {
+	emptyResult := &v1.RayJobList{}
 	obj, err := c.Fake.
-		Invokes(testing.NewListAction(rayjobsResource, rayjobsKind, c.ns, opts), &v1.RayJobList{})
+		Invokes(testing.NewListActionWithOptions(rayjobsResource, rayjobsKind, c.ns, opts), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 
 	label, _, _ := testing.ExtractFromListOptions(opts)
@@ -62,40 +64,43 @@ func (c *FakeRayJobs) List(ctx context.Context, opts metav1.ListOptions) (result
 // Watch returns a watch.Interface that watches the requested rayJobs.
 func (c *FakeRayJobs) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
 	return c.Fake.
-		InvokesWatch(testing.NewWatchAction(rayjobsResource, c.ns, opts))
+		InvokesWatch(testing.NewWatchActionWithOptions(rayjobsResource, c.ns, opts))
 
 }
 
 // Create takes the representation of a rayJob and creates it.  Returns the server's representation of the rayJob, and an error, if there is any.
 func (c *FakeRayJobs) Create(ctx context.Context, rayJob *v1.RayJob, opts metav1.CreateOptions) (result *v1.RayJob, err error) {
+	emptyResult := &v1.RayJob{}
 	obj, err := c.Fake.
-		Invokes(testing.NewCreateAction(rayjobsResource, c.ns, rayJob), &v1.RayJob{})
+		Invokes(testing.NewCreateActionWithOptions(rayjobsResource, c.ns, rayJob, opts), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayJob), err
 }
 
 // Update takes the representation of a rayJob and updates it. Returns the server's representation of the rayJob, and an error, if there is any.
 func (c *FakeRayJobs) Update(ctx context.Context, rayJob *v1.RayJob, opts metav1.UpdateOptions) (result *v1.RayJob, err error) {
+	emptyResult := &v1.RayJob{}
 	obj, err := c.Fake.
-		Invokes(testing.NewUpdateAction(rayjobsResource, c.ns, rayJob), &v1.RayJob{})
+		Invokes(testing.NewUpdateActionWithOptions(rayjobsResource, c.ns, rayJob, opts), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayJob), err
 }
 
 // UpdateStatus was generated because the type contains a Status member.
 // Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
-func (c *FakeRayJobs) UpdateStatus(ctx context.Context, rayJob *v1.RayJob, opts metav1.UpdateOptions) (*v1.RayJob, error) {
+func (c *FakeRayJobs) UpdateStatus(ctx context.Context, rayJob *v1.RayJob, opts metav1.UpdateOptions) (result *v1.RayJob, err error) {
+	emptyResult := &v1.RayJob{}
 	obj, err := c.Fake.
-		Invokes(testing.NewUpdateSubresourceAction(rayjobsResource, "status", c.ns, rayJob), &v1.RayJob{})
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(rayjobsResource, "status", c.ns, rayJob, opts), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayJob), err
 }
@@ -110,7 +115,7 @@ func (c *FakeRayJobs) Delete(ctx context.Context, name string, opts metav1.Delet
 
 // DeleteCollection deletes a collection of objects.
 func (c *FakeRayJobs) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
-	action := testing.NewDeleteCollectionAction(rayjobsResource, c.ns, listOpts)
+	action := testing.NewDeleteCollectionActionWithOptions(rayjobsResource, c.ns, opts, listOpts)
 
 	_, err := c.Fake.Invokes(action, &v1.RayJobList{})
 	return err
@@ -118,11 +123,12 @@ func (c *FakeRayJobs) DeleteCollection(ctx context.Context, opts metav1.DeleteOp
 
 // Patch applies the patch and returns the patched rayJob.
 func (c *FakeRayJobs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.RayJob, err error) {
+	emptyResult := &v1.RayJob{}
 	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(rayjobsResource, c.ns, name, pt, data, subresources...), &v1.RayJob{})
+		Invokes(testing.NewPatchSubresourceActionWithOptions(rayjobsResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayJob), err
 }
@@ -140,11 +146,12 @@ func (c *FakeRayJobs) Apply(ctx context.Context, rayJob *rayv1.RayJobApplyConfig
 	if name == nil {
 		return nil, fmt.Errorf("rayJob.Name must be provided to Apply")
 	}
+	emptyResult := &v1.RayJob{}
 	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(rayjobsResource, c.ns, *name, types.ApplyPatchType, data), &v1.RayJob{})
+		Invokes(testing.NewPatchSubresourceActionWithOptions(rayjobsResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions()), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayJob), err
 }
@@ -163,11 +170,12 @@ func (c *FakeRayJobs) ApplyStatus(ctx context.Context, rayJob *rayv1.RayJobApply
 	if name == nil {
 		return nil, fmt.Errorf("rayJob.Name must be provided to Apply")
 	}
+	emptyResult := &v1.RayJob{}
 	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(rayjobsResource, c.ns, *name, types.ApplyPatchType, data, "status"), &v1.RayJob{})
+		Invokes(testing.NewPatchSubresourceActionWithOptions(rayjobsResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions(), "status"), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayJob), err
 }
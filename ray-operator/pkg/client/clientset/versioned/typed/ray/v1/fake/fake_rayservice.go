@@ -28,22 +28,24 @@ var rayservicesKind = v1.SchemeGroupVersion.WithKind("RayService")
 
 // Get takes name of the rayService, and returns the corresponding rayService object, and an error if there is any.
 func (c *FakeRayServices) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.RayService, err error) {
+	emptyResult := &v1.RayService{}
 	obj, err := c.Fake.
-		Invokes(testing.NewGetAction(rayservicesResource, c.ns, name), &v1.RayService{})
+		Invokes(testing.NewGetActionWithOptions(rayservicesResource, c.ns, name, options), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayService), err
 }
 
 // List takes label and field selectors, and returns the list of RayServices that match those selectors.
 func (c *FakeRayServices) List(ctx context.Context, opts metav1.ListOptions) (result *v1.RayServiceList, err error) {
+	emptyResult := &v1.RayServiceList{}
 	obj, err := c.Fake.
-		Invokes(testing.NewListAction(rayservicesResource, rayservicesKind, c.ns, opts), &v1.RayServiceList{})
+		Invokes(testing.NewListActionWithOptions(rayservicesResource, rayservicesKind, c.ns, opts), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 
 	label, _, _ := testing.ExtractFromListOptions(opts)
@@ -62,40 +64,43 @@ func (c *FakeRayServices) List(ctx context.Context, opts metav1.ListOptions) (re
 // Watch returns a watch.Interface that watches the requested rayServices.
 func (c *FakeRayServices) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
 	return c.Fake.
-		InvokesWatch(testing.NewWatchAction(rayservicesResource, c.ns, opts))
+		InvokesWatch(testing.NewWatchActionWithOptions(rayservicesResource, c.ns, opts))
 
 }
 
 // Create takes the representation of a rayService and creates it.  Returns the server's representation of the rayService, and an error, if there is any.
 func (c *FakeRayServices) Create(ctx context.Context, rayService *v1.RayService, opts metav1.CreateOptions) (result *v1.RayService, err error) {
+	emptyResult := &v1.RayService{}
 	obj, err := c.Fake.
-		Invokes(testing.NewCreateAction(rayservicesResource, c.ns, rayService), &v1.RayService{})
+		Invokes(testing.NewCreateActionWithOptions(rayservicesResource, c.ns, rayService, opts), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayService), err
 }
 
 // Update takes the representation of a rayService and updates it. Returns the server's representation of the rayService, and an error, if there is any.
 func (c *FakeRayServices) Update(ctx context.Context, rayService *v1.RayService, opts metav1.UpdateOptions) (result *v1.RayService, err error) {
+	emptyResult := &v1.RayService{}
 	obj, err := c.Fake.
-		Invokes(testing.NewUpdateAction(rayservicesResource, c.ns, rayService), &v1.RayService{})
+		Invokes(testing.NewUpdateActionWithOptions(rayservicesResource, c.ns, rayService, opts), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayService), err
 }
 
 // UpdateStatus was generated because the type contains a Status member.
 // Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
-func (c *FakeRayServices) UpdateStatus(ctx context.Context, rayService *v1.RayService, opts metav1.UpdateOptions) (*v1.RayService, error) {
+func (c *FakeRayServices) UpdateStatus(ctx context.Context, rayService *v1.RayService, opts metav1.UpdateOptions) (result *v1.RayService, err error) {
+	emptyResult := &v1.RayService{}
 	obj, err := c.Fake.
-		Invokes(testing.NewUpdateSubresourceAction(rayservicesResource, "status", c.ns, rayService), &v1.RayService{})
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(rayservicesResource, "status", c.ns, rayService, opts), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayService), err
 }
@@ -110,7 +115,7 @@ func (c *FakeRayServices) Delete(ctx context.Context, name string, opts metav1.D
 
 // DeleteCollection deletes a collection of objects.
 func (c *FakeRayServices) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
-	action := testing.NewDeleteCollectionAction(rayservicesResource, c.ns, listOpts)
+	action := testing.NewDeleteCollectionActionWithOptions(rayservicesResource, c.ns, opts, listOpts)
 
 	_, err := c.Fake.Invokes(action, &v1.RayServiceList{})
 	return err
@@ -118,11 +123,12 @@ func (c *FakeRayServices) DeleteCollection(ctx context.Context, opts metav1.Dele
 
 // Patch applies the patch and returns the patched rayService.
 func (c *FakeRayServices) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.RayService, err error) {
+	emptyResult := &v1.RayService{}
 	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(rayservicesResource, c.ns, name, pt, data, subresources...), &v1.RayService{})
+		Invokes(testing.NewPatchSubresourceActionWithOptions(rayservicesResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayService), err
 }
@@ -140,11 +146,12 @@ func (c *FakeRayServices) Apply(ctx context.Context, rayService *rayv1.RayServic
 	if name == nil {
 		return nil, fmt.Errorf("rayService.Name must be provided to Apply")
 	}
+	emptyResult := &v1.RayService{}
 	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(rayservicesResource, c.ns, *name, types.ApplyPatchType, data), &v1.RayService{})
+		Invokes(testing.NewPatchSubresourceActionWithOptions(rayservicesResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions()), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayService), err
 }
@@ -163,11 +170,12 @@ func (c *FakeRayServices) ApplyStatus(ctx context.Context, rayService *rayv1.Ray
 	if name == nil {
 		return nil, fmt.Errorf("rayService.Name must be provided to Apply")
 	}
+	emptyResult := &v1.RayService{}
 	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(rayservicesResource, c.ns, *name, types.ApplyPatchType, data, "status"), &v1.RayService{})
+		Invokes(testing.NewPatchSubresourceActionWithOptions(rayservicesResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions(), "status"), emptyResult)
 
 	if obj == nil {
-		return nil, err
+		return emptyResult, err
 	}
 	return obj.(*v1.RayService), err
 }
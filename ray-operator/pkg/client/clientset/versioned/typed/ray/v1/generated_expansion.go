@@ -7,3 +7,5 @@ type RayClusterExpansion interface{}
 type RayJobExpansion interface{}
 
 type RayServiceExpansion interface{}
+
+type RayWorkerGroupExpansion interface{}
@@ -15,6 +15,7 @@ type RayV1Interface interface {
 	RayClustersGetter
 	RayJobsGetter
 	RayServicesGetter
+	RayWorkerGroupsGetter
 }
 
 // RayV1Client is used to interact with features provided by the ray.io group.
@@ -34,6 +35,10 @@ func (c *RayV1Client) RayServices(namespace string) RayServiceInterface {
 	return newRayServices(c, namespace)
 }
 
+func (c *RayV1Client) RayWorkerGroups(namespace string) RayWorkerGroupInterface {
+	return newRayWorkerGroups(c, namespace)
+}
+
 // NewForConfig creates a new RayV1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -4,9 +4,6 @@ package v1
 
 import (
 	"context"
-	json "encoding/json"
-	"fmt"
-	"time"
 
 	v1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	rayv1 "github.com/ray-project/kuberay/ray-operator/pkg/client/applyconfiguration/ray/v1"
@@ -14,7 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 	watch "k8s.io/apimachinery/pkg/watch"
-	rest "k8s.io/client-go/rest"
+	gentype "k8s.io/client-go/gentype"
 )
 
 // RayClustersGetter has a method to return a RayClusterInterface.
@@ -27,6 +24,7 @@ type RayClustersGetter interface {
 type RayClusterInterface interface {
 	Create(ctx context.Context, rayCluster *v1.RayCluster, opts metav1.CreateOptions) (*v1.RayCluster, error)
 	Update(ctx context.Context, rayCluster *v1.RayCluster, opts metav1.UpdateOptions) (*v1.RayCluster, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
 	UpdateStatus(ctx context.Context, rayCluster *v1.RayCluster, opts metav1.UpdateOptions) (*v1.RayCluster, error)
 	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
@@ -35,206 +33,25 @@ type RayClusterInterface interface {
 	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.RayCluster, err error)
 	Apply(ctx context.Context, rayCluster *rayv1.RayClusterApplyConfiguration, opts metav1.ApplyOptions) (result *v1.RayCluster, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
 	ApplyStatus(ctx context.Context, rayCluster *rayv1.RayClusterApplyConfiguration, opts metav1.ApplyOptions) (result *v1.RayCluster, err error)
 	RayClusterExpansion
 }
 
 // rayClusters implements RayClusterInterface
 type rayClusters struct {
-	client rest.Interface
-	ns     string
+	*gentype.ClientWithListAndApply[*v1.RayCluster, *v1.RayClusterList, *rayv1.RayClusterApplyConfiguration]
 }
 
 // newRayClusters returns a RayClusters
 func newRayClusters(c *RayV1Client, namespace string) *rayClusters {
 	return &rayClusters{
-		client: c.RESTClient(),
-		ns:     namespace,
+		gentype.NewClientWithListAndApply[*v1.RayCluster, *v1.RayClusterList, *rayv1.RayClusterApplyConfiguration](
+			"rayclusters",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *v1.RayCluster { return &v1.RayCluster{} },
+			func() *v1.RayClusterList { return &v1.RayClusterList{} }),
 	}
 }
-
-// Get takes name of the rayCluster, and returns the corresponding rayCluster object, and an error if there is any.
-func (c *rayClusters) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.RayCluster, err error) {
-	result = &v1.RayCluster{}
-	err = c.client.Get().
-		Namespace(c.ns).
-		Resource("rayclusters").
-		Name(name).
-		VersionedParams(&options, scheme.ParameterCodec).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// List takes label and field selectors, and returns the list of RayClusters that match those selectors.
-func (c *rayClusters) List(ctx context.Context, opts metav1.ListOptions) (result *v1.RayClusterList, err error) {
-	var timeout time.Duration
-	if opts.TimeoutSeconds != nil {
-		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
-	}
-	result = &v1.RayClusterList{}
-	err = c.client.Get().
-		Namespace(c.ns).
-		Resource("rayclusters").
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Timeout(timeout).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// Watch returns a watch.Interface that watches the requested rayClusters.
-func (c *rayClusters) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
-	var timeout time.Duration
-	if opts.TimeoutSeconds != nil {
-		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
-	}
-	opts.Watch = true
-	return c.client.Get().
-		Namespace(c.ns).
-		Resource("rayclusters").
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Timeout(timeout).
-		Watch(ctx)
-}
-
-// Create takes the representation of a rayCluster and creates it.  Returns the server's representation of the rayCluster, and an error, if there is any.
-func (c *rayClusters) Create(ctx context.Context, rayCluster *v1.RayCluster, opts metav1.CreateOptions) (result *v1.RayCluster, err error) {
-	result = &v1.RayCluster{}
-	err = c.client.Post().
-		Namespace(c.ns).
-		Resource("rayclusters").
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Body(rayCluster).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// Update takes the representation of a rayCluster and updates it. Returns the server's representation of the rayCluster, and an error, if there is any.
-func (c *rayClusters) Update(ctx context.Context, rayCluster *v1.RayCluster, opts metav1.UpdateOptions) (result *v1.RayCluster, err error) {
-	result = &v1.RayCluster{}
-	err = c.client.Put().
-		Namespace(c.ns).
-		Resource("rayclusters").
-		Name(rayCluster.Name).
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Body(rayCluster).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// UpdateStatus was generated because the type contains a Status member.
-// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
-func (c *rayClusters) UpdateStatus(ctx context.Context, rayCluster *v1.RayCluster, opts metav1.UpdateOptions) (result *v1.RayCluster, err error) {
-	result = &v1.RayCluster{}
-	err = c.client.Put().
-		Namespace(c.ns).
-		Resource("rayclusters").
-		Name(rayCluster.Name).
-		SubResource("status").
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Body(rayCluster).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// Delete takes name of the rayCluster and deletes it. Returns an error if one occurs.
-func (c *rayClusters) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
-	return c.client.Delete().
-		Namespace(c.ns).
-		Resource("rayclusters").
-		Name(name).
-		Body(&opts).
-		Do(ctx).
-		Error()
-}
-
-// DeleteCollection deletes a collection of objects.
-func (c *rayClusters) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
-	var timeout time.Duration
-	if listOpts.TimeoutSeconds != nil {
-		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
-	}
-	return c.client.Delete().
-		Namespace(c.ns).
-		Resource("rayclusters").
-		VersionedParams(&listOpts, scheme.ParameterCodec).
-		Timeout(timeout).
-		Body(&opts).
-		Do(ctx).
-		Error()
-}
-
-// Patch applies the patch and returns the patched rayCluster.
-func (c *rayClusters) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.RayCluster, err error) {
-	result = &v1.RayCluster{}
-	err = c.client.Patch(pt).
-		Namespace(c.ns).
-		Resource("rayclusters").
-		Name(name).
-		SubResource(subresources...).
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Body(data).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// Apply takes the given apply declarative configuration, applies it and returns the applied rayCluster.
-func (c *rayClusters) Apply(ctx context.Context, rayCluster *rayv1.RayClusterApplyConfiguration, opts metav1.ApplyOptions) (result *v1.RayCluster, err error) {
-	if rayCluster == nil {
-		return nil, fmt.Errorf("rayCluster provided to Apply must not be nil")
-	}
-	patchOpts := opts.ToPatchOptions()
-	data, err := json.Marshal(rayCluster)
-	if err != nil {
-		return nil, err
-	}
-	name := rayCluster.Name
-	if name == nil {
-		return nil, fmt.Errorf("rayCluster.Name must be provided to Apply")
-	}
-	result = &v1.RayCluster{}
-	err = c.client.Patch(types.ApplyPatchType).
-		Namespace(c.ns).
-		Resource("rayclusters").
-		Name(*name).
-		VersionedParams(&patchOpts, scheme.ParameterCodec).
-		Body(data).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// ApplyStatus was generated because the type contains a Status member.
-// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
-func (c *rayClusters) ApplyStatus(ctx context.Context, rayCluster *rayv1.RayClusterApplyConfiguration, opts metav1.ApplyOptions) (result *v1.RayCluster, err error) {
-	if rayCluster == nil {
-		return nil, fmt.Errorf("rayCluster provided to Apply must not be nil")
-	}
-	patchOpts := opts.ToPatchOptions()
-	data, err := json.Marshal(rayCluster)
-	if err != nil {
-		return nil, err
-	}
-
-	name := rayCluster.Name
-	if name == nil {
-		return nil, fmt.Errorf("rayCluster.Name must be provided to Apply")
-	}
-
-	result = &v1.RayCluster{}
-	err = c.client.Patch(types.ApplyPatchType).
-		Namespace(c.ns).
-		Resource("rayclusters").
-		Name(*name).
-		SubResource("status").
-		VersionedParams(&patchOpts, scheme.ParameterCodec).
-		Body(data).
-		Do(ctx).
-		Into(result)
-	return
-}
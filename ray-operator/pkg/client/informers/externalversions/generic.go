@@ -43,6 +43,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ray().V1().RayJobs().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("rayservices"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ray().V1().RayServices().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("rayworkergroups"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ray().V1().RayWorkerGroups().Informer()}, nil
 
 	}
 
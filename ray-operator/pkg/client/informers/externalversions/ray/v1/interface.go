@@ -14,6 +14,8 @@ type Interface interface {
 	RayJobs() RayJobInformer
 	// RayServices returns a RayServiceInformer.
 	RayServices() RayServiceInformer
+	// RayWorkerGroups returns a RayWorkerGroupInformer.
+	RayWorkerGroups() RayWorkerGroupInformer
 }
 
 type version struct {
@@ -41,3 +43,8 @@ func (v *version) RayJobs() RayJobInformer {
 func (v *version) RayServices() RayServiceInformer {
 	return &rayServiceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// RayWorkerGroups returns a RayWorkerGroupInformer.
+func (v *version) RayWorkerGroups() RayWorkerGroupInformer {
+	return &rayWorkerGroupInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -25,3 +25,11 @@ type RayServiceListerExpansion interface{}
 // RayServiceNamespaceListerExpansion allows custom methods to be added to
 // RayServiceNamespaceLister.
 type RayServiceNamespaceListerExpansion interface{}
+
+// RayWorkerGroupListerExpansion allows custom methods to be added to
+// RayWorkerGroupLister.
+type RayWorkerGroupListerExpansion interface{}
+
+// RayWorkerGroupNamespaceListerExpansion allows custom methods to be added to
+// RayWorkerGroupNamespaceLister.
+type RayWorkerGroupNamespaceListerExpansion interface{}
@@ -4,8 +4,8 @@ package v1
 
 import (
 	v1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -22,25 +22,17 @@ type RayJobLister interface {
 
 // rayJobLister implements the RayJobLister interface.
 type rayJobLister struct {
-	indexer cache.Indexer
+	listers.ResourceIndexer[*v1.RayJob]
 }
 
 // NewRayJobLister returns a new RayJobLister.
 func NewRayJobLister(indexer cache.Indexer) RayJobLister {
-	return &rayJobLister{indexer: indexer}
-}
-
-// List lists all RayJobs in the indexer.
-func (s *rayJobLister) List(selector labels.Selector) (ret []*v1.RayJob, err error) {
-	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
-		ret = append(ret, m.(*v1.RayJob))
-	})
-	return ret, err
+	return &rayJobLister{listers.New[*v1.RayJob](indexer, v1.Resource("rayjob"))}
 }
 
 // RayJobs returns an object that can list and get RayJobs.
 func (s *rayJobLister) RayJobs(namespace string) RayJobNamespaceLister {
-	return rayJobNamespaceLister{indexer: s.indexer, namespace: namespace}
+	return rayJobNamespaceLister{listers.NewNamespaced[*v1.RayJob](s.ResourceIndexer, namespace)}
 }
 
 // RayJobNamespaceLister helps list and get RayJobs.
@@ -58,26 +50,5 @@ type RayJobNamespaceLister interface {
 // rayJobNamespaceLister implements the RayJobNamespaceLister
 // interface.
 type rayJobNamespaceLister struct {
-	indexer   cache.Indexer
-	namespace string
-}
-
-// List lists all RayJobs in the indexer for a given namespace.
-func (s rayJobNamespaceLister) List(selector labels.Selector) (ret []*v1.RayJob, err error) {
-	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
-		ret = append(ret, m.(*v1.RayJob))
-	})
-	return ret, err
-}
-
-// Get retrieves the RayJob from the indexer for a given namespace and name.
-func (s rayJobNamespaceLister) Get(name string) (*v1.RayJob, error) {
-	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
-	if err != nil {
-		return nil, err
-	}
-	if !exists {
-		return nil, errors.NewNotFound(v1.Resource("rayjob"), name)
-	}
-	return obj.(*v1.RayJob), nil
+	listers.ResourceIndexer[*v1.RayJob]
 }
@@ -4,8 +4,8 @@ package v1
 
 import (
 	v1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -22,25 +22,17 @@ type RayServiceLister interface {
 
 // rayServiceLister implements the RayServiceLister interface.
 type rayServiceLister struct {
-	indexer cache.Indexer
+	listers.ResourceIndexer[*v1.RayService]
 }
 
 // NewRayServiceLister returns a new RayServiceLister.
 func NewRayServiceLister(indexer cache.Indexer) RayServiceLister {
-	return &rayServiceLister{indexer: indexer}
-}
-
-// List lists all RayServices in the indexer.
-func (s *rayServiceLister) List(selector labels.Selector) (ret []*v1.RayService, err error) {
-	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
-		ret = append(ret, m.(*v1.RayService))
-	})
-	return ret, err
+	return &rayServiceLister{listers.New[*v1.RayService](indexer, v1.Resource("rayservice"))}
 }
 
 // RayServices returns an object that can list and get RayServices.
 func (s *rayServiceLister) RayServices(namespace string) RayServiceNamespaceLister {
-	return rayServiceNamespaceLister{indexer: s.indexer, namespace: namespace}
+	return rayServiceNamespaceLister{listers.NewNamespaced[*v1.RayService](s.ResourceIndexer, namespace)}
 }
 
 // RayServiceNamespaceLister helps list and get RayServices.
@@ -58,26 +50,5 @@ type RayServiceNamespaceLister interface {
 // rayServiceNamespaceLister implements the RayServiceNamespaceLister
 // interface.
 type rayServiceNamespaceLister struct {
-	indexer   cache.Indexer
-	namespace string
-}
-
-// List lists all RayServices in the indexer for a given namespace.
-func (s rayServiceNamespaceLister) List(selector labels.Selector) (ret []*v1.RayService, err error) {
-	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
-		ret = append(ret, m.(*v1.RayService))
-	})
-	return ret, err
-}
-
-// Get retrieves the RayService from the indexer for a given namespace and name.
-func (s rayServiceNamespaceLister) Get(name string) (*v1.RayService, error) {
-	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
-	if err != nil {
-		return nil, err
-	}
-	if !exists {
-		return nil, errors.NewNotFound(v1.Resource("rayservice"), name)
-	}
-	return obj.(*v1.RayService), nil
+	listers.ResourceIndexer[*v1.RayService]
 }
@@ -15,8 +15,41 @@ const (
 	// rep: https://github.com/ray-project/enhancements/pull/54
 	// alpha: v1.2
 	//
-	// Enables new conditions in RayCluster status
+	// Enables new conditions in RayCluster status. The Ready condition of RayClusters and the conditions of RayJobs and
+	// RayServices are set regardless of it.
 	RayClusterStatusConditions featuregate.Feature = "RayClusterStatusConditions"
+
+	// alpha: v1.3
+	//
+	// Enables the RayWorkerGroup controller, which scales RayCluster worker groups through the scale subresource
+	RayWorkerGroup featuregate.Feature = "RayWorkerGroup"
+
+	// alpha: v1.3
+	//
+	// Enables replica groups for multi-host worker groups: the numOfHosts Pods of a replica are labeled with the
+	// replica name, the replica index and their host index, and are created and deleted together
+	RayMultiHostIndexing featuregate.Feature = "RayMultiHostIndexing"
+
+	// alpha: v1.3
+	//
+	// Enables the Kueue integration: RayClusters and RayJobs with the kueue.x-k8s.io/queue-name label stay suspended
+	// until Kueue admits their Workloads. Kueue must be installed in the Kubernetes cluster.
+	KueueIntegration featuregate.Feature = "KueueIntegration"
+
+	// alpha: v1.3
+	//
+	// Enables namespace quotas: the Ray Pods of a namespace cannot request more CPU, memory and GPU than the limits
+	// in the ConfigMaps with the ray.io/quota label in the namespace, and the RayClusters that would exceed them are
+	// marked with the QuotaExceeded condition instead of creating Pods
+	RayNamespaceQuota featuregate.Feature = "RayNamespaceQuota"
+
+	// alpha: v1.3
+	//
+	// Injects the autoscaler, the sidecar containers of the operator configuration and the sidecarContainers of the
+	// head and worker groups as Kubernetes native sidecar containers, i.e. init containers with restartPolicy Always,
+	// which start in their order before the Ray container, stop in the reverse order after it, and do not keep a Pod
+	// running once the Ray container exits. Requires Kubernetes 1.29 or later.
+	NativeSidecarContainers featuregate.Feature = "NativeSidecarContainers"
 )
 
 func init() {
@@ -25,11 +58,16 @@ func init() {
 
 var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	RayClusterStatusConditions: {Default: false, PreRelease: featuregate.Alpha},
+	RayWorkerGroup:             {Default: false, PreRelease: featuregate.Alpha},
+	RayMultiHostIndexing:       {Default: false, PreRelease: featuregate.Alpha},
+	KueueIntegration:           {Default: false, PreRelease: featuregate.Alpha},
+	RayNamespaceQuota:          {Default: false, PreRelease: featuregate.Alpha},
+	NativeSidecarContainers:    {Default: false, PreRelease: featuregate.Alpha},
 }
 
 // SetFeatureGateDuringTest is a helper method to override feature gates in tests.
-func SetFeatureGateDuringTest(tb testing.TB, f featuregate.Feature, value bool) func() {
-	return featuregatetesting.SetFeatureGateDuringTest(tb, utilfeature.DefaultFeatureGate, f, value)
+func SetFeatureGateDuringTest(tb testing.TB, f featuregate.Feature, value bool) {
+	featuregatetesting.SetFeatureGateDuringTest(tb, utilfeature.DefaultFeatureGate, f, value)
 }
 
 // Enabled is helper for `utilfeature.DefaultFeatureGate.Enabled()`